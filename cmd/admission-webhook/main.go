@@ -21,6 +21,7 @@ import (
 	"github.com/openshift/generic-admission-server/pkg/cmd"
 	"github.com/pingcap/tidb-operator/pkg/features"
 	"github.com/pingcap/tidb-operator/pkg/version"
+	"github.com/pingcap/tidb-operator/pkg/webhook/pod"
 	"github.com/pingcap/tidb-operator/pkg/webhook/statefulset"
 	"github.com/pingcap/tidb-operator/pkg/webhook/strategy"
 	"k8s.io/component-base/logs"
@@ -71,6 +72,7 @@ func main() {
 
 	statefulSetAdmissionHook := statefulset.NewStatefulSetAdmissionControl()
 	strategyAdmissionHook := strategy.NewStrategyAdmissionHook(&strategy.Registry)
+	pdEvictionAdmissionHook := pod.NewPDEvictionAdmissionControl()
 
-	cmd.RunAdmissionServer(statefulSetAdmissionHook, strategyAdmissionHook)
+	cmd.RunAdmissionServer(statefulSetAdmissionHook, strategyAdmissionHook, pdEvictionAdmissionHook)
 }
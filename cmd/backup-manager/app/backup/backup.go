@@ -222,6 +222,22 @@ func (bo *Options) backupCommandTemplate(backup *v1alpha1.Backup, specificArgs [
 	return fullArgs, nil
 }
 
+// brVersion returns the release version printed by `br --version`, it's empty if the version can't be got.
+func (bo *Options) brVersion(ctx context.Context) string {
+	bin := filepath.Join(util.BRBinPath, "br")
+	output, err := exec.CommandContext(ctx, bin, "--version").Output()
+	if err != nil {
+		klog.Warningf("cluster %s, get br version failed, err: %v", bo, err)
+		return ""
+	}
+	for _, line := range strings.Split(string(output), "\n") {
+		if strings.HasPrefix(line, "Release Version:") {
+			return strings.TrimSpace(strings.TrimPrefix(line, "Release Version:"))
+		}
+	}
+	return ""
+}
+
 // brCommandRun run br binary to do backup work.
 func (bo *Options) brCommandRun(ctx context.Context, fullArgs []string) error {
 	return bo.brCommandRunWithLogCallback(ctx, fullArgs, nil)
@@ -232,7 +248,7 @@ func (bo *Options) brCommandRunWithLogCallback(ctx context.Context, fullArgs []s
 	if len(fullArgs) == 0 {
 		return fmt.Errorf("command is invalid, fullArgs: %v", fullArgs)
 	}
-	klog.Infof("Running br command with args: %v", fullArgs)
+	klog.Infof("Running br command with args: %v", backupUtil.RedactArgs(fullArgs))
 	bin := filepath.Join(util.BRBinPath, "br")
 	cmd := exec.CommandContext(ctx, bin, fullArgs...)
 
@@ -246,7 +262,7 @@ func (bo *Options) brCommandRunWithLogCallback(ctx context.Context, fullArgs []s
 	}
 	err = cmd.Start()
 	if err != nil {
-		return fmt.Errorf("cluster %s, execute br command failed, args: %s, err: %v", bo, fullArgs, err)
+		return fmt.Errorf("cluster %s, execute br command failed, args: %s, err: %v", bo, backupUtil.RedactArgs(fullArgs), err)
 	}
 	var errMsg string
 	reader := bufio.NewReader(stdOut)
@@ -276,7 +292,7 @@ func (bo *Options) brCommandRunWithLogCallback(ctx context.Context, fullArgs []s
 
 	e2eTestSimulate(bo)
 
-	klog.Infof("Run br commond %v for cluster %s successfully", fullArgs, bo)
+	klog.Infof("Run br commond %v for cluster %s successfully", backupUtil.RedactArgs(fullArgs), bo)
 	return nil
 }
 
@@ -23,11 +23,14 @@ import (
 	"github.com/Masterminds/semver"
 	"github.com/dustin/go-humanize"
 	"github.com/pingcap/errors"
+	kvbackup "github.com/pingcap/kvproto/pkg/backup"
 	"github.com/pingcap/tidb-operator/cmd/backup-manager/app/clean"
 	"github.com/pingcap/tidb-operator/cmd/backup-manager/app/constants"
 	"github.com/pingcap/tidb-operator/cmd/backup-manager/app/util"
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/backup/catalog"
 	bkconstants "github.com/pingcap/tidb-operator/pkg/backup/constants"
+	pkgbackuputil "github.com/pingcap/tidb-operator/pkg/backup/util"
 	listers "github.com/pingcap/tidb-operator/pkg/client/listers/pingcap/v1alpha1"
 	"github.com/pingcap/tidb-operator/pkg/controller"
 	pkgutil "github.com/pingcap/tidb-operator/pkg/util"
@@ -391,6 +394,10 @@ func (bm *Manager) performBackup(ctx context.Context, backup *v1alpha1.Backup, d
 			BackupSizeReadable: &backupSizeReadable,
 			CommitTs:           &ts,
 		}
+		// the catalog is best effort, the backup is completed anyway
+		if err := bm.addToCatalog(ctx, backup, backupMeta, started, updateStatus.TimeCompleted.Time); err != nil {
+			klog.Warningf("Add backup %s to the backup catalog failed, err: %s", bm, err)
+		}
 	}
 	return bm.StatusUpdater.Update(backup, &v1alpha1.BackupCondition{
 		Type:   v1alpha1.BackupComplete,
@@ -399,6 +406,31 @@ func (bm *Manager) performBackup(ctx context.Context, backup *v1alpha1.Backup, d
 }
 
 // performLogBackup execute log backup commands according to backup cr.
+// addToCatalog records the completed backup in the catalog of the cluster next to the backup
+func (bm *Manager) addToCatalog(ctx context.Context, backup *v1alpha1.Backup, meta *kvbackup.BackupMeta, started, completed time.Time) error {
+	m, err := catalog.NewManifest(backup, meta, bm.brVersion(ctx))
+	if err != nil {
+		return err
+	}
+	m.TimeStarted = metav1.Time{Time: started}
+	m.TimeCompleted = metav1.Time{Time: completed}
+
+	storage, err := catalog.StorageForBackup(backup.Spec.StorageProvider)
+	if err != nil {
+		return err
+	}
+	c, err := catalog.Open(storage, m.ClusterNamespace, m.Cluster, &pkgbackuputil.StorageCredential{})
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+	if err := c.Add(ctx, m); err != nil {
+		return err
+	}
+	klog.Infof("Add backup %s with commitTs %d to the backup catalog of cluster %s/%s", bm, m.CommitTs, m.ClusterNamespace, m.Cluster)
+	return nil
+}
+
 func (bm *Manager) performLogBackup(ctx context.Context, backup *v1alpha1.Backup) error {
 	var (
 		err          error
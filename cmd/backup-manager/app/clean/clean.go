@@ -26,6 +26,7 @@ import (
 	"github.com/pingcap/tidb-operator/cmd/backup-manager/app/constants"
 	"github.com/pingcap/tidb-operator/cmd/backup-manager/app/util"
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/backup/catalog"
 	bkutil "github.com/pingcap/tidb-operator/pkg/backup/util"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/klog/v2"
@@ -156,6 +157,29 @@ func (bo *Options) CleanBRRemoteBackupData(ctx context.Context, backup *v1alpha1
 	})
 }
 
+// removeFromCatalog removes the cleaned backup from the backup catalog of the cluster, the failure is only logged
+// since the validation of the catalog reports the backups whose data is missing anyway
+func (bo *Options) removeFromCatalog(ctx context.Context, backup *v1alpha1.Backup) {
+	clusterNamespace := backup.Spec.BR.ClusterNamespace
+	if clusterNamespace == "" {
+		clusterNamespace = backup.Namespace
+	}
+	storage, err := catalog.StorageForBackup(backup.Spec.StorageProvider)
+	if err != nil {
+		klog.Warningf("For backup %s, failed to get the backup catalog: %s", bo, err)
+		return
+	}
+	c, err := catalog.Open(storage, clusterNamespace, backup.Spec.BR.Cluster, &bkutil.StorageCredential{})
+	if err != nil {
+		klog.Warningf("For backup %s, failed to open the backup catalog: %s", bo, err)
+		return
+	}
+	defer c.Close()
+	if err := c.Remove(ctx, backup.Namespace, backup.Name); err != nil {
+		klog.Warningf("For backup %s, failed to remove it from the backup catalog: %s", bo, err)
+	}
+}
+
 func (bo *Options) cleanBRRemoteBackupDataOnce(ctx context.Context, backend *bkutil.StorageBackend, opt v1alpha1.CleanOption, round int) error {
 	klog.Infof("For backup %s clean %d, start to clean backup with opt: %+v", bo, round, opt)
 
@@ -97,6 +97,9 @@ func (bm *Manager) performCleanBackup(ctx context.Context, backup *v1alpha1.Back
 	} else {
 		if backup.Spec.BR != nil {
 			err = bm.CleanBRRemoteBackupData(ctx, backup)
+			if err == nil {
+				bm.removeFromCatalog(ctx, backup)
+			}
 		} else {
 			opts := util.GetOptions(backup.Spec.StorageProvider)
 			err = bm.cleanRemoteBackupData(ctx, backup.Status.BackupPath, opts)
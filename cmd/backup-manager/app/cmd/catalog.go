@@ -0,0 +1,139 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/pingcap/tidb-operator/cmd/backup-manager/app/util"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/backup/catalog"
+	bkutil "github.com/pingcap/tidb-operator/pkg/backup/util"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+)
+
+// catalogOptions contains the input arguments to the catalog commands
+type catalogOptions struct {
+	Namespace          string
+	BackupName         string
+	BackupScheduleName string
+}
+
+// NewCatalogCommand implements the catalog command
+func NewCatalogCommand() *cobra.Command {
+	co := catalogOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "catalog",
+		Short: "List and validate the restorable points in the backup catalog of a tidb cluster.",
+		Run:   runHelp,
+	}
+	cmd.PersistentFlags().StringVar(&co.Namespace, "namespace", "", "Namespace of the Backup or BackupSchedule")
+	cmd.PersistentFlags().StringVar(&co.BackupName, "backupName", "", "Backup CRD object name, the catalog next to the backup is used")
+	cmd.PersistentFlags().StringVar(&co.BackupScheduleName, "backupScheduleName", "", "BackupSchedule CRD object name, the catalog of the scheduled backups is used")
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "list",
+		Short: "List the backups in the backup catalog.",
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(runCatalog(co, kubecfg, false))
+		},
+	})
+	cmd.AddCommand(&cobra.Command{
+		Use:   "validate",
+		Short: "Validate the data of the backups in the backup catalog and show whether they are restorable.",
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(runCatalog(co, kubecfg, true))
+		},
+	})
+	return cmd
+}
+
+func runCatalog(co catalogOptions, kubecfg string, validate bool) error {
+	if co.Namespace == "" || (co.BackupName == "") == (co.BackupScheduleName == "") {
+		return fmt.Errorf("--namespace and one of --backupName and --backupScheduleName must be set")
+	}
+	cli, err := util.NewCRCli(kubecfg)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	var spec v1alpha1.BackupSpec
+	var storage v1alpha1.StorageProvider
+	if co.BackupName != "" {
+		backup, err := cli.PingcapV1alpha1().Backups(co.Namespace).Get(ctx, co.BackupName, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		spec = backup.Spec
+		storage, err = catalog.StorageForBackup(spec.StorageProvider)
+		if err != nil {
+			return err
+		}
+	} else {
+		bs, err := cli.PingcapV1alpha1().BackupSchedules(co.Namespace).Get(ctx, co.BackupScheduleName, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		spec = bs.Spec.BackupTemplate
+		storage, err = catalog.StorageForSchedule(spec.StorageProvider)
+		if err != nil {
+			return err
+		}
+	}
+	if spec.BR == nil {
+		return fmt.Errorf("the backup catalog is only maintained for the backups by BR")
+	}
+	clusterNamespace := spec.BR.ClusterNamespace
+	if clusterNamespace == "" {
+		clusterNamespace = co.Namespace
+	}
+
+	c, err := catalog.Open(storage, clusterNamespace, spec.BR.Cluster, &bkutil.StorageCredential{})
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	defer w.Flush()
+	if !validate {
+		manifests, err := c.List(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(w, "BACKUP\tCOMMITTS\tDEPENDSON\tSIZE\tCLUSTER VERSION\tBR VERSION\tCOMPLETED\tPATH")
+		for _, m := range manifests {
+			fmt.Fprintf(w, "%s/%s\t%d\t%s\t%d\t%s\t%s\t%s\t%s\n", m.Namespace, m.Name, m.CommitTs, m.DependsOn, m.Size,
+				m.ClusterVersion, m.BRVersion, m.TimeCompleted.Format("2006-01-02T15:04:05Z07:00"), m.Path)
+		}
+		return nil
+	}
+
+	results, err := c.Validate(ctx)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(w, "BACKUP\tCOMMITTS\tRESTORABLE\tREASON")
+	for _, r := range results {
+		fmt.Fprintf(w, "%s/%s\t%d\t%t\t%s\n", r.Namespace, r.Name, r.CommitTs, r.Restorable, r.Reason)
+	}
+	return nil
+}
@@ -68,4 +68,7 @@ const (
 
 	// DefaultTableFilter is the default table filter 'db.table' matching
 	DefaultTableFilter = "!/^(mysql|test|INFORMATION_SCHEMA|PERFORMANCE_SCHEMA|METRICS_SCHEMA|INSPECTION_SCHEMA)$/.*"
+
+	// LightningRouteConfigFile is the name of the TiDB Lightning config file holding the routes converted from the rename rules
+	LightningRouteConfigFile = "lightning-routes.toml"
 )
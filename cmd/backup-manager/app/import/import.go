@@ -14,18 +14,23 @@
 package _import
 
 import (
+	"bufio"
+	"bytes"
 	"context"
 	"fmt"
 	"io"
+	"os"
 	"os/exec"
 	"path"
 	"path/filepath"
 	"strings"
 
+	"github.com/BurntSushi/toml"
 	"github.com/mholt/archiver"
 	"github.com/pingcap/tidb-operator/cmd/backup-manager/app/constants"
 	backupUtil "github.com/pingcap/tidb-operator/cmd/backup-manager/app/util"
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
 	"github.com/pingcap/tidb-operator/pkg/util"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/klog/v2"
@@ -83,8 +88,13 @@ func (ro *Options) downloadBackupData(ctx context.Context, localPath string, opt
 	return nil
 }
 
-func (ro *Options) loadTidbClusterData(ctx context.Context, restorePath string, restore *v1alpha1.Restore) error {
-	tableFilter := restore.Spec.TableFilter
+func (ro *Options) loadTidbClusterData(
+	ctx context.Context,
+	restorePath string,
+	restore *v1alpha1.Restore,
+	statusUpdater controller.RestoreConditionUpdaterInterface,
+) error {
+	tableFilter := restore.GetTableFilters()
 
 	if exist := backupUtil.IsDirExist(restorePath); !exist {
 		return fmt.Errorf("dir %s does not exist or is not a dir", restorePath)
@@ -106,6 +116,14 @@ func (ro *Options) loadTidbClusterData(ctx context.Context, restorePath string,
 		args = append(args, "-f", filter)
 	}
 
+	if len(restore.Spec.RenameRules) > 0 {
+		configFile, err := writeLightningRouteConfig(filepath.Dir(restorePath), restore.Spec.RenameRules)
+		if err != nil {
+			return fmt.Errorf("cluster %s, %v", ro, err)
+		}
+		args = append(args, fmt.Sprintf("--config=%s", configFile))
+	}
+
 	if ro.TLSClient {
 		if !ro.SkipClientCA {
 			args = append(args, fmt.Sprintf("--ca=%s", path.Join(util.TiDBClientTLSPath, corev1.ServiceAccountRootCAKey)))
@@ -114,6 +132,8 @@ func (ro *Options) loadTidbClusterData(ctx context.Context, restorePath string,
 		args = append(args, fmt.Sprintf("--key=%s", path.Join(util.TiDBClientTLSPath, corev1.TLSPrivateKeyKey)))
 	}
 
+	args = append(args, backupUtil.ConstructLightningOptionsForRestore(restore)...)
+
 	binPath := "/tidb-lightning"
 	if restore.Spec.ToolImage != "" {
 		binPath = path.Join(util.LightningBinPath, "tidb-lightning")
@@ -121,13 +141,81 @@ func (ro *Options) loadTidbClusterData(ctx context.Context, restorePath string,
 
 	klog.Infof("The lightning process is ready, command \"%s %s\"", binPath, strings.Join(args, " "))
 
-	output, err := exec.CommandContext(ctx, binPath, args...).CombinedOutput()
+	cmd := exec.CommandContext(ctx, binPath, args...)
+	stdOut, err := cmd.StdoutPipe()
+	if err != nil {
+		return fmt.Errorf("cluster %s, create stdout pipe failed, err: %v", ro, err)
+	}
+	stdErr, err := cmd.StderrPipe()
 	if err != nil {
-		return fmt.Errorf("cluster %s, execute loader command %v failed, output: %s, err: %v", ro, args, string(output), err)
+		return fmt.Errorf("cluster %s, create stderr pipe failed, err: %v", ro, err)
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("cluster %s, execute loader command %v failed, err: %v", ro, args, err)
+	}
+
+	progressReporter := backupUtil.NewRestoreProgressReporter(restore, statusUpdater)
+	var errMsg string
+	reader := bufio.NewReader(stdOut)
+	for {
+		line, err := reader.ReadString('\n')
+		if strings.Contains(line, "[ERROR]") {
+			errMsg += line
+		} else if sample, ok := backupUtil.ParseLightningProgress(line); ok {
+			if err := progressReporter.Report(sample); err != nil {
+				klog.Errorf("report restore %s progress error %v", ro, err)
+			}
+		}
+		klog.Info(strings.Replace(line, "\n", "", -1))
+		if err != nil || io.EOF == err {
+			break
+		}
+	}
+	tmpErr, _ := io.ReadAll(stdErr)
+	if len(tmpErr) > 0 {
+		klog.Info(string(tmpErr))
+		errMsg += string(tmpErr)
+	}
+
+	if err := cmd.Wait(); err != nil {
+		return fmt.Errorf("cluster %s, execute loader command %v failed, errMsg: %s, err: %v", ro, args, errMsg, err)
 	}
 	return nil
 }
 
+// lightningRoute is the route rule of TiDB Lightning, which renames the matched databases or tables
+type lightningRoute struct {
+	SchemaPattern string `toml:"schema-pattern"`
+	TablePattern  string `toml:"table-pattern,omitempty"`
+	TargetSchema  string `toml:"target-schema"`
+	TargetTable   string `toml:"target-table,omitempty"`
+}
+
+// writeLightningRouteConfig writes the rename rules as the routes of TiDB Lightning into a config file in dir
+func writeLightningRouteConfig(dir string, rules []v1alpha1.RenameRule) (string, error) {
+	config := struct {
+		Routes []lightningRoute `toml:"routes"`
+	}{}
+	for _, rule := range rules {
+		config.Routes = append(config.Routes, lightningRoute{
+			SchemaPattern: rule.FromDatabase,
+			TablePattern:  rule.FromTable,
+			TargetSchema:  rule.ToDatabase,
+			TargetTable:   rule.ToTable,
+		})
+	}
+
+	buf := new(bytes.Buffer)
+	if err := toml.NewEncoder(buf).Encode(config); err != nil {
+		return "", fmt.Errorf("encode lightning routes failed, err: %v", err)
+	}
+	configFile := filepath.Join(dir, constants.LightningRouteConfigFile)
+	if err := os.WriteFile(configFile, buf.Bytes(), 0644); err != nil {
+		return "", fmt.Errorf("write lightning config file %s failed, err: %v", configFile, err)
+	}
+	return configFile, nil
+}
+
 // unarchiveBackupData unarchive backup data to dest dir
 // NOTE: no context/timeout supported for `tarGz.Unarchive`, this may cause to be KILLed when blocking.
 func unarchiveBackupData(backupFile, destDir string) (string, error) {
@@ -0,0 +1,65 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package _import
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/BurntSushi/toml"
+	. "github.com/onsi/gomega"
+	"github.com/pingcap/tidb-operator/cmd/backup-manager/app/constants"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+)
+
+func TestWriteLightningRouteConfig(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	dir := t.TempDir()
+	rules := []v1alpha1.RenameRule{
+		{FromDatabase: "db", ToDatabase: "db_new"},
+		{FromDatabase: "db*", FromTable: "t?", ToDatabase: "db_new", ToTable: "t_new"},
+	}
+	configFile, err := writeLightningRouteConfig(dir, rules)
+	g.Expect(err).Should(BeNil())
+	g.Expect(configFile).Should(Equal(filepath.Join(dir, constants.LightningRouteConfigFile)))
+
+	data, err := os.ReadFile(configFile)
+	g.Expect(err).Should(BeNil())
+	g.Expect(string(data)).ShouldNot(ContainSubstring("table-pattern = \"\""))
+	g.Expect(string(data)).ShouldNot(ContainSubstring("target-table = \"\""))
+
+	config := struct {
+		Routes []lightningRoute `toml:"routes"`
+	}{}
+	_, err = toml.Decode(string(data), &config)
+	g.Expect(err).Should(BeNil())
+	g.Expect(config.Routes).Should(Equal([]lightningRoute{
+		{SchemaPattern: "db", TargetSchema: "db_new"},
+		{SchemaPattern: "db*", TablePattern: "t?", TargetSchema: "db_new", TargetTable: "t_new"},
+	}))
+
+	// the config file is overwritten by the later restore
+	configFile, err = writeLightningRouteConfig(dir, rules[:1])
+	g.Expect(err).Should(BeNil())
+	config.Routes = nil
+	_, err = toml.DecodeFile(configFile, &config)
+	g.Expect(err).Should(BeNil())
+	g.Expect(config.Routes).Should(HaveLen(1))
+
+	// the dir does not exist
+	_, err = writeLightningRouteConfig(filepath.Join(dir, "not-exist"), rules)
+	g.Expect(err).ShouldNot(BeNil())
+}
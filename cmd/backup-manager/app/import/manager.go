@@ -151,7 +151,7 @@ func (rm *RestoreManager) performRestore(ctx context.Context, restore *v1alpha1.
 	}
 	klog.Infof("get cluster %s commitTs %s success", rm, commitTs)
 
-	err = rm.loadTidbClusterData(ctx, unarchiveDataPath, restore)
+	err = rm.loadTidbClusterData(ctx, unarchiveDataPath, restore, rm.StatusUpdater)
 	if err != nil {
 		errs = append(errs, err)
 		klog.Errorf("restore cluster %s from backup %s failed, err: %s", rm, rm.BackupPath, err)
@@ -95,6 +95,10 @@ func (rm *Manager) ProcessRestore() error {
 	if restore.Spec.BR == nil {
 		return fmt.Errorf("no br config in %s", rm)
 	}
+	if v1alpha1.IsRestoreFailed(restore) {
+		// the pod recreated by the job for the checkpoint restore can't resume the failed restore
+		return fmt.Errorf("restore %s is already failed", rm)
+	}
 
 	if restore.Spec.To == nil {
 		return rm.performRestore(ctx, restore.DeepCopy(), nil)
@@ -142,10 +146,20 @@ func (rm *Manager) ProcessRestore() error {
 func (rm *Manager) performRestore(ctx context.Context, restore *v1alpha1.Restore, db *sql.DB) error {
 	started := time.Now()
 
+	var (
+		attempts      int32
+		runningStatus *controller.RestoreUpdateStatus
+	)
+	if restore.IsCheckpointEnabled() {
+		// BR resumes from the checkpoint of the previous attempts if it's not the first attempt
+		attempts = restore.Status.Attempts + 1
+		runningStatus = &controller.RestoreUpdateStatus{Attempts: &attempts}
+		klog.Infof("restore %s attempt %d of %d", rm, attempts, restore.GetMaxAttempts())
+	}
 	err := rm.StatusUpdater.Update(restore, &v1alpha1.RestoreCondition{
 		Type:   v1alpha1.RestoreRunning,
 		Status: corev1.ConditionTrue,
-	}, nil)
+	}, runningStatus)
 	if err != nil {
 		return err
 	}
@@ -267,8 +281,13 @@ func (rm *Manager) performRestore(ctx context.Context, restore *v1alpha1.Restore
 	if restoreErr != nil {
 		errs = append(errs, restoreErr)
 		klog.Errorf("restore cluster %s from %s failed, err: %s", rm, restore.Spec.Type, restoreErr)
+		failedType := v1alpha1.RestoreFailed
+		if restore.IsCheckpointEnabled() && attempts < restore.GetMaxAttempts() {
+			// the job recreates the pod to resume from the checkpoint
+			failedType = v1alpha1.RestoreRetryFailed
+		}
 		uerr := rm.StatusUpdater.Update(restore, &v1alpha1.RestoreCondition{
-			Type:    v1alpha1.RestoreFailed,
+			Type:    failedType,
 			Status:  corev1.ConditionTrue,
 			Reason:  "RestoreDataFromRemoteFailed",
 			Message: restoreErr.Error(),
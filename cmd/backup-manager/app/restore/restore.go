@@ -115,7 +115,7 @@ func (ro *Options) restoreData(
 		restoreType,
 	}
 	fullArgs = append(fullArgs, args...)
-	klog.Infof("Running br command with args: %v", fullArgs)
+	klog.Infof("Running br command with args: %v", backupUtil.RedactArgs(fullArgs))
 	bin := path.Join(util.BRBinPath, "br")
 	cmd := exec.CommandContext(ctx, bin, fullArgs...)
 
@@ -129,13 +129,14 @@ func (ro *Options) restoreData(
 	}
 	err = cmd.Start()
 	if err != nil {
-		return fmt.Errorf("cluster %s, execute br command failed, args: %s, err: %v", ro, fullArgs, err)
+		return fmt.Errorf("cluster %s, execute br command failed, args: %s, err: %v", ro, backupUtil.RedactArgs(fullArgs), err)
 	}
 
 	var (
 		progressWg     sync.WaitGroup
 		progressCancel context.CancelFunc
 	)
+	progressReporter := backupUtil.NewRestoreProgressReporter(restore, statusUpdater)
 	if useProgressFile {
 		progressCtx, cancel := context.WithCancel(ctx)
 		defer cancel()
@@ -144,7 +145,7 @@ func (ro *Options) restoreData(
 		progressWg.Add(1)
 		go func() {
 			defer progressWg.Done()
-			ro.updateProgressFromFile(progressCtx.Done(), restore, progressFile, progressStep, statusUpdater)
+			ro.updateProgressFromFile(progressCtx.Done(), restore, progressFile, progressStep, statusUpdater, progressReporter)
 		}()
 	}
 
@@ -156,9 +157,12 @@ func (ro *Options) restoreData(
 			errMsg += line
 		} else {
 			if !useProgressFile {
-				ro.updateProgressAccordingToBrLog(line, restore, statusUpdater)
+				ro.updateProgressAccordingToBrLog(line, restore, statusUpdater, progressReporter)
 			}
 			ro.updateResolvedTSForCSB(line, restore, progressStep, statusUpdater)
+			if restore.IsCheckpointEnabled() {
+				ro.updateCheckpointTs(line, restore, statusUpdater)
+			}
 		}
 		klog.Info(strings.Replace(line, "\n", "", -1))
 		if err != nil || io.EOF == err {
@@ -197,6 +201,9 @@ func (ro *Options) restoreData(
 		}); err != nil {
 			klog.Errorf("update restore %s progress error %v", ro, err)
 		}
+		if err := progressReporter.Report(backupUtil.RestoreProgressSample{Step: progressStep, Percentage: progress}); err != nil {
+			klog.Errorf("report restore %s progress error %v", ro, err)
+		}
 	}
 
 	klog.Infof("Restore data for cluster %s successfully", ro)
@@ -251,12 +258,20 @@ func constructBROptions(restore *v1alpha1.Restore) ([]string, error) {
 	if config.OnLine != nil {
 		args = append(args, fmt.Sprintf("--online=%t", *config.OnLine))
 	}
+	if restore.IsCheckpointEnabled() {
+		args = append(args, "--use-checkpoint=true")
+	}
 	args = append(args, config.Options...)
 	return args, nil
 }
 
 // updateProgressAccordingToBrLog update restore progress according to the br log.
-func (ro *Options) updateProgressAccordingToBrLog(line string, restore *v1alpha1.Restore, statusUpdater controller.RestoreConditionUpdaterInterface) {
+func (ro *Options) updateProgressAccordingToBrLog(
+	line string,
+	restore *v1alpha1.Restore,
+	statusUpdater controller.RestoreConditionUpdaterInterface,
+	progressReporter *backupUtil.RestoreProgressReporter,
+) {
 	step, progress := backupUtil.ParseRestoreProgress(line)
 	if step != "" {
 		fvalue, progressUpdateErr := strconv.ParseFloat(progress, 64)
@@ -273,6 +288,9 @@ func (ro *Options) updateProgressAccordingToBrLog(line string, restore *v1alpha1
 		if progressUpdateErr != nil {
 			klog.Errorf("update restore %s progress error %v", ro, progressUpdateErr)
 		}
+		if err := progressReporter.Report(backupUtil.RestoreProgressSample{Step: step, Percentage: fvalue}); err != nil {
+			klog.Errorf("report restore %s progress error %v", ro, err)
+		}
 	}
 }
 
@@ -302,12 +320,35 @@ func (ro *Options) updateResolvedTSForCSB(
 	}
 }
 
+// checkpointTsRegexp matches the TS of the checkpoint in the br log, e.g. checkpoint-ts=445378201845973000
+var checkpointTsRegexp = regexp.MustCompile(`checkpoint[-_]ts"?[=:]\s*"?(\d+)`)
+
+// updateCheckpointTs updates the TS of the checkpoint which the restore resumes from according to the br log.
+func (ro *Options) updateCheckpointTs(
+	line string,
+	restore *v1alpha1.Restore,
+	statusUpdater controller.RestoreConditionUpdaterInterface,
+) {
+	matches := checkpointTsRegexp.FindStringSubmatch(line)
+	if len(matches) != 2 {
+		return
+	}
+	ts := matches[1]
+	klog.Infof("restore %s resumes from checkpoint ts %s", ro, ts)
+	if err := statusUpdater.Update(restore, nil, &controller.RestoreUpdateStatus{
+		CheckpointTs: &ts,
+	}); err != nil {
+		klog.Errorf("update restore %s checkpoint ts error %v", ro, err)
+	}
+}
+
 func (ro *Options) updateProgressFromFile(
 	stopCh <-chan struct{},
 	backup *v1alpha1.Restore,
 	progressFile string,
 	progressStep string,
 	statusUpdater controller.RestoreConditionUpdaterInterface,
+	progressReporter *backupUtil.RestoreProgressReporter,
 ) {
 	ticker := time.NewTicker(time.Second)
 	defer ticker.Stop()
@@ -339,6 +380,9 @@ func (ro *Options) updateProgressFromFile(
 			}); err != nil {
 				klog.Errorf("Failed to update BackupUpdateStatus for cluster %s, %v", ro, err)
 			}
+			if err := progressReporter.Report(backupUtil.RestoreProgressSample{Step: progressStep, Percentage: progress}); err != nil {
+				klog.Errorf("Failed to report restore progress for cluster %s, %v", ro, err)
+			}
 		case <-stopCh:
 			return
 		}
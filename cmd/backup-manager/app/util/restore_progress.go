@@ -0,0 +1,177 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// DefaultRestoreProgressReportInterval is the min interval to report the progress of a step
+	DefaultRestoreProgressReportInterval = 30 * time.Second
+
+	// RestoreProgressReason is the reason of the Running condition updated by the progress report
+	RestoreProgressReason = "RestoreProgress"
+
+	// LightningRestoreStep is the step name of the restore by TiDB Lightning if it doesn't report its state
+	LightningRestoreStep = "Import"
+)
+
+var (
+	lightningTotalRegex        = regexp.MustCompile(`\[total=([\d.]+)%\]`)
+	lightningRestoreBytesRegex = regexp.MustCompile(`\[restore-bytes=([^/\]]+)/([^\]]+)\]`)
+	lightningStateRegex        = regexp.MustCompile(`\[state=([^\]]+)\]`)
+	lightningRemainingRegex    = regexp.MustCompile(`\[remaining=([^\]]+)\]`)
+)
+
+// RestoreProgressSample is a progress sample of the running step of restore
+type RestoreProgressSample struct {
+	Step          string
+	Percentage    float64
+	RestoredBytes int64
+	TotalBytes    int64
+	// Remaining is the remaining time reported by the tool, it's estimated by the reporter if zero
+	Remaining time.Duration
+}
+
+// ParseLightningProgress parses the progress log of TiDB Lightning, which looks like
+// [progress] [total=5.8%] ... [restore-bytes=1.1GiB/19.45GiB] ... [state=writing] [remaining=38m3s]
+func ParseLightningProgress(line string) (RestoreProgressSample, bool) {
+	sample := RestoreProgressSample{Step: LightningRestoreStep}
+	matches := lightningTotalRegex.FindStringSubmatch(line)
+	if len(matches) < 2 {
+		return sample, false
+	}
+	percentage, err := strconv.ParseFloat(matches[1], 64)
+	if err != nil {
+		return sample, false
+	}
+	sample.Percentage = percentage
+
+	if matches := lightningRestoreBytesRegex.FindStringSubmatch(line); len(matches) == 3 {
+		if restored, err := humanize.ParseBytes(matches[1]); err == nil {
+			sample.RestoredBytes = int64(restored)
+		}
+		if total, err := humanize.ParseBytes(matches[2]); err == nil {
+			sample.TotalBytes = int64(total)
+		}
+	}
+	if matches := lightningStateRegex.FindStringSubmatch(line); len(matches) == 2 {
+		sample.Step = matches[1]
+	}
+	if matches := lightningRemainingRegex.FindStringSubmatch(line); len(matches) == 2 {
+		if remaining, err := time.ParseDuration(matches[1]); err == nil {
+			sample.Remaining = remaining
+		}
+	}
+	return sample, true
+}
+
+// RestoreProgressReporter reports the progress of the running step of restore to the restore status
+// and the message of the Running condition. The reports are throttled by the interval except for the
+// first and the last report of a step, so that a long restore doesn't flood the API server.
+type RestoreProgressReporter struct {
+	restore       *v1alpha1.Restore
+	statusUpdater controller.RestoreConditionUpdaterInterface
+	interval      time.Duration
+	now           func() time.Time
+
+	mu         sync.Mutex
+	step       string
+	stepStart  time.Time
+	lastReport time.Time
+}
+
+// NewRestoreProgressReporter returns a RestoreProgressReporter of the restore
+func NewRestoreProgressReporter(restore *v1alpha1.Restore, statusUpdater controller.RestoreConditionUpdaterInterface) *RestoreProgressReporter {
+	return &RestoreProgressReporter{
+		restore:       restore,
+		statusUpdater: statusUpdater,
+		interval:      DefaultRestoreProgressReportInterval,
+		now:           time.Now,
+	}
+}
+
+// Report reports the progress sample if the step changes, completes or the interval elapses
+func (r *RestoreProgressReporter) Report(sample RestoreProgressSample) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	force := sample.Percentage >= 100
+	if sample.Step != r.step {
+		r.step = sample.Step
+		r.stepStart = now
+		force = true
+	}
+	if !force && now.Sub(r.lastReport) < r.interval {
+		return nil
+	}
+	r.lastReport = now
+
+	progress := &v1alpha1.RestoreCurrentProgress{
+		Step:           sample.Step,
+		Percentage:     sample.Percentage,
+		RestoredBytes:  sample.RestoredBytes,
+		TotalBytes:     sample.TotalBytes,
+		StepStartTime:  metav1.Time{Time: r.stepStart},
+		LastUpdateTime: metav1.Time{Time: now},
+	}
+	if remaining := r.estimateRemaining(sample, now); remaining > 0 {
+		progress.EstimatedRemainingTime = &metav1.Duration{Duration: remaining}
+	}
+
+	return r.statusUpdater.Update(r.restore, &v1alpha1.RestoreCondition{
+		Type:    v1alpha1.RestoreRunning,
+		Status:  corev1.ConditionTrue,
+		Reason:  RestoreProgressReason,
+		Message: restoreProgressMessage(progress),
+	}, &controller.RestoreUpdateStatus{
+		CurrentProgress: progress,
+	})
+}
+
+// estimateRemaining returns the remaining time reported by the tool, or estimates it by assuming the
+// rest of the step runs as fast as the completed part
+func (r *RestoreProgressReporter) estimateRemaining(sample RestoreProgressSample, now time.Time) time.Duration {
+	if sample.Remaining > 0 {
+		return sample.Remaining
+	}
+	if sample.Percentage <= 0 || sample.Percentage >= 100 {
+		return 0
+	}
+	elapsed := now.Sub(r.stepStart)
+	return time.Duration(float64(elapsed) * (100 - sample.Percentage) / sample.Percentage).Round(time.Second)
+}
+
+func restoreProgressMessage(progress *v1alpha1.RestoreCurrentProgress) string {
+	msg := fmt.Sprintf("step %s is %.2f%% completed", progress.Step, progress.Percentage)
+	if progress.TotalBytes > 0 {
+		msg += fmt.Sprintf(", restored %s of %s", humanize.IBytes(uint64(progress.RestoredBytes)), humanize.IBytes(uint64(progress.TotalBytes)))
+	}
+	if progress.EstimatedRemainingTime != nil {
+		msg += fmt.Sprintf(", about %s remaining", progress.EstimatedRemainingTime.Duration)
+	}
+	return msg
+}
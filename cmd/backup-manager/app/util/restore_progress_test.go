@@ -0,0 +1,101 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+)
+
+type recordedRestoreUpdate struct {
+	condition *v1alpha1.RestoreCondition
+	status    *controller.RestoreUpdateStatus
+}
+
+type recordingRestoreUpdater struct {
+	updates []recordedRestoreUpdate
+}
+
+func (u *recordingRestoreUpdater) Update(_ *v1alpha1.Restore, condition *v1alpha1.RestoreCondition, newStatus *controller.RestoreUpdateStatus) error {
+	u.updates = append(u.updates, recordedRestoreUpdate{condition, newStatus})
+	return nil
+}
+
+func TestParseLightningProgress(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	line := `[2024/01/02 03:04:05.678 +00:00] [INFO] [import.go:1234] [progress] [total=25.0%] [tables="1/4 (25.0%)"] [chunks="8/32 (25.0%)"] [engines="0/2 (0.0%)"] [restore-bytes=1GiB/4GiB] [import-bytes=0B/4GiB(estimated)] ["encode speed(MiB/s)"=12.5] [state=writing] [remaining=1h2m3s]`
+	sample, ok := ParseLightningProgress(line)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(sample).To(Equal(RestoreProgressSample{
+		Step:          "writing",
+		Percentage:    25,
+		RestoredBytes: 1 << 30,
+		TotalBytes:    4 << 30,
+		Remaining:     time.Hour + 2*time.Minute + 3*time.Second,
+	}))
+
+	sample, ok = ParseLightningProgress(`[progress] [total=50.0%]`)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(sample).To(Equal(RestoreProgressSample{Step: LightningRestoreStep, Percentage: 50}))
+
+	_, ok = ParseLightningProgress(`[INFO] [restore.go:123] ["restore table start"]`)
+	g.Expect(ok).To(BeFalse())
+}
+
+func TestRestoreProgressReporter(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	updater := &recordingRestoreUpdater{}
+	reporter := NewRestoreProgressReporter(&v1alpha1.Restore{}, updater)
+	now := time.Date(2024, 1, 2, 3, 0, 0, 0, time.UTC)
+	reporter.now = func() time.Time { return now }
+
+	// the first report of a step is always sent
+	g.Expect(reporter.Report(RestoreProgressSample{Step: "Full Restore", Percentage: 0})).To(Succeed())
+	g.Expect(updater.updates).To(HaveLen(1))
+	g.Expect(updater.updates[0].condition.Type).To(Equal(v1alpha1.RestoreRunning))
+	g.Expect(updater.updates[0].status.CurrentProgress.EstimatedRemainingTime).To(BeNil())
+
+	// the reports within the interval are dropped
+	now = now.Add(10 * time.Second)
+	g.Expect(reporter.Report(RestoreProgressSample{Step: "Full Restore", Percentage: 5})).To(Succeed())
+	g.Expect(updater.updates).To(HaveLen(1))
+
+	// the remaining time is estimated from the elapsed time of the step
+	now = now.Add(50 * time.Second)
+	g.Expect(reporter.Report(RestoreProgressSample{Step: "Full Restore", Percentage: 25})).To(Succeed())
+	g.Expect(updater.updates).To(HaveLen(2))
+	progress := updater.updates[1].status.CurrentProgress
+	g.Expect(progress.Percentage).To(Equal(25.0))
+	g.Expect(progress.StepStartTime.Time).To(Equal(now.Add(-time.Minute)))
+	g.Expect(progress.EstimatedRemainingTime.Duration).To(Equal(3 * time.Minute))
+	g.Expect(updater.updates[1].condition.Message).To(Equal("step Full Restore is 25.00% completed, about 3m0s remaining"))
+
+	// the completion of a step is always sent
+	now = now.Add(time.Second)
+	g.Expect(reporter.Report(RestoreProgressSample{Step: "Full Restore", Percentage: 100})).To(Succeed())
+	g.Expect(updater.updates).To(HaveLen(3))
+	g.Expect(updater.updates[2].status.CurrentProgress.EstimatedRemainingTime).To(BeNil())
+
+	// a new step resets the start time
+	now = now.Add(time.Second)
+	g.Expect(reporter.Report(RestoreProgressSample{Step: "Checksum", Percentage: 1})).To(Succeed())
+	g.Expect(updater.updates).To(HaveLen(4))
+	g.Expect(updater.updates[3].status.CurrentProgress.StepStartTime.Time).To(Equal(now))
+}
@@ -40,6 +40,11 @@ import (
 	cmdutil "k8s.io/kubectl/pkg/cmd/util"
 )
 
+const (
+	defaultDumplingThreads = 16
+	defaultDumplingRows    = 10000
+)
+
 var (
 	cmdHelpMsg        string
 	supportedVersions = map[string]struct{}{
@@ -50,8 +55,8 @@ var (
 	DefaultVersion = "4.0"
 	defaultOptions = []string{
 		// "--tidb-force-priority=LOW_PRIORITY",
-		"--threads=16",
-		"--rows=10000",
+		fmt.Sprintf("--threads=%d", defaultDumplingThreads),
+		fmt.Sprintf("--rows=%d", defaultDumplingRows),
 	}
 	defaultTableFilterOptions = []string{
 		"--filter", "*.*",
@@ -145,6 +150,24 @@ func NormalizeBucketURI(bucket string) string {
 	return strings.Replace(bucket, "://", ":", 1)
 }
 
+// sensitiveArgPrefixes are the prefixes of the args whose values are not logged
+var sensitiveArgPrefixes = []string{"--azblob.sas-token="}
+
+// RedactArgs returns a copy of args with the values of the sensitive args masked, so that the args can be logged
+func RedactArgs(args []string) []string {
+	redacted := make([]string, 0, len(args))
+	for _, arg := range args {
+		for _, prefix := range sensitiveArgPrefixes {
+			if strings.HasPrefix(arg, prefix) {
+				arg = prefix + "******"
+				break
+			}
+		}
+		redacted = append(redacted, arg)
+	}
+	return redacted
+}
+
 // GetOptionValueFromEnv get option's value from environment variable. If unset, return empty string.
 func GetOptionValueFromEnv(option, envPrefix string) string {
 	envVar := envPrefix + "_" + strings.Replace(strings.ToUpper(option), "-", "_", -1)
@@ -211,15 +234,49 @@ func ConstructDumplingOptionsForBackup(backup *v1alpha1.Backup) []string {
 		return args
 	}
 
-	if len(config.Dumpling.Options) != 0 {
-		args = append(args, config.Dumpling.Options...)
+	dumpling := config.Dumpling
+	// the default threads and rows are not used if the options are customized for backward compatibility
+	if len(dumpling.Options) == 0 {
+		threads, rows := int64(defaultDumplingThreads), int64(defaultDumplingRows)
+		if dumpling.Threads != nil {
+			threads = int64(*dumpling.Threads)
+		}
+		if dumpling.Rows != nil {
+			rows = *dumpling.Rows
+		}
+		args = append(args, fmt.Sprintf("--threads=%d", threads), fmt.Sprintf("--rows=%d", rows))
 	} else {
-		args = append(args, defaultOptions...)
+		if dumpling.Threads != nil {
+			args = append(args, fmt.Sprintf("--threads=%d", *dumpling.Threads))
+		}
+		if dumpling.Rows != nil {
+			args = append(args, fmt.Sprintf("--rows=%d", *dumpling.Rows))
+		}
+	}
+	if dumpling.FileSize != "" {
+		args = append(args, fmt.Sprintf("--filesize=%s", dumpling.FileSize))
 	}
+	// the options are appended at last so that they override the ones above
+	args = append(args, dumpling.Options...)
 
 	return args
 }
 
+// ConstructLightningOptionsForRestore constructs TiDB Lightning options for restore
+func ConstructLightningOptionsForRestore(restore *v1alpha1.Restore) []string {
+	var args []string
+	lightning := restore.Spec.Lightning
+	if lightning == nil {
+		return args
+	}
+	if lightning.RegionConcurrency != nil {
+		args = append(args, fmt.Sprintf("--region-concurrency=%d", *lightning.RegionConcurrency))
+	}
+	// the options are appended at last so that they override the ones above
+	args = append(args, lightning.Options...)
+	return args
+}
+
 // ConstructBRGlobalOptionsForRestore constructs BR global options for restore.
 func ConstructBRGlobalOptionsForRestore(restore *v1alpha1.Restore) ([]string, error) {
 	var args []string
@@ -234,8 +291,8 @@ func ConstructBRGlobalOptionsForRestore(restore *v1alpha1.Restore) ([]string, er
 	}
 	args = append(args, storageArgs...)
 
-	if config.TableFilter != nil && len(config.TableFilter) > 0 {
-		for _, tableFilter := range config.TableFilter {
+	if tableFilters := restore.GetTableFilters(); len(tableFilters) > 0 {
+		for _, tableFilter := range tableFilters {
 			args = append(args, "--filter", tableFilter)
 		}
 		return args, nil
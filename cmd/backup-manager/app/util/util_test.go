@@ -131,6 +131,42 @@ func TestConstructDumplingOptionsForBackup(t *testing.T) {
 	}
 }
 
+func TestConstructDumplingParallelismOptions(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	backup := newBackup()
+	backup.Spec.TableFilter = []string{"mysql.*"}
+	backup.Spec.Dumpling = &v1alpha1.DumplingConfig{
+		Threads:  pointer.Int32Ptr(64),
+		FileSize: "256MiB",
+	}
+	g.Expect(ConstructDumplingOptionsForBackup(backup)).To(Equal([]string{
+		"--filter", "mysql.*", "--threads=64", "--rows=10000", "--filesize=256MiB",
+	}))
+
+	// the options override the fields and the defaults are not used
+	backup.Spec.Dumpling.Rows = pointer.Int64Ptr(200000)
+	backup.Spec.Dumpling.Options = []string{"--threads=8"}
+	g.Expect(ConstructDumplingOptionsForBackup(backup)).To(Equal([]string{
+		"--filter", "mysql.*", "--threads=64", "--rows=200000", "--filesize=256MiB", "--threads=8",
+	}))
+}
+
+func TestConstructLightningOptionsForRestore(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	restore := newRestore()
+	g.Expect(ConstructLightningOptionsForRestore(restore)).To(BeEmpty())
+
+	restore.Spec.Lightning = &v1alpha1.LightningConfig{
+		RegionConcurrency: pointer.Int32Ptr(32),
+		Options:           []string{"--check-requirements=false"},
+	}
+	g.Expect(ConstructLightningOptionsForRestore(restore)).To(Equal([]string{
+		"--region-concurrency=32", "--check-requirements=false",
+	}))
+}
+
 func TestConstructBRGlobalOptionsForBackup(t *testing.T) {
 	g := NewGomegaWithT(t)
 
@@ -317,6 +353,7 @@ func TestConstructBRGlobalOptionsForRestore(t *testing.T) {
 	type testcase struct {
 		name             string
 		hasRestoreFilter bool
+		hasFilterRules   bool
 		hasTable         bool
 		hasDB            bool
 	}
@@ -328,6 +365,15 @@ func TestConstructBRGlobalOptionsForRestore(t *testing.T) {
 			hasTable:         false,
 			hasDB:            false,
 		},
+		{
+			name:           "customize filter rules",
+			hasFilterRules: true,
+		},
+		{
+			name:             "customize filter and filter rules",
+			hasRestoreFilter: true,
+			hasFilterRules:   true,
+		},
 		{
 			name:             "customize filter, empty table and database",
 			hasRestoreFilter: true,
@@ -367,6 +413,14 @@ func TestConstructBRGlobalOptionsForRestore(t *testing.T) {
 				expectArgs = append(expectArgs, "--filter", customBackupFilter[0])
 			}
 
+			if tt.hasFilterRules {
+				restore.Spec.TableFilters = []v1alpha1.TableFilterRule{
+					{Database: "db"},
+					{Database: "db", Table: "t", Exclude: true},
+				}
+				expectArgs = append(expectArgs, "--filter", "db.*", "--filter", "!db.t")
+			}
+
 			if tt.hasTable {
 				restore.Spec.Type = v1alpha1.BackupTypeTable
 				restore.Spec.BR.Table = customTable[0]
@@ -737,3 +791,12 @@ func TestGetSliceExcludeOneString(t *testing.T) {
 		})
 	}
 }
+
+func TestRedactArgs(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	args := []string{"backup", "full", "--storage=azure://container/prefix/", "--azblob.sas-token=sv=2021&sig=abc"}
+	g.Expect(RedactArgs(args)).To(Equal([]string{"backup", "full", "--storage=azure://container/prefix/", "--azblob.sas-token=******"}))
+	// the args are not changed
+	g.Expect(args[3]).To(Equal("--azblob.sas-token=sv=2021&sig=abc"))
+}
@@ -43,6 +43,7 @@ import (
 	"github.com/pingcap/tidb-operator/pkg/controller/fedvolumebackup"
 	"github.com/pingcap/tidb-operator/pkg/controller/fedvolumebackupschedule"
 	"github.com/pingcap/tidb-operator/pkg/controller/fedvolumerestore"
+	"github.com/pingcap/tidb-operator/pkg/controller/tidbclusterfederation"
 	"github.com/pingcap/tidb-operator/pkg/metrics"
 	"github.com/pingcap/tidb-operator/pkg/version"
 )
@@ -111,12 +112,12 @@ func main() {
 	}
 
 	// init kube clients to the federation K8s clusters
-	fedClients, err := initFederationKubeClients(cliCfg)
+	fedClients, fedKubeClients, err := initFederationKubeClients(cliCfg)
 	if err != nil {
 		klog.Fatalf("failed to init federation kube clients: %v", err)
 	}
 
-	deps := controller.NewBrFedDependencies(cliCfg, cli, kubeCli, genericCli, fedClients)
+	deps := controller.NewBrFedDependencies(cliCfg, cli, kubeCli, genericCli, fedClients, fedKubeClients)
 
 	onStarted := func(ctx context.Context) {
 		// Define some nested types to simplify the codebase
@@ -138,6 +139,7 @@ func main() {
 			fedvolumebackup.NewController(deps),
 			fedvolumerestore.NewController(deps),
 			fedvolumebackupschedule.NewController(deps),
+			tidbclusterfederation.NewController(deps),
 		}
 
 		// Start informer factories after all controllers are initialized.
@@ -218,13 +220,14 @@ func main() {
 	klog.Infof("br-federation-manager exited")
 }
 
-func initFederationKubeClients(cliCfg *controller.BrFedCLIConfig) (map[string]*fedversioned.Clientset, error) {
+func initFederationKubeClients(cliCfg *controller.BrFedCLIConfig) (map[string]*fedversioned.Clientset, map[string]kubernetes.Interface, error) {
 	files, err := os.ReadDir(cliCfg.FederationKubeConfigPath)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	clients := make(map[string]*fedversioned.Clientset)
+	kubeClients := make(map[string]kubernetes.Interface)
 	for _, f := range files {
 		if f.IsDir() || f.Name() == "..data" {
 			continue
@@ -232,7 +235,7 @@ func initFederationKubeClients(cliCfg *controller.BrFedCLIConfig) (map[string]*f
 
 		cfg, err := clientcmd.BuildConfigFromFlags("", filepath.Join(cliCfg.FederationKubeConfigPath, f.Name()))
 		if err != nil {
-			return nil, err // return error if any kube client init failed
+			return nil, nil, err // return error if any kube client init failed
 		}
 
 		// we use the same QPS and Burst as for the API server which is running this manager now
@@ -241,12 +244,17 @@ func initFederationKubeClients(cliCfg *controller.BrFedCLIConfig) (map[string]*f
 
 		cli, err := fedversioned.NewForConfig(cfg)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		clients[f.Name()] = cli
+		kubeCli, err := kubernetes.NewForConfig(cfg)
+		if err != nil {
+			return nil, nil, err
+		}
+		kubeClients[f.Name()] = kubeCli
 	}
 
-	return clients, nil
+	return clients, kubeClients, nil
 }
 
 func createHTTPServer() *http.Server {
@@ -22,17 +22,23 @@ import (
 	"os/signal"
 	"reflect"
 	"syscall"
+	"time"
 
 	"github.com/pingcap/advanced-statefulset/client/apis/apps/v1/helper"
 	asclientset "github.com/pingcap/advanced-statefulset/client/client/clientset/versioned"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
 	"github.com/pingcap/tidb-operator/pkg/client/clientset/versioned"
 	"github.com/pingcap/tidb-operator/pkg/controller"
 	"github.com/pingcap/tidb-operator/pkg/controller/autoscaler"
 	"github.com/pingcap/tidb-operator/pkg/controller/backup"
 	"github.com/pingcap/tidb-operator/pkg/controller/backupschedule"
 	"github.com/pingcap/tidb-operator/pkg/controller/dmcluster"
+	"github.com/pingcap/tidb-operator/pkg/controller/dmtask"
+	"github.com/pingcap/tidb-operator/pkg/controller/jobgc"
 	"github.com/pingcap/tidb-operator/pkg/controller/restore"
 	"github.com/pingcap/tidb-operator/pkg/controller/tidbcluster"
+	"github.com/pingcap/tidb-operator/pkg/controller/tidbclusterclaim"
+	"github.com/pingcap/tidb-operator/pkg/controller/tidbclusterfleet"
 	"github.com/pingcap/tidb-operator/pkg/controller/tidbdashboard"
 	"github.com/pingcap/tidb-operator/pkg/controller/tidbinitializer"
 	"github.com/pingcap/tidb-operator/pkg/controller/tidbmonitor"
@@ -41,8 +47,10 @@ import (
 	"github.com/pingcap/tidb-operator/pkg/metrics"
 	"github.com/pingcap/tidb-operator/pkg/scheme"
 	"github.com/pingcap/tidb-operator/pkg/upgrader"
+	httputil "github.com/pingcap/tidb-operator/pkg/util/http"
 	"github.com/pingcap/tidb-operator/pkg/version"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	apiextensionsclientset "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/kubernetes"
@@ -68,6 +76,25 @@ func main() {
 		os.Exit(0)
 	}
 
+	if err := cliCfg.LoadOperatorConfiguration(); err != nil {
+		klog.Fatalf("failed to load operator configuration: %v", err)
+	}
+	if err := cliCfg.ValidateProxy(); err != nil {
+		klog.Fatal(err)
+	}
+	if err := cliCfg.ValidateOrphanResourcePolicy(); err != nil {
+		klog.Fatal(err)
+	}
+	if err := cliCfg.ValidateCRDCheckPolicy(); err != nil {
+		klog.Fatal(err)
+	}
+	httputil.SetProxy(cliCfg.HTTPProxy, cliCfg.HTTPSProxy, cliCfg.NoProxy)
+	retryPolicy, err := cliCfg.ComponentHTTPRetryPolicy()
+	if err != nil {
+		klog.Fatal(err)
+	}
+	httputil.SetRetryPolicy(retryPolicy)
+
 	logs.InitLogs()
 	defer logs.FlushLogs()
 
@@ -118,6 +145,10 @@ func main() {
 	if err != nil {
 		klog.Fatalf("failed to get advanced-statefulset Clientset: %v", err)
 	}
+	extCli, err := apiextensionsclientset.NewForConfig(cfg)
+	if err != nil {
+		klog.Fatalf("failed to get apiextensions Clientset: %v", err)
+	}
 	// TODO: optimize the read of genericCli with the shared cache
 	genericCli, err := client.New(cfg, client.Options{Scheme: scheme.Scheme})
 	if err != nil {
@@ -132,10 +163,17 @@ func main() {
 		operatorUpgrader = upgrader.NewUpgrader(kubeCli, cli, asCli, ns)
 	}
 
+	var astsChecker *upgrader.ASTSChecker
 	if features.DefaultFeatureGate.Enabled(features.AdvancedStatefulSet) {
 		// If AdvancedStatefulSet is enabled, we hijack the Kubernetes client to use
 		// AdvancedStatefulSet.
-		kubeCli = helper.NewHijackClient(kubeCli, asCli)
+		hijackedCli := helper.NewHijackClient(kubeCli, asCli)
+		if cliCfg.ClusterScoped {
+			astsChecker = upgrader.NewASTSChecker(extCli, kubeCli, hijackedCli, asCli, metav1.NamespaceAll)
+		} else {
+			astsChecker = upgrader.NewASTSChecker(extCli, kubeCli, hijackedCli, asCli, ns)
+		}
+		kubeCli = hijackedCli
 	}
 
 	deps, err := controller.NewDependencies(ns, cliCfg, cli, kubeCli, genericCli)
@@ -143,6 +181,8 @@ func main() {
 		klog.Fatalf("failed to create Dependencies: %s", err)
 	}
 
+	go cliCfg.WatchOperatorConfiguration(wait.NeverStop)
+
 	onStarted := func(ctx context.Context) {
 		// Upgrade before running any controller logic. If it fails, we wait
 		// for process supervisor to restart it again.
@@ -150,6 +190,32 @@ func main() {
 			klog.Fatalf("failed to upgrade: %v", err)
 		}
 
+		// Check the installed CRDs before running any controller logic, the fields missing in the
+		// CRDs are pruned by the API server and the controllers would see the default values.
+		if cliCfg.CRDCheckPolicy != controller.CRDCheckPolicyIgnore {
+			kinds := []v1alpha1.CrdKind{
+				v1alpha1.DefaultCrdKinds.TiDBCluster,
+				v1alpha1.DefaultCrdKinds.DMCluster,
+				v1alpha1.DefaultCrdKinds.Backup,
+				v1alpha1.DefaultCrdKinds.Restore,
+				v1alpha1.DefaultCrdKinds.BackupSchedule,
+				v1alpha1.DefaultCrdKinds.TiDBMonitor,
+				v1alpha1.DefaultCrdKinds.TiDBInitializer,
+				v1alpha1.DefaultCrdKinds.TiDBNGMonitoring,
+			}
+			if features.DefaultFeatureGate.Enabled(features.AutoScaling) {
+				kinds = append(kinds, v1alpha1.DefaultCrdKinds.TidbClusterAutoScaler)
+			}
+			block := cliCfg.CRDCheckPolicy == controller.CRDCheckPolicyBlock
+			upgrader.WaitForCompatibleCRDs(upgrader.NewCRDChecker(extCli), kinds, block, 30*time.Second, ctx.Done())
+		}
+
+		// Verify the Advanced StatefulSet CRD and controller periodically, the incompatibilities are
+		// reported in the conditions of the TidbClusters.
+		if astsChecker != nil {
+			go upgrader.RunASTSChecker(astsChecker, deps.ASTSCompatibility, 5*time.Minute, ctx.Done())
+		}
+
 		// Define some nested types to simplify the codebase
 		type Controller interface {
 			Run(int, <-chan struct{})
@@ -168,6 +234,7 @@ func main() {
 		controllers := []Controller{
 			tidbcluster.NewController(deps),
 			tidbcluster.NewPodController(deps),
+			tidbcluster.NewRestartController(deps),
 			dmcluster.NewController(deps),
 			backup.NewController(deps),
 			restore.NewController(deps),
@@ -176,10 +243,20 @@ func main() {
 			tidbmonitor.NewController(deps),
 			tidbngmonitoring.NewController(deps),
 			tidbdashboard.NewController(deps),
+			jobgc.NewController(deps),
 		}
 		if features.DefaultFeatureGate.Enabled(features.AutoScaling) {
 			controllers = append(controllers, autoscaler.NewController(deps))
 		}
+		if features.DefaultFeatureGate.Enabled(features.FleetStatus) {
+			controllers = append(controllers, tidbclusterfleet.NewController(deps, ns))
+		}
+		if features.DefaultFeatureGate.Enabled(features.ClusterClaim) {
+			controllers = append(controllers, tidbclusterclaim.NewController(deps))
+		}
+		if features.DefaultFeatureGate.Enabled(features.DMTask) {
+			controllers = append(controllers, dmtask.NewController(deps))
+		}
 
 		// Start informer factories after all controllers are initialized.
 		informerFactories := []InformerFactory{
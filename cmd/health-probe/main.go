@@ -0,0 +1,149 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"database/sql"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"path"
+	"syscall"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/pingcap/tidb-operator/pkg/healthprobe"
+	"github.com/pingcap/tidb-operator/pkg/util"
+	"github.com/pingcap/tidb-operator/pkg/version"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/component-base/logs"
+	"k8s.io/klog/v2"
+)
+
+var (
+	printVersion bool
+	host         string
+	port         int
+	user         string
+	database     string
+	interval     time.Duration
+	timeout      time.Duration
+	tlsEnabled   bool
+)
+
+func init() {
+	flag.BoolVar(&printVersion, "V", false, "Show version and quit")
+	flag.BoolVar(&printVersion, "version", false, "Show version and quit")
+	flag.StringVar(&host, "host", "", "The host of the TiDB service")
+	flag.IntVar(&port, "port", 4000, "The port of the TiDB service")
+	flag.StringVar(&user, "user", "root", "The user to connect to TiDB, the password is read from the TIDB_PASSWORD environment variable")
+	flag.StringVar(&database, "database", "tidb_operator", "The database where the probe table is created")
+	flag.DurationVar(&interval, "interval", 30*time.Second, "The interval between two probes")
+	flag.DurationVar(&timeout, "timeout", 10*time.Second, "The timeout of a probe")
+	flag.BoolVar(&tlsEnabled, "tls", false, "Connect to TiDB with the client certificates in "+util.TiDBClientTLSPath)
+	flag.Parse()
+}
+
+func main() {
+	if printVersion {
+		version.PrintVersionInfo()
+		os.Exit(0)
+	}
+	version.LogVersionInfo()
+
+	logs.InitLogs()
+	defer logs.FlushLogs()
+
+	cfg := mysql.NewConfig()
+	cfg.User = user
+	cfg.Passwd = os.Getenv("TIDB_PASSWORD")
+	cfg.Net = "tcp"
+	cfg.Addr = fmt.Sprintf("%s:%d", host, port)
+	cfg.Timeout = timeout
+	if tlsEnabled {
+		if err := registerTLSConfig(); err != nil {
+			klog.Fatalf("failed to load the client certificates: %v", err)
+		}
+		cfg.TLSConfig = "probe"
+	}
+	// the connection is not established until the first probe, so that the failures are reported
+	db, err := sql.Open("mysql", cfg.FormatDSN())
+	if err != nil {
+		klog.Fatalf("failed to open datasource: %v", err)
+	}
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+
+	id, err := os.Hostname()
+	if err != nil {
+		klog.Fatalf("failed to get hostname: %v", err)
+	}
+	prober := healthprobe.NewProber(db, database, id, timeout)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go prober.Run(interval, ctx.Done())
+
+	mux := http.NewServeMux()
+	mux.Handle(healthprobe.ResultPath, prober)
+	srv := http.Server{Addr: fmt.Sprintf(":%d", healthprobe.Port), Handler: mux}
+	sc := make(chan os.Signal, 1)
+	signal.Notify(sc,
+		syscall.SIGHUP,
+		syscall.SIGINT,
+		syscall.SIGTERM,
+		syscall.SIGQUIT,
+	)
+
+	go func() {
+		sig := <-sc
+		klog.Infof("got signal %s to exit", sig)
+		if err2 := srv.Shutdown(context.Background()); err2 != nil {
+			klog.Fatal("fail to shutdown the HTTP server", err2)
+		}
+	}()
+
+	klog.Infof("starting TiDB health probe of %s, listening on %s", cfg.Addr, srv.Addr)
+	if err = srv.ListenAndServe(); err != http.ErrServerClosed {
+		klog.Fatal(err)
+	}
+	klog.Infof("tidb-health-probe exited")
+}
+
+func registerTLSConfig() error {
+	rootCertPool := x509.NewCertPool()
+	pem, err := os.ReadFile(path.Join(util.TiDBClientTLSPath, corev1.ServiceAccountRootCAKey))
+	if err != nil {
+		return err
+	}
+	if ok := rootCertPool.AppendCertsFromPEM(pem); !ok {
+		return fmt.Errorf("failed to append PEM")
+	}
+	cert, err := tls.LoadX509KeyPair(
+		path.Join(util.TiDBClientTLSPath, corev1.TLSCertKey),
+		path.Join(util.TiDBClientTLSPath, corev1.TLSPrivateKeyKey))
+	if err != nil {
+		return err
+	}
+	return mysql.RegisterTLSConfig("probe", &tls.Config{
+		RootCAs:      rootCertPool,
+		Certificates: []tls.Certificate{cert},
+		ServerName:   host,
+	})
+}
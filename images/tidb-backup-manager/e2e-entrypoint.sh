@@ -16,6 +16,17 @@
 set -e
 
 export GOOGLE_APPLICATION_CREDENTIALS=/tmp/google-credentials.json
+AZURE_SERVICE_PRINCIPAL_FILE=""
+AZURE_SAS_URL=""
+AZURE_USE_MSI=false
+if [[ -n "${AZURE_CLIENT_SECRET:-}" ]]; then
+    AZURE_SERVICE_PRINCIPAL_FILE=/tmp/azure-service-principal.json
+elif [[ -n "${AZURE_STORAGE_SAS_TOKEN:-}" ]]; then
+    AZURE_SAS_URL="https://${AZURE_STORAGE_ACCOUNT}.blob.core.windows.net/?${AZURE_STORAGE_SAS_TOKEN#\?}"
+elif [[ -z "${AZURE_STORAGE_KEY:-}" ]]; then
+    # use the managed identity if no credentials are given
+    AZURE_USE_MSI=true
+fi
 echo "Create rclone.conf file."
 cat <<EOF > /tmp/rclone.conf
 [s3]
@@ -36,11 +47,23 @@ object_acl = ${GCS_OBJECT_ACL}
 bucket_acl = ${GCS_BUCKET_ACL}
 location =  ${GCS_LOCATION}
 storage_class = ${GCS_STORAGE_CLASS:-"COLDLINE"}
-[azure]
+[azblob]
 type = azureblob
-account = ${AZUREBLOB_ACCOUNT}
-key = ${AZUREBLOB_KEY}
+account = ${AZURE_STORAGE_ACCOUNT}
+key = ${AZURE_STORAGE_KEY}
+sas_url = ${AZURE_SAS_URL}
+service_principal_file = ${AZURE_SERVICE_PRINCIPAL_FILE}
+use_msi = ${AZURE_USE_MSI}
+msi_client_id = ${AZURE_CLIENT_ID}
+access_tier = ${AZURE_ACCESS_TIER}
+EOF
+
+if [[ -n "${AZURE_SERVICE_PRINCIPAL_FILE}" ]]; then
+    echo "Create azure-service-principal.json file."
+    cat <<EOF > ${AZURE_SERVICE_PRINCIPAL_FILE}
+{"appId": "${AZURE_CLIENT_ID}", "password": "${AZURE_CLIENT_SECRET}", "tenant": "${AZURE_TENANT_ID}"}
 EOF
+fi
 
 if [[ -n "${GCS_SERVICE_ACCOUNT_JSON_KEY:-}" ]]; then
     echo "Create google-credentials.json file."
@@ -27,15 +27,195 @@ import (
 
 func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenAPIDefinition {
 	return map[string]common.OpenAPIDefinition{
-		"github.com/pingcap/tidb-operator/pkg/apis/federation/pingcap/v1alpha1.VolumeBackup":             schema_apis_federation_pingcap_v1alpha1_VolumeBackup(ref),
-		"github.com/pingcap/tidb-operator/pkg/apis/federation/pingcap/v1alpha1.VolumeBackupList":         schema_apis_federation_pingcap_v1alpha1_VolumeBackupList(ref),
-		"github.com/pingcap/tidb-operator/pkg/apis/federation/pingcap/v1alpha1.VolumeBackupSchedule":     schema_apis_federation_pingcap_v1alpha1_VolumeBackupSchedule(ref),
-		"github.com/pingcap/tidb-operator/pkg/apis/federation/pingcap/v1alpha1.VolumeBackupScheduleList": schema_apis_federation_pingcap_v1alpha1_VolumeBackupScheduleList(ref),
-		"github.com/pingcap/tidb-operator/pkg/apis/federation/pingcap/v1alpha1.VolumeBackupScheduleSpec": schema_apis_federation_pingcap_v1alpha1_VolumeBackupScheduleSpec(ref),
-		"github.com/pingcap/tidb-operator/pkg/apis/federation/pingcap/v1alpha1.VolumeBackupSpec":         schema_apis_federation_pingcap_v1alpha1_VolumeBackupSpec(ref),
-		"github.com/pingcap/tidb-operator/pkg/apis/federation/pingcap/v1alpha1.VolumeRestore":            schema_apis_federation_pingcap_v1alpha1_VolumeRestore(ref),
-		"github.com/pingcap/tidb-operator/pkg/apis/federation/pingcap/v1alpha1.VolumeRestoreList":        schema_apis_federation_pingcap_v1alpha1_VolumeRestoreList(ref),
-		"github.com/pingcap/tidb-operator/pkg/apis/federation/pingcap/v1alpha1.VolumeRestoreSpec":        schema_apis_federation_pingcap_v1alpha1_VolumeRestoreSpec(ref),
+		"github.com/pingcap/tidb-operator/pkg/apis/federation/pingcap/v1alpha1.TidbClusterFederation":       schema_apis_federation_pingcap_v1alpha1_TidbClusterFederation(ref),
+		"github.com/pingcap/tidb-operator/pkg/apis/federation/pingcap/v1alpha1.TidbClusterFederationList":   schema_apis_federation_pingcap_v1alpha1_TidbClusterFederationList(ref),
+		"github.com/pingcap/tidb-operator/pkg/apis/federation/pingcap/v1alpha1.TidbClusterFederationMember": schema_apis_federation_pingcap_v1alpha1_TidbClusterFederationMember(ref),
+		"github.com/pingcap/tidb-operator/pkg/apis/federation/pingcap/v1alpha1.TidbClusterFederationSpec":   schema_apis_federation_pingcap_v1alpha1_TidbClusterFederationSpec(ref),
+		"github.com/pingcap/tidb-operator/pkg/apis/federation/pingcap/v1alpha1.VolumeBackup":                schema_apis_federation_pingcap_v1alpha1_VolumeBackup(ref),
+		"github.com/pingcap/tidb-operator/pkg/apis/federation/pingcap/v1alpha1.VolumeBackupList":            schema_apis_federation_pingcap_v1alpha1_VolumeBackupList(ref),
+		"github.com/pingcap/tidb-operator/pkg/apis/federation/pingcap/v1alpha1.VolumeBackupSchedule":        schema_apis_federation_pingcap_v1alpha1_VolumeBackupSchedule(ref),
+		"github.com/pingcap/tidb-operator/pkg/apis/federation/pingcap/v1alpha1.VolumeBackupScheduleList":    schema_apis_federation_pingcap_v1alpha1_VolumeBackupScheduleList(ref),
+		"github.com/pingcap/tidb-operator/pkg/apis/federation/pingcap/v1alpha1.VolumeBackupScheduleSpec":    schema_apis_federation_pingcap_v1alpha1_VolumeBackupScheduleSpec(ref),
+		"github.com/pingcap/tidb-operator/pkg/apis/federation/pingcap/v1alpha1.VolumeBackupSpec":            schema_apis_federation_pingcap_v1alpha1_VolumeBackupSpec(ref),
+		"github.com/pingcap/tidb-operator/pkg/apis/federation/pingcap/v1alpha1.VolumeRestore":               schema_apis_federation_pingcap_v1alpha1_VolumeRestore(ref),
+		"github.com/pingcap/tidb-operator/pkg/apis/federation/pingcap/v1alpha1.VolumeRestoreList":           schema_apis_federation_pingcap_v1alpha1_VolumeRestoreList(ref),
+		"github.com/pingcap/tidb-operator/pkg/apis/federation/pingcap/v1alpha1.VolumeRestoreSpec":           schema_apis_federation_pingcap_v1alpha1_VolumeRestoreSpec(ref),
+	}
+}
+
+func schema_apis_federation_pingcap_v1alpha1_TidbClusterFederation(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "TidbClusterFederation coordinates the TidbClusters deployed across the Kubernetes clusters of the federation into one TiDB cluster, which share the PD quorum bootstrapped by the first member",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("github.com/pingcap/tidb-operator/pkg/apis/federation/pingcap/v1alpha1.TidbClusterFederationSpec"),
+						},
+					},
+				},
+				Required: []string{"spec"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/pingcap/tidb-operator/pkg/apis/federation/pingcap/v1alpha1.TidbClusterFederationSpec"},
+	}
+}
+
+func schema_apis_federation_pingcap_v1alpha1_TidbClusterFederationList(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "TidbClusterFederationList is TidbClusterFederation list",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"),
+						},
+					},
+					"items": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/pingcap/tidb-operator/pkg/apis/federation/pingcap/v1alpha1.TidbClusterFederation"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"metadata", "items"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/pingcap/tidb-operator/pkg/apis/federation/pingcap/v1alpha1.TidbClusterFederation", "k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"},
+	}
+}
+
+func schema_apis_federation_pingcap_v1alpha1_TidbClusterFederationMember(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "TidbClusterFederationMember is a TidbCluster in a Kubernetes cluster of the federation.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"k8sClusterName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "K8sClusterName is the name of the Kubernetes cluster, which is the name of its kubeconfig file in the federation kubeconfig directory of the br-federation-manager",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"tcNamespace": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TCNamespace is the namespace of the TidbCluster",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"tcName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TCName is the name of the TidbCluster",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"clusterDomain": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ClusterDomain is the cluster domain of the Kubernetes cluster, the peer URLs of the member are qualified by it to be resolvable across the Kubernetes clusters",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"k8sClusterName", "tcNamespace", "tcName", "clusterDomain"},
+			},
+		},
+	}
+}
+
+func schema_apis_federation_pingcap_v1alpha1_TidbClusterFederationSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "TidbClusterFederationSpec describes the members of a TidbClusterFederation.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"members": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Members are the TidbClusters in the Kubernetes clusters of the federation. They're bootstrapped one by one in order, the first member bootstraps the PD quorum and the others join it once all the members before them are ready. The TidbClusters are created by the users and should be paused until they're joined.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/pingcap/tidb-operator/pkg/apis/federation/pingcap/v1alpha1.TidbClusterFederationMember"),
+									},
+								},
+							},
+						},
+					},
+					"secretNames": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SecretNames are the secrets in the namespace of the TidbClusterFederation, which are copied into the namespace of every member before it's started, e.g. the TLS certificates issued by the CA shared by the members",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"members"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/pingcap/tidb-operator/pkg/apis/federation/pingcap/v1alpha1.TidbClusterFederationMember"},
 	}
 }
 
@@ -56,6 +56,8 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&VolumeBackupScheduleList{},
 		&VolumeRestore{},
 		&VolumeRestoreList{},
+		&TidbClusterFederation{},
+		&TidbClusterFederationList{},
 	)
 
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
@@ -157,3 +157,105 @@ type VolumeRestoreCondition struct {
 	Reason             string      `json:"reason,omitempty"`
 	Message            string      `json:"message,omitempty"`
 }
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// TidbClusterFederation coordinates the TidbClusters deployed across the Kubernetes clusters of the federation
+// into one TiDB cluster, which share the PD quorum bootstrapped by the first member
+//
+// +k8s:openapi-gen=true
+// +kubebuilder:resource:shortName="tcf"
+// +genclient:noStatus
+type TidbClusterFederation struct {
+	metav1.TypeMeta `json:",inline"`
+	// +k8s:openapi-gen=false
+	metav1.ObjectMeta `json:"metadata"`
+
+	Spec TidbClusterFederationSpec `json:"spec"`
+
+	// +k8s:openapi-gen=false
+	Status TidbClusterFederationStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// TidbClusterFederationList is TidbClusterFederation list
+// +k8s:openapi-gen=true
+type TidbClusterFederationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+	Items           []TidbClusterFederation `json:"items"`
+}
+
+// TidbClusterFederationSpec describes the members of a TidbClusterFederation.
+// +k8s:openapi-gen=true
+type TidbClusterFederationSpec struct {
+	// Members are the TidbClusters in the Kubernetes clusters of the federation. They're bootstrapped one by one
+	// in order, the first member bootstraps the PD quorum and the others join it once all the members before them
+	// are ready. The TidbClusters are created by the users and should be paused until they're joined.
+	// +kubebuilder:validation:MinItems=1
+	Members []TidbClusterFederationMember `json:"members"`
+
+	// SecretNames are the secrets in the namespace of the TidbClusterFederation, which are copied into the namespace
+	// of every member before it's started, e.g. the TLS certificates issued by the CA shared by the members
+	// +optional
+	SecretNames []string `json:"secretNames,omitempty"`
+}
+
+// TidbClusterFederationMember is a TidbCluster in a Kubernetes cluster of the federation.
+// +k8s:openapi-gen=true
+type TidbClusterFederationMember struct {
+	// K8sClusterName is the name of the Kubernetes cluster, which is the name of its kubeconfig file
+	// in the federation kubeconfig directory of the br-federation-manager
+	K8sClusterName string `json:"k8sClusterName"`
+
+	// TCNamespace is the namespace of the TidbCluster
+	TCNamespace string `json:"tcNamespace"`
+
+	// TCName is the name of the TidbCluster
+	TCName string `json:"tcName"`
+
+	// ClusterDomain is the cluster domain of the Kubernetes cluster, the peer URLs of the member are
+	// qualified by it to be resolvable across the Kubernetes clusters
+	ClusterDomain string `json:"clusterDomain"`
+}
+
+// TidbClusterFederationMemberPhase is the phase of a member of a TidbClusterFederation
+type TidbClusterFederationMemberPhase string
+
+const (
+	// TidbClusterFederationMemberWaiting means the member waits for the members before it to be ready
+	TidbClusterFederationMemberWaiting TidbClusterFederationMemberPhase = "Waiting"
+	// TidbClusterFederationMemberStarting means the member is started and isn't ready yet
+	TidbClusterFederationMemberStarting TidbClusterFederationMemberPhase = "Starting"
+	// TidbClusterFederationMemberReady means the member is ready
+	TidbClusterFederationMemberReady TidbClusterFederationMemberPhase = "Ready"
+	// TidbClusterFederationMemberFailed means the member can't be joined, see the message for the reason
+	TidbClusterFederationMemberFailed TidbClusterFederationMemberPhase = "Failed"
+)
+
+// AnnTidbClusterFederationPaused is the annotation of the TidbCluster paused by the TidbClusterFederation,
+// which is removed when the TidbCluster is resumed
+const AnnTidbClusterFederationPaused = "federation.pingcap.com/paused"
+
+// TidbClusterFederationStatus represents the current status of a TidbClusterFederation.
+type TidbClusterFederationStatus struct {
+	// ClusterID is the ID of the TiDB cluster bootstrapped by the first member
+	ClusterID string `json:"clusterID,omitempty"`
+
+	// +nullable
+	Members []TidbClusterFederationMemberStatus `json:"members,omitempty"`
+}
+
+// TidbClusterFederationMemberStatus is the status of a member of a TidbClusterFederation.
+type TidbClusterFederationMemberStatus struct {
+	K8sClusterName string                           `json:"k8sClusterName"`
+	TCNamespace    string                           `json:"tcNamespace"`
+	TCName         string                           `json:"tcName"`
+	Phase          TidbClusterFederationMemberPhase `json:"phase"`
+	Message        string                           `json:"message,omitempty"`
+
+	// +nullable
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+}
@@ -62,6 +62,12 @@ const (
 	// BackupProtectionFinalizer is the name of finalizer on backups or federation backups
 	BackupProtectionFinalizer string = "tidb.pingcap.com/backup-protection"
 
+	// TeardownFinalizer is the name of finalizer on tidbclusters which are torn down by the operator on deletion
+	TeardownFinalizer string = "tidb.pingcap.com/teardown"
+
+	// DMTaskFinalizer is the name of finalizer on DM tasks whose tasks and sources are removed from dm-master on deletion
+	DMTaskFinalizer string = "tidb.pingcap.com/dm-task"
+
 	// AutoScalingGroupLabelKey describes the autoscaling group of the TiDB
 	AutoScalingGroupLabelKey = "tidb.pingcap.com/autoscaling-group"
 	// AutoInstanceLabelKey is label key used in autoscaling, it represents the autoscaler name
@@ -70,6 +76,8 @@ const (
 	AutoComponentLabelKey string = "tidb.pingcap.com/auto-component"
 	// BaseTCLabelKey is label key used for heterogeneous clusters to refer to its base TidbCluster
 	BaseTCLabelKey string = "tidb.pingcap.com/base-tc"
+	// TiKVGroupLabelKey is label key used for the heterogeneous clusters of the TiKV groups, it represents the group name
+	TiKVGroupLabelKey string = "tidb.pingcap.com/tikv-group"
 
 	// AnnHATopologyKey defines the High availability topology key
 	AnnHATopologyKey = "pingcap.com/ha-topology-key"
@@ -89,6 +97,18 @@ const (
 	AnnTiKVPartition string = "tidb.pingcap.com/tikv-partition"
 	// AnnForceUpgradeKey is tc annotation key to indicate whether force upgrade should be done
 	AnnForceUpgradeKey = "tidb.pingcap.com/force-upgrade"
+	// AnnApplyPendingChangesKey is tc annotation key to indicate whether the spec changes held during an in-progress upgrade or scale should be applied immediately,
+	// it is one-shot and removed by the operator after the pending changes are applied
+	AnnApplyPendingChangesKey = "tidb.pingcap.com/apply-pending-changes"
+	// AnnPDForceBootstrapKey is tc annotation key to indicate whether discovery may bootstrap a new PD cluster
+	// even if the tc has been bootstrapped before, e.g. when recovering PD with pd-recover
+	AnnPDForceBootstrapKey = "tidb.pingcap.com/pd-force-bootstrap"
+	// AnnPDAllowQuorumLossKey is tc annotation key to indicate whether voluntary disruptions of PD pods may leave
+	// fewer than quorum healthy PD members, e.g. to upgrade a cluster with only one or two PD members
+	AnnPDAllowQuorumLossKey = "tidb.pingcap.com/pd-allow-quorum-loss"
+	// AnnSkipConfigValidationKey is tc annotation key to indicate whether the admission webhook should skip the
+	// validation of the config of the components against their versions
+	AnnSkipConfigValidationKey = "tidb.pingcap.com/skip-config-validation"
 	// AnnPDDeferDeleting is pd pod annotation key  in pod for defer for deleting pod
 	AnnPDDeferDeleting = "tidb.pingcap.com/pd-defer-deleting"
 	// AnnSysctlInit is pod annotation key to indicate whether configuring sysctls with init container
@@ -97,6 +117,8 @@ const (
 	AnnEvictLeaderBeginTime = "tidb.pingcap.com/evictLeaderBeginTime"
 	// AnnTiCDCGracefulShutdownBeginTime is pod annotation key to indicate the begin time for graceful shutdown TiCDC
 	AnnTiCDCGracefulShutdownBeginTime = "tidb.pingcap.com/ticdc-graceful-shutdown-begin-time"
+	// AnnStandbyKey is sts annotation key to indicate whether the sts is reconciled with zero replicas for a warm standby tc
+	AnnStandbyKey = "tidb.pingcap.com/standby"
 	// AnnStsLastSyncTimestamp is sts annotation key to indicate the last timestamp the operator sync the sts
 	AnnStsLastSyncTimestamp = "tidb.pingcap.com/sync-timestamp"
 
@@ -105,6 +127,16 @@ const (
 
 	// AnnForceUpgradeVal is tc annotation value to indicate whether force upgrade should be done
 	AnnForceUpgradeVal = "true"
+	// AnnPDAllowQuorumLossVal is tc annotation value to allow voluntary disruptions of PD pods breaking the quorum
+	AnnPDAllowQuorumLossVal = "true"
+	// AnnSkipConfigValidationVal is tc annotation value to skip the validation of the config of the components
+	AnnSkipConfigValidationVal = "true"
+	// AnnApplyPendingChangesVal is tc annotation value to indicate whether the held spec changes should be applied immediately
+	AnnApplyPendingChangesVal = "true"
+	// AnnPDForceBootstrapVal is tc annotation value to indicate whether discovery may bootstrap a new PD cluster
+	AnnPDForceBootstrapVal = "true"
+	// AnnStandbyVal is sts annotation value to indicate whether the sts is reconciled for a warm standby tc
+	AnnStandbyVal = "true"
 	// AnnSysctlInitVal is pod annotation value to indicate whether configuring sysctls with init container
 	AnnSysctlInitVal = "true"
 
@@ -126,6 +158,19 @@ const (
 	// AnnDMWorkerDeleteSlots is annotation key of dm-worker delete slots.
 	AnnDMWorkerDeleteSlots = "dm-worker.tidb.pingcap.com/delete-slots"
 
+	// AnnPDRestartOrdinals is annotation key of the ordinals of the pd pods to restart gracefully one by one.
+	AnnPDRestartOrdinals = "pd.tidb.pingcap.com/restart-ordinals"
+	// AnnTiDBRestartOrdinals is annotation key of the ordinals of the tidb pods to restart gracefully one by one.
+	AnnTiDBRestartOrdinals = "tidb.tidb.pingcap.com/restart-ordinals"
+	// AnnTiKVRestartOrdinals is annotation key of the ordinals of the tikv pods to restart gracefully one by one.
+	AnnTiKVRestartOrdinals = "tikv.tidb.pingcap.com/restart-ordinals"
+	// AnnTiFlashRestartOrdinals is annotation key of the ordinals of the tiflash pods to restart one by one.
+	AnnTiFlashRestartOrdinals = "tiflash.tidb.pingcap.com/restart-ordinals"
+	// AnnTiCDCRestartOrdinals is annotation key of the ordinals of the ticdc pods to restart one by one.
+	AnnTiCDCRestartOrdinals = "ticdc.tidb.pingcap.com/restart-ordinals"
+	// AnnTiProxyRestartOrdinals is annotation key of the ordinals of the tiproxy pods to restart one by one.
+	AnnTiProxyRestartOrdinals = "tiproxy.tidb.pingcap.com/restart-ordinals"
+
 	// AnnSkipTLSWhenConnectTiDB describes whether skip TLS when connecting to TiDB Server
 	AnnSkipTLSWhenConnectTiDB = "tidb.tidb.pingcap.com/skip-tls-when-connect-tidb"
 
@@ -137,6 +182,35 @@ const (
 	// when TiDB cluster is restored from volume snapshot based backup.
 	AnnTiKVVolumesReadyKey = "tidb.pingcap.com/tikv-volumes-ready"
 
+	// AnnTiKVGroupSpecHashKey is the annotation key of the hash of the spec of a heterogeneous TidbCluster
+	// created for a TiKV group, the TidbCluster is only updated when the hash changes.
+	AnnTiKVGroupSpecHashKey = "tidb.pingcap.com/tikv-group-spec-hash"
+
+	// AnnRestartReasonKey is pod annotation key of the cause of the last restart of the pod initiated by the operator
+	AnnRestartReasonKey = "tidb.pingcap.com/restart-reason"
+	// AnnRestartDetailKey is pod annotation key of the detail of the cause of the last restart, e.g. the new image or config hash
+	AnnRestartDetailKey = "tidb.pingcap.com/restart-detail"
+	// AnnRestartedAtKey is the annotation key users set in the annotations of a component to restart its pods gracefully
+	AnnRestartedAtKey = "tidb.pingcap.com/restartedAt"
+
+	// RestartReasonUpgrade is the restart reason of the pods recreated for a new image
+	RestartReasonUpgrade = "upgrade"
+	// RestartReasonConfigChange is the restart reason of the pods recreated for a new config
+	RestartReasonConfigChange = "config-change"
+	// RestartReasonUserRequested is the restart reason of the pods recreated for a change of AnnRestartedAtKey
+	RestartReasonUserRequested = "user-requested"
+	// RestartReasonSpecChange is the restart reason of the pods recreated for the other changes of the pod template
+	RestartReasonSpecChange = "spec-change"
+	// RestartReasonFailover is the restart reason of the pods deleted by the failover
+	RestartReasonFailover = "failover"
+	// RestartReasonVolumeReplace is the restart reason of the pods recreated with the replaced volumes
+	RestartReasonVolumeReplace = "volume-replace"
+
+	// AnnPropagatedFromKey is secret annotation key of the namespace/name of the secret it's copied from by the secret propagation
+	AnnPropagatedFromKey = "tidb.pingcap.com/propagated-from"
+	// AnnPropagatedHashKey is secret annotation key of the hash of the data of the secret it's copied from
+	AnnPropagatedHashKey = "tidb.pingcap.com/propagated-hash"
+
 	// PDLabelVal is PD label value
 	PDLabelVal string = "pd"
 	// TiDBLabelVal is TiDB label value
@@ -153,6 +227,8 @@ const (
 	PumpLabelVal string = "pump"
 	// DiscoveryLabelVal is Discovery label value
 	DiscoveryLabelVal string = "discovery"
+	// HealthProbeLabelVal is health probe label value
+	HealthProbeLabelVal string = "health-probe"
 	// TiDBMonitorVal is Monitor label value
 	TiDBMonitorVal string = "monitor"
 
@@ -452,6 +528,11 @@ func (l Label) Discovery() Label {
 	return l.Component(DiscoveryLabelVal)
 }
 
+// HealthProbe assigns health-probe to component key in label
+func (l Label) HealthProbe() Label {
+	return l.Component(HealthProbeLabelVal)
+}
+
 // TiDB assigns tidb to component key in label
 func (l Label) TiDB() Label {
 	return l.Component(TiDBLabelVal)
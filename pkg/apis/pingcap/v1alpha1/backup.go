@@ -69,6 +69,12 @@ func (bk *Backup) GetBackupPVCName() string {
 	return fmt.Sprintf("backup-pvc-%s", bk.GetTidbEndpointHash())
 }
 
+// GetBrokerCredentialsSecretName return the name of the secret storing the storage credentials issued
+// by the credentials broker for the backup
+func (bk *Backup) GetBrokerCredentialsSecretName() string {
+	return fmt.Sprintf("backup-%s-broker-credentials", bk.GetName())
+}
+
 // GetInstanceName return the backup instance name
 func (bk *Backup) GetInstanceName() string {
 	if bk.Labels != nil {
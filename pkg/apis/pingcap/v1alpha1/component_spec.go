@@ -60,6 +60,7 @@ type ComponentAccessor interface {
 	NodeSelector() map[string]string
 	Labels() map[string]string
 	Annotations() map[string]string
+	Metadata() ObjectMetadata
 	Tolerations() []corev1.Toleration
 	PodSecurityContext() *corev1.PodSecurityContext
 	SchedulerName() string
@@ -77,6 +78,8 @@ type ComponentAccessor interface {
 	PodManagementPolicy() apps.PodManagementPolicyType
 	TopologySpreadConstraints() []corev1.TopologySpreadConstraint
 	SuspendAction() *SuspendAction
+	PodTemplatePatches() []PodTemplatePatch
+	Lifecycle() *corev1.Lifecycle
 }
 
 func (tc *TidbCluster) AllComponentSpec() []ComponentAccessor {
@@ -166,6 +169,7 @@ type componentAccessorImpl struct {
 	clusterNodeSelector       map[string]string
 	clusterAnnotations        map[string]string
 	clusterLabels             map[string]string
+	clusterMetadata           *ObjectMetadata
 	tolerations               []corev1.Toleration
 	dnsConfig                 *corev1.PodDNSConfig
 	dnsPolicy                 corev1.DNSPolicy
@@ -300,6 +304,31 @@ func (a *componentAccessorImpl) Annotations() map[string]string {
 	return anno
 }
 
+// Metadata returns the labels and annotations propagated to the resources generated for the component,
+// the component-level ones override the cluster-level ones.
+func (a *componentAccessorImpl) Metadata() ObjectMetadata {
+	md := ObjectMetadata{Labels: map[string]string{}, Annotations: map[string]string{}}
+	for _, m := range []*ObjectMetadata{a.clusterMetadata, a.componentMetadata()} {
+		if m == nil {
+			continue
+		}
+		for k, v := range m.Labels {
+			md.Labels[k] = v
+		}
+		for k, v := range m.Annotations {
+			md.Annotations[k] = v
+		}
+	}
+	return md
+}
+
+func (a *componentAccessorImpl) componentMetadata() *ObjectMetadata {
+	if a.ComponentSpec == nil {
+		return nil
+	}
+	return a.ComponentSpec.Metadata
+}
+
 func (a *componentAccessorImpl) Tolerations() []corev1.Toleration {
 	if a.ComponentSpec == nil || len(a.ComponentSpec.Tolerations) == 0 {
 		return a.tolerations
@@ -313,6 +342,11 @@ func (a *componentAccessorImpl) DnsPolicy() corev1.DNSPolicy {
 	}
 
 	if a.dnsPolicy != "" {
+		// the cluster level ClusterFirst is meant for the pods without hostNetwork, which can't resolve
+		// the services in the cluster if hostNetwork is enabled for the component only
+		if a.dnsPolicy == corev1.DNSClusterFirst && a.HostNetwork() {
+			return corev1.DNSClusterFirstWithHostNet
+		}
 		return a.dnsPolicy
 	}
 
@@ -435,6 +469,12 @@ func (a *componentAccessorImpl) TopologySpreadConstraints() []corev1.TopologySpr
 			TopologyKey:       tsc.TopologyKey,
 			WhenUnsatisfiable: corev1.DoNotSchedule,
 		}
+		if tsc.MaxSkew > 0 {
+			ptsc.MaxSkew = tsc.MaxSkew
+		}
+		if tsc.WhenUnsatisfiable != "" {
+			ptsc.WhenUnsatisfiable = tsc.WhenUnsatisfiable
+		}
 		componentLabelVal := getComponentLabelValue(a.component)
 		var l label.Label
 		switch a.kind {
@@ -461,6 +501,20 @@ func (a *componentAccessorImpl) SuspendAction() *SuspendAction {
 	return action
 }
 
+func (a *componentAccessorImpl) PodTemplatePatches() []PodTemplatePatch {
+	if a.ComponentSpec == nil {
+		return nil
+	}
+	return a.ComponentSpec.PodTemplatePatches
+}
+
+func (a *componentAccessorImpl) Lifecycle() *corev1.Lifecycle {
+	if a.ComponentSpec == nil {
+		return nil
+	}
+	return a.ComponentSpec.Lifecycle
+}
+
 func getComponentLabelValue(c MemberType) string {
 	switch c {
 	case PDMemberType:
@@ -504,6 +558,7 @@ func buildTidbClusterComponentAccessor(c MemberType, tc *TidbCluster, componentS
 		clusterNodeSelector:       spec.NodeSelector,
 		clusterLabels:             spec.Labels,
 		clusterAnnotations:        spec.Annotations,
+		clusterMetadata:           spec.Metadata,
 		tolerations:               spec.Tolerations,
 		dnsConfig:                 spec.DNSConfig,
 		dnsPolicy:                 spec.DNSPolicy,
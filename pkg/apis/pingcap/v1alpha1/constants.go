@@ -20,6 +20,18 @@ const (
 	// DefaultTiDBServicePort is the default tidb cluster port for connecting
 	DefaultTiDBServicePort = int32(4000)
 
+	// DefaultTiDBStatusPort is the default port of the status API of tidb
+	DefaultTiDBStatusPort = int32(10080)
+
+	// DefaultPDClientPort is the default port of the client service of pd
+	DefaultPDClientPort = int32(2379)
+
+	// DefaultTiKVServerPort is the default port of the gRPC service of tikv
+	DefaultTiKVServerPort = int32(20160)
+
+	// DefaultTiKVStatusPort is the default port of the status API of tikv
+	DefaultTiKVStatusPort = int32(20180)
+
 	// DefaultTidbUser is the default tidb user for login tidb cluster
 	DefaultTidbUser = "root"
 )
@@ -56,6 +56,26 @@ func SetTidbClusterDefault(tc *v1alpha1.TidbCluster) {
 	if tc.Spec.TiProxy != nil {
 		setTiProxySpecDefault(tc)
 	}
+	if tc.Spec.TopologyZones != nil {
+		setTopologyZonesReplicas(tc)
+	}
+}
+
+// setTopologyZonesReplicas sets the replicas of the components to the sum of their zone quotas,
+// so that the components are scaled by the quotas
+func setTopologyZonesReplicas(tc *v1alpha1.TidbCluster) {
+	if quotas := tc.ZoneQuotas(v1alpha1.PDMemberType); len(quotas) != 0 && tc.Spec.PD != nil {
+		tc.Spec.PD.Replicas = v1alpha1.ZoneQuotasReplicas(quotas)
+	}
+	if quotas := tc.ZoneQuotas(v1alpha1.TiKVMemberType); len(quotas) != 0 && tc.Spec.TiKV != nil {
+		tc.Spec.TiKV.Replicas = v1alpha1.ZoneQuotasReplicas(quotas)
+	}
+	if quotas := tc.ZoneQuotas(v1alpha1.TiDBMemberType); len(quotas) != 0 && tc.Spec.TiDB != nil {
+		tc.Spec.TiDB.Replicas = v1alpha1.ZoneQuotasReplicas(quotas)
+	}
+	if quotas := tc.ZoneQuotas(v1alpha1.TiFlashMemberType); len(quotas) != 0 && tc.Spec.TiFlash != nil {
+		tc.Spec.TiFlash.Replicas = v1alpha1.ZoneQuotasReplicas(quotas)
+	}
 }
 
 // setTidbClusterSpecDefault is only managed the property under Spec
@@ -91,6 +111,10 @@ func setTidbSpecDefault(tc *v1alpha1.TidbCluster) {
 	if tc.Spec.TiDB.MaxFailoverCount == nil {
 		tc.Spec.TiDB.MaxFailoverCount = pointer.Int32Ptr(3)
 	}
+	// the config is rendered from the defaults of the config profile even if it's not specified
+	if tc.Spec.ConfigProfile != "" && tc.Spec.TiDB.Config == nil {
+		tc.Spec.TiDB.Config = v1alpha1.NewTiDBConfig()
+	}
 
 	// Start set config if need.
 	if tc.Spec.TiDB.Config == nil {
@@ -112,6 +136,10 @@ func setTikvSpecDefault(tc *v1alpha1.TidbCluster) {
 	if tc.Spec.TiKV.MaxFailoverCount == nil {
 		tc.Spec.TiKV.MaxFailoverCount = pointer.Int32Ptr(3)
 	}
+	// the config is rendered from the defaults of the config profile even if it's not specified
+	if tc.Spec.ConfigProfile != "" && tc.Spec.TiKV.Config == nil {
+		tc.Spec.TiKV.Config = v1alpha1.NewTiKVConfig()
+	}
 }
 
 func setPdSpecDefault(tc *v1alpha1.TidbCluster) {
@@ -123,6 +151,10 @@ func setPdSpecDefault(tc *v1alpha1.TidbCluster) {
 	if tc.Spec.PD.MaxFailoverCount == nil {
 		tc.Spec.PD.MaxFailoverCount = pointer.Int32Ptr(3)
 	}
+	// the config is rendered from the defaults of the config profile even if it's not specified
+	if tc.Spec.ConfigProfile != "" && tc.Spec.PD.Config == nil {
+		tc.Spec.PD.Config = v1alpha1.NewPDConfig()
+	}
 }
 
 func setPumpSpecDefault(tc *v1alpha1.TidbCluster) {
@@ -77,6 +77,24 @@ func TestSetTidbSpecDefault(t *testing.T) {
 	g.Expect(tc.Spec.TiDB.Config.Get("log.file.filename").AsString()).Should(Equal(fileName))
 	g.Expect(tc.Spec.TiDB.Config.Get("log.file.max-size").AsInt()).Should(Equal(maxSize))
 
+	// the config is defaulted to render the config profile
+	tc = newTidbCluster()
+	tc.Spec.ConfigProfile = v1alpha1.ConfigProfileLight
+	setTidbSpecDefault(tc)
+	g.Expect(tc.Spec.TiDB.Config).ShouldNot(BeNil())
+	g.Expect(tc.Spec.TiDB.Config.Get("log.file.max-backups").AsInt()).Should(Equal(int64(tidbLogMaxBackups)))
+}
+
+func TestSetTopologyZonesReplicas(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbCluster()
+	tc.Spec.TiDB.Replicas = 1
+	tc.Spec.TopologyZones = &v1alpha1.TopologyZones{
+		TiDB: map[string]int32{"us-east-1a": 2, "us-east-1b": 2, "us-east-1c": 1},
+	}
+	setTopologyZonesReplicas(tc)
+	g.Expect(tc.Spec.TiDB.Replicas).Should(Equal(int32(5)))
 }
 
 func newTidbCluster() *v1alpha1.TidbCluster {
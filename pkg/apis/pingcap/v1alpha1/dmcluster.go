@@ -17,6 +17,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/pingcap/tidb-operator/pkg/apis/label"
 	"k8s.io/apimachinery/pkg/types"
@@ -143,6 +144,20 @@ func (dc *DMCluster) GetWorkerRecoverByUID() types.UID {
 	return dc.Spec.Worker.Failover.RecoverByUID
 }
 
+// GetWorkerFailoverPeriod returns how long a dm-worker has to be offline before the failover, defaultPeriod
+// is returned if it's not set
+func (dc *DMCluster) GetWorkerFailoverPeriod(defaultPeriod time.Duration) time.Duration {
+	if dc.Spec.Worker == nil || dc.Spec.Worker.Failover == nil || dc.Spec.Worker.Failover.Period == nil {
+		return defaultPeriod
+	}
+	return dc.Spec.Worker.Failover.Period.Duration
+}
+
+// WorkerAutoRecoverFailover returns whether a failure dm-worker is recovered once it's online again
+func (dc *DMCluster) WorkerAutoRecoverFailover() bool {
+	return dc.Spec.Worker != nil && dc.Spec.Worker.Failover != nil && dc.Spec.Worker.Failover.RecoverPolicy == FailoverRecoverPolicyAuto
+}
+
 func (dc *DMCluster) GetInstanceName() string {
 	return dc.Name
 }
@@ -0,0 +1,299 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"github.com/pingcap/tidb-operator/pkg/apis/util/config"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DMTask is a data migration task run by a DMCluster. The sources and the task are submitted to
+// dm-master by its OpenAPI, which requires `openapi = true` in the config of dm-master, and are kept
+// in sync with the spec. The task and the sources it creates are removed from dm-master when the
+// DMTask is deleted.
+//
+// +genclient
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:resource:shortName="dmt"
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Cluster",type=string,JSONPath=`.spec.cluster.name`,description="The DMCluster running the task"
+// +kubebuilder:printcolumn:name="Mode",type=string,JSONPath=`.spec.taskMode`,description="The mode of the task"
+// +kubebuilder:printcolumn:name="Stage",type=string,JSONPath=`.status.stage`,description="The stage of the task"
+// +kubebuilder:printcolumn:name="Message",type=string,JSONPath=`.status.message`,description="The last error of the task",priority=1
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+type DMTask struct {
+	metav1.TypeMeta `json:",inline"`
+	// +k8s:openapi-gen=false
+	metav1.ObjectMeta `json:"metadata"`
+
+	// Spec defines the sources, the target and the config of the task.
+	Spec DMTaskSpec `json:"spec"`
+
+	// Status is the most recently observed status of the task.
+	//
+	// +k8s:openapi-gen=false
+	Status DMTaskStatus `json:"status,omitempty"`
+}
+
+// DMTaskList is a DMTask list.
+//
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type DMTaskList struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// +k8s:openapi-gen=false
+	metav1.ListMeta `json:"metadata"`
+
+	Items []DMTask `json:"items"`
+}
+
+// DMTaskMode is the migration mode of a DMTask.
+type DMTaskMode string
+
+const (
+	// DMTaskModeAll migrates the full data and then replicates the incremental changes.
+	DMTaskModeAll DMTaskMode = "all"
+	// DMTaskModeFull only migrates the full data.
+	DMTaskModeFull DMTaskMode = "full"
+	// DMTaskModeIncremental only replicates the incremental changes.
+	DMTaskModeIncremental DMTaskMode = "incremental"
+)
+
+// DMTaskSpec is the spec of a DMTask.
+type DMTaskSpec struct {
+	// Cluster is the DMCluster to run the task.
+	Cluster DMClusterRef `json:"cluster"`
+
+	// Sources are the upstream databases of the task, they are created in dm-master if they don't exist.
+	// +kubebuilder:validation:MinItems=1
+	Sources []DMTaskSource `json:"sources"`
+
+	// Target is the downstream database of the task.
+	Target DMTaskTarget `json:"target"`
+
+	// TaskMode is the migration mode of the task, defaults to all.
+	// +kubebuilder:validation:Enum=all;full;incremental
+	// +optional
+	TaskMode DMTaskMode `json:"taskMode,omitempty"`
+
+	// ShardMode is the mode to coordinate the DDLs of the sharded tables, the tables are not
+	// merged if it's empty.
+	// +kubebuilder:validation:Enum="";pessimistic;optimistic
+	// +optional
+	ShardMode string `json:"shardMode,omitempty"`
+
+	// OnDuplicate is the behavior when the data to import conflicts with the existing data
+	// in the full migration, defaults to overwrite.
+	// +kubebuilder:validation:Enum=overwrite;error
+	// +optional
+	OnDuplicate string `json:"onDuplicate,omitempty"`
+
+	// MetaSchema is the schema in the target database to store the checkpoints of the task,
+	// defaults to dm_meta.
+	// +optional
+	MetaSchema string `json:"metaSchema,omitempty"`
+
+	// TableMigrateRules select the tables to migrate and route them to the target tables,
+	// all the tables of the sources are migrated if it's empty.
+	// +optional
+	TableMigrateRules []DMTableMigrateRule `json:"tableMigrateRules,omitempty"`
+
+	// Config is the other items of the task in the format of the OpenAPI of dm-master, e.g.
+	// `binlog_filter_rule` and `source_config`. The items defined by the fields above take precedence.
+	// +optional
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:XPreserveUnknownFields
+	Config *config.GenericConfig `json:"config,omitempty"`
+
+	// Paused stops the task until it's set to false.
+	// +optional
+	Paused bool `json:"paused,omitempty"`
+}
+
+// DMClusterRef references a DMCluster.
+type DMClusterRef struct {
+	// Name of the DMCluster.
+	Name string `json:"name"`
+
+	// Namespace of the DMCluster, defaults to the namespace of the DMTask.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// DMTaskSource is an upstream database of a DMTask.
+type DMTaskSource struct {
+	// Name of the source in dm-master, the sources are shared by the tasks of the same DMCluster.
+	Name string `json:"name"`
+
+	// Host is the address of the upstream database.
+	Host string `json:"host"`
+
+	// Port is the port of the upstream database, defaults to 3306.
+	// +optional
+	Port int32 `json:"port,omitempty"`
+
+	// User is the user to connect to the upstream database.
+	User string `json:"user"`
+
+	// SecretName is the name of the secret which stores the password of the user with the key `password`,
+	// the secret is in the namespace of the DMTask.
+	// +optional
+	SecretName string `json:"secretName,omitempty"`
+
+	// EnableGTID replicates the incremental changes by GTID instead of the binlog position.
+	// +optional
+	EnableGTID bool `json:"enableGTID,omitempty"`
+
+	// BinlogName is the binlog file to start the incremental replication from,
+	// it only takes effect on the creation of the task in the incremental mode.
+	// +optional
+	BinlogName string `json:"binlogName,omitempty"`
+
+	// BinlogPos is the position in BinlogName to start the incremental replication from.
+	// +optional
+	BinlogPos *int64 `json:"binlogPos,omitempty"`
+
+	// BinlogGTID is the GTID set to start the incremental replication from if EnableGTID is true.
+	// +optional
+	BinlogGTID string `json:"binlogGTID,omitempty"`
+}
+
+// DMTaskTarget is the downstream database of a DMTask.
+type DMTaskTarget struct {
+	// Host is the address of the downstream database.
+	Host string `json:"host"`
+
+	// Port is the port of the downstream database, defaults to 4000.
+	// +optional
+	Port int32 `json:"port,omitempty"`
+
+	// User is the user to connect to the downstream database.
+	User string `json:"user"`
+
+	// SecretName is the name of the secret which stores the password of the user with the key `password`,
+	// the secret is in the namespace of the DMTask.
+	// +optional
+	SecretName string `json:"secretName,omitempty"`
+}
+
+// DMTableMigrateRule selects the upstream tables to migrate and routes them to the target tables.
+type DMTableMigrateRule struct {
+	// Source selects the upstream tables, the wildcards `*` and `?` are supported in the schema and the table.
+	Source DMTableSelector `json:"source"`
+
+	// Target routes the selected tables, they are migrated to the tables of the same names if it's nil.
+	// +optional
+	Target *DMTableTarget `json:"target,omitempty"`
+
+	// BinlogFilterRules are the names of the binlog filter rules defined in `config.binlog_filter_rule`
+	// to apply to the selected tables.
+	// +optional
+	BinlogFilterRules []string `json:"binlogFilterRules,omitempty"`
+}
+
+// DMTableSelector selects the tables of a source.
+type DMTableSelector struct {
+	// SourceName is the name of the source.
+	SourceName string `json:"sourceName"`
+
+	// Schema is the schema pattern of the tables.
+	Schema string `json:"schema"`
+
+	// Table is the table pattern, all the tables of the schema are selected if it's empty.
+	// +optional
+	Table string `json:"table,omitempty"`
+}
+
+// DMTableTarget is the target table of the selected tables.
+type DMTableTarget struct {
+	// Schema is the target schema.
+	Schema string `json:"schema"`
+
+	// Table is the target table, the tables are migrated to the tables of the same names if it's empty.
+	// +optional
+	Table string `json:"table,omitempty"`
+}
+
+// DMTaskStage is the stage of a DMTask.
+type DMTaskStage string
+
+const (
+	// DMTaskStagePending means the task isn't submitted to dm-master yet.
+	DMTaskStagePending DMTaskStage = "Pending"
+	// DMTaskStageRunning means all the subtasks are running.
+	DMTaskStageRunning DMTaskStage = "Running"
+	// DMTaskStagePaused means a subtask is paused, usually by an error.
+	DMTaskStagePaused DMTaskStage = "Paused"
+	// DMTaskStageStopped means the task is stopped.
+	DMTaskStageStopped DMTaskStage = "Stopped"
+	// DMTaskStageFinished means all the subtasks are finished, only for the full mode.
+	DMTaskStageFinished DMTaskStage = "Finished"
+	// DMTaskStageFailed means the task can't be submitted to dm-master.
+	DMTaskStageFailed DMTaskStage = "Failed"
+)
+
+// DMTaskStatus is the status of a DMTask.
+type DMTaskStatus struct {
+	// Stage of the task, it's the most severe stage of the subtasks.
+	// +optional
+	Stage DMTaskStage `json:"stage,omitempty"`
+
+	// Message is the last error of submitting the task or of the subtasks.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// ObservedGeneration is the generation of the DMTask submitted to dm-master last time.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// ConfigHash is the hash of the task config submitted to dm-master last time.
+	// +optional
+	ConfigHash string `json:"configHash,omitempty"`
+
+	// Sources are the hashes of the configs of the sources submitted to dm-master, indexed by the name.
+	// +optional
+	Sources map[string]string `json:"sources,omitempty"`
+
+	// SubTasks are the status of the subtasks on the sources reported by dm-master.
+	// +optional
+	SubTasks []DMSubTaskStatus `json:"subTasks,omitempty"`
+}
+
+// DMSubTaskStatus is the status of a subtask of a DMTask on a source.
+type DMSubTaskStatus struct {
+	// SourceName is the name of the source.
+	SourceName string `json:"sourceName"`
+
+	// WorkerName is the name of the dm-worker running the subtask.
+	// +optional
+	WorkerName string `json:"workerName,omitempty"`
+
+	// Stage of the subtask, e.g. Running, Paused, Stopped or Finished.
+	// +optional
+	Stage string `json:"stage,omitempty"`
+
+	// Unit is the running processing unit of the subtask, e.g. Dump, Load or Sync.
+	// +optional
+	Unit string `json:"unit,omitempty"`
+
+	// UnresolvedDDLLockID is the ID of the DDL lock waiting to be resolved in the shard mode.
+	// +optional
+	UnresolvedDDLLockID string `json:"unresolvedDDLLockID,omitempty"`
+
+	// ErrorMessage is the error of the subtask.
+	// +optional
+	ErrorMessage string `json:"errorMessage,omitempty"`
+}
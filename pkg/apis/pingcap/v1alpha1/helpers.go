@@ -16,7 +16,9 @@ package v1alpha1
 import (
 	"fmt"
 	"hash/fnv"
+	"strings"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/rand"
 	"k8s.io/apimachinery/pkg/util/sets"
 )
@@ -105,3 +107,61 @@ func GetStorageVolumeName(storageVolumeName string, memberType MemberType) Stora
 func GetStorageVolumeNameForTiFlash(index int) StorageVolumeName {
 	return StorageVolumeName(fmt.Sprintf("data%d", index))
 }
+
+// EnvVars returns the proxy environment variables in both upper and lower case, as the tools
+// running in the containers may read either of them. It returns nil if p is nil.
+func (p *HTTPProxyConfig) EnvVars() []corev1.EnvVar {
+	if p == nil {
+		return nil
+	}
+	var envs []corev1.EnvVar
+	add := func(name, value string) {
+		if value == "" {
+			return
+		}
+		envs = append(envs, corev1.EnvVar{Name: name, Value: value}, corev1.EnvVar{Name: strings.ToLower(name), Value: value})
+	}
+	add("HTTP_PROXY", p.HTTPProxy)
+	add("HTTPS_PROXY", p.HTTPSProxy)
+	add("NO_PROXY", p.NoProxy)
+	return envs
+}
+
+// additionalArgsReservedFlags are the flags set by the start scripts of the components,
+// they can not be set by the additional args of the components.
+var additionalArgsReservedFlags = map[MemberType][]string{
+	PDMemberType:       {"data-dir", "name", "peer-urls", "advertise-peer-urls", "client-urls", "advertise-client-urls", "config", "join", "initial-cluster"},
+	TiKVMemberType:     {"pd", "advertise-addr", "addr", "status-addr", "data-dir", "capacity", "config", "labels", "advertise-status-addr"},
+	TiDBMemberType:     {"store", "advertise-address", "host", "path", "config", "log-slow-query", "enable-binlog", "plugin-dir", "plugin-load"},
+	TiFlashMemberType:  {"config-file"},
+	TiCDCMemberType:    {"addr", "advertise-addr", "gc-ttl", "log-file", "log-level", "pd", "ca", "cert", "key", "config"},
+	TiProxyMemberType:  {"config"},
+	PumpMemberType:     {"pd-urls", "L", "log-file", "advertise-addr", "data-dir", "config"},
+	DMMasterMemberType: {"data-dir", "name", "peer-urls", "advertise-peer-urls", "master-addr", "advertise-addr", "config", "join", "initial-cluster"},
+	DMWorkerMemberType: {"name", "join", "advertise-addr", "worker-addr", "config", "labels"},
+}
+
+// AdditionalArgsReservedFlags returns the flags set by the start script of the component
+func AdditionalArgsReservedFlags(memberType MemberType) []string {
+	return additionalArgsReservedFlags[memberType]
+}
+
+// AdditionalArgFlagName returns the name of the flag in the arg without leading dashes, e.g. "config" for "--config=/etc/tikv.toml"
+func AdditionalArgFlagName(arg string) string {
+	return strings.TrimLeft(strings.SplitN(arg, "=", 2)[0], "-")
+}
+
+// AdditionalArgsConflict returns the first arg in args that sets a flag set by the start script of the component,
+// or any of the generated args, and the name of the flag. It returns "" if there is no conflict.
+func AdditionalArgsConflict(memberType MemberType, args []string, generatedArgs ...string) (string, string) {
+	generated := sets.NewString(AdditionalArgsReservedFlags(memberType)...)
+	for _, arg := range generatedArgs {
+		generated.Insert(AdditionalArgFlagName(arg))
+	}
+	for _, arg := range args {
+		if name := AdditionalArgFlagName(arg); generated.Has(name) {
+			return arg, name
+		}
+	}
+	return "", ""
+}
@@ -68,10 +68,20 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&TidbClusterAutoScalerList{},
 		&DMCluster{},
 		&DMClusterList{},
+		&DMTask{},
+		&DMTaskList{},
 		&TidbNGMonitoring{},
 		&TidbNGMonitoringList{},
 		&TidbDashboard{},
 		&TidbDashboardList{},
+		&TidbClusterFleet{},
+		&TidbClusterFleetList{},
+		&TidbClusterSecretGrant{},
+		&TidbClusterSecretGrantList{},
+		&TidbClusterTemplate{},
+		&TidbClusterTemplateList{},
+		&TidbClusterClaim{},
+		&TidbClusterClaimList{},
 	)
 
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
@@ -29,6 +29,50 @@ func (rs *Restore) GetRestoreJobName() string {
 	return fmt.Sprintf("restore-%s", rs.GetName())
 }
 
+// GetTableFilters returns all the table filter expressions of the restore,
+// including the ones converted from the structured rules in Spec.TableFilters
+func (rs *Restore) GetTableFilters() []string {
+	var filters []string
+	filters = append(filters, rs.Spec.TableFilter...)
+	for _, rule := range rs.Spec.TableFilters {
+		filters = append(filters, rule.String())
+	}
+	return filters
+}
+
+// String returns the table filter expression of the rule
+func (r TableFilterRule) String() string {
+	table := r.Table
+	if table == "" {
+		table = "*"
+	}
+	expr := fmt.Sprintf("%s.%s", r.Database, table)
+	if r.Exclude {
+		expr = "!" + expr
+	}
+	return expr
+}
+
+// defaultRestoreCheckpointMaxAttempts is the default max number of the attempts of the restore resumable from the checkpoint
+const defaultRestoreCheckpointMaxAttempts = 3
+
+// IsCheckpointEnabled returns whether the restore is resumable from the checkpoint of BR
+func (rs *Restore) IsCheckpointEnabled() bool {
+	return rs.Spec.Checkpoint != nil && rs.Spec.BR != nil && rs.Spec.Mode != RestoreModeVolumeSnapshot
+}
+
+// GetMaxAttempts returns the max number of the attempts of the restore, which is 1 if the restore
+// is not resumable from the checkpoint
+func (rs *Restore) GetMaxAttempts() int32 {
+	if !rs.IsCheckpointEnabled() {
+		return 1
+	}
+	if rs.Spec.Checkpoint.MaxAttempts == nil {
+		return defaultRestoreCheckpointMaxAttempts
+	}
+	return *rs.Spec.Checkpoint.MaxAttempts
+}
+
 // GetInstanceName return the restore instance name
 func (rs *Restore) GetInstanceName() string {
 	if rs.Labels != nil {
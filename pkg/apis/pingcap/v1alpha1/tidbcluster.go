@@ -16,12 +16,15 @@ package v1alpha1
 import (
 	"encoding/json"
 	"fmt"
+	"regexp"
 	"strings"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog/v2"
 
@@ -43,6 +46,26 @@ const (
 	// defaultTiCDCGracefulShutdownTimeout is the timeout limit of graceful
 	// shutdown a TiCDC pod.
 	defaultTiCDCGracefulShutdownTimeout = 10 * time.Minute
+	// defaultPreStopHookTimeout is the timeout limit of the preStop hook of PD and TiDB pods
+	defaultPreStopHookTimeout = 30 * time.Second
+	// defaultTiKVPreStopHookTimeout is the timeout limit of the preStop hook of TiKV pods
+	defaultTiKVPreStopHookTimeout = 5 * time.Minute
+	// defaultTiDBPluginSourcePath is the default directory of plugin binaries in the plugin image
+	defaultTiDBPluginSourcePath = "/plugins"
+	// defaultTiDBUpgradeMaxUnavailable is the default max number of the TiDB pods upgraded at once in parallel
+	defaultTiDBUpgradeMaxUnavailable = "25%"
+	// defaultCanaryUpgradeSoakDuration is the default soak period of each step of the canary upgrade
+	defaultCanaryUpgradeSoakDuration = 10 * time.Minute
+	// defaultLoadAwareRestartMaxDelay is the default max time the restart of a pod is delayed for its load
+	defaultLoadAwareRestartMaxDelay = time.Hour
+	// defaultTeardownMaxConcurrentPVCDeletions is the default max number of the PVCs being deleted at once during the teardown
+	defaultTeardownMaxConcurrentPVCDeletions = 10
+	// defaultScaleInApprovalWebhookTimeout is the default timeout of calling the scale-in approval webhook
+	defaultScaleInApprovalWebhookTimeout = 10 * time.Second
+	// defaultVaultPKIPath is the default mount path of the PKI secrets engine of Vault
+	defaultVaultPKIPath = "pki"
+	// defaultVaultKubernetesAuthPath is the default mount path of the Kubernetes auth method of Vault
+	defaultVaultKubernetesAuthPath = "kubernetes"
 
 	// the latest version
 	versionLatest = "latest"
@@ -148,6 +171,22 @@ func (tc *TidbCluster) TiKVEvictLeaderTimeout() time.Duration {
 	return defaultEvictLeaderTimeout
 }
 
+// TiKVEvictLeaderTimeoutPolicy returns what to do when the leader eviction before the upgrade times out
+func (tc *TidbCluster) TiKVEvictLeaderTimeoutPolicy() EvictLeaderTimeoutPolicy {
+	if tc.Spec.TiKV != nil && tc.Spec.TiKV.EvictLeaderTimeoutPolicy != "" {
+		return tc.Spec.TiKV.EvictLeaderTimeoutPolicy
+	}
+	return EvictLeaderTimeoutPolicyContinue
+}
+
+// PDConfigDriftPolicy returns how the drift of the online config of PD is handled
+func (tc *TidbCluster) PDConfigDriftPolicy() PDConfigDriftPolicy {
+	if tc.Spec.PD != nil && tc.Spec.PD.ConfigDriftPolicy != "" {
+		return tc.Spec.PD.ConfigDriftPolicy
+	}
+	return PDConfigDriftPolicyIgnore
+}
+
 func (tc *TidbCluster) TiKVWaitLeaderTransferBackTimeout() time.Duration {
 	if tc.Spec.TiKV != nil && tc.Spec.TiKV.WaitLeaderTransferBackTimeout != nil {
 		return tc.Spec.TiKV.WaitLeaderTransferBackTimeout.Duration
@@ -155,6 +194,43 @@ func (tc *TidbCluster) TiKVWaitLeaderTransferBackTimeout() time.Duration {
 	return defaultWaitLeaderTransferBackTimeout
 }
 
+// TiKVMaxUpgradeConcurrency returns the max number of the TiKV pods upgraded at once.
+func (tc *TidbCluster) TiKVMaxUpgradeConcurrency() int {
+	if tc.Spec.TiKV != nil && tc.Spec.TiKV.MaxUpgradeConcurrency != nil && *tc.Spec.TiKV.MaxUpgradeConcurrency > 1 {
+		return int(*tc.Spec.TiKV.MaxUpgradeConcurrency)
+	}
+	return 1
+}
+
+// TiKVPreStopHookTimeout returns the timeout of the preStop hook of TiKV pods,
+// which is never longer than the timeout to evict leader.
+func (tc *TidbCluster) TiKVPreStopHookTimeout() time.Duration {
+	timeout := defaultTiKVPreStopHookTimeout
+	if tc.Spec.TiKV != nil && tc.Spec.TiKV.PreStopHook != nil && tc.Spec.TiKV.PreStopHook.Timeout != nil {
+		timeout = tc.Spec.TiKV.PreStopHook.Timeout.Duration
+	}
+	if evictLeaderTimeout := tc.TiKVEvictLeaderTimeout(); timeout > evictLeaderTimeout {
+		return evictLeaderTimeout
+	}
+	return timeout
+}
+
+// PDPreStopHookTimeout returns the timeout of the preStop hook of PD pods.
+func (tc *TidbCluster) PDPreStopHookTimeout() time.Duration {
+	if tc.Spec.PD != nil && tc.Spec.PD.PreStopHook != nil && tc.Spec.PD.PreStopHook.Timeout != nil {
+		return tc.Spec.PD.PreStopHook.Timeout.Duration
+	}
+	return defaultPreStopHookTimeout
+}
+
+// TiDBPreStopHookTimeout returns the timeout of the preStop hook of TiDB pods.
+func (tc *TidbCluster) TiDBPreStopHookTimeout() time.Duration {
+	if tc.Spec.TiDB != nil && tc.Spec.TiDB.PreStopHook != nil && tc.Spec.TiDB.PreStopHook.Timeout != nil {
+		return tc.Spec.TiDB.PreStopHook.Timeout.Duration
+	}
+	return defaultPreStopHookTimeout
+}
+
 // TiFlashImage return the image used by TiFlash.
 //
 // If TiFlash isn't specified, return empty string.
@@ -466,7 +542,7 @@ func (tc *TidbCluster) ComponentIsSuspended(typ MemberType) bool {
 }
 
 func (tc *TidbCluster) getDeleteSlots(component string) (deleteSlots sets.Int32) {
-	deleteSlots = sets.NewInt32()
+	deleteSlots = sets.NewInt32(tc.OfflineOrdinals(MemberType(component))...)
 	annotations := tc.GetAnnotations()
 	if annotations == nil {
 		return deleteSlots
@@ -500,6 +576,18 @@ func (tc *TidbCluster) getDeleteSlots(component string) (deleteSlots sets.Int32)
 	return
 }
 
+// OfflineOrdinals returns the ordinals of the pods of the component removed when scaling in, which are
+// merged into the delete slots
+func (tc *TidbCluster) OfflineOrdinals(typ MemberType) []int32 {
+	switch {
+	case typ == TiKVMemberType && tc.Spec.TiKV != nil:
+		return tc.Spec.TiKV.OfflineOrdinals
+	case typ == TiDBMemberType && tc.Spec.TiDB != nil:
+		return tc.Spec.TiDB.OfflineOrdinals
+	}
+	return nil
+}
+
 // PDAllPodsStarted return whether all pods of PD are started.
 //
 // If PD isn't specified, return false.
@@ -554,7 +642,7 @@ func (tc *TidbCluster) GetPDDeletedFailureReplicas() int32 {
 }
 
 func (tc *TidbCluster) PDStsDesiredReplicas() int32 {
-	if tc.Spec.PD == nil {
+	if tc.Spec.PD == nil || tc.IsStandby() {
 		return 0
 	}
 	return tc.Spec.PD.Replicas + tc.GetPDDeletedFailureReplicas()
@@ -611,10 +699,15 @@ func (tc *TidbCluster) TiKVAllStoresReady() bool {
 }
 
 func (tc *TidbCluster) TiKVStsDesiredReplicas() int32 {
-	if tc.Spec.TiKV == nil {
+	if tc.Spec.TiKV == nil || tc.IsStandby() {
 		return 0
 	}
-	return tc.Spec.TiKV.Replicas + int32(len(tc.Status.TiKV.FailureStores))
+	replicas := tc.Spec.TiKV.Replicas + int32(len(tc.Status.TiKV.FailureStores))
+	// the spare TiKV holding the data of the stores whose volumes are being replaced
+	if tc.Status.TiKV.VolReplaceInProgress {
+		replicas++
+	}
+	return replicas
 }
 
 func (tc *TidbCluster) TiKVStsActualReplicas() int32 {
@@ -668,7 +761,7 @@ func (tc *TidbCluster) TiFlashAllStoresReady() bool {
 }
 
 func (tc *TidbCluster) TiFlashStsDesiredReplicas() int32 {
-	if tc.Spec.TiFlash == nil {
+	if tc.Spec.TiFlash == nil || tc.IsStandby() {
 		return 0
 	}
 	return tc.Spec.TiFlash.Replicas + int32(len(tc.Status.TiFlash.FailureStores))
@@ -787,8 +880,95 @@ func (tc *TidbCluster) TiDBAllMembersReady() bool {
 	return true
 }
 
+// IsQuotaExceeded returns whether the pods of any component are rejected by the ResourceQuota or LimitRange
+func (tc *TidbCluster) IsQuotaExceeded() bool {
+	for _, cond := range tc.Status.Conditions {
+		if cond.Type == TidbClusterQuotaExceeded {
+			return cond.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// TiDBUpgradeMaxUnavailable returns the max number of the TiDB pods upgraded at once,
+// it's always 1 unless spec.tidb.upgradePolicy is Parallel.
+func (tc *TidbCluster) TiDBUpgradeMaxUnavailable() int {
+	if tc.Spec.TiDB == nil || tc.Spec.TiDB.UpgradePolicy != TiDBUpgradePolicyParallel {
+		return 1
+	}
+	maxUnavailable := intstr.FromString(defaultTiDBUpgradeMaxUnavailable)
+	if tc.Spec.TiDB.MaxUnavailable != nil {
+		maxUnavailable = *tc.Spec.TiDB.MaxUnavailable
+	}
+	n, err := intstr.GetScaledValueFromIntOrPercent(&maxUnavailable, int(tc.Spec.TiDB.Replicas), true)
+	if err != nil {
+		klog.Warningf("tidbcluster %s/%s has invalid spec.tidb.maxUnavailable %s: %v", tc.Namespace, tc.Name, maxUnavailable.String(), err)
+		return 1
+	}
+	if n < 1 {
+		return 1
+	}
+	return n
+}
+
+// TiDBCanaryUpgrade returns the canary upgrade of TiDB, it's nil if the pods are upgraded in parallel.
+func (tc *TidbCluster) TiDBCanaryUpgrade() *CanaryUpgrade {
+	if tc.Spec.TiDB == nil || tc.TiDBUpgradeMaxUnavailable() > 1 {
+		return nil
+	}
+	return tc.Spec.TiDB.CanaryUpgrade
+}
+
+// TiDBLoadAwareRestart returns the load-aware restart of TiDB, it's nil if the pods are upgraded in parallel.
+func (tc *TidbCluster) TiDBLoadAwareRestart() *LoadAwareRestart {
+	if tc.Spec.TiDB == nil || tc.TiDBUpgradeMaxUnavailable() > 1 {
+		return nil
+	}
+	return tc.Spec.TiDB.LoadAwareRestart
+}
+
+// TiKVCanaryUpgrade returns the canary upgrade of TiKV, it's nil if the pods are upgraded in batches.
+func (tc *TidbCluster) TiKVCanaryUpgrade() *CanaryUpgrade {
+	if tc.Spec.TiKV == nil || tc.TiKVMaxUpgradeConcurrency() > 1 {
+		return nil
+	}
+	return tc.Spec.TiKV.CanaryUpgrade
+}
+
+// GetStep returns the number of the pods upgraded before each soak period
+func (c *CanaryUpgrade) GetStep() int32 {
+	if c.Step == nil || *c.Step < 1 {
+		return 1
+	}
+	return *c.Step
+}
+
+// GetSoakDuration returns the soak period of each step
+func (c *CanaryUpgrade) GetSoakDuration() time.Duration {
+	if c.SoakDuration == nil {
+		return defaultCanaryUpgradeSoakDuration
+	}
+	return c.SoakDuration.Duration
+}
+
+// GetMaxRestarts returns the max number of the container restarts of an upgraded pod
+func (c *CanaryUpgrade) GetMaxRestarts() int32 {
+	if c.MaxRestarts == nil {
+		return 0
+	}
+	return *c.MaxRestarts
+}
+
+// GetMaxDelay returns the max time the restart of a pod is delayed for its load
+func (r *LoadAwareRestart) GetMaxDelay() time.Duration {
+	if r.MaxDelay == nil {
+		return defaultLoadAwareRestartMaxDelay
+	}
+	return r.MaxDelay.Duration
+}
+
 func (tc *TidbCluster) TiDBStsDesiredReplicas() int32 {
-	if tc.Spec.TiDB == nil {
+	if tc.Spec.TiDB == nil || tc.IsStandby() {
 		return 0
 	}
 	return tc.Spec.TiDB.Replicas + int32(len(tc.Status.TiDB.FailureMembers))
@@ -935,10 +1115,41 @@ func (tc *TidbCluster) IsTLSClusterEnabled() bool {
 	return tc.Spec.TLSCluster != nil && tc.Spec.TLSCluster.Enabled
 }
 
+// VaultPKI returns the Vault PKI secrets engine to issue the certificates of the cluster,
+// it returns nil if the TLS is not enabled or the certificates are not issued from Vault
+func (tc *TidbCluster) VaultPKI() *VaultPKI {
+	if !tc.IsTLSClusterEnabled() {
+		return nil
+	}
+	return tc.Spec.TLSCluster.Vault
+}
+
+// GetPath returns the mount path of the PKI secrets engine
+func (v *VaultPKI) GetPath() string {
+	if v.Path == "" {
+		return defaultVaultPKIPath
+	}
+	return v.Path
+}
+
+// GetPath returns the mount path of the Kubernetes auth method
+func (a *VaultKubernetesAuth) GetPath() string {
+	if a.Path == "" {
+		return defaultVaultKubernetesAuthPath
+	}
+	return a.Path
+}
+
 func (tc *TidbCluster) IsRecoveryMode() bool {
 	return tc.Spec.RecoveryMode
 }
 
+// IsStandby returns whether the TidbCluster is a warm standby, whose PD, TiKV,
+// TiFlash and TiDB are reconciled with zero replicas.
+func (tc *TidbCluster) IsStandby() bool {
+	return tc.Spec.Standby != nil
+}
+
 func (tc *TidbCluster) NeedToSyncTiDBInitializer() bool {
 	return tc.Spec.TiDB != nil && tc.Spec.TiDB.Initializer != nil && tc.Spec.TiDB.Initializer.CreatePassword && tc.Status.TiDB.PasswordInitialized == nil
 }
@@ -958,6 +1169,42 @@ func (tc *TidbCluster) Timezone() string {
 	return tz
 }
 
+// ZoneTopologyKey returns the node label of the zones declared in spec.topologyZones
+func (tc *TidbCluster) ZoneTopologyKey() string {
+	if tc.Spec.TopologyZones == nil || tc.Spec.TopologyZones.TopologyKey == "" {
+		return corev1.LabelTopologyZone
+	}
+	return tc.Spec.TopologyZones.TopologyKey
+}
+
+// ZoneQuotas returns the replicas of the component in each zone, it returns nil if they're not declared
+func (tc *TidbCluster) ZoneQuotas(typ MemberType) map[string]int32 {
+	zones := tc.Spec.TopologyZones
+	if zones == nil {
+		return nil
+	}
+	switch typ {
+	case PDMemberType:
+		return zones.PD
+	case TiKVMemberType:
+		return zones.TiKV
+	case TiDBMemberType:
+		return zones.TiDB
+	case TiFlashMemberType:
+		return zones.TiFlash
+	}
+	return nil
+}
+
+// ZoneQuotasReplicas returns the sum of the replicas of the component in all zones
+func ZoneQuotasReplicas(quotas map[string]int32) int32 {
+	var replicas int32
+	for _, r := range quotas {
+		replicas += r
+	}
+	return replicas
+}
+
 func (tc *TidbCluster) IsPVReclaimEnabled() bool {
 	enabled := tc.Spec.EnablePVReclaim
 	if enabled == nil {
@@ -966,6 +1213,72 @@ func (tc *TidbCluster) IsPVReclaimEnabled() bool {
 	return *enabled
 }
 
+// componentPVCPolicy returns the PV reclaim policy and the scale-in PVC retention of the component,
+// they're nil if they're not set for the component
+func (tc *TidbCluster) componentPVCPolicy(typ MemberType) (*corev1.PersistentVolumeReclaimPolicy, *ScaleInPVCRetentionPolicy, *metav1.Duration) {
+	switch {
+	case typ == PDMemberType && tc.Spec.PD != nil:
+		return tc.Spec.PD.PVReclaimPolicy, tc.Spec.PD.ScaleInPVCRetentionPolicy, tc.Spec.PD.ScaleInPVCDeletionGracePeriod
+	case typ == TiKVMemberType && tc.Spec.TiKV != nil:
+		return tc.Spec.TiKV.PVReclaimPolicy, tc.Spec.TiKV.ScaleInPVCRetentionPolicy, tc.Spec.TiKV.ScaleInPVCDeletionGracePeriod
+	case typ == TiFlashMemberType && tc.Spec.TiFlash != nil:
+		return tc.Spec.TiFlash.PVReclaimPolicy, tc.Spec.TiFlash.ScaleInPVCRetentionPolicy, tc.Spec.TiFlash.ScaleInPVCDeletionGracePeriod
+	case typ == TiDBMemberType && tc.Spec.TiDB != nil:
+		return tc.Spec.TiDB.PVReclaimPolicy, tc.Spec.TiDB.ScaleInPVCRetentionPolicy, tc.Spec.TiDB.ScaleInPVCDeletionGracePeriod
+	case typ == TiCDCMemberType && tc.Spec.TiCDC != nil:
+		return tc.Spec.TiCDC.PVReclaimPolicy, tc.Spec.TiCDC.ScaleInPVCRetentionPolicy, tc.Spec.TiCDC.ScaleInPVCDeletionGracePeriod
+	case typ == PumpMemberType && tc.Spec.Pump != nil:
+		return tc.Spec.Pump.PVReclaimPolicy, tc.Spec.Pump.ScaleInPVCRetentionPolicy, tc.Spec.Pump.ScaleInPVCDeletionGracePeriod
+	}
+	return nil, nil, nil
+}
+
+// PVReclaimPolicy returns the reclaim policy of the PVs of the component
+func (tc *TidbCluster) PVReclaimPolicy(typ MemberType) corev1.PersistentVolumeReclaimPolicy {
+	if policy, _, _ := tc.componentPVCPolicy(typ); policy != nil {
+		return *policy
+	}
+	if tc.Spec.PVReclaimPolicy != nil {
+		return *tc.Spec.PVReclaimPolicy
+	}
+	return corev1.PersistentVolumeReclaimRetain
+}
+
+// ScaleInPVCRetentionPolicy returns the retention policy of the PVCs left by the scale-in of the component,
+// it's nil if it's set neither for the component nor for the cluster
+func (tc *TidbCluster) ScaleInPVCRetentionPolicy(typ MemberType) *ScaleInPVCRetentionPolicy {
+	if _, policy, _ := tc.componentPVCPolicy(typ); policy != nil {
+		return policy
+	}
+	return tc.Spec.ScaleInPVCRetentionPolicy
+}
+
+// IsScaleInPVCDeleted returns whether the PVCs left by the scale-in of the component are deleted, they're
+// deleted for PD, TiKV and TiFlash if the PV reclaim is enabled and the retention policy isn't set
+func (tc *TidbCluster) IsScaleInPVCDeleted(typ MemberType) bool {
+	switch typ {
+	case PDMemberType, TiKVMemberType, TiFlashMemberType, TiDBMemberType, TiCDCMemberType, PumpMemberType:
+	default:
+		return false
+	}
+	if policy := tc.ScaleInPVCRetentionPolicy(typ); policy != nil {
+		return *policy == ScaleInPVCDelete
+	}
+	return tc.IsPVReclaimEnabled() && (typ == PDMemberType || typ == TiKVMemberType || typ == TiFlashMemberType)
+}
+
+// ScaleInPVCDeletionGracePeriod returns how long the PVCs left by the scale-in of the component are kept
+// before they're deleted
+func (tc *TidbCluster) ScaleInPVCDeletionGracePeriod(typ MemberType) time.Duration {
+	if _, _, period := tc.componentPVCPolicy(typ); period != nil {
+		return period.Duration
+	}
+	if tc.Spec.ScaleInPVCDeletionGracePeriod != nil {
+		return tc.Spec.ScaleInPVCDeletionGracePeriod.Duration
+	}
+	return 0
+}
+
 func (tc *TidbCluster) IsTiDBBinlogEnabled() bool {
 	var binlogEnabled *bool
 	if tc.Spec.TiDB != nil {
@@ -1006,6 +1319,29 @@ func (tidb *TiDBSpec) GetSlowLogTailerSpec() TiDBSlowLogTailerSpec {
 	return *tidb.SlowLogTailer
 }
 
+// IsPluginSourceEnabled returns whether the plugin binaries are provided by an image or a PVC
+func (tidb *TiDBSpec) IsPluginSourceEnabled() bool {
+	return len(tidb.Plugins) > 0 && tidb.PluginSource != nil
+}
+
+// GetPath returns the directory of the plugin binaries in the plugin image
+func (s *TiDBPluginSource) GetPath() string {
+	if s.Path == "" {
+		return defaultTiDBPluginSourcePath
+	}
+	return s.Path
+}
+
+// tidbPluginPathPattern matches the absolute paths that are safe to be used in the
+// shell command of the plugin init container
+var tidbPluginPathPattern = regexp.MustCompile(`^/[A-Za-z0-9._/-]*$`)
+
+// IsPathValid returns whether the directory of the plugin binaries is an absolute path
+// that only consists of letters, digits and `._/-`
+func (s *TiDBPluginSource) IsPathValid() bool {
+	return tidbPluginPathPattern.MatchString(s.GetPath())
+}
+
 // GetServicePort returns the service port for tidb
 func (tidb *TiDBSpec) GetServicePort() int32 {
 	port := DefaultTiDBServicePort
@@ -1015,6 +1351,38 @@ func (tidb *TiDBSpec) GetServicePort() int32 {
 	return port
 }
 
+// GetServerPort returns the port of the MySQL protocol listened by tidb
+func (tidb *TiDBSpec) GetServerPort() int32 {
+	if tidb != nil && tidb.Ports != nil && tidb.Ports.Server != nil {
+		return *tidb.Ports.Server
+	}
+	return DefaultTiDBServicePort
+}
+
+// GetStatusPort returns the port of the status API listened by tidb
+func (tidb *TiDBSpec) GetStatusPort() int32 {
+	if tidb != nil && tidb.Ports != nil && tidb.Ports.Status != nil {
+		return *tidb.Ports.Status
+	}
+	return DefaultTiDBStatusPort
+}
+
+// GetServerPort returns the port of the gRPC service listened by tikv
+func (tikv *TiKVSpec) GetServerPort() int32 {
+	if tikv != nil && tikv.Ports != nil && tikv.Ports.Server != nil {
+		return *tikv.Ports.Server
+	}
+	return DefaultTiKVServerPort
+}
+
+// GetStatusPort returns the port of the status API listened by tikv
+func (tikv *TiKVSpec) GetStatusPort() int32 {
+	if tikv != nil && tikv.Ports != nil && tikv.Ports.Status != nil {
+		return *tikv.Ports.Status
+	}
+	return DefaultTiKVStatusPort
+}
+
 func (tikv *TiKVSpec) ShouldSeparateRocksDBLog() bool {
 	separateRocksDBLog := tikv.SeparateRocksDBLog
 	if separateRocksDBLog == nil {
@@ -1045,6 +1413,29 @@ func (tikv *TiKVSpec) GetRecoverByUID() types.UID {
 	return tikv.Failover.RecoverByUID
 }
 
+// GetFailoverPeriod returns how long a store has to be down before the failover, defaultPeriod
+// is returned if it's not set
+func (tikv *TiKVSpec) GetFailoverPeriod(defaultPeriod time.Duration) time.Duration {
+	if tikv.Failover == nil || tikv.Failover.Period == nil {
+		return defaultPeriod
+	}
+	return tikv.Failover.Period.Duration
+}
+
+// AutoRecoverFailover returns whether a failure store is recovered once its original store is up again
+func (tikv *TiKVSpec) AutoRecoverFailover() bool {
+	return tikv.Failover != nil && tikv.Failover.RecoverPolicy == FailoverRecoverPolicyAuto
+}
+
+// GetNodeFailureGracePeriod returns the grace period before force deleting a pod stuck in
+// Terminating on a NotReady node, zero means disabled
+func (tikv *TiKVSpec) GetNodeFailureGracePeriod() time.Duration {
+	if tikv.Failover == nil || tikv.Failover.NodeFailureGracePeriod == nil {
+		return 0
+	}
+	return tikv.Failover.NodeFailureGracePeriod.Duration
+}
+
 func (tikv *TiKVSpec) GetScaleInParallelism() int {
 	if tikv.ScalePolicy.ScaleInParallelism == nil {
 		return 1
@@ -1066,6 +1457,29 @@ func (tiflash *TiFlashSpec) GetRecoverByUID() types.UID {
 	return tiflash.Failover.RecoverByUID
 }
 
+// GetFailoverPeriod returns how long a store has to be down before the failover, defaultPeriod
+// is returned if it's not set
+func (tiflash *TiFlashSpec) GetFailoverPeriod(defaultPeriod time.Duration) time.Duration {
+	if tiflash.Failover == nil || tiflash.Failover.Period == nil {
+		return defaultPeriod
+	}
+	return tiflash.Failover.Period.Duration
+}
+
+// AutoRecoverFailover returns whether a failure store is recovered once its original store is up again
+func (tiflash *TiFlashSpec) AutoRecoverFailover() bool {
+	return tiflash.Failover != nil && tiflash.Failover.RecoverPolicy == FailoverRecoverPolicyAuto
+}
+
+// GetNodeFailureGracePeriod returns the grace period before force deleting a pod stuck in
+// Terminating on a NotReady node, zero means disabled
+func (tiflash *TiFlashSpec) GetNodeFailureGracePeriod() time.Duration {
+	if tiflash.Failover == nil || tiflash.Failover.NodeFailureGracePeriod == nil {
+		return 0
+	}
+	return tiflash.Failover.NodeFailureGracePeriod.Duration
+}
+
 func (tiflash *TiFlashSpec) GetScaleInParallelism() int {
 	if tiflash.ScalePolicy.ScaleInParallelism == nil {
 		return 1
@@ -1126,6 +1540,61 @@ func (tc *TidbCluster) GetInstanceName() string {
 	return tc.Name
 }
 
+// StatefulSetDriftMode returns the drift mode of the StatefulSets, defaults to Ignore
+func (tc *TidbCluster) StatefulSetDriftMode() DriftMode {
+	if tc.Spec.DriftPolicy == nil || tc.Spec.DriftPolicy.StatefulSet == "" {
+		return DriftModeIgnore
+	}
+	return tc.Spec.DriftPolicy.StatefulSet
+}
+
+// TeardownMaxConcurrentPVCDeletions returns the max number of the PVCs being deleted at the same time
+// during the teardown, defaults to 10
+func (tc *TidbCluster) TeardownMaxConcurrentPVCDeletions() int {
+	if tc.Spec.Teardown == nil || tc.Spec.Teardown.MaxConcurrentPVCDeletions == nil {
+		return defaultTeardownMaxConcurrentPVCDeletions
+	}
+	return int(*tc.Spec.Teardown.MaxConcurrentPVCDeletions)
+}
+
+// ScaleInNeedsApproval returns whether the scale-in of the component needs the external approval
+func (tc *TidbCluster) ScaleInNeedsApproval(memberType MemberType) bool {
+	if tc.Spec.ScaleInApproval == nil {
+		return false
+	}
+	if len(tc.Spec.ScaleInApproval.Components) == 0 {
+		return memberType == PDMemberType || memberType == TiKVMemberType
+	}
+	for _, component := range tc.Spec.ScaleInApproval.Components {
+		if component == memberType {
+			return true
+		}
+	}
+	return false
+}
+
+// ScaleInApprovalWebhookTimeout returns the timeout of calling the scale-in approval webhook, defaults to 10s
+func (tc *TidbCluster) ScaleInApprovalWebhookTimeout() time.Duration {
+	if tc.Spec.ScaleInApproval == nil || tc.Spec.ScaleInApproval.Webhook == nil || tc.Spec.ScaleInApproval.Webhook.TimeoutSeconds == nil {
+		return defaultScaleInApprovalWebhookTimeout
+	}
+	return time.Duration(*tc.Spec.ScaleInApproval.Webhook.TimeoutSeconds) * time.Second
+}
+
+// ServiceDriftMode returns the drift mode of the Services, defaults to Ignore
+func (tc *TidbCluster) ServiceDriftMode() DriftMode {
+	if tc.Spec.DriftPolicy == nil || tc.Spec.DriftPolicy.Service == "" {
+		return DriftModeIgnore
+	}
+	return tc.Spec.DriftPolicy.Service
+}
+
+// IsTiProxyTLSOffloadEnabled returns whether TiProxy offloads TLS for the clients, TiDB requires
+// secure transport in this case so that the clients without TLS can only connect through TiProxy.
+func (tc *TidbCluster) IsTiProxyTLSOffloadEnabled() bool {
+	return tc.Spec.TiProxy != nil && tc.Spec.TiProxy.TLSOffload
+}
+
 func (tc *TidbCluster) SkipTLSWhenConnectTiDB() bool {
 	_, ok := tc.Annotations[label.AnnSkipTLSWhenConnectTiDB]
 	return ok
@@ -1223,6 +1692,12 @@ func (tc *TidbCluster) AcrossK8s() bool {
 	return tc.Spec.AcrossK8s
 }
 
+// EmbeddedDiscovery returns whether the discovery logic is rendered into the PD start script
+// instead of running the discovery Deployment.
+func (tc *TidbCluster) EmbeddedDiscovery() bool {
+	return tc.Spec.Discovery.Mode == DiscoveryModeEmbedded
+}
+
 // IsComponentVolumeResizing returns true if any volume of component is resizing.
 func (tc *TidbCluster) IsComponentVolumeResizing(compType MemberType) bool {
 	comp := tc.ComponentStatus(compType)
@@ -510,6 +510,31 @@ func TestComponentAccessor(t *testing.T) {
 				g.Expect(a.SchedulerName()).Should(Equal("override"))
 			},
 		},
+		{
+			name: "dns policy with hostNetwork at component-level",
+			cluster: &TidbClusterSpec{
+				DNSPolicy: corev1.DNSClusterFirst,
+			},
+			component: &ComponentSpec{
+				HostNetwork: pointer.BoolPtr(true),
+			},
+			expectFn: func(g *GomegaWithT, a ComponentAccessor) {
+				g.Expect(a.DnsPolicy()).Should(Equal(corev1.DNSClusterFirstWithHostNet))
+			},
+		},
+		{
+			name: "dns policy overridden at component-level",
+			cluster: &TidbClusterSpec{
+				DNSPolicy: corev1.DNSClusterFirstWithHostNet,
+			},
+			component: &ComponentSpec{
+				HostNetwork: pointer.BoolPtr(true),
+				DNSPolicy:   corev1.DNSDefault,
+			},
+			expectFn: func(g *GomegaWithT, a ComponentAccessor) {
+				g.Expect(a.DnsPolicy()).Should(Equal(corev1.DNSDefault))
+			},
+		},
 		{
 			name: "node selector merge",
 			cluster: &TidbClusterSpec{
@@ -813,6 +838,20 @@ func TestTiCDCGracefulShutdownTimeout(t *testing.T) {
 	g.Expect(tc.TiCDCGracefulShutdownTimeout()).To(Equal(time.Minute))
 }
 
+func TestTiKVPreStopHookTimeout(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbCluster()
+	g.Expect(tc.TiKVPreStopHookTimeout()).To(Equal(defaultTiKVPreStopHookTimeout))
+
+	tc.Spec.TiKV.PreStopHook = &PreStopHookSpec{Timeout: &metav1.Duration{Duration: 10 * time.Minute}}
+	g.Expect(tc.TiKVPreStopHookTimeout()).To(Equal(10 * time.Minute))
+
+	// the timeout is never longer than the timeout to evict leader
+	tc.Spec.TiKV.EvictLeaderTimeout = pointer.StringPtr("3m")
+	g.Expect(tc.TiKVPreStopHookTimeout()).To(Equal(3 * time.Minute))
+}
+
 func TestComponentFunc(t *testing.T) {
 	t.Run("ComponentIsNormal", func(t *testing.T) {
 		g := NewGomegaWithT(t)
@@ -131,6 +131,27 @@ type BasicAutoScalerSpec struct {
 	// The key is resource_type name of the resource
 	// +optional
 	Resources map[string]AutoResource `json:"resources,omitempty"`
+
+	// Schedules defines the scheduled scaling rules, the replicas of the rule whose window is active
+	// are created in a separate auto-scaled TidbCluster, and the metric-based rules can't scale in
+	// the component while any window is active.
+	// +optional
+	Schedules []ScheduledScalingRule `json:"schedules,omitempty"`
+}
+
+// +k8s:openapi-gen=true
+// ScheduledScalingRule describes the replicas of the component in a recurring time window
+type ScheduledScalingRule struct {
+	// Name is the unique name of the rule
+	Name string `json:"name"`
+	// Start is the cron expression in UTC when the window starts, e.g. "0 8 * * 1-5"
+	Start string `json:"start"`
+	// End is the cron expression in UTC when the window ends, e.g. "0 20 * * 1-5"
+	End string `json:"end"`
+	// Replicas is the number of the scheduled instances while the window is active.
+	// If the windows of several rules are active at the same time, the rule with the
+	// largest replicas takes effect.
+	Replicas int32 `json:"replicas"`
 }
 
 // +k8s:openapi-gen=true
@@ -189,6 +210,23 @@ type BasicAutoScalerStatus struct {
 	// LastAutoScalingTimestamp describes the last auto-scaling timestamp for the component(tidb/tikv)
 	// +optional
 	LastAutoScalingTimestamp *metav1.Time `json:"lastAutoScalingTimestamp,omitempty"`
+	// LastAppliedSchedule describes the last scheduled scaling rule applied, only set for the scheduled group
+	// +optional
+	LastAppliedSchedule *ScheduledScalingStatus `json:"lastAppliedSchedule,omitempty"`
+}
+
+// +k8s:openapi-gen=true
+// ScheduledScalingStatus describes the status of the scheduled scaling
+type ScheduledScalingStatus struct {
+	// Name is the name of the last applied rule
+	Name string `json:"name"`
+	// Replicas is the replicas of the last applied rule
+	Replicas int32 `json:"replicas"`
+	// Active indicates whether the window of the rule is active
+	Active bool `json:"active"`
+	// LastTransitionTime is the last time the rule is applied or the window ends
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
 }
 
 // +k8s:openapi-gen=true
@@ -0,0 +1,85 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TidbClusterFleet is a read-only summary of all the TiDB clusters managed by a TiDB Operator.
+// It is updated periodically by the operator in the namespace the operator runs in, and is meant
+// to be consumed by platform dashboards.
+//
+// +genclient
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:resource:shortName="tcf"
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Clusters",type=integer,JSONPath=`.status.clusters`,description="The number of TidbClusters"
+// +kubebuilder:printcolumn:name="Ready",type=integer,JSONPath=`.status.readyClusters`,description="The number of ready TidbClusters"
+// +kubebuilder:printcolumn:name="Upgrading",type=integer,JSONPath=`.status.pendingUpgrades`,description="The number of TidbClusters being upgraded"
+// +kubebuilder:printcolumn:name="FailedBackups",type=integer,JSONPath=`.status.failedBackups`,description="The number of failed Backups"
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+type TidbClusterFleet struct {
+	metav1.TypeMeta `json:",inline"`
+	// +k8s:openapi-gen=false
+	metav1.ObjectMeta `json:"metadata"`
+
+	// Status is the most recently aggregated status of the TiDB clusters.
+	//
+	// +k8s:openapi-gen=false
+	Status TidbClusterFleetStatus `json:"status,omitempty"`
+}
+
+// TidbClusterFleetList is a TidbClusterFleet list.
+//
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type TidbClusterFleetList struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// +k8s:openapi-gen=false
+	metav1.ListMeta `json:"metadata"`
+
+	Items []TidbClusterFleet `json:"items"`
+}
+
+// TidbClusterFleetStatus is the aggregated status of the TiDB clusters managed by a TiDB Operator.
+type TidbClusterFleetStatus struct {
+	// Clusters is the number of TidbClusters.
+	Clusters int32 `json:"clusters"`
+
+	// ReadyClusters is the number of TidbClusters whose Ready condition is true.
+	ReadyClusters int32 `json:"readyClusters"`
+
+	// Phases is the number of TidbClusters in each phase.
+	// The phase of a TidbCluster is the first non Normal phase of its components, or Normal.
+	// +optional
+	Phases map[MemberPhase]int32 `json:"phases,omitempty"`
+
+	// Versions is the number of TidbClusters running each version, which is the tag of the images of the
+	// components in the status. A TidbCluster whose components run different versions is counted for each of them.
+	// +optional
+	Versions map[string]int32 `json:"versions,omitempty"`
+
+	// PendingUpgrades is the number of TidbClusters that are being upgraded.
+	PendingUpgrades int32 `json:"pendingUpgrades"`
+
+	// FailedBackups is the number of Backups that are failed.
+	FailedBackups int32 `json:"failedBackups"`
+
+	// LastUpdateTime is the last time the status was aggregated.
+	// +nullable
+	LastUpdateTime metav1.Time `json:"lastUpdateTime,omitempty"`
+}
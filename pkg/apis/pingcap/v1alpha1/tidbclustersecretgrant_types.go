@@ -0,0 +1,75 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TidbClusterSecretGrant grants the TidbClusters in the other namespaces that join a TidbCluster in the namespace
+// of the grant by spec.cluster to copy the secrets of it into their namespaces by spec.secretPropagation.
+// TiDB Operator keeps the copies in sync with the secrets while they're granted.
+//
+// +genclient
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:resource:shortName="tcsg"
+// +kubebuilder:printcolumn:name="Cluster",type=string,JSONPath=`.spec.cluster`,description="The TidbCluster whose secrets are granted"
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+type TidbClusterSecretGrant struct {
+	metav1.TypeMeta `json:",inline"`
+	// +k8s:openapi-gen=false
+	metav1.ObjectMeta `json:"metadata"`
+
+	// Spec defines the secrets granted and the TidbClusters granted to copy them.
+	Spec TidbClusterSecretGrantSpec `json:"spec"`
+}
+
+// TidbClusterSecretGrantList is a TidbClusterSecretGrant list.
+//
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type TidbClusterSecretGrantList struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// +k8s:openapi-gen=false
+	metav1.ListMeta `json:"metadata"`
+
+	Items []TidbClusterSecretGrant `json:"items"`
+}
+
+// TidbClusterSecretGrantSpec is the spec of a TidbClusterSecretGrant.
+type TidbClusterSecretGrantSpec struct {
+	// Cluster is the name of the TidbCluster in the namespace of the grant that the granted TidbClusters join.
+	Cluster string `json:"cluster"`
+
+	// From are the TidbClusters granted to copy the secrets.
+	// +kubebuilder:validation:MinItems=1
+	From []SecretGrantSubject `json:"from"`
+
+	// SecretNames are the names of the secrets in the namespace of the grant that can be copied.
+	// Optional: Defaults to the client secret of the cluster, which contains the CA certificate
+	// +optional
+	SecretNames []string `json:"secretNames,omitempty"`
+}
+
+// SecretGrantSubject is the TidbClusters granted to copy the secrets.
+type SecretGrantSubject struct {
+	// Namespace of the granted TidbClusters.
+	Namespace string `json:"namespace"`
+
+	// Name of the granted TidbCluster, all the TidbClusters in the namespace are granted if it's empty.
+	// +optional
+	Name string `json:"name,omitempty"`
+}
@@ -0,0 +1,212 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TidbClusterTemplate is a template of TidbCluster defined by platform teams, e.g. the version,
+// TLS and monitoring defaults, along with the sizes the users can choose by a TidbClusterClaim.
+//
+// +genclient
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:resource:shortName="tct"
+// +kubebuilder:printcolumn:name="Version",type=string,JSONPath=`.spec.template.version`,description="The version of the rendered TidbClusters"
+// +kubebuilder:printcolumn:name="DefaultSize",type=string,JSONPath=`.spec.defaultSize`,description="The size used if a claim doesn't specify one"
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+type TidbClusterTemplate struct {
+	metav1.TypeMeta `json:",inline"`
+	// +k8s:openapi-gen=false
+	metav1.ObjectMeta `json:"metadata"`
+
+	// Spec defines the TidbClusters rendered from the template.
+	Spec TidbClusterTemplateSpec `json:"spec"`
+}
+
+// TidbClusterTemplateList is a TidbClusterTemplate list.
+//
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type TidbClusterTemplateList struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// +k8s:openapi-gen=false
+	metav1.ListMeta `json:"metadata"`
+
+	Items []TidbClusterTemplate `json:"items"`
+}
+
+// TidbClusterTemplateSpec is the spec of a TidbClusterTemplate.
+type TidbClusterTemplateSpec struct {
+	// Template is the spec of the rendered TidbClusters, the replicas and resources of the
+	// components are overridden by the size chosen by the claim.
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:Schemaless
+	Template TidbClusterSpec `json:"template"`
+
+	// Sizes are the resource tiers that the claims can choose.
+	// +kubebuilder:validation:MinItems=1
+	Sizes []TidbClusterSize `json:"sizes"`
+
+	// DefaultSize is the name of the size used if a claim doesn't specify one,
+	// defaults to the first one of Sizes.
+	// +optional
+	DefaultSize string `json:"defaultSize,omitempty"`
+}
+
+// TidbClusterSize is a resource tier of the TidbClusters rendered from a TidbClusterTemplate.
+type TidbClusterSize struct {
+	// Name of the size, e.g. small, medium or large.
+	Name string `json:"name"`
+
+	// PD is the size of PD, it's ignored if the template doesn't contain PD.
+	// +optional
+	PD *ComponentSize `json:"pd,omitempty"`
+
+	// TiKV is the size of TiKV, it's ignored if the template doesn't contain TiKV.
+	// +optional
+	TiKV *ComponentSize `json:"tikv,omitempty"`
+
+	// TiDB is the size of TiDB, it's ignored if the template doesn't contain TiDB.
+	// +optional
+	TiDB *ComponentSize `json:"tidb,omitempty"`
+
+	// TiFlash is the size of TiFlash, it's ignored if the template doesn't contain TiFlash.
+	// +optional
+	TiFlash *ComponentSize `json:"tiflash,omitempty"`
+}
+
+// ComponentSize is the replicas and resources of a component.
+type ComponentSize struct {
+	// Replicas of the component.
+	// +kubebuilder:validation:Minimum=0
+	Replicas int32 `json:"replicas"`
+
+	// Resources of the component, the storage size is set by `requests.storage`.
+	// +optional
+	corev1.ResourceRequirements `json:",inline"`
+}
+
+// TidbClusterClaim is a request of a TidbCluster rendered from a TidbClusterTemplate,
+// the rendered TidbCluster is owned by the claim and kept in sync with the template.
+//
+// +genclient
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:resource:shortName="tcc"
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Template",type=string,JSONPath=`.spec.templateRef.name`,description="The template of the claim"
+// +kubebuilder:printcolumn:name="Size",type=string,JSONPath=`.status.size`,description="The size of the rendered TidbCluster"
+// +kubebuilder:printcolumn:name="Cluster",type=string,JSONPath=`.status.clusterName`,description="The rendered TidbCluster"
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`,description="The phase of the claim"
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+type TidbClusterClaim struct {
+	metav1.TypeMeta `json:",inline"`
+	// +k8s:openapi-gen=false
+	metav1.ObjectMeta `json:"metadata"`
+
+	// Spec is the request of the TidbCluster.
+	Spec TidbClusterClaimSpec `json:"spec"`
+
+	// Status is the most recently observed status of the claim.
+	//
+	// +k8s:openapi-gen=false
+	Status TidbClusterClaimStatus `json:"status,omitempty"`
+}
+
+// TidbClusterClaimList is a TidbClusterClaim list.
+//
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type TidbClusterClaimList struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// +k8s:openapi-gen=false
+	metav1.ListMeta `json:"metadata"`
+
+	Items []TidbClusterClaim `json:"items"`
+}
+
+// TidbClusterClaimSpec is the spec of a TidbClusterClaim.
+type TidbClusterClaimSpec struct {
+	// TemplateRef is the TidbClusterTemplate to render the TidbCluster from.
+	TemplateRef TidbClusterTemplateRef `json:"templateRef"`
+
+	// Size is the name of a size of the template, defaults to the default size of the template.
+	// +optional
+	Size string `json:"size,omitempty"`
+
+	// ClusterName is the name of the rendered TidbCluster, defaults to the name of the claim.
+	// It can't be changed after the TidbCluster is rendered.
+	// +optional
+	ClusterName string `json:"clusterName,omitempty"`
+
+	// Paused holds the changes of the template and the size back from the rendered TidbCluster until
+	// it's set to false. The changes are also held while the TidbCluster is being upgraded or scaled.
+	// +optional
+	Paused bool `json:"paused,omitempty"`
+}
+
+// TidbClusterTemplateRef references a TidbClusterTemplate, the templates in other namespaces can
+// only be referenced if their namespaces are allowed by the `--template-namespaces` flag of the operator.
+type TidbClusterTemplateRef struct {
+	// Name of the TidbClusterTemplate.
+	Name string `json:"name"`
+
+	// Namespace of the TidbClusterTemplate, defaults to the namespace of the claim.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// TidbClusterClaimPhase is the phase of a TidbClusterClaim.
+type TidbClusterClaimPhase string
+
+const (
+	// TidbClusterClaimPending means the TidbCluster is not rendered yet.
+	TidbClusterClaimPending TidbClusterClaimPhase = "Pending"
+	// TidbClusterClaimBound means the TidbCluster is rendered from the template.
+	TidbClusterClaimBound TidbClusterClaimPhase = "Bound"
+	// TidbClusterClaimFailed means the TidbCluster can't be rendered, e.g. the template or the size doesn't exist.
+	TidbClusterClaimFailed TidbClusterClaimPhase = "Failed"
+)
+
+// TidbClusterClaimStatus is the status of a TidbClusterClaim.
+type TidbClusterClaimStatus struct {
+	// Phase of the claim.
+	// +optional
+	Phase TidbClusterClaimPhase `json:"phase,omitempty"`
+
+	// Message explains why the claim is in the phase, or why the changes are held back from the TidbCluster.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// ClusterName is the name of the rendered TidbCluster.
+	// +optional
+	ClusterName string `json:"clusterName,omitempty"`
+
+	// Size is the name of the size of the rendered TidbCluster.
+	// +optional
+	Size string `json:"size,omitempty"`
+
+	// ObservedGeneration is the generation of the claim observed when the TidbCluster is rendered last time.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// ObservedTemplateGeneration is the generation of the template observed when the TidbCluster is rendered last time.
+	// +optional
+	ObservedTemplateGeneration int64 `json:"observedTemplateGeneration,omitempty"`
+}
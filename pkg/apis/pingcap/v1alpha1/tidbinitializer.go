@@ -13,6 +13,8 @@
 
 package v1alpha1
 
+const defaultSQLBundleMetadataTable = "mysql.tidb_initializer_scripts"
+
 // GetPermitHost retrieves the permit host from TidbInitializer
 func (ti *TidbInitializer) GetPermitHost() string {
 	var permitHost string
@@ -23,3 +25,11 @@ func (ti *TidbInitializer) GetPermitHost() string {
 	}
 	return permitHost
 }
+
+// GetSQLBundleMetadataTable returns the table recording the applied scripts of the SQL bundle
+func (ti *TidbInitializer) GetSQLBundleMetadataTable() string {
+	if ti.Spec.SQLBundle == nil || ti.Spec.SQLBundle.MetadataTable == "" {
+		return defaultSQLBundleMetadataTable
+	}
+	return ti.Spec.SQLBundle.MetadataTable
+}
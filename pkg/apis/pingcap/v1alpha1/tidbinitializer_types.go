@@ -85,6 +85,11 @@ type TidbInitializerSpec struct {
 	// +optional
 	InitSqlConfigMap *string `json:"initSqlConfigMap,omitempty"`
 
+	// SQLBundle is the SQL scripts applied in the order of their names after the initialization. The applied
+	// scripts are recorded with their checksums, so that only the new scripts are applied when the bundle changes.
+	// +optional
+	SQLBundle *SQLBundleSource `json:"sqlBundle,omitempty"`
+
 	// +optional
 	PasswordSecret *string `json:"passwordSecret,omitempty"`
 
@@ -101,12 +106,33 @@ type TidbInitializerSpec struct {
 	TLSClientSecretName *string `json:"tlsClientSecretName,omitempty"`
 }
 
+// SQLBundleSource is a ConfigMap or a Secret whose keys are the names of the SQL scripts
+// +k8s:openapi-gen=true
+type SQLBundleSource struct {
+	// ConfigMap is the name of the ConfigMap of the SQL scripts
+	// +optional
+	ConfigMap *string `json:"configMap,omitempty"`
+
+	// Secret is the name of the Secret of the SQL scripts, which is used if the scripts contain credentials
+	// +optional
+	Secret *string `json:"secret,omitempty"`
+
+	// MetadataTable is the table recording the names and the checksums of the applied scripts
+	// Optional: Defaults to mysql.tidb_initializer_scripts
+	// +optional
+	MetadataTable string `json:"metadataTable,omitempty"`
+}
+
 // +k8s:openapi-gen=true
 type TidbInitializerStatus struct {
 	batchv1.JobStatus `json:",inline"`
 
 	// Phase is a user readable state inferred from the underlying Job status and TidbCluster status
 	Phase InitializePhase `json:"phase,omitempty"`
+
+	// SQLBundleChecksum is the checksum of the SQL bundle applied by the last completed job
+	// +optional
+	SQLBundleChecksum string `json:"sqlBundleChecksum,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
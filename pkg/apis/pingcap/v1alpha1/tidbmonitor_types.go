@@ -14,6 +14,7 @@
 package v1alpha1
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/prometheus/common/model"
@@ -23,6 +24,21 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+const (
+	// defaultPrometheusDownsampledInterval is the default resolution of the downsampled tier of Prometheus
+	defaultPrometheusDownsampledInterval = "5m"
+	// PrometheusDownsampledRecordPrefix is the prefix of the series recorded for the downsampled tier of Prometheus
+	PrometheusDownsampledRecordPrefix = "downsampled:"
+	// defaultBlackboxExporterBaseImage is the default image of blackbox-exporter
+	defaultBlackboxExporterBaseImage = "prom/blackbox-exporter"
+	// defaultBlackboxExporterVersion is the default version of blackbox-exporter
+	defaultBlackboxExporterVersion = "v0.24.0"
+	// defaultBlackboxProbeInterval is the default interval of the blackbox probes
+	defaultBlackboxProbeInterval = "30s"
+	// RemoteKubeconfigKey is the key of the kubeconfig in the secret referenced by RemoteClusterRef
+	RemoteKubeconfigKey = "kubeconfig"
+)
+
 // +genclient
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
@@ -159,13 +175,17 @@ type TidbMonitorSpec struct {
 	Replicas *int32 `json:"replicas,omitempty"`
 
 	// EXPERIMENTAL: Number of shards to distribute targets onto. Number of
-	// replicas multiplied by shards is the total number of Pods created. Note
-	// that scaling down shards will not reshard data onto remaining instances,
-	// it must be manually moved. Increasing shards will not reshard data
+	// replicas multiplied by shards is the total number of Pods created, each
+	// shard is a StatefulSet with its own persistent volumes. Scaling down
+	// shards deletes the StatefulSets of the removed shards but retains their
+	// PVCs, the data is not resharded onto remaining instances and it must be
+	// manually moved. Increasing shards will not reshard data
 	// either but it will continue to be available from the same instances. To
 	// query globally use Thanos sidecar and Thanos querier or remote write
 	// data to a central location. Sharding is done on the content of the
 	// `__address__` target meta-label.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
 	Shards *int32 `json:"shards,omitempty"`
 
 	// Additional volumes of TidbMonitor pod.
@@ -188,6 +208,11 @@ type TidbMonitorSpec struct {
 
 	// PreferIPv6 indicates whether to prefer IPv6 addresses for all components.
 	PreferIPv6 bool `json:"preferIPv6,omitempty"`
+
+	// Blackbox deploys blackbox-exporter in the monitor pod to probe the TiDB and PD services
+	// of the monitored clusters, which measures the uptime from the network's perspective.
+	// +optional
+	Blackbox *BlackboxExporterSpec `json:"blackbox,omitempty"`
 }
 
 // PrometheusReloaderSpec is the desired state of prometheus configuration reloader
@@ -215,6 +240,11 @@ type PrometheusSpec struct {
 	// +optional
 	RetentionTime *string `json:"retentionTime,omitempty"`
 
+	// Retention configures the retention tiers of Prometheus.
+	// If the raw retention is set, it will override the value of `RetentionTime` and `ReserveDays`.
+	// +optional
+	Retention *PrometheusRetentionSpec `json:"retention,omitempty"`
+
 	// Ingress configuration of Prometheus
 	// +optional
 	Ingress *IngressSpec `json:"ingress,omitempty"`
@@ -234,6 +264,80 @@ type PrometheusSpec struct {
 	AdditionalVolumeMounts []corev1.VolumeMount `json:"additionalVolumeMounts,omitempty"`
 }
 
+// PrometheusRetentionSpec configures the retention tiers of Prometheus
+type PrometheusRetentionSpec struct {
+	// Raw is the retention time of the raw samples, Units Supported: y, w, d, h, m, s, ms.
+	// +optional
+	Raw *string `json:"raw,omitempty"`
+
+	// Downsampled configures a long-term tier that keeps downsampled samples for a longer time than
+	// the raw samples, so that long range views do not need an external storage or an unbounded disk.
+	// +optional
+	Downsampled *PrometheusDownsampledSpec `json:"downsampled,omitempty"`
+}
+
+// PrometheusDownsampledSpec is the desired state of the downsampled tier of Prometheus.
+// The tier is served by a second Prometheus container in the monitor pod listening on port 9091.
+// The raw Prometheus evaluates the recording rules that aggregate the key metrics of the clusters
+// at the downsampling interval, and the tier federates only the series recorded by these rules.
+type PrometheusDownsampledSpec struct {
+	corev1.ResourceRequirements `json:",inline"`
+
+	// Retention is the retention time of the downsampled samples, Units Supported: y, w, d, h, m, s, ms.
+	Retention string `json:"retention"`
+
+	// Interval is the resolution of the downsampled samples, it must not be less than 1m.
+	// Optional: Defaults to 5m
+	// +optional
+	Interval *string `json:"interval,omitempty"`
+
+	// Rules are the additional recording rules kept in the downsampled tier, they are evaluated
+	// along with the built-in ones. The record names must start with `downsampled:`.
+	// +optional
+	Rules []PrometheusRecordingRule `json:"rules,omitempty"`
+}
+
+// PrometheusRecordingRule is a recording rule of Prometheus
+type PrometheusRecordingRule struct {
+	// Record is the name of the series to output to
+	Record string `json:"record"`
+
+	// Expr is the PromQL expression to evaluate, it should aggregate the raw series
+	Expr string `json:"expr"`
+}
+
+// BlackboxExporterSpec is the desired state of blackbox-exporter.
+// The exporter runs as a container in the monitor pod listening on port 9115. The PD service of each
+// monitored cluster is probed by a TLS handshake if the cluster enables TLS, otherwise by a TCP connection,
+// and the TiDB service is probed by a TCP connection. The probes are scraped by the Prometheus of the monitor
+// with the `blackbox` job, and the default alert rules on the probe results are loaded by the Prometheus.
+type BlackboxExporterSpec struct {
+	MonitorContainer `json:",inline"`
+
+	// Interval is the interval of the probes.
+	// Optional: Defaults to 30s
+	// +optional
+	Interval *string `json:"interval,omitempty"`
+
+	// Targets are the additional endpoints to probe, e.g. the external load balancers of TiDB.
+	// +optional
+	Targets []BlackboxTarget `json:"targets,omitempty"`
+}
+
+// BlackboxTarget is an endpoint probed by blackbox-exporter
+type BlackboxTarget struct {
+	// Name is the name of the endpoint, it's set as the `endpoint` label of the probe results
+	Name string `json:"name"`
+
+	// Address is the address of the endpoint in the form of host:port
+	Address string `json:"address"`
+
+	// TLS indicates whether to probe the endpoint by a TLS handshake, the certificate of the
+	// endpoint is not verified.
+	// +optional
+	TLS bool `json:"tls,omitempty"`
+}
+
 // +k8s:openapi-gen=true
 // Config  is the the desired state of Prometheus Configuration
 type PrometheusConfiguration struct {
@@ -369,6 +473,45 @@ type TidbClusterRef struct {
 	// ClusterDomain is the domain of TidbCluster object
 	// +optional
 	ClusterDomain string `json:"clusterDomain,omitempty"`
+
+	// Remote references the cluster in another Kubernetes cluster, it's only supported by TidbMonitor.
+	// +optional
+	Remote *RemoteClusterRef `json:"remote,omitempty"`
+}
+
+// RemoteClusterRef describes how TidbMonitor scrapes a cluster in another Kubernetes cluster.
+// The pods of the cluster are discovered by the API server of the Kubernetes cluster if the kubeconfig
+// is set, and their addresses are suffixed with the ClusterDomain of the reference if it's set.
+// Otherwise the static endpoints of the components are scraped.
+type RemoteClusterRef struct {
+	// KubernetesCluster is the name of the Kubernetes cluster, it's set as the `kubernetes_cluster` label
+	// of the scraped series to distinguish the cluster from the same named clusters in other Kubernetes clusters.
+	KubernetesCluster string `json:"kubernetesCluster"`
+
+	// KubeconfigSecret is the name of the secret in the namespace of TidbMonitor which contains the kubeconfig
+	// of the Kubernetes cluster by the `kubeconfig` key. The credentials must be embedded in the kubeconfig.
+	// +optional
+	KubeconfigSecret string `json:"kubeconfigSecret,omitempty"`
+
+	// Endpoints are the static endpoints of the components, which are used when the API server of the
+	// Kubernetes cluster is not reachable from TidbMonitor.
+	// +optional
+	Endpoints []RemoteClusterEndpoints `json:"endpoints,omitempty"`
+
+	// TLSClientSecret is the name of the secret in the namespace of TidbMonitor which contains the client
+	// certificate of the cluster by the `ca.crt`, `tls.crt` and `tls.key` keys, it must be set if the cluster
+	// enables TLS.
+	// +optional
+	TLSClientSecret string `json:"tlsClientSecret,omitempty"`
+}
+
+// RemoteClusterEndpoints are the static endpoints of a component of the cluster in another Kubernetes cluster
+type RemoteClusterEndpoints struct {
+	// Component is the component of the endpoints, e.g. pd, tidb, tikv, tiflash, ticdc, dm-master, dm-worker
+	Component string `json:"component"`
+
+	// Targets are the addresses of the metrics API of the component in the form of host:port
+	Targets []string `json:"targets"`
 }
 
 // +k8s:openapi-gen=true
@@ -459,6 +602,9 @@ type RemoteWriteSpec struct {
 	// File to read bearer token for remote write.
 	// +optional
 	BearerTokenFile string `json:"bearerTokenFile,omitempty"`
+	// Secret containing the bearer token for remote write, it takes precedence over BearerToken.
+	// +optional
+	BearerTokenSecret *corev1.SecretKeySelector `json:"bearerTokenSecret,omitempty"`
 	// TLS Config to use for remote write.
 	// +optional
 	TLSConfig *TLSConfig `json:"tlsConfig,omitempty"`
@@ -557,6 +703,55 @@ func (tm *TidbMonitor) GetShards() int32 {
 	return shards
 }
 
+// PrometheusRawRetention returns the retention time of the raw samples of Prometheus
+func (tm *TidbMonitor) PrometheusRawRetention() string {
+	prom := tm.Spec.Prometheus
+	if prom.Retention != nil && prom.Retention.Raw != nil {
+		return *prom.Retention.Raw
+	}
+	if prom.RetentionTime != nil {
+		return *prom.RetentionTime
+	}
+	return fmt.Sprintf("%dd", prom.ReserveDays)
+}
+
+// PrometheusDownsampled returns the spec of the downsampled tier of Prometheus, nil if it is disabled
+func (tm *TidbMonitor) PrometheusDownsampled() *PrometheusDownsampledSpec {
+	if tm.Spec.Prometheus.Retention == nil {
+		return nil
+	}
+	return tm.Spec.Prometheus.Retention.Downsampled
+}
+
+// GetInterval returns the resolution of the downsampled samples
+func (d *PrometheusDownsampledSpec) GetInterval() string {
+	if d.Interval == nil || *d.Interval == "" {
+		return defaultPrometheusDownsampledInterval
+	}
+	return *d.Interval
+}
+
+// GetImage returns the image of blackbox-exporter
+func (b *BlackboxExporterSpec) GetImage() string {
+	baseImage := b.BaseImage
+	if baseImage == "" {
+		baseImage = defaultBlackboxExporterBaseImage
+	}
+	version := b.Version
+	if version == "" {
+		version = defaultBlackboxExporterVersion
+	}
+	return fmt.Sprintf("%s:%s", baseImage, version)
+}
+
+// GetInterval returns the interval of the probes
+func (b *BlackboxExporterSpec) GetInterval() string {
+	if b.Interval == nil || *b.Interval == "" {
+		return defaultBlackboxProbeInterval
+	}
+	return *b.Interval
+}
+
 func (tm *TidbMonitor) Timezone() string {
 	tz := tm.Spec.Timezone
 	if len(tz) <= 0 {
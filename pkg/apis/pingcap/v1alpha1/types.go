@@ -20,6 +20,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 
 	"github.com/pingcap/tidb-operator/pkg/apis/util/config"
 )
@@ -57,6 +58,7 @@ const (
 	ContainerSlowLogTailer    ContainerName = "slowlog"
 	ContainerRocksDBLogTailer ContainerName = "rocksdblog"
 	ContainerRaftLogTailer    ContainerName = "raftlog"
+	ContainerDataDirPreCheck  ContainerName = "datadir-precheck"
 )
 
 // MemberType represents member type
@@ -125,6 +127,18 @@ const (
 	ConfigUpdateStrategyRollingUpdate ConfigUpdateStrategy = "RollingUpdate"
 )
 
+// ConfigProfile is a set of tuned default config of the components for a kind of workload
+type ConfigProfile string
+
+const (
+	// ConfigProfileOLTP is tuned for high-concurrency transactional workloads
+	ConfigProfileOLTP ConfigProfile = "OLTP"
+	// ConfigProfileHTAP is tuned for mixed transactional and analytical workloads with TiFlash
+	ConfigProfileHTAP ConfigProfile = "HTAP"
+	// ConfigProfileLight is tuned for small clusters on limited resources
+	ConfigProfileLight ConfigProfile = "Light"
+)
+
 type StartScriptVersion string
 
 const (
@@ -257,11 +271,38 @@ type TidbClusterSpec struct {
 	// related components to use the new ConfigMap, that is, the new configuration will be applied automatically.
 	ConfigUpdateStrategy ConfigUpdateStrategy `json:"configUpdateStrategy,omitempty"`
 
+	// ConfigProfile selects the tuned default config of PD, TiKV and TiDB for the workload of the cluster.
+	// The defaults are chosen by the minor version of each component and are layered under the config of
+	// the component, that is, the keys set in the config of the component always take precedence.
+	// OLTP is tuned for high-concurrency transactions, HTAP for mixed transactional and analytical
+	// workloads with TiFlash, and Light for small clusters on limited resources, e.g. development and CI.
+	// Empty (default) uses the default config of the components.
+	// Changing the profile changes the config of the components, which is applied by a rolling update.
+	// +kubebuilder:validation:Enum="";OLTP;HTAP;Light
+	// +optional
+	ConfigProfile ConfigProfile `json:"configProfile,omitempty"`
+
 	// Whether enable PVC reclaim for orphan PVC left by statefulset scale-in
 	// Optional: Defaults to false
 	// +optional
 	EnablePVReclaim *bool `json:"enablePVReclaim,omitempty"`
 
+	// ScaleInPVCRetentionPolicy is how the PVCs left by the scale-in are handled.
+	// Retain keeps the PVCs, and they're reused by the scale-out of TiDB and TiCDC whose data isn't bound to the
+	// members, the PVCs of the other components are recreated by the scale-out.
+	// Delete deletes the PVCs after spec.scaleInPVCDeletionGracePeriod, and sets the reclaim policy of the PVs to Delete.
+	// Optional: Defaults to Delete for PD, TiKV and TiFlash if enablePVReclaim is true, otherwise the PVCs are kept
+	// until they're recreated by the scale-out
+	// +optional
+	// +kubebuilder:validation:Enum=Retain;Delete
+	ScaleInPVCRetentionPolicy *ScaleInPVCRetentionPolicy `json:"scaleInPVCRetentionPolicy,omitempty"`
+
+	// ScaleInPVCDeletionGracePeriod is how long the PVCs left by the scale-in are kept before they're deleted by
+	// the Delete policy, so that the data can be recovered if the scale-in is a mistake.
+	// Optional: Defaults to 0
+	// +optional
+	ScaleInPVCDeletionGracePeriod *metav1.Duration `json:"scaleInPVCDeletionGracePeriod,omitempty"`
+
 	// Whether enable the TLS connection between TiDB server components
 	// Optional: Defaults to nil
 	// +optional
@@ -296,6 +337,12 @@ type TidbClusterSpec struct {
 	// +optional
 	Labels map[string]string `json:"labels,omitempty"`
 
+	// Metadata is the custom labels and annotations propagated to all the resources generated
+	// for the components of TiDB cluster, including StatefulSets, Pods, PVCs, Services, ConfigMaps
+	// and Jobs. Can be overrode by metadata in the specific component spec.
+	// +optional
+	Metadata *ObjectMetadata `json:"metadata,omitempty"`
+
 	// Base tolerations of TiDB cluster Pods, components may add more tolerations upon this respectively
 	// +optional
 	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
@@ -336,6 +383,13 @@ type TidbClusterSpec struct {
 	// +optional
 	Cluster *TidbClusterRef `json:"cluster,omitempty"`
 
+	// SecretPropagation copies the secrets of the cluster referenced by spec.cluster, e.g. the client secret with
+	// the CA certificate, into the namespace of this cluster and keeps them in sync. The secrets must be granted by
+	// a TidbClusterSecretGrant in the namespace of the referenced cluster. It only works when the clusters are in
+	// the same Kubernetes cluster.
+	// +optional
+	SecretPropagation *SecretPropagation `json:"secretPropagation,omitempty"`
+
 	// PDAddresses are the external PD addresses, if configured, the PDs in this TidbCluster will join to the configured PD cluster.
 	// +optional
 	PDAddresses []string `json:"pdAddresses,omitempty"`
@@ -374,6 +428,154 @@ type TidbClusterSpec struct {
 
 	// PreferIPv6 indicates whether to prefer IPv6 addresses for all components.
 	PreferIPv6 bool `json:"preferIPv6,omitempty"`
+
+	// Standby makes the TidbCluster a warm standby, e.g. a mirror of a cluster in a DR Kubernetes cluster.
+	// The resources of PD, TiKV, TiFlash and TiDB are reconciled with zero replicas, and the PVCs of the
+	// desired replicas are pre-provisioned, so promoting the cluster by removing this field only scales it up.
+	// It can only be set on a cluster that has not been bootstrapped.
+	// +optional
+	Standby *StandbySpec `json:"standby,omitempty"`
+
+	// Proxy is the proxy for the egress HTTP(S) requests of the discovery and the backup, restore and clean jobs
+	// of the cluster, including the Dumpling and TiDB Lightning jobs accessing the TiDB service of the cluster,
+	// it overrides the proxy configured for the operator.
+	// +optional
+	Proxy *HTTPProxyConfig `json:"proxy,omitempty"`
+
+	// HealthProbe runs a synthetic SQL probe against the TiDB service periodically and records
+	// the results in the status and the metrics.
+	// +optional
+	HealthProbe *HealthProbeSpec `json:"healthProbe,omitempty"`
+
+	// TopologyZones declares the replicas of the components in each zone. The replicas of a component
+	// are the sum of its quotas, its pods are restricted to the zones by node affinity and placed by
+	// the quotas in tidb-scheduler, so the pods of the components must be scheduled by tidb-scheduler.
+	// When scaling in with the advanced StatefulSet enabled, the pods in the zones above their quotas are removed first.
+	// +optional
+	TopologyZones *TopologyZones `json:"topologyZones,omitempty"`
+
+	// DriftPolicy controls how the drift of the StatefulSets and Services from the state rendered by the operator,
+	// e.g. caused by `kubectl edit`, is handled.
+	// Optional: Defaults to ignore the drift
+	// +optional
+	DriftPolicy *DriftPolicy `json:"driftPolicy,omitempty"`
+
+	// Teardown makes the operator tear down the cluster when the TidbCluster is deleted, the StatefulSets of
+	// the components are deleted in the reverse dependency order, from TiDB to PD, and the PVCs are deleted
+	// with bounded concurrency. The progress is reported in the status until the teardown is done.
+	// +optional
+	Teardown *TeardownSpec `json:"teardown,omitempty"`
+
+	// ScaleInApproval makes the destructive scale-in of PD and TiKV wait for the approval of an external
+	// system, e.g. a change management system, before the members or the stores are removed.
+	// The pending scale-in is reported in the status until it's done.
+	// +optional
+	ScaleInApproval *ScaleInApproval `json:"scaleInApproval,omitempty"`
+}
+
+// SecretPropagation is the secrets copied from the cluster referenced by spec.cluster
+type SecretPropagation struct {
+	// Secrets are the secrets to copy.
+	// Optional: Defaults to the client secret of the referenced cluster, copied as the client secret of this cluster
+	// +optional
+	Secrets []PropagatedSecret `json:"secrets,omitempty"`
+}
+
+// PropagatedSecret is a secret copied from the namespace of the referenced cluster
+type PropagatedSecret struct {
+	// Name is the name of the secret in the namespace of the referenced cluster
+	Name string `json:"name"`
+
+	// TargetName is the name of the copy in the namespace of this cluster
+	// Optional: Defaults to Name
+	// +optional
+	TargetName string `json:"targetName,omitempty"`
+}
+
+// ScaleInApproval is the external approval of the scale-in of a TidbCluster. If both the webhook and
+// the condition are set, the scale-in needs to be approved by both of them.
+type ScaleInApproval struct {
+	// Components are the components whose scale-in needs to be approved, only pd and tikv are supported.
+	// Optional: Defaults to pd and tikv
+	// +optional
+	Components []MemberType `json:"components,omitempty"`
+
+	// Webhook is called to approve the scale-in of each pod.
+	// +optional
+	Webhook *ScaleInApprovalWebhook `json:"webhook,omitempty"`
+
+	// ConditionType is the type of the condition of the TidbCluster which approves the scale-in. Another
+	// controller approves the pending scale-in in the status by setting the condition to True, the
+	// condition must be updated after the scale-in is requested.
+	// +optional
+	ConditionType TidbClusterConditionType `json:"conditionType,omitempty"`
+}
+
+// ScaleInApprovalWebhook is the webhook to approve the scale-in. The operator POSTs the namespace and
+// the name of the TidbCluster, the component, the pod and the target replicas as JSON to the URL,
+// and the scale-in is approved if the webhook responds 200 with `{"allowed": true}`.
+type ScaleInApprovalWebhook struct {
+	// URL of the webhook.
+	URL string `json:"url"`
+
+	// TimeoutSeconds is the timeout of calling the webhook.
+	// Optional: Defaults to 10
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+}
+
+// TeardownSpec is the teardown of a TidbCluster on deletion.
+type TeardownSpec struct {
+	// DeletePVCs is whether to delete the PVCs of the components, the PVs are handled by their reclaim policy.
+	// +optional
+	DeletePVCs bool `json:"deletePVCs,omitempty"`
+
+	// MaxConcurrentPVCDeletions is the max number of the PVCs being deleted at the same time.
+	// Optional: Defaults to 10
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxConcurrentPVCDeletions *int32 `json:"maxConcurrentPVCDeletions,omitempty"`
+}
+
+// DriftMode is the mode to handle the drift of a kind of resources.
+type DriftMode string
+
+const (
+	// DriftModeEnforce reverts the drifted resources to the state rendered by the operator.
+	DriftModeEnforce DriftMode = "Enforce"
+	// DriftModeWarn reports the drifted resources in the status and the Drifted condition.
+	DriftModeWarn DriftMode = "Warn"
+	// DriftModeIgnore ignores the drift, which is the default.
+	DriftModeIgnore DriftMode = "Ignore"
+)
+
+// DriftPolicy is the drift mode of each kind of resources.
+// A resource is drifted if the fields rendered by the operator are changed in the live object,
+// the fields which are not rendered by the operator are not compared.
+type DriftPolicy struct {
+	// StatefulSet is the drift mode of the StatefulSets, the replicas, update strategy and pod template are compared.
+	// Optional: Defaults to Ignore
+	// +kubebuilder:validation:Enum:="";"Enforce";"Warn";"Ignore"
+	// +optional
+	StatefulSet DriftMode `json:"statefulSet,omitempty"`
+
+	// Service is the drift mode of the Services, the spec is compared.
+	// Optional: Defaults to Ignore
+	// +kubebuilder:validation:Enum:="";"Enforce";"Warn";"Ignore"
+	// +optional
+	Service DriftMode `json:"service,omitempty"`
+}
+
+// DriftedResource is a resource which is drifted from the state rendered by the operator.
+type DriftedResource struct {
+	// Kind of the resource, e.g. StatefulSet or Service.
+	Kind string `json:"kind"`
+	// Name of the resource.
+	Name string `json:"name"`
+	// Fields are the drifted fields of the resource.
+	// +optional
+	Fields []string `json:"fields,omitempty"`
 }
 
 // TidbClusterStatus represents the current status of a tidb cluster.
@@ -387,6 +589,28 @@ type TidbClusterStatus struct {
 	TiProxy    TiProxyStatus             `json:"tiproxy,omitempty"`
 	TiCDC      TiCDCStatus               `json:"ticdc,omitempty"`
 	AutoScaler *TidbClusterAutoScalerRef `json:"auto-scaler,omitempty"`
+	// HealthProbe is the result of the last synthetic SQL probe.
+	// +optional
+	HealthProbe *HealthProbeStatus `json:"healthProbe,omitempty"`
+	// Binding references the Secret which contains the connection info of TiDB,
+	// it follows the Provisioned Service duck type of the Service Binding spec.
+	// +optional
+	Binding *corev1.LocalObjectReference `json:"binding,omitempty"`
+	// DriftedResources are the resources drifted from the state rendered by the operator,
+	// which are reported if the drift mode of their kind is Warn.
+	// +optional
+	DriftedResources []DriftedResource `json:"driftedResources,omitempty"`
+	// Teardown is the progress of the teardown of the cluster after it's deleted.
+	// +optional
+	Teardown *TeardownStatus `json:"teardown,omitempty"`
+	// PodRestarts are the causes of the pod restarts initiated by the operator, indexed by the pod name.
+	// They are removed once the new pods are annotated with the causes.
+	// +optional
+	PodRestarts map[string]PodRestart `json:"podRestarts,omitempty"`
+	// PendingScaleIns are the scale-ins of PD and TiKV waiting for or approved by the external approval,
+	// indexed by the pod name.
+	// +optional
+	PendingScaleIns map[string]PendingScaleIn `json:"pendingScaleIns,omitempty"`
 	// Represents the latest available observations of a tidb cluster's state.
 	// +optional
 	// +nullable
@@ -426,12 +650,45 @@ const (
 	// - All TiKV stores are up.
 	// - All TiFlash stores are up.
 	TidbClusterReady TidbClusterConditionType = "Ready"
+	// TidbClusterDegraded indicates that the tidb cluster is serving but can
+	// not tolerate the failure it is expected to, e.g. region replicas are
+	// concentrated in one zone according to the PD location labels.
+	TidbClusterDegraded TidbClusterConditionType = "Degraded"
+	// TidbClusterPDAvailable indicates whether the PD API requests of the operator succeed,
+	// the reason tells why they fail, e.g. PDLeaderChanging, PDEtcdTimeout or PDUnreachable.
+	TidbClusterPDAvailable TidbClusterConditionType = "PDAvailable"
+	// TidbClusterDrifted indicates whether any StatefulSet or Service is drifted from the state
+	// rendered by the operator, it is only reported if the drift policy is set.
+	TidbClusterDrifted TidbClusterConditionType = "Drifted"
+	// TidbClusterQuotaExceeded indicates whether the pods of any component are rejected by the
+	// ResourceQuota or LimitRange of the namespace, the auto failover is paused while it's true.
+	TidbClusterQuotaExceeded TidbClusterConditionType = "QuotaExceeded"
+	// TidbClusterAdvancedStatefulSetCompatible indicates whether the Advanced StatefulSet CRD and controller
+	// are compatible with the operator and the StatefulSets of the cluster are managed consistently, it is
+	// only reported if the AdvancedStatefulSet feature is enabled.
+	TidbClusterAdvancedStatefulSetCompatible TidbClusterConditionType = "AdvancedStatefulSetCompatible"
+)
+
+// ScaleInPVCRetentionPolicy is how the PVCs left by the scale-in are handled
+type ScaleInPVCRetentionPolicy string
+
+const (
+	// ScaleInPVCRetain keeps the PVCs left by the scale-in
+	ScaleInPVCRetain ScaleInPVCRetentionPolicy = "Retain"
+	// ScaleInPVCDelete deletes the PVCs left by the scale-in after the grace period
+	ScaleInPVCDelete ScaleInPVCRetentionPolicy = "Delete"
 )
 
 // The `Type` of the component condition
 const (
 	// ComponentVolumeResizing indicates that any volume of this component is resizing.
 	ComponentVolumeResizing string = "ComponentVolumeResizing"
+	// ComponentReady indicates that all the members of this component are ready and up to date.
+	ComponentReady string = "ComponentReady"
+	// ComponentUpgradeInProgress indicates that the pods of this component are being upgraded.
+	ComponentUpgradeInProgress string = "ComponentUpgradeInProgress"
+	// ComponentScalingBlocked indicates that the scaling of this component can't make progress.
+	ComponentScalingBlocked string = "ComponentScalingBlocked"
 )
 
 // +k8s:openapi-gen=true
@@ -439,8 +696,30 @@ const (
 type DiscoverySpec struct {
 	*ComponentSpec              `json:",inline"`
 	corev1.ResourceRequirements `json:",inline"`
+
+	// Mode is how the PD members find each other when they start without data.
+	// In Deployment mode the discovery service runs in a Deployment.
+	// In Embedded mode no discovery Deployment is created, the PD start script joins the
+	// cluster through the PD service and the PD pod with ordinal 0 bootstraps the cluster
+	// if the PD service is not reachable. Embedded mode requires the v2 start script and
+	// does not support clusters deployed across Kubernetes, heterogeneous clusters and the
+	// preStop hooks of PD and TiKV.
+	// Defaults to Deployment.
+	// +kubebuilder:validation:Enum:="";"Deployment";"Embedded"
+	// +optional
+	Mode DiscoveryMode `json:"mode,omitempty"`
 }
 
+// DiscoveryMode is how the PD members find each other when they start without data.
+type DiscoveryMode string
+
+const (
+	// DiscoveryModeDeployment runs the discovery service in a Deployment.
+	DiscoveryModeDeployment DiscoveryMode = "Deployment"
+	// DiscoveryModeEmbedded renders the discovery logic into the PD start script.
+	DiscoveryModeEmbedded DiscoveryMode = "Embedded"
+)
+
 // +k8s:openapi-gen=true
 // PDSpec contains details of PD members
 type PDSpec struct {
@@ -475,6 +754,21 @@ type PDSpec struct {
 	// +optional
 	StorageClassName *string `json:"storageClassName,omitempty"`
 
+	// PVReclaimPolicy is the reclaim policy of the PVs of PD, it overrides spec.pvReclaimPolicy.
+	// +optional
+	PVReclaimPolicy *corev1.PersistentVolumeReclaimPolicy `json:"pvReclaimPolicy,omitempty"`
+
+	// ScaleInPVCRetentionPolicy is how the PVCs left by the scale-in of PD are handled, it overrides
+	// spec.scaleInPVCRetentionPolicy.
+	// +optional
+	// +kubebuilder:validation:Enum=Retain;Delete
+	ScaleInPVCRetentionPolicy *ScaleInPVCRetentionPolicy `json:"scaleInPVCRetentionPolicy,omitempty"`
+
+	// ScaleInPVCDeletionGracePeriod is how long the PVCs left by the scale-in of PD are kept before they're
+	// deleted by the Delete policy, it overrides spec.scaleInPVCDeletionGracePeriod.
+	// +optional
+	ScaleInPVCDeletionGracePeriod *metav1.Duration `json:"scaleInPVCDeletionGracePeriod,omitempty"`
+
 	// StorageVolumes configure additional storage for PD pods.
 	// +optional
 	StorageVolumes []StorageVolume `json:"storageVolumes,omitempty"`
@@ -496,6 +790,13 @@ type PDSpec struct {
 	// +kubebuilder:validation:XPreserveUnknownFields
 	Config *PDConfigWraper `json:"config,omitempty"`
 
+	// AdditionalArgs are the command line arguments appended to the start command of pd-server,
+	// for flags that can not be set in the config file, e.g. "--flag=value".
+	// Arguments conflicting with the flags generated by the operator are rejected.
+	// Only supported by start script v2, it is rejected if spec.startScriptVersion is not v2.
+	// +optional
+	AdditionalArgs []string `json:"additionalArgs,omitempty"`
+
 	// TLSClientSecretName is the name of secret which stores tidb server client certificate
 	// which used by Dashboard.
 	// +optional
@@ -514,6 +815,71 @@ type PDSpec struct {
 	// +optional
 	// +kubebuilder:validation:Enum:="";"v1"
 	StartUpScriptVersion string `json:"startUpScriptVersion,omitempty"`
+
+	// PreStopHook enables the preStop hook which transfers the PD leader away before the
+	// PD container is stopped, e.g. when the node is gracefully shut down by kubelet.
+	// +optional
+	PreStopHook *PreStopHookSpec `json:"preStopHook,omitempty"`
+
+	// ClusterID is the ID of the PD cluster that the PD members are expected to join,
+	// discovery refuses to let a PD member join a PD cluster with another ID.
+	// If not set, the cluster ID recorded in the status of the TidbCluster is expected,
+	// or that of the TidbCluster referenced by spec.cluster if the status is not synced yet.
+	// The join is allowed without the verification if none of them is known.
+	// +optional
+	ClusterID string `json:"clusterID,omitempty"`
+
+	// PodDisruptionBudget configures the PodDisruptionBudget of the PD pods
+	// +optional
+	PodDisruptionBudget *PodDisruptionBudgetSpec `json:"podDisruptionBudget,omitempty"`
+
+	// LeaderPreference configures the PD members where the PD leader is preferred, e.g. the ones in the
+	// same region as the primary workload. The operator sets the leader priority of the PD members in PD
+	// so that PD transfers the leader to a healthy preferred member, and re-asserts the priorities after
+	// the members restart. Removing it doesn't reset the priorities in PD.
+	// +optional
+	LeaderPreference *PDLeaderPreference `json:"leaderPreference,omitempty"`
+
+	// ConfigDriftPolicy is how the drift of the online config and schedulers of PD from the declared
+	// spec.pd.config, e.g. after they are changed by pd-ctl, is handled. The items of schedule, replication
+	// and pd-server and the schedulers without args in schedule.schedulers-v2 are checked.
+	// Ignore (default) doesn't check the drift, Report records the drifted keys in the ConfigDrifted
+	// condition of the PD status and by events, and Revert changes them back to the declared values
+	// through the PD API.
+	// +kubebuilder:validation:Enum="";Ignore;Report;Revert
+	// +optional
+	ConfigDriftPolicy PDConfigDriftPolicy `json:"configDriftPolicy,omitempty"`
+
+	// DataDirPreCheck adds an init container which checks the data directory before starting PD
+	// +optional
+	DataDirPreCheck *DataDirPreCheck `json:"dataDirPreCheck,omitempty"`
+}
+
+// PDConfigDriftPolicy is how the drift of the online config of PD from the declared config is handled
+type PDConfigDriftPolicy string
+
+const (
+	// PDConfigDriftPolicyIgnore doesn't check the drift
+	PDConfigDriftPolicyIgnore PDConfigDriftPolicy = "Ignore"
+	// PDConfigDriftPolicyReport records the drift in the condition and by events
+	PDConfigDriftPolicyReport PDConfigDriftPolicy = "Report"
+	// PDConfigDriftPolicyRevert changes the drifted config back to the declared values
+	PDConfigDriftPolicyRevert PDConfigDriftPolicy = "Revert"
+)
+
+// PDLeaderPreference is the preference of the PD leader, a PD member is preferred if it matches any
+// of the zones or the member name patterns
+type PDLeaderPreference struct {
+	// Zones are the zones where the PD leader is preferred, the zone of a PD member is the
+	// topology.kubernetes.io/zone label of the node of its pod. The nodes can only be read when
+	// the operator has the cluster permission of nodes.
+	// +optional
+	Zones []string `json:"zones,omitempty"`
+
+	// Members are the glob patterns of the names of the PD members where the PD leader is preferred,
+	// e.g. basic-pd-0 or basic-pd-[01]
+	// +optional
+	Members []string `json:"members,omitempty"`
 }
 
 // TiKVSpec contains details of TiKV members
@@ -529,6 +895,13 @@ type TiKVSpec struct {
 	// +kubebuilder:validation:Minimum=0
 	Replicas int32 `json:"replicas"`
 
+	// OfflineOrdinals are the ordinals of the pods removed when scaling in instead of the pods with the largest
+	// ordinals, e.g. the pods on the nodes being decommissioned, the stores are offlined safely as in the normal scale-in.
+	// The replicas should be decreased by the number of the ordinals, otherwise the pods are replaced by the pods
+	// of new ordinals. They're merged into the delete slots and only work with the AdvancedStatefulSet feature.
+	// +optional
+	OfflineOrdinals []int32 `json:"offlineOrdinals,omitempty"`
+
 	// Base image of the component, image tag is now allowed during validation
 	// +kubebuilder:default=pingcap/tikv
 	// +optional
@@ -573,6 +946,21 @@ type TiKVSpec struct {
 	// +optional
 	StorageClassName *string `json:"storageClassName,omitempty"`
 
+	// PVReclaimPolicy is the reclaim policy of the PVs of TiKV, it overrides spec.pvReclaimPolicy.
+	// +optional
+	PVReclaimPolicy *corev1.PersistentVolumeReclaimPolicy `json:"pvReclaimPolicy,omitempty"`
+
+	// ScaleInPVCRetentionPolicy is how the PVCs left by the scale-in of TiKV are handled, it overrides
+	// spec.scaleInPVCRetentionPolicy.
+	// +optional
+	// +kubebuilder:validation:Enum=Retain;Delete
+	ScaleInPVCRetentionPolicy *ScaleInPVCRetentionPolicy `json:"scaleInPVCRetentionPolicy,omitempty"`
+
+	// ScaleInPVCDeletionGracePeriod is how long the PVCs left by the scale-in of TiKV are kept before they're
+	// deleted by the Delete policy, it overrides spec.scaleInPVCDeletionGracePeriod.
+	// +optional
+	ScaleInPVCDeletionGracePeriod *metav1.Duration `json:"scaleInPVCDeletionGracePeriod,omitempty"`
+
 	// Subdirectory within the volume to store TiKV Data. By default, the data
 	// is stored in the root directory of volume which is mounted at
 	// /var/lib/tikv.
@@ -590,13 +978,20 @@ type TiKVSpec struct {
 	// +kubebuilder:validation:XPreserveUnknownFields
 	Config *TiKVConfigWraper `json:"config,omitempty"`
 
+	// AdditionalArgs are the command line arguments appended to the start command of tikv-server,
+	// for flags that can not be set in the config file, e.g. "--flag=value".
+	// Arguments conflicting with the flags generated by the operator are rejected.
+	// Only supported by start script v2, it is rejected if spec.startScriptVersion is not v2.
+	// +optional
+	AdditionalArgs []string `json:"additionalArgs,omitempty"`
+
 	// RecoverFailover indicates that Operator can recover the failed Pods
 	// +optional
 	RecoverFailover bool `json:"recoverFailover,omitempty"`
 
 	// Failover is the configurations of failover
 	// +optional
-	Failover *Failover `json:"failover,omitempty"`
+	Failover *StoreFailover `json:"failover,omitempty"`
 
 	// MountClusterClientSecret indicates whether to mount `cluster-client-secret` to the Pod
 	// +optional
@@ -607,6 +1002,15 @@ type TiKVSpec struct {
 	// +optional
 	EvictLeaderTimeout *string `json:"evictLeaderTimeout,omitempty"`
 
+	// EvictLeaderTimeoutPolicy is what to do when the leader eviction before the upgrade of a TiKV pod times out,
+	// `Continue` upgrades the pod with the remaining leaders, `Block` keeps waiting for the leaders to be evicted
+	// until the timeout is raised or the policy is changed. The stalled eviction is reported by the
+	// LeaderEvictionStalled condition of TiKV.
+	// Optional: Defaults to `Continue`
+	// +kubebuilder:validation:Enum:="";"Continue";"Block"
+	// +optional
+	EvictLeaderTimeoutPolicy EvictLeaderTimeoutPolicy `json:"evictLeaderTimeoutPolicy,omitempty"`
+
 	// WaitLeaderTransferBackTimeout indicates the timeout to wait for leader transfer back before
 	// the next tikv upgrade.
 	//
@@ -614,6 +1018,27 @@ type TiKVSpec struct {
 	// +optional
 	WaitLeaderTransferBackTimeout *metav1.Duration `json:"waitLeaderTransferBackTimeout,omitempty"`
 
+	// MaxUpgradeConcurrency is the max number of the TiKV pods upgraded at once. The pods are only upgraded
+	// together if PD confirms that their stores share no region replicas, so a region never loses more than
+	// one replica during the upgrade. The pods are batched in the descending order of the ordinals.
+	//
+	// Defaults to 1
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxUpgradeConcurrency *int32 `json:"maxUpgradeConcurrency,omitempty"`
+
+	// CanaryUpgrade upgrades the TiKV pods in steps, the pods upgraded in each step are evaluated for a soak
+	// period before the upgrade advances, and they're rolled back on regression.
+	// It's ignored if MaxUpgradeConcurrency is greater than 1.
+	// Optional: Defaults to nil
+	// +optional
+	CanaryUpgrade *CanaryUpgrade `json:"canaryUpgrade,omitempty"`
+
+	// PreStopHook enables the preStop hook which evicts the region leaders before the
+	// TiKV container is stopped, e.g. when the node is gracefully shut down by kubelet.
+	// +optional
+	PreStopHook *PreStopHookSpec `json:"preStopHook,omitempty"`
+
 	// StorageVolumes configure additional storage for TiKV pods.
 	// +optional
 	StorageVolumes []StorageVolume `json:"storageVolumes,omitempty"`
@@ -623,12 +1048,155 @@ type TiKVSpec struct {
 	StoreLabels []string `json:"storeLabels,omitempty"`
 
 	// EnableNamedStatusPort enables status port(20180) in the Pod spec.
+	// The status port is always in the Pod spec if hostNetwork is enabled and the ports are customized.
 	// If you set it to `true` for an existing cluster, the TiKV cluster will be rolling updated.
 	EnableNamedStatusPort bool `json:"enableNamedStatusPort,omitempty"`
 
 	// ScalePolicy is the scale configuration for TiKV
 	// +optional
 	ScalePolicy ScalePolicy `json:"scalePolicy,omitempty"`
+
+	// IORateLimit configures the IO rate limiter of TiKV
+	// +optional
+	IORateLimit *TiKVIORateLimit `json:"ioRateLimit,omitempty"`
+
+	// PodDisruptionBudget configures the PodDisruptionBudget of the TiKV pods
+	// +optional
+	PodDisruptionBudget *PodDisruptionBudgetSpec `json:"podDisruptionBudget,omitempty"`
+
+	// StoreLimits configures the store limits of the TiKV stores in PD, which control the speed of
+	// the rebalancing when the cluster is scaled out or in. The limits are applied to all the TiKV
+	// stores of this cluster by the PD member manager whenever they drift.
+	// +optional
+	StoreLimits *TiKVStoreLimits `json:"storeLimits,omitempty"`
+
+	// Groups are the additional groups of TiKV with different resources or placement in the cluster,
+	// e.g. the TiKV nodes with high memory and the ones with large storage.
+	// Each group is deployed as a heterogeneous TidbCluster named `<cluster>-tikv-<group>` which joins
+	// this cluster, its TiKV spec is the TiKV spec of this cluster overridden by the group. The stores of
+	// a group have the server label `tikv-group: <group>` so that the placement rules can be pinned to it.
+	// If TLS is enabled, the certificates of the heterogeneous clusters have to be issued as well.
+	// A group removed from the list is scaled in to 0 before its TidbCluster is deleted.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Groups []TiKVGroupSpec `json:"groups,omitempty"`
+
+	// Ports are the ports listened by TiKV, which are propagated to the start script, the probes and the
+	// services. With hostNetwork, the ports are reserved on the nodes so that the TiKV pods of different
+	// clusters sharing the nodes can use different ports without conflicts.
+	// Changing the ports of an existing cluster is not supported since the addresses of the stores are
+	// persisted by PD.
+	// +optional
+	Ports *TiKVPorts `json:"ports,omitempty"`
+
+	// DataDirPreCheck adds an init container which checks the data directory before starting TiKV
+	// +optional
+	DataDirPreCheck *DataDirPreCheck `json:"dataDirPreCheck,omitempty"`
+}
+
+// TiKVPorts are the ports listened by TiKV
+// +k8s:openapi-gen=true
+type TiKVPorts struct {
+	// Server is the port of the gRPC service
+	// Optional: Defaults to 20160
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	Server *int32 `json:"server,omitempty"`
+
+	// Status is the port of the status and metrics API
+	// Optional: Defaults to 20180
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	Status *int32 `json:"status,omitempty"`
+}
+
+// DataDirPreCheck is the check of the data directory done by an init container before starting the component.
+// The ownership and permissions of the directory are always checked by writing a file in it as the user of
+// the component. A failed check stops the pod from starting with the reason in the termination message of the
+// init container, which is reported by the DataDirPreCheckFailed condition of the component status and by events.
+// +k8s:openapi-gen=true
+type DataDirPreCheck struct {
+	// FSTypes are the allowed filesystem types of the data directory as shown in /proc/mounts, e.g. ext4 and xfs
+	// Optional: Defaults to any filesystem type
+	// +optional
+	FSTypes []string `json:"fsTypes,omitempty"`
+
+	// MinAvailable is the minimum available space of the filesystem of the data directory
+	// +optional
+	MinAvailable *resource.Quantity `json:"minAvailable,omitempty"`
+}
+
+// TiKVGroupSpec describes a group of TiKV, the fields which are not set are inherited from the TiKV spec
+// of the cluster.
+// +k8s:openapi-gen=true
+type TiKVGroupSpec struct {
+	// Name of the group, it's used in the name of the heterogeneous TidbCluster of the group
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name"`
+
+	// The desired ready replicas of the group
+	// +kubebuilder:validation:Minimum=0
+	Replicas int32 `json:"replicas"`
+
+	// Resources of the TiKV pods of the group, including the storage request
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// StorageClassName of the persistent volume for TiKV data storage of the group
+	// +optional
+	StorageClassName *string `json:"storageClassName,omitempty"`
+
+	// NodeSelector of the TiKV pods of the group, it's merged with the node selector of TiKV
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Tolerations of the TiKV pods of the group, they're appended to the tolerations of TiKV
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// ServerLabels are the additional labels of the TiKV stores of the group, which are mapped to
+	// `server.labels` of the TiKV config
+	// +optional
+	ServerLabels map[string]string `json:"serverLabels,omitempty"`
+}
+
+// TiKVStoreLimits configures the store limits of the TiKV stores, a rate is the number of the peers
+// that can be added to or removed from a store per minute. A limit is left as it is if it's not set.
+// +k8s:openapi-gen=true
+type TiKVStoreLimits struct {
+	// AddPeer is the rate of adding peers to a store, it's mapped to `store limit <id> <rate> add-peer` of pd-ctl.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	AddPeer *float64 `json:"addPeer,omitempty"`
+
+	// RemovePeer is the rate of removing peers from a store, it's mapped to `store limit <id> <rate> remove-peer` of pd-ctl.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	RemovePeer *float64 `json:"removePeer,omitempty"`
+}
+
+// TiKVIORateLimit configures the IO rate limiter of TiKV
+// +k8s:openapi-gen=true
+type TiKVIORateLimit struct {
+	// MaxBytesPerSec is the maximum IO bandwidth of TiKV, such as "200MiB".
+	// It's mapped to `storage.io-rate-limit.max-bytes-per-sec` and "0MB" means no limit.
+	// +optional
+	MaxBytesPerSec string `json:"maxBytesPerSec,omitempty"`
+
+	// Mode is the kind of the IO operations to limit, such as "write-only", "read-only" and "all".
+	// It's mapped to `storage.io-rate-limit.mode`.
+	// +kubebuilder:validation:Enum:="";"write-only";"read-only";"all"
+	// +optional
+	Mode string `json:"mode,omitempty"`
+
+	// MaintenanceMaxBytesPerSec is the maximum IO bandwidth of TiKV applied online while a BR snapshot backup
+	// or restore of the cluster is running, to protect the latency of the foreground requests.
+	// The configured bandwidth is restored after the backup or restore completes.
+	// +optional
+	MaintenanceMaxBytesPerSec string `json:"maintenanceMaxBytesPerSec,omitempty"`
 }
 
 // TiFlashSpec contains details of TiFlash members
@@ -665,10 +1233,32 @@ type TiFlashSpec struct {
 	// TiFlash supports multiple disks.
 	StorageClaims []StorageClaim `json:"storageClaims"`
 
+	// PVReclaimPolicy is the reclaim policy of the PVs of TiFlash, it overrides spec.pvReclaimPolicy.
+	// +optional
+	PVReclaimPolicy *corev1.PersistentVolumeReclaimPolicy `json:"pvReclaimPolicy,omitempty"`
+
+	// ScaleInPVCRetentionPolicy is how the PVCs left by the scale-in of TiFlash are handled, it overrides
+	// spec.scaleInPVCRetentionPolicy.
+	// +optional
+	// +kubebuilder:validation:Enum=Retain;Delete
+	ScaleInPVCRetentionPolicy *ScaleInPVCRetentionPolicy `json:"scaleInPVCRetentionPolicy,omitempty"`
+
+	// ScaleInPVCDeletionGracePeriod is how long the PVCs left by the scale-in of TiFlash are kept before they're
+	// deleted by the Delete policy, it overrides spec.scaleInPVCDeletionGracePeriod.
+	// +optional
+	ScaleInPVCDeletionGracePeriod *metav1.Duration `json:"scaleInPVCDeletionGracePeriod,omitempty"`
+
 	// Config is the Configuration of TiFlash
 	// +optional
 	Config *TiFlashConfigWraper `json:"config,omitempty"`
 
+	// AdditionalArgs are the command line arguments appended to the start command of TiFlash,
+	// for flags that can not be set in the config file, e.g. "--flag=value".
+	// Arguments conflicting with the flags generated by the operator are rejected.
+	// Only supported by start script v2, it is rejected if spec.startScriptVersion is not v2.
+	// +optional
+	AdditionalArgs []string `json:"additionalArgs,omitempty"`
+
 	// Initializer is the configurations of the init container for TiFlash
 	//
 	// +optional
@@ -684,11 +1274,15 @@ type TiFlashSpec struct {
 
 	// Failover is the configurations of failover
 	// +optional
-	Failover *Failover `json:"failover,omitempty"`
+	Failover *StoreFailover `json:"failover,omitempty"`
 
 	// ScalePolicy is the scale configuration for TiFlash
 	// +optional
 	ScalePolicy ScalePolicy `json:"scalePolicy,omitempty"`
+
+	// PodDisruptionBudget configures the PodDisruptionBudget of the TiFlash pods
+	// +optional
+	PodDisruptionBudget *PodDisruptionBudgetSpec `json:"podDisruptionBudget,omitempty"`
 }
 
 // TiCDCSpec contains details of TiCDC members
@@ -720,6 +1314,13 @@ type TiCDCSpec struct {
 	// +kubebuilder:validation:XPreserveUnknownFields
 	Config *CDCConfigWraper `json:"config,omitempty"`
 
+	// AdditionalArgs are the command line arguments appended to the start command of TiCDC,
+	// for flags that can not be set in the config file, e.g. "--flag=value".
+	// Arguments conflicting with the flags generated by the operator are rejected.
+	// Only supported by start script v2, it is rejected if spec.startScriptVersion is not v2.
+	// +optional
+	AdditionalArgs []string `json:"additionalArgs,omitempty"`
+
 	// StorageVolumes configure additional storage for TiCDC pods.
 	// +optional
 	StorageVolumes []StorageVolume `json:"storageVolumes,omitempty"`
@@ -729,11 +1330,73 @@ type TiCDCSpec struct {
 	// +optional
 	StorageClassName *string `json:"storageClassName,omitempty"`
 
+	// PVReclaimPolicy is the reclaim policy of the PVs of TiCDC, it overrides spec.pvReclaimPolicy.
+	// +optional
+	PVReclaimPolicy *corev1.PersistentVolumeReclaimPolicy `json:"pvReclaimPolicy,omitempty"`
+
+	// ScaleInPVCRetentionPolicy is how the PVCs left by the scale-in of TiCDC are handled, it overrides
+	// spec.scaleInPVCRetentionPolicy.
+	// +optional
+	// +kubebuilder:validation:Enum=Retain;Delete
+	ScaleInPVCRetentionPolicy *ScaleInPVCRetentionPolicy `json:"scaleInPVCRetentionPolicy,omitempty"`
+
+	// ScaleInPVCDeletionGracePeriod is how long the PVCs left by the scale-in of TiCDC are kept before they're
+	// deleted by the Delete policy, it overrides spec.scaleInPVCDeletionGracePeriod.
+	// +optional
+	ScaleInPVCDeletionGracePeriod *metav1.Duration `json:"scaleInPVCDeletionGracePeriod,omitempty"`
+
 	// GracefulShutdownTimeout is the timeout of gracefully shutdown a TiCDC pod.
 	// Encoded in the format of Go Duration.
 	// Defaults to 10m
 	// +optional
 	GracefulShutdownTimeout *metav1.Duration `json:"gracefulShutdownTimeout,omitempty"`
+
+	// PodDisruptionBudget configures the PodDisruptionBudget of the TiCDC pods
+	// +optional
+	PodDisruptionBudget *PodDisruptionBudgetSpec `json:"podDisruptionBudget,omitempty"`
+
+	// Changefeeds are the changefeeds created and reconciled by the operator via the TiCDC API.
+	// A changefeed removed from the list is removed from TiCDC, the changefeeds not created by
+	// the operator are left untouched.
+	// +optional
+	// +listType=map
+	// +listMapKey=id
+	Changefeeds []TiCDCChangefeed `json:"changefeeds,omitempty"`
+}
+
+// TiCDCChangefeed is a changefeed of TiCDC which replicates the data changes to a sink
+type TiCDCChangefeed struct {
+	// ID is the ID of the changefeed
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z0-9]+(\-[a-zA-Z0-9]+)*$`
+	ID string `json:"id"`
+
+	// SinkURI is the URI of the downstream, e.g. mysql://root@downstream-tidb:4000/ or
+	// kafka://kafka:9092/topic?protocol=canal-json
+	// +optional
+	SinkURI string `json:"sinkURI,omitempty"`
+
+	// SinkURISecretRef refers to the key of a secret which contains the sink URI, it's used
+	// instead of SinkURI when the URI contains credentials
+	// +optional
+	SinkURISecretRef *corev1.SecretKeySelector `json:"sinkURISecretRef,omitempty"`
+
+	// StartTs is the TSO from which the changefeed starts to replicate, it only takes effect
+	// when the changefeed is created. Defaults to the current TSO.
+	// +optional
+	StartTs uint64 `json:"startTs,omitempty"`
+
+	// TargetTs is the TSO at which the changefeed stops replicating, 0 means never
+	// +optional
+	TargetTs uint64 `json:"targetTs,omitempty"`
+
+	// FilterRules are the table filter rules of the changefeed, e.g. ["db1.*", "!db1.tmp"].
+	// Defaults to all the tables.
+	// +optional
+	FilterRules []string `json:"filterRules,omitempty"`
+
+	// Paused pauses the changefeed
+	// +optional
+	Paused bool `json:"paused,omitempty"`
 }
 
 // TiCDCConfig is the configuration of tidbcdc
@@ -779,6 +1442,15 @@ type TiProxySpec struct {
 	// +optional
 	TLSClientSecretName *string `json:"tlsClientSecretName,omitempty"`
 
+	// TLSOffload makes TiProxy the TLS offload proxy of TiDB for legacy MySQL clients which can't use TLS.
+	// TiProxy accepts the connections without TLS from the clients, and always connects to TiDB with TLS
+	// by the client certificate managed by the operator. TiDB is configured with
+	// `security.require-secure-transport` so that the clients without TLS can only connect through TiProxy.
+	// It requires `spec.tidb.tlsClient.enabled`.
+	// Optional: Defaults to false
+	// +optional
+	TLSOffload bool `json:"tlsOffload,omitempty"`
+
 	// Base image of the component, image tag is now allowed during validation
 	// +kubebuilder:default=pingcap/tiproxy
 	// +optional
@@ -790,6 +1462,12 @@ type TiProxySpec struct {
 	// +kubebuilder:validation:XPreserveUnknownFields
 	Config *TiProxyConfigWraper `json:"config,omitempty"`
 
+	// AdditionalArgs are the command line arguments appended to the start command of TiProxy,
+	// for flags that can not be set in the config file, e.g. "--flag=value".
+	// Arguments conflicting with the flags generated by the operator are rejected.
+	// +optional
+	AdditionalArgs []string `json:"additionalArgs,omitempty"`
+
 	// StorageVolumes configure additional storage for TiProxy pods.
 	// +optional
 	StorageVolumes []StorageVolume `json:"storageVolumes,omitempty"`
@@ -839,6 +1517,13 @@ type TiDBSpec struct {
 	// +kubebuilder:validation:Minimum=0
 	Replicas int32 `json:"replicas"`
 
+	// OfflineOrdinals are the ordinals of the pods removed when scaling in instead of the pods with the largest
+	// ordinals, e.g. the pods on the nodes being decommissioned, the members are removed gracefully as in the normal scale-in.
+	// The replicas should be decreased by the number of the ordinals, otherwise the pods are replaced by the pods
+	// of new ordinals. They're merged into the delete slots and only work with the AdvancedStatefulSet feature.
+	// +optional
+	OfflineOrdinals []int32 `json:"offlineOrdinals,omitempty"`
+
 	// Base image of the component, image tag is now allowed during validation
 	// +kubebuilder:default=pingcap/tidb
 	// +optional
@@ -886,8 +1571,11 @@ type TiDBSpec struct {
 	// Plugins is a list of plugins that are loaded by TiDB server, empty means plugin disabled
 	// +optional
 	Plugins []string `json:"plugins,omitempty"`
-	// TODO: additional volumes should be used to hold .so plugin binaries.
-	// Because this is not a complete implementation, maybe we can change this without backward compatibility.
+
+	// PluginSource specifies where the .so binaries of the Plugins are loaded from.
+	// If not set, the plugin binaries must already exist in the plugin directory of the TiDB image.
+	// +optional
+	PluginSource *TiDBPluginSource `json:"pluginSource,omitempty"`
 
 	// Config is the Configuration of tidb-servers
 	// +optional
@@ -895,11 +1583,18 @@ type TiDBSpec struct {
 	// +kubebuilder:validation:XPreserveUnknownFields
 	Config *TiDBConfigWraper `json:"config,omitempty"`
 
-	// Lifecycle describes actions that the management system should take in response to container lifecycle
-	// events. For the PostStart and PreStop lifecycle handlers, management of the container blocks
-	// until the action is complete, unless the container process fails, in which case the handler is aborted.
+	// AdditionalArgs are the command line arguments appended to the start command of tidb-server,
+	// for flags that can not be set in the config file, e.g. "--flag=value".
+	// Arguments conflicting with the flags generated by the operator are rejected.
+	// Only supported by start script v2, it is rejected if spec.startScriptVersion is not v2.
 	// +optional
-	Lifecycle *corev1.Lifecycle `json:"lifecycle,omitempty"`
+	AdditionalArgs []string `json:"additionalArgs,omitempty"`
+
+	// PreStopHook enables the preStop hook which waits for the client connections to be closed before the
+	// TiDB container is stopped, e.g. when the node is gracefully shut down by kubelet.
+	// The hook polls the status port of TiDB with curl, which is only shipped in the images since v4.0.9.
+	// +optional
+	PreStopHook *PreStopHookSpec `json:"preStopHook,omitempty"`
 
 	// StorageVolumes configure additional storage for TiDB pods.
 	// +optional
@@ -910,6 +1605,21 @@ type TiDBSpec struct {
 	// +optional
 	StorageClassName *string `json:"storageClassName,omitempty"`
 
+	// PVReclaimPolicy is the reclaim policy of the PVs of TiDB, it overrides spec.pvReclaimPolicy.
+	// +optional
+	PVReclaimPolicy *corev1.PersistentVolumeReclaimPolicy `json:"pvReclaimPolicy,omitempty"`
+
+	// ScaleInPVCRetentionPolicy is how the PVCs left by the scale-in of TiDB are handled, it overrides
+	// spec.scaleInPVCRetentionPolicy.
+	// +optional
+	// +kubebuilder:validation:Enum=Retain;Delete
+	ScaleInPVCRetentionPolicy *ScaleInPVCRetentionPolicy `json:"scaleInPVCRetentionPolicy,omitempty"`
+
+	// ScaleInPVCDeletionGracePeriod is how long the PVCs left by the scale-in of TiDB are kept before they're
+	// deleted by the Delete policy, it overrides spec.scaleInPVCDeletionGracePeriod.
+	// +optional
+	ScaleInPVCDeletionGracePeriod *metav1.Duration `json:"scaleInPVCDeletionGracePeriod,omitempty"`
+
 	// Initializer is the init configurations of TiDB
 	//
 	// +optional
@@ -921,12 +1631,279 @@ type TiDBSpec struct {
 	// Only v6.6.0+ supports this feature.
 	// +optional
 	BootstrapSQLConfigMapName *string `json:"bootstrapSQLConfigMapName,omitempty"`
+
+	// Binding publishes the connection info of TiDB in a Secret following the Service Binding spec,
+	// so that applications can consume it without hardcoding the DNS name of the TiDB service.
+	// Optional: No binding Secret is created by default.
+	// +optional
+	Binding *TiDBBindingSpec `json:"binding,omitempty"`
+
+	// UpgradePolicy is the policy to upgrade the TiDB pods, `Sequential` upgrades the pods one by one
+	// and `Parallel` upgrades at most MaxUnavailable pods at once.
+	// Optional: Defaults to `Sequential`
+	// +kubebuilder:validation:Enum:="";"Sequential";"Parallel"
+	// +optional
+	UpgradePolicy TiDBUpgradePolicy `json:"upgradePolicy,omitempty"`
+
+	// MaxUnavailable is the max number of the TiDB pods upgraded at once when UpgradePolicy is `Parallel`,
+	// it's an absolute number or a percentage of the replicas, which is rounded up.
+	// Optional: Defaults to 25%
+	// +optional
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+
+	// CanaryUpgrade upgrades the TiDB pods in steps, the pods upgraded in each step are evaluated for a soak
+	// period before the upgrade advances, and they're rolled back on regression.
+	// It's ignored if UpgradePolicy is `Parallel`.
+	// Optional: Defaults to nil
+	// +optional
+	CanaryUpgrade *CanaryUpgrade `json:"canaryUpgrade,omitempty"`
+
+	// LoadAwareRestart delays the restart of each TiDB pod in an upgrade to a moment when the load of
+	// the pod is low, which is queried from Prometheus.
+	// It's ignored if UpgradePolicy is `Parallel`.
+	// Optional: Defaults to nil
+	// +optional
+	LoadAwareRestart *LoadAwareRestart `json:"loadAwareRestart,omitempty"`
+
+	// PodDisruptionBudget configures the PodDisruptionBudget of the TiDB pods
+	// +optional
+	PodDisruptionBudget *PodDisruptionBudgetSpec `json:"podDisruptionBudget,omitempty"`
+
+	// Ports are the ports listened by TiDB, which are propagated to the config, the probes and the services.
+	// The certificates don't need to be reissued for the non-default ports since the SANs don't contain ports.
+	// +optional
+	Ports *TiDBPorts `json:"ports,omitempty"`
+}
+
+// TiDBPorts are the ports listened by TiDB
+// +k8s:openapi-gen=true
+type TiDBPorts struct {
+	// Server is the port of the MySQL protocol
+	// Optional: Defaults to 4000
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	Server *int32 `json:"server,omitempty"`
+
+	// Status is the port of the status and metrics API
+	// Optional: Defaults to 10080
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	Status *int32 `json:"status,omitempty"`
+}
+
+// PodDisruptionBudgetSpec is the spec of the PodDisruptionBudget of the pods of a component
+// +k8s:openapi-gen=true
+type PodDisruptionBudgetSpec struct {
+	// Enabled indicates whether to create the PodDisruptionBudget, the existing one is deleted if it's false.
+	// Optional: Defaults to true
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// MaxUnavailable is the max number of the pods unavailable during voluntary disruptions, e.g. node drains,
+	// it's an absolute number or a percentage of the replicas.
+	// Optional: Defaults to the number of the members PD can lose without breaking the quorum (at least 1),
+	// 1 for TiKV, TiFlash and TiCDC, and 25% for TiDB
+	// +optional
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+}
+
+// TiDBUpgradePolicy is the policy to upgrade the TiDB pods
+type TiDBUpgradePolicy string
+
+const (
+	// TiDBUpgradePolicySequential upgrades the TiDB pods one by one
+	TiDBUpgradePolicySequential TiDBUpgradePolicy = "Sequential"
+	// TiDBUpgradePolicyParallel upgrades at most MaxUnavailable TiDB pods at once
+	TiDBUpgradePolicyParallel TiDBUpgradePolicy = "Parallel"
+)
+
+// EvictLeaderTimeoutPolicy is what to do when the leader eviction before the upgrade times out
+type EvictLeaderTimeoutPolicy string
+
+const (
+	// EvictLeaderTimeoutPolicyContinue upgrades the pod with the remaining leaders
+	EvictLeaderTimeoutPolicyContinue EvictLeaderTimeoutPolicy = "Continue"
+	// EvictLeaderTimeoutPolicyBlock keeps waiting for the leaders to be evicted
+	EvictLeaderTimeoutPolicyBlock EvictLeaderTimeoutPolicy = "Block"
+)
+
+// CanaryUpgrade is the strategy to upgrade the pods of a component in steps. After the pods of a step
+// are upgraded, they're evaluated for SoakDuration, and the StatefulSet partition is lowered to upgrade
+// the next step if no regression is found. On regression, the upgraded pods are rolled back to the
+// current revision of the StatefulSet, and the upgrade is held until the pod template changes again.
+//
+// +k8s:openapi-gen=true
+type CanaryUpgrade struct {
+	// Step is the number of the pods upgraded before each soak period.
+	// Optional: Defaults to 1
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Step *int32 `json:"step,omitempty"`
+
+	// SoakDuration is how long the upgraded pods are evaluated before the upgrade advances.
+	// Optional: Defaults to 10m
+	// +optional
+	SoakDuration *metav1.Duration `json:"soakDuration,omitempty"`
+
+	// MaxRestarts is the max number of the container restarts of an upgraded pod.
+	// Optional: Defaults to 0
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxRestarts *int32 `json:"maxRestarts,omitempty"`
+
+	// MaxErrorRate is the max ratio of the failed SQL statements to all the statements executed by the
+	// upgraded TiDB pods since they started, e.g. 0.01. It's only evaluated for TiDB.
+	// Optional: The error rate is not evaluated by default
+	// +optional
+	MaxErrorRate *float64 `json:"maxErrorRate,omitempty"`
+
+	// DisableRollback pauses the upgrade instead of rolling back the upgraded pods on regression.
+	// Optional: Defaults to false
+	// +optional
+	DisableRollback bool `json:"disableRollback,omitempty"`
+}
+
+// CanaryUpgradePhase is the phase of the canary upgrade of a component
+type CanaryUpgradePhase string
+
+const (
+	// CanaryUpgradeUpgrading means the pods of the current step are being upgraded
+	CanaryUpgradeUpgrading CanaryUpgradePhase = "Upgrading"
+	// CanaryUpgradeSoaking means the upgraded pods are being evaluated
+	CanaryUpgradeSoaking CanaryUpgradePhase = "Soaking"
+	// CanaryUpgradeRolledBack means the upgraded pods are rolled back on regression
+	CanaryUpgradeRolledBack CanaryUpgradePhase = "RolledBack"
+	// CanaryUpgradePaused means the upgrade is paused on regression
+	CanaryUpgradePaused CanaryUpgradePhase = "Paused"
+)
+
+// CanaryUpgradeStatus is the status of the canary upgrade of a component
+type CanaryUpgradeStatus struct {
+	// Phase is the phase of the canary upgrade.
+	Phase CanaryUpgradePhase `json:"phase,omitempty"`
+	// Revision is the StatefulSet revision which the pods are upgraded to.
+	Revision string `json:"revision,omitempty"`
+	// TemplateHash is the hash of the pod spec which the pods are upgraded to.
+	TemplateHash string `json:"templateHash,omitempty"`
+	// Partition is the StatefulSet partition of the last soak period.
+	Partition int32 `json:"partition,omitempty"`
+	// SoakStartTime is the start time of the current soak period.
+	// +nullable
+	SoakStartTime *metav1.Time `json:"soakStartTime,omitempty"`
+	// Message is the regression found by the canary upgrade.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// LoadAwareRestart is the strategy to restart the pods at a low-traffic moment. The restart of a pod is
+// delayed until its load is below all the thresholds, or until MaxDelay passes since the restart is
+// delayed for the first time. The pods are only restarted inside the maintenance window if it's set.
+//
+// +k8s:openapi-gen=true
+type LoadAwareRestart struct {
+	// PrometheusURL is the URL of the Prometheus which scrapes the metrics of the pods, e.g. the
+	// Prometheus of the TidbMonitor `http://basic-prometheus.tidb-cluster:9090`
+	PrometheusURL string `json:"prometheusURL"`
+
+	// MaxConnections is the max number of the active client connections of a pod to restart it.
+	// Optional: The connections are not evaluated by default
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxConnections *int32 `json:"maxConnections,omitempty"`
+
+	// MaxQPS is the max number of the SQL statements executed by a pod per second to restart it.
+	// Optional: The QPS is not evaluated by default
+	// +optional
+	MaxQPS *float64 `json:"maxQPS,omitempty"`
+
+	// MaxDelay is the max time the restart of a pod is delayed for its load, the pod is restarted
+	// regardless of the load after it.
+	// Optional: Defaults to 1h
+	// +optional
+	MaxDelay *metav1.Duration `json:"maxDelay,omitempty"`
+
+	// Window is the recurring maintenance window in which the pods are restarted.
+	// Optional: The pods are restarted at any time by default
+	// +optional
+	Window *MaintenanceWindow `json:"window,omitempty"`
+}
+
+// MaintenanceWindow is a recurring time window for the maintenance operations
+//
+// +k8s:openapi-gen=true
+type MaintenanceWindow struct {
+	// Start is the cron expression in UTC when the window starts, e.g. "0 2 * * *"
+	Start string `json:"start"`
+	// End is the cron expression in UTC when the window ends, e.g. "0 5 * * *"
+	End string `json:"end"`
+}
+
+// LoadAwareRestartStatus is the status of the restart delayed for the load of a pod
+type LoadAwareRestartStatus struct {
+	// Pod is the name of the pod whose restart is delayed.
+	Pod string `json:"pod"`
+	// DelayStartTime is the time when the restart of the pod is delayed for the first time.
+	DelayStartTime metav1.Time `json:"delayStartTime"`
+	// Message explains why the restart is delayed.
+	// +optional
+	Message string `json:"message,omitempty"`
 }
 
 type TiDBInitializer struct {
 	CreatePassword bool `json:"createPassword,omitempty"`
 }
 
+// TiDBBindingSpec describes the binding Secret of TiDB.
+// The Secret has the type `servicebinding.io/mysql` and contains the keys `type`, `provider`, `host` and `port`,
+// the key `certificates` with the CA of the TiDB server if TLS is enabled for MySQL clients,
+// and the keys `username` and `password` if User is set.
+type TiDBBindingSpec struct {
+	// SecretName is the name of the binding Secret.
+	// Optional: Defaults to <clusterName>-tidb-binding
+	// +optional
+	SecretName string `json:"secretName,omitempty"`
+
+	// User whose credentials are published in the binding Secret. The password is read from the
+	// password Secret of the TidbInitializer of the cluster, or from the Secret of the random root
+	// password if `spec.tidb.initializer.createPassword` is enabled.
+	// Optional: No credentials are published by default.
+	// +optional
+	User string `json:"user,omitempty"`
+}
+
+// TiDBPluginSource is the source of the TiDB plugin binaries, exactly one of
+// Image and PersistentVolumeClaim must be set.
+// +k8s:openapi-gen=true
+type TiDBPluginSource struct {
+	// Image is an image that contains the plugin binaries, they are copied into
+	// the plugin directory of TiDB by an init container before TiDB starts.
+	// The image must contain a shell and the `cp` command.
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// ImagePullPolicy of the plugin image.
+	// +optional
+	ImagePullPolicy corev1.PullPolicy `json:"imagePullPolicy,omitempty"`
+
+	// Path is the directory in the plugin image that contains the plugin binaries.
+	// Optional: Defaults to /plugins
+	// +optional
+	Path string `json:"path,omitempty"`
+
+	// PersistentVolumeClaim is an existing PVC that contains the plugin binaries,
+	// it is mounted read-only as the plugin directory of TiDB.
+	// +optional
+	PersistentVolumeClaim *corev1.PersistentVolumeClaimVolumeSource `json:"persistentVolumeClaim,omitempty"`
+
+	// TiDBVersion is the TiDB version the plugins are built with.
+	// TiDB refuses to load plugins built with a different version, so if it is set,
+	// it must equal the version of TiDB.
+	// +optional
+	TiDBVersion string `json:"tidbVersion,omitempty"`
+}
+
 const (
 	// TCPProbeType represents the readiness prob method with TCP
 	TCPProbeType string = "tcp"
@@ -981,12 +1958,34 @@ type PumpSpec struct {
 	// +optional
 	StorageClassName *string `json:"storageClassName,omitempty"`
 
+	// PVReclaimPolicy is the reclaim policy of the PVs of Pump, it overrides spec.pvReclaimPolicy.
+	// +optional
+	PVReclaimPolicy *corev1.PersistentVolumeReclaimPolicy `json:"pvReclaimPolicy,omitempty"`
+
+	// ScaleInPVCRetentionPolicy is how the PVCs left by the scale-in of Pump are handled, it overrides
+	// spec.scaleInPVCRetentionPolicy.
+	// +optional
+	// +kubebuilder:validation:Enum=Retain;Delete
+	ScaleInPVCRetentionPolicy *ScaleInPVCRetentionPolicy `json:"scaleInPVCRetentionPolicy,omitempty"`
+
+	// ScaleInPVCDeletionGracePeriod is how long the PVCs left by the scale-in of Pump are kept before they're
+	// deleted by the Delete policy, it overrides spec.scaleInPVCDeletionGracePeriod.
+	// +optional
+	ScaleInPVCDeletionGracePeriod *metav1.Duration `json:"scaleInPVCDeletionGracePeriod,omitempty"`
+
 	// The configuration of Pump cluster.
 	// +optional
 	// +kubebuilder:validation:Schemaless
 	// +kubebuilder:validation:XPreserveUnknownFields
 	Config *config.GenericConfig `json:"config,omitempty"`
 
+	// AdditionalArgs are the command line arguments appended to the start command of Pump,
+	// for flags that can not be set in the config file, e.g. "--flag=value".
+	// Arguments conflicting with the flags generated by the operator are rejected.
+	// Only supported by start script v2, it is rejected if spec.startScriptVersion is not v2.
+	// +optional
+	AdditionalArgs []string `json:"additionalArgs,omitempty"`
+
 	// +k8s:openapi-gen=false
 	// For backward compatibility with helm chart
 	SetTimeZone *bool `json:"setTimeZone,omitempty"`
@@ -1079,6 +2078,12 @@ type ComponentSpec struct {
 	// +optional
 	Labels map[string]string `json:"labels,omitempty"`
 
+	// Metadata for the resources generated for the component. Merge into the cluster-level metadata if non-empty.
+	// Only takes effect for the components of TiDB cluster.
+	// Optional: Defaults to cluster-level setting
+	// +optional
+	Metadata *ObjectMetadata `json:"metadata,omitempty"`
+
 	// Tolerations of the component. Override the cluster-level tolerations if non-empty
 	// Optional: Defaults to cluster-level setting
 	// +optional
@@ -1179,6 +2184,62 @@ type ComponentSpec struct {
 	// the default behavior is like setting type as "tcp"
 	// +optional
 	ReadinessProbe *Probe `json:"readinessProbe,omitempty"`
+
+	// PodTemplatePatches are applied in order to the pod template generated by
+	// TiDB Operator as the last step of rendering the StatefulSet. It is an
+	// escape hatch for fields that are not modeled by the CRD yet, the patched
+	// fields are not validated by TiDB Operator.
+	// +optional
+	PodTemplatePatches []PodTemplatePatch `json:"podTemplatePatches,omitempty"`
+
+	// Lifecycle describes actions that the management system should take in response to the lifecycle
+	// events of the main container of the component. For the PostStart and PreStop lifecycle handlers,
+	// management of the container blocks until the action is complete, unless the container process fails,
+	// in which case the handler is aborted.
+	// If the preStop hook of TiDB Operator is enabled by preStopHook, it's composed with Lifecycle.PreStop
+	// instead of being replaced: the command of Lifecycle.PreStop runs first and then the hook of TiDB Operator,
+	// so Lifecycle.PreStop must be an exec handler in this case.
+	// +optional
+	Lifecycle *corev1.Lifecycle `json:"lifecycle,omitempty"`
+}
+
+// ObjectMetadata is the custom labels and annotations of the resources generated by TiDB Operator.
+// The labels and annotations set by TiDB Operator take precedence over the ones of the component,
+// and the ones of the component take precedence over the ones of the cluster. Changing the metadata
+// of the Pods triggers a rolling update of the component.
+// +k8s:openapi-gen=true
+type ObjectMetadata struct {
+	// Labels added to the generated resources
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Annotations added to the generated resources
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// PodTemplatePatchType is the type of a PodTemplatePatch
+type PodTemplatePatchType string
+
+const (
+	// PodTemplatePatchTypeStrategicMerge means the patch is a strategic merge patch
+	PodTemplatePatchTypeStrategicMerge PodTemplatePatchType = "strategic"
+	// PodTemplatePatchTypeJSON means the patch is a RFC6902 JSON patch
+	PodTemplatePatchTypeJSON PodTemplatePatchType = "json"
+)
+
+// PodTemplatePatch is a patch applied to the pod template of a component
+// +k8s:openapi-gen=true
+type PodTemplatePatch struct {
+	// Type of the patch, `strategic` for a strategic merge patch of
+	// corev1.PodTemplateSpec or `json` for a RFC6902 JSON patch.
+	// Optional: Defaults to strategic
+	// +kubebuilder:validation:Enum=strategic;json
+	// +optional
+	Type PodTemplatePatchType `json:"type,omitempty"`
+
+	// Patch is the content of the patch in JSON or YAML format
+	Patch string `json:"patch"`
 }
 
 // ServiceSpec specifies the service object in k8s
@@ -1215,62 +2276,249 @@ type ServiceSpec struct {
 	// +kubebuilder:validation:Minimum=1
 	// +kubebuilder:validation:Maximum=65535
 	// +optional
-	Port *int32 `json:"port,omitempty"`
+	Port *int32 `json:"port,omitempty"`
+
+	// LoadBalancerSourceRanges is the loadBalancerSourceRanges of service
+	// If specified and supported by the platform, this will restrict traffic through the cloud-provider
+	// load-balancer will be restricted to the specified client IPs. This field will be ignored if the
+	// cloud-provider does not support the feature."
+	// More info: https://kubernetes.io/docs/concepts/services-networking/service/#aws-nlb-support
+	// Optional: Defaults to omitted
+	// +optional
+	LoadBalancerSourceRanges []string `json:"loadBalancerSourceRanges,omitempty"`
+
+	// IPFamilyPolicy is the ipFamilyPolicy of service, it overrides the PreferDualStack policy set by spec.preferIPv6.
+	// +optional
+	IPFamilyPolicy *corev1.IPFamilyPolicyType `json:"ipFamilyPolicy,omitempty"`
+
+	// IPFamilies is the ipFamilies of service, e.g. ["IPv6", "IPv4"] for a dual-stack service preferring IPv6.
+	// +optional
+	IPFamilies []corev1.IPFamily `json:"ipFamilies,omitempty"`
+}
+
+// TiDBServiceSpec defines `.tidb.service` field of `TidbCluster.spec`.
+// +k8s:openapi-gen=true
+type TiDBServiceSpec struct {
+	// +k8s:openapi-gen=false
+	ServiceSpec `json:",inline"`
+
+	// ExternalTrafficPolicy of the service
+	// Optional: Defaults to omitted
+	// +optional
+	ExternalTrafficPolicy *corev1.ServiceExternalTrafficPolicyType `json:"externalTrafficPolicy,omitempty"`
+
+	// Whether expose the status port
+	// Optional: Defaults to true
+	// +optional
+	ExposeStatus *bool `json:"exposeStatus,omitempty"`
+
+	// Expose the tidb cluster mysql port to MySQLNodePort
+	// Optional: Defaults to 0
+	// +optional
+	MySQLNodePort *int `json:"mysqlNodePort,omitempty"`
+
+	// Expose the tidb status node port to StatusNodePort
+	// Optional: Defaults to 0
+	// +optional
+	StatusNodePort *int `json:"statusNodePort,omitempty"`
+
+	// Expose additional ports for TiDB
+	// Optional: Defaults to omitted
+	// +optional
+	AdditionalPorts []corev1.ServicePort `json:"additionalPorts,omitempty"`
+}
+
+// (Deprecated) Service represent service type used in TidbCluster
+// +k8s:openapi-gen=false
+type Service struct {
+	Name string `json:"name,omitempty"`
+	Type string `json:"type,omitempty"`
+}
+
+// SuspendAction defines the suspend actions for a component.
+//
+// +k8s:openapi-gen=true
+type SuspendAction struct {
+	SuspendStatefulSet bool `json:"suspendStatefulSet,omitempty"`
+}
+
+// StandbySpec defines how a warm standby TidbCluster is prepared.
+//
+// +k8s:openapi-gen=true
+type StandbySpec struct {
+	// PrePullImages pre-pulls the image of each component on the nodes the component can be scheduled on
+	// according to its nodeSelector and tolerations, by a DaemonSet named <cluster>-<component>-standby-image-puller.
+	// +optional
+	PrePullImages bool `json:"prePullImages,omitempty"`
+}
+
+// HTTPProxyConfig is the proxy for the egress HTTP(S) requests, it follows the semantics
+// of the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables.
+//
+// +k8s:openapi-gen=true
+type HTTPProxyConfig struct {
+	// HTTPProxy is the proxy for HTTP requests, e.g. http://proxy.example.com:3128
+	// +optional
+	HTTPProxy string `json:"httpProxy,omitempty"`
+	// HTTPSProxy is the proxy for HTTPS requests, e.g. http://proxy.example.com:3128
+	// +optional
+	HTTPSProxy string `json:"httpsProxy,omitempty"`
+	// NoProxy is a comma-separated list of hosts, domains, IPs or CIDRs that are accessed without the proxy.
+	// It should include the in-cluster domains, e.g. .svc,.cluster.local, to keep the requests to the
+	// components of the cluster from going through the proxy.
+	// +optional
+	NoProxy string `json:"noProxy,omitempty"`
+}
+
+// HealthProbeSpec describes the synthetic SQL probe of a TidbCluster. The probe runs in a Deployment
+// managed by the operator, it writes a row in a transaction and reads it back through the TiDB service.
+type HealthProbeSpec struct {
+	// Image of the probe, defaults to the image of the discovery.
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// IntervalSeconds is the interval between two probes.
+	// Defaults to 30.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	IntervalSeconds *int32 `json:"intervalSeconds,omitempty"`
+
+	// TimeoutSeconds is the timeout of a probe.
+	// Defaults to 10.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+
+	// Database where the probe table is created.
+	// Defaults to tidb_operator.
+	// +optional
+	Database string `json:"database,omitempty"`
+
+	// User to connect to TiDB.
+	// Defaults to root.
+	// +optional
+	User string `json:"user,omitempty"`
+
+	// SecretName is the name of the secret containing the password of the user in the key `password`.
+	// The user has no password if it's not set.
+	// +optional
+	SecretName string `json:"secretName,omitempty"`
 
-	// LoadBalancerSourceRanges is the loadBalancerSourceRanges of service
-	// If specified and supported by the platform, this will restrict traffic through the cloud-provider
-	// load-balancer will be restricted to the specified client IPs. This field will be ignored if the
-	// cloud-provider does not support the feature."
-	// More info: https://kubernetes.io/docs/concepts/services-networking/service/#aws-nlb-support
-	// Optional: Defaults to omitted
+	// Resources of the probe.
 	// +optional
-	LoadBalancerSourceRanges []string `json:"loadBalancerSourceRanges,omitempty"`
+	corev1.ResourceRequirements `json:",inline"`
 }
 
-// TiDBServiceSpec defines `.tidb.service` field of `TidbCluster.spec`.
-// +k8s:openapi-gen=true
-type TiDBServiceSpec struct {
-	// +k8s:openapi-gen=false
-	ServiceSpec `json:",inline"`
+// TopologyZones declares the per-zone replica quotas of the components of a TidbCluster.
+// The keys of the quotas are the values of the topology key on the nodes, e.g. {"us-east-1a": 2, "us-east-1b": 1}.
+type TopologyZones struct {
+	// TopologyKey is the node label of the zones.
+	// Defaults to topology.kubernetes.io/zone.
+	// +optional
+	TopologyKey string `json:"topologyKey,omitempty"`
 
-	// ExternalTrafficPolicy of the service
-	// Optional: Defaults to omitted
+	// SetLocationLabel makes the operator prepend the zone to the location labels of PD if it's missing,
+	// so that the replicas of the regions are spread across the zones. Changing the location labels of
+	// a running cluster makes PD reschedule the regions, so it's not done unless this is set.
 	// +optional
-	ExternalTrafficPolicy *corev1.ServiceExternalTrafficPolicyType `json:"externalTrafficPolicy,omitempty"`
+	SetLocationLabel bool `json:"setLocationLabel,omitempty"`
 
-	// Whether expose the status port
-	// Optional: Defaults to true
+	// PD is the replicas of PD in each zone.
 	// +optional
-	ExposeStatus *bool `json:"exposeStatus,omitempty"`
+	PD map[string]int32 `json:"pd,omitempty"`
 
-	// Expose the tidb cluster mysql port to MySQLNodePort
-	// Optional: Defaults to 0
+	// TiKV is the replicas of TiKV in each zone.
 	// +optional
-	MySQLNodePort *int `json:"mysqlNodePort,omitempty"`
+	TiKV map[string]int32 `json:"tikv,omitempty"`
 
-	// Expose the tidb status node port to StatusNodePort
-	// Optional: Defaults to 0
+	// TiDB is the replicas of TiDB in each zone.
 	// +optional
-	StatusNodePort *int `json:"statusNodePort,omitempty"`
+	TiDB map[string]int32 `json:"tidb,omitempty"`
 
-	// Expose additional ports for TiDB
-	// Optional: Defaults to omitted
+	// TiFlash is the replicas of TiFlash in each zone.
 	// +optional
-	AdditionalPorts []corev1.ServicePort `json:"additionalPorts,omitempty"`
+	TiFlash map[string]int32 `json:"tiflash,omitempty"`
 }
 
-// (Deprecated) Service represent service type used in TidbCluster
-// +k8s:openapi-gen=false
-type Service struct {
-	Name string `json:"name,omitempty"`
-	Type string `json:"type,omitempty"`
+// HealthProbeStatus is the result of the synthetic SQL probe of a TidbCluster.
+type HealthProbeStatus struct {
+	// Success is whether the last probe succeeded.
+	Success bool `json:"success"`
+	// LatencyMilliseconds is the latency of the last probe.
+	// +optional
+	LatencyMilliseconds int64 `json:"latencyMilliseconds,omitempty"`
+	// Message is the error of the last failed probe.
+	// +optional
+	Message string `json:"message,omitempty"`
+	// ConsecutiveFailures is the number of the consecutive failed probes.
+	// +optional
+	ConsecutiveFailures int32 `json:"consecutiveFailures,omitempty"`
+	// LastProbeTime is the time of the last probe.
+	// +nullable
+	LastProbeTime metav1.Time `json:"lastProbeTime,omitempty"`
 }
 
-// SuspendAction defines the suspend actions for a component.
-//
-// +k8s:openapi-gen=true
-type SuspendAction struct {
-	SuspendStatefulSet bool `json:"suspendStatefulSet,omitempty"`
+// TeardownStatus is the progress of the teardown of a TidbCluster.
+type TeardownStatus struct {
+	// Component is the component being torn down.
+	// +optional
+	Component MemberType `json:"component,omitempty"`
+	// DeletingPVCs is the number of the PVCs of the component being deleted.
+	// +optional
+	DeletingPVCs int32 `json:"deletingPVCs,omitempty"`
+	// RemainingPVCs is the number of the PVCs of the cluster not deleted yet, including the ones being deleted.
+	// +optional
+	RemainingPVCs int32 `json:"remainingPVCs,omitempty"`
+	// StartTime is the time when the teardown started.
+	// +nullable
+	StartTime metav1.Time `json:"startTime,omitempty"`
+}
+
+// PodRestart is the cause of a restart of a pod initiated by the operator.
+type PodRestart struct {
+	// Reason is the machine-readable cause of the restart, one of upgrade, config-change,
+	// user-requested, spec-change and failover.
+	Reason string `json:"reason"`
+	// Detail is the detail of the cause, e.g. the new image for upgrade and the hash of
+	// the new config for config-change.
+	// +optional
+	Detail string `json:"detail,omitempty"`
+	// PodUID is the UID of the pod restarted, the next pod of the same name is annotated with the cause.
+	PodUID types.UID `json:"podUID"`
+	// Time is the time when the restart was initiated.
+	// +nullable
+	Time metav1.Time `json:"time,omitempty"`
+}
+
+// PendingScaleIn is a scale-in of a pod which needs the external approval.
+type PendingScaleIn struct {
+	// Component of the pod.
+	Component MemberType `json:"component"`
+	// RequestTime is the time when the scale-in was requested.
+	// +nullable
+	RequestTime metav1.Time `json:"requestTime,omitempty"`
+	// Approved is whether the scale-in is approved, the approval is kept until the scale-in is done.
+	// +optional
+	Approved bool `json:"approved,omitempty"`
+	// Message is the reason the scale-in is not approved yet.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// PendingChanges is the spec changes of a component that are held by the operator
+// because they would otherwise interleave with an in-progress operation.
+// They are applied once the operation completes, or immediately if the TidbCluster
+// is annotated with `tidb.pingcap.com/apply-pending-changes: "true"`. The annotation
+// is removed by the operator after the pending changes are applied.
+type PendingChanges struct {
+	// Operation is the in-progress operation that holds the changes.
+	Operation MemberPhase `json:"operation"`
+	// Since is the time when the changes were first held.
+	// +nullable
+	Since metav1.Time `json:"since,omitempty"`
+	// Message is a human readable description of the held changes.
+	// +optional
+	Message string `json:"message,omitempty"`
 }
 
 // PDStatus is PD status
@@ -1289,12 +2537,44 @@ type PDStatus struct {
 	Image           string                     `json:"image,omitempty"`
 	// Volumes contains the status of all volumes.
 	Volumes map[StorageVolumeName]*StorageVolumeStatus `json:"volumes,omitempty"`
+	// PendingChanges records the spec changes held until the in-progress upgrade or scale completes.
+	// +optional
+	PendingChanges *PendingChanges `json:"pendingChanges,omitempty"`
+	// Disruptions records the in-flight voluntary disruptions of PD pods, the key is the pod name.
+	// A new voluntary disruption is refused if it leaves fewer than quorum healthy PD members.
+	// +optional
+	Disruptions map[string]*PDDisruptionStatus `json:"disruptions,omitempty"`
+	// VolReplaceInProgress is true if the volumes of PD are being replaced.
+	// +optional
+	VolReplaceInProgress bool `json:"volReplaceInProgress,omitempty"`
 	// Represents the latest available observations of a component's state.
 	// +optional
 	// +nullable
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
+// PDDisruptionSource is the source of a voluntary disruption of PD pod
+type PDDisruptionSource string
+
+const (
+	// PDDisruptionSourceUpgrade means the pod is restarted by the rolling upgrade
+	PDDisruptionSourceUpgrade PDDisruptionSource = "upgrade"
+	// PDDisruptionSourceScaleIn means the pod is removed by the scale-in
+	PDDisruptionSourceScaleIn PDDisruptionSource = "scale-in"
+	// PDDisruptionSourcePodAnnotation means the pod is deleted as requested by the annotation
+	// `tidb.pingcap.com/pd-transfer-leader: delete-pod`
+	PDDisruptionSourcePodAnnotation PDDisruptionSource = "pod-annotation"
+)
+
+// PDDisruptionStatus is the status of an in-flight voluntary disruption of PD pod
+type PDDisruptionStatus struct {
+	Source    PDDisruptionSource `json:"source"`
+	BeginTime metav1.Time        `json:"beginTime"`
+	// PodUID is the UID of the pod when the disruption begins, the disruption
+	// finishes after the pod is recreated and its member is healthy again.
+	PodUID types.UID `json:"podUID,omitempty"`
+}
+
 // PDMember is PD member
 type PDMember struct {
 	Name string `json:"name"`
@@ -1345,12 +2625,51 @@ type TiDBStatus struct {
 	PasswordInitialized      *bool                        `json:"passwordInitialized,omitempty"`
 	// Volumes contains the status of all volumes.
 	Volumes map[StorageVolumeName]*StorageVolumeStatus `json:"volumes,omitempty"`
+	// PendingChanges records the spec changes held until the in-progress upgrade or scale completes.
+	// +optional
+	PendingChanges *PendingChanges `json:"pendingChanges,omitempty"`
+	// ServerTLS is the status of reloading the TiDB server-side certificate.
+	// +optional
+	ServerTLS *TiDBServerTLSStatus `json:"serverTLS,omitempty"`
+	// ServerLabels is the summary of syncing the server labels of the TiDB members.
+	// +optional
+	ServerLabels *TiDBServerLabelsStatus `json:"serverLabels,omitempty"`
+	// CanaryUpgrade is the status of the canary upgrade of TiDB.
+	// +optional
+	CanaryUpgrade *CanaryUpgradeStatus `json:"canaryUpgrade,omitempty"`
+	// LoadAwareRestart is the status of the restart of the TiDB pod delayed for its load.
+	// +optional
+	LoadAwareRestart *LoadAwareRestartStatus `json:"loadAwareRestart,omitempty"`
 	// Represents the latest available observations of a component's state.
 	// +optional
 	// +nullable
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
+// TiDBServerTLSStatus is the status of reloading the TiDB server-side certificate.
+type TiDBServerTLSStatus struct {
+	// SecretHash is the hash of the data of the TiDB server-side certificate Secret.
+	SecretHash string `json:"secretHash,omitempty"`
+	// LastChangeTime is the time when the change of the Secret is observed.
+	// +nullable
+	LastChangeTime metav1.Time `json:"lastChangeTime,omitempty"`
+	// Reloaded indicates whether the certificate has been reloaded by all the healthy TiDB members.
+	Reloaded bool `json:"reloaded,omitempty"`
+}
+
+// TiDBServerLabelsStatus is the summary of syncing the server labels, e.g. the zone, of the healthy
+// TiDB members to the location labels of their nodes.
+type TiDBServerLabelsStatus struct {
+	// SyncedMembers is the number of the TiDB members whose server labels are set.
+	SyncedMembers int32 `json:"syncedMembers,omitempty"`
+	// OutOfSyncMembers are the healthy TiDB members whose server labels failed to be set.
+	// +optional
+	OutOfSyncMembers []string `json:"outOfSyncMembers,omitempty"`
+	// LastTransitionTime is the last time the summary changed.
+	// +nullable
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+}
+
 // TiDBMember is TiDB member
 type TiDBMember struct {
 	Name   string `json:"name"`
@@ -1382,6 +2701,9 @@ const (
 	PDLeaderTransferAnnKey = "tidb.pingcap.com/pd-transfer-leader"
 	// TiDBGracefulShutdownAnnKey is the annotation key to graceful shutdown tidb pod by user.
 	TiDBGracefulShutdownAnnKey = "tidb.pingcap.com/tidb-graceful-shutdown"
+	// PreStopBeginTimeAnnKey is the annotation key to record the time when the preStop hook of a pod begins,
+	// the annotations added by the preStop hook are removed after the pod is restarted.
+	PreStopBeginTimeAnnKey = "tidb.pingcap.com/pre-stop-begin-time"
 )
 
 // The `Value` of annotation controls the behavior when the leader count drops to zero, the valid value is one of:
@@ -1423,6 +2745,43 @@ const (
 	// Normally we only allow one pod evicts leader.
 	// TODO: set this condition before all leader eviction behavior
 	ConditionTypeLeaderEvicting = "LeaderEvicting"
+
+	// It means whether the leader eviction before the upgrade of a TiKV pod times out
+	ConditionTypeLeaderEvictionStalled = "LeaderEvictionStalled"
+
+	// It means whether the online config or schedulers of PD differ from the declared ones
+	ConditionTypeConfigDrifted = "ConfigDrifted"
+
+	// It means whether the data directory pre-check of some pods fails
+	ConditionTypeDataDirPreCheckFailed = "DataDirPreCheckFailed"
+)
+
+// The reasons of the ConfigDrifted condition
+const (
+	// ConfigDriftReasonDrifted is added when the drift is found and reported
+	ConfigDriftReasonDrifted = "ConfigDrifted"
+	// ConfigDriftReasonRevertFailed is added when the drift is found but failed to be reverted
+	ConfigDriftReasonRevertFailed = "RevertFailed"
+	// ConfigDriftReasonInSync is added when the online config matches the declared one
+	ConfigDriftReasonInSync = "ConfigInSync"
+)
+
+// The reasons of the DataDirPreCheckFailed condition
+const (
+	// DataDirPreCheckReasonFailed is added when the data directory pre-check of some pods fails
+	DataDirPreCheckReasonFailed = "PreCheckFailed"
+	// DataDirPreCheckReasonPassed is added when no pre-check fails
+	DataDirPreCheckReasonPassed = "PreCheckPassed"
+)
+
+// The reasons of the LeaderEvictionStalled condition
+const (
+	// LeaderEvictionReasonTimeoutBlocked is added when the eviction times out and the upgrade is blocked
+	LeaderEvictionReasonTimeoutBlocked = "EvictLeaderTimeoutBlocked"
+	// LeaderEvictionReasonTimeoutContinued is added when the eviction times out and the pod is upgraded anyway
+	LeaderEvictionReasonTimeoutContinued = "EvictLeaderTimeoutContinued"
+	// LeaderEvictionReasonEvicted is added when the leaders are evicted in time
+	LeaderEvictionReasonEvicted = "LeaderEvicted"
 )
 
 // TiKVStatus is TiKV status
@@ -1440,12 +2799,45 @@ type TiKVStatus struct {
 	EvictLeader     map[string]*EvictLeaderStatus `json:"evictLeader,omitempty"`
 	// Volumes contains the status of all volumes.
 	Volumes map[StorageVolumeName]*StorageVolumeStatus `json:"volumes,omitempty"`
+	// PendingChanges records the spec changes held until the in-progress upgrade or scale completes.
+	// +optional
+	PendingChanges *PendingChanges `json:"pendingChanges,omitempty"`
+	// IORateLimit is the maximum IO bandwidth applied online during a backup or restore,
+	// it's empty if the configured bandwidth is in effect.
+	// +optional
+	IORateLimit string `json:"ioRateLimit,omitempty"`
+	// Groups are the status of the TiKV groups, the key is the name of the group.
+	// +optional
+	Groups map[string]TiKVGroupStatus `json:"groups,omitempty"`
+	// CanaryUpgrade is the status of the canary upgrade of TiKV.
+	// +optional
+	CanaryUpgrade *CanaryUpgradeStatus `json:"canaryUpgrade,omitempty"`
+	// VolReplaceInProgress is true if the volumes of TiKV are being replaced, a spare TiKV is scaled out
+	// to hold the data of the stores being replaced until the replacement is completed.
+	// +optional
+	VolReplaceInProgress bool `json:"volReplaceInProgress,omitempty"`
 	// Represents the latest available observations of a component's state.
 	// +optional
 	// +nullable
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
+// TiKVGroupStatus is the status of a TiKV group
+type TiKVGroupStatus struct {
+	// Cluster is the name of the heterogeneous TidbCluster of the group
+	Cluster string `json:"cluster"`
+	// Replicas is the desired replicas of the group
+	Replicas int32 `json:"replicas"`
+	// UpStores is the number of the Up stores of the group
+	UpStores int32 `json:"upStores"`
+	// Phase is the phase of TiKV of the group
+	// +optional
+	Phase MemberPhase `json:"phase,omitempty"`
+	// Deleting indicates that the group is removed from the spec and being scaled in before it's deleted
+	// +optional
+	Deleting bool `json:"deleting,omitempty"`
+}
+
 // TiFlashStatus is TiFlash status
 type TiFlashStatus struct {
 	Synced          bool                        `json:"synced,omitempty"`
@@ -1459,6 +2851,9 @@ type TiFlashStatus struct {
 	Image           string                      `json:"image,omitempty"`
 	// Volumes contains the status of all volumes.
 	Volumes map[StorageVolumeName]*StorageVolumeStatus `json:"volumes,omitempty"`
+	// PendingChanges records the spec changes held until the in-progress upgrade or scale completes.
+	// +optional
+	PendingChanges *PendingChanges `json:"pendingChanges,omitempty"`
 	// Represents the latest available observations of a component's state.
 	// +optional
 	// +nullable
@@ -1485,6 +2880,9 @@ type TiProxyStatus struct {
 	Members     map[string]TiProxyMember                   `json:"members,omitempty"`
 	StatefulSet *apps.StatefulSetStatus                    `json:"statefulSet,omitempty"`
 	Volumes     map[StorageVolumeName]*StorageVolumeStatus `json:"volumes,omitempty"`
+	// PendingChanges records the spec changes held until the in-progress upgrade or scale completes.
+	// +optional
+	PendingChanges *PendingChanges `json:"pendingChanges,omitempty"`
 	// Represents the latest available observations of a component's state.
 	// +optional
 	// +nullable
@@ -1497,8 +2895,14 @@ type TiCDCStatus struct {
 	Phase       MemberPhase             `json:"phase,omitempty"`
 	StatefulSet *apps.StatefulSetStatus `json:"statefulSet,omitempty"`
 	Captures    map[string]TiCDCCapture `json:"captures,omitempty"`
+	// Changefeeds contains the status of the changefeeds managed by the operator, indexed by the ID
+	// +optional
+	Changefeeds map[string]TiCDCChangefeedStatus `json:"changefeeds,omitempty"`
 	// Volumes contains the status of all volumes.
 	Volumes map[StorageVolumeName]*StorageVolumeStatus `json:"volumes,omitempty"`
+	// PendingChanges records the spec changes held until the in-progress upgrade or scale completes.
+	// +optional
+	PendingChanges *PendingChanges `json:"pendingChanges,omitempty"`
 	// Represents the latest available observations of a component's state.
 	// +optional
 	// +nullable
@@ -1514,6 +2918,23 @@ type TiCDCCapture struct {
 	Ready   bool   `json:"ready,omitempty"`
 }
 
+// TiCDCChangefeedStatus is the status of a changefeed managed by the operator
+type TiCDCChangefeedStatus struct {
+	// State is the state of the changefeed reported by TiCDC, e.g. normal, stopped, error, failed or finished
+	State string `json:"state,omitempty"`
+	// CheckpointTSO is the TSO before which all the data changes are replicated to the sink
+	CheckpointTSO uint64 `json:"checkpointTSO,omitempty"`
+	// CheckpointTime is the physical time of CheckpointTSO
+	// +nullable
+	CheckpointTime *metav1.Time `json:"checkpointTime,omitempty"`
+	// CheckpointLag is the lag of the checkpoint behind the time of the last sync
+	CheckpointLag *metav1.Duration `json:"checkpointLag,omitempty"`
+	// Error is the last error of the changefeed reported by TiCDC
+	Error string `json:"error,omitempty"`
+	// ConfigHash is the hash of the changefeed configuration last applied to TiCDC
+	ConfigHash string `json:"configHash,omitempty"`
+}
+
 // TiKVStores is either Up/Down/Offline/Tombstone
 type TiKVStore struct {
 	// store id is also uint64, due to the same reason as pd id, we store id as string
@@ -1531,6 +2952,28 @@ type TiKVStore struct {
 	// It is set when evicting leader and used to wait for most leaders to transfer back after upgrade.
 	// It is unset after leader transfer is completed.
 	LeaderCountBeforeUpgrade *int32 `json:"leaderCountBeforeUpgrade,omitempty"`
+	// Drain is the progress of moving the regions out of the store, it is set only when the store is offline.
+	// +optional
+	Drain *StoreDrainStatus `json:"drain,omitempty"`
+}
+
+// StoreDrainStatus is the progress of moving the regions out of an offline store
+type StoreDrainStatus struct {
+	// StartTime is the time when the store is found offline.
+	StartTime metav1.Time `json:"startTime"`
+	// InitialBytes is the size of the regions on the store when it is found offline.
+	InitialBytes int64 `json:"initialBytes"`
+	// RegionsRemaining is the number of the regions left on the store.
+	RegionsRemaining int32 `json:"regionsRemaining"`
+	// BytesRemaining is the size of the regions left on the store.
+	BytesRemaining int64 `json:"bytesRemaining"`
+	// BytesMovedPerMinute is the average size of the regions moved out per minute since the drain started.
+	// +optional
+	BytesMovedPerMinute int64 `json:"bytesMovedPerMinute,omitempty"`
+	// EstimatedCompletionTime is the time estimated by BytesMovedPerMinute when all the regions are moved out.
+	// +nullable
+	// +optional
+	EstimatedCompletionTime *metav1.Time `json:"estimatedCompletionTime,omitempty"`
 }
 
 // TiKVFailureStore is the tikv failure store information
@@ -1599,6 +3042,13 @@ type TiDBTLSClient struct {
 	// Optional: defaults to false
 	// +optional
 	SkipInternalClientCA bool `json:"skipInternalClientCA,omitempty"`
+
+	// AutoReload reloads the TiDB server-side certificate by `ALTER INSTANCE RELOAD TLS` when the
+	// <clusterName>-tidb-server-secret is renewed, e.g. by cert-manager, instead of restarting TiDB.
+	// The certificates of the mutual TLS between components are reloaded by the components themselves.
+	// Optional: defaults to false
+	// +optional
+	AutoReload bool `json:"autoReload,omitempty"`
 }
 
 // TLSCluster can enable mutual TLS connection between TiDB cluster components
@@ -1622,6 +3072,78 @@ type TLSCluster struct {
 	//        Same for other components.
 	// +optional
 	Enabled bool `json:"enabled,omitempty"`
+
+	// ClientSecretName is the name of the secret that stores the client certificate (tls.crt and tls.key)
+	// used by TiDB Operator to connect to the cluster.
+	// Optional: Defaults to <clusterName>-cluster-client-secret for TidbCluster and <clusterName>-dm-client-secret for DMCluster
+	// +optional
+	ClientSecretName string `json:"clientSecretName,omitempty"`
+
+	// CASecretName is the name of the secret that stores the CA certificate (ca.crt) used by TiDB Operator
+	// to verify the servers of the cluster, the secret must be in the same namespace as the client secret.
+	// It allows the clusters, e.g. a DMCluster and a TidbCluster, to be issued by different PKIs.
+	// Optional: Defaults to the ca.crt in the client secret
+	// +optional
+	CASecretName string `json:"caSecretName,omitempty"`
+
+	// Vault configures TiDB Operator to issue the certificates of the components and the client from
+	// the PKI secrets engine of HashiCorp Vault, and write them into the <clusterName>-<componentName>-cluster-secret
+	// and the client secret. The certificates are renewed automatically before they expire.
+	// It's only supported by TidbCluster.
+	// +optional
+	Vault *VaultPKI `json:"vault,omitempty"`
+}
+
+// VaultPKI is the PKI secrets engine of HashiCorp Vault to issue the certificates of a TiDB cluster.
+// The role must allow the common name TiDB and the DNS names of the services of the components,
+// including the wildcard names of the peer services.
+type VaultPKI struct {
+	// Address is the address of the Vault server, e.g. https://vault.vault.svc:8200
+	Address string `json:"address"`
+
+	// Path is the mount path of the PKI secrets engine
+	// Optional: Defaults to pki
+	// +optional
+	Path string `json:"path,omitempty"`
+
+	// Role is the role of the PKI secrets engine to issue the certificates
+	Role string `json:"role"`
+
+	// Auth is the Kubernetes auth method to log in to Vault
+	Auth VaultKubernetesAuth `json:"auth"`
+
+	// CASecretName is the name of the secret that stores the CA certificate (ca.crt) to verify the Vault server,
+	// the secret must be in the same namespace as the cluster.
+	// Optional: Defaults to the system CAs
+	// +optional
+	CASecretName string `json:"caSecretName,omitempty"`
+
+	// TTL is the requested lifetime of the certificates, it's capped by the max TTL of the role.
+	// Optional: Defaults to the TTL of the role
+	// +optional
+	TTL *metav1.Duration `json:"ttl,omitempty"`
+
+	// RenewBefore is how long before the certificates expire to renew them.
+	// Optional: Defaults to one third of the lifetime of the certificates
+	// +optional
+	RenewBefore *metav1.Duration `json:"renewBefore,omitempty"`
+}
+
+// VaultKubernetesAuth is the Kubernetes auth method of Vault
+type VaultKubernetesAuth struct {
+	// Path is the mount path of the Kubernetes auth method
+	// Optional: Defaults to kubernetes
+	// +optional
+	Path string `json:"path,omitempty"`
+
+	// Role is the role of the Kubernetes auth method bound to the service account
+	Role string `json:"role"`
+
+	// ServiceAccountName is the name of the service account in the namespace of the cluster to log in to Vault,
+	// TiDB Operator requests a token of it by the TokenRequest API.
+	// Optional: Defaults to the service account of TiDB Operator
+	// +optional
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
 }
 
 // +genclient
@@ -1728,6 +3250,12 @@ type S3StorageProvider struct {
 	// SecretName is the name of secret which stores
 	// S3 compliant storage access key and secret key.
 	SecretName string `json:"secretName,omitempty"`
+	// UseCredentialsBroker makes the backup jobs use the short-lived credentials issued by the operator,
+	// which can only access the bucket and the prefix of the backup, instead of the credentials in SecretName.
+	// It's only supported by the aws provider for backups, and requires the broker enabled for the operator
+	// by --backup-credentials-broker-role-arn.
+	// +optional
+	UseCredentialsBroker bool `json:"useCredentialsBroker,omitempty"`
 	// Prefix of the data path.
 	Prefix string `json:"prefix,omitempty"`
 	// SSE Sever-Side Encryption.
@@ -1773,9 +3301,17 @@ type AzblobStorageProvider struct {
 	AccessTier string `json:"accessTier,omitempty"`
 	// SecretName is the name of secret which stores the
 	// azblob service account credentials.
+	// The secret contains AZURE_STORAGE_ACCOUNT and one of the credentials: AZURE_CLIENT_ID,
+	// AZURE_CLIENT_SECRET and AZURE_TENANT_ID for AAD, AZURE_STORAGE_KEY for the shared key or
+	// AZURE_STORAGE_SAS_TOKEN for the SAS token. The managed identity of the pod is used if none
+	// of them is given, AZURE_CLIENT_ID is set for a user-assigned identity.
 	SecretName string `json:"secretName,omitempty"`
 	// Prefix of the data path.
 	Prefix string `json:"prefix,omitempty"`
+	// StorageAccount is the storage account of the azure blob storage, it overrides the
+	// AZURE_STORAGE_ACCOUNT of the secret, e.g. it's set for the managed identity without a secret.
+	// +optional
+	StorageAccount string `json:"storageAccount,omitempty"`
 }
 
 // BackupType represents the backup type.
@@ -1954,6 +3490,9 @@ type BackupSpec struct {
 	// Affinity of backup Pods
 	// +optional
 	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+	// NodeSelector of backup Pods, e.g. to run the large exports on the dedicated nodes
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
 	// Use KMS to decrypt the secrets
 	UseKMS bool `json:"useKMS,omitempty"`
 	// Specify service account of backup
@@ -1978,9 +3517,40 @@ type BackupSpec struct {
 // DumplingConfig contains config for dumpling
 type DumplingConfig struct {
 	// Options means options for backup data to remote storage with dumpling.
+	// They override the options set by the other fields, and the default threads and rows are not used if
+	// they are set.
 	Options []string `json:"options,omitempty"`
 	// Deprecated. Please use `Spec.TableFilter` instead. TableFilter means Table filter expression for 'db.table' matching
 	TableFilter []string `json:"tableFilter,omitempty"`
+	// Threads is the number of the concurrent threads of dumpling
+	// Optional: Defaults to 16
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Threads *int32 `json:"threads,omitempty"`
+	// Rows is the number of the rows of each chunk, the tables are split into the chunks which are
+	// dumped concurrently
+	// Optional: Defaults to 10000
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Rows *int64 `json:"rows,omitempty"`
+	// FileSize is the max size of each dumped file, e.g. 256MiB
+	// Optional: Defaults to unlimited
+	// +optional
+	FileSize string `json:"fileSize,omitempty"`
+}
+
+// +k8s:openapi-gen=true
+// LightningConfig contains config for TiDB Lightning
+type LightningConfig struct {
+	// RegionConcurrency is the number of the concurrent workers importing the data
+	// Optional: Defaults to the number of the logical CPUs of the node
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	RegionConcurrency *int32 `json:"regionConcurrency,omitempty"`
+	// Options means options for restore data with TiDB Lightning, they override the options set by the
+	// other fields.
+	// +optional
+	Options []string `json:"options,omitempty"`
 }
 
 // +k8s:openapi-gen=true
@@ -2080,6 +3650,9 @@ const (
 	BackupStopped BackupConditionType = "Stopped"
 	// BackupRestart means the backup was restarted, now just support snapshot backup
 	BackupRestart BackupConditionType = "Restart"
+	// BackupQuotaExceeded means the backup job is rejected by the ResourceQuota or LimitRange
+	// of the namespace, the creation is retried with backoff
+	BackupQuotaExceeded BackupConditionType = "QuotaExceeded"
 )
 
 // BackupCondition describes the observed state of a Backup at a certain point.
@@ -2137,6 +3710,11 @@ type BackupStatus struct {
 	// TODO: remove nullable, https://github.com/kubernetes/kubernetes/issues/86811
 	// +nullable
 	TimeCompleted metav1.Time `json:"timeCompleted,omitempty"`
+	// TimeQueued is the time at which the scheduled backup was queued by the concurrency limits of the operator.
+	// +nullable
+	TimeQueued metav1.Time `json:"timeQueued,omitempty"`
+	// QueueDuration is how long the scheduled backup was queued before its job was created.
+	QueueDuration string `json:"queueDuration,omitempty"`
 	// BackupSizeReadable is the data size of the backup.
 	// the difference with BackupSize is that its format is human readable
 	BackupSizeReadable string `json:"backupSizeReadable,omitempty"`
@@ -2159,6 +3737,19 @@ type BackupStatus struct {
 	Progresses []Progress `json:"progresses,omitempty"`
 	// BackoffRetryStatus is status of the backoff retry, it will be used when backup pod or job exited unexpectedly
 	BackoffRetryStatus []BackoffRetryRecord `json:"backoffRetryStatus,omitempty"`
+	// Topology is the topology of the backup cluster recorded by the volume-snapshot backup
+	// +optional
+	Topology *BackupTopology `json:"topology,omitempty"`
+}
+
+// BackupTopology is the topology of the backup cluster
+type BackupTopology struct {
+	// ClusterDomain is the Kubernetes cluster domain of the backup cluster
+	// +optional
+	ClusterDomain string `json:"clusterDomain,omitempty"`
+	// Zones are the zones of the TiKV volumes of the backup cluster
+	// +optional
+	Zones []string `json:"zones,omitempty"`
 }
 
 // +genclient
@@ -2205,9 +3796,21 @@ type BackupScheduleSpec struct {
 	// 0 is magic number to indicate un-limited backups.
 	// if MaxBackups and MaxReservedTime are set at the same time, MaxReservedTime is preferred
 	// and MaxBackups is ignored.
+	// The log backup is not counted, it is truncated to the oldest kept backup.
 	MaxBackups *int32 `json:"maxBackups,omitempty"`
 	// MaxReservedTime is to specify how long backups we want to keep.
+	// The log backup is truncated to keep the data within the reserved time for PiTR.
 	MaxReservedTime *string `json:"maxReservedTime,omitempty"`
+	// MaxSize is the max total size of the completed snapshot backups, e.g. 500Gi.
+	// The oldest completed backups are deleted when it's exceeded, in addition to the ones deleted by
+	// MaxBackups or MaxReservedTime, but the latest completed backup is always kept.
+	// The backups are created with the Delete clean policy if it's set, so that the data of the deleted
+	// backups is removed from the backup storage. The log backup is not counted.
+	// +optional
+	MaxSize *string `json:"maxSize,omitempty"`
+	// GCDryRun makes the garbage collection only report the backups it would delete in the status.
+	// +optional
+	GCDryRun bool `json:"gcDryRun,omitempty"`
 	// BackupTemplate is the specification of the backup structure to get scheduled.
 	// +optional
 	BackupTemplate BackupSpec `json:"backupTemplate"`
@@ -2234,6 +3837,23 @@ type BackupScheduleStatus struct {
 	LastBackupTime *metav1.Time `json:"lastBackupTime,omitempty"`
 	// AllBackupCleanTime represents the time when all backup entries are cleaned up
 	AllBackupCleanTime *metav1.Time `json:"allBackupCleanTime,omitempty"`
+	// GC is the result of the last garbage collection of the backups.
+	// +optional
+	GC *BackupScheduleGCStatus `json:"gc,omitempty"`
+}
+
+// BackupScheduleGCStatus is the result of the garbage collection of the backups of a BackupSchedule.
+type BackupScheduleGCStatus struct {
+	// DryRun is whether the backups are only reported instead of deleted.
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
+	// Backups is the number of the snapshot backups kept.
+	Backups int32 `json:"backups"`
+	// Size is the total size in bytes of the completed snapshot backups kept.
+	Size int64 `json:"size"`
+	// DeletedBackups are the backups deleted by the garbage collection, or the ones it would delete in the dry-run mode.
+	// +optional
+	DeletedBackups []string `json:"deletedBackups,omitempty"`
 }
 
 // +genclient
@@ -2306,6 +3926,9 @@ const (
 	RestoreRetryFailed RestoreConditionType = "RetryFailed"
 	// RestoreInvalid means invalid restore CR.
 	RestoreInvalid RestoreConditionType = "Invalid"
+	// RestoreQuotaExceeded means the restore job is rejected by the ResourceQuota or LimitRange
+	// of the namespace, the creation is retried with backoff
+	RestoreQuotaExceeded RestoreConditionType = "QuotaExceeded"
 )
 
 // RestoreCondition describes the observed state of a Restore at a certain point.
@@ -2369,12 +3992,23 @@ type RestoreSpec struct {
 	StorageSize string `json:"storageSize,omitempty"`
 	// BR is the configs for BR.
 	BR *BRConfig `json:"br,omitempty"`
+	// Lightning is the configs for TiDB Lightning, which is used if BR is not set.
+	// +optional
+	Lightning *LightningConfig `json:"lightning,omitempty"`
+	// Checkpoint makes the restore resumable from the checkpoint persisted by BR in the restored cluster,
+	// the restore pod is recreated when it fails, e.g. it's evicted, and BR resumes from the last checkpoint
+	// instead of restoring from scratch. It's only supported by the snapshot and PiTR restore of BR v7.1.0 or later.
+	// +optional
+	Checkpoint *RestoreCheckpoint `json:"checkpoint,omitempty"`
 	// Base tolerations of restore Pods, components may add more tolerations upon this respectively
 	// +optional
 	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
 	// Affinity of restore Pods
 	// +optional
 	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+	// NodeSelector of restore Pods, e.g. to run the large imports on the dedicated nodes
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
 	// Use KMS to decrypt the secrets
 	UseKMS bool `json:"useKMS,omitempty"`
 	// Specify service account of restore
@@ -2389,6 +4023,18 @@ type RestoreSpec struct {
 	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
 	// TableFilter means Table filter expression for 'db.table' matching. BR supports this from v4.0.3.
 	TableFilter []string `json:"tableFilter,omitempty"`
+	// TableFilters are the structured table filter rules, they are appended to the expressions in TableFilter.
+	// +optional
+	TableFilters []TableFilterRule `json:"tableFilters,omitempty"`
+	// RenameRules rename the databases or tables in the backup data when they are restored.
+	// It is only supported by the restore with TiDB Lightning, as BR does not support renaming.
+	// +optional
+	RenameRules []RenameRule `json:"renameRules,omitempty"`
+	// TopologyMapping maps the zones of the backup cluster to the zones of the restore cluster for the
+	// volume-snapshot restore, the key is the zone recorded in the backup and the value is the zone to restore into.
+	// The zones of the restored volumes must match the mapping, such as by the `--target-az` option of BR.
+	// +optional
+	TopologyMapping map[string]string `json:"topologyMapping,omitempty"`
 
 	// PodSecurityContext of the component
 	// +optional
@@ -2398,6 +4044,42 @@ type RestoreSpec struct {
 	PriorityClassName string `json:"priorityClassName,omitempty"`
 }
 
+// RestoreCheckpoint is the config of the restore resumable from the checkpoint.
+type RestoreCheckpoint struct {
+	// MaxAttempts is the max number of the attempts of the restore, including the first one.
+	// Defaults to 3.
+	// +optional
+	MaxAttempts *int32 `json:"maxAttempts,omitempty"`
+}
+
+// TableFilterRule is a structured table filter rule for 'db.table' matching.
+// The names support the wildcards `*`, `?` and `[]`.
+type TableFilterRule struct {
+	// Database is the pattern of the database name
+	Database string `json:"database"`
+	// Table is the pattern of the table name, defaults to `*` which matches all tables in the database
+	// +optional
+	Table string `json:"table,omitempty"`
+	// Exclude means the matched tables are excluded instead of included
+	// +optional
+	Exclude bool `json:"exclude,omitempty"`
+}
+
+// RenameRule renames the databases or tables in the backup data when they are restored.
+type RenameRule struct {
+	// FromDatabase is the pattern of the database name in the backup data, it supports the wildcards `*`, `?` and `[]`
+	FromDatabase string `json:"fromDatabase"`
+	// FromTable is the pattern of the table name in the backup data, it supports the wildcards `*`, `?` and `[]`.
+	// If it is empty, only the database is renamed.
+	// +optional
+	FromTable string `json:"fromTable,omitempty"`
+	// ToDatabase is the database name that the matched tables are restored to
+	ToDatabase string `json:"toDatabase"`
+	// ToTable is the table name that the matched tables are restored to, it's required if FromTable is set
+	// +optional
+	ToTable string `json:"toTable,omitempty"`
+}
+
 // RestoreStatus represents the current status of a tidb cluster restore.
 type RestoreStatus struct {
 	// TimeStarted is the time at which the restore was started.
@@ -2415,6 +4097,39 @@ type RestoreStatus struct {
 	// Progresses is the progress of restore.
 	// +nullable
 	Progresses []Progress `json:"progresses,omitempty"`
+	// CurrentProgress is the progress of the running step of restore, which is updated periodically.
+	// +optional
+	CurrentProgress *RestoreCurrentProgress `json:"currentProgress,omitempty"`
+	// Attempts is the number of the attempts of the restore resumable from the checkpoint.
+	// +optional
+	Attempts int32 `json:"attempts,omitempty"`
+	// CheckpointTs is the TS of the checkpoint which the last attempt resumed from, it's reported by BR.
+	// +optional
+	CheckpointTs string `json:"checkpointTs,omitempty"`
+}
+
+// RestoreCurrentProgress is the progress of the running step of restore.
+type RestoreCurrentProgress struct {
+	// Step is the name of the running step.
+	Step string `json:"step,omitempty"`
+	// Percentage is the completed percentage of the step.
+	Percentage float64 `json:"percentage,omitempty"`
+	// RestoredBytes is the size of the data restored, it's only reported by TiDB Lightning.
+	// +optional
+	RestoredBytes int64 `json:"restoredBytes,omitempty"`
+	// TotalBytes is the size of the data to restore, it's only reported by TiDB Lightning.
+	// +optional
+	TotalBytes int64 `json:"totalBytes,omitempty"`
+	// EstimatedRemainingTime is reported by TiDB Lightning, or estimated from the elapsed time and
+	// the completed percentage of the step for BR.
+	// +optional
+	EstimatedRemainingTime *metav1.Duration `json:"estimatedRemainingTime,omitempty"`
+	// StepStartTime is the time at which the step was started.
+	// +nullable
+	StepStartTime metav1.Time `json:"stepStartTime,omitempty"`
+	// LastUpdateTime is the time at which the progress was updated.
+	// +nullable
+	LastUpdateTime metav1.Time `json:"lastUpdateTime,omitempty"`
 }
 
 // +k8s:openapi-gen=true
@@ -2688,6 +4403,12 @@ type MasterSpec struct {
 	// +kubebuilder:validation:XPreserveUnknownFields
 	Config *MasterConfigWraper `json:"config,omitempty"`
 
+	// AdditionalArgs are the command line arguments appended to the start command of dm-master,
+	// for flags that can not be set in the config file, e.g. "--flag=value".
+	// Arguments conflicting with the flags generated by the operator are rejected.
+	// +optional
+	AdditionalArgs []string `json:"additionalArgs,omitempty"`
+
 	// Start up script version
 	// +optional
 	// +kubebuilder:validation:Enum:="";"v1"
@@ -2755,6 +4476,12 @@ type WorkerSpec struct {
 	// +kubebuilder:validation:XPreserveUnknownFields
 	Config *WorkerConfigWraper `json:"config,omitempty"`
 
+	// AdditionalArgs are the command line arguments appended to the start command of dm-worker,
+	// for flags that can not be set in the config file, e.g. "--flag=value".
+	// Arguments conflicting with the flags generated by the operator are rejected.
+	// +optional
+	AdditionalArgs []string `json:"additionalArgs,omitempty"`
+
 	// RecoverFailover indicates that Operator can recover the failover Pods
 	// +optional
 	RecoverFailover bool `json:"recoverFailover,omitempty"`
@@ -2762,6 +4489,48 @@ type WorkerSpec struct {
 	// Failover is the configurations of failover
 	// +optional
 	Failover *Failover `json:"failover,omitempty"`
+
+	// Relay is the config of the relay log of the sources. The relay log is stored in the relay directory
+	// under the data directory of dm-worker, so that it's on the persistent volume of dm-worker and is
+	// counted in storageSize.
+	// +optional
+	Relay *DMRelaySpec `json:"relay,omitempty"`
+}
+
+// DMRelaySpec is the config of the relay log of the sources, it's applied to the sources managed by
+// DMTasks through the dm-master API
+// +k8s:openapi-gen=true
+type DMRelaySpec struct {
+	// Sources are the names of the sources whose relay log is enabled, the relay log of a source is pulled
+	// by the dm-worker bound to the source. The relay log of the other sources is disabled.
+	// +optional
+	Sources []string `json:"sources,omitempty"`
+
+	// Purge is how the relay log is purged
+	// +optional
+	Purge *DMRelayPurge `json:"purge,omitempty"`
+}
+
+// DMRelayPurge is how the relay log of the sources is purged, the defaults of DM are used for the
+// unset fields
+// +k8s:openapi-gen=true
+type DMRelayPurge struct {
+	// Interval is the interval in seconds to check whether the relay log should be purged.
+	// Optional: Defaults to 3600
+	// +optional
+	Interval *int64 `json:"interval,omitempty"`
+
+	// Expires is the hours to keep the relay log which isn't used by any task, 0 means the relay log
+	// never expires.
+	// Optional: Defaults to 0
+	// +optional
+	Expires *int64 `json:"expires,omitempty"`
+
+	// RemainSpace is the minimum free space in GiB of the volume of dm-worker, the relay log which isn't
+	// used by any task is purged when the free space is less than it.
+	// Optional: Defaults to 15
+	// +optional
+	RemainSpace *int64 `json:"remainSpace,omitempty"`
 }
 
 // DMClusterCondition is dm cluster condition
@@ -2935,11 +4704,21 @@ type TopologySpreadConstraint struct {
 	// and identical values are considered to be in the same topology.
 	// We consider each <key, value> as a "bucket", and try to put balanced number
 	// of pods into each bucket.
-	// MaxSkew is default set to 1
-	// WhenUnsatisfiable is default set to DoNotSchedule
 	// LabelSelector is generated by component type
 	// See pkg/apis/pingcap/v1alpha1/tidbcluster_component.go#TopologySpreadConstraints()
 	TopologyKey string `json:"topologyKey"`
+
+	// MaxSkew is the max difference between the numbers of the pods of the component in any two buckets.
+	// Default to 1.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxSkew int32 `json:"maxSkew,omitempty"`
+
+	// WhenUnsatisfiable is how the pod is scheduled if it doesn't satisfy the spread constraint,
+	// DoNotSchedule or ScheduleAnyway. Default to DoNotSchedule.
+	// +kubebuilder:validation:Enum=DoNotSchedule;ScheduleAnyway
+	// +optional
+	WhenUnsatisfiable corev1.UnsatisfiableConstraintAction `json:"whenUnsatisfiable,omitempty"`
 }
 
 // Failover contains the failover specification.
@@ -2949,6 +4728,55 @@ type Failover struct {
 	// it takes effect only when set `spec.recoverFailover=false`
 	// +optional
 	RecoverByUID types.UID `json:"recoverByUID,omitempty"`
+
+	// Period is how long a member has to be down before it's marked as a failure and a new replica is
+	// created for it, it overrides the failover period flag of the controller manager of the component
+	// +optional
+	Period *metav1.Duration `json:"period,omitempty"`
+
+	// RecoverPolicy is how the failure members are recovered.
+	// `Manual` (default) keeps the failover replicas until `recoverFailover` is set or `recoverByUID` matches
+	// the failoverUID in the status, then they're removed once all the original pods are healthy.
+	// `Auto` removes a failure member without the manual recovery once its original member is up again,
+	// so that the failover replica created for it is scaled in.
+	// +kubebuilder:validation:Enum="";Manual;Auto
+	// +optional
+	RecoverPolicy FailoverRecoverPolicy `json:"recoverPolicy,omitempty"`
+}
+
+// FailoverRecoverPolicy is how the failure members are recovered
+type FailoverRecoverPolicy string
+
+const (
+	// FailoverRecoverPolicyManual recovers the failure members when it's requested by recoverFailover or recoverByUID
+	FailoverRecoverPolicyManual FailoverRecoverPolicy = "Manual"
+	// FailoverRecoverPolicyAuto recovers a failure member once its original member is up again
+	FailoverRecoverPolicyAuto FailoverRecoverPolicy = "Auto"
+)
+
+// StoreFailover contains the failover specification of TiKV and TiFlash stores.
+// +k8s:openapi-gen=true
+type StoreFailover struct {
+	Failover `json:",inline"`
+
+	// NodeFailureGracePeriod is the time to wait after the Kubernetes node of a store becomes NotReady
+	// before force deleting the pod of the store if it is stuck in Terminating, so that the pod can be
+	// recreated and the failover can proceed without manual force deletion.
+	// Optional: Defaults to nil, which means the pods stuck in Terminating are never force deleted
+	// +optional
+	NodeFailureGracePeriod *metav1.Duration `json:"nodeFailureGracePeriod,omitempty"`
+}
+
+// PreStopHookSpec contains the specification of the preStop hook generated by TiDB Operator,
+// which hands over the workload of a pod before its container is stopped.
+// +k8s:openapi-gen=true
+type PreStopHookSpec struct {
+	// Timeout is the max duration the preStop hook waits for the workload to be handed over.
+	// If terminationGracePeriodSeconds is not set, it defaults to the timeout plus 30s for the
+	// component to shut down, otherwise it must be larger than the timeout.
+	// Optional: Defaults to 30s for PD and TiDB, 5min for TiKV but not longer than evictLeaderTimeout
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
 }
 
 type ScalePolicy struct {
@@ -0,0 +1,319 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/Masterminds/semver"
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/apis/util/config"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/klog/v2"
+)
+
+// removedConfigKey is a config key which is removed or deprecated since some version of the component
+type removedConfigKey struct {
+	key string
+	// versions is the constraint of the versions which don't support the key any more
+	versions string
+	// hint tells how to migrate the key
+	hint string
+}
+
+// componentConfigRules are the rules of the config of a component
+type componentConfigRules struct {
+	component string
+	// sections are the known top-level tables of the config and the constraints of the versions supporting them,
+	// an empty constraint means all the versions. The tables unknown to the rules are only warned because the list
+	// can't cover all the versions of the component.
+	sections map[string]string
+	removed  []removedConfigKey
+}
+
+var tikvConfigRules = componentConfigRules{
+	component: "TiKV",
+	sections: map[string]string{
+		"log":               "",
+		"memory":            "",
+		"quota":             "",
+		"readpool":          "",
+		"server":            "",
+		"storage":           "",
+		"pd":                "",
+		"metric":            "",
+		"raftstore":         "",
+		"coprocessor":       "",
+		"coprocessor-v2":    "",
+		"rocksdb":           "",
+		"raftdb":            "",
+		"raft-engine":       "",
+		"security":          "",
+		"import":            "",
+		"backup":            "",
+		"backup-stream":     "",
+		"log-backup":        ">= 6.2.0-0",
+		"pessimistic-txn":   "",
+		"gc":                "",
+		"split":             "",
+		"cdc":               "",
+		"resolved-ts":       "",
+		"resource-metering": "",
+		"causal-ts":         "",
+		"resource-control":  ">= 7.0.0-0",
+		"in-memory-engine":  ">= 8.5.0-0",
+	},
+	removed: []removedConfigKey{
+		{key: "raftstore.sync-log", versions: ">= 5.0.0-0", hint: "the raft log is always synced, remove it"},
+		{key: "server.snap-max-write-bytes-per-sec", versions: ">= 6.1.0-0", hint: "use server.snap-io-max-bytes-per-sec instead"},
+		{key: "storage.block-cache.shared", versions: ">= 6.6.0-0", hint: "the block cache is always shared, remove it"},
+	},
+}
+
+var pdConfigRules = componentConfigRules{
+	component: "PD",
+	sections: map[string]string{
+		"log":              "",
+		"metric":           "",
+		"schedule":         "",
+		"replication":      "",
+		"pd-server":        "",
+		"labels":           "",
+		"security":         "",
+		"label-property":   "",
+		"dashboard":        "",
+		"replication-mode": "",
+		"keyspace":         ">= 6.6.0-0",
+		"controller":       ">= 7.0.0-0",
+		"micro-service":    ">= 8.0.0-0",
+	},
+	removed: []removedConfigKey{
+		{key: "schedule.disable-remove-down-replica", versions: ">= 5.0.0-0", hint: "use schedule.enable-remove-down-replica instead"},
+		{key: "schedule.disable-replace-offline-replica", versions: ">= 5.0.0-0", hint: "use schedule.enable-replace-offline-replica instead"},
+		{key: "schedule.disable-make-up-replica", versions: ">= 5.0.0-0", hint: "use schedule.enable-make-up-replica instead"},
+		{key: "schedule.disable-remove-extra-replica", versions: ">= 5.0.0-0", hint: "use schedule.enable-remove-extra-replica instead"},
+		{key: "schedule.disable-location-replacement", versions: ">= 5.0.0-0", hint: "use schedule.enable-location-replacement instead"},
+		{key: "schedule.store-balance-rate", versions: ">= 5.0.0-0", hint: "use schedule.store-limit instead"},
+	},
+}
+
+var tidbConfigRules = componentConfigRules{
+	component: "TiDB",
+	sections: map[string]string{
+		"log":                 "",
+		"instance":            ">= 6.1.0-0",
+		"security":            "",
+		"status":              "",
+		"performance":         "",
+		"prepared-plan-cache": "",
+		"opentracing":         "",
+		"pd-client":           "",
+		"proxy-protocol":      "",
+		"tikv-client":         "",
+		"binlog":              "",
+		"plugin":              "",
+		"pessimistic-txn":     "",
+		"stmt-summary":        "",
+		"experimental":        "",
+		"isolation-read":      "",
+		"labels":              "",
+		"top-sql":             "",
+		"transaction-summary": "",
+		"txn-local-latches":   "",
+	},
+	// the config items are moved to the system variables since v6.1.0
+	removed: []removedConfigKey{
+		{key: "prepared-plan-cache.enabled", versions: ">= 6.1.0-0", hint: "set the system variable tidb_enable_prepared_plan_cache instead"},
+		{key: "performance.committer-concurrency", versions: ">= 6.1.0-0", hint: "set the system variable tidb_committer_concurrency instead"},
+		{key: "performance.run-auto-analyze", versions: ">= 6.1.0-0", hint: "set the system variable tidb_enable_auto_analyze instead"},
+		{key: "oom-action", versions: ">= 6.1.0-0", hint: "set the system variable tidb_mem_oom_action instead"},
+		{key: "mem-quota-query", versions: ">= 6.1.0-0", hint: "set the system variable tidb_mem_quota_query instead"},
+		{key: "check-mb4-value-in-utf8", versions: ">= 6.1.0-0", hint: "set the system variable tidb_check_mb4_value_in_utf8 instead"},
+		{key: "enable-collect-execution-info", versions: ">= 6.1.0-0", hint: "use instance.tidb_enable_collect_execution_info instead"},
+		{key: "log.enable-slow-log", versions: ">= 6.1.0-0", hint: "use instance.tidb_enable_slow_log instead"},
+		{key: "log.slow-threshold", versions: ">= 6.1.0-0", hint: "use instance.tidb_slow_log_threshold instead"},
+		{key: "log.query-log-max-len", versions: ">= 6.1.0-0", hint: "set the system variable tidb_query_log_max_len instead"},
+	},
+}
+
+// componentConfig is the config of a component to be validated against the version of the component
+type componentConfig struct {
+	cfg     *config.GenericConfig
+	version string
+	rules   componentConfigRules
+	fldPath *field.Path
+}
+
+// componentConfigsOf returns the configs of PD, TiKV and TiDB of the cluster, it returns nothing if the validation
+// is skipped by the annotation tidb.pingcap.com/skip-config-validation: "true"
+func componentConfigsOf(tc *v1alpha1.TidbCluster) []componentConfig {
+	if tc.Annotations[label.AnnSkipConfigValidationKey] == label.AnnSkipConfigValidationVal {
+		return nil
+	}
+	fldPath := field.NewPath("spec")
+	var configs []componentConfig
+	if tc.Spec.PD != nil && tc.Spec.PD.Config != nil {
+		configs = append(configs, componentConfig{tc.Spec.PD.Config.GenericConfig, tc.PDVersion(), pdConfigRules, fldPath.Child("pd", "config")})
+	}
+	if tc.Spec.TiKV != nil && tc.Spec.TiKV.Config != nil {
+		configs = append(configs, componentConfig{tc.Spec.TiKV.Config.GenericConfig, tc.TiKVVersion(), tikvConfigRules, fldPath.Child("tikv", "config")})
+	}
+	if tc.Spec.TiDB != nil && tc.Spec.TiDB.Config != nil {
+		configs = append(configs, componentConfig{tc.Spec.TiDB.Config.GenericConfig, tc.TiDBVersion(), tidbConfigRules, fldPath.Child("tidb", "config")})
+	}
+	return configs
+}
+
+// validateComponentConfigs validates the config of PD, TiKV and TiDB against their versions, so that the config
+// known to be unsupported by or removed from the versions is rejected when it's applied rather than crashing the
+// components.
+func validateComponentConfigs(tc *v1alpha1.TidbCluster) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for _, c := range componentConfigsOf(tc) {
+		allErrs = append(allErrs, validateComponentConfig(c.cfg, c.version, c.rules, c.fldPath)...)
+	}
+	return allErrs
+}
+
+// warnUnknownConfigSections logs the sections of the config unknown to the rules, which may be mistyped
+func warnUnknownConfigSections(tc *v1alpha1.TidbCluster) {
+	for _, w := range unknownConfigSections(tc) {
+		klog.Warningf("TidbCluster %s/%s: %s", tc.Namespace, tc.Name, w)
+	}
+}
+
+// unknownConfigSections returns the warnings of the top-level tables of the configs unknown to the rules
+func unknownConfigSections(tc *v1alpha1.TidbCluster) []string {
+	var warnings []string
+	for _, c := range componentConfigsOf(tc) {
+		for _, key := range configSections(c.cfg) {
+			if _, known := c.rules.sections[key]; known {
+				continue
+			}
+			msg := fmt.Sprintf("%s: unknown section of the %s config", c.fldPath.Key(key), c.rules.component)
+			if similar := similarConfigSection(key, c.rules.sections); similar != "" {
+				msg = fmt.Sprintf("%s, did you mean %s?", msg, similar)
+			}
+			warnings = append(warnings, msg)
+		}
+	}
+	return warnings
+}
+
+// configSections returns the sorted top-level tables of the config
+func configSections(cfg *config.GenericConfig) []string {
+	var sections []string
+	if cfg == nil {
+		return sections
+	}
+	for key, value := range cfg.Inner() {
+		if _, ok := value.(map[string]interface{}); ok {
+			sections = append(sections, key)
+		}
+	}
+	sort.Strings(sections)
+	return sections
+}
+
+// validateUpdateComponentConfigs only rejects the config errors introduced by the update, so that the existing
+// clusters aren't blocked by the config which was accepted before
+func validateUpdateComponentConfigs(old, tc *v1alpha1.TidbCluster) field.ErrorList {
+	existing := map[string]struct{}{}
+	for _, err := range validateComponentConfigs(old) {
+		existing[err.Error()] = struct{}{}
+	}
+	allErrs := field.ErrorList{}
+	for _, err := range validateComponentConfigs(tc) {
+		if _, ok := existing[err.Error()]; !ok {
+			allErrs = append(allErrs, err)
+		}
+	}
+	return allErrs
+}
+
+// validateComponentConfig validates the known top-level tables of the config are supported and the keys aren't
+// removed for the version of the component. Only the tables are checked because the scalar items at the top level
+// vary a lot between the versions.
+func validateComponentConfig(cfg *config.GenericConfig, version string, rules componentConfigRules, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if cfg == nil {
+		return allErrs
+	}
+	// the version of the component may be unknown, e.g. latest or nightly, skip the checks of the versions
+	v, _ := semver.NewVersion(version)
+
+	for _, key := range configSections(cfg) {
+		versions := rules.sections[key]
+		if versions != "" && v != nil {
+			if c, err := semver.NewConstraint(versions); err == nil && !c.Check(v) {
+				allErrs = append(allErrs, field.Invalid(fldPath.Key(key), key, fmt.Sprintf("section %s is not supported by %s %s, requires %s", key, rules.component, version, versions)))
+			}
+		}
+	}
+
+	if v == nil {
+		return allErrs
+	}
+	for _, r := range rules.removed {
+		value := cfg.Get(r.key)
+		if value == nil {
+			continue
+		}
+		if c, err := semver.NewConstraint(r.versions); err == nil && c.Check(v) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Key(r.key), value.Interface(), fmt.Sprintf("%s is removed or deprecated in %s %s (%s), %s", r.key, rules.component, version, r.versions, r.hint)))
+		}
+	}
+	return allErrs
+}
+
+// similarConfigSection returns the known section within the edit distance of 2 from the key, which is likely the
+// one mistyped
+func similarConfigSection(key string, sections map[string]string) string {
+	similar, minDistance := "", 3
+	for section := range sections {
+		if d := editDistance(key, section); d < minDistance || d == minDistance && section < similar {
+			similar, minDistance = section, d
+		}
+	}
+	return similar
+}
+
+// editDistance returns the Levenshtein distance between a and b
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = prev[j-1] + cost
+			if prev[j]+1 < cur[j] {
+				cur[j] = prev[j] + 1
+			}
+			if cur[j-1]+1 < cur[j] {
+				cur[j] = cur[j-1] + 1
+			}
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,120 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newTidbClusterForConfigValidation(version string) *v1alpha1.TidbCluster {
+	return &v1alpha1.TidbCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "basic", Namespace: "default"},
+		Spec: v1alpha1.TidbClusterSpec{
+			Version: version,
+			PD: &v1alpha1.PDSpec{
+				BaseImage: "pingcap/pd",
+				Config:    v1alpha1.NewPDConfig(),
+			},
+			TiKV: &v1alpha1.TiKVSpec{
+				BaseImage: "pingcap/tikv",
+				Config:    v1alpha1.NewTiKVConfig(),
+			},
+			TiDB: &v1alpha1.TiDBSpec{
+				BaseImage: "pingcap/tidb",
+				Config:    v1alpha1.NewTiDBConfig(),
+			},
+		},
+	}
+}
+
+func TestValidateComponentConfigs(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbClusterForConfigValidation("v7.5.0")
+	tc.Spec.PD.Config.Set("schedule.leader-schedule-limit", 4)
+	tc.Spec.TiKV.Config.Set("raftstore.store-pool-size", 2)
+	tc.Spec.TiKV.Config.Set("log-level", "info")
+	tc.Spec.TiDB.Config.Set("instance.tidb_slow_log_threshold", 300)
+	g.Expect(validateComponentConfigs(tc)).To(BeEmpty())
+
+	// the sections unknown to the rules are only warned
+	tc.Spec.TiKV.Config.Set("raftsotre.apply-pool-size", 2)
+	tc.Spec.TiDB.Config.Set("pd-client.pd-server-timeout", 3)
+	tc.Spec.TiDB.Config.Set("transaction-summary.transaction-summary-capacity", 500)
+	g.Expect(validateComponentConfigs(tc)).To(BeEmpty())
+	g.Expect(unknownConfigSections(tc)).To(Equal([]string{"spec.tikv.config[raftsotre]: unknown section of the TiKV config, did you mean raftstore?"}))
+
+	// the removed keys
+	tc = newTidbClusterForConfigValidation("v7.5.0")
+	tc.Spec.TiKV.Config.Set("raftstore.sync-log", true)
+	tc.Spec.TiDB.Config.Set("oom-action", "cancel")
+	errs := validateComponentConfigs(tc)
+	g.Expect(errs).To(HaveLen(2))
+	g.Expect(errs[0].Field).To(Equal("spec.tikv.config[raftstore.sync-log]"))
+	g.Expect(errs[0].Detail).To(Equal("raftstore.sync-log is removed or deprecated in TiKV v7.5.0 (>= 5.0.0-0), the raft log is always synced, remove it"))
+	g.Expect(errs[1].Field).To(Equal("spec.tidb.config[oom-action]"))
+
+	// the keys are supported by the earlier versions
+	tc.Spec.Version = "v4.0.16"
+	g.Expect(validateComponentConfigs(tc)).To(BeEmpty())
+
+	// the versions are unknown
+	tc.Spec.Version = "nightly"
+	g.Expect(validateComponentConfigs(tc)).To(BeEmpty())
+
+	// the section isn't supported by the version
+	tc = newTidbClusterForConfigValidation("v6.5.0")
+	tc.Spec.TiKV.Config.Set("resource-control.enabled", true)
+	errs = validateComponentConfigs(tc)
+	g.Expect(errs).To(HaveLen(1))
+	g.Expect(errs[0].Detail).To(Equal("section resource-control is not supported by TiKV v6.5.0, requires >= 7.0.0-0"))
+
+	tc = newTidbClusterForConfigValidation("v7.5.0")
+	tc.Spec.PD.Config.Set("micro-service.enable-scheduling-fallback", true)
+	errs = validateComponentConfigs(tc)
+	g.Expect(errs).To(HaveLen(1))
+	g.Expect(errs[0].Detail).To(Equal("section micro-service is not supported by PD v7.5.0, requires >= 8.0.0-0"))
+
+	tc.Annotations = map[string]string{label.AnnSkipConfigValidationKey: label.AnnSkipConfigValidationVal}
+	g.Expect(validateComponentConfigs(tc)).To(BeEmpty())
+
+	tc.Annotations = nil
+	tc.Spec.Version = "v8.1.0"
+	g.Expect(validateComponentConfigs(tc)).To(BeEmpty())
+}
+
+func TestValidateUpdateComponentConfigs(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	old := newTidbClusterForConfigValidation("v6.5.0")
+	old.Spec.TiDB.Config.Set("oom-action", "cancel")
+
+	// the config accepted before doesn't block the update
+	tc := old.DeepCopy()
+	tc.Spec.TiDB.Replicas = 3
+	g.Expect(validateUpdateComponentConfigs(old, tc)).To(BeEmpty())
+
+	// the error introduced by the upgrade is rejected
+	tc.Spec.TiKV.Config.Set("storage.block-cache.shared", true)
+	tc.Spec.Version = "v7.1.0"
+	errs := validateUpdateComponentConfigs(old, tc)
+	g.Expect(errs).To(HaveLen(2))
+	g.Expect(errs[0].Field).To(Equal("spec.tikv.config[storage.block-cache.shared]"))
+	g.Expect(errs[1].Field).To(Equal("spec.tidb.config[oom-action]"))
+}
@@ -16,24 +16,38 @@ package validation
 import (
 	"encoding/json"
 	"fmt"
+	"net"
 	"net/url"
 	"os"
 	"path"
 	"path/filepath"
 	"reflect"
+	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/Masterminds/semver"
 	"github.com/pingcap/tidb-operator/pkg/apis/label"
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	httputil "github.com/pingcap/tidb-operator/pkg/util/http"
 	"github.com/prometheus/common/model"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	apivalidation "k8s.io/apimachinery/pkg/api/validation"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	utilnet "k8s.io/utils/net"
+	"sigs.k8s.io/yaml"
+)
+
+// Limits of pod DNS config, the same as kubernetes.
+const (
+	maxDNSNameservers     = 3
+	maxDNSSearchPaths     = 6
+	maxDNSSearchListChars = 256
 )
 
 // ValidateTidbCluster validates a TidbCluster, it performs basic validation for all TidbClusters despite it is legacy
@@ -46,6 +60,30 @@ func ValidateTidbCluster(tc *v1alpha1.TidbCluster) field.ErrorList {
 	allErrs = append(allErrs, validateAnnotations(tc.ObjectMeta.Annotations, fldPath.Child("annotations"))...)
 	// validate spec
 	allErrs = append(allErrs, validateTiDBClusterSpec(&tc.Spec, field.NewPath("spec"))...)
+	if tc.IsTiProxyTLSOffloadEnabled() {
+		allErrs = append(allErrs, validateTiProxyTLSOffload(tc, field.NewPath("spec", "tiproxy", "tlsOffload"))...)
+	}
+	return allErrs
+}
+
+// validateTiProxyTLSOffload validates TiProxy can connect to TiDB with TLS when it offloads TLS for the clients
+func validateTiProxyTLSOffload(tc *v1alpha1.TidbCluster, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if tc.Spec.TiDB == nil || !tc.Spec.TiDB.IsTLSClientEnabled() {
+		allErrs = append(allErrs, field.Invalid(fldPath, true, "spec.tidb.tlsClient.enabled must be true to offload TLS by TiProxy"))
+	}
+	if tc.SkipTLSWhenConnectTiDB() {
+		allErrs = append(allErrs, field.Invalid(fldPath, true, fmt.Sprintf("TLS offload can't be used with the annotation %s", label.AnnSkipTLSWhenConnectTiDB)))
+	}
+	// TiDB must require secure transport so that the clients without TLS can't bypass TiProxy
+	if tc.Spec.TiDB != nil && tc.Spec.TiDB.Config != nil {
+		if v := tc.Spec.TiDB.Config.Get("security.require-secure-transport"); v != nil {
+			if required, ok := v.Interface().(bool); !ok || !required {
+				allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "tidb", "config").Key("security.require-secure-transport"), v.Interface(),
+					"TiDB must require secure transport when TiProxy offloads TLS"))
+			}
+		}
+	}
 	return allErrs
 }
 
@@ -97,7 +135,25 @@ func ValidateTidbMonitor(monitor *v1alpha1.TidbMonitor) field.ErrorList {
 
 	allErrs = append(allErrs, validateService(&monitor.Spec.Prometheus.Service, field.NewPath("spec"))...)
 	allErrs = append(allErrs, validatePromDurationStr(monitor.Spec.Prometheus.RetentionTime, field.NewPath("spec"))...)
+	if monitor.Spec.Prometheus.Retention != nil {
+		allErrs = append(allErrs, validatePrometheusRetention(monitor.Spec.Prometheus.Retention, field.NewPath("spec", "prometheus", "retention"))...)
+	}
 	allErrs = append(allErrs, validateService(&monitor.Spec.Reloader.Service, field.NewPath("spec"))...)
+	if monitor.Spec.Blackbox != nil {
+		allErrs = append(allErrs, validateBlackboxExporter(monitor.Spec.Blackbox, field.NewPath("spec", "blackbox"))...)
+	}
+	for i, ref := range monitor.Spec.Clusters {
+		if ref.Remote != nil {
+			allErrs = append(allErrs, validateRemoteClusterRef(ref.Remote, remoteTidbClusterComponents, field.NewPath("spec", "clusters").Index(i).Child("remote"))...)
+		}
+	}
+	if monitor.Spec.DM != nil {
+		for i, ref := range monitor.Spec.DM.Clusters {
+			if ref.Remote != nil {
+				allErrs = append(allErrs, validateRemoteClusterRef(ref.Remote, remoteDMClusterComponents, field.NewPath("spec", "dm", "clusters").Index(i).Child("remote"))...)
+			}
+		}
+	}
 	if monitor.Spec.Persistent {
 		allErrs = append(allErrs, validateStorageInfo(monitor.Spec.Storage, field.NewPath("spec"))...)
 	}
@@ -127,14 +183,22 @@ func validateTiDBClusterSpec(spec *v1alpha1.TidbClusterSpec, fldPath *field.Path
 	allErrs := field.ErrorList{}
 
 	allErrs = append(allErrs, validateDiscoverySpec(spec.Discovery, fldPath.Child("discovery"))...)
+	if spec.Discovery.Mode == v1alpha1.DiscoveryModeEmbedded {
+		allErrs = append(allErrs, validateEmbeddedDiscovery(spec, fldPath)...)
+	}
+	allErrs = append(allErrs, validateTopologySpreadConstraints(spec.TopologySpreadConstraints, fldPath.Child("topologySpreadConstraints"))...)
+	allErrs = append(allErrs, validatePVCPolicy(nil, spec.ScaleInPVCRetentionPolicy, spec.ScaleInPVCDeletionGracePeriod, fldPath)...)
+	allErrs = append(allErrs, validateConfigProfile(spec.ConfigProfile, fldPath.Child("configProfile"))...)
 	if spec.PD != nil {
 		allErrs = append(allErrs, validatePDSpec(spec.PD, fldPath.Child("pd"))...)
 	}
 	if spec.TiKV != nil {
 		allErrs = append(allErrs, validateTiKVSpec(spec.TiKV, fldPath.Child("tikv"))...)
+		allErrs = append(allErrs, validateTiKVFeatures(spec, fldPath.Child("tikv"))...)
 	}
 	if spec.TiDB != nil {
 		allErrs = append(allErrs, validateTiDBSpec(spec.TiDB, fldPath.Child("tidb"))...)
+		allErrs = append(allErrs, validateTiDBPluginVersion(spec, fldPath.Child("tidb"))...)
 	}
 	if spec.Pump != nil {
 		allErrs = append(allErrs, validatePumpSpec(spec.Pump, fldPath.Child("pump"))...)
@@ -148,6 +212,262 @@ func validateTiDBClusterSpec(spec *v1alpha1.TidbClusterSpec, fldPath *field.Path
 	if spec.PDAddresses != nil {
 		allErrs = append(allErrs, validatePDAddresses(spec.PDAddresses, fldPath.Child("pdAddresses"))...)
 	}
+	allErrs = append(allErrs, validateDNSPolicy(spec.DNSPolicy, fldPath.Child("dnsPolicy"))...)
+	allErrs = append(allErrs, validatePodDNSConfig(spec.DNSConfig, fldPath.Child("dnsConfig"))...)
+	allErrs = append(allErrs, validateEffectivePodDNS(spec, fldPath)...)
+	allErrs = append(allErrs, validateAdditionalArgs(spec, fldPath)...)
+	if spec.Proxy != nil {
+		allErrs = append(allErrs, validateProxy(spec.Proxy, fldPath.Child("proxy"))...)
+	}
+	if spec.HealthProbe != nil {
+		allErrs = append(allErrs, validateHealthProbe(spec.HealthProbe, fldPath.Child("healthProbe"))...)
+	}
+	if spec.TopologyZones != nil {
+		allErrs = append(allErrs, validateTopologyZones(spec, fldPath.Child("topologyZones"))...)
+	}
+	if spec.Teardown != nil && spec.Teardown.MaxConcurrentPVCDeletions != nil && *spec.Teardown.MaxConcurrentPVCDeletions <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("teardown", "maxConcurrentPVCDeletions"), *spec.Teardown.MaxConcurrentPVCDeletions, "must be greater than 0"))
+	}
+	if spec.ScaleInApproval != nil {
+		allErrs = append(allErrs, validateScaleInApproval(spec.ScaleInApproval, fldPath.Child("scaleInApproval"))...)
+	}
+	if spec.SecretPropagation != nil {
+		allErrs = append(allErrs, validateSecretPropagation(spec, fldPath)...)
+	}
+	if spec.TLSCluster != nil && spec.TLSCluster.Vault != nil {
+		allErrs = append(allErrs, validateVaultPKI(spec.TLSCluster.Vault, fldPath.Child("tlsCluster", "vault"))...)
+	}
+	return allErrs
+}
+
+// validateSecretPropagation validates the secrets are copied from the cluster referenced by spec.cluster
+func validateSecretPropagation(spec *v1alpha1.TidbClusterSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if spec.Cluster == nil || spec.Cluster.Name == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("cluster", "name"), "the cluster to copy the secrets from must be set"))
+	}
+	targets := map[string]bool{}
+	for i, secret := range spec.SecretPropagation.Secrets {
+		if secret.Name == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("secretPropagation", "secrets").Index(i).Child("name"), "name of the secret must be set"))
+		}
+		target := secret.TargetName
+		if target == "" {
+			target = secret.Name
+		}
+		if targets[target] {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Child("secretPropagation", "secrets").Index(i).Child("targetName"), target))
+		}
+		targets[target] = true
+	}
+	return allErrs
+}
+
+func validateVaultPKI(vault *v1alpha1.VaultPKI, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if _, err := url.ParseRequestURI(vault.Address); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("address"), vault.Address, err.Error()))
+	}
+	if vault.Role == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("role"), "role of the PKI secrets engine must be set"))
+	}
+	if vault.Auth.Role == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("auth", "role"), "role of the Kubernetes auth method must be set"))
+	}
+	if vault.TTL != nil && vault.TTL.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("ttl"), vault.TTL.Duration.String(), "must be greater than 0"))
+	}
+	if vault.RenewBefore != nil && vault.RenewBefore.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("renewBefore"), vault.RenewBefore.Duration.String(), "must be greater than 0"))
+	}
+	if vault.TTL != nil && vault.RenewBefore != nil && vault.RenewBefore.Duration >= vault.TTL.Duration {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("renewBefore"), vault.RenewBefore.Duration.String(), "must be less than ttl"))
+	}
+	return allErrs
+}
+
+func validateScaleInApproval(approval *v1alpha1.ScaleInApproval, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for i, component := range approval.Components {
+		if component != v1alpha1.PDMemberType && component != v1alpha1.TiKVMemberType {
+			allErrs = append(allErrs, field.NotSupported(fldPath.Child("components").Index(i), component, []string{v1alpha1.PDMemberType.String(), v1alpha1.TiKVMemberType.String()}))
+		}
+	}
+	if approval.Webhook == nil && approval.ConditionType == "" {
+		allErrs = append(allErrs, field.Required(fldPath, "webhook or conditionType must be set"))
+	}
+	if approval.Webhook != nil {
+		if _, err := url.ParseRequestURI(approval.Webhook.URL); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("webhook", "url"), approval.Webhook.URL, err.Error()))
+		}
+		if approval.Webhook.TimeoutSeconds != nil && *approval.Webhook.TimeoutSeconds <= 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("webhook", "timeoutSeconds"), *approval.Webhook.TimeoutSeconds, "must be greater than 0"))
+		}
+	}
+	return allErrs
+}
+
+// validateTopologyZones validates the zone quotas are declared for the existing components
+// which are scheduled by tidb-scheduler, as the quotas are only enforced by it
+func validateTopologyZones(spec *v1alpha1.TidbClusterSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	zones := spec.TopologyZones
+	if zones.TopologyKey != "" {
+		for _, msg := range validation.IsQualifiedName(zones.TopologyKey) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("topologyKey"), zones.TopologyKey, msg))
+		}
+	}
+	var pd, tikv, tidb, tiflash *v1alpha1.ComponentSpec
+	if spec.PD != nil {
+		pd = &spec.PD.ComponentSpec
+	}
+	if spec.TiKV != nil {
+		tikv = &spec.TiKV.ComponentSpec
+	}
+	if spec.TiDB != nil {
+		tidb = &spec.TiDB.ComponentSpec
+	}
+	if spec.TiFlash != nil {
+		tiflash = &spec.TiFlash.ComponentSpec
+	}
+	schedulerName := func(component *v1alpha1.ComponentSpec) string {
+		if component.SchedulerName != nil {
+			return *component.SchedulerName
+		}
+		return spec.SchedulerName
+	}
+	for _, c := range []struct {
+		name      string
+		quotas    map[string]int32
+		component *v1alpha1.ComponentSpec
+	}{
+		{"pd", zones.PD, pd},
+		{"tikv", zones.TiKV, tikv},
+		{"tidb", zones.TiDB, tidb},
+		{"tiflash", zones.TiFlash, tiflash},
+	} {
+		if len(c.quotas) == 0 {
+			continue
+		}
+		if c.component == nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child(c.name), fmt.Sprintf("%s is not deployed", c.name)))
+			continue
+		}
+		if name := schedulerName(c.component); name == "" || name == corev1.DefaultSchedulerName {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child(c.name),
+				fmt.Sprintf("the zone quotas are only enforced by tidb-scheduler, but the pods of %s are scheduled by %s", c.name, corev1.DefaultSchedulerName)))
+		}
+		for zone, replicas := range c.quotas {
+			for _, msg := range validation.IsValidLabelValue(zone) {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child(c.name).Key(zone), zone, msg))
+			}
+			if zone == "" {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child(c.name).Key(zone), zone, "zone must not be empty"))
+			}
+			if replicas < 0 {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child(c.name).Key(zone), replicas, "must be greater than or equal to 0"))
+			}
+		}
+	}
+	return allErrs
+}
+
+// validateHealthProbe validates the probe finishes before the next one starts
+func validateHealthProbe(spec *v1alpha1.HealthProbeSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if spec.IntervalSeconds != nil && *spec.IntervalSeconds <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("intervalSeconds"), *spec.IntervalSeconds, "must be greater than 0"))
+	}
+	if spec.TimeoutSeconds != nil && *spec.TimeoutSeconds <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("timeoutSeconds"), *spec.TimeoutSeconds, "must be greater than 0"))
+	}
+	if spec.IntervalSeconds != nil && spec.TimeoutSeconds != nil && *spec.TimeoutSeconds > *spec.IntervalSeconds {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("timeoutSeconds"), *spec.TimeoutSeconds, "must not be greater than intervalSeconds"))
+	}
+	return allErrs
+}
+
+// validateProxy validates the proxy URLs and the NO_PROXY list
+func validateProxy(proxy *v1alpha1.HTTPProxyConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if proxy.HTTPProxy != "" {
+		if err := httputil.ValidateProxyURL(proxy.HTTPProxy); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("httpProxy"), proxy.HTTPProxy, err.Error()))
+		}
+	}
+	if proxy.HTTPSProxy != "" {
+		if err := httputil.ValidateProxyURL(proxy.HTTPSProxy); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("httpsProxy"), proxy.HTTPSProxy, err.Error()))
+		}
+	}
+	if proxy.NoProxy != "" {
+		if err := httputil.ValidateNoProxy(proxy.NoProxy); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("noProxy"), proxy.NoProxy, err.Error()))
+		}
+	}
+	return allErrs
+}
+
+// additionalArgPattern matches a flag with an optional value, the value must not contain whitespaces
+// or shell special characters because the args are expanded in the start script without quoting
+var additionalArgPattern = regexp.MustCompile(`^--?[A-Za-z0-9][A-Za-z0-9._-]*(=[A-Za-z0-9._:/,@%+=-]*)?$`)
+
+// validateAdditionalArgs validates the additional command line arguments of the components
+func validateAdditionalArgs(spec *v1alpha1.TidbClusterSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	type componentArgs struct {
+		memberType v1alpha1.MemberType
+		args       []string
+	}
+	components := []componentArgs{}
+	if spec.PD != nil {
+		components = append(components, componentArgs{v1alpha1.PDMemberType, spec.PD.AdditionalArgs})
+	}
+	if spec.TiKV != nil {
+		components = append(components, componentArgs{v1alpha1.TiKVMemberType, spec.TiKV.AdditionalArgs})
+	}
+	if spec.TiDB != nil {
+		components = append(components, componentArgs{v1alpha1.TiDBMemberType, spec.TiDB.AdditionalArgs})
+	}
+	if spec.Pump != nil {
+		components = append(components, componentArgs{v1alpha1.PumpMemberType, spec.Pump.AdditionalArgs})
+	}
+	if spec.TiFlash != nil {
+		components = append(components, componentArgs{v1alpha1.TiFlashMemberType, spec.TiFlash.AdditionalArgs})
+	}
+	if spec.TiCDC != nil {
+		components = append(components, componentArgs{v1alpha1.TiCDCMemberType, spec.TiCDC.AdditionalArgs})
+	}
+	for _, c := range components {
+		if len(c.args) == 0 {
+			continue
+		}
+		path := fldPath.Child(c.memberType.String(), "additionalArgs")
+		if spec.StartScriptVersion != v1alpha1.StartScriptV2 {
+			allErrs = append(allErrs, field.Forbidden(path, "additionalArgs is only supported by start script v2, set spec.startScriptVersion to v2 to use it"))
+		}
+		allErrs = append(allErrs, validateComponentAdditionalArgs(c.memberType, c.args, path)...)
+	}
+	// the start script of TiProxy is the same for all the start script versions
+	if spec.TiProxy != nil {
+		allErrs = append(allErrs, validateComponentAdditionalArgs(v1alpha1.TiProxyMemberType, spec.TiProxy.AdditionalArgs, fldPath.Child("tiproxy", "additionalArgs"))...)
+	}
+	return allErrs
+}
+
+// validateComponentAdditionalArgs validates the format of the additional args of a component,
+// and that they don't set the flags set by the start script of the component
+func validateComponentAdditionalArgs(memberType v1alpha1.MemberType, args []string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for i, arg := range args {
+		if !additionalArgPattern.MatchString(arg) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i), arg,
+				"must be a flag like --flag or --flag=value, and the value must not contain whitespaces or shell special characters"))
+		}
+	}
+	if arg, name := v1alpha1.AdditionalArgsConflict(memberType, args); arg != "" {
+		allErrs = append(allErrs, field.Invalid(fldPath, arg, fmt.Sprintf("conflicts with the flag %q generated by the operator", name)))
+	}
 	return allErrs
 }
 
@@ -159,9 +479,39 @@ func validateDiscoverySpec(spec v1alpha1.DiscoverySpec, fldPath *field.Path) fie
 	return allErrs
 }
 
+// validateEmbeddedDiscovery validates the cluster does not depend on the discovery Deployment,
+// which is not created in the embedded discovery mode
+func validateEmbeddedDiscovery(spec *v1alpha1.TidbClusterSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	modePath := fldPath.Child("discovery", "mode")
+	if spec.PD == nil {
+		allErrs = append(allErrs, field.Invalid(modePath, spec.Discovery.Mode, "requires spec.pd to be set"))
+	}
+	if spec.StartScriptVersion != v1alpha1.StartScriptV2 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("startScriptVersion"), spec.StartScriptVersion, "must be v2 in the embedded discovery mode"))
+	}
+	if spec.AcrossK8s {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("acrossK8s"), spec.AcrossK8s, "is not supported in the embedded discovery mode"))
+	}
+	if spec.Cluster != nil && len(spec.Cluster.Name) > 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("cluster"), spec.Cluster.Name, "is not supported in the embedded discovery mode"))
+	}
+	if len(spec.PDAddresses) > 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("pdAddresses"), spec.PDAddresses, "is not supported in the embedded discovery mode"))
+	}
+	if spec.PD != nil && spec.PD.PreStopHook != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("pd", "preStopHook"), "the preStop hook of PD requires the discovery Deployment"))
+	}
+	if spec.TiKV != nil && spec.TiKV.PreStopHook != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("tikv", "preStopHook"), "the preStop hook of TiKV requires the discovery Deployment"))
+	}
+	return allErrs
+}
+
 func validatePDSpec(spec *v1alpha1.PDSpec, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 	allErrs = append(allErrs, validateComponentSpec(&spec.ComponentSpec, fldPath)...)
+	allErrs = append(allErrs, validatePVCPolicy(spec.PVReclaimPolicy, spec.ScaleInPVCRetentionPolicy, spec.ScaleInPVCDeletionGracePeriod, fldPath)...)
 	allErrs = append(allErrs, validateRequestsStorage(spec.ResourceRequirements.Requests, fldPath)...)
 	if len(spec.StorageVolumes) > 0 {
 		allErrs = append(allErrs, validateStorageVolumes(spec.StorageVolumes, fldPath.Child("storageVolumes"))...)
@@ -169,6 +519,16 @@ func validatePDSpec(spec *v1alpha1.PDSpec, fldPath *field.Path) field.ErrorList
 	if spec.Service != nil {
 		allErrs = append(allErrs, validateService(spec.Service, fldPath)...)
 	}
+	allErrs = append(allErrs, validatePreStopHook(spec.PreStopHook, spec.TerminationGracePeriodSeconds, fldPath.Child("preStopHook"))...)
+	allErrs = append(allErrs, validateLifecycle(spec.Lifecycle, spec.PreStopHook, fldPath.Child("lifecycle"))...)
+	if spec.LeaderPreference != nil {
+		for i, pattern := range spec.LeaderPreference.Members {
+			if _, err := path.Match(pattern, ""); err != nil {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("leaderPreference", "members").Index(i), pattern, err.Error()))
+			}
+		}
+	}
+	allErrs = append(allErrs, validateDataDirPreCheck(spec.DataDirPreCheck, fldPath.Child("dataDirPreCheck"))...)
 	return allErrs
 }
 
@@ -190,6 +550,7 @@ func validatePDAddresses(arrayOfAddresses []string, fldPath *field.Path) field.E
 func validateTiKVSpec(spec *v1alpha1.TiKVSpec, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 	allErrs = append(allErrs, validateComponentSpec(&spec.ComponentSpec, fldPath)...)
+	allErrs = append(allErrs, validatePVCPolicy(spec.PVReclaimPolicy, spec.ScaleInPVCRetentionPolicy, spec.ScaleInPVCDeletionGracePeriod, fldPath)...)
 	allErrs = append(allErrs, validateRequestsStorage(spec.ResourceRequirements.Requests, fldPath)...)
 	allErrs = append(allErrs, validateScalePolicy(&spec.ScalePolicy, fldPath.Child("scalePolicy"))...)
 	if len(spec.DataSubDir) > 0 {
@@ -205,12 +566,247 @@ func validateTiKVSpec(spec *v1alpha1.TiKVSpec, fldPath *field.Path) field.ErrorL
 		allErrs = append(allErrs, validateVolumeName(spec.RocksDBLogVolumeName, spec.StorageVolumes, spec.AdditionalVolumes, spec.AdditionalVolumeMounts, fldPath)...)
 	}
 	allErrs = append(allErrs, validateTimeDurationStr(spec.EvictLeaderTimeout, fldPath.Child("evictLeaderTimeout"))...)
+	allErrs = append(allErrs, validatePreStopHook(spec.PreStopHook, spec.TerminationGracePeriodSeconds, fldPath.Child("preStopHook"))...)
+	allErrs = append(allErrs, validateLifecycle(spec.Lifecycle, spec.PreStopHook, fldPath.Child("lifecycle"))...)
+	allErrs = append(allErrs, validateTiKVGroups(spec.Groups, fldPath.Child("groups"))...)
+	allErrs = append(allErrs, validateCanaryUpgrade(spec.CanaryUpgrade, false, fldPath.Child("canaryUpgrade"))...)
+	allErrs = append(allErrs, validateOfflineOrdinals(spec.OfflineOrdinals, fldPath.Child("offlineOrdinals"))...)
+	allErrs = append(allErrs, validateTiKVPorts(spec, fldPath)...)
+	allErrs = append(allErrs, validateDataDirPreCheck(spec.DataDirPreCheck, fldPath.Child("dataDirPreCheck"))...)
+	return allErrs
+}
+
+// validateTiKVPorts validates the ports of TiKV don't conflict with each other and with the addresses set in
+// the config, which are overridden by the start script
+func validateTiKVPorts(spec *v1alpha1.TiKVSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if spec.Ports == nil {
+		return allErrs
+	}
+	server, status := spec.GetServerPort(), spec.GetStatusPort()
+	if server == status {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("ports", "status"), status, "must be different from the server port"))
+	}
+	if spec.Config != nil {
+		for _, key := range []string{"server.addr", "server.advertise-addr", "server.status-addr", "server.advertise-status-addr"} {
+			if v := spec.Config.Get(key); v != nil {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("config").Key(key), v.Interface(), "must not be set when ports are set"))
+			}
+		}
+	}
+	return allErrs
+}
+
+// fsTypePattern matches the filesystem types in /proc/mounts, which are put into the pre-check script
+var fsTypePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// validateDataDirPreCheck validates the filesystem types and the minimum available space of the pre-check
+func validateDataDirPreCheck(check *v1alpha1.DataDirPreCheck, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if check == nil {
+		return allErrs
+	}
+	for i, fsType := range check.FSTypes {
+		if !fsTypePattern.MatchString(fsType) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("fsTypes").Index(i), fsType, "must be a filesystem type like ext4 or xfs"))
+		}
+	}
+	if check.MinAvailable != nil && check.MinAvailable.Sign() < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("minAvailable"), check.MinAvailable.String(), "must not be negative"))
+	}
+	return allErrs
+}
+
+// disallowMutateTiKVServerPort forbids changing the server port of TiKV of an existing cluster, since the
+// addresses of the stores are persisted by PD and the stores can't start with different addresses
+func disallowMutateTiKVServerPort(old, tikv *v1alpha1.TiKVSpec, path *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if old == nil || tikv == nil {
+		return allErrs
+	}
+	if old.GetServerPort() != tikv.GetServerPort() {
+		allErrs = append(allErrs, field.Forbidden(path, "the server port of TiKV can't be changed"))
+	}
+	return allErrs
+}
+
+// validateTiKVGroups validates that the names of the TiKV groups are unique
+func validateTiKVGroups(groups []v1alpha1.TiKVGroupSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	names := map[string]struct{}{}
+	for i, group := range groups {
+		idxPath := fldPath.Index(i)
+		if _, ok := names[group.Name]; ok {
+			allErrs = append(allErrs, field.Duplicate(idxPath.Child("name"), group.Name))
+		}
+		names[group.Name] = struct{}{}
+		if group.Resources != nil {
+			allErrs = append(allErrs, validateRequestsStorage(group.Resources.Requests, idxPath.Child("resources"))...)
+		}
+	}
+	return allErrs
+}
+
+// tikvFeature is a TiKV feature that can be turned on or off by the config or the spec
+type tikvFeature struct {
+	name string
+	// path is the field to turn on or off the feature, relative to the TiKV spec
+	path string
+	// state returns whether the feature is turned on explicitly, or nil if it's left to the default of TiKV
+	state func(spec *v1alpha1.TiKVSpec) *bool
+	// versions is the constraint of the TiKV versions supporting the feature
+	versions string
+	// requires are the features that must not be turned off when the feature is on
+	requires []string
+	// conflicts are the features that must not be turned on when the feature is on
+	conflicts []string
+}
+
+// tikvFeatures is the compatibility table of the TiKV features, only the features turned on or off explicitly
+// are checked so that the clusters relying on the defaults of TiKV are not rejected
+var tikvFeatures = []tikvFeature{
+	{
+		name:     "raft-engine",
+		path:     "config.raft-engine.enable",
+		state:    tikvConfigBool("raft-engine.enable"),
+		versions: ">= 5.4.0-0",
+	},
+	{
+		name:     "titan",
+		path:     "config.rocksdb.titan.enabled",
+		state:    tikvConfigBool("rocksdb.titan.enabled"),
+		versions: ">= 3.0.0-0",
+	},
+	{
+		name: "async-io",
+		path: "config.raftstore.store-io-pool-size",
+		state: func(spec *v1alpha1.TiKVSpec) *bool {
+			if spec.Config == nil {
+				return nil
+			}
+			v := spec.Config.Get("raftstore.store-io-pool-size")
+			if v == nil {
+				return nil
+			}
+			size, err := v.AsInt()
+			if err != nil {
+				return nil
+			}
+			on := size > 0
+			return &on
+		},
+		versions: ">= 5.3.0-0",
+	},
+	{
+		name: "partitioned-raft-kv",
+		path: "config.storage.engine",
+		state: func(spec *v1alpha1.TiKVSpec) *bool {
+			if spec.Config == nil {
+				return nil
+			}
+			v := spec.Config.Get("storage.engine")
+			if v == nil {
+				return nil
+			}
+			engine, err := v.AsString()
+			if err != nil {
+				return nil
+			}
+			on := engine == "partitioned-raft-kv"
+			return &on
+		},
+		versions:  ">= 6.6.0-0",
+		requires:  []string{"raft-engine"},
+		conflicts: []string{"titan"},
+	},
+	{
+		// the sidecar tails the log of raftdb, which is not written by raft-engine
+		name:      "separate-raft-log",
+		path:      "separateRaftLog",
+		state:     func(spec *v1alpha1.TiKVSpec) *bool { return spec.SeparateRaftLog },
+		conflicts: []string{"raft-engine"},
+	},
+}
+
+// tikvConfigBool returns the state of a TiKV feature turned on or off by the boolean config of the key
+func tikvConfigBool(key string) func(spec *v1alpha1.TiKVSpec) *bool {
+	return func(spec *v1alpha1.TiKVSpec) *bool {
+		if spec.Config == nil {
+			return nil
+		}
+		v := spec.Config.Get(key)
+		if v == nil {
+			return nil
+		}
+		on, ok := v.Interface().(bool)
+		if !ok {
+			return nil
+		}
+		return &on
+	}
+}
+
+// validateTiKVFeatures validates the TiKV features turned on are supported by the version of TiKV and
+// compatible with each other according to tikvFeatures
+func validateTiKVFeatures(spec *v1alpha1.TidbClusterSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	states := map[string]*bool{}
+	for _, f := range tikvFeatures {
+		states[f.name] = f.state(spec.TiKV)
+	}
+
+	version := spec.Version
+	if spec.TiKV.Version != nil {
+		version = *spec.TiKV.Version
+	}
+	// the version of TiKV may be unknown, e.g. latest or nightly, skip the check of the versions
+	tikvVersion, _ := semver.NewVersion(version)
+
+	for _, f := range tikvFeatures {
+		if on := states[f.name]; on == nil || !*on {
+			continue
+		}
+		path := fldPath.Child(f.path)
+		if f.versions != "" && tikvVersion != nil {
+			if c, err := semver.NewConstraint(f.versions); err == nil && !c.Check(tikvVersion) {
+				allErrs = append(allErrs, field.Invalid(path, true, fmt.Sprintf("%s is not supported by TiKV %s, requires %s", f.name, version, f.versions)))
+			}
+		}
+		for _, name := range f.requires {
+			if on := states[name]; on != nil && !*on {
+				allErrs = append(allErrs, field.Invalid(path, true, fmt.Sprintf("%s requires %s, which is turned off", f.name, name)))
+			}
+		}
+		for _, name := range f.conflicts {
+			if on := states[name]; on != nil && *on {
+				allErrs = append(allErrs, field.Invalid(path, true, fmt.Sprintf("%s can not be turned on together with %s", f.name, name)))
+			}
+		}
+	}
+	return allErrs
+}
+
+// validatePVCPolicy validates the PV reclaim policy and the retention of the PVCs left by the scale-in
+func validatePVCPolicy(pvReclaimPolicy *corev1.PersistentVolumeReclaimPolicy, retention *v1alpha1.ScaleInPVCRetentionPolicy, gracePeriod *metav1.Duration, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if pvReclaimPolicy != nil && *pvReclaimPolicy != corev1.PersistentVolumeReclaimRetain && *pvReclaimPolicy != corev1.PersistentVolumeReclaimDelete {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("pvReclaimPolicy"), *pvReclaimPolicy,
+			[]string{string(corev1.PersistentVolumeReclaimRetain), string(corev1.PersistentVolumeReclaimDelete)}))
+	}
+	if retention != nil && *retention != v1alpha1.ScaleInPVCRetain && *retention != v1alpha1.ScaleInPVCDelete {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("scaleInPVCRetentionPolicy"), *retention,
+			[]string{string(v1alpha1.ScaleInPVCRetain), string(v1alpha1.ScaleInPVCDelete)}))
+	}
+	if gracePeriod != nil && gracePeriod.Duration < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("scaleInPVCDeletionGracePeriod"), gracePeriod.Duration.String(), "must not be negative"))
+	}
 	return allErrs
 }
 
 func validateTiFlashSpec(spec *v1alpha1.TiFlashSpec, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 	allErrs = append(allErrs, validateComponentSpec(&spec.ComponentSpec, fldPath)...)
+	allErrs = append(allErrs, validatePVCPolicy(spec.PVReclaimPolicy, spec.ScaleInPVCRetentionPolicy, spec.ScaleInPVCDeletionGracePeriod, fldPath)...)
 	allErrs = append(allErrs, validateTiFlashConfig(spec.Config, fldPath)...)
 	if len(spec.StorageClaims) < 1 {
 		allErrs = append(allErrs, field.Invalid(fldPath.Child("spec.StorageClaims"),
@@ -223,9 +819,33 @@ func validateTiFlashSpec(spec *v1alpha1.TiFlashSpec, fldPath *field.Path) field.
 func validateTiCDCSpec(spec *v1alpha1.TiCDCSpec, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 	allErrs = append(allErrs, validateComponentSpec(&spec.ComponentSpec, fldPath)...)
+	allErrs = append(allErrs, validatePVCPolicy(spec.PVReclaimPolicy, spec.ScaleInPVCRetentionPolicy, spec.ScaleInPVCDeletionGracePeriod, fldPath)...)
 	if len(spec.StorageVolumes) > 0 {
 		allErrs = append(allErrs, validateStorageVolumes(spec.StorageVolumes, fldPath.Child("storageVolumes"))...)
 	}
+	allErrs = append(allErrs, validateTiCDCChangefeeds(spec.Changefeeds, fldPath.Child("changefeeds"))...)
+	return allErrs
+}
+
+func validateTiCDCChangefeeds(changefeeds []v1alpha1.TiCDCChangefeed, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	ids := map[string]bool{}
+	for i, changefeed := range changefeeds {
+		idxPath := fldPath.Index(i)
+		if ids[changefeed.ID] {
+			allErrs = append(allErrs, field.Duplicate(idxPath.Child("id"), changefeed.ID))
+		}
+		ids[changefeed.ID] = true
+		if changefeed.SinkURI == "" && changefeed.SinkURISecretRef == nil {
+			allErrs = append(allErrs, field.Required(idxPath.Child("sinkURI"), "either sinkURI or sinkURISecretRef must be set"))
+		}
+		if changefeed.SinkURI != "" && changefeed.SinkURISecretRef != nil {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("sinkURISecretRef"), changefeed.SinkURISecretRef.Name, "sinkURI and sinkURISecretRef can't be set together"))
+		}
+		if changefeed.TargetTs != 0 && changefeed.TargetTs <= changefeed.StartTs {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("targetTs"), changefeed.TargetTs, "targetTs must be greater than startTs"))
+		}
+	}
 	return allErrs
 }
 
@@ -283,6 +903,7 @@ func validateTiFlashConfig(config *v1alpha1.TiFlashConfigWraper, path *field.Pat
 func validateTiDBSpec(spec *v1alpha1.TiDBSpec, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 	allErrs = append(allErrs, validateComponentSpec(&spec.ComponentSpec, fldPath)...)
+	allErrs = append(allErrs, validatePVCPolicy(spec.PVReclaimPolicy, spec.ScaleInPVCRetentionPolicy, spec.ScaleInPVCDeletionGracePeriod, fldPath)...)
 	if spec.Service != nil {
 		allErrs = append(allErrs, validateService(&spec.Service.ServiceSpec, fldPath)...)
 	}
@@ -292,12 +913,236 @@ func validateTiDBSpec(spec *v1alpha1.TiDBSpec, fldPath *field.Path) field.ErrorL
 	if spec.ShouldSeparateSlowLog() && spec.SlowLogVolumeName != "" {
 		allErrs = append(allErrs, validateVolumeName(spec.SlowLogVolumeName, spec.StorageVolumes, spec.AdditionalVolumes, spec.AdditionalVolumeMounts, fldPath)...)
 	}
+	allErrs = append(allErrs, validateTiDBPlugins(spec, fldPath)...)
+	allErrs = append(allErrs, validatePreStopHook(spec.PreStopHook, spec.TerminationGracePeriodSeconds, fldPath.Child("preStopHook"))...)
+	allErrs = append(allErrs, validateLifecycle(spec.Lifecycle, spec.PreStopHook, fldPath.Child("lifecycle"))...)
+	if spec.MaxUnavailable != nil {
+		allErrs = append(allErrs, validateMaxUnavailable(spec.MaxUnavailable, fldPath.Child("maxUnavailable"))...)
+	}
+	allErrs = append(allErrs, validateCanaryUpgrade(spec.CanaryUpgrade, true, fldPath.Child("canaryUpgrade"))...)
+	allErrs = append(allErrs, validateLoadAwareRestart(spec.LoadAwareRestart, fldPath.Child("loadAwareRestart"))...)
+	allErrs = append(allErrs, validateOfflineOrdinals(spec.OfflineOrdinals, fldPath.Child("offlineOrdinals"))...)
+	allErrs = append(allErrs, validateTiDBPorts(spec, fldPath)...)
+	return allErrs
+}
+
+// validateTiDBPorts validates the ports of TiDB don't conflict with each other, with the ports of the
+// service and with the ports set in the config, which are overridden by the ports
+func validateTiDBPorts(spec *v1alpha1.TiDBSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if spec.Ports == nil {
+		return allErrs
+	}
+	server, status := spec.GetServerPort(), spec.GetStatusPort()
+	if server == status {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("ports", "status"), status, "must be different from the server port"))
+	}
+	if spec.Service != nil {
+		exposeStatus := spec.Service.ShouldExposeStatus()
+		if exposeStatus && spec.GetServicePort() == status {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("ports", "status"), status, "must be different from the port of the service which exposes the status port"))
+		}
+		for i, p := range spec.Service.AdditionalPorts {
+			if p.Port == spec.GetServicePort() || (exposeStatus && p.Port == status) {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("service", "additionalPorts").Index(i).Child("port"), p.Port, "conflicts with the ports of TiDB"))
+			}
+		}
+	}
+	if spec.Config != nil {
+		for _, c := range []struct {
+			key  string
+			port int32
+		}{{"port", server}, {"status.status-port", status}} {
+			v := spec.Config.Get(c.key)
+			if v == nil {
+				continue
+			}
+			if n, err := v.AsInt(); err != nil || n != int64(c.port) {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("config").Key(c.key), v.Interface(), fmt.Sprintf("conflicts with the port %d in ports", c.port)))
+			}
+		}
+	}
+	return allErrs
+}
+
+// validateOfflineOrdinals validates the offline ordinals are non-negative and not duplicated
+func validateOfflineOrdinals(ordinals []int32, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	seen := map[int32]bool{}
+	for i, ordinal := range ordinals {
+		if ordinal < 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i), ordinal, "must be greater than or equal to 0"))
+		}
+		if seen[ordinal] {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Index(i), ordinal))
+		}
+		seen[ordinal] = true
+	}
+	return allErrs
+}
+
+// validateCanaryUpgrade validates the soak period is positive and the error rate, which is only
+// evaluated for TiDB, is between 0 and 1.
+func validateCanaryUpgrade(canary *v1alpha1.CanaryUpgrade, isTiDB bool, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if canary == nil {
+		return allErrs
+	}
+	if canary.Step != nil && *canary.Step < 1 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("step"), *canary.Step, "must be positive"))
+	}
+	if canary.SoakDuration != nil && canary.SoakDuration.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("soakDuration"), canary.SoakDuration.Duration.String(), "must be positive"))
+	}
+	if canary.MaxRestarts != nil && *canary.MaxRestarts < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("maxRestarts"), *canary.MaxRestarts, "must not be negative"))
+	}
+	if canary.MaxErrorRate != nil {
+		if !isTiDB {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("maxErrorRate"), "the error rate is only evaluated for TiDB"))
+		} else if *canary.MaxErrorRate < 0 || *canary.MaxErrorRate > 1 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("maxErrorRate"), *canary.MaxErrorRate, "must be between 0 and 1"))
+		}
+	}
+	return allErrs
+}
+
+// validateLoadAwareRestart validates the Prometheus URL, the thresholds and the max delay
+func validateLoadAwareRestart(restart *v1alpha1.LoadAwareRestart, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if restart == nil {
+		return allErrs
+	}
+	if u, err := url.Parse(restart.PrometheusURL); err != nil || u.Scheme == "" || u.Host == "" {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("prometheusURL"), restart.PrometheusURL, "must be an absolute URL"))
+	}
+	if restart.MaxConnections != nil && *restart.MaxConnections < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("maxConnections"), *restart.MaxConnections, "must not be negative"))
+	}
+	if restart.MaxQPS != nil && *restart.MaxQPS < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("maxQPS"), *restart.MaxQPS, "must not be negative"))
+	}
+	if restart.MaxDelay != nil && restart.MaxDelay.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("maxDelay"), restart.MaxDelay.Duration.String(), "must be positive"))
+	}
+	if restart.Window != nil {
+		if restart.Window.Start == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("window", "start"), "the start of the window is required"))
+		}
+		if restart.Window.End == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("window", "end"), "the end of the window is required"))
+		}
+	}
+	return allErrs
+}
+
+// validateMaxUnavailable validates the maxUnavailable is a positive number or percentage
+func validateMaxUnavailable(maxUnavailable *intstr.IntOrString, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	value, err := intstr.GetScaledValueFromIntOrPercent(maxUnavailable, 100, true)
+	if err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath, maxUnavailable.String(), err.Error()))
+	} else if value <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath, maxUnavailable.String(), "must be positive"))
+	}
+	return allErrs
+}
+
+// validatePreStopHook validates the preStop hook, its timeout must be positive and less than the
+// terminationGracePeriodSeconds of the pod if both of them are set.
+func validatePreStopHook(hook *v1alpha1.PreStopHookSpec, gracePeriodSeconds *int64, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if hook == nil || hook.Timeout == nil {
+		return allErrs
+	}
+	if hook.Timeout.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("timeout"), hook.Timeout.Duration.String(), "must be positive"))
+		return allErrs
+	}
+	if gracePeriodSeconds != nil && hook.Timeout.Duration >= time.Duration(*gracePeriodSeconds)*time.Second {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("timeout"), hook.Timeout.Duration.String(),
+			fmt.Sprintf("must be less than terminationGracePeriodSeconds %d", *gracePeriodSeconds)))
+	}
+	return allErrs
+}
+
+// validateLifecycle validates the lifecycle of a component, its preStop handler must be an exec handler to be
+// composed with the preStop hook of TiDB Operator.
+func validateLifecycle(lifecycle *corev1.Lifecycle, hook *v1alpha1.PreStopHookSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if hook == nil || lifecycle == nil || lifecycle.PreStop == nil {
+		return allErrs
+	}
+	if lifecycle.PreStop.Exec == nil || len(lifecycle.PreStop.Exec.Command) == 0 {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("preStop"), "must be an exec handler to be composed with preStopHook"))
+	}
+	return allErrs
+}
+
+// validateTiDBPlugins validates the plugins and the plugin source of TiDB
+func validateTiDBPlugins(spec *v1alpha1.TiDBSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for i, plugin := range spec.Plugins {
+		// TiDB loads a plugin by its ID, which is in the format of `name-version`
+		idx := strings.LastIndex(plugin, "-")
+		if idx <= 0 || idx == len(plugin)-1 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("plugins").Index(i), plugin, "plugin must be in the format of name-version"))
+		}
+	}
+
+	source := spec.PluginSource
+	if source == nil {
+		return allErrs
+	}
+	sourcePath := fldPath.Child("pluginSource")
+	if len(spec.Plugins) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("plugins"), "plugins must be set if pluginSource is set"))
+	}
+	if (source.Image == "") == (source.PersistentVolumeClaim == nil) {
+		allErrs = append(allErrs, field.Invalid(sourcePath, source, "exactly one of image and persistentVolumeClaim must be set"))
+	}
+	if source.PersistentVolumeClaim != nil && source.PersistentVolumeClaim.ClaimName == "" {
+		allErrs = append(allErrs, field.Required(sourcePath.Child("persistentVolumeClaim", "claimName"), "claimName must be set"))
+	}
+	if !source.IsPathValid() {
+		allErrs = append(allErrs, field.Invalid(sourcePath.Child("path"), source.Path, "path must be an absolute path that only contains letters, digits and ._/-"))
+	}
+	return allErrs
+}
+
+// validateTiDBPluginVersion checks that the plugins are built with the same version of TiDB,
+// because TiDB refuses to load a plugin built with a different version.
+func validateTiDBPluginVersion(spec *v1alpha1.TidbClusterSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	source := spec.TiDB.PluginSource
+	if source == nil || source.TiDBVersion == "" {
+		return allErrs
+	}
+	versionPath := fldPath.Child("pluginSource", "tidbVersion")
+	pluginVersion, err := semver.NewVersion(source.TiDBVersion)
+	if err != nil {
+		return append(allErrs, field.Invalid(versionPath, source.TiDBVersion, fmt.Sprintf("invalid version: %v", err)))
+	}
+
+	version := spec.Version
+	if spec.TiDB.Version != nil {
+		version = *spec.TiDB.Version
+	}
+	tidbVersion, err := semver.NewVersion(version)
+	if err != nil {
+		// the version of TiDB is unknown, e.g. latest or nightly, skip the check
+		return allErrs
+	}
+	if !pluginVersion.Equal(tidbVersion) {
+		allErrs = append(allErrs, field.Invalid(versionPath, source.TiDBVersion, fmt.Sprintf("plugins built with TiDB %s can not be loaded by TiDB %s", source.TiDBVersion, version)))
+	}
 	return allErrs
 }
 
 func validatePumpSpec(spec *v1alpha1.PumpSpec, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 	allErrs = append(allErrs, validateComponentSpec(&spec.ComponentSpec, fldPath)...)
+	allErrs = append(allErrs, validatePVCPolicy(spec.PVReclaimPolicy, spec.ScaleInPVCRetentionPolicy, spec.ScaleInPVCDeletionGracePeriod, fldPath)...)
 	// fix pump spec
 	if _, ok := spec.ResourceRequirements.Requests["storage"]; !ok {
 		allErrs = append(allErrs, field.Invalid(fldPath.Child("spec.ResourceRequirements.Requests"),
@@ -315,10 +1160,14 @@ func validateDMClusterSpec(spec *v1alpha1.DMClusterSpec, fldPath *field.Path) fi
 		}
 	}
 	allErrs = append(allErrs, validateDMDiscoverySpec(spec.Discovery, fldPath.Child("discovery"))...)
+	allErrs = append(allErrs, validateTopologySpreadConstraints(spec.TopologySpreadConstraints, fldPath.Child("topologySpreadConstraints"))...)
 	allErrs = append(allErrs, validateMasterSpec(&spec.Master, fldPath.Child("master"))...)
 	if spec.Worker != nil {
 		allErrs = append(allErrs, validateWorkerSpec(spec.Worker, fldPath.Child("worker"))...)
 	}
+	if spec.TLSCluster != nil && spec.TLSCluster.Vault != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("tlsCluster", "vault"), "issuing the certificates from Vault is not supported by DMCluster"))
+	}
 	return allErrs
 }
 
@@ -333,6 +1182,7 @@ func validateDMDiscoverySpec(spec v1alpha1.DMDiscoverySpec, fldPath *field.Path)
 func validateMasterSpec(spec *v1alpha1.MasterSpec, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 	allErrs = append(allErrs, validateComponentSpec(&spec.ComponentSpec, fldPath)...)
+	allErrs = append(allErrs, validateComponentAdditionalArgs(v1alpha1.DMMasterMemberType, spec.AdditionalArgs, fldPath.Child("additionalArgs"))...)
 	// make sure that storageSize for dm-master is assigned
 	if spec.Replicas > 0 && spec.StorageSize == "" {
 		allErrs = append(allErrs, field.Required(fldPath.Child("storageSize"), "storageSize must not be empty"))
@@ -343,6 +1193,52 @@ func validateMasterSpec(spec *v1alpha1.MasterSpec, fldPath *field.Path) field.Er
 func validateWorkerSpec(spec *v1alpha1.WorkerSpec, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 	allErrs = append(allErrs, validateComponentSpec(&spec.ComponentSpec, fldPath)...)
+	allErrs = append(allErrs, validateComponentAdditionalArgs(v1alpha1.DMWorkerMemberType, spec.AdditionalArgs, fldPath.Child("additionalArgs"))...)
+	if spec.Relay != nil {
+		allErrs = append(allErrs, validateDMRelay(spec.Relay, spec.StorageSize, fldPath.Child("relay"))...)
+	}
+	return allErrs
+}
+
+// validateDMRelay validates the relay log config of dm-worker, the free space kept by the purge must be less than
+// the storage of dm-worker, otherwise the relay log is purged all the time
+func validateDMRelay(relay *v1alpha1.DMRelaySpec, storageSize string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	sources := map[string]struct{}{}
+	for i, source := range relay.Sources {
+		idxPath := fldPath.Child("sources").Index(i)
+		if source == "" {
+			allErrs = append(allErrs, field.Required(idxPath, "source name must not be empty"))
+			continue
+		}
+		if _, ok := sources[source]; ok {
+			allErrs = append(allErrs, field.Duplicate(idxPath, source))
+		}
+		sources[source] = struct{}{}
+	}
+	if relay.Purge == nil {
+		return allErrs
+	}
+	purgePath := fldPath.Child("purge")
+	for _, item := range []struct {
+		name  string
+		value *int64
+	}{
+		{"interval", relay.Purge.Interval},
+		{"expires", relay.Purge.Expires},
+		{"remainSpace", relay.Purge.RemainSpace},
+	} {
+		if item.value != nil && *item.value < 0 {
+			allErrs = append(allErrs, field.Invalid(purgePath.Child(item.name), *item.value, "must be greater than or equal to 0"))
+		}
+	}
+	if relay.Purge.RemainSpace != nil && storageSize != "" {
+		size, err := resource.ParseQuantity(storageSize)
+		if err == nil && *relay.Purge.RemainSpace<<30 >= size.Value() {
+			allErrs = append(allErrs, field.Invalid(purgePath.Child("remainSpace"), *relay.Purge.RemainSpace,
+				fmt.Sprintf("must be less than the storage size %s of dm-worker", storageSize)))
+		}
+	}
 	return allErrs
 }
 
@@ -374,6 +1270,172 @@ func validateComponentSpec(spec *v1alpha1.ComponentSpec, fldPath *field.Path) fi
 	// TODO validate other fields
 	allErrs = append(allErrs, validateEnv(spec.Env, fldPath.Child("env"))...)
 	allErrs = append(allErrs, validateAdditionalContainers(spec.AdditionalContainers, fldPath.Child("additionalContainers"))...)
+	allErrs = append(allErrs, validatePodTemplatePatches(spec.PodTemplatePatches, fldPath.Child("podTemplatePatches"))...)
+	allErrs = append(allErrs, validateDNSPolicy(spec.DNSPolicy, fldPath.Child("dnsPolicy"))...)
+	allErrs = append(allErrs, validatePodDNSConfig(spec.DNSConfig, fldPath.Child("dnsConfig"))...)
+	allErrs = append(allErrs, validateTopologySpreadConstraints(spec.TopologySpreadConstraints, fldPath.Child("topologySpreadConstraints"))...)
+	return allErrs
+}
+
+// validateTopologySpreadConstraints validates the topology spread constraints, the topology keys must be unique.
+func validateTopologySpreadConstraints(constraints []v1alpha1.TopologySpreadConstraint, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	keys := map[string]bool{}
+	for i, c := range constraints {
+		idxPath := fldPath.Index(i)
+		if c.TopologyKey == "" {
+			allErrs = append(allErrs, field.Required(idxPath.Child("topologyKey"), "topology key must be set"))
+		} else if keys[c.TopologyKey] {
+			allErrs = append(allErrs, field.Duplicate(idxPath.Child("topologyKey"), c.TopologyKey))
+		}
+		keys[c.TopologyKey] = true
+		if c.MaxSkew < 0 {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("maxSkew"), c.MaxSkew, "must be greater than 0"))
+		}
+		switch c.WhenUnsatisfiable {
+		case "", corev1.DoNotSchedule, corev1.ScheduleAnyway:
+		default:
+			allErrs = append(allErrs, field.NotSupported(idxPath.Child("whenUnsatisfiable"), c.WhenUnsatisfiable,
+				[]string{string(corev1.DoNotSchedule), string(corev1.ScheduleAnyway)}))
+		}
+	}
+	return allErrs
+}
+
+// validateDNSPolicy validates the DNS policy of pods, an empty policy means to use the default one.
+func validateDNSPolicy(policy corev1.DNSPolicy, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	switch policy {
+	case "", corev1.DNSClusterFirstWithHostNet, corev1.DNSClusterFirst, corev1.DNSDefault, corev1.DNSNone:
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath, policy, []string{
+			string(corev1.DNSClusterFirstWithHostNet), string(corev1.DNSClusterFirst), string(corev1.DNSDefault), string(corev1.DNSNone)}))
+	}
+	return allErrs
+}
+
+func validateConfigProfile(profile v1alpha1.ConfigProfile, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	switch profile {
+	case "", v1alpha1.ConfigProfileOLTP, v1alpha1.ConfigProfileHTAP, v1alpha1.ConfigProfileLight:
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath, profile, []string{
+			string(v1alpha1.ConfigProfileOLTP), string(v1alpha1.ConfigProfileHTAP), string(v1alpha1.ConfigProfileLight)}))
+	}
+	return allErrs
+}
+
+// validatePodDNSConfig validates the DNS config of pods with the same limits as kubernetes.
+func validatePodDNSConfig(config *corev1.PodDNSConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if config == nil {
+		return allErrs
+	}
+	if len(config.Nameservers) > maxDNSNameservers {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("nameservers"), config.Nameservers, fmt.Sprintf("must not have more than %v nameservers", maxDNSNameservers)))
+	}
+	for i, ns := range config.Nameservers {
+		if ip := net.ParseIP(ns); ip == nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("nameservers").Index(i), ns, "must be valid IP address"))
+		}
+	}
+	if len(config.Searches) > maxDNSSearchPaths {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("searches"), config.Searches, fmt.Sprintf("must not have more than %v search paths", maxDNSSearchPaths)))
+	}
+	if len(strings.Join(config.Searches, " ")) > maxDNSSearchListChars {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("searches"), config.Searches, fmt.Sprintf("must not have more than %v characters (including spaces) in the search list", maxDNSSearchListChars)))
+	}
+	for i, search := range config.Searches {
+		for _, msg := range validation.IsDNS1123Subdomain(strings.TrimSuffix(search, ".")) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("searches").Index(i), search, msg))
+		}
+	}
+	for i, option := range config.Options {
+		if len(option.Name) == 0 {
+			allErrs = append(allErrs, field.Required(fldPath.Child("options").Index(i).Child("name"), "must not be empty"))
+		}
+	}
+	return allErrs
+}
+
+// validateEffectivePodDNS validates that pods using the `None` DNS policy have at least one nameserver,
+// the DNS policy and config of a component fall back to the ones of the cluster respectively.
+func validateEffectivePodDNS(spec *v1alpha1.TidbClusterSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	components := []struct {
+		name string
+		spec *v1alpha1.ComponentSpec
+	}{}
+	add := func(name string, spec *v1alpha1.ComponentSpec) {
+		components = append(components, struct {
+			name string
+			spec *v1alpha1.ComponentSpec
+		}{name, spec})
+	}
+	if spec.PD != nil {
+		add("pd", &spec.PD.ComponentSpec)
+	}
+	if spec.TiKV != nil {
+		add("tikv", &spec.TiKV.ComponentSpec)
+	}
+	if spec.TiDB != nil {
+		add("tidb", &spec.TiDB.ComponentSpec)
+	}
+	if spec.Pump != nil {
+		add("pump", &spec.Pump.ComponentSpec)
+	}
+	if spec.TiFlash != nil {
+		add("tiflash", &spec.TiFlash.ComponentSpec)
+	}
+	if spec.TiCDC != nil {
+		add("ticdc", &spec.TiCDC.ComponentSpec)
+	}
+	if spec.TiProxy != nil {
+		add("tiproxy", &spec.TiProxy.ComponentSpec)
+	}
+	for _, comp := range components {
+		policy, config := spec.DNSPolicy, spec.DNSConfig
+		if comp.spec.DNSPolicy != "" {
+			policy = comp.spec.DNSPolicy
+		}
+		if comp.spec.DNSConfig != nil {
+			config = comp.spec.DNSConfig
+		}
+		if policy == corev1.DNSNone && (config == nil || len(config.Nameservers) == 0) {
+			allErrs = append(allErrs, field.Required(fldPath.Child(comp.name).Child("dnsConfig").Child("nameservers"),
+				fmt.Sprintf("must provide at least one DNS nameserver when DNS policy is %s", corev1.DNSNone)))
+		}
+	}
+	return allErrs
+}
+
+// validatePodTemplatePatches validates the patches can be parsed, whether they can be
+// applied is only known when rendering the pod template.
+func validatePodTemplatePatches(patches []v1alpha1.PodTemplatePatch, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for i, p := range patches {
+		idxPath := fldPath.Index(i)
+		data, err := yaml.YAMLToJSON([]byte(p.Patch))
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("patch"), p.Patch, fmt.Sprintf("patch is not valid JSON or YAML: %v", err)))
+			continue
+		}
+		switch p.Type {
+		case v1alpha1.PodTemplatePatchTypeJSON:
+			var ops []map[string]interface{}
+			if err := json.Unmarshal(data, &ops); err != nil {
+				allErrs = append(allErrs, field.Invalid(idxPath.Child("patch"), p.Patch, "JSON patch must be a list of operations"))
+			}
+		case v1alpha1.PodTemplatePatchTypeStrategicMerge, "":
+			var obj map[string]interface{}
+			if err := json.Unmarshal(data, &obj); err != nil {
+				allErrs = append(allErrs, field.Invalid(idxPath.Child("patch"), p.Patch, "strategic merge patch must be an object"))
+			}
+		default:
+			allErrs = append(allErrs, field.NotSupported(idxPath.Child("type"), p.Type,
+				[]string{string(v1alpha1.PodTemplatePatchTypeStrategicMerge), string(v1alpha1.PodTemplatePatchTypeJSON)}))
+		}
+	}
 	return allErrs
 }
 
@@ -523,6 +1585,8 @@ func ValidateCreateTidbCluster(tc *v1alpha1.TidbCluster) field.ErrorList {
 	// basic validation
 	allErrs = append(allErrs, ValidateTidbCluster(tc)...)
 	allErrs = append(allErrs, validateNewTidbClusterSpec(&tc.Spec, field.NewPath("spec"))...)
+	allErrs = append(allErrs, validateComponentConfigs(tc)...)
+	warnUnknownConfigSections(tc)
 	return allErrs
 }
 
@@ -539,10 +1603,24 @@ func ValidateUpdateTidbCluster(old, tc *v1alpha1.TidbCluster) field.ErrorList {
 	allErrs = append(allErrs, validateUpdatePDConfig(old.Spec.PD, tc.Spec.PD, field.NewPath("spec.pd.config"))...)
 	allErrs = append(allErrs, disallowMutateBootstrapSQLConfigMapName(old.Spec.TiDB, tc.Spec.TiDB, field.NewPath("spec.tidb.bootstrapSQLConfigMapName"))...)
 	allErrs = append(allErrs, disallowUsingLegacyAPIInNewCluster(old, tc)...)
+	allErrs = append(allErrs, disallowStandbyOnBootstrappedCluster(old, tc, field.NewPath("spec.standby"))...)
+	allErrs = append(allErrs, disallowMutateTiKVServerPort(old.Spec.TiKV, tc.Spec.TiKV, field.NewPath("spec.tikv.ports.server"))...)
+	allErrs = append(allErrs, validateUpdateComponentConfigs(old, tc)...)
+	warnUnknownConfigSections(tc)
 
 	return allErrs
 }
 
+// disallowStandbyOnBootstrappedCluster forbids turning a bootstrapped TidbCluster into a warm standby,
+// which would scale in all its members
+func disallowStandbyOnBootstrappedCluster(old, tc *v1alpha1.TidbCluster, path *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if old.Spec.Standby == nil && tc.Spec.Standby != nil && (old.Status.ClusterID != "" || len(old.Status.PD.Members) > 0) {
+		allErrs = append(allErrs, field.Forbidden(path, "standby can only be set on a cluster that has not been bootstrapped"))
+	}
+	return allErrs
+}
+
 // For now we limit some validations only in Create phase to keep backward compatibility
 // TODO(aylei): call this in ValidateTidbCluster after we deprecated the old versions of helm chart officially
 func validateNewTidbClusterSpec(spec *v1alpha1.TidbClusterSpec, path *field.Path) field.ErrorList {
@@ -687,6 +1765,22 @@ func validateService(spec *v1alpha1.ServiceSpec, fldPath *field.Path) field.Erro
 			allErrs = append(allErrs, field.Invalid(fldPath.Child("spec.LoadBalancerSourceRanges"), spec.LoadBalancerSourceRanges, "service.Spec.LoadBalancerSourceRanges is not valid. Expecting a list of IP ranges. For example, 10.0.0.0/24."))
 		}
 	}
+	if len(spec.IPFamilies) > 2 {
+		allErrs = append(allErrs, field.TooMany(fldPath.Child("ipFamilies"), len(spec.IPFamilies), 2))
+	}
+	seen := map[corev1.IPFamily]bool{}
+	for i, family := range spec.IPFamilies {
+		if family != corev1.IPv4Protocol && family != corev1.IPv6Protocol {
+			allErrs = append(allErrs, field.NotSupported(fldPath.Child("ipFamilies").Index(i), family, []string{string(corev1.IPv4Protocol), string(corev1.IPv6Protocol)}))
+		}
+		if seen[family] {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Child("ipFamilies").Index(i), family))
+		}
+		seen[family] = true
+	}
+	if spec.IPFamilyPolicy != nil && *spec.IPFamilyPolicy == corev1.IPFamilyPolicySingleStack && len(spec.IPFamilies) > 1 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("ipFamilies"), spec.IPFamilies, "must contain only one family when ipFamilyPolicy is SingleStack"))
+	}
 	return allErrs
 }
 
@@ -744,6 +1838,112 @@ func validatePromDurationStr(timeStr *string, fldPath *field.Path) field.ErrorLi
 	return allErrs
 }
 
+// validatePrometheusRetention validates the retention tiers of Prometheus
+func validatePrometheusRetention(retention *v1alpha1.PrometheusRetentionSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	allErrs = append(allErrs, validatePromDurationStr(retention.Raw, fldPath.Child("raw"))...)
+	downsampled := retention.Downsampled
+	if downsampled == nil {
+		return allErrs
+	}
+	downsampledPath := fldPath.Child("downsampled")
+	if downsampled.Retention == "" {
+		allErrs = append(allErrs, field.Required(downsampledPath.Child("retention"), "retention of the downsampled samples must be set"))
+	} else {
+		allErrs = append(allErrs, validatePromDurationStr(&downsampled.Retention, downsampledPath.Child("retention"))...)
+	}
+	if downsampled.Interval != nil {
+		interval, err := model.ParseDuration(*downsampled.Interval)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(downsampledPath.Child("interval"), downsampled.Interval, "must be a valid Prom time duration string, e.g. 5m"))
+		} else if time.Duration(interval) < time.Minute {
+			allErrs = append(allErrs, field.Invalid(downsampledPath.Child("interval"), downsampled.Interval, "must not be less than 1m"))
+		}
+	}
+	for i, rule := range downsampled.Rules {
+		rulePath := downsampledPath.Child("rules").Index(i)
+		if !strings.HasPrefix(rule.Record, v1alpha1.PrometheusDownsampledRecordPrefix) || !model.IsValidMetricName(model.LabelValue(rule.Record)) {
+			allErrs = append(allErrs, field.Invalid(rulePath.Child("record"), rule.Record,
+				fmt.Sprintf("must be a valid metric name starting with %q", v1alpha1.PrometheusDownsampledRecordPrefix)))
+		}
+		if rule.Expr == "" {
+			allErrs = append(allErrs, field.Required(rulePath.Child("expr"), "expr of the recording rule must be set"))
+		}
+	}
+	return allErrs
+}
+
+// validateBlackboxExporter validates the probe interval and the additional targets of blackbox-exporter
+func validateBlackboxExporter(blackbox *v1alpha1.BlackboxExporterSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	allErrs = append(allErrs, validatePromDurationStr(blackbox.Interval, fldPath.Child("interval"))...)
+	names := map[string]struct{}{}
+	for i, target := range blackbox.Targets {
+		targetPath := fldPath.Child("targets").Index(i)
+		if target.Name == "" {
+			allErrs = append(allErrs, field.Required(targetPath.Child("name"), "name of the target must be set"))
+		} else if _, ok := names[target.Name]; ok {
+			allErrs = append(allErrs, field.Duplicate(targetPath.Child("name"), target.Name))
+		}
+		names[target.Name] = struct{}{}
+		host, port, err := net.SplitHostPort(target.Address)
+		if err != nil || host == "" {
+			allErrs = append(allErrs, field.Invalid(targetPath.Child("address"), target.Address, "must be in the form of host:port"))
+			continue
+		}
+		if p, err := strconv.Atoi(port); err != nil || len(validation.IsValidPortNum(p)) > 0 {
+			allErrs = append(allErrs, field.Invalid(targetPath.Child("address"), target.Address, "must have a valid port number"))
+		}
+	}
+	return allErrs
+}
+
+var (
+	// remoteTidbClusterComponents are the components of the TidbCluster in another Kubernetes cluster
+	// which can be scraped by the static endpoints
+	remoteTidbClusterComponents = []string{"pd", "tidb", "tikv", "tiproxy", "tiflash", "tiflash-proxy", "pump", "drainer", "ticdc", "importer", "lightning"}
+	// remoteDMClusterComponents are the components of the DMCluster in another Kubernetes cluster
+	// which can be scraped by the static endpoints
+	remoteDMClusterComponents = []string{"dm-master", "dm-worker"}
+)
+
+// validateRemoteClusterRef validates the reference of the cluster in another Kubernetes cluster
+func validateRemoteClusterRef(remote *v1alpha1.RemoteClusterRef, components []string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if remote.KubernetesCluster == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("kubernetesCluster"), "name of the Kubernetes cluster must be set"))
+	} else {
+		for _, msg := range validation.IsDNS1123Label(remote.KubernetesCluster) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("kubernetesCluster"), remote.KubernetesCluster, msg))
+		}
+	}
+	if (remote.KubeconfigSecret == "") == (len(remote.Endpoints) == 0) {
+		allErrs = append(allErrs, field.Invalid(fldPath, remote.KubeconfigSecret, "exactly one of kubeconfigSecret and endpoints must be set"))
+	}
+	for i, endpoints := range remote.Endpoints {
+		endpointsPath := fldPath.Child("endpoints").Index(i)
+		valid := false
+		for _, c := range components {
+			if endpoints.Component == c {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			allErrs = append(allErrs, field.NotSupported(endpointsPath.Child("component"), endpoints.Component, components))
+		}
+		if len(endpoints.Targets) == 0 {
+			allErrs = append(allErrs, field.Required(endpointsPath.Child("targets"), "targets of the component must be set"))
+		}
+		for j, target := range endpoints.Targets {
+			if host, port, err := net.SplitHostPort(target); err != nil || host == "" || port == "" {
+				allErrs = append(allErrs, field.Invalid(endpointsPath.Child("targets").Index(j), target, "must be in the form of host:port"))
+			}
+		}
+	}
+	return allErrs
+}
+
 // clusterVersionLessThan2 makes sure that deployed dm cluster version not to be v1.0.x
 func clusterVersionLessThan2(version string) (bool, error) {
 	v, err := semver.NewVersion(version)
@@ -16,6 +16,7 @@ package validation
 import (
 	"strings"
 	"testing"
+	"time"
 
 	. "github.com/onsi/gomega"
 	"github.com/pingcap/tidb-operator/pkg/apis/label"
@@ -672,6 +673,536 @@ func TestValidatePromDurationStr(t *testing.T) {
 	}
 }
 
+func TestValidatePrometheusRetention(t *testing.T) {
+	successCases := []v1alpha1.PrometheusRetentionSpec{
+		{},
+		{Raw: pointer.StringPtr("2d")},
+		{Raw: pointer.StringPtr("2d"), Downsampled: &v1alpha1.PrometheusDownsampledSpec{Retention: "30d"}},
+		{Downsampled: &v1alpha1.PrometheusDownsampledSpec{Retention: "1y", Interval: pointer.StringPtr("1h")}},
+		{Downsampled: &v1alpha1.PrometheusDownsampledSpec{Retention: "30d", Rules: []v1alpha1.PrometheusRecordingRule{
+			{Record: "downsampled:tidb_server_query_duration_seconds:p99", Expr: "histogram_quantile(0.99, sum(rate(tidb_server_handle_query_duration_seconds_bucket[5m])) by (le))"},
+		}}},
+	}
+
+	for _, c := range successCases {
+		errs := validatePrometheusRetention(&c, field.NewPath("retention"))
+		if len(errs) > 0 {
+			t.Errorf("expected success: %v", errs)
+		}
+	}
+
+	errorCases := []v1alpha1.PrometheusRetentionSpec{
+		{Raw: pointer.StringPtr("prom")},
+		{Downsampled: &v1alpha1.PrometheusDownsampledSpec{}},
+		{Downsampled: &v1alpha1.PrometheusDownsampledSpec{Retention: "-30d"}},
+		{Downsampled: &v1alpha1.PrometheusDownsampledSpec{Retention: "30d", Interval: pointer.StringPtr("30s")}},
+		{Downsampled: &v1alpha1.PrometheusDownsampledSpec{Retention: "30d", Interval: pointer.StringPtr("5")}},
+		{Downsampled: &v1alpha1.PrometheusDownsampledSpec{Retention: "30d", Rules: []v1alpha1.PrometheusRecordingRule{{Record: "tidb_server_query:rate", Expr: "sum(rate(tidb_server_query_total[5m]))"}}}},
+		{Downsampled: &v1alpha1.PrometheusDownsampledSpec{Retention: "30d", Rules: []v1alpha1.PrometheusRecordingRule{{Record: "downsampled:tidb-server", Expr: "sum(tidb_server_connections)"}}}},
+		{Downsampled: &v1alpha1.PrometheusDownsampledSpec{Retention: "30d", Rules: []v1alpha1.PrometheusRecordingRule{{Record: "downsampled:tidb_server_connections"}}}},
+	}
+
+	for _, c := range errorCases {
+		errs := validatePrometheusRetention(&c, field.NewPath("retention"))
+		if len(errs) == 0 {
+			t.Errorf("expected failure for %v", c)
+		}
+	}
+}
+
+func TestValidateBlackboxExporter(t *testing.T) {
+	successCases := []v1alpha1.BlackboxExporterSpec{
+		{},
+		{Interval: pointer.StringPtr("1m")},
+		{Targets: []v1alpha1.BlackboxTarget{
+			{Name: "tidb-lb", Address: "tidb.example.com:4000"},
+			{Name: "pd-lb", Address: "10.0.0.1:2379", TLS: true},
+		}},
+	}
+
+	for _, c := range successCases {
+		errs := validateBlackboxExporter(&c, field.NewPath("blackbox"))
+		if len(errs) > 0 {
+			t.Errorf("expected success: %v", errs)
+		}
+	}
+
+	errorCases := []v1alpha1.BlackboxExporterSpec{
+		{Interval: pointer.StringPtr("30")},
+		{Targets: []v1alpha1.BlackboxTarget{{Address: "tidb.example.com:4000"}}},
+		{Targets: []v1alpha1.BlackboxTarget{{Name: "tidb-lb", Address: "tidb.example.com"}}},
+		{Targets: []v1alpha1.BlackboxTarget{{Name: "tidb-lb", Address: "tidb.example.com:mysql"}}},
+		{Targets: []v1alpha1.BlackboxTarget{{Name: "tidb-lb", Address: ":4000"}}},
+		{Targets: []v1alpha1.BlackboxTarget{
+			{Name: "tidb-lb", Address: "10.0.0.1:4000"},
+			{Name: "tidb-lb", Address: "10.0.0.2:4000"},
+		}},
+	}
+
+	for _, c := range errorCases {
+		errs := validateBlackboxExporter(&c, field.NewPath("blackbox"))
+		if len(errs) == 0 {
+			t.Errorf("expected failure for %v", c)
+		}
+	}
+}
+
+func TestValidateConfigProfile(t *testing.T) {
+	for _, profile := range []v1alpha1.ConfigProfile{"", v1alpha1.ConfigProfileOLTP, v1alpha1.ConfigProfileHTAP, v1alpha1.ConfigProfileLight} {
+		if errs := validateConfigProfile(profile, field.NewPath("configProfile")); len(errs) > 0 {
+			t.Errorf("expected success for %q: %v", profile, errs)
+		}
+	}
+	for _, profile := range []v1alpha1.ConfigProfile{"oltp", "OLAP"} {
+		if errs := validateConfigProfile(profile, field.NewPath("configProfile")); len(errs) == 0 {
+			t.Errorf("expected failure for %q", profile)
+		}
+	}
+}
+
+func TestValidateRemoteClusterRef(t *testing.T) {
+	successCases := []v1alpha1.RemoteClusterRef{
+		{KubernetesCluster: "us-west-1", KubeconfigSecret: "us-west-1-kubeconfig"},
+		{KubernetesCluster: "us-west-1", KubeconfigSecret: "us-west-1-kubeconfig", TLSClientSecret: "basic-client"},
+		{KubernetesCluster: "us-west-1", Endpoints: []v1alpha1.RemoteClusterEndpoints{
+			{Component: "pd", Targets: []string{"10.0.0.1:2379", "10.0.0.2:2379"}},
+			{Component: "tikv", Targets: []string{"tikv.example.com:20180"}},
+		}},
+	}
+
+	for _, c := range successCases {
+		errs := validateRemoteClusterRef(&c, remoteTidbClusterComponents, field.NewPath("remote"))
+		if len(errs) > 0 {
+			t.Errorf("expected success: %v", errs)
+		}
+	}
+
+	errorCases := []v1alpha1.RemoteClusterRef{
+		{KubeconfigSecret: "us-west-1-kubeconfig"},
+		{KubernetesCluster: "US_West", KubeconfigSecret: "us-west-1-kubeconfig"},
+		{KubernetesCluster: "us-west-1"},
+		{KubernetesCluster: "us-west-1", KubeconfigSecret: "us-west-1-kubeconfig", Endpoints: []v1alpha1.RemoteClusterEndpoints{
+			{Component: "pd", Targets: []string{"10.0.0.1:2379"}},
+		}},
+		{KubernetesCluster: "us-west-1", Endpoints: []v1alpha1.RemoteClusterEndpoints{{Component: "dm-master", Targets: []string{"10.0.0.1:8261"}}}},
+		{KubernetesCluster: "us-west-1", Endpoints: []v1alpha1.RemoteClusterEndpoints{{Component: "pd"}}},
+		{KubernetesCluster: "us-west-1", Endpoints: []v1alpha1.RemoteClusterEndpoints{{Component: "pd", Targets: []string{"10.0.0.1"}}}},
+	}
+
+	for _, c := range errorCases {
+		errs := validateRemoteClusterRef(&c, remoteTidbClusterComponents, field.NewPath("remote"))
+		if len(errs) == 0 {
+			t.Errorf("expected failure for %v", c)
+		}
+	}
+}
+
+func TestValidatePodTemplatePatches(t *testing.T) {
+	successCases := [][]v1alpha1.PodTemplatePatch{
+		nil,
+		{
+			{Patch: "spec:\n  shareProcessNamespace: true\n"},
+			{Type: v1alpha1.PodTemplatePatchTypeStrategicMerge, Patch: `{"metadata":{"labels":{"a":"b"}}}`},
+			{Type: v1alpha1.PodTemplatePatchTypeJSON, Patch: `[{"op":"add","path":"/spec/hostname","value":"foo"}]`},
+		},
+	}
+
+	for _, c := range successCases {
+		errs := validatePodTemplatePatches(c, field.NewPath("podTemplatePatches"))
+		if len(errs) > 0 {
+			t.Errorf("expected success: %v", errs)
+		}
+	}
+
+	errorCases := [][]v1alpha1.PodTemplatePatch{
+		{
+			{Patch: "spec: [unclosed"},
+		},
+		{
+			{Type: v1alpha1.PodTemplatePatchTypeJSON, Patch: `{"spec":{}}`},
+		},
+		{
+			{Type: v1alpha1.PodTemplatePatchTypeStrategicMerge, Patch: `[]`},
+		},
+		{
+			{Type: "merge", Patch: `{}`},
+		},
+	}
+
+	for _, c := range errorCases {
+		errs := validatePodTemplatePatches(c, field.NewPath("podTemplatePatches"))
+		if len(errs) == 0 {
+			t.Errorf("expected failure for %v", c)
+		}
+	}
+}
+
+func TestValidateTiDBPlugins(t *testing.T) {
+	successCases := []v1alpha1.TiDBSpec{
+		{},
+		{Plugins: []string{"audit-1", "whitelist-1"}},
+		{
+			Plugins:      []string{"audit-1"},
+			PluginSource: &v1alpha1.TiDBPluginSource{Image: "plugins:v7.1.0", Path: "/opt/plugins"},
+		},
+		{
+			Plugins: []string{"audit-1"},
+			PluginSource: &v1alpha1.TiDBPluginSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "plugins"},
+			},
+		},
+	}
+
+	for _, c := range successCases {
+		errs := validateTiDBPlugins(&c, field.NewPath("tidb"))
+		if len(errs) > 0 {
+			t.Errorf("expected success: %v", errs)
+		}
+	}
+
+	errorCases := []v1alpha1.TiDBSpec{
+		{Plugins: []string{"audit"}},
+		{Plugins: []string{"audit-"}},
+		{PluginSource: &v1alpha1.TiDBPluginSource{Image: "plugins:v7.1.0"}},
+		{Plugins: []string{"audit-1"}, PluginSource: &v1alpha1.TiDBPluginSource{}},
+		{
+			Plugins: []string{"audit-1"},
+			PluginSource: &v1alpha1.TiDBPluginSource{
+				Image:                 "plugins:v7.1.0",
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "plugins"},
+			},
+		},
+		{
+			Plugins: []string{"audit-1"},
+			PluginSource: &v1alpha1.TiDBPluginSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{},
+			},
+		},
+		{
+			Plugins:      []string{"audit-1"},
+			PluginSource: &v1alpha1.TiDBPluginSource{Image: "plugins:v7.1.0", Path: "plugins"},
+		},
+		{
+			Plugins:      []string{"audit-1"},
+			PluginSource: &v1alpha1.TiDBPluginSource{Image: "plugins:v7.1.0", Path: "/plugins; rm -rf /"},
+		},
+	}
+
+	for _, c := range errorCases {
+		errs := validateTiDBPlugins(&c, field.NewPath("tidb"))
+		if len(errs) == 0 {
+			t.Errorf("expected failure for %v", c)
+		}
+	}
+}
+
+func TestValidateTiDBPluginVersion(t *testing.T) {
+	g := NewGomegaWithT(t)
+	tests := []struct {
+		name           string
+		version        string
+		tidbVersion    *string
+		pluginVersion  string
+		expectedErrors int
+	}{
+		{
+			name:           "no plugin version",
+			version:        "v7.1.0",
+			expectedErrors: 0,
+		},
+		{
+			name:           "same version",
+			version:        "v7.1.0",
+			pluginVersion:  "v7.1.0",
+			expectedErrors: 0,
+		},
+		{
+			name:           "tidb version overrides cluster version",
+			version:        "v7.1.0",
+			tidbVersion:    pointer.StringPtr("v7.1.1"),
+			pluginVersion:  "v7.1.1",
+			expectedErrors: 0,
+		},
+		{
+			name:           "unknown tidb version",
+			version:        "latest",
+			pluginVersion:  "v7.1.0",
+			expectedErrors: 0,
+		},
+		{
+			name:           "different version",
+			version:        "v7.1.0",
+			pluginVersion:  "v6.5.0",
+			expectedErrors: 1,
+		},
+		{
+			name:           "invalid plugin version",
+			version:        "v7.1.0",
+			pluginVersion:  "foo",
+			expectedErrors: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := &v1alpha1.TidbClusterSpec{
+				Version: tt.version,
+				TiDB: &v1alpha1.TiDBSpec{
+					Plugins: []string{"audit-1"},
+					PluginSource: &v1alpha1.TiDBPluginSource{
+						Image:       "plugins",
+						TiDBVersion: tt.pluginVersion,
+					},
+				},
+			}
+			spec.TiDB.Version = tt.tidbVersion
+			errs := validateTiDBPluginVersion(spec, field.NewPath("spec", "tidb"))
+			g.Expect(len(errs)).Should(Equal(tt.expectedErrors))
+		})
+	}
+}
+
+func TestValidateTiKVFeatures(t *testing.T) {
+	g := NewGomegaWithT(t)
+	tests := []struct {
+		name            string
+		version         string
+		tikvVersion     *string
+		config          map[string]interface{}
+		separateRaftLog *bool
+		expectedErrors  int
+	}{
+		{
+			name:           "defaults",
+			version:        "v7.5.0",
+			expectedErrors: 0,
+		},
+		{
+			name:    "supported features",
+			version: "v7.5.0",
+			config: map[string]interface{}{
+				"raft-engine.enable":           true,
+				"rocksdb.titan.enabled":        true,
+				"raftstore.store-io-pool-size": 1,
+				"storage.engine":               "raft-kv",
+			},
+			expectedErrors: 0,
+		},
+		{
+			name:           "raft-engine is not supported",
+			version:        "v5.0.0",
+			config:         map[string]interface{}{"raft-engine.enable": true},
+			expectedErrors: 1,
+		},
+		{
+			name:           "tikv version overrides cluster version",
+			version:        "v7.5.0",
+			tikvVersion:    pointer.StringPtr("v5.2.0"),
+			config:         map[string]interface{}{"raftstore.store-io-pool-size": 1},
+			expectedErrors: 1,
+		},
+		{
+			name:           "unknown tikv version",
+			version:        "latest",
+			config:         map[string]interface{}{"storage.engine": "partitioned-raft-kv"},
+			expectedErrors: 0,
+		},
+		{
+			name:    "partitioned-raft-kv without raft-engine",
+			version: "v7.5.0",
+			config: map[string]interface{}{
+				"storage.engine":     "partitioned-raft-kv",
+				"raft-engine.enable": false,
+			},
+			expectedErrors: 1,
+		},
+		{
+			name:    "partitioned-raft-kv with titan",
+			version: "v6.5.0",
+			config: map[string]interface{}{
+				"storage.engine":        "partitioned-raft-kv",
+				"rocksdb.titan.enabled": true,
+			},
+			expectedErrors: 2,
+		},
+		{
+			name:            "separate raft log with raft-engine",
+			version:         "v7.5.0",
+			config:          map[string]interface{}{"raft-engine.enable": true},
+			separateRaftLog: pointer.BoolPtr(true),
+			expectedErrors:  1,
+		},
+		{
+			name:            "separate raft log with raftdb",
+			version:         "v7.5.0",
+			config:          map[string]interface{}{"raft-engine.enable": false},
+			separateRaftLog: pointer.BoolPtr(true),
+			expectedErrors:  0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := &v1alpha1.TidbClusterSpec{
+				Version: tt.version,
+				TiKV: &v1alpha1.TiKVSpec{
+					Config:          v1alpha1.NewTiKVConfig(),
+					SeparateRaftLog: tt.separateRaftLog,
+				},
+			}
+			spec.TiKV.Version = tt.tikvVersion
+			for k, v := range tt.config {
+				spec.TiKV.Config.Set(k, v)
+			}
+			errs := validateTiKVFeatures(spec, field.NewPath("spec", "tikv"))
+			g.Expect(len(errs)).Should(Equal(tt.expectedErrors))
+		})
+	}
+}
+
+func TestValidateTiDBPorts(t *testing.T) {
+	g := NewGomegaWithT(t)
+	tests := []struct {
+		name           string
+		ports          *v1alpha1.TiDBPorts
+		service        *v1alpha1.TiDBServiceSpec
+		config         map[string]interface{}
+		expectedErrors int
+	}{
+		{
+			name:           "default ports",
+			config:         map[string]interface{}{"port": 4001},
+			expectedErrors: 0,
+		},
+		{
+			name:           "custom ports",
+			ports:          &v1alpha1.TiDBPorts{Server: pointer.Int32Ptr(14000), Status: pointer.Int32Ptr(20080)},
+			config:         map[string]interface{}{"port": 14000},
+			expectedErrors: 0,
+		},
+		{
+			name:           "server port conflicts with status port",
+			ports:          &v1alpha1.TiDBPorts{Server: pointer.Int32Ptr(10080)},
+			expectedErrors: 1,
+		},
+		{
+			name:           "ports conflict with config",
+			ports:          &v1alpha1.TiDBPorts{Server: pointer.Int32Ptr(14000), Status: pointer.Int32Ptr(20080)},
+			config:         map[string]interface{}{"port": 4000, "status.status-port": 10080},
+			expectedErrors: 2,
+		},
+		{
+			name:  "status port conflicts with service port",
+			ports: &v1alpha1.TiDBPorts{Status: pointer.Int32Ptr(4000), Server: pointer.Int32Ptr(14000)},
+			service: &v1alpha1.TiDBServiceSpec{
+				AdditionalPorts: []corev1.ServicePort{{Name: "extra", Port: 4000}},
+			},
+			expectedErrors: 2,
+		},
+		{
+			name:  "status port is not exposed",
+			ports: &v1alpha1.TiDBPorts{Status: pointer.Int32Ptr(4000), Server: pointer.Int32Ptr(14000)},
+			service: &v1alpha1.TiDBServiceSpec{
+				ServiceSpec:  v1alpha1.ServiceSpec{Port: pointer.Int32Ptr(3306)},
+				ExposeStatus: pointer.BoolPtr(false),
+			},
+			expectedErrors: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := &v1alpha1.TiDBSpec{
+				Config:  v1alpha1.NewTiDBConfig(),
+				Ports:   tt.ports,
+				Service: tt.service,
+			}
+			for k, v := range tt.config {
+				spec.Config.Set(k, v)
+			}
+			errs := validateTiDBPorts(spec, field.NewPath("spec", "tidb"))
+			g.Expect(len(errs)).Should(Equal(tt.expectedErrors))
+		})
+	}
+}
+
+func TestValidateTiKVPorts(t *testing.T) {
+	g := NewGomegaWithT(t)
+	tests := []struct {
+		name           string
+		ports          *v1alpha1.TiKVPorts
+		config         map[string]interface{}
+		expectedErrors int
+	}{
+		{
+			name:           "default ports",
+			config:         map[string]interface{}{"server.status-addr": "0.0.0.0:20180"},
+			expectedErrors: 0,
+		},
+		{
+			name:           "custom ports",
+			ports:          &v1alpha1.TiKVPorts{Server: pointer.Int32Ptr(30160), Status: pointer.Int32Ptr(30180)},
+			expectedErrors: 0,
+		},
+		{
+			name:           "server port conflicts with status port",
+			ports:          &v1alpha1.TiKVPorts{Server: pointer.Int32Ptr(20180)},
+			expectedErrors: 1,
+		},
+		{
+			name:           "ports conflict with config",
+			ports:          &v1alpha1.TiKVPorts{Server: pointer.Int32Ptr(30160)},
+			config:         map[string]interface{}{"server.addr": "0.0.0.0:20160", "server.advertise-status-addr": "tikv:20180"},
+			expectedErrors: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := &v1alpha1.TiKVSpec{
+				Config: v1alpha1.NewTiKVConfig(),
+				Ports:  tt.ports,
+			}
+			for k, v := range tt.config {
+				spec.Config.Set(k, v)
+			}
+			errs := validateTiKVPorts(spec, field.NewPath("spec", "tikv"))
+			g.Expect(len(errs)).Should(Equal(tt.expectedErrors))
+		})
+	}
+
+	old := &v1alpha1.TiKVSpec{}
+	g.Expect(disallowMutateTiKVServerPort(old, &v1alpha1.TiKVSpec{Ports: &v1alpha1.TiKVPorts{Status: pointer.Int32Ptr(30180)}}, field.NewPath("spec", "tikv"))).Should(BeEmpty())
+	g.Expect(disallowMutateTiKVServerPort(old, &v1alpha1.TiKVSpec{Ports: &v1alpha1.TiKVPorts{Server: pointer.Int32Ptr(30160)}}, field.NewPath("spec", "tikv"))).Should(HaveLen(1))
+}
+
+func TestValidateServiceIPFamilies(t *testing.T) {
+	g := NewGomegaWithT(t)
+	singleStack := corev1.IPFamilyPolicySingleStack
+	dualStack := corev1.IPFamilyPolicyRequireDualStack
+	tests := []struct {
+		name           string
+		policy         *corev1.IPFamilyPolicyType
+		families       []corev1.IPFamily
+		expectedErrors int
+	}{
+		{name: "dual stack", policy: &dualStack, families: []corev1.IPFamily{corev1.IPv6Protocol, corev1.IPv4Protocol}},
+		{name: "single stack", policy: &singleStack, families: []corev1.IPFamily{corev1.IPv6Protocol}},
+		{name: "single stack with two families", policy: &singleStack, families: []corev1.IPFamily{corev1.IPv6Protocol, corev1.IPv4Protocol}, expectedErrors: 1},
+		{name: "unknown family", families: []corev1.IPFamily{"IPv5"}, expectedErrors: 1},
+		{name: "duplicated families", families: []corev1.IPFamily{corev1.IPv4Protocol, corev1.IPv4Protocol}, expectedErrors: 1},
+		{name: "too many families", families: []corev1.IPFamily{corev1.IPv4Protocol, corev1.IPv6Protocol, corev1.IPv4Protocol}, expectedErrors: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := &v1alpha1.ServiceSpec{IPFamilyPolicy: tt.policy, IPFamilies: tt.families}
+			errs := validateService(spec, field.NewPath("spec", "tidb"))
+			g.Expect(len(errs)).Should(Equal(tt.expectedErrors))
+		})
+	}
+}
+
 func TestValidatePDAddresses(t *testing.T) {
 	successCases := [][]string{
 		{
@@ -826,3 +1357,455 @@ func Test_disallowMutateBootstrapSQLConfigMapName(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateAdditionalArgs(t *testing.T) {
+	g := NewGomegaWithT(t)
+	tests := []struct {
+		name           string
+		version        v1alpha1.StartScriptVersion
+		args           []string
+		expectedErrors int
+	}{
+		{
+			name:    "valid args",
+			version: v1alpha1.StartScriptV2,
+			args:    []string{"--flag", "-flag=value", "--addrs=a:1,b:2"},
+		},
+		{
+			name:           "start script v1",
+			version:        v1alpha1.StartScriptV1,
+			args:           []string{"--flag"},
+			expectedErrors: 1,
+		},
+		{
+			name:           "invalid args",
+			version:        v1alpha1.StartScriptV2,
+			args:           []string{"value", "--flag=$(id)", "--flag=a b", "--flag=*"},
+			expectedErrors: 4,
+		},
+		{
+			name:           "conflict with a generated flag",
+			version:        v1alpha1.StartScriptV2,
+			args:           []string{"--flag", "--data-dir=/tmp/tikv"},
+			expectedErrors: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := &v1alpha1.TidbClusterSpec{
+				StartScriptVersion: tt.version,
+				TiKV:               &v1alpha1.TiKVSpec{AdditionalArgs: tt.args},
+			}
+			errs := validateAdditionalArgs(spec, field.NewPath("spec"))
+			g.Expect(errs).To(HaveLen(tt.expectedErrors))
+		})
+	}
+}
+
+func TestValidateTopologyZones(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbCluster()
+	tc.Spec.SchedulerName = "tidb-scheduler"
+	tc.Spec.TopologyZones = &v1alpha1.TopologyZones{
+		TiKV: map[string]int32{"us-east-1a": 2, "us-east-1b": 1},
+		TiDB: map[string]int32{"us-east-1a": 1},
+	}
+	g.Expect(validateTopologyZones(&tc.Spec, field.NewPath("spec", "topologyZones"))).To(BeEmpty())
+
+	// the quotas are not enforced by the default scheduler
+	tc.Spec.TiDB.SchedulerName = pointer.StringPtr(corev1.DefaultSchedulerName)
+	errs := validateTopologyZones(&tc.Spec, field.NewPath("spec", "topologyZones"))
+	g.Expect(errs).To(HaveLen(1))
+	g.Expect(errs[0].Field).To(Equal("spec.topologyZones.tidb"))
+	tc.Spec.TiDB.SchedulerName = nil
+	tc.Spec.SchedulerName = ""
+	g.Expect(validateTopologyZones(&tc.Spec, field.NewPath("spec", "topologyZones"))).To(HaveLen(2))
+	tc.Spec.SchedulerName = "tidb-scheduler"
+
+	// the component is not deployed
+	tc.Spec.TopologyZones.TiFlash = map[string]int32{"us-east-1a": 1}
+	errs = validateTopologyZones(&tc.Spec, field.NewPath("spec", "topologyZones"))
+	g.Expect(errs).To(HaveLen(1))
+	g.Expect(errs[0].Type).To(Equal(field.ErrorTypeForbidden))
+	tc.Spec.TopologyZones.TiFlash = nil
+
+	tc.Spec.TopologyZones.TiKV["us-east-1c"] = -1
+	tc.Spec.TopologyZones.TopologyKey = "invalid key"
+	g.Expect(validateTopologyZones(&tc.Spec, field.NewPath("spec", "topologyZones"))).To(HaveLen(2))
+}
+
+func TestValidateAdditionalArgsOfTiProxyAndDM(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// TiProxy supports additional args with start script v1
+	spec := &v1alpha1.TidbClusterSpec{
+		StartScriptVersion: v1alpha1.StartScriptV1,
+		TiProxy:            &v1alpha1.TiProxySpec{AdditionalArgs: []string{"--flag"}},
+	}
+	g.Expect(validateAdditionalArgs(spec, field.NewPath("spec"))).To(BeEmpty())
+	spec.TiProxy.AdditionalArgs = []string{"--config=/tmp/proxy.toml"}
+	g.Expect(validateAdditionalArgs(spec, field.NewPath("spec"))).To(HaveLen(1))
+
+	master := &v1alpha1.MasterSpec{StorageSize: "10Gi", AdditionalArgs: []string{"--flag=value"}}
+	g.Expect(validateMasterSpec(master, field.NewPath("spec", "master"))).To(BeEmpty())
+	master.AdditionalArgs = []string{"--master-addr=:8261"}
+	g.Expect(validateMasterSpec(master, field.NewPath("spec", "master"))).To(HaveLen(1))
+
+	worker := &v1alpha1.WorkerSpec{AdditionalArgs: []string{"--worker-addr=0.0.0.0:8262", "--flag=a b"}}
+	g.Expect(validateWorkerSpec(worker, field.NewPath("spec", "worker"))).To(HaveLen(2))
+}
+
+func TestValidateDMRelay(t *testing.T) {
+	g := NewGomegaWithT(t)
+	fldPath := field.NewPath("spec", "worker", "relay")
+	int64Ptr := func(i int64) *int64 { return &i }
+
+	relay := &v1alpha1.DMRelaySpec{
+		Sources: []string{"mysql-01", "mysql-02"},
+		Purge:   &v1alpha1.DMRelayPurge{Interval: int64Ptr(3600), Expires: int64Ptr(24), RemainSpace: int64Ptr(15)},
+	}
+	g.Expect(validateDMRelay(relay, "100Gi", fldPath)).To(BeEmpty())
+	// the storage size is unknown
+	g.Expect(validateDMRelay(relay, "", fldPath)).To(BeEmpty())
+
+	errs := validateDMRelay(relay, "10Gi", fldPath)
+	g.Expect(errs).To(HaveLen(1))
+	g.Expect(errs[0].Field).To(Equal("spec.worker.relay.purge.remainSpace"))
+
+	relay.Sources = []string{"mysql-01", "", "mysql-01"}
+	relay.Purge.Expires = int64Ptr(-1)
+	errs = validateDMRelay(relay, "100Gi", fldPath)
+	g.Expect(errs).To(HaveLen(3))
+	g.Expect(errs[0].Type).To(Equal(field.ErrorTypeRequired))
+	g.Expect(errs[1].Type).To(Equal(field.ErrorTypeDuplicate))
+	g.Expect(errs[2].Field).To(Equal("spec.worker.relay.purge.expires"))
+}
+
+func TestValidateTiProxyTLSOffload(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := &v1alpha1.TidbCluster{
+		Spec: v1alpha1.TidbClusterSpec{
+			TiDB:    &v1alpha1.TiDBSpec{},
+			TiProxy: &v1alpha1.TiProxySpec{TLSOffload: true},
+		},
+	}
+	fldPath := field.NewPath("spec", "tiproxy", "tlsOffload")
+	g.Expect(validateTiProxyTLSOffload(tc, fldPath)).To(HaveLen(1))
+
+	tc.Spec.TiDB.TLSClient = &v1alpha1.TiDBTLSClient{Enabled: true}
+	g.Expect(validateTiProxyTLSOffload(tc, fldPath)).To(BeEmpty())
+
+	// TiDB must not accept the clients without TLS bypassing TiProxy
+	tc.Spec.TiDB.Config = v1alpha1.NewTiDBConfig()
+	tc.Spec.TiDB.Config.Set("security.require-secure-transport", false)
+	errs := validateTiProxyTLSOffload(tc, fldPath)
+	g.Expect(errs).To(HaveLen(1))
+	g.Expect(errs[0].Field).To(Equal("spec.tidb.config[security.require-secure-transport]"))
+	tc.Spec.TiDB.Config.Set("security.require-secure-transport", true)
+	g.Expect(validateTiProxyTLSOffload(tc, fldPath)).To(BeEmpty())
+
+	tc.Annotations = map[string]string{label.AnnSkipTLSWhenConnectTiDB: ""}
+	g.Expect(validateTiProxyTLSOffload(tc, fldPath)).To(HaveLen(1))
+}
+
+func TestValidateProxy(t *testing.T) {
+	g := NewGomegaWithT(t)
+	tests := []struct {
+		name           string
+		proxy          v1alpha1.HTTPProxyConfig
+		expectedErrors int
+	}{
+		{
+			name: "valid proxy",
+			proxy: v1alpha1.HTTPProxyConfig{
+				HTTPProxy:  "http://proxy.example.com:3128",
+				HTTPSProxy: "socks5://10.0.0.1:1080",
+				NoProxy:    ".svc, .cluster.local,10.0.0.0/8,localhost",
+			},
+		},
+		{
+			name: "invalid proxy",
+			proxy: v1alpha1.HTTPProxyConfig{
+				HTTPProxy:  "proxy.example.com:3128",
+				HTTPSProxy: "ftp://proxy.example.com",
+				NoProxy:    ".svc,,http://example.com",
+			},
+			expectedErrors: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := validateProxy(&tt.proxy, field.NewPath("spec", "proxy"))
+			g.Expect(errs).To(HaveLen(tt.expectedErrors))
+		})
+	}
+}
+
+func Test_disallowStandbyOnBootstrappedCluster(t *testing.T) {
+	g := NewGomegaWithT(t)
+	bootstrapped := &v1alpha1.TidbCluster{Status: v1alpha1.TidbClusterStatus{ClusterID: "1"}}
+	standby := &v1alpha1.TidbCluster{Spec: v1alpha1.TidbClusterSpec{Standby: &v1alpha1.StandbySpec{}}}
+	tests := []struct {
+		name      string
+		old       *v1alpha1.TidbCluster
+		new       *v1alpha1.TidbCluster
+		wantError bool
+	}{
+		{
+			name:      "set on a new cluster",
+			old:       &v1alpha1.TidbCluster{},
+			new:       standby,
+			wantError: false,
+		},
+		{
+			name:      "set on a bootstrapped cluster",
+			old:       bootstrapped,
+			new:       standby,
+			wantError: true,
+		},
+		{
+			name:      "promote",
+			old:       standby,
+			new:       bootstrapped,
+			wantError: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := disallowStandbyOnBootstrappedCluster(tt.old, tt.new, field.NewPath("spec.standby"))
+			if tt.wantError {
+				g.Expect(len(errs)).NotTo(Equal(0))
+			} else {
+				g.Expect(len(errs)).To(Equal(0))
+			}
+		})
+	}
+}
+
+func TestValidatePodDNS(t *testing.T) {
+	successCases := []v1alpha1.TidbClusterSpec{
+		{},
+		{
+			DNSPolicy: corev1.DNSClusterFirstWithHostNet,
+			PD:        &v1alpha1.PDSpec{},
+		},
+		{
+			DNSPolicy: corev1.DNSNone,
+			DNSConfig: &corev1.PodDNSConfig{
+				Nameservers: []string{"169.254.20.10"},
+				Searches:    []string{"svc.cluster.local", "cluster-b.local."},
+				Options:     []corev1.PodDNSConfigOption{{Name: "ndots", Value: pointer.StringPtr("2")}},
+			},
+			PD: &v1alpha1.PDSpec{},
+		},
+		{
+			DNSConfig: &corev1.PodDNSConfig{Nameservers: []string{"169.254.20.10"}},
+			TiKV: &v1alpha1.TiKVSpec{
+				ComponentSpec: v1alpha1.ComponentSpec{DNSPolicy: corev1.DNSNone},
+			},
+		},
+	}
+
+	for _, c := range successCases {
+		errs := validateTiDBClusterSpec(&c, field.NewPath("spec"))
+		if len(errs) > 0 {
+			t.Errorf("expected success: %v", errs)
+		}
+	}
+
+	errorCases := []v1alpha1.TidbClusterSpec{
+		{DNSPolicy: "ClusterLast"},
+		{DNSConfig: &corev1.PodDNSConfig{Nameservers: []string{"1.1.1.1", "1.1.1.2", "1.1.1.3", "1.1.1.4"}}},
+		{DNSConfig: &corev1.PodDNSConfig{Nameservers: []string{"dns.local"}}},
+		{DNSConfig: &corev1.PodDNSConfig{Searches: []string{"a", "b", "c", "d", "e", "f", "g"}}},
+		{DNSConfig: &corev1.PodDNSConfig{Searches: []string{"Invalid_Domain"}}},
+		{DNSConfig: &corev1.PodDNSConfig{Options: []corev1.PodDNSConfigOption{{Value: pointer.StringPtr("2")}}}},
+		{
+			DNSPolicy: corev1.DNSNone,
+			PD:        &v1alpha1.PDSpec{},
+		},
+		{
+			TiDB: &v1alpha1.TiDBSpec{
+				ComponentSpec: v1alpha1.ComponentSpec{
+					DNSPolicy: corev1.DNSNone,
+					DNSConfig: &corev1.PodDNSConfig{Searches: []string{"svc.cluster.local"}},
+				},
+			},
+		},
+	}
+
+	for _, c := range errorCases {
+		errs := validateTiDBClusterSpec(&c, field.NewPath("spec"))
+		if len(errs) == 0 {
+			t.Errorf("expected failure for %v", c)
+		}
+	}
+}
+
+func TestValidateTopologySpreadConstraints(t *testing.T) {
+	successCases := []v1alpha1.TidbClusterSpec{
+		{
+			TopologySpreadConstraints: []v1alpha1.TopologySpreadConstraint{
+				{TopologyKey: corev1.LabelTopologyZone},
+				{TopologyKey: corev1.LabelHostname, MaxSkew: 2, WhenUnsatisfiable: corev1.ScheduleAnyway},
+			},
+		},
+		{
+			TiKV: &v1alpha1.TiKVSpec{
+				ComponentSpec: v1alpha1.ComponentSpec{
+					TopologySpreadConstraints: []v1alpha1.TopologySpreadConstraint{
+						{TopologyKey: corev1.LabelTopologyZone, WhenUnsatisfiable: corev1.DoNotSchedule},
+					},
+				},
+			},
+		},
+	}
+	for _, c := range successCases {
+		errs := validateTiDBClusterSpec(&c, field.NewPath("spec"))
+		if len(errs) > 0 {
+			t.Errorf("expected success: %v", errs)
+		}
+	}
+
+	errorCases := []v1alpha1.TidbClusterSpec{
+		{
+			TopologySpreadConstraints: []v1alpha1.TopologySpreadConstraint{
+				{TopologyKey: corev1.LabelTopologyZone},
+				{TopologyKey: corev1.LabelTopologyZone, MaxSkew: 2},
+			},
+		},
+		{
+			TopologySpreadConstraints: []v1alpha1.TopologySpreadConstraint{{TopologyKey: corev1.LabelHostname, MaxSkew: -1}},
+		},
+		{
+			TiDB: &v1alpha1.TiDBSpec{
+				ComponentSpec: v1alpha1.ComponentSpec{
+					TopologySpreadConstraints: []v1alpha1.TopologySpreadConstraint{
+						{TopologyKey: corev1.LabelTopologyZone, WhenUnsatisfiable: "Never"},
+					},
+				},
+			},
+		},
+	}
+	for _, c := range errorCases {
+		errs := validateTiDBClusterSpec(&c, field.NewPath("spec"))
+		if len(errs) == 0 {
+			t.Errorf("expected failure for %v", c)
+		}
+	}
+}
+
+func TestValidatePreStopHook(t *testing.T) {
+	g := NewGomegaWithT(t)
+	tests := []struct {
+		name               string
+		hook               *v1alpha1.PreStopHookSpec
+		gracePeriodSeconds *int64
+		expectedErrors     int
+	}{
+		{
+			name:           "no preStop hook",
+			expectedErrors: 0,
+		},
+		{
+			name:           "default timeout",
+			hook:           &v1alpha1.PreStopHookSpec{},
+			expectedErrors: 0,
+		},
+		{
+			name:               "timeout less than grace period",
+			hook:               &v1alpha1.PreStopHookSpec{Timeout: &metav1.Duration{Duration: 30 * time.Second}},
+			gracePeriodSeconds: pointer.Int64Ptr(60),
+			expectedErrors:     0,
+		},
+		{
+			name:               "timeout not less than grace period",
+			hook:               &v1alpha1.PreStopHookSpec{Timeout: &metav1.Duration{Duration: time.Minute}},
+			gracePeriodSeconds: pointer.Int64Ptr(60),
+			expectedErrors:     1,
+		},
+		{
+			name:           "negative timeout",
+			hook:           &v1alpha1.PreStopHookSpec{Timeout: &metav1.Duration{Duration: -time.Second}},
+			expectedErrors: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := validatePreStopHook(tt.hook, tt.gracePeriodSeconds, field.NewPath("preStopHook"))
+			g.Expect(len(errs)).Should(Equal(tt.expectedErrors))
+		})
+	}
+
+	// the exec lifecycle.preStop is composed with preStopHook, the other handlers can't be composed
+	tc := newTidbCluster()
+	tc.Spec.TiDB.PreStopHook = &v1alpha1.PreStopHookSpec{}
+	tc.Spec.TiDB.Lifecycle = &corev1.Lifecycle{PreStop: &corev1.Handler{Exec: &corev1.ExecAction{Command: []string{"sleep", "10"}}}}
+	g.Expect(validateTiDBSpec(tc.Spec.TiDB, field.NewPath("tidb"))).To(BeEmpty())
+	tc.Spec.TiDB.Lifecycle = &corev1.Lifecycle{PreStop: &corev1.Handler{HTTPGet: &corev1.HTTPGetAction{Path: "/deregister"}}}
+	g.Expect(validateTiDBSpec(tc.Spec.TiDB, field.NewPath("tidb"))).To(HaveLen(1))
+}
+
+func TestValidateEmbeddedDiscovery(t *testing.T) {
+	g := NewGomegaWithT(t)
+	tests := []struct {
+		name           string
+		modify         func(tc *v1alpha1.TidbCluster)
+		expectedErrors int
+	}{
+		{
+			name:           "v2 start script",
+			modify:         func(tc *v1alpha1.TidbCluster) {},
+			expectedErrors: 0,
+		},
+		{
+			name: "v1 start script",
+			modify: func(tc *v1alpha1.TidbCluster) {
+				tc.Spec.StartScriptVersion = v1alpha1.StartScriptV1
+			},
+			expectedErrors: 1,
+		},
+		{
+			name: "without pd",
+			modify: func(tc *v1alpha1.TidbCluster) {
+				tc.Spec.PD = nil
+			},
+			expectedErrors: 1,
+		},
+		{
+			name: "across k8s",
+			modify: func(tc *v1alpha1.TidbCluster) {
+				tc.Spec.AcrossK8s = true
+			},
+			expectedErrors: 1,
+		},
+		{
+			name: "heterogeneous cluster",
+			modify: func(tc *v1alpha1.TidbCluster) {
+				tc.Spec.Cluster = &v1alpha1.TidbClusterRef{Name: "base"}
+			},
+			expectedErrors: 1,
+		},
+		{
+			name: "preStop hooks",
+			modify: func(tc *v1alpha1.TidbCluster) {
+				tc.Spec.PD.PreStopHook = &v1alpha1.PreStopHookSpec{}
+				tc.Spec.TiKV.PreStopHook = &v1alpha1.PreStopHookSpec{}
+			},
+			expectedErrors: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := newTidbCluster()
+			tc.Spec.Discovery.Mode = v1alpha1.DiscoveryModeEmbedded
+			tc.Spec.StartScriptVersion = v1alpha1.StartScriptV2
+			tt.modify(tc)
+			errs := validateEmbeddedDiscovery(&tc.Spec, field.NewPath("spec"))
+			g.Expect(len(errs)).Should(Equal(tt.expectedErrors))
+		})
+	}
+}
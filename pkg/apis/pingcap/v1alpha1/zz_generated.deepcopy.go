@@ -29,6 +29,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 	types "k8s.io/apimachinery/pkg/types"
+	intstr "k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -298,6 +299,27 @@ func (in *BackupSchedule) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupScheduleGCStatus) DeepCopyInto(out *BackupScheduleGCStatus) {
+	*out = *in
+	if in.DeletedBackups != nil {
+		in, out := &in.DeletedBackups, &out.DeletedBackups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupScheduleGCStatus.
+func (in *BackupScheduleGCStatus) DeepCopy() *BackupScheduleGCStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupScheduleGCStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BackupScheduleList) DeepCopyInto(out *BackupScheduleList) {
 	*out = *in
@@ -344,6 +366,11 @@ func (in *BackupScheduleSpec) DeepCopyInto(out *BackupScheduleSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.MaxSize != nil {
+		in, out := &in.MaxSize, &out.MaxSize
+		*out = new(string)
+		**out = **in
+	}
 	in.BackupTemplate.DeepCopyInto(&out.BackupTemplate)
 	if in.LogBackupTemplate != nil {
 		in, out := &in.LogBackupTemplate, &out.LogBackupTemplate
@@ -389,6 +416,11 @@ func (in *BackupScheduleStatus) DeepCopyInto(out *BackupScheduleStatus) {
 		in, out := &in.AllBackupCleanTime, &out.AllBackupCleanTime
 		*out = (*in).DeepCopy()
 	}
+	if in.GC != nil {
+		in, out := &in.GC, &out.GC
+		*out = new(BackupScheduleGCStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -461,6 +493,13 @@ func (in *BackupSpec) DeepCopyInto(out *BackupSpec) {
 		*out = new(v1.Affinity)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	if in.CleanOption != nil {
 		in, out := &in.CleanOption, &out.CleanOption
 		*out = new(CleanOption)
@@ -490,6 +529,7 @@ func (in *BackupStatus) DeepCopyInto(out *BackupStatus) {
 	*out = *in
 	in.TimeStarted.DeepCopyInto(&out.TimeStarted)
 	in.TimeCompleted.DeepCopyInto(&out.TimeCompleted)
+	in.TimeQueued.DeepCopyInto(&out.TimeQueued)
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]BackupCondition, len(*in))
@@ -518,6 +558,11 @@ func (in *BackupStatus) DeepCopyInto(out *BackupStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Topology != nil {
+		in, out := &in.Topology, &out.Topology
+		*out = new(BackupTopology)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -531,6 +576,27 @@ func (in *BackupStatus) DeepCopy() *BackupStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupTopology) DeepCopyInto(out *BackupTopology) {
+	*out = *in
+	if in.Zones != nil {
+		in, out := &in.Zones, &out.Zones
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupTopology.
+func (in *BackupTopology) DeepCopy() *BackupTopology {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupTopology)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BasicAuth) DeepCopyInto(out *BasicAuth) {
 	*out = *in
@@ -581,6 +647,11 @@ func (in *BasicAutoScalerSpec) DeepCopyInto(out *BasicAutoScalerSpec) {
 			(*out)[key] = *val.DeepCopy()
 		}
 	}
+	if in.Schedules != nil {
+		in, out := &in.Schedules, &out.Schedules
+		*out = make([]ScheduledScalingRule, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -601,6 +672,11 @@ func (in *BasicAutoScalerStatus) DeepCopyInto(out *BasicAutoScalerStatus) {
 		in, out := &in.LastAutoScalingTimestamp, &out.LastAutoScalingTimestamp
 		*out = (*in).DeepCopy()
 	}
+	if in.LastAppliedSchedule != nil {
+		in, out := &in.LastAppliedSchedule, &out.LastAppliedSchedule
+		*out = new(ScheduledScalingStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -671,6 +747,49 @@ func (in *Binlog) DeepCopy() *Binlog {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BlackboxExporterSpec) DeepCopyInto(out *BlackboxExporterSpec) {
+	*out = *in
+	in.MonitorContainer.DeepCopyInto(&out.MonitorContainer)
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(string)
+		**out = **in
+	}
+	if in.Targets != nil {
+		in, out := &in.Targets, &out.Targets
+		*out = make([]BlackboxTarget, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BlackboxExporterSpec.
+func (in *BlackboxExporterSpec) DeepCopy() *BlackboxExporterSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BlackboxExporterSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BlackboxTarget) DeepCopyInto(out *BlackboxTarget) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BlackboxTarget.
+func (in *BlackboxTarget) DeepCopy() *BlackboxTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(BlackboxTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CDCConfigWraper) DeepCopyInto(out *CDCConfigWraper) {
 	*out = *in
@@ -691,6 +810,62 @@ func (in *CDCConfigWraper) DeepCopy() *CDCConfigWraper {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CanaryUpgrade) DeepCopyInto(out *CanaryUpgrade) {
+	*out = *in
+	if in.Step != nil {
+		in, out := &in.Step, &out.Step
+		*out = new(int32)
+		**out = **in
+	}
+	if in.SoakDuration != nil {
+		in, out := &in.SoakDuration, &out.SoakDuration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.MaxRestarts != nil {
+		in, out := &in.MaxRestarts, &out.MaxRestarts
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxErrorRate != nil {
+		in, out := &in.MaxErrorRate, &out.MaxErrorRate
+		*out = new(float64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CanaryUpgrade.
+func (in *CanaryUpgrade) DeepCopy() *CanaryUpgrade {
+	if in == nil {
+		return nil
+	}
+	out := new(CanaryUpgrade)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CanaryUpgradeStatus) DeepCopyInto(out *CanaryUpgradeStatus) {
+	*out = *in
+	if in.SoakStartTime != nil {
+		in, out := &in.SoakStartTime, &out.SoakStartTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CanaryUpgradeStatus.
+func (in *CanaryUpgradeStatus) DeepCopy() *CanaryUpgradeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CanaryUpgradeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CleanOption) DeepCopyInto(out *CleanOption) {
 	*out = *in
@@ -711,6 +886,11 @@ func (in *CleanOption) DeepCopy() *CleanOption {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterRef) DeepCopyInto(out *ClusterRef) {
 	*out = *in
+	if in.Remote != nil {
+		in, out := &in.Remote, &out.Remote
+		*out = new(RemoteClusterRef)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -850,6 +1030,23 @@ func (in *CommonConfig) DeepCopy() *CommonConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentSize) DeepCopyInto(out *ComponentSize) {
+	*out = *in
+	in.ResourceRequirements.DeepCopyInto(&out.ResourceRequirements)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentSize.
+func (in *ComponentSize) DeepCopy() *ComponentSize {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentSize)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ComponentSpec) DeepCopyInto(out *ComponentSpec) {
 	*out = *in
@@ -909,6 +1106,11 @@ func (in *ComponentSpec) DeepCopyInto(out *ComponentSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.Metadata != nil {
+		in, out := &in.Metadata, &out.Metadata
+		*out = new(ObjectMetadata)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Tolerations != nil {
 		in, out := &in.Tolerations, &out.Tolerations
 		*out = make([]v1.Toleration, len(*in))
@@ -993,6 +1195,16 @@ func (in *ComponentSpec) DeepCopyInto(out *ComponentSpec) {
 		*out = new(Probe)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.PodTemplatePatches != nil {
+		in, out := &in.PodTemplatePatches, &out.PodTemplatePatches
+		*out = make([]PodTemplatePatch, len(*in))
+		copy(*out, *in)
+	}
+	if in.Lifecycle != nil {
+		in, out := &in.Lifecycle, &out.Lifecycle
+		*out = new(v1.Lifecycle)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1193,6 +1405,22 @@ func (in *DMClusterList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DMClusterRef) DeepCopyInto(out *DMClusterRef) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DMClusterRef.
+func (in *DMClusterRef) DeepCopy() *DMClusterRef {
+	if in == nil {
+		return nil
+	}
+	out := new(DMClusterRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DMClusterSpec) DeepCopyInto(out *DMClusterSpec) {
 	*out = *in
@@ -1221,7 +1449,7 @@ func (in *DMClusterSpec) DeepCopyInto(out *DMClusterSpec) {
 	if in.TLSCluster != nil {
 		in, out := &in.TLSCluster, &out.TLSCluster
 		*out = new(TLSCluster)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.TLSClientSecretNames != nil {
 		in, out := &in.TLSClientSecretNames, &out.TLSClientSecretNames
@@ -1373,7 +1601,9 @@ func (in *DMMonitorSpec) DeepCopyInto(out *DMMonitorSpec) {
 	if in.Clusters != nil {
 		in, out := &in.Clusters, &out.Clusters
 		*out = make([]ClusterRef, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	in.Initializer.DeepCopyInto(&out.Initializer)
 	return
@@ -1390,112 +1620,432 @@ func (in *DMMonitorSpec) DeepCopy() *DMMonitorSpec {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DMSecurityConfig) DeepCopyInto(out *DMSecurityConfig) {
+func (in *DMRelayPurge) DeepCopyInto(out *DMRelayPurge) {
 	*out = *in
-	if in.SSLCA != nil {
-		in, out := &in.SSLCA, &out.SSLCA
-		*out = new(string)
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(int64)
 		**out = **in
 	}
-	if in.SSLCert != nil {
-		in, out := &in.SSLCert, &out.SSLCert
-		*out = new(string)
+	if in.Expires != nil {
+		in, out := &in.Expires, &out.Expires
+		*out = new(int64)
 		**out = **in
 	}
-	if in.SSLKey != nil {
-		in, out := &in.SSLKey, &out.SSLKey
-		*out = new(string)
+	if in.RemainSpace != nil {
+		in, out := &in.RemainSpace, &out.RemainSpace
+		*out = new(int64)
 		**out = **in
 	}
-	if in.CertAllowedCN != nil {
-		in, out := &in.CertAllowedCN, &out.CertAllowedCN
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DMRelayPurge.
+func (in *DMRelayPurge) DeepCopy() *DMRelayPurge {
+	if in == nil {
+		return nil
+	}
+	out := new(DMRelayPurge)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DMRelaySpec) DeepCopyInto(out *DMRelaySpec) {
+	*out = *in
+	if in.Sources != nil {
+		in, out := &in.Sources, &out.Sources
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Purge != nil {
+		in, out := &in.Purge, &out.Purge
+		*out = new(DMRelayPurge)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DMSecurityConfig.
-func (in *DMSecurityConfig) DeepCopy() *DMSecurityConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DMRelaySpec.
+func (in *DMRelaySpec) DeepCopy() *DMRelaySpec {
 	if in == nil {
 		return nil
 	}
-	out := new(DMSecurityConfig)
+	out := new(DMRelaySpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DashboardConfig) DeepCopyInto(out *DashboardConfig) {
+func (in *DMSecurityConfig) DeepCopyInto(out *DMSecurityConfig) {
 	*out = *in
-	if in.TiDBCAPath != nil {
-		in, out := &in.TiDBCAPath, &out.TiDBCAPath
-		*out = new(string)
-		**out = **in
-	}
-	if in.TiDBCertPath != nil {
-		in, out := &in.TiDBCertPath, &out.TiDBCertPath
+	if in.SSLCA != nil {
+		in, out := &in.SSLCA, &out.SSLCA
 		*out = new(string)
 		**out = **in
 	}
-	if in.TiDBKeyPath != nil {
-		in, out := &in.TiDBKeyPath, &out.TiDBKeyPath
+	if in.SSLCert != nil {
+		in, out := &in.SSLCert, &out.SSLCert
 		*out = new(string)
 		**out = **in
 	}
-	if in.PublicPathPrefix != nil {
-		in, out := &in.PublicPathPrefix, &out.PublicPathPrefix
+	if in.SSLKey != nil {
+		in, out := &in.SSLKey, &out.SSLKey
 		*out = new(string)
 		**out = **in
 	}
-	if in.InternalProxy != nil {
-		in, out := &in.InternalProxy, &out.InternalProxy
-		*out = new(bool)
-		**out = **in
-	}
-	if in.DisableTelemetry != nil {
-		in, out := &in.DisableTelemetry, &out.DisableTelemetry
-		*out = new(bool)
-		**out = **in
-	}
-	if in.EnableTelemetry != nil {
-		in, out := &in.EnableTelemetry, &out.EnableTelemetry
-		*out = new(bool)
-		**out = **in
-	}
-	if in.EnableExperimental != nil {
-		in, out := &in.EnableExperimental, &out.EnableExperimental
-		*out = new(bool)
-		**out = **in
+	if in.CertAllowedCN != nil {
+		in, out := &in.CertAllowedCN, &out.CertAllowedCN
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DashboardConfig.
-func (in *DashboardConfig) DeepCopy() *DashboardConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DMSecurityConfig.
+func (in *DMSecurityConfig) DeepCopy() *DMSecurityConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(DashboardConfig)
+	out := new(DMSecurityConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DataResource) DeepCopyInto(out *DataResource) {
+func (in *DMSubTaskStatus) DeepCopyInto(out *DMSubTaskStatus) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	if in.Data != nil {
-		in, out := &in.Data, &out.Data
-		*out = make([]byte, len(*in))
-		copy(*out, *in)
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataResource.
-func (in *DataResource) DeepCopy() *DataResource {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DMSubTaskStatus.
+func (in *DMSubTaskStatus) DeepCopy() *DMSubTaskStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DMSubTaskStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DMTableMigrateRule) DeepCopyInto(out *DMTableMigrateRule) {
+	*out = *in
+	out.Source = in.Source
+	if in.Target != nil {
+		in, out := &in.Target, &out.Target
+		*out = new(DMTableTarget)
+		**out = **in
+	}
+	if in.BinlogFilterRules != nil {
+		in, out := &in.BinlogFilterRules, &out.BinlogFilterRules
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DMTableMigrateRule.
+func (in *DMTableMigrateRule) DeepCopy() *DMTableMigrateRule {
+	if in == nil {
+		return nil
+	}
+	out := new(DMTableMigrateRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DMTableSelector) DeepCopyInto(out *DMTableSelector) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DMTableSelector.
+func (in *DMTableSelector) DeepCopy() *DMTableSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(DMTableSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DMTableTarget) DeepCopyInto(out *DMTableTarget) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DMTableTarget.
+func (in *DMTableTarget) DeepCopy() *DMTableTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(DMTableTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DMTask) DeepCopyInto(out *DMTask) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DMTask.
+func (in *DMTask) DeepCopy() *DMTask {
+	if in == nil {
+		return nil
+	}
+	out := new(DMTask)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DMTask) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DMTaskList) DeepCopyInto(out *DMTaskList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DMTask, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DMTaskList.
+func (in *DMTaskList) DeepCopy() *DMTaskList {
+	if in == nil {
+		return nil
+	}
+	out := new(DMTaskList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DMTaskList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DMTaskSource) DeepCopyInto(out *DMTaskSource) {
+	*out = *in
+	if in.BinlogPos != nil {
+		in, out := &in.BinlogPos, &out.BinlogPos
+		*out = new(int64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DMTaskSource.
+func (in *DMTaskSource) DeepCopy() *DMTaskSource {
+	if in == nil {
+		return nil
+	}
+	out := new(DMTaskSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DMTaskSpec) DeepCopyInto(out *DMTaskSpec) {
+	*out = *in
+	out.Cluster = in.Cluster
+	if in.Sources != nil {
+		in, out := &in.Sources, &out.Sources
+		*out = make([]DMTaskSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	out.Target = in.Target
+	if in.TableMigrateRules != nil {
+		in, out := &in.TableMigrateRules, &out.TableMigrateRules
+		*out = make([]DMTableMigrateRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DMTaskSpec.
+func (in *DMTaskSpec) DeepCopy() *DMTaskSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DMTaskSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DMTaskStatus) DeepCopyInto(out *DMTaskStatus) {
+	*out = *in
+	if in.Sources != nil {
+		in, out := &in.Sources, &out.Sources
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.SubTasks != nil {
+		in, out := &in.SubTasks, &out.SubTasks
+		*out = make([]DMSubTaskStatus, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DMTaskStatus.
+func (in *DMTaskStatus) DeepCopy() *DMTaskStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DMTaskStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DMTaskTarget) DeepCopyInto(out *DMTaskTarget) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DMTaskTarget.
+func (in *DMTaskTarget) DeepCopy() *DMTaskTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(DMTaskTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DashboardConfig) DeepCopyInto(out *DashboardConfig) {
+	*out = *in
+	if in.TiDBCAPath != nil {
+		in, out := &in.TiDBCAPath, &out.TiDBCAPath
+		*out = new(string)
+		**out = **in
+	}
+	if in.TiDBCertPath != nil {
+		in, out := &in.TiDBCertPath, &out.TiDBCertPath
+		*out = new(string)
+		**out = **in
+	}
+	if in.TiDBKeyPath != nil {
+		in, out := &in.TiDBKeyPath, &out.TiDBKeyPath
+		*out = new(string)
+		**out = **in
+	}
+	if in.PublicPathPrefix != nil {
+		in, out := &in.PublicPathPrefix, &out.PublicPathPrefix
+		*out = new(string)
+		**out = **in
+	}
+	if in.InternalProxy != nil {
+		in, out := &in.InternalProxy, &out.InternalProxy
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DisableTelemetry != nil {
+		in, out := &in.DisableTelemetry, &out.DisableTelemetry
+		*out = new(bool)
+		**out = **in
+	}
+	if in.EnableTelemetry != nil {
+		in, out := &in.EnableTelemetry, &out.EnableTelemetry
+		*out = new(bool)
+		**out = **in
+	}
+	if in.EnableExperimental != nil {
+		in, out := &in.EnableExperimental, &out.EnableExperimental
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DashboardConfig.
+func (in *DashboardConfig) DeepCopy() *DashboardConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(DashboardConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataDirPreCheck) DeepCopyInto(out *DataDirPreCheck) {
+	*out = *in
+	if in.FSTypes != nil {
+		in, out := &in.FSTypes, &out.FSTypes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MinAvailable != nil {
+		in, out := &in.MinAvailable, &out.MinAvailable
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataDirPreCheck.
+func (in *DataDirPreCheck) DeepCopy() *DataDirPreCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(DataDirPreCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataResource) DeepCopyInto(out *DataResource) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	if in.Data != nil {
+		in, out := &in.Data, &out.Data
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataResource.
+func (in *DataResource) DeepCopy() *DataResource {
 	if in == nil {
 		return nil
 	}
@@ -1583,6 +2133,43 @@ func (in *DiscoverySpec) DeepCopy() *DiscoverySpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DriftPolicy) DeepCopyInto(out *DriftPolicy) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriftPolicy.
+func (in *DriftPolicy) DeepCopy() *DriftPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(DriftPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DriftedResource) DeepCopyInto(out *DriftedResource) {
+	*out = *in
+	if in.Fields != nil {
+		in, out := &in.Fields, &out.Fields
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriftedResource.
+func (in *DriftedResource) DeepCopy() *DriftedResource {
+	if in == nil {
+		return nil
+	}
+	out := new(DriftedResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DumplingConfig) DeepCopyInto(out *DumplingConfig) {
 	*out = *in
@@ -1596,6 +2183,16 @@ func (in *DumplingConfig) DeepCopyInto(out *DumplingConfig) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Threads != nil {
+		in, out := &in.Threads, &out.Threads
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Rows != nil {
+		in, out := &in.Rows, &out.Rows
+		*out = new(int64)
+		**out = **in
+	}
 	return
 }
 
@@ -1710,6 +2307,11 @@ func (in *ExternalEndpoint) DeepCopy() *ExternalEndpoint {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Failover) DeepCopyInto(out *Failover) {
 	*out = *in
+	if in.Period != nil {
+		in, out := &in.Period, &out.Period
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 	return
 }
 
@@ -2200,12 +2802,72 @@ func (in *GrafanaSpec) DeepCopyInto(out *GrafanaSpec) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrafanaSpec.
-func (in *GrafanaSpec) DeepCopy() *GrafanaSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrafanaSpec.
+func (in *GrafanaSpec) DeepCopy() *GrafanaSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GrafanaSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPProxyConfig) DeepCopyInto(out *HTTPProxyConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPProxyConfig.
+func (in *HTTPProxyConfig) DeepCopy() *HTTPProxyConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPProxyConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HealthProbeSpec) DeepCopyInto(out *HealthProbeSpec) {
+	*out = *in
+	if in.IntervalSeconds != nil {
+		in, out := &in.IntervalSeconds, &out.IntervalSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	in.ResourceRequirements.DeepCopyInto(&out.ResourceRequirements)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HealthProbeSpec.
+func (in *HealthProbeSpec) DeepCopy() *HealthProbeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HealthProbeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HealthProbeStatus) DeepCopyInto(out *HealthProbeStatus) {
+	*out = *in
+	in.LastProbeTime.DeepCopyInto(&out.LastProbeTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HealthProbeStatus.
+func (in *HealthProbeStatus) DeepCopy() *HealthProbeStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(GrafanaSpec)
+	out := new(HealthProbeStatus)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -2379,6 +3041,85 @@ func (in *IsolationRead) DeepCopy() *IsolationRead {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LightningConfig) DeepCopyInto(out *LightningConfig) {
+	*out = *in
+	if in.RegionConcurrency != nil {
+		in, out := &in.RegionConcurrency, &out.RegionConcurrency
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Options != nil {
+		in, out := &in.Options, &out.Options
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LightningConfig.
+func (in *LightningConfig) DeepCopy() *LightningConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(LightningConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LoadAwareRestart) DeepCopyInto(out *LoadAwareRestart) {
+	*out = *in
+	if in.MaxConnections != nil {
+		in, out := &in.MaxConnections, &out.MaxConnections
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxQPS != nil {
+		in, out := &in.MaxQPS, &out.MaxQPS
+		*out = new(float64)
+		**out = **in
+	}
+	if in.MaxDelay != nil {
+		in, out := &in.MaxDelay, &out.MaxDelay
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Window != nil {
+		in, out := &in.Window, &out.Window
+		*out = new(MaintenanceWindow)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoadAwareRestart.
+func (in *LoadAwareRestart) DeepCopy() *LoadAwareRestart {
+	if in == nil {
+		return nil
+	}
+	out := new(LoadAwareRestart)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LoadAwareRestartStatus) DeepCopyInto(out *LoadAwareRestartStatus) {
+	*out = *in
+	in.DelayStartTime.DeepCopyInto(&out.DelayStartTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoadAwareRestartStatus.
+func (in *LoadAwareRestartStatus) DeepCopy() *LoadAwareRestartStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(LoadAwareRestartStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LocalStorageProvider) DeepCopyInto(out *LocalStorageProvider) {
 	*out = *in
@@ -2515,6 +3256,22 @@ func (in *LogTailerSpec) DeepCopy() *LogTailerSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindow) DeepCopyInto(out *MaintenanceWindow) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindow.
+func (in *MaintenanceWindow) DeepCopy() *MaintenanceWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MasterConfig) DeepCopyInto(out *MasterConfig) {
 	*out = *in
@@ -2740,6 +3497,11 @@ func (in *MasterSpec) DeepCopyInto(out *MasterSpec) {
 		*out = new(MasterConfigWraper)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.AdditionalArgs != nil {
+		in, out := &in.AdditionalArgs, &out.AdditionalArgs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -2932,6 +3694,36 @@ func (in *Networks) DeepCopy() *Networks {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectMetadata) DeepCopyInto(out *ObjectMetadata) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectMetadata.
+func (in *ObjectMetadata) DeepCopy() *ObjectMetadata {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectMetadata)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ObservedStorageVolumeStatus) DeepCopyInto(out *ObservedStorageVolumeStatus) {
 	*out = *in
@@ -3234,6 +4026,23 @@ func (in *PDConfigWraper) DeepCopy() *PDConfigWraper {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PDDisruptionStatus) DeepCopyInto(out *PDDisruptionStatus) {
+	*out = *in
+	in.BeginTime.DeepCopyInto(&out.BeginTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PDDisruptionStatus.
+func (in *PDDisruptionStatus) DeepCopy() *PDDisruptionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PDDisruptionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PDFailureMember) DeepCopyInto(out *PDFailureMember) {
 	*out = *in
@@ -3290,6 +4099,32 @@ func (in PDLabelPropertyConfig) DeepCopy() PDLabelPropertyConfig {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PDLeaderPreference) DeepCopyInto(out *PDLeaderPreference) {
+	*out = *in
+	if in.Zones != nil {
+		in, out := &in.Zones, &out.Zones
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Members != nil {
+		in, out := &in.Members, &out.Members
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PDLeaderPreference.
+func (in *PDLeaderPreference) DeepCopy() *PDLeaderPreference {
+	if in == nil {
+		return nil
+	}
+	out := new(PDLeaderPreference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PDLogConfig) DeepCopyInto(out *PDLogConfig) {
 	*out = *in
@@ -3766,6 +4601,21 @@ func (in *PDSpec) DeepCopyInto(out *PDSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.PVReclaimPolicy != nil {
+		in, out := &in.PVReclaimPolicy, &out.PVReclaimPolicy
+		*out = new(v1.PersistentVolumeReclaimPolicy)
+		**out = **in
+	}
+	if in.ScaleInPVCRetentionPolicy != nil {
+		in, out := &in.ScaleInPVCRetentionPolicy, &out.ScaleInPVCRetentionPolicy
+		*out = new(ScaleInPVCRetentionPolicy)
+		**out = **in
+	}
+	if in.ScaleInPVCDeletionGracePeriod != nil {
+		in, out := &in.ScaleInPVCDeletionGracePeriod, &out.ScaleInPVCDeletionGracePeriod
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 	if in.StorageVolumes != nil {
 		in, out := &in.StorageVolumes, &out.StorageVolumes
 		*out = make([]StorageVolume, len(*in))
@@ -3778,6 +4628,11 @@ func (in *PDSpec) DeepCopyInto(out *PDSpec) {
 		*out = new(PDConfigWraper)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.AdditionalArgs != nil {
+		in, out := &in.AdditionalArgs, &out.AdditionalArgs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.TLSClientSecretName != nil {
 		in, out := &in.TLSClientSecretName, &out.TLSClientSecretName
 		*out = new(string)
@@ -3793,6 +4648,26 @@ func (in *PDSpec) DeepCopyInto(out *PDSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.PreStopHook != nil {
+		in, out := &in.PreStopHook, &out.PreStopHook
+		*out = new(PreStopHookSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodDisruptionBudget != nil {
+		in, out := &in.PodDisruptionBudget, &out.PodDisruptionBudget
+		*out = new(PodDisruptionBudgetSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LeaderPreference != nil {
+		in, out := &in.LeaderPreference, &out.LeaderPreference
+		*out = new(PDLeaderPreference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DataDirPreCheck != nil {
+		in, out := &in.DataDirPreCheck, &out.DataDirPreCheck
+		*out = new(DataDirPreCheck)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -3858,6 +4733,26 @@ func (in *PDStatus) DeepCopyInto(out *PDStatus) {
 			(*out)[key] = outVal
 		}
 	}
+	if in.PendingChanges != nil {
+		in, out := &in.PendingChanges, &out.PendingChanges
+		*out = new(PendingChanges)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Disruptions != nil {
+		in, out := &in.Disruptions, &out.Disruptions
+		*out = make(map[string]*PDDisruptionStatus, len(*in))
+		for key, val := range *in {
+			var outVal *PDDisruptionStatus
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = new(PDDisruptionStatus)
+				(*in).DeepCopyInto(*out)
+			}
+			(*out)[key] = outVal
+		}
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]metav1.Condition, len(*in))
@@ -3926,6 +4821,40 @@ func (in PDStoreLabels) DeepCopy() PDStoreLabels {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PendingChanges) DeepCopyInto(out *PendingChanges) {
+	*out = *in
+	in.Since.DeepCopyInto(&out.Since)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PendingChanges.
+func (in *PendingChanges) DeepCopy() *PendingChanges {
+	if in == nil {
+		return nil
+	}
+	out := new(PendingChanges)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PendingScaleIn) DeepCopyInto(out *PendingScaleIn) {
+	*out = *in
+	in.RequestTime.DeepCopyInto(&out.RequestTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PendingScaleIn.
+func (in *PendingScaleIn) DeepCopy() *PendingScaleIn {
+	if in == nil {
+		return nil
+	}
+	out := new(PendingScaleIn)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Performance) DeepCopyInto(out *Performance) {
 	*out = *in
@@ -4074,38 +5003,118 @@ func (in *PlanCache) DeepCopyInto(out *PlanCache) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlanCache.
-func (in *PlanCache) DeepCopy() *PlanCache {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlanCache.
+func (in *PlanCache) DeepCopy() *PlanCache {
+	if in == nil {
+		return nil
+	}
+	out := new(PlanCache)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Plugin) DeepCopyInto(out *Plugin) {
+	*out = *in
+	if in.Dir != nil {
+		in, out := &in.Dir, &out.Dir
+		*out = new(string)
+		**out = **in
+	}
+	if in.Load != nil {
+		in, out := &in.Load, &out.Load
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Plugin.
+func (in *Plugin) DeepCopy() *Plugin {
+	if in == nil {
+		return nil
+	}
+	out := new(Plugin)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodDisruptionBudgetSpec) DeepCopyInto(out *PodDisruptionBudgetSpec) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodDisruptionBudgetSpec.
+func (in *PodDisruptionBudgetSpec) DeepCopy() *PodDisruptionBudgetSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PodDisruptionBudgetSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodRestart) DeepCopyInto(out *PodRestart) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodRestart.
+func (in *PodRestart) DeepCopy() *PodRestart {
 	if in == nil {
 		return nil
 	}
-	out := new(PlanCache)
+	out := new(PodRestart)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Plugin) DeepCopyInto(out *Plugin) {
+func (in *PodTemplatePatch) DeepCopyInto(out *PodTemplatePatch) {
 	*out = *in
-	if in.Dir != nil {
-		in, out := &in.Dir, &out.Dir
-		*out = new(string)
-		**out = **in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodTemplatePatch.
+func (in *PodTemplatePatch) DeepCopy() *PodTemplatePatch {
+	if in == nil {
+		return nil
 	}
-	if in.Load != nil {
-		in, out := &in.Load, &out.Load
-		*out = new(string)
+	out := new(PodTemplatePatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PreStopHookSpec) DeepCopyInto(out *PreStopHookSpec) {
+	*out = *in
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
 		**out = **in
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Plugin.
-func (in *Plugin) DeepCopy() *Plugin {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PreStopHookSpec.
+func (in *PreStopHookSpec) DeepCopy() *PreStopHookSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(Plugin)
+	out := new(PreStopHookSpec)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -4256,6 +5265,49 @@ func (in *PrometheusConfiguration) DeepCopy() *PrometheusConfiguration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrometheusDownsampledSpec) DeepCopyInto(out *PrometheusDownsampledSpec) {
+	*out = *in
+	in.ResourceRequirements.DeepCopyInto(&out.ResourceRequirements)
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(string)
+		**out = **in
+	}
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]PrometheusRecordingRule, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusDownsampledSpec.
+func (in *PrometheusDownsampledSpec) DeepCopy() *PrometheusDownsampledSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PrometheusDownsampledSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrometheusRecordingRule) DeepCopyInto(out *PrometheusRecordingRule) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusRecordingRule.
+func (in *PrometheusRecordingRule) DeepCopy() *PrometheusRecordingRule {
+	if in == nil {
+		return nil
+	}
+	out := new(PrometheusRecordingRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PrometheusReloaderSpec) DeepCopyInto(out *PrometheusReloaderSpec) {
 	*out = *in
@@ -4273,6 +5325,32 @@ func (in *PrometheusReloaderSpec) DeepCopy() *PrometheusReloaderSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrometheusRetentionSpec) DeepCopyInto(out *PrometheusRetentionSpec) {
+	*out = *in
+	if in.Raw != nil {
+		in, out := &in.Raw, &out.Raw
+		*out = new(string)
+		**out = **in
+	}
+	if in.Downsampled != nil {
+		in, out := &in.Downsampled, &out.Downsampled
+		*out = new(PrometheusDownsampledSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusRetentionSpec.
+func (in *PrometheusRetentionSpec) DeepCopy() *PrometheusRetentionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PrometheusRetentionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PrometheusSpec) DeepCopyInto(out *PrometheusSpec) {
 	*out = *in
@@ -4283,6 +5361,11 @@ func (in *PrometheusSpec) DeepCopyInto(out *PrometheusSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.Retention != nil {
+		in, out := &in.Retention, &out.Retention
+		*out = new(PrometheusRetentionSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Ingress != nil {
 		in, out := &in.Ingress, &out.Ingress
 		*out = new(IngressSpec)
@@ -4324,6 +5407,22 @@ func (in *PrometheusSpec) DeepCopy() *PrometheusSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PropagatedSecret) DeepCopyInto(out *PropagatedSecret) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PropagatedSecret.
+func (in *PropagatedSecret) DeepCopy() *PropagatedSecret {
+	if in == nil {
+		return nil
+	}
+	out := new(PropagatedSecret)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ProxyConfig) DeepCopyInto(out *ProxyConfig) {
 	*out = *in
@@ -4467,10 +5566,30 @@ func (in *PumpSpec) DeepCopyInto(out *PumpSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.PVReclaimPolicy != nil {
+		in, out := &in.PVReclaimPolicy, &out.PVReclaimPolicy
+		*out = new(v1.PersistentVolumeReclaimPolicy)
+		**out = **in
+	}
+	if in.ScaleInPVCRetentionPolicy != nil {
+		in, out := &in.ScaleInPVCRetentionPolicy, &out.ScaleInPVCRetentionPolicy
+		*out = new(ScaleInPVCRetentionPolicy)
+		**out = **in
+	}
+	if in.ScaleInPVCDeletionGracePeriod != nil {
+		in, out := &in.ScaleInPVCDeletionGracePeriod, &out.ScaleInPVCDeletionGracePeriod
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 	if in.Config != nil {
 		in, out := &in.Config, &out.Config
 		*out = (*in).DeepCopy()
 	}
+	if in.AdditionalArgs != nil {
+		in, out := &in.AdditionalArgs, &out.AdditionalArgs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.SetTimeZone != nil {
 		in, out := &in.SetTimeZone, &out.SetTimeZone
 		*out = new(bool)
@@ -4634,6 +5753,50 @@ func (in *ReloaderSpec) DeepCopy() *ReloaderSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteClusterEndpoints) DeepCopyInto(out *RemoteClusterEndpoints) {
+	*out = *in
+	if in.Targets != nil {
+		in, out := &in.Targets, &out.Targets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteClusterEndpoints.
+func (in *RemoteClusterEndpoints) DeepCopy() *RemoteClusterEndpoints {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteClusterEndpoints)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteClusterRef) DeepCopyInto(out *RemoteClusterRef) {
+	*out = *in
+	if in.Endpoints != nil {
+		in, out := &in.Endpoints, &out.Endpoints
+		*out = make([]RemoteClusterEndpoints, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteClusterRef.
+func (in *RemoteClusterRef) DeepCopy() *RemoteClusterRef {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteClusterRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RemoteWriteSpec) DeepCopyInto(out *RemoteWriteSpec) {
 	*out = *in
@@ -4654,6 +5817,11 @@ func (in *RemoteWriteSpec) DeepCopyInto(out *RemoteWriteSpec) {
 		*out = new(BasicAuth)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.BearerTokenSecret != nil {
+		in, out := &in.BearerTokenSecret, &out.BearerTokenSecret
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.TLSConfig != nil {
 		in, out := &in.TLSConfig, &out.TLSConfig
 		*out = new(TLSConfig)
@@ -4694,6 +5862,22 @@ func (in *RemoteWriteSpec) DeepCopy() *RemoteWriteSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RenameRule) DeepCopyInto(out *RenameRule) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RenameRule.
+func (in *RenameRule) DeepCopy() *RenameRule {
+	if in == nil {
+		return nil
+	}
+	out := new(RenameRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Restore) DeepCopyInto(out *Restore) {
 	*out = *in
@@ -4722,6 +5906,27 @@ func (in *Restore) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestoreCheckpoint) DeepCopyInto(out *RestoreCheckpoint) {
+	*out = *in
+	if in.MaxAttempts != nil {
+		in, out := &in.MaxAttempts, &out.MaxAttempts
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreCheckpoint.
+func (in *RestoreCheckpoint) DeepCopy() *RestoreCheckpoint {
+	if in == nil {
+		return nil
+	}
+	out := new(RestoreCheckpoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RestoreCondition) DeepCopyInto(out *RestoreCondition) {
 	*out = *in
@@ -4739,6 +5944,29 @@ func (in *RestoreCondition) DeepCopy() *RestoreCondition {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestoreCurrentProgress) DeepCopyInto(out *RestoreCurrentProgress) {
+	*out = *in
+	if in.EstimatedRemainingTime != nil {
+		in, out := &in.EstimatedRemainingTime, &out.EstimatedRemainingTime
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	in.StepStartTime.DeepCopyInto(&out.StepStartTime)
+	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreCurrentProgress.
+func (in *RestoreCurrentProgress) DeepCopy() *RestoreCurrentProgress {
+	if in == nil {
+		return nil
+	}
+	out := new(RestoreCurrentProgress)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RestoreList) DeepCopyInto(out *RestoreList) {
 	*out = *in
@@ -4805,6 +6033,16 @@ func (in *RestoreSpec) DeepCopyInto(out *RestoreSpec) {
 		*out = new(BRConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Lightning != nil {
+		in, out := &in.Lightning, &out.Lightning
+		*out = new(LightningConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Checkpoint != nil {
+		in, out := &in.Checkpoint, &out.Checkpoint
+		*out = new(RestoreCheckpoint)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Tolerations != nil {
 		in, out := &in.Tolerations, &out.Tolerations
 		*out = make([]v1.Toleration, len(*in))
@@ -4817,6 +6055,13 @@ func (in *RestoreSpec) DeepCopyInto(out *RestoreSpec) {
 		*out = new(v1.Affinity)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	if in.ImagePullSecrets != nil {
 		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
 		*out = make([]v1.LocalObjectReference, len(*in))
@@ -4827,6 +6072,23 @@ func (in *RestoreSpec) DeepCopyInto(out *RestoreSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.TableFilters != nil {
+		in, out := &in.TableFilters, &out.TableFilters
+		*out = make([]TableFilterRule, len(*in))
+		copy(*out, *in)
+	}
+	if in.RenameRules != nil {
+		in, out := &in.RenameRules, &out.RenameRules
+		*out = make([]RenameRule, len(*in))
+		copy(*out, *in)
+	}
+	if in.TopologyMapping != nil {
+		in, out := &in.TopologyMapping, &out.TopologyMapping
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	if in.PodSecurityContext != nil {
 		in, out := &in.PodSecurityContext, &out.PodSecurityContext
 		*out = new(v1.PodSecurityContext)
@@ -4864,59 +6126,137 @@ func (in *RestoreStatus) DeepCopyInto(out *RestoreStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.CurrentProgress != nil {
+		in, out := &in.CurrentProgress, &out.CurrentProgress
+		*out = new(RestoreCurrentProgress)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreStatus.
+func (in *RestoreStatus) DeepCopy() *RestoreStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RestoreStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *S3StorageProvider) DeepCopyInto(out *S3StorageProvider) {
+	*out = *in
+	if in.Options != nil {
+		in, out := &in.Options, &out.Options
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new S3StorageProvider.
+func (in *S3StorageProvider) DeepCopy() *S3StorageProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(S3StorageProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SQLBundleSource) DeepCopyInto(out *SQLBundleSource) {
+	*out = *in
+	if in.ConfigMap != nil {
+		in, out := &in.ConfigMap, &out.ConfigMap
+		*out = new(string)
+		**out = **in
+	}
+	if in.Secret != nil {
+		in, out := &in.Secret, &out.Secret
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SQLBundleSource.
+func (in *SQLBundleSource) DeepCopy() *SQLBundleSource {
+	if in == nil {
+		return nil
+	}
+	out := new(SQLBundleSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SafeTLSConfig) DeepCopyInto(out *SafeTLSConfig) {
+	*out = *in
+	in.CA.DeepCopyInto(&out.CA)
+	in.Cert.DeepCopyInto(&out.Cert)
+	if in.KeySecret != nil {
+		in, out := &in.KeySecret, &out.KeySecret
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreStatus.
-func (in *RestoreStatus) DeepCopy() *RestoreStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SafeTLSConfig.
+func (in *SafeTLSConfig) DeepCopy() *SafeTLSConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(RestoreStatus)
+	out := new(SafeTLSConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *S3StorageProvider) DeepCopyInto(out *S3StorageProvider) {
+func (in *ScaleInApproval) DeepCopyInto(out *ScaleInApproval) {
 	*out = *in
-	if in.Options != nil {
-		in, out := &in.Options, &out.Options
-		*out = make([]string, len(*in))
+	if in.Components != nil {
+		in, out := &in.Components, &out.Components
+		*out = make([]MemberType, len(*in))
 		copy(*out, *in)
 	}
+	if in.Webhook != nil {
+		in, out := &in.Webhook, &out.Webhook
+		*out = new(ScaleInApprovalWebhook)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new S3StorageProvider.
-func (in *S3StorageProvider) DeepCopy() *S3StorageProvider {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaleInApproval.
+func (in *ScaleInApproval) DeepCopy() *ScaleInApproval {
 	if in == nil {
 		return nil
 	}
-	out := new(S3StorageProvider)
+	out := new(ScaleInApproval)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SafeTLSConfig) DeepCopyInto(out *SafeTLSConfig) {
+func (in *ScaleInApprovalWebhook) DeepCopyInto(out *ScaleInApprovalWebhook) {
 	*out = *in
-	in.CA.DeepCopyInto(&out.CA)
-	in.Cert.DeepCopyInto(&out.Cert)
-	if in.KeySecret != nil {
-		in, out := &in.KeySecret, &out.KeySecret
-		*out = new(v1.SecretKeySelector)
-		(*in).DeepCopyInto(*out)
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int32)
+		**out = **in
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SafeTLSConfig.
-func (in *SafeTLSConfig) DeepCopy() *SafeTLSConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaleInApprovalWebhook.
+func (in *ScaleInApprovalWebhook) DeepCopy() *ScaleInApprovalWebhook {
 	if in == nil {
 		return nil
 	}
-	out := new(SafeTLSConfig)
+	out := new(ScaleInApprovalWebhook)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -4947,6 +6287,55 @@ func (in *ScalePolicy) DeepCopy() *ScalePolicy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScheduledScalingRule) DeepCopyInto(out *ScheduledScalingRule) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduledScalingRule.
+func (in *ScheduledScalingRule) DeepCopy() *ScheduledScalingRule {
+	if in == nil {
+		return nil
+	}
+	out := new(ScheduledScalingRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScheduledScalingStatus) DeepCopyInto(out *ScheduledScalingStatus) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduledScalingStatus.
+func (in *ScheduledScalingStatus) DeepCopy() *ScheduledScalingStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ScheduledScalingStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretGrantSubject) DeepCopyInto(out *SecretGrantSubject) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretGrantSubject.
+func (in *SecretGrantSubject) DeepCopy() *SecretGrantSubject {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretGrantSubject)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SecretOrConfigMap) DeepCopyInto(out *SecretOrConfigMap) {
 	*out = *in
@@ -4973,6 +6362,27 @@ func (in *SecretOrConfigMap) DeepCopy() *SecretOrConfigMap {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretPropagation) DeepCopyInto(out *SecretPropagation) {
+	*out = *in
+	if in.Secrets != nil {
+		in, out := &in.Secrets, &out.Secrets
+		*out = make([]PropagatedSecret, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretPropagation.
+func (in *SecretPropagation) DeepCopy() *SecretPropagation {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretPropagation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SecretRef) DeepCopyInto(out *SecretRef) {
 	*out = *in
@@ -5103,6 +6513,16 @@ func (in *ServiceSpec) DeepCopyInto(out *ServiceSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.IPFamilyPolicy != nil {
+		in, out := &in.IPFamilyPolicy, &out.IPFamilyPolicy
+		*out = new(v1.IPFamilyPolicyType)
+		**out = **in
+	}
+	if in.IPFamilies != nil {
+		in, out := &in.IPFamilies, &out.IPFamilies
+		*out = make([]v1.IPFamily, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -5116,6 +6536,22 @@ func (in *ServiceSpec) DeepCopy() *ServiceSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StandbySpec) DeepCopyInto(out *StandbySpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StandbySpec.
+func (in *StandbySpec) DeepCopy() *StandbySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(StandbySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Status) DeepCopyInto(out *Status) {
 	*out = *in
@@ -5294,6 +6730,49 @@ func (in *StorageVolumeStatus) DeepCopy() *StorageVolumeStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StoreDrainStatus) DeepCopyInto(out *StoreDrainStatus) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	if in.EstimatedCompletionTime != nil {
+		in, out := &in.EstimatedCompletionTime, &out.EstimatedCompletionTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StoreDrainStatus.
+func (in *StoreDrainStatus) DeepCopy() *StoreDrainStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(StoreDrainStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StoreFailover) DeepCopyInto(out *StoreFailover) {
+	*out = *in
+	in.Failover.DeepCopyInto(&out.Failover)
+	if in.NodeFailureGracePeriod != nil {
+		in, out := &in.NodeFailureGracePeriod, &out.NodeFailureGracePeriod
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StoreFailover.
+func (in *StoreFailover) DeepCopy() *StoreFailover {
+	if in == nil {
+		return nil
+	}
+	out := new(StoreFailover)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SuspendAction) DeepCopyInto(out *SuspendAction) {
 	*out = *in
@@ -5313,6 +6792,11 @@ func (in *SuspendAction) DeepCopy() *SuspendAction {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TLSCluster) DeepCopyInto(out *TLSCluster) {
 	*out = *in
+	if in.Vault != nil {
+		in, out := &in.Vault, &out.Vault
+		*out = new(VaultPKI)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -5343,6 +6827,60 @@ func (in *TLSConfig) DeepCopy() *TLSConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TableFilterRule) DeepCopyInto(out *TableFilterRule) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TableFilterRule.
+func (in *TableFilterRule) DeepCopy() *TableFilterRule {
+	if in == nil {
+		return nil
+	}
+	out := new(TableFilterRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TeardownSpec) DeepCopyInto(out *TeardownSpec) {
+	*out = *in
+	if in.MaxConcurrentPVCDeletions != nil {
+		in, out := &in.MaxConcurrentPVCDeletions, &out.MaxConcurrentPVCDeletions
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TeardownSpec.
+func (in *TeardownSpec) DeepCopy() *TeardownSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TeardownSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TeardownStatus) DeepCopyInto(out *TeardownStatus) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TeardownStatus.
+func (in *TeardownStatus) DeepCopy() *TeardownStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TeardownStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ThanosSpec) DeepCopyInto(out *ThanosSpec) {
 	*out = *in
@@ -5408,6 +6946,57 @@ func (in *TiCDCCapture) DeepCopy() *TiCDCCapture {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TiCDCChangefeed) DeepCopyInto(out *TiCDCChangefeed) {
+	*out = *in
+	if in.SinkURISecretRef != nil {
+		in, out := &in.SinkURISecretRef, &out.SinkURISecretRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FilterRules != nil {
+		in, out := &in.FilterRules, &out.FilterRules
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TiCDCChangefeed.
+func (in *TiCDCChangefeed) DeepCopy() *TiCDCChangefeed {
+	if in == nil {
+		return nil
+	}
+	out := new(TiCDCChangefeed)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TiCDCChangefeedStatus) DeepCopyInto(out *TiCDCChangefeedStatus) {
+	*out = *in
+	if in.CheckpointTime != nil {
+		in, out := &in.CheckpointTime, &out.CheckpointTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CheckpointLag != nil {
+		in, out := &in.CheckpointLag, &out.CheckpointLag
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TiCDCChangefeedStatus.
+func (in *TiCDCChangefeedStatus) DeepCopy() *TiCDCChangefeedStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TiCDCChangefeedStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TiCDCConfig) DeepCopyInto(out *TiCDCConfig) {
 	*out = *in
@@ -5459,6 +7048,11 @@ func (in *TiCDCSpec) DeepCopyInto(out *TiCDCSpec) {
 		*out = new(CDCConfigWraper)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.AdditionalArgs != nil {
+		in, out := &in.AdditionalArgs, &out.AdditionalArgs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.StorageVolumes != nil {
 		in, out := &in.StorageVolumes, &out.StorageVolumes
 		*out = make([]StorageVolume, len(*in))
@@ -5471,11 +7065,38 @@ func (in *TiCDCSpec) DeepCopyInto(out *TiCDCSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.PVReclaimPolicy != nil {
+		in, out := &in.PVReclaimPolicy, &out.PVReclaimPolicy
+		*out = new(v1.PersistentVolumeReclaimPolicy)
+		**out = **in
+	}
+	if in.ScaleInPVCRetentionPolicy != nil {
+		in, out := &in.ScaleInPVCRetentionPolicy, &out.ScaleInPVCRetentionPolicy
+		*out = new(ScaleInPVCRetentionPolicy)
+		**out = **in
+	}
+	if in.ScaleInPVCDeletionGracePeriod != nil {
+		in, out := &in.ScaleInPVCDeletionGracePeriod, &out.ScaleInPVCDeletionGracePeriod
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 	if in.GracefulShutdownTimeout != nil {
 		in, out := &in.GracefulShutdownTimeout, &out.GracefulShutdownTimeout
 		*out = new(metav1.Duration)
 		**out = **in
 	}
+	if in.PodDisruptionBudget != nil {
+		in, out := &in.PodDisruptionBudget, &out.PodDisruptionBudget
+		*out = new(PodDisruptionBudgetSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Changefeeds != nil {
+		in, out := &in.Changefeeds, &out.Changefeeds
+		*out = make([]TiCDCChangefeed, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -5504,6 +7125,13 @@ func (in *TiCDCStatus) DeepCopyInto(out *TiCDCStatus) {
 			(*out)[key] = val
 		}
 	}
+	if in.Changefeeds != nil {
+		in, out := &in.Changefeeds, &out.Changefeeds
+		*out = make(map[string]TiCDCChangefeedStatus, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
 	if in.Volumes != nil {
 		in, out := &in.Volumes, &out.Volumes
 		*out = make(map[StorageVolumeName]*StorageVolumeStatus, len(*in))
@@ -5519,6 +7147,11 @@ func (in *TiCDCStatus) DeepCopyInto(out *TiCDCStatus) {
 			(*out)[key] = outVal
 		}
 	}
+	if in.PendingChanges != nil {
+		in, out := &in.PendingChanges, &out.PendingChanges
+		*out = new(PendingChanges)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]metav1.Condition, len(*in))
@@ -5550,12 +7183,28 @@ func (in *TiDBAccessConfig) DeepCopyInto(out *TiDBAccessConfig) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TiDBAccessConfig.
-func (in *TiDBAccessConfig) DeepCopy() *TiDBAccessConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TiDBAccessConfig.
+func (in *TiDBAccessConfig) DeepCopy() *TiDBAccessConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(TiDBAccessConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TiDBBindingSpec) DeepCopyInto(out *TiDBBindingSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TiDBBindingSpec.
+func (in *TiDBBindingSpec) DeepCopy() *TiDBBindingSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(TiDBAccessConfig)
+	out := new(TiDBBindingSpec)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -5873,6 +7522,92 @@ func (in *TiDBMember) DeepCopy() *TiDBMember {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TiDBPluginSource) DeepCopyInto(out *TiDBPluginSource) {
+	*out = *in
+	if in.PersistentVolumeClaim != nil {
+		in, out := &in.PersistentVolumeClaim, &out.PersistentVolumeClaim
+		*out = new(v1.PersistentVolumeClaimVolumeSource)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TiDBPluginSource.
+func (in *TiDBPluginSource) DeepCopy() *TiDBPluginSource {
+	if in == nil {
+		return nil
+	}
+	out := new(TiDBPluginSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TiDBPorts) DeepCopyInto(out *TiDBPorts) {
+	*out = *in
+	if in.Server != nil {
+		in, out := &in.Server, &out.Server
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Status != nil {
+		in, out := &in.Status, &out.Status
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TiDBPorts.
+func (in *TiDBPorts) DeepCopy() *TiDBPorts {
+	if in == nil {
+		return nil
+	}
+	out := new(TiDBPorts)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TiDBServerLabelsStatus) DeepCopyInto(out *TiDBServerLabelsStatus) {
+	*out = *in
+	if in.OutOfSyncMembers != nil {
+		in, out := &in.OutOfSyncMembers, &out.OutOfSyncMembers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TiDBServerLabelsStatus.
+func (in *TiDBServerLabelsStatus) DeepCopy() *TiDBServerLabelsStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TiDBServerLabelsStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TiDBServerTLSStatus) DeepCopyInto(out *TiDBServerTLSStatus) {
+	*out = *in
+	in.LastChangeTime.DeepCopyInto(&out.LastChangeTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TiDBServerTLSStatus.
+func (in *TiDBServerTLSStatus) DeepCopy() *TiDBServerTLSStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TiDBServerTLSStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TiDBServiceSpec) DeepCopyInto(out *TiDBServiceSpec) {
 	*out = *in
@@ -5949,6 +7684,11 @@ func (in *TiDBSpec) DeepCopyInto(out *TiDBSpec) {
 	*out = *in
 	in.ComponentSpec.DeepCopyInto(&out.ComponentSpec)
 	in.ResourceRequirements.DeepCopyInto(&out.ResourceRequirements)
+	if in.OfflineOrdinals != nil {
+		in, out := &in.OfflineOrdinals, &out.OfflineOrdinals
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
 	if in.Service != nil {
 		in, out := &in.Service, &out.Service
 		*out = new(TiDBServiceSpec)
@@ -5989,14 +7729,24 @@ func (in *TiDBSpec) DeepCopyInto(out *TiDBSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.PluginSource != nil {
+		in, out := &in.PluginSource, &out.PluginSource
+		*out = new(TiDBPluginSource)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Config != nil {
 		in, out := &in.Config, &out.Config
 		*out = new(TiDBConfigWraper)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.Lifecycle != nil {
-		in, out := &in.Lifecycle, &out.Lifecycle
-		*out = new(v1.Lifecycle)
+	if in.AdditionalArgs != nil {
+		in, out := &in.AdditionalArgs, &out.AdditionalArgs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PreStopHook != nil {
+		in, out := &in.PreStopHook, &out.PreStopHook
+		*out = new(PreStopHookSpec)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.StorageVolumes != nil {
@@ -6011,6 +7761,21 @@ func (in *TiDBSpec) DeepCopyInto(out *TiDBSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.PVReclaimPolicy != nil {
+		in, out := &in.PVReclaimPolicy, &out.PVReclaimPolicy
+		*out = new(v1.PersistentVolumeReclaimPolicy)
+		**out = **in
+	}
+	if in.ScaleInPVCRetentionPolicy != nil {
+		in, out := &in.ScaleInPVCRetentionPolicy, &out.ScaleInPVCRetentionPolicy
+		*out = new(ScaleInPVCRetentionPolicy)
+		**out = **in
+	}
+	if in.ScaleInPVCDeletionGracePeriod != nil {
+		in, out := &in.ScaleInPVCDeletionGracePeriod, &out.ScaleInPVCDeletionGracePeriod
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 	if in.Initializer != nil {
 		in, out := &in.Initializer, &out.Initializer
 		*out = new(TiDBInitializer)
@@ -6021,6 +7786,36 @@ func (in *TiDBSpec) DeepCopyInto(out *TiDBSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.Binding != nil {
+		in, out := &in.Binding, &out.Binding
+		*out = new(TiDBBindingSpec)
+		**out = **in
+	}
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.CanaryUpgrade != nil {
+		in, out := &in.CanaryUpgrade, &out.CanaryUpgrade
+		*out = new(CanaryUpgrade)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LoadAwareRestart != nil {
+		in, out := &in.LoadAwareRestart, &out.LoadAwareRestart
+		*out = new(LoadAwareRestart)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodDisruptionBudget != nil {
+		in, out := &in.PodDisruptionBudget, &out.PodDisruptionBudget
+		*out = new(PodDisruptionBudgetSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Ports != nil {
+		in, out := &in.Ports, &out.Ports
+		*out = new(TiDBPorts)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -6076,6 +7871,31 @@ func (in *TiDBStatus) DeepCopyInto(out *TiDBStatus) {
 			(*out)[key] = outVal
 		}
 	}
+	if in.PendingChanges != nil {
+		in, out := &in.PendingChanges, &out.PendingChanges
+		*out = new(PendingChanges)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServerTLS != nil {
+		in, out := &in.ServerTLS, &out.ServerTLS
+		*out = new(TiDBServerTLSStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServerLabels != nil {
+		in, out := &in.ServerLabels, &out.ServerLabels
+		*out = new(TiDBServerLabelsStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CanaryUpgrade != nil {
+		in, out := &in.CanaryUpgrade, &out.CanaryUpgrade
+		*out = new(CanaryUpgradeStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LoadAwareRestart != nil {
+		in, out := &in.LoadAwareRestart, &out.LoadAwareRestart
+		*out = new(LoadAwareRestartStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]metav1.Condition, len(*in))
@@ -6226,11 +8046,31 @@ func (in *TiFlashSpec) DeepCopyInto(out *TiFlashSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.PVReclaimPolicy != nil {
+		in, out := &in.PVReclaimPolicy, &out.PVReclaimPolicy
+		*out = new(v1.PersistentVolumeReclaimPolicy)
+		**out = **in
+	}
+	if in.ScaleInPVCRetentionPolicy != nil {
+		in, out := &in.ScaleInPVCRetentionPolicy, &out.ScaleInPVCRetentionPolicy
+		*out = new(ScaleInPVCRetentionPolicy)
+		**out = **in
+	}
+	if in.ScaleInPVCDeletionGracePeriod != nil {
+		in, out := &in.ScaleInPVCDeletionGracePeriod, &out.ScaleInPVCDeletionGracePeriod
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 	if in.Config != nil {
 		in, out := &in.Config, &out.Config
 		*out = new(TiFlashConfigWraper)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.AdditionalArgs != nil {
+		in, out := &in.AdditionalArgs, &out.AdditionalArgs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.Initializer != nil {
 		in, out := &in.Initializer, &out.Initializer
 		*out = new(InitContainerSpec)
@@ -6243,10 +8083,15 @@ func (in *TiFlashSpec) DeepCopyInto(out *TiFlashSpec) {
 	}
 	if in.Failover != nil {
 		in, out := &in.Failover, &out.Failover
-		*out = new(Failover)
-		**out = **in
+		*out = new(StoreFailover)
+		(*in).DeepCopyInto(*out)
 	}
 	in.ScalePolicy.DeepCopyInto(&out.ScalePolicy)
+	if in.PodDisruptionBudget != nil {
+		in, out := &in.PodDisruptionBudget, &out.PodDisruptionBudget
+		*out = new(PodDisruptionBudgetSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -6311,6 +8156,11 @@ func (in *TiFlashStatus) DeepCopyInto(out *TiFlashStatus) {
 			(*out)[key] = outVal
 		}
 	}
+	if in.PendingChanges != nil {
+		in, out := &in.PendingChanges, &out.PendingChanges
+		*out = new(PendingChanges)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]metav1.Condition, len(*in))
@@ -7170,6 +9020,85 @@ func (in *TiKVGCConfig) DeepCopy() *TiKVGCConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TiKVGroupSpec) DeepCopyInto(out *TiKVGroupSpec) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StorageClassName != nil {
+		in, out := &in.StorageClassName, &out.StorageClassName
+		*out = new(string)
+		**out = **in
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]v1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ServerLabels != nil {
+		in, out := &in.ServerLabels, &out.ServerLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TiKVGroupSpec.
+func (in *TiKVGroupSpec) DeepCopy() *TiKVGroupSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TiKVGroupSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TiKVGroupStatus) DeepCopyInto(out *TiKVGroupStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TiKVGroupStatus.
+func (in *TiKVGroupStatus) DeepCopy() *TiKVGroupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TiKVGroupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TiKVIORateLimit) DeepCopyInto(out *TiKVIORateLimit) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TiKVIORateLimit.
+func (in *TiKVIORateLimit) DeepCopy() *TiKVIORateLimit {
+	if in == nil {
+		return nil
+	}
+	out := new(TiKVIORateLimit)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TiKVImportConfig) DeepCopyInto(out *TiKVImportConfig) {
 	*out = *in
@@ -7326,6 +9255,32 @@ func (in *TiKVPessimisticTxn) DeepCopy() *TiKVPessimisticTxn {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TiKVPorts) DeepCopyInto(out *TiKVPorts) {
+	*out = *in
+	if in.Server != nil {
+		in, out := &in.Server, &out.Server
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Status != nil {
+		in, out := &in.Status, &out.Status
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TiKVPorts.
+func (in *TiKVPorts) DeepCopy() *TiKVPorts {
+	if in == nil {
+		return nil
+	}
+	out := new(TiKVPorts)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TiKVRaftDBConfig) DeepCopyInto(out *TiKVRaftDBConfig) {
 	*out = *in
@@ -8070,6 +10025,11 @@ func (in *TiKVSpec) DeepCopyInto(out *TiKVSpec) {
 	*out = *in
 	in.ComponentSpec.DeepCopyInto(&out.ComponentSpec)
 	in.ResourceRequirements.DeepCopyInto(&out.ResourceRequirements)
+	if in.OfflineOrdinals != nil {
+		in, out := &in.OfflineOrdinals, &out.OfflineOrdinals
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
 	if in.Privileged != nil {
 		in, out := &in.Privileged, &out.Privileged
 		*out = new(bool)
@@ -8100,15 +10060,35 @@ func (in *TiKVSpec) DeepCopyInto(out *TiKVSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.PVReclaimPolicy != nil {
+		in, out := &in.PVReclaimPolicy, &out.PVReclaimPolicy
+		*out = new(v1.PersistentVolumeReclaimPolicy)
+		**out = **in
+	}
+	if in.ScaleInPVCRetentionPolicy != nil {
+		in, out := &in.ScaleInPVCRetentionPolicy, &out.ScaleInPVCRetentionPolicy
+		*out = new(ScaleInPVCRetentionPolicy)
+		**out = **in
+	}
+	if in.ScaleInPVCDeletionGracePeriod != nil {
+		in, out := &in.ScaleInPVCDeletionGracePeriod, &out.ScaleInPVCDeletionGracePeriod
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 	if in.Config != nil {
 		in, out := &in.Config, &out.Config
 		*out = new(TiKVConfigWraper)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.AdditionalArgs != nil {
+		in, out := &in.AdditionalArgs, &out.AdditionalArgs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.Failover != nil {
 		in, out := &in.Failover, &out.Failover
-		*out = new(Failover)
-		**out = **in
+		*out = new(StoreFailover)
+		(*in).DeepCopyInto(*out)
 	}
 	if in.MountClusterClientSecret != nil {
 		in, out := &in.MountClusterClientSecret, &out.MountClusterClientSecret
@@ -8125,6 +10105,21 @@ func (in *TiKVSpec) DeepCopyInto(out *TiKVSpec) {
 		*out = new(metav1.Duration)
 		**out = **in
 	}
+	if in.MaxUpgradeConcurrency != nil {
+		in, out := &in.MaxUpgradeConcurrency, &out.MaxUpgradeConcurrency
+		*out = new(int32)
+		**out = **in
+	}
+	if in.CanaryUpgrade != nil {
+		in, out := &in.CanaryUpgrade, &out.CanaryUpgrade
+		*out = new(CanaryUpgrade)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PreStopHook != nil {
+		in, out := &in.PreStopHook, &out.PreStopHook
+		*out = new(PreStopHookSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.StorageVolumes != nil {
 		in, out := &in.StorageVolumes, &out.StorageVolumes
 		*out = make([]StorageVolume, len(*in))
@@ -8132,12 +10127,44 @@ func (in *TiKVSpec) DeepCopyInto(out *TiKVSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.StoreLabels != nil {
-		in, out := &in.StoreLabels, &out.StoreLabels
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	if in.StoreLabels != nil {
+		in, out := &in.StoreLabels, &out.StoreLabels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.ScalePolicy.DeepCopyInto(&out.ScalePolicy)
+	if in.IORateLimit != nil {
+		in, out := &in.IORateLimit, &out.IORateLimit
+		*out = new(TiKVIORateLimit)
+		**out = **in
+	}
+	if in.PodDisruptionBudget != nil {
+		in, out := &in.PodDisruptionBudget, &out.PodDisruptionBudget
+		*out = new(PodDisruptionBudgetSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StoreLimits != nil {
+		in, out := &in.StoreLimits, &out.StoreLimits
+		*out = new(TiKVStoreLimits)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]TiKVGroupSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Ports != nil {
+		in, out := &in.Ports, &out.Ports
+		*out = new(TiKVPorts)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DataDirPreCheck != nil {
+		in, out := &in.DataDirPreCheck, &out.DataDirPreCheck
+		*out = new(DataDirPreCheck)
+		(*in).DeepCopyInto(*out)
 	}
-	in.ScalePolicy.DeepCopyInto(&out.ScalePolicy)
 	return
 }
 
@@ -8217,6 +10244,23 @@ func (in *TiKVStatus) DeepCopyInto(out *TiKVStatus) {
 			(*out)[key] = outVal
 		}
 	}
+	if in.PendingChanges != nil {
+		in, out := &in.PendingChanges, &out.PendingChanges
+		*out = new(PendingChanges)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make(map[string]TiKVGroupStatus, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.CanaryUpgrade != nil {
+		in, out := &in.CanaryUpgrade, &out.CanaryUpgrade
+		*out = new(CanaryUpgradeStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]metav1.Condition, len(*in))
@@ -8353,6 +10397,11 @@ func (in *TiKVStore) DeepCopyInto(out *TiKVStore) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.Drain != nil {
+		in, out := &in.Drain, &out.Drain
+		*out = new(StoreDrainStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -8366,6 +10415,32 @@ func (in *TiKVStore) DeepCopy() *TiKVStore {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TiKVStoreLimits) DeepCopyInto(out *TiKVStoreLimits) {
+	*out = *in
+	if in.AddPeer != nil {
+		in, out := &in.AddPeer, &out.AddPeer
+		*out = new(float64)
+		**out = **in
+	}
+	if in.RemovePeer != nil {
+		in, out := &in.RemovePeer, &out.RemovePeer
+		*out = new(float64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TiKVStoreLimits.
+func (in *TiKVStoreLimits) DeepCopy() *TiKVStoreLimits {
+	if in == nil {
+		return nil
+	}
+	out := new(TiKVStoreLimits)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TiKVTitanCfConfig) DeepCopyInto(out *TiKVTitanCfConfig) {
 	*out = *in
@@ -8566,6 +10641,11 @@ func (in *TiProxySpec) DeepCopyInto(out *TiProxySpec) {
 		*out = new(TiProxyConfigWraper)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.AdditionalArgs != nil {
+		in, out := &in.AdditionalArgs, &out.AdditionalArgs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.StorageVolumes != nil {
 		in, out := &in.StorageVolumes, &out.StorageVolumes
 		*out = make([]StorageVolume, len(*in))
@@ -8621,6 +10701,11 @@ func (in *TiProxyStatus) DeepCopyInto(out *TiProxyStatus) {
 			(*out)[key] = outVal
 		}
 	}
+	if in.PendingChanges != nil {
+		in, out := &in.PendingChanges, &out.PendingChanges
+		*out = new(PendingChanges)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]metav1.Condition, len(*in))
@@ -8783,7 +10868,7 @@ func (in *TidbClusterAutoScalerRef) DeepCopy() *TidbClusterAutoScalerRef {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TidbClusterAutoScalerSpec) DeepCopyInto(out *TidbClusterAutoScalerSpec) {
 	*out = *in
-	out.Cluster = in.Cluster
+	in.Cluster.DeepCopyInto(&out.Cluster)
 	if in.TiKV != nil {
 		in, out := &in.TiKV, &out.TiKV
 		*out = new(TikvAutoScalerSpec)
@@ -8837,6 +10922,100 @@ func (in *TidbClusterAutoScalerStatus) DeepCopy() *TidbClusterAutoScalerStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TidbClusterClaim) DeepCopyInto(out *TidbClusterClaim) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	out.Status = in.Status
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TidbClusterClaim.
+func (in *TidbClusterClaim) DeepCopy() *TidbClusterClaim {
+	if in == nil {
+		return nil
+	}
+	out := new(TidbClusterClaim)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TidbClusterClaim) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TidbClusterClaimList) DeepCopyInto(out *TidbClusterClaimList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]TidbClusterClaim, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TidbClusterClaimList.
+func (in *TidbClusterClaimList) DeepCopy() *TidbClusterClaimList {
+	if in == nil {
+		return nil
+	}
+	out := new(TidbClusterClaimList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TidbClusterClaimList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TidbClusterClaimSpec) DeepCopyInto(out *TidbClusterClaimSpec) {
+	*out = *in
+	out.TemplateRef = in.TemplateRef
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TidbClusterClaimSpec.
+func (in *TidbClusterClaimSpec) DeepCopy() *TidbClusterClaimSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TidbClusterClaimSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TidbClusterClaimStatus) DeepCopyInto(out *TidbClusterClaimStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TidbClusterClaimStatus.
+func (in *TidbClusterClaimStatus) DeepCopy() *TidbClusterClaimStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TidbClusterClaimStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TidbClusterCondition) DeepCopyInto(out *TidbClusterCondition) {
 	*out = *in
@@ -8855,6 +11034,97 @@ func (in *TidbClusterCondition) DeepCopy() *TidbClusterCondition {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TidbClusterFleet) DeepCopyInto(out *TidbClusterFleet) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TidbClusterFleet.
+func (in *TidbClusterFleet) DeepCopy() *TidbClusterFleet {
+	if in == nil {
+		return nil
+	}
+	out := new(TidbClusterFleet)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TidbClusterFleet) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TidbClusterFleetList) DeepCopyInto(out *TidbClusterFleetList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]TidbClusterFleet, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TidbClusterFleetList.
+func (in *TidbClusterFleetList) DeepCopy() *TidbClusterFleetList {
+	if in == nil {
+		return nil
+	}
+	out := new(TidbClusterFleetList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TidbClusterFleetList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TidbClusterFleetStatus) DeepCopyInto(out *TidbClusterFleetStatus) {
+	*out = *in
+	if in.Phases != nil {
+		in, out := &in.Phases, &out.Phases
+		*out = make(map[MemberPhase]int32, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Versions != nil {
+		in, out := &in.Versions, &out.Versions
+		*out = make(map[string]int32, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TidbClusterFleetStatus.
+func (in *TidbClusterFleetStatus) DeepCopy() *TidbClusterFleetStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TidbClusterFleetStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TidbClusterList) DeepCopyInto(out *TidbClusterList) {
 	*out = *in
@@ -8870,18 +11140,99 @@ func (in *TidbClusterList) DeepCopyInto(out *TidbClusterList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TidbClusterList.
-func (in *TidbClusterList) DeepCopy() *TidbClusterList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TidbClusterList.
+func (in *TidbClusterList) DeepCopy() *TidbClusterList {
+	if in == nil {
+		return nil
+	}
+	out := new(TidbClusterList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TidbClusterList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TidbClusterRef) DeepCopyInto(out *TidbClusterRef) {
+	*out = *in
+	if in.Remote != nil {
+		in, out := &in.Remote, &out.Remote
+		*out = new(RemoteClusterRef)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TidbClusterRef.
+func (in *TidbClusterRef) DeepCopy() *TidbClusterRef {
+	if in == nil {
+		return nil
+	}
+	out := new(TidbClusterRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TidbClusterSecretGrant) DeepCopyInto(out *TidbClusterSecretGrant) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TidbClusterSecretGrant.
+func (in *TidbClusterSecretGrant) DeepCopy() *TidbClusterSecretGrant {
+	if in == nil {
+		return nil
+	}
+	out := new(TidbClusterSecretGrant)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TidbClusterSecretGrant) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TidbClusterSecretGrantList) DeepCopyInto(out *TidbClusterSecretGrantList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]TidbClusterSecretGrant, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TidbClusterSecretGrantList.
+func (in *TidbClusterSecretGrantList) DeepCopy() *TidbClusterSecretGrantList {
 	if in == nil {
 		return nil
 	}
-	out := new(TidbClusterList)
+	out := new(TidbClusterSecretGrantList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *TidbClusterList) DeepCopyObject() runtime.Object {
+func (in *TidbClusterSecretGrantList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -8889,17 +11240,63 @@ func (in *TidbClusterList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TidbClusterRef) DeepCopyInto(out *TidbClusterRef) {
+func (in *TidbClusterSecretGrantSpec) DeepCopyInto(out *TidbClusterSecretGrantSpec) {
 	*out = *in
+	if in.From != nil {
+		in, out := &in.From, &out.From
+		*out = make([]SecretGrantSubject, len(*in))
+		copy(*out, *in)
+	}
+	if in.SecretNames != nil {
+		in, out := &in.SecretNames, &out.SecretNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TidbClusterRef.
-func (in *TidbClusterRef) DeepCopy() *TidbClusterRef {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TidbClusterSecretGrantSpec.
+func (in *TidbClusterSecretGrantSpec) DeepCopy() *TidbClusterSecretGrantSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(TidbClusterRef)
+	out := new(TidbClusterSecretGrantSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TidbClusterSize) DeepCopyInto(out *TidbClusterSize) {
+	*out = *in
+	if in.PD != nil {
+		in, out := &in.PD, &out.PD
+		*out = new(ComponentSize)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TiKV != nil {
+		in, out := &in.TiKV, &out.TiKV
+		*out = new(ComponentSize)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TiDB != nil {
+		in, out := &in.TiDB, &out.TiDB
+		*out = new(ComponentSize)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TiFlash != nil {
+		in, out := &in.TiFlash, &out.TiFlash
+		*out = new(ComponentSize)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TidbClusterSize.
+func (in *TidbClusterSize) DeepCopy() *TidbClusterSize {
+	if in == nil {
+		return nil
+	}
+	out := new(TidbClusterSize)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -8963,10 +11360,20 @@ func (in *TidbClusterSpec) DeepCopyInto(out *TidbClusterSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.ScaleInPVCRetentionPolicy != nil {
+		in, out := &in.ScaleInPVCRetentionPolicy, &out.ScaleInPVCRetentionPolicy
+		*out = new(ScaleInPVCRetentionPolicy)
+		**out = **in
+	}
+	if in.ScaleInPVCDeletionGracePeriod != nil {
+		in, out := &in.ScaleInPVCDeletionGracePeriod, &out.ScaleInPVCDeletionGracePeriod
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 	if in.TLSCluster != nil {
 		in, out := &in.TLSCluster, &out.TLSCluster
 		*out = new(TLSCluster)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.HostNetwork != nil {
 		in, out := &in.HostNetwork, &out.HostNetwork
@@ -9004,6 +11411,11 @@ func (in *TidbClusterSpec) DeepCopyInto(out *TidbClusterSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.Metadata != nil {
+		in, out := &in.Metadata, &out.Metadata
+		*out = new(ObjectMetadata)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Tolerations != nil {
 		in, out := &in.Tolerations, &out.Tolerations
 		*out = make([]v1.Toleration, len(*in))
@@ -9029,7 +11441,12 @@ func (in *TidbClusterSpec) DeepCopyInto(out *TidbClusterSpec) {
 	if in.Cluster != nil {
 		in, out := &in.Cluster, &out.Cluster
 		*out = new(TidbClusterRef)
-		**out = **in
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecretPropagation != nil {
+		in, out := &in.SecretPropagation, &out.SecretPropagation
+		*out = new(SecretPropagation)
+		(*in).DeepCopyInto(*out)
 	}
 	if in.PDAddresses != nil {
 		in, out := &in.PDAddresses, &out.PDAddresses
@@ -9051,6 +11468,41 @@ func (in *TidbClusterSpec) DeepCopyInto(out *TidbClusterSpec) {
 		*out = new(SuspendAction)
 		**out = **in
 	}
+	if in.Standby != nil {
+		in, out := &in.Standby, &out.Standby
+		*out = new(StandbySpec)
+		**out = **in
+	}
+	if in.Proxy != nil {
+		in, out := &in.Proxy, &out.Proxy
+		*out = new(HTTPProxyConfig)
+		**out = **in
+	}
+	if in.HealthProbe != nil {
+		in, out := &in.HealthProbe, &out.HealthProbe
+		*out = new(HealthProbeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TopologyZones != nil {
+		in, out := &in.TopologyZones, &out.TopologyZones
+		*out = new(TopologyZones)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DriftPolicy != nil {
+		in, out := &in.DriftPolicy, &out.DriftPolicy
+		*out = new(DriftPolicy)
+		**out = **in
+	}
+	if in.Teardown != nil {
+		in, out := &in.Teardown, &out.Teardown
+		*out = new(TeardownSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ScaleInApproval != nil {
+		in, out := &in.ScaleInApproval, &out.ScaleInApproval
+		*out = new(ScaleInApproval)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -9079,6 +11531,42 @@ func (in *TidbClusterStatus) DeepCopyInto(out *TidbClusterStatus) {
 		*out = new(TidbClusterAutoScalerRef)
 		**out = **in
 	}
+	if in.HealthProbe != nil {
+		in, out := &in.HealthProbe, &out.HealthProbe
+		*out = new(HealthProbeStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Binding != nil {
+		in, out := &in.Binding, &out.Binding
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+	if in.DriftedResources != nil {
+		in, out := &in.DriftedResources, &out.DriftedResources
+		*out = make([]DriftedResource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Teardown != nil {
+		in, out := &in.Teardown, &out.Teardown
+		*out = new(TeardownStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodRestarts != nil {
+		in, out := &in.PodRestarts, &out.PodRestarts
+		*out = make(map[string]PodRestart, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.PendingScaleIns != nil {
+		in, out := &in.PendingScaleIns, &out.PendingScaleIns
+		*out = make(map[string]PendingScaleIn, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]TidbClusterCondition, len(*in))
@@ -9099,6 +11587,106 @@ func (in *TidbClusterStatus) DeepCopy() *TidbClusterStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TidbClusterTemplate) DeepCopyInto(out *TidbClusterTemplate) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TidbClusterTemplate.
+func (in *TidbClusterTemplate) DeepCopy() *TidbClusterTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(TidbClusterTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TidbClusterTemplate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TidbClusterTemplateList) DeepCopyInto(out *TidbClusterTemplateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]TidbClusterTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TidbClusterTemplateList.
+func (in *TidbClusterTemplateList) DeepCopy() *TidbClusterTemplateList {
+	if in == nil {
+		return nil
+	}
+	out := new(TidbClusterTemplateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TidbClusterTemplateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TidbClusterTemplateRef) DeepCopyInto(out *TidbClusterTemplateRef) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TidbClusterTemplateRef.
+func (in *TidbClusterTemplateRef) DeepCopy() *TidbClusterTemplateRef {
+	if in == nil {
+		return nil
+	}
+	out := new(TidbClusterTemplateRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TidbClusterTemplateSpec) DeepCopyInto(out *TidbClusterTemplateSpec) {
+	*out = *in
+	in.Template.DeepCopyInto(&out.Template)
+	if in.Sizes != nil {
+		in, out := &in.Sizes, &out.Sizes
+		*out = make([]TidbClusterSize, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TidbClusterTemplateSpec.
+func (in *TidbClusterTemplateSpec) DeepCopy() *TidbClusterTemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TidbClusterTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TidbDashboard) DeepCopyInto(out *TidbDashboard) {
 	*out = *in
@@ -9168,7 +11756,9 @@ func (in *TidbDashboardSpec) DeepCopyInto(out *TidbDashboardSpec) {
 	if in.Clusters != nil {
 		in, out := &in.Clusters, &out.Clusters
 		*out = make([]TidbClusterRef, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.PVReclaimPolicy != nil {
 		in, out := &in.PVReclaimPolicy, &out.PVReclaimPolicy
@@ -9301,7 +11891,7 @@ func (in *TidbInitializerList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TidbInitializerSpec) DeepCopyInto(out *TidbInitializerSpec) {
 	*out = *in
-	out.Clusters = in.Clusters
+	in.Clusters.DeepCopyInto(&out.Clusters)
 	if in.PodSecurityContext != nil {
 		in, out := &in.PodSecurityContext, &out.PodSecurityContext
 		*out = new(v1.PodSecurityContext)
@@ -9332,6 +11922,11 @@ func (in *TidbInitializerSpec) DeepCopyInto(out *TidbInitializerSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.SQLBundle != nil {
+		in, out := &in.SQLBundle, &out.SQLBundle
+		*out = new(SQLBundleSource)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.PasswordSecret != nil {
 		in, out := &in.PasswordSecret, &out.PasswordSecret
 		*out = new(string)
@@ -9460,7 +12055,9 @@ func (in *TidbMonitorSpec) DeepCopyInto(out *TidbMonitorSpec) {
 	if in.Clusters != nil {
 		in, out := &in.Clusters, &out.Clusters
 		*out = make([]TidbClusterRef, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	in.Prometheus.DeepCopyInto(&out.Prometheus)
 	if in.Grafana != nil {
@@ -9584,6 +12181,11 @@ func (in *TidbMonitorSpec) DeepCopyInto(out *TidbMonitorSpec) {
 		*out = new(v1.PodSecurityContext)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Blackbox != nil {
+		in, out := &in.Blackbox, &out.Blackbox
+		*out = new(BlackboxExporterSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -9691,7 +12293,9 @@ func (in *TidbNGMonitoringSpec) DeepCopyInto(out *TidbNGMonitoringSpec) {
 	if in.Clusters != nil {
 		in, out := &in.Clusters, &out.Clusters
 		*out = make([]TidbClusterRef, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.PVReclaimPolicy != nil {
 		in, out := &in.PVReclaimPolicy, &out.PVReclaimPolicy
@@ -9779,6 +12383,50 @@ func (in *TopologySpreadConstraint) DeepCopy() *TopologySpreadConstraint {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TopologyZones) DeepCopyInto(out *TopologyZones) {
+	*out = *in
+	if in.PD != nil {
+		in, out := &in.PD, &out.PD
+		*out = make(map[string]int32, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.TiKV != nil {
+		in, out := &in.TiKV, &out.TiKV
+		*out = make(map[string]int32, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.TiDB != nil {
+		in, out := &in.TiDB, &out.TiDB
+		*out = make(map[string]int32, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.TiFlash != nil {
+		in, out := &in.TiFlash, &out.TiFlash
+		*out = make(map[string]int32, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TopologyZones.
+func (in *TopologyZones) DeepCopy() *TopologyZones {
+	if in == nil {
+		return nil
+	}
+	out := new(TopologyZones)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TxnLocalLatches) DeepCopyInto(out *TxnLocalLatches) {
 	*out = *in
@@ -9860,6 +12508,49 @@ func (in *User) DeepCopy() *User {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultKubernetesAuth) DeepCopyInto(out *VaultKubernetesAuth) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultKubernetesAuth.
+func (in *VaultKubernetesAuth) DeepCopy() *VaultKubernetesAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultKubernetesAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultPKI) DeepCopyInto(out *VaultPKI) {
+	*out = *in
+	out.Auth = in.Auth
+	if in.TTL != nil {
+		in, out := &in.TTL, &out.TTL
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.RenewBefore != nil {
+		in, out := &in.RenewBefore, &out.RenewBefore
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultPKI.
+func (in *VaultPKI) DeepCopy() *VaultPKI {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultPKI)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WorkerConfig) DeepCopyInto(out *WorkerConfig) {
 	*out = *in
@@ -9971,10 +12662,20 @@ func (in *WorkerSpec) DeepCopyInto(out *WorkerSpec) {
 		*out = new(WorkerConfigWraper)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.AdditionalArgs != nil {
+		in, out := &in.AdditionalArgs, &out.AdditionalArgs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.Failover != nil {
 		in, out := &in.Failover, &out.Failover
 		*out = new(Failover)
-		**out = **in
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Relay != nil {
+		in, out := &in.Relay, &out.Relay
+		*out = new(DMRelaySpec)
+		(*in).DeepCopyInto(*out)
 	}
 	return
 }
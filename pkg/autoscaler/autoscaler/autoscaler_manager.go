@@ -116,11 +116,15 @@ func (am *autoScalerManager) syncPD(tc *v1alpha1.TidbCluster, tac *v1alpha1.Tidb
 func (am *autoScalerManager) syncAutoScaling(tc *v1alpha1.TidbCluster, tac *v1alpha1.TidbClusterAutoScaler) error {
 	var errs []error
 	if tac.Spec.TiDB != nil {
+		// The scheduled scaling is synced first so that the metric-based rules know whether a window is active
+		if err := am.syncSchedules(tc, tac, v1alpha1.TiDBMemberType); err != nil {
+			errs = append(errs, err)
+		}
 		if tac.Spec.TiDB.External != nil {
 			if err := am.syncExternal(tc, tac, v1alpha1.TiDBMemberType); err != nil {
 				errs = append(errs, err)
 			}
-		} else {
+		} else if len(tac.Spec.TiDB.Rules) > 0 {
 			if err := am.syncPD(tc, tac, v1alpha1.TiDBMemberType); err != nil {
 				errs = append(errs, err)
 			}
@@ -128,11 +132,15 @@ func (am *autoScalerManager) syncAutoScaling(tc *v1alpha1.TidbCluster, tac *v1al
 	}
 
 	if tac.Spec.TiKV != nil {
+		// The scheduled scaling is synced first so that the metric-based rules know whether a window is active
+		if err := am.syncSchedules(tc, tac, v1alpha1.TiKVMemberType); err != nil {
+			errs = append(errs, err)
+		}
 		if tac.Spec.TiKV.External != nil {
 			if err := am.syncExternal(tc, tac, v1alpha1.TiKVMemberType); err != nil {
 				errs = append(errs, err)
 			}
-		} else {
+		} else if len(tac.Spec.TiKV.Rules) > 0 {
 			if err := am.syncPD(tc, tac, v1alpha1.TiKVMemberType); err != nil {
 				errs = append(errs, err)
 			}
@@ -23,6 +23,7 @@ import (
 
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
 	"github.com/pingcap/tidb-operator/pkg/util/crypto"
+	httputil "github.com/pingcap/tidb-operator/pkg/util/http"
 	corelisterv1 "k8s.io/client-go/listers/core/v1"
 	"k8s.io/klog/v2"
 )
@@ -87,6 +88,7 @@ func getClient(endpoint v1alpha1.ExternalEndpoint, secretLister corelisterv1.Sec
 			return nil, err
 		}
 		tr := &http.Transport{
+			Proxy:             httputil.Proxy,
 			TLSClientConfig:   tlsConfig,
 			DisableKeepAlives: true,
 		}
@@ -96,7 +98,8 @@ func getClient(endpoint v1alpha1.ExternalEndpoint, secretLister corelisterv1.Sec
 		}
 	} else {
 		client = &http.Client{
-			Timeout: defaultTimeout,
+			Timeout:   defaultTimeout,
+			Transport: &http.Transport{Proxy: httputil.ProxyOrFromEnvironment},
 		}
 	}
 	return client, nil
@@ -0,0 +1,184 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package autoscaler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/robfig/cron"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// The TidbCluster for the scheduled scaling will be "<original-tcname>-<component>-scheduled"
+	scheduledTcNamePattern = "%s-%s-scheduled"
+	scheduledStatusKey     = "scheduled"
+)
+
+// activeSchedule returns the rule whose window is active at the time, the rule with the largest
+// replicas wins if the windows of several rules overlap. The window of a rule is active if the
+// next end of the window comes before the next start.
+func activeSchedule(rules []v1alpha1.ScheduledScalingRule, now time.Time) (*v1alpha1.ScheduledScalingRule, error) {
+	var active *v1alpha1.ScheduledScalingRule
+	for i := range rules {
+		rule := &rules[i]
+		start, err := cron.ParseStandard(rule.Start)
+		if err != nil {
+			return nil, fmt.Errorf("parse start %q of schedule %s failed, err: %v", rule.Start, rule.Name, err)
+		}
+		end, err := cron.ParseStandard(rule.End)
+		if err != nil {
+			return nil, fmt.Errorf("parse end %q of schedule %s failed, err: %v", rule.End, rule.Name, err)
+		}
+		if !end.Next(now).Before(start.Next(now)) {
+			continue
+		}
+		if active == nil || rule.Replicas > active.Replicas {
+			active = rule
+		}
+	}
+	return active, nil
+}
+
+// isScheduleActive returns whether the window of a scheduled scaling rule of the component is active
+// in the last reconciliation.
+func isScheduleActive(tac *v1alpha1.TidbClusterAutoScaler, component v1alpha1.MemberType) bool {
+	var status *v1alpha1.ScheduledScalingStatus
+	switch component {
+	case v1alpha1.TiDBMemberType:
+		status = tac.Status.TiDB[scheduledStatusKey].LastAppliedSchedule
+	case v1alpha1.TiKVMemberType:
+		status = tac.Status.TiKV[scheduledStatusKey].LastAppliedSchedule
+	}
+	return status != nil && status.Active
+}
+
+func (am *autoScalerManager) syncSchedules(tc *v1alpha1.TidbCluster, tac *v1alpha1.TidbClusterAutoScaler, component v1alpha1.MemberType) error {
+	spec := getBasicAutoScalerSpec(tac, component)
+	rule, err := activeSchedule(spec.Schedules, time.Now().UTC())
+	if err != nil {
+		return err
+	}
+
+	var targetReplicas int32
+	if rule != nil {
+		targetReplicas = rule.Replicas
+	}
+
+	scheduledTcName := fmt.Sprintf(scheduledTcNamePattern, tc.Name, component.String())
+	scheduledTc, err := am.deps.TiDBClusterLister.TidbClusters(tc.Namespace).Get(scheduledTcName)
+	if err != nil && !errors.IsNotFound(err) {
+		klog.Errorf("tac[%s/%s] failed to get scheduled tc[%s/%s], err: %v", tac.Namespace, tac.Name, tc.Namespace, scheduledTcName, err)
+		return err
+	}
+
+	switch {
+	case scheduledTc == nil && targetReplicas > 0:
+		err = am.createScheduledAutoCluster(tc, tac, scheduledTcName, component, targetReplicas)
+	case scheduledTc != nil && targetReplicas <= 0:
+		err = am.gracefullyDeleteTidbCluster(scheduledTc)
+	case scheduledTc != nil:
+		err = am.updateScheduledAutoCluster(scheduledTc, component, targetReplicas)
+	}
+	if err != nil {
+		klog.Errorf("tac[%s/%s] failed to sync scheduled tc[%s/%s], err: %v", tac.Namespace, tac.Name, tc.Namespace, scheduledTcName, err)
+		return err
+	}
+
+	updateScheduledScalingStatus(tac, component, rule)
+	return nil
+}
+
+func (am *autoScalerManager) createScheduledAutoCluster(tc *v1alpha1.TidbCluster, tac *v1alpha1.TidbClusterAutoScaler, scheduledTcName string, component v1alpha1.MemberType, targetReplicas int32) error {
+	autoTc := newAutoScalingCluster(tc, tac, scheduledTcName, component.String())
+	switch component {
+	case v1alpha1.TiDBMemberType:
+		autoTc.Spec.TiDB.Replicas = targetReplicas
+	case v1alpha1.TiKVMemberType:
+		autoTc.Spec.TiKV.Replicas = targetReplicas
+	}
+
+	_, err := am.deps.Clientset.PingcapV1alpha1().TidbClusters(tc.Namespace).Create(context.TODO(), autoTc, metav1.CreateOptions{})
+	return err
+}
+
+// updateScheduledAutoCluster sets the replicas of the scheduled cluster, the scale interval isn't checked
+// because the replicas change only at the boundaries of the windows.
+func (am *autoScalerManager) updateScheduledAutoCluster(scheduledTc *v1alpha1.TidbCluster, component v1alpha1.MemberType, targetReplicas int32) error {
+	updated := scheduledTc.DeepCopy()
+	switch component {
+	case v1alpha1.TiDBMemberType:
+		if updated.Spec.TiDB.Replicas == targetReplicas {
+			return nil
+		}
+		updated.Spec.TiDB.Replicas = targetReplicas
+	case v1alpha1.TiKVMemberType:
+		if updated.Spec.TiKV.Replicas == targetReplicas {
+			return nil
+		}
+		updated.Spec.TiKV.Replicas = targetReplicas
+	}
+
+	_, err := am.deps.TiDBClusterControl.UpdateTidbCluster(updated, &updated.Status, &scheduledTc.Status)
+	return err
+}
+
+// updateScheduledScalingStatus records the last applied rule, the rule is kept after the window ends
+// so that users can see which rule is applied last.
+func updateScheduledScalingStatus(tac *v1alpha1.TidbClusterAutoScaler, component v1alpha1.MemberType, rule *v1alpha1.ScheduledScalingRule) {
+	var status v1alpha1.BasicAutoScalerStatus
+	switch component {
+	case v1alpha1.TiDBMemberType:
+		status = tac.Status.TiDB[scheduledStatusKey].BasicAutoScalerStatus
+	case v1alpha1.TiKVMemberType:
+		status = tac.Status.TiKV[scheduledStatusKey].BasicAutoScalerStatus
+	}
+
+	last := status.LastAppliedSchedule
+	now := metav1.Now()
+	switch {
+	case rule != nil && (last == nil || !last.Active || last.Name != rule.Name || last.Replicas != rule.Replicas):
+		status.LastAppliedSchedule = &v1alpha1.ScheduledScalingStatus{
+			Name:               rule.Name,
+			Replicas:           rule.Replicas,
+			Active:             true,
+			LastTransitionTime: now,
+		}
+	case rule == nil && last != nil && last.Active:
+		status.LastAppliedSchedule = last.DeepCopy()
+		status.LastAppliedSchedule.Active = false
+		status.LastAppliedSchedule.LastTransitionTime = now
+	default:
+		return
+	}
+	status.LastAutoScalingTimestamp = &now
+
+	switch component {
+	case v1alpha1.TiDBMemberType:
+		if tac.Status.TiDB == nil {
+			tac.Status.TiDB = map[string]v1alpha1.TidbAutoScalerStatus{}
+		}
+		tac.Status.TiDB[scheduledStatusKey] = v1alpha1.TidbAutoScalerStatus{BasicAutoScalerStatus: status}
+	case v1alpha1.TiKVMemberType:
+		if tac.Status.TiKV == nil {
+			tac.Status.TiKV = map[string]v1alpha1.TikvAutoScalerStatus{}
+		}
+		tac.Status.TiKV[scheduledStatusKey] = v1alpha1.TikvAutoScalerStatus{BasicAutoScalerStatus: status}
+	}
+}
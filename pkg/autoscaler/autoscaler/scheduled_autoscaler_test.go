@@ -0,0 +1,118 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package autoscaler
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+)
+
+func TestActiveSchedule(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	weekdays := v1alpha1.ScheduledScalingRule{Name: "weekdays", Start: "0 8 * * 1-5", End: "0 20 * * 1-5", Replicas: 10}
+	peak := v1alpha1.ScheduledScalingRule{Name: "peak", Start: "0 12 * * *", End: "0 14 * * *", Replicas: 20}
+	// 2023-03-06 is a Monday
+	monday := func(hour, minute int) time.Time {
+		return time.Date(2023, 3, 6, hour, minute, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name   string
+		rules  []v1alpha1.ScheduledScalingRule
+		now    time.Time
+		expect string
+	}{
+		{
+			name:  "before the window",
+			rules: []v1alpha1.ScheduledScalingRule{weekdays},
+			now:   monday(7, 59),
+		},
+		{
+			name:   "at the start of the window",
+			rules:  []v1alpha1.ScheduledScalingRule{weekdays},
+			now:    monday(8, 0),
+			expect: "weekdays",
+		},
+		{
+			name:  "after the window",
+			rules: []v1alpha1.ScheduledScalingRule{weekdays},
+			now:   monday(20, 0),
+		},
+		{
+			name:  "weekend",
+			rules: []v1alpha1.ScheduledScalingRule{weekdays},
+			now:   monday(10, 0).AddDate(0, 0, -1),
+		},
+		{
+			name:   "overlapped windows",
+			rules:  []v1alpha1.ScheduledScalingRule{weekdays, peak},
+			now:    monday(13, 0),
+			expect: "peak",
+		},
+		{
+			name:   "only one window is active",
+			rules:  []v1alpha1.ScheduledScalingRule{weekdays, peak},
+			now:    monday(15, 0),
+			expect: "weekdays",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := activeSchedule(tt.rules, tt.now)
+			g.Expect(err).To(Succeed())
+			if tt.expect == "" {
+				g.Expect(rule).To(BeNil())
+			} else {
+				g.Expect(rule).NotTo(BeNil())
+				g.Expect(rule.Name).To(Equal(tt.expect))
+			}
+		})
+	}
+
+	_, err := activeSchedule([]v1alpha1.ScheduledScalingRule{{Name: "invalid", Start: "0 8 * *", End: "0 20 * * *"}}, monday(8, 0))
+	g.Expect(err).To(HaveOccurred())
+}
+
+func TestScheduledScalingStatus(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tac := newTidbClusterAutoScaler()
+	rule := &v1alpha1.ScheduledScalingRule{Name: "weekdays", Replicas: 10}
+
+	updateScheduledScalingStatus(tac, v1alpha1.TiDBMemberType, nil)
+	g.Expect(tac.Status.TiDB).To(BeEmpty())
+	g.Expect(isScheduleActive(tac, v1alpha1.TiDBMemberType)).To(BeFalse())
+
+	updateScheduledScalingStatus(tac, v1alpha1.TiDBMemberType, rule)
+	status := tac.Status.TiDB[scheduledStatusKey].LastAppliedSchedule
+	g.Expect(status).NotTo(BeNil())
+	g.Expect(status.Name).To(Equal("weekdays"))
+	g.Expect(status.Replicas).To(Equal(int32(10)))
+	g.Expect(isScheduleActive(tac, v1alpha1.TiDBMemberType)).To(BeTrue())
+	g.Expect(isScheduleActive(tac, v1alpha1.TiKVMemberType)).To(BeFalse())
+
+	// the metric-based scaling-in is not permitted in the window
+	g.Expect(checkAutoScaling(tac, v1alpha1.TiDBMemberType, "group", 3, 2)).To(BeFalse())
+
+	updateScheduledScalingStatus(tac, v1alpha1.TiDBMemberType, nil)
+	status = tac.Status.TiDB[scheduledStatusKey].LastAppliedSchedule
+	g.Expect(status.Name).To(Equal("weekdays"))
+	g.Expect(status.Active).To(BeFalse())
+	g.Expect(isScheduleActive(tac, v1alpha1.TiDBMemberType)).To(BeFalse())
+}
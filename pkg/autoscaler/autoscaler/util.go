@@ -23,6 +23,7 @@ import (
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
 	"github.com/pingcap/tidb-operator/pkg/controller"
 	"github.com/pingcap/tidb-operator/pkg/pdapi"
+	"github.com/robfig/cron"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -33,8 +34,12 @@ import (
 var zeroQuantity = resource.MustParse("0")
 
 // checkAutoScaling would check whether an autoscaling for a group is permitted
+// The metric-based scaling-in is not permitted while the window of a scheduled scaling rule is active.
 func checkAutoScaling(tac *v1alpha1.TidbClusterAutoScaler, memberType v1alpha1.MemberType, group string, beforeReplicas, afterReplicas int32) bool {
 	if beforeReplicas > afterReplicas {
+		if isScheduleActive(tac, memberType) {
+			return false
+		}
 		switch memberType {
 		case v1alpha1.TiKVMemberType:
 			return checkAutoScalingInterval(tac, *tac.Spec.TiKV.ScaleInIntervalSeconds, memberType, group)
@@ -209,11 +214,18 @@ func defaultTAC(tac *v1alpha1.TidbClusterAutoScaler, tc *v1alpha1.TidbCluster) {
 func validateBasicAutoScalerSpec(tac *v1alpha1.TidbClusterAutoScaler, component v1alpha1.MemberType) error {
 	spec := getBasicAutoScalerSpec(tac, component)
 
+	if err := validateSchedules(tac, spec.Schedules, component); err != nil {
+		return err
+	}
+
 	if spec.External != nil {
 		return nil
 	}
 
 	if len(spec.Rules) == 0 {
+		if len(spec.Schedules) > 0 {
+			return nil
+		}
 		return fmt.Errorf("no rules defined for component %s in %s/%s", component.String(), tac.Namespace, tac.Name)
 	}
 	resources := getSpecResources(tac, component)
@@ -267,6 +279,28 @@ func validateBasicAutoScalerSpec(tac *v1alpha1.TidbClusterAutoScaler, component
 	return nil
 }
 
+func validateSchedules(tac *v1alpha1.TidbClusterAutoScaler, schedules []v1alpha1.ScheduledScalingRule, component v1alpha1.MemberType) error {
+	names := map[string]struct{}{}
+	for _, rule := range schedules {
+		if len(rule.Name) == 0 {
+			return fmt.Errorf("empty name of schedule for %s in %s/%s", component.String(), tac.Namespace, tac.Name)
+		}
+		if _, ok := names[rule.Name]; ok {
+			return fmt.Errorf("duplicated schedule %s for %s in %s/%s", rule.Name, component.String(), tac.Namespace, tac.Name)
+		}
+		names[rule.Name] = struct{}{}
+		if rule.Replicas < 0 {
+			return fmt.Errorf("replicas (%d) should not be negative for schedule %s of %s in %s/%s", rule.Replicas, rule.Name, component.String(), tac.Namespace, tac.Name)
+		}
+		for _, expr := range []string{rule.Start, rule.End} {
+			if _, err := cron.ParseStandard(expr); err != nil {
+				return fmt.Errorf("invalid cron expression %q for schedule %s of %s in %s/%s: %v", expr, rule.Name, component.String(), tac.Namespace, tac.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
 func validateTAC(tac *v1alpha1.TidbClusterAutoScaler) error {
 	if tac.Spec.TiDB != nil && tac.Spec.TiDB.External == nil && len(tac.Spec.TiDB.Resources) == 0 {
 		return fmt.Errorf("no resources provided for tidb in %s/%s", tac.Namespace, tac.Name)
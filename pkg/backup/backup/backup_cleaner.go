@@ -133,6 +133,12 @@ func (bc *backupCleaner) makeCleanJob(backup *v1alpha1.Backup) (*batchv1.Job, st
 	if err != nil {
 		return nil, reason, err
 	}
+	brokerEnv, reason, err := brokerCredentialsEnv(bc.deps, backup)
+	if err != nil {
+		return nil, reason, err
+	}
+	envVars = append(envVars, brokerEnv...)
+	envVars = append(envVars, bc.deps.CLIConfig.ProxyEnvVars(bc.getClusterProxy(backup))...)
 
 	// set env vars specified in backup.Spec.Env
 	envVars = util.AppendOverwriteEnv(envVars, backup.Spec.Env)
@@ -175,7 +181,7 @@ func (bc *backupCleaner) makeCleanJob(backup *v1alpha1.Backup) (*batchv1.Job, st
 			Containers: []corev1.Container{
 				{
 					Name:            label.BackupJobLabelVal,
-					Image:           bc.deps.CLIConfig.TiDBBackupManagerImage,
+					Image:           bc.deps.CLIConfig.GetTiDBBackupManagerImage(),
 					Args:            args,
 					ImagePullPolicy: corev1.PullIfNotPresent,
 					Env:             util.AppendEnvIfPresent(envVars, "TZ"),
@@ -187,6 +193,7 @@ func (bc *backupCleaner) makeCleanJob(backup *v1alpha1.Backup) (*batchv1.Job, st
 			Tolerations:       backup.Spec.Tolerations,
 			ImagePullSecrets:  backup.Spec.ImagePullSecrets,
 			Affinity:          backup.Spec.Affinity,
+			NodeSelector:      backup.Spec.NodeSelector,
 			Volumes:           volumes,
 			PriorityClassName: backup.Spec.PriorityClassName,
 		},
@@ -268,3 +275,23 @@ func (bc *backupCleaner) ensureBackupJobFinished(backup *v1alpha1.Backup) (bool,
 	// no job or all job finished
 	return isAllFinished, nil
 }
+
+// getClusterProxy returns the proxy of the cluster backed up by BR or Dumpling, or nil if the cluster is not found,
+// e.g. it's deleted before the backup, then the proxy of the operator is used.
+func (bc *backupCleaner) getClusterProxy(backup *v1alpha1.Backup) *v1alpha1.HTTPProxyConfig {
+	if backup.Spec.BR == nil {
+		if backup.Spec.From == nil {
+			return nil
+		}
+		return backuputil.GetTiDBHostClusterProxy(backup.Spec.From.Host, backup.Namespace, bc.deps.TiDBClusterLister)
+	}
+	clusterNamespace := backup.Spec.BR.ClusterNamespace
+	if clusterNamespace == "" {
+		clusterNamespace = backup.Namespace
+	}
+	tc, err := bc.deps.TiDBClusterLister.TidbClusters(clusterNamespace).Get(backup.Spec.BR.Cluster)
+	if err != nil {
+		return nil
+	}
+	return tc.Spec.Proxy
+}
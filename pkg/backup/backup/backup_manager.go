@@ -106,6 +106,12 @@ func (bm *backupManager) syncBackupJob(backup *v1alpha1.Backup) error {
 		return err
 	}
 
+	// wait the running scheduled backups under the concurrency limits
+	queueStatus, err := bm.waitBackupQueue(backup)
+	if err != nil {
+		return err
+	}
+
 	// make backup job
 	var job *batchv1.Job
 	var reason string
@@ -114,9 +120,26 @@ func (bm *backupManager) syncBackupJob(backup *v1alpha1.Backup) error {
 		klog.Errorf("backup %s/%s create job %s failed, reason is %s, error %v.", ns, name, backupJobName, reason, err)
 		return err
 	}
+	if queueStatus != nil {
+		if updateStatus == nil {
+			updateStatus = queueStatus
+		} else {
+			updateStatus.QueueDuration = queueStatus.QueueDuration
+		}
+	}
 
 	// create k8s job
 	if err := bm.deps.JobControl.CreateJob(backup, job); err != nil {
+		if resource, ok := controller.QuotaExceededResource(err); ok {
+			bm.statusUpdater.Update(backup, &v1alpha1.BackupCondition{
+				Command: logBackupSubcommand,
+				Type:    v1alpha1.BackupQuotaExceeded,
+				Status:  corev1.ConditionTrue,
+				Reason:  "CreateBackupJobRejected",
+				Message: fmt.Sprintf("create job %s is rejected by %s", backupJobName, resource),
+			}, nil)
+			return controller.RequeueErrorf("create backup %s/%s job %s is rejected by %s, err: %v", ns, name, backupJobName, resource, err)
+		}
 		errMsg := fmt.Errorf("create backup %s/%s job %s failed, err: %v", ns, name, backupJobName, err)
 		bm.statusUpdater.Update(backup, &v1alpha1.BackupCondition{
 			Command: logBackupSubcommand,
@@ -165,6 +188,10 @@ func (bm *backupManager) validateBackup(backup *v1alpha1.Backup) error {
 
 		tikvImage := tc.TiKVImage()
 		err = backuputil.ValidateBackup(backup, tikvImage)
+		// the SST files are not written to or read from the storage by TiKV for volume snapshots
+		if err == nil && backup.Spec.Local != nil && backup.Spec.Mode != v1alpha1.BackupModeVolumeSnapshot {
+			err = backuputil.ValidateLocalStorageOfTiKV(backup.Spec.Local, tc)
+		}
 	}
 
 	if err != nil {
@@ -299,6 +326,12 @@ func (bm *backupManager) makeExportJob(backup *v1alpha1.Backup) (*batchv1.Job, s
 		return nil, reason, fmt.Errorf("backup %s/%s, %v", ns, name, err)
 	}
 	envVars = append(envVars, storageEnv...)
+	brokerEnv, reason, err := brokerCredentialsEnv(bm.deps, backup)
+	if err != nil {
+		return nil, reason, err
+	}
+	envVars = append(envVars, brokerEnv...)
+	envVars = append(envVars, bm.deps.CLIConfig.ProxyEnvVars(backuputil.GetTiDBHostClusterProxy(backup.Spec.From.Host, ns, bm.deps.TiDBClusterLister))...)
 
 	// set env vars specified in backup.Spec.Env
 	envVars = util.AppendOverwriteEnv(envVars, backup.Spec.Env)
@@ -391,7 +424,7 @@ func (bm *backupManager) makeExportJob(backup *v1alpha1.Backup) (*batchv1.Job, s
 			Containers: []corev1.Container{
 				{
 					Name:            label.BackupJobLabelVal,
-					Image:           bm.deps.CLIConfig.TiDBBackupManagerImage,
+					Image:           bm.deps.CLIConfig.GetTiDBBackupManagerImage(),
 					Args:            args,
 					ImagePullPolicy: corev1.PullIfNotPresent,
 					VolumeMounts: append([]corev1.VolumeMount{
@@ -405,6 +438,7 @@ func (bm *backupManager) makeExportJob(backup *v1alpha1.Backup) (*batchv1.Job, s
 			Tolerations:      backup.Spec.Tolerations,
 			ImagePullSecrets: backup.Spec.ImagePullSecrets,
 			Affinity:         backup.Spec.Affinity,
+			NodeSelector:     backup.Spec.NodeSelector,
 			Volumes: append([]corev1.Volume{
 				{
 					Name: label.BackupJobLabelVal,
@@ -469,10 +503,16 @@ func (bm *backupManager) makeBRBackupJob(backup *v1alpha1.Backup) (*batchv1.Job,
 	}
 
 	envVars = append(envVars, storageEnv...)
+	brokerEnv, reason, err := brokerCredentialsEnv(bm.deps, backup)
+	if err != nil {
+		return nil, reason, err
+	}
+	envVars = append(envVars, brokerEnv...)
 	envVars = append(envVars, corev1.EnvVar{
 		Name:  "BR_LOG_TO_TERM",
 		Value: string(rune(1)),
 	})
+	envVars = append(envVars, bm.deps.CLIConfig.ProxyEnvVars(tc.Spec.Proxy)...)
 
 	// set env vars specified in backup.Spec.Env
 	envVars = util.AppendOverwriteEnv(envVars, backup.Spec.Env)
@@ -616,7 +656,7 @@ func (bm *backupManager) makeBRBackupJob(backup *v1alpha1.Backup) (*batchv1.Job,
 			Containers: []corev1.Container{
 				{
 					Name:            label.BackupJobLabelVal,
-					Image:           bm.deps.CLIConfig.TiDBBackupManagerImage,
+					Image:           bm.deps.CLIConfig.GetTiDBBackupManagerImage(),
 					Args:            args,
 					ImagePullPolicy: corev1.PullIfNotPresent,
 					VolumeMounts:    volumeMounts,
@@ -628,6 +668,7 @@ func (bm *backupManager) makeBRBackupJob(backup *v1alpha1.Backup) (*batchv1.Job,
 			Tolerations:       backup.Spec.Tolerations,
 			ImagePullSecrets:  backup.Spec.ImagePullSecrets,
 			Affinity:          backup.Spec.Affinity,
+			NodeSelector:      backup.Spec.NodeSelector,
 			Volumes:           volumes,
 			PriorityClassName: backup.Spec.PriorityClassName,
 		},
@@ -688,7 +729,7 @@ func (bm *backupManager) saveClusterMetaToExternalStorage(b *v1alpha1.Backup, cs
 }
 
 func (bm *backupManager) volumeSnapshotBackup(b *v1alpha1.Backup, tc *v1alpha1.TidbCluster) (string, error) {
-	if s, reason, err := snapshotter.NewSnapshotterForBackup(b.Spec.Mode, bm.deps); err != nil {
+	if s, reason, err := snapshotter.NewSnapshotterForBackup(b.Spec.Mode, bm.deps, tc); err != nil {
 		return reason, err
 	} else if s != nil {
 		csb, reason, err := s.GenerateBackupMetadata(b, tc)
@@ -696,6 +737,12 @@ func (bm *backupManager) volumeSnapshotBackup(b *v1alpha1.Backup, tc *v1alpha1.T
 			return reason, err
 		}
 
+		// record the topology, so that the restore into a differently shaped cluster can map the zones
+		topology := snapshotter.GetBackupTopology(tc, csb.Kubernetes.PVs)
+		if err := bm.statusUpdater.Update(b, nil, &controller.BackupUpdateStatus{Topology: topology}); err != nil {
+			return "UpdateBackupTopologyFailed", err
+		}
+
 		return bm.saveClusterMetaToExternalStorage(b, csb)
 	}
 	return "", nil
@@ -0,0 +1,124 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backup
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/klog/v2"
+)
+
+// waitBackupQueue queues the scheduled snapshot backup until the number of the running scheduled backups,
+// in total and in the same storage bucket, is below the limits of the operator. The queued backups are
+// dequeued in the order they are queued. It returns the queue status to update when the backup is dequeued.
+func (bm *backupManager) waitBackupQueue(backup *v1alpha1.Backup) (*controller.BackupUpdateStatus, error) {
+	ns := backup.GetNamespace()
+	name := backup.GetName()
+	limit := bm.deps.CLIConfig.MaxConcurrentScheduledBackups
+	bucketLimit := bm.deps.CLIConfig.MaxConcurrentScheduledBackupsPerBucket
+
+	if !isQueueableBackup(backup) || (limit <= 0 && bucketLimit <= 0) {
+		return nil, nil
+	}
+
+	req, err := labels.NewRequirement(label.BackupScheduleLabelKey, selection.Exists, nil)
+	if err != nil {
+		return nil, err
+	}
+	backups, err := bm.deps.BackupLister.List(labels.NewSelector().Add(*req))
+	if err != nil {
+		return nil, fmt.Errorf("backup %s/%s list scheduled backups failed, err: %v", ns, name, err)
+	}
+
+	now := metav1.Now()
+	queuedTime := backup.Status.TimeQueued
+	if queuedTime.IsZero() {
+		queuedTime = now
+	}
+	total, inBucket := countBackupsAhead(backup, queuedTime, backups)
+	if (limit <= 0 || total < limit) && (bucketLimit <= 0 || inBucket < bucketLimit) {
+		if backup.Status.TimeQueued.IsZero() {
+			return nil, nil
+		}
+		duration := now.Sub(backup.Status.TimeQueued.Time).Round(time.Second).String()
+		klog.Infof("backup %s/%s is dequeued after %s", ns, name, duration)
+		return &controller.BackupUpdateStatus{QueueDuration: &duration}, nil
+	}
+
+	if backup.Status.TimeQueued.IsZero() {
+		if err := bm.statusUpdater.Update(backup, nil, &controller.BackupUpdateStatus{TimeQueued: &now}); err != nil {
+			return nil, err
+		}
+	}
+	return nil, controller.RequeueErrorf("backup %s/%s is queued, %d scheduled backups are running or queued ahead, %d of them to the same bucket",
+		ns, name, total, inBucket)
+}
+
+// countBackupsAhead counts the running scheduled backups and the scheduled backups queued before the backup,
+// in total and in the same storage bucket.
+func countBackupsAhead(backup *v1alpha1.Backup, queuedTime metav1.Time, backups []*v1alpha1.Backup) (int, int) {
+	bucket := backupStorageBucket(backup)
+	total, inBucket := 0, 0
+	for _, b := range backups {
+		if b.Namespace == backup.Namespace && b.Name == backup.Name {
+			continue
+		}
+		if !isQueueableBackup(b) || v1alpha1.IsBackupComplete(b) || v1alpha1.IsBackupFailed(b) || v1alpha1.IsBackupInvalid(b) {
+			continue
+		}
+		if !v1alpha1.IsBackupScheduled(b) {
+			// not queued yet or queued after the backup
+			if b.Status.TimeQueued.IsZero() || queuedTime.Before(&b.Status.TimeQueued) {
+				continue
+			}
+			if b.Status.TimeQueued.Equal(&queuedTime) && b.Namespace+"/"+b.Name > backup.Namespace+"/"+backup.Name {
+				continue
+			}
+		}
+		total++
+		if bucket != "" && backupStorageBucket(b) == bucket {
+			inBucket++
+		}
+	}
+	return total, inBucket
+}
+
+// isQueueableBackup returns whether the backup is a snapshot backup created by a BackupSchedule
+func isQueueableBackup(backup *v1alpha1.Backup) bool {
+	if backup.Spec.Mode == v1alpha1.BackupModeLog {
+		return false
+	}
+	_, ok := backup.Labels[label.BackupScheduleLabelKey]
+	return ok
+}
+
+// backupStorageBucket returns the storage bucket of the backup, it's empty if the storage has no bucket
+func backupStorageBucket(backup *v1alpha1.Backup) string {
+	switch {
+	case backup.Spec.S3 != nil:
+		return fmt.Sprintf("s3://%s/%s", backup.Spec.S3.Endpoint, backup.Spec.S3.Bucket)
+	case backup.Spec.Gcs != nil:
+		return fmt.Sprintf("gcs://%s", backup.Spec.Gcs.Bucket)
+	case backup.Spec.Azblob != nil:
+		return fmt.Sprintf("azblob://%s", backup.Spec.Azblob.Container)
+	}
+	return ""
+}
@@ -29,6 +29,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/pointer"
 )
 
@@ -366,3 +367,108 @@ func TestClean(t *testing.T) {
 	}
 
 }
+
+func TestCountBackupsAhead(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	now := metav1.Now()
+	earlier := metav1.NewTime(now.Add(-time.Minute))
+	newBackup := func(name, bucket string, queued metav1.Time, condition v1alpha1.BackupConditionType) *v1alpha1.Backup {
+		b := &v1alpha1.Backup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "ns",
+				Labels:    map[string]string{"tidb.pingcap.com/backup-schedule": "bs"},
+			},
+			Spec: v1alpha1.BackupSpec{
+				StorageProvider: v1alpha1.StorageProvider{S3: &v1alpha1.S3StorageProvider{Bucket: bucket}},
+			},
+		}
+		b.Status.TimeQueued = queued
+		if condition != "" {
+			b.Status.Conditions = []v1alpha1.BackupCondition{{Type: condition, Status: corev1.ConditionTrue}}
+		}
+		return b
+	}
+
+	backups := []*v1alpha1.Backup{
+		// running
+		newBackup("running-a", "a", metav1.Time{}, v1alpha1.BackupScheduled),
+		newBackup("running-b", "b", earlier, v1alpha1.BackupScheduled),
+		// finished
+		newBackup("complete", "a", metav1.Time{}, v1alpha1.BackupComplete),
+		// queued ahead
+		newBackup("queued-a", "a", earlier, ""),
+		// queued at the same time but after it by name
+		newBackup("queued-z", "a", now, ""),
+		// queued later
+		newBackup("queued-later", "a", metav1.NewTime(now.Add(time.Minute)), ""),
+		// not queued yet
+		newBackup("new", "a", metav1.Time{}, ""),
+	}
+	// the log backup is never queued
+	logBackup := newBackup("log", "a", metav1.Time{}, v1alpha1.BackupScheduled)
+	logBackup.Spec.Mode = v1alpha1.BackupModeLog
+	backups = append(backups, logBackup)
+
+	backup := newBackup("queued-m", "a", now, "")
+	total, inBucket := countBackupsAhead(backup, now, backups)
+	g.Expect(total).Should(Equal(3))
+	g.Expect(inBucket).Should(Equal(2))
+
+	g.Expect(isQueueableBackup(logBackup)).Should(BeFalse())
+	g.Expect(isQueueableBackup(&v1alpha1.Backup{})).Should(BeFalse())
+	g.Expect(backupStorageBucket(backup)).Should(Equal("s3:///a"))
+}
+
+func TestBrokerCredentialsEnv(t *testing.T) {
+	g := NewGomegaWithT(t)
+	deps := controller.NewFakeDependencies()
+
+	backup := &v1alpha1.Backup{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "backup"},
+		Spec: v1alpha1.BackupSpec{
+			StorageProvider: v1alpha1.StorageProvider{
+				S3: &v1alpha1.S3StorageProvider{Provider: v1alpha1.S3StorageProviderTypeAWS, Bucket: "bucket"},
+			},
+		},
+	}
+
+	// the backup doesn't use the broker
+	envs, _, err := brokerCredentialsEnv(deps, backup)
+	g.Expect(err).Should(BeNil())
+	g.Expect(envs).Should(BeEmpty())
+
+	// the broker is not enabled for the operator
+	backup.Spec.S3.UseCredentialsBroker = true
+	_, reason, err := brokerCredentialsEnv(deps, backup)
+	g.Expect(err).ShouldNot(BeNil())
+	g.Expect(reason).Should(Equal("CredentialsBrokerDisabled"))
+
+	// the issued credentials are stored in the secret referred by the env vars
+	broker := deps.CredentialsBroker.(*controller.FakeCredentialsBroker)
+	broker.SetCredentials(&controller.StorageCredentials{
+		AccessKeyID:     "id",
+		SecretAccessKey: "secret",
+		SessionToken:    "token",
+		Expiration:      time.Now().Add(time.Hour),
+	})
+	envs, _, err = brokerCredentialsEnv(deps, backup)
+	g.Expect(err).Should(BeNil())
+	g.Expect(envs).Should(HaveLen(3))
+	for _, env := range envs {
+		g.Expect(env.ValueFrom.SecretKeyRef.Name).Should(Equal(backup.GetBrokerCredentialsSecretName()))
+	}
+	secret := &corev1.Secret{}
+	cli := deps.GenericControl.(*controller.FakeGenericControl).FakeCli
+	err = cli.Get(context.TODO(), types.NamespacedName{Namespace: backup.Namespace, Name: backup.GetBrokerCredentialsSecretName()}, secret)
+	g.Expect(err).Should(BeNil())
+	g.Expect(secret.Data).Should(HaveKeyWithValue("session_token", []byte("token")))
+	g.Expect(secret.Annotations).Should(HaveKey(annBrokerCredentialsExpiration))
+
+	// the failure of the broker fails the job creation
+	broker.SetError(fmt.Errorf("access denied"))
+	_, reason, err = brokerCredentialsEnv(deps, backup)
+	g.Expect(err).ShouldNot(BeNil())
+	g.Expect(reason).Should(Equal("IssueCredentialsFailed"))
+}
@@ -0,0 +1,87 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backup
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/backup/constants"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+)
+
+// annBrokerCredentialsExpiration is the annotation of the expiration time of the credentials issued by the broker
+const annBrokerCredentialsExpiration = "tidb.pingcap.com/credentials-expiration"
+
+// brokerCredentialsEnv issues the short-lived storage credentials for the backup using the credentials broker,
+// stores them in a secret owned by the backup and returns the env vars of the job referring to the secret.
+// It returns nothing if the backup doesn't use the broker.
+func brokerCredentialsEnv(deps *controller.Dependencies, backup *v1alpha1.Backup) ([]corev1.EnvVar, string, error) {
+	s3 := backup.Spec.S3
+	if s3 == nil || !s3.UseCredentialsBroker {
+		return nil, "", nil
+	}
+	ns := backup.GetNamespace()
+	name := backup.GetName()
+
+	if !deps.CredentialsBroker.Enabled() {
+		return nil, "CredentialsBrokerDisabled", fmt.Errorf("backup %s/%s uses the credentials broker, which is not enabled for the operator", ns, name)
+	}
+	credentials, err := deps.CredentialsBroker.IssueS3Credentials(ns, name, s3)
+	if err != nil {
+		return nil, "IssueCredentialsFailed", fmt.Errorf("backup %s/%s issue storage credentials failed, err: %v", ns, name, err)
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      backup.GetBrokerCredentialsSecretName(),
+			Namespace: ns,
+			Labels:    label.NewBackup().Instance(backup.GetInstanceName()),
+			Annotations: map[string]string{
+				annBrokerCredentialsExpiration: credentials.Expiration.Format(time.RFC3339),
+			},
+		},
+		Data: map[string][]byte{
+			constants.S3AccessKey:    []byte(credentials.AccessKeyID),
+			constants.S3SecretKey:    []byte(credentials.SecretAccessKey),
+			constants.S3SessionToken: []byte(credentials.SessionToken),
+		},
+	}
+	if _, err := deps.TypedControl.CreateOrUpdateSecret(backup, secret); err != nil {
+		return nil, "CreateCredentialsSecretFailed", fmt.Errorf("backup %s/%s create secret %s of the storage credentials failed, err: %v", ns, name, secret.Name, err)
+	}
+	klog.Infof("backup %s/%s issued storage credentials expiring at %s", ns, name, credentials.Expiration.Format(time.RFC3339))
+
+	secretEnvVar := func(envName, key string) corev1.EnvVar {
+		return corev1.EnvVar{
+			Name: envName,
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: secret.Name},
+					Key:                  key,
+				},
+			},
+		}
+	}
+	return []corev1.EnvVar{
+		secretEnvVar("AWS_ACCESS_KEY_ID", constants.S3AccessKey),
+		secretEnvVar("AWS_SECRET_ACCESS_KEY", constants.S3SecretKey),
+		secretEnvVar("AWS_SESSION_TOKEN", constants.S3SessionToken),
+	}, "", nil
+}
@@ -15,6 +15,7 @@ package backupschedule
 
 import (
 	"fmt"
+	"hash/fnv"
 	"path"
 	"sort"
 	"strings"
@@ -30,10 +31,15 @@ import (
 	"github.com/pingcap/tidb-operator/pkg/util"
 	"github.com/robfig/cron"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog/v2"
 )
 
+// logBackupTruncateInterval is the minimal interval to truncate the log backup which has no snapshot backups
+const logBackupTruncateInterval = time.Hour
+
 type nowFn func() time.Time
 
 type backupScheduleManager struct {
@@ -69,6 +75,14 @@ func (bm *backupScheduleManager) Sync(bs *v1alpha1.BackupSchedule) error {
 		return err
 	}
 
+	// spread out the backup schedules firing at the same time
+	jitter := backupScheduleJitter(bs, bm.deps.CLIConfig.BackupScheduleMaxJitter)
+	if bm.now().Before(scheduledTime.Add(jitter)) {
+		klog.V(4).Infof("backup schedule %s/%s, delay the backup scheduled at %s by %s", bs.GetNamespace(), bs.GetName(),
+			scheduledTime.Format(time.RFC3339), jitter)
+		return nil
+	}
+
 	// delete the last backup job for release the backup PVC
 	if err := bm.deleteLastBackupJob(bs); err != nil {
 		return nil
@@ -217,6 +231,17 @@ func getLastScheduledTime(bs *v1alpha1.BackupSchedule, nowFn nowFn) (*time.Time,
 	return &scheduledTime, nil
 }
 
+// backupScheduleJitter returns the delay of the backups of the backup schedule, which is stable for
+// the backup schedule and less than maxJitter.
+func backupScheduleJitter(bs *v1alpha1.BackupSchedule, maxJitter time.Duration) time.Duration {
+	if maxJitter <= 0 {
+		return 0
+	}
+	hf := fnv.New64a()
+	hf.Write([]byte(bs.GetNamespace() + "/" + bs.GetName()))
+	return time.Duration(hf.Sum64() % uint64(maxJitter))
+}
+
 func buildBackup(bs *v1alpha1.BackupSchedule, timestamp time.Time) *v1alpha1.Backup {
 	ns := bs.GetNamespace()
 	bsName := bs.GetName()
@@ -258,6 +283,11 @@ func buildBackup(bs *v1alpha1.BackupSchedule, timestamp time.Time) *v1alpha1.Bac
 		backupSpec.ImagePullSecrets = bs.Spec.ImagePullSecrets
 	}
 
+	// the data of the backups deleted by MaxSize must be removed from the backup storage
+	if bs.Spec.MaxSize != nil {
+		backupSpec.CleanPolicy = v1alpha1.CleanPolicyTypeDelete
+	}
+
 	bsLabel := util.CombineStringMap(label.NewBackupSchedule().Instance(bsName).BackupSchedule(bsName), bs.Labels)
 	backup := &v1alpha1.Backup{
 		Spec: backupSpec,
@@ -326,21 +356,80 @@ func (bm *backupScheduleManager) backupGC(bs *v1alpha1.BackupSchedule) {
 	ns := bs.GetNamespace()
 	bsName := bs.GetName()
 
+	hasMaxBackups := bs.Spec.MaxBackups != nil && *bs.Spec.MaxBackups > 0
+	if bs.Spec.MaxReservedTime == nil && !hasMaxBackups && bs.Spec.MaxSize == nil {
+		// TODO: When the backup schedule gc policy is not set, we should set a default backup gc policy.
+		klog.Warningf("backup schedule %s/%s does not set backup gc policy", ns, bsName)
+		return
+	}
+
+	gc := &v1alpha1.BackupScheduleGCStatus{DryRun: bs.Spec.GCDryRun}
 	// if MaxBackups and MaxReservedTime are set at the same time, MaxReservedTime is preferred.
 	if bs.Spec.MaxReservedTime != nil {
-		bm.backupGCByMaxReservedTime(bs)
+		bm.backupGCByMaxReservedTime(bs, gc)
+	} else if hasMaxBackups {
+		bm.backupGCByMaxBackups(bs, gc)
+	}
+	// MaxSize applies to the backups kept by the policies above
+	if bs.Spec.MaxSize != nil {
+		bm.backupGCByMaxSize(bs, gc)
+	}
+
+	backupsList, err := bm.getBackupList(bs)
+	if err != nil {
+		klog.Errorf("backupGC failed, err: %s", err)
 		return
 	}
+	deleted := sets.NewString(gc.DeletedBackups...)
+	for _, backup := range backupsList {
+		if backup.Spec.Mode == v1alpha1.BackupModeLog || backup.DeletionTimestamp != nil || deleted.Has(backup.GetName()) {
+			continue
+		}
+		gc.Backups++
+		if v1alpha1.IsBackupComplete(backup) {
+			gc.Size += backup.Status.BackupSize
+		}
+	}
+	bs.Status.GC = gc
+}
+
+// gcBackups deletes the backups, or only records them in the dry-run mode, and returns whether all of them are deleted
+func (bm *backupScheduleManager) gcBackups(bs *v1alpha1.BackupSchedule, gc *v1alpha1.BackupScheduleGCStatus, backups []*v1alpha1.Backup) bool {
+	ns := bs.GetNamespace()
+	bsName := bs.GetName()
+
+	for _, backup := range backups {
+		if gc.DryRun {
+			klog.Infof("backup schedule %s/%s gc backup %s skipped in dry-run mode", ns, bsName, backup.GetName())
+		} else {
+			if err := bm.deps.BackupControl.DeleteBackup(backup); err != nil {
+				klog.Errorf("backup schedule %s/%s gc backup %s failed, err %v", ns, bsName, backup.GetName(), err)
+				return false
+			}
+			klog.Infof("backup schedule %s/%s gc backup %s success", ns, bsName, backup.GetName())
+		}
+		gc.DeletedBackups = append(gc.DeletedBackups, backup.GetName())
+	}
+	return true
+}
 
-	if bs.Spec.MaxBackups != nil && *bs.Spec.MaxBackups > 0 {
-		bm.backupGCByMaxBackups(bs)
+// truncateLogBackup truncates the log backup to truncateTSO, the log backup is not truncated in the dry-run mode
+func (bm *backupScheduleManager) truncateLogBackup(bs *v1alpha1.BackupSchedule, gc *v1alpha1.BackupScheduleGCStatus, logBackup *v1alpha1.Backup, truncateTSO uint64) {
+	ns := bs.GetNamespace()
+	bsName := bs.GetName()
+
+	if gc.DryRun {
+		klog.Infof("backup schedule %s/%s truncate log backup %s skipped in dry-run mode, truncateTSO %d", ns, bsName, logBackup.GetName(), truncateTSO)
+		return
+	}
+	if err := bm.deps.BackupControl.TruncateLogBackup(logBackup, truncateTSO); err != nil {
+		klog.Errorf("backup schedule %s/%s truncate log backup %s failed, truncateTSO %d, err %v", ns, bsName, logBackup.GetName(), truncateTSO, err)
 		return
 	}
-	// TODO: When the backup schedule gc policy is not set, we should set a default backup gc policy.
-	klog.Warningf("backup schedule %s/%s does not set backup gc policy", ns, bsName)
+	klog.Infof("backup schedule %s/%s truncate log backup %s success, truncateTSO %d", ns, bsName, logBackup.GetName(), truncateTSO)
 }
 
-func (bm *backupScheduleManager) backupGCByMaxReservedTime(bs *v1alpha1.BackupSchedule) {
+func (bm *backupScheduleManager) backupGCByMaxReservedTime(bs *v1alpha1.BackupSchedule, gc *v1alpha1.BackupScheduleGCStatus) {
 	ns := bs.GetNamespace()
 	bsName := bs.GetName()
 
@@ -358,6 +447,10 @@ func (bm *backupScheduleManager) backupGCByMaxReservedTime(bs *v1alpha1.BackupSc
 
 	ascBackups, logBackup := separateSnapshotBackupsAndLogBackup(backupsList)
 	if len(ascBackups) == 0 {
+		if logBackup != nil {
+			// there is no snapshot backup, the log backup is truncated by the retention period alone
+			bm.truncateLogBackupByMaxReservedTime(bs, gc, logBackup, reservedTime)
+		}
 		return
 	}
 
@@ -380,30 +473,69 @@ func (bm *backupScheduleManager) backupGCByMaxReservedTime(bs *v1alpha1.BackupSc
 		}
 	}
 
-	for _, backup := range expiredBackups {
-		// delete the expired backup
-		if err = bm.deps.BackupControl.DeleteBackup(backup); err != nil {
-			klog.Errorf("backup schedule %s/%s gc backup %s failed, err %v", ns, bsName, backup.GetName(), err)
-			return
-		}
-		klog.Infof("backup schedule %s/%s gc backup %s success", ns, bsName, backup.GetName())
+	// delete the expired backups
+	if !bm.gcBackups(bs, gc, expiredBackups) {
+		return
 	}
 
 	if truncateTSO > 0 {
-		// truncate the log backup
-		if err = bm.deps.BackupControl.TruncateLogBackup(logBackup, truncateTSO); err != nil {
-			klog.Errorf("backup schedule %s/%s truncate log backup %s failed, truncateTSO %d, err %v", ns, bsName, logBackup.GetName(), truncateTSO, err)
-			return
-		}
-		klog.Infof("backup schedule %s/%s truncate log backup %s success, truncateTSO %d", ns, bsName, logBackup.GetName(), truncateTSO)
+		bm.truncateLogBackup(bs, gc, logBackup, truncateTSO)
 	}
 
-	if len(expiredBackups) == len(backupsList) && len(expiredBackups) > 0 {
+	if len(expiredBackups) == len(backupsList) && len(expiredBackups) > 0 && !gc.DryRun {
 		// All backups have been deleted, so the last backup information in the backupSchedule should be reset
 		bm.resetLastBackup(bs)
 	}
 }
 
+// truncateLogBackupByMaxReservedTime truncates the log backup which has no snapshot backups in the backup schedule
+func (bm *backupScheduleManager) truncateLogBackupByMaxReservedTime(bs *v1alpha1.BackupSchedule, gc *v1alpha1.BackupScheduleGCStatus, logBackup *v1alpha1.Backup, reservedTime time.Duration) {
+	ns := bs.GetNamespace()
+	bsName := bs.GetName()
+
+	truncateTSO, err := calLogBackupTruncateTSOByReservedTime(logBackup, reservedTime)
+	if err != nil {
+		klog.Errorf("backup schedule %s/%s caculate truncate tso of log backup %s, err: %s", ns, bsName, logBackup.GetName(), err)
+		return
+	}
+	if truncateTSO == 0 {
+		return
+	}
+	bm.truncateLogBackup(bs, gc, logBackup, truncateTSO)
+}
+
+// calLogBackupTruncateTSOByReservedTime calculates the truncate tso of the log backup without snapshot backups,
+// which is checkpointTS - reservedTime.
+// the checkpoint ts is always changing, so it returns 0 if the last truncation is within logBackupTruncateInterval
+// to avoid truncating the log backup frequently.
+func calLogBackupTruncateTSOByReservedTime(logBackup *v1alpha1.Backup, reservedTime time.Duration) (uint64, error) {
+	checkPointTSO, err := config.ParseTSString(logBackup.Status.LogCheckpointTs)
+	if err != nil {
+		return 0, perrors.Annotatef(err, "parse checkpoint ts of log backup %s/%s", logBackup.Namespace, logBackup.Name)
+	}
+	if checkPointTSO == 0 {
+		return 0, nil
+	}
+	truncatedTSO, err := config.ParseTSString(logBackup.Spec.LogTruncateUntil)
+	if err != nil {
+		return 0, perrors.Annotatef(err, "parse truncate until ts of log backup %s/%s", logBackup.Namespace, logBackup.Name)
+	}
+
+	truncateTSO := calculateExpiredTSO(checkPointTSO, reservedTime)
+	if truncatedTSO != 0 && config.TSOToTS(truncateTSO)-config.TSOToTS(truncatedTSO) < int64(logBackupTruncateInterval.Seconds()) {
+		return 0, nil
+	}
+
+	isTruncateTSOInLogBackup, err := checkTruncateTSOWithinLogBackupRange(logBackup, truncateTSO)
+	if err != nil {
+		return 0, perrors.Annotate(err, "check truncate ts in log backup")
+	}
+	if isTruncateTSOInLogBackup {
+		return truncateTSO, nil
+	}
+	return 0, nil
+}
+
 // separateSnapshotBackupsAndLogBackup return snapot backups ordry by create time asc and log backup
 func separateSnapshotBackupsAndLogBackup(backupsList []*v1alpha1.Backup) ([]*v1alpha1.Backup, *v1alpha1.Backup) {
 	var (
@@ -615,7 +747,7 @@ func checkTruncateTSOWithinLogBackupRange(logBackup *v1alpha1.Backup, truncateTS
 	return false, nil
 }
 
-func (bm *backupScheduleManager) backupGCByMaxBackups(bs *v1alpha1.BackupSchedule) {
+func (bm *backupScheduleManager) backupGCByMaxBackups(bs *v1alpha1.BackupSchedule, gc *v1alpha1.BackupScheduleGCStatus) {
 	ns := bs.GetNamespace()
 	bsName := bs.GetName()
 
@@ -625,28 +757,98 @@ func (bm *backupScheduleManager) backupGCByMaxBackups(bs *v1alpha1.BackupSchedul
 		return
 	}
 
+	// the log backup is never deleted by MaxBackups, it is truncated to the oldest reserved snapshot backup instead
+	var logBackup *v1alpha1.Backup
+	snapshotBackups := make([]*v1alpha1.Backup, 0, len(backupsList))
+	for _, backup := range backupsList {
+		if backup.Spec.Mode == v1alpha1.BackupModeLog {
+			logBackup = backup
+			continue
+		}
+		snapshotBackups = append(snapshotBackups, backup)
+	}
+	backupsList = snapshotBackups
+
 	sort.Sort(byCreateTimeDesc(backupsList))
 
 	var deleteCount int
-	for i, backup := range backupsList {
-		if i < int(*bs.Spec.MaxBackups) {
-			continue
+	if len(backupsList) > int(*bs.Spec.MaxBackups) {
+		if !bm.gcBackups(bs, gc, backupsList[*bs.Spec.MaxBackups:]) {
+			return
+		}
+		deleteCount = len(backupsList) - int(*bs.Spec.MaxBackups)
+	}
+
+	if logBackup != nil && deleteCount > 0 && deleteCount < len(backupsList) {
+		// truncate the log backup to the commit ts of the oldest reserved backup, which is the starting point of PiTR
+		oldest := backupsList[len(backupsList)-deleteCount-1]
+		truncateTSO, err := config.ParseTSString(oldest.Status.CommitTs)
+		if err != nil {
+			klog.Errorf("backup schedule %s/%s parse commit ts of backup %s, err: %s", ns, bsName, oldest.GetName(), err)
+			return
 		}
-		// delete the backup
-		if err := bm.deps.BackupControl.DeleteBackup(backup); err != nil {
-			klog.Errorf("backup schedule %s/%s gc backup %s failed, err %v", ns, bsName, backup.GetName(), err)
+		isTruncateTSOInLogBackup, err := checkTruncateTSOWithinLogBackupRange(logBackup, truncateTSO)
+		if err != nil {
+			klog.Errorf("backup schedule %s/%s check truncate ts in log backup %s, err: %s", ns, bsName, logBackup.GetName(), err)
 			return
 		}
-		deleteCount += 1
-		klog.Infof("backup schedule %s/%s gc backup %s success", ns, bsName, backup.GetName())
+		if isTruncateTSOInLogBackup {
+			bm.truncateLogBackup(bs, gc, logBackup, truncateTSO)
+		}
 	}
 
-	if deleteCount == len(backupsList) && deleteCount > 0 {
+	if deleteCount == len(backupsList) && deleteCount > 0 && !gc.DryRun {
 		// All backups have been deleted, so the last backup information in the backupSchedule should be reset
 		bm.resetLastBackup(bs)
 	}
 }
 
+// backupGCByMaxSize deletes the oldest completed backups until the total size of the rest is within MaxSize,
+// the backups already deleted by the other policies are not counted
+func (bm *backupScheduleManager) backupGCByMaxSize(bs *v1alpha1.BackupSchedule, gc *v1alpha1.BackupScheduleGCStatus) {
+	ns := bs.GetNamespace()
+	bsName := bs.GetName()
+
+	maxSize, err := resource.ParseQuantity(*bs.Spec.MaxSize)
+	if err != nil {
+		klog.Errorf("backup schedule %s/%s, invalid MaxSize %s", ns, bsName, *bs.Spec.MaxSize)
+		return
+	}
+
+	backupsList, err := bm.getBackupList(bs)
+	if err != nil {
+		klog.Errorf("backupGCByMaxSize failed, err: %s", err)
+		return
+	}
+
+	deleted := sets.NewString(gc.DeletedBackups...)
+	completedBackups := make([]*v1alpha1.Backup, 0, len(backupsList))
+	for _, backup := range backupsList {
+		if backup.Spec.Mode == v1alpha1.BackupModeLog || backup.DeletionTimestamp != nil || deleted.Has(backup.GetName()) {
+			continue
+		}
+		if v1alpha1.IsBackupComplete(backup) {
+			completedBackups = append(completedBackups, backup)
+		}
+	}
+	sort.Sort(byCreateTimeDesc(completedBackups))
+
+	bm.gcBackups(bs, gc, calculateBackupsOverSize(completedBackups, maxSize.Value()))
+}
+
+// calculateBackupsOverSize returns the oldest backups to delete so that the total size of the rest is within maxSize,
+// the latest backup is always kept. The backups are sorted by the creation time in descending order.
+func calculateBackupsOverSize(backups []*v1alpha1.Backup, maxSize int64) []*v1alpha1.Backup {
+	var total int64
+	for i, backup := range backups {
+		total += backup.Status.BackupSize
+		if i > 0 && total > maxSize {
+			return backups[i:]
+		}
+	}
+	return nil
+}
+
 func (bm *backupScheduleManager) resetLastBackup(bs *v1alpha1.BackupSchedule) {
 	bs.Status.LastBackupTime = nil
 	bs.Status.LastBackup = ""
@@ -336,6 +336,63 @@ func TestCaculateExpiredBackupsWithLogBackup(t *testing.T) {
 	}
 }
 
+func TestCalLogBackupTruncateTSOByReservedTime(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	var (
+		now        = time.Now()
+		last10Min  = now.Add(-time.Minute * 10).Unix()
+		last1Day   = now.Add(-time.Hour * 24 * 1).Unix()
+		lastDay30m = now.Add(-time.Hour*24 - time.Minute*30).Unix()
+		last2Day   = now.Add(-time.Hour * 24 * 2).Unix()
+		last3Day   = now.Add(-time.Hour * 24 * 3).Unix()
+	)
+	truncated := func(logBackup *v1alpha1.Backup, ts int64) *v1alpha1.Backup {
+		logBackup.Spec.LogTruncateUntil = getTSOStr(ts)
+		return logBackup
+	}
+
+	testCases := []struct {
+		name               string
+		logBackup          *v1alpha1.Backup
+		expectedTruncateTS uint64
+	}{
+		{
+			name:               "log backup just start, no commit ts/checkpoint ts",
+			logBackup:          fakeLogBackup(nil, nil),
+			expectedTruncateTS: 0,
+		},
+		{
+			name:               "log backup is shorter than the reserved time",
+			logBackup:          fakeLogBackup(&last1Day, &last10Min),
+			expectedTruncateTS: 0,
+		},
+		{
+			name:               "log backup is truncated to checkpoint ts - reserved time",
+			logBackup:          fakeLogBackup(&last3Day, &last10Min),
+			expectedTruncateTS: getTSO(now.Add(-time.Hour*24 - time.Minute*10).Unix()),
+		},
+		{
+			name:               "log backup has been truncated within the truncate interval",
+			logBackup:          truncated(fakeLogBackup(&last3Day, &last10Min), lastDay30m),
+			expectedTruncateTS: 0,
+		},
+		{
+			name:               "log backup has been truncated before the truncate interval",
+			logBackup:          truncated(fakeLogBackup(&last3Day, &last10Min), last2Day),
+			expectedTruncateTS: getTSO(now.Add(-time.Hour*24 - time.Minute*10).Unix()),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			truncateTS, err := calLogBackupTruncateTSOByReservedTime(tc.logBackup, 24*time.Hour)
+			g.Expect(err).Should(BeNil())
+			g.Expect(truncateTS).Should(Equal(tc.expectedTruncateTS))
+		})
+	}
+}
+
 type helper struct {
 	t    *testing.T
 	deps *controller.Dependencies
@@ -497,3 +554,57 @@ func getTSOStr(ts int64) string {
 func getTSO(ts int64) uint64 {
 	return uint64((ts << 18) * 1000)
 }
+
+func TestBackupScheduleJitter(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	bs := &v1alpha1.BackupSchedule{ObjectMeta: metav1.ObjectMeta{Name: "bs", Namespace: "ns"}}
+	g.Expect(backupScheduleJitter(bs, 0)).Should(Equal(time.Duration(0)))
+
+	jitter := backupScheduleJitter(bs, time.Hour)
+	g.Expect(jitter).Should(BeNumerically(">=", 0))
+	g.Expect(jitter).Should(BeNumerically("<", time.Hour))
+	// the jitter is stable for a backup schedule
+	g.Expect(backupScheduleJitter(bs, time.Hour)).Should(Equal(jitter))
+}
+
+func TestBackupGCByMaxSize(t *testing.T) {
+	g := NewGomegaWithT(t)
+	helper := newHelper(t)
+	defer helper.close()
+	m := NewBackupScheduleManager(helper.deps).(*backupScheduleManager)
+
+	bs := &v1alpha1.BackupSchedule{}
+	bs.Namespace = "ns"
+	bs.Name = "bsname"
+	bs.Spec.MaxSize = pointer.StringPtr("100")
+	bs.Spec.GCDryRun = true
+	g.Expect(buildBackup(bs, time.Now()).Spec.CleanPolicy).Should(Equal(v1alpha1.CleanPolicyTypeDelete))
+
+	now := time.Now()
+	for i := 0; i < 4; i++ {
+		bk := buildBackup(bs, now.Add(time.Duration(i)*time.Hour))
+		bk.CreationTimestamp = metav1.Time{Time: now.Add(time.Duration(i) * time.Hour)}
+		bk.Status.BackupSize = 40
+		bk.Status.Conditions = []v1alpha1.BackupCondition{{Type: v1alpha1.BackupComplete, Status: v1.ConditionTrue}}
+		helper.createBackup(bk)
+	}
+	// the running backup is not counted
+	running := buildBackup(bs, now.Add(4*time.Hour))
+	running.CreationTimestamp = metav1.Time{Time: now.Add(4 * time.Hour)}
+	helper.createBackup(running)
+
+	// the backups are only reported in the dry-run mode
+	m.backupGC(bs)
+	oldest := []string{bs.GetBackupCRDName(now.Add(time.Hour)), bs.GetBackupCRDName(now)}
+	g.Expect(bs.Status.GC).Should(Equal(&v1alpha1.BackupScheduleGCStatus{DryRun: true, Backups: 3, Size: 80, DeletedBackups: oldest}))
+	helper.checkBacklist(bs.Namespace, 5, false)
+
+	bs.Spec.GCDryRun = false
+	m.backupGC(bs)
+	g.Expect(bs.Status.GC.DeletedBackups).Should(Equal(oldest))
+	helper.checkBacklist(bs.Namespace, 3, false)
+
+	// the latest completed backup is always kept
+	g.Expect(calculateBackupsOverSize([]*v1alpha1.Backup{{Status: v1alpha1.BackupStatus{BackupSize: 200}}}, 100)).Should(BeEmpty())
+}
@@ -0,0 +1,383 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package catalog maintains the catalog of the BR backups of a cluster in the backup storage. The catalog
+// outlives the Backup objects, which are garbage collected by the backup schedules, so that the restorable
+// points can still be listed and validated.
+//
+// The catalog of a cluster is stored in the directory backup-catalog/<namespace>/<cluster> next to the
+// backups, e.g. under the prefix of a backup schedule, and contains a manifest for each backup.
+package catalog
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/gogo/protobuf/proto"
+	kvbackup "github.com/pingcap/kvproto/pkg/backup"
+	"gocloud.dev/blob"
+	"gocloud.dev/gcerrors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/backup/constants"
+	"github.com/pingcap/tidb-operator/pkg/backup/util"
+)
+
+const (
+	// Dir is the directory of the catalogs relative to the parent of the backups
+	Dir = "backup-catalog"
+	// manifestSuffix is the suffix of the manifest files in a catalog
+	manifestSuffix = ".json"
+	// manifestVersion is the version of the manifest format
+	manifestVersion = 1
+	listPageSize    = 1000
+)
+
+// Manifest describes a backup recorded in the catalog
+type Manifest struct {
+	Version int `json:"version"`
+	// Namespace and Name of the Backup object
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	// ClusterNamespace and Cluster are the backed up TidbCluster
+	ClusterNamespace string `json:"clusterNamespace"`
+	Cluster          string `json:"cluster"`
+	// ClusterID is the ID of the PD cluster
+	ClusterID      uint64 `json:"clusterID"`
+	ClusterVersion string `json:"clusterVersion,omitempty"`
+	BRVersion      string `json:"brVersion,omitempty"`
+
+	Type    v1alpha1.BackupType      `json:"backupType,omitempty"`
+	Storage v1alpha1.StorageProvider `json:"storage"`
+	Path    string                   `json:"path"`
+	// StartTs is the TSO the incremental backup starts from, it's 0 for a full backup
+	StartTs uint64 `json:"startTs,omitempty"`
+	// CommitTs is the TSO the backup can be restored to
+	CommitTs uint64 `json:"commitTs"`
+	// DependsOn is the name of the backup the incremental backup is based on
+	DependsOn string `json:"dependsOn,omitempty"`
+
+	Size     int64    `json:"size"`
+	Checksum Checksum `json:"checksum"`
+
+	TimeStarted   metav1.Time `json:"timeStarted,omitempty"`
+	TimeCompleted metav1.Time `json:"timeCompleted,omitempty"`
+}
+
+// Checksum is the checksum of the data files of a backup, which is aggregated from the checksums
+// of the files recorded in the BR backup meta
+type Checksum struct {
+	Files      int    `json:"files"`
+	Crc64Xor   uint64 `json:"crc64xor"`
+	TotalKvs   uint64 `json:"totalKvs"`
+	TotalBytes uint64 `json:"totalBytes"`
+}
+
+// Result is the result of validating a backup of the catalog
+type Result struct {
+	*Manifest
+	// Restorable is whether the data of the backup and the backups it depends on are complete
+	Restorable bool
+	// Reason is the reason why the backup isn't restorable
+	Reason string
+}
+
+// NewManifest returns the manifest of the completed backup from the BR backup meta
+func NewManifest(backup *v1alpha1.Backup, meta *kvbackup.BackupMeta, brVersion string) (*Manifest, error) {
+	backupPath, err := util.GetStoragePath(backup.Spec.StorageProvider)
+	if err != nil {
+		return nil, err
+	}
+	m := &Manifest{
+		Version:          manifestVersion,
+		Namespace:        backup.Namespace,
+		Name:             backup.Name,
+		ClusterNamespace: backup.Namespace,
+		ClusterID:        meta.ClusterId,
+		ClusterVersion:   meta.ClusterVersion,
+		BRVersion:        brVersion,
+		Type:             backup.Spec.Type,
+		Storage:          backup.Spec.StorageProvider,
+		Path:             backupPath,
+		StartTs:          meta.StartVersion,
+		CommitTs:         meta.EndVersion,
+		Size:             int64(meta.Size()),
+		Checksum:         checksumOf(meta),
+	}
+	for _, file := range meta.Files {
+		m.Size += int64(file.Size_)
+	}
+	if backup.Spec.BR != nil {
+		m.Cluster = backup.Spec.BR.Cluster
+		if backup.Spec.BR.ClusterNamespace != "" {
+			m.ClusterNamespace = backup.Spec.BR.ClusterNamespace
+		}
+	}
+	return m, nil
+}
+
+func checksumOf(meta *kvbackup.BackupMeta) Checksum {
+	c := Checksum{Files: len(meta.Files)}
+	for _, file := range meta.Files {
+		c.Crc64Xor ^= file.Crc64Xor
+		c.TotalKvs += file.TotalKvs
+		c.TotalBytes += file.TotalBytes
+	}
+	return c
+}
+
+// StorageForBackup returns the storage of the catalogs of the backups stored next to the backup
+func StorageForBackup(provider v1alpha1.StorageProvider) (v1alpha1.StorageProvider, error) {
+	prefix, err := prefixOf(provider)
+	if err != nil {
+		return provider, err
+	}
+	parent := path.Dir(strings.Trim(prefix, "/"))
+	if parent == "." {
+		parent = ""
+	}
+	return withPrefix(provider, path.Join(parent, Dir)), nil
+}
+
+// StorageForSchedule returns the storage of the catalogs of the backups created by the backup schedule
+// from the backup template
+func StorageForSchedule(provider v1alpha1.StorageProvider) (v1alpha1.StorageProvider, error) {
+	prefix, err := prefixOf(provider)
+	if err != nil {
+		return provider, err
+	}
+	return withPrefix(provider, path.Join(prefix, Dir)), nil
+}
+
+func prefixOf(provider v1alpha1.StorageProvider) (string, error) {
+	switch util.GetStorageType(provider) {
+	case v1alpha1.BackupStorageTypeS3:
+		return provider.S3.Prefix, nil
+	case v1alpha1.BackupStorageTypeGcs:
+		return provider.Gcs.Prefix, nil
+	case v1alpha1.BackupStorageTypeAzblob:
+		return provider.Azblob.Prefix, nil
+	case v1alpha1.BackupStorageTypeLocal:
+		return provider.Local.Prefix, nil
+	default:
+		return "", fmt.Errorf("storage %s not supported yet", util.GetStorageType(provider))
+	}
+}
+
+func withPrefix(provider v1alpha1.StorageProvider, prefix string) v1alpha1.StorageProvider {
+	p := *provider.DeepCopy()
+	switch {
+	case p.S3 != nil:
+		p.S3.Prefix = prefix
+	case p.Gcs != nil:
+		p.Gcs.Prefix = prefix
+	case p.Azblob != nil:
+		p.Azblob.Prefix = prefix
+	case p.Local != nil:
+		p.Local.Prefix = prefix
+	}
+	return p
+}
+
+// Catalog is the catalog of the backups of a cluster
+type Catalog struct {
+	cred    *util.StorageCredential
+	backend *util.StorageBackend
+}
+
+// Open opens the catalog of the cluster in the catalog storage returned by StorageForBackup or StorageForSchedule
+func Open(storage v1alpha1.StorageProvider, clusterNamespace, cluster string, cred *util.StorageCredential) (*Catalog, error) {
+	prefix, err := prefixOf(storage)
+	if err != nil {
+		return nil, err
+	}
+	storage = withPrefix(storage, path.Join(prefix, clusterNamespace, cluster))
+	if storage.Local != nil {
+		// the local bucket can't be opened until the directory exists
+		if err := os.MkdirAll(path.Join(storage.Local.VolumeMount.MountPath, storage.Local.Prefix), 0755); err != nil {
+			return nil, err
+		}
+	}
+	backend, err := util.NewStorageBackend(storage, cred)
+	if err != nil {
+		return nil, err
+	}
+	return &Catalog{cred: cred, backend: backend}, nil
+}
+
+// Close closes the catalog
+func (c *Catalog) Close() error {
+	return c.backend.Close()
+}
+
+// Add records the backup in the catalog. The incremental backup depends on the backup in the catalog
+// whose commit TS is the start TS of it.
+func (c *Catalog) Add(ctx context.Context, m *Manifest) error {
+	if m.StartTs > 0 && m.DependsOn == "" {
+		manifests, err := c.List(ctx)
+		if err != nil {
+			return err
+		}
+		for _, base := range manifests {
+			if base.CommitTs == m.StartTs && base.Name != m.Name {
+				m.DependsOn = base.Name
+				break
+			}
+		}
+	}
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	return c.backend.WriteAll(ctx, manifestKey(m.Namespace, m.Name), data, nil)
+}
+
+// Remove removes the backup from the catalog
+func (c *Catalog) Remove(ctx context.Context, namespace, name string) error {
+	err := c.backend.Delete(ctx, manifestKey(namespace, name))
+	if gcerrors.Code(err) == gcerrors.NotFound {
+		return nil
+	}
+	return err
+}
+
+func manifestKey(namespace, name string) string {
+	return namespace + "_" + name + manifestSuffix
+}
+
+// List returns the backups of the catalog in the order of the commit TS
+func (c *Catalog) List(ctx context.Context) ([]*Manifest, error) {
+	var manifests []*Manifest
+	iter := c.backend.ListPage(nil)
+	for {
+		objs, err := iter.Next(ctx, listPageSize)
+		for _, obj := range objs {
+			if obj.IsDir || !strings.HasSuffix(obj.Key, manifestSuffix) {
+				continue
+			}
+			data, rerr := c.backend.ReadAll(ctx, obj.Key)
+			if rerr != nil {
+				return nil, fmt.Errorf("read manifest %s: %v", obj.Key, rerr)
+			}
+			m := &Manifest{}
+			if rerr := json.Unmarshal(data, m); rerr != nil {
+				return nil, fmt.Errorf("unmarshal manifest %s: %v", obj.Key, rerr)
+			}
+			manifests = append(manifests, m)
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	sort.SliceStable(manifests, func(i, j int) bool {
+		return manifests[i].CommitTs < manifests[j].CommitTs
+	})
+	return manifests, nil
+}
+
+// Validate checks the data of the backups of the catalog against their manifests, a backup is restorable
+// if its data files are complete and so are the backups it depends on
+func (c *Catalog) Validate(ctx context.Context) ([]Result, error) {
+	manifests, err := c.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	byName := make(map[string]*Result, len(manifests))
+	results := make([]Result, len(manifests))
+	for i, m := range manifests {
+		results[i] = Result{Manifest: m, Restorable: true}
+		if reason := c.validateData(ctx, m); reason != "" {
+			results[i].Restorable = false
+			results[i].Reason = reason
+		}
+		byName[m.Name] = &results[i]
+	}
+
+	// follow the dependency chain, manifests are sorted by commit TS so the bases are validated first
+	for i := range results {
+		r := &results[i]
+		if !r.Restorable || r.StartTs == 0 {
+			continue
+		}
+		base, ok := byName[r.DependsOn]
+		switch {
+		case r.DependsOn == "" || !ok:
+			r.Restorable = false
+			r.Reason = fmt.Sprintf("the base backup with commit ts %d is not found", r.StartTs)
+		case !base.Restorable:
+			r.Restorable = false
+			r.Reason = fmt.Sprintf("the base backup %s is not restorable", base.Name)
+		}
+	}
+	return results, nil
+}
+
+// validateData returns the reason why the data of the backup doesn't match the manifest
+func (c *Catalog) validateData(ctx context.Context, m *Manifest) string {
+	backend, err := util.NewStorageBackend(m.Storage, c.cred)
+	if err != nil {
+		return fmt.Sprintf("open the backup storage: %v", err)
+	}
+	defer backend.Close()
+
+	data, err := backend.ReadAll(ctx, constants.MetaFile)
+	if err != nil {
+		return fmt.Sprintf("read %s: %v", constants.MetaFile, err)
+	}
+	meta := &kvbackup.BackupMeta{}
+	if err := proto.Unmarshal(data, meta); err != nil {
+		return fmt.Sprintf("unmarshal %s: %v", constants.MetaFile, err)
+	}
+	if meta.EndVersion != m.CommitTs {
+		return fmt.Sprintf("commit ts %d doesn't match %d of the manifest", meta.EndVersion, m.CommitTs)
+	}
+	if checksum := checksumOf(meta); checksum != m.Checksum {
+		return fmt.Sprintf("checksum %+v doesn't match %+v of the manifest", checksum, m.Checksum)
+	}
+
+	sizes := map[string]int64{}
+	iter := backend.ListPage(&blob.ListOptions{})
+	for {
+		objs, err := iter.Next(ctx, listPageSize)
+		for _, obj := range objs {
+			sizes[obj.Key] = obj.Size
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Sprintf("list the data files: %v", err)
+		}
+	}
+	for _, file := range meta.Files {
+		size, ok := sizes[file.Name]
+		if !ok {
+			return fmt.Sprintf("data file %s is missing", file.Name)
+		}
+		if file.Size_ > 0 && uint64(size) != file.Size_ {
+			return fmt.Sprintf("size %d of data file %s doesn't match %d", size, file.Name, file.Size_)
+		}
+	}
+	return ""
+}
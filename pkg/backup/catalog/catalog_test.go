@@ -0,0 +1,135 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package catalog
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	. "github.com/onsi/gomega"
+	kvbackup "github.com/pingcap/kvproto/pkg/backup"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/backup/constants"
+	"github.com/pingcap/tidb-operator/pkg/backup/util"
+)
+
+func TestStorage(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	storage, err := StorageForBackup(v1alpha1.StorageProvider{S3: &v1alpha1.S3StorageProvider{Bucket: "bucket", Prefix: "schedule/backup-1"}})
+	g.Expect(err).To(Succeed())
+	g.Expect(storage.S3.Prefix).To(Equal("schedule/backup-catalog"))
+
+	storage, err = StorageForBackup(v1alpha1.StorageProvider{Gcs: &v1alpha1.GcsStorageProvider{Bucket: "bucket", Prefix: "backup-1"}})
+	g.Expect(err).To(Succeed())
+	g.Expect(storage.Gcs.Prefix).To(Equal("backup-catalog"))
+
+	storage, err = StorageForSchedule(v1alpha1.StorageProvider{Azblob: &v1alpha1.AzblobStorageProvider{Container: "container", Prefix: "schedule"}})
+	g.Expect(err).To(Succeed())
+	g.Expect(storage.Azblob.Prefix).To(Equal("schedule/backup-catalog"))
+}
+
+func TestCatalog(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	newBackup := func(name string, startTs, commitTs uint64) *v1alpha1.Backup {
+		backup := &v1alpha1.Backup{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ns"},
+			Spec: v1alpha1.BackupSpec{
+				StorageProvider: v1alpha1.StorageProvider{Local: &v1alpha1.LocalStorageProvider{
+					VolumeMount: corev1.VolumeMount{MountPath: dir},
+					Prefix:      "schedule/" + name,
+				}},
+				BR: &v1alpha1.BRConfig{Cluster: "basic"},
+			},
+		}
+		meta := &kvbackup.BackupMeta{
+			ClusterId:      1,
+			ClusterVersion: "v6.5.0",
+			StartVersion:   startTs,
+			EndVersion:     commitTs,
+			Files: []*kvbackup.File{
+				{Name: "1.sst", Crc64Xor: 1, TotalKvs: 10, TotalBytes: 100, Size_: 5},
+				{Name: "2.sst", Crc64Xor: 2, TotalKvs: 20, TotalBytes: 200, Size_: 5},
+			},
+		}
+		backupDir := filepath.Join(dir, "schedule", name)
+		g.Expect(os.MkdirAll(backupDir, 0755)).To(Succeed())
+		data, err := proto.Marshal(meta)
+		g.Expect(err).To(Succeed())
+		g.Expect(os.WriteFile(filepath.Join(backupDir, constants.MetaFile), data, 0644)).To(Succeed())
+		for _, file := range meta.Files {
+			g.Expect(os.WriteFile(filepath.Join(backupDir, file.Name), []byte("12345"), 0644)).To(Succeed())
+		}
+
+		storage, err := StorageForBackup(backup.Spec.StorageProvider)
+		g.Expect(err).To(Succeed())
+		c, err := Open(storage, "ns", "basic", &util.StorageCredential{})
+		g.Expect(err).To(Succeed())
+		defer c.Close()
+		m, err := NewManifest(backup, meta, "v6.5.0")
+		g.Expect(err).To(Succeed())
+		g.Expect(m.Checksum).To(Equal(Checksum{Files: 2, Crc64Xor: 3, TotalKvs: 30, TotalBytes: 300}))
+		g.Expect(c.Add(ctx, m)).To(Succeed())
+		return backup
+	}
+
+	newBackup("full", 0, 100)
+	newBackup("incr", 100, 200)
+
+	storage, err := StorageForSchedule(v1alpha1.StorageProvider{Local: &v1alpha1.LocalStorageProvider{
+		VolumeMount: corev1.VolumeMount{MountPath: dir},
+		Prefix:      "schedule",
+	}})
+	g.Expect(err).To(Succeed())
+	c, err := Open(storage, "ns", "basic", &util.StorageCredential{})
+	g.Expect(err).To(Succeed())
+	defer c.Close()
+
+	manifests, err := c.List(ctx)
+	g.Expect(err).To(Succeed())
+	g.Expect(manifests).To(HaveLen(2))
+	g.Expect(manifests[0].Name).To(Equal("full"))
+	g.Expect(manifests[1].Name).To(Equal("incr"))
+	g.Expect(manifests[1].DependsOn).To(Equal("full"))
+
+	results, err := c.Validate(ctx)
+	g.Expect(err).To(Succeed())
+	g.Expect(results[0].Restorable).To(BeTrue())
+	g.Expect(results[1].Restorable).To(BeTrue())
+
+	// a missing data file of the base backup breaks the whole chain
+	g.Expect(os.Remove(filepath.Join(dir, "schedule", "full", "2.sst"))).To(Succeed())
+	results, err = c.Validate(ctx)
+	g.Expect(err).To(Succeed())
+	g.Expect(results[0].Restorable).To(BeFalse())
+	g.Expect(results[0].Reason).To(ContainSubstring("2.sst is missing"))
+	g.Expect(results[1].Restorable).To(BeFalse())
+	g.Expect(results[1].Reason).To(Equal("the base backup full is not restorable"))
+
+	g.Expect(c.Remove(ctx, "ns", "full")).To(Succeed())
+	g.Expect(c.Remove(ctx, "ns", "full")).To(Succeed())
+	results, err = c.Validate(ctx)
+	g.Expect(err).To(Succeed())
+	g.Expect(results).To(HaveLen(1))
+	g.Expect(results[0].Reason).To(Equal("the base backup with commit ts 100 is not found"))
+}
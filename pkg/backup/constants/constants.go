@@ -35,6 +35,9 @@ const (
 	// S3SecretKey represents the S3 compatible secret access key in related secret
 	S3SecretKey = "secret_key"
 
+	// S3SessionToken represents the optional session token of the temporary S3 credentials in related secret
+	S3SessionToken = "session_token"
+
 	// GcsCredentialsKey represents the gcs service account credentials json key in related secret
 	GcsCredentialsKey = "credentials"
 
@@ -53,6 +56,9 @@ const (
 	// AzblobTenantID represents the Azure Directory (tenant) ID for the application using AAD credtentials in related secret
 	AzblobTenantID = "AZURE_TENANT_ID"
 
+	// AzblobSASToken represents the Azure SAS token of the storage account or the container using SAS credential in related secret
+	AzblobSASToken = "AZURE_STORAGE_SAS_TOKEN"
+
 	// BackupManagerEnvVarPrefix represents the environment variable used for tidb-backup-manager must include this prefix
 	BackupManagerEnvVarPrefix = "BACKUP_MANAGER"
 
@@ -95,6 +95,10 @@ func (rm *restoreManager) syncRestoreJob(restore *v1alpha1.Restore) error {
 
 		tikvImage := tc.TiKVImage()
 		err = backuputil.ValidateRestore(restore, tikvImage)
+		// the SST files are not written to or read from the storage by TiKV for volume snapshots
+		if err == nil && restore.Spec.Local != nil && restore.Spec.Mode != v1alpha1.RestoreModeVolumeSnapshot {
+			err = backuputil.ValidateLocalStorageOfTiKV(restore.Spec.Local, tc)
+		}
 	}
 
 	if err != nil {
@@ -188,6 +192,15 @@ func (rm *restoreManager) syncRestoreJob(restore *v1alpha1.Restore) error {
 	}
 
 	if err := rm.deps.JobControl.CreateJob(restore, job); err != nil {
+		if resource, ok := controller.QuotaExceededResource(err); ok {
+			rm.statusUpdater.Update(restore, &v1alpha1.RestoreCondition{
+				Type:    v1alpha1.RestoreQuotaExceeded,
+				Status:  corev1.ConditionTrue,
+				Reason:  "CreateRestoreJobRejected",
+				Message: fmt.Sprintf("create job %s is rejected by %s", restoreJobName, resource),
+			}, nil)
+			return controller.RequeueErrorf("create restore %s/%s job %s is rejected by %s, err: %v", ns, name, restoreJobName, resource, err)
+		}
 		errMsg := fmt.Errorf("create restore %s/%s job %s failed, err: %v", ns, name, restoreJobName, err)
 		rm.statusUpdater.Update(restore, &v1alpha1.RestoreCondition{
 			Type:    v1alpha1.RestoreRetryFailed,
@@ -411,16 +424,16 @@ func (rm *restoreManager) volumeSnapshotRestore(r *v1alpha1.Restore, tc *v1alpha
 			return "", nil
 		}
 
-		s, reason, err := snapshotter.NewSnapshotterForRestore(r.Spec.Mode, rm.deps)
-		if err != nil {
-			return reason, err
-		}
 		// setRestoreVolumeID for all PVs, and reset PVC/PVs,
 		// then commit all PVC/PVs for TiKV restore volumes
 		csb, reason, err := rm.readRestoreMetaFromExternalStorage(r)
 		if err != nil {
 			return reason, err
 		}
+		s, reason, err := snapshotter.NewSnapshotterForRestore(r.Spec.Mode, rm.deps, csb)
+		if err != nil {
+			return reason, err
+		}
 
 		if reason, err := s.PrepareRestoreMetadata(r, csb); err != nil {
 			return reason, err
@@ -460,6 +473,7 @@ func (rm *restoreManager) makeImportJob(restore *v1alpha1.Restore) (*batchv1.Job
 	}
 
 	envVars = append(envVars, storageEnv...)
+	envVars = append(envVars, rm.deps.CLIConfig.ProxyEnvVars(backuputil.GetTiDBHostClusterProxy(restore.Spec.To.Host, ns, rm.deps.TiDBClusterLister))...)
 	// set env vars specified in backup.Spec.Env
 	envVars = util.AppendOverwriteEnv(envVars, restore.Spec.Env)
 
@@ -538,7 +552,7 @@ func (rm *restoreManager) makeImportJob(restore *v1alpha1.Restore) (*batchv1.Job
 			Containers: []corev1.Container{
 				{
 					Name:            label.RestoreJobLabelVal,
-					Image:           rm.deps.CLIConfig.TiDBBackupManagerImage,
+					Image:           rm.deps.CLIConfig.GetTiDBBackupManagerImage(),
 					Args:            args,
 					ImagePullPolicy: corev1.PullIfNotPresent,
 					VolumeMounts: append([]corev1.VolumeMount{
@@ -552,6 +566,7 @@ func (rm *restoreManager) makeImportJob(restore *v1alpha1.Restore) (*batchv1.Job
 			Tolerations:      restore.Spec.Tolerations,
 			ImagePullSecrets: restore.Spec.ImagePullSecrets,
 			Affinity:         restore.Spec.Affinity,
+			NodeSelector:     restore.Spec.NodeSelector,
 			Volumes: append([]corev1.Volume{
 				{
 					Name: label.RestoreJobLabelVal,
@@ -618,6 +633,7 @@ func (rm *restoreManager) makeRestoreJob(restore *v1alpha1.Restore) (*batchv1.Jo
 		Name:  "BR_LOG_TO_TERM",
 		Value: string(rune(1)),
 	})
+	envVars = append(envVars, rm.deps.CLIConfig.ProxyEnvVars(tc.Spec.Proxy)...)
 	// set env vars specified in backup.Spec.Env
 	envVars = util.AppendOverwriteEnv(envVars, restore.Spec.Env)
 
@@ -747,7 +763,7 @@ func (rm *restoreManager) makeRestoreJob(restore *v1alpha1.Restore) (*batchv1.Jo
 			Containers: []corev1.Container{
 				{
 					Name:            label.RestoreJobLabelVal,
-					Image:           rm.deps.CLIConfig.TiDBBackupManagerImage,
+					Image:           rm.deps.CLIConfig.GetTiDBBackupManagerImage(),
 					Args:            args,
 					ImagePullPolicy: corev1.PullIfNotPresent,
 					VolumeMounts:    volumeMounts,
@@ -759,6 +775,7 @@ func (rm *restoreManager) makeRestoreJob(restore *v1alpha1.Restore) (*batchv1.Jo
 			Tolerations:       restore.Spec.Tolerations,
 			ImagePullSecrets:  restore.Spec.ImagePullSecrets,
 			Affinity:          restore.Spec.Affinity,
+			NodeSelector:      restore.Spec.NodeSelector,
 			Volumes:           volumes,
 			PriorityClassName: restore.Spec.PriorityClassName,
 		},
@@ -775,7 +792,8 @@ func (rm *restoreManager) makeRestoreJob(restore *v1alpha1.Restore) (*batchv1.Jo
 			},
 		},
 		Spec: batchv1.JobSpec{
-			BackoffLimit: pointer.Int32Ptr(0),
+			// the failed pod is recreated to resume the restore from the checkpoint
+			BackoffLimit: pointer.Int32Ptr(restore.GetMaxAttempts() - 1),
 			Template:     *podSpec,
 		},
 	}
@@ -234,6 +234,26 @@ func TestBRRestore(t *testing.T) {
 	}
 }
 
+func TestBRRestoreWithCheckpoint(t *testing.T) {
+	g := NewGomegaWithT(t)
+	helper := newHelper(t)
+	defer helper.Close()
+
+	restore := genValidBRRestores()[0]
+	restore.Spec.Checkpoint = &v1alpha1.RestoreCheckpoint{MaxAttempts: pointer.Int32Ptr(5)}
+	helper.createRestore(restore)
+	helper.CreateSecret(restore)
+	helper.CreateTC(restore.Spec.BR.ClusterNamespace, restore.Spec.BR.Cluster)
+
+	m := NewRestoreManager(helper.Deps)
+	g.Expect(m.Sync(restore)).Should(Succeed())
+	helper.hasCondition(restore.Namespace, restore.Name, v1alpha1.RestoreScheduled, "")
+	job, err := helper.Deps.KubeClientset.BatchV1().Jobs(restore.Namespace).Get(context.TODO(), restore.GetRestoreJobName(), metav1.GetOptions{})
+	g.Expect(err).Should(BeNil())
+	// the pod is recreated by the job for each of the other attempts
+	g.Expect(*job.Spec.BackoffLimit).Should(Equal(int32(4)))
+}
+
 func TestBRRestoreByEBS(t *testing.T) {
 	g := NewGomegaWithT(t)
 	helper := newHelper(t)
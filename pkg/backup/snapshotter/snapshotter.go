@@ -29,6 +29,7 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/uuid"
 	"k8s.io/klog/v2"
 )
@@ -68,13 +69,26 @@ func (s *BaseSnapshotter) Init(deps *controller.Dependencies, conf map[string]st
 	return nil
 }
 
-func NewSnapshotterForBackup(m v1alpha1.BackupMode, d *controller.Dependencies) (Snapshotter, string, error) {
+// NewSnapshotterForBackup returns the snapshotter of the backup mode, the cloud provider of a volume snapshot
+// backup is inferred from the PVs of the TiKV of tc.
+func NewSnapshotterForBackup(m v1alpha1.BackupMode, d *controller.Dependencies, tc *v1alpha1.TidbCluster) (Snapshotter, string, error) {
 	var s Snapshotter
 	switch m {
 	case v1alpha1.BackupModeVolumeSnapshot:
-		// Currently, we only support aws volume snapshot. If gcp volume snapshot is supported
-		// in the future, we can infer the provider from the storage class.
-		s = &AWSSnapshotter{}
+		var pvs []*corev1.PersistentVolume
+		if d != nil && tc != nil {
+			sel, err := label.New().Instance(tc.Name).Namespace(tc.Namespace).TiKV().Selector()
+			if err != nil {
+				return nil, fmt.Sprintf("unexpected error generating pv label selector: %v", err), err
+			}
+			if pvs, err = d.PVLister.List(sel); err != nil {
+				return nil, fmt.Sprintf("failed to fetch pvs %s:%s", label.ComponentLabelKey, label.TiKVLabelVal), err
+			}
+		}
+		var err error
+		if s, err = newVolumeSnapshotter(pvs); err != nil {
+			return nil, "UnsupportedVolumeSnapshot", err
+		}
 	default:
 		s = &NoneSnapshotter{}
 	}
@@ -86,13 +100,20 @@ func NewSnapshotterForBackup(m v1alpha1.BackupMode, d *controller.Dependencies)
 	return s, "", nil
 }
 
-func NewSnapshotterForRestore(m v1alpha1.RestoreMode, d *controller.Dependencies) (Snapshotter, string, error) {
+// NewSnapshotterForRestore returns the snapshotter of the restore mode, the cloud provider of a volume snapshot
+// restore is inferred from the PVs recorded in the backup metadata csb.
+func NewSnapshotterForRestore(m v1alpha1.RestoreMode, d *controller.Dependencies, csb *CloudSnapBackup) (Snapshotter, string, error) {
 	var s Snapshotter
 	switch m {
 	case v1alpha1.RestoreModeVolumeSnapshot:
-		// Currently, we only support aws volume snapshot. If gcp volume snapshot is supported
-		// in the future, we can infer the provider from the storage class.
-		s = &AWSSnapshotter{}
+		var pvs []*corev1.PersistentVolume
+		if csb != nil && csb.Kubernetes != nil {
+			pvs = csb.Kubernetes.PVs
+		}
+		var err error
+		if s, err = newVolumeSnapshotter(pvs); err != nil {
+			return nil, "UnsupportedVolumeSnapshot", err
+		}
 	default:
 		s = &NoneSnapshotter{}
 	}
@@ -104,6 +125,22 @@ func NewSnapshotterForRestore(m v1alpha1.RestoreMode, d *controller.Dependencies
 	return s, "", nil
 }
 
+// newVolumeSnapshotter returns the snapshotter of the cloud provider of the volumes, which is inferred from
+// the CSI driver or the in-tree volume source of the PVs. AWS EBS is used if there are no PVs.
+func newVolumeSnapshotter(pvs []*corev1.PersistentVolume) (Snapshotter, error) {
+	for _, pv := range pvs {
+		switch {
+		case pv.Spec.CSI != nil && pv.Spec.CSI.Driver == constants.EbsCSIDriver, pv.Spec.AWSElasticBlockStore != nil:
+			return &AWSSnapshotter{}, nil
+		case pv.Spec.CSI != nil && pv.Spec.CSI.Driver == constants.PdCSIDriver, pv.Spec.GCEPersistentDisk != nil:
+			return &GCPSnapshotter{}, nil
+		case pv.Spec.CSI != nil:
+			return nil, fmt.Errorf("volume snapshot is not supported for CSI driver %s of pv %s", pv.Spec.CSI.Driver, pv.Name)
+		}
+	}
+	return &AWSSnapshotter{}, nil
+}
+
 func (s *BaseSnapshotter) PrepareCSBK8SMeta(csb *CloudSnapBackup, tc *v1alpha1.TidbCluster) ([]*corev1.Pod, string, error) {
 	if s.deps == nil {
 		return nil, "NotExistDependencies", fmt.Errorf("unexpected error for nil dependencies")
@@ -484,6 +521,8 @@ func (m *StoresMixture) ProcessCSBPVCsAndPVs(r *v1alpha1.Restore, csb *CloudSnap
 		// Reset the PV's binding status so that Kubernetes can properly
 		// associate it with the restored PVC.
 		resetVolumeBindingInfo(pvc, pv)
+		// Remap the PV's zone before the volumeID is set, as the volumeID may contain the zone
+		remapPVZone(pv, r.Spec.TopologyMapping)
 		// Reset the PV's volumeID for restore from snapshot
 		if err := m.snapshotter.SetVolumeID(pv, restoreVolID); err != nil {
 			return "ResetRestoreVolumeIDFailed", fmt.Errorf("failed to set pv-%s, %s", pv.Name, err.Error())
@@ -587,6 +626,60 @@ func resetPVCSequence(stsName string, pvcs []*corev1.PersistentVolumeClaim, pvs
 	return sequentialPVCs, sequentialPVs, nil
 }
 
+// zoneTopologyKeys are the label keys and node affinity keys of the zone of PVs
+var zoneTopologyKeys = sets.NewString(
+	corev1.LabelZoneFailureDomainStable,
+	corev1.LabelZoneFailureDomain,
+	"topology.ebs.csi.aws.com/zone",
+	"topology.gke.io/zone",
+)
+
+// GetBackupTopology returns the topology of the backup cluster, the zones are collected from the TiKV PVs
+func GetBackupTopology(tc *v1alpha1.TidbCluster, pvs []*corev1.PersistentVolume) *v1alpha1.BackupTopology {
+	zones := sets.NewString()
+	for _, pv := range pvs {
+		for key, value := range pv.Labels {
+			if zoneTopologyKeys.Has(key) && value != "" {
+				zones.Insert(value)
+			}
+		}
+	}
+	return &v1alpha1.BackupTopology{
+		ClusterDomain: tc.Spec.ClusterDomain,
+		Zones:         zones.List(),
+	}
+}
+
+// remapPVZone remaps the zone labels and the zone node affinity of the PV by the topology mapping,
+// the zones not in the mapping are kept
+func remapPVZone(pv *corev1.PersistentVolume, mapping map[string]string) {
+	if len(mapping) == 0 {
+		return
+	}
+	for key, value := range pv.Labels {
+		if target, ok := mapping[value]; ok && zoneTopologyKeys.Has(key) {
+			pv.Labels[key] = target
+		}
+	}
+	if pv.Spec.NodeAffinity == nil || pv.Spec.NodeAffinity.Required == nil {
+		return
+	}
+	terms := pv.Spec.NodeAffinity.Required.NodeSelectorTerms
+	for i := range terms {
+		for j := range terms[i].MatchExpressions {
+			expr := &terms[i].MatchExpressions[j]
+			if !zoneTopologyKeys.Has(expr.Key) {
+				continue
+			}
+			for k, value := range expr.Values {
+				if target, ok := mapping[value]; ok {
+					expr.Values[k] = target
+				}
+			}
+		}
+	}
+}
+
 func (m *StoresMixture) generateRestoreVolumeIDMap(stores []*StoresBackup) {
 	vols := []*VolumeBackup{}
 	for _, store := range stores {
@@ -454,7 +454,7 @@ func TestGenerateBackupMetadata(t *testing.T) {
 
 	for _, tt := range cases {
 		t.Run(tt.name, func(t *testing.T) {
-			s, _, err := NewSnapshotterForBackup(tt.backup.Spec.Mode, deps)
+			s, _, err := NewSnapshotterForBackup(tt.backup.Spec.Mode, deps, tc)
 			require.NoError(t, err)
 			_, _, err = s.GenerateBackupMetadata(tt.backup, tc)
 			if tt.wantErr {
@@ -839,6 +839,54 @@ func TestSetVolumeIDForCSI(t *testing.T) {
 	}
 }
 
+func TestNewVolumeSnapshotter(t *testing.T) {
+	csiPV := func(driver string) *corev1.PersistentVolume {
+		return &corev1.PersistentVolume{
+			ObjectMeta: metav1.ObjectMeta{Name: "pv-" + driver},
+			Spec: corev1.PersistentVolumeSpec{
+				PersistentVolumeSource: corev1.PersistentVolumeSource{
+					CSI: &corev1.CSIPersistentVolumeSource{Driver: driver},
+				},
+			},
+		}
+	}
+
+	cases := []struct {
+		name    string
+		pvs     []*corev1.PersistentVolume
+		want    Snapshotter
+		wantErr bool
+	}{
+		{name: "no pvs", want: &AWSSnapshotter{}},
+		{name: "ebs csi", pvs: []*corev1.PersistentVolume{csiPV(constants.EbsCSIDriver)}, want: &AWSSnapshotter{}},
+		{name: "pd csi", pvs: []*corev1.PersistentVolume{csiPV(constants.PdCSIDriver)}, want: &GCPSnapshotter{}},
+		{
+			name: "in-tree gce pd",
+			pvs: []*corev1.PersistentVolume{{
+				Spec: corev1.PersistentVolumeSpec{
+					PersistentVolumeSource: corev1.PersistentVolumeSource{
+						GCEPersistentDisk: &corev1.GCEPersistentDiskVolumeSource{PDName: "pd"},
+					},
+				},
+			}},
+			want: &GCPSnapshotter{},
+		},
+		{name: "unsupported csi", pvs: []*corev1.PersistentVolume{csiPV("local.csi.example.com")}, wantErr: true},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := newVolumeSnapshotter(tt.pvs)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.IsType(t, tt.want, s)
+		})
+	}
+}
+
 func TestPrepareRestoreMetadata(t *testing.T) {
 	helper := newHelper(t)
 	defer helper.Close()
@@ -858,7 +906,7 @@ func TestPrepareRestoreMetadata(t *testing.T) {
 		},
 	}
 
-	s, _, err := NewSnapshotterForRestore(restore.Spec.Mode, deps)
+	s, _, err := NewSnapshotterForRestore(restore.Spec.Mode, deps, nil)
 	require.NoError(t, err)
 
 	// missing .annotation["tidb.pingcap.com/backup-cloud-snapshot"] as metadata
@@ -2026,3 +2074,56 @@ func TestResetPVCSequence(t *testing.T) {
 	}
 
 }
+
+func TestGetBackupTopology(t *testing.T) {
+	tc := &v1alpha1.TidbCluster{}
+	tc.Spec.ClusterDomain = "cluster-1.local"
+	pvs := []*corev1.PersistentVolume{
+		{ObjectMeta: metav1.ObjectMeta{Name: "pv-0", Labels: map[string]string{corev1.LabelZoneFailureDomainStable: "us-west-2b"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "pv-1", Labels: map[string]string{corev1.LabelZoneFailureDomain: "us-west-2a"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "pv-2", Labels: map[string]string{corev1.LabelZoneFailureDomainStable: "us-west-2a"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "pv-3"}},
+	}
+	topology := GetBackupTopology(tc, pvs)
+	assert.Equal(t, &v1alpha1.BackupTopology{
+		ClusterDomain: "cluster-1.local",
+		Zones:         []string{"us-west-2a", "us-west-2b"},
+	}, topology)
+}
+
+func TestRemapPVZone(t *testing.T) {
+	pv := &corev1.PersistentVolume{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "pv-0",
+			Labels: map[string]string{
+				corev1.LabelZoneFailureDomain: "us-west-2a",
+				label.InstanceLabelKey:        "us-west-2a",
+			},
+		},
+		Spec: corev1.PersistentVolumeSpec{
+			NodeAffinity: &corev1.VolumeNodeAffinity{
+				Required: &corev1.NodeSelector{
+					NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+						MatchExpressions: []corev1.NodeSelectorRequirement{
+							{Key: "topology.ebs.csi.aws.com/zone", Operator: corev1.NodeSelectorOpIn, Values: []string{"us-west-2a"}},
+							{Key: corev1.LabelHostname, Operator: corev1.NodeSelectorOpIn, Values: []string{"us-west-2a"}},
+						},
+					}},
+				},
+			},
+		},
+	}
+	remapPVZone(pv, map[string]string{"us-west-2a": "us-east-1c"})
+
+	assert.Equal(t, "us-east-1c", pv.Labels[corev1.LabelZoneFailureDomain])
+	assert.Equal(t, "us-west-2a", pv.Labels[label.InstanceLabelKey])
+	exprs := pv.Spec.NodeAffinity.Required.NodeSelectorTerms[0].MatchExpressions
+	assert.Equal(t, []string{"us-east-1c"}, exprs[0].Values)
+	assert.Equal(t, []string{"us-west-2a"}, exprs[1].Values)
+
+	// the AWS in-tree volume ID follows the remapped zone
+	pv.Spec.AWSElasticBlockStore = &corev1.AWSElasticBlockStoreVolumeSource{}
+	s := &AWSSnapshotter{}
+	require.NoError(t, s.SetVolumeID(pv, "vol-0123"))
+	assert.Equal(t, "aws://us-east-1c/vol-0123", pv.Spec.AWSElasticBlockStore.VolumeID)
+}
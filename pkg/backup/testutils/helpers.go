@@ -135,6 +135,8 @@ func (h *Helper) CreateTC(namespace, clusterName string) {
 			TiKV: &v1alpha1.TiKVSpec{
 				BaseImage: "pingcap/tikv",
 				Replicas:  3,
+				// the local storage of GenValidStorageProviders is shared with TiKV
+				AdditionalVolumeMounts: []corev1.VolumeMount{{Name: "nfs", MountPath: "/some/path"}},
 			},
 			TiDB: &v1alpha1.TiDBSpec{
 				TLSClient: &v1alpha1.TiDBTLSClient{Enabled: true},
@@ -26,6 +26,7 @@ import (
 
 	"cloud.google.com/go/storage"
 	"github.com/Azure/azure-storage-blob-go/azblob"
+	"github.com/Azure/go-autorest/autorest/adal"
 	"github.com/Azure/go-autorest/autorest/azure/auth"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/credentials"
@@ -52,6 +53,8 @@ import (
 const (
 	maxRetries         = 3 // number of retries to make of operations
 	defaultStorageFlag = "storage"
+	// azblobResource is the resource of the OAuth2 tokens to access the azure blob storage
+	azblobResource = "https://storage.azure.com/"
 )
 
 type StorageCredential struct {
@@ -510,6 +513,18 @@ type azblobSharedKeyCred struct {
 	sharedKey string
 }
 
+// Azure Blob Storage using SAS token credentials
+type azblobSASCred struct {
+	account  string
+	sasToken string
+}
+
+// Azure Blob Storage using the managed identity, clientID is set for a user-assigned identity
+type azblobMSICred struct {
+	account  string
+	clientID string
+}
+
 // newAzblobStorage initialize a new azblob storage
 func newAzblobStorage(conf *azblobConfig) (*blob.Bucket, error) {
 	account := os.Getenv("AZURE_STORAGE_ACCOUNT")
@@ -525,31 +540,38 @@ func newAzblobStorage(conf *azblobConfig) (*blob.Bucket, error) {
 	// Azure shared key with access to the storage account
 	accountKey := os.Getenv("AZURE_STORAGE_KEY")
 
-	// check condition for using AAD credentials first
-	var usingAAD bool
-	if len(clientID) != 0 && len(clientSecret) != 0 && len(tenantID) != 0 {
-		usingAAD = true
-	} else if len(accountKey) != 0 {
-		usingAAD = false
-	} else {
-		return nil, errors.New("Missing necessary key(s) for credentials")
-	}
+	// Azure SAS token with access to the storage account or the container
+	sasToken := os.Getenv("AZURE_STORAGE_SAS_TOKEN")
 
-	// initialize a new azblob storage using AAD or shared key credentials
+	// initialize a new azblob storage using AAD, shared key or SAS token credentials in order,
+	// the managed identity is used if none of them is given
 	var bucket *blob.Bucket
 	var err error
-	if usingAAD {
+	switch {
+	case len(clientID) != 0 && len(clientSecret) != 0 && len(tenantID) != 0:
 		bucket, err = newAzblobStorageUsingAAD(conf, &azblobAADCred{
 			account:      account,
 			clientID:     clientID,
 			clientSecret: clientSecret,
 			tenantID:     tenantID,
 		})
-	} else {
+	case len(clientSecret) != 0 || len(tenantID) != 0:
+		return nil, errors.New("Missing necessary key(s) for AAD credentials")
+	case len(accountKey) != 0:
 		bucket, err = newAzblobStorageUsingSharedKey(conf, &azblobSharedKeyCred{
 			account:   account,
 			sharedKey: accountKey,
 		})
+	case len(sasToken) != 0:
+		bucket, err = newAzblobStorageUsingSAS(conf, &azblobSASCred{
+			account:  account,
+			sasToken: sasToken,
+		})
+	default:
+		bucket, err = newAzblobStorageUsingMSI(conf, &azblobMSICred{
+			account:  account,
+			clientID: clientID,
+		})
 	}
 	if err != nil {
 		return nil, err
@@ -566,7 +588,7 @@ func newAzblobStorageUsingAAD(conf *azblobConfig, cred *azblobAADCred) (*blob.Bu
 	ccc := auth.NewClientCredentialsConfig(cred.clientID, cred.clientSecret, cred.tenantID)
 
 	// Set the target resource to the Azure storage.
-	ccc.Resource = "https://storage.azure.com/"
+	ccc.Resource = azblobResource
 	token, err := ccc.ServicePrincipalToken()
 	if err != nil {
 		return nil, err
@@ -610,6 +632,56 @@ func newAzblobStorageUsingSharedKey(conf *azblobConfig, cred *azblobSharedKeyCre
 	return azureblob.OpenBucket(ctx, pipeline, accountName, conf.container, &azureblob.Options{Credential: credential})
 }
 
+// newAzblobStorageUsingSAS initialize a new azblob storage using SAS token credentials
+func newAzblobStorageUsingSAS(conf *azblobConfig, cred *azblobSASCred) (*blob.Bucket, error) {
+	ctx := context.Background()
+
+	// Azure Storage Account.
+	accountName := azureblob.AccountName(cred.account)
+
+	// The SAS token is appended to the requests, so the anonymous credential is used.
+	pipeline := azureblob.NewPipeline(azblob.NewAnonymousCredential(), azblob.PipelineOptions{})
+
+	// Create a *blob.Bucket.
+	return azureblob.OpenBucket(ctx, pipeline, accountName, conf.container, &azureblob.Options{SASToken: azureblob.SASToken(cred.sasToken)})
+}
+
+// newAzblobStorageUsingMSI initialize a new azblob storage using the managed identity
+func newAzblobStorageUsingMSI(conf *azblobConfig, cred *azblobMSICred) (*blob.Bucket, error) {
+	// Azure Storage Account.
+	accountName := azureblob.AccountName(cred.account)
+
+	// Get an Oauth2 token of the managed identity for use with Azure Storage.
+	msiEndpoint, err := adal.GetMSIVMEndpoint()
+	if err != nil {
+		return nil, err
+	}
+	var token *adal.ServicePrincipalToken
+	if len(cred.clientID) != 0 {
+		token, err = adal.NewServicePrincipalTokenFromMSIWithUserAssignedID(msiEndpoint, azblobResource, cred.clientID)
+	} else {
+		token, err = adal.NewServicePrincipalTokenFromMSI(msiEndpoint, azblobResource)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Refresh OAuth2 token.
+	if err := token.RefreshWithContext(context.Background()); err != nil {
+		return nil, err
+	}
+
+	// Create the credential using the OAuth2 token.
+	credential := azblob.NewTokenCredential(token.OAuthToken(), nil)
+
+	// Create a Pipeline, using whatever PipelineOptions you need.
+	pipeline := azureblob.NewPipeline(credential, azblob.PipelineOptions{})
+
+	// Create a *blob.Bucket.
+	ctx := context.Background()
+	return azureblob.OpenBucket(ctx, pipeline, accountName, conf.container, new(azureblob.Options))
+}
+
 // newGcsStorageOption constructs the arg for --flag option and the remote path for br
 func newGcsStorageOptionForFlag(conf *gcsConfig, flag string) []string {
 	var gcsoptions []string
@@ -644,6 +716,10 @@ func newAzblobStorageOptionForFlag(conf *azblobConfig, flag string) []string {
 	if conf.accessTier != "" {
 		azblobOptions = append(azblobOptions, fmt.Sprintf("--azblob.access-tier=%s", conf.accessTier))
 	}
+	// BR only accepts the SAS token by the flag, the env is set in the backup-manager pod
+	if sasToken := os.Getenv(constants.AzblobSASToken); sasToken != "" {
+		azblobOptions = append(azblobOptions, fmt.Sprintf("--azblob.sas-token=%s", sasToken))
+	}
 	return azblobOptions
 }
 
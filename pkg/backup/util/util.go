@@ -27,19 +27,26 @@ import (
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
 	"github.com/pingcap/tidb-operator/pkg/apis/util/config"
 	"github.com/pingcap/tidb-operator/pkg/backup/constants"
+	listers "github.com/pingcap/tidb-operator/pkg/client/listers/pingcap/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/wait"
 	corelisterv1 "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/util/retry"
 	"k8s.io/klog/v2"
+	"k8s.io/utils/pointer"
 )
 
+// tidbServiceSuffix is the suffix of the name of the TiDB service of a TidbCluster
+const tidbServiceSuffix = "-tidb"
+
 var (
 	// the first version which allows skipping setting tikv_gc_life_time
 	// https://github.com/pingcap/br/pull/553
 	tikvLessThanV408, _ = semver.NewConstraint("<v4.0.8-0")
 	// the first version which supports log backup
 	tikvLessThanV610, _ = semver.NewConstraint("<v6.1.0-0")
+	// the first version which supports the checkpoint restore
+	tikvLessThanV710, _ = semver.NewConstraint("<v7.1.0-0")
 )
 
 // CheckAllKeysExistInSecret check if all keys are included in the specific secret
@@ -135,6 +142,16 @@ func generateS3CertEnvVar(s3 *v1alpha1.S3StorageProvider, useKMS bool) ([]corev1
 					},
 				},
 			},
+			{
+				Name: "AWS_SESSION_TOKEN",
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: s3.SecretName},
+						Key:                  constants.S3SessionToken,
+						Optional:             pointer.BoolPtr(true),
+					},
+				},
+			},
 		}...)
 	}
 
@@ -182,8 +199,45 @@ func generateGcsCertEnvVar(gcs *v1alpha1.GcsStorageProvider) ([]corev1.EnvVar, s
 	return envVars, "", nil
 }
 
+// azblobAuth is the way to authenticate to the azure blob storage
+type azblobAuth string
+
+const (
+	// azblobAuthAAD uses the client secret of an AAD application
+	azblobAuthAAD azblobAuth = "AAD"
+	// azblobAuthSharedKey uses the access key of the storage account
+	azblobAuthSharedKey azblobAuth = "SharedKey"
+	// azblobAuthSAS uses a SAS token of the storage account or the container
+	azblobAuthSAS azblobAuth = "SAS"
+	// azblobAuthManagedIdentity uses the managed identity of the pod, AZURE_CLIENT_ID is set for a user-assigned identity
+	azblobAuthManagedIdentity azblobAuth = "ManagedIdentity"
+)
+
+// getAzblobAuth returns the way to authenticate according to the keys of the azblob secret, the missing
+// keys are returned if the AAD credentials are partially given.
+func getAzblobAuth(secret *corev1.Secret) (azblobAuth, string, bool) {
+	keyStrAAD, exist := CheckAllKeysExistInSecret(secret, constants.AzblobClientID, constants.AzblobClientScrt, constants.AzblobTenantID)
+	if exist {
+		return azblobAuthAAD, "", true
+	}
+	if _, exist := CheckAllKeysExistInSecret(secret, constants.AzblobAccountKey); exist {
+		return azblobAuthSharedKey, "", true
+	}
+	if _, exist := CheckAllKeysExistInSecret(secret, constants.AzblobSASToken); exist {
+		return azblobAuthSAS, "", true
+	}
+	// only AZURE_CLIENT_ID is allowed for the managed identity
+	if _, exist := secret.Data[constants.AzblobClientScrt]; exist {
+		return "", keyStrAAD, false
+	}
+	if _, exist := secret.Data[constants.AzblobTenantID]; exist {
+		return "", keyStrAAD, false
+	}
+	return azblobAuthManagedIdentity, "", true
+}
+
 // generateAzblobCertEnvVar generate the env info in order to access azure blob storage
-func generateAzblobCertEnvVar(azblob *v1alpha1.AzblobStorageProvider, useAAD bool) ([]corev1.EnvVar, string, error) {
+func generateAzblobCertEnvVar(azblob *v1alpha1.AzblobStorageProvider, auth azblobAuth) ([]corev1.EnvVar, string, error) {
 	if len(azblob.AccessTier) == 0 {
 		azblob.AccessTier = "Cool"
 	}
@@ -193,61 +247,44 @@ func generateAzblobCertEnvVar(azblob *v1alpha1.AzblobStorageProvider, useAAD boo
 			Value: azblob.AccessTier,
 		},
 	}
-	if azblob.SecretName != "" {
-		envVars = append(envVars, []corev1.EnvVar{
-			{
-				Name: "AZURE_STORAGE_ACCOUNT",
-				ValueFrom: &corev1.EnvVarSource{
-					SecretKeyRef: &corev1.SecretKeySelector{
-						LocalObjectReference: corev1.LocalObjectReference{Name: azblob.SecretName},
-						Key:                  constants.AzblobAccountName,
-					},
-				},
-			},
-		}...)
-		if useAAD {
-			envVars = append(envVars, []corev1.EnvVar{
-				{
-					Name: "AZURE_CLIENT_ID",
-					ValueFrom: &corev1.EnvVarSource{
-						SecretKeyRef: &corev1.SecretKeySelector{
-							LocalObjectReference: corev1.LocalObjectReference{Name: azblob.SecretName},
-							Key:                  constants.AzblobClientID,
-						},
-					},
-				},
-				{
-					Name: "AZURE_CLIENT_SECRET",
-					ValueFrom: &corev1.EnvVarSource{
-						SecretKeyRef: &corev1.SecretKeySelector{
-							LocalObjectReference: corev1.LocalObjectReference{Name: azblob.SecretName},
-							Key:                  constants.AzblobClientScrt,
-						},
-					},
-				},
-				{
-					Name: "AZURE_TENANT_ID",
-					ValueFrom: &corev1.EnvVarSource{
-						SecretKeyRef: &corev1.SecretKeySelector{
-							LocalObjectReference: corev1.LocalObjectReference{Name: azblob.SecretName},
-							Key:                  constants.AzblobTenantID,
-						},
-					},
-				},
-			}...)
-		} else {
-			envVars = append(envVars, []corev1.EnvVar{
-				{
-					Name: "AZURE_STORAGE_KEY",
-					ValueFrom: &corev1.EnvVarSource{
-						SecretKeyRef: &corev1.SecretKeySelector{
-							LocalObjectReference: corev1.LocalObjectReference{Name: azblob.SecretName},
-							Key:                  constants.AzblobAccountKey,
-						},
-					},
-				},
-			}...)
+	if azblob.StorageAccount != "" {
+		envVars = append(envVars, corev1.EnvVar{
+			Name:  "AZURE_STORAGE_ACCOUNT",
+			Value: azblob.StorageAccount,
+		})
+	}
+	if azblob.SecretName == "" {
+		return envVars, "", nil
+	}
+
+	secretEnvVar := func(name, key string, optional bool) corev1.EnvVar {
+		ref := &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: azblob.SecretName},
+			Key:                  key,
 		}
+		if optional {
+			ref.Optional = &optional
+		}
+		return corev1.EnvVar{Name: name, ValueFrom: &corev1.EnvVarSource{SecretKeyRef: ref}}
+	}
+	if azblob.StorageAccount == "" {
+		envVars = append(envVars, secretEnvVar("AZURE_STORAGE_ACCOUNT", constants.AzblobAccountName, false))
+	}
+	switch auth {
+	case azblobAuthAAD:
+		envVars = append(envVars,
+			secretEnvVar("AZURE_CLIENT_ID", constants.AzblobClientID, false),
+			secretEnvVar("AZURE_CLIENT_SECRET", constants.AzblobClientScrt, false),
+			secretEnvVar("AZURE_TENANT_ID", constants.AzblobTenantID, false),
+		)
+	case azblobAuthSharedKey:
+		envVars = append(envVars, secretEnvVar("AZURE_STORAGE_KEY", constants.AzblobAccountKey, false))
+	case azblobAuthSAS:
+		envVars = append(envVars, secretEnvVar("AZURE_STORAGE_SAS_TOKEN", constants.AzblobSASToken, false))
+	case azblobAuthManagedIdentity:
+		envVars = append(envVars, secretEnvVar("AZURE_CLIENT_ID", constants.AzblobClientID, true))
+	default:
+		return envVars, "UnsupportedAzblobAuth", fmt.Errorf("unsupported azblob auth %s", auth)
 	}
 	return envVars, "", nil
 }
@@ -302,7 +339,7 @@ func GenerateStorageCertEnv(ns string, useKMS bool, provider v1alpha1.StoragePro
 			return certEnv, reason, err
 		}
 	case v1alpha1.BackupStorageTypeAzblob:
-		useAAD := true
+		auth := azblobAuthManagedIdentity
 		azblobSecretName := provider.Azblob.SecretName
 		if azblobSecretName != "" {
 			secret, err := secretLister.Secrets(ns).Get(azblobSecretName)
@@ -311,18 +348,23 @@ func GenerateStorageCertEnv(ns string, useKMS bool, provider v1alpha1.StoragePro
 				return certEnv, "GetAzblobSecretFailed", err
 			}
 
-			keyStrAAD, exist := CheckAllKeysExistInSecret(secret, constants.AzblobAccountName, constants.AzblobClientID, constants.AzblobClientScrt, constants.AzblobTenantID)
-			if !exist {
-				keyStrShared, exist := CheckAllKeysExistInSecret(secret, constants.AzblobAccountName, constants.AzblobAccountKey)
+			if provider.Azblob.StorageAccount == "" {
+				keyStr, exist := CheckAllKeysExistInSecret(secret, constants.AzblobAccountName)
 				if !exist {
-					err := fmt.Errorf("the azblob secret %s/%s missing some keys for AAD %s or shared %s", ns, azblobSecretName, keyStrAAD, keyStrShared)
+					err := fmt.Errorf("the azblob secret %s/%s missing some keys %s and storageAccount is not set", ns, azblobSecretName, keyStr)
 					return certEnv, "azblobKeyNotExist", err
 				}
-				useAAD = false
+			}
+			var keyStr string
+			var ok bool
+			auth, keyStr, ok = getAzblobAuth(secret)
+			if !ok {
+				err := fmt.Errorf("the azblob secret %s/%s missing some keys for AAD %s", ns, azblobSecretName, keyStr)
+				return certEnv, "azblobKeyNotExist", err
 			}
 		}
 
-		certEnv, reason, err = generateAzblobCertEnvVar(provider.Azblob, useAAD)
+		certEnv, reason, err = generateAzblobCertEnvVar(provider.Azblob, auth)
 
 		if err != nil {
 			return certEnv, reason, err
@@ -388,6 +430,8 @@ func GetBackupBucketName(backup *v1alpha1.Backup) (string, string, error) {
 		bucketName = backup.Spec.S3.Bucket
 	case v1alpha1.BackupStorageTypeGcs:
 		bucketName = backup.Spec.Gcs.Bucket
+	case v1alpha1.BackupStorageTypeAzblob:
+		bucketName = backup.Spec.Azblob.Container
 	default:
 		return bucketName, "UnsupportedStorageType", fmt.Errorf("backup %s/%s unsupported storage type %s", ns, name, storageType)
 	}
@@ -406,6 +450,8 @@ func GetBackupPrefixName(backup *v1alpha1.Backup) (string, string, error) {
 		prefix = backup.Spec.S3.Prefix
 	case v1alpha1.BackupStorageTypeGcs:
 		prefix = backup.Spec.Gcs.Prefix
+	case v1alpha1.BackupStorageTypeAzblob:
+		prefix = backup.Spec.Azblob.Prefix
 	default:
 		return prefix, "UnsupportedStorageType", fmt.Errorf("backup %s/%s unsupported storage type %s", ns, name, storageType)
 	}
@@ -440,6 +486,8 @@ func GetBackupDataPath(provider v1alpha1.StorageProvider) (string, string, error
 		backupPath = provider.S3.Path
 	case v1alpha1.BackupStorageTypeGcs:
 		backupPath = provider.Gcs.Path
+	case v1alpha1.BackupStorageTypeAzblob:
+		backupPath = provider.Azblob.Path
 	default:
 		return backupPath, "UnsupportedStorageType", fmt.Errorf("unsupported storage type %s", storageType)
 	}
@@ -471,6 +519,11 @@ func ValidateBackup(backup *v1alpha1.Backup, tikvImage string) error {
 	ns := backup.Namespace
 	name := backup.Name
 
+	if backup.Spec.S3 != nil && backup.Spec.S3.UseCredentialsBroker {
+		if err := validateCredentialsBroker(backup); err != nil {
+			return fmt.Errorf("%v in spec of %s/%s", err, ns, name)
+		}
+	}
 	if backup.Spec.BR == nil {
 		if reason := validateAccessConfig(backup.Spec.From); reason != "" {
 			return fmt.Errorf(reason, ns, name)
@@ -478,6 +531,9 @@ func ValidateBackup(backup *v1alpha1.Backup, tikvImage string) error {
 		if backup.Spec.StorageSize == "" {
 			return fmt.Errorf("missing StorageSize config in spec of %s/%s", ns, name)
 		}
+		if backup.Spec.Local != nil {
+			return fmt.Errorf("local storage is only supported by BR in spec of %s/%s", ns, name)
+		}
 	} else {
 		if !canSkipSetGCLifeTime(tikvImage) {
 			if reason := validateAccessConfig(backup.Spec.From); reason != "" {
@@ -512,6 +568,10 @@ func ValidateBackup(backup *v1alpha1.Backup, tikvImage string) error {
 			if err := validateGcs(ns, name, backup.Spec.Gcs); err != nil {
 				return err
 			}
+		} else if backup.Spec.Azblob != nil {
+			if err := validateAzblob(ns, name, backup.Spec.Azblob); err != nil {
+				return err
+			}
 		} else if backup.Spec.Local != nil {
 			if err := validateLocal(ns, name, backup.Spec.Local); err != nil {
 				return err
@@ -559,6 +619,22 @@ func ValidateRestore(restore *v1alpha1.Restore, tikvImage string) error {
 	ns := restore.Namespace
 	name := restore.Name
 
+	if restore.Spec.S3 != nil && restore.Spec.S3.UseCredentialsBroker {
+		return fmt.Errorf("useCredentialsBroker is only supported by backups in spec of %s/%s", ns, name)
+	}
+	if err := validateTableFilterRules(restore.Spec.TableFilters); err != nil {
+		return fmt.Errorf("invalid tableFilters in spec of %s/%s: %v", ns, name, err)
+	}
+	if len(restore.Spec.RenameRules) > 0 && restore.Spec.BR != nil {
+		return fmt.Errorf("renameRules is not supported by BR in spec of %s/%s", ns, name)
+	}
+	if err := validateRenameRules(restore.Spec.RenameRules); err != nil {
+		return fmt.Errorf("invalid renameRules in spec of %s/%s: %v", ns, name, err)
+	}
+	if restore.Spec.Lightning != nil && restore.Spec.BR != nil {
+		return fmt.Errorf("lightning is not supported by BR in spec of %s/%s", ns, name)
+	}
+
 	if restore.Spec.BR == nil {
 		if reason := validateAccessConfig(restore.Spec.To); reason != "" {
 			return fmt.Errorf(reason, ns, name)
@@ -566,6 +642,9 @@ func ValidateRestore(restore *v1alpha1.Restore, tikvImage string) error {
 		if restore.Spec.StorageSize == "" {
 			return fmt.Errorf("missing StorageSize config in spec of %s/%s", ns, name)
 		}
+		if restore.Spec.Local != nil {
+			return fmt.Errorf("local storage is only supported by BR in spec of %s/%s", ns, name)
+		}
 	} else {
 		if !canSkipSetGCLifeTime(tikvImage) {
 			if reason := validateAccessConfig(restore.Spec.To); reason != "" {
@@ -591,6 +670,18 @@ func ValidateRestore(restore *v1alpha1.Restore, tikvImage string) error {
 			return fmt.Errorf("table should be configured for BR with restore type table in spec of %s/%s", ns, name)
 		}
 
+		if restore.Spec.Checkpoint != nil {
+			if restore.Spec.Mode == v1alpha1.RestoreModeVolumeSnapshot {
+				return fmt.Errorf("checkpoint is not supported by the volume-snapshot restore in spec of %s/%s", ns, name)
+			}
+			if restore.Spec.Checkpoint.MaxAttempts != nil && *restore.Spec.Checkpoint.MaxAttempts < 1 {
+				return fmt.Errorf("maxAttempts of checkpoint should be at least 1 in spec of %s/%s", ns, name)
+			}
+			if !isCheckpointRestoreSupported(tikvImage) {
+				return fmt.Errorf("checkpoint is only supported by BR v7.1.0 or later in spec of %s/%s", ns, name)
+			}
+		}
+
 		// validate storage providers
 		if restore.Spec.S3 != nil {
 			if err := validateS3(ns, name, restore.Spec.S3); err != nil {
@@ -600,6 +691,10 @@ func ValidateRestore(restore *v1alpha1.Restore, tikvImage string) error {
 			if err := validateGcs(ns, name, restore.Spec.Gcs); err != nil {
 				return err
 			}
+		} else if restore.Spec.Azblob != nil {
+			if err := validateAzblob(ns, name, restore.Spec.Azblob); err != nil {
+				return err
+			}
 		} else if restore.Spec.Local != nil {
 			if err := validateLocal(ns, name, restore.Spec.Local); err != nil {
 				return err
@@ -609,6 +704,80 @@ func ValidateRestore(restore *v1alpha1.Restore, tikvImage string) error {
 	return nil
 }
 
+// validateTableFilterName checks the name pattern in a table filter rule, the
+// characters having special meanings in the table filter syntax are not allowed
+func validateTableFilterName(pattern string) error {
+	if strings.ContainsAny(pattern, ". \t\n") {
+		return fmt.Errorf("%q should not contain '.' or whitespaces", pattern)
+	}
+	if strings.HasPrefix(pattern, "!") || strings.HasPrefix(pattern, "/") || strings.HasPrefix(pattern, "@") {
+		return fmt.Errorf("%q should not start with '!', '/' or '@'", pattern)
+	}
+	return nil
+}
+
+func validateTableFilterRules(rules []v1alpha1.TableFilterRule) error {
+	for i, rule := range rules {
+		if rule.Database == "" {
+			return fmt.Errorf("database of rule %d should not be empty", i)
+		}
+		if err := validateTableFilterName(rule.Database); err != nil {
+			return fmt.Errorf("database of rule %d: %v", i, err)
+		}
+		if err := validateTableFilterName(rule.Table); err != nil {
+			return fmt.Errorf("table of rule %d: %v", i, err)
+		}
+	}
+	return nil
+}
+
+func validateRenameRules(rules []v1alpha1.RenameRule) error {
+	froms := map[string]struct{}{}
+	for i, rule := range rules {
+		if rule.FromDatabase == "" || rule.ToDatabase == "" {
+			return fmt.Errorf("fromDatabase and toDatabase of rule %d should not be empty", i)
+		}
+		if rule.FromTable == "" && rule.ToTable != "" {
+			return fmt.Errorf("toTable of rule %d should be empty if fromTable is empty", i)
+		}
+		if rule.FromTable != "" && rule.ToTable == "" {
+			return fmt.Errorf("toTable of rule %d should not be empty if fromTable is set", i)
+		}
+		for _, pattern := range []string{rule.FromDatabase, rule.FromTable} {
+			if err := validateTableFilterName(pattern); err != nil {
+				return fmt.Errorf("rule %d: %v", i, err)
+			}
+		}
+		for _, target := range []string{rule.ToDatabase, rule.ToTable} {
+			if strings.ContainsAny(target, "*?[]") {
+				return fmt.Errorf("rule %d: %q should not contain wildcards", i, target)
+			}
+		}
+		from := rule.FromDatabase + "." + rule.FromTable
+		if _, ok := froms[from]; ok {
+			return fmt.Errorf("rule %d: duplicated rule for %s", i, from)
+		}
+		froms[from] = struct{}{}
+	}
+	return nil
+}
+
+// validateCredentialsBroker checks whether the backup can use the short-lived credentials issued by the broker
+func validateCredentialsBroker(backup *v1alpha1.Backup) error {
+	s3 := backup.Spec.S3
+	if s3.Provider != v1alpha1.S3StorageProviderTypeAWS {
+		return fmt.Errorf("useCredentialsBroker is only supported by the aws provider")
+	}
+	if s3.SecretName != "" {
+		return fmt.Errorf("secretName can't be set with useCredentialsBroker")
+	}
+	// the credentials would expire before the log backup task stops
+	if backup.Spec.Mode == v1alpha1.BackupModeLog {
+		return fmt.Errorf("useCredentialsBroker is not supported by log backups")
+	}
+	return nil
+}
+
 func validateS3(ns, name string, s3 *v1alpha1.S3StorageProvider) error {
 	configuredForBR := fmt.Sprintf("configured for BR in spec of %s/%s", ns, name)
 	if s3.Bucket == "" {
@@ -641,6 +810,14 @@ func validateGcs(ns, name string, gcs *v1alpha1.GcsStorageProvider) error {
 	return nil
 }
 
+func validateAzblob(ns, name string, azblob *v1alpha1.AzblobStorageProvider) error {
+	configuredForBR := fmt.Sprintf("configured for BR in spec of %s/%s", ns, name)
+	if azblob.Container == "" {
+		return fmt.Errorf("container should be %s", configuredForBR)
+	}
+	return nil
+}
+
 func validateLocal(ns, name string, local *v1alpha1.LocalStorageProvider) error {
 	configuredForBR := fmt.Sprintf("configured for BR in spec of %s/%s", ns, name)
 	if local.VolumeMount.Name != local.Volume.Name {
@@ -655,6 +832,33 @@ func validateLocal(ns, name string, local *v1alpha1.LocalStorageProvider) error
 	return nil
 }
 
+// ValidateLocalStorageOfTiKV checks the path of the local storage is mounted into the TiKV pods of the cluster,
+// because BR writes and reads the SST files in the path of the filesystem of each TiKV, so the local storage
+// must be a volume shared by the BR job and all the TiKV pods, e.g. an NFS volume.
+func ValidateLocalStorageOfTiKV(local *v1alpha1.LocalStorageProvider, tc *v1alpha1.TidbCluster) error {
+	if tc.Spec.TiKV == nil {
+		return nil
+	}
+	mountPath := path.Clean(local.VolumeMount.MountPath)
+	// only the additional volumes can be shared, the storage volumes are the PVCs of each TiKV pod
+	// which can't be mounted by the BR job
+	volumes := map[string]struct{}{}
+	for _, v := range tc.Spec.TiKV.AdditionalVolumes {
+		volumes[v.Name] = struct{}{}
+	}
+	for _, m := range tc.Spec.TiKV.AdditionalVolumeMounts {
+		if _, ok := volumes[m.Name]; !ok {
+			continue
+		}
+		p := path.Clean(m.MountPath)
+		if mountPath == p || strings.HasPrefix(mountPath, p+"/") {
+			return nil
+		}
+	}
+	return fmt.Errorf("the local storage path %s is not mounted into the TiKV pods of cluster %s/%s, mount the same volume by spec.tikv.additionalVolumes and spec.tikv.additionalVolumeMounts",
+		mountPath, tc.Namespace, tc.Name)
+}
+
 // ParseImage returns the image name and the tag from the input image string
 func ParseImage(image string) (string, string) {
 	var name, tag string
@@ -709,6 +913,17 @@ func isLogBackSupport(tikvImage string) bool {
 	return true
 }
 
+// isCheckpointRestoreSupported returns whether BR of the tikv version supports the checkpoint restore
+func isCheckpointRestoreSupported(tikvImage string) bool {
+	_, version := ParseImage(tikvImage)
+	v, err := semver.NewVersion(version)
+	if err != nil {
+		klog.Errorf("Parse version %s failure, error: %v", version, err)
+		return true
+	}
+	return !tikvLessThanV710.Check(v)
+}
+
 // GetStorageRestorePath generate the path of a specific storage from Restore
 func GetStoragePath(privoder v1alpha1.StorageProvider) (string, error) {
 	var url, bucket, prefix string
@@ -801,3 +1016,22 @@ func GetVolSnapBackupMetaData(r *v1alpha1.Restore, secretLister corelisterv1.Sec
 	}
 	return backupMeta, nil
 }
+
+// GetTiDBHostClusterProxy returns the proxy of the TidbCluster whose TiDB service is the host accessed by
+// Dumpling or TiDB Lightning, e.g. "<cluster>-tidb" or "<cluster>-tidb.<namespace>.svc", ns is the namespace
+// of the host if it doesn't contain one. It returns nil if the host is not the TiDB service of a TidbCluster,
+// then the proxy of the operator is used.
+func GetTiDBHostClusterProxy(host, ns string, tcLister listers.TidbClusterLister) *v1alpha1.HTTPProxyConfig {
+	parts := strings.Split(host, ".")
+	if !strings.HasSuffix(parts[0], tidbServiceSuffix) {
+		return nil
+	}
+	if len(parts) > 1 {
+		ns = parts[1]
+	}
+	tc, err := tcLister.TidbClusters(ns).Get(strings.TrimSuffix(parts[0], tidbServiceSuffix))
+	if err != nil || tc.Spec.TiDB == nil {
+		return nil
+	}
+	return tc.Spec.Proxy
+}
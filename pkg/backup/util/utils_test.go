@@ -21,10 +21,13 @@ import (
 	. "github.com/onsi/gomega"
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
 	"github.com/pingcap/tidb-operator/pkg/backup/constants"
+	listers "github.com/pingcap/tidb-operator/pkg/client/listers/pingcap/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	kubeinformers "k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/utils/pointer"
 )
 
 func TestCheckAllKeysExistInSecret(t *testing.T) {
@@ -168,13 +171,13 @@ func TestGenerateAzblobCertEnvVar(t *testing.T) {
 	azblob = &v1alpha1.AzblobStorageProvider{
 		AccessTier: "",
 	}
-	envs, _, err := generateAzblobCertEnvVar(azblob, true)
+	envs, _, err := generateAzblobCertEnvVar(azblob, azblobAuthAAD)
 	g.Expect(err).Should(BeNil())
 	contains(envs, "AZURE_ACCESS_TIER", "Cool")
 
 	// test &v1alpha1.AzblobStorageProvider AccessTier set value
 	azblob.AccessTier = "Hot"
-	envs, _, err = generateAzblobCertEnvVar(azblob, true)
+	envs, _, err = generateAzblobCertEnvVar(azblob, azblobAuthAAD)
 	g.Expect(err).Should(BeNil())
 	contains(envs, "AZURE_ACCESS_TIER", "Hot")
 }
@@ -311,10 +314,43 @@ func TestGenerateStorageCertEnv(t *testing.T) {
 			g.Expect(err).Should(BeNil())
 			_, _, err = GenerateStorageCertEnv(ns, false, test.provider, informer.Core().V1().Secrets().Lister())
 			g.Expect(err).Should(BeNil())
+
+			// test SAS token
+			s.Data = map[string][]byte{
+				constants.AzblobAccountName: []byte("dummy"),
+				constants.AzblobSASToken:    []byte("dummy"),
+			}
+			err = informer.Core().V1().Secrets().Informer().GetIndexer().Update(s)
+			g.Expect(err).Should(BeNil())
+			envs, _, err := GenerateStorageCertEnv(ns, false, test.provider, informer.Core().V1().Secrets().Lister())
+			g.Expect(err).Should(BeNil())
+			g.Expect(envNames(envs)).Should(ConsistOf("AZURE_ACCESS_TIER", "AZURE_STORAGE_ACCOUNT", "AZURE_STORAGE_SAS_TOKEN"))
+
+			// test managed identity with the storage account in spec
+			s.Data = map[string][]byte{
+				constants.AzblobClientID: []byte("dummy"),
+			}
+			err = informer.Core().V1().Secrets().Informer().GetIndexer().Update(s)
+			g.Expect(err).Should(BeNil())
+			_, _, err = GenerateStorageCertEnv(ns, false, test.provider, informer.Core().V1().Secrets().Lister())
+			g.Expect(err.Error()).Should(MatchRegexp(".*missing some keys.*"))
+			provider := test.provider.DeepCopy()
+			provider.Azblob.StorageAccount = "account"
+			envs, _, err = GenerateStorageCertEnv(ns, false, *provider, informer.Core().V1().Secrets().Lister())
+			g.Expect(err).Should(BeNil())
+			g.Expect(envNames(envs)).Should(ConsistOf("AZURE_ACCESS_TIER", "AZURE_STORAGE_ACCOUNT", "AZURE_CLIENT_ID"))
 		}
 	}
 }
 
+func envNames(envs []corev1.EnvVar) []string {
+	names := make([]string, 0, len(envs))
+	for _, env := range envs {
+		names = append(names, env.Name)
+	}
+	return names
+}
+
 func TestGenerateTidbPasswordEnv(t *testing.T) {
 	g := NewGomegaWithT(t)
 	ns := "ns"
@@ -490,6 +526,10 @@ func TestValidateBackup(t *testing.T) {
 	backup.Spec.StorageSize = "1m"
 	match("")
 
+	backup.Spec.Local = &v1alpha1.LocalStorageProvider{}
+	match("local storage is only supported by BR")
+	backup.Spec.Local = nil
+
 	// start BR != nil case
 	backup.Spec.BR = &v1alpha1.BRConfig{}
 	match("cluster should be configured for BR in spec")
@@ -550,8 +590,15 @@ func TestValidateRestore(t *testing.T) {
 	restore.Spec.StorageSize = "1m"
 	match("")
 
+	restore.Spec.Local = &v1alpha1.LocalStorageProvider{}
+	match("local storage is only supported by BR")
+	restore.Spec.Local = nil
+
 	// start BR != nil case
 	restore.Spec.BR = &v1alpha1.BRConfig{}
+	restore.Spec.Lightning = &v1alpha1.LightningConfig{}
+	match("lightning is not supported by BR")
+	restore.Spec.Lightning = nil
 	match("cluster should be configured for BR in spec")
 
 	restore.Spec.BR.Cluster = "tidb"
@@ -581,6 +628,90 @@ func TestValidateRestore(t *testing.T) {
 
 	restore.Spec.S3.Endpoint = "s3://localhost:80"
 	match("")
+
+	// checkpoint
+	restore.Spec.Checkpoint = &v1alpha1.RestoreCheckpoint{MaxAttempts: pointer.Int32Ptr(0)}
+	match("maxAttempts of checkpoint should be at least 1")
+
+	restore.Spec.Checkpoint.MaxAttempts = pointer.Int32Ptr(3)
+	match("checkpoint is only supported by BR v7.1.0 or later")
+
+	g.Expect(ValidateRestore(restore, "tikv:v7.1.0")).Should(Succeed())
+
+	restore.Spec.Mode = v1alpha1.RestoreModeVolumeSnapshot
+	match("checkpoint is not supported by the volume-snapshot restore")
+
+	restore.Spec.Mode = ""
+	restore.Spec.Checkpoint = nil
+
+	// table filter and rename rules
+	restore.Spec.TableFilters = []v1alpha1.TableFilterRule{{Table: "t"}}
+	match("database of rule 0 should not be empty")
+
+	restore.Spec.TableFilters = []v1alpha1.TableFilterRule{{Database: "db.t"}}
+	match("should not contain '.'")
+
+	restore.Spec.TableFilters = []v1alpha1.TableFilterRule{{Database: "!db"}}
+	match("should not start with")
+
+	restore.Spec.TableFilters = []v1alpha1.TableFilterRule{{Database: "db*", Table: "t?", Exclude: true}}
+	match("")
+
+	restore.Spec.RenameRules = []v1alpha1.RenameRule{{FromDatabase: "db", ToDatabase: "db_new"}}
+	match("renameRules is not supported by BR")
+
+	restore.Spec.BR = nil
+	match("")
+
+	restore.Spec.RenameRules = []v1alpha1.RenameRule{{FromDatabase: "db", FromTable: "t"}}
+	match("fromDatabase and toDatabase of rule 0 should not be empty")
+
+	restore.Spec.RenameRules = []v1alpha1.RenameRule{{FromDatabase: "db", FromTable: "t", ToDatabase: "db_new"}}
+	match("toTable of rule 0 should not be empty")
+
+	restore.Spec.RenameRules = []v1alpha1.RenameRule{{FromDatabase: "db", ToDatabase: "db_new", ToTable: "t"}}
+	match("toTable of rule 0 should be empty")
+
+	restore.Spec.RenameRules = []v1alpha1.RenameRule{{FromDatabase: "db", ToDatabase: "db_*"}}
+	match("should not contain wildcards")
+
+	restore.Spec.RenameRules = []v1alpha1.RenameRule{
+		{FromDatabase: "db", ToDatabase: "db_new"},
+		{FromDatabase: "db", ToDatabase: "db_new2"},
+	}
+	match("duplicated rule")
+
+	restore.Spec.RenameRules = []v1alpha1.RenameRule{
+		{FromDatabase: "db", ToDatabase: "db_new"},
+		{FromDatabase: "db", FromTable: "t*", ToDatabase: "db_new", ToTable: "t"},
+	}
+	match("")
+}
+
+func TestValidateLocalStorageOfTiKV(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	local := &v1alpha1.LocalStorageProvider{
+		VolumeMount: corev1.VolumeMount{Name: "nfs", MountPath: "/nfs/backup"},
+	}
+	tc := &v1alpha1.TidbCluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "tc"},
+		Spec:       v1alpha1.TidbClusterSpec{TiKV: &v1alpha1.TiKVSpec{}},
+	}
+	err := ValidateLocalStorageOfTiKV(local, tc)
+	g.Expect(err).Should(HaveOccurred())
+	g.Expect(err.Error()).Should(ContainSubstring("is not mounted into the TiKV pods"))
+
+	tc.Spec.TiKV.AdditionalVolumes = []corev1.Volume{{Name: "nfs"}}
+	tc.Spec.TiKV.AdditionalVolumeMounts = []corev1.VolumeMount{{Name: "nfs", MountPath: "/nfs-data"}}
+	g.Expect(ValidateLocalStorageOfTiKV(local, tc)).ShouldNot(Succeed())
+
+	tc.Spec.TiKV.AdditionalVolumeMounts = []corev1.VolumeMount{{Name: "nfs", MountPath: "/nfs/"}}
+	g.Expect(ValidateLocalStorageOfTiKV(local, tc)).Should(Succeed())
+
+	// the mount of a storage volume isn't shared with the BR job
+	tc.Spec.TiKV.AdditionalVolumes = nil
+	g.Expect(ValidateLocalStorageOfTiKV(local, tc)).ShouldNot(Succeed())
 }
 
 func TestGetImageTag(t *testing.T) {
@@ -640,3 +771,21 @@ func TestGetImageTag(t *testing.T) {
 		})
 	}
 }
+
+func TestGetTiDBHostClusterProxy(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	proxy := &v1alpha1.HTTPProxyConfig{HTTPSProxy: "http://proxy.example.com:3128"}
+	g.Expect(indexer.Add(&v1alpha1.TidbCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "basic", Namespace: "tidb"},
+		Spec:       v1alpha1.TidbClusterSpec{TiDB: &v1alpha1.TiDBSpec{}, Proxy: proxy},
+	})).To(Succeed())
+	tcLister := listers.NewTidbClusterLister(indexer)
+
+	g.Expect(GetTiDBHostClusterProxy("basic-tidb", "tidb", tcLister)).To(Equal(proxy))
+	g.Expect(GetTiDBHostClusterProxy("basic-tidb.tidb.svc.cluster.local", "backup", tcLister)).To(Equal(proxy))
+	g.Expect(GetTiDBHostClusterProxy("basic-tidb", "backup", tcLister)).To(BeNil())
+	g.Expect(GetTiDBHostClusterProxy("basic-pd.tidb", "tidb", tcLister)).To(BeNil())
+	g.Expect(GetTiDBHostClusterProxy("10.0.0.1", "tidb", tcLister)).To(BeNil())
+}
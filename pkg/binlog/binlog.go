@@ -24,6 +24,7 @@ import (
 
 	"github.com/pingcap/errors"
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	httputil "github.com/pingcap/tidb-operator/pkg/util/http"
 	"go.etcd.io/etcd/clientv3"
 	"google.golang.org/grpc"
 )
@@ -65,6 +66,7 @@ func NewBinlogClient(pdEndpoint []string, tlsConfig *tls.Config, timeout time.Du
 		httpClient: &http.Client{
 			Timeout: timeout,
 			Transport: &http.Transport{
+				Proxy:             httputil.Proxy,
 				TLSClientConfig:   tlsConfig,
 				DisableKeepAlives: true,
 			},
@@ -37,6 +37,10 @@ func (c *FakePingcapV1alpha1) DMClusters(namespace string) v1alpha1.DMClusterInt
 	return &FakeDMClusters{c, namespace}
 }
 
+func (c *FakePingcapV1alpha1) DMTasks(namespace string) v1alpha1.DMTaskInterface {
+	return &FakeDMTasks{c, namespace}
+}
+
 func (c *FakePingcapV1alpha1) DataResources(namespace string) v1alpha1.DataResourceInterface {
 	return &FakeDataResources{c, namespace}
 }
@@ -53,6 +57,22 @@ func (c *FakePingcapV1alpha1) TidbClusterAutoScalers(namespace string) v1alpha1.
 	return &FakeTidbClusterAutoScalers{c, namespace}
 }
 
+func (c *FakePingcapV1alpha1) TidbClusterClaims(namespace string) v1alpha1.TidbClusterClaimInterface {
+	return &FakeTidbClusterClaims{c, namespace}
+}
+
+func (c *FakePingcapV1alpha1) TidbClusterFleets(namespace string) v1alpha1.TidbClusterFleetInterface {
+	return &FakeTidbClusterFleets{c, namespace}
+}
+
+func (c *FakePingcapV1alpha1) TidbClusterSecretGrants(namespace string) v1alpha1.TidbClusterSecretGrantInterface {
+	return &FakeTidbClusterSecretGrants{c, namespace}
+}
+
+func (c *FakePingcapV1alpha1) TidbClusterTemplates(namespace string) v1alpha1.TidbClusterTemplateInterface {
+	return &FakeTidbClusterTemplates{c, namespace}
+}
+
 func (c *FakePingcapV1alpha1) TidbDashboards(namespace string) v1alpha1.TidbDashboardInterface {
 	return &FakeTidbDashboards{c, namespace}
 }
@@ -21,6 +21,8 @@ type BackupScheduleExpansion interface{}
 
 type DMClusterExpansion interface{}
 
+type DMTaskExpansion interface{}
+
 type DataResourceExpansion interface{}
 
 type RestoreExpansion interface{}
@@ -29,6 +31,14 @@ type TidbClusterExpansion interface{}
 
 type TidbClusterAutoScalerExpansion interface{}
 
+type TidbClusterClaimExpansion interface{}
+
+type TidbClusterFleetExpansion interface{}
+
+type TidbClusterSecretGrantExpansion interface{}
+
+type TidbClusterTemplateExpansion interface{}
+
 type TidbDashboardExpansion interface{}
 
 type TidbInitializerExpansion interface{}
@@ -26,10 +26,15 @@ type PingcapV1alpha1Interface interface {
 	BackupsGetter
 	BackupSchedulesGetter
 	DMClustersGetter
+	DMTasksGetter
 	DataResourcesGetter
 	RestoresGetter
 	TidbClustersGetter
 	TidbClusterAutoScalersGetter
+	TidbClusterClaimsGetter
+	TidbClusterFleetsGetter
+	TidbClusterSecretGrantsGetter
+	TidbClusterTemplatesGetter
 	TidbDashboardsGetter
 	TidbInitializersGetter
 	TidbMonitorsGetter
@@ -53,6 +58,10 @@ func (c *PingcapV1alpha1Client) DMClusters(namespace string) DMClusterInterface
 	return newDMClusters(c, namespace)
 }
 
+func (c *PingcapV1alpha1Client) DMTasks(namespace string) DMTaskInterface {
+	return newDMTasks(c, namespace)
+}
+
 func (c *PingcapV1alpha1Client) DataResources(namespace string) DataResourceInterface {
 	return newDataResources(c, namespace)
 }
@@ -69,6 +78,22 @@ func (c *PingcapV1alpha1Client) TidbClusterAutoScalers(namespace string) TidbClu
 	return newTidbClusterAutoScalers(c, namespace)
 }
 
+func (c *PingcapV1alpha1Client) TidbClusterClaims(namespace string) TidbClusterClaimInterface {
+	return newTidbClusterClaims(c, namespace)
+}
+
+func (c *PingcapV1alpha1Client) TidbClusterFleets(namespace string) TidbClusterFleetInterface {
+	return newTidbClusterFleets(c, namespace)
+}
+
+func (c *PingcapV1alpha1Client) TidbClusterSecretGrants(namespace string) TidbClusterSecretGrantInterface {
+	return newTidbClusterSecretGrants(c, namespace)
+}
+
+func (c *PingcapV1alpha1Client) TidbClusterTemplates(namespace string) TidbClusterTemplateInterface {
+	return newTidbClusterTemplates(c, namespace)
+}
+
 func (c *PingcapV1alpha1Client) TidbDashboards(namespace string) TidbDashboardInterface {
 	return newTidbDashboards(c, namespace)
 }
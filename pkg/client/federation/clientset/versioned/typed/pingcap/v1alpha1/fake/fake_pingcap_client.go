@@ -25,6 +25,10 @@ type FakeFederationV1alpha1 struct {
 	*testing.Fake
 }
 
+func (c *FakeFederationV1alpha1) TidbClusterFederations(namespace string) v1alpha1.TidbClusterFederationInterface {
+	return &FakeTidbClusterFederations{c, namespace}
+}
+
 func (c *FakeFederationV1alpha1) VolumeBackups(namespace string) v1alpha1.VolumeBackupInterface {
 	return &FakeVolumeBackups{c, namespace}
 }
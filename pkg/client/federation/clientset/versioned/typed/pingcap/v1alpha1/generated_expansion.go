@@ -15,6 +15,8 @@
 
 package v1alpha1
 
+type TidbClusterFederationExpansion interface{}
+
 type VolumeBackupExpansion interface{}
 
 type VolumeBackupScheduleExpansion interface{}
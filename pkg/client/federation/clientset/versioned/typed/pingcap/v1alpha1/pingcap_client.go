@@ -23,6 +23,7 @@ import (
 
 type FederationV1alpha1Interface interface {
 	RESTClient() rest.Interface
+	TidbClusterFederationsGetter
 	VolumeBackupsGetter
 	VolumeBackupSchedulesGetter
 	VolumeRestoresGetter
@@ -33,6 +34,10 @@ type FederationV1alpha1Client struct {
 	restClient rest.Interface
 }
 
+func (c *FederationV1alpha1Client) TidbClusterFederations(namespace string) TidbClusterFederationInterface {
+	return newTidbClusterFederations(c, namespace)
+}
+
 func (c *FederationV1alpha1Client) VolumeBackups(namespace string) VolumeBackupInterface {
 	return newVolumeBackups(c, namespace)
 }
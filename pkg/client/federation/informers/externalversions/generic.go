@@ -50,6 +50,8 @@ func (f *genericInformer) Lister() cache.GenericLister {
 func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource) (GenericInformer, error) {
 	switch resource {
 	// Group=federation.pingcap.com, Version=v1alpha1
+	case v1alpha1.SchemeGroupVersion.WithResource("tidbclusterfederations"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Federation().V1alpha1().TidbClusterFederations().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("volumebackups"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Federation().V1alpha1().VolumeBackups().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("volumebackupschedules"):
@@ -21,6 +21,8 @@ import (
 
 // Interface provides access to all the informers in this group version.
 type Interface interface {
+	// TidbClusterFederations returns a TidbClusterFederationInformer.
+	TidbClusterFederations() TidbClusterFederationInformer
 	// VolumeBackups returns a VolumeBackupInformer.
 	VolumeBackups() VolumeBackupInformer
 	// VolumeBackupSchedules returns a VolumeBackupScheduleInformer.
@@ -40,6 +42,11 @@ func New(f internalinterfaces.SharedInformerFactory, namespace string, tweakList
 	return &version{factory: f, namespace: namespace, tweakListOptions: tweakListOptions}
 }
 
+// TidbClusterFederations returns a TidbClusterFederationInformer.
+func (v *version) TidbClusterFederations() TidbClusterFederationInformer {
+	return &tidbClusterFederationInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // VolumeBackups returns a VolumeBackupInformer.
 func (v *version) VolumeBackups() VolumeBackupInformer {
 	return &volumeBackupInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
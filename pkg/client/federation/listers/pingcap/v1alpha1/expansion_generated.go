@@ -15,6 +15,14 @@
 
 package v1alpha1
 
+// TidbClusterFederationListerExpansion allows custom methods to be added to
+// TidbClusterFederationLister.
+type TidbClusterFederationListerExpansion interface{}
+
+// TidbClusterFederationNamespaceListerExpansion allows custom methods to be added to
+// TidbClusterFederationNamespaceLister.
+type TidbClusterFederationNamespaceListerExpansion interface{}
+
 // VolumeBackupListerExpansion allows custom methods to be added to
 // VolumeBackupLister.
 type VolumeBackupListerExpansion interface{}
@@ -27,6 +27,8 @@ type Interface interface {
 	BackupSchedules() BackupScheduleInformer
 	// DMClusters returns a DMClusterInformer.
 	DMClusters() DMClusterInformer
+	// DMTasks returns a DMTaskInformer.
+	DMTasks() DMTaskInformer
 	// DataResources returns a DataResourceInformer.
 	DataResources() DataResourceInformer
 	// Restores returns a RestoreInformer.
@@ -35,6 +37,14 @@ type Interface interface {
 	TidbClusters() TidbClusterInformer
 	// TidbClusterAutoScalers returns a TidbClusterAutoScalerInformer.
 	TidbClusterAutoScalers() TidbClusterAutoScalerInformer
+	// TidbClusterClaims returns a TidbClusterClaimInformer.
+	TidbClusterClaims() TidbClusterClaimInformer
+	// TidbClusterFleets returns a TidbClusterFleetInformer.
+	TidbClusterFleets() TidbClusterFleetInformer
+	// TidbClusterSecretGrants returns a TidbClusterSecretGrantInformer.
+	TidbClusterSecretGrants() TidbClusterSecretGrantInformer
+	// TidbClusterTemplates returns a TidbClusterTemplateInformer.
+	TidbClusterTemplates() TidbClusterTemplateInformer
 	// TidbDashboards returns a TidbDashboardInformer.
 	TidbDashboards() TidbDashboardInformer
 	// TidbInitializers returns a TidbInitializerInformer.
@@ -71,6 +81,11 @@ func (v *version) DMClusters() DMClusterInformer {
 	return &dMClusterInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// DMTasks returns a DMTaskInformer.
+func (v *version) DMTasks() DMTaskInformer {
+	return &dMTaskInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // DataResources returns a DataResourceInformer.
 func (v *version) DataResources() DataResourceInformer {
 	return &dataResourceInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -91,6 +106,26 @@ func (v *version) TidbClusterAutoScalers() TidbClusterAutoScalerInformer {
 	return &tidbClusterAutoScalerInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// TidbClusterClaims returns a TidbClusterClaimInformer.
+func (v *version) TidbClusterClaims() TidbClusterClaimInformer {
+	return &tidbClusterClaimInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// TidbClusterFleets returns a TidbClusterFleetInformer.
+func (v *version) TidbClusterFleets() TidbClusterFleetInformer {
+	return &tidbClusterFleetInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// TidbClusterSecretGrants returns a TidbClusterSecretGrantInformer.
+func (v *version) TidbClusterSecretGrants() TidbClusterSecretGrantInformer {
+	return &tidbClusterSecretGrantInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// TidbClusterTemplates returns a TidbClusterTemplateInformer.
+func (v *version) TidbClusterTemplates() TidbClusterTemplateInformer {
+	return &tidbClusterTemplateInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // TidbDashboards returns a TidbDashboardInformer.
 func (v *version) TidbDashboards() TidbDashboardInformer {
 	return &tidbDashboardInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -39,6 +39,14 @@ type DMClusterListerExpansion interface{}
 // DMClusterNamespaceLister.
 type DMClusterNamespaceListerExpansion interface{}
 
+// DMTaskListerExpansion allows custom methods to be added to
+// DMTaskLister.
+type DMTaskListerExpansion interface{}
+
+// DMTaskNamespaceListerExpansion allows custom methods to be added to
+// DMTaskNamespaceLister.
+type DMTaskNamespaceListerExpansion interface{}
+
 // DataResourceListerExpansion allows custom methods to be added to
 // DataResourceLister.
 type DataResourceListerExpansion interface{}
@@ -71,6 +79,38 @@ type TidbClusterAutoScalerListerExpansion interface{}
 // TidbClusterAutoScalerNamespaceLister.
 type TidbClusterAutoScalerNamespaceListerExpansion interface{}
 
+// TidbClusterClaimListerExpansion allows custom methods to be added to
+// TidbClusterClaimLister.
+type TidbClusterClaimListerExpansion interface{}
+
+// TidbClusterClaimNamespaceListerExpansion allows custom methods to be added to
+// TidbClusterClaimNamespaceLister.
+type TidbClusterClaimNamespaceListerExpansion interface{}
+
+// TidbClusterFleetListerExpansion allows custom methods to be added to
+// TidbClusterFleetLister.
+type TidbClusterFleetListerExpansion interface{}
+
+// TidbClusterFleetNamespaceListerExpansion allows custom methods to be added to
+// TidbClusterFleetNamespaceLister.
+type TidbClusterFleetNamespaceListerExpansion interface{}
+
+// TidbClusterSecretGrantListerExpansion allows custom methods to be added to
+// TidbClusterSecretGrantLister.
+type TidbClusterSecretGrantListerExpansion interface{}
+
+// TidbClusterSecretGrantNamespaceListerExpansion allows custom methods to be added to
+// TidbClusterSecretGrantNamespaceLister.
+type TidbClusterSecretGrantNamespaceListerExpansion interface{}
+
+// TidbClusterTemplateListerExpansion allows custom methods to be added to
+// TidbClusterTemplateLister.
+type TidbClusterTemplateListerExpansion interface{}
+
+// TidbClusterTemplateNamespaceListerExpansion allows custom methods to be added to
+// TidbClusterTemplateNamespaceLister.
+type TidbClusterTemplateNamespaceListerExpansion interface{}
+
 // TidbDashboardListerExpansion allows custom methods to be added to
 // TidbDashboardLister.
 type TidbDashboardListerExpansion interface{}
@@ -0,0 +1,73 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// ASTSProblem is an incompatibility of Advanced StatefulSet with this build of the operator
+type ASTSProblem struct {
+	// Namespace and Cluster are the TidbCluster affected, they're empty if all the TidbClusters are affected
+	Namespace string
+	Cluster   string
+	Message   string
+}
+
+func (p ASTSProblem) String() string {
+	if p.Cluster == "" {
+		return p.Message
+	}
+	return fmt.Sprintf("%s/%s: %s", p.Namespace, p.Cluster, p.Message)
+}
+
+// FormatASTSProblems formats the problems in one message
+func FormatASTSProblems(problems []ASTSProblem) string {
+	msgs := make([]string, 0, len(problems))
+	for _, p := range problems {
+		msgs = append(msgs, p.String())
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// ASTSCompatibility records the result of the latest compatibility check of Advanced StatefulSet,
+// which runs periodically if the AdvancedStatefulSet feature is enabled.
+type ASTSCompatibility struct {
+	lock     sync.RWMutex
+	checked  bool
+	problems []ASTSProblem
+}
+
+// Set records the result of a check
+func (c *ASTSCompatibility) Set(problems []ASTSProblem) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.checked = true
+	c.problems = problems
+}
+
+// Get returns whether it has been checked and the messages of the problems affecting the TidbCluster
+func (c *ASTSCompatibility) Get(ns, tcName string) (bool, []string) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	var msgs []string
+	for _, p := range c.problems {
+		if p.Cluster == "" || (p.Namespace == ns && p.Cluster == tcName) {
+			msgs = append(msgs, p.Message)
+		}
+	}
+	return c.checked, msgs
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/pingcap/tidb-operator/pkg/client/clientset/versioned"
 	informers "github.com/pingcap/tidb-operator/pkg/client/informers/externalversions/pingcap/v1alpha1"
 	listers "github.com/pingcap/tidb-operator/pkg/client/listers/pingcap/v1alpha1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/client-go/tools/cache"
@@ -40,6 +41,10 @@ type BackupUpdateStatus struct {
 	TimeStarted *metav1.Time
 	// TimeCompleted is the time at which the backup was completed.
 	TimeCompleted *metav1.Time
+	// TimeQueued is the time at which the scheduled backup was queued.
+	TimeQueued *metav1.Time
+	// QueueDuration is how long the scheduled backup was queued.
+	QueueDuration *string
 	// BackupSizeReadable is the data size of the backup.
 	// the difference with BackupSize is that its format is human readable
 	BackupSizeReadable *string
@@ -72,6 +77,8 @@ type BackupUpdateStatus struct {
 	RetryReason *string
 	// OriginalReason is the original reason of backup job or pod failed
 	OriginalReason *string
+	// Topology is the topology of the backup cluster.
+	Topology *v1alpha1.BackupTopology
 }
 
 // BackupConditionUpdaterInterface enables updating Backup conditions.
@@ -150,6 +157,18 @@ func updateBackupStatus(status *v1alpha1.BackupStatus, newStatus *BackupUpdateSt
 		status.TimeCompleted = *newStatus.TimeCompleted
 		isUpdate = true
 	}
+	if newStatus.TimeQueued != nil && status.TimeQueued != *newStatus.TimeQueued {
+		status.TimeQueued = *newStatus.TimeQueued
+		isUpdate = true
+	}
+	if newStatus.QueueDuration != nil && status.QueueDuration != *newStatus.QueueDuration {
+		status.QueueDuration = *newStatus.QueueDuration
+		isUpdate = true
+	}
+	if newStatus.Topology != nil && !apiequality.Semantic.DeepEqual(status.Topology, newStatus.Topology) {
+		status.Topology = newStatus.Topology
+		isUpdate = true
+	}
 	if newStatus.BackupSizeReadable != nil && status.BackupSizeReadable != *newStatus.BackupSizeReadable {
 		status.BackupSizeReadable = *newStatus.BackupSizeReadable
 		isUpdate = true
@@ -50,20 +50,23 @@ type BrFedDependencies struct {
 	Recorder                       record.EventRecorder
 
 	// Listers
-	VolumeBackupLister         listers.VolumeBackupLister
-	VolumeRestoreLister        listers.VolumeRestoreLister
-	VolumeBackupScheduleLister listers.VolumeBackupScheduleLister
+	VolumeBackupLister          listers.VolumeBackupLister
+	VolumeRestoreLister         listers.VolumeRestoreLister
+	VolumeBackupScheduleLister  listers.VolumeBackupScheduleLister
+	TidbClusterFederationLister listers.TidbClusterFederationLister
 
 	// Controls
 	BrFedControls
 
 	// FedClientset is the clientset for the federation clusters
 	FedClientset map[string]*fedversioned.Clientset
+	// FedKubeClientset is the Kubernetes clientset for the federation clusters
+	FedKubeClientset map[string]kubernetes.Interface
 }
 
 // NewBrFedDependencies is used to construct the dependencies
 func NewBrFedDependencies(cliCfg *BrFedCLIConfig, clientset versioned.Interface, kubeClientset kubernetes.Interface,
-	genericCli client.Client, fedClientset map[string]*fedversioned.Clientset) *BrFedDependencies {
+	genericCli client.Client, fedClientset map[string]*fedversioned.Clientset, fedKubeClientset map[string]kubernetes.Interface) *BrFedDependencies {
 	tweakListOptionsFunc := func(options *metav1.ListOptions) {
 		if len(options.LabelSelector) > 0 {
 			options.LabelSelector += ",app.kubernetes.io/managed-by=tidb-operator"
@@ -85,7 +88,7 @@ func NewBrFedDependencies(cliCfg *BrFedCLIConfig, clientset versioned.Interface,
 		Interface: eventv1.New(kubeClientset.CoreV1().RESTClient()).Events("")})
 	recorder := eventBroadcaster.NewRecorder(v1alpha1.Scheme, corev1.EventSource{Component: "br-federation-manager"})
 
-	deps := newBrFedDependencies(cliCfg, clientset, kubeClientset, genericCli, informerFactory, kubeInformerFactory, labelFilterKubeInformerFactory, recorder, fedClientset)
+	deps := newBrFedDependencies(cliCfg, clientset, kubeClientset, genericCli, informerFactory, kubeInformerFactory, labelFilterKubeInformerFactory, recorder, fedClientset, fedKubeClientset)
 	deps.BrFedControls = newRealBrFedControls(cliCfg, clientset, kubeClientset, genericCli, informerFactory, kubeInformerFactory, recorder)
 	return deps
 }
@@ -99,7 +102,8 @@ func newBrFedDependencies(
 	kubeInformerFactory kubeinformers.SharedInformerFactory,
 	labelFilterKubeInformerFactory kubeinformers.SharedInformerFactory,
 	recorder record.EventRecorder,
-	fedClientset map[string]*fedversioned.Clientset) *BrFedDependencies {
+	fedClientset map[string]*fedversioned.Clientset,
+	fedKubeClientset map[string]kubernetes.Interface) *BrFedDependencies {
 	return &BrFedDependencies{
 		CLIConfig:                      cliCfg,
 		Clientset:                      clientset,
@@ -111,11 +115,13 @@ func newBrFedDependencies(
 		Recorder:                       recorder,
 
 		// Listers
-		VolumeBackupLister:         informerFactory.Federation().V1alpha1().VolumeBackups().Lister(),
-		VolumeRestoreLister:        informerFactory.Federation().V1alpha1().VolumeRestores().Lister(),
-		VolumeBackupScheduleLister: informerFactory.Federation().V1alpha1().VolumeBackupSchedules().Lister(),
+		VolumeBackupLister:          informerFactory.Federation().V1alpha1().VolumeBackups().Lister(),
+		VolumeRestoreLister:         informerFactory.Federation().V1alpha1().VolumeRestores().Lister(),
+		VolumeBackupScheduleLister:  informerFactory.Federation().V1alpha1().VolumeBackupSchedules().Lister(),
+		TidbClusterFederationLister: informerFactory.Federation().V1alpha1().TidbClusterFederations().Lister(),
 
-		FedClientset: fedClientset,
+		FedClientset:     fedClientset,
+		FedKubeClientset: fedKubeClientset,
 	}
 }
 
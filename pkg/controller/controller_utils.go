@@ -20,8 +20,10 @@ import (
 	"regexp"
 
 	"github.com/dustin/go-humanize"
+	perrors "github.com/pingcap/errors"
 	fedv1alpha1 "github.com/pingcap/tidb-operator/pkg/apis/federation/pingcap/v1alpha1"
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/metrics"
 	"github.com/pingcap/tidb-operator/pkg/scheme"
 	"github.com/pingcap/tidb-operator/pkg/util"
 	corev1 "k8s.io/api/core/v1"
@@ -66,6 +68,9 @@ var (
 	// tidbDashboardKind contains the schema.GroupVersionKind for TidbDashboard controller type.
 	tidbDashboardKind = v1alpha1.SchemeGroupVersion.WithKind("TidbDashboard")
 
+	// TidbClusterClaimKind contains the schema.GroupVersionKind for TidbClusterClaim controller type.
+	TidbClusterClaimKind = v1alpha1.SchemeGroupVersion.WithKind("TidbClusterClaim")
+
 	// FedVolumeBackupControllerKind contains the schema.GroupVersionKind for federation VolumeBackup controller type.
 	FedVolumeBackupControllerKind = fedv1alpha1.SchemeGroupVersion.WithKind("VolumeBackup")
 
@@ -74,6 +79,9 @@ var (
 
 	// FedVolumeBackupScheduleControllerKind contains the schema.GroupVersionKind for federation VolumeBackupSchedule controller type.
 	FedVolumeBackupScheduleControllerKind = fedv1alpha1.SchemeGroupVersion.WithKind("VolumeBackupSchedule")
+
+	// TidbClusterFederationControllerKind contains the schema.GroupVersionKind for federation TidbClusterFederation controller type.
+	TidbClusterFederationControllerKind = fedv1alpha1.SchemeGroupVersion.WithKind("TidbClusterFederation")
 )
 
 // RequeueError is used to requeue the item, this error type should't be considered as a real error
@@ -116,6 +124,21 @@ func IsIgnoreError(err error) bool {
 	return ok
 }
 
+// ObserveReconcile records the result of a reconciliation of the controller in the metrics,
+// a RequeueError is counted as a requeue and an IgnoreError as a success rather than an error
+func ObserveReconcile(name string, err error) {
+	result := metrics.ReconcileResultSuccess
+	switch {
+	case err == nil || perrors.Find(err, IsIgnoreError) != nil:
+	case perrors.Find(err, IsRequeueError) != nil:
+		result = metrics.ReconcileResultRequeue
+	default:
+		result = metrics.ReconcileResultError
+		metrics.ReconcileErrors.WithLabelValues(name).Inc()
+	}
+	metrics.ReconcileTotal.WithLabelValues(name, result).Inc()
+}
+
 // GetOwnerRef returns TidbCluster's OwnerReference
 func GetOwnerRef(tc *v1alpha1.TidbCluster) metav1.OwnerReference {
 	controller := true
@@ -238,6 +261,20 @@ func GetTiDBDashboardOwnerRef(td *v1alpha1.TidbDashboard) metav1.OwnerReference
 	}
 }
 
+// GetTidbClusterClaimOwnerRef returns TidbClusterClaim's OwnerReference
+func GetTidbClusterClaimOwnerRef(claim *v1alpha1.TidbClusterClaim) metav1.OwnerReference {
+	controller := true
+	blockOwnerDeletion := true
+	return metav1.OwnerReference{
+		APIVersion:         TidbClusterClaimKind.GroupVersion().String(),
+		Kind:               TidbClusterClaimKind.Kind,
+		Name:               claim.GetName(),
+		UID:                claim.GetUID(),
+		Controller:         &controller,
+		BlockOwnerDeletion: &blockOwnerDeletion,
+	}
+}
+
 // GetServiceType returns member's service type
 func GetServiceType(services []v1alpha1.Service, serviceName string) corev1.ServiceType {
 	for _, svc := range services {
@@ -367,6 +404,11 @@ func DiscoveryMemberName(clusterName string) string {
 	return fmt.Sprintf("%s-discovery", clusterName)
 }
 
+// HealthProbeMemberName returns the name of the health probe of tidb cluster
+func HealthProbeMemberName(clusterName string) string {
+	return fmt.Sprintf("%s-health-probe", clusterName)
+}
+
 // DMMasterMemberName returns dm-master member name
 func DMMasterMemberName(clusterName string) string {
 	return fmt.Sprintf("%s-dm-master", clusterName)
@@ -392,6 +434,11 @@ func TiDBInitSecret(clusterName string) string {
 	return fmt.Sprintf("%s-init", clusterName)
 }
 
+// TiDBBindingSecret returns the default name of the tidb binding secret
+func TiDBBindingSecret(clusterName string) string {
+	return fmt.Sprintf("%s-tidb-binding", clusterName)
+}
+
 // AnnProm adds annotations for prometheus scraping metrics
 func AnnProm(port int32, path string) map[string]string {
 	return map[string]string{
@@ -400,6 +447,23 @@ func AnnProm(port int32, path string) map[string]string {
 		"prometheus.io/port":   fmt.Sprintf("%d", port),
 	}
 }
+
+// PropagateMetadata adds the custom labels and annotations to the object, the keys that
+// are already set on the object, e.g. the ones managed by TiDB Operator, are not overridden.
+// It returns true if the object is changed.
+func PropagateMetadata(obj metav1.Object, md v1alpha1.ObjectMetadata) bool {
+	changed := false
+	if labels := util.CombineStringMap(obj.GetLabels(), md.Labels); len(labels) != len(obj.GetLabels()) {
+		obj.SetLabels(labels)
+		changed = true
+	}
+	if annotations := util.CombineStringMap(obj.GetAnnotations(), md.Annotations); len(annotations) != len(obj.GetAnnotations()) {
+		obj.SetAnnotations(annotations)
+		changed = true
+	}
+	return changed
+}
+
 func FormatClusterDomainForRegex(clusterDomain string) string {
 	if clusterDomain == "" {
 		return ""
@@ -23,6 +23,8 @@ import (
 
 	. "github.com/onsi/gomega"
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/metrics"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	apps "k8s.io/api/apps/v1"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -48,6 +50,20 @@ func TestIgnoreError(t *testing.T) {
 	g.Expect(IsIgnoreError(fmt.Errorf("i am not an ignore error"))).To(BeFalse())
 }
 
+func TestObserveReconcile(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	name := "test-observe-reconcile"
+	ObserveReconcile(name, nil)
+	ObserveReconcile(name, IgnoreErrorf("ignored"))
+	ObserveReconcile(name, RequeueErrorf("requeue"))
+	ObserveReconcile(name, fmt.Errorf("failed"))
+	g.Expect(testutil.ToFloat64(metrics.ReconcileTotal.WithLabelValues(name, metrics.ReconcileResultSuccess))).To(Equal(2.0))
+	g.Expect(testutil.ToFloat64(metrics.ReconcileTotal.WithLabelValues(name, metrics.ReconcileResultRequeue))).To(Equal(1.0))
+	g.Expect(testutil.ToFloat64(metrics.ReconcileTotal.WithLabelValues(name, metrics.ReconcileResultError))).To(Equal(1.0))
+	g.Expect(testutil.ToFloat64(metrics.ReconcileErrors.WithLabelValues(name))).To(Equal(1.0))
+}
+
 func TestGetOwnerRef(t *testing.T) {
 	g := NewGomegaWithT(t)
 
@@ -311,6 +327,27 @@ func TestAnnProm(t *testing.T) {
 	g.Expect(ann["prometheus.io/port"]).To(Equal("9090"))
 }
 
+func TestPropagateMetadata(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	md := v1alpha1.ObjectMetadata{
+		Labels:      map[string]string{"team": "db", label.ComponentLabelKey: "user"},
+		Annotations: map[string]string{"owner": "dba"},
+	}
+	svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{
+		Labels: map[string]string{label.ComponentLabelKey: "tidb"},
+	}}
+	g.Expect(PropagateMetadata(svc, md)).To(BeTrue())
+	g.Expect(svc.Labels).To(Equal(map[string]string{"team": "db", label.ComponentLabelKey: "tidb"}))
+	g.Expect(svc.Annotations).To(Equal(map[string]string{"owner": "dba"}))
+	g.Expect(PropagateMetadata(svc, md)).To(BeFalse())
+
+	cm := &corev1.ConfigMap{}
+	g.Expect(PropagateMetadata(cm, v1alpha1.ObjectMetadata{})).To(BeFalse())
+	g.Expect(cm.Labels).To(BeNil())
+	g.Expect(cm.Annotations).To(BeNil())
+}
+
 func TestMemberConfigMapName(t *testing.T) {
 	g := NewGomegaWithT(t)
 
@@ -0,0 +1,207 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+)
+
+// maxRoleSessionNameLength is the max length of the session name of AssumeRole
+const maxRoleSessionNameLength = 64
+
+// StorageCredentials are the short-lived credentials issued for a backup to access its storage
+type StorageCredentials struct {
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+	Expiration      time.Time
+}
+
+// CredentialsBrokerInterface issues the short-lived storage credentials for the backups, so that the users who
+// trigger the backups don't need to hold the long-lived credentials of the buckets
+type CredentialsBrokerInterface interface {
+	// Enabled returns whether the broker is enabled for the operator
+	Enabled() bool
+	// IssueS3Credentials issues the credentials of the backup ns/name, which can only access the bucket and
+	// the prefix of the S3 storage
+	IssueS3Credentials(ns, name string, s3 *v1alpha1.S3StorageProvider) (*StorageCredentials, error)
+}
+
+// defaultCredentialsBroker is default implementation of CredentialsBrokerInterface, it assumes the role of
+// --backup-credentials-broker-role-arn with the credentials of the operator, with a session policy limiting
+// the access to the storage of the backup.
+type defaultCredentialsBroker struct {
+	cliCfg *CLIConfig
+}
+
+// NewDefaultCredentialsBroker returns a defaultCredentialsBroker instance
+func NewDefaultCredentialsBroker(cliCfg *CLIConfig) *defaultCredentialsBroker {
+	return &defaultCredentialsBroker{cliCfg: cliCfg}
+}
+
+func (b *defaultCredentialsBroker) Enabled() bool {
+	return b.cliCfg.BackupCredentialsBrokerRoleARN != ""
+}
+
+func (b *defaultCredentialsBroker) IssueS3Credentials(ns, name string, s3 *v1alpha1.S3StorageProvider) (*StorageCredentials, error) {
+	if !b.Enabled() {
+		return nil, fmt.Errorf("the backup credentials broker is not enabled")
+	}
+	policy, err := s3SessionPolicy(s3)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	var opts []func(*config.LoadOptions) error
+	if s3.Region != "" {
+		opts = append(opts, config.WithRegion(s3.Region))
+	}
+	cfg, err := config.LoadDefaultConfig(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("can't load aws config: %w", err)
+	}
+	out, err := sts.NewFromConfig(cfg).AssumeRole(ctx, &sts.AssumeRoleInput{
+		RoleArn:         aws.String(b.cliCfg.BackupCredentialsBrokerRoleARN),
+		RoleSessionName: aws.String(roleSessionName(ns, name)),
+		DurationSeconds: aws.Int32(int32(b.cliCfg.BackupCredentialsBrokerDuration.Seconds())),
+		Policy:          aws.String(policy),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("assume role %s for backup %s/%s failed: %w", b.cliCfg.BackupCredentialsBrokerRoleARN, ns, name, err)
+	}
+	if out.Credentials == nil {
+		return nil, fmt.Errorf("assume role %s for backup %s/%s returns no credentials", b.cliCfg.BackupCredentialsBrokerRoleARN, ns, name)
+	}
+	return &StorageCredentials{
+		AccessKeyID:     aws.ToString(out.Credentials.AccessKeyId),
+		SecretAccessKey: aws.ToString(out.Credentials.SecretAccessKey),
+		SessionToken:    aws.ToString(out.Credentials.SessionToken),
+		Expiration:      aws.ToTime(out.Credentials.Expiration),
+	}, nil
+}
+
+// roleSessionName returns the session name identifying the backup in the CloudTrail logs
+func roleSessionName(ns, name string) string {
+	n := fmt.Sprintf("%s-%s", ns, name)
+	if len(n) > maxRoleSessionNameLength {
+		n = n[:maxRoleSessionNameLength]
+	}
+	return n
+}
+
+type iamPolicyStatement struct {
+	Effect    string                       `json:"Effect"`
+	Action    []string                     `json:"Action"`
+	Resource  []string                     `json:"Resource"`
+	Condition map[string]map[string]string `json:"Condition,omitempty"`
+}
+
+type iamPolicy struct {
+	Version   string               `json:"Version"`
+	Statement []iamPolicyStatement `json:"Statement"`
+}
+
+// s3SessionPolicy returns the session policy allowing to access the objects under the prefix of the bucket
+// of the S3 storage only
+func s3SessionPolicy(s3 *v1alpha1.S3StorageProvider) (string, error) {
+	bucket, prefix := s3.Bucket, s3.Prefix
+	if bucket == "" {
+		// the path is in the format of <bucket>/<prefix>
+		bucket, prefix, _ = strings.Cut(strings.Trim(s3.Path, "/"), "/")
+	}
+	if bucket == "" {
+		return "", fmt.Errorf("the bucket of the s3 storage is not set")
+	}
+	prefix = strings.Trim(prefix, "/")
+	objects := fmt.Sprintf("arn:aws:s3:::%s/*", bucket)
+	listPrefix := "*"
+	if prefix != "" {
+		objects = fmt.Sprintf("arn:aws:s3:::%s/%s/*", bucket, prefix)
+		listPrefix = prefix + "/*"
+	}
+	policy := iamPolicy{
+		Version: "2012-10-17",
+		Statement: []iamPolicyStatement{
+			{
+				Effect:   "Allow",
+				Action:   []string{"s3:GetObject", "s3:PutObject", "s3:DeleteObject", "s3:AbortMultipartUpload", "s3:ListMultipartUploadParts"},
+				Resource: []string{objects},
+			},
+			{
+				Effect:    "Allow",
+				Action:    []string{"s3:ListBucket"},
+				Resource:  []string{fmt.Sprintf("arn:aws:s3:::%s", bucket)},
+				Condition: map[string]map[string]string{"StringLike": {"s3:prefix": listPrefix}},
+			},
+			{
+				Effect:   "Allow",
+				Action:   []string{"s3:GetBucketLocation"},
+				Resource: []string{fmt.Sprintf("arn:aws:s3:::%s", bucket)},
+			},
+		},
+	}
+	data, err := json.Marshal(policy)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// FakeCredentialsBroker is a fake implementation of CredentialsBrokerInterface.
+type FakeCredentialsBroker struct {
+	enabled     bool
+	credentials *StorageCredentials
+	err         error
+}
+
+// NewFakeCredentialsBroker returns a FakeCredentialsBroker instance
+func NewFakeCredentialsBroker() *FakeCredentialsBroker {
+	return &FakeCredentialsBroker{}
+}
+
+// SetCredentials enables the broker and sets the credentials returned by IssueS3Credentials
+func (b *FakeCredentialsBroker) SetCredentials(credentials *StorageCredentials) {
+	b.enabled = true
+	b.credentials = credentials
+}
+
+// SetError sets the error returned by IssueS3Credentials
+func (b *FakeCredentialsBroker) SetError(err error) {
+	b.err = err
+}
+
+func (b *FakeCredentialsBroker) Enabled() bool {
+	return b.enabled
+}
+
+func (b *FakeCredentialsBroker) IssueS3Credentials(_, _ string, _ *v1alpha1.S3StorageProvider) (*StorageCredentials, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	if !b.enabled {
+		return nil, fmt.Errorf("the backup credentials broker is not enabled")
+	}
+	return b.credentials, nil
+}
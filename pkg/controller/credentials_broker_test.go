@@ -0,0 +1,41 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+)
+
+func TestS3SessionPolicy(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	policy, err := s3SessionPolicy(&v1alpha1.S3StorageProvider{Bucket: "bucket", Prefix: "/ns/backup/"})
+	g.Expect(err).To(Succeed())
+	g.Expect(policy).To(ContainSubstring(`"arn:aws:s3:::bucket/ns/backup/*"`))
+	g.Expect(policy).To(ContainSubstring(`"s3:prefix":"ns/backup/*"`))
+
+	// the bucket and the prefix are parsed from the path
+	policy, err = s3SessionPolicy(&v1alpha1.S3StorageProvider{Path: "bucket/ns"})
+	g.Expect(err).To(Succeed())
+	g.Expect(policy).To(ContainSubstring(`"arn:aws:s3:::bucket/ns/*"`))
+
+	_, err = s3SessionPolicy(&v1alpha1.S3StorageProvider{})
+	g.Expect(err).To(HaveOccurred())
+
+	g.Expect(roleSessionName("ns", strings.Repeat("a", 100))).To(HaveLen(maxRoleSessionNameLength))
+}
@@ -17,6 +17,7 @@ import (
 	"context"
 	"flag"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -49,6 +50,7 @@ import (
 	"github.com/pingcap/tidb-operator/pkg/tiflashapi"
 	"github.com/pingcap/tidb-operator/pkg/tikvapi"
 	utildiscovery "github.com/pingcap/tidb-operator/pkg/util/discovery"
+	httputil "github.com/pingcap/tidb-operator/pkg/util/http"
 )
 
 // CLIConfig is used save all configuration read from command line parameters
@@ -95,8 +97,87 @@ type CLIConfig struct {
 	// KubeClientQPS indicates the maximum QPS to the kubenetes API server from client.
 	KubeClientQPS   float64
 	KubeClientBurst int
+
+	// ConfigFile is the path of the operator configuration file, the fields set in it take precedence over
+	// the command line flags, and some of them are reloaded at runtime when the file changes.
+	ConfigFile string
+	// ConfigReloadInterval is the interval to check whether the operator configuration file changes
+	ConfigReloadInterval time.Duration
+
+	// JobTTLSecondsAfterFinished is the time after which the finished backup, restore, clean and initializer
+	// Jobs are deleted by the operator, 0 means they are kept until their owners are deleted
+	JobTTLSecondsAfterFinished int
+	// The number of the finished Jobs kept for each kind in a namespace, a negative value means unlimited
+	BackupJobHistoryLimit      int
+	RestoreJobHistoryLimit     int
+	CleanJobHistoryLimit       int
+	InitializerJobHistoryLimit int
+	// JobLogTailLines is the number of the last lines of logs preserved in a ConfigMap for each pod
+	// of a Job deleted by the operator
+	JobLogTailLines int
+	// BackupScheduleMaxJitter is the max delay added to the scheduled time of the backups of a BackupSchedule,
+	// the delay is stable for each BackupSchedule, so that the schedules firing at the same time are spread out
+	BackupScheduleMaxJitter time.Duration
+	// The max number of the running scheduled snapshot backups in total and in each storage bucket, the other
+	// scheduled backups are queued in the order they are created, 0 means unlimited
+	MaxConcurrentScheduledBackups          int
+	MaxConcurrentScheduledBackupsPerBucket int
+	// The proxy for the egress HTTP(S) requests of the operator, and of the discovery and the jobs of the
+	// clusters without their own proxy, they follow the semantics of HTTP_PROXY, HTTPS_PROXY and NO_PROXY
+	HTTPProxy  string
+	HTTPSProxy string
+	NoProxy    string
+	// OrphanResourcePolicy is how the services, configmaps and PDBs labeled for a TidbCluster but no longer
+	// referenced by its spec are handled, it's one of Ignore, DryRun, Adopt and Prune
+	OrphanResourcePolicy string
+	// TemplateNamespaces is the comma-separated namespaces whose TidbClusterTemplates can be referenced by
+	// the TidbClusterClaims in other namespaces, "*" means all namespaces
+	TemplateNamespaces string
+	// CRDCheckPolicy is how the incompatibilities between the installed CRDs and this operator are handled
+	// at startup, it's one of Block, Warn and Ignore
+	CRDCheckPolicy string
+	// The retry, timeout and circuit breaker policy of the HTTP clients of the components, e.g. PD and TiDB,
+	// see httputil.RetryPolicy
+	ComponentHTTPMaxRetries       int
+	ComponentHTTPInitialBackoff   time.Duration
+	ComponentHTTPMaxBackoff       time.Duration
+	ComponentHTTPEndpointTimeouts string
+	ComponentHTTPBreakerThreshold int
+	ComponentHTTPBreakerCooldown  time.Duration
+	// BackupCredentialsBrokerRoleARN is the role assumed by the operator to issue the short-lived storage
+	// credentials of the backups using the credentials broker, empty means the broker is disabled
+	BackupCredentialsBrokerRoleARN string
+	// BackupCredentialsBrokerDuration is the lifetime of the credentials issued by the broker
+	BackupCredentialsBrokerDuration time.Duration
+
+	// lock protects the fields which can be reloaded at runtime
+	lock sync.RWMutex
+	// configData and loadedConfig are the content of the operator configuration file loaded last time
+	configData   []byte
+	loadedConfig *OperatorConfiguration
 }
 
+const (
+	// OrphanResourcePolicyIgnore ignores the orphan resources
+	OrphanResourcePolicyIgnore = "Ignore"
+	// OrphanResourcePolicyDryRun only reports the orphan and unowned resources by events
+	OrphanResourcePolicyDryRun = "DryRun"
+	// OrphanResourcePolicyAdopt makes the TidbCluster own the unowned resources, so that they are
+	// garbage collected with it, and reports the orphan resources
+	OrphanResourcePolicyAdopt = "Adopt"
+	// OrphanResourcePolicyPrune deletes the orphan resources and adopts the other unowned resources
+	OrphanResourcePolicyPrune = "Prune"
+)
+
+const (
+	// CRDCheckPolicyBlock doesn't start the controllers until the installed CRDs are compatible
+	CRDCheckPolicyBlock = "Block"
+	// CRDCheckPolicyWarn only reports the incompatibilities of the installed CRDs
+	CRDCheckPolicyWarn = "Warn"
+	// CRDCheckPolicyIgnore skips the check of the installed CRDs
+	CRDCheckPolicyIgnore = "Ignore"
+)
+
 // DefaultCLIConfig returns the default command line configuration
 func DefaultCLIConfig() *CLIConfig {
 	return &CLIConfig{
@@ -119,6 +200,22 @@ func DefaultCLIConfig() *CLIConfig {
 		TiDBBackupManagerImage: "pingcap/tidb-backup-manager:latest",
 		TiDBDiscoveryImage:     "pingcap/tidb-operator:latest",
 		Selector:               "",
+		ConfigReloadInterval:   10 * time.Second,
+
+		BackupJobHistoryLimit:      -1,
+		RestoreJobHistoryLimit:     -1,
+		CleanJobHistoryLimit:       -1,
+		InitializerJobHistoryLimit: -1,
+		JobLogTailLines:            100,
+		OrphanResourcePolicy:       OrphanResourcePolicyIgnore,
+		CRDCheckPolicy:             CRDCheckPolicyBlock,
+
+		ComponentHTTPMaxRetries:      2,
+		ComponentHTTPInitialBackoff:  200 * time.Millisecond,
+		ComponentHTTPMaxBackoff:      2 * time.Second,
+		ComponentHTTPBreakerCooldown: 30 * time.Second,
+
+		BackupCredentialsBrokerDuration: time.Hour,
 	}
 }
 
@@ -153,6 +250,110 @@ func (c *CLIConfig) AddFlag(_ *flag.FlagSet) {
 	flag.DurationVar(&c.RetryPeriod, "leader-retry-period", c.RetryPeriod, "leader-retry-period is the duration the LeaderElector clients should wait between tries of actions")
 	flag.Float64Var(&c.KubeClientQPS, "kube-client-qps", c.KubeClientQPS, "The maximum QPS to the kubenetes API server from client")
 	flag.IntVar(&c.KubeClientBurst, "kube-client-burst", c.KubeClientBurst, "The maximum burst for throttle to the kubenetes API server from client")
+	flag.StringVar(&c.ConfigFile, "config", c.ConfigFile, "The path of the operator configuration file, the fields set in it take precedence over the command line flags")
+	flag.DurationVar(&c.ConfigReloadInterval, "config-reload-interval", c.ConfigReloadInterval, "The interval to check whether the operator configuration file changes")
+	flag.IntVar(&c.JobTTLSecondsAfterFinished, "job-ttl-seconds-after-finished", c.JobTTLSecondsAfterFinished, "The seconds after which the finished backup, restore, clean and initializer jobs are deleted, 0 means never")
+	flag.IntVar(&c.BackupJobHistoryLimit, "backup-job-history-limit", c.BackupJobHistoryLimit, "The number of finished backup jobs kept in a namespace, a negative value means unlimited")
+	flag.IntVar(&c.RestoreJobHistoryLimit, "restore-job-history-limit", c.RestoreJobHistoryLimit, "The number of finished restore jobs kept in a namespace, a negative value means unlimited")
+	flag.IntVar(&c.CleanJobHistoryLimit, "clean-job-history-limit", c.CleanJobHistoryLimit, "The number of finished clean jobs kept in a namespace, a negative value means unlimited")
+	flag.IntVar(&c.InitializerJobHistoryLimit, "initializer-job-history-limit", c.InitializerJobHistoryLimit, "The number of finished initializer jobs kept in a namespace, a negative value means unlimited")
+	flag.IntVar(&c.JobLogTailLines, "job-log-tail-lines", c.JobLogTailLines, "The number of the last log lines preserved in a ConfigMap for each pod of a job deleted by the operator, 0 means not preserved")
+	flag.DurationVar(&c.BackupScheduleMaxJitter, "backup-schedule-max-jitter", c.BackupScheduleMaxJitter, "The max delay added to the scheduled time of the backups of a backup schedule to spread out the schedules firing at the same time, 0 means no delay")
+	flag.IntVar(&c.MaxConcurrentScheduledBackups, "max-concurrent-scheduled-backups", c.MaxConcurrentScheduledBackups, "The max number of the running scheduled snapshot backups, the others are queued, 0 means unlimited")
+	flag.IntVar(&c.MaxConcurrentScheduledBackupsPerBucket, "max-concurrent-scheduled-backups-per-bucket", c.MaxConcurrentScheduledBackupsPerBucket, "The max number of the running scheduled snapshot backups to the same storage bucket, the others are queued, 0 means unlimited")
+	flag.StringVar(&c.HTTPProxy, "http-proxy", c.HTTPProxy, "The proxy for HTTP requests of the operator and of the discovery and jobs of the clusters without their own proxy")
+	flag.StringVar(&c.HTTPSProxy, "https-proxy", c.HTTPSProxy, "The proxy for HTTPS requests of the operator and of the discovery and jobs of the clusters without their own proxy")
+	flag.StringVar(&c.OrphanResourcePolicy, "orphan-resource-policy", c.OrphanResourcePolicy, "How the services, configmaps and PDBs labeled for a TidbCluster but no longer referenced by its spec are handled: Ignore (default), DryRun (only report them by events once), Adopt (make the cluster own the unowned ones) or Prune (adopt the referenced ones and delete the others)")
+	flag.StringVar(&c.NoProxy, "no-proxy", c.NoProxy, "The comma-separated hosts, domains, IPs or CIDRs accessed without the proxy, it should include the in-cluster domains")
+	flag.StringVar(&c.TemplateNamespaces, "template-namespaces", c.TemplateNamespaces, "The comma-separated namespaces whose TidbClusterTemplates can be referenced by the TidbClusterClaims in other namespaces, '*' means all, by default a claim can only reference the templates in its own namespace")
+	flag.StringVar(&c.CRDCheckPolicy, "crd-check-policy", c.CRDCheckPolicy, "How the incompatibilities between the installed CRDs and this operator, e.g. the fields missing in the CRDs, are handled at startup: Block (default, don't start the controllers until the CRDs are upgraded), Warn (only log them) or Ignore")
+	flag.IntVar(&c.ComponentHTTPMaxRetries, "component-http-max-retries", c.ComponentHTTPMaxRetries, "The max number of the retries of the idempotent HTTP requests to the components like PD and TiDB after network errors or 502/503/504 responses, 0 disables the retries")
+	flag.DurationVar(&c.ComponentHTTPInitialBackoff, "component-http-initial-backoff", c.ComponentHTTPInitialBackoff, "The backoff before the first retry of the HTTP requests to the components, it's doubled for each retry")
+	flag.DurationVar(&c.ComponentHTTPMaxBackoff, "component-http-max-backoff", c.ComponentHTTPMaxBackoff, "The max backoff between the retries of the HTTP requests to the components")
+	flag.StringVar(&c.ComponentHTTPEndpointTimeouts, "component-http-endpoint-timeouts", c.ComponentHTTPEndpointTimeouts, "The comma-separated <path prefix>=<duration> timeouts of each attempt of the HTTP requests to the components, e.g. pd/api/v1/stores=10s, the others time out in 5s")
+	flag.IntVar(&c.ComponentHTTPBreakerThreshold, "component-http-breaker-threshold", c.ComponentHTTPBreakerThreshold, "The number of the consecutive failures of a component endpoint after which its requests fail fast for the cooldown, 0 disables the circuit breaker")
+	flag.DurationVar(&c.ComponentHTTPBreakerCooldown, "component-http-breaker-cooldown", c.ComponentHTTPBreakerCooldown, "The duration the requests to a component endpoint fail fast after its circuit breaker opens")
+	flag.StringVar(&c.BackupCredentialsBrokerRoleARN, "backup-credentials-broker-role-arn", c.BackupCredentialsBrokerRoleARN, "The AWS role assumed by the operator to issue the short-lived S3 credentials of the backups with useCredentialsBroker, so that their users don't need to hold the bucket credentials, empty disables the broker")
+	flag.DurationVar(&c.BackupCredentialsBrokerDuration, "backup-credentials-broker-duration", c.BackupCredentialsBrokerDuration, "The lifetime of the credentials issued by the backup credentials broker, it should cover the duration of the backups, between 15m and the max session duration of the role")
+}
+
+// The following getters read the fields which can be reloaded from the operator configuration file at runtime.
+
+// IsAutoFailover returns whether auto failover is enabled.
+func (c *CLIConfig) IsAutoFailover() bool {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.AutoFailover
+}
+
+// GetPDFailoverPeriod returns the failover period of PD.
+func (c *CLIConfig) GetPDFailoverPeriod() time.Duration {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.PDFailoverPeriod
+}
+
+// GetTiKVFailoverPeriod returns the failover period of TiKV.
+func (c *CLIConfig) GetTiKVFailoverPeriod() time.Duration {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.TiKVFailoverPeriod
+}
+
+// GetTiDBFailoverPeriod returns the failover period of TiDB.
+func (c *CLIConfig) GetTiDBFailoverPeriod() time.Duration {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.TiDBFailoverPeriod
+}
+
+// GetTiFlashFailoverPeriod returns the failover period of TiFlash.
+func (c *CLIConfig) GetTiFlashFailoverPeriod() time.Duration {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.TiFlashFailoverPeriod
+}
+
+// GetMasterFailoverPeriod returns the failover period of dm-master.
+func (c *CLIConfig) GetMasterFailoverPeriod() time.Duration {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.MasterFailoverPeriod
+}
+
+// GetWorkerFailoverPeriod returns the failover period of dm-worker.
+func (c *CLIConfig) GetWorkerFailoverPeriod() time.Duration {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.WorkerFailoverPeriod
+}
+
+// IsDetectNodeFailure returns whether node failure detection is enabled.
+func (c *CLIConfig) IsDetectNodeFailure() bool {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.DetectNodeFailure
+}
+
+// GetPodHardRecoveryPeriod returns the hard recovery period for a failure pod.
+func (c *CLIConfig) GetPodHardRecoveryPeriod() time.Duration {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.PodHardRecoveryPeriod
+}
+
+// GetTiDBBackupManagerImage returns the image of backup manager tool.
+func (c *CLIConfig) GetTiDBBackupManagerImage() string {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.TiDBBackupManagerImage
+}
+
+// GetTiDBDiscoveryImage returns the image of the tidb discovery service.
+func (c *CLIConfig) GetTiDBDiscoveryImage() string {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.TiDBDiscoveryImage
 }
 
 // HasNodePermission returns whether the user has permission for node operations.
@@ -170,6 +371,76 @@ func (c *CLIConfig) HasSCPermission() bool {
 	return c.ClusterScoped || c.ClusterPermissionSC
 }
 
+// ValidateOrphanResourcePolicy validates the orphan resource policy.
+func (c *CLIConfig) ValidateOrphanResourcePolicy() error {
+	switch c.OrphanResourcePolicy {
+	case OrphanResourcePolicyIgnore, OrphanResourcePolicyDryRun, OrphanResourcePolicyAdopt, OrphanResourcePolicyPrune:
+		return nil
+	}
+	return fmt.Errorf("invalid -orphan-resource-policy %q, it should be one of %s, %s, %s and %s", c.OrphanResourcePolicy,
+		OrphanResourcePolicyIgnore, OrphanResourcePolicyDryRun, OrphanResourcePolicyAdopt, OrphanResourcePolicyPrune)
+}
+
+// ValidateCRDCheckPolicy validates the CRD check policy.
+func (c *CLIConfig) ValidateCRDCheckPolicy() error {
+	switch c.CRDCheckPolicy {
+	case CRDCheckPolicyBlock, CRDCheckPolicyWarn, CRDCheckPolicyIgnore:
+		return nil
+	}
+	return fmt.Errorf("invalid -crd-check-policy %q, it should be one of %s, %s and %s", c.CRDCheckPolicy,
+		CRDCheckPolicyBlock, CRDCheckPolicyWarn, CRDCheckPolicyIgnore)
+}
+
+// ValidateProxy validates the proxy of the operator.
+func (c *CLIConfig) ValidateProxy() error {
+	for name, proxy := range map[string]string{"http-proxy": c.HTTPProxy, "https-proxy": c.HTTPSProxy} {
+		if proxy == "" {
+			continue
+		}
+		if err := httputil.ValidateProxyURL(proxy); err != nil {
+			return fmt.Errorf("invalid -%s %q: %v", name, proxy, err)
+		}
+	}
+	if c.NoProxy != "" {
+		if err := httputil.ValidateNoProxy(c.NoProxy); err != nil {
+			return fmt.Errorf("invalid -no-proxy: %v", err)
+		}
+	}
+	return nil
+}
+
+// ComponentHTTPRetryPolicy returns the retry policy of the HTTP clients of the components.
+func (c *CLIConfig) ComponentHTTPRetryPolicy() (httputil.RetryPolicy, error) {
+	if c.ComponentHTTPMaxRetries < 0 || c.ComponentHTTPBreakerThreshold < 0 {
+		return httputil.RetryPolicy{}, fmt.Errorf("-component-http-max-retries and -component-http-breaker-threshold must not be negative")
+	}
+	if c.ComponentHTTPInitialBackoff < 0 || c.ComponentHTTPMaxBackoff < c.ComponentHTTPInitialBackoff {
+		return httputil.RetryPolicy{}, fmt.Errorf("-component-http-max-backoff %s must not be less than -component-http-initial-backoff %s",
+			c.ComponentHTTPMaxBackoff, c.ComponentHTTPInitialBackoff)
+	}
+	timeouts, err := httputil.ParseEndpointTimeouts(c.ComponentHTTPEndpointTimeouts)
+	if err != nil {
+		return httputil.RetryPolicy{}, fmt.Errorf("invalid -component-http-endpoint-timeouts: %v", err)
+	}
+	return httputil.RetryPolicy{
+		MaxRetries:       c.ComponentHTTPMaxRetries,
+		InitialBackoff:   c.ComponentHTTPInitialBackoff,
+		MaxBackoff:       c.ComponentHTTPMaxBackoff,
+		EndpointTimeouts: timeouts,
+		BreakerThreshold: c.ComponentHTTPBreakerThreshold,
+		BreakerCooldown:  c.ComponentHTTPBreakerCooldown,
+	}, nil
+}
+
+// ProxyEnvVars returns the proxy environment variables for the containers of a cluster, proxy is the
+// proxy of the cluster and it takes precedence over the proxy of the operator.
+func (c *CLIConfig) ProxyEnvVars(proxy *v1alpha1.HTTPProxyConfig) []corev1.EnvVar {
+	if proxy == nil {
+		proxy = &v1alpha1.HTTPProxyConfig{HTTPProxy: c.HTTPProxy, HTTPSProxy: c.HTTPSProxy, NoProxy: c.NoProxy}
+	}
+	return proxy.EnvVars()
+}
+
 type Controls struct {
 	JobControl         JobControlInterface
 	ConfigMapControl   ConfigMapControlInterface
@@ -193,6 +464,9 @@ type Controls struct {
 	BackupControl      BackupControlInterface
 	RestoreControl     RestoreControlInterface
 	SecretControl      SecretControlInterface
+	HealthProbeControl HealthProbeControlInterface
+	PrometheusControl  PrometheusControlInterface
+	CredentialsBroker  CredentialsBrokerInterface
 }
 
 // Dependencies is used to store all shared dependent resources to avoid
@@ -240,6 +514,9 @@ type Dependencies struct {
 	Controls
 
 	AWSConfig aws.Config
+
+	// ASTSCompatibility is the result of the compatibility check of Advanced StatefulSet
+	ASTSCompatibility *ASTSCompatibility
 }
 
 func newRealControls(
@@ -294,6 +571,9 @@ func newRealControls(
 		BackupControl:      NewRealBackupControl(clientset, recorder),
 		RestoreControl:     NewRealRestoreControl(clientset, restoreLister, recorder),
 		SecretControl:      NewRealSecretControl(kubeClientset, secretLister, recorder),
+		HealthProbeControl: NewDefaultHealthProbeControl(),
+		PrometheusControl:  NewDefaultPrometheusControl(),
+		CredentialsBroker:  NewDefaultCredentialsBroker(cliCfg),
 	}
 }
 
@@ -381,7 +661,8 @@ func newDependencies(
 		TiDBNGMonitoringLister:      informerFactory.Pingcap().V1alpha1().TidbNGMonitorings().Lister(),
 		TiDBDashboardLister:         informerFactory.Pingcap().V1alpha1().TidbDashboards().Lister(),
 
-		AWSConfig: cfg,
+		AWSConfig:         cfg,
+		ASTSCompatibility: &ASTSCompatibility{},
 	}, nil
 }
 
@@ -452,6 +733,9 @@ func newFakeControl(kubeClientset kubernetes.Interface, informerFactory informer
 		TiDBControl:        NewFakeTiDBControl(kubeInformerFactory.Core().V1().Secrets().Lister()),
 		BackupControl:      NewFakeBackupControl(informerFactory.Pingcap().V1alpha1().Backups()),
 		SecretControl:      NewFakeSecretControl(kubeInformerFactory.Core().V1().Secrets()),
+		HealthProbeControl: NewFakeHealthProbeControl(),
+		PrometheusControl:  NewFakePrometheusControl(),
+		CredentialsBroker:  NewFakeCredentialsBroker(),
 	}
 }
 
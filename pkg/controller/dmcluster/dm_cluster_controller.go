@@ -124,7 +124,9 @@ func (c *Controller) processNextWorkItem() bool {
 		return false
 	}
 	defer c.queue.Done(key)
-	if err := c.sync(key.(string)); err != nil {
+	err := c.sync(key.(string))
+	controller.ObserveReconcile(c.Name(), err)
+	if err != nil {
 		if perrors.Find(err, controller.IsRequeueError) != nil {
 			klog.Infof("DMCluster: %v, still need sync: %v, requeuing", key.(string), err)
 		} else {
@@ -16,16 +16,19 @@ package controller
 import (
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
 	"github.com/pingcap/tidb-operator/pkg/dmapi"
+	"github.com/pingcap/tidb-operator/pkg/pdapi"
 )
 
 // GetMasterClient gets the master client from the DMCluster
 func GetMasterClient(dmControl dmapi.MasterControlInterface, dc *v1alpha1.DMCluster) dmapi.MasterClient {
-	return dmControl.GetMasterClient(dc.GetNamespace(), dc.GetName(), dc.IsTLSClusterEnabled())
+	return dmControl.GetMasterClient(dc.GetNamespace(), dc.GetName(), dc.IsTLSClusterEnabled(),
+		pdapi.TLSFromCluster(pdapi.Namespace(dc.GetNamespace()), dc.Spec.TLSCluster))
 }
 
 // GetMasterClient gets the master client from the DMCluster
 func GetMasterPeerClient(dmControl dmapi.MasterControlInterface, dc *v1alpha1.DMCluster, podName string) dmapi.MasterClient {
-	return dmControl.GetMasterPeerClient(dc.GetNamespace(), dc.GetName(), podName, dc.IsTLSClusterEnabled())
+	return dmControl.GetMasterPeerClient(dc.GetNamespace(), dc.GetName(), podName, dc.IsTLSClusterEnabled(),
+		pdapi.TLSFromCluster(pdapi.Namespace(dc.GetNamespace()), dc.Spec.TLSCluster))
 }
 
 // NewFakeMasterClient creates a fake master client that is set as the master client
@@ -0,0 +1,598 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dmtask
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	listers "github.com/pingcap/tidb-operator/pkg/client/listers/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/dmapi"
+	mngerutils "github.com/pingcap/tidb-operator/pkg/manager/utils"
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/klog/v2"
+	"k8s.io/kubernetes/pkg/util/slice"
+)
+
+const (
+	// passwordKey is the key of the password in the secrets of the sources and the target
+	passwordKey = "password"
+
+	defaultSourcePort  = 3306
+	defaultTargetPort  = 4000
+	defaultOnDuplicate = "overwrite"
+
+	// the stages of the subtasks reported by dm-master
+	subTaskStageRunning  = "Running"
+	subTaskStagePaused   = "Paused"
+	subTaskStageStopped  = "Stopped"
+	subTaskStageFinished = "Finished"
+)
+
+// ControlInterface reconciles DMTask
+type ControlInterface interface {
+	// ReconcileDMTask submits the sources and the task to dm-master and records the stage of the task
+	ReconcileDMTask(task *v1alpha1.DMTask) error
+}
+
+// NewDefaultDMTaskControl returns a new instance of the default DMTask ControlInterface
+func NewDefaultDMTaskControl(deps *controller.Dependencies, taskLister listers.DMTaskLister) ControlInterface {
+	return &defaultDMTaskControl{
+		deps:       deps,
+		taskLister: taskLister,
+	}
+}
+
+type defaultDMTaskControl struct {
+	deps       *controller.Dependencies
+	taskLister listers.DMTaskLister
+}
+
+func (c *defaultDMTaskControl) ReconcileDMTask(task *v1alpha1.DMTask) error {
+	if task.DeletionTimestamp != nil {
+		return c.cleanup(task)
+	}
+	if !slice.ContainsString(task.Finalizers, label.DMTaskFinalizer, nil) {
+		task = task.DeepCopy()
+		task.Finalizers = append(task.Finalizers, label.DMTaskFinalizer)
+		updated, err := c.deps.Clientset.PingcapV1alpha1().DMTasks(task.Namespace).Update(context.TODO(), task, metav1.UpdateOptions{})
+		if err != nil {
+			return fmt.Errorf("add finalizer to DMTask %s/%s failed, error: %v", task.Namespace, task.Name, err)
+		}
+		task = updated
+	}
+
+	status := task.Status.DeepCopy()
+	err := c.sync(task, status)
+	if err != nil && !controller.IsRequeueError(err) {
+		if status.Stage == "" {
+			status.Stage = v1alpha1.DMTaskStageFailed
+		}
+		status.Message = err.Error()
+		c.deps.Recorder.Event(task, corev1.EventTypeWarning, "SyncFailed", err.Error())
+	}
+	if updateErr := c.updateStatus(task, status); updateErr != nil {
+		return updateErr
+	}
+	// the task is reconciled again if the DMCluster is changed
+	if controller.IsIgnoreError(err) {
+		return nil
+	}
+	return err
+}
+
+func (c *defaultDMTaskControl) sync(task *v1alpha1.DMTask, status *v1alpha1.DMTaskStatus) error {
+	dc, err := c.getDMCluster(task)
+	if err != nil {
+		if status.Stage == "" {
+			status.Stage = v1alpha1.DMTaskStagePending
+		}
+		return err
+	}
+	if !dc.MasterIsAvailable() {
+		if status.Stage == "" {
+			status.Stage = v1alpha1.DMTaskStagePending
+		}
+		return controller.RequeueErrorf("DMTask %s/%s: dm-master of DMCluster %s/%s is not available", task.Namespace, task.Name, dc.Namespace, dc.Name)
+	}
+	cli := controller.GetMasterClient(c.deps.DMMasterControl, dc)
+
+	if err := c.syncSources(task, dc, cli, status); err != nil {
+		return err
+	}
+	if err := c.syncTask(task, cli, status); err != nil {
+		return err
+	}
+	if err := c.removeSources(task, dc, cli, status, false); err != nil {
+		return err
+	}
+	status.ObservedGeneration = task.Generation
+	return c.syncStage(task, cli, status)
+}
+
+// getDMCluster returns the DMCluster running the task
+func (c *defaultDMTaskControl) getDMCluster(task *v1alpha1.DMTask) (*v1alpha1.DMCluster, error) {
+	ns := task.Spec.Cluster.Namespace
+	if ns == "" {
+		ns = task.Namespace
+	}
+	dc, err := c.deps.DMClusterLister.DMClusters(ns).Get(task.Spec.Cluster.Name)
+	if errors.IsNotFound(err) {
+		return nil, controller.IgnoreErrorf("DMCluster %s/%s is not found", ns, task.Spec.Cluster.Name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get DMCluster %s/%s failed, error: %v", ns, task.Spec.Cluster.Name, err)
+	}
+	return dc, nil
+}
+
+// syncSources creates the sources of the task if they don't exist, and updates them if their configs are
+// changed since they are submitted last time
+func (c *defaultDMTaskControl) syncSources(task *v1alpha1.DMTask, dc *v1alpha1.DMCluster, cli dmapi.MasterClient, status *v1alpha1.DMTaskStatus) error {
+	sources, err := cli.ListSources()
+	if err != nil {
+		return err
+	}
+	existing := map[string]bool{}
+	for _, source := range sources {
+		existing[source.SourceName] = true
+	}
+
+	hashes := map[string]string{}
+	for name, hash := range status.Sources {
+		hashes[name] = hash
+	}
+	for i := range task.Spec.Sources {
+		spec := &task.Spec.Sources[i]
+		source, err := c.newSource(task, dc, spec)
+		if err != nil {
+			return err
+		}
+		hash, err := mngerutils.Sha256Sum(source)
+		if err != nil {
+			return err
+		}
+		switch {
+		case !existing[source.SourceName]:
+			klog.Infof("DMTask %s/%s: create source %s", task.Namespace, task.Name, source.SourceName)
+			if err := cli.CreateSource(source); err != nil {
+				return err
+			}
+		case hashes[source.SourceName] != hash:
+			klog.Infof("DMTask %s/%s: update source %s", task.Namespace, task.Name, source.SourceName)
+			if err := cli.UpdateSource(source); err != nil {
+				return err
+			}
+		}
+		hashes[source.SourceName] = hash
+		status.Sources = hashes
+	}
+	return nil
+}
+
+// syncTask creates the task if it doesn't exist, and updates it if its config is changed since it's
+// submitted last time. The task is stopped to be updated, it's started again by syncStage.
+func (c *defaultDMTaskControl) syncTask(task *v1alpha1.DMTask, cli dmapi.MasterClient, status *v1alpha1.DMTaskStatus) error {
+	desired, err := c.newTask(task)
+	if err != nil {
+		return err
+	}
+	hash, err := mngerutils.Sha256Sum(desired)
+	if err != nil {
+		return err
+	}
+
+	tasks, err := cli.ListTasks()
+	if err != nil {
+		return err
+	}
+	var exists bool
+	for _, t := range tasks {
+		if name, _ := t["name"].(string); name == task.Name {
+			exists = true
+			break
+		}
+	}
+
+	if !exists {
+		klog.Infof("DMTask %s/%s: create task", task.Namespace, task.Name)
+		if err := cli.CreateTask(desired, sourceNames(task)); err != nil {
+			return err
+		}
+		status.ConfigHash = hash
+		c.deps.Recorder.Event(task, corev1.EventTypeNormal, "Created", "task is created in dm-master")
+		return nil
+	}
+	if hash == status.ConfigHash {
+		return nil
+	}
+	klog.Infof("DMTask %s/%s: update task", task.Namespace, task.Name)
+	if err := cli.StopTask(task.Name); err != nil {
+		return err
+	}
+	if err := cli.UpdateTask(desired); err != nil {
+		return err
+	}
+	status.ConfigHash = hash
+	c.deps.Recorder.Event(task, corev1.EventTypeNormal, "Updated", "task is updated in dm-master")
+	return nil
+}
+
+// syncStage starts or stops the task according to the spec and records the status of the subtasks.
+// The subtasks paused by errors are left to the auto-resume of dm-worker.
+func (c *defaultDMTaskControl) syncStage(task *v1alpha1.DMTask, cli dmapi.MasterClient, status *v1alpha1.DMTaskStatus) error {
+	subTasks, err := cli.GetTaskStatus(task.Name)
+	if err != nil {
+		return err
+	}
+	stage := aggregateStage(subTasks)
+	switch {
+	case task.Spec.Paused && stage != v1alpha1.DMTaskStageStopped && stage != v1alpha1.DMTaskStageFinished:
+		klog.Infof("DMTask %s/%s: stop task", task.Namespace, task.Name)
+		if err := cli.StopTask(task.Name); err != nil {
+			return err
+		}
+		return controller.RequeueErrorf("DMTask %s/%s: task is being stopped", task.Namespace, task.Name)
+	case !task.Spec.Paused && (stage == v1alpha1.DMTaskStageStopped || stage == v1alpha1.DMTaskStagePending):
+		klog.Infof("DMTask %s/%s: start task", task.Namespace, task.Name)
+		if err := cli.StartTask(task.Name); err != nil {
+			return err
+		}
+		return controller.RequeueErrorf("DMTask %s/%s: task is being started", task.Namespace, task.Name)
+	}
+
+	status.Stage = stage
+	status.SubTasks = nil
+	var msgs []string
+	for _, st := range subTasks {
+		status.SubTasks = append(status.SubTasks, v1alpha1.DMSubTaskStatus{
+			SourceName:          st.SourceName,
+			WorkerName:          st.WorkerName,
+			Stage:               st.Stage,
+			Unit:                st.Unit,
+			UnresolvedDDLLockID: st.UnresolvedDDLLockID,
+			ErrorMessage:        st.ErrorMsg,
+		})
+		if st.ErrorMsg != "" {
+			msgs = append(msgs, fmt.Sprintf("%s: %s", st.SourceName, st.ErrorMsg))
+		}
+	}
+	status.Message = strings.Join(msgs, "; ")
+	return nil
+}
+
+// removeSources removes the sources submitted by the task but not used by it anymore, the sources used by
+// other DMTasks of the same DMCluster are kept. All the sources of the task are removed if all is true.
+func (c *defaultDMTaskControl) removeSources(task *v1alpha1.DMTask, dc *v1alpha1.DMCluster, cli dmapi.MasterClient,
+	status *v1alpha1.DMTaskStatus, all bool) error {
+	used := map[string]bool{}
+	if !all {
+		for _, name := range sourceNames(task) {
+			used[name] = true
+		}
+	}
+	var removed []string
+	for name := range status.Sources {
+		if !used[name] {
+			removed = append(removed, name)
+		}
+	}
+	if len(removed) == 0 {
+		return nil
+	}
+
+	tasks, err := c.taskLister.List(labels.Everything())
+	if err != nil {
+		return err
+	}
+	for _, other := range tasks {
+		if other.UID == task.UID || other.DeletionTimestamp != nil {
+			continue
+		}
+		if ref, err := c.getDMCluster(other); err != nil || ref.UID != dc.UID {
+			continue
+		}
+		for _, name := range sourceNames(other) {
+			used[name] = true
+		}
+	}
+
+	sources := map[string]string{}
+	for name, hash := range status.Sources {
+		sources[name] = hash
+	}
+	for _, name := range removed {
+		if !used[name] {
+			klog.Infof("DMTask %s/%s: delete source %s", task.Namespace, task.Name, name)
+			if err := cli.DeleteSource(name); err != nil {
+				return err
+			}
+		}
+		delete(sources, name)
+		status.Sources = sources
+	}
+	if len(status.Sources) == 0 {
+		status.Sources = nil
+	}
+	return nil
+}
+
+// cleanup removes the task and its sources from dm-master, and then removes the finalizer
+func (c *defaultDMTaskControl) cleanup(task *v1alpha1.DMTask) error {
+	if !slice.ContainsString(task.Finalizers, label.DMTaskFinalizer, nil) {
+		return nil
+	}
+	dc, err := c.getDMCluster(task)
+	if err != nil && !controller.IsIgnoreError(err) {
+		return err
+	}
+	// nothing needs to be cleaned up if the DMCluster is deleted
+	if dc != nil {
+		if !dc.MasterIsAvailable() {
+			return controller.RequeueErrorf("DMTask %s/%s: dm-master of DMCluster %s/%s is not available", task.Namespace, task.Name, dc.Namespace, dc.Name)
+		}
+		cli := controller.GetMasterClient(c.deps.DMMasterControl, dc)
+		tasks, err := cli.ListTasks()
+		if err != nil {
+			return err
+		}
+		for _, t := range tasks {
+			if name, _ := t["name"].(string); name == task.Name {
+				klog.Infof("DMTask %s/%s: delete task", task.Namespace, task.Name)
+				if err := cli.DeleteTask(task.Name); err != nil {
+					return err
+				}
+				break
+			}
+		}
+		status := task.Status.DeepCopy()
+		if err := c.removeSources(task, dc, cli, status, true); err != nil {
+			return err
+		}
+	}
+
+	task = task.DeepCopy()
+	task.Finalizers = slice.RemoveString(task.Finalizers, label.DMTaskFinalizer, nil)
+	if _, err := c.deps.Clientset.PingcapV1alpha1().DMTasks(task.Namespace).Update(context.TODO(), task, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("remove finalizer of DMTask %s/%s failed, error: %v", task.Namespace, task.Name, err)
+	}
+	klog.Infof("DMTask %s/%s: task and sources are removed from dm-master", task.Namespace, task.Name)
+	return nil
+}
+
+// newSource returns the config of the source in the format of the OpenAPI of dm-master, the relay log of the
+// source is configured by spec.worker.relay of the DMCluster
+func (c *defaultDMTaskControl) newSource(task *v1alpha1.DMTask, dc *v1alpha1.DMCluster, spec *v1alpha1.DMTaskSource) (*dmapi.Source, error) {
+	password, err := c.getPassword(task.Namespace, spec.SecretName)
+	if err != nil {
+		return nil, err
+	}
+	port := spec.Port
+	if port == 0 {
+		port = defaultSourcePort
+	}
+	source := &dmapi.Source{
+		SourceName: spec.Name,
+		Host:       spec.Host,
+		Port:       port,
+		User:       spec.User,
+		Password:   password,
+		EnableGTID: spec.EnableGTID,
+		Enable:     true,
+	}
+	if dc.Spec.Worker != nil && dc.Spec.Worker.Relay != nil {
+		relay := dc.Spec.Worker.Relay
+		source.RelayConfig = &dmapi.SourceRelayConfig{EnableRelay: slice.ContainsString(relay.Sources, spec.Name, nil)}
+		if relay.Purge != nil {
+			source.Purge = &dmapi.SourcePurge{
+				Interval:    relay.Purge.Interval,
+				Expires:     relay.Purge.Expires,
+				RemainSpace: relay.Purge.RemainSpace,
+			}
+		}
+	}
+	return source, nil
+}
+
+// newTask returns the config of the task in the format of the OpenAPI of dm-master, the fields of the spec
+// override the items of spec.config
+func (c *defaultDMTaskControl) newTask(task *v1alpha1.DMTask) (dmapi.Task, error) {
+	spec := &task.Spec
+	t := dmapi.Task{}
+	if spec.Config != nil {
+		for k, v := range spec.Config.DeepCopy().Inner() {
+			t[k] = v
+		}
+	}
+
+	t["name"] = task.Name
+	t["task_mode"] = string(v1alpha1.DMTaskModeAll)
+	if spec.TaskMode != "" {
+		t["task_mode"] = string(spec.TaskMode)
+	}
+	if spec.ShardMode != "" {
+		t["shard_mode"] = spec.ShardMode
+	}
+	t["on_duplicate"] = defaultOnDuplicate
+	if spec.OnDuplicate != "" {
+		t["on_duplicate"] = spec.OnDuplicate
+	}
+	if spec.MetaSchema != "" {
+		t["meta_schema"] = spec.MetaSchema
+	}
+
+	password, err := c.getPassword(task.Namespace, spec.Target.SecretName)
+	if err != nil {
+		return nil, err
+	}
+	port := spec.Target.Port
+	if port == 0 {
+		port = defaultTargetPort
+	}
+	t["target_config"] = map[string]interface{}{
+		"host":     spec.Target.Host,
+		"port":     port,
+		"user":     spec.Target.User,
+		"password": password,
+	}
+
+	var rules []interface{}
+	for _, rule := range spec.TableMigrateRules {
+		source := map[string]interface{}{
+			"source_name": rule.Source.SourceName,
+			"schema":      rule.Source.Schema,
+		}
+		if rule.Source.Table != "" {
+			source["table"] = rule.Source.Table
+		}
+		r := map[string]interface{}{"source": source}
+		if rule.Target != nil {
+			target := map[string]interface{}{"schema": rule.Target.Schema}
+			if rule.Target.Table != "" {
+				target["table"] = rule.Target.Table
+			}
+			r["target"] = target
+		}
+		if len(rule.BinlogFilterRules) > 0 {
+			r["binlog_filter_rule"] = rule.BinlogFilterRules
+		}
+		rules = append(rules, r)
+	}
+	if len(rules) == 0 {
+		for _, name := range sourceNames(task) {
+			rules = append(rules, map[string]interface{}{
+				"source": map[string]interface{}{"source_name": name, "schema": "*", "table": "*"},
+			})
+		}
+	}
+	t["table_migrate_rule"] = rules
+
+	sourceConfig, _ := t["source_config"].(map[string]interface{})
+	if sourceConfig == nil {
+		sourceConfig = map[string]interface{}{}
+	}
+	var sourceConf []interface{}
+	for _, source := range spec.Sources {
+		conf := map[string]interface{}{"source_name": source.Name}
+		if source.BinlogName != "" {
+			conf["binlog_name"] = source.BinlogName
+		}
+		if source.BinlogPos != nil {
+			conf["binlog_pos"] = *source.BinlogPos
+		}
+		if source.BinlogGTID != "" {
+			conf["binlog_gtid"] = source.BinlogGTID
+		}
+		sourceConf = append(sourceConf, conf)
+	}
+	sourceConfig["source_conf"] = sourceConf
+	t["source_config"] = sourceConfig
+	return t, nil
+}
+
+// getPassword returns the password in the secret, it's empty if the secret name is empty
+func (c *defaultDMTaskControl) getPassword(ns, secretName string) (string, error) {
+	if secretName == "" {
+		return "", nil
+	}
+	secret, err := c.deps.SecretLister.Secrets(ns).Get(secretName)
+	if err != nil {
+		return "", fmt.Errorf("get secret %s/%s failed, error: %v", ns, secretName, err)
+	}
+	password, ok := secret.Data[passwordKey]
+	if !ok {
+		return "", fmt.Errorf("key %s is not found in secret %s/%s", passwordKey, ns, secretName)
+	}
+	return string(password), nil
+}
+
+func (c *defaultDMTaskControl) updateStatus(task *v1alpha1.DMTask, status *v1alpha1.DMTaskStatus) error {
+	if apiequality.Semantic.DeepEqual(&task.Status, status) {
+		return nil
+	}
+	task = task.DeepCopy()
+	task.Status = *status
+	_, err := c.deps.Clientset.PingcapV1alpha1().DMTasks(task.Namespace).UpdateStatus(context.TODO(), task, metav1.UpdateOptions{})
+	if err != nil {
+		return fmt.Errorf("update status of DMTask %s/%s failed, error: %v", task.Namespace, task.Name, err)
+	}
+	return nil
+}
+
+// sourceNames returns the names of the sources of the task
+func sourceNames(task *v1alpha1.DMTask) []string {
+	var names []string
+	for _, source := range task.Spec.Sources {
+		names = append(names, source.Name)
+	}
+	return names
+}
+
+// aggregateStage returns the most severe stage of the subtasks, the stages are ordered by
+// Paused, Stopped, Running and Finished. It's Pending if there is no subtask.
+func aggregateStage(subTasks []dmapi.SubTaskStatus) v1alpha1.DMTaskStage {
+	if len(subTasks) == 0 {
+		return v1alpha1.DMTaskStagePending
+	}
+	severity := map[string]int{
+		subTaskStageFinished: 0,
+		subTaskStageRunning:  1,
+		subTaskStageStopped:  2,
+		subTaskStagePaused:   3,
+	}
+	stage := subTaskStageFinished
+	for _, st := range subTasks {
+		s, ok := severity[st.Stage]
+		if !ok {
+			// the stages like New are regarded as not started
+			s, st.Stage = severity[subTaskStageStopped], subTaskStageStopped
+		}
+		if s > severity[stage] {
+			stage = st.Stage
+		}
+	}
+	return v1alpha1.DMTaskStage(stage)
+}
+
+var _ ControlInterface = &defaultDMTaskControl{}
+
+// FakeDMTaskControl is a fake DMTask ControlInterface
+type FakeDMTaskControl struct {
+	err error
+}
+
+// NewFakeDMTaskControl returns a FakeDMTaskControl
+func NewFakeDMTaskControl() *FakeDMTaskControl {
+	return &FakeDMTaskControl{}
+}
+
+// SetReconcileDMTaskError sets error for DMTaskControl
+func (c *FakeDMTaskControl) SetReconcileDMTaskError(err error) {
+	c.err = err
+}
+
+// ReconcileDMTask fake ReconcileDMTask
+func (c *FakeDMTaskControl) ReconcileDMTask(_ *v1alpha1.DMTask) error {
+	return c.err
+}
+
+var _ ControlInterface = &FakeDMTaskControl{}
@@ -0,0 +1,294 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dmtask
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/apis/util/config"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/dmapi"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// fakeDMMaster records the sources and the tasks submitted to the fake dm-master
+type fakeDMMaster struct {
+	sources  map[string]*dmapi.Source
+	tasks    map[string]dmapi.Task
+	stages   map[string]string
+	calls    []dmapi.ActionType
+	subTasks []dmapi.SubTaskStatus
+}
+
+func newFakeDMMaster(cli *dmapi.FakeMasterClient) *fakeDMMaster {
+	m := &fakeDMMaster{sources: map[string]*dmapi.Source{}, tasks: map[string]dmapi.Task{}, stages: map[string]string{}}
+	record := func(actionType dmapi.ActionType, fn func(action *dmapi.Action) interface{}) {
+		cli.AddReaction(actionType, func(action *dmapi.Action) (interface{}, error) {
+			m.calls = append(m.calls, actionType)
+			return fn(action), nil
+		})
+	}
+	record(dmapi.ListSourcesActionType, func(_ *dmapi.Action) interface{} {
+		var sources []dmapi.Source
+		for _, source := range m.sources {
+			sources = append(sources, *source)
+		}
+		return sources
+	})
+	record(dmapi.CreateSourceActionType, func(action *dmapi.Action) interface{} {
+		m.sources[action.Source.SourceName] = action.Source
+		return nil
+	})
+	record(dmapi.UpdateSourceActionType, func(action *dmapi.Action) interface{} {
+		m.sources[action.Source.SourceName] = action.Source
+		return nil
+	})
+	record(dmapi.DeleteSourceActionType, func(action *dmapi.Action) interface{} {
+		delete(m.sources, action.Name)
+		return nil
+	})
+	record(dmapi.ListTasksActionType, func(_ *dmapi.Action) interface{} {
+		var tasks []dmapi.Task
+		for _, task := range m.tasks {
+			tasks = append(tasks, task)
+		}
+		return tasks
+	})
+	record(dmapi.CreateTaskActionType, func(action *dmapi.Action) interface{} {
+		name := action.Task["name"].(string)
+		m.tasks[name] = action.Task
+		m.stages[name] = "Stopped"
+		return nil
+	})
+	record(dmapi.UpdateTaskActionType, func(action *dmapi.Action) interface{} {
+		m.tasks[action.Task["name"].(string)] = action.Task
+		return nil
+	})
+	record(dmapi.StartTaskActionType, func(action *dmapi.Action) interface{} {
+		m.stages[action.Name] = "Running"
+		return nil
+	})
+	record(dmapi.StopTaskActionType, func(action *dmapi.Action) interface{} {
+		m.stages[action.Name] = "Stopped"
+		return nil
+	})
+	record(dmapi.DeleteTaskActionType, func(action *dmapi.Action) interface{} {
+		delete(m.tasks, action.Name)
+		return nil
+	})
+	record(dmapi.GetTaskStatusActionType, func(action *dmapi.Action) interface{} {
+		if m.subTasks != nil {
+			return m.subTasks
+		}
+		return []dmapi.SubTaskStatus{{Name: action.Name, SourceName: "mysql-01", WorkerName: "worker-0", Stage: m.stages[action.Name], Unit: "Sync"}}
+	})
+	return m
+}
+
+func (m *fakeDMMaster) reset() {
+	m.calls = nil
+}
+
+func TestReconcileDMTask(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := context.TODO()
+
+	deps := controller.NewFakeDependencies()
+	taskInformer := deps.InformerFactory.Pingcap().V1alpha1().DMTasks()
+	control := NewDefaultDMTaskControl(deps, taskInformer.Lister())
+	taskCli := deps.Clientset.PingcapV1alpha1().DMTasks(corev1.NamespaceDefault)
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "db-secret", Namespace: corev1.NamespaceDefault},
+		Data:       map[string][]byte{"password": []byte("secret")},
+	}
+	g.Expect(deps.KubeInformerFactory.Core().V1().Secrets().Informer().GetIndexer().Add(secret)).To(Succeed())
+
+	task := newDMTask()
+	_, err := taskCli.Create(ctx, task, metav1.CreateOptions{})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	// the DMCluster is not found
+	g.Expect(control.ReconcileDMTask(task)).To(Succeed())
+	task, err = taskCli.Get(ctx, task.Name, metav1.GetOptions{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(task.Finalizers).To(ContainElement(label.DMTaskFinalizer))
+	g.Expect(task.Status.Stage).To(Equal(v1alpha1.DMTaskStagePending))
+	g.Expect(task.Status.Message).To(ContainSubstring("is not found"))
+
+	dc := newDMCluster()
+	g.Expect(deps.InformerFactory.Pingcap().V1alpha1().DMClusters().Informer().GetIndexer().Add(dc)).To(Succeed())
+	master := newFakeDMMaster(controller.NewFakeMasterClient(deps.DMMasterControl.(*dmapi.FakeMasterControl), dc))
+
+	// the sources and the task are created and the task is started
+	err = control.ReconcileDMTask(task)
+	g.Expect(controller.IsRequeueError(err)).To(BeTrue())
+	g.Expect(master.sources).To(HaveKey("mysql-01"))
+	g.Expect(master.sources["mysql-01"].Password).To(Equal("secret"))
+	g.Expect(master.sources["mysql-01"].Port).To(Equal(int32(3306)))
+	g.Expect(master.tasks).To(HaveKey("task"))
+	g.Expect(master.tasks["task"]["task_mode"]).To(Equal("all"))
+	g.Expect(master.tasks["task"]["on_duplicate"]).To(Equal("overwrite"))
+	g.Expect(master.tasks["task"]["target_config"]).To(HaveKeyWithValue("password", "secret"))
+	g.Expect(master.tasks["task"]["table_migrate_rule"]).To(HaveLen(1))
+	// the items of the config are passed through
+	g.Expect(master.tasks["task"]).To(HaveKey("binlog_filter_rule"))
+	g.Expect(master.stages["task"]).To(Equal("Running"))
+
+	task, err = taskCli.Get(ctx, task.Name, metav1.GetOptions{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(control.ReconcileDMTask(task)).To(Succeed())
+	task, err = taskCli.Get(ctx, task.Name, metav1.GetOptions{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(task.Status.Stage).To(Equal(v1alpha1.DMTaskStageRunning))
+	g.Expect(task.Status.Message).To(BeEmpty())
+	g.Expect(task.Status.ConfigHash).NotTo(BeEmpty())
+	g.Expect(task.Status.Sources).To(HaveKey("mysql-01"))
+	g.Expect(task.Status.SubTasks).To(Equal([]v1alpha1.DMSubTaskStatus{{SourceName: "mysql-01", WorkerName: "worker-0", Stage: "Running", Unit: "Sync"}}))
+
+	// nothing is submitted if the spec isn't changed
+	master.reset()
+	g.Expect(control.ReconcileDMTask(task)).To(Succeed())
+	g.Expect(master.calls).To(Equal([]dmapi.ActionType{dmapi.ListSourcesActionType, dmapi.ListTasksActionType, dmapi.GetTaskStatusActionType}))
+
+	// the sources are updated if the relay log of the DMCluster is changed
+	interval := int64(600)
+	dc.Spec.Worker = &v1alpha1.WorkerSpec{Relay: &v1alpha1.DMRelaySpec{
+		Sources: []string{"mysql-01"},
+		Purge:   &v1alpha1.DMRelayPurge{Interval: &interval},
+	}}
+	g.Expect(deps.InformerFactory.Pingcap().V1alpha1().DMClusters().Informer().GetIndexer().Update(dc)).To(Succeed())
+	master.reset()
+	g.Expect(control.ReconcileDMTask(task)).To(Succeed())
+	g.Expect(master.calls).To(ContainElement(dmapi.UpdateSourceActionType))
+	g.Expect(master.sources["mysql-01"].RelayConfig).To(Equal(&dmapi.SourceRelayConfig{EnableRelay: true}))
+	g.Expect(master.sources["mysql-01"].Purge).To(Equal(&dmapi.SourcePurge{Interval: &interval}))
+	task, err = taskCli.Get(ctx, task.Name, metav1.GetOptions{})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	// the task is stopped to be updated and started again
+	task.Spec.TaskMode = v1alpha1.DMTaskModeIncremental
+	task, err = taskCli.Update(ctx, task, metav1.UpdateOptions{})
+	g.Expect(err).NotTo(HaveOccurred())
+	master.reset()
+	err = control.ReconcileDMTask(task)
+	g.Expect(controller.IsRequeueError(err)).To(BeTrue())
+	g.Expect(master.calls).To(ContainElements(dmapi.StopTaskActionType, dmapi.UpdateTaskActionType, dmapi.StartTaskActionType))
+	g.Expect(master.tasks["task"]["task_mode"]).To(Equal("incremental"))
+	g.Expect(master.stages["task"]).To(Equal("Running"))
+
+	// the task is stopped if it's paused
+	task, err = taskCli.Get(ctx, task.Name, metav1.GetOptions{})
+	g.Expect(err).NotTo(HaveOccurred())
+	task.Spec.Paused = true
+	err = control.ReconcileDMTask(task)
+	g.Expect(controller.IsRequeueError(err)).To(BeTrue())
+	g.Expect(master.stages["task"]).To(Equal("Stopped"))
+	g.Expect(control.ReconcileDMTask(task)).To(Succeed())
+	task, err = taskCli.Get(ctx, task.Name, metav1.GetOptions{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(task.Status.Stage).To(Equal(v1alpha1.DMTaskStageStopped))
+
+	// the errors of the subtasks are reported
+	task.Spec.Paused = false
+	master.subTasks = []dmapi.SubTaskStatus{{Name: "task", SourceName: "mysql-01", Stage: "Paused", Unit: "Sync", ErrorMsg: "table not found"}}
+	g.Expect(control.ReconcileDMTask(task)).To(Succeed())
+	task, err = taskCli.Get(ctx, task.Name, metav1.GetOptions{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(task.Status.Stage).To(Equal(v1alpha1.DMTaskStagePaused))
+	g.Expect(task.Status.Message).To(Equal("mysql-01: table not found"))
+
+	// the task and the sources are removed on deletion
+	task.DeletionTimestamp = &metav1.Time{}
+	g.Expect(control.ReconcileDMTask(task)).To(Succeed())
+	g.Expect(master.tasks).To(BeEmpty())
+	g.Expect(master.sources).To(BeEmpty())
+	task, err = taskCli.Get(ctx, task.Name, metav1.GetOptions{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(task.Finalizers).NotTo(ContainElement(label.DMTaskFinalizer))
+}
+
+func TestRemoveSharedSources(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	deps := controller.NewFakeDependencies()
+	taskInformer := deps.InformerFactory.Pingcap().V1alpha1().DMTasks()
+	control := NewDefaultDMTaskControl(deps, taskInformer.Lister()).(*defaultDMTaskControl)
+	dc := newDMCluster()
+	g.Expect(deps.InformerFactory.Pingcap().V1alpha1().DMClusters().Informer().GetIndexer().Add(dc)).To(Succeed())
+	cli := controller.NewFakeMasterClient(deps.DMMasterControl.(*dmapi.FakeMasterControl), dc)
+	master := newFakeDMMaster(cli)
+	master.sources["mysql-01"] = &dmapi.Source{SourceName: "mysql-01"}
+	master.sources["mysql-02"] = &dmapi.Source{SourceName: "mysql-02"}
+
+	// mysql-01 is still used by another task of the DMCluster
+	other := newDMTask()
+	other.Name = "other"
+	other.UID = types.UID("other")
+	g.Expect(taskInformer.Informer().GetIndexer().Add(other)).To(Succeed())
+
+	task := newDMTask()
+	task.Spec.Sources = nil
+	status := &v1alpha1.DMTaskStatus{Sources: map[string]string{"mysql-01": "a", "mysql-02": "b"}}
+	g.Expect(control.removeSources(task, dc, cli, status, false)).To(Succeed())
+	g.Expect(master.sources).To(HaveKey("mysql-01"))
+	g.Expect(master.sources).NotTo(HaveKey("mysql-02"))
+	g.Expect(status.Sources).To(BeNil())
+}
+
+func TestAggregateStage(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	g.Expect(aggregateStage(nil)).To(Equal(v1alpha1.DMTaskStagePending))
+	g.Expect(aggregateStage([]dmapi.SubTaskStatus{{Stage: "Finished"}, {Stage: "Running"}})).To(Equal(v1alpha1.DMTaskStageRunning))
+	g.Expect(aggregateStage([]dmapi.SubTaskStatus{{Stage: "Running"}, {Stage: "Paused"}, {Stage: "Stopped"}})).To(Equal(v1alpha1.DMTaskStagePaused))
+	g.Expect(aggregateStage([]dmapi.SubTaskStatus{{Stage: "New"}, {Stage: "Running"}})).To(Equal(v1alpha1.DMTaskStageStopped))
+	g.Expect(aggregateStage([]dmapi.SubTaskStatus{{Stage: "Finished"}})).To(Equal(v1alpha1.DMTaskStageFinished))
+}
+
+func newDMCluster() *v1alpha1.DMCluster {
+	return &v1alpha1.DMCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "dc", Namespace: corev1.NamespaceDefault, UID: types.UID("dc")},
+		Spec: v1alpha1.DMClusterSpec{
+			Master: v1alpha1.MasterSpec{Replicas: 1},
+		},
+		Status: v1alpha1.DMClusterStatus{
+			Master: v1alpha1.MasterStatus{
+				Members: map[string]v1alpha1.MasterMember{"dc-dm-master-0": {Name: "dc-dm-master-0", Health: true}},
+			},
+		},
+	}
+}
+
+func newDMTask() *v1alpha1.DMTask {
+	return &v1alpha1.DMTask{
+		ObjectMeta: metav1.ObjectMeta{Name: "task", Namespace: corev1.NamespaceDefault, UID: types.UID("task")},
+		Spec: v1alpha1.DMTaskSpec{
+			Cluster: v1alpha1.DMClusterRef{Name: "dc"},
+			Sources: []v1alpha1.DMTaskSource{{Name: "mysql-01", Host: "mysql", User: "root", SecretName: "db-secret"}},
+			Target:  v1alpha1.DMTaskTarget{Host: "tidb", User: "root", SecretName: "db-secret"},
+			Config: config.New(map[string]interface{}{
+				"binlog_filter_rule": map[string]interface{}{
+					"ignore-drop": map[string]interface{}{"ignore_event": []interface{}{"drop database"}},
+				},
+			}),
+		},
+	}
+}
@@ -0,0 +1,167 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dmtask
+
+import (
+	"fmt"
+	"time"
+
+	perrors "github.com/pingcap/errors"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	listers "github.com/pingcap/tidb-operator/pkg/client/listers/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/metrics"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+)
+
+// Controller submits the DMTasks to dm-master of the DMClusters
+type Controller struct {
+	deps       *controller.Dependencies
+	control    ControlInterface
+	taskLister listers.DMTaskLister
+	queue      workqueue.RateLimitingInterface
+}
+
+// NewController creates a dmtask controller.
+func NewController(deps *controller.Dependencies) *Controller {
+	taskInformer := deps.InformerFactory.Pingcap().V1alpha1().DMTasks()
+	dcInformer := deps.InformerFactory.Pingcap().V1alpha1().DMClusters()
+
+	c := &Controller{
+		deps:       deps,
+		control:    NewDefaultDMTaskControl(deps, taskInformer.Lister()),
+		taskLister: taskInformer.Lister(),
+		queue: workqueue.NewNamedRateLimitingQueue(
+			controller.NewControllerRateLimiter(1*time.Second, 100*time.Second),
+			"dmtask",
+		),
+	}
+
+	controller.WatchForObject(taskInformer.Informer(), c.queue)
+	dcInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: c.enqueueTasksOfCluster,
+		UpdateFunc: func(_, cur interface{}) {
+			c.enqueueTasksOfCluster(cur)
+		},
+		DeleteFunc: c.enqueueTasksOfCluster,
+	})
+
+	return c
+}
+
+// Name returns the name of the dmtask controller
+func (c *Controller) Name() string {
+	return "dmtask"
+}
+
+// Run run workers
+func (c *Controller) Run(workers int, stopCh <-chan struct{}) {
+	defer utilruntime.HandleCrash()
+	defer c.queue.ShutDown()
+
+	klog.Info("Starting dmtask controller")
+	defer klog.Info("Shutting down dmtask controller")
+
+	for i := 0; i < workers; i++ {
+		go wait.Until(c.worker, time.Second, stopCh)
+	}
+
+	<-stopCh
+}
+
+func (c *Controller) worker() {
+	for c.processNextWorkItem() {
+	}
+}
+
+// processNextWorkItem dequeues items, processes them, and marks them done.
+// It enforces that the syncHandler is never
+// invoked concurrently with the same key.
+func (c *Controller) processNextWorkItem() bool {
+	metrics.ActiveWorkers.WithLabelValues(c.Name()).Add(1)
+	defer metrics.ActiveWorkers.WithLabelValues(c.Name()).Add(-1)
+
+	key, quit := c.queue.Get()
+	if quit {
+		return false
+	}
+	defer c.queue.Done(key)
+	err := c.sync(key.(string))
+	controller.ObserveReconcile(c.Name(), err)
+	if err != nil {
+		if perrors.Find(err, controller.IsRequeueError) != nil {
+			klog.Infof("DMTask: %v, still need sync: %v, requeuing", key.(string), err)
+		} else {
+			utilruntime.HandleError(fmt.Errorf("DMTask: %v, sync failed, err: %v, requeuing", key.(string), err))
+		}
+		c.queue.AddRateLimited(key)
+	} else {
+		c.queue.Forget(key)
+	}
+	return true
+}
+
+func (c *Controller) sync(key string) error {
+	startTime := time.Now()
+	defer func() {
+		duration := time.Since(startTime)
+		metrics.ReconcileTime.WithLabelValues(c.Name()).Observe(duration.Seconds())
+		klog.V(4).Infof("Finished syncing DMTask %q (%v)", key, duration)
+	}()
+
+	ns, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return err
+	}
+	task, err := c.taskLister.DMTasks(ns).Get(name)
+	if errors.IsNotFound(err) {
+		klog.Infof("DMTask %v has been deleted", key)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return c.control.ReconcileDMTask(task)
+}
+
+// enqueueTasksOfCluster enqueues the tasks run by the DMCluster
+func (c *Controller) enqueueTasksOfCluster(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	dc, ok := obj.(*v1alpha1.DMCluster)
+	if !ok {
+		return
+	}
+	tasks, err := c.taskLister.List(labels.Everything())
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("list DMTasks failed, err: %v", err))
+		return
+	}
+	for _, task := range tasks {
+		ns := task.Spec.Cluster.Namespace
+		if ns == "" {
+			ns = task.Namespace
+		}
+		if ns == dc.Namespace && task.Spec.Cluster.Name == dc.Name {
+			c.queue.Add(task.Namespace + "/" + task.Name)
+		}
+	}
+}
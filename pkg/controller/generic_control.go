@@ -24,6 +24,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	extensionsv1beta1 "k8s.io/api/extensions/v1beta1"
 	networkingv1 "k8s.io/api/networking/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -62,6 +63,8 @@ type TypedControlInterface interface {
 	CreateOrUpdateIngress(controller client.Object, ingress *networkingv1.Ingress) (*networkingv1.Ingress, error)
 	// CreateOrUpdateIngressV1beta1 create the desired v1beta1 ingress or update the current one to desired state if already existed
 	CreateOrUpdateIngressV1beta1(controller client.Object, ingress *extensionsv1beta1.Ingress) (*extensionsv1beta1.Ingress, error)
+	// CreateOrUpdatePodDisruptionBudget create the desired pdb or update the current one to desired state if already existed
+	CreateOrUpdatePodDisruptionBudget(controller client.Object, pdb *policyv1beta1.PodDisruptionBudget) (*policyv1beta1.PodDisruptionBudget, error)
 	// UpdateStatus update the /status subresource of the object
 	UpdateStatus(newStatus client.Object) error
 	// Delete delete the given object from the cluster
@@ -132,6 +135,9 @@ func (w *typedWrapper) CreateOrUpdateSecret(controller client.Object, secret *co
 
 		existingSecret.Data = desiredSecret.Data
 		existingSecret.Labels = desiredSecret.Labels
+		if existingSecret.Annotations == nil {
+			existingSecret.Annotations = map[string]string{}
+		}
 		for k, v := range desiredSecret.Annotations {
 			existingSecret.Annotations[k] = v
 		}
@@ -252,6 +258,9 @@ func (w *typedWrapper) CreateOrUpdateConfigMap(controller client.Object, cm *cor
 
 		existingCm.Data = desiredCm.Data
 		existingCm.Labels = desiredCm.Labels
+		if existingCm.Annotations == nil {
+			existingCm.Annotations = map[string]string{}
+		}
 		for k, v := range desiredCm.Annotations {
 			existingCm.Annotations[k] = v
 		}
@@ -383,6 +392,23 @@ func (w *typedWrapper) CreateOrUpdateIngress(controller client.Object, ingress *
 	return result.(*networkingv1.Ingress), nil
 }
 
+func (w *typedWrapper) CreateOrUpdatePodDisruptionBudget(controller client.Object, pdb *policyv1beta1.PodDisruptionBudget) (*policyv1beta1.PodDisruptionBudget, error) {
+	result, err := w.GenericControlInterface.CreateOrUpdate(controller, pdb, func(existing, desired client.Object) error {
+		existingPDB := existing.(*policyv1beta1.PodDisruptionBudget)
+		desiredPDB := desired.(*policyv1beta1.PodDisruptionBudget)
+
+		existingPDB.Labels = desiredPDB.Labels
+		existingPDB.Spec.Selector = desiredPDB.Spec.Selector
+		existingPDB.Spec.MinAvailable = desiredPDB.Spec.MinAvailable
+		existingPDB.Spec.MaxUnavailable = desiredPDB.Spec.MaxUnavailable
+		return nil
+	}, true)
+	if err != nil {
+		return nil, err
+	}
+	return result.(*policyv1beta1.PodDisruptionBudget), nil
+}
+
 func (w *typedWrapper) Create(controller, obj client.Object) error {
 	return w.GenericControlInterface.Create(controller, obj, true)
 }
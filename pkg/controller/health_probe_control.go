@@ -0,0 +1,87 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/healthprobe"
+	httputil "github.com/pingcap/tidb-operator/pkg/util/http"
+)
+
+// HealthProbeControlInterface gets the results of the synthetic SQL probes of TidbClusters
+type HealthProbeControlInterface interface {
+	// GetResult returns the result of the last probe of the TidbCluster
+	GetResult(tc *v1alpha1.TidbCluster) (*v1alpha1.HealthProbeStatus, error)
+}
+
+// defaultHealthProbeControl is default implementation of HealthProbeControlInterface.
+type defaultHealthProbeControl struct {
+	// for unit test only
+	testURL string
+}
+
+// NewDefaultHealthProbeControl returns a defaultHealthProbeControl instance
+func NewDefaultHealthProbeControl() *defaultHealthProbeControl {
+	return &defaultHealthProbeControl{}
+}
+
+func (c *defaultHealthProbeControl) GetResult(tc *v1alpha1.TidbCluster) (*v1alpha1.HealthProbeStatus, error) {
+	// the probe is served in plain HTTP inside the cluster, so the proxy is not used
+	httpClient := &http.Client{Timeout: timeout}
+	url := fmt.Sprintf("%s%s", c.getBaseURL(tc), healthprobe.ResultPath)
+	body, err := httputil.GetBodyOK(httpClient, url)
+	if err != nil {
+		return nil, err
+	}
+	result := &v1alpha1.HealthProbeStatus{}
+	if err := json.Unmarshal(body, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c *defaultHealthProbeControl) getBaseURL(tc *v1alpha1.TidbCluster) string {
+	if c.testURL != "" {
+		return c.testURL
+	}
+	return fmt.Sprintf("http://%s.%s:%d", HealthProbeMemberName(tc.GetName()), tc.GetNamespace(), healthprobe.Port)
+}
+
+// FakeHealthProbeControl is a fake implementation of HealthProbeControlInterface.
+type FakeHealthProbeControl struct {
+	result *v1alpha1.HealthProbeStatus
+	err    error
+}
+
+// NewFakeHealthProbeControl returns a FakeHealthProbeControl instance
+func NewFakeHealthProbeControl() *FakeHealthProbeControl {
+	return &FakeHealthProbeControl{}
+}
+
+// SetResult sets the result returned by GetResult
+func (c *FakeHealthProbeControl) SetResult(result *v1alpha1.HealthProbeStatus, err error) {
+	c.result = result
+	c.err = err
+}
+
+func (c *FakeHealthProbeControl) GetResult(_ *v1alpha1.TidbCluster) (*v1alpha1.HealthProbeStatus, error) {
+	if c.result == nil && c.err == nil {
+		return nil, fmt.Errorf("no probe has finished")
+	}
+	return c.result, c.err
+}
@@ -20,19 +20,22 @@ import (
 	"net/http"
 
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/metrics"
 	"github.com/pingcap/tidb-operator/pkg/util"
+	httputil "github.com/pingcap/tidb-operator/pkg/util/http"
 	v1 "k8s.io/api/core/v1"
 	corelisterv1 "k8s.io/client-go/listers/core/v1"
 )
 
 type httpClient struct {
 	secretLister corelisterv1.SecretLister
+	// component is the component that the requests are sent to, which is recorded in the metrics
+	component v1alpha1.MemberType
 }
 
 func (c *httpClient) getHTTPClient(tc *v1alpha1.TidbCluster) (*http.Client, error) {
-	httpClient := &http.Client{Timeout: timeout}
 	if !tc.IsTLSClusterEnabled() {
-		return httpClient, nil
+		return httputil.NewClient(timeout, c.instrument(&http.Transport{Proxy: httputil.Proxy})), nil
 	}
 
 	tcName := tc.Name
@@ -60,7 +63,12 @@ func (c *httpClient) getHTTPClient(tc *v1alpha1.TidbCluster) (*http.Client, erro
 		RootCAs:      rootCAs,
 		Certificates: []tls.Certificate{tlsCert},
 	}
-	httpClient.Transport = &http.Transport{TLSClientConfig: config, DisableKeepAlives: true}
+	return httputil.NewClient(timeout, c.instrument(&http.Transport{TLSClientConfig: config, DisableKeepAlives: true, Proxy: httputil.Proxy})), nil
+}
 
-	return httpClient, nil
+func (c *httpClient) instrument(transport *http.Transport) http.RoundTripper {
+	if c.component == "" {
+		return transport
+	}
+	return metrics.InstrumentRoundTripper(c.component.String(), transport)
 }
@@ -0,0 +1,297 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jobgc
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/selection"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// gcInterval is the interval to check the finished Jobs
+	gcInterval = time.Minute
+	// jobLogConfigMapSuffix is the suffix of the name of the ConfigMap preserving the logs of a deleted Job
+	jobLogConfigMapSuffix = "-log"
+	// maxJobLogBytes is the maximum size of the logs preserved for each pod
+	maxJobLogBytes = 64 * 1024
+)
+
+// Controller deletes the finished backup, restore, clean and initializer Jobs created by the operator
+// according to the TTL and the history limits of the kinds, the last lines of the logs of their pods
+// are preserved in a ConfigMap owned by the owner of the Job before the Job is deleted.
+type Controller struct {
+	deps *controller.Dependencies
+	// now is used for testing
+	now func() time.Time
+}
+
+// NewController creates a job gc controller.
+func NewController(deps *controller.Dependencies) *Controller {
+	return &Controller{
+		deps: deps,
+		now:  time.Now,
+	}
+}
+
+// Name returns the name of the job gc controller
+func (c *Controller) Name() string {
+	return "jobgc"
+}
+
+// Run runs the gc loop until stopCh is closed, workers is ignored as the gc is done in one goroutine
+func (c *Controller) Run(_ int, stopCh <-chan struct{}) {
+	defer utilruntime.HandleCrash()
+
+	klog.Info("Starting jobgc controller")
+	defer klog.Info("Shutting down jobgc controller")
+
+	wait.Until(func() {
+		err := c.sync()
+		controller.ObserveReconcile(c.Name(), err)
+		if err != nil {
+			utilruntime.HandleError(fmt.Errorf("jobgc: %v", err))
+		}
+	}, gcInterval, stopCh)
+}
+
+func (c *Controller) enabled() bool {
+	cfg := c.deps.CLIConfig
+	return cfg.JobTTLSecondsAfterFinished > 0 || cfg.BackupJobHistoryLimit >= 0 || cfg.RestoreJobHistoryLimit >= 0 ||
+		cfg.CleanJobHistoryLimit >= 0 || cfg.InitializerJobHistoryLimit >= 0
+}
+
+// historyLimit returns the history limit of the Jobs of the component, a negative value means unlimited
+func (c *Controller) historyLimit(component string) int {
+	cfg := c.deps.CLIConfig
+	switch component {
+	case label.BackupJobLabelVal:
+		return cfg.BackupJobHistoryLimit
+	case label.RestoreJobLabelVal:
+		return cfg.RestoreJobHistoryLimit
+	case label.CleanJobLabelVal:
+		return cfg.CleanJobHistoryLimit
+	case label.InitJobLabelVal:
+		return cfg.InitializerJobHistoryLimit
+	}
+	return -1
+}
+
+func (c *Controller) sync() error {
+	if !c.enabled() {
+		return nil
+	}
+
+	req, err := labels.NewRequirement(label.ComponentLabelKey, selection.In,
+		[]string{label.BackupJobLabelVal, label.RestoreJobLabelVal, label.CleanJobLabelVal, label.InitJobLabelVal})
+	if err != nil {
+		return err
+	}
+	jobs, err := c.deps.JobLister.List(labels.NewSelector().Add(*req))
+	if err != nil {
+		return fmt.Errorf("failed to list jobs, error: %v", err)
+	}
+
+	// the finished jobs grouped by namespace and component
+	groups := map[string][]*batchv1.Job{}
+	for _, job := range jobs {
+		if _, finished := jobFinishedTime(job); !finished || job.DeletionTimestamp != nil || !ownedByOperator(job) {
+			continue
+		}
+		key := job.Namespace + "/" + job.Labels[label.ComponentLabelKey]
+		groups[key] = append(groups[key], job)
+	}
+
+	ttl := time.Duration(c.deps.CLIConfig.JobTTLSecondsAfterFinished) * time.Second
+	var errs []error
+	for _, group := range groups {
+		// the latest finished job first
+		sort.Slice(group, func(i, j int) bool {
+			ti, _ := jobFinishedTime(group[i])
+			tj, _ := jobFinishedTime(group[j])
+			return ti.After(tj)
+		})
+		limit := c.historyLimit(group[0].Labels[label.ComponentLabelKey])
+		for i, job := range group {
+			finishedTime, _ := jobFinishedTime(job)
+			expired := ttl > 0 && c.now().Sub(finishedTime) >= ttl
+			overLimit := limit >= 0 && i >= limit
+			if !expired && !overLimit {
+				continue
+			}
+			if err := c.deleteJob(job); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%v", errs)
+	}
+	return nil
+}
+
+// deleteJob preserves the logs of the pods of the job and deletes the job
+func (c *Controller) deleteJob(job *batchv1.Job) error {
+	ref := metav1.GetControllerOf(job)
+	if ref == nil {
+		// the job has been orphaned after it is listed
+		return nil
+	}
+	owner, err := c.getOwner(job, ref)
+	if err != nil {
+		return err
+	}
+	if owner == nil {
+		// the owner is being deleted, the job is garbage collected with it
+		return nil
+	}
+
+	if c.deps.CLIConfig.JobLogTailLines > 0 {
+		// the job is kept and retried in the next round if its logs can not be preserved
+		cmName, err := c.preserveJobLogs(job, ref)
+		if err != nil {
+			return err
+		}
+		if cmName != "" {
+			c.deps.Recorder.Eventf(owner, corev1.EventTypeNormal, "JobLogPreserved",
+				"the logs of finished job %s are preserved in configmap %s", job.Name, cmName)
+		}
+	}
+
+	klog.Infof("jobgc: delete finished %s job %s/%s", job.Labels[label.ComponentLabelKey], job.Namespace, job.Name)
+	return c.deps.JobControl.DeleteJob(owner, job)
+}
+
+// preserveJobLogs saves the last lines of the logs of the pods of the job into a ConfigMap owned
+// by the owner of the job, it returns the name of the ConfigMap or "" if the job has no pods.
+func (c *Controller) preserveJobLogs(job *batchv1.Job, ref *metav1.OwnerReference) (string, error) {
+	ns := job.Namespace
+	selector, err := metav1.LabelSelectorAsSelector(job.Spec.Selector)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse the selector of job %s/%s, error: %v", ns, job.Name, err)
+	}
+	pods, err := c.deps.PodLister.Pods(ns).List(selector)
+	if err != nil {
+		return "", fmt.Errorf("failed to list the pods of job %s/%s, error: %v", ns, job.Name, err)
+	}
+	if len(pods) == 0 {
+		return "", nil
+	}
+
+	data := map[string]string{}
+	tailLines := int64(c.deps.CLIConfig.JobLogTailLines)
+	limitBytes := int64(maxJobLogBytes)
+	for _, pod := range pods {
+		logs, err := c.getPodLogs(pod, &corev1.PodLogOptions{TailLines: &tailLines, LimitBytes: &limitBytes})
+		if err != nil {
+			return "", fmt.Errorf("failed to get the logs of pod %s/%s of job %s, error: %v", ns, pod.Name, job.Name, err)
+		}
+		data[pod.Name+".log"] = logs
+	}
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            job.Name + jobLogConfigMapSuffix,
+			Namespace:       ns,
+			Labels:          job.Labels,
+			OwnerReferences: []metav1.OwnerReference{*ref},
+		},
+		Data: data,
+	}
+	_, err = c.deps.KubeClientset.CoreV1().ConfigMaps(ns).Create(context.TODO(), cm, metav1.CreateOptions{})
+	if errors.IsAlreadyExists(err) {
+		_, err = c.deps.KubeClientset.CoreV1().ConfigMaps(ns).Update(context.TODO(), cm, metav1.UpdateOptions{})
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to preserve the logs of job %s/%s in configmap %s, error: %v", ns, job.Name, cm.Name, err)
+	}
+	return cm.Name, nil
+}
+
+func (c *Controller) getPodLogs(pod *corev1.Pod, opts *corev1.PodLogOptions) (string, error) {
+	stream, err := c.deps.KubeClientset.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, opts).Stream(context.TODO())
+	if err != nil {
+		return "", err
+	}
+	defer stream.Close()
+	logs, err := io.ReadAll(stream)
+	if err != nil {
+		return "", err
+	}
+	return string(logs), nil
+}
+
+// getOwner returns the Backup, Restore or TidbInitializer referred by ref that owns the job, or nil if it's being deleted
+func (c *Controller) getOwner(job *batchv1.Job, ref *metav1.OwnerReference) (runtime.Object, error) {
+	ns := job.Namespace
+	var (
+		owner metav1.Object
+		err   error
+	)
+	switch ref.Kind {
+	case v1alpha1.BackupKind:
+		owner, err = c.deps.BackupLister.Backups(ns).Get(ref.Name)
+	case v1alpha1.RestoreKind:
+		owner, err = c.deps.RestoreLister.Restores(ns).Get(ref.Name)
+	case v1alpha1.TiDBInitializerKind:
+		owner, err = c.deps.TiDBInitializerLister.TidbInitializers(ns).Get(ref.Name)
+	default:
+		return nil, fmt.Errorf("job %s/%s is owned by unknown kind %s", ns, job.Name, ref.Kind)
+	}
+	if errors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the owner %s %s of job %s/%s, error: %v", ref.Kind, ref.Name, ns, job.Name, err)
+	}
+	if owner.GetUID() != ref.UID || owner.GetDeletionTimestamp() != nil {
+		return nil, nil
+	}
+	return owner.(runtime.Object), nil
+}
+
+// ownedByOperator returns whether the job is owned by a custom resource of the operator
+func ownedByOperator(job *batchv1.Job) bool {
+	ref := metav1.GetControllerOf(job)
+	return ref != nil && ref.APIVersion == v1alpha1.SchemeGroupVersion.String()
+}
+
+// jobFinishedTime returns the time when the job completed or failed
+func jobFinishedTime(job *batchv1.Job) (time.Time, bool) {
+	for _, c := range job.Status.Conditions {
+		if (c.Type == batchv1.JobComplete || c.Type == batchv1.JobFailed) && c.Status == corev1.ConditionTrue {
+			if c.Type == batchv1.JobComplete && job.Status.CompletionTime != nil {
+				return job.Status.CompletionTime.Time, true
+			}
+			return c.LastTransitionTime.Time, true
+		}
+	}
+	return time.Time{}, false
+}
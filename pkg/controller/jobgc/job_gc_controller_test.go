@@ -0,0 +1,236 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jobgc
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// recordJobControl records the names of the deleted jobs
+type recordJobControl struct {
+	controller.JobControlInterface
+	deleted sets.String
+}
+
+func (c *recordJobControl) DeleteJob(_ runtime.Object, job *batchv1.Job) error {
+	c.deleted.Insert(job.Name)
+	return nil
+}
+
+func TestJobGCSync(t *testing.T) {
+	g := NewGomegaWithT(t)
+	now := time.Now()
+
+	type testcase struct {
+		name        string
+		ttlSeconds  int
+		limit       int
+		ownerExists bool
+		expected    []string
+	}
+	tests := []testcase{
+		{
+			name:        "disabled",
+			ttlSeconds:  0,
+			limit:       -1,
+			ownerExists: true,
+			expected:    []string{},
+		},
+		{
+			name:        "history limit",
+			ttlSeconds:  0,
+			limit:       1,
+			ownerExists: true,
+			expected:    []string{"job-1", "job-2"},
+		},
+		{
+			name:        "history limit 0",
+			ttlSeconds:  0,
+			limit:       0,
+			ownerExists: true,
+			expected:    []string{"job-0", "job-1", "job-2"},
+		},
+		{
+			name:        "ttl",
+			ttlSeconds:  int((90 * time.Minute).Seconds()),
+			limit:       -1,
+			ownerExists: true,
+			expected:    []string{"job-1", "job-2"},
+		},
+		{
+			name:        "ttl and history limit",
+			ttlSeconds:  int((150 * time.Minute).Seconds()),
+			limit:       1,
+			ownerExists: true,
+			expected:    []string{"job-1", "job-2"},
+		},
+		{
+			name:        "owner is deleted",
+			ttlSeconds:  0,
+			limit:       0,
+			ownerExists: false,
+			expected:    []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Log(tt.name)
+
+		deps := controller.NewFakeDependencies()
+		deps.CLIConfig.JobTTLSecondsAfterFinished = tt.ttlSeconds
+		deps.CLIConfig.BackupJobHistoryLimit = tt.limit
+		jobControl := &recordJobControl{deleted: sets.NewString()}
+		deps.JobControl = jobControl
+		c := NewController(deps)
+		c.now = func() time.Time { return now }
+
+		jobIndexer := deps.KubeInformerFactory.Batch().V1().Jobs().Informer().GetIndexer()
+		backupIndexer := deps.InformerFactory.Pingcap().V1alpha1().Backups().Informer().GetIndexer()
+		for i := 0; i < 3; i++ {
+			backup := newBackup(fmt.Sprintf("backup-%d", i))
+			if tt.ownerExists {
+				g.Expect(backupIndexer.Add(backup)).To(Succeed())
+			}
+			// job-0 finished 1 hour ago, job-1 2 hours ago and job-2 3 hours ago
+			job := newFinishedJob(backup, fmt.Sprintf("job-%d", i), now.Add(-time.Duration(i+1)*time.Hour))
+			g.Expect(jobIndexer.Add(job)).To(Succeed())
+		}
+		// running jobs are never deleted
+		running := newFinishedJob(newBackup("backup-running"), "job-running", now)
+		running.Status = batchv1.JobStatus{}
+		g.Expect(jobIndexer.Add(running)).To(Succeed())
+
+		g.Expect(c.sync()).To(Succeed())
+		g.Expect(jobControl.deleted.List()).To(Equal(tt.expected))
+	}
+}
+
+func TestJobGCPreserveJobLogs(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	deps := controller.NewFakeDependencies()
+	c := NewController(deps)
+	job := newFinishedJob(newBackup("backup"), "job", time.Now())
+
+	// no pod, no logs are preserved
+	cmName, err := c.preserveJobLogs(job, metav1.GetControllerOf(job))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(cmName).To(BeEmpty())
+
+	podIndexer := deps.KubeInformerFactory.Core().V1().Pods().Informer().GetIndexer()
+	g.Expect(podIndexer.Add(&corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "job-abcde",
+			Namespace: job.Namespace,
+			Labels:    job.Spec.Selector.MatchLabels,
+		},
+	})).To(Succeed())
+	cmName, err = c.preserveJobLogs(job, metav1.GetControllerOf(job))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(cmName).To(Equal("job-log"))
+	cm, err := deps.KubeClientset.CoreV1().ConfigMaps(job.Namespace).Get(context.TODO(), cmName, metav1.GetOptions{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(cm.Data).To(HaveKey("job-abcde.log"))
+	g.Expect(cm.OwnerReferences).To(Equal(job.OwnerReferences))
+
+	// preserve again updates the configmap
+	_, err = c.preserveJobLogs(job, metav1.GetControllerOf(job))
+	g.Expect(err).NotTo(HaveOccurred())
+}
+
+func TestJobGCDeleteOrphanedJob(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	deps := controller.NewFakeDependencies()
+	jobControl := &recordJobControl{deleted: sets.NewString()}
+	deps.JobControl = jobControl
+	c := NewController(deps)
+
+	// the job is orphaned after it is listed
+	job := newFinishedJob(newBackup("backup"), "job", time.Now())
+	job.OwnerReferences = nil
+	g.Expect(c.deleteJob(job)).To(Succeed())
+	g.Expect(jobControl.deleted.List()).To(BeEmpty())
+}
+
+func TestJobFinishedTime(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	finished := time.Now().Add(-time.Hour).Truncate(time.Second)
+	job := &batchv1.Job{}
+	_, ok := jobFinishedTime(job)
+	g.Expect(ok).To(BeFalse())
+
+	job.Status.Conditions = []batchv1.JobCondition{
+		{Type: batchv1.JobFailed, Status: corev1.ConditionTrue, LastTransitionTime: metav1.NewTime(finished)},
+	}
+	ft, ok := jobFinishedTime(job)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(ft).To(Equal(finished))
+
+	job.Status.Conditions[0].Type = batchv1.JobComplete
+	job.Status.CompletionTime = &metav1.Time{Time: finished.Add(-time.Minute)}
+	ft, ok = jobFinishedTime(job)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(ft).To(Equal(finished.Add(-time.Minute)))
+}
+
+func newBackup(name string) *v1alpha1.Backup {
+	return &v1alpha1.Backup{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       v1alpha1.BackupKind,
+			APIVersion: v1alpha1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: corev1.NamespaceDefault,
+			UID:       types.UID("uid-" + name),
+		},
+	}
+}
+
+func newFinishedJob(backup *v1alpha1.Backup, name string, finished time.Time) *batchv1.Job {
+	jobLabels := label.NewBackup().Instance("test").BackupJob().Backup(backup.Name)
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			Namespace:       backup.Namespace,
+			Labels:          jobLabels,
+			OwnerReferences: []metav1.OwnerReference{controller.GetBackupOwnerRef(backup)},
+		},
+		Spec: batchv1.JobSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"controller-uid": name}},
+		},
+		Status: batchv1.JobStatus{
+			CompletionTime: &metav1.Time{Time: finished},
+			Conditions: []batchv1.JobCondition{
+				{Type: batchv1.JobComplete, Status: corev1.ConditionTrue},
+			},
+		},
+	}
+}
@@ -0,0 +1,260 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/yaml"
+
+	"github.com/pingcap/tidb-operator/pkg/features"
+)
+
+// OperatorConfiguration holds the tuning of tidb-controller-manager, it is read from the file
+// specified by `--config` which is usually mounted from a ConfigMap.
+//
+// Fields left unset keep the values of the command line flags.
+type OperatorConfiguration struct {
+	// The following fields only take effect when tidb-controller-manager starts.
+
+	// Workers is the number of workers that are allowed to sync concurrently.
+	Workers *int `json:"workers,omitempty"`
+	// ResyncDuration is the resync time of informer
+	ResyncDuration *metav1.Duration `json:"resyncDuration,omitempty"`
+	// Selector is used to filter CR labels to decide what resources should be watched and synced by controller
+	Selector *string `json:"selector,omitempty"`
+	// KubeClientQPS indicates the maximum QPS to the kubenetes API server from client.
+	KubeClientQPS *float64 `json:"kubeClientQPS,omitempty"`
+	// KubeClientBurst indicates the maximum burst for throttle to the kubenetes API server from client.
+	KubeClientBurst *int `json:"kubeClientBurst,omitempty"`
+	// FeatureGates is a map of feature names to bools that enable or disable features.
+	FeatureGates map[string]bool `json:"featureGates,omitempty"`
+
+	// The following fields are reloaded when the file changes.
+
+	AutoFailover          *bool            `json:"autoFailover,omitempty"`
+	PDFailoverPeriod      *metav1.Duration `json:"pdFailoverPeriod,omitempty"`
+	TiKVFailoverPeriod    *metav1.Duration `json:"tikvFailoverPeriod,omitempty"`
+	TiDBFailoverPeriod    *metav1.Duration `json:"tidbFailoverPeriod,omitempty"`
+	TiFlashFailoverPeriod *metav1.Duration `json:"tiflashFailoverPeriod,omitempty"`
+	MasterFailoverPeriod  *metav1.Duration `json:"dmMasterFailoverPeriod,omitempty"`
+	WorkerFailoverPeriod  *metav1.Duration `json:"dmWorkerFailoverPeriod,omitempty"`
+	// DetectNodeFailure enables detection of node failures for stateful failure pods for recovery
+	DetectNodeFailure *bool `json:"detectNodeFailure,omitempty"`
+	// PodHardRecoveryPeriod is the hard recovery period for a failure pod
+	PodHardRecoveryPeriod *metav1.Duration `json:"podHardRecoveryPeriod,omitempty"`
+	// TiDBBackupManagerImage is the image of backup manager tool
+	TiDBBackupManagerImage *string `json:"tidbBackupManagerImage,omitempty"`
+	// TiDBDiscoveryImage is the image of the tidb discovery service
+	TiDBDiscoveryImage *string `json:"tidbDiscoveryImage,omitempty"`
+}
+
+// ParseOperatorConfiguration parses and validates the operator configuration,
+// unknown fields are rejected to catch typos.
+func ParseOperatorConfiguration(data []byte) (*OperatorConfiguration, error) {
+	oc := &OperatorConfiguration{}
+	if err := yaml.UnmarshalStrict(data, oc); err != nil {
+		return nil, fmt.Errorf("failed to parse operator configuration: %v", err)
+	}
+	if err := oc.Validate(); err != nil {
+		return nil, err
+	}
+	return oc, nil
+}
+
+// Validate checks whether the operator configuration is valid
+func (oc *OperatorConfiguration) Validate() error {
+	var errs []string
+	if oc.Workers != nil && *oc.Workers <= 0 {
+		errs = append(errs, "workers must be greater than 0")
+	}
+	if oc.KubeClientQPS != nil && *oc.KubeClientQPS < 0 {
+		errs = append(errs, "kubeClientQPS must not be negative")
+	}
+	if oc.KubeClientBurst != nil && *oc.KubeClientBurst < 0 {
+		errs = append(errs, "kubeClientBurst must not be negative")
+	}
+	for _, d := range []struct {
+		name     string
+		duration *metav1.Duration
+	}{
+		{"resyncDuration", oc.ResyncDuration},
+		{"pdFailoverPeriod", oc.PDFailoverPeriod},
+		{"tikvFailoverPeriod", oc.TiKVFailoverPeriod},
+		{"tidbFailoverPeriod", oc.TiDBFailoverPeriod},
+		{"tiflashFailoverPeriod", oc.TiFlashFailoverPeriod},
+		{"dmMasterFailoverPeriod", oc.MasterFailoverPeriod},
+		{"dmWorkerFailoverPeriod", oc.WorkerFailoverPeriod},
+		{"podHardRecoveryPeriod", oc.PodHardRecoveryPeriod},
+	} {
+		if d.duration != nil && d.duration.Duration < 0 {
+			errs = append(errs, fmt.Sprintf("%s must not be negative", d.name))
+		}
+	}
+	if oc.TiDBBackupManagerImage != nil && *oc.TiDBBackupManagerImage == "" {
+		errs = append(errs, "tidbBackupManagerImage must not be empty")
+	}
+	if oc.TiDBDiscoveryImage != nil && *oc.TiDBDiscoveryImage == "" {
+		errs = append(errs, "tidbDiscoveryImage must not be empty")
+	}
+	for name := range oc.FeatureGates {
+		if !features.IsKnown(name) {
+			errs = append(errs, fmt.Sprintf("unknown feature gate %q", name))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid operator configuration: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// LoadOperatorConfiguration reads the operator configuration file specified by `--config`
+// and applies all the fields set in it, it does nothing if no file is specified.
+// It should be called before the clients and the controllers are created.
+func (c *CLIConfig) LoadOperatorConfiguration() error {
+	if c.ConfigFile == "" {
+		return nil
+	}
+	data, err := os.ReadFile(c.ConfigFile)
+	if err != nil {
+		return fmt.Errorf("failed to read operator configuration file %s: %v", c.ConfigFile, err)
+	}
+	oc, err := ParseOperatorConfiguration(data)
+	if err != nil {
+		return err
+	}
+
+	if oc.Workers != nil {
+		c.Workers = *oc.Workers
+	}
+	if oc.ResyncDuration != nil {
+		c.ResyncDuration = oc.ResyncDuration.Duration
+	}
+	if oc.Selector != nil {
+		c.Selector = *oc.Selector
+	}
+	if oc.KubeClientQPS != nil {
+		c.KubeClientQPS = *oc.KubeClientQPS
+	}
+	if oc.KubeClientBurst != nil {
+		c.KubeClientBurst = *oc.KubeClientBurst
+	}
+	if len(oc.FeatureGates) > 0 {
+		features.DefaultFeatureGate.SetFromMap(oc.FeatureGates)
+	}
+	c.applyReloadableConfiguration(oc)
+
+	c.configData = data
+	c.loadedConfig = oc
+	klog.Infof("operator configuration is loaded from %s", c.ConfigFile)
+	return nil
+}
+
+// WatchOperatorConfiguration checks the operator configuration file periodically, and applies
+// the fields which can take effect at runtime when the file changes. Changes of the other fields
+// are only logged as they require a restart of tidb-controller-manager.
+func (c *CLIConfig) WatchOperatorConfiguration(stopCh <-chan struct{}) {
+	if c.ConfigFile == "" {
+		return
+	}
+	wait.Until(c.reloadOperatorConfiguration, c.ConfigReloadInterval, stopCh)
+}
+
+func (c *CLIConfig) reloadOperatorConfiguration() {
+	data, err := os.ReadFile(c.ConfigFile)
+	if err != nil {
+		klog.Errorf("failed to read operator configuration file %s: %v", c.ConfigFile, err)
+		return
+	}
+	if bytes.Equal(data, c.configData) {
+		return
+	}
+	oc, err := ParseOperatorConfiguration(data)
+	if err != nil {
+		// keep running with the current configuration
+		klog.Errorf("ignore the change of operator configuration file %s: %v", c.ConfigFile, err)
+		return
+	}
+
+	if c.loadedConfig != nil {
+		old := c.loadedConfig
+		for _, f := range []struct {
+			name     string
+			old, new interface{}
+		}{
+			{"workers", old.Workers, oc.Workers},
+			{"resyncDuration", old.ResyncDuration, oc.ResyncDuration},
+			{"selector", old.Selector, oc.Selector},
+			{"kubeClientQPS", old.KubeClientQPS, oc.KubeClientQPS},
+			{"kubeClientBurst", old.KubeClientBurst, oc.KubeClientBurst},
+			{"featureGates", old.FeatureGates, oc.FeatureGates},
+		} {
+			if !reflect.DeepEqual(f.old, f.new) {
+				klog.Warningf("%s of operator configuration is changed, restart tidb-controller-manager to take effect", f.name)
+			}
+		}
+	}
+	c.applyReloadableConfiguration(oc)
+
+	c.configData = data
+	c.loadedConfig = oc
+	klog.Infof("operator configuration is reloaded from %s", c.ConfigFile)
+}
+
+// applyReloadableConfiguration applies the fields which can take effect at runtime
+func (c *CLIConfig) applyReloadableConfiguration(oc *OperatorConfiguration) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if oc.AutoFailover != nil {
+		c.AutoFailover = *oc.AutoFailover
+	}
+	if oc.PDFailoverPeriod != nil {
+		c.PDFailoverPeriod = oc.PDFailoverPeriod.Duration
+	}
+	if oc.TiKVFailoverPeriod != nil {
+		c.TiKVFailoverPeriod = oc.TiKVFailoverPeriod.Duration
+	}
+	if oc.TiDBFailoverPeriod != nil {
+		c.TiDBFailoverPeriod = oc.TiDBFailoverPeriod.Duration
+	}
+	if oc.TiFlashFailoverPeriod != nil {
+		c.TiFlashFailoverPeriod = oc.TiFlashFailoverPeriod.Duration
+	}
+	if oc.MasterFailoverPeriod != nil {
+		c.MasterFailoverPeriod = oc.MasterFailoverPeriod.Duration
+	}
+	if oc.WorkerFailoverPeriod != nil {
+		c.WorkerFailoverPeriod = oc.WorkerFailoverPeriod.Duration
+	}
+	if oc.DetectNodeFailure != nil {
+		c.DetectNodeFailure = *oc.DetectNodeFailure
+	}
+	if oc.PodHardRecoveryPeriod != nil {
+		c.PodHardRecoveryPeriod = oc.PodHardRecoveryPeriod.Duration
+	}
+	if oc.TiDBBackupManagerImage != nil {
+		c.TiDBBackupManagerImage = *oc.TiDBBackupManagerImage
+	}
+	if oc.TiDBDiscoveryImage != nil {
+		c.TiDBDiscoveryImage = *oc.TiDBDiscoveryImage
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestParseOperatorConfiguration(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tests := []struct {
+		name    string
+		data    string
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			data: `
+workers: 10
+resyncDuration: 1m
+featureGates:
+  AutoScaling: true
+autoFailover: false
+tikvFailoverPeriod: 10m
+tidbDiscoveryImage: pingcap/tidb-operator:v1.5.0
+`,
+		},
+		{
+			name:    "unknown field",
+			data:    "worker: 10",
+			wantErr: true,
+		},
+		{
+			name:    "invalid workers",
+			data:    "workers: 0",
+			wantErr: true,
+		},
+		{
+			name:    "negative duration",
+			data:    "pdFailoverPeriod: -1m",
+			wantErr: true,
+		},
+		{
+			name:    "empty image",
+			data:    `tidbBackupManagerImage: ""`,
+			wantErr: true,
+		},
+		{
+			name:    "unknown feature gate",
+			data:    "featureGates: {Unknown: true}",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		_, err := ParseOperatorConfiguration([]byte(tt.data))
+		if tt.wantErr {
+			g.Expect(err).To(HaveOccurred(), tt.name)
+		} else {
+			g.Expect(err).NotTo(HaveOccurred(), tt.name)
+		}
+	}
+}
+
+func TestLoadAndReloadOperatorConfiguration(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	file := filepath.Join(t.TempDir(), "config.yaml")
+	g.Expect(os.WriteFile(file, []byte("workers: 10\ntikvFailoverPeriod: 10m\n"), 0644)).To(Succeed())
+
+	c := DefaultCLIConfig()
+	c.ConfigFile = file
+	g.Expect(c.LoadOperatorConfiguration()).To(Succeed())
+	g.Expect(c.Workers).To(Equal(10))
+	g.Expect(c.GetTiKVFailoverPeriod()).To(Equal(10 * time.Minute))
+	g.Expect(c.IsAutoFailover()).To(BeTrue())
+
+	// reloadable fields take effect, workers requires a restart
+	g.Expect(os.WriteFile(file, []byte("workers: 20\nautoFailover: false\ntikvFailoverPeriod: 15m\n"), 0644)).To(Succeed())
+	c.reloadOperatorConfiguration()
+	g.Expect(c.Workers).To(Equal(10))
+	g.Expect(c.GetTiKVFailoverPeriod()).To(Equal(15 * time.Minute))
+	g.Expect(c.IsAutoFailover()).To(BeFalse())
+
+	// invalid configuration is ignored
+	g.Expect(os.WriteFile(file, []byte("tikvFailoverPeriod: -1m\n"), 0644)).To(Succeed())
+	c.reloadOperatorConfiguration()
+	g.Expect(c.GetTiKVFailoverPeriod()).To(Equal(15 * time.Minute))
+
+	// no file specified
+	c = DefaultCLIConfig()
+	g.Expect(c.LoadOperatorConfiguration()).To(Succeed())
+	g.Expect(c.Workers).To(Equal(5))
+}
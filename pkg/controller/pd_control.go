@@ -23,11 +23,12 @@ func getPDClientFromService(pdControl pdapi.PDControlInterface, tc *v1alpha1.Tid
 	if tc.Heterogeneous() && tc.WithoutLocalPD() {
 		return pdControl.GetPDClient(pdapi.Namespace(tc.Spec.Cluster.Namespace), tc.Spec.Cluster.Name, tc.IsTLSClusterEnabled(),
 			pdapi.TLSCertFromTC(pdapi.Namespace(tc.GetNamespace()), tc.GetName()),
+			pdapi.TLSFromCluster(pdapi.Namespace(tc.Namespace), tc.Spec.TLSCluster),
 			pdapi.ClusterRef(tc.Spec.Cluster.ClusterDomain),
 			pdapi.UseHeadlessService(tc.Spec.AcrossK8s),
 		)
 	}
-	return pdControl.GetPDClient(pdapi.Namespace(tc.GetNamespace()), tc.GetName(), tc.IsTLSClusterEnabled())
+	return pdControl.GetPDClient(pdapi.Namespace(tc.GetNamespace()), tc.GetName(), tc.IsTLSClusterEnabled(), pdapi.TLSFromCluster(pdapi.Namespace(tc.Namespace), tc.Spec.TLSCluster))
 }
 
 // GetPDClient tries to return an available PDClient
@@ -48,7 +49,8 @@ func GetPDClient(pdControl pdapi.PDControlInterface, tc *v1alpha1.TidbCluster) p
 	}
 
 	for _, pdMember := range tc.Status.PD.PeerMembers {
-		pdPeerClient := pdControl.GetPDClient(pdapi.Namespace(tc.GetNamespace()), tc.GetName(), tc.IsTLSClusterEnabled(), pdapi.SpecifyClient(pdMember.ClientURL, pdMember.Name))
+		pdPeerClient := pdControl.GetPDClient(pdapi.Namespace(tc.GetNamespace()), tc.GetName(), tc.IsTLSClusterEnabled(),
+			pdapi.SpecifyClient(pdMember.ClientURL, pdMember.Name), pdapi.TLSFromCluster(pdapi.Namespace(tc.Namespace), tc.Spec.TLSCluster))
 		_, err := pdPeerClient.GetHealth()
 		if err == nil {
 			return pdPeerClient
@@ -0,0 +1,114 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	httputil "github.com/pingcap/tidb-operator/pkg/util/http"
+)
+
+// PrometheusControlInterface queries the metrics from Prometheus
+type PrometheusControlInterface interface {
+	// Query evaluates the instant query in the Prometheus of the URL, and returns the sum of the values of the
+	// result vector, which is 0 if the vector is empty
+	Query(prometheusURL, query string) (float64, error)
+}
+
+type prometheusResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+	Data   struct {
+		ResultType string `json:"resultType"`
+		Result     []struct {
+			Value []interface{} `json:"value"`
+		} `json:"result"`
+	} `json:"data"`
+}
+
+// defaultPrometheusControl is default implementation of PrometheusControlInterface.
+type defaultPrometheusControl struct{}
+
+// NewDefaultPrometheusControl returns a defaultPrometheusControl instance
+func NewDefaultPrometheusControl() *defaultPrometheusControl {
+	return &defaultPrometheusControl{}
+}
+
+func (c *defaultPrometheusControl) Query(prometheusURL, query string) (float64, error) {
+	httpClient := &http.Client{Timeout: timeout}
+	apiURL := fmt.Sprintf("%s/api/v1/query?query=%s", strings.TrimSuffix(prometheusURL, "/"), url.QueryEscape(query))
+	body, err := httputil.GetBodyOK(httpClient, apiURL)
+	if err != nil {
+		return 0, err
+	}
+	resp := &prometheusResponse{}
+	if err := json.Unmarshal(body, resp); err != nil {
+		return 0, err
+	}
+	if resp.Status != "success" {
+		return 0, fmt.Errorf("query %q failed, status: %s, error: %s", query, resp.Status, resp.Error)
+	}
+	if resp.Data.ResultType != "vector" {
+		return 0, fmt.Errorf("query %q returns %s instead of vector", query, resp.Data.ResultType)
+	}
+	sum := 0.0
+	for _, r := range resp.Data.Result {
+		if len(r.Value) != 2 {
+			return 0, fmt.Errorf("query %q returns invalid sample %v", query, r.Value)
+		}
+		s, ok := r.Value[1].(string)
+		if !ok {
+			return 0, fmt.Errorf("query %q returns invalid sample %v", query, r.Value)
+		}
+		v, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return 0, fmt.Errorf("query %q returns invalid sample %v: %v", query, r.Value, err)
+		}
+		sum += v
+	}
+	return sum, nil
+}
+
+// FakePrometheusControl is a fake implementation of PrometheusControlInterface.
+type FakePrometheusControl struct {
+	values map[string]float64
+	err    error
+}
+
+// NewFakePrometheusControl returns a FakePrometheusControl instance
+func NewFakePrometheusControl() *FakePrometheusControl {
+	return &FakePrometheusControl{values: map[string]float64{}}
+}
+
+// SetValue sets the value returned by Query for the query
+func (c *FakePrometheusControl) SetValue(query string, value float64) {
+	c.values[query] = value
+}
+
+// SetError sets the error returned by Query
+func (c *FakePrometheusControl) SetError(err error) {
+	c.err = err
+}
+
+func (c *FakePrometheusControl) Query(_, query string) (float64, error) {
+	if c.err != nil {
+		return 0, c.err
+	}
+	return c.values[query], nil
+}
@@ -301,7 +301,7 @@ func (c *FakePVCControl) UpdateMetaInfo(_ runtime.Object, pvc *corev1.Persistent
 	setIfNotEmpty(pvc.Labels, label.StoreIDLabelKey, pod.Labels[label.StoreIDLabelKey])
 	setIfNotEmpty(pvc.Labels, label.AnnPodNameKey, pod.GetName())
 	setIfNotEmpty(pvc.Annotations, label.AnnPodNameKey, pod.GetName())
-	return nil, c.PVCIndexer.Update(pvc)
+	return pvc, c.PVCIndexer.Update(pvc)
 }
 
 func (c *FakePVCControl) GetPVC(name, namespace string) (*corev1.PersistentVolumeClaim, error) {
@@ -0,0 +1,66 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+var (
+	// e.g. exceeded quota: compute-resources, requested: limits.cpu=2,limits.memory=2Gi, used: ..., limited: ...
+	exceededQuotaRegexp = regexp.MustCompile(`exceeded quota: ([^,\s]+), requested: (\S+), used:`)
+	// e.g. failed quota: compute-resources: must specify limits.cpu,limits.memory
+	failedQuotaRegexp = regexp.MustCompile(`failed quota: ([^:\s]+): must specify (\S+)`)
+	// e.g. maximum cpu usage per Container is 1, but limit is 2
+	limitRangeRegexp = regexp.MustCompile(`(maximum|minimum) (\S+) usage per (Container|Pod|PersistentVolumeClaim)`)
+)
+
+// QuotaExceededResource returns the ResourceQuota or LimitRange and the resources that reject
+// the creation of an object, ok is false if the error is not caused by them.
+func QuotaExceededResource(err error) (string, bool) {
+	if err == nil || !apierrors.IsForbidden(err) {
+		return "", false
+	}
+	return ParseQuotaExceededMessage(err.Error())
+}
+
+// ParseQuotaExceededMessage parses the message of an error or event returned by the
+// ResourceQuota or LimitRange admission, the result doesn't contain the used amount
+// so that it is stable when the usage of the namespace changes.
+func ParseQuotaExceededMessage(msg string) (string, bool) {
+	if m := exceededQuotaRegexp.FindStringSubmatch(msg); m != nil {
+		return fmt.Sprintf("ResourceQuota %s: %s", m[1], resourceNames(m[2])), true
+	}
+	if m := failedQuotaRegexp.FindStringSubmatch(msg); m != nil {
+		return fmt.Sprintf("ResourceQuota %s: %s", m[1], m[2]), true
+	}
+	if m := limitRangeRegexp.FindStringSubmatch(msg); m != nil {
+		return fmt.Sprintf("LimitRange: %s %s per %s", m[1], m[2], m[3]), true
+	}
+	return "", false
+}
+
+// resourceNames strips the amounts from a list like limits.cpu=2,limits.memory=2Gi
+func resourceNames(requested string) string {
+	items := strings.Split(requested, ",")
+	names := make([]string, 0, len(items))
+	for _, item := range items {
+		names = append(names, strings.SplitN(item, "=", 2)[0])
+	}
+	return strings.Join(names, ",")
+}
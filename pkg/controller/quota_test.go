@@ -0,0 +1,74 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"fmt"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestQuotaExceededResource(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	forbidden := func(msg string) error {
+		return apierrors.NewForbidden(schema.GroupResource{Resource: "pods"}, "demo-tikv-3", fmt.Errorf(msg))
+	}
+	tests := []struct {
+		name     string
+		err      error
+		resource string
+		ok       bool
+	}{
+		{
+			name:     "exceeded quota",
+			err:      forbidden("exceeded quota: compute-resources, requested: limits.cpu=2,limits.memory=2Gi, used: limits.cpu=3,limits.memory=3Gi, limited: limits.cpu=4,limits.memory=4Gi"),
+			resource: "ResourceQuota compute-resources: limits.cpu,limits.memory",
+			ok:       true,
+		},
+		{
+			name:     "failed quota",
+			err:      forbidden("failed quota: compute-resources: must specify limits.cpu,limits.memory"),
+			resource: "ResourceQuota compute-resources: limits.cpu,limits.memory",
+			ok:       true,
+		},
+		{
+			name:     "limit range",
+			err:      forbidden("maximum cpu usage per Container is 1, but limit is 2"),
+			resource: "LimitRange: maximum cpu per Container",
+			ok:       true,
+		},
+		{
+			name: "other forbidden error",
+			err:  forbidden("User \"foo\" cannot create resource \"pods\""),
+		},
+		{
+			name: "not a forbidden error",
+			err:  fmt.Errorf("exceeded quota: compute-resources, requested: limits.cpu=2, used: limits.cpu=3, limited: limits.cpu=4"),
+		},
+		{
+			name: "nil error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resource, ok := QuotaExceededResource(tt.err)
+			g.Expect(ok).To(Equal(tt.ok))
+			g.Expect(resource).To(Equal(tt.resource))
+		})
+	}
+}
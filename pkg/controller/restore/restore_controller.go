@@ -23,6 +23,7 @@ import (
 	"github.com/pingcap/tidb-operator/pkg/backup/restore"
 	"github.com/pingcap/tidb-operator/pkg/controller"
 	"github.com/pingcap/tidb-operator/pkg/metrics"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
@@ -101,7 +102,9 @@ func (c *Controller) processNextWorkItem() bool {
 		return false
 	}
 	defer c.queue.Done(key)
-	if err := c.sync(key.(string)); err != nil {
+	err := c.sync(key.(string))
+	controller.ObserveReconcile(c.Name(), err)
+	if err != nil {
 		if perrors.Find(err, controller.IsRequeueError) != nil {
 			klog.Infof("Restore: %v, still need sync: %v, requeuing", key.(string), err)
 			c.queue.AddRateLimited(key)
@@ -200,6 +203,11 @@ func (c *Controller) updateRestore(cur interface{}) {
 	}
 
 	if v1alpha1.IsRestoreScheduled(newRestore) || v1alpha1.IsRestoreRunning(newRestore) {
+		if newRestore.IsCheckpointEnabled() {
+			// the failed pods are recreated by the job to resume from the checkpoint until it runs out of the attempts
+			c.detectRestoreJobFailure(newRestore)
+			return
+		}
 		selector, err := label.NewRestore().Instance(newRestore.GetInstanceName()).RestoreJob().Restore(name).Selector()
 		if err != nil {
 			klog.Errorf("Fail to generate selector for restore %s/%s, %v", ns, name, err)
@@ -233,6 +241,36 @@ func (c *Controller) updateRestore(cur interface{}) {
 	c.enqueueRestore(newRestore)
 }
 
+// detectRestoreJobFailure marks the restore as failed if its job has failed
+func (c *Controller) detectRestoreJobFailure(restore *v1alpha1.Restore) {
+	ns := restore.GetNamespace()
+	name := restore.GetName()
+	jobName := restore.GetRestoreJobName()
+
+	job, err := c.deps.JobLister.Jobs(ns).Get(jobName)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			klog.Errorf("Fail to get job %s for restore %s/%s, %v", jobName, ns, name, err)
+		}
+		return
+	}
+	for _, cond := range job.Status.Conditions {
+		if cond.Type == batchv1.JobFailed && cond.Status == corev1.ConditionTrue {
+			klog.Infof("restore %s/%s has failed job %s after %d attempts.", ns, name, jobName, restore.Status.Attempts)
+			err = c.control.UpdateCondition(restore, &v1alpha1.RestoreCondition{
+				Type:    v1alpha1.RestoreFailed,
+				Status:  corev1.ConditionTrue,
+				Reason:  "AlreadyFailed",
+				Message: fmt.Sprintf("Job %s has failed, reason %s, message %s", jobName, cond.Reason, cond.Message),
+			})
+			if err != nil {
+				klog.Errorf("Fail to update the condition of restore %s/%s, %v", ns, name, err)
+			}
+			return
+		}
+	}
+}
+
 // enqueueRestore enqueues the given restore in the work queue.
 func (c *Controller) enqueueRestore(obj interface{}) {
 	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
@@ -23,6 +23,7 @@ import (
 	"github.com/pingcap/tidb-operator/pkg/apis/label"
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
 	"github.com/pingcap/tidb-operator/pkg/controller"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/cache"
@@ -65,6 +66,27 @@ func TestRestoreControllerUpdateRestore(t *testing.T) {
 		cache.WaitForCacheSync(context.TODO().Done(), rtc.deps.KubeInformerFactory.Core().V1().Pods().Informer().HasSynced)
 	}
 
+	// enable the checkpoint and create a failed pod and a job in the informers.
+	createCheckpointRestorePod := func(jobFailed bool) func(*GomegaWithT, *Controller, *v1alpha1.Restore) {
+		return func(g *GomegaWithT, rtc *Controller, restore *v1alpha1.Restore) {
+			restore.Spec.BR = &v1alpha1.BRConfig{Cluster: "demo1"}
+			restore.Spec.Checkpoint = &v1alpha1.RestoreCheckpoint{}
+			job := &batchv1.Job{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      restore.GetRestoreJobName(),
+					Namespace: restore.Namespace,
+				},
+			}
+			if jobFailed {
+				job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: corev1.ConditionTrue}}
+			}
+			_, err := rtc.deps.KubeClientset.BatchV1().Jobs(restore.Namespace).Create(context.TODO(), job, metav1.CreateOptions{})
+			g.Expect(err).To(Succeed())
+			createFailedPod(g, rtc, restore)
+			cache.WaitForCacheSync(context.TODO().Done(), rtc.deps.KubeInformerFactory.Batch().V1().Jobs().Informer().HasSynced)
+		}
+	}
+
 	updatingToFail := func(g *GomegaWithT, rtc *Controller, restore *v1alpha1.Restore) {
 		control := rtc.control.(*FakeRestoreControl)
 		condition := control.condition
@@ -133,6 +155,26 @@ func TestRestoreControllerUpdateRestore(t *testing.T) {
 			},
 			afterUpdateFn: updatingToFail,
 		},
+		{
+			name:           "restore resumable from checkpoint has been running with failed pod",
+			conditionType:  v1alpha1.RestoreRunning,
+			beforeUpdateFn: createCheckpointRestorePod(false),
+			expectFn: func(g *GomegaWithT, rtc *Controller) {
+				g.Expect(rtc.queue.Len()).To(Equal(0))
+			},
+			afterUpdateFn: func(g *GomegaWithT, rtc *Controller, restore *v1alpha1.Restore) {
+				g.Expect(rtc.control.(*FakeRestoreControl).condition).To(BeNil())
+			},
+		},
+		{
+			name:           "restore resumable from checkpoint has been running with failed job",
+			conditionType:  v1alpha1.RestoreRunning,
+			beforeUpdateFn: createCheckpointRestorePod(true),
+			expectFn: func(g *GomegaWithT, rtc *Controller) {
+				g.Expect(rtc.queue.Len()).To(Equal(0))
+			},
+			afterUpdateFn: updatingToFail,
+		},
 	}
 
 	for _, tt := range tests {
@@ -21,6 +21,7 @@ import (
 	"github.com/pingcap/tidb-operator/pkg/client/clientset/versioned"
 	informers "github.com/pingcap/tidb-operator/pkg/client/informers/externalversions/pingcap/v1alpha1"
 	listers "github.com/pingcap/tidb-operator/pkg/client/listers/pingcap/v1alpha1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/client-go/tools/cache"
@@ -45,6 +46,12 @@ type RestoreUpdateStatus struct {
 	Progress *float64
 	// ProgressUpdateTime is the progress update time.
 	ProgressUpdateTime *metav1.Time
+	// CurrentProgress is the progress of the running step.
+	CurrentProgress *v1alpha1.RestoreCurrentProgress
+	// Attempts is the number of the attempts of the restore resumable from the checkpoint.
+	Attempts *int32
+	// CheckpointTs is the TS of the checkpoint which the last attempt resumed from.
+	CheckpointTs *string
 }
 
 // RestoreConditionUpdaterInterface enables updating Restore conditions.
@@ -127,6 +134,18 @@ func updateRestoreStatus(status *v1alpha1.RestoreStatus, newStatus *RestoreUpdat
 			isUpdate = true
 		}
 	}
+	if newStatus.CurrentProgress != nil && !apiequality.Semantic.DeepEqual(status.CurrentProgress, newStatus.CurrentProgress) {
+		status.CurrentProgress = newStatus.CurrentProgress.DeepCopy()
+		isUpdate = true
+	}
+	if newStatus.Attempts != nil && status.Attempts != *newStatus.Attempts {
+		status.Attempts = *newStatus.Attempts
+		isUpdate = true
+	}
+	if newStatus.CheckpointTs != nil && status.CheckpointTs != *newStatus.CheckpointTs {
+		status.CheckpointTs = *newStatus.CheckpointTs
+		isUpdate = true
+	}
 
 	return isUpdate
 }
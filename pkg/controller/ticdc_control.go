@@ -49,6 +49,31 @@ type drainCaptureResp struct {
 	CurrentTableCount int `json:"current_table_count"`
 }
 
+// ChangefeedInfo is the changefeed returned by the TiCDC API
+type ChangefeedInfo struct {
+	ID             string           `json:"id"`
+	State          string           `json:"state"`
+	CheckpointTSO  uint64           `json:"checkpoint_tso"`
+	CheckpointTime string           `json:"checkpoint_time"`
+	Error          *ChangefeedError `json:"error"`
+}
+
+// ChangefeedError is the error of a changefeed returned by the TiCDC API
+type ChangefeedError struct {
+	Addr    string `json:"addr"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// ChangefeedConfig is the request to create or update a changefeed
+type ChangefeedConfig struct {
+	ID          string   `json:"changefeed_id"`
+	SinkURI     string   `json:"sink_uri"`
+	StartTs     uint64   `json:"start_ts,omitempty"`
+	TargetTs    uint64   `json:"target_ts,omitempty"`
+	FilterRules []string `json:"filter_rules,omitempty"`
+}
+
 // TiCDCControlInterface is the interface that knows how to manage ticdc captures
 type TiCDCControlInterface interface {
 	// GetStatus returns ticdc's status
@@ -65,6 +90,18 @@ type TiCDCControlInterface interface {
 	// IsHealthy gets the healthy status of TiCDC cluster.
 	// Returns true if the TiCDC cluster is heathy.
 	IsHealthy(tc *v1alpha1.TidbCluster, ordinal int32) (ok bool, err error)
+	// ListChangefeeds returns all the changefeeds of the TiCDC cluster.
+	ListChangefeeds(tc *v1alpha1.TidbCluster, ordinal int32) ([]ChangefeedInfo, error)
+	// CreateChangefeed creates a changefeed.
+	CreateChangefeed(tc *v1alpha1.TidbCluster, ordinal int32, cfg *ChangefeedConfig) error
+	// UpdateChangefeed updates the sink URI, the target TSO and the filter rules of a paused changefeed.
+	UpdateChangefeed(tc *v1alpha1.TidbCluster, ordinal int32, cfg *ChangefeedConfig) error
+	// PauseChangefeed pauses a changefeed.
+	PauseChangefeed(tc *v1alpha1.TidbCluster, ordinal int32, id string) error
+	// ResumeChangefeed resumes a paused changefeed.
+	ResumeChangefeed(tc *v1alpha1.TidbCluster, ordinal int32, id string) error
+	// RemoveChangefeed removes a changefeed.
+	RemoveChangefeed(tc *v1alpha1.TidbCluster, ordinal int32, id string) error
 }
 
 // defaultTiCDCControl is default implementation of TiCDCControlInterface.
@@ -76,7 +113,7 @@ type defaultTiCDCControl struct {
 
 // NewDefaultTiCDCControl returns a defaultTiCDCControl instance
 func NewDefaultTiCDCControl(secretLister corelisterv1.SecretLister) *defaultTiCDCControl {
-	return &defaultTiCDCControl{httpClient: httpClient{secretLister: secretLister}}
+	return &defaultTiCDCControl{httpClient: httpClient{secretLister: secretLister, component: v1alpha1.TiCDCMemberType}}
 }
 
 func (c *defaultTiCDCControl) GetStatus(tc *v1alpha1.TidbCluster, ordinal int32) (*CaptureStatus, error) {
@@ -263,6 +300,80 @@ func (c *defaultTiCDCControl) IsHealthy(tc *v1alpha1.TidbCluster, ordinal int32)
 	return true, nil
 }
 
+func (c *defaultTiCDCControl) ListChangefeeds(tc *v1alpha1.TidbCluster, ordinal int32) ([]ChangefeedInfo, error) {
+	httpClient, err := c.getHTTPClient(tc)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := getBodyOK(httpClient, c.getBaseURL(tc, ordinal)+"/api/v1/changefeeds")
+	if err != nil {
+		return nil, fmt.Errorf("ticdc list changefeeds failed, error: %v", err)
+	}
+	var changefeeds []ChangefeedInfo
+	if err := json.Unmarshal(body, &changefeeds); err != nil {
+		return nil, fmt.Errorf("ticdc list changefeeds failed, unmarshal response error: %v", err)
+	}
+	return changefeeds, nil
+}
+
+func (c *defaultTiCDCControl) CreateChangefeed(tc *v1alpha1.TidbCluster, ordinal int32, cfg *ChangefeedConfig) error {
+	return c.changefeedRequest(tc, ordinal, http.MethodPost, "/api/v1/changefeeds", cfg)
+}
+
+func (c *defaultTiCDCControl) UpdateChangefeed(tc *v1alpha1.TidbCluster, ordinal int32, cfg *ChangefeedConfig) error {
+	// the start TSO can't be updated
+	update := *cfg
+	update.StartTs = 0
+	return c.changefeedRequest(tc, ordinal, http.MethodPut, "/api/v1/changefeeds/"+cfg.ID, &update)
+}
+
+func (c *defaultTiCDCControl) PauseChangefeed(tc *v1alpha1.TidbCluster, ordinal int32, id string) error {
+	return c.changefeedRequest(tc, ordinal, http.MethodPost, "/api/v1/changefeeds/"+id+"/pause", nil)
+}
+
+func (c *defaultTiCDCControl) ResumeChangefeed(tc *v1alpha1.TidbCluster, ordinal int32, id string) error {
+	return c.changefeedRequest(tc, ordinal, http.MethodPost, "/api/v1/changefeeds/"+id+"/resume", nil)
+}
+
+func (c *defaultTiCDCControl) RemoveChangefeed(tc *v1alpha1.TidbCluster, ordinal int32, id string) error {
+	return c.changefeedRequest(tc, ordinal, http.MethodDelete, "/api/v1/changefeeds/"+id, nil)
+}
+
+// changefeedRequest sends a request to the changefeed API, the request succeeds if the status code is 2xx
+func (c *defaultTiCDCControl) changefeedRequest(tc *v1alpha1.TidbCluster, ordinal int32, method, path string, payload interface{}) error {
+	httpClient, err := c.getHTTPClient(tc)
+	if err != nil {
+		return err
+	}
+
+	var body io.Reader
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return fmt.Errorf("ticdc %s %s failed, marshal request error: %v", method, path, err)
+		}
+		body = bytes.NewReader(data)
+	}
+	req, err := http.NewRequest(method, c.getBaseURL(tc, ordinal)+path, body)
+	if err != nil {
+		return fmt.Errorf("ticdc %s %s failed, new request error: %v", method, path, err)
+	}
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("ticdc %s %s failed, request error: %v", method, path, err)
+	}
+	defer httputil.DeferClose(res.Body)
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		msg, _ := io.ReadAll(res.Body)
+		return fmt.Errorf("ticdc %s %s failed, status code: %d, response: %s", method, path, res.StatusCode, string(msg))
+	}
+	return nil
+}
+
 func (c *defaultTiCDCControl) getBaseURL(tc *v1alpha1.TidbCluster, ordinal int32) string {
 	if c.testURL != "" {
 		return c.testURL
@@ -334,6 +445,13 @@ type FakeTiCDCControl struct {
 	DrainCaptureFn func(tc *v1alpha1.TidbCluster, ordinal int32) (tableCount int, retry bool, err error)
 	ResignOwnerFn  func(tc *v1alpha1.TidbCluster, ordinal int32) (ok bool, err error)
 	IsHealthyFn    func(tc *v1alpha1.TidbCluster, ordinal int32) (ok bool, err error)
+
+	ListChangefeedsFn  func(tc *v1alpha1.TidbCluster, ordinal int32) ([]ChangefeedInfo, error)
+	CreateChangefeedFn func(tc *v1alpha1.TidbCluster, ordinal int32, cfg *ChangefeedConfig) error
+	UpdateChangefeedFn func(tc *v1alpha1.TidbCluster, ordinal int32, cfg *ChangefeedConfig) error
+	PauseChangefeedFn  func(tc *v1alpha1.TidbCluster, ordinal int32, id string) error
+	ResumeChangefeedFn func(tc *v1alpha1.TidbCluster, ordinal int32, id string) error
+	RemoveChangefeedFn func(tc *v1alpha1.TidbCluster, ordinal int32, id string) error
 }
 
 // NewFakeTiCDCControl returns a FakeTiCDCControl instance
@@ -368,3 +486,45 @@ func (c *FakeTiCDCControl) IsHealthy(tc *v1alpha1.TidbCluster, ordinal int32) (b
 	}
 	return c.IsHealthyFn(tc, ordinal)
 }
+
+func (c *FakeTiCDCControl) ListChangefeeds(tc *v1alpha1.TidbCluster, ordinal int32) ([]ChangefeedInfo, error) {
+	if c.ListChangefeedsFn == nil {
+		return nil, fmt.Errorf("undefined ListChangefeeds")
+	}
+	return c.ListChangefeedsFn(tc, ordinal)
+}
+
+func (c *FakeTiCDCControl) CreateChangefeed(tc *v1alpha1.TidbCluster, ordinal int32, cfg *ChangefeedConfig) error {
+	if c.CreateChangefeedFn == nil {
+		return fmt.Errorf("undefined CreateChangefeed")
+	}
+	return c.CreateChangefeedFn(tc, ordinal, cfg)
+}
+
+func (c *FakeTiCDCControl) UpdateChangefeed(tc *v1alpha1.TidbCluster, ordinal int32, cfg *ChangefeedConfig) error {
+	if c.UpdateChangefeedFn == nil {
+		return fmt.Errorf("undefined UpdateChangefeed")
+	}
+	return c.UpdateChangefeedFn(tc, ordinal, cfg)
+}
+
+func (c *FakeTiCDCControl) PauseChangefeed(tc *v1alpha1.TidbCluster, ordinal int32, id string) error {
+	if c.PauseChangefeedFn == nil {
+		return fmt.Errorf("undefined PauseChangefeed")
+	}
+	return c.PauseChangefeedFn(tc, ordinal, id)
+}
+
+func (c *FakeTiCDCControl) ResumeChangefeed(tc *v1alpha1.TidbCluster, ordinal int32, id string) error {
+	if c.ResumeChangefeedFn == nil {
+		return fmt.Errorf("undefined ResumeChangefeed")
+	}
+	return c.ResumeChangefeedFn(tc, ordinal, id)
+}
+
+func (c *FakeTiCDCControl) RemoveChangefeed(tc *v1alpha1.TidbCluster, ordinal int32, id string) error {
+	if c.RemoveChangefeedFn == nil {
+		return fmt.Errorf("undefined RemoveChangefeed")
+	}
+	return c.RemoveChangefeedFn(tc, ordinal, id)
+}
@@ -19,10 +19,14 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	neturl "net/url"
+	"strings"
 	"time"
 
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/apis/util/config"
 	httputil "github.com/pingcap/tidb-operator/pkg/util/http"
+	"github.com/prometheus/common/expfmt"
 	corelisterv1 "k8s.io/client-go/listers/core/v1"
 )
 
@@ -31,12 +35,35 @@ const (
 	// NotDDLOwnerError is the error message which was returned when the tidb node is not a ddl owner
 	NotDDLOwnerError = "This node is not a ddl owner, can't be resigned."
 	timeout          = 5 * time.Second
+
+	metricNameQueryTotal = "tidb_server_query_total"
+	labelNameResult      = "result"
+	resultError          = "Error"
 )
 
 type DBInfo struct {
 	IsOwner bool `json:"is_owner"`
 }
 
+// ClusterInfo is the info of all the tidb servers returned by a tidb server
+type ClusterInfo struct {
+	OwnerID        string                 `json:"owner_id"`
+	AllServersInfo map[string]*ServerInfo `json:"all_servers_info"`
+}
+
+// ServerInfo is the info of a tidb server, IP is the advertise address of the server
+type ServerInfo struct {
+	ID         string `json:"ddl_id"`
+	IP         string `json:"ip"`
+	StatusPort uint   `json:"status_port"`
+}
+
+// QueryCounters are the numbers of the SQL statements executed by a tidb server since it started
+type QueryCounters struct {
+	Total  float64
+	Failed float64
+}
+
 // TiDBControlInterface is the interface that knows how to manage tidb peers
 type TiDBControlInterface interface {
 	// GetHealth returns tidb's health info
@@ -45,6 +72,17 @@ type TiDBControlInterface interface {
 	GetInfo(tc *v1alpha1.TidbCluster, ordinal int32) (*DBInfo, error)
 	// SetServerLabels update TiDB's labels config
 	SetServerLabels(tc *v1alpha1.TidbCluster, ordinal int32, labels map[string]string) error
+	// ResignDDLOwner resigns the ddl owner of tidb, it returns true if the tidb is not the ddl owner
+	ResignDDLOwner(tc *v1alpha1.TidbCluster, ordinal int32) (bool, error)
+	// GetDDLOwner returns the name of the tidb pod that is the ddl owner, which is queried from the tidb of the ordinal
+	GetDDLOwner(tc *v1alpha1.TidbCluster, ordinal int32) (string, error)
+	// GetQueryCounters returns the numbers of the SQL statements executed by the tidb since it started
+	GetQueryCounters(tc *v1alpha1.TidbCluster, ordinal int32) (*QueryCounters, error)
+	// GetSettings returns the config in effect of the tidb, which is returned by the /settings API
+	GetSettings(tc *v1alpha1.TidbCluster, ordinal int32) (*config.GenericConfig, error)
+	// SetSettings changes the settings of the tidb online by the /settings API, the keys are the form fields
+	// of the API, e.g. `log_level`
+	SetSettings(tc *v1alpha1.TidbCluster, ordinal int32, settings map[string]string) error
 }
 
 // defaultTiDBControl is default implementation of TiDBControlInterface.
@@ -56,7 +94,7 @@ type defaultTiDBControl struct {
 
 // NewDefaultTiDBControl returns a defaultTiDBControl instance
 func NewDefaultTiDBControl(secretLister corelisterv1.SecretLister) *defaultTiDBControl {
-	return &defaultTiDBControl{httpClient: httpClient{secretLister: secretLister}}
+	return &defaultTiDBControl{httpClient: httpClient{secretLister: secretLister, component: v1alpha1.TiDBMemberType}}
 }
 
 func (c *defaultTiDBControl) GetHealth(tc *v1alpha1.TidbCluster, ordinal int32) (bool, error) {
@@ -121,6 +159,140 @@ func (c *defaultTiDBControl) SetServerLabels(tc *v1alpha1.TidbCluster, ordinal i
 	return err
 }
 
+// ResignDDLOwner resigns the ddl owner of tidb, it returns true if the tidb is not the ddl owner
+func (c *defaultTiDBControl) ResignDDLOwner(tc *v1alpha1.TidbCluster, ordinal int32) (bool, error) {
+	httpClient, err := c.getHTTPClient(tc)
+	if err != nil {
+		return false, err
+	}
+
+	url := fmt.Sprintf("%s/ddl/owner/resign", c.getBaseURL(tc, ordinal))
+	res, err := httpClient.Post(url, "", nil)
+	if err != nil {
+		return false, err
+	}
+	defer httputil.DeferClose(res.Body)
+	if res.StatusCode == http.StatusOK {
+		return false, nil
+	}
+	err = httputil.ReadErrorBody(res.Body)
+	if strings.Contains(err.Error(), NotDDLOwnerError) {
+		return true, nil
+	}
+	return false, err
+}
+
+// GetDDLOwner returns the name of the tidb pod that is the ddl owner, which is queried from the tidb of the ordinal
+func (c *defaultTiDBControl) GetDDLOwner(tc *v1alpha1.TidbCluster, ordinal int32) (string, error) {
+	httpClient, err := c.getHTTPClient(tc)
+	if err != nil {
+		return "", err
+	}
+
+	url := fmt.Sprintf("%s/info/all", c.getBaseURL(tc, ordinal))
+	body, err := getBodyOK(httpClient, url)
+	if err != nil {
+		return "", err
+	}
+	info := ClusterInfo{}
+	if err := json.Unmarshal(body, &info); err != nil {
+		return "", err
+	}
+	owner, ok := info.AllServersInfo[info.OwnerID]
+	if !ok {
+		return "", fmt.Errorf("ddl owner %s is not found in the servers of %s", info.OwnerID, url)
+	}
+	// the advertise address of tidb is the fqdn of the pod
+	return strings.SplitN(owner.IP, ".", 2)[0], nil
+}
+
+// GetQueryCounters returns the numbers of the SQL statements executed by the tidb since it started,
+// which are summed up from the tidb_server_query_total metric of the tidb
+func (c *defaultTiDBControl) GetQueryCounters(tc *v1alpha1.TidbCluster, ordinal int32) (*QueryCounters, error) {
+	httpClient, err := c.getHTTPClient(tc)
+	if err != nil {
+		return nil, err
+	}
+
+	url := fmt.Sprintf("%s/metrics", c.getBaseURL(tc, ordinal))
+	body, err := getBodyOK(httpClient, url)
+	if err != nil {
+		return nil, err
+	}
+	counters, err := ParseQueryCounters(body)
+	if err != nil {
+		return nil, fmt.Errorf("parse the metrics of %s failed: %v", url, err)
+	}
+	return counters, nil
+}
+
+// ParseQueryCounters sums up the query counters from the tidb_server_query_total metric in the metrics
+// exposed by tidb
+func ParseQueryCounters(metrics []byte) (*QueryCounters, error) {
+	parser := expfmt.TextParser{}
+	families, err := parser.TextToMetricFamilies(bytes.NewReader(metrics))
+	if err != nil {
+		return nil, err
+	}
+	counters := &QueryCounters{}
+	family, ok := families[metricNameQueryTotal]
+	if !ok {
+		return counters, nil
+	}
+	for _, m := range family.GetMetric() {
+		value := m.GetCounter().GetValue()
+		counters.Total += value
+		for _, l := range m.GetLabel() {
+			if l.GetName() == labelNameResult && l.GetValue() == resultError {
+				counters.Failed += value
+			}
+		}
+	}
+	return counters, nil
+}
+
+// GetSettings returns the config in effect of the tidb, which is returned by the /settings API
+func (c *defaultTiDBControl) GetSettings(tc *v1alpha1.TidbCluster, ordinal int32) (*config.GenericConfig, error) {
+	httpClient, err := c.getHTTPClient(tc)
+	if err != nil {
+		return nil, err
+	}
+
+	url := fmt.Sprintf("%s/settings", c.getBaseURL(tc, ordinal))
+	body, err := getBodyOK(httpClient, url)
+	if err != nil {
+		return nil, err
+	}
+	settings := map[string]interface{}{}
+	if err := json.Unmarshal(body, &settings); err != nil {
+		return nil, err
+	}
+	return config.New(settings), nil
+}
+
+// SetSettings changes the settings of the tidb online by the /settings API
+func (c *defaultTiDBControl) SetSettings(tc *v1alpha1.TidbCluster, ordinal int32, settings map[string]string) error {
+	httpClient, err := c.getHTTPClient(tc)
+	if err != nil {
+		return err
+	}
+
+	form := neturl.Values{}
+	for k, v := range settings {
+		form.Set(k, v)
+	}
+	url := fmt.Sprintf("%s/settings", c.getBaseURL(tc, ordinal))
+	res, err := httpClient.PostForm(url, form)
+	if err != nil {
+		return err
+	}
+	defer httputil.DeferClose(res.Body)
+	if res.StatusCode != http.StatusOK {
+		return httputil.ReadErrorBody(res.Body)
+	}
+	return nil
+}
+
 func getBodyOK(httpClient *http.Client, apiURL string) ([]byte, error) {
 	res, err := httpClient.Get(apiURL)
 	if err != nil {
@@ -149,7 +321,7 @@ func (c *defaultTiDBControl) getBaseURL(tc *v1alpha1.TidbCluster, ordinal int32)
 	scheme := tc.Scheme()
 	hostName := fmt.Sprintf("%s-%d", TiDBMemberName(tcName), ordinal)
 
-	return fmt.Sprintf("%s://%s.%s.%s:10080", scheme, hostName, TiDBPeerMemberName(tcName), ns)
+	return fmt.Sprintf("%s://%s.%s.%s:%d", scheme, hostName, TiDBPeerMemberName(tcName), ns, tc.Spec.TiDB.GetStatusPort())
 }
 
 // FakeTiDBControl is a fake implementation of TiDBControlInterface.
@@ -158,6 +330,13 @@ type FakeTiDBControl struct {
 	tiDBInfo       *DBInfo
 	getInfoError   error
 	setLabelsError error
+	setLabelsErrFn func(ordinal int32) error
+	ddlOwner       string
+	ddlOwnerError  error
+	queryCounters  map[string]*QueryCounters
+	settings       map[string]*config.GenericConfig
+	settingsSet    map[string]map[string]string
+	settingsError  error
 }
 
 // NewFakeTiDBControl returns a FakeTiDBControl instance
@@ -174,6 +353,40 @@ func (c *FakeTiDBControl) SetLabelsErr(err error) {
 	c.setLabelsError = err
 }
 
+// SetLabelsErrFn sets the function returning the error of setting the labels of the ordinal
+func (c *FakeTiDBControl) SetLabelsErrFn(fn func(ordinal int32) error) {
+	c.setLabelsErrFn = fn
+}
+
+// SetDDLOwner sets the pod name of the ddl owner for FakeTiDBControl
+func (c *FakeTiDBControl) SetDDLOwner(podName string, err error) {
+	c.ddlOwner = podName
+	c.ddlOwnerError = err
+}
+
+// SetQueryCounters sets the query counters of the tidb pods for FakeTiDBControl
+func (c *FakeTiDBControl) SetQueryCounters(counters map[string]*QueryCounters) {
+	c.queryCounters = counters
+}
+
+// SetPodSettings sets the config in effect of the tidb pod returned by GetSettings
+func (c *FakeTiDBControl) SetPodSettings(podName string, settings *config.GenericConfig) {
+	if c.settings == nil {
+		c.settings = map[string]*config.GenericConfig{}
+	}
+	c.settings[podName] = settings
+}
+
+// SetSettingsError sets the error returned by GetSettings and SetSettings
+func (c *FakeTiDBControl) SetSettingsError(err error) {
+	c.settingsError = err
+}
+
+// SettingsSet returns the settings of the tidb pod changed by SetSettings
+func (c *FakeTiDBControl) SettingsSet(podName string) map[string]string {
+	return c.settingsSet[podName]
+}
+
 func (c *FakeTiDBControl) GetHealth(tc *v1alpha1.TidbCluster, ordinal int32) (bool, error) {
 	podName := fmt.Sprintf("%s-%d", TiDBMemberName(tc.GetName()), ordinal)
 	if c.healthInfo == nil {
@@ -190,5 +403,60 @@ func (c *FakeTiDBControl) GetInfo(tc *v1alpha1.TidbCluster, ordinal int32) (*DBI
 }
 
 func (c *FakeTiDBControl) SetServerLabels(tc *v1alpha1.TidbCluster, ordinal int32, labels map[string]string) error {
+	if c.setLabelsErrFn != nil {
+		return c.setLabelsErrFn(ordinal)
+	}
 	return c.setLabelsError
 }
+
+func (c *FakeTiDBControl) ResignDDLOwner(tc *v1alpha1.TidbCluster, ordinal int32) (bool, error) {
+	if c.ddlOwnerError != nil {
+		return false, c.ddlOwnerError
+	}
+	podName := fmt.Sprintf("%s-%d", TiDBMemberName(tc.GetName()), ordinal)
+	if c.ddlOwner != podName {
+		return true, nil
+	}
+	c.ddlOwner = ""
+	return false, nil
+}
+
+func (c *FakeTiDBControl) GetDDLOwner(tc *v1alpha1.TidbCluster, ordinal int32) (string, error) {
+	return c.ddlOwner, c.ddlOwnerError
+}
+
+func (c *FakeTiDBControl) GetQueryCounters(tc *v1alpha1.TidbCluster, ordinal int32) (*QueryCounters, error) {
+	podName := fmt.Sprintf("%s-%d", TiDBMemberName(tc.GetName()), ordinal)
+	if counters, ok := c.queryCounters[podName]; ok {
+		return counters, nil
+	}
+	return &QueryCounters{}, nil
+}
+
+func (c *FakeTiDBControl) GetSettings(tc *v1alpha1.TidbCluster, ordinal int32) (*config.GenericConfig, error) {
+	if c.settingsError != nil {
+		return nil, c.settingsError
+	}
+	podName := fmt.Sprintf("%s-%d", TiDBMemberName(tc.GetName()), ordinal)
+	if settings, ok := c.settings[podName]; ok {
+		return settings, nil
+	}
+	return config.New(map[string]interface{}{}), nil
+}
+
+func (c *FakeTiDBControl) SetSettings(tc *v1alpha1.TidbCluster, ordinal int32, settings map[string]string) error {
+	if c.settingsError != nil {
+		return c.settingsError
+	}
+	podName := fmt.Sprintf("%s-%d", TiDBMemberName(tc.GetName()), ordinal)
+	if c.settingsSet == nil {
+		c.settingsSet = map[string]map[string]string{}
+	}
+	if c.settingsSet[podName] == nil {
+		c.settingsSet[podName] = map[string]string{}
+	}
+	for k, v := range settings {
+		c.settingsSet[podName][k] = v
+	}
+	return nil
+}
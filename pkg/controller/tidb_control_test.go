@@ -211,6 +211,72 @@ func TestInfo(t *testing.T) {
 	}
 }
 
+func TestResignDDLOwner(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	cases := []struct {
+		caseName    string
+		status      int
+		body        string
+		notOwner    bool
+		errExpected bool
+	}{
+		{caseName: "resign the ddl owner", status: http.StatusOK},
+		{caseName: "not the ddl owner", status: http.StatusBadRequest, body: NotDDLOwnerError, notOwner: true},
+		{caseName: "resign failed", status: http.StatusInternalServerError, body: "internal error", errExpected: true},
+	}
+
+	for _, c := range cases {
+		svc := getClientServer(func(w http.ResponseWriter, request *http.Request) {
+			g.Expect(request.Method).To(Equal("POST"), "check method")
+			g.Expect(request.URL.Path).To(Equal("/ddl/owner/resign"), "check url")
+			w.WriteHeader(c.status)
+			w.Write([]byte(c.body))
+		})
+		defer svc.Close()
+
+		fakeClient := &fake.Clientset{}
+		informer := kubeinformers.NewSharedInformerFactory(fakeClient, 0)
+		control := NewDefaultTiDBControl(informer.Core().V1().Secrets().Lister())
+		control.testURL = svc.URL
+		notOwner, err := control.ResignDDLOwner(getTidbCluster(), 0)
+		if c.errExpected {
+			g.Expect(err).To(HaveOccurred(), c.caseName)
+		} else {
+			g.Expect(err).NotTo(HaveOccurred(), c.caseName)
+		}
+		g.Expect(notOwner).To(Equal(c.notOwner), c.caseName)
+	}
+}
+
+func TestGetDDLOwner(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	svc := getClientServer(func(w http.ResponseWriter, request *http.Request) {
+		g.Expect(request.Method).To(Equal("GET"), "check method")
+		g.Expect(request.URL.Path).To(Equal("/info/all"), "check url")
+		w.Header().Set("Content-Type", ContentTypeJSON)
+		data, err := json.Marshal(ClusterInfo{
+			OwnerID: "id-1",
+			AllServersInfo: map[string]*ServerInfo{
+				"id-0": {ID: "id-0", IP: "demo-tidb-0.demo-tidb-peer.default.svc", StatusPort: 10080},
+				"id-1": {ID: "id-1", IP: "demo-tidb-1.demo-tidb-peer.default.svc", StatusPort: 10080},
+			},
+		})
+		g.Expect(err).NotTo(HaveOccurred())
+		w.Write(data)
+	})
+	defer svc.Close()
+
+	fakeClient := &fake.Clientset{}
+	informer := kubeinformers.NewSharedInformerFactory(fakeClient, 0)
+	control := NewDefaultTiDBControl(informer.Core().V1().Secrets().Lister())
+	control.testURL = svc.URL
+	owner, err := control.GetDDLOwner(getTidbCluster(), 0)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(owner).To(Equal("demo-tidb-1"))
+}
+
 func TestGetHTTPClient(t *testing.T) {
 	g := NewGomegaWithT(t)
 
@@ -349,3 +415,64 @@ func fakeSecret(fakeClient *fake.Clientset) {
 		}, nil
 	})
 }
+
+func TestGetQueryCounters(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	svc := getClientServer(func(w http.ResponseWriter, request *http.Request) {
+		g.Expect(request.URL.Path).To(Equal("/metrics"), "check url")
+		w.Write([]byte(`# HELP tidb_server_query_total Counter of queries.
+# TYPE tidb_server_query_total counter
+tidb_server_query_total{result="Error",type="Query"} 3
+tidb_server_query_total{result="OK",type="Query"} 90
+tidb_server_query_total{result="OK",type="StmtExecute"} 7
+# HELP tidb_server_connections Number of connections.
+# TYPE tidb_server_connections gauge
+tidb_server_connections 2
+`))
+	})
+	defer svc.Close()
+
+	fakeClient := &fake.Clientset{}
+	informer := kubeinformers.NewSharedInformerFactory(fakeClient, 0)
+	control := NewDefaultTiDBControl(informer.Core().V1().Secrets().Lister())
+	control.testURL = svc.URL
+	counters, err := control.GetQueryCounters(getTidbCluster(), 0)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(counters).To(Equal(&QueryCounters{Total: 100, Failed: 3}))
+}
+
+func TestSettings(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	svc := getClientServer(func(w http.ResponseWriter, request *http.Request) {
+		g.Expect(request.URL.Path).To(Equal("/settings"), "check url")
+		switch request.Method {
+		case http.MethodGet:
+			w.Write([]byte(`{"log":{"level":"info"},"check-mb4-value-in-utf8":true}`))
+		case http.MethodPost:
+			g.Expect(request.ParseForm()).To(Succeed())
+			if request.PostForm.Get("log_level") == "invalid" {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte("illegal log level"))
+				return
+			}
+			g.Expect(request.PostForm.Get("log_level")).To(Equal("warn"))
+		}
+	})
+	defer svc.Close()
+
+	fakeClient := &fake.Clientset{}
+	informer := kubeinformers.NewSharedInformerFactory(fakeClient, 0)
+	control := NewDefaultTiDBControl(informer.Core().V1().Secrets().Lister())
+	control.testURL = svc.URL
+	settings, err := control.GetSettings(getTidbCluster(), 0)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(settings.Get("log.level").MustString()).To(Equal("info"))
+	g.Expect(settings.Get("check-mb4-value-in-utf8").Interface()).To(Equal(true))
+
+	g.Expect(control.SetSettings(getTidbCluster(), 0, map[string]string{"log_level": "warn"})).To(Succeed())
+	err = control.SetSettings(getTidbCluster(), 0, map[string]string{"log_level": "invalid"})
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(err.Error()).To(ContainSubstring("illegal log level"))
+}
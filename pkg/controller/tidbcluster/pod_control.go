@@ -15,6 +15,7 @@ package tidbcluster
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"sync"
 	"time"
@@ -29,6 +30,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/tools/cache"
@@ -150,6 +152,7 @@ func (c *PodController) processNextWorkItem() bool {
 	}
 	defer c.queue.Done(key)
 	result, err := c.sync(key.(string))
+	controller.ObserveReconcile(c.Name(), err)
 	if err != nil {
 		utilruntime.HandleError(fmt.Errorf("TidbCluster pod: %v, sync failed %v, requeuing", key.(string), err))
 		c.queue.AddRateLimited(key)
@@ -213,6 +216,10 @@ func (c *PodController) sync(key string) (reconcile.Result, error) {
 
 	component := pod.Labels[label.ComponentLabelKey]
 	ctx := context.Background()
+	if preStopHookFinished(pod) {
+		// the annotations added by the preStop hook are useless after the pod is restarted
+		return reconcile.Result{}, c.removePreStopAnnotations(ctx, pod, component)
+	}
 	switch component {
 	case label.PDLabelVal:
 		return c.syncPDPod(ctx, pod, tc)
@@ -271,6 +278,11 @@ func (c *PodController) syncPDPod(ctx context.Context, pod *corev1.Pod, tc *v1al
 
 	// Delete pod after leader transfer if configured.
 	if value == v1alpha1.TransferLeaderValueDeletePod {
+		// Make sure no other voluntary disruption is in flight which would lose the quorum together with this one.
+		if err = member.BeginPDDisruption(c.deps, tc, pod.Name, v1alpha1.PDDisruptionSourcePodAnnotation); err != nil {
+			klog.Infof("Defer deleting pd pod %s/%s: %v", pod.Namespace, pod.Name, err)
+			return reconcile.Result{RequeueAfter: RequeueInterval}, nil
+		}
 		err = c.deps.KubeClientset.CoreV1().Pods(pod.Namespace).Delete(ctx, pod.Name, metav1.DeleteOptions{})
 		if err != nil && !errors.IsNotFound(err) {
 			return reconcile.Result{}, perrors.Annotatef(err, "failed to delete pod %q", pod.Name)
@@ -340,7 +352,8 @@ func (c *PodController) syncTiKVPod(ctx context.Context, pod *corev1.Pod, tc *v1
 		// delete pod after eviction finished if needed
 		if value == v1alpha1.EvictLeaderValueDeletePod {
 			tlsEnabled := tc.IsTLSClusterEnabled()
-			kvClient := c.deps.TiKVControl.GetTiKVPodClient(tc.Namespace, tc.Name, pod.Name, tlsEnabled)
+			kvClient := c.deps.TiKVControl.GetTiKVPodClient(tc.Namespace, tc.Name, pod.Name, tc.Spec.TiKV.GetStatusPort(), tlsEnabled,
+				pdapi.TLSFromCluster(pdapi.Namespace(tc.Namespace), tc.Spec.TLSCluster))
 			leaderCount, err := kvClient.GetLeaderCount()
 			if err != nil {
 				return reconcile.Result{}, perrors.Annotatef(err, "failed to get leader count for pod %s/%s", pod.Namespace, pod.Name)
@@ -458,6 +471,53 @@ func (c *PodController) syncTiDBPod(ctx context.Context, pod *corev1.Pod, tc *v1
 	return reconcile.Result{}, nil
 }
 
+// preStopHookFinished returns whether all containers of the pod have been restarted after the preStop hook began.
+func preStopHookFinished(pod *corev1.Pod) bool {
+	value, ok := pod.Annotations[v1alpha1.PreStopBeginTimeAnnKey]
+	if !ok || pod.DeletionTimestamp != nil || !podutil.IsPodReady(pod) {
+		return false
+	}
+	beginTime, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		klog.Warningf("Ignore invalid value %q of annotation %q for Pod %s/%s", value, v1alpha1.PreStopBeginTimeAnnKey, pod.Namespace, pod.Name)
+		return false
+	}
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.State.Running == nil || !status.State.Running.StartedAt.After(beginTime) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *PodController) removePreStopAnnotations(ctx context.Context, pod *corev1.Pod, component string) error {
+	keys := []string{v1alpha1.PreStopBeginTimeAnnKey}
+	switch component {
+	case label.PDLabelVal:
+		keys = append(keys, v1alpha1.PDLeaderTransferAnnKey)
+	case label.TiKVLabelVal:
+		keys = append(keys, v1alpha1.EvictLeaderAnnKey)
+	}
+	annotations := map[string]interface{}{}
+	for _, key := range keys {
+		annotations[key] = nil
+	}
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": annotations,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = c.deps.KubeClientset.CoreV1().Pods(pod.Namespace).Patch(ctx, pod.Name, types.MergePatchType, patch, metav1.PatchOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		return perrors.Annotatef(err, "failed to remove annotations of preStop hook from pod %s/%s", pod.Namespace, pod.Name)
+	}
+	klog.Infof("Pod %s/%s is restarted after the preStop hook, remove annotations %v", pod.Namespace, pod.Name, keys)
+	return nil
+}
+
 func needDeleteTiDBPod(pod *corev1.Pod) string {
 	if pod.Annotations == nil {
 		return ""
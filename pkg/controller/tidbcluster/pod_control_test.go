@@ -43,6 +43,10 @@ func (c *kvClient) GetLeaderCount() (int, error) {
 	return int(count), nil
 }
 
+func (c *kvClient) UpdateConfig(config map[string]string) error {
+	return nil
+}
+
 func TestTiKVPodSync(t *testing.T) {
 	interval := time.Millisecond * 100
 	timeout := time.Minute * 1
@@ -526,3 +530,42 @@ func newTiKVPod(tc *v1alpha1.TidbCluster) *corev1.Pod {
 		},
 	}
 }
+
+func TestPreStopHookFinished(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	beginTime := time.Now().Add(-time.Minute).Truncate(time.Second)
+	newPod := func(startedAt time.Time, ready bool) *corev1.Pod {
+		readyStatus := corev1.ConditionFalse
+		if ready {
+			readyStatus = corev1.ConditionTrue
+		}
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "test-tikv-0",
+				Namespace:   metav1.NamespaceDefault,
+				Annotations: map[string]string{v1alpha1.PreStopBeginTimeAnnKey: beginTime.Format(time.RFC3339)},
+			},
+			Status: corev1.PodStatus{
+				Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: readyStatus}},
+				ContainerStatuses: []corev1.ContainerStatus{
+					{State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{StartedAt: metav1.NewTime(startedAt)}}},
+				},
+			},
+		}
+	}
+
+	g.Expect(preStopHookFinished(newPod(beginTime.Add(time.Second), true))).To(BeTrue())
+	// the container has not been restarted
+	g.Expect(preStopHookFinished(newPod(beginTime.Add(-time.Hour), true))).To(BeFalse())
+	// the pod is not ready
+	g.Expect(preStopHookFinished(newPod(beginTime.Add(time.Second), false))).To(BeFalse())
+	// the pod is being deleted
+	pod := newPod(beginTime.Add(time.Second), true)
+	pod.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+	g.Expect(preStopHookFinished(pod)).To(BeFalse())
+	// the preStop hook has never begun
+	pod = newPod(beginTime.Add(time.Second), true)
+	pod.Annotations = nil
+	g.Expect(preStopHookFinished(pod)).To(BeFalse())
+}
@@ -0,0 +1,342 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tidbcluster
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	perrors "github.com/pingcap/errors"
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/metrics"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+	podutil "k8s.io/kubernetes/pkg/api/v1/pod"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// restartComponent is a component whose pods can be restarted by the restart-ordinals annotation
+type restartComponent struct {
+	memberType v1alpha1.MemberType
+	// annKey is the annotation key of the TidbCluster listing the ordinals to restart
+	annKey string
+	// podAnnKey is the annotation key of the pod handled by PodController to restart the pod gracefully,
+	// the pod is deleted directly if it's empty
+	podAnnKey string
+	// podAnnValue is the value of podAnnKey to delete the pod after the graceful operation
+	podAnnValue string
+}
+
+var restartComponents = []restartComponent{
+	{memberType: v1alpha1.PDMemberType, annKey: label.AnnPDRestartOrdinals, podAnnKey: v1alpha1.PDLeaderTransferAnnKey, podAnnValue: v1alpha1.TransferLeaderValueDeletePod},
+	{memberType: v1alpha1.TiKVMemberType, annKey: label.AnnTiKVRestartOrdinals, podAnnKey: v1alpha1.EvictLeaderAnnKey, podAnnValue: v1alpha1.EvictLeaderValueDeletePod},
+	{memberType: v1alpha1.TiDBMemberType, annKey: label.AnnTiDBRestartOrdinals, podAnnKey: v1alpha1.TiDBGracefulShutdownAnnKey, podAnnValue: v1alpha1.TiDBPodDeletionDeletePod},
+	{memberType: v1alpha1.TiFlashMemberType, annKey: label.AnnTiFlashRestartOrdinals},
+	{memberType: v1alpha1.TiCDCMemberType, annKey: label.AnnTiCDCRestartOrdinals},
+	{memberType: v1alpha1.TiProxyMemberType, annKey: label.AnnTiProxyRestartOrdinals},
+}
+
+// RestartController restarts the pods of the ordinals listed in the restart-ordinals annotations of
+// a TidbCluster one by one, without modifying the StatefulSets. The leaders on the PD and TiKV pods are
+// transferred or evicted and the TiDB pods are shut down gracefully by PodController before they are deleted.
+type RestartController struct {
+	deps  *controller.Dependencies
+	queue workqueue.RateLimitingInterface
+
+	recheckDuration time.Duration
+}
+
+// NewRestartController creates a RestartController.
+func NewRestartController(deps *controller.Dependencies) *RestartController {
+	c := &RestartController{
+		deps: deps,
+		queue: workqueue.NewNamedRateLimitingQueue(
+			controller.NewControllerRateLimiter(1*time.Second, 100*time.Second),
+			"tidbcluster restart",
+		),
+		recheckDuration: 15 * time.Second,
+	}
+
+	tcInformer := deps.InformerFactory.Pingcap().V1alpha1().TidbClusters()
+	tcInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: c.enqueueTidbCluster,
+		UpdateFunc: func(old, cur interface{}) {
+			c.enqueueTidbCluster(cur)
+		},
+	})
+
+	return c
+}
+
+// enqueueTidbCluster enqueues the given tidbcluster in the work queue if it has any ordinals to restart.
+func (c *RestartController) enqueueTidbCluster(obj interface{}) {
+	tc, ok := obj.(*v1alpha1.TidbCluster)
+	if !ok || !hasRestartOrdinals(tc) {
+		return
+	}
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("Cound't get key for object %+v: %v", obj, err))
+		return
+	}
+	c.queue.Add(key)
+}
+
+// Name returns the name of the RestartController.
+func (c *RestartController) Name() string {
+	return "tidbcluster-restart"
+}
+
+// Run the controller.
+func (c *RestartController) Run(workers int, stopCh <-chan struct{}) {
+	defer utilruntime.HandleCrash()
+	defer c.queue.ShutDown()
+
+	klog.Info("Starting tidbcluster restart controller")
+	defer klog.Info("Shutting down tidbcluster restart controller")
+
+	for i := 0; i < workers; i++ {
+		go wait.Until(c.worker, time.Second, stopCh)
+	}
+
+	<-stopCh
+}
+
+// worker runs a worker goroutine that invokes processNextWorkItem until the the controller's queue is closed
+func (c *RestartController) worker() {
+	for c.processNextWorkItem() {
+	}
+}
+
+// processNextWorkItem dequeues items, processes them, and marks them done. It enforces that the syncHandler is never
+// invoked concurrently with the same key.
+func (c *RestartController) processNextWorkItem() bool {
+	metrics.ActiveWorkers.WithLabelValues(c.Name()).Add(1)
+	defer metrics.ActiveWorkers.WithLabelValues(c.Name()).Add(-1)
+
+	key, quit := c.queue.Get()
+	if quit {
+		return false
+	}
+	defer c.queue.Done(key)
+	result, err := c.sync(key.(string))
+	controller.ObserveReconcile(c.Name(), err)
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("TidbCluster restart: %v, sync failed %v, requeuing", key.(string), err))
+		c.queue.AddRateLimited(key)
+	} else if result.RequeueAfter > 0 {
+		c.queue.AddAfter(key, result.RequeueAfter)
+	} else {
+		c.queue.Forget(key)
+	}
+	return true
+}
+
+func (c *RestartController) sync(key string) (reconcile.Result, error) {
+	ns, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	tc, err := c.deps.TiDBClusterLister.TidbClusters(ns).Get(name)
+	if errors.IsNotFound(err) {
+		klog.Infof("TidbCluster %v has been deleted", key)
+		return reconcile.Result{}, nil
+	}
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if tc.Spec.Paused {
+		return reconcile.Result{}, nil
+	}
+	tc = tc.DeepCopy()
+
+	ctx := context.Background()
+	pending := false
+	for _, comp := range restartComponents {
+		ordinals, ok := getRestartOrdinals(tc, comp.annKey)
+		if !ok {
+			continue
+		}
+		if len(ordinals) > 0 {
+			if err := c.restartNextPod(ctx, tc, comp, ordinals); err != nil {
+				return reconcile.Result{}, err
+			}
+			ordinals, _ = getRestartOrdinals(tc, comp.annKey)
+		}
+		pending = pending || len(ordinals) > 0
+	}
+	if pending {
+		return reconcile.Result{RequeueAfter: c.recheckDuration}, nil
+	}
+	return reconcile.Result{}, nil
+}
+
+// restartNextPod restarts the pod of the first ordinal if all the pods of the component are ready,
+// and removes the ordinal from the annotation of the TidbCluster once the restart begins.
+func (c *RestartController) restartNextPod(ctx context.Context, tc *v1alpha1.TidbCluster, comp restartComponent, ordinals []int32) error {
+	ns := tc.GetNamespace()
+	status := tc.ComponentStatus(comp.memberType)
+	if status == nil {
+		klog.Warningf("TidbCluster %s/%s: %s is not deployed, ignore the ordinals %v to restart", ns, tc.Name, comp.memberType, ordinals)
+		return c.setRestartOrdinals(ctx, tc, comp.annKey, nil)
+	}
+	if status.GetPhase() != v1alpha1.NormalPhase {
+		klog.V(4).Infof("TidbCluster %s/%s: %s is in %s phase, defer restarting pods %v", ns, tc.Name, comp.memberType, status.GetPhase(), ordinals)
+		return nil
+	}
+
+	setName := controller.MemberName(tc.GetName(), comp.memberType)
+	sts, err := c.deps.StatefulSetLister.StatefulSets(ns).Get(setName)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return perrors.Annotatef(err, "failed to get statefulset %s/%s", ns, setName)
+	}
+	selector, err := label.New().Instance(tc.GetName()).Component(comp.memberType.String()).Selector()
+	if err != nil {
+		return err
+	}
+	pods, err := c.deps.PodLister.Pods(ns).List(selector)
+	if err != nil {
+		return perrors.Annotatef(err, "failed to list pods of %s/%s", ns, setName)
+	}
+	// restart the pods one by one, wait for the last restarted pod to be recreated and ready
+	if sts.Spec.Replicas == nil || int32(len(pods)) != *sts.Spec.Replicas {
+		return nil
+	}
+	for _, pod := range pods {
+		if pod.DeletionTimestamp != nil || !podutil.IsPodReady(pod) || isPodRestarting(pod) {
+			klog.V(4).Infof("TidbCluster %s/%s: pod %s is restarting or not ready, defer restarting pods %v", ns, tc.Name, pod.Name, ordinals)
+			return nil
+		}
+	}
+
+	ordinal := ordinals[0]
+	podName := fmt.Sprintf("%s-%d", setName, ordinal)
+	pod, err := c.deps.PodLister.Pods(ns).Get(podName)
+	switch {
+	case errors.IsNotFound(err):
+		klog.Warningf("TidbCluster %s/%s: pod %s is not found, skip restarting it", ns, tc.Name, podName)
+	case err != nil:
+		return perrors.Annotatef(err, "failed to get pod %s/%s", ns, podName)
+	default:
+		if err := c.restartPod(ctx, pod, comp); err != nil {
+			return err
+		}
+		c.deps.Recorder.Eventf(tc, corev1.EventTypeNormal, "RestartPod", "restart pod %s of ordinal %d requested by annotation %s", podName, ordinal, comp.annKey)
+	}
+	return c.setRestartOrdinals(ctx, tc, comp.annKey, ordinals[1:])
+}
+
+// restartPod deletes the pod, or lets PodController delete it gracefully if the component supports it.
+func (c *RestartController) restartPod(ctx context.Context, pod *corev1.Pod, comp restartComponent) error {
+	if comp.podAnnKey == "" {
+		klog.Infof("Restart pod %s/%s by deleting it", pod.Namespace, pod.Name)
+		err := c.deps.KubeClientset.CoreV1().Pods(pod.Namespace).Delete(ctx, pod.Name, metav1.DeleteOptions{})
+		if err != nil && !errors.IsNotFound(err) {
+			return perrors.Annotatef(err, "failed to delete pod %q", pod.Name)
+		}
+		return nil
+	}
+
+	klog.Infof("Restart pod %s/%s gracefully by annotation %s", pod.Namespace, pod.Name, comp.podAnnKey)
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]string{comp.podAnnKey: comp.podAnnValue},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = c.deps.KubeClientset.CoreV1().Pods(pod.Namespace).Patch(ctx, pod.Name, types.MergePatchType, patch, metav1.PatchOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		return perrors.Annotatef(err, "failed to annotate pod %s/%s with %s", pod.Namespace, pod.Name, comp.podAnnKey)
+	}
+	return nil
+}
+
+// setRestartOrdinals updates the ordinals in the annotation of the TidbCluster, the annotation is removed
+// if there are no ordinals left.
+func (c *RestartController) setRestartOrdinals(ctx context.Context, tc *v1alpha1.TidbCluster, annKey string, ordinals []int32) error {
+	return retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		if len(ordinals) == 0 {
+			delete(tc.Annotations, annKey)
+		} else {
+			value, err := json.Marshal(ordinals)
+			if err != nil {
+				return err
+			}
+			tc.Annotations[annKey] = string(value)
+		}
+		updated, updateErr := c.deps.Clientset.PingcapV1alpha1().TidbClusters(tc.Namespace).Update(ctx, tc, metav1.UpdateOptions{})
+		if updateErr == nil {
+			*tc = *updated
+			return nil
+		}
+		if latest, err := c.deps.TiDBClusterLister.TidbClusters(tc.Namespace).Get(tc.Name); err == nil {
+			// make a copy so we don't mutate the shared cache
+			*tc = *latest.DeepCopy()
+		} else {
+			utilruntime.HandleError(fmt.Errorf("error getting updated tc %s/%s from lister: %v", tc.Namespace, tc.Name, err))
+		}
+		return updateErr
+	})
+}
+
+// isPodRestarting returns whether the pod is being restarted gracefully by PodController
+func isPodRestarting(pod *corev1.Pod) bool {
+	for _, comp := range restartComponents {
+		if comp.podAnnKey != "" && pod.Annotations[comp.podAnnKey] == comp.podAnnValue {
+			return true
+		}
+	}
+	return false
+}
+
+// getRestartOrdinals returns the ordinals in the annotation, it returns false if the annotation
+// is not set or invalid.
+func getRestartOrdinals(tc *v1alpha1.TidbCluster, annKey string) ([]int32, bool) {
+	value, ok := tc.GetAnnotations()[annKey]
+	if !ok {
+		return nil, false
+	}
+	var ordinals []int32
+	if err := json.Unmarshal([]byte(value), &ordinals); err != nil {
+		klog.Warningf("TidbCluster %s/%s: ignore invalid value %q of annotation %s, %v", tc.Namespace, tc.Name, value, annKey, err)
+		return nil, false
+	}
+	return ordinals, true
+}
+
+func hasRestartOrdinals(tc *v1alpha1.TidbCluster) bool {
+	for _, comp := range restartComponents {
+		if _, ok := tc.GetAnnotations()[comp.annKey]; ok {
+			return true
+		}
+	}
+	return false
+}
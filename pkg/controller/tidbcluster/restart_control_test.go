@@ -0,0 +1,145 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tidbcluster
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	apps "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+func TestRestartControllerSync(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := context.Background()
+
+	deps := controller.NewFakeDependencies()
+	c := NewRestartController(deps)
+	tcIndexer := deps.InformerFactory.Pingcap().V1alpha1().TidbClusters().Informer().GetIndexer()
+	podIndexer := deps.KubeInformerFactory.Core().V1().Pods().Informer().GetIndexer()
+	setIndexer := deps.KubeInformerFactory.Apps().V1().StatefulSets().Informer().GetIndexer()
+
+	tc := newTidbCluster()
+	tc.Annotations = map[string]string{
+		label.AnnTiKVRestartOrdinals: "[2,5]",
+		label.AnnTiDBRestartOrdinals: "invalid",
+	}
+	tc.Status.TiKV.Phase = v1alpha1.NormalPhase
+	tc, err := deps.Clientset.PingcapV1alpha1().TidbClusters(tc.Namespace).Create(ctx, tc, metav1.CreateOptions{})
+	g.Expect(err).To(Succeed())
+	g.Expect(tcIndexer.Add(tc)).To(Succeed())
+	key := fmt.Sprintf("%s/%s", tc.Namespace, tc.Name)
+	syncTC := func() *v1alpha1.TidbCluster {
+		latest, err := deps.Clientset.PingcapV1alpha1().TidbClusters(tc.Namespace).Get(ctx, tc.Name, metav1.GetOptions{})
+		g.Expect(err).To(Succeed())
+		g.Expect(tcIndexer.Update(latest)).To(Succeed())
+		return latest
+	}
+
+	setName := controller.TiKVMemberName(tc.Name)
+	g.Expect(setIndexer.Add(&apps.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Namespace: tc.Namespace, Name: setName},
+		Spec:       apps.StatefulSetSpec{Replicas: pointer.Int32Ptr(3)},
+	})).To(Succeed())
+	for i := 0; i < 3; i++ {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: tc.Namespace,
+				Name:      fmt.Sprintf("%s-%d", setName, i),
+				Labels:    label.New().Instance(tc.Name).TiKV().Labels(),
+			},
+			Status: corev1.PodStatus{
+				Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+			},
+		}
+		_, err := deps.KubeClientset.CoreV1().Pods(pod.Namespace).Create(ctx, pod, metav1.CreateOptions{})
+		g.Expect(err).To(Succeed())
+		g.Expect(podIndexer.Add(pod)).To(Succeed())
+	}
+	syncPod := func(name string) *corev1.Pod {
+		pod, err := deps.KubeClientset.CoreV1().Pods(tc.Namespace).Get(ctx, name, metav1.GetOptions{})
+		g.Expect(err).To(Succeed())
+		g.Expect(podIndexer.Update(pod)).To(Succeed())
+		return pod
+	}
+
+	// the leaders of the first pod are evicted before it's deleted
+	result, err := c.sync(key)
+	g.Expect(err).To(Succeed())
+	g.Expect(result.RequeueAfter).To(BeNumerically(">", 0))
+	pod := syncPod(setName + "-2")
+	g.Expect(pod.Annotations).To(HaveKeyWithValue(v1alpha1.EvictLeaderAnnKey, v1alpha1.EvictLeaderValueDeletePod))
+	tc = syncTC()
+	g.Expect(tc.Annotations).To(HaveKeyWithValue(label.AnnTiKVRestartOrdinals, "[5]"))
+	g.Expect(tc.Annotations).To(HaveKeyWithValue(label.AnnTiDBRestartOrdinals, "invalid"))
+
+	// the next pod waits for the restarting pod
+	result, err = c.sync(key)
+	g.Expect(err).To(Succeed())
+	g.Expect(result.RequeueAfter).To(BeNumerically(">", 0))
+	tc = syncTC()
+	g.Expect(tc.Annotations).To(HaveKeyWithValue(label.AnnTiKVRestartOrdinals, "[5]"))
+
+	// the ordinal of a missing pod is dropped after the restarted pod is ready
+	delete(pod.Annotations, v1alpha1.EvictLeaderAnnKey)
+	_, err = deps.KubeClientset.CoreV1().Pods(pod.Namespace).Update(ctx, pod, metav1.UpdateOptions{})
+	g.Expect(err).To(Succeed())
+	syncPod(pod.Name)
+	result, err = c.sync(key)
+	g.Expect(err).To(Succeed())
+	g.Expect(result.RequeueAfter).To(BeZero())
+	tc = syncTC()
+	g.Expect(tc.Annotations).NotTo(HaveKey(label.AnnTiKVRestartOrdinals))
+
+	// the pods of the components without graceful restart are deleted directly
+	tc.Spec.TiCDC = &v1alpha1.TiCDCSpec{Replicas: 1}
+	tc.Status.TiCDC.Phase = v1alpha1.NormalPhase
+	tc.Annotations[label.AnnTiCDCRestartOrdinals] = "[0]"
+	tc, err = deps.Clientset.PingcapV1alpha1().TidbClusters(tc.Namespace).Update(ctx, tc, metav1.UpdateOptions{})
+	g.Expect(err).To(Succeed())
+	syncTC()
+	cdcSetName := controller.TiCDCMemberName(tc.Name)
+	g.Expect(setIndexer.Add(&apps.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Namespace: tc.Namespace, Name: cdcSetName},
+		Spec:       apps.StatefulSetSpec{Replicas: pointer.Int32Ptr(1)},
+	})).To(Succeed())
+	cdcPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: tc.Namespace,
+			Name:      cdcSetName + "-0",
+			Labels:    label.New().Instance(tc.Name).TiCDC().Labels(),
+		},
+		Status: corev1.PodStatus{
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+		},
+	}
+	_, err = deps.KubeClientset.CoreV1().Pods(cdcPod.Namespace).Create(ctx, cdcPod, metav1.CreateOptions{})
+	g.Expect(err).To(Succeed())
+	g.Expect(podIndexer.Add(cdcPod)).To(Succeed())
+	_, err = c.sync(key)
+	g.Expect(err).To(Succeed())
+	_, err = deps.KubeClientset.CoreV1().Pods(cdcPod.Namespace).Get(ctx, cdcPod.Name, metav1.GetOptions{})
+	g.Expect(errors.IsNotFound(err)).To(BeTrue())
+	tc = syncTC()
+	g.Expect(tc.Annotations).NotTo(HaveKey(label.AnnTiCDCRestartOrdinals))
+}
@@ -14,12 +14,27 @@
 package tidbcluster
 
 import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/features"
 	utiltidbcluster "github.com/pingcap/tidb-operator/pkg/util/tidbcluster"
 	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
 )
 
+// quotaEventWindow is the window in which the FailedCreate events of a StatefulSet are
+// considered as the current state, the StatefulSet controller retries the creation with backoff
+// and the events are aggregated, so the last timestamp keeps updating while the pods are rejected.
+const quotaEventWindow = 10 * time.Minute
+
 // TidbClusterConditionUpdater interface that translates cluster state into
 // into tidb cluster status conditions.
 type TidbClusterConditionUpdater interface {
@@ -27,16 +42,88 @@ type TidbClusterConditionUpdater interface {
 }
 
 type tidbClusterConditionUpdater struct {
+	deps *controller.Dependencies
 }
 
 var _ TidbClusterConditionUpdater = &tidbClusterConditionUpdater{}
 
 func (u *tidbClusterConditionUpdater) Update(tc *v1alpha1.TidbCluster) error {
 	u.updateReadyCondition(tc)
-	// in the future, we may return error when we need to Kubernetes API, etc.
+	u.updateDriftedCondition(tc)
+	u.updateASTSCompatibleCondition(tc)
+	rejections, err := u.quotaRejections(tc)
+	if err != nil {
+		return err
+	}
+	u.updateComponentConditions(tc, rejections)
+	u.updateQuotaExceededCondition(tc, rejections)
 	return nil
 }
 
+// componentReadiness is how the readiness of each component is checked, which is the same as the Ready condition
+// of the cluster, Pump is not checked by the Ready condition so it has no ComponentReady condition
+var componentReadiness = map[v1alpha1.MemberType]struct {
+	ready   func(tc *v1alpha1.TidbCluster) bool
+	reason  string
+	message string
+}{
+	v1alpha1.PDMemberType:      {(*v1alpha1.TidbCluster).PDAllMembersReady, utiltidbcluster.PDUnhealthy, "PD(s) are not healthy"},
+	v1alpha1.TiKVMemberType:    {(*v1alpha1.TidbCluster).TiKVAllStoresReady, utiltidbcluster.TiKVStoreNotUp, "TiKV store(s) are not up"},
+	v1alpha1.TiDBMemberType:    {(*v1alpha1.TidbCluster).TiDBAllMembersReady, utiltidbcluster.TiDBUnhealthy, "TiDB(s) are not healthy"},
+	v1alpha1.TiFlashMemberType: {(*v1alpha1.TidbCluster).TiFlashAllStoresReady, utiltidbcluster.TiFlashStoreNotUp, "TiFlash store(s) are not up"},
+	v1alpha1.TiCDCMemberType:   {(*v1alpha1.TidbCluster).TiCDCAllCapturesReady, utiltidbcluster.TiCDCCaptureNotReady, "TiCDC capture(s) are not up"},
+	v1alpha1.TiProxyMemberType: {(*v1alpha1.TidbCluster).TiProxyAllMembersReady, utiltidbcluster.TiProxyUnhealthy, "TiProxy(s) are not healthy"},
+}
+
+// updateComponentConditions updates the conditions of each component, so that the readiness, the upgrade and the
+// blocked scaling of the components can be observed without re-deriving them from the status of the members
+func (u *tidbClusterConditionUpdater) updateComponentConditions(tc *v1alpha1.TidbCluster, rejections map[string]string) {
+	newCondition := func(condType string, ok bool, reason, message string) metav1.Condition {
+		cond := metav1.Condition{
+			Type:               condType,
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: tc.Generation,
+			Reason:             reason,
+			Message:            message,
+		}
+		if ok {
+			cond.Status = metav1.ConditionTrue
+		}
+		return cond
+	}
+	for _, status := range tc.AllComponentStatus() {
+		if r, ok := componentReadiness[status.MemberType()]; ok {
+			sts := status.GetStatefulSet()
+			switch {
+			case sts != nil && sts.CurrentRevision != sts.UpdateRevision:
+				status.SetCondition(newCondition(v1alpha1.ComponentReady, false, utiltidbcluster.StatfulSetNotUpToDate, "Statefulset is in progress"))
+			case !r.ready(tc):
+				status.SetCondition(newCondition(v1alpha1.ComponentReady, false, r.reason, r.message))
+			default:
+				status.SetCondition(newCondition(v1alpha1.ComponentReady, true, utiltidbcluster.Ready, "All members are ready"))
+			}
+		}
+
+		if status.GetPhase() == v1alpha1.UpgradePhase {
+			status.SetCondition(newCondition(v1alpha1.ComponentUpgradeInProgress, true, utiltidbcluster.Upgrading, "Pod(s) are being upgraded"))
+		} else {
+			status.SetCondition(newCondition(v1alpha1.ComponentUpgradeInProgress, false, utiltidbcluster.NotUpgrading, "No upgrade is in progress"))
+		}
+
+		setName := controller.MemberName(tc.Name, status.MemberType())
+		switch {
+		case rejections[setName] != "":
+			status.SetCondition(newCondition(v1alpha1.ComponentScalingBlocked, true, utiltidbcluster.PodsRejectedByQuota,
+				fmt.Sprintf("Pod(s) are rejected: %s", rejections[setName])))
+		case status.GetPhase() == v1alpha1.ScalePhase && !status.GetSynced():
+			status.SetCondition(newCondition(v1alpha1.ComponentScalingBlocked, true, utiltidbcluster.ScalingStatusNotSynced,
+				"The status of the members fails to sync, which the scaling depends on"))
+		default:
+			status.SetCondition(newCondition(v1alpha1.ComponentScalingBlocked, false, utiltidbcluster.ScalingNotBlocked, "Scaling is not blocked"))
+		}
+	}
+}
+
 func allStatefulSetsAreUpToDate(tc *v1alpha1.TidbCluster) bool {
 	isUpToDate := func(status *appsv1.StatefulSetStatus, requireExist bool) bool {
 		if status == nil {
@@ -47,7 +134,8 @@ func allStatefulSetsAreUpToDate(tc *v1alpha1.TidbCluster) bool {
 	return (isUpToDate(tc.Status.PD.StatefulSet, false)) &&
 		(isUpToDate(tc.Status.TiKV.StatefulSet, false)) &&
 		(isUpToDate(tc.Status.TiDB.StatefulSet, false)) &&
-		isUpToDate(tc.Status.TiFlash.StatefulSet, false)
+		isUpToDate(tc.Status.TiFlash.StatefulSet, false) &&
+		isUpToDate(tc.Status.TiProxy.StatefulSet, false)
 }
 
 func (u *tidbClusterConditionUpdater) updateReadyCondition(tc *v1alpha1.TidbCluster) {
@@ -74,6 +162,9 @@ func (u *tidbClusterConditionUpdater) updateReadyCondition(tc *v1alpha1.TidbClus
 	case tc.Spec.TiCDC != nil && !tc.TiCDCAllCapturesReady():
 		reason = utiltidbcluster.TiCDCCaptureNotReady
 		message = "TiCDC capture(s) are not up"
+	case tc.Spec.TiProxy != nil && !tc.TiProxyAllMembersReady():
+		reason = utiltidbcluster.TiProxyUnhealthy
+		message = "TiProxy(s) are not healthy"
 	default:
 		status = v1.ConditionTrue
 		reason = utiltidbcluster.Ready
@@ -82,3 +173,159 @@ func (u *tidbClusterConditionUpdater) updateReadyCondition(tc *v1alpha1.TidbClus
 	cond := utiltidbcluster.NewTidbClusterCondition(v1alpha1.TidbClusterReady, status, reason, message)
 	utiltidbcluster.SetTidbClusterCondition(&tc.Status, *cond)
 }
+
+func (u *tidbClusterConditionUpdater) updateDriftedCondition(tc *v1alpha1.TidbCluster) {
+	if tc.Spec.DriftPolicy == nil {
+		return
+	}
+	status := v1.ConditionFalse
+	reason := utiltidbcluster.NoDrift
+	message := "No resource is drifted"
+	if len(tc.Status.DriftedResources) > 0 {
+		resources := make([]string, 0, len(tc.Status.DriftedResources))
+		for _, r := range tc.Status.DriftedResources {
+			resources = append(resources, fmt.Sprintf("%s %s: %s", r.Kind, r.Name, strings.Join(r.Fields, ",")))
+		}
+		status = v1.ConditionTrue
+		reason = utiltidbcluster.ResourcesDrifted
+		message = fmt.Sprintf("Resource(s) are drifted: %s", strings.Join(resources, "; "))
+	}
+	cond := utiltidbcluster.NewTidbClusterCondition(v1alpha1.TidbClusterDrifted, status, reason, message)
+	utiltidbcluster.SetTidbClusterCondition(&tc.Status, *cond)
+}
+
+// quotaRejections returns the ResourceQuota or LimitRange that rejects the pods of each StatefulSet of the
+// components, the key is the name of the StatefulSet
+func (u *tidbClusterConditionUpdater) quotaRejections(tc *v1alpha1.TidbCluster) (map[string]string, error) {
+	rejections := map[string]string{}
+	for _, setName := range componentStatefulSetNames(tc) {
+		resource, err := u.quotaRejection(tc.Namespace, setName)
+		if err != nil {
+			return nil, err
+		}
+		if resource != "" {
+			rejections[setName] = resource
+		}
+	}
+	return rejections, nil
+}
+
+func (u *tidbClusterConditionUpdater) updateQuotaExceededCondition(tc *v1alpha1.TidbCluster, rejections map[string]string) {
+	var rejected []string
+	for _, setName := range componentStatefulSetNames(tc) {
+		if resource := rejections[setName]; resource != "" {
+			rejected = append(rejected, fmt.Sprintf("%s: %s", setName, resource))
+		}
+	}
+
+	if len(rejected) == 0 {
+		// only report the condition after any pod has been rejected
+		if utiltidbcluster.GetTidbClusterCondition(tc.Status, v1alpha1.TidbClusterQuotaExceeded) == nil {
+			return
+		}
+		cond := utiltidbcluster.NewTidbClusterCondition(v1alpha1.TidbClusterQuotaExceeded, v1.ConditionFalse,
+			utiltidbcluster.QuotaSufficient, "No pod is rejected by ResourceQuota or LimitRange")
+		utiltidbcluster.SetTidbClusterCondition(&tc.Status, *cond)
+		return
+	}
+	cond := utiltidbcluster.NewTidbClusterCondition(v1alpha1.TidbClusterQuotaExceeded, v1.ConditionTrue,
+		utiltidbcluster.PodsRejectedByQuota, fmt.Sprintf("Pod(s) are rejected: %s", strings.Join(rejected, "; ")))
+	utiltidbcluster.SetTidbClusterCondition(&tc.Status, *cond)
+}
+
+// updateASTSCompatibleCondition reports the result of the latest compatibility check of Advanced StatefulSet,
+// the check runs periodically if the AdvancedStatefulSet feature is enabled
+func (u *tidbClusterConditionUpdater) updateASTSCompatibleCondition(tc *v1alpha1.TidbCluster) {
+	if !features.DefaultFeatureGate.Enabled(features.AdvancedStatefulSet) || u.deps.ASTSCompatibility == nil {
+		return
+	}
+	checked, problems := u.deps.ASTSCompatibility.Get(tc.Namespace, tc.Name)
+	if !checked {
+		return
+	}
+	status := v1.ConditionTrue
+	reason := utiltidbcluster.AdvancedStatefulSetVerified
+	message := "Advanced StatefulSet is compatible with the operator"
+	if len(problems) > 0 {
+		status = v1.ConditionFalse
+		reason = utiltidbcluster.AdvancedStatefulSetIncompatible
+		message = fmt.Sprintf("Advanced StatefulSet is incompatible with the operator: %s", strings.Join(problems, "; "))
+	}
+	cond := utiltidbcluster.NewTidbClusterCondition(v1alpha1.TidbClusterAdvancedStatefulSetCompatible, status, reason, message)
+	utiltidbcluster.SetTidbClusterCondition(&tc.Status, *cond)
+}
+
+// quotaRejection returns the ResourceQuota or LimitRange that rejects the pods of the StatefulSet,
+// it's empty if the StatefulSet has all the desired pods or the pods are not rejected recently.
+func (u *tidbClusterConditionUpdater) quotaRejection(ns, setName string) (string, error) {
+	set, err := u.deps.StatefulSetLister.StatefulSets(ns).Get(setName)
+	if errors.IsNotFound(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get statefulset %s/%s: %v", ns, setName, err)
+	}
+	if set.Spec.Replicas == nil || set.Status.Replicas >= *set.Spec.Replicas {
+		return "", nil
+	}
+
+	selector := fields.Set{
+		"involvedObject.kind": "StatefulSet",
+		"involvedObject.name": setName,
+		"reason":              "FailedCreate",
+	}.AsSelector().String()
+	events, err := u.deps.KubeClientset.CoreV1().Events(ns).List(context.TODO(), metav1.ListOptions{FieldSelector: selector})
+	if err != nil {
+		return "", fmt.Errorf("failed to list events of statefulset %s/%s: %v", ns, setName, err)
+	}
+	var latest *v1.Event
+	for i := range events.Items {
+		ev := &events.Items[i]
+		if ev.InvolvedObject.Kind != "StatefulSet" || ev.InvolvedObject.Name != setName || ev.Reason != "FailedCreate" {
+			continue
+		}
+		if latest == nil || eventTime(ev).After(eventTime(latest)) {
+			latest = ev
+		}
+	}
+	if latest == nil || time.Since(eventTime(latest)) > quotaEventWindow {
+		return "", nil
+	}
+	resource, _ := controller.ParseQuotaExceededMessage(latest.Message)
+	return resource, nil
+}
+
+func eventTime(ev *v1.Event) time.Time {
+	if !ev.LastTimestamp.IsZero() {
+		return ev.LastTimestamp.Time
+	}
+	return ev.EventTime.Time
+}
+
+// componentStatefulSetNames returns the names of the StatefulSets of the components in the spec
+func componentStatefulSetNames(tc *v1alpha1.TidbCluster) []string {
+	tcName := tc.Name
+	var names []string
+	if tc.Spec.PD != nil {
+		names = append(names, controller.PDMemberName(tcName))
+	}
+	if tc.Spec.TiKV != nil {
+		names = append(names, controller.TiKVMemberName(tcName))
+	}
+	if tc.Spec.TiDB != nil {
+		names = append(names, controller.TiDBMemberName(tcName))
+	}
+	if tc.Spec.TiFlash != nil {
+		names = append(names, controller.TiFlashMemberName(tcName))
+	}
+	if tc.Spec.TiCDC != nil {
+		names = append(names, controller.TiCDCMemberName(tcName))
+	}
+	if tc.Spec.TiProxy != nil {
+		names = append(names, controller.TiProxyMemberName(tcName))
+	}
+	if tc.Spec.Pump != nil {
+		names = append(names, controller.PumpMemberName(tcName))
+	}
+	return names
+}
@@ -14,13 +14,19 @@
 package tidbcluster
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/features"
 	utiltidbcluster "github.com/pingcap/tidb-operator/pkg/util/tidbcluster"
 	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
 )
 
 func TestTidbClusterConditionUpdater_Ready(t *testing.T) {
@@ -341,6 +347,74 @@ func TestTidbClusterConditionUpdater_Ready(t *testing.T) {
 			wantReason:  utiltidbcluster.TiCDCCaptureNotReady,
 			wantMessage: "TiCDC capture(s) are not up",
 		},
+		{
+			name: "tiproxy(s) not healthy",
+			tc: &v1alpha1.TidbCluster{
+				Spec: v1alpha1.TidbClusterSpec{
+					PD: &v1alpha1.PDSpec{
+						Replicas: 1,
+					},
+					TiKV: &v1alpha1.TiKVSpec{
+						Replicas: 1,
+					},
+					TiDB: &v1alpha1.TiDBSpec{
+						Replicas: 1,
+					},
+					TiProxy: &v1alpha1.TiProxySpec{
+						Replicas: 1,
+					},
+				},
+				Status: v1alpha1.TidbClusterStatus{
+					PD: v1alpha1.PDStatus{
+						Members: map[string]v1alpha1.PDMember{
+							"pd-0": {
+								Health: true,
+							},
+						},
+						StatefulSet: &appsv1.StatefulSetStatus{
+							CurrentRevision: "2",
+							UpdateRevision:  "2",
+						},
+					},
+					TiDB: v1alpha1.TiDBStatus{
+						Members: map[string]v1alpha1.TiDBMember{
+							"tidb-0": {
+								Health: true,
+							},
+						},
+						StatefulSet: &appsv1.StatefulSetStatus{
+							CurrentRevision: "2",
+							UpdateRevision:  "2",
+						},
+					},
+					TiKV: v1alpha1.TiKVStatus{
+						Stores: map[string]v1alpha1.TiKVStore{
+							"tikv-0": {
+								State: "Up",
+							},
+						},
+						StatefulSet: &appsv1.StatefulSetStatus{
+							CurrentRevision: "2",
+							UpdateRevision:  "2",
+						},
+					},
+					TiProxy: v1alpha1.TiProxyStatus{
+						Members: map[string]v1alpha1.TiProxyMember{
+							"tiproxy-0": {
+								Health: false,
+							},
+						},
+						StatefulSet: &appsv1.StatefulSetStatus{
+							CurrentRevision: "2",
+							UpdateRevision:  "2",
+						},
+					},
+				},
+			},
+			wantStatus:  v1.ConditionFalse,
+			wantReason:  utiltidbcluster.TiProxyUnhealthy,
+			wantMessage: "TiProxy(s) are not healthy",
+		},
 		{
 			name: "all ready",
 			tc: &v1alpha1.TidbCluster{
@@ -413,7 +487,7 @@ func TestTidbClusterConditionUpdater_Ready(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			conditionUpdater := &tidbClusterConditionUpdater{}
+			conditionUpdater := &tidbClusterConditionUpdater{deps: controller.NewFakeDependencies()}
 			conditionUpdater.Update(tt.tc)
 			cond := utiltidbcluster.GetTidbClusterCondition(tt.tc.Status, v1alpha1.TidbClusterReady)
 			if diff := cmp.Diff(tt.wantStatus, cond.Status); diff != "" {
@@ -428,3 +502,179 @@ func TestTidbClusterConditionUpdater_Ready(t *testing.T) {
 		})
 	}
 }
+
+func TestTidbClusterConditionUpdater_QuotaExceeded(t *testing.T) {
+	tc := &v1alpha1.TidbCluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "demo"},
+		Spec: v1alpha1.TidbClusterSpec{
+			TiKV: &v1alpha1.TiKVSpec{},
+		},
+	}
+	setName := controller.TiKVMemberName(tc.Name)
+	deps := controller.NewFakeDependencies()
+	set := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Namespace: tc.Namespace, Name: setName},
+		Spec:       appsv1.StatefulSetSpec{Replicas: pointer.Int32Ptr(4)},
+		Status:     appsv1.StatefulSetStatus{Replicas: 3},
+	}
+	deps.KubeInformerFactory.Apps().V1().StatefulSets().Informer().GetIndexer().Add(set)
+	conditionUpdater := &tidbClusterConditionUpdater{deps: deps}
+
+	// no event, the condition is not reported
+	if err := conditionUpdater.Update(tc); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cond := utiltidbcluster.GetTidbClusterCondition(tc.Status, v1alpha1.TidbClusterQuotaExceeded); cond != nil {
+		t.Fatalf("unexpected condition: %v", cond)
+	}
+
+	event := &v1.Event{
+		ObjectMeta:     metav1.ObjectMeta{Namespace: tc.Namespace, Name: setName + ".1"},
+		InvolvedObject: v1.ObjectReference{Kind: "StatefulSet", Namespace: tc.Namespace, Name: setName},
+		Reason:         "FailedCreate",
+		Message: "create Pod demo-tikv-3 in StatefulSet demo-tikv failed error: pods \"demo-tikv-3\" is forbidden: " +
+			"exceeded quota: compute-resources, requested: limits.cpu=2, used: limits.cpu=6, limited: limits.cpu=6",
+		LastTimestamp: metav1.Now(),
+	}
+	if _, err := deps.KubeClientset.CoreV1().Events(tc.Namespace).Create(context.TODO(), event, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("failed to create event: %v", err)
+	}
+	if err := conditionUpdater.Update(tc); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cond := utiltidbcluster.GetTidbClusterCondition(tc.Status, v1alpha1.TidbClusterQuotaExceeded)
+	if cond == nil || cond.Status != v1.ConditionTrue || cond.Reason != utiltidbcluster.PodsRejectedByQuota {
+		t.Fatalf("unexpected condition: %v", cond)
+	}
+	if diff := cmp.Diff("Pod(s) are rejected: demo-tikv: ResourceQuota compute-resources: limits.cpu", cond.Message); diff != "" {
+		t.Errorf("unexpected message (-want, +got): %s", diff)
+	}
+	if !tc.IsQuotaExceeded() {
+		t.Errorf("expect the quota of the cluster to be exceeded")
+	}
+
+	// the event is outdated
+	event.LastTimestamp = metav1.NewTime(time.Now().Add(-2 * quotaEventWindow))
+	if _, err := deps.KubeClientset.CoreV1().Events(tc.Namespace).Update(context.TODO(), event, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("failed to update event: %v", err)
+	}
+	if err := conditionUpdater.Update(tc); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cond = utiltidbcluster.GetTidbClusterCondition(tc.Status, v1alpha1.TidbClusterQuotaExceeded)
+	if cond == nil || cond.Status != v1.ConditionFalse || cond.Reason != utiltidbcluster.QuotaSufficient {
+		t.Fatalf("unexpected condition: %v", cond)
+	}
+}
+
+func TestTidbClusterConditionUpdater_AdvancedStatefulSetCompatible(t *testing.T) {
+	saved := features.DefaultFeatureGate.String()
+	features.DefaultFeatureGate.Set("AdvancedStatefulSet=true")
+	defer features.DefaultFeatureGate.Set(saved) // reset features on exit
+
+	tc := &v1alpha1.TidbCluster{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "demo"}}
+	deps := controller.NewFakeDependencies()
+	conditionUpdater := &tidbClusterConditionUpdater{deps: deps}
+
+	// not checked yet, the condition is not reported
+	if err := conditionUpdater.Update(tc); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cond := utiltidbcluster.GetTidbClusterCondition(tc.Status, v1alpha1.TidbClusterAdvancedStatefulSetCompatible); cond != nil {
+		t.Fatalf("unexpected condition: %v", cond)
+	}
+
+	// the problems of the other clusters are ignored
+	deps.ASTSCompatibility.Set([]controller.ASTSProblem{{Namespace: "default", Cluster: "other", Message: "not migrated"}})
+	if err := conditionUpdater.Update(tc); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cond := utiltidbcluster.GetTidbClusterCondition(tc.Status, v1alpha1.TidbClusterAdvancedStatefulSetCompatible)
+	if cond == nil || cond.Status != v1.ConditionTrue || cond.Reason != utiltidbcluster.AdvancedStatefulSetVerified {
+		t.Fatalf("unexpected condition: %v", cond)
+	}
+
+	deps.ASTSCompatibility.Set([]controller.ASTSProblem{
+		{Message: "controller too old"},
+		{Namespace: "default", Cluster: "demo", Message: "not migrated"},
+	})
+	if err := conditionUpdater.Update(tc); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cond = utiltidbcluster.GetTidbClusterCondition(tc.Status, v1alpha1.TidbClusterAdvancedStatefulSetCompatible)
+	if cond == nil || cond.Status != v1.ConditionFalse || cond.Reason != utiltidbcluster.AdvancedStatefulSetIncompatible {
+		t.Fatalf("unexpected condition: %v", cond)
+	}
+	if diff := cmp.Diff("Advanced StatefulSet is incompatible with the operator: controller too old; not migrated", cond.Message); diff != "" {
+		t.Errorf("unexpected message (-want, +got): %s", diff)
+	}
+}
+
+func TestTidbClusterConditionUpdater_ComponentConditions(t *testing.T) {
+	tc := &v1alpha1.TidbCluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "demo", Generation: 3},
+		Spec: v1alpha1.TidbClusterSpec{
+			PD:   &v1alpha1.PDSpec{Replicas: 1},
+			TiKV: &v1alpha1.TiKVSpec{Replicas: 1},
+			Pump: &v1alpha1.PumpSpec{Replicas: 1},
+		},
+		Status: v1alpha1.TidbClusterStatus{
+			PD: v1alpha1.PDStatus{
+				Phase:   v1alpha1.UpgradePhase,
+				Members: map[string]v1alpha1.PDMember{"demo-pd-0": {Health: true}},
+				StatefulSet: &appsv1.StatefulSetStatus{
+					CurrentRevision: "1",
+					UpdateRevision:  "2",
+				},
+			},
+			TiKV: v1alpha1.TiKVStatus{
+				Phase:  v1alpha1.ScalePhase,
+				Synced: false,
+				Stores: map[string]v1alpha1.TiKVStore{"1": {State: v1alpha1.TiKVStateDown}},
+			},
+			Pump: v1alpha1.PumpStatus{Phase: v1alpha1.NormalPhase},
+		},
+	}
+	conditionUpdater := &tidbClusterConditionUpdater{deps: controller.NewFakeDependencies()}
+	if err := conditionUpdater.Update(tc); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expectCondition := func(conds []metav1.Condition, condType string, status metav1.ConditionStatus, reason string) {
+		t.Helper()
+		for _, cond := range conds {
+			if cond.Type != condType {
+				continue
+			}
+			if cond.Status != status || cond.Reason != reason || cond.ObservedGeneration != tc.Generation {
+				t.Errorf("unexpected condition %s: %v", condType, cond)
+			}
+			if cond.LastTransitionTime.IsZero() {
+				t.Errorf("expect the transition time of condition %s to be set", condType)
+			}
+			return
+		}
+		t.Errorf("condition %s not found in %v", condType, conds)
+	}
+	expectCondition(tc.Status.PD.Conditions, v1alpha1.ComponentReady, metav1.ConditionFalse, utiltidbcluster.StatfulSetNotUpToDate)
+	expectCondition(tc.Status.PD.Conditions, v1alpha1.ComponentUpgradeInProgress, metav1.ConditionTrue, utiltidbcluster.Upgrading)
+	expectCondition(tc.Status.PD.Conditions, v1alpha1.ComponentScalingBlocked, metav1.ConditionFalse, utiltidbcluster.ScalingNotBlocked)
+	expectCondition(tc.Status.TiKV.Conditions, v1alpha1.ComponentReady, metav1.ConditionFalse, utiltidbcluster.TiKVStoreNotUp)
+	expectCondition(tc.Status.TiKV.Conditions, v1alpha1.ComponentUpgradeInProgress, metav1.ConditionFalse, utiltidbcluster.NotUpgrading)
+	expectCondition(tc.Status.TiKV.Conditions, v1alpha1.ComponentScalingBlocked, metav1.ConditionTrue, utiltidbcluster.ScalingStatusNotSynced)
+	expectCondition(tc.Status.Pump.Conditions, v1alpha1.ComponentScalingBlocked, metav1.ConditionFalse, utiltidbcluster.ScalingNotBlocked)
+	for _, cond := range tc.Status.Pump.Conditions {
+		if cond.Type == v1alpha1.ComponentReady {
+			t.Errorf("unexpected condition %v of pump", cond)
+		}
+	}
+
+	// the conditions are updated once the members are ready
+	tc.Status.PD.Phase = v1alpha1.NormalPhase
+	tc.Status.PD.StatefulSet.CurrentRevision = "2"
+	if err := conditionUpdater.Update(tc); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expectCondition(tc.Status.PD.Conditions, v1alpha1.ComponentReady, metav1.ConditionTrue, utiltidbcluster.Ready)
+	expectCondition(tc.Status.PD.Conditions, v1alpha1.ComponentUpgradeInProgress, metav1.ConditionFalse, utiltidbcluster.NotUpgrading)
+}
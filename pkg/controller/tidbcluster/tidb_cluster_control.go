@@ -14,12 +14,17 @@
 package tidbcluster
 
 import (
+	"encoding/json"
+	"fmt"
+
 	v1 "k8s.io/api/core/v1"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	errorutils "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/klog/v2"
+	"k8s.io/kubernetes/pkg/util/slice"
 
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1/defaulting"
 	v1alpha1validation "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1/validation"
@@ -49,15 +54,22 @@ func NewDefaultTidbClusterControl(
 	tiproxyMemberManager manager.Manager,
 	reclaimPolicyManager manager.Manager,
 	metaManager manager.Manager,
+	orphanResourceJanitor manager.Manager,
 	orphanPodsCleaner member.OrphanPodsCleaner,
 	pvcCleaner member.PVCCleanerInterface,
 	// pvcResizer member.PVCResizerInterface,
 	pvcModifier volumes.PVCModifierInterface,
+	pvcReplacer volumes.PVCReplacerInterface,
 	pumpMemberManager manager.Manager,
 	tiflashMemberManager manager.Manager,
 	ticdcMemberManager manager.Manager,
+	healthProbeManager manager.Manager,
 	discoveryManager member.TidbDiscoveryManager,
+	standbyManager manager.Manager,
 	tidbClusterStatusManager manager.Manager,
+	teardownManager manager.Manager,
+	vaultCertManager manager.Manager,
+	secretPropagationManager manager.Manager,
 	conditionUpdater TidbClusterConditionUpdater,
 	recorder record.EventRecorder) ControlInterface {
 	return &defaultTidbClusterControl{
@@ -68,14 +80,21 @@ func NewDefaultTidbClusterControl(
 		tiproxyMemberManager:     tiproxyMemberManager,
 		reclaimPolicyManager:     reclaimPolicyManager,
 		metaManager:              metaManager,
+		orphanResourceJanitor:    orphanResourceJanitor,
 		orphanPodsCleaner:        orphanPodsCleaner,
 		pvcCleaner:               pvcCleaner,
 		pvcModifier:              pvcModifier,
+		pvcReplacer:              pvcReplacer,
 		pumpMemberManager:        pumpMemberManager,
 		tiflashMemberManager:     tiflashMemberManager,
 		ticdcMemberManager:       ticdcMemberManager,
+		healthProbeManager:       healthProbeManager,
 		discoveryManager:         discoveryManager,
+		standbyManager:           standbyManager,
 		tidbClusterStatusManager: tidbClusterStatusManager,
+		teardownManager:          teardownManager,
+		vaultCertManager:         vaultCertManager,
+		secretPropagationManager: secretPropagationManager,
 		conditionUpdater:         conditionUpdater,
 		recorder:                 recorder,
 	}
@@ -89,20 +108,31 @@ type defaultTidbClusterControl struct {
 	tiproxyMemberManager     manager.Manager
 	reclaimPolicyManager     manager.Manager
 	metaManager              manager.Manager
+	orphanResourceJanitor    manager.Manager
 	orphanPodsCleaner        member.OrphanPodsCleaner
 	pvcCleaner               member.PVCCleanerInterface
 	pvcModifier              volumes.PVCModifierInterface
+	pvcReplacer              volumes.PVCReplacerInterface
 	pumpMemberManager        manager.Manager
 	tiflashMemberManager     manager.Manager
 	ticdcMemberManager       manager.Manager
+	healthProbeManager       manager.Manager
 	discoveryManager         member.TidbDiscoveryManager
+	standbyManager           manager.Manager
 	tidbClusterStatusManager manager.Manager
+	teardownManager          manager.Manager
+	vaultCertManager         manager.Manager
+	secretPropagationManager manager.Manager
 	conditionUpdater         TidbClusterConditionUpdater
 	recorder                 record.EventRecorder
 }
 
 // UpdateStatefulSet executes the core logic loop for a tidbcluster.
 func (c *defaultTidbClusterControl) UpdateTidbCluster(tc *v1alpha1.TidbCluster) error {
+	if tc.DeletionTimestamp != nil {
+		return c.teardownTidbCluster(tc)
+	}
+
 	c.defaulting(tc)
 	if !c.validate(tc) {
 		return nil // fatal error, no need to retry on invalid object
@@ -119,6 +149,10 @@ func (c *defaultTidbClusterControl) UpdateTidbCluster(tc *v1alpha1.TidbCluster)
 		errs = append(errs, err)
 	}
 
+	if err := c.clearApplyPendingChanges(tc); err != nil {
+		errs = append(errs, err)
+	}
+
 	if apiequality.Semantic.DeepEqual(&tc.Status, oldStatus) {
 		return errorutils.NewAggregate(errs)
 	}
@@ -129,6 +163,60 @@ func (c *defaultTidbClusterControl) UpdateTidbCluster(tc *v1alpha1.TidbCluster)
 	return errorutils.NewAggregate(errs)
 }
 
+// teardownTidbCluster tears down a deleted TidbCluster, the components are not reconciled any more so that
+// the resources deleted by the teardown are not created again.
+func (c *defaultTidbClusterControl) teardownTidbCluster(tc *v1alpha1.TidbCluster) error {
+	oldStatus := tc.Status.DeepCopy()
+	err := c.teardownManager.Sync(tc)
+	// the TidbCluster is removed once the finalizer is removed, there is no status to update
+	if !slice.ContainsString(tc.Finalizers, label.TeardownFinalizer, nil) || apiequality.Semantic.DeepEqual(&tc.Status, oldStatus) {
+		return err
+	}
+	errs := []error{err}
+	if _, err := c.tcControl.UpdateTidbCluster(tc.DeepCopy(), &tc.Status, oldStatus); err != nil {
+		errs = append(errs, err)
+	}
+	return errorutils.NewAggregate(errs)
+}
+
+// clearApplyPendingChanges removes the annotation to apply the pending changes once the pending changes
+// of all components are applied, so that it does not release the changes made during later upgrades.
+func (c *defaultTidbClusterControl) clearApplyPendingChanges(tc *v1alpha1.TidbCluster) error {
+	if !member.NeedApplyPendingChanges(tc.Annotations) {
+		return nil
+	}
+	for _, pending := range []*v1alpha1.PendingChanges{
+		tc.Status.PD.PendingChanges,
+		tc.Status.TiKV.PendingChanges,
+		tc.Status.TiDB.PendingChanges,
+		tc.Status.TiFlash.PendingChanges,
+		tc.Status.TiCDC.PendingChanges,
+		tc.Status.TiProxy.PendingChanges,
+	} {
+		if pending != nil {
+			// the component is not synced yet, e.g. the sync of a previous component failed
+			return nil
+		}
+	}
+	data, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]interface{}{
+				label.AnnApplyPendingChangesKey: nil,
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if _, err := c.tcControl.Patch(tc, data); err != nil {
+		return fmt.Errorf("failed to remove annotation %s of tc %s/%s, error: %v", label.AnnApplyPendingChangesKey, tc.GetNamespace(), tc.GetName(), err)
+	}
+	// the status update below sends the whole object, so remove the annotation from it too
+	delete(tc.Annotations, label.AnnApplyPendingChangesKey)
+	klog.Infof("tidbcluster %s/%s: the pending changes are applied, remove annotation %s", tc.GetNamespace(), tc.GetName(), label.AnnApplyPendingChangesKey)
+	return nil
+}
+
 func (c *defaultTidbClusterControl) validate(tc *v1alpha1.TidbCluster) bool {
 	errs := v1alpha1validation.ValidateTidbCluster(tc)
 	if len(errs) > 0 {
@@ -150,6 +238,12 @@ func (c *defaultTidbClusterControl) updateTidbCluster(tc *v1alpha1.TidbCluster)
 	ns := tc.GetNamespace()
 	tcName := tc.GetName()
 
+	// keeping the teardown finalizer by whether the teardown is enabled
+	if err := c.teardownManager.Sync(tc); err != nil {
+		metrics.ClusterUpdateErrors.WithLabelValues(ns, tcName, "teardown").Inc()
+		return err
+	}
+
 	// syncing all PVs managed by operator's reclaim policy to Retain
 	if err := c.reclaimPolicyManager.Sync(tc); err != nil {
 		metrics.ClusterUpdateErrors.WithLabelValues(ns, tcName, "pv_reclaim_policy").Inc()
@@ -169,12 +263,31 @@ func (c *defaultTidbClusterControl) updateTidbCluster(tc *v1alpha1.TidbCluster)
 		}
 	}
 
+	// issue or renew the certificates of the components and the client from Vault,
+	// they're required by the discovery and the components to start
+	if err := c.vaultCertManager.Sync(tc); err != nil {
+		metrics.ClusterUpdateErrors.WithLabelValues(ns, tcName, "vault_cert").Inc()
+		return err
+	}
+
+	// copy the granted secrets of the joined cluster, e.g. the client secret with the CA certificate
+	if err := c.secretPropagationManager.Sync(tc); err != nil {
+		metrics.ClusterUpdateErrors.WithLabelValues(ns, tcName, "secret_propagation").Inc()
+		return err
+	}
+
 	// reconcile TiDB discovery service
 	if err := c.discoveryManager.Reconcile(tc); err != nil {
 		metrics.ClusterUpdateErrors.WithLabelValues(ns, tcName, "discovery").Inc()
 		return err
 	}
 
+	// pre-pull the images of a warm standby cluster
+	if err := c.standbyManager.Sync(tc); err != nil {
+		metrics.ClusterUpdateErrors.WithLabelValues(ns, tcName, "standby").Inc()
+		return err
+	}
+
 	// works that should be done to make the pd cluster current state match the desired state:
 	//   - create or update the pd service
 	//   - create or update the pd headless service
@@ -261,6 +374,14 @@ func (c *defaultTidbClusterControl) updateTidbCluster(tc *v1alpha1.TidbCluster)
 		return err
 	}
 
+	// works that should be done to make the health probe of the cluster current:
+	//   - deploy the probe which writes and reads a row through the tidb service periodically
+	//   - sync the result of the last probe to TidbCluster object
+	if err := c.healthProbeManager.Sync(tc); err != nil {
+		metrics.ClusterUpdateErrors.WithLabelValues(ns, tcName, "health_probe").Inc()
+		return err
+	}
+
 	// syncing the labels from Pod to PVC and PV, these labels include:
 	//   - label.StoreIDLabelKey
 	//   - label.MemberIDLabelKey
@@ -270,6 +391,13 @@ func (c *defaultTidbClusterControl) updateTidbCluster(tc *v1alpha1.TidbCluster)
 		return err
 	}
 
+	// reporting, adopting or pruning the services and configmaps of the cluster which are
+	// no longer referenced by the spec, according to the orphan resource policy
+	if err := c.orphanResourceJanitor.Sync(tc); err != nil {
+		metrics.ClusterUpdateErrors.WithLabelValues(ns, tcName, "orphan_resource_janitor").Inc()
+		return err
+	}
+
 	// cleaning the pod scheduling annotation for pd and tikv
 	pvcSkipReasons, err := c.pvcCleaner.Clean(tc)
 	if err != nil {
@@ -282,6 +410,14 @@ func (c *defaultTidbClusterControl) updateTidbCluster(tc *v1alpha1.TidbCluster)
 		}
 	}
 
+	// replace the volumes which can't be modified in place one pod at a time
+	if features.DefaultFeatureGate.Enabled(features.VolumeReplacing) {
+		if err := c.pvcReplacer.Sync(tc); err != nil {
+			metrics.ClusterUpdateErrors.WithLabelValues(ns, tcName, "pvc_replacer").Inc()
+			return err
+		}
+	}
+
 	// modify volumes if necessary
 	if features.DefaultFeatureGate.Enabled(features.VolumeModifying) {
 		if err := c.pvcModifier.Sync(tc); err != nil {
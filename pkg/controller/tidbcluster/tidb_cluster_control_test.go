@@ -19,6 +19,7 @@ import (
 	"testing"
 
 	. "github.com/onsi/gomega"
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
 	"github.com/pingcap/tidb-operator/pkg/client/clientset/versioned/fake"
 	informers "github.com/pingcap/tidb-operator/pkg/client/informers/externalversions"
@@ -294,6 +295,23 @@ func TestTidbClusterStatusEquality(t *testing.T) {
 	g.Expect(apiequality.Semantic.DeepEqual(&tcStatus, tcStatusCopy)).To(Equal(false))
 }
 
+func TestTidbClusterControlClearApplyPendingChanges(t *testing.T) {
+	g := NewGomegaWithT(t)
+	control, _, _, _, _, _, _, _, _ := newFakeTidbClusterControl()
+	c := control.(*defaultTidbClusterControl)
+
+	tc := newTidbClusterForTidbClusterControl()
+	tc.Annotations = map[string]string{label.AnnApplyPendingChangesKey: label.AnnApplyPendingChangesVal}
+	tc.Status.TiKV.PendingChanges = &v1alpha1.PendingChanges{Operation: v1alpha1.UpgradePhase}
+	// the pending changes of tikv are not applied yet
+	g.Expect(c.clearApplyPendingChanges(tc)).To(Succeed())
+	g.Expect(tc.Annotations).To(HaveKey(label.AnnApplyPendingChangesKey))
+
+	tc.Status.TiKV.PendingChanges = nil
+	g.Expect(c.clearApplyPendingChanges(tc)).To(Succeed())
+	g.Expect(tc.Annotations).NotTo(HaveKey(label.AnnApplyPendingChangesKey))
+}
+
 func newFakeTidbClusterControl() (
 	ControlInterface,
 	*meta.FakeReclaimPolicyManager,
@@ -314,14 +332,20 @@ func newFakeTidbClusterControl() (
 	tidbMemberManager := mm.NewFakeTiDBMemberManager()
 	reclaimPolicyManager := meta.NewFakeReclaimPolicyManager()
 	metaManager := meta.NewFakeMetaManager()
+	orphanResourceJanitor := meta.NewFakeOrphanResourceJanitor()
 	orphanPodCleaner := mm.NewFakeOrphanPodsCleaner()
 	pvcCleaner := mm.NewFakePVCCleaner()
 	pumpMemberManager := mm.NewFakePumpMemberManager()
 	tiflashMemberManager := mm.NewFakeTiFlashMemberManager()
 	tiproxyMemberManager := mm.NewFakeTiProxyMemberManager()
 	ticdcMemberManager := mm.NewFakeTiCDCMemberManager()
+	healthProbeManager := mm.NewFakeHealthProbeManager()
 	discoveryManager := mm.NewFakeDiscoveryManger()
+	standbyManager := mm.NewFakeStandbyManager()
 	statusManager := mm.NewFakeTidbClusterStatusManager()
+	teardownManager := meta.NewFakeTeardownManager()
+	vaultCertManager := meta.NewFakeVaultCertManager()
+	secretPropagationManager := meta.NewFakeSecretPropagationManager()
 	pvcResizer := mm.NewFakePVCResizer()
 	control := NewDefaultTidbClusterControl(
 		tcUpdater,
@@ -331,15 +355,22 @@ func newFakeTidbClusterControl() (
 		tiproxyMemberManager,
 		reclaimPolicyManager,
 		metaManager,
+		orphanResourceJanitor,
 		orphanPodCleaner,
 		pvcCleaner,
 		pvcResizer,
+		pvcResizer,
 		pumpMemberManager,
 		tiflashMemberManager,
 		ticdcMemberManager,
+		healthProbeManager,
 		discoveryManager,
+		standbyManager,
 		statusManager,
-		&tidbClusterConditionUpdater{},
+		teardownManager,
+		vaultCertManager,
+		secretPropagationManager,
+		&tidbClusterConditionUpdater{deps: controller.NewFakeDependencies()},
 		recorder,
 	)
 
@@ -61,15 +61,22 @@ func NewController(deps *controller.Dependencies) *Controller {
 			mm.NewTiProxyMemberManager(deps, mm.NewTiProxyScaler(deps), mm.NewTiProxyUpgrader(deps), suspender),
 			meta.NewReclaimPolicyManager(deps),
 			meta.NewMetaManager(deps),
+			meta.NewOrphanResourceJanitor(deps),
 			mm.NewOrphanPodsCleaner(deps),
 			mm.NewRealPVCCleaner(deps),
 			volumes.NewPVCModifier(deps),
+			volumes.NewPVCReplacer(deps),
 			mm.NewPumpMemberManager(deps, mm.NewPumpScaler(deps), suspender, podVolumeModifier),
 			mm.NewTiFlashMemberManager(deps, mm.NewTiFlashFailover(deps), mm.NewTiFlashScaler(deps), mm.NewTiFlashUpgrader(deps), suspender, podVolumeModifier),
 			mm.NewTiCDCMemberManager(deps, mm.NewTiCDCScaler(deps), mm.NewTiCDCUpgrader(deps), suspender, podVolumeModifier),
+			mm.NewHealthProbeManager(deps),
 			mm.NewTidbDiscoveryManager(deps),
+			mm.NewStandbyManager(deps),
 			mm.NewTidbClusterStatusManager(deps),
-			&tidbClusterConditionUpdater{},
+			meta.NewTeardownManager(deps),
+			meta.NewVaultCertManager(deps),
+			meta.NewSecretPropagationManager(deps),
+			&tidbClusterConditionUpdater{deps: deps},
 			deps.Recorder,
 		),
 		queue: workqueue.NewNamedRateLimitingQueue(
@@ -135,7 +142,9 @@ func (c *Controller) processNextWorkItem() bool {
 		return false
 	}
 	defer c.queue.Done(key)
-	if err := c.sync(key.(string)); err != nil {
+	err := c.sync(key.(string))
+	controller.ObserveReconcile(c.Name(), err)
+	if err != nil {
 		if perrors.Find(err, controller.IsRequeueError) != nil {
 			klog.Infof("TidbCluster: %v, still need sync: %v, requeuing", key.(string), err)
 		} else {
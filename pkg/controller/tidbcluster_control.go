@@ -15,8 +15,10 @@ package controller
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
+	jsonpatch "github.com/evanphx/json-patch"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
@@ -66,7 +68,7 @@ func (c *realTidbClusterControl) UpdateTidbCluster(tc *v1alpha1.TidbCluster, new
 	// don't wait due to limited number of clients, but backoff after the default number of steps
 	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
 		var updateErr error
-		updateTC, updateErr = c.cli.PingcapV1alpha1().TidbClusters(ns).Update(context.TODO(), tc, metav1.UpdateOptions{})
+		updateTC, updateErr = c.updateOrPatch(tc)
 		if updateErr == nil {
 			klog.Infof("TidbCluster: [%s/%s] updated successfully", ns, tcName)
 			return nil
@@ -79,6 +81,8 @@ func (c *realTidbClusterControl) UpdateTidbCluster(tc *v1alpha1.TidbCluster, new
 			// TiKV.EvictLeader is controlled by pod leader evictor in pkg/controller/tidbcluster/pod_control.go
 			// So don't overwrite it
 			status.TiKV.EvictLeader = tc.Status.TiKV.EvictLeader
+			// PD.Disruptions is shared by the voluntary disruptions from different sources, so don't overwrite it
+			status.PD.Disruptions = tc.Status.PD.Disruptions
 			tc.Status = *status
 		} else {
 			utilruntime.HandleError(fmt.Errorf("error getting updated TidbCluster %s/%s from lister: %v", ns, tcName, err))
@@ -92,6 +96,53 @@ func (c *realTidbClusterControl) UpdateTidbCluster(tc *v1alpha1.TidbCluster, new
 	return updateTC, err
 }
 
+// updateOrPatch writes the TidbCluster by a JSON merge patch of the changes against the cached object it's
+// based on, so only the changed chunks of the status are sent instead of the whole object, which is large for
+// a cluster with hundreds of stores. The resource version in the patch keeps the optimistic concurrency of an
+// update. The whole object is updated if the cached object is missing or is not the one tc is based on.
+func (c *realTidbClusterControl) updateOrPatch(tc *v1alpha1.TidbCluster) (*v1alpha1.TidbCluster, error) {
+	ns, tcName := tc.GetNamespace(), tc.GetName()
+	if c.tcLister != nil {
+		base, err := c.tcLister.TidbClusters(ns).Get(tcName)
+		if err == nil && base.ResourceVersion == tc.ResourceVersion {
+			patch, err := createTidbClusterMergePatch(base, tc)
+			if err == nil {
+				return c.cli.PingcapV1alpha1().TidbClusters(ns).Patch(context.TODO(), tcName, types.MergePatchType, patch, metav1.PatchOptions{})
+			}
+			klog.Warningf("failed to create the patch of TidbCluster: [%s/%s], update the whole object, error: %v", ns, tcName, err)
+		}
+	}
+	return c.cli.PingcapV1alpha1().TidbClusters(ns).Update(context.TODO(), tc, metav1.UpdateOptions{})
+}
+
+// createTidbClusterMergePatch returns the JSON merge patch from base to tc, with the resource version of tc as the
+// precondition of the patch.
+func createTidbClusterMergePatch(base, tc *v1alpha1.TidbCluster) ([]byte, error) {
+	baseJSON, err := json.Marshal(base)
+	if err != nil {
+		return nil, err
+	}
+	tcJSON, err := json.Marshal(tc)
+	if err != nil {
+		return nil, err
+	}
+	patchJSON, err := jsonpatch.CreateMergePatch(baseJSON, tcJSON)
+	if err != nil {
+		return nil, err
+	}
+	patch := map[string]interface{}{}
+	if err := json.Unmarshal(patchJSON, &patch); err != nil {
+		return nil, err
+	}
+	meta, ok := patch["metadata"].(map[string]interface{})
+	if !ok {
+		meta = map[string]interface{}{}
+		patch["metadata"] = meta
+	}
+	meta["resourceVersion"] = tc.ResourceVersion
+	return json.Marshal(patch)
+}
+
 func (c *realTidbClusterControl) Update(tc *v1alpha1.TidbCluster) (*v1alpha1.TidbCluster, error) {
 	ns := tc.GetNamespace()
 	tcName := tc.GetName()
@@ -14,6 +14,7 @@
 package controller
 
 import (
+	"encoding/json"
 	"errors"
 	"testing"
 
@@ -71,3 +72,36 @@ func TestTidbClusterControlUpdateTidbClusterConflictSuccess(t *testing.T) {
 	_, err = control.Update(tc)
 	g.Expect(err).To(Succeed())
 }
+
+func TestTidbClusterControlUpdateTidbClusterPatch(t *testing.T) {
+	g := NewGomegaWithT(t)
+	recorder := record.NewFakeRecorder(10)
+	tc := newTidbCluster()
+	tc.ResourceVersion = "1"
+	tc.Status.TiKV.Stores = map[string]v1alpha1.TiKVStore{"1": {ID: "1"}, "2": {ID: "2"}}
+	fakeClient := &fake.Clientset{}
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	g.Expect(indexer.Add(tc)).To(Succeed())
+	control := NewRealTidbClusterControl(fakeClient, listers.NewTidbClusterLister(indexer), recorder)
+	var patch map[string]interface{}
+	fakeClient.AddReactor("patch", "tidbclusters", func(action core.Action) (bool, runtime.Object, error) {
+		g.Expect(json.Unmarshal(action.(core.PatchAction).GetPatch(), &patch)).To(Succeed())
+		return true, tc, nil
+	})
+
+	// only the changes of the status are sent
+	updated := tc.DeepCopy()
+	delete(updated.Status.TiKV.Stores, "1")
+	updated.Status.TiKV.Synced = true
+	_, err := control.UpdateTidbCluster(updated, &updated.Status, &tc.Status)
+	g.Expect(err).To(Succeed())
+	g.Expect(patch).To(Equal(map[string]interface{}{
+		"metadata": map[string]interface{}{"resourceVersion": "1"},
+		"status": map[string]interface{}{
+			"tikv": map[string]interface{}{
+				"synced": true,
+				"stores": map[string]interface{}{"1": nil},
+			},
+		},
+	}))
+}
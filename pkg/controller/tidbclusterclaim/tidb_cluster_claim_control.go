@@ -0,0 +1,318 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tidbclusterclaim
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	listers "github.com/pingcap/tidb-operator/pkg/client/listers/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+)
+
+// ControlInterface reconciles TidbClusterClaim
+type ControlInterface interface {
+	// ReconcileTidbClusterClaim renders the TidbCluster of the claim from its template
+	ReconcileTidbClusterClaim(claim *v1alpha1.TidbClusterClaim) error
+}
+
+// NewDefaultTidbClusterClaimControl returns a new instance of the default TidbClusterClaim ControlInterface
+func NewDefaultTidbClusterClaimControl(deps *controller.Dependencies, templateLister listers.TidbClusterTemplateLister) ControlInterface {
+	return &defaultTidbClusterClaimControl{
+		deps:           deps,
+		templateLister: templateLister,
+	}
+}
+
+type defaultTidbClusterClaimControl struct {
+	deps           *controller.Dependencies
+	templateLister listers.TidbClusterTemplateLister
+}
+
+func (c *defaultTidbClusterClaimControl) ReconcileTidbClusterClaim(claim *v1alpha1.TidbClusterClaim) error {
+	status := claim.Status.DeepCopy()
+	err := c.reconcile(claim, status)
+	if err != nil {
+		status.Phase = v1alpha1.TidbClusterClaimFailed
+		status.Message = err.Error()
+		c.deps.Recorder.Event(claim, corev1.EventTypeWarning, "RenderFailed", err.Error())
+	}
+	if updateErr := c.updateStatus(claim, status); updateErr != nil {
+		return updateErr
+	}
+	// the claim is reconciled again if the template or the cluster is changed
+	if controller.IsIgnoreError(err) {
+		return nil
+	}
+	return err
+}
+
+func (c *defaultTidbClusterClaimControl) reconcile(claim *v1alpha1.TidbClusterClaim, status *v1alpha1.TidbClusterClaimStatus) error {
+	templateNS := claim.Spec.TemplateRef.Namespace
+	if templateNS == "" {
+		templateNS = claim.Namespace
+	}
+	if !templateNamespaceAllowed(c.deps.CLIConfig.TemplateNamespaces, claim.Namespace, templateNS) {
+		return controller.IgnoreErrorf("TidbClusterTemplates in namespace %s can't be referenced by the claims in namespace %s", templateNS, claim.Namespace)
+	}
+	tpl, err := c.templateLister.TidbClusterTemplates(templateNS).Get(claim.Spec.TemplateRef.Name)
+	if errors.IsNotFound(err) {
+		return controller.IgnoreErrorf("TidbClusterTemplate %s/%s is not found", templateNS, claim.Spec.TemplateRef.Name)
+	}
+	if err != nil {
+		return fmt.Errorf("get TidbClusterTemplate %s/%s failed, error: %v", templateNS, claim.Spec.TemplateRef.Name, err)
+	}
+	size, err := findSize(tpl, claim.Spec.Size)
+	if err != nil {
+		return err
+	}
+
+	// the name of the rendered cluster can't be changed
+	clusterName := status.ClusterName
+	if clusterName == "" {
+		clusterName = claim.Spec.ClusterName
+	}
+	if clusterName == "" {
+		clusterName = claim.Name
+	}
+	desired, err := renderTidbCluster(tpl, size, claim, clusterName)
+	if err != nil {
+		return err
+	}
+
+	heldReason, err := c.applyTidbCluster(claim, desired)
+	if err != nil {
+		return err
+	}
+	status.Phase = v1alpha1.TidbClusterClaimBound
+	status.ClusterName = clusterName
+	status.Message = heldReason
+	if heldReason != "" {
+		// the observed generations are kept, so that the changes not rolled out yet can be told
+		return nil
+	}
+	status.Size = size.Name
+	status.ObservedGeneration = claim.Generation
+	status.ObservedTemplateGeneration = tpl.Generation
+	return nil
+}
+
+// templateNamespaceAllowed returns whether the claims in claimNS can reference the templates in templateNS,
+// allowed is the comma-separated namespaces whose templates can be referenced across namespaces.
+func templateNamespaceAllowed(allowed, claimNS, templateNS string) bool {
+	if claimNS == templateNS {
+		return true
+	}
+	for _, ns := range strings.Split(allowed, ",") {
+		ns = strings.TrimSpace(ns)
+		if ns == "*" || ns == templateNS {
+			return true
+		}
+	}
+	return false
+}
+
+// applyTidbCluster creates the TidbCluster or updates it if the rendered spec is changed, e.g. the template is
+// updated or the claim is resized. The rendered spec is compared with the last applied one instead of the spec
+// of the TidbCluster, as the defaults are persisted into the spec by the operator.
+//
+// Only the difference between the last applied spec and the rendered spec is merged into the TidbCluster, so the
+// defaults and the fields set by others are kept unless the template or the size changes them. The changes are held back while the claim is paused or the TidbCluster is being
+// upgraded or scaled, the reason is returned in that case.
+func (c *defaultTidbClusterClaimControl) applyTidbCluster(claim *v1alpha1.TidbClusterClaim, desired *v1alpha1.TidbCluster) (string, error) {
+	ns, name := desired.Namespace, desired.Name
+	cli := c.deps.Clientset.PingcapV1alpha1().TidbClusters(ns)
+	tc, err := c.deps.TiDBClusterLister.TidbClusters(ns).Get(name)
+	if errors.IsNotFound(err) {
+		klog.Infof("TidbClusterClaim %s/%s: create TidbCluster %s", claim.Namespace, claim.Name, name)
+		if _, err := cli.Create(context.TODO(), desired, metav1.CreateOptions{}); err != nil {
+			return "", fmt.Errorf("create TidbCluster %s/%s failed, error: %v", ns, name, err)
+		}
+		c.deps.Recorder.Eventf(claim, corev1.EventTypeNormal, "Rendered", "TidbCluster %s is created", name)
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("get TidbCluster %s/%s failed, error: %v", ns, name, err)
+	}
+	if !metav1.IsControlledBy(tc, claim) {
+		return "", controller.IgnoreErrorf("TidbCluster %s/%s already exists and is not owned by the claim", ns, name)
+	}
+	lastApplied := tc.Annotations[controller.LastAppliedConfigAnnotation]
+	desiredApplied := desired.Annotations[controller.LastAppliedConfigAnnotation]
+	if lastApplied == desiredApplied {
+		return "", nil
+	}
+	if claim.Spec.Paused {
+		return "the changes are held back as the claim is paused", nil
+	}
+	if components := rollingComponents(tc); len(components) > 0 {
+		return fmt.Sprintf("the changes are held back until the rollout of %s is done", strings.Join(components, ", ")), nil
+	}
+
+	spec, err := mergeTidbClusterSpec(lastApplied, desiredApplied, &tc.Spec)
+	if err != nil {
+		return "", fmt.Errorf("merge the rendered spec into TidbCluster %s/%s failed, error: %v", ns, name, err)
+	}
+	klog.Infof("TidbClusterClaim %s/%s: update TidbCluster %s", claim.Namespace, claim.Name, name)
+	tc = tc.DeepCopy()
+	tc.Spec = *spec
+	if tc.Annotations == nil {
+		tc.Annotations = map[string]string{}
+	}
+	tc.Annotations[controller.LastAppliedConfigAnnotation] = desiredApplied
+	if _, err := cli.Update(context.TODO(), tc, metav1.UpdateOptions{}); err != nil {
+		return "", fmt.Errorf("update TidbCluster %s/%s failed, error: %v", ns, name, err)
+	}
+	c.deps.Recorder.Eventf(claim, corev1.EventTypeNormal, "Rendered", "TidbCluster %s is updated", name)
+	return "", nil
+}
+
+// rollingComponents returns the components of the TidbCluster being upgraded or scaled
+func rollingComponents(tc *v1alpha1.TidbCluster) []string {
+	var components []string
+	for _, status := range tc.AllComponentStatus() {
+		switch status.GetPhase() {
+		case v1alpha1.UpgradePhase, v1alpha1.ScalePhase:
+			components = append(components, status.MemberType().String())
+		}
+	}
+	return components
+}
+
+// mergeTidbClusterSpec merges the changes from the last applied spec to the desired one into the current spec by a
+// JSON merge patch, the last applied spec may be empty if the TidbCluster is not rendered by the claim before.
+func mergeTidbClusterSpec(lastApplied, desired string, current *v1alpha1.TidbClusterSpec) (*v1alpha1.TidbClusterSpec, error) {
+	if lastApplied == "" {
+		lastApplied = "{}"
+	}
+	currentJSON, err := json.Marshal(current)
+	if err != nil {
+		return nil, err
+	}
+	patch, err := jsonpatch.CreateMergePatch([]byte(lastApplied), []byte(desired))
+	if err != nil {
+		return nil, err
+	}
+	merged, err := jsonpatch.MergePatch(currentJSON, patch)
+	if err != nil {
+		return nil, err
+	}
+	spec := &v1alpha1.TidbClusterSpec{}
+	if err := json.Unmarshal(merged, spec); err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
+
+func (c *defaultTidbClusterClaimControl) updateStatus(claim *v1alpha1.TidbClusterClaim, status *v1alpha1.TidbClusterClaimStatus) error {
+	if apiequality.Semantic.DeepEqual(&claim.Status, status) {
+		return nil
+	}
+	claim = claim.DeepCopy()
+	claim.Status = *status
+	_, err := c.deps.Clientset.PingcapV1alpha1().TidbClusterClaims(claim.Namespace).UpdateStatus(context.TODO(), claim, metav1.UpdateOptions{})
+	if err != nil {
+		return fmt.Errorf("update status of TidbClusterClaim %s/%s failed, error: %v", claim.Namespace, claim.Name, err)
+	}
+	return nil
+}
+
+// findSize returns the size of the template by name, the default size is returned if name is empty
+func findSize(tpl *v1alpha1.TidbClusterTemplate, name string) (*v1alpha1.TidbClusterSize, error) {
+	if name == "" {
+		name = tpl.Spec.DefaultSize
+	}
+	if name == "" && len(tpl.Spec.Sizes) > 0 {
+		name = tpl.Spec.Sizes[0].Name
+	}
+	for i := range tpl.Spec.Sizes {
+		if tpl.Spec.Sizes[i].Name == name {
+			return &tpl.Spec.Sizes[i], nil
+		}
+	}
+	return nil, controller.IgnoreErrorf("size %q is not found in TidbClusterTemplate %s/%s", name, tpl.Namespace, tpl.Name)
+}
+
+// renderTidbCluster renders the TidbCluster of the claim from the template and the size
+func renderTidbCluster(tpl *v1alpha1.TidbClusterTemplate, size *v1alpha1.TidbClusterSize,
+	claim *v1alpha1.TidbClusterClaim, clusterName string) (*v1alpha1.TidbCluster, error) {
+	tc := &v1alpha1.TidbCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            clusterName,
+			Namespace:       claim.Namespace,
+			OwnerReferences: []metav1.OwnerReference{controller.GetTidbClusterClaimOwnerRef(claim)},
+		},
+		Spec: *tpl.Spec.Template.DeepCopy(),
+	}
+
+	applySize := func(cs *v1alpha1.ComponentSize, replicas *int32, resources *corev1.ResourceRequirements) {
+		if cs == nil {
+			return
+		}
+		*replicas = cs.Replicas
+		*resources = *cs.ResourceRequirements.DeepCopy()
+	}
+	if tc.Spec.PD != nil {
+		applySize(size.PD, &tc.Spec.PD.Replicas, &tc.Spec.PD.ResourceRequirements)
+	}
+	if tc.Spec.TiKV != nil {
+		applySize(size.TiKV, &tc.Spec.TiKV.Replicas, &tc.Spec.TiKV.ResourceRequirements)
+	}
+	if tc.Spec.TiDB != nil {
+		applySize(size.TiDB, &tc.Spec.TiDB.Replicas, &tc.Spec.TiDB.ResourceRequirements)
+	}
+	if tc.Spec.TiFlash != nil {
+		applySize(size.TiFlash, &tc.Spec.TiFlash.Replicas, &tc.Spec.TiFlash.ResourceRequirements)
+	}
+
+	b, err := json.Marshal(tc.Spec)
+	if err != nil {
+		return nil, err
+	}
+	tc.Annotations = map[string]string{controller.LastAppliedConfigAnnotation: string(b)}
+	return tc, nil
+}
+
+var _ ControlInterface = &defaultTidbClusterClaimControl{}
+
+// FakeTidbClusterClaimControl is a fake TidbClusterClaim ControlInterface
+type FakeTidbClusterClaimControl struct {
+	err error
+}
+
+// NewFakeTidbClusterClaimControl returns a FakeTidbClusterClaimControl
+func NewFakeTidbClusterClaimControl() *FakeTidbClusterClaimControl {
+	return &FakeTidbClusterClaimControl{}
+}
+
+// SetReconcileTidbClusterClaimError sets error for TidbClusterClaimControl
+func (c *FakeTidbClusterClaimControl) SetReconcileTidbClusterClaimError(err error) {
+	c.err = err
+}
+
+// ReconcileTidbClusterClaim fake ReconcileTidbClusterClaim
+func (c *FakeTidbClusterClaimControl) ReconcileTidbClusterClaim(_ *v1alpha1.TidbClusterClaim) error {
+	return c.err
+}
+
+var _ ControlInterface = &FakeTidbClusterClaimControl{}
@@ -0,0 +1,241 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tidbclusterclaim
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestReconcileTidbClusterClaim(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := context.TODO()
+
+	deps := controller.NewFakeDependencies()
+	templateInformer := deps.InformerFactory.Pingcap().V1alpha1().TidbClusterTemplates()
+	control := NewDefaultTidbClusterClaimControl(deps, templateInformer.Lister())
+	claimCli := deps.Clientset.PingcapV1alpha1().TidbClusterClaims(corev1.NamespaceDefault)
+	tcCli := deps.Clientset.PingcapV1alpha1().TidbClusters(corev1.NamespaceDefault)
+
+	claim := newTidbClusterClaim()
+	_, err := claimCli.Create(ctx, claim, metav1.CreateOptions{})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	// the template is not found
+	g.Expect(control.ReconcileTidbClusterClaim(claim)).To(Succeed())
+	claim, err = claimCli.Get(ctx, claim.Name, metav1.GetOptions{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(claim.Status.Phase).To(Equal(v1alpha1.TidbClusterClaimFailed))
+
+	// the TidbCluster is created with the default size
+	tpl := newTidbClusterTemplate()
+	g.Expect(templateInformer.Informer().GetIndexer().Add(tpl)).To(Succeed())
+	g.Expect(control.ReconcileTidbClusterClaim(claim)).To(Succeed())
+	claim, err = claimCli.Get(ctx, claim.Name, metav1.GetOptions{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(claim.Status.Phase).To(Equal(v1alpha1.TidbClusterClaimBound))
+	g.Expect(claim.Status.ClusterName).To(Equal("claim"))
+	g.Expect(claim.Status.Size).To(Equal("small"))
+	tc, err := tcCli.Get(ctx, "claim", metav1.GetOptions{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(metav1.IsControlledBy(tc, claim)).To(BeTrue())
+	g.Expect(tc.Spec.Version).To(Equal("v7.1.0"))
+	g.Expect(tc.Spec.TiKV.Replicas).To(Equal(int32(3)))
+	g.Expect(tc.Spec.TiDB.Replicas).To(Equal(int32(1)))
+
+	// the TidbCluster is resized, only the fields changed by the size are merged into it
+	tcIndexer := deps.InformerFactory.Pingcap().V1alpha1().TidbClusters().Informer().GetIndexer()
+	tc.Spec.Version = "v6.5.0"
+	tc.Spec.TiDB.Replicas = 2
+	g.Expect(tcIndexer.Add(tc)).To(Succeed())
+	claim.Spec.Size = "large"
+	g.Expect(control.ReconcileTidbClusterClaim(claim)).To(Succeed())
+	tc, err = tcCli.Get(ctx, "claim", metav1.GetOptions{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(tc.Spec.Version).To(Equal("v6.5.0"))
+	g.Expect(tc.Spec.TiKV.Replicas).To(Equal(int32(5)))
+	g.Expect(tc.Spec.TiKV.Requests.Storage().String()).To(Equal("500Gi"))
+	g.Expect(tc.Spec.TiDB.Replicas).To(Equal(int32(2)))
+	claim, err = claimCli.Get(ctx, claim.Name, metav1.GetOptions{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(claim.Status.Size).To(Equal("large"))
+
+	// the changes of the template are held back while the TidbCluster is being upgraded
+	tc.Status.TiKV.Phase = v1alpha1.UpgradePhase
+	g.Expect(tcIndexer.Update(tc)).To(Succeed())
+	tpl = tpl.DeepCopy()
+	tpl.Spec.Template.Version = "v7.5.0"
+	tpl.Generation = 2
+	g.Expect(templateInformer.Informer().GetIndexer().Update(tpl)).To(Succeed())
+	g.Expect(control.ReconcileTidbClusterClaim(claim)).To(Succeed())
+	claim, err = claimCli.Get(ctx, claim.Name, metav1.GetOptions{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(claim.Status.Phase).To(Equal(v1alpha1.TidbClusterClaimBound))
+	g.Expect(claim.Status.Message).To(ContainSubstring("tikv"))
+	g.Expect(claim.Status.ObservedTemplateGeneration).To(BeZero())
+	tc, err = tcCli.Get(ctx, "claim", metav1.GetOptions{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(tc.Spec.Version).To(Equal("v6.5.0"))
+
+	// and while the claim is paused
+	tc.Status.TiKV.Phase = v1alpha1.NormalPhase
+	g.Expect(tcIndexer.Update(tc)).To(Succeed())
+	claim.Spec.Paused = true
+	g.Expect(control.ReconcileTidbClusterClaim(claim)).To(Succeed())
+	claim, err = claimCli.Get(ctx, claim.Name, metav1.GetOptions{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(claim.Status.Message).To(ContainSubstring("paused"))
+	tc, err = tcCli.Get(ctx, "claim", metav1.GetOptions{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(tc.Spec.Version).To(Equal("v6.5.0"))
+
+	// the fields changed by the template are updated once the claim is resumed
+	claim.Spec.Paused = false
+	g.Expect(control.ReconcileTidbClusterClaim(claim)).To(Succeed())
+	claim, err = claimCli.Get(ctx, claim.Name, metav1.GetOptions{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(claim.Status.Message).To(BeEmpty())
+	g.Expect(claim.Status.ObservedTemplateGeneration).To(Equal(int64(2)))
+	tc, err = tcCli.Get(ctx, "claim", metav1.GetOptions{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(tc.Spec.Version).To(Equal("v7.5.0"))
+	g.Expect(tc.Spec.TiDB.Replicas).To(Equal(int32(2)))
+
+	// the size is not found
+	claim, err = claimCli.Get(ctx, claim.Name, metav1.GetOptions{})
+	g.Expect(err).NotTo(HaveOccurred())
+	claim.Spec.Size = "huge"
+	g.Expect(control.ReconcileTidbClusterClaim(claim)).To(Succeed())
+	claim, err = claimCli.Get(ctx, claim.Name, metav1.GetOptions{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(claim.Status.Phase).To(Equal(v1alpha1.TidbClusterClaimFailed))
+	g.Expect(claim.Status.ClusterName).To(Equal("claim"))
+}
+
+func TestReconcileTidbClusterClaimNotOwned(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := context.TODO()
+
+	deps := controller.NewFakeDependencies()
+	templateInformer := deps.InformerFactory.Pingcap().V1alpha1().TidbClusterTemplates()
+	control := NewDefaultTidbClusterClaimControl(deps, templateInformer.Lister())
+	g.Expect(templateInformer.Informer().GetIndexer().Add(newTidbClusterTemplate())).To(Succeed())
+
+	claim := newTidbClusterClaim()
+	_, err := deps.Clientset.PingcapV1alpha1().TidbClusterClaims(claim.Namespace).Create(ctx, claim, metav1.CreateOptions{})
+	g.Expect(err).NotTo(HaveOccurred())
+	// the TidbCluster created by the users is never overwritten
+	tc := &v1alpha1.TidbCluster{ObjectMeta: metav1.ObjectMeta{Name: claim.Name, Namespace: claim.Namespace}}
+	g.Expect(deps.InformerFactory.Pingcap().V1alpha1().TidbClusters().Informer().GetIndexer().Add(tc)).To(Succeed())
+
+	g.Expect(control.ReconcileTidbClusterClaim(claim)).To(Succeed())
+	claim, err = deps.Clientset.PingcapV1alpha1().TidbClusterClaims(claim.Namespace).Get(ctx, claim.Name, metav1.GetOptions{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(claim.Status.Phase).To(Equal(v1alpha1.TidbClusterClaimFailed))
+	g.Expect(claim.Status.Message).To(ContainSubstring("not owned"))
+}
+
+func TestReconcileTidbClusterClaimTemplateNamespace(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := context.TODO()
+
+	deps := controller.NewFakeDependencies()
+	templateInformer := deps.InformerFactory.Pingcap().V1alpha1().TidbClusterTemplates()
+	control := NewDefaultTidbClusterClaimControl(deps, templateInformer.Lister())
+	tpl := newTidbClusterTemplate()
+	tpl.Namespace = "templates"
+	g.Expect(templateInformer.Informer().GetIndexer().Add(tpl)).To(Succeed())
+
+	claim := newTidbClusterClaim()
+	claim.Spec.TemplateRef.Namespace = tpl.Namespace
+	claimCli := deps.Clientset.PingcapV1alpha1().TidbClusterClaims(claim.Namespace)
+	_, err := claimCli.Create(ctx, claim, metav1.CreateOptions{})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	// the templates in other namespaces can't be referenced by default
+	g.Expect(control.ReconcileTidbClusterClaim(claim)).To(Succeed())
+	claim, err = claimCli.Get(ctx, claim.Name, metav1.GetOptions{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(claim.Status.Phase).To(Equal(v1alpha1.TidbClusterClaimFailed))
+	g.Expect(claim.Status.Message).To(ContainSubstring("can't be referenced"))
+
+	deps.CLIConfig.TemplateNamespaces = "others, templates"
+	g.Expect(control.ReconcileTidbClusterClaim(claim)).To(Succeed())
+	claim, err = claimCli.Get(ctx, claim.Name, metav1.GetOptions{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(claim.Status.Phase).To(Equal(v1alpha1.TidbClusterClaimBound))
+}
+
+func TestTemplateNamespaceAllowed(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	g.Expect(templateNamespaceAllowed("", "ns", "ns")).To(BeTrue())
+	g.Expect(templateNamespaceAllowed("", "ns", "templates")).To(BeFalse())
+	g.Expect(templateNamespaceAllowed("others", "ns", "templates")).To(BeFalse())
+	g.Expect(templateNamespaceAllowed("others,templates", "ns", "templates")).To(BeTrue())
+	g.Expect(templateNamespaceAllowed("*", "ns", "templates")).To(BeTrue())
+}
+
+func newTidbClusterClaim() *v1alpha1.TidbClusterClaim {
+	return &v1alpha1.TidbClusterClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "claim",
+			Namespace: corev1.NamespaceDefault,
+			UID:       types.UID("claim"),
+		},
+		Spec: v1alpha1.TidbClusterClaimSpec{
+			TemplateRef: v1alpha1.TidbClusterTemplateRef{Name: "template"},
+		},
+	}
+}
+
+func newTidbClusterTemplate() *v1alpha1.TidbClusterTemplate {
+	return &v1alpha1.TidbClusterTemplate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "template",
+			Namespace: corev1.NamespaceDefault,
+		},
+		Spec: v1alpha1.TidbClusterTemplateSpec{
+			Template: v1alpha1.TidbClusterSpec{
+				Version: "v7.1.0",
+				PD:      &v1alpha1.PDSpec{Replicas: 3},
+				TiKV:    &v1alpha1.TiKVSpec{},
+				TiDB:    &v1alpha1.TiDBSpec{Replicas: 1},
+			},
+			Sizes: []v1alpha1.TidbClusterSize{
+				{
+					Name: "small",
+					TiKV: &v1alpha1.ComponentSize{Replicas: 3},
+				},
+				{
+					Name: "large",
+					TiKV: &v1alpha1.ComponentSize{
+						Replicas: 5,
+						ResourceRequirements: corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("500Gi")},
+						},
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,196 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tidbclusterclaim
+
+import (
+	"fmt"
+	"time"
+
+	perrors "github.com/pingcap/errors"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	listers "github.com/pingcap/tidb-operator/pkg/client/listers/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/metrics"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+)
+
+// Controller renders the TidbClusters of the TidbClusterClaims from the TidbClusterTemplates
+type Controller struct {
+	deps        *controller.Dependencies
+	control     ControlInterface
+	claimLister listers.TidbClusterClaimLister
+	queue       workqueue.RateLimitingInterface
+}
+
+// NewController creates a tidbclusterclaim controller.
+func NewController(deps *controller.Dependencies) *Controller {
+	claimInformer := deps.InformerFactory.Pingcap().V1alpha1().TidbClusterClaims()
+	templateInformer := deps.InformerFactory.Pingcap().V1alpha1().TidbClusterTemplates()
+	tcInformer := deps.InformerFactory.Pingcap().V1alpha1().TidbClusters()
+
+	c := &Controller{
+		deps:        deps,
+		control:     NewDefaultTidbClusterClaimControl(deps, templateInformer.Lister()),
+		claimLister: claimInformer.Lister(),
+		queue: workqueue.NewNamedRateLimitingQueue(
+			controller.NewControllerRateLimiter(1*time.Second, 100*time.Second),
+			"tidbclusterclaim",
+		),
+	}
+
+	controller.WatchForObject(claimInformer.Informer(), c.queue)
+	templateInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: c.enqueueClaimsOfTemplate,
+		UpdateFunc: func(_, cur interface{}) {
+			c.enqueueClaimsOfTemplate(cur)
+		},
+		DeleteFunc: c.enqueueClaimsOfTemplate,
+	})
+	tcInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: c.enqueueClaimOfCluster,
+		UpdateFunc: func(_, cur interface{}) {
+			c.enqueueClaimOfCluster(cur)
+		},
+		DeleteFunc: c.enqueueClaimOfCluster,
+	})
+
+	return c
+}
+
+// Name returns the name of the tidbclusterclaim controller
+func (c *Controller) Name() string {
+	return "tidbclusterclaim"
+}
+
+// Run run workers
+func (c *Controller) Run(workers int, stopCh <-chan struct{}) {
+	defer utilruntime.HandleCrash()
+	defer c.queue.ShutDown()
+
+	klog.Info("Starting tidbclusterclaim controller")
+	defer klog.Info("Shutting down tidbclusterclaim controller")
+
+	for i := 0; i < workers; i++ {
+		go wait.Until(c.worker, time.Second, stopCh)
+	}
+
+	<-stopCh
+}
+
+func (c *Controller) worker() {
+	for c.processNextWorkItem() {
+	}
+}
+
+// processNextWorkItem dequeues items, processes them, and marks them done.
+// It enforces that the syncHandler is never
+// invoked concurrently with the same key.
+func (c *Controller) processNextWorkItem() bool {
+	metrics.ActiveWorkers.WithLabelValues(c.Name()).Add(1)
+	defer metrics.ActiveWorkers.WithLabelValues(c.Name()).Add(-1)
+
+	key, quit := c.queue.Get()
+	if quit {
+		return false
+	}
+	defer c.queue.Done(key)
+	err := c.sync(key.(string))
+	controller.ObserveReconcile(c.Name(), err)
+	if err != nil {
+		if perrors.Find(err, controller.IsRequeueError) != nil {
+			klog.Infof("TidbClusterClaim: %v, still need sync: %v, requeuing", key.(string), err)
+		} else {
+			utilruntime.HandleError(fmt.Errorf("TidbClusterClaim: %v, sync failed, err: %v, requeuing", key.(string), err))
+		}
+		c.queue.AddRateLimited(key)
+	} else {
+		c.queue.Forget(key)
+	}
+	return true
+}
+
+func (c *Controller) sync(key string) error {
+	startTime := time.Now()
+	defer func() {
+		duration := time.Since(startTime)
+		metrics.ReconcileTime.WithLabelValues(c.Name()).Observe(duration.Seconds())
+		klog.V(4).Infof("Finished syncing TidbClusterClaim %q (%v)", key, duration)
+	}()
+
+	ns, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return err
+	}
+	claim, err := c.claimLister.TidbClusterClaims(ns).Get(name)
+	if errors.IsNotFound(err) {
+		klog.Infof("TidbClusterClaim %v has been deleted", key)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if claim.DeletionTimestamp != nil {
+		return nil
+	}
+	return c.control.ReconcileTidbClusterClaim(claim)
+}
+
+// enqueueClaimsOfTemplate enqueues the claims referencing the template
+func (c *Controller) enqueueClaimsOfTemplate(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	tpl, ok := obj.(*v1alpha1.TidbClusterTemplate)
+	if !ok {
+		return
+	}
+	claims, err := c.claimLister.List(labels.Everything())
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("list TidbClusterClaims failed, err: %v", err))
+		return
+	}
+	for _, claim := range claims {
+		ns := claim.Spec.TemplateRef.Namespace
+		if ns == "" {
+			ns = claim.Namespace
+		}
+		if ns == tpl.Namespace && claim.Spec.TemplateRef.Name == tpl.Name {
+			c.queue.Add(claim.Namespace + "/" + claim.Name)
+		}
+	}
+}
+
+// enqueueClaimOfCluster enqueues the claim controlling the TidbCluster, so that the TidbCluster
+// is rendered again if it's deleted
+func (c *Controller) enqueueClaimOfCluster(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	tc, ok := obj.(*v1alpha1.TidbCluster)
+	if !ok {
+		return
+	}
+	ref := metav1.GetControllerOf(tc)
+	if ref == nil || ref.Kind != controller.TidbClusterClaimKind.Kind {
+		return
+	}
+	c.queue.Add(tc.Namespace + "/" + ref.Name)
+}
@@ -0,0 +1,238 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tidbclusterfederation
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/federation/pingcap/v1alpha1"
+	pingcapv1alpha1 "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	fedversioned "github.com/pingcap/tidb-operator/pkg/client/clientset/versioned"
+	"github.com/pingcap/tidb-operator/pkg/client/federation/clientset/versioned"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+)
+
+// ControlInterface implements the control logic for updating TidbClusterFederation
+// It is implemented as an interface to allow for extensions that provide different semantics.
+// Currently, there is only one implementation.
+type ControlInterface interface {
+	// UpdateTidbClusterFederation bootstraps and joins the members of the TidbClusterFederation in order
+	UpdateTidbClusterFederation(tcf *v1alpha1.TidbClusterFederation) error
+}
+
+// MemberClients are the clients of a Kubernetes cluster of the federation
+type MemberClients struct {
+	Clientset     fedversioned.Interface
+	KubeClientset kubernetes.Interface
+}
+
+// NewDefaultTidbClusterFederationControl returns a new instance of the default TidbClusterFederation ControlInterface implementation.
+func NewDefaultTidbClusterFederationControl(
+	cli versioned.Interface,
+	kubeCli kubernetes.Interface,
+	members map[string]MemberClients,
+	recorder record.EventRecorder) ControlInterface {
+	return &defaultTidbClusterFederationControl{
+		cli:      cli,
+		kubeCli:  kubeCli,
+		members:  members,
+		recorder: recorder,
+	}
+}
+
+type defaultTidbClusterFederationControl struct {
+	cli      versioned.Interface
+	kubeCli  kubernetes.Interface
+	members  map[string]MemberClients
+	recorder record.EventRecorder
+}
+
+// UpdateTidbClusterFederation executes the core logic loop for a TidbClusterFederation.
+func (c *defaultTidbClusterFederationControl) UpdateTidbClusterFederation(tcf *v1alpha1.TidbClusterFederation) error {
+	tcf.SetGroupVersionKind(controller.TidbClusterFederationControllerKind)
+	oldStatus := tcf.Status.DeepCopy()
+
+	err := c.syncMembers(tcf)
+
+	if apiequality.Semantic.DeepEqual(&tcf.Status, oldStatus) {
+		return err
+	}
+	if _, updateErr := c.cli.FederationV1alpha1().TidbClusterFederations(tcf.Namespace).Update(context.TODO(), tcf, metav1.UpdateOptions{}); updateErr != nil {
+		return fmt.Errorf("failed to update the status of TidbClusterFederation %s/%s, error: %v", tcf.Namespace, tcf.Name, updateErr)
+	}
+	return err
+}
+
+// syncMembers syncs the members in order, a member is started only if all the members before it are ready.
+// It returns a requeue error until all the members are ready.
+func (c *defaultTidbClusterFederationControl) syncMembers(tcf *v1alpha1.TidbClusterFederation) error {
+	ns := tcf.Namespace
+	name := tcf.Name
+	statuses := make([]v1alpha1.TidbClusterFederationMemberStatus, 0, len(tcf.Spec.Members))
+	defer func() {
+		tcf.Status.Members = mergeMemberStatuses(tcf.Status.Members, statuses)
+	}()
+
+	allReady := true
+	for i, member := range tcf.Spec.Members {
+		phase, msg, err := c.syncMember(tcf, i, allReady)
+		if err != nil {
+			phase, msg = v1alpha1.TidbClusterFederationMemberFailed, err.Error()
+		}
+		statuses = append(statuses, v1alpha1.TidbClusterFederationMemberStatus{
+			K8sClusterName: member.K8sClusterName,
+			TCNamespace:    member.TCNamespace,
+			TCName:         member.TCName,
+			Phase:          phase,
+			Message:        msg,
+		})
+		if err != nil {
+			return err
+		}
+		allReady = allReady && phase == v1alpha1.TidbClusterFederationMemberReady
+	}
+	if !allReady {
+		return controller.RequeueErrorf("TidbClusterFederation %s/%s: waiting for all the members to be ready", ns, name)
+	}
+	return nil
+}
+
+// syncMember syncs the i-th member, and returns its phase and the message explaining the phase.
+// previousReady is whether all the members before it are ready.
+func (c *defaultTidbClusterFederationControl) syncMember(tcf *v1alpha1.TidbClusterFederation, i int, previousReady bool) (v1alpha1.TidbClusterFederationMemberPhase, string, error) {
+	member := tcf.Spec.Members[i]
+	clients, ok := c.members[member.K8sClusterName]
+	if !ok {
+		return v1alpha1.TidbClusterFederationMemberFailed, fmt.Sprintf("the kubeconfig of Kubernetes cluster %s is not found", member.K8sClusterName), nil
+	}
+	tcs := clients.Clientset.PingcapV1alpha1().TidbClusters(member.TCNamespace)
+	tc, err := tcs.Get(context.TODO(), member.TCName, metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		return v1alpha1.TidbClusterFederationMemberWaiting, "the TidbCluster is not created", nil
+	}
+	if err != nil {
+		return "", "", fmt.Errorf("failed to get TidbCluster %s/%s in Kubernetes cluster %s, error: %v", member.TCNamespace, member.TCName, member.K8sClusterName, err)
+	}
+
+	started := tc.Status.ClusterID != ""
+	if i == 0 && tc.Spec.Cluster != nil {
+		return v1alpha1.TidbClusterFederationMemberFailed, "the first member bootstraps the cluster and must not set spec.cluster", nil
+	}
+	if i > 0 && started && tcf.Status.ClusterID != "" && tc.Status.ClusterID != tcf.Status.ClusterID {
+		return v1alpha1.TidbClusterFederationMemberFailed, fmt.Sprintf("the TidbCluster runs cluster %s instead of cluster %s bootstrapped by the first member", tc.Status.ClusterID, tcf.Status.ClusterID), nil
+	}
+	if started && tc.Spec.ClusterDomain != member.ClusterDomain {
+		return v1alpha1.TidbClusterFederationMemberFailed, fmt.Sprintf("the cluster domain %q of the started TidbCluster can't be changed to %q", tc.Spec.ClusterDomain, member.ClusterDomain), nil
+	}
+
+	newTC := tc.DeepCopy()
+	newTC.Spec.ClusterDomain = member.ClusterDomain
+	newTC.Spec.AcrossK8s = true
+	if i > 0 {
+		first := tcf.Spec.Members[0]
+		newTC.Spec.Cluster = &pingcapv1alpha1.TidbClusterRef{
+			Namespace:     first.TCNamespace,
+			Name:          first.TCName,
+			ClusterDomain: first.ClusterDomain,
+		}
+	}
+
+	phase := v1alpha1.TidbClusterFederationMemberStarting
+	msg := ""
+	switch {
+	case !previousReady && !started:
+		// keep the member paused, otherwise it may bootstrap a PD quorum of its own
+		phase, msg = v1alpha1.TidbClusterFederationMemberWaiting, "waiting for the members before it to be ready"
+		if !newTC.Spec.Paused {
+			newTC.Spec.Paused = true
+			metav1.SetMetaDataAnnotation(&newTC.ObjectMeta, v1alpha1.AnnTidbClusterFederationPaused, "true")
+		}
+	case newTC.Spec.Paused && (!started || newTC.Annotations[v1alpha1.AnnTidbClusterFederationPaused] != ""):
+		if err := c.copySecrets(tcf, member, clients.KubeClientset); err != nil {
+			return "", "", err
+		}
+		newTC.Spec.Paused = false
+		delete(newTC.Annotations, v1alpha1.AnnTidbClusterFederationPaused)
+		klog.Infof("TidbClusterFederation %s/%s: start member %s/%s in Kubernetes cluster %s", tcf.Namespace, tcf.Name, member.TCNamespace, member.TCName, member.K8sClusterName)
+		c.recorder.Eventf(tcf, corev1.EventTypeNormal, "MemberStarted", "start member %s/%s in Kubernetes cluster %s", member.TCNamespace, member.TCName, member.K8sClusterName)
+	case isMemberReady(tc):
+		phase = v1alpha1.TidbClusterFederationMemberReady
+	}
+
+	if !apiequality.Semantic.DeepEqual(tc.Spec, newTC.Spec) || !apiequality.Semantic.DeepEqual(tc.Annotations, newTC.Annotations) {
+		if _, err := tcs.Update(context.TODO(), newTC, metav1.UpdateOptions{}); err != nil {
+			return "", "", fmt.Errorf("failed to update TidbCluster %s/%s in Kubernetes cluster %s, error: %v", member.TCNamespace, member.TCName, member.K8sClusterName, err)
+		}
+	}
+	if i == 0 && phase == v1alpha1.TidbClusterFederationMemberReady {
+		tcf.Status.ClusterID = tc.Status.ClusterID
+	}
+	return phase, msg, nil
+}
+
+// copySecrets copies the secrets in spec.secretNames into the namespace of the member
+func (c *defaultTidbClusterFederationControl) copySecrets(tcf *v1alpha1.TidbClusterFederation, member v1alpha1.TidbClusterFederationMember, kubeCli kubernetes.Interface) error {
+	for _, name := range tcf.Spec.SecretNames {
+		secret, err := c.kubeCli.CoreV1().Secrets(tcf.Namespace).Get(context.TODO(), name, metav1.GetOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to get secret %s/%s, error: %v", tcf.Namespace, name, err)
+		}
+		secrets := kubeCli.CoreV1().Secrets(member.TCNamespace)
+		existing, err := secrets.Get(context.TODO(), name, metav1.GetOptions{})
+		if errors.IsNotFound(err) {
+			_, err = secrets.Create(context.TODO(), &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: member.TCNamespace, Name: name},
+				Type:       secret.Type,
+				Data:       secret.Data,
+			}, metav1.CreateOptions{})
+		} else if err == nil && !apiequality.Semantic.DeepEqual(existing.Data, secret.Data) {
+			existing.Data = secret.Data
+			_, err = secrets.Update(context.TODO(), existing, metav1.UpdateOptions{})
+		}
+		if err != nil {
+			return fmt.Errorf("failed to copy secret %s/%s to Kubernetes cluster %s, error: %v", tcf.Namespace, name, member.K8sClusterName, err)
+		}
+	}
+	return nil
+}
+
+// isMemberReady returns whether the member is started and its PD members are all ready
+func isMemberReady(tc *pingcapv1alpha1.TidbCluster) bool {
+	return tc.Status.ClusterID != "" && (tc.Spec.PD == nil || tc.PDAllMembersReady())
+}
+
+// mergeMemberStatuses returns the new statuses of the members, keeping the last transition time of the
+// members whose phases aren't changed
+func mergeMemberStatuses(old, statuses []v1alpha1.TidbClusterFederationMemberStatus) []v1alpha1.TidbClusterFederationMemberStatus {
+	now := metav1.Now()
+	for i := range statuses {
+		statuses[i].LastTransitionTime = now
+		for _, o := range old {
+			if o.K8sClusterName == statuses[i].K8sClusterName && o.TCNamespace == statuses[i].TCNamespace &&
+				o.TCName == statuses[i].TCName && o.Phase == statuses[i].Phase {
+				statuses[i].LastTransitionTime = o.LastTransitionTime
+			}
+		}
+	}
+	return statuses
+}
@@ -0,0 +1,116 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tidbclusterfederation
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/federation/pingcap/v1alpha1"
+	pingcapv1alpha1 "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	pingcapfake "github.com/pingcap/tidb-operator/pkg/client/clientset/versioned/fake"
+	"github.com/pingcap/tidb-operator/pkg/client/federation/clientset/versioned/fake"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+)
+
+func TestUpdateTidbClusterFederation(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	newTC := func(name string, paused bool) *pingcapv1alpha1.TidbCluster {
+		return &pingcapv1alpha1.TidbCluster{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "tidb", Name: name},
+			Spec: pingcapv1alpha1.TidbClusterSpec{
+				Paused: paused,
+				PD:     &pingcapv1alpha1.PDSpec{Replicas: 1},
+			},
+		}
+	}
+	tcf := &v1alpha1.TidbClusterFederation{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "fed", Name: "basic"},
+		Spec: v1alpha1.TidbClusterFederationSpec{
+			Members: []v1alpha1.TidbClusterFederationMember{
+				{K8sClusterName: "k8s-1", TCNamespace: "tidb", TCName: "basic-1", ClusterDomain: "cluster1.local"},
+				{K8sClusterName: "k8s-2", TCNamespace: "tidb", TCName: "basic-2", ClusterDomain: "cluster2.local"},
+			},
+			SecretNames: []string{"cluster-ca"},
+		},
+	}
+	cli := fake.NewSimpleClientset(tcf)
+	kubeCli := kubefake.NewSimpleClientset(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "fed", Name: "cluster-ca"},
+		Data:       map[string][]byte{"ca.crt": []byte("ca")},
+	})
+	tc1 := newTC("basic-1", false)
+	tc2 := newTC("basic-2", false)
+	members := map[string]MemberClients{
+		"k8s-1": {Clientset: pingcapfake.NewSimpleClientset(tc1), KubeClientset: kubefake.NewSimpleClientset()},
+		"k8s-2": {Clientset: pingcapfake.NewSimpleClientset(tc2), KubeClientset: kubefake.NewSimpleClientset()},
+	}
+	control := NewDefaultTidbClusterFederationControl(cli, kubeCli, members, record.NewFakeRecorder(10))
+	getTC := func(k8sName, name string) *pingcapv1alpha1.TidbCluster {
+		tc, err := members[k8sName].Clientset.PingcapV1alpha1().TidbClusters("tidb").Get(context.TODO(), name, metav1.GetOptions{})
+		g.Expect(err).To(Succeed())
+		return tc
+	}
+	phases := func() []v1alpha1.TidbClusterFederationMemberPhase {
+		var phases []v1alpha1.TidbClusterFederationMemberPhase
+		for _, s := range tcf.Status.Members {
+			phases = append(phases, s.Phase)
+		}
+		return phases
+	}
+
+	// the second member is paused until the first one is ready
+	err := control.UpdateTidbClusterFederation(tcf)
+	g.Expect(controller.IsRequeueError(err)).To(BeTrue())
+	g.Expect(phases()).To(Equal([]v1alpha1.TidbClusterFederationMemberPhase{v1alpha1.TidbClusterFederationMemberStarting, v1alpha1.TidbClusterFederationMemberWaiting}))
+	tc1 = getTC("k8s-1", "basic-1")
+	g.Expect(tc1.Spec.ClusterDomain).To(Equal("cluster1.local"))
+	g.Expect(tc1.Spec.AcrossK8s).To(BeTrue())
+	g.Expect(tc1.Spec.Cluster).To(BeNil())
+	tc2 = getTC("k8s-2", "basic-2")
+	g.Expect(tc2.Spec.Paused).To(BeTrue())
+	g.Expect(tc2.Annotations).To(HaveKey(v1alpha1.AnnTidbClusterFederationPaused))
+
+	// the second member joins the cluster once the first one is ready
+	tc1.Status.ClusterID = "1234"
+	tc1.Status.PD.Members = map[string]pingcapv1alpha1.PDMember{"basic-1-pd-0": {Health: true}}
+	_, err = members["k8s-1"].Clientset.PingcapV1alpha1().TidbClusters("tidb").Update(context.TODO(), tc1, metav1.UpdateOptions{})
+	g.Expect(err).To(Succeed())
+	err = control.UpdateTidbClusterFederation(tcf)
+	g.Expect(controller.IsRequeueError(err)).To(BeTrue())
+	g.Expect(tcf.Status.ClusterID).To(Equal("1234"))
+	g.Expect(phases()).To(Equal([]v1alpha1.TidbClusterFederationMemberPhase{v1alpha1.TidbClusterFederationMemberReady, v1alpha1.TidbClusterFederationMemberStarting}))
+	tc2 = getTC("k8s-2", "basic-2")
+	g.Expect(tc2.Spec.Paused).To(BeFalse())
+	g.Expect(tc2.Annotations).NotTo(HaveKey(v1alpha1.AnnTidbClusterFederationPaused))
+	g.Expect(tc2.Spec.Cluster).To(Equal(&pingcapv1alpha1.TidbClusterRef{Namespace: "tidb", Name: "basic-1", ClusterDomain: "cluster1.local"}))
+	secret, err := members["k8s-2"].KubeClientset.CoreV1().Secrets("tidb").Get(context.TODO(), "cluster-ca", metav1.GetOptions{})
+	g.Expect(err).To(Succeed())
+	g.Expect(secret.Data).To(HaveKeyWithValue("ca.crt", []byte("ca")))
+
+	// the member bootstrapping a cluster of its own is failed
+	tc2.Status.ClusterID = "5678"
+	_, err = members["k8s-2"].Clientset.PingcapV1alpha1().TidbClusters("tidb").Update(context.TODO(), tc2, metav1.UpdateOptions{})
+	g.Expect(err).To(Succeed())
+	err = control.UpdateTidbClusterFederation(tcf)
+	g.Expect(controller.IsRequeueError(err)).To(BeTrue())
+	g.Expect(phases()).To(Equal([]v1alpha1.TidbClusterFederationMemberPhase{v1alpha1.TidbClusterFederationMemberReady, v1alpha1.TidbClusterFederationMemberFailed}))
+}
@@ -0,0 +1,162 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tidbclusterfederation
+
+import (
+	"fmt"
+	"time"
+
+	perrors "github.com/pingcap/errors"
+	"k8s.io/apimachinery/pkg/api/errors"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/federation/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/metrics"
+)
+
+// Controller controls TidbClusterFederation.
+type Controller struct {
+	deps *controller.BrFedDependencies
+	// control returns an interface capable of syncing a TidbClusterFederation.
+	// Abstracted out for testing.
+	control ControlInterface
+	// TidbClusterFederations that need to be synced.
+	queue workqueue.RateLimitingInterface
+}
+
+// NewController creates a TidbClusterFederation controller.
+func NewController(deps *controller.BrFedDependencies) *Controller {
+	members := make(map[string]MemberClients, len(deps.FedClientset))
+	for name, cli := range deps.FedClientset {
+		members[name] = MemberClients{Clientset: cli, KubeClientset: deps.FedKubeClientset[name]}
+	}
+	c := &Controller{
+		deps:    deps,
+		control: NewDefaultTidbClusterFederationControl(deps.Clientset, deps.KubeClientset, members, deps.Recorder),
+		queue: workqueue.NewNamedRateLimitingQueue(
+			controller.NewControllerRateLimiter(1*time.Second, 100*time.Second),
+			"tidbClusterFederation",
+		),
+	}
+
+	informer := deps.InformerFactory.Federation().V1alpha1().TidbClusterFederations()
+	informer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: c.enqueueTidbClusterFederation,
+		UpdateFunc: func(old, cur interface{}) {
+			c.enqueueTidbClusterFederation(cur)
+		},
+		DeleteFunc: c.enqueueTidbClusterFederation,
+	})
+
+	return c
+}
+
+// Name returns TidbClusterFederation controller name.
+func (c *Controller) Name() string {
+	return "tidbClusterFederation"
+}
+
+// Run runs the TidbClusterFederation controller.
+func (c *Controller) Run(workers int, stopCh <-chan struct{}) {
+	defer utilruntime.HandleCrash()
+	defer c.queue.ShutDown()
+
+	klog.Info("Starting tidbClusterFederation controller")
+	defer klog.Info("Shutting down tidbClusterFederation controller")
+
+	for i := 0; i < workers; i++ {
+		go wait.Until(c.worker, time.Second, stopCh)
+	}
+
+	<-stopCh
+}
+
+// worker runs a worker goroutine that invokes processNextWorkItem until the the controller's queue is closed
+func (c *Controller) worker() {
+	for c.processNextWorkItem() {
+	}
+}
+
+// processNextWorkItem dequeues items, processes them, and marks them done. It enforces that the syncHandler is never
+// invoked concurrently with the same key.
+func (c *Controller) processNextWorkItem() bool {
+	metrics.ActiveWorkers.WithLabelValues(c.Name()).Add(1)
+	defer metrics.ActiveWorkers.WithLabelValues(c.Name()).Add(-1)
+
+	key, quit := c.queue.Get()
+	if quit {
+		return false
+	}
+	defer c.queue.Done(key)
+	err := c.sync(key.(string))
+	controller.ObserveReconcile(c.Name(), err)
+	if err != nil {
+		if perrors.Find(err, controller.IsRequeueError) != nil {
+			klog.Infof("TidbClusterFederation: %v, still need sync: %v, requeuing", key.(string), err)
+			c.queue.AddRateLimited(key)
+		} else if perrors.Find(err, controller.IsIgnoreError) != nil {
+			klog.V(4).Infof("TidbClusterFederation: %v, ignore err: %v", key.(string), err)
+		} else {
+			utilruntime.HandleError(fmt.Errorf("TidbClusterFederation: %v, sync failed, err: %v, requeuing", key.(string), err))
+			c.queue.AddRateLimited(key)
+		}
+	} else {
+		c.queue.Forget(key)
+	}
+	return true
+}
+
+// sync syncs the given TidbClusterFederation.
+func (c *Controller) sync(key string) error {
+	startTime := time.Now()
+	defer func() {
+		duration := time.Since(startTime)
+		metrics.ReconcileTime.WithLabelValues(c.Name()).Observe(duration.Seconds())
+		klog.V(4).Infof("Finished syncing TidbClusterFederation %q (%v)", key, duration)
+	}()
+
+	ns, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return err
+	}
+	tcf, err := c.deps.TidbClusterFederationLister.TidbClusterFederations(ns).Get(name)
+	if errors.IsNotFound(err) {
+		klog.Infof("TidbClusterFederation has been deleted %v", key)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	return c.control.UpdateTidbClusterFederation(tcf.DeepCopy())
+}
+
+// enqueueTidbClusterFederation enqueues the given TidbClusterFederation in the work queue.
+func (c *Controller) enqueueTidbClusterFederation(obj interface{}) {
+	if tcf, ok := obj.(*v1alpha1.TidbClusterFederation); ok && tcf.DeletionTimestamp != nil {
+		// the members are left as they are when the federation is deleted
+		return
+	}
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("cound't get key for object %+v: %v", obj, err))
+		return
+	}
+	c.queue.Add(key)
+}
@@ -0,0 +1,219 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tidbclusterfleet
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/metrics"
+	utiltidbcluster "github.com/pingcap/tidb-operator/pkg/util/tidbcluster"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+)
+
+// FleetName is the name of the TidbClusterFleet object maintained by the operator.
+const FleetName = "tidb-operator"
+
+// Controller periodically aggregates the status of all TidbClusters and Backups
+// watched by the operator into a TidbClusterFleet object and the fleet metrics.
+type Controller struct {
+	deps      *controller.Dependencies
+	namespace string
+}
+
+// NewController creates a fleet controller which maintains the TidbClusterFleet in the given namespace.
+func NewController(deps *controller.Dependencies, namespace string) *Controller {
+	// make sure the informers are registered before the informer factory is started
+	deps.InformerFactory.Pingcap().V1alpha1().TidbClusters().Informer()
+	deps.InformerFactory.Pingcap().V1alpha1().Backups().Informer()
+	return &Controller{
+		deps:      deps,
+		namespace: namespace,
+	}
+}
+
+// Name returns the name of the controller
+func (c *Controller) Name() string {
+	return "tidbclusterfleet"
+}
+
+// Run aggregates the fleet status every resync period until stopCh is closed.
+// The number of workers is ignored as there is only one object to update.
+func (c *Controller) Run(_ int, stopCh <-chan struct{}) {
+	defer utilruntime.HandleCrash()
+
+	klog.Info("Starting TidbClusterFleet controller")
+	defer klog.Info("Shutting down TidbClusterFleet controller")
+
+	wait.Until(func() {
+		err := c.sync()
+		controller.ObserveReconcile(c.Name(), err)
+		if err != nil {
+			utilruntime.HandleError(fmt.Errorf("TidbClusterFleet: %s/%s, sync failed, err: %v", c.namespace, FleetName, err))
+		}
+	}, c.deps.CLIConfig.ResyncDuration, stopCh)
+}
+
+func (c *Controller) sync() error {
+	startTime := time.Now()
+	defer func() {
+		duration := time.Since(startTime)
+		metrics.ReconcileTime.WithLabelValues(c.Name()).Observe(duration.Seconds())
+		klog.V(4).Infof("Finished syncing TidbClusterFleet %s/%s (%v)", c.namespace, FleetName, duration)
+	}()
+
+	tcs, err := c.deps.TiDBClusterLister.List(labels.Everything())
+	if err != nil {
+		return fmt.Errorf("list tidbclusters failed, error: %v", err)
+	}
+	backups, err := c.deps.BackupLister.List(labels.Everything())
+	if err != nil {
+		return fmt.Errorf("list backups failed, error: %v", err)
+	}
+
+	status := aggregateFleetStatus(tcs, backups)
+	status.LastUpdateTime = metav1.Now()
+	updateFleetMetrics(status)
+
+	return c.updateFleetStatus(status)
+}
+
+func (c *Controller) updateFleetStatus(status *v1alpha1.TidbClusterFleetStatus) error {
+	cli := c.deps.Clientset.PingcapV1alpha1().TidbClusterFleets(c.namespace)
+	fleet, err := cli.Get(context.TODO(), FleetName, metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		fleet, err = cli.Create(context.TODO(), &v1alpha1.TidbClusterFleet{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: c.namespace,
+				Name:      FleetName,
+			},
+		}, metav1.CreateOptions{})
+	}
+	if err != nil {
+		return fmt.Errorf("get tidbclusterfleet [%s/%s] failed, error: %v", c.namespace, FleetName, err)
+	}
+
+	fleet = fleet.DeepCopy()
+	fleet.Status = *status
+	if _, err := cli.UpdateStatus(context.TODO(), fleet, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("update status of tidbclusterfleet [%s/%s] failed, error: %v", c.namespace, FleetName, err)
+	}
+	return nil
+}
+
+// aggregateFleetStatus summarizes the given TidbClusters and Backups.
+func aggregateFleetStatus(tcs []*v1alpha1.TidbCluster, backups []*v1alpha1.Backup) *v1alpha1.TidbClusterFleetStatus {
+	status := &v1alpha1.TidbClusterFleetStatus{
+		Phases:   map[v1alpha1.MemberPhase]int32{},
+		Versions: map[string]int32{},
+	}
+
+	for _, tc := range tcs {
+		status.Clusters++
+
+		phase := clusterPhase(tc)
+		status.Phases[phase]++
+		if phase == v1alpha1.UpgradePhase {
+			status.PendingUpgrades++
+		}
+
+		if cond := utiltidbcluster.GetTidbClusterReadyCondition(tc.Status); cond != nil && cond.Status == corev1.ConditionTrue {
+			status.ReadyClusters++
+		}
+
+		for _, version := range runningVersions(tc) {
+			status.Versions[version]++
+		}
+	}
+
+	for _, backup := range backups {
+		if v1alpha1.IsBackupFailed(backup) {
+			status.FailedBackups++
+		}
+	}
+
+	return status
+}
+
+// clusterPhase returns the first non Normal phase of the components, or Normal if all components are Normal.
+func clusterPhase(tc *v1alpha1.TidbCluster) v1alpha1.MemberPhase {
+	for _, component := range tc.AllComponentStatus() {
+		if phase := component.GetPhase(); phase != "" && phase != v1alpha1.NormalPhase {
+			return phase
+		}
+	}
+	return v1alpha1.NormalPhase
+}
+
+// runningVersions returns the distinct versions of the images running the components of the TidbCluster, which
+// are reported in the status once the components are synced. The spec.version is not used as it's the version
+// the cluster is going to run, and is not the version of the components whose image is set explicitly.
+func runningVersions(tc *v1alpha1.TidbCluster) []string {
+	images := []string{}
+	if tc.Spec.PD != nil {
+		images = append(images, tc.Status.PD.Image)
+	}
+	if tc.Spec.TiKV != nil {
+		images = append(images, tc.Status.TiKV.Image)
+	}
+	if tc.Spec.TiDB != nil {
+		images = append(images, tc.Status.TiDB.Image)
+	}
+	if tc.Spec.TiFlash != nil {
+		images = append(images, tc.Status.TiFlash.Image)
+	}
+	versions := sets.NewString()
+	for _, image := range images {
+		if version := imageVersion(image); version != "" {
+			versions.Insert(version)
+		}
+	}
+	return versions.List()
+}
+
+// imageVersion returns the tag of the image, or empty if the image is empty or not tagged
+func imageVersion(image string) string {
+	image = strings.SplitN(image, "@", 2)[0]
+	idx := strings.LastIndexByte(image, ':')
+	if idx < 0 || strings.ContainsRune(image[idx+1:], '/') {
+		// the colon is the port of the registry
+		return ""
+	}
+	return image[idx+1:]
+}
+
+func updateFleetMetrics(status *v1alpha1.TidbClusterFleetStatus) {
+	metrics.FleetClusters.Reset()
+	for phase, count := range status.Phases {
+		metrics.FleetClusters.WithLabelValues(string(phase)).Set(float64(count))
+	}
+	metrics.FleetVersions.Reset()
+	for version, count := range status.Versions {
+		metrics.FleetVersions.WithLabelValues(version).Set(float64(count))
+	}
+	metrics.FleetReadyClusters.Set(float64(status.ReadyClusters))
+	metrics.FleetPendingUpgrades.Set(float64(status.PendingUpgrades))
+	metrics.FleetFailedBackups.Set(float64(status.FailedBackups))
+}
@@ -0,0 +1,145 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tidbclusterfleet
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newTidbCluster(name, version string, ready bool, tikvPhase v1alpha1.MemberPhase) *v1alpha1.TidbCluster {
+	tc := &v1alpha1.TidbCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: corev1.NamespaceDefault,
+			Name:      name,
+		},
+		Spec: v1alpha1.TidbClusterSpec{
+			Version: version,
+			PD:      &v1alpha1.PDSpec{},
+			TiKV:    &v1alpha1.TiKVSpec{},
+			TiDB:    &v1alpha1.TiDBSpec{},
+		},
+	}
+	if version != "" {
+		tc.Status.PD.Image = "pingcap/pd:" + version
+		tc.Status.TiKV.Image = "pingcap/tikv:" + version
+		tc.Status.TiDB.Image = "pingcap/tidb:" + version
+	}
+	tc.Status.PD.Phase = v1alpha1.NormalPhase
+	tc.Status.TiKV.Phase = tikvPhase
+	tc.Status.TiDB.Phase = v1alpha1.NormalPhase
+	status := corev1.ConditionFalse
+	if ready {
+		status = corev1.ConditionTrue
+	}
+	tc.Status.Conditions = []v1alpha1.TidbClusterCondition{
+		{Type: v1alpha1.TidbClusterReady, Status: status},
+	}
+	return tc
+}
+
+func newBackup(name string, failed bool) *v1alpha1.Backup {
+	backup := &v1alpha1.Backup{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: corev1.NamespaceDefault,
+			Name:      name,
+		},
+	}
+	if failed {
+		backup.Status.Conditions = []v1alpha1.BackupCondition{
+			{Type: v1alpha1.BackupFailed, Status: corev1.ConditionTrue},
+		}
+	}
+	return backup
+}
+
+func TestAggregateFleetStatus(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tcs := []*v1alpha1.TidbCluster{
+		newTidbCluster("tc1", "v6.5.0", true, v1alpha1.NormalPhase),
+		newTidbCluster("tc2", "v6.5.0", true, v1alpha1.ScalePhase),
+		newTidbCluster("tc3", "v7.1.0", false, v1alpha1.UpgradePhase),
+		newTidbCluster("tc4", "", false, ""),
+		newTidbCluster("tc5", "v7.1.0", true, v1alpha1.NormalPhase),
+	}
+	// the spec.version is not running yet
+	tcs[0].Spec.Version = "v7.5.0"
+	// the components run different versions
+	tcs[4].Status.TiDB.Image = "localhost:5000/pingcap/tidb:v7.5.0"
+	backups := []*v1alpha1.Backup{
+		newBackup("b1", false),
+		newBackup("b2", true),
+	}
+
+	status := aggregateFleetStatus(tcs, backups)
+	g.Expect(status.Clusters).To(Equal(int32(5)))
+	g.Expect(status.ReadyClusters).To(Equal(int32(3)))
+	g.Expect(status.Phases).To(Equal(map[v1alpha1.MemberPhase]int32{
+		v1alpha1.NormalPhase:  3,
+		v1alpha1.ScalePhase:   1,
+		v1alpha1.UpgradePhase: 1,
+	}))
+	g.Expect(status.Versions).To(Equal(map[string]int32{
+		"v6.5.0": 2,
+		"v7.1.0": 2,
+		"v7.5.0": 1,
+	}))
+	g.Expect(status.PendingUpgrades).To(Equal(int32(1)))
+	g.Expect(status.FailedBackups).To(Equal(int32(1)))
+}
+
+func TestImageVersion(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	g.Expect(imageVersion("")).To(BeEmpty())
+	g.Expect(imageVersion("pingcap/tikv")).To(BeEmpty())
+	g.Expect(imageVersion("pingcap/tikv:v7.1.0")).To(Equal("v7.1.0"))
+	g.Expect(imageVersion("localhost:5000/pingcap/tikv")).To(BeEmpty())
+	g.Expect(imageVersion("localhost:5000/pingcap/tikv:v7.1.0")).To(Equal("v7.1.0"))
+	g.Expect(imageVersion("pingcap/tikv:v7.1.0@sha256:abc")).To(Equal("v7.1.0"))
+}
+
+func TestControllerSync(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	deps := controller.NewSimpleClientDependencies()
+	// the fleet is maintained in the namespace of the operator rather than the clusters
+	operatorNamespace := "tidb-admin"
+	c := NewController(deps, operatorNamespace)
+	tcIndexer := deps.InformerFactory.Pingcap().V1alpha1().TidbClusters().Informer().GetIndexer()
+	g.Expect(tcIndexer.Add(newTidbCluster("tc1", "v6.5.0", true, v1alpha1.NormalPhase))).To(Succeed())
+
+	// the fleet is created on first sync
+	g.Expect(c.sync()).To(Succeed())
+	fleet, err := deps.Clientset.PingcapV1alpha1().TidbClusterFleets(operatorNamespace).Get(context.TODO(), FleetName, metav1.GetOptions{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(fleet.Status.Clusters).To(Equal(int32(1)))
+	g.Expect(fleet.Status.ReadyClusters).To(Equal(int32(1)))
+	g.Expect(fleet.Status.Versions).To(Equal(map[string]int32{"v6.5.0": 1}))
+
+	// and updated afterwards
+	g.Expect(tcIndexer.Add(newTidbCluster("tc2", "v7.1.0", false, v1alpha1.UpgradePhase))).To(Succeed())
+	g.Expect(c.sync()).To(Succeed())
+	fleet, err = deps.Clientset.PingcapV1alpha1().TidbClusterFleets(operatorNamespace).Get(context.TODO(), FleetName, metav1.GetOptions{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(fleet.Status.Clusters).To(Equal(int32(2)))
+	g.Expect(fleet.Status.PendingUpgrades).To(Equal(int32(1)))
+}
@@ -109,6 +109,7 @@ func (c *Controller) processNextWorkItem() bool {
 
 	key := keyIface.(string)
 	err := c.sync(key)
+	controller.ObserveReconcile(c.Name(), err)
 	if err != nil {
 		if perrors.Find(err, controller.IsRequeueError) != nil {
 			klog.Infof("TidbDashboard %v still need sync: %v, re-queuing", key, err)
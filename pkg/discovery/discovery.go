@@ -15,16 +15,24 @@ package discovery
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net"
 	"os"
+	"strconv"
 	"strings"
 	"sync"
+	"time"
 
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
 	"github.com/pingcap/tidb-operator/pkg/client/clientset/versioned"
 	"github.com/pingcap/tidb-operator/pkg/controller"
 	"github.com/pingcap/tidb-operator/pkg/dmapi"
 	"github.com/pingcap/tidb-operator/pkg/pdapi"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/klog/v2"
 )
@@ -34,10 +42,15 @@ type TiDBDiscovery interface {
 	Discover(string) (string, error)
 	DiscoverDM(string) (string, error)
 	VerifyPDEndpoint(string) (string, error)
+	PreStop(podName string, remoteIP string) (string, error)
 }
 
+// ErrPreStopForbidden is returned if the preStop request doesn't come from the pod itself
+var ErrPreStopForbidden = errors.New("the preStop request is not sent by the pod")
+
 type tidbDiscovery struct {
 	cli           versioned.Interface
+	kubeCli       kubernetes.Interface
 	lock          sync.Mutex
 	clusters      map[string]*clusterInfo
 	dmClusters    map[string]*clusterInfo
@@ -61,6 +74,7 @@ type pdEndpointURL struct {
 func NewTiDBDiscovery(pdControl pdapi.PDControlInterface, masterControl dmapi.MasterControlInterface, cli versioned.Interface, kubeCli kubernetes.Interface) TiDBDiscovery {
 	return &tidbDiscovery{
 		cli:           cli,
+		kubeCli:       kubeCli,
 		pdControl:     pdControl,
 		masterControl: masterControl,
 		clusters:      map[string]*clusterInfo{},
@@ -114,6 +128,9 @@ func (d *tidbDiscovery) Discover(advertisePeerUrl string) (string, error) {
 		if len(pdAddresses) != 0 {
 			return fmt.Sprintf("--join=%s", strings.Join(pdAddresses, ",")), nil
 		}
+		if err := checkBootstrapSafety(tc); err != nil {
+			return "", err
+		}
 		// Initialize the PD cluster with the FQDN format service record if deploy across k8s or tc.Spec.ClusterDomain is set
 		if tc.AcrossK8s() || tc.Spec.ClusterDomain != "" {
 			return fmt.Sprintf("--initial-cluster=%s=%s://%s", strArr[0], tc.Scheme(), advertisePeerUrl), nil
@@ -126,7 +143,8 @@ func (d *tidbDiscovery) Discover(advertisePeerUrl string) (string, error) {
 
 	if tc.Spec.PD != nil {
 		// connect to pd of current cluster
-		pdClients = append(pdClients, d.pdControl.GetPDClient(pdapi.Namespace(tc.GetNamespace()), tc.GetName(), tc.IsTLSClusterEnabled()))
+		pdClients = append(pdClients, d.pdControl.GetPDClient(pdapi.Namespace(tc.GetNamespace()), tc.GetName(), tc.IsTLSClusterEnabled(),
+			pdapi.TLSFromCluster(pdapi.Namespace(tc.Namespace), tc.Spec.TLSCluster)))
 	}
 
 	if tc.Heterogeneous() {
@@ -138,6 +156,7 @@ func (d *tidbDiscovery) Discover(advertisePeerUrl string) (string, error) {
 		pdClients = append(pdClients,
 			d.pdControl.GetPDClient(pdapi.Namespace(namespace), tc.Spec.Cluster.Name, tc.IsTLSClusterEnabled(),
 				pdapi.TLSCertFromTC(pdapi.Namespace(tc.GetNamespace()), tc.GetName()),
+				pdapi.TLSFromCluster(pdapi.Namespace(tc.Namespace), tc.Spec.TLSCluster),
 				pdapi.ClusterRef(tc.Spec.Cluster.ClusterDomain),
 				pdapi.UseHeadlessService(tc.Spec.AcrossK8s),
 			),
@@ -145,7 +164,8 @@ func (d *tidbDiscovery) Discover(advertisePeerUrl string) (string, error) {
 	}
 
 	for _, pdMember := range tc.Status.PD.PeerMembers {
-		pdClients = append(pdClients, d.pdControl.GetPDClient(pdapi.Namespace(ns), tc.Name, tc.IsTLSClusterEnabled(), pdapi.SpecifyClient(pdMember.ClientURL, pdMember.Name)))
+		pdClients = append(pdClients, d.pdControl.GetPDClient(pdapi.Namespace(ns), tc.Name, tc.IsTLSClusterEnabled(),
+			pdapi.SpecifyClient(pdMember.ClientURL, pdMember.Name), pdapi.TLSFromCluster(pdapi.Namespace(tc.Namespace), tc.Spec.TLSCluster)))
 	}
 
 	var membersInfo *pdapi.MembersInfo
@@ -158,6 +178,9 @@ func (d *tidbDiscovery) Discover(advertisePeerUrl string) (string, error) {
 	if err != nil {
 		return "", err
 	}
+	if err := d.verifyClusterID(tc, membersInfo); err != nil {
+		return "", err
+	}
 
 	membersArr := make([]string, 0)
 	for _, member := range membersInfo.Members {
@@ -181,6 +204,67 @@ func (d *tidbDiscovery) Discover(advertisePeerUrl string) (string, error) {
 	return fmt.Sprintf("--join=%s", strings.Join(membersArr, ",")), nil
 }
 
+// checkBootstrapSafety refuses to bootstrap a new PD cluster if the tc has been bootstrapped before,
+// as the new PD cluster gets a different cluster ID and splits from the existing stores.
+func checkBootstrapSafety(tc *v1alpha1.TidbCluster) error {
+	if tc.Status.ClusterID == "" || tc.Annotations[label.AnnPDForceBootstrapKey] == label.AnnPDForceBootstrapVal {
+		return nil
+	}
+	return fmt.Errorf("refuse to bootstrap a new PD cluster for tidbcluster %s/%s which has been bootstrapped with cluster ID %s, "+
+		"set annotation %s=%s to bootstrap anyway", tc.Namespace, tc.Name, tc.Status.ClusterID, label.AnnPDForceBootstrapKey, label.AnnPDForceBootstrapVal)
+}
+
+// verifyClusterID verifies that the PD cluster to join has the expected cluster ID, so that a PD member
+// never joins another cluster by mistake, e.g. through a wrong ClusterDomain. The join is allowed with a
+// warning if the expected cluster ID can not be resolved, e.g. the first PD member of a cluster joining
+// a cluster in another Kubernetes cluster, whose cluster ID is only synced after a PD member has joined.
+func (d *tidbDiscovery) verifyClusterID(tc *v1alpha1.TidbCluster, membersInfo *pdapi.MembersInfo) error {
+	expected := d.expectedClusterID(tc)
+	if expected == "" {
+		klog.Warningf("the expected PD cluster ID of tidbcluster %s/%s is unknown, skip verifying the cluster ID of the PD cluster to join, "+
+			"set spec.pd.clusterID to verify it", tc.Namespace, tc.Name)
+		return nil
+	}
+	if membersInfo.Header == nil || membersInfo.Header.ClusterId == 0 {
+		return fmt.Errorf("refuse to join PD cluster without cluster ID, tidbcluster %s/%s expects cluster ID %s",
+			tc.Namespace, tc.Name, expected)
+	}
+	clusterID := strconv.FormatUint(membersInfo.Header.ClusterId, 10)
+	if clusterID != expected {
+		return fmt.Errorf("refuse to join PD cluster with cluster ID %s, tidbcluster %s/%s expects cluster ID %s",
+			clusterID, tc.Namespace, tc.Name, expected)
+	}
+	return nil
+}
+
+// expectedClusterID returns the ID of the PD cluster the PD members of the tc are expected to join,
+// which is spec.pd.clusterID if set, or the cluster ID recorded in the status of the tc, or that of
+// the tc referenced by spec.cluster in the same Kubernetes cluster. It returns empty if the cluster
+// ID can not be resolved.
+func (d *tidbDiscovery) expectedClusterID(tc *v1alpha1.TidbCluster) string {
+	if tc.Spec.PD != nil && tc.Spec.PD.ClusterID != "" {
+		return tc.Spec.PD.ClusterID
+	}
+	if tc.Status.ClusterID != "" {
+		return tc.Status.ClusterID
+	}
+	// the tc referenced across Kubernetes clusters can't be got from this Kubernetes cluster
+	if tc.Heterogeneous() && !tc.AcrossK8s() {
+		ns := tc.Spec.Cluster.Namespace
+		if ns == "" {
+			ns = tc.Namespace
+		}
+		// discovery is only allowed to get the referenced tc in the same namespace
+		ref, err := d.cli.PingcapV1alpha1().TidbClusters(ns).Get(context.TODO(), tc.Spec.Cluster.Name, metav1.GetOptions{})
+		if err != nil {
+			klog.Warningf("failed to get tidbcluster %s/%s referenced by tidbcluster %s/%s, error: %v", ns, tc.Spec.Cluster.Name, tc.Namespace, tc.Name, err)
+		} else if ref.Status.ClusterID != "" {
+			return ref.Status.ClusterID
+		}
+	}
+	return ""
+}
+
 func (d *tidbDiscovery) DiscoverDM(advertisePeerUrl string) (string, error) {
 	d.lock.Lock()
 	defer d.lock.Unlock()
@@ -224,7 +308,8 @@ func (d *tidbDiscovery) DiscoverDM(advertisePeerUrl string) (string, error) {
 		return fmt.Sprintf("--initial-cluster=%s=%s://%s", podName, dc.Scheme(), advertisePeerUrl), nil
 	}
 
-	masterClient := d.masterControl.GetMasterClient(dc.GetNamespace(), dc.GetName(), dc.IsTLSClusterEnabled())
+	masterClient := d.masterControl.GetMasterClient(dc.GetNamespace(), dc.GetName(), dc.IsTLSClusterEnabled(),
+		pdapi.TLSFromCluster(pdapi.Namespace(dc.GetNamespace()), dc.Spec.TLSCluster))
 	mastersInfos, err := masterClient.GetMasters()
 	if err != nil {
 		return "", err
@@ -284,6 +369,101 @@ func (d *tidbDiscovery) VerifyPDEndpoint(pdURL string) (string, error) {
 	return strings.Join(returnPDMembers, ","), nil
 }
 
+// PreStop is called by the preStop hook of a PD or TiKV pod, it annotates the pod to let the operator
+// hand over the workload of the pod, and returns how much workload is left on the pod, i.e. 1 if the
+// PD member is still the leader, or the region leader count of the TiKV store.
+// The request is only accepted from the IP of the pod, so other clients can't hand over the workload of
+// arbitrary pods through discovery.
+func (d *tidbDiscovery) PreStop(podName string, remoteIP string) (string, error) {
+	ns := os.Getenv("MY_POD_NAMESPACE")
+	pod, err := d.kubeCli.CoreV1().Pods(ns).Get(context.TODO(), podName, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+	if !podHasIP(pod, remoteIP) {
+		return "", ErrPreStopForbidden
+	}
+
+	var annKey, annValue string
+	component := pod.Labels[label.ComponentLabelKey]
+	switch component {
+	case label.PDLabelVal:
+		annKey, annValue = v1alpha1.PDLeaderTransferAnnKey, v1alpha1.TransferLeaderValueNone
+	case label.TiKVLabelVal:
+		annKey, annValue = v1alpha1.EvictLeaderAnnKey, v1alpha1.EvictLeaderValueNone
+	default:
+		return "0", nil
+	}
+
+	tcName := pod.Labels[label.InstanceLabelKey]
+	tc, err := d.cli.PingcapV1alpha1().TidbClusters(ns).Get(context.TODO(), tcName, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+
+	if _, ok := pod.Annotations[annKey]; !ok {
+		patch := fmt.Sprintf(`{"metadata":{"annotations":{%q:%q,%q:%q}}}`,
+			annKey, annValue, v1alpha1.PreStopBeginTimeAnnKey, time.Now().Format(time.RFC3339))
+		_, err = d.kubeCli.CoreV1().Pods(ns).Patch(context.TODO(), podName, types.MergePatchType, []byte(patch), metav1.PatchOptions{})
+		if err != nil {
+			return "", err
+		}
+		klog.Infof("pod %s/%s is stopping, annotate it with %s=%s", ns, podName, annKey, annValue)
+	}
+
+	pdClient := controller.GetPDClient(d.pdControl, tc)
+	switch component {
+	case label.PDLabelVal:
+		leader, err := pdClient.GetPDLeader()
+		if err != nil {
+			return "", err
+		}
+		// the PD member name is the FQDN of the pod when the cluster is deployed across k8s
+		if leader.Name == podName || strings.HasPrefix(leader.Name, podName+".") {
+			return "1", nil
+		}
+		return "0", nil
+	default:
+		for _, store := range tc.Status.TiKV.Stores {
+			if store.PodName != podName {
+				continue
+			}
+			storeID, err := strconv.ParseUint(store.ID, 10, 64)
+			if err != nil {
+				return "", err
+			}
+			storeInfo, err := pdClient.GetStore(storeID)
+			if err != nil {
+				return "", err
+			}
+			if storeInfo.Status == nil {
+				return "", fmt.Errorf("status of store %d is empty", storeID)
+			}
+			return strconv.Itoa(storeInfo.Status.LeaderCount), nil
+		}
+		// the store has not been registered to PD yet
+		return "0", nil
+	}
+}
+
+// podHasIP returns whether the ip is one of the IPs of the pod
+func podHasIP(pod *corev1.Pod, ip string) bool {
+	remote := net.ParseIP(ip)
+	if remote == nil {
+		return false
+	}
+	ips := []string{pod.Status.PodIP}
+	for _, podIP := range pod.Status.PodIPs {
+		ips = append(ips, podIP.IP)
+	}
+	for _, podIP := range ips {
+		if parsed := net.ParseIP(podIP); parsed != nil && parsed.Equal(remote) {
+			return true
+		}
+	}
+	return false
+}
+
 // parsePDURL parses pdURL to PDEndpoint related information
 func parsePDURL(pdURL string) pdEndpointURL {
 	// Deal with scheme
@@ -22,11 +22,13 @@ import (
 
 	. "github.com/onsi/gomega"
 	"github.com/pingcap/kvproto/pkg/pdpb"
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
 	"github.com/pingcap/tidb-operator/pkg/client/clientset/versioned/fake"
 	"github.com/pingcap/tidb-operator/pkg/controller"
 	"github.com/pingcap/tidb-operator/pkg/dmapi"
 	"github.com/pingcap/tidb-operator/pkg/pdapi"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	kubeinformers "k8s.io/client-go/informers"
@@ -202,7 +204,11 @@ func TestDiscoveryDiscovery(t *testing.T) {
 			name: "1 cluster, third ordinal, return the initial-cluster args",
 			ns:   "default",
 			url:  "demo-pd-2.demo-pd-peer.default.svc:2380",
-			tc:   newTC(),
+			tc: func() *v1alpha1.TidbCluster {
+				tc := newTC()
+				tc.Status.ClusterID = ""
+				return tc
+			}(),
 			clusters: map[string]*clusterInfo{
 				"default/demo": {
 					resourceVersion: "1",
@@ -254,6 +260,7 @@ func TestDiscoveryDiscovery(t *testing.T) {
 			tc:   newTC(),
 			getMembersFn: func() (*pdapi.MembersInfo, error) {
 				return &pdapi.MembersInfo{
+					Header: &pdpb.ResponseHeader{ClusterId: 6994402138450221331},
 					Members: []*pdpb.Member{
 						{
 							PeerUrls: []string{"demo-pd-2.demo-pd-peer.default.svc:2380"},
@@ -278,6 +285,217 @@ func TestDiscoveryDiscovery(t *testing.T) {
 				g.Expect(s).To(Equal("--join=demo-pd-2.demo-pd-peer.default.svc:2379"))
 			},
 		},
+		{
+			name: "1 cluster, third ordinal, refuse to bootstrap a bootstrapped cluster",
+			ns:   "default",
+			url:  "demo-pd-2.demo-pd-peer.default.svc:2380",
+			tc: func() *v1alpha1.TidbCluster {
+				tc := newTC()
+				tc.Status.ClusterID = "6994402138450221331"
+				return tc
+			}(),
+			clusters: map[string]*clusterInfo{
+				"default/demo": {
+					resourceVersion: "1",
+					peers: map[string]struct{}{
+						"demo-pd-0": {},
+						"demo-pd-1": {},
+					},
+				},
+			},
+			expectFn: func(g *GomegaWithT, td *tidbDiscovery, s string, err error) {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(strings.Contains(err.Error(), "refuse to bootstrap a new PD cluster")).To(BeTrue())
+				g.Expect(s).To(BeEmpty())
+			},
+		},
+		{
+			name: "1 cluster, third ordinal, force bootstrap a bootstrapped cluster",
+			ns:   "default",
+			url:  "demo-pd-2.demo-pd-peer.default.svc:2380",
+			tc: func() *v1alpha1.TidbCluster {
+				tc := newTC()
+				tc.Annotations = map[string]string{label.AnnPDForceBootstrapKey: label.AnnPDForceBootstrapVal}
+				tc.Status.ClusterID = "6994402138450221331"
+				return tc
+			}(),
+			clusters: map[string]*clusterInfo{
+				"default/demo": {
+					resourceVersion: "1",
+					peers: map[string]struct{}{
+						"demo-pd-0": {},
+						"demo-pd-1": {},
+					},
+				},
+			},
+			expectFn: func(g *GomegaWithT, td *tidbDiscovery, s string, err error) {
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(s).To(Equal("--initial-cluster=demo-pd-2=http://demo-pd-2.demo-pd-peer.default.svc:2380"))
+			},
+		},
+		{
+			name: "1 cluster, the first ordinal third request, cluster ID mismatch",
+			ns:   "default",
+			url:  "demo-pd-0.demo-pd-peer.default.svc:2380",
+			tc: func() *v1alpha1.TidbCluster {
+				tc := newTC()
+				tc.Status.ClusterID = "6994402138450221331"
+				return tc
+			}(),
+			getMembersFn: func() (*pdapi.MembersInfo, error) {
+				return &pdapi.MembersInfo{
+					Header: &pdpb.ResponseHeader{ClusterId: 7000000000000000000},
+					Members: []*pdpb.Member{
+						{
+							PeerUrls: []string{"demo-pd-2.demo-pd-peer.default.svc:2380"},
+						},
+					},
+				}, nil
+			},
+			clusters: map[string]*clusterInfo{
+				"default/demo": {
+					resourceVersion: "1",
+					peers: map[string]struct{}{
+						"demo-pd-0": {},
+						"demo-pd-1": {},
+					},
+				},
+			},
+			expectFn: func(g *GomegaWithT, td *tidbDiscovery, s string, err error) {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(strings.Contains(err.Error(), "refuse to join PD cluster with cluster ID 7000000000000000000")).To(BeTrue())
+				g.Expect(s).To(BeEmpty())
+			},
+		},
+		{
+			name: "1 cluster, the first ordinal third request, cluster ID matches",
+			ns:   "default",
+			url:  "demo-pd-0.demo-pd-peer.default.svc:2380",
+			tc: func() *v1alpha1.TidbCluster {
+				tc := newTC()
+				tc.Status.ClusterID = "6994402138450221331"
+				return tc
+			}(),
+			getMembersFn: func() (*pdapi.MembersInfo, error) {
+				return &pdapi.MembersInfo{
+					Header: &pdpb.ResponseHeader{ClusterId: 6994402138450221331},
+					Members: []*pdpb.Member{
+						{
+							PeerUrls: []string{"demo-pd-2.demo-pd-peer.default.svc:2380"},
+						},
+					},
+				}, nil
+			},
+			clusters: map[string]*clusterInfo{
+				"default/demo": {
+					resourceVersion: "1",
+					peers: map[string]struct{}{
+						"demo-pd-0": {},
+						"demo-pd-1": {},
+					},
+				},
+			},
+			expectFn: func(g *GomegaWithT, td *tidbDiscovery, s string, err error) {
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(s).To(Equal("--join=demo-pd-2.demo-pd-peer.default.svc:2379"))
+			},
+		},
+		{
+			name: "1 cluster, the first ordinal third request, expected cluster ID unresolved",
+			ns:   "default",
+			url:  "demo-pd-0.demo-pd-peer.default.svc:2380",
+			tc: func() *v1alpha1.TidbCluster {
+				tc := newTC()
+				tc.Status.ClusterID = ""
+				return tc
+			}(),
+			getMembersFn: func() (*pdapi.MembersInfo, error) {
+				return &pdapi.MembersInfo{
+					Header: &pdpb.ResponseHeader{ClusterId: 6994402138450221331},
+					Members: []*pdpb.Member{
+						{
+							PeerUrls: []string{"demo-pd-2.demo-pd-peer.default.svc:2380"},
+						},
+					},
+				}, nil
+			},
+			clusters: map[string]*clusterInfo{
+				"default/demo": {
+					resourceVersion: "1",
+					peers: map[string]struct{}{
+						"demo-pd-0": {},
+						"demo-pd-1": {},
+					},
+				},
+			},
+			expectFn: func(g *GomegaWithT, td *tidbDiscovery, s string, err error) {
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(s).To(Equal("--join=demo-pd-2.demo-pd-peer.default.svc:2379"))
+			},
+		},
+		{
+			name: "across kubernetes, the first pd of the second cluster joins the first cluster",
+			ns:   "default",
+			url:  "demo-pd-0.demo-pd-peer.default.svc.cluster2.com:2380",
+			tc: func() *v1alpha1.TidbCluster {
+				tc := newTC()
+				tc.Spec.AcrossK8s = true
+				tc.Spec.ClusterDomain = "cluster2.com"
+				tc.Spec.Cluster = &v1alpha1.TidbClusterRef{Name: "cluster1", Namespace: "default", ClusterDomain: "cluster1.com"}
+				tc.Status.ClusterID = ""
+				return tc
+			}(),
+			getMembersFn: func() (*pdapi.MembersInfo, error) {
+				return &pdapi.MembersInfo{
+					Header: &pdpb.ResponseHeader{ClusterId: 6994402138450221331},
+					Members: []*pdpb.Member{
+						{
+							Name:     "cluster1-pd-0.cluster1-pd-peer.default.svc.cluster1.com",
+							PeerUrls: []string{"http://cluster1-pd-0.cluster1-pd-peer.default.svc.cluster1.com:2380"},
+						},
+					},
+				}, nil
+			},
+			clusters: map[string]*clusterInfo{},
+			expectFn: func(g *GomegaWithT, td *tidbDiscovery, s string, err error) {
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(s).To(Equal("--join=http://cluster1-pd-0.cluster1-pd-peer.default.svc.cluster1.com:2379"))
+			},
+		},
+		{
+			name: "1 cluster, the first ordinal third request, cluster ID in spec",
+			ns:   "default",
+			url:  "demo-pd-0.demo-pd-peer.default.svc:2380",
+			tc: func() *v1alpha1.TidbCluster {
+				tc := newTC()
+				tc.Spec.PD.ClusterID = "7000000000000000000"
+				tc.Status.ClusterID = ""
+				return tc
+			}(),
+			getMembersFn: func() (*pdapi.MembersInfo, error) {
+				return &pdapi.MembersInfo{
+					Header: &pdpb.ResponseHeader{ClusterId: 7000000000000000000},
+					Members: []*pdpb.Member{
+						{
+							PeerUrls: []string{"demo-pd-2.demo-pd-peer.default.svc:2380"},
+						},
+					},
+				}, nil
+			},
+			clusters: map[string]*clusterInfo{
+				"default/demo": {
+					resourceVersion: "1",
+					peers: map[string]struct{}{
+						"demo-pd-0": {},
+						"demo-pd-1": {},
+					},
+				},
+			},
+			expectFn: func(g *GomegaWithT, td *tidbDiscovery, s string, err error) {
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(s).To(Equal("--join=demo-pd-2.demo-pd-peer.default.svc:2379"))
+			},
+		},
 		{
 			name: "1 cluster, the second ordinal second request, get members success",
 			ns:   "default",
@@ -285,6 +503,7 @@ func TestDiscoveryDiscovery(t *testing.T) {
 			tc:   newTC(),
 			getMembersFn: func() (*pdapi.MembersInfo, error) {
 				return &pdapi.MembersInfo{
+					Header: &pdpb.ResponseHeader{ClusterId: 6994402138450221331},
 					Members: []*pdpb.Member{
 						{
 							PeerUrls: []string{"demo-pd-0.demo-pd-peer.default.svc:2380"},
@@ -321,6 +540,7 @@ func TestDiscoveryDiscovery(t *testing.T) {
 			}(),
 			getMembersFn: func() (*pdapi.MembersInfo, error) {
 				return &pdapi.MembersInfo{
+					Header: &pdpb.ResponseHeader{ClusterId: 6994402138450221331},
 					Members: []*pdpb.Member{
 						{
 							PeerUrls: []string{"demo-pd-0.demo-pd-peer.default.svc:2380"},
@@ -354,6 +574,7 @@ func TestDiscoveryDiscovery(t *testing.T) {
 			tc:   newTC(),
 			getMembersFn: func() (*pdapi.MembersInfo, error) {
 				return &pdapi.MembersInfo{
+					Header: &pdpb.ResponseHeader{ClusterId: 6994402138450221331},
 					Members: []*pdpb.Member{
 						{
 							PeerUrls: []string{"demo-pd-2.demo-pd-peer.default.svc.cluster.local:2380"},
@@ -389,6 +610,7 @@ func TestDiscoveryDiscovery(t *testing.T) {
 			}(),
 			getMembersFn: func() (*pdapi.MembersInfo, error) {
 				return &pdapi.MembersInfo{
+					Header: &pdpb.ResponseHeader{ClusterId: 6994402138450221331},
 					Members: []*pdpb.Member{
 						{
 							PeerUrls: []string{"demo-pd-0.demo-pd-peer.default.svc:2380"},
@@ -465,6 +687,7 @@ func TestDiscoveryDiscovery(t *testing.T) {
 			}(),
 			getMembersFn: func() (*pdapi.MembersInfo, error) {
 				return &pdapi.MembersInfo{
+					Header: &pdpb.ResponseHeader{ClusterId: 6994402138450221331},
 					Members: []*pdpb.Member{
 						{
 							PeerUrls: []string{"http://address0:2380"},
@@ -511,6 +734,7 @@ func TestDiscoveryDiscovery(t *testing.T) {
 			}(),
 			getMembersFn: func() (*pdapi.MembersInfo, error) {
 				return &pdapi.MembersInfo{
+					Header: &pdpb.ResponseHeader{ClusterId: 6994402138450221331},
 					Members: []*pdpb.Member{
 						{
 							PeerUrls: []string{"demo-pd-3.demo-pd-peer.default.svc:2380"},
@@ -1148,6 +1372,138 @@ func TestDiscoveryVerifyPDEndpoint(t *testing.T) {
 	}
 }
 
+func TestDiscoveryPreStop(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	type testcase struct {
+		name      string
+		component string
+		podName   string
+		leader    string
+		remoteIP  string
+		expect    string
+		expectErr bool
+		annKey    string
+	}
+	testFn := func(test testcase) {
+		t.Log(test.name)
+		cli := fake.NewSimpleClientset()
+		kubeCli := kubefake.NewSimpleClientset()
+		informer := kubeinformers.NewSharedInformerFactory(kubeCli, 0)
+		fakePDControl := pdapi.NewFakePDControl(informer.Core().V1().Secrets().Lister())
+		fakeMasterControl := dmapi.NewFakeMasterControl(informer.Core().V1().Secrets().Lister())
+		pdClient := pdapi.NewFakePDClient()
+
+		tc := newTC()
+		tc.Status.TiKV.Stores = map[string]v1alpha1.TiKVStore{
+			"1": {ID: "1", PodName: "demo-tikv-0"},
+		}
+		cli.PingcapV1alpha1().TidbClusters(tc.Namespace).Create(context.TODO(), tc, metav1.CreateOptions{})
+		fakePDControl.SetPDClient(pdapi.Namespace(tc.GetNamespace()), tc.GetName(), pdClient)
+		pdClient.AddReaction(pdapi.GetPDLeaderActionType, func(action *pdapi.Action) (interface{}, error) {
+			return &pdpb.Member{Name: test.leader}, nil
+		})
+		pdClient.AddReaction(pdapi.GetStoreActionType, func(action *pdapi.Action) (interface{}, error) {
+			return &pdapi.StoreInfo{Status: &pdapi.StoreStatus{LeaderCount: 10}}, nil
+		})
+		kubeCli.CoreV1().Pods(tc.Namespace).Create(context.TODO(), &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      test.podName,
+				Namespace: tc.Namespace,
+				Labels: map[string]string{
+					label.ComponentLabelKey: test.component,
+					label.InstanceLabelKey:  tc.Name,
+				},
+			},
+			Status: corev1.PodStatus{PodIP: "10.0.0.1"},
+		}, metav1.CreateOptions{})
+
+		td := NewTiDBDiscovery(fakePDControl, fakeMasterControl, cli, kubeCli)
+		os.Setenv("MY_POD_NAMESPACE", tc.Namespace)
+		remoteIP := test.remoteIP
+		if remoteIP == "" {
+			remoteIP = "10.0.0.1"
+		}
+		re, err := td.PreStop(test.podName, remoteIP)
+		if test.expectErr {
+			g.Expect(err).To(MatchError(ErrPreStopForbidden))
+			pod, err := kubeCli.CoreV1().Pods(tc.Namespace).Get(context.TODO(), test.podName, metav1.GetOptions{})
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(pod.Annotations).To(BeEmpty())
+			return
+		}
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(re).To(Equal(test.expect))
+
+		pod, err := kubeCli.CoreV1().Pods(tc.Namespace).Get(context.TODO(), test.podName, metav1.GetOptions{})
+		g.Expect(err).NotTo(HaveOccurred())
+		if test.annKey == "" {
+			g.Expect(pod.Annotations).To(BeEmpty())
+		} else {
+			g.Expect(pod.Annotations).To(HaveKey(test.annKey))
+			g.Expect(pod.Annotations).To(HaveKey(v1alpha1.PreStopBeginTimeAnnKey))
+		}
+	}
+
+	tests := []testcase{
+		{
+			name:      "pd member is the leader",
+			component: label.PDLabelVal,
+			podName:   "demo-pd-0",
+			leader:    "demo-pd-0",
+			expect:    "1",
+			annKey:    v1alpha1.PDLeaderTransferAnnKey,
+		},
+		{
+			name:      "pd member with cluster domain is the leader",
+			component: label.PDLabelVal,
+			podName:   "demo-pd-0",
+			leader:    "demo-pd-0.demo-pd-peer.default.svc.cluster.local",
+			expect:    "1",
+			annKey:    v1alpha1.PDLeaderTransferAnnKey,
+		},
+		{
+			name:      "request not sent by the pod",
+			component: label.PDLabelVal,
+			podName:   "demo-pd-0",
+			leader:    "demo-pd-0",
+			remoteIP:  "10.0.0.2",
+			expectErr: true,
+		},
+		{
+			name:      "pd member is not the leader",
+			component: label.PDLabelVal,
+			podName:   "demo-pd-0",
+			leader:    "demo-pd-1",
+			expect:    "0",
+			annKey:    v1alpha1.PDLeaderTransferAnnKey,
+		},
+		{
+			name:      "tikv store has leaders",
+			component: label.TiKVLabelVal,
+			podName:   "demo-tikv-0",
+			expect:    "10",
+			annKey:    v1alpha1.EvictLeaderAnnKey,
+		},
+		{
+			name:      "tikv store is not registered",
+			component: label.TiKVLabelVal,
+			podName:   "demo-tikv-1",
+			expect:    "0",
+			annKey:    v1alpha1.EvictLeaderAnnKey,
+		},
+		{
+			name:      "other components",
+			component: label.TiDBLabelVal,
+			podName:   "demo-tidb-0",
+			expect:    "0",
+		},
+	}
+	for _, test := range tests {
+		testFn(test)
+	}
+}
+
 func newTC() *v1alpha1.TidbCluster {
 	return &v1alpha1.TidbCluster{
 		TypeMeta: metav1.TypeMeta{Kind: "TidbCluster", APIVersion: "v1alpha1"},
@@ -1159,6 +1515,9 @@ func newTC() *v1alpha1.TidbCluster {
 		Spec: v1alpha1.TidbClusterSpec{
 			PD: &v1alpha1.PDSpec{Replicas: 3},
 		},
+		Status: v1alpha1.TidbClusterStatus{
+			ClusterID: "6994402138450221331",
+		},
 	}
 }
 
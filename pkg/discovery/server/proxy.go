@@ -24,6 +24,7 @@ import (
 	"strings"
 
 	"github.com/pingcap/tidb-operator/pkg/manager/member"
+	httputil "github.com/pingcap/tidb-operator/pkg/util/http"
 	"k8s.io/klog/v2"
 )
 
@@ -63,7 +64,7 @@ func buildProxy(url *url.URL, tlsEnabled bool) (*httputil.ReverseProxy, error) {
 			Certificates: []tls.Certificate{cert},
 			RootCAs:      rootCAs,
 		}
-		proxy.Transport = &http.Transport{TLSClientConfig: tlsConfig}
+		proxy.Transport = &http.Transport{TLSClientConfig: tlsConfig, Proxy: httputil.Proxy}
 	}
 	director := proxy.Director
 	proxy.Director = func(req *http.Request) {
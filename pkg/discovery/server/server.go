@@ -15,8 +15,10 @@ package server
 
 import (
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"strings"
 
@@ -50,6 +52,7 @@ func (s *server) registerHandlers() {
 	ws.Route(ws.GET("/new/{advertise-peer-url}").To(s.newHandler))
 	ws.Route(ws.GET("/new/{advertise-peer-url}/{register-type}").To(s.newHandler))
 	ws.Route(ws.GET("/verify/{pd-url}").To(s.newVerifyHandler))
+	ws.Route(ws.GET("/prestop/{pod-name}").To(s.preStopHandler))
 	s.container.Add(ws)
 }
 
@@ -131,3 +134,28 @@ func (s *server) newVerifyHandler(req *restful.Request, resp *restful.Response)
 		klog.Errorf("failed to writeString: %s, %v", result, err)
 	}
 }
+
+func (s *server) preStopHandler(req *restful.Request, resp *restful.Response) {
+	podName := req.PathParameter("pod-name")
+	remoteIP, _, err := net.SplitHostPort(req.Request.RemoteAddr)
+	if err != nil {
+		remoteIP = req.Request.RemoteAddr
+	}
+	result, err := s.discovery.PreStop(podName, remoteIP)
+	if err != nil {
+		klog.Errorf("failed to handle preStop of pod %s from %s, %v", podName, remoteIP, err)
+		status := http.StatusInternalServerError
+		if errors.Is(err, discovery.ErrPreStopForbidden) {
+			status = http.StatusForbidden
+		}
+		if werr := resp.WriteError(status, err); werr != nil {
+			klog.Errorf("failed to writeError: %v", werr)
+		}
+		return
+	}
+
+	klog.Infof("workload left on pod %s: %s", podName, result)
+	if _, err := io.WriteString(resp, result); err != nil {
+		klog.Errorf("failed to writeString: %s, %v", result, err)
+	}
+}
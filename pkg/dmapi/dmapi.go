@@ -20,6 +20,8 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/metrics"
 	httputil "github.com/pingcap/tidb-operator/pkg/util/http"
 )
 
@@ -36,6 +38,29 @@ type MasterClient interface {
 	EvictLeader() error
 	DeleteMaster(name string) error
 	DeleteWorker(name string) error
+
+	// ListSources returns all the data sources by the OpenAPI
+	ListSources() ([]Source, error)
+	// CreateSource creates a data source by the OpenAPI
+	CreateSource(source *Source) error
+	// UpdateSource updates a data source by the OpenAPI
+	UpdateSource(source *Source) error
+	// DeleteSource deletes a data source by the OpenAPI
+	DeleteSource(name string) error
+	// ListTasks returns all the tasks by the OpenAPI
+	ListTasks() ([]Task, error)
+	// CreateTask creates a task on the sources by the OpenAPI, the task isn't started
+	CreateTask(task Task, sources []string) error
+	// UpdateTask updates a stopped task by the OpenAPI
+	UpdateTask(task Task) error
+	// StartTask starts a task by the OpenAPI
+	StartTask(name string) error
+	// StopTask stops a task by the OpenAPI
+	StopTask(name string) error
+	// DeleteTask deletes a task by the OpenAPI
+	DeleteTask(name string) error
+	// GetTaskStatus returns the status of the subtasks of a task by the OpenAPI
+	GetTaskStatus(name string) ([]SubTaskStatus, error)
 }
 
 var (
@@ -229,9 +254,7 @@ func (c *masterClient) DeleteWorker(name string) error {
 func NewMasterClient(url string, timeout time.Duration, tlsConfig *tls.Config, disableKeepalive bool) MasterClient {
 	return &masterClient{
 		url: url,
-		httpClient: &http.Client{
-			Timeout:   timeout,
-			Transport: &http.Transport{TLSClientConfig: tlsConfig, DisableKeepAlives: disableKeepalive},
-		},
+		httpClient: httputil.NewClient(timeout, metrics.InstrumentRoundTripper(v1alpha1.DMMasterMemberType.String(),
+			&http.Transport{TLSClientConfig: tlsConfig, DisableKeepAlives: disableKeepalive, Proxy: httputil.Proxy})),
 	}
 }
@@ -208,3 +208,38 @@ func TestDeleteMember(t *testing.T) {
 		g.Expect(err).NotTo(HaveOccurred())
 	}
 }
+
+func TestTaskOpenAPI(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	svc := getClientServer(func(w http.ResponseWriter, request *http.Request) {
+		w.Header().Set("Content-Type", ContentTypeJSON)
+		switch {
+		case request.Method == "GET" && request.URL.Path == "/"+tasksPrefix+"/task/status":
+			w.Write([]byte(`{"total":1,"data":[{"name":"task","source_name":"mysql-01","worker_name":"worker-0","stage":"Paused","unit":"Sync","error_msg":"table not found"}]}`))
+		case request.Method == "POST" && request.URL.Path == "/"+tasksPrefix:
+			g.Expect(request.Header.Get("Content-Type")).To(Equal(ContentTypeJSON))
+			req := &createTaskReq{}
+			g.Expect(json.NewDecoder(request.Body).Decode(req)).To(Succeed())
+			g.Expect(req.Task).To(HaveKeyWithValue("name", "task"))
+			g.Expect(req.SourceNameList).To(Equal([]string{"mysql-01"}))
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{}`))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error_code":46018,"error_msg":"task not exist"}`))
+		}
+	})
+	defer svc.Close()
+
+	masterClient := NewMasterClient(svc.URL, DefaultTimeout, &tls.Config{}, false)
+	subTasks, err := masterClient.GetTaskStatus("task")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(subTasks).To(Equal([]SubTaskStatus{{Name: "task", SourceName: "mysql-01", WorkerName: "worker-0", Stage: "Paused", Unit: "Sync", ErrorMsg: "table not found"}}))
+
+	g.Expect(masterClient.CreateTask(Task{"name": "task"}, []string{"mysql-01"})).To(Succeed())
+
+	err = masterClient.StartTask("other")
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(err.Error()).To(ContainSubstring("error code: 46018, error: task not exist"))
+}
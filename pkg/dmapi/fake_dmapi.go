@@ -20,12 +20,23 @@ import (
 type ActionType string
 
 const (
-	GetMastersActionType   ActionType = "GetMasters"
-	GetWorkersActionType   ActionType = "GetWorkers"
-	GetLeaderActionType    ActionType = "GetLeader"
-	EvictLeaderActionType  ActionType = "EvictLeader"
-	DeleteMasterActionType ActionType = "DeleteMaster"
-	DeleteWorkerActionType ActionType = "DeleteWorker"
+	GetMastersActionType    ActionType = "GetMasters"
+	GetWorkersActionType    ActionType = "GetWorkers"
+	GetLeaderActionType     ActionType = "GetLeader"
+	EvictLeaderActionType   ActionType = "EvictLeader"
+	DeleteMasterActionType  ActionType = "DeleteMaster"
+	DeleteWorkerActionType  ActionType = "DeleteWorker"
+	ListSourcesActionType   ActionType = "ListSources"
+	CreateSourceActionType  ActionType = "CreateSource"
+	UpdateSourceActionType  ActionType = "UpdateSource"
+	DeleteSourceActionType  ActionType = "DeleteSource"
+	ListTasksActionType     ActionType = "ListTasks"
+	CreateTaskActionType    ActionType = "CreateTask"
+	UpdateTaskActionType    ActionType = "UpdateTask"
+	StartTaskActionType     ActionType = "StartTask"
+	StopTaskActionType      ActionType = "StopTask"
+	DeleteTaskActionType    ActionType = "DeleteTask"
+	GetTaskStatusActionType ActionType = "GetTaskStatus"
 )
 
 type NotFoundReaction struct {
@@ -37,9 +48,12 @@ func (nfr *NotFoundReaction) Error() string {
 }
 
 type Action struct {
-	ID     uint64
-	Name   string
-	Labels map[string]string
+	ID      uint64
+	Name    string
+	Labels  map[string]string
+	Source  *Source
+	Task    Task
+	Sources []string
 }
 
 type Reaction func(action *Action) (interface{}, error)
@@ -113,3 +127,78 @@ func (c *FakeMasterClient) DeleteWorker(_ string) error {
 	_, err := c.fakeAPI(DeleteWorkerActionType, action)
 	return err
 }
+
+func (c *FakeMasterClient) ListSources() ([]Source, error) {
+	action := &Action{}
+	result, err := c.fakeAPI(ListSourcesActionType, action)
+	if err != nil {
+		return nil, err
+	}
+	return result.([]Source), nil
+}
+
+func (c *FakeMasterClient) CreateSource(source *Source) error {
+	action := &Action{Source: source}
+	_, err := c.fakeAPI(CreateSourceActionType, action)
+	return err
+}
+
+func (c *FakeMasterClient) UpdateSource(source *Source) error {
+	action := &Action{Source: source}
+	_, err := c.fakeAPI(UpdateSourceActionType, action)
+	return err
+}
+
+func (c *FakeMasterClient) DeleteSource(name string) error {
+	action := &Action{Name: name}
+	_, err := c.fakeAPI(DeleteSourceActionType, action)
+	return err
+}
+
+func (c *FakeMasterClient) ListTasks() ([]Task, error) {
+	action := &Action{}
+	result, err := c.fakeAPI(ListTasksActionType, action)
+	if err != nil {
+		return nil, err
+	}
+	return result.([]Task), nil
+}
+
+func (c *FakeMasterClient) CreateTask(task Task, sources []string) error {
+	action := &Action{Task: task, Sources: sources}
+	_, err := c.fakeAPI(CreateTaskActionType, action)
+	return err
+}
+
+func (c *FakeMasterClient) UpdateTask(task Task) error {
+	action := &Action{Task: task}
+	_, err := c.fakeAPI(UpdateTaskActionType, action)
+	return err
+}
+
+func (c *FakeMasterClient) StartTask(name string) error {
+	action := &Action{Name: name}
+	_, err := c.fakeAPI(StartTaskActionType, action)
+	return err
+}
+
+func (c *FakeMasterClient) StopTask(name string) error {
+	action := &Action{Name: name}
+	_, err := c.fakeAPI(StopTaskActionType, action)
+	return err
+}
+
+func (c *FakeMasterClient) DeleteTask(name string) error {
+	action := &Action{Name: name}
+	_, err := c.fakeAPI(DeleteTaskActionType, action)
+	return err
+}
+
+func (c *FakeMasterClient) GetTaskStatus(name string) ([]SubTaskStatus, error) {
+	action := &Action{Name: name}
+	result, err := c.fakeAPI(GetTaskStatusActionType, action)
+	if err != nil {
+		return nil, err
+	}
+	return result.([]SubTaskStatus), nil
+}
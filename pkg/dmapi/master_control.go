@@ -27,9 +27,9 @@ import (
 
 // MasterControlInterface is an interface that knows how to manage and get dm cluster's master client
 type MasterControlInterface interface {
-	// GetMasterClient provides MasterClient of the dm cluster.
-	GetMasterClient(namespace string, dcName string, tlsEnabled bool) MasterClient
-	GetMasterPeerClient(namespace string, dcName, podName string, tlsEnabled bool) MasterClient
+	// GetMasterClient provides MasterClient of the dm cluster, the TLS options select the client certificate and the CA.
+	GetMasterClient(namespace string, dcName string, tlsEnabled bool, opts ...pdapi.Option) MasterClient
+	GetMasterPeerClient(namespace string, dcName, podName string, tlsEnabled bool, opts ...pdapi.Option) MasterClient
 }
 
 // defaultMasterControl is the default implementation of MasterControlInterface.
@@ -45,7 +45,7 @@ func NewDefaultMasterControl(secretLister corelisterv1.SecretLister) MasterContr
 }
 
 // GetMasterClient provides a MasterClient of real dm-master cluster, if the MasterClient not existing, it will create new one.
-func (mc *defaultMasterControl) GetMasterClient(namespace string, dcName string, tlsEnabled bool) MasterClient {
+func (mc *defaultMasterControl) GetMasterClient(namespace string, dcName string, tlsEnabled bool, opts ...pdapi.Option) MasterClient {
 	mc.mutex.Lock()
 	defer mc.mutex.Unlock()
 
@@ -55,7 +55,7 @@ func (mc *defaultMasterControl) GetMasterClient(namespace string, dcName string,
 
 	if tlsEnabled {
 		scheme = "https"
-		tlsConfig, err = pdapi.GetTLSConfig(mc.secretLister, pdapi.Namespace(namespace), util.DMClientTLSSecretName(dcName))
+		tlsConfig, err = pdapi.GetTLSConfigFromOptions(mc.secretLister, pdapi.Namespace(namespace), util.DMClientTLSSecretName(dcName), opts...)
 		if err != nil {
 			klog.Errorf("Unable to get tls config for dm cluster %q, master client may not work: %v", dcName, err)
 			return NewMasterClient(MasterClientURL(namespace, dcName, scheme), DefaultTimeout, tlsConfig, true)
@@ -71,7 +71,7 @@ func (mc *defaultMasterControl) GetMasterClient(namespace string, dcName string,
 	return mc.masterClients[key]
 }
 
-func (mc *defaultMasterControl) GetMasterPeerClient(namespace string, dcName string, podName string, tlsEnabled bool) MasterClient {
+func (mc *defaultMasterControl) GetMasterPeerClient(namespace string, dcName string, podName string, tlsEnabled bool, opts ...pdapi.Option) MasterClient {
 	mc.mutex.Lock()
 	defer mc.mutex.Unlock()
 
@@ -81,7 +81,7 @@ func (mc *defaultMasterControl) GetMasterPeerClient(namespace string, dcName str
 
 	if tlsEnabled {
 		scheme = "https"
-		tlsConfig, err = pdapi.GetTLSConfig(mc.secretLister, pdapi.Namespace(namespace), util.DMClientTLSSecretName(dcName))
+		tlsConfig, err = pdapi.GetTLSConfigFromOptions(mc.secretLister, pdapi.Namespace(namespace), util.DMClientTLSSecretName(dcName), opts...)
 		if err != nil {
 			klog.Errorf("Unable to get tls config for dm cluster %q, master client may not work: %v", dcName, err)
 			return NewMasterClient(MasterPeerClientURL(namespace, dcName, podName, scheme), DefaultTimeout, tlsConfig, true)
@@ -133,10 +133,10 @@ func (fmc *FakeMasterControl) SetMasterPeerClient(namespace, dcName, podName str
 	fmc.masterPeerClients[masterPeerClientKey("http", namespace, dcName, podName)] = masterPeerClient
 }
 
-func (fmc *FakeMasterControl) GetMasterClient(namespace string, dcName string, tlsEnabled bool) MasterClient {
-	return fmc.defaultMasterControl.GetMasterClient(namespace, dcName, tlsEnabled)
+func (fmc *FakeMasterControl) GetMasterClient(namespace string, dcName string, tlsEnabled bool, opts ...pdapi.Option) MasterClient {
+	return fmc.defaultMasterControl.GetMasterClient(namespace, dcName, tlsEnabled, opts...)
 }
 
-func (fmc *FakeMasterControl) GetMasterPeerClient(namespace, dcName, podName string, tlsEnabled bool) MasterClient {
+func (fmc *FakeMasterControl) GetMasterPeerClient(namespace, dcName, podName string, tlsEnabled bool, opts ...pdapi.Option) MasterClient {
 	return fmc.masterPeerClients[masterPeerClientKey("http", namespace, dcName, podName)]
 }
@@ -0,0 +1,206 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dmapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	httputil "github.com/pingcap/tidb-operator/pkg/util/http"
+)
+
+// the prefixes of the OpenAPI of dm-master, which is enabled by `openapi = true` in the config of dm-master
+var (
+	sourcesPrefix = "api/v1/sources"
+	tasksPrefix   = "api/v1/tasks"
+)
+
+// Source is a data source of the OpenAPI of dm-master
+type Source struct {
+	SourceName string `json:"source_name"`
+	Host       string `json:"host"`
+	Port       int32  `json:"port"`
+	User       string `json:"user"`
+	Password   string `json:"password"`
+	EnableGTID bool   `json:"enable_gtid"`
+	Enable     bool   `json:"enable"`
+	// Purge is the purge config of the relay log of the source
+	Purge *SourcePurge `json:"purge,omitempty"`
+	// RelayConfig is the config of the relay log of the source
+	RelayConfig *SourceRelayConfig `json:"relay_config,omitempty"`
+}
+
+// SourcePurge is the purge config of the relay log of a source of the OpenAPI of dm-master
+type SourcePurge struct {
+	Interval    *int64 `json:"interval,omitempty"`
+	Expires     *int64 `json:"expires,omitempty"`
+	RemainSpace *int64 `json:"remain_space,omitempty"`
+}
+
+// SourceRelayConfig is the relay log config of a source of the OpenAPI of dm-master
+type SourceRelayConfig struct {
+	EnableRelay bool `json:"enable_relay"`
+}
+
+// Task is the config of a task of the OpenAPI of dm-master, it's kept as a generic object so that
+// the items not known by the operator can be passed through
+type Task map[string]interface{}
+
+// SubTaskStatus is the status of a subtask of the OpenAPI of dm-master
+type SubTaskStatus struct {
+	Name                string `json:"name"`
+	SourceName          string `json:"source_name"`
+	WorkerName          string `json:"worker_name"`
+	Stage               string `json:"stage"`
+	Unit                string `json:"unit"`
+	UnresolvedDDLLockID string `json:"unresolved_ddl_lock_id"`
+	ErrorMsg            string `json:"error_msg"`
+}
+
+type sourceListResp struct {
+	Total int      `json:"total"`
+	Data  []Source `json:"data"`
+}
+
+type taskListResp struct {
+	Total int    `json:"total"`
+	Data  []Task `json:"data"`
+}
+
+type subTaskStatusListResp struct {
+	Total int             `json:"total"`
+	Data  []SubTaskStatus `json:"data"`
+}
+
+type createSourceReq struct {
+	Source *Source `json:"source"`
+}
+
+type createTaskReq struct {
+	Task           Task     `json:"task"`
+	SourceNameList []string `json:"source_name_list,omitempty"`
+}
+
+type updateTaskReq struct {
+	Task Task `json:"task"`
+}
+
+// errorResp is the response of the OpenAPI of dm-master on failure
+type errorResp struct {
+	ErrorCode int    `json:"error_code"`
+	ErrorMsg  string `json:"error_msg"`
+}
+
+func (c *masterClient) ListSources() ([]Source, error) {
+	resp := &sourceListResp{}
+	if err := c.openAPIRequest(http.MethodGet, sourcesPrefix, nil, resp); err != nil {
+		return nil, err
+	}
+	return resp.Data, nil
+}
+
+func (c *masterClient) CreateSource(source *Source) error {
+	return c.openAPIRequest(http.MethodPost, sourcesPrefix, &createSourceReq{Source: source}, nil)
+}
+
+func (c *masterClient) UpdateSource(source *Source) error {
+	return c.openAPIRequest(http.MethodPut, sourcesPrefix+"/"+source.SourceName, &createSourceReq{Source: source}, nil)
+}
+
+func (c *masterClient) DeleteSource(name string) error {
+	return c.openAPIRequest(http.MethodDelete, sourcesPrefix+"/"+name+"?force=true", nil, nil)
+}
+
+func (c *masterClient) ListTasks() ([]Task, error) {
+	resp := &taskListResp{}
+	if err := c.openAPIRequest(http.MethodGet, tasksPrefix, nil, resp); err != nil {
+		return nil, err
+	}
+	return resp.Data, nil
+}
+
+func (c *masterClient) CreateTask(task Task, sources []string) error {
+	return c.openAPIRequest(http.MethodPost, tasksPrefix, &createTaskReq{Task: task, SourceNameList: sources}, nil)
+}
+
+func (c *masterClient) UpdateTask(task Task) error {
+	name, _ := task["name"].(string)
+	return c.openAPIRequest(http.MethodPut, tasksPrefix+"/"+name, &updateTaskReq{Task: task}, nil)
+}
+
+func (c *masterClient) StartTask(name string) error {
+	return c.openAPIRequest(http.MethodPost, tasksPrefix+"/"+name+"/start", struct{}{}, nil)
+}
+
+func (c *masterClient) StopTask(name string) error {
+	return c.openAPIRequest(http.MethodPost, tasksPrefix+"/"+name+"/stop", struct{}{}, nil)
+}
+
+func (c *masterClient) DeleteTask(name string) error {
+	return c.openAPIRequest(http.MethodDelete, tasksPrefix+"/"+name+"?force=true", nil, nil)
+}
+
+func (c *masterClient) GetTaskStatus(name string) ([]SubTaskStatus, error) {
+	resp := &subTaskStatusListResp{}
+	if err := c.openAPIRequest(http.MethodGet, tasksPrefix+"/"+name+"/status", nil, resp); err != nil {
+		return nil, err
+	}
+	return resp.Data, nil
+}
+
+// openAPIRequest sends a request to the OpenAPI of dm-master and decodes the response into result if it's not nil,
+// the request succeeds if the status code is 2xx
+func (c *masterClient) openAPIRequest(method, path string, payload, result interface{}) error {
+	var body io.Reader
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return fmt.Errorf("dm-master %s %s failed, marshal request error: %v", method, path, err)
+		}
+		body = bytes.NewReader(data)
+	}
+	req, err := http.NewRequest(method, fmt.Sprintf("%s/%s", c.url, path), body)
+	if err != nil {
+		return fmt.Errorf("dm-master %s %s failed, new request error: %v", method, path, err)
+	}
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("dm-master %s %s failed, request error: %v", method, path, err)
+	}
+	defer httputil.DeferClose(res.Body)
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		return fmt.Errorf("dm-master %s %s failed, read response error: %v", method, path, err)
+	}
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		errResp := &errorResp{}
+		if err := json.Unmarshal(data, errResp); err == nil && errResp.ErrorMsg != "" {
+			return fmt.Errorf("dm-master %s %s failed, error code: %d, error: %s", method, path, errResp.ErrorCode, errResp.ErrorMsg)
+		}
+		return fmt.Errorf("dm-master %s %s failed, status code: %d, response: %s", method, path, res.StatusCode, string(data))
+	}
+	if result == nil || len(data) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(data, result); err != nil {
+		return fmt.Errorf("dm-master %s %s failed, unmarshal response: %s, error: %v", method, path, string(data), err)
+	}
+	return nil
+}
@@ -32,6 +32,11 @@ var (
 		AdvancedStatefulSet: false,
 		AutoScaling:         false,
 		VolumeModifying:     false,
+		FleetStatus:         false,
+		ClusterClaim:        false,
+		DMTask:              false,
+		SecretPropagation:   false,
+		VolumeReplacing:     false,
 	}
 	// DefaultFeatureGate is a shared global FeatureGate.
 	DefaultFeatureGate FeatureGate = NewDefaultFeatureGate()
@@ -49,6 +54,23 @@ const (
 
 	// VolumeModifying controls whether allow to modify volumes
 	VolumeModifying string = "VolumeModifying"
+
+	// FleetStatus controls whether to aggregate the status of all TidbClusters into a TidbClusterFleet
+	FleetStatus string = "FleetStatus"
+
+	// ClusterClaim controls whether to render TidbClusters of TidbClusterClaims from TidbClusterTemplates
+	ClusterClaim string = "ClusterClaim"
+
+	// DMTask controls whether to submit the DMTasks to dm-master of the DMClusters
+	DMTask string = "DMTask"
+
+	// SecretPropagation controls whether to copy the secrets granted by TidbClusterSecretGrants into the namespaces
+	// of the TidbClusters joining the granting clusters
+	SecretPropagation string = "SecretPropagation"
+
+	// VolumeReplacing controls whether to replace the pods and volumes of PD and TiKV one by one if their storage
+	// classes or sizes are changed in the way which can't be done by modifying the volumes
+	VolumeReplacing string = "VolumeReplacing"
 )
 
 type FeatureGate interface {
@@ -126,6 +148,12 @@ func (f *featureGate) SetFromMap(m map[string]bool) {
 	klog.V(1).Infof("feature gates: %v", f.enabledFeatures)
 }
 
+// IsKnown returns true if the key is a feature known by the operator.
+func IsKnown(key string) bool {
+	_, ok := defaultFeatures[key]
+	return ok
+}
+
 func NewFeatureGate() FeatureGate {
 	f := &featureGate{
 		enabledFeatures: make(map[string]bool),
@@ -0,0 +1,138 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package healthprobe
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// Port is the port that the probe serves the result on
+	Port = 10263
+	// ResultPath is the path that the probe serves the result on
+	ResultPath = "/result"
+	// Table is the table written and read by the probe
+	Table = "health_probe"
+)
+
+// Prober writes a row in a transaction and reads it back periodically, the result of the
+// last probe is served in JSON as a v1alpha1.HealthProbeStatus.
+type Prober struct {
+	db       *sql.DB
+	database string
+	id       string
+	timeout  time.Duration
+
+	lock   sync.RWMutex
+	result *v1alpha1.HealthProbeStatus
+}
+
+// NewProber returns a Prober writing the row identified by id into the probe table of the database
+func NewProber(db *sql.DB, database, id string, timeout time.Duration) *Prober {
+	return &Prober{
+		db:       db,
+		database: database,
+		id:       id,
+		timeout:  timeout,
+	}
+}
+
+// Run probes every interval until stopCh is closed
+func (p *Prober) Run(interval time.Duration, stopCh <-chan struct{}) {
+	wait.Until(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), p.timeout)
+		defer cancel()
+		start := time.Now()
+		err := p.probe(ctx)
+		p.record(start, time.Since(start), err)
+	}, interval, stopCh)
+}
+
+func (p *Prober) probe(ctx context.Context) error {
+	if _, err := p.db.ExecContext(ctx, fmt.Sprintf("CREATE DATABASE IF NOT EXISTS `%s`", p.database)); err != nil {
+		return fmt.Errorf("create database %s failed, err: %v", p.database, err)
+	}
+	table := fmt.Sprintf("`%s`.`%s`", p.database, Table)
+	if _, err := p.db.ExecContext(ctx, fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (id VARCHAR(255) PRIMARY KEY, ts BIGINT NOT NULL)", table)); err != nil {
+		return fmt.Errorf("create table %s failed, err: %v", table, err)
+	}
+
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction failed, err: %v", err)
+	}
+	defer tx.Rollback() // nolint: errcheck
+
+	ts := time.Now().UnixNano()
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf("REPLACE INTO %s (id, ts) VALUES (?, ?)", table), p.id, ts); err != nil { // nolint: gosec
+		return fmt.Errorf("write probe row failed, err: %v", err)
+	}
+	var got int64
+	if err := tx.QueryRowContext(ctx, fmt.Sprintf("SELECT ts FROM %s WHERE id = ?", table), p.id).Scan(&got); err != nil { // nolint: gosec
+		return fmt.Errorf("read probe row failed, err: %v", err)
+	}
+	if got != ts {
+		return fmt.Errorf("read probe row failed, expect ts %d, got %d", ts, got)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction failed, err: %v", err)
+	}
+	return nil
+}
+
+func (p *Prober) record(start time.Time, latency time.Duration, err error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	result := &v1alpha1.HealthProbeStatus{
+		Success:             err == nil,
+		LatencyMilliseconds: latency.Milliseconds(),
+		LastProbeTime:       metav1.NewTime(start),
+	}
+	if err != nil {
+		klog.Warningf("health probe failed, err: %v", err)
+		result.Message = err.Error()
+		result.ConsecutiveFailures = 1
+		if p.result != nil {
+			result.ConsecutiveFailures += p.result.ConsecutiveFailures
+		}
+	}
+	p.result = result
+}
+
+// ServeHTTP serves the result of the last probe, it responds 404 if no probe has finished
+func (p *Prober) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	if p.result == nil {
+		http.Error(w, "no probe has finished", http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(p.result); err != nil {
+		klog.Errorf("failed to encode the health probe result, err: %v", err)
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package healthprobe
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+)
+
+func TestProberServeHTTP(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	p := NewProber(nil, "tidb_operator", "probe", time.Second)
+	get := func() (int, *v1alpha1.HealthProbeStatus) {
+		rec := httptest.NewRecorder()
+		p.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, ResultPath, nil))
+		if rec.Code != http.StatusOK {
+			return rec.Code, nil
+		}
+		result := &v1alpha1.HealthProbeStatus{}
+		g.Expect(json.Unmarshal(rec.Body.Bytes(), result)).To(Succeed())
+		return rec.Code, result
+	}
+
+	code, _ := get()
+	g.Expect(code).To(Equal(http.StatusNotFound))
+
+	p.record(time.Now(), 20*time.Millisecond, errors.New("connection refused"))
+	p.record(time.Now(), 30*time.Millisecond, errors.New("connection refused"))
+	code, result := get()
+	g.Expect(code).To(Equal(http.StatusOK))
+	g.Expect(result.Success).To(BeFalse())
+	g.Expect(result.Message).To(Equal("connection refused"))
+	g.Expect(result.ConsecutiveFailures).To(Equal(int32(2)))
+
+	p.record(time.Now(), 10*time.Millisecond, nil)
+	_, result = get()
+	g.Expect(result.Success).To(BeTrue())
+	g.Expect(result.LatencyMilliseconds).To(Equal(int64(10)))
+	g.Expect(result.ConsecutiveFailures).To(BeZero())
+}
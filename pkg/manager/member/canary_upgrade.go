@@ -0,0 +1,226 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	apps "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	podutil "k8s.io/kubernetes/pkg/api/v1/pod"
+)
+
+const (
+	canaryUpgradeRolledBackReason = "CanaryUpgradeRolledBack"
+	canaryUpgradePausedReason     = "CanaryUpgradePaused"
+)
+
+// canaryUpgrader upgrades the pods of a component in steps, the pods upgraded in each step are soaked
+// for a while before the partition is lowered further, and they're rolled back on regression.
+type canaryUpgrader struct {
+	deps       *controller.Dependencies
+	tc         *v1alpha1.TidbCluster
+	memberType v1alpha1.MemberType
+	spec       *v1alpha1.CanaryUpgrade
+	status     **v1alpha1.CanaryUpgradeStatus
+	// setStatus is the status of the StatefulSet of the component
+	setStatus *apps.StatefulSetStatus
+	podName   func(ordinal int32) string
+	// healthy returns whether the member of the upgraded pod is healthy
+	healthy func(ordinal int32) bool
+	// regression returns the regression found in the metrics of the upgraded pods, it's empty if there is none
+	regression func(ordinals []int32) (string, error)
+}
+
+// holdCanaryUpgrade keeps the StatefulSet unchanged if the pod template was rolled back or paused by the canary
+// upgrade, until the pod template changes again. It returns whether the StatefulSet is held.
+func holdCanaryUpgrade(canary *v1alpha1.CanaryUpgrade, status **v1alpha1.CanaryUpgradeStatus, oldSet, newSet *apps.StatefulSet) (bool, error) {
+	st := *status
+	if canary == nil || st == nil || (st.Phase != v1alpha1.CanaryUpgradeRolledBack && st.Phase != v1alpha1.CanaryUpgradePaused) {
+		return false, nil
+	}
+	hash, err := podTemplateHash(newSet)
+	if err != nil {
+		return false, err
+	}
+	if hash != st.TemplateHash {
+		// the pod template is changed, start over
+		*status = nil
+		return false, nil
+	}
+	klog.Infof("%s/%s's pod template was %s by the canary upgrade: %s, change the pod template to upgrade again",
+		newSet.GetNamespace(), newSet.GetName(), st.Phase, st.Message)
+	newSet.Spec.Template = *oldSet.Spec.Template.DeepCopy()
+	newSet.Spec.UpdateStrategy = oldSet.Spec.UpdateStrategy
+	return true, nil
+}
+
+// evaluate checks the upgraded pods for regression, the pods are rolled back if there is any. It returns
+// whether the upgraded pods are rolled back or the upgrade is paused.
+func (c *canaryUpgrader) evaluate(oldSet, newSet *apps.StatefulSet, podOrdinals []int32) (bool, error) {
+	ns := c.tc.GetNamespace()
+	st := *c.status
+	if st == nil || st.Revision != c.setStatus.UpdateRevision {
+		hash, err := podTemplateHash(newSet)
+		if err != nil {
+			return false, err
+		}
+		// the partition is 0 before the first soak period, as it's always positive in the soak periods
+		*c.status = &v1alpha1.CanaryUpgradeStatus{
+			Phase:        v1alpha1.CanaryUpgradeUpgrading,
+			Revision:     c.setStatus.UpdateRevision,
+			TemplateHash: hash,
+		}
+		return false, nil
+	}
+
+	var upgraded []int32
+	reason := ""
+	for _, i := range podOrdinals {
+		pod, err := c.deps.PodLister.Pods(ns).Get(c.podName(i))
+		if errors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return false, fmt.Errorf("canaryUpgrader.evaluate: failed to get pod %s/%s, error: %s", ns, c.podName(i), err)
+		}
+		if pod.Labels[apps.ControllerRevisionHashLabelKey] != st.Revision {
+			continue
+		}
+		upgraded = append(upgraded, i)
+		if restarts := podRestarts(pod); restarts > c.spec.GetMaxRestarts() {
+			reason = fmt.Sprintf("upgraded pod %s restarted %d times", pod.Name, restarts)
+			break
+		}
+		// the upgraded pods are all healthy when the soak period starts
+		if st.Phase == v1alpha1.CanaryUpgradeSoaking && (!podutil.IsPodReady(pod) || !c.healthy(i)) {
+			reason = fmt.Sprintf("upgraded pod %s is not healthy", pod.Name)
+			break
+		}
+	}
+	if reason == "" && st.Phase == v1alpha1.CanaryUpgradeSoaking && c.regression != nil && len(upgraded) > 0 {
+		var err error
+		if reason, err = c.regression(upgraded); err != nil {
+			return false, err
+		}
+	}
+	if reason == "" {
+		return false, nil
+	}
+	return true, c.rollback(oldSet, newSet, reason)
+}
+
+// soak starts the soak period after every step of the pods are upgraded, and returns a requeue error until
+// the soak period ends. upgraded is the number of the pods upgraded, which are all healthy.
+func (c *canaryUpgrader) soak(oldSet *apps.StatefulSet, upgraded int) error {
+	st := *c.status
+	if upgraded == 0 || upgraded%int(c.spec.GetStep()) != 0 {
+		return nil
+	}
+	ns := c.tc.GetNamespace()
+	tcName := c.tc.GetName()
+	partition := *oldSet.Spec.UpdateStrategy.RollingUpdate.Partition
+	if st.Partition != partition {
+		now := metav1.Now()
+		st.Phase = v1alpha1.CanaryUpgradeSoaking
+		st.Partition = partition
+		st.SoakStartTime = &now
+		klog.Infof("tidbcluster: [%s/%s]'s %d upgraded %s pods start soaking", ns, tcName, upgraded, c.memberType)
+		return controller.RequeueErrorf("tidbcluster: [%s/%s]'s %d upgraded %s pods are soaking", ns, tcName, upgraded, c.memberType)
+	}
+	if st.Phase != v1alpha1.CanaryUpgradeSoaking {
+		// the soak period of the partition is passed
+		return nil
+	}
+	if remaining := st.SoakStartTime.Add(c.spec.GetSoakDuration()).Sub(time.Now()); remaining > 0 {
+		return controller.RequeueErrorf("tidbcluster: [%s/%s]'s %d upgraded %s pods are soaking, %s remaining",
+			ns, tcName, upgraded, c.memberType, remaining.Round(time.Second))
+	}
+	klog.Infof("tidbcluster: [%s/%s]'s %d upgraded %s pods are soaked without regression, advance the upgrade", ns, tcName, upgraded, c.memberType)
+	st.Phase = v1alpha1.CanaryUpgradeUpgrading
+	st.SoakStartTime = nil
+	return nil
+}
+
+// rollback rolls the upgraded pods back to the current revision of the StatefulSet, or pauses the
+// upgrade if the rollback is disabled
+func (c *canaryUpgrader) rollback(oldSet, newSet *apps.StatefulSet, reason string) error {
+	ns := c.tc.GetNamespace()
+	tcName := c.tc.GetName()
+	st := *c.status
+	st.Message = reason
+	st.SoakStartTime = nil
+	newSet.Spec.UpdateStrategy = oldSet.Spec.UpdateStrategy
+
+	if c.spec.DisableRollback {
+		st.Phase = v1alpha1.CanaryUpgradePaused
+		klog.Warningf("tidbcluster: [%s/%s]'s %s upgrade is paused on regression: %s", ns, tcName, c.memberType, reason)
+		c.deps.Recorder.Eventf(c.tc, corev1.EventTypeWarning, canaryUpgradePausedReason, "%s upgrade is paused: %s", c.memberType, reason)
+		return nil
+	}
+
+	template, err := c.revisionTemplate(c.setStatus.CurrentRevision)
+	if err != nil {
+		return err
+	}
+	newSet.Spec.Template = *template
+	st.Phase = v1alpha1.CanaryUpgradeRolledBack
+	klog.Warningf("tidbcluster: [%s/%s]'s %s upgrade is rolled back to revision %s on regression: %s", ns, tcName, c.memberType, c.setStatus.CurrentRevision, reason)
+	c.deps.Recorder.Eventf(c.tc, corev1.EventTypeWarning, canaryUpgradeRolledBackReason, "%s upgrade is rolled back to revision %s: %s", c.memberType, c.setStatus.CurrentRevision, reason)
+	return nil
+}
+
+// revisionTemplate returns the pod template of the StatefulSet revision, the data of the ControllerRevision
+// of a StatefulSet is the patch replacing the pod template
+func (c *canaryUpgrader) revisionTemplate(name string) (*corev1.PodTemplateSpec, error) {
+	ns := c.tc.GetNamespace()
+	revision, err := c.deps.KubeClientset.AppsV1().ControllerRevisions(ns).Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("canaryUpgrader.rollback: failed to get controller revision %s/%s, error: %s", ns, name, err)
+	}
+	patch := struct {
+		Spec struct {
+			Template corev1.PodTemplateSpec `json:"template"`
+		} `json:"spec"`
+	}{}
+	if err := json.Unmarshal(revision.Data.Raw, &patch); err != nil {
+		return nil, fmt.Errorf("canaryUpgrader.rollback: failed to unmarshal controller revision %s/%s, error: %s", ns, name, err)
+	}
+	return &patch.Spec.Template, nil
+}
+
+// podTemplateHash returns the hash of the pod spec of the StatefulSet
+func podTemplateHash(set *apps.StatefulSet) (string, error) {
+	b, err := json.Marshal(set.Spec.Template.Spec)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal the pod spec of %s/%s: %v", set.GetNamespace(), set.GetName(), err)
+	}
+	return v1alpha1.HashContents(b), nil
+}
+
+func podRestarts(pod *corev1.Pod) int32 {
+	restarts := int32(0)
+	for _, status := range pod.Status.ContainerStatuses {
+		restarts += status.RestartCount
+	}
+	return restarts
+}
@@ -18,11 +18,14 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
 	"github.com/pingcap/tidb-operator/pkg/controller"
+	mngerutils "github.com/pingcap/tidb-operator/pkg/manager/utils"
 	"github.com/pingcap/tidb-operator/pkg/util"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -31,7 +34,9 @@ import (
 
 // StoreAccess contains the common set of functions to access the properties of TiKV and TiFlash types
 type StoreAccess interface {
-	GetFailoverPeriod(cliConfig *controller.CLIConfig) time.Duration
+	GetFailoverPeriod(tc *v1alpha1.TidbCluster, cliConfig *controller.CLIConfig) time.Duration
+	AutoRecoverFailover(tc *v1alpha1.TidbCluster) bool
+	GetNodeFailureGracePeriod(tc *v1alpha1.TidbCluster) time.Duration
 	GetMemberType() v1alpha1.MemberType
 	GetMaxFailoverCount(tc *v1alpha1.TidbCluster) *int32
 	GetStores(tc *v1alpha1.TidbCluster) map[string]v1alpha1.TiKVStore
@@ -65,6 +70,13 @@ func (sf *commonStoreFailover) Failover(tc *v1alpha1.TidbCluster) error {
 	// If HostDown is set and Store is Down then delete Store after some gap from the time of pod restart
 	// If HostDown is set and Store has been removed or become Tombstone then remove PVC and set StoreDeleted
 
+	if err := sf.forceDeletePodOnFailedNode(tc); err != nil {
+		if controller.IsIgnoreError(err) {
+			return nil
+		}
+		return err
+	}
+
 	if err := sf.failureRecovery.RestartPodOnHostDown(tc); err != nil {
 		if controller.IsIgnoreError(err) {
 			return nil
@@ -117,7 +129,7 @@ func (sf *commonStoreFailover) tryMarkAStoreAsFailure(tc *v1alpha1.TidbCluster)
 			// (before it enters into Offline/Tombstone state)
 			continue
 		}
-		deadline := store.LastTransitionTime.Add(sf.storeAccess.GetFailoverPeriod(sf.deps.CLIConfig))
+		deadline := store.LastTransitionTime.Add(sf.storeAccess.GetFailoverPeriod(tc, sf.deps.CLIConfig))
 		exist := false
 		for _, failureStore := range sf.storeAccess.GetFailureStores(tc) {
 			if failureStore.PodName == podName {
@@ -159,6 +171,68 @@ func (sf *commonStoreFailover) tryMarkAStoreAsFailure(tc *v1alpha1.TidbCluster)
 	return nil
 }
 
+// forceDeletePodOnFailedNode force deletes the pod of a store that is stuck in Terminating because the K8s node
+// hosting it has been NotReady for longer than the node failure grace period. The kubelet of a failed node can
+// not confirm the deletion of the pod, so the StatefulSet controller would never recreate it and the failover
+// could not proceed without a manual force deletion.
+//
+// To protect the data of the store, the pod is only force deleted if the store is not Up, i.e. PD has not
+// received heartbeats from it, and the store has stayed in that state for the whole grace period as well.
+// At most one pod is force deleted in a sync round.
+func (sf *commonStoreFailover) forceDeletePodOnFailedNode(tc *v1alpha1.TidbCluster) error {
+	gracePeriod := sf.storeAccess.GetNodeFailureGracePeriod(tc)
+	if gracePeriod <= 0 || sf.deps.NodeLister == nil {
+		return nil
+	}
+	ns := tc.GetNamespace()
+	tcName := tc.GetName()
+	memberType := sf.storeAccess.GetMemberType()
+
+	for _, store := range sf.storeAccess.GetStores(tc) {
+		if store.State == v1alpha1.TiKVStateUp || store.PodName == "" {
+			continue
+		}
+		if store.LastTransitionTime.IsZero() || time.Now().Before(store.LastTransitionTime.Add(gracePeriod)) {
+			continue
+		}
+		pod, err := sf.deps.PodLister.Pods(ns).Get(store.PodName)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return fmt.Errorf("%s failover [forceDeletePodOnFailedNode]: failed to get pod %s for tc %s/%s, error: %s", memberType, store.PodName, ns, tcName, err)
+		}
+		if pod.DeletionTimestamp == nil || pod.Spec.NodeName == "" {
+			continue
+		}
+		node, err := sf.deps.NodeLister.Get(pod.Spec.NodeName)
+		if err != nil {
+			// the pods on a deleted node are cleaned up by the pod garbage collector of K8s
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return fmt.Errorf("%s failover [forceDeletePodOnFailedNode]: failed to get node %s for tc %s/%s, error: %s", memberType, pod.Spec.NodeName, ns, tcName, err)
+		}
+		readyCond := getNodeReadyCondition(node.Status)
+		if readyCond == nil || readyCond.Status == corev1.ConditionTrue {
+			continue
+		}
+		if time.Now().Before(readyCond.LastTransitionTime.Add(gracePeriod)) {
+			continue
+		}
+
+		mngerutils.RecordPodRestart(tc, pod, label.RestartReasonFailover, "node-not-ready")
+		if err := sf.deps.PodControl.ForceDeletePod(tc, pod); err != nil {
+			return err
+		}
+		msg := fmt.Sprintf("%s pod %s/%s stuck in Terminating on NotReady node %s is force deleted", memberType, ns, pod.Name, pod.Spec.NodeName)
+		klog.Info(msg)
+		sf.deps.Recorder.Event(tc, corev1.EventTypeWarning, recoveryEventReason, msg)
+		return controller.IgnoreErrorf(msg)
+	}
+	return nil
+}
+
 // invokeDeleteFailureStore invokes delete of a failure store. A time gap is given after a pod restart to allow the pod to
 // be created properly and the store to come up, for ex., in cases like EBS volumes.
 func (sf *commonStoreFailover) invokeDeleteFailureStore(tc *v1alpha1.TidbCluster, failureStore v1alpha1.TiKVFailureStore) error {
@@ -175,6 +249,9 @@ func (sf *commonStoreFailover) invokeDeleteFailureStore(tc *v1alpha1.TidbCluster
 			if parseErr != nil {
 				return parseErr
 			}
+			if err := fenceStore(sf.deps, tc, storeUintId, failureStore.PodName, v1alpha1.TiKVStateDown); err != nil {
+				return err
+			}
 			pdCli := controller.GetPDClient(sf.deps.PDControl, tc)
 			if deleteErr := pdCli.DeleteStore(storeUintId); deleteErr != nil {
 				return deleteErr
@@ -220,7 +297,21 @@ func (sf *commonStoreFailover) RemoveUndesiredFailures(tc *v1alpha1.TidbCluster)
 			// slots feature. We should remove the record of undesired pods,
 			// otherwise an extra replacement pod will be created.
 			delete(sf.storeAccess.GetFailureStores(tc), key)
+			continue
 		}
+		if sf.storeAccess.AutoRecoverFailover(tc) && !failureStore.HostDown {
+			// the original store is up again, so the failover replica created for it is not needed any more,
+			// the failure stores on the down hosts are recovered after their stores are recreated
+			if store, ok := sf.storeAccess.GetStore(tc, failureStore.StoreID); ok && store.State == v1alpha1.TiKVStateUp {
+				delete(sf.storeAccess.GetFailureStores(tc), key)
+				msg := fmt.Sprintf("%s store '%s' of pod %s is up again, remove it from the failure stores", sf.storeAccess.GetMemberType(), failureStore.StoreID, failureStore.PodName)
+				klog.Infof("%s failover: %s in cluster %s/%s", sf.storeAccess.GetMemberType(), msg, tc.GetNamespace(), tc.GetName())
+				sf.deps.Recorder.Event(tc, corev1.EventTypeNormal, recoveryEventReason, msg)
+			}
+		}
+	}
+	if sf.storeAccess.AutoRecoverFailover(tc) && len(sf.storeAccess.GetFailureStores(tc)) == 0 {
+		sf.storeAccess.ClearFailStatus(tc)
 	}
 }
 
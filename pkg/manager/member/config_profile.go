@@ -0,0 +1,147 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"github.com/Masterminds/semver"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/apis/util/config"
+)
+
+// configProfileDefaults is the tuned default config of a component since a minor version, it's layered on the
+// defaults of the earlier minor versions of the same profile.
+type configProfileDefaults struct {
+	major  int64
+	minor  int64
+	config map[string]interface{}
+}
+
+// configProfiles is the tuned default config of each profile and component, the defaults of a component are
+// ordered by the minor version. The components of the versions earlier than the first minor version aren't tuned.
+var configProfiles = map[v1alpha1.ConfigProfile]map[v1alpha1.MemberType][]configProfileDefaults{
+	v1alpha1.ConfigProfileOLTP: {
+		v1alpha1.PDMemberType: {
+			{major: 6, minor: 5, config: map[string]interface{}{
+				"schedule.leader-schedule-limit":     int64(8),
+				"schedule.region-schedule-limit":     int64(4096),
+				"schedule.hot-region-schedule-limit": int64(8),
+			}},
+		},
+		v1alpha1.TiKVMemberType: {
+			{major: 6, minor: 5, config: map[string]interface{}{
+				"server.grpc-concurrency":            int64(8),
+				"raftstore.store-pool-size":          int64(4),
+				"raftstore.apply-pool-size":          int64(4),
+				"storage.scheduler-worker-pool-size": int64(8),
+				"rocksdb.max-background-jobs":        int64(8),
+			}},
+		},
+		v1alpha1.TiDBMemberType: {
+			{major: 6, minor: 5, config: map[string]interface{}{
+				"token-limit":                       int64(2000),
+				"tikv-client.grpc-connection-count": int64(8),
+				"tikv-client.max-batch-wait-time":   int64(2000000),
+			}},
+			{major: 7, minor: 1, config: map[string]interface{}{
+				"performance.lite-init-stats":         true,
+				"performance.concurrently-init-stats": true,
+			}},
+		},
+	},
+	v1alpha1.ConfigProfileHTAP: {
+		v1alpha1.PDMemberType: {
+			{major: 6, minor: 5, config: map[string]interface{}{
+				"schedule.leader-schedule-limit":     int64(4),
+				"schedule.region-schedule-limit":     int64(2048),
+				"replication.enable-placement-rules": true,
+			}},
+		},
+		v1alpha1.TiKVMemberType: {
+			{major: 6, minor: 5, config: map[string]interface{}{
+				"server.grpc-concurrency":            int64(8),
+				"raftstore.apply-pool-size":          int64(4),
+				"readpool.unified.max-thread-count":  int64(16),
+				"storage.scheduler-worker-pool-size": int64(8),
+			}},
+		},
+		v1alpha1.TiDBMemberType: {
+			{major: 6, minor: 5, config: map[string]interface{}{
+				"token-limit":                        int64(1000),
+				"tikv-client.copr-cache.capacity-mb": float64(2000),
+				"performance.stats-load-concurrency": int64(8),
+			}},
+			{major: 7, minor: 1, config: map[string]interface{}{
+				"performance.lite-init-stats":         true,
+				"performance.concurrently-init-stats": true,
+			}},
+		},
+	},
+	v1alpha1.ConfigProfileLight: {
+		v1alpha1.PDMemberType: {
+			{major: 6, minor: 5, config: map[string]interface{}{
+				"schedule.region-schedule-limit":  int64(512),
+				"schedule.replica-schedule-limit": int64(16),
+			}},
+		},
+		v1alpha1.TiKVMemberType: {
+			{major: 6, minor: 5, config: map[string]interface{}{
+				"server.grpc-concurrency":            int64(2),
+				"raftstore.store-pool-size":          int64(1),
+				"raftstore.apply-pool-size":          int64(1),
+				"readpool.unified.max-thread-count":  int64(2),
+				"storage.scheduler-worker-pool-size": int64(2),
+				"storage.block-cache.capacity":       "1GiB",
+				"rocksdb.max-background-jobs":        int64(2),
+			}},
+		},
+		v1alpha1.TiDBMemberType: {
+			{major: 6, minor: 5, config: map[string]interface{}{
+				"token-limit":                        int64(200),
+				"tikv-client.grpc-connection-count":  int64(2),
+				"tikv-client.copr-cache.capacity-mb": float64(100),
+				"performance.stats-load-concurrency": int64(2),
+			}},
+			{major: 7, minor: 1, config: map[string]interface{}{
+				"performance.lite-init-stats": true,
+			}},
+		},
+	},
+}
+
+// configProfileDefaultsFor returns the tuned default config of the profile for the component of the version.
+// The defaults of all the minor versions are used if the version isn't semantic, e.g. latest or nightly.
+func configProfileDefaultsFor(profile v1alpha1.ConfigProfile, memberType v1alpha1.MemberType, version string) map[string]interface{} {
+	v, _ := semver.NewVersion(version)
+	defaults := map[string]interface{}{}
+	for _, d := range configProfiles[profile][memberType] {
+		if v != nil && (v.Major() < d.major || v.Major() == d.major && v.Minor() < d.minor) {
+			break
+		}
+		for key, value := range d.config {
+			defaults[key] = value
+		}
+	}
+	return defaults
+}
+
+// applyConfigProfile layers the tuned default config of the config profile of the cluster under the config of
+// the component, the keys already set in the config are kept.
+func applyConfigProfile(cfg *config.GenericConfig, tc *v1alpha1.TidbCluster, memberType v1alpha1.MemberType, version string) {
+	if tc.Spec.ConfigProfile == "" || cfg == nil {
+		return
+	}
+	for key, value := range configProfileDefaultsFor(tc.Spec.ConfigProfile, memberType, version) {
+		cfg.SetIfNil(key, value)
+	}
+}
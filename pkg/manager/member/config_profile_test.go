@@ -0,0 +1,66 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+)
+
+func TestConfigProfileDefaultsFor(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// the components earlier than the first tuned minor version aren't tuned
+	g.Expect(configProfileDefaultsFor(v1alpha1.ConfigProfileOLTP, v1alpha1.TiDBMemberType, "v6.1.7")).To(BeEmpty())
+	g.Expect(configProfileDefaultsFor("", v1alpha1.TiDBMemberType, "v7.5.0")).To(BeEmpty())
+
+	defaults := configProfileDefaultsFor(v1alpha1.ConfigProfileOLTP, v1alpha1.TiDBMemberType, "v6.5.3")
+	g.Expect(defaults).To(HaveKeyWithValue("token-limit", int64(2000)))
+	g.Expect(defaults).NotTo(HaveKey("performance.lite-init-stats"))
+
+	// the defaults of a minor version are layered on the earlier ones, and the pre-releases of the minor
+	// version are tuned the same
+	for _, version := range []string{"v7.1.0-alpha", "v7.5.1", "latest"} {
+		defaults = configProfileDefaultsFor(v1alpha1.ConfigProfileOLTP, v1alpha1.TiDBMemberType, version)
+		g.Expect(defaults).To(HaveKeyWithValue("token-limit", int64(2000)), version)
+		g.Expect(defaults).To(HaveKeyWithValue("performance.lite-init-stats", true), version)
+	}
+}
+
+func TestApplyConfigProfile(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbClusterForPD()
+	tc.Spec.PD.Config = v1alpha1.NewPDConfig()
+	tc.Spec.PD.Config.Set("schedule.leader-schedule-limit", int64(16))
+
+	cfg := tc.Spec.PD.Config.DeepCopy()
+	applyConfigProfile(cfg.GenericConfig, tc, v1alpha1.PDMemberType, "v7.1.0")
+	g.Expect(cfg.Get("schedule.region-schedule-limit")).To(BeNil())
+
+	// the keys set by users take precedence over the profile
+	tc.Spec.ConfigProfile = v1alpha1.ConfigProfileOLTP
+	applyConfigProfile(cfg.GenericConfig, tc, v1alpha1.PDMemberType, "v7.1.0")
+	g.Expect(cfg.Get("schedule.leader-schedule-limit").MustInt()).To(Equal(int64(16)))
+	g.Expect(cfg.Get("schedule.region-schedule-limit").MustInt()).To(Equal(int64(4096)))
+	g.Expect(tc.Spec.PD.Config.Get("schedule.region-schedule-limit")).To(BeNil())
+
+	tc.Spec.PD.Image = "pingcap/pd:v7.1.0"
+	cm, err := getPDConfigMap(tc)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(cm.Data["config-file"]).To(ContainSubstring("leader-schedule-limit = 16"))
+	g.Expect(cm.Data["config-file"]).To(ContainSubstring("region-schedule-limit = 4096"))
+}
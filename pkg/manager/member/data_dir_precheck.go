@@ -0,0 +1,158 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// dataDirPreCheckScript checks the data directory mounted at dir, the reason of a failed check is written to
+// the termination message of the init container so that it's shown in the pod status.
+const dataDirPreCheckScript = `dir="%s"
+fail() {
+  echo "$1" >&2
+  echo "$1" > /dev/termination-log
+  exit 1
+}
+probe="${dir}/.datadir-precheck"
+if ! (touch "${probe}" && rm -f "${probe}") 2>/dev/null; then
+  fail "data directory ${dir} is not writable by uid $(id -u) gid $(id -g): owner $(stat -c %%u:%%g "${dir}"), mode $(stat -c %%a "${dir}")"
+fi
+fstype=$(awk -v dir="${dir}" '($2 == "/" || dir == $2 || index(dir, $2 "/") == 1) && length($2) >= len { len = length($2); t = $3 } END { print t }' /proc/mounts)
+echo "data directory ${dir} is on ${fstype}"
+%s%s`
+
+const dataDirFSTypeCheckScript = `case "${fstype}" in
+  %s) ;;
+  *) fail "filesystem type ${fstype} of data directory ${dir} is not one of %s" ;;
+esac
+`
+
+const dataDirAvailableCheckScript = `avail=$(df -Pk "${dir}" | awk 'NR == 2 { print $4 }')
+if [ "${avail}" -lt %d ]; then
+  fail "available space ${avail}KiB of data directory ${dir} is less than %dKiB"
+fi
+`
+
+// buildDataDirPreCheckContainer returns the init container which checks the data directory mounted by dataVol,
+// nil is returned if the check is not enabled.
+func buildDataDirPreCheckContainer(tc *v1alpha1.TidbCluster, check *v1alpha1.DataDirPreCheck, dataVol corev1.VolumeMount, resources corev1.ResourceRequirements) *corev1.Container {
+	if check == nil {
+		return nil
+	}
+	fsTypeCheck := ""
+	if len(check.FSTypes) > 0 {
+		fsTypeCheck = fmt.Sprintf(dataDirFSTypeCheckScript, strings.Join(check.FSTypes, "|"), strings.Join(check.FSTypes, ", "))
+	}
+	availableCheck := ""
+	if check.MinAvailable != nil && !check.MinAvailable.IsZero() {
+		minKiB := (check.MinAvailable.Value() + 1023) / 1024
+		availableCheck = fmt.Sprintf(dataDirAvailableCheckScript, minKiB, minKiB)
+	}
+	return &corev1.Container{
+		Name:            v1alpha1.ContainerDataDirPreCheck.String(),
+		Image:           tc.HelperImage(),
+		ImagePullPolicy: tc.HelperImagePullPolicy(),
+		Command: []string{
+			"sh",
+			"-c",
+			fmt.Sprintf(dataDirPreCheckScript, dataVol.MountPath, fsTypeCheck, availableCheck),
+		},
+		VolumeMounts: []corev1.VolumeMount{dataVol},
+		// the same as the sysctl init container, the resources are equal to the app container so that the
+		// effective requests of the pod are not changed
+		Resources: resources,
+	}
+}
+
+// syncDataDirPreCheckStatus reports the pods of the component whose data directory pre-check fails by the
+// DataDirPreCheckFailed condition of the component status and by events.
+func syncDataDirPreCheckStatus(deps *controller.Dependencies, tc *v1alpha1.TidbCluster, memberType v1alpha1.MemberType, check *v1alpha1.DataDirPreCheck) error {
+	status := tc.ComponentStatus(memberType)
+	if status == nil {
+		return nil
+	}
+	if check == nil {
+		status.RemoveCondition(v1alpha1.ConditionTypeDataDirPreCheckFailed)
+		return nil
+	}
+
+	ns := tc.GetNamespace()
+	tcName := tc.GetName()
+	selector, err := label.New().Instance(tc.GetInstanceName()).Component(memberType.String()).Selector()
+	if err != nil {
+		return err
+	}
+	pods, err := deps.PodLister.Pods(ns).List(selector)
+	if err != nil {
+		return fmt.Errorf("list pods of %s for cluster %s/%s failed, error: %v", memberType, ns, tcName, err)
+	}
+	var failures []string
+	for _, pod := range pods {
+		if reason, failed := dataDirPreCheckFailure(pod); failed {
+			failures = append(failures, fmt.Sprintf("%s: %s", pod.Name, reason))
+		}
+	}
+	sort.Strings(failures)
+
+	newCondition := metav1.Condition{
+		Type:               v1alpha1.ConditionTypeDataDirPreCheckFailed,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: tc.Generation,
+		Reason:             v1alpha1.DataDirPreCheckReasonPassed,
+	}
+	if len(failures) > 0 {
+		msg := fmt.Sprintf("the data directory pre-check of %s fails: %s", memberType, strings.Join(failures, "; "))
+		if cond := meta.FindStatusCondition(status.GetConditions(), v1alpha1.ConditionTypeDataDirPreCheckFailed); cond == nil || cond.Message != msg {
+			deps.Recorder.Event(tc, corev1.EventTypeWarning, "DataDirPreCheckFailed", msg)
+		}
+		newCondition.Status = metav1.ConditionTrue
+		newCondition.Reason = v1alpha1.DataDirPreCheckReasonFailed
+		newCondition.Message = msg
+	}
+	status.SetCondition(newCondition)
+	return nil
+}
+
+// dataDirPreCheckFailure returns the reason of the failed data directory pre-check of the pod, the last
+// termination is used when the init container is restarting
+func dataDirPreCheckFailure(pod *corev1.Pod) (string, bool) {
+	for _, s := range pod.Status.InitContainerStatuses {
+		if s.Name != v1alpha1.ContainerDataDirPreCheck.String() {
+			continue
+		}
+		terminated := s.State.Terminated
+		if terminated == nil && s.State.Waiting != nil {
+			terminated = s.LastTerminationState.Terminated
+		}
+		if terminated == nil || terminated.ExitCode == 0 {
+			return "", false
+		}
+		reason := strings.TrimSpace(terminated.Message)
+		if reason == "" {
+			reason = fmt.Sprintf("exit code %d", terminated.ExitCode)
+		}
+		return reason, true
+	}
+	return "", false
+}
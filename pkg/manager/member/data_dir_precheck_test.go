@@ -0,0 +1,104 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/manager/member/constants"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestBuildDataDirPreCheckContainer(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbClusterForPD()
+	dataVol := corev1.VolumeMount{Name: "pd", MountPath: constants.PDDataVolumeMountPath}
+	g.Expect(buildDataDirPreCheckContainer(tc, nil, dataVol, corev1.ResourceRequirements{})).To(BeNil())
+
+	// only the ownership and permissions are checked by default
+	c := buildDataDirPreCheckContainer(tc, &v1alpha1.DataDirPreCheck{}, dataVol, corev1.ResourceRequirements{})
+	g.Expect(c).NotTo(BeNil())
+	g.Expect(c.Name).To(Equal(v1alpha1.ContainerDataDirPreCheck.String()))
+	g.Expect(c.Image).To(Equal(tc.HelperImage()))
+	g.Expect(c.VolumeMounts).To(Equal([]corev1.VolumeMount{dataVol}))
+	script := c.Command[2]
+	g.Expect(script).To(ContainSubstring(`dir="/var/lib/pd"`))
+	g.Expect(script).To(ContainSubstring(`stat -c %u:%g`))
+	g.Expect(script).NotTo(ContainSubstring("case"))
+	g.Expect(script).NotTo(ContainSubstring("df -Pk"))
+
+	minAvailable := resource.MustParse("10Gi")
+	c = buildDataDirPreCheckContainer(tc, &v1alpha1.DataDirPreCheck{
+		FSTypes:      []string{"ext4", "xfs"},
+		MinAvailable: &minAvailable,
+	}, dataVol, corev1.ResourceRequirements{})
+	script = c.Command[2]
+	g.Expect(script).To(ContainSubstring("  ext4|xfs) ;;"))
+	g.Expect(script).To(ContainSubstring("is not one of ext4, xfs"))
+	g.Expect(script).To(ContainSubstring(`if [ "${avail}" -lt 10485760 ]; then`))
+}
+
+func TestSyncDataDirPreCheckStatus(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	deps := controller.NewFakeDependencies()
+	tc := newTidbClusterForPD()
+	check := &v1alpha1.DataDirPreCheck{}
+	podLabels := label.New().Instance(tc.GetInstanceName()).PD().Labels()
+	indexer := deps.KubeInformerFactory.Core().V1().Pods().Informer().GetIndexer()
+
+	passed := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-pd-0", Namespace: tc.Namespace, Labels: podLabels},
+		Status: corev1.PodStatus{InitContainerStatuses: []corev1.ContainerStatus{{
+			Name:  v1alpha1.ContainerDataDirPreCheck.String(),
+			State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 0}},
+		}}},
+	}
+	g.Expect(indexer.Add(passed)).To(Succeed())
+	g.Expect(syncDataDirPreCheckStatus(deps, tc, v1alpha1.PDMemberType, check)).To(Succeed())
+	cond := meta.FindStatusCondition(tc.Status.PD.Conditions, v1alpha1.ConditionTypeDataDirPreCheckFailed)
+	g.Expect(cond).NotTo(BeNil())
+	g.Expect(cond.Status).To(Equal(metav1.ConditionFalse))
+
+	// the last termination is reported while the init container is in back-off
+	failed := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-pd-1", Namespace: tc.Namespace, Labels: podLabels},
+		Status: corev1.PodStatus{InitContainerStatuses: []corev1.ContainerStatus{{
+			Name:  v1alpha1.ContainerDataDirPreCheck.String(),
+			State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+			LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+				ExitCode: 1,
+				Message:  "filesystem type nfs of data directory /var/lib/pd is not one of ext4\n",
+			}},
+		}}},
+	}
+	g.Expect(indexer.Add(failed)).To(Succeed())
+	g.Expect(syncDataDirPreCheckStatus(deps, tc, v1alpha1.PDMemberType, check)).To(Succeed())
+	cond = meta.FindStatusCondition(tc.Status.PD.Conditions, v1alpha1.ConditionTypeDataDirPreCheckFailed)
+	g.Expect(cond.Status).To(Equal(metav1.ConditionTrue))
+	g.Expect(cond.Reason).To(Equal(v1alpha1.DataDirPreCheckReasonFailed))
+	g.Expect(cond.Message).To(Equal("the data directory pre-check of pd fails: test-pd-1: filesystem type nfs of data directory /var/lib/pd is not one of ext4"))
+
+	// the condition is removed once the check is disabled
+	g.Expect(syncDataDirPreCheckStatus(deps, tc, v1alpha1.PDMemberType, nil)).To(Succeed())
+	g.Expect(meta.FindStatusCondition(tc.Status.PD.Conditions, v1alpha1.ConditionTypeDataDirPreCheckFailed)).To(BeNil())
+}
@@ -230,7 +230,7 @@ func (m *masterMemberManager) syncMasterStatefulSetForDMCluster(dc *v1alpha1.DMC
 	// Perform failover logic if necessary. Note that this will only update
 	// DMCluster status. The actual scaling performs in next sync loop (if a
 	// new replica needs to be added).
-	if m.deps.CLIConfig.AutoFailover {
+	if m.deps.CLIConfig.IsAutoFailover() {
 		if m.shouldRecover(dc) {
 			m.failover.Recover(dc)
 		} else if dc.MasterAllPodsStarted() && !dc.MasterAllMembersReady() || dc.MasterAutoFailovering() {
@@ -453,6 +453,9 @@ func (m *masterMemberManager) getNewMasterServiceForDMCluster(dc *v1alpha1.DMClu
 	if dc.Spec.PreferIPv6 {
 		SetServiceWhenPreferIPv6(masterSvc)
 	}
+	if svcSpec != nil {
+		SetServiceIPFamilies(masterSvc, &svcSpec.ServiceSpec)
+	}
 
 	return masterSvc
 }
@@ -690,6 +693,7 @@ func getNewMasterSetForDMCluster(dc *v1alpha1.DMCluster, cm *corev1.ConfigMap) (
 	}
 	masterContainer.Env = util.AppendEnv(env, baseMasterSpec.Env())
 	masterContainer.EnvFrom = baseMasterSpec.EnvFrom()
+	setLifecycle(&masterContainer, baseMasterSpec.Lifecycle())
 	podSpec.Volumes = append(vols, baseMasterSpec.AdditionalVolumes()...)
 
 	podSpec.Containers, err = MergePatchContainers([]corev1.Container{masterContainer}, baseMasterSpec.AdditionalContainers())
@@ -748,6 +752,9 @@ func getNewMasterSetForDMCluster(dc *v1alpha1.DMCluster, cm *corev1.ConfigMap) (
 		},
 	}
 
+	if err := ApplyPodTemplatePatches(&masterSet.Spec.Template, baseMasterSpec.PodTemplatePatches()); err != nil {
+		return nil, fmt.Errorf("failed to apply pod template patches for dm-master of [%s/%s], error: %v", dc.Namespace, dc.Name, err)
+	}
 	return masterSet, nil
 }
 
@@ -769,9 +776,13 @@ func getMasterConfigMap(dc *v1alpha1.DMCluster) (*corev1.ConfigMap, error) {
 		return nil, err
 	}
 
+	if arg, name := v1alpha1.AdditionalArgsConflict(v1alpha1.DMMasterMemberType, dc.Spec.Master.AdditionalArgs); arg != "" {
+		return nil, fmt.Errorf("additional arg %q of dm-master conflicts with the flag %q generated by the operator", arg, name)
+	}
 	model := &startscriptv1.DMMasterStartScriptModel{
-		Scheme:  dc.Scheme(),
-		DataDir: filepath.Join(dmMasterDataVolumeMountPath, dc.Spec.Master.DataSubDir),
+		Scheme:    dc.Scheme(),
+		DataDir:   filepath.Join(dmMasterDataVolumeMountPath, dc.Spec.Master.DataSubDir),
+		ExtraArgs: strings.Join(dc.Spec.Master.AdditionalArgs, " "),
 	}
 	if dc.Spec.Master.StartUpScriptVersion == "v1" {
 		model.CheckDomainScript = v1.DMMasterCheckDNSV1
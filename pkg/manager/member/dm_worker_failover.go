@@ -48,7 +48,7 @@ func (f *workerFailover) Failover(dc *v1alpha1.DMCluster) error {
 			// (before it enters into Offline/Tombstone state)
 			continue
 		}
-		deadline := worker.LastTransitionTime.Add(f.deps.CLIConfig.WorkerFailoverPeriod)
+		deadline := worker.LastTransitionTime.Add(dc.GetWorkerFailoverPeriod(f.deps.CLIConfig.GetWorkerFailoverPeriod()))
 		exist := false
 		for _, failureWorker := range dc.Status.Worker.FailureMembers {
 			if failureWorker.PodName == podName {
@@ -97,8 +97,22 @@ func (f *workerFailover) RemoveUndesiredFailures(dc *v1alpha1.DMCluster) {
 			// slots feature. We should remove the record of undesired pods,
 			// otherwise an extra replacement pod will be created.
 			delete(dc.Status.Worker.FailureMembers, key)
+			continue
+		}
+		if dc.WorkerAutoRecoverFailover() {
+			// the original worker is online again, so the failover replica created for it is not needed any more
+			if worker, ok := dc.Status.Worker.Members[failureWorker.PodName]; ok && worker.Stage != v1alpha1.DMWorkerStateOffline {
+				delete(dc.Status.Worker.FailureMembers, key)
+				msg := fmt.Sprintf("dm-worker %s is online again, remove it from the failure members", failureWorker.PodName)
+				klog.Infof("dm-worker failover: %s in cluster %s/%s", msg, dc.GetNamespace(), dc.GetName())
+				f.deps.Recorder.Event(dc, corev1.EventTypeNormal, recoveryEventReason, msg)
+			}
 		}
 	}
+	if dc.WorkerAutoRecoverFailover() && len(dc.Status.Worker.FailureMembers) == 0 {
+		dc.Status.Worker.FailureMembers = nil
+		dc.Status.Worker.FailoverUID = ""
+	}
 }
 
 type fakeWorkerFailover struct{}
@@ -43,6 +43,8 @@ const (
 	dmWorkerDataVolumeMountPath = "/var/lib/dm-worker"
 	// dmWorkerClusterCertPath is where the cert for inter-cluster communication stored (if any)
 	dmWorkerClusterCertPath = "/var/lib/dm-worker-tls"
+	// dmWorkerRelayDir is the directory of the relay log under the data directory of dm-worker
+	dmWorkerRelayDir = "relay"
 )
 
 type workerMemberManager struct {
@@ -198,7 +200,7 @@ func (m *workerMemberManager) syncWorkerStatefulSetForDMCluster(dc *v1alpha1.DMC
 		m.failover.RemoveUndesiredFailures(dc)
 	}
 	if len(dc.Status.Worker.FailureMembers) > 0 &&
-		(dc.Spec.Worker.RecoverFailover || dc.Status.Worker.FailoverUID == dc.GetWorkerRecoverByUID()) &&
+		(dc.Spec.Worker.RecoverFailover || dc.WorkerAutoRecoverFailover() || dc.Status.Worker.FailoverUID == dc.GetWorkerRecoverByUID()) &&
 		shouldRecoverDM(dc, label.DMWorkerLabelVal, m.deps.PodLister) {
 		m.failover.Recover(dc)
 	}
@@ -227,7 +229,7 @@ func (m *workerMemberManager) syncWorkerStatefulSetForDMCluster(dc *v1alpha1.DMC
 	// Perform failover logic if necessary. Note that this will only update
 	// DMCluster status. The actual scaling performs in next sync loop (if a
 	// new replica needs to be added).
-	if m.deps.CLIConfig.AutoFailover && dc.Spec.Worker.MaxFailoverCount != nil {
+	if m.deps.CLIConfig.IsAutoFailover() && dc.Spec.Worker.MaxFailoverCount != nil {
 		if dc.WorkerAllPodsStarted() && !dc.WorkerAllMembersReady() {
 			if err := m.failover.Failover(dc); err != nil {
 				return err
@@ -501,6 +503,7 @@ func getNewWorkerSetForDMCluster(dc *v1alpha1.DMCluster, cm *corev1.ConfigMap) (
 	}
 	workerContainer.Env = util.AppendEnv(env, baseWorkerSpec.Env())
 	workerContainer.EnvFrom = baseWorkerSpec.EnvFrom()
+	setLifecycle(&workerContainer, baseWorkerSpec.Lifecycle())
 	podSpec.Volumes = append(vols, baseWorkerSpec.AdditionalVolumes()...)
 
 	podSpec.Containers, err = MergePatchContainers([]corev1.Container{workerContainer}, baseWorkerSpec.AdditionalContainers())
@@ -551,6 +554,9 @@ func getNewWorkerSetForDMCluster(dc *v1alpha1.DMCluster, cm *corev1.ConfigMap) (
 		},
 	}
 
+	if err := ApplyPodTemplatePatches(&workerSet.Spec.Template, baseWorkerSpec.PodTemplatePatches()); err != nil {
+		return nil, fmt.Errorf("failed to apply pod template patches for dm-worker of [%s/%s], error: %v", dc.Namespace, dc.Name, err)
+	}
 	return workerSet, nil
 }
 
@@ -566,14 +572,22 @@ func getWorkerConfigMap(dc *v1alpha1.DMCluster) (*corev1.ConfigMap, error) {
 		config.Set("ssl-cert", path.Join(dmWorkerClusterCertPath, corev1.TLSCertKey))
 		config.Set("ssl-key", path.Join(dmWorkerClusterCertPath, corev1.TLSPrivateKeyKey))
 	}
+	// store the relay log on the persistent volume of dm-worker unless it's set explicitly
+	if dc.Spec.Worker.Relay != nil {
+		config.SetIfNil("relay-dir", filepath.Join(dmWorkerDataVolumeMountPath, dc.Spec.Worker.DataSubDir, dmWorkerRelayDir))
+	}
 
 	confText, err := config.MarshalTOML()
 	if err != nil {
 		return nil, err
 	}
+	if arg, name := v1alpha1.AdditionalArgsConflict(v1alpha1.DMWorkerMemberType, dc.Spec.Worker.AdditionalArgs); arg != "" {
+		return nil, fmt.Errorf("additional arg %q of dm-worker conflicts with the flag %q generated by the operator", arg, name)
+	}
 	startScript, err := startscriptv1.RenderDMWorkerStartScript(&startscriptv1.DMWorkerStartScriptModel{
 		DataDir:       filepath.Join(dmWorkerDataVolumeMountPath, dc.Spec.Worker.DataSubDir),
 		MasterAddress: controller.DMMasterMemberName(dc.Name) + ":8261",
+		ExtraArgs:     strings.Join(dc.Spec.Worker.AdditionalArgs, " "),
 	})
 	if err != nil {
 		return nil, err
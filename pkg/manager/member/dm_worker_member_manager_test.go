@@ -1438,6 +1438,52 @@ func TestGetNewWorkerConfigMap(t *testing.T) {
 				Data: map[string]string{
 					"config-file": `keepalive-ttl = 25
 log-level = "info"
+`,
+					"startup-script": "",
+				},
+			},
+		},
+		{
+			name: "relay log",
+			dc: v1alpha1.DMCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "foo",
+					Namespace: "ns",
+				},
+				Spec: v1alpha1.DMClusterSpec{
+					Worker: &v1alpha1.WorkerSpec{
+						DataSubDir: "data",
+						Relay:      &v1alpha1.DMRelaySpec{Sources: []string{"mysql-01"}},
+					},
+				},
+			},
+			expected: corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "foo-dm-worker",
+					Namespace: "ns",
+					Labels: map[string]string{
+						"app.kubernetes.io/name":       "dm-cluster",
+						"app.kubernetes.io/managed-by": "tidb-operator",
+						"app.kubernetes.io/instance":   "foo",
+						"app.kubernetes.io/component":  "dm-worker",
+					},
+					OwnerReferences: []metav1.OwnerReference{
+						{
+							APIVersion: "pingcap.com/v1alpha1",
+							Kind:       "DMCluster",
+							Name:       "foo",
+							UID:        "",
+							Controller: func(b bool) *bool {
+								return &b
+							}(true),
+							BlockOwnerDeletion: func(b bool) *bool {
+								return &b
+							}(true),
+						},
+					},
+				},
+				Data: map[string]string{
+					"config-file": `relay-dir = "/var/lib/dm-worker/data/relay"
 `,
 					"startup-script": "",
 				},
@@ -19,8 +19,10 @@ import (
 	"strings"
 	"time"
 
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
 	"github.com/pingcap/tidb-operator/pkg/controller"
+	mngerutils "github.com/pingcap/tidb-operator/pkg/manager/utils"
 	"github.com/pingcap/tidb-operator/pkg/util"
 
 	corev1 "k8s.io/api/core/v1"
@@ -76,7 +78,7 @@ type commonStatefulFailureRecovery struct {
 
 // RestartPodOnHostDown checks for HostDown for any failure store or member then does a force restart of the pod
 func (fr *commonStatefulFailureRecovery) RestartPodOnHostDown(tc *v1alpha1.TidbCluster) error {
-	if fr.deps.CLIConfig.DetectNodeFailure {
+	if fr.deps.CLIConfig.IsDetectNodeFailure() {
 		if fr.failureObjectAccess.IsHostDownForFailedPod(tc) {
 			if canAutoFailureRecovery(tc) {
 				if err := fr.restartPodForHostDown(tc); err != nil {
@@ -113,7 +115,7 @@ func (fr *commonStatefulFailureRecovery) checkAndMarkHostDown(tc *v1alpha1.TidbC
 
 				// Check node and pod conditions and set HostDown in FailureStore
 				var reason string
-				if fr.deps.CLIConfig.PodHardRecoveryPeriod > 0 && time.Now().After(fr.failureObjectAccess.GetLastTransitionTime(tc, objectId).Add(fr.deps.CLIConfig.PodHardRecoveryPeriod)) {
+				if fr.deps.CLIConfig.GetPodHardRecoveryPeriod() > 0 && time.Now().After(fr.failureObjectAccess.GetLastTransitionTime(tc, objectId).Add(fr.deps.CLIConfig.GetPodHardRecoveryPeriod())) {
 					reason = hdReasonStoreDownTimeExceeded
 				}
 				if len(reason) == 0 {
@@ -186,6 +188,7 @@ func (fr *commonStatefulFailureRecovery) restartPodForHostDown(tc *v1alpha1.Tidb
 				// If the failed pod has already been restarted once, its CreationTimestamp will be after FailureMember.CreatedAt
 				if fr.failureObjectAccess.GetCreatedAt(tc, objectId).After(pod.CreationTimestamp.Time) {
 					// Use force option to delete the pod
+					mngerutils.RecordPodRestart(tc, pod, label.RestartReasonFailover, "host-down")
 					if err = fr.deps.PodControl.ForceDeletePod(tc, pod); err != nil {
 						return err
 					}
@@ -247,6 +250,7 @@ func (fr *commonStatefulFailureRecovery) deletePodAndPvcs(tc *v1alpha1.TidbClust
 		// The Scheduled condition of pod if true can confirm that the K8s node is not cordoned.
 		podScheduled := isPodConditionScheduledTrue(pod.Status.Conditions)
 		klog.Infof("%s failover[deletePodAndPvcs]: Scheduled condition of pod %s of tc %s/%s: %t", memberType, failurePodName, ns, tcName, podScheduled)
+		mngerutils.RecordPodRestart(tc, pod, label.RestartReasonFailover, "recreate-pvcs")
 		if deleteErr := fr.deps.PodControl.DeletePod(tc, pod); deleteErr != nil {
 			return deleteErr
 		}
@@ -0,0 +1,99 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// The fencing checks are done before the destructive PD calls, e.g. deleting a store or removing a member.
+// The stores and members in the status of TidbCluster and the pods in the informer cache may be stale,
+// e.g. a pod is recreated quickly and a new store is registered, so the identity of the target is queried
+// again from PD and the API server, and the call is skipped with a requeue error if the target is not the
+// expected one any more.
+
+// fenceStore checks that the store still maps to the pod and the state of the store is one of the expected states
+// if any is given. The store-id label of the pod is checked if it's set.
+func fenceStore(deps *controller.Dependencies, tc *v1alpha1.TidbCluster, storeID uint64, podName string, states ...string) error {
+	ns := tc.GetNamespace()
+	store, err := controller.GetPDClient(deps.PDControl, tc).GetStore(storeID)
+	if err != nil {
+		return fmt.Errorf("fencing: failed to get store %d of tc %s/%s, error: %v", storeID, ns, tc.GetName(), err)
+	}
+	if store.Store == nil {
+		return controller.RequeueErrorf("fencing: store %d of tc %s/%s is not found", storeID, ns, tc.GetName())
+	}
+	ip := strings.Split(store.Store.GetAddress(), ":")[0]
+	if storePodName := strings.Split(ip, ".")[0]; storePodName != podName {
+		return controller.RequeueErrorf("fencing: store %d of tc %s/%s maps to pod %s, expected %s", storeID, ns, tc.GetName(), storePodName, podName)
+	}
+	if len(states) > 0 && !sets.NewString(states...).Has(store.Store.StateName) {
+		return controller.RequeueErrorf("fencing: store %d of tc %s/%s is %s, expected %v", storeID, ns, tc.GetName(), store.Store.StateName, states)
+	}
+	return fencePod(deps, tc, podName, label.StoreIDLabelKey, fmt.Sprintf("%d", storeID))
+}
+
+// fencePDMember checks that the PD member of the id still has the name, it's skipped if the member has been removed.
+func fencePDMember(deps *controller.Dependencies, tc *v1alpha1.TidbCluster, memberName string, memberID uint64, podName string) error {
+	ns := tc.GetNamespace()
+	members, err := controller.GetPDClient(deps.PDControl, tc).GetMembers()
+	if err != nil {
+		return fmt.Errorf("fencing: failed to get pd members of tc %s/%s, error: %v", ns, tc.GetName(), err)
+	}
+	for _, member := range members.Members {
+		if member.GetMemberId() == memberID && member.GetName() != memberName {
+			return controller.RequeueErrorf("fencing: pd member %d of tc %s/%s is %s, expected %s", memberID, ns, tc.GetName(), member.GetName(), memberName)
+		}
+		if member.GetName() == memberName && memberID != 0 && member.GetMemberId() != memberID {
+			return controller.RequeueErrorf("fencing: pd member %s of tc %s/%s is %d, expected %d", memberName, ns, tc.GetName(), member.GetMemberId(), memberID)
+		}
+	}
+	return fencePod(deps, tc, podName, label.MemberIDLabelKey, fmt.Sprintf("%d", memberID))
+}
+
+// fencePod checks that the pod in the informer cache is not recreated, and the id label of the pod equals the
+// expected id if the label is set. It's skipped if the pod is not in the cache.
+func fencePod(deps *controller.Dependencies, tc *v1alpha1.TidbCluster, podName, idLabelKey, id string) error {
+	ns := tc.GetNamespace()
+	cachedPod, err := deps.PodLister.Pods(ns).Get(podName)
+	if errors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("fencing: failed to get pod %s/%s from cache, error: %v", ns, podName, err)
+	}
+	pod, err := deps.KubeClientset.CoreV1().Pods(ns).Get(context.TODO(), podName, metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		return controller.RequeueErrorf("fencing: pod %s/%s is deleted", ns, podName)
+	}
+	if err != nil {
+		return fmt.Errorf("fencing: failed to get pod %s/%s, error: %v", ns, podName, err)
+	}
+	if pod.UID != cachedPod.UID {
+		return controller.RequeueErrorf("fencing: pod %s/%s is recreated, uid %s, expected %s", ns, podName, pod.UID, cachedPod.UID)
+	}
+	if podID := pod.Labels[idLabelKey]; podID != "" && id != "0" && podID != id {
+		return controller.RequeueErrorf("fencing: pod %s/%s has %s %s, expected %s", ns, podName, idLabelKey, podID, id)
+	}
+	return nil
+}
@@ -0,0 +1,214 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/pingcap/kvproto/pkg/metapb"
+	"github.com/pingcap/kvproto/pkg/pdpb"
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/pdapi"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	core "k8s.io/client-go/testing"
+)
+
+func TestFenceStore(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tests := []struct {
+		name        string
+		address     string
+		state       string
+		states      []string
+		livePodUID  types.UID
+		storeLabel  string
+		expectError bool
+	}{
+		{
+			name:    "store maps to the pod",
+			address: "test-tikv-1.test-tikv-peer.default.svc:20160",
+			state:   v1alpha1.TiKVStateUp,
+		},
+		{
+			name:        "store maps to another pod",
+			address:     "test-tikv-2.test-tikv-peer.default.svc:20160",
+			state:       v1alpha1.TiKVStateUp,
+			expectError: true,
+		},
+		{
+			name:        "store is not in the expected state",
+			address:     "test-tikv-1.test-tikv-peer.default.svc:20160",
+			state:       v1alpha1.TiKVStateUp,
+			states:      []string{v1alpha1.TiKVStateDown},
+			expectError: true,
+		},
+		{
+			name:        "pod is recreated",
+			address:     "test-tikv-1.test-tikv-peer.default.svc:20160",
+			state:       v1alpha1.TiKVStateUp,
+			livePodUID:  "new-uid",
+			expectError: true,
+		},
+		{
+			name:        "pod has another store id",
+			address:     "test-tikv-1.test-tikv-peer.default.svc:20160",
+			state:       v1alpha1.TiKVStateUp,
+			storeLabel:  "2",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := newTidbClusterForPD()
+			deps := controller.NewFakeDependencies()
+			pod := newPodForFailover(tc, v1alpha1.TiKVMemberType, 1)
+			pod.UID = "uid"
+			deps.KubeInformerFactory.Core().V1().Pods().Informer().GetIndexer().Add(pod)
+			livePod := pod.DeepCopy()
+			if tt.livePodUID != "" {
+				livePod.UID = tt.livePodUID
+			}
+			if tt.storeLabel != "" {
+				livePod.Labels = map[string]string{label.StoreIDLabelKey: tt.storeLabel}
+			}
+			deps.KubeClientset.(*kubefake.Clientset).Tracker().Add(livePod)
+
+			pdClient := controller.NewFakePDClient(deps.PDControl.(*pdapi.FakePDControl), tc)
+			pdClient.AddReaction(pdapi.GetStoreActionType, func(action *pdapi.Action) (interface{}, error) {
+				return &pdapi.StoreInfo{Store: &pdapi.MetaStore{
+					Store:     &metapb.Store{Id: action.ID, Address: tt.address},
+					StateName: tt.state,
+				}}, nil
+			})
+
+			err := fenceStore(deps, tc, 1, pod.Name, tt.states...)
+			if tt.expectError {
+				g.Expect(controller.IsRequeueError(err)).To(BeTrue())
+			} else {
+				g.Expect(err).To(Succeed())
+			}
+		})
+	}
+}
+
+func TestFencePDMember(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tests := []struct {
+		name        string
+		members     []*pdpb.Member
+		memberID    uint64
+		expectError bool
+	}{
+		{
+			name:     "member has the name",
+			members:  []*pdpb.Member{{Name: "test-pd-1", MemberId: 1}},
+			memberID: 1,
+		},
+		{
+			name:     "member is removed",
+			members:  []*pdpb.Member{{Name: "test-pd-0", MemberId: 0}},
+			memberID: 1,
+		},
+		{
+			name:        "member id is taken by another member",
+			members:     []*pdpb.Member{{Name: "test-pd-2", MemberId: 1}},
+			memberID:    1,
+			expectError: true,
+		},
+		{
+			name:        "member is rejoined with another id",
+			members:     []*pdpb.Member{{Name: "test-pd-1", MemberId: 2}},
+			memberID:    1,
+			expectError: true,
+		},
+		{
+			name:    "member id is unknown",
+			members: []*pdpb.Member{{Name: "test-pd-1", MemberId: 2}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := newTidbClusterForPD()
+			deps := controller.NewFakeDependencies()
+			pdClient := controller.NewFakePDClient(deps.PDControl.(*pdapi.FakePDControl), tc)
+			pdClient.AddReaction(pdapi.GetMembersActionType, func(action *pdapi.Action) (interface{}, error) {
+				return &pdapi.MembersInfo{Members: tt.members}, nil
+			})
+
+			err := fencePDMember(deps, tc, "test-pd-1", tt.memberID, "test-pd-1")
+			if tt.expectError {
+				g.Expect(controller.IsRequeueError(err)).To(BeTrue())
+			} else {
+				g.Expect(err).To(Succeed())
+			}
+		})
+	}
+}
+
+// addFencingReactions makes the fencing checks pass, the stores and the members are served from the status
+// of the tc and the pods are served from the informer cache.
+func addFencingReactions(deps *controller.Dependencies, pdClient *pdapi.FakePDClient, tc *v1alpha1.TidbCluster) {
+	pdClient.AddReaction(pdapi.GetStoreActionType, func(action *pdapi.Action) (interface{}, error) {
+		id := fmt.Sprintf("%d", action.ID)
+		for _, stores := range []map[string]v1alpha1.TiKVStore{
+			tc.Status.TiKV.Stores, tc.Status.TiKV.TombstoneStores, tc.Status.TiFlash.Stores, tc.Status.TiFlash.TombstoneStores,
+		} {
+			if store, ok := stores[id]; ok {
+				podName := store.PodName
+				if failureStore, ok := tc.Status.TiKV.FailureStores[id]; ok && podName == "" {
+					podName = failureStore.PodName
+				}
+				return &pdapi.StoreInfo{Store: &pdapi.MetaStore{
+					Store:     &metapb.Store{Id: action.ID, Address: podName + ":20160"},
+					StateName: store.State,
+				}}, nil
+			}
+		}
+		return nil, fmt.Errorf("store %s is not found", id)
+	})
+	pdClient.AddReaction(pdapi.GetMembersActionType, func(action *pdapi.Action) (interface{}, error) {
+		members := &pdapi.MembersInfo{}
+		for _, member := range tc.Status.PD.Members {
+			id, _ := strconv.ParseUint(member.ID, 10, 64)
+			members.Members = append(members.Members, &pdpb.Member{Name: member.Name, MemberId: id})
+		}
+		return members, nil
+	})
+
+	podIndexer := deps.KubeInformerFactory.Core().V1().Pods().Informer().GetIndexer()
+	deps.KubeClientset.(*kubefake.Clientset).PrependReactor("get", "pods", func(action core.Action) (bool, runtime.Object, error) {
+		get := action.(core.GetAction)
+		obj, exists, err := podIndexer.GetByKey(fmt.Sprintf("%s/%s", get.GetNamespace(), get.GetName()))
+		if err != nil {
+			return true, nil, err
+		}
+		if !exists {
+			return true, nil, errors.NewNotFound(corev1.Resource("pods"), get.GetName())
+		}
+		return true, obj.(*corev1.Pod), nil
+	})
+}
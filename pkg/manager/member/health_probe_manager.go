@@ -0,0 +1,273 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/healthprobe"
+	"github.com/pingcap/tidb-operator/pkg/manager"
+	"github.com/pingcap/tidb-operator/pkg/metrics"
+	"github.com/pingcap/tidb-operator/pkg/util"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/pointer"
+)
+
+const (
+	defaultHealthProbeInterval = 30
+	defaultHealthProbeTimeout  = 10
+	defaultHealthProbeDatabase = "tidb_operator"
+	defaultHealthProbeUser     = "root"
+	healthProbePasswordKey     = "password"
+)
+
+type healthProbeManager struct {
+	deps *controller.Dependencies
+}
+
+// NewHealthProbeManager returns a manager that deploys the synthetic SQL probe of a TidbCluster
+// and records the result of its last probe in the status and the metrics.
+func NewHealthProbeManager(deps *controller.Dependencies) manager.Manager {
+	return &healthProbeManager{deps: deps}
+}
+
+func (m *healthProbeManager) Sync(tc *v1alpha1.TidbCluster) error {
+	if tc.Spec.HealthProbe == nil || tc.Spec.TiDB == nil {
+		return m.cleanup(tc)
+	}
+
+	if _, err := m.deps.TypedControl.CreateOrUpdateDeployment(tc, m.getHealthProbeDeployment(tc)); err != nil {
+		return controller.RequeueErrorf("error creating or updating health probe deployment: %v", err)
+	}
+	if _, err := m.deps.TypedControl.CreateOrUpdateService(tc, getHealthProbeService(tc)); err != nil {
+		return controller.RequeueErrorf("error creating or updating health probe service: %v", err)
+	}
+
+	result, err := m.deps.HealthProbeControl.GetResult(tc)
+	if err != nil {
+		// the probe may be starting, keep the last result
+		klog.V(4).Infof("failed to get the health probe result of tc %s/%s, error: %v", tc.Namespace, tc.Name, err)
+		return nil
+	}
+	tc.Status.HealthProbe = result
+
+	success := 0.0
+	if result.Success {
+		success = 1
+	}
+	metrics.ClusterHealthProbeSuccess.WithLabelValues(tc.Namespace, tc.Name).Set(success)
+	metrics.ClusterHealthProbeLatency.WithLabelValues(tc.Namespace, tc.Name).Set(
+		(time.Duration(result.LatencyMilliseconds) * time.Millisecond).Seconds())
+	return nil
+}
+
+// cleanup deletes the probe if it's removed from the spec
+func (m *healthProbeManager) cleanup(tc *v1alpha1.TidbCluster) error {
+	tc.Status.HealthProbe = nil
+	metrics.ClusterHealthProbeSuccess.DeleteLabelValues(tc.Namespace, tc.Name)
+	metrics.ClusterHealthProbeLatency.DeleteLabelValues(tc.Namespace, tc.Name)
+
+	name := controller.HealthProbeMemberName(tc.Name)
+	deploy, err := m.deps.DeploymentLister.Deployments(tc.Namespace).Get(name)
+	if err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("healthProbeManager: failed to get deployment %s/%s, error: %v", tc.Namespace, name, err)
+	}
+	if err == nil && metav1.IsControlledBy(deploy, tc) {
+		if err := m.deps.TypedControl.Delete(tc, deploy.DeepCopy()); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	}
+	svc, err := m.deps.ServiceLister.Services(tc.Namespace).Get(name)
+	if err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("healthProbeManager: failed to get service %s/%s, error: %v", tc.Namespace, name, err)
+	}
+	if err == nil && metav1.IsControlledBy(svc, tc) {
+		if err := m.deps.TypedControl.Delete(tc, svc.DeepCopy()); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+func getHealthProbeMeta(tc *v1alpha1.TidbCluster) (metav1.ObjectMeta, label.Label) {
+	l := label.New().Instance(tc.GetInstanceName()).HealthProbe()
+	return metav1.ObjectMeta{
+		Name:            controller.HealthProbeMemberName(tc.Name),
+		Namespace:       tc.Namespace,
+		Labels:          l,
+		OwnerReferences: []metav1.OwnerReference{controller.GetOwnerRef(tc)},
+	}, l
+}
+
+func getHealthProbeService(tc *v1alpha1.TidbCluster) *corev1.Service {
+	meta, l := getHealthProbeMeta(tc)
+	svc := &corev1.Service{
+		ObjectMeta: meta,
+		Spec: corev1.ServiceSpec{
+			Type: corev1.ServiceTypeClusterIP,
+			Ports: []corev1.ServicePort{
+				{
+					Name:       "health-probe",
+					Port:       healthprobe.Port,
+					TargetPort: intstr.FromInt(healthprobe.Port),
+					Protocol:   corev1.ProtocolTCP,
+				},
+			},
+			Selector: l,
+		},
+	}
+	if tc.Spec.PreferIPv6 {
+		SetServiceWhenPreferIPv6(svc)
+	}
+	return svc
+}
+
+func (m *healthProbeManager) getHealthProbeDeployment(tc *v1alpha1.TidbCluster) *appsv1.Deployment {
+	spec := tc.Spec.HealthProbe
+	meta, l := getHealthProbeMeta(tc)
+
+	interval := int32(defaultHealthProbeInterval)
+	if spec.IntervalSeconds != nil {
+		interval = *spec.IntervalSeconds
+	}
+	timeout := int32(defaultHealthProbeTimeout)
+	if spec.TimeoutSeconds != nil {
+		timeout = *spec.TimeoutSeconds
+	}
+	database := spec.Database
+	if database == "" {
+		database = defaultHealthProbeDatabase
+	}
+	user := spec.User
+	if user == "" {
+		user = defaultHealthProbeUser
+	}
+	image := spec.Image
+	if image == "" {
+		image = m.deps.CLIConfig.GetTiDBDiscoveryImage()
+	}
+
+	args := []string{
+		"-host=" + fmt.Sprintf("%s.%s.svc", controller.TiDBMemberName(tc.Name), tc.Namespace),
+		"-port=" + strconv.Itoa(int(tc.Spec.TiDB.GetServicePort())),
+		"-user=" + user,
+		"-database=" + database,
+		"-interval=" + (time.Duration(interval) * time.Second).String(),
+		"-timeout=" + (time.Duration(timeout) * time.Second).String(),
+	}
+	envs := []corev1.EnvVar{
+		{
+			Name:  "TZ",
+			Value: tc.Timezone(),
+		},
+	}
+	if spec.SecretName != "" {
+		envs = append(envs, corev1.EnvVar{
+			Name: "TIDB_PASSWORD",
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: spec.SecretName},
+					Key:                  healthProbePasswordKey,
+				},
+			},
+		})
+	}
+
+	// the probe is scheduled like the discovery
+	baseSpec := tc.BaseDiscoverySpec()
+	podSpec := baseSpec.BuildPodSpec()
+	container := corev1.Container{
+		Name:            label.HealthProbeLabelVal,
+		Image:           image,
+		ImagePullPolicy: baseSpec.ImagePullPolicy(),
+		Command:         []string{"/usr/local/bin/tidb-health-probe"},
+		Args:            args,
+		Env:             envs,
+		Resources:       controller.ContainerResource(spec.ResourceRequirements),
+		Ports: []corev1.ContainerPort{
+			{
+				Name:          "health-probe",
+				Protocol:      corev1.ProtocolTCP,
+				ContainerPort: healthprobe.Port,
+			},
+		},
+	}
+	if tc.Spec.TiDB.IsTLSClientEnabled() && !tc.SkipTLSWhenConnectTiDB() {
+		container.Args = append(container.Args, "-tls="+strconv.FormatBool(true))
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+			Name:      "tidb-client-tls",
+			ReadOnly:  true,
+			MountPath: util.TiDBClientTLSPath,
+		})
+		podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+			Name: "tidb-client-tls",
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: util.TiDBClientTLSSecretName(tc.Name, nil),
+				},
+			},
+		})
+	}
+	podSpec.Containers = []corev1.Container{container}
+	podSpec.InitContainers = nil
+
+	d := &appsv1.Deployment{
+		ObjectMeta: meta,
+		Spec: appsv1.DeploymentSpec{
+			Strategy: appsv1.DeploymentStrategy{Type: appsv1.RecreateDeploymentStrategyType},
+			Replicas: pointer.Int32Ptr(1),
+			Selector: l.LabelSelector(),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: util.CombineStringMap(l.Labels(), baseSpec.Labels()),
+				},
+				Spec: podSpec,
+			},
+		},
+	}
+	b, err := json.Marshal(d.Spec.Template.Spec)
+	if err == nil {
+		d.Annotations = map[string]string{controller.LastAppliedPodTemplate: string(b)}
+	}
+	return d
+}
+
+var _ manager.Manager = &healthProbeManager{}
+
+type FakeHealthProbeManager struct {
+	err error
+}
+
+func NewFakeHealthProbeManager() *FakeHealthProbeManager {
+	return &FakeHealthProbeManager{}
+}
+
+func (m *FakeHealthProbeManager) SetSyncError(err error) {
+	m.err = err
+}
+
+func (m *FakeHealthProbeManager) Sync(_ *v1alpha1.TidbCluster) error {
+	return m.err
+}
@@ -0,0 +1,134 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/pointer"
+)
+
+func TestHealthProbeManagerSync(t *testing.T) {
+	g := NewGomegaWithT(t)
+	type testcase struct {
+		name    string
+		prepare func(tc *v1alpha1.TidbCluster, probeControl *controller.FakeHealthProbeControl)
+		expect  func(deploys []appsv1.Deployment, svcs []corev1.Service, tc *v1alpha1.TidbCluster, err error)
+	}
+	testFn := func(tt *testcase) {
+		t.Log(tt.name)
+
+		tc := newTidbClusterForTiDB()
+		fakeDeps := controller.NewFakeDependencies()
+		ctrl := fakeDeps.GenericControl.(*controller.FakeGenericControl)
+		probeControl := fakeDeps.HealthProbeControl.(*controller.FakeHealthProbeControl)
+		m := NewHealthProbeManager(fakeDeps)
+		if tt.prepare != nil {
+			tt.prepare(tc, probeControl)
+		}
+
+		err := m.Sync(tc)
+		deployList := &appsv1.DeploymentList{}
+		g.Expect(ctrl.FakeCli.List(context.TODO(), deployList)).To(Succeed())
+		svcList := &corev1.ServiceList{}
+		g.Expect(ctrl.FakeCli.List(context.TODO(), svcList)).To(Succeed())
+		tt.expect(deployList.Items, svcList.Items, tc, err)
+	}
+
+	cases := []*testcase{
+		{
+			name: "probe not enabled",
+			expect: func(deploys []appsv1.Deployment, svcs []corev1.Service, tc *v1alpha1.TidbCluster, err error) {
+				g.Expect(err).To(Succeed())
+				g.Expect(deploys).To(BeEmpty())
+				g.Expect(svcs).To(BeEmpty())
+				g.Expect(tc.Status.HealthProbe).To(BeNil())
+			},
+		},
+		{
+			name: "probe enabled",
+			prepare: func(tc *v1alpha1.TidbCluster, probeControl *controller.FakeHealthProbeControl) {
+				tc.Spec.HealthProbe = &v1alpha1.HealthProbeSpec{
+					IntervalSeconds: pointer.Int32Ptr(60),
+					SecretName:      "probe-secret",
+				}
+				probeControl.SetResult(&v1alpha1.HealthProbeStatus{Success: true, LatencyMilliseconds: 12}, nil)
+			},
+			expect: func(deploys []appsv1.Deployment, svcs []corev1.Service, tc *v1alpha1.TidbCluster, err error) {
+				g.Expect(err).To(Succeed())
+				g.Expect(deploys).To(HaveLen(1))
+				g.Expect(deploys[0].Name).To(Equal("test-health-probe"))
+				container := deploys[0].Spec.Template.Spec.Containers[0]
+				g.Expect(container.Args).To(ContainElements(
+					"-host=test-tidb.default.svc",
+					"-user=root",
+					"-database=tidb_operator",
+					"-interval=1m0s",
+					"-timeout=10s",
+				))
+				g.Expect(container.Env).To(ContainElement(corev1.EnvVar{
+					Name: "TIDB_PASSWORD",
+					ValueFrom: &corev1.EnvVarSource{
+						SecretKeyRef: &corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{Name: "probe-secret"},
+							Key:                  "password",
+						},
+					},
+				}))
+				g.Expect(svcs).To(HaveLen(1))
+				g.Expect(svcs[0].Name).To(Equal("test-health-probe"))
+				g.Expect(tc.Status.HealthProbe).NotTo(BeNil())
+				g.Expect(tc.Status.HealthProbe.Success).To(BeTrue())
+				g.Expect(tc.Status.HealthProbe.LatencyMilliseconds).To(Equal(int64(12)))
+			},
+		},
+		{
+			name: "keep the last result if the probe is unreachable",
+			prepare: func(tc *v1alpha1.TidbCluster, probeControl *controller.FakeHealthProbeControl) {
+				tc.Spec.HealthProbe = &v1alpha1.HealthProbeSpec{}
+				tc.Status.HealthProbe = &v1alpha1.HealthProbeStatus{Success: true}
+				probeControl.SetResult(nil, fmt.Errorf("connection refused"))
+			},
+			expect: func(deploys []appsv1.Deployment, svcs []corev1.Service, tc *v1alpha1.TidbCluster, err error) {
+				g.Expect(err).To(Succeed())
+				g.Expect(deploys).To(HaveLen(1))
+				g.Expect(tc.Status.HealthProbe).NotTo(BeNil())
+				g.Expect(tc.Status.HealthProbe.Success).To(BeTrue())
+			},
+		},
+		{
+			name: "report failed probe",
+			prepare: func(tc *v1alpha1.TidbCluster, probeControl *controller.FakeHealthProbeControl) {
+				tc.Spec.HealthProbe = &v1alpha1.HealthProbeSpec{}
+				probeControl.SetResult(&v1alpha1.HealthProbeStatus{Success: false, Message: "commit transaction failed", ConsecutiveFailures: 3}, nil)
+			},
+			expect: func(deploys []appsv1.Deployment, svcs []corev1.Service, tc *v1alpha1.TidbCluster, err error) {
+				g.Expect(err).To(Succeed())
+				g.Expect(tc.Status.HealthProbe.Success).To(BeFalse())
+				g.Expect(tc.Status.HealthProbe.ConsecutiveFailures).To(Equal(int32(3)))
+			},
+		},
+	}
+
+	for _, tt := range cases {
+		testFn(tt)
+	}
+}
@@ -14,6 +14,7 @@
 package member
 
 import (
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
 	corelisterv1 "k8s.io/client-go/listers/core/v1"
 )
@@ -27,6 +28,21 @@ var shortLabelNameToK8sLabel = map[string][]string{
 	"host":   {corev1.LabelHostname},
 }
 
+// zoneLocationLabel returns the location label of the zones declared in spec.topologyZones for TiKV,
+// it returns "" if the zones are not declared or the location label is not required to be set.
+func zoneLocationLabel(tc *v1alpha1.TidbCluster) string {
+	if len(tc.ZoneQuotas(v1alpha1.TiKVMemberType)) == 0 || !tc.Spec.TopologyZones.SetLocationLabel {
+		return ""
+	}
+	key := tc.ZoneTopologyKey()
+	for _, name := range shortLabelNameToK8sLabel["zone"] {
+		if key == name {
+			return "zone"
+		}
+	}
+	return key
+}
+
 // NodeAvailabilityStatus has the availability status information of a k8s node
 type NodeAvailabilityStatus struct {
 	NodeUnavailable   bool
@@ -17,6 +17,7 @@ import (
 	"testing"
 
 	. "github.com/onsi/gomega"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
 	"github.com/pingcap/tidb-operator/pkg/controller"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -158,3 +159,22 @@ func TestGetNodeLabels(t *testing.T) {
 		testFn(test, t)
 	}
 }
+
+func TestZoneLocationLabel(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := &v1alpha1.TidbCluster{}
+	g.Expect(zoneLocationLabel(tc)).To(BeEmpty())
+
+	tc.Spec.TopologyZones = &v1alpha1.TopologyZones{
+		TiKV: map[string]int32{"us-east-1a": 1},
+	}
+	// the location labels are not changed unless it's required explicitly
+	g.Expect(zoneLocationLabel(tc)).To(BeEmpty())
+
+	tc.Spec.TopologyZones.SetLocationLabel = true
+	g.Expect(zoneLocationLabel(tc)).To(Equal("zone"))
+
+	tc.Spec.TopologyZones.TopologyKey = "example.com/rack"
+	g.Expect(zoneLocationLabel(tc)).To(Equal("example.com/rack"))
+}
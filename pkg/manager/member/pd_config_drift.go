@@ -0,0 +1,312 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/apis/util/config"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/klog/v2"
+)
+
+// pdOnlineConfigSections are the sections of the PD config which are persisted by PD after the bootstrap and
+// can be changed online, the declared values in them don't take effect by restarting PD once they're changed
+var pdOnlineConfigSections = []string{"schedule", "replication", "pd-server"}
+
+// pdSchedulersKey is the config item of the schedulers created by PD at the bootstrap
+const pdSchedulersKey = "schedule.schedulers-v2"
+
+// pdConfigDrift is the difference between the online config and schedulers of PD and the declared ones
+type pdConfigDrift struct {
+	// values are the declared values of the drifted config items
+	values map[string]interface{}
+	// the schedulers declared but not running, and the ones running but declared disabled
+	missingSchedulers  []string
+	disabledSchedulers []string
+}
+
+func (d *pdConfigDrift) empty() bool {
+	return len(d.values) == 0 && len(d.missingSchedulers) == 0 && len(d.disabledSchedulers) == 0
+}
+
+// String returns the drifted keys and schedulers in a stable order
+func (d *pdConfigDrift) String() string {
+	var items []string
+	keys := make([]string, 0, len(d.values))
+	for k := range d.values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	if len(keys) > 0 {
+		items = append(items, fmt.Sprintf("config %s", strings.Join(keys, ", ")))
+	}
+	if len(d.missingSchedulers) > 0 {
+		items = append(items, fmt.Sprintf("missing schedulers %s", strings.Join(d.missingSchedulers, ", ")))
+	}
+	if len(d.disabledSchedulers) > 0 {
+		items = append(items, fmt.Sprintf("disabled schedulers %s", strings.Join(d.disabledSchedulers, ", ")))
+	}
+	return strings.Join(items, "; ")
+}
+
+// syncPDConfigDrift checks whether the online config and schedulers of PD drift from spec.pd.config, e.g. after
+// they're changed by pd-ctl, and reports or reverts the drift according to spec.pd.configDriftPolicy.
+func (m *pdMemberManager) syncPDConfigDrift(tc *v1alpha1.TidbCluster) error {
+	policy := tc.PDConfigDriftPolicy()
+	if policy == v1alpha1.PDConfigDriftPolicyIgnore || tc.Spec.PD.Config == nil {
+		tc.Status.PD.RemoveCondition(v1alpha1.ConditionTypeConfigDrifted)
+		return nil
+	}
+	if !tc.PDIsAvailable() {
+		return nil
+	}
+
+	ns := tc.GetNamespace()
+	tcName := tc.GetName()
+	pdCli := controller.GetPDClient(m.deps.PDControl, tc)
+	online, err := pdCli.GetOnlineConfig()
+	if err != nil {
+		return fmt.Errorf("failed to get online config of pd of cluster %s/%s: %v", ns, tcName, err)
+	}
+	schedulers, err := pdCli.GetSchedulers()
+	if err != nil {
+		return fmt.Errorf("failed to get schedulers of pd of cluster %s/%s: %v", ns, tcName, err)
+	}
+	declared := tc.Spec.PD.Config.GenericConfig
+	drift := &pdConfigDrift{values: pdConfigDriftValues(declared, online)}
+	drift.missingSchedulers, drift.disabledSchedulers = pdSchedulersDrift(declared, schedulers)
+
+	if drift.empty() {
+		setPDConfigDriftedCondition(tc, false, v1alpha1.ConfigDriftReasonInSync, "")
+		return nil
+	}
+
+	if policy == v1alpha1.PDConfigDriftPolicyReport {
+		msg := fmt.Sprintf("the online pd config drifts from spec.pd.config: %s", drift)
+		if cond := meta.FindStatusCondition(tc.Status.PD.Conditions, v1alpha1.ConditionTypeConfigDrifted); cond == nil || cond.Message != msg {
+			m.deps.Recorder.Event(tc, corev1.EventTypeWarning, "PDConfigDrifted", msg)
+		}
+		setPDConfigDriftedCondition(tc, true, v1alpha1.ConfigDriftReasonDrifted, msg)
+		return nil
+	}
+
+	var errs []error
+	if len(drift.values) > 0 {
+		if err := pdCli.SetConfig(drift.values); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	for _, name := range drift.missingSchedulers {
+		if err := pdCli.AddScheduler(name); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	for _, name := range drift.disabledSchedulers {
+		if err := pdCli.RemoveScheduler(name); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		err := utilerrors.NewAggregate(errs)
+		msg := fmt.Sprintf("failed to revert the online pd config to spec.pd.config: %s: %v", drift, err)
+		m.deps.Recorder.Event(tc, corev1.EventTypeWarning, "PDConfigRevertFailed", msg)
+		setPDConfigDriftedCondition(tc, true, v1alpha1.ConfigDriftReasonRevertFailed, msg)
+		return controller.RequeueErrorf("cluster %s/%s: %s", ns, tcName, msg)
+	}
+	msg := fmt.Sprintf("reverted the online pd config to spec.pd.config: %s", drift)
+	klog.Infof("cluster %s/%s %s", ns, tcName, msg)
+	m.deps.Recorder.Event(tc, corev1.EventTypeNormal, "PDConfigReverted", msg)
+	setPDConfigDriftedCondition(tc, false, v1alpha1.ConfigDriftReasonInSync, msg)
+	return nil
+}
+
+func setPDConfigDriftedCondition(tc *v1alpha1.TidbCluster, drifted bool, reason, message string) {
+	status := metav1.ConditionFalse
+	if drifted {
+		status = metav1.ConditionTrue
+	}
+	tc.Status.PD.SetCondition(metav1.Condition{
+		Type:               v1alpha1.ConditionTypeConfigDrifted,
+		Status:             status,
+		ObservedGeneration: tc.Generation,
+		Reason:             reason,
+		Message:            message,
+	})
+}
+
+// pdConfigDriftValues returns the declared values of the items in pdOnlineConfigSections which are different
+// online, the items unknown to PD are ignored
+func pdConfigDriftValues(declared, online *config.GenericConfig) map[string]interface{} {
+	drifted := map[string]interface{}{}
+	for _, section := range pdOnlineConfigSections {
+		v := declared.Get(section)
+		if v == nil {
+			continue
+		}
+		for key, want := range flattenPDConfig(section, v.Interface()) {
+			if key == pdSchedulersKey {
+				continue
+			}
+			got := online.Get(key)
+			if got == nil {
+				continue
+			}
+			if !pdConfigValueEqual(want, got.Interface()) {
+				drifted[key] = want
+			}
+		}
+	}
+	return drifted
+}
+
+// flattenPDConfig returns the leaf items of the config table with the dotted keys
+func flattenPDConfig(prefix string, v interface{}) map[string]interface{} {
+	items := map[string]interface{}{}
+	table, ok := v.(map[string]interface{})
+	if !ok {
+		items[prefix] = v
+		return items
+	}
+	for k, sub := range table {
+		for key, leaf := range flattenPDConfig(prefix+"."+k, sub) {
+			items[key] = leaf
+		}
+	}
+	return items
+}
+
+// pdConfigValueEqual compares the declared value decoded from TOML or JSON with the online one returned by PD
+// in JSON, the durations are compared by their values as PD formats them like 1h0m0s
+func pdConfigValueEqual(declared, online interface{}) bool {
+	switch d := declared.(type) {
+	case string:
+		o, ok := online.(string)
+		if !ok {
+			return false
+		}
+		if d == o {
+			return true
+		}
+		dd, err1 := time.ParseDuration(d)
+		od, err2 := time.ParseDuration(o)
+		return err1 == nil && err2 == nil && dd == od
+	case int64, int, float64:
+		o, ok := online.(float64)
+		return ok && toFloat64(d) == o
+	case []interface{}, []string:
+		ds := toInterfaceSlice(d)
+		// the string slices like replication.location-labels are formatted as comma-separated strings
+		if o, ok := online.(string); ok {
+			elems := make([]string, 0, len(ds))
+			for _, e := range ds {
+				elems = append(elems, fmt.Sprint(e))
+			}
+			return strings.Join(elems, ",") == o
+		}
+		o, ok := online.([]interface{})
+		if !ok || len(o) != len(ds) {
+			return false
+		}
+		for i := range ds {
+			if !pdConfigValueEqual(ds[i], o[i]) {
+				return false
+			}
+		}
+		return true
+	}
+	return reflect.DeepEqual(declared, online)
+}
+
+// toInterfaceSlice returns the elements of the array decoded from TOML or JSON
+func toInterfaceSlice(v interface{}) []interface{} {
+	switch s := v.(type) {
+	case []interface{}:
+		return s
+	case []string:
+		r := make([]interface{}, 0, len(s))
+		for _, e := range s {
+			r = append(r, e)
+		}
+		return r
+	}
+	return nil
+}
+
+func toFloat64(v interface{}) float64 {
+	switch n := v.(type) {
+	case int64:
+		return float64(n)
+	case int:
+		return float64(n)
+	case float64:
+		return n
+	}
+	return 0
+}
+
+// pdSchedulersDrift returns the schedulers without args in schedule.schedulers-v2 which are not running,
+// and the ones declared disabled but running
+func pdSchedulersDrift(declared *config.GenericConfig, running []string) (missing, disabled []string) {
+	v := declared.Get(pdSchedulersKey)
+	if v == nil {
+		return nil, nil
+	}
+	var entries []map[string]interface{}
+	switch s := v.Interface().(type) {
+	case []map[string]interface{}:
+		entries = s
+	case []interface{}:
+		for _, e := range s {
+			if m, ok := e.(map[string]interface{}); ok {
+				entries = append(entries, m)
+			}
+		}
+	}
+
+	isRunning := map[string]bool{}
+	for _, name := range running {
+		isRunning[name] = true
+	}
+	for _, e := range entries {
+		typ, _ := e["type"].(string)
+		if typ == "" {
+			continue
+		}
+		// the schedulers with args, e.g. evict-leader, are created for specific stores
+		if args := toInterfaceSlice(e["args"]); len(args) > 0 {
+			continue
+		}
+		name := typ + "-scheduler"
+		if off, _ := e["disable"].(bool); off {
+			if isRunning[name] {
+				disabled = append(disabled, name)
+			}
+		} else if !isRunning[name] {
+			missing = append(missing, name)
+		}
+	}
+	sort.Strings(missing)
+	sort.Strings(disabled)
+	return missing, disabled
+}
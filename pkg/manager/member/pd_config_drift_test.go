@@ -0,0 +1,186 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/apis/util/config"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/pdapi"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSyncPDConfigDrift(t *testing.T) {
+	declared := `
+[schedule]
+leader-schedule-limit = 4
+max-merge-region-keys = 200000
+patrol-region-interval = "100ms"
+
+[[schedule.schedulers-v2]]
+type = "balance-leader"
+
+[[schedule.schedulers-v2]]
+type = "balance-hot-region"
+disable = true
+
+[[schedule.schedulers-v2]]
+type = "evict-leader"
+args = ["1"]
+
+[replication]
+location-labels = ["zone", "host"]
+
+[log]
+level = "debug"
+`
+	online := map[string]interface{}{
+		"schedule": map[string]interface{}{
+			"leader-schedule-limit":  float64(4),
+			"max-merge-region-keys":  float64(200000),
+			"patrol-region-interval": "100ms",
+		},
+		"replication": map[string]interface{}{
+			"location-labels": "zone,host",
+		},
+		"log": map[string]interface{}{
+			"level": "info",
+		},
+	}
+	runningSchedulers := []string{"balance-leader-scheduler", "balance-region-scheduler"}
+
+	tests := []struct {
+		name              string
+		policy            v1alpha1.PDConfigDriftPolicy
+		changeOnline      func(online map[string]interface{}) []string
+		expectCondition   metav1.ConditionStatus
+		expectReason      string
+		expectConfig      map[string]interface{}
+		expectAdded       []string
+		expectRemoved     []string
+		expectNoCondition bool
+	}{
+		{
+			name:              "drift is ignored by default",
+			expectNoCondition: true,
+		},
+		{
+			name:            "config in sync",
+			policy:          v1alpha1.PDConfigDriftPolicyReport,
+			expectCondition: metav1.ConditionFalse,
+			expectReason:    v1alpha1.ConfigDriftReasonInSync,
+		},
+		{
+			name:   "drift is reported",
+			policy: v1alpha1.PDConfigDriftPolicyReport,
+			changeOnline: func(online map[string]interface{}) []string {
+				online["schedule"].(map[string]interface{})["leader-schedule-limit"] = float64(64)
+				return []string{"balance-hot-region-scheduler"}
+			},
+			expectCondition: metav1.ConditionTrue,
+			expectReason:    v1alpha1.ConfigDriftReasonDrifted,
+		},
+		{
+			name:   "drift is reverted",
+			policy: v1alpha1.PDConfigDriftPolicyRevert,
+			changeOnline: func(online map[string]interface{}) []string {
+				online["schedule"].(map[string]interface{})["patrol-region-interval"] = "10ms"
+				online["replication"].(map[string]interface{})["location-labels"] = "zone"
+				return []string{"balance-hot-region-scheduler"}
+			},
+			expectCondition: metav1.ConditionFalse,
+			expectReason:    v1alpha1.ConfigDriftReasonInSync,
+			expectConfig: map[string]interface{}{
+				"schedule.patrol-region-interval": "100ms",
+				"replication.location-labels":     []interface{}{"zone", "host"},
+			},
+			expectAdded:   []string{"balance-leader-scheduler"},
+			expectRemoved: []string{"balance-hot-region-scheduler"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+
+			pmm, _, _ := newFakePDMemberManager()
+			tc := newTidbClusterForPD()
+			tc.Spec.PD.ConfigDriftPolicy = tt.policy
+			tc.Spec.PD.Config = v1alpha1.NewPDConfig()
+			g.Expect(tc.Spec.PD.Config.UnmarshalTOML([]byte(declared))).To(Succeed())
+			tc.Status.PD.Members = map[string]v1alpha1.PDMember{
+				"test-pd-0": {Name: "test-pd-0", Health: true},
+				"test-pd-1": {Name: "test-pd-1", Health: true},
+				"test-pd-2": {Name: "test-pd-2", Health: true},
+			}
+
+			values := map[string]interface{}{}
+			for k, v := range online {
+				section := map[string]interface{}{}
+				for sk, sv := range v.(map[string]interface{}) {
+					section[sk] = sv
+				}
+				values[k] = section
+			}
+			schedulers := runningSchedulers
+			if tt.changeOnline != nil {
+				schedulers = tt.changeOnline(values)
+			}
+			current := config.New(values)
+
+			pdClient := controller.NewFakePDClient(pmm.deps.PDControl.(*pdapi.FakePDControl), tc)
+			pdClient.AddReaction(pdapi.GetOnlineConfigActionType, func(action *pdapi.Action) (interface{}, error) {
+				return current, nil
+			})
+			pdClient.AddReaction(pdapi.GetSchedulersActionType, func(action *pdapi.Action) (interface{}, error) {
+				return schedulers, nil
+			})
+			var setConfig map[string]interface{}
+			pdClient.AddReaction(pdapi.SetConfigActionType, func(action *pdapi.Action) (interface{}, error) {
+				setConfig = action.Config
+				return nil, nil
+			})
+			var added, removed []string
+			pdClient.AddReaction(pdapi.AddSchedulerActionType, func(action *pdapi.Action) (interface{}, error) {
+				added = append(added, action.Name)
+				return nil, nil
+			})
+			pdClient.AddReaction(pdapi.RemoveSchedulerActionType, func(action *pdapi.Action) (interface{}, error) {
+				removed = append(removed, action.Name)
+				return nil, nil
+			})
+
+			g.Expect(pmm.syncPDConfigDrift(tc)).To(Succeed())
+			cond := meta.FindStatusCondition(tc.Status.PD.Conditions, v1alpha1.ConditionTypeConfigDrifted)
+			if tt.expectNoCondition {
+				g.Expect(cond).To(BeNil())
+				return
+			}
+			g.Expect(cond).NotTo(BeNil())
+			g.Expect(cond.Status).To(Equal(tt.expectCondition))
+			g.Expect(cond.Reason).To(Equal(tt.expectReason))
+			if tt.expectConfig == nil {
+				g.Expect(setConfig).To(BeNil())
+			} else {
+				g.Expect(setConfig).To(Equal(tt.expectConfig))
+			}
+			g.Expect(added).To(Equal(tt.expectAdded))
+			g.Expect(removed).To(Equal(tt.expectRemoved))
+		})
+	}
+}
@@ -0,0 +1,210 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	podutil "k8s.io/kubernetes/pkg/api/v1/pod"
+)
+
+// BeginPDDisruption records a voluntary disruption of the PD pod in tc.Status.PD.Disruptions before it's started.
+// It refuses the disruption if it leaves fewer than quorum healthy PD members, taking the other in-flight
+// disruptions into account, unless the tc is annotated with `tidb.pingcap.com/pd-allow-quorum-loss: "true"`.
+//
+// The record is patched with the resourceVersion of tc as a precondition, so concurrent disruptions requested
+// by different sources, such as the upgrader and the pod controller, can not both pass the check.
+func BeginPDDisruption(deps *controller.Dependencies, tc *v1alpha1.TidbCluster, podName string, source v1alpha1.PDDisruptionSource) error {
+	ns := tc.GetNamespace()
+	tcName := tc.GetName()
+
+	if _, ok := tc.Status.PD.Disruptions[podName]; ok {
+		return nil
+	}
+	if err := checkPDDisruptionQuorum(tc, podName, source); err != nil {
+		return err
+	}
+
+	disruption := &v1alpha1.PDDisruptionStatus{
+		Source:    source,
+		BeginTime: metav1.Now(),
+	}
+	pod, err := deps.PodLister.Pods(ns).Get(podName)
+	if err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("BeginPDDisruption: failed to get pod %s/%s for tc %s/%s, error: %s", ns, podName, ns, tcName, err)
+	}
+	if err == nil {
+		disruption.PodUID = pod.UID
+	}
+	metadata := map[string]interface{}{}
+	if tc.ResourceVersion != "" {
+		metadata["resourceVersion"] = tc.ResourceVersion
+	}
+	data, err := json.Marshal(map[string]interface{}{
+		"metadata": metadata,
+		"status": map[string]interface{}{
+			"pd": map[string]interface{}{
+				"disruptions": map[string]interface{}{
+					podName: disruption,
+				},
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	updated, err := deps.Clientset.PingcapV1alpha1().TidbClusters(ns).Patch(context.TODO(), tcName, types.MergePatchType, data, metav1.PatchOptions{})
+	if err != nil {
+		if errors.IsConflict(err) {
+			return controller.RequeueErrorf("tc[%s/%s] is changed concurrently, recheck the disruption of pd pod %s", ns, tcName, podName)
+		}
+		return fmt.Errorf("BeginPDDisruption: failed to record the disruption of pd pod %s in tc %s/%s, error: %v", podName, ns, tcName, err)
+	}
+
+	tc.ResourceVersion = updated.ResourceVersion
+	tc.Status.PD.Disruptions = updated.Status.PD.Disruptions
+	klog.Infof("tc[%s/%s] begins the %s disruption of pd pod %s", ns, tcName, source, podName)
+	return nil
+}
+
+// EndPDDisruption removes the record of the voluntary disruption of the PD pod
+func EndPDDisruption(deps *controller.Dependencies, tc *v1alpha1.TidbCluster, podName string) error {
+	ns := tc.GetNamespace()
+	tcName := tc.GetName()
+
+	if _, ok := tc.Status.PD.Disruptions[podName]; !ok {
+		return nil
+	}
+	data, err := json.Marshal(map[string]interface{}{
+		"status": map[string]interface{}{
+			"pd": map[string]interface{}{
+				"disruptions": map[string]interface{}{
+					podName: nil,
+				},
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	updated, err := deps.Clientset.PingcapV1alpha1().TidbClusters(ns).Patch(context.TODO(), tcName, types.MergePatchType, data, metav1.PatchOptions{})
+	if err != nil {
+		return fmt.Errorf("EndPDDisruption: failed to remove the disruption of pd pod %s in tc %s/%s, error: %v", podName, ns, tcName, err)
+	}
+
+	tc.ResourceVersion = updated.ResourceVersion
+	tc.Status.PD.Disruptions = updated.Status.PD.Disruptions
+	klog.Infof("tc[%s/%s] ends the disruption of pd pod %s", ns, tcName, podName)
+	return nil
+}
+
+// checkPDDisruptionQuorum checks whether the disruption of the PD pod leaves at least quorum healthy PD members.
+// Members of the pods recorded in tc.Status.PD.Disruptions are treated as unhealthy.
+func checkPDDisruptionQuorum(tc *v1alpha1.TidbCluster, podName string, source v1alpha1.PDDisruptionSource) error {
+	total, healthy := 0, 0
+	targetIsMember := false
+	for _, member := range tc.Status.PD.Members {
+		memberPodName := pdMemberPodName(member.Name)
+		if memberPodName == podName {
+			targetIsMember = true
+		}
+		total++
+		if _, disrupted := tc.Status.PD.Disruptions[memberPodName]; member.Health && !disrupted && memberPodName != podName {
+			healthy++
+		}
+	}
+	// disruptions of peer members are recorded in the TidbClusters they belong to,
+	// here we can only rely on their health
+	for _, member := range tc.Status.PD.PeerMembers {
+		total++
+		if member.Health {
+			healthy++
+		}
+	}
+
+	if source == v1alpha1.PDDisruptionSourceScaleIn && targetIsMember {
+		// the member is deleted from the PD cluster
+		total--
+	}
+	if total == 0 {
+		return nil
+	}
+	quorum := total/2 + 1
+	if healthy >= quorum {
+		return nil
+	}
+
+	// Clusters with one or two PD members can not tolerate any member failure, every disruption of them
+	// loses the quorum, so it's only allowed if the user explicitly accepts the unavailability.
+	if tc.Annotations[label.AnnPDAllowQuorumLossKey] == label.AnnPDAllowQuorumLossVal {
+		klog.Warningf("tc[%s/%s] allows the %s disruption of pd pod %s breaking the quorum, it leaves %d healthy pd members less than quorum %d",
+			tc.GetNamespace(), tc.GetName(), source, podName, healthy, quorum)
+		return nil
+	}
+	return controller.RequeueErrorf("tc[%s/%s] refuses the %s disruption of pd pod %s, it leaves %d healthy pd members (%d in-flight disruptions) less than quorum %d, "+
+		"set the annotation %s=%s to allow it",
+		tc.GetNamespace(), tc.GetName(), source, podName, healthy, len(tc.Status.PD.Disruptions), quorum,
+		label.AnnPDAllowQuorumLossKey, label.AnnPDAllowQuorumLossVal)
+}
+
+// syncPDDisruptions removes the records of the finished voluntary disruptions, a disruption is finished when
+// - the pod is recreated, ready and its member is healthy, or
+// - the pod is gone and its member has been deleted from the PD cluster
+func syncPDDisruptions(deps *controller.Dependencies, tc *v1alpha1.TidbCluster) error {
+	ns := tc.GetNamespace()
+	for podName, disruption := range tc.Status.PD.Disruptions {
+		var member *v1alpha1.PDMember
+		for _, m := range tc.Status.PD.Members {
+			if pdMemberPodName(m.Name) == podName {
+				m := m
+				member = &m
+				break
+			}
+		}
+
+		finished := false
+		pod, err := deps.PodLister.Pods(ns).Get(podName)
+		switch {
+		case errors.IsNotFound(err):
+			finished = member == nil
+		case err != nil:
+			return fmt.Errorf("syncPDDisruptions: failed to get pod %s/%s, error: %v", ns, podName, err)
+		default:
+			finished = disruption == nil ||
+				pod.UID != disruption.PodUID && podutil.IsPodReady(pod) && member != nil && member.Health
+		}
+		if finished {
+			if err := EndPDDisruption(deps, tc, podName); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// pdMemberPodName returns the pod name of the PD member, the member name
+// is the FQDN of the pod if the cluster domain is set.
+func pdMemberPodName(memberName string) string {
+	return strings.SplitN(memberName, ".", 2)[0]
+}
@@ -0,0 +1,203 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestCheckPDDisruptionQuorum(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	newTC := func(replicas int, unhealthy []int, disrupted []int) *v1alpha1.TidbCluster {
+		tc := newTidbClusterForPD()
+		tc.Status.PD.Members = map[string]v1alpha1.PDMember{}
+		for i := 0; i < replicas; i++ {
+			name := fmt.Sprintf("%s.test-pd-peer.default.svc.cluster.local", PdPodName(tc.Name, int32(i)))
+			tc.Status.PD.Members[name] = v1alpha1.PDMember{Name: name, Health: true}
+		}
+		for _, i := range unhealthy {
+			name := fmt.Sprintf("%s.test-pd-peer.default.svc.cluster.local", PdPodName(tc.Name, int32(i)))
+			tc.Status.PD.Members[name] = v1alpha1.PDMember{Name: name, Health: false}
+		}
+		for _, i := range disrupted {
+			if tc.Status.PD.Disruptions == nil {
+				tc.Status.PD.Disruptions = map[string]*v1alpha1.PDDisruptionStatus{}
+			}
+			tc.Status.PD.Disruptions[PdPodName(tc.Name, int32(i))] = &v1alpha1.PDDisruptionStatus{Source: v1alpha1.PDDisruptionSourceUpgrade}
+		}
+		return tc
+	}
+
+	tests := []struct {
+		name      string
+		tc        *v1alpha1.TidbCluster
+		target    int32
+		source    v1alpha1.PDDisruptionSource
+		expectErr bool
+	}{
+		{
+			name:   "all healthy",
+			tc:     newTC(3, nil, nil),
+			target: 0,
+			source: v1alpha1.PDDisruptionSourceUpgrade,
+		},
+		{
+			name:      "another member is unhealthy",
+			tc:        newTC(3, []int{1}, nil),
+			target:    0,
+			source:    v1alpha1.PDDisruptionSourceUpgrade,
+			expectErr: true,
+		},
+		{
+			name:      "another disruption is in flight",
+			tc:        newTC(3, nil, []int{1}),
+			target:    0,
+			source:    v1alpha1.PDDisruptionSourcePodAnnotation,
+			expectErr: true,
+		},
+		{
+			name:   "another disruption is in flight in 5 members",
+			tc:     newTC(5, nil, []int{1}),
+			target: 0,
+			source: v1alpha1.PDDisruptionSourcePodAnnotation,
+		},
+		{
+			name:      "two disruptions are in flight in 5 members",
+			tc:        newTC(5, nil, []int{1, 2}),
+			target:    0,
+			source:    v1alpha1.PDDisruptionSourcePodAnnotation,
+			expectErr: true,
+		},
+		{
+			name:   "disrupt the unhealthy member",
+			tc:     newTC(3, []int{1}, nil),
+			target: 1,
+			source: v1alpha1.PDDisruptionSourceUpgrade,
+		},
+		{
+			name:      "upgrade the only member",
+			tc:        newTC(1, nil, nil),
+			target:    0,
+			source:    v1alpha1.PDDisruptionSourceUpgrade,
+			expectErr: true,
+		},
+		{
+			name: "upgrade the only member allowing quorum loss",
+			tc: func() *v1alpha1.TidbCluster {
+				tc := newTC(1, nil, nil)
+				tc.Annotations = map[string]string{label.AnnPDAllowQuorumLossKey: label.AnnPDAllowQuorumLossVal}
+				return tc
+			}(),
+			target: 0,
+			source: v1alpha1.PDDisruptionSourceUpgrade,
+		},
+		{
+			name:      "upgrade one of two members",
+			tc:        newTC(2, nil, nil),
+			target:    0,
+			source:    v1alpha1.PDDisruptionSourceUpgrade,
+			expectErr: true,
+		},
+		{
+			name:      "upgrade one of two members when the other is disrupted",
+			tc:        newTC(2, nil, []int{1}),
+			target:    0,
+			source:    v1alpha1.PDDisruptionSourceUpgrade,
+			expectErr: true,
+		},
+		{
+			name:   "scale in from 2 to 1",
+			tc:     newTC(2, nil, nil),
+			target: 1,
+			source: v1alpha1.PDDisruptionSourceScaleIn,
+		},
+	}
+
+	for _, tt := range tests {
+		err := checkPDDisruptionQuorum(tt.tc, PdPodName(tt.tc.Name, tt.target), tt.source)
+		if tt.expectErr {
+			g.Expect(err).To(HaveOccurred(), tt.name)
+		} else {
+			g.Expect(err).NotTo(HaveOccurred(), tt.name)
+		}
+	}
+}
+
+func TestPDDisruptionLifecycle(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := context.TODO()
+
+	deps := controller.NewFakeDependencies()
+	podIndexer := deps.KubeInformerFactory.Core().V1().Pods().Informer().GetIndexer()
+
+	tc := newTidbClusterForPD()
+	tc.Status.PD.Members = map[string]v1alpha1.PDMember{}
+	for i := 0; i < 3; i++ {
+		name := PdPodName(tc.Name, int32(i))
+		tc.Status.PD.Members[name] = v1alpha1.PDMember{Name: name, Health: true}
+		g.Expect(podIndexer.Add(&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: tc.Namespace,
+				UID:       types.UID(name),
+			},
+			Status: corev1.PodStatus{
+				Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+			},
+		})).To(Succeed())
+	}
+	_, err := deps.Clientset.PingcapV1alpha1().TidbClusters(tc.Namespace).Create(ctx, tc, metav1.CreateOptions{})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	pod0 := PdPodName(tc.Name, 0)
+	pod1 := PdPodName(tc.Name, 1)
+	g.Expect(BeginPDDisruption(deps, tc, pod0, v1alpha1.PDDisruptionSourceUpgrade)).To(Succeed())
+	g.Expect(tc.Status.PD.Disruptions).To(HaveKey(pod0))
+	g.Expect(tc.Status.PD.Disruptions[pod0].PodUID).To(Equal(types.UID(pod0)))
+
+	// begin again is a no-op
+	g.Expect(BeginPDDisruption(deps, tc, pod0, v1alpha1.PDDisruptionSourceUpgrade)).To(Succeed())
+	// another source is refused while the first disruption is in flight
+	g.Expect(BeginPDDisruption(deps, tc, pod1, v1alpha1.PDDisruptionSourcePodAnnotation)).NotTo(Succeed())
+
+	// the pod is not recreated yet
+	g.Expect(syncPDDisruptions(deps, tc)).To(Succeed())
+	g.Expect(tc.Status.PD.Disruptions).To(HaveKey(pod0))
+
+	// the pod is recreated and ready
+	obj, _, err := podIndexer.GetByKey(tc.Namespace + "/" + pod0)
+	g.Expect(err).NotTo(HaveOccurred())
+	pod := obj.(*corev1.Pod).DeepCopy()
+	pod.UID = "recreated"
+	g.Expect(podIndexer.Update(pod)).To(Succeed())
+	g.Expect(syncPDDisruptions(deps, tc)).To(Succeed())
+	g.Expect(tc.Status.PD.Disruptions).NotTo(HaveKey(pod0))
+
+	g.Expect(BeginPDDisruption(deps, tc, pod1, v1alpha1.PDDisruptionSourcePodAnnotation)).To(Succeed())
+	updated, err := deps.Clientset.PingcapV1alpha1().TidbClusters(tc.Namespace).Get(ctx, tc.Name, metav1.GetOptions{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(updated.Status.PD.Disruptions).To(HaveKey(pod1))
+	g.Expect(updated.Status.PD.Disruptions[pod1].Source).To(Equal(v1alpha1.PDDisruptionSourcePodAnnotation))
+}
@@ -115,7 +115,7 @@ func (f *pdFailover) tryToMarkAPeerAsFailure(tc *v1alpha1.TidbCluster) error {
 		if tc.Status.PD.FailureMembers == nil {
 			tc.Status.PD.FailureMembers = map[string]v1alpha1.PDFailureMember{}
 		}
-		failoverDeadline := pdMember.LastTransitionTime.Add(f.deps.CLIConfig.PDFailoverPeriod)
+		failoverDeadline := pdMember.LastTransitionTime.Add(f.deps.CLIConfig.GetPDFailoverPeriod())
 		_, exist := tc.Status.PD.FailureMembers[pdName]
 
 		if pdMember.Health || time.Now().Before(failoverDeadline) || exist {
@@ -190,6 +190,9 @@ func (f *pdFailover) tryToDeleteAFailureMember(tc *v1alpha1.TidbCluster) error {
 	if err != nil {
 		return err
 	}
+	if err := fencePDMember(f.deps, tc, failurePDName, memberID, failurePodName); err != nil {
+		return err
+	}
 	// invoke deleteMember api to delete a member from the pd cluster
 	if err := controller.GetPDClient(f.deps.PDControl, tc).DeleteMemberByID(memberID); err != nil {
 		klog.Errorf("pd failover[tryToDeleteAFailureMember]: failed to delete member %s/%s(%d), error: %v", ns, failurePodName, memberID, err)
@@ -677,6 +677,7 @@ func TestPDFailoverFailover(t *testing.T) {
 			pdFailover, pvcIndexer, podIndexer, nodeIndexer, fakePDControl, fakePodControl, fakePVCControl := newFakePDFailover(test.failureRecoveryArgs.detectNodeFailure)
 			pdFailover.deps.Recorder = recorder
 			pdClient := controller.NewFakePDClient(fakePDControl, tc)
+			addFencingReactions(pdFailover.deps, pdClient, tc)
 
 			pdClient.AddReaction(pdapi.DeleteMemberByIDActionType, func(action *pdapi.Action) (interface{}, error) {
 				if test.delMemberFailed {
@@ -0,0 +1,122 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	corev1 "k8s.io/api/core/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// pdPreferredLeaderPriority is the leader priority of the PD members preferred by spec.pd.leaderPreference
+	pdPreferredLeaderPriority = 100
+	// pdDefaultLeaderPriority is the leader priority of the other PD members
+	pdDefaultLeaderPriority = 0
+)
+
+// syncPDLeaderPreference applies spec.pd.leaderPreference to the leader priorities of the PD members through
+// the PD API whenever they drift, e.g. after a member is recreated. PD transfers the leader to the healthy
+// member with the highest priority by itself.
+func (m *pdMemberManager) syncPDLeaderPreference(tc *v1alpha1.TidbCluster) error {
+	pref := tc.Spec.PD.LeaderPreference
+	if pref == nil || (len(pref.Zones) == 0 && len(pref.Members) == 0) {
+		return nil
+	}
+	if !tc.PDIsAvailable() {
+		return nil
+	}
+
+	ns := tc.GetNamespace()
+	tcName := tc.GetName()
+	pdCli := controller.GetPDClient(m.deps.PDControl, tc)
+	members, err := pdCli.GetMembers()
+	if err != nil {
+		return fmt.Errorf("failed to get pd members of cluster %s/%s: %v", ns, tcName, err)
+	}
+
+	var errs []error
+	for _, member := range members.Members {
+		name := member.GetName()
+		// the peer members of the other Kubernetes clusters are managed by their own TidbClusters
+		if _, ok := tc.Status.PD.Members[name]; !ok {
+			continue
+		}
+		priority := pdDefaultLeaderPriority
+		if m.isPreferredPDLeader(tc, name) {
+			priority = pdPreferredLeaderPriority
+		}
+		if int(member.GetLeaderPriority()) == priority {
+			continue
+		}
+		if err := pdCli.SetMemberLeaderPriority(name, priority); err != nil {
+			errs = append(errs, fmt.Errorf("failed to set leader priority of pd member %s of cluster %s/%s: %v", name, ns, tcName, err))
+			continue
+		}
+		klog.Infof("set leader priority of pd member %s of cluster %s/%s to %d", name, ns, tcName, priority)
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// isPreferredPDLeader returns whether the PD member matches spec.pd.leaderPreference
+func (m *pdMemberManager) isPreferredPDLeader(tc *v1alpha1.TidbCluster, name string) bool {
+	pref := tc.Spec.PD.LeaderPreference
+	// the member name is the FQDN of the pod if the cluster is across Kubernetes clusters
+	podName := strings.Split(name, ".")[0]
+	for _, pattern := range pref.Members {
+		if matched, _ := path.Match(pattern, podName); matched {
+			return true
+		}
+	}
+	if len(pref.Zones) == 0 {
+		return false
+	}
+
+	zone, err := m.pdMemberZone(tc.GetNamespace(), podName)
+	if err != nil {
+		klog.Warningf("failed to get the zone of pd member %s of cluster %s/%s: %v", name, tc.GetNamespace(), tc.GetName(), err)
+		return false
+	}
+	for _, z := range pref.Zones {
+		if z == zone {
+			return true
+		}
+	}
+	return false
+}
+
+// pdMemberZone returns the zone of the node of the PD pod
+func (m *pdMemberManager) pdMemberZone(ns, podName string) (string, error) {
+	if m.deps.NodeLister == nil {
+		return "", fmt.Errorf("the nodes can't be read without the cluster permission")
+	}
+	pod, err := m.deps.PodLister.Pods(ns).Get(podName)
+	if err != nil {
+		return "", err
+	}
+	if pod.Spec.NodeName == "" {
+		return "", fmt.Errorf("pod %s is not scheduled", podName)
+	}
+	node, err := m.deps.NodeLister.Get(pod.Spec.NodeName)
+	if err != nil {
+		return "", err
+	}
+	return node.Labels[corev1.LabelTopologyZone], nil
+}
@@ -0,0 +1,102 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/pingcap/kvproto/pkg/pdpb"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/pdapi"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSyncPDLeaderPreference(t *testing.T) {
+	tests := []struct {
+		name       string
+		preference *v1alpha1.PDLeaderPreference
+		current    map[string]int32
+		expect     map[string]int
+	}{
+		{
+			name:    "leader preference not set",
+			current: map[string]int32{"test-pd-0": 0, "test-pd-1": 0, "test-pd-2": 0},
+		},
+		{
+			name:       "prefer the members by name",
+			preference: &v1alpha1.PDLeaderPreference{Members: []string{"test-pd-[12]"}},
+			current:    map[string]int32{"test-pd-0": 0, "test-pd-1": 0, "test-pd-2": pdPreferredLeaderPriority},
+			expect:     map[string]int{"test-pd-1": pdPreferredLeaderPriority},
+		},
+		{
+			name:       "prefer the members by zone",
+			preference: &v1alpha1.PDLeaderPreference{Zones: []string{"zone-a"}},
+			current:    map[string]int32{"test-pd-0": 0, "test-pd-1": pdPreferredLeaderPriority, "test-pd-2": 0},
+			expect:     map[string]int{"test-pd-0": pdPreferredLeaderPriority, "test-pd-1": pdDefaultLeaderPriority},
+		},
+		{
+			name:       "the priorities are in sync",
+			preference: &v1alpha1.PDLeaderPreference{Zones: []string{"zone-a"}, Members: []string{"test-pd-2"}},
+			current:    map[string]int32{"test-pd-0": pdPreferredLeaderPriority, "test-pd-1": 0, "test-pd-2": pdPreferredLeaderPriority},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+
+			pmm, podIndexer, _ := newFakePDMemberManager()
+			nodeIndexer := pmm.deps.KubeInformerFactory.Core().V1().Nodes().Informer().GetIndexer()
+			tc := newTidbClusterForPD()
+			tc.Spec.PD.LeaderPreference = tt.preference
+			tc.Status.PD.Members = map[string]v1alpha1.PDMember{}
+			zones := []string{"zone-a", "zone-b", "zone-b"}
+			var members []*pdpb.Member
+			for i, zone := range zones {
+				name := PdPodName(tc.Name, int32(i))
+				tc.Status.PD.Members[name] = v1alpha1.PDMember{Name: name, Health: true}
+				members = append(members, &pdpb.Member{Name: name, LeaderPriority: tt.current[name]})
+				g.Expect(podIndexer.Add(&corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: tc.Namespace},
+					Spec:       corev1.PodSpec{NodeName: "node-" + name},
+				})).To(Succeed())
+				g.Expect(nodeIndexer.Add(&corev1.Node{
+					ObjectMeta: metav1.ObjectMeta{Name: "node-" + name, Labels: map[string]string{corev1.LabelTopologyZone: zone}},
+				})).To(Succeed())
+			}
+			// a peer member of another Kubernetes cluster is skipped
+			members = append(members, &pdpb.Member{Name: "test-pd-0.test-pd-peer.other.svc", LeaderPriority: 1})
+
+			pdClient := controller.NewFakePDClient(pmm.deps.PDControl.(*pdapi.FakePDControl), tc)
+			pdClient.AddReaction(pdapi.GetMembersActionType, func(action *pdapi.Action) (interface{}, error) {
+				return &pdapi.MembersInfo{Members: members}, nil
+			})
+			priorities := map[string]int{}
+			pdClient.AddReaction(pdapi.SetMemberLeaderPriorityActionType, func(action *pdapi.Action) (interface{}, error) {
+				priorities[action.Name] = action.Priority
+				return nil, nil
+			})
+
+			g.Expect(pmm.syncPDLeaderPreference(tc)).To(Succeed())
+			if tt.expect == nil {
+				g.Expect(priorities).To(BeEmpty())
+			} else {
+				g.Expect(priorities).To(Equal(tt.expect))
+			}
+		})
+	}
+}
@@ -29,7 +29,10 @@ import (
 	"github.com/pingcap/tidb-operator/pkg/manager/suspender"
 	mngerutils "github.com/pingcap/tidb-operator/pkg/manager/utils"
 	"github.com/pingcap/tidb-operator/pkg/manager/volumes"
+	"github.com/pingcap/tidb-operator/pkg/metrics"
+	"github.com/pingcap/tidb-operator/pkg/pdapi"
 	"github.com/pingcap/tidb-operator/pkg/util"
+	utiltidbcluster "github.com/pingcap/tidb-operator/pkg/util/tidbcluster"
 
 	"github.com/Masterminds/semver"
 	apps "k8s.io/api/apps/v1"
@@ -101,8 +104,33 @@ func (m *pdMemberManager) Sync(tc *v1alpha1.TidbCluster) error {
 		return err
 	}
 
+	// Report the failed data directory pre-checks of the PD pods
+	if err := syncDataDirPreCheckStatus(m.deps, tc, v1alpha1.PDMemberType, tc.Spec.PD.DataDirPreCheck); err != nil {
+		return err
+	}
+
 	// Sync PD StatefulSet
-	return m.syncPDStatefulSetForTidbCluster(tc)
+	if err := m.syncPDStatefulSetForTidbCluster(tc); err != nil {
+		return err
+	}
+
+	// Sync the store limits of TiKV
+	if err := m.syncTiKVStoreLimits(tc); err != nil {
+		return err
+	}
+
+	// Sync the leader priorities of the PD members
+	if err := m.syncPDLeaderPreference(tc); err != nil {
+		return err
+	}
+
+	// Check the drift of the online config of PD
+	if err := m.syncPDConfigDrift(tc); err != nil {
+		return err
+	}
+
+	// Sync PD PodDisruptionBudget
+	return syncPodDisruptionBudget(m.deps, tc, pdPDBComponent(tc))
 }
 
 func (m *pdMemberManager) syncPDServiceForTidbCluster(tc *v1alpha1.TidbCluster) error {
@@ -114,7 +142,7 @@ func (m *pdMemberManager) syncPDServiceForTidbCluster(tc *v1alpha1.TidbCluster)
 	ns := tc.GetNamespace()
 	tcName := tc.GetName()
 
-	newSvc := m.getNewPDServiceForTidbCluster(tc)
+	newSvc := getNewPDServiceForTidbCluster(tc)
 	oldSvcTmp, err := m.deps.ServiceLister.Services(ns).Get(controller.PDMemberName(tcName))
 	if errors.IsNotFound(err) {
 		err = controller.SetServiceLastAppliedConfigAnnotation(newSvc)
@@ -193,6 +221,10 @@ func (m *pdMemberManager) syncPDStatefulSetForTidbCluster(tc *v1alpha1.TidbClust
 		klog.Errorf("failed to sync TidbCluster: [%s/%s]'s status, error: %v", ns, tcName, err)
 	}
 
+	if err := syncPDDisruptions(m.deps, tc); err != nil {
+		klog.Errorf("failed to sync TidbCluster: [%s/%s]'s pd disruptions, error: %v", ns, tcName, err)
+	}
+
 	if tc.Spec.Paused {
 		klog.V(4).Infof("tidb cluster %s/%s is paused, skip syncing for pd statefulset", tc.GetNamespace(), tc.GetName())
 		return nil
@@ -206,6 +238,9 @@ func (m *pdMemberManager) syncPDStatefulSetForTidbCluster(tc *v1alpha1.TidbClust
 	if err != nil {
 		return err
 	}
+	if tc.IsStandby() {
+		return syncStandbyStatefulSet(m.deps, tc, tc.PDStsDesiredOrdinals(true), newPDSet, oldPDSet)
+	}
 	if setNotExist {
 		err = mngerutils.SetStatefulSetLastAppliedConfigAnnotation(newPDSet)
 		if err != nil {
@@ -232,16 +267,27 @@ func (m *pdMemberManager) syncPDStatefulSetForTidbCluster(tc *v1alpha1.TidbClust
 		}
 	}
 
+	// Pod template changes made in the middle of an upgrade or a scale are held until it
+	// completes, unless the pending changes are applied explicitly. The replicas are not held.
+	if err := holdPendingChanges(tc, v1alpha1.PDMemberType, newPDSet, oldPDSet); err != nil {
+		return err
+	}
+
 	// Scaling takes precedence over upgrading because:
 	// - if a pd fails in the upgrading, users may want to delete it or add
 	//   new replicas
 	// - it's ok to scale in the middle of upgrading (in statefulset controller
 	//   scaling takes precedence over upgrading too)
-	if err := m.scaler.Scale(tc, oldPDSet, newPDSet); err != nil {
+	if isStandbyStatefulSet(oldPDSet) && len(tc.Status.PD.Members) == 0 {
+		// the PD cluster of a promoted warm standby cluster is bootstrapped like a new cluster,
+		// all the members are created at once instead of being scaled out one by one
+		klog.Infof("tidbcluster: [%s/%s]'s pd is not bootstrapped, create %d members", ns, tcName, *newPDSet.Spec.Replicas)
+	} else if err := m.scaler.Scale(tc, oldPDSet, newPDSet); err != nil {
 		return err
 	}
 
-	if m.deps.CLIConfig.AutoFailover {
+	// the failover is paused while the pods are rejected by the quota, the new members can't be created either
+	if m.deps.CLIConfig.IsAutoFailover() && !tc.IsQuotaExceeded() {
 		if m.shouldRecover(tc) {
 			m.failover.Recover(tc)
 		} else if tc.Spec.PD.MaxFailoverCount != nil && *tc.Spec.PD.MaxFailoverCount > 0 && (tc.PDAllPodsStarted() && !tc.PDAllMembersReady() || tc.PDAutoFailovering()) {
@@ -326,6 +372,7 @@ func (m *pdMemberManager) syncTidbClusterStatus(tc *v1alpha1.TidbCluster, set *a
 	healthInfo, err := pdClient.GetHealth()
 	if err != nil {
 		tc.Status.PD.Synced = false
+		syncPDAvailableCondition(tc, err)
 		// get endpoints info
 		eps, epErr := m.deps.EndpointLister.Endpoints(ns).Get(controller.PDMemberName(tcName))
 		if epErr != nil {
@@ -338,17 +385,23 @@ func (m *pdMemberManager) syncTidbClusterStatus(tc *v1alpha1.TidbCluster, set *a
 		return err
 	}
 
-	cluster, err := pdClient.GetCluster()
-	if err != nil {
-		tc.Status.PD.Synced = false
-		return err
+	// the cluster ID never changes once the cluster is bootstrapped, so it's fetched only once
+	if tc.Status.ClusterID == "" {
+		cluster, err := pdClient.GetCluster()
+		if err != nil {
+			tc.Status.PD.Synced = false
+			syncPDAvailableCondition(tc, err)
+			return err
+		}
+		tc.Status.ClusterID = strconv.FormatUint(cluster.Id, 10)
 	}
-	tc.Status.ClusterID = strconv.FormatUint(cluster.Id, 10)
 	leader, err := pdClient.GetPDLeader()
 	if err != nil {
 		tc.Status.PD.Synced = false
+		syncPDAvailableCondition(tc, err)
 		return err
 	}
+	syncPDAvailableCondition(tc, nil)
 
 	rePDMembers, err := regexp.Compile(fmt.Sprintf(pdMemberLimitPattern, tc.Name, tc.Name, tc.Namespace, controller.FormatClusterDomainForRegex(tc.Spec.ClusterDomain)))
 	if err != nil {
@@ -416,6 +469,28 @@ func (m *pdMemberManager) syncTidbClusterStatus(tc *v1alpha1.TidbCluster, set *a
 	return nil
 }
 
+// syncPDAvailableCondition sets the PDAvailable condition of tc by the result of the PD API requests,
+// the reason of the condition and the metrics of the failures tell why the requests fail, e.g. PD is
+// electing a leader or PD is unreachable.
+func syncPDAvailableCondition(tc *v1alpha1.TidbCluster, err error) {
+	if tc.IsStandby() {
+		// PD is not running in a standby cluster
+		return
+	}
+	status := corev1.ConditionTrue
+	reason := utiltidbcluster.PDAPIAvailable
+	message := "PD API requests succeed"
+	if err != nil {
+		errReason := pdapi.ClassifyAPIError(err)
+		metrics.ClusterPDAPIErrors.WithLabelValues(tc.Namespace, tc.Name, string(errReason)).Inc()
+		status = corev1.ConditionFalse
+		reason = string(errReason)
+		message = err.Error()
+	}
+	cond := utiltidbcluster.NewTidbClusterCondition(v1alpha1.TidbClusterPDAvailable, status, reason, message)
+	utiltidbcluster.SetTidbClusterCondition(&tc.Status, *cond)
+}
+
 // syncPDConfigMap syncs the configmap of PD
 func (m *pdMemberManager) syncPDConfigMap(tc *v1alpha1.TidbCluster, set *apps.StatefulSet) (*corev1.ConfigMap, error) {
 	// For backward compatibility, only sync tidb configmap when .pd.config is non-nil
@@ -438,10 +513,11 @@ func (m *pdMemberManager) syncPDConfigMap(tc *v1alpha1.TidbCluster, set *apps.St
 	if err != nil {
 		return nil, err
 	}
+	controller.PropagateMetadata(newCm, tc.BasePDSpec().Metadata())
 	return m.deps.TypedControl.CreateOrUpdateConfigMap(tc, newCm)
 }
 
-func (m *pdMemberManager) getNewPDServiceForTidbCluster(tc *v1alpha1.TidbCluster) *corev1.Service {
+func getNewPDServiceForTidbCluster(tc *v1alpha1.TidbCluster) *corev1.Service {
 	ns := tc.Namespace
 	tcName := tc.Name
 	svcName := controller.PDMemberName(tcName)
@@ -489,9 +565,13 @@ func (m *pdMemberManager) getNewPDServiceForTidbCluster(tc *v1alpha1.TidbCluster
 		}
 	}
 
+	controller.PropagateMetadata(pdService, tc.BasePDSpec().Metadata())
 	if tc.Spec.PreferIPv6 {
 		SetServiceWhenPreferIPv6(pdService)
 	}
+	if svcSpec != nil {
+		SetServiceIPFamilies(pdService, svcSpec)
+	}
 
 	return pdService
 }
@@ -532,6 +612,7 @@ func getNewPDHeadlessServiceForTidbCluster(tc *v1alpha1.TidbCluster) *corev1.Ser
 		},
 	}
 
+	controller.PropagateMetadata(svc, tc.BasePDSpec().Metadata())
 	if tc.Spec.PreferIPv6 {
 		SetServiceWhenPreferIPv6(svc)
 	}
@@ -699,6 +780,9 @@ func getNewPDSetForTidbCluster(tc *v1alpha1.TidbCluster, cm *corev1.ConfigMap) (
 	if len(initContainers) > 0 {
 		podSecurityContext.Sysctls = []corev1.Sysctl{}
 	}
+	if c := buildDataDirPreCheckContainer(tc, tc.Spec.PD.DataDirPreCheck, corev1.VolumeMount{Name: dataVolumeName, MountPath: constants.PDDataVolumeMountPath}, controller.ContainerResource(tc.Spec.PD.ResourceRequirements)); c != nil {
+		initContainers = append(initContainers, *c)
+	}
 
 	storageRequest, err := controller.ParseStorageRequest(tc.Spec.PD.Requests)
 	if err != nil {
@@ -781,6 +865,7 @@ func getNewPDSetForTidbCluster(tc *v1alpha1.TidbCluster, cm *corev1.ConfigMap) (
 	}
 
 	podSpec := basePDSpec.BuildPodSpec()
+	setZoneAffinity(&podSpec, tc, v1alpha1.PDMemberType)
 	if basePDSpec.HostNetwork() {
 		env = append(env, corev1.EnvVar{
 			Name: "POD_NAME",
@@ -793,6 +878,10 @@ func getNewPDSetForTidbCluster(tc *v1alpha1.TidbCluster, cm *corev1.ConfigMap) (
 	}
 	pdContainer.Env = util.AppendEnv(env, basePDSpec.Env())
 	pdContainer.EnvFrom = basePDSpec.EnvFrom()
+	setLifecycle(&pdContainer, basePDSpec.Lifecycle())
+	if tc.Spec.PD.PreStopHook != nil {
+		setPreStopHook(&podSpec, &pdContainer, tc.PDPreStopHookTimeout(), buildDiscoveryPreStopScript(tc))
+	}
 	podSpec.Volumes = append(vols, basePDSpec.AdditionalVolumes()...)
 	podSpec.Containers, err = MergePatchContainers([]corev1.Container{pdContainer}, basePDSpec.AdditionalContainers())
 	if err != nil {
@@ -844,6 +933,10 @@ func getNewPDSetForTidbCluster(tc *v1alpha1.TidbCluster, cm *corev1.ConfigMap) (
 	}
 
 	pdSet.Spec.VolumeClaimTemplates = append(pdSet.Spec.VolumeClaimTemplates, additionalPVCs...)
+	propagateStatefulSetMetadata(pdSet, basePDSpec.Metadata())
+	if err := ApplyPodTemplatePatches(&pdSet.Spec.Template, basePDSpec.PodTemplatePatches()); err != nil {
+		return nil, fmt.Errorf("failed to apply pod template patches for PD of [%s/%s], error: %v", tc.Namespace, tc.Name, err)
+	}
 	return pdSet, nil
 }
 
@@ -853,6 +946,7 @@ func getPDConfigMap(tc *v1alpha1.TidbCluster) (*corev1.ConfigMap, error) {
 		return nil, nil
 	}
 	config := tc.Spec.PD.Config.DeepCopy() // use copy to not update tc spec
+	applyConfigProfile(config.GenericConfig, tc, v1alpha1.PDMemberType, tc.PDVersion())
 
 	clusterVersionGE4, err := clusterVersionGreaterThanOrEqualTo4(tc.PDVersion())
 	if err != nil {
@@ -40,6 +40,7 @@ import (
 	"github.com/pingcap/tidb-operator/pkg/manager/suspender"
 	"github.com/pingcap/tidb-operator/pkg/manager/volumes"
 	"github.com/pingcap/tidb-operator/pkg/pdapi"
+	utiltidbcluster "github.com/pingcap/tidb-operator/pkg/util/tidbcluster"
 )
 
 func TestPDMemberManagerSyncCreate(t *testing.T) {
@@ -463,6 +464,32 @@ func TestPDMemberManagerSyncUpdate(t *testing.T) {
 				g.Expect(tc.Status.PD.Members).To(BeNil())
 			},
 		},
+		{
+			name: "skip fetching cluster ID if it's known",
+			modify: func(tc *v1alpha1.TidbCluster) {
+				tc.Spec.PD.Replicas = 5
+				tc.Status.ClusterID = "1"
+			},
+			pdHealth: &pdapi.HealthInfo{Healths: []pdapi.MemberHealth{
+				{Name: "pd1", MemberID: uint64(1), ClientUrls: []string{"http://test-pd-1.test-pd-peer.default.svc:2379"}, Health: true},
+			}},
+			errWhenUpdateStatefulSet:   false,
+			errWhenUpdatePDService:     false,
+			errWhenUpdatePDPeerService: false,
+			errWhenGetCluster:          true,
+			errWhenGetPDHealth:         false,
+			err:                        false,
+			expectPDServiceFn:          nil,
+			expectPDPeerServiceFn:      nil,
+			expectStatefulSetFn: func(g *GomegaWithT, set *apps.StatefulSet, err error) {
+				g.Expect(err).NotTo(HaveOccurred())
+			},
+			expectTidbClusterFn: func(g *GomegaWithT, tc *v1alpha1.TidbCluster) {
+				g.Expect(tc.Status.PD.Synced).To(BeTrue())
+				g.Expect(tc.Status.ClusterID).To(Equal("1"))
+				g.Expect(len(tc.Status.PD.Members)).To(Equal(1))
+			},
+		},
 		{
 			name: "patch pd container lifecycle configuration when sync cluster  ",
 			modify: func(tc *v1alpha1.TidbCluster) {
@@ -927,6 +954,28 @@ func TestPDMemberManagerSyncPDSts(t *testing.T) {
 	}
 }
 
+func TestSyncPDAvailableCondition(t *testing.T) {
+	g := NewGomegaWithT(t)
+	tc := newTidbClusterForPD()
+
+	syncPDAvailableCondition(tc, fmt.Errorf("[PD:apiutil:ErrRedirect]redirect failed"))
+	cond := utiltidbcluster.GetTidbClusterCondition(tc.Status, v1alpha1.TidbClusterPDAvailable)
+	g.Expect(cond).NotTo(BeNil())
+	g.Expect(cond.Status).To(Equal(corev1.ConditionFalse))
+	g.Expect(cond.Reason).To(Equal(string(pdapi.APIErrorLeaderChanging)))
+
+	syncPDAvailableCondition(tc, fmt.Errorf("dial tcp 10.0.0.1:2379: connect: connection refused"))
+	cond = utiltidbcluster.GetTidbClusterCondition(tc.Status, v1alpha1.TidbClusterPDAvailable)
+	g.Expect(cond.Status).To(Equal(corev1.ConditionFalse))
+	g.Expect(cond.Reason).To(Equal(string(pdapi.APIErrorUnreachable)))
+
+	syncPDAvailableCondition(tc, nil)
+	cond = utiltidbcluster.GetTidbClusterCondition(tc.Status, v1alpha1.TidbClusterPDAvailable)
+	g.Expect(cond.Status).To(Equal(corev1.ConditionTrue))
+	g.Expect(cond.Reason).To(Equal(utiltidbcluster.PDAPIAvailable))
+	g.Expect(tc.Status.Conditions).To(HaveLen(1))
+}
+
 func newFakePDMemberManager() (*pdMemberManager, cache.Indexer, cache.Indexer) {
 	fakeDeps := controller.NewFakeDependencies()
 	podIndexer := fakeDeps.KubeInformerFactory.Core().V1().Pods().Informer().GetIndexer()
@@ -2675,8 +2724,7 @@ func TestGetNewPdServiceForTidbCluster(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			pmm, _, _ := newFakePDMemberManager()
-			svc := pmm.getNewPDServiceForTidbCluster(&tt.tc)
+			svc := getNewPDServiceForTidbCluster(&tt.tc)
 			if diff := cmp.Diff(tt.expected, *svc); diff != "" {
 				t.Errorf("unexpected Service (-want, +got): %s", diff)
 			}
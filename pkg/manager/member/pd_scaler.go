@@ -15,6 +15,7 @@ package member
 
 import (
 	"fmt"
+	"strconv"
 
 	"github.com/pingcap/advanced-statefulset/client/apis/apps/v1/helper"
 	apps "k8s.io/api/apps/v1"
@@ -39,12 +40,20 @@ func NewPDScaler(deps *controller.Dependencies) Scaler {
 }
 
 func (s *pdScaler) Scale(meta metav1.Object, oldSet *apps.StatefulSet, newSet *apps.StatefulSet) error {
+	if tc, ok := meta.(*v1alpha1.TidbCluster); ok {
+		if err := s.setZoneAwareDeleteSlots(tc, v1alpha1.PDMemberType, oldSet, newSet); err != nil {
+			return err
+		}
+	}
 	scaling, _, _, _ := scaleOne(oldSet, newSet)
 	if scaling > 0 {
 		return s.ScaleOut(meta, oldSet, newSet)
 	} else if scaling < 0 {
 		return s.ScaleIn(meta, oldSet, newSet)
 	}
+	if tc, ok := meta.(*v1alpha1.TidbCluster); ok {
+		clearPendingScaleIns(tc, v1alpha1.PDMemberType)
+	}
 	return nil
 }
 
@@ -99,6 +108,15 @@ func (s *pdScaler) ScaleIn(meta metav1.Object, oldSet *apps.StatefulSet, newSet
 		return nil
 	}
 
+	// the member is removed only after the scale-in is approved if the external approval is required
+	if err := approveScaleIn(s.deps, tc, v1alpha1.PDMemberType, pdPodName, tc.Spec.PD.Replicas); err != nil {
+		return err
+	}
+
+	if err := BeginPDDisruption(s.deps, tc, pdPodName, v1alpha1.PDDisruptionSourceScaleIn); err != nil {
+		return err
+	}
+
 	pdClient := controller.GetPDClient(s.deps.PDControl, tc)
 	leader, err := pdClient.GetPDLeader()
 	if err != nil {
@@ -136,6 +154,15 @@ func (s *pdScaler) ScaleIn(meta metav1.Object, oldSet *apps.StatefulSet, newSet
 		}
 	}
 
+	var memberID uint64
+	if member, ok := tc.Status.PD.Members[memberName]; ok {
+		if memberID, err = strconv.ParseUint(member.ID, 10, 64); err != nil {
+			return err
+		}
+	}
+	if err := fencePDMember(s.deps, tc, memberName, memberID, pdPodName); err != nil {
+		return err
+	}
 	err = pdClient.DeleteMember(memberName)
 	if err != nil {
 		klog.Errorf("pdScaler.ScaleIn: failed to delete member %s, %v", memberName, err)
@@ -159,6 +186,7 @@ func (s *pdScaler) ScaleIn(meta metav1.Object, oldSet *apps.StatefulSet, newSet
 		}
 	}
 
+	clearPendingScaleIn(tc, pdPodName)
 	setReplicasAndDeleteSlots(newSet, replicas, deleteSlots)
 	return nil
 }
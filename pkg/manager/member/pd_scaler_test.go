@@ -14,6 +14,7 @@
 package member
 
 import (
+	"context"
 	"fmt"
 	"testing"
 	"time"
@@ -261,6 +262,7 @@ func TestPDScalerScaleIn(t *testing.T) {
 		}
 
 		pdClient := controller.NewFakePDClient(pdControl, tc)
+		addFencingReactions(scaler.deps, pdClient, tc)
 
 		pdClient.AddReaction(pdapi.GetPDLeaderActionType, func(action *pdapi.Action) (interface{}, error) {
 			leader := pdpb.Member{
@@ -291,8 +293,10 @@ func TestPDScalerScaleIn(t *testing.T) {
 		}
 
 		tc.Status.PD.Synced = !test.statusSyncFailed
+		_, err := scaler.deps.Clientset.PingcapV1alpha1().TidbClusters(tc.Namespace).Create(context.TODO(), tc, metav1.CreateOptions{})
+		g.Expect(err).NotTo(HaveOccurred())
 
-		err := scaler.ScaleIn(tc, oldSet, newSet)
+		err = scaler.ScaleIn(tc, oldSet, newSet)
 		if test.err {
 			g.Expect(err).To(HaveOccurred())
 		} else {
@@ -0,0 +1,77 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/pdapi"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/klog/v2"
+)
+
+// syncTiKVStoreLimits applies spec.tikv.storeLimits to the Up TiKV stores of the cluster through the PD API
+// whenever the store limits in PD drift, e.g. after the cluster is recreated or the limits are changed by pd-ctl.
+// The limits of the TiFlash stores are left as they are.
+func (m *pdMemberManager) syncTiKVStoreLimits(tc *v1alpha1.TidbCluster) error {
+	if tc.Spec.TiKV == nil || tc.Spec.TiKV.StoreLimits == nil {
+		return nil
+	}
+	limits := tc.Spec.TiKV.StoreLimits
+	if limits.AddPeer == nil && limits.RemovePeer == nil {
+		return nil
+	}
+	if !tc.PDIsAvailable() || len(tc.Status.TiKV.Stores) == 0 {
+		return nil
+	}
+
+	ns := tc.GetNamespace()
+	tcName := tc.GetName()
+	pdCli := controller.GetPDClient(m.deps.PDControl, tc)
+	current, err := pdCli.GetStoreLimits()
+	if err != nil {
+		return fmt.Errorf("failed to get store limits of cluster %s/%s: %v", ns, tcName, err)
+	}
+
+	var errs []error
+	for id, store := range tc.Status.TiKV.Stores {
+		if store.State != v1alpha1.TiKVStateUp {
+			continue
+		}
+		storeID, err := strconv.ParseUint(id, 10, 64)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid id %q of tikv store %s/%s: %v", id, ns, store.PodName, err))
+			continue
+		}
+		limit := current[storeID]
+		if limits.AddPeer != nil && (limit == nil || limit.AddPeer != *limits.AddPeer) {
+			if err := pdCli.SetStoreLimit(storeID, pdapi.StoreLimitTypeAddPeer, *limits.AddPeer); err != nil {
+				errs = append(errs, fmt.Errorf("failed to set add-peer limit of tikv store %d of cluster %s/%s: %v", storeID, ns, tcName, err))
+			} else {
+				klog.Infof("set add-peer limit of tikv store %d of cluster %s/%s to %v", storeID, ns, tcName, *limits.AddPeer)
+			}
+		}
+		if limits.RemovePeer != nil && (limit == nil || limit.RemovePeer != *limits.RemovePeer) {
+			if err := pdCli.SetStoreLimit(storeID, pdapi.StoreLimitTypeRemovePeer, *limits.RemovePeer); err != nil {
+				errs = append(errs, fmt.Errorf("failed to set remove-peer limit of tikv store %d of cluster %s/%s: %v", storeID, ns, tcName, err))
+			} else {
+				klog.Infof("set remove-peer limit of tikv store %d of cluster %s/%s to %v", storeID, ns, tcName, *limits.RemovePeer)
+			}
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
@@ -0,0 +1,118 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"fmt"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/pdapi"
+)
+
+func TestSyncTiKVStoreLimits(t *testing.T) {
+	ptr := func(v float64) *float64 { return &v }
+	type setLimit struct {
+		storeID   uint64
+		limitType string
+		rate      float64
+	}
+
+	tests := []struct {
+		name        string
+		limits      *v1alpha1.TiKVStoreLimits
+		current     map[uint64]*pdapi.StoreLimit
+		getErr      error
+		expectErr   bool
+		expectLimit []setLimit
+	}{
+		{
+			name: "store limits not set",
+		},
+		{
+			name:   "store limits in sync",
+			limits: &v1alpha1.TiKVStoreLimits{AddPeer: ptr(15), RemovePeer: ptr(20)},
+			current: map[uint64]*pdapi.StoreLimit{
+				1: {AddPeer: 15, RemovePeer: 20},
+				2: {AddPeer: 15, RemovePeer: 20},
+			},
+		},
+		{
+			name:   "store limits drift",
+			limits: &v1alpha1.TiKVStoreLimits{AddPeer: ptr(15), RemovePeer: ptr(20)},
+			current: map[uint64]*pdapi.StoreLimit{
+				1: {AddPeer: 30, RemovePeer: 20},
+			},
+			expectLimit: []setLimit{
+				{1, pdapi.StoreLimitTypeAddPeer, 15},
+				{2, pdapi.StoreLimitTypeAddPeer, 15},
+				{2, pdapi.StoreLimitTypeRemovePeer, 20},
+			},
+		},
+		{
+			name:   "only add-peer is set",
+			limits: &v1alpha1.TiKVStoreLimits{AddPeer: ptr(15)},
+			current: map[uint64]*pdapi.StoreLimit{
+				1: {AddPeer: 15, RemovePeer: 30},
+				2: {AddPeer: 15, RemovePeer: 30},
+			},
+		},
+		{
+			name:      "failed to get store limits",
+			limits:    &v1alpha1.TiKVStoreLimits{AddPeer: ptr(15)},
+			getErr:    fmt.Errorf("failed to get store limits"),
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+
+			pmm, _, _ := newFakePDMemberManager()
+			tc := newTidbClusterForPD()
+			tc.Spec.TiKV.StoreLimits = tt.limits
+			tc.Status.PD.Members = map[string]v1alpha1.PDMember{
+				"test-pd-0": {Name: "test-pd-0", Health: true},
+				"test-pd-1": {Name: "test-pd-1", Health: true},
+				"test-pd-2": {Name: "test-pd-2", Health: true},
+			}
+			tc.Status.TiKV.Stores = map[string]v1alpha1.TiKVStore{
+				"1": {ID: "1", PodName: "test-tikv-0", State: v1alpha1.TiKVStateUp},
+				"2": {ID: "2", PodName: "test-tikv-1", State: v1alpha1.TiKVStateUp},
+				"3": {ID: "3", PodName: "test-tikv-2", State: v1alpha1.TiKVStateOffline},
+			}
+
+			pdClient := controller.NewFakePDClient(pmm.deps.PDControl.(*pdapi.FakePDControl), tc)
+			pdClient.AddReaction(pdapi.GetStoreLimitsActionType, func(action *pdapi.Action) (interface{}, error) {
+				return tt.current, tt.getErr
+			})
+			var limits []setLimit
+			pdClient.AddReaction(pdapi.SetStoreLimitActionType, func(action *pdapi.Action) (interface{}, error) {
+				limits = append(limits, setLimit{action.ID, action.LimitType, action.Rate})
+				return nil, nil
+			})
+
+			err := pmm.syncTiKVStoreLimits(tc)
+			if tt.expectErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).To(Succeed())
+			}
+			g.Expect(limits).To(ConsistOf(tt.expectLimit))
+		})
+	}
+}
@@ -139,6 +139,11 @@ func (u *pdUpgrader) upgradePDPod(tc *v1alpha1.TidbCluster, ordinal int32, newSe
 		}
 	}
 
+	if err := BeginPDDisruption(u.deps, tc, upgradePodName, v1alpha1.PDDisruptionSourceUpgrade); err != nil {
+		return err
+	}
+
+	recordUpgradeRestart(u.deps, tc, upgradePodName, newSet)
 	mngerutils.SetUpgradePartition(newSet, ordinal)
 	return nil
 }
@@ -14,6 +14,7 @@
 package member
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
@@ -54,6 +55,8 @@ func TestPDUpgraderUpgrade(t *testing.T) {
 		if test.changeFn != nil {
 			test.changeFn(tc)
 		}
+		_, err := upgrader.(*pdUpgrader).deps.Clientset.PingcapV1alpha1().TidbClusters(tc.Namespace).Create(context.TODO(), tc, metav1.CreateOptions{})
+		g.Expect(err).NotTo(HaveOccurred())
 
 		if test.transferLeaderErr {
 			pdClient.AddReaction(pdapi.TransferPDLeaderActionType, func(action *pdapi.Action) (interface{}, error) {
@@ -82,7 +85,7 @@ func TestPDUpgraderUpgrade(t *testing.T) {
 
 		newSet.Spec.UpdateStrategy.RollingUpdate.Partition = pointer.Int32Ptr(3)
 
-		err := upgrader.Upgrade(tc, oldSet, newSet)
+		err = upgrader.Upgrade(tc, oldSet, newSet)
 		test.errExpectFn(g, err)
 		test.expectFn(g, tc, newSet)
 	}
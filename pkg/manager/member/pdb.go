@@ -0,0 +1,138 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// pdbComponent describes the PodDisruptionBudget of a component
+type pdbComponent struct {
+	name   string
+	labels label.Label
+	spec   *v1alpha1.PodDisruptionBudgetSpec
+	// replicas is the desired replicas of the statefulset, e.g. it's 0 for a standby cluster
+	replicas int32
+	// defaultMaxUnavailable is used if spec.maxUnavailable is not set
+	defaultMaxUnavailable intstr.IntOrString
+}
+
+func pdPDBComponent(tc *v1alpha1.TidbCluster) pdbComponent {
+	// keep the quorum of the PD members, at least one member can be evicted so that the node drains are not
+	// blocked forever, e.g. for a single member cluster
+	maxUnavailable := (tc.Spec.PD.Replicas - 1) / 2
+	if maxUnavailable < 1 {
+		maxUnavailable = 1
+	}
+	return pdbComponent{
+		name:                  controller.PDMemberName(tc.Name),
+		labels:                label.New().Instance(tc.GetInstanceName()).PD(),
+		spec:                  tc.Spec.PD.PodDisruptionBudget,
+		replicas:              tc.PDStsDesiredReplicas(),
+		defaultMaxUnavailable: intstr.FromInt(int(maxUnavailable)),
+	}
+}
+
+func tikvPDBComponent(tc *v1alpha1.TidbCluster) pdbComponent {
+	return pdbComponent{
+		name:                  controller.TiKVMemberName(tc.Name),
+		labels:                label.New().Instance(tc.GetInstanceName()).TiKV(),
+		spec:                  tc.Spec.TiKV.PodDisruptionBudget,
+		replicas:              tc.TiKVStsDesiredReplicas(),
+		defaultMaxUnavailable: intstr.FromInt(1),
+	}
+}
+
+func tidbPDBComponent(tc *v1alpha1.TidbCluster) pdbComponent {
+	return pdbComponent{
+		name:                  controller.TiDBMemberName(tc.Name),
+		labels:                label.New().Instance(tc.GetInstanceName()).TiDB(),
+		spec:                  tc.Spec.TiDB.PodDisruptionBudget,
+		replicas:              tc.TiDBStsDesiredReplicas(),
+		defaultMaxUnavailable: intstr.FromString("25%"),
+	}
+}
+
+func tiflashPDBComponent(tc *v1alpha1.TidbCluster) pdbComponent {
+	return pdbComponent{
+		name:                  controller.TiFlashMemberName(tc.Name),
+		labels:                label.New().Instance(tc.GetInstanceName()).TiFlash(),
+		spec:                  tc.Spec.TiFlash.PodDisruptionBudget,
+		replicas:              tc.TiFlashStsDesiredReplicas(),
+		defaultMaxUnavailable: intstr.FromInt(1),
+	}
+}
+
+func ticdcPDBComponent(tc *v1alpha1.TidbCluster) pdbComponent {
+	return pdbComponent{
+		name:                  controller.TiCDCMemberName(tc.Name),
+		labels:                label.New().Instance(tc.GetInstanceName()).TiCDC(),
+		spec:                  tc.Spec.TiCDC.PodDisruptionBudget,
+		replicas:              tc.TiCDCDeployDesiredReplicas(),
+		defaultMaxUnavailable: intstr.FromInt(1),
+	}
+}
+
+// syncPodDisruptionBudget creates or updates the PodDisruptionBudget of the component, the PodDisruptionBudget
+// created by the operator is deleted if it's disabled or the component has no replicas.
+func syncPodDisruptionBudget(deps *controller.Dependencies, tc *v1alpha1.TidbCluster, c pdbComponent) error {
+	ns := tc.GetNamespace()
+	if c.replicas <= 0 || (c.spec != nil && c.spec.Enabled != nil && !*c.spec.Enabled) {
+		pdb, err := deps.KubeClientset.PolicyV1beta1().PodDisruptionBudgets(ns).Get(context.TODO(), c.name, metav1.GetOptions{})
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("syncPodDisruptionBudget: failed to get pdb %s/%s, error: %v", ns, c.name, err)
+		}
+		// the pdb created by the users is left as is
+		if !metav1.IsControlledBy(pdb, tc) {
+			return nil
+		}
+		if err := deps.TypedControl.Delete(tc, pdb); err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("syncPodDisruptionBudget: failed to delete pdb %s/%s, error: %v", ns, c.name, err)
+		}
+		return nil
+	}
+
+	maxUnavailable := c.defaultMaxUnavailable
+	if c.spec != nil && c.spec.MaxUnavailable != nil {
+		maxUnavailable = *c.spec.MaxUnavailable
+	}
+	pdb := &policyv1beta1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            c.name,
+			Namespace:       ns,
+			Labels:          c.labels.Copy().Labels(),
+			OwnerReferences: []metav1.OwnerReference{controller.GetOwnerRef(tc)},
+		},
+		Spec: policyv1beta1.PodDisruptionBudgetSpec{
+			Selector:       c.labels.LabelSelector(),
+			MaxUnavailable: &maxUnavailable,
+		},
+	}
+	if _, err := deps.TypedControl.CreateOrUpdatePodDisruptionBudget(tc, pdb); err != nil {
+		return fmt.Errorf("syncPodDisruptionBudget: failed to sync pdb %s/%s, error: %v", ns, c.name, err)
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/pointer"
+)
+
+func TestSyncPodDisruptionBudget(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tests := []struct {
+		name           string
+		update         func(tc *v1alpha1.TidbCluster)
+		existing       bool
+		ownedByTC      bool
+		exist          bool
+		maxUnavailable intstr.IntOrString
+	}{
+		{
+			name:           "default max unavailable of 3 members",
+			exist:          true,
+			maxUnavailable: intstr.FromInt(1),
+		},
+		{
+			name: "default max unavailable of 5 members",
+			update: func(tc *v1alpha1.TidbCluster) {
+				tc.Spec.PD.Replicas = 5
+			},
+			exist:          true,
+			maxUnavailable: intstr.FromInt(2),
+		},
+		{
+			name: "default max unavailable of 1 member",
+			update: func(tc *v1alpha1.TidbCluster) {
+				tc.Spec.PD.Replicas = 1
+			},
+			exist:          true,
+			maxUnavailable: intstr.FromInt(1),
+		},
+		{
+			name: "max unavailable is set",
+			update: func(tc *v1alpha1.TidbCluster) {
+				maxUnavailable := intstr.FromString("50%")
+				tc.Spec.PD.PodDisruptionBudget = &v1alpha1.PodDisruptionBudgetSpec{MaxUnavailable: &maxUnavailable}
+			},
+			existing:       true,
+			ownedByTC:      true,
+			exist:          true,
+			maxUnavailable: intstr.FromString("50%"),
+		},
+		{
+			name: "disabled",
+			update: func(tc *v1alpha1.TidbCluster) {
+				tc.Spec.PD.PodDisruptionBudget = &v1alpha1.PodDisruptionBudgetSpec{Enabled: pointer.BoolPtr(false)}
+			},
+			existing:  true,
+			ownedByTC: true,
+			exist:     false,
+		},
+		{
+			name: "disabled and created by users",
+			update: func(tc *v1alpha1.TidbCluster) {
+				tc.Spec.PD.PodDisruptionBudget = &v1alpha1.PodDisruptionBudgetSpec{Enabled: pointer.BoolPtr(false)}
+			},
+			existing:       true,
+			exist:          true,
+			maxUnavailable: intstr.FromInt(3),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := newTidbClusterForPD()
+			tc.UID = "uid"
+			if tt.update != nil {
+				tt.update(tc)
+			}
+			deps := controller.NewFakeDependencies()
+			cli := deps.GenericControl.(*controller.FakeGenericControl).FakeCli
+			key := types.NamespacedName{Namespace: tc.Namespace, Name: controller.PDMemberName(tc.Name)}
+			if tt.existing {
+				maxUnavailable := intstr.FromInt(3)
+				pdb := &policyv1beta1.PodDisruptionBudget{
+					ObjectMeta: metav1.ObjectMeta{Namespace: key.Namespace, Name: key.Name},
+					Spec:       policyv1beta1.PodDisruptionBudgetSpec{MaxUnavailable: &maxUnavailable},
+				}
+				if tt.ownedByTC {
+					pdb.OwnerReferences = []metav1.OwnerReference{controller.GetOwnerRef(tc)}
+				}
+				g.Expect(cli.Create(context.TODO(), pdb.DeepCopy())).To(Succeed())
+				_, err := deps.KubeClientset.PolicyV1beta1().PodDisruptionBudgets(key.Namespace).Create(context.TODO(), pdb, metav1.CreateOptions{})
+				g.Expect(err).To(Succeed())
+			}
+
+			g.Expect(syncPodDisruptionBudget(deps, tc, pdPDBComponent(tc))).To(Succeed())
+
+			pdb := &policyv1beta1.PodDisruptionBudget{}
+			err := cli.Get(context.TODO(), key, pdb)
+			if !tt.exist {
+				g.Expect(errors.IsNotFound(err)).To(BeTrue())
+				return
+			}
+			g.Expect(err).To(Succeed())
+			g.Expect(*pdb.Spec.MaxUnavailable).To(Equal(tt.maxUnavailable))
+		})
+	}
+}
@@ -164,10 +164,11 @@ func buildBinlogClient(tc *v1alpha1.TidbCluster, control pdapi.PDControlInterfac
 		// connect to pd of other cluster and use own cert
 		endpoints, tlsConfig, err = control.GetEndpoints(pdapi.Namespace(tc.Spec.Cluster.Namespace), tc.Spec.Cluster.Name, tc.IsTLSClusterEnabled(),
 			pdapi.TLSCertFromTC(pdapi.Namespace(tc.Namespace), tc.Name),
+			pdapi.TLSFromCluster(pdapi.Namespace(tc.Namespace), tc.Spec.TLSCluster),
 			pdapi.ClusterRef(tc.Spec.Cluster.ClusterDomain),
 		)
 	} else {
-		endpoints, tlsConfig, err = control.GetEndpoints(pdapi.Namespace(tc.Namespace), tc.Name, tc.IsTLSClusterEnabled())
+		endpoints, tlsConfig, err = control.GetEndpoints(pdapi.Namespace(tc.Namespace), tc.Name, tc.IsTLSClusterEnabled(), pdapi.TLSFromCluster(pdapi.Namespace(tc.Namespace), tc.Spec.TLSCluster))
 	}
 	if err != nil {
 		return nil, err
@@ -249,6 +250,9 @@ func (m *pumpMemberManager) syncHeadlessService(tc *v1alpha1.TidbCluster) error
 	if err != nil {
 		return err
 	}
+	if mngerutils.CheckServiceDrift(tc, oldSvc) {
+		equal = false
+	}
 	isOrphan := metav1.GetControllerOf(oldSvc) == nil
 
 	if !equal || isOrphan {
@@ -288,6 +292,7 @@ func (m *pumpMemberManager) syncConfigMap(tc *v1alpha1.TidbCluster, set *appsv1.
 	if err != nil {
 		return nil, err
 	}
+	controller.PropagateMetadata(newCm, tc.BasePumpSpec().Metadata())
 	return m.deps.TypedControl.CreateOrUpdateConfigMap(tc, newCm)
 }
 
@@ -315,6 +320,7 @@ func getNewPumpHeadlessService(tc *v1alpha1.TidbCluster) *corev1.Service {
 		},
 	}
 
+	controller.PropagateMetadata(svc, tc.BasePumpSpec().Metadata())
 	if tc.Spec.PreferIPv6 {
 		SetServiceWhenPreferIPv6(svc)
 	}
@@ -474,6 +480,8 @@ func getNewPumpStatefulSet(tc *v1alpha1.TidbCluster, cm *corev1.ConfigMap) (*app
 		},
 	}
 
+	setLifecycle(&containers[0], spec.Lifecycle())
+
 	volumeClaims := []corev1.PersistentVolumeClaim{
 		{
 			ObjectMeta: metav1.ObjectMeta{
@@ -522,7 +530,7 @@ func getNewPumpStatefulSet(tc *v1alpha1.TidbCluster, cm *corev1.ConfigMap) (*app
 		podManagementPolicy = spec.PodManagementPolicy()
 	}
 
-	return &appsv1.StatefulSet{
+	pumpSet := &appsv1.StatefulSet{
 		ObjectMeta: objMeta,
 		Spec: appsv1.StatefulSetSpec{
 			Selector:    stsLabels.LabelSelector(),
@@ -536,7 +544,12 @@ func getNewPumpStatefulSet(tc *v1alpha1.TidbCluster, cm *corev1.ConfigMap) (*app
 				Type: spec.StatefulSetUpdateStrategy(),
 			},
 		},
-	}, nil
+	}
+	propagateStatefulSetMetadata(pumpSet, spec.Metadata())
+	if err := ApplyPodTemplatePatches(&pumpSet.Spec.Template, spec.PodTemplatePatches()); err != nil {
+		return nil, fmt.Errorf("failed to apply pod template patches for Pump of [%s/%s], error: %v", objMeta.Namespace, objMeta.Name, err)
+	}
+	return pumpSet, nil
 }
 
 func getPumpMeta(tc *v1alpha1.TidbCluster, nameFunc func(string) string) (metav1.ObjectMeta, label.Label) {
@@ -16,6 +16,7 @@ package member
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/pingcap/tidb-operator/pkg/apis/label"
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
@@ -29,7 +30,7 @@ import (
 )
 
 const (
-	skipReasonPVCCleanerIsNotTarget              = "pvc cleaner: member type is not pd, tikv or tiflash"
+	skipReasonPVCCleanerIsNotTarget              = "pvc cleaner: member type is not the target"
 	skipReasonPVCCleanerDeferDeletePVCNotHasLock = "pvc cleaner: defer delete PVC not has schedule lock"
 	skipReasonPVCCleanerPVCNotHasLock            = "pvc cleaner: pvc not has schedule lock"
 	skipReasonPVCCleanerPodWaitingForScheduling  = "pvc cleaner: waiting for pod scheduling"
@@ -42,6 +43,7 @@ const (
 	skipReasonPVCCleanerPVCHasBeenDeleted        = "pvc cleaner: pvc has been deleted"
 	skipReasonPVCCleanerPVCNotFound              = "pvc cleaner: not found pvc from apiserver"
 	skipReasonPVCCleanerPVCChanged               = "pvc cleaner: pvc changed before deletion"
+	skipReasonPVCCleanerInGracePeriod            = "pvc cleaner: pvc is in the deletion grace period"
 )
 
 // PVCCleaner implements the logic for cleaning the pvc related resource
@@ -72,7 +74,7 @@ func (c *realPVCCleaner) reclaimPV(meta metav1.Object) (map[string]string, error
 	var clusterType string
 	switch meta := meta.(type) {
 	case *v1alpha1.TidbCluster:
-		if !meta.IsPVReclaimEnabled() {
+		if !isScaleInPVCDeletedByAnyMember(meta) {
 			return nil, nil
 		}
 		clusterType = "tidbcluster"
@@ -96,7 +98,15 @@ func (c *realPVCCleaner) reclaimPV(meta metav1.Object) (map[string]string, error
 	for _, pvc := range pvcs {
 		pvcName := pvc.GetName()
 		l := label.Label(pvc.Labels)
-		if !(l.IsPD() || l.IsTiKV() || l.IsTiFlash() || l.IsDMMaster() || l.IsDMWorker()) {
+		var gracePeriod time.Duration
+		if tc, ok := meta.(*v1alpha1.TidbCluster); ok {
+			typ := v1alpha1.MemberType(l.ComponentType())
+			if !tc.IsScaleInPVCDeleted(typ) {
+				skipReason[pvcName] = skipReasonPVCCleanerIsNotTarget
+				continue
+			}
+			gracePeriod = tc.ScaleInPVCDeletionGracePeriod(typ)
+		} else if !(l.IsDMMaster() || l.IsDMWorker()) {
 			skipReason[pvcName] = skipReasonPVCCleanerIsNotTarget
 			continue
 		}
@@ -119,6 +129,15 @@ func (c *realPVCCleaner) reclaimPV(meta metav1.Object) (map[string]string, error
 			continue
 		}
 
+		if gracePeriod > 0 {
+			// The PVC is kept for the grace period after it's left by the scale-in
+			deferDeleting, err := time.Parse(time.RFC3339, pvc.Annotations[label.AnnPVCDeferDeleting])
+			if err == nil && time.Since(deferDeleting) < gracePeriod {
+				skipReason[pvcName] = skipReasonPVCCleanerInGracePeriod
+				continue
+			}
+		}
+
 		// PVC has been marked as defer delete PVC, try to reclaim the PV bound to this PVC
 		podName, exist := pvc.Annotations[label.AnnPodNameKey]
 		if !exist {
@@ -193,6 +212,17 @@ func (c *realPVCCleaner) reclaimPV(meta metav1.Object) (map[string]string, error
 	return skipReason, nil
 }
 
+// isScaleInPVCDeletedByAnyMember returns whether the PVCs left by the scale-in of any member are deleted
+func isScaleInPVCDeletedByAnyMember(tc *v1alpha1.TidbCluster) bool {
+	for _, typ := range []v1alpha1.MemberType{v1alpha1.PDMemberType, v1alpha1.TiKVMemberType, v1alpha1.TiFlashMemberType,
+		v1alpha1.TiDBMemberType, v1alpha1.TiCDCMemberType, v1alpha1.PumpMemberType} {
+		if tc.IsScaleInPVCDeleted(typ) {
+			return true
+		}
+	}
+	return false
+}
+
 // cleanScheduleLock cleans AnnPVCPodScheduling label if necessary.
 func (c *realPVCCleaner) cleanScheduleLock(meta metav1.Object) (map[string]string, error) {
 	ns := meta.GetNamespace()
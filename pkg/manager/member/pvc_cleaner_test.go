@@ -848,6 +848,53 @@ func TestPVCCleanerReclaimPV(t *testing.T) {
 	}
 }
 
+func TestPVCCleanerReclaimPVScaleInPVCRetentionPolicy(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbClusterForPD()
+	tc.Spec.EnablePVReclaim = pointer.BoolPtr(false)
+	deletePolicy := v1alpha1.ScaleInPVCDelete
+	tc.Spec.TiDB = &v1alpha1.TiDBSpec{ScaleInPVCRetentionPolicy: &deletePolicy}
+	tc.Spec.PD.ScaleInPVCRetentionPolicy = &deletePolicy
+	tc.Spec.PD.ScaleInPVCDeletionGracePeriod = &metav1.Duration{Duration: time.Hour}
+
+	pvc := func(name string, l label.Label) *corev1.PersistentVolumeClaim {
+		return &corev1.PersistentVolumeClaim{
+			TypeMeta: metav1.TypeMeta{Kind: "PersistentVolumeClaim", APIVersion: "v1"},
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: metav1.NamespaceDefault,
+				Name:      name,
+				Labels:    l.Labels(),
+				Annotations: map[string]string{
+					label.AnnPVCDeferDeleting: time.Now().Add(-time.Minute).Format(time.RFC3339),
+					label.AnnPodNameKey:       name,
+				},
+			},
+			Spec: corev1.PersistentVolumeClaimSpec{VolumeName: "pv-" + name},
+			Status: corev1.PersistentVolumeClaimStatus{
+				Phase: corev1.ClaimBound,
+			},
+		}
+	}
+	pdPVC := pvc("pd-test-pd-0", label.New().Instance(tc.GetInstanceName()).PD())
+	tidbPVC := pvc("tidb-test-tidb-0", label.New().Instance(tc.GetInstanceName()).TiDB())
+	tikvPVC := pvc("tikv-test-tikv-0", label.New().Instance(tc.GetInstanceName()).TiKV())
+
+	pcc, fakeCli, _, pvcIndexer, _, _, _ := newFakePVCCleaner()
+	for _, pvc := range []*corev1.PersistentVolumeClaim{pdPVC, tidbPVC, tikvPVC} {
+		pvcIndexer.Add(pvc)
+		fakeCli.CoreV1().PersistentVolumeClaims(pvc.Namespace).Create(context.TODO(), pvc, metav1.CreateOptions{})
+	}
+
+	skipReason, err := pcc.reclaimPV(tc)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(skipReason).To(Equal(map[string]string{
+		"pd-test-pd-0":     skipReasonPVCCleanerInGracePeriod,
+		"tidb-test-tidb-0": skipReasonPVCCleanerNotFoundPV,
+		"tikv-test-tikv-0": skipReasonPVCCleanerIsNotTarget,
+	}))
+}
+
 func TestPVCCleanerCleanScheduleLock(t *testing.T) {
 	g := NewGomegaWithT(t)
 
@@ -0,0 +1,259 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"fmt"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1/defaulting"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1/validation"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	mngerutils "github.com/pingcap/tidb-operator/pkg/manager/utils"
+	apps "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// RenderTidbCluster renders the objects the operator creates for a new TidbCluster without accessing
+// the Kubernetes API, which allows to preview the manifests of a spec or to test them against golden files.
+// The TidbCluster is defaulted and validated the same way as the operator does before rendering.
+// The objects are rendered in the order they are reconciled, the objects depending on the state of the
+// running cluster, like the TLS secrets and the initializer job, are not rendered.
+func RenderTidbCluster(tc *v1alpha1.TidbCluster, cliConfig *controller.CLIConfig) ([]client.Object, error) {
+	tc = tc.DeepCopy()
+	defaulting.SetTidbClusterDefault(tc)
+	if errs := validation.ValidateTidbCluster(tc); len(errs) > 0 {
+		return nil, fmt.Errorf("invalid TidbCluster %s/%s: %v", tc.Namespace, tc.Name, errs.ToAggregate())
+	}
+	if cliConfig == nil {
+		cliConfig = controller.DefaultCLIConfig()
+	}
+
+	var objs []client.Object
+	discovery, err := getTidbDiscoveryObjects(tc, cliConfig)
+	if err != nil {
+		return nil, err
+	}
+	if discovery != nil {
+		objs = append(objs, discovery.role, discovery.serviceAccount, discovery.roleBinding, discovery.deployment,
+			getTidbDiscoveryService(tc, discovery.deployment, discovery.preferIPv6))
+	}
+
+	for _, render := range []func(*v1alpha1.TidbCluster) ([]client.Object, error){
+		renderPD,
+		renderTiProxy,
+		renderTiFlash,
+		renderTiKV,
+		renderPump,
+		renderTiDB,
+		renderTiCDC,
+	} {
+		componentObjs, err := render(tc)
+		if err != nil {
+			return nil, err
+		}
+		objs = append(objs, componentObjs...)
+	}
+
+	for _, obj := range objs {
+		var err error
+		switch o := obj.(type) {
+		case *corev1.Service:
+			err = controller.SetServiceLastAppliedConfigAnnotation(o)
+		case *apps.StatefulSet:
+			err = mngerutils.SetStatefulSetLastAppliedConfigAnnotation(o)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return objs, nil
+}
+
+// renderConfigMap names the desired ConfigMap of a component as a new one and propagates the metadata
+func renderConfigMap(cm *corev1.ConfigMap, spec v1alpha1.ComponentAccessor) (*corev1.ConfigMap, error) {
+	if cm == nil {
+		return nil, nil
+	}
+	if err := mngerutils.NameNewConfigMap(spec.ConfigUpdateStrategy(), cm); err != nil {
+		return nil, err
+	}
+	controller.PropagateMetadata(cm, spec.Metadata())
+	return cm, nil
+}
+
+// componentObjects returns the non-nil objects of a component
+func componentObjects(svcs []*corev1.Service, cm *corev1.ConfigMap, set *apps.StatefulSet) []client.Object {
+	var objs []client.Object
+	for _, svc := range svcs {
+		if svc != nil {
+			objs = append(objs, svc)
+		}
+	}
+	if cm != nil {
+		objs = append(objs, cm)
+	}
+	return append(objs, set)
+}
+
+func renderPD(tc *v1alpha1.TidbCluster) ([]client.Object, error) {
+	if tc.Spec.PD == nil {
+		return nil, nil
+	}
+	var cm *corev1.ConfigMap
+	// only the ConfigMap with non-nil config is synced, see syncPDConfigMap
+	if tc.Spec.PD.Config != nil {
+		desired, err := getPDConfigMap(tc)
+		if err != nil {
+			return nil, err
+		}
+		if cm, err = renderConfigMap(desired, tc.BasePDSpec()); err != nil {
+			return nil, err
+		}
+	}
+	set, err := getNewPDSetForTidbCluster(tc, cm)
+	if err != nil {
+		return nil, err
+	}
+	svcs := []*corev1.Service{getNewPDServiceForTidbCluster(tc), getNewPDHeadlessServiceForTidbCluster(tc)}
+	return componentObjects(svcs, cm, set), nil
+}
+
+func renderTiProxy(tc *v1alpha1.TidbCluster) ([]client.Object, error) {
+	if tc.Spec.TiProxy == nil {
+		return nil, nil
+	}
+	cm, err := getTiProxyConfigMap(tc)
+	if err != nil {
+		return nil, err
+	}
+	// the ConfigMap of TiProxy is always updated in place, see syncConfigMap
+	controller.PropagateMetadata(cm, tc.BaseTiProxySpec().Metadata())
+	set, err := getNewTiProxyStatefulSet(tc, cm)
+	if err != nil {
+		return nil, err
+	}
+	svcs := []*corev1.Service{getNewTiProxyService(tc, true), getNewTiProxyService(tc, false)}
+	return componentObjects(svcs, cm, set), nil
+}
+
+func renderTiFlash(tc *v1alpha1.TidbCluster) ([]client.Object, error) {
+	if tc.Spec.TiFlash == nil {
+		return nil, nil
+	}
+	desired, err := getTiFlashConfigMap(tc)
+	if err != nil {
+		return nil, err
+	}
+	cm, err := renderConfigMap(desired, tc.BaseTiFlashSpec())
+	if err != nil {
+		return nil, err
+	}
+	set, err := getNewStatefulSet(tc, cm)
+	if err != nil {
+		return nil, err
+	}
+	return componentObjects([]*corev1.Service{getNewHeadlessService(tc)}, cm, set), nil
+}
+
+func renderTiKV(tc *v1alpha1.TidbCluster) ([]client.Object, error) {
+	if tc.Spec.TiKV == nil {
+		return nil, nil
+	}
+	var cm *corev1.ConfigMap
+	// only the ConfigMap with non-nil config is synced, see syncTiKVConfigMap
+	if tc.Spec.TiKV.Config != nil {
+		desired, err := getTikVConfigMap(tc)
+		if err != nil {
+			return nil, err
+		}
+		if cm, err = renderConfigMap(desired, tc.BaseTiKVSpec()); err != nil {
+			return nil, err
+		}
+	}
+	set, err := getNewTiKVSetForTidbCluster(tc, cm)
+	if err != nil {
+		return nil, err
+	}
+	var svcs []*corev1.Service
+	for _, svcConfig := range tikvSvcConfigs {
+		svcs = append(svcs, getNewServiceForTidbCluster(tc, svcConfig))
+	}
+	return componentObjects(svcs, cm, set), nil
+}
+
+func renderPump(tc *v1alpha1.TidbCluster) ([]client.Object, error) {
+	if tc.Spec.Pump == nil {
+		return nil, nil
+	}
+	desired, err := getNewPumpConfigMap(tc)
+	if err != nil {
+		return nil, err
+	}
+	cm, err := renderConfigMap(desired, tc.BasePumpSpec())
+	if err != nil {
+		return nil, err
+	}
+	set, err := getNewPumpStatefulSet(tc, cm)
+	if err != nil {
+		return nil, err
+	}
+	return componentObjects([]*corev1.Service{getNewPumpHeadlessService(tc)}, cm, set), nil
+}
+
+func renderTiDB(tc *v1alpha1.TidbCluster) ([]client.Object, error) {
+	if tc.Spec.TiDB == nil {
+		return nil, nil
+	}
+	var cm *corev1.ConfigMap
+	// only the ConfigMap with non-nil config is synced, see syncTiDBConfigMap
+	if tc.Spec.TiDB.Config != nil {
+		desired, err := getTiDBConfigMap(tc)
+		if err != nil {
+			return nil, err
+		}
+		if cm, err = renderConfigMap(desired, tc.BaseTiDBSpec()); err != nil {
+			return nil, err
+		}
+	}
+	set, err := getNewTiDBSetForTidbCluster(tc, cm)
+	if err != nil {
+		return nil, err
+	}
+	svcs := []*corev1.Service{getNewTiDBHeadlessServiceForTidbCluster(tc), getNewTiDBServiceOrNil(tc)}
+	return componentObjects(svcs, cm, set), nil
+}
+
+func renderTiCDC(tc *v1alpha1.TidbCluster) ([]client.Object, error) {
+	if tc.Spec.TiCDC == nil {
+		return nil, nil
+	}
+	var cm *corev1.ConfigMap
+	// only the ConfigMap with new config items is synced, see syncTiCDCConfigMap
+	if tc.Spec.TiCDC.Config != nil && !tc.Spec.TiCDC.Config.OnlyOldItems() {
+		desired, err := getTiCDCConfigMap(tc)
+		if err != nil {
+			return nil, err
+		}
+		if cm, err = renderConfigMap(desired, tc.BaseTiCDCSpec()); err != nil {
+			return nil, err
+		}
+	}
+	set, err := getNewTiCDCStatefulSet(tc, cm)
+	if err != nil {
+		return nil, err
+	}
+	return componentObjects([]*corev1.Service{getNewCDCHeadlessService(tc)}, cm, set), nil
+}
@@ -0,0 +1,103 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	apps "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newTidbClusterForRender() *v1alpha1.TidbCluster {
+	storage := corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("10Gi")},
+	}
+	return &v1alpha1.TidbCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "basic", Namespace: "default"},
+		Spec: v1alpha1.TidbClusterSpec{
+			Version: "v7.5.0",
+			PD: &v1alpha1.PDSpec{
+				Replicas:             3,
+				ResourceRequirements: storage,
+				Config:               v1alpha1.NewPDConfig(),
+			},
+			TiKV: &v1alpha1.TiKVSpec{
+				Replicas:             3,
+				ResourceRequirements: storage,
+				Config:               v1alpha1.NewTiKVConfig(),
+			},
+			TiDB: &v1alpha1.TiDBSpec{
+				Replicas: 2,
+				Config:   v1alpha1.NewTiDBConfig(),
+			},
+		},
+	}
+}
+
+func TestRenderTidbCluster(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbClusterForRender()
+	objs, err := RenderTidbCluster(tc, nil)
+	g.Expect(err).To(Succeed())
+
+	var names []string
+	for _, obj := range objs {
+		names = append(names, fmt.Sprintf("%T/%s", obj, obj.GetName()))
+	}
+	g.Expect(names).To(HaveLen(15))
+	g.Expect(names[:5]).To(Equal([]string{
+		"*v1.Role/basic-discovery",
+		"*v1.ServiceAccount/basic-discovery",
+		"*v1.RoleBinding/basic-discovery",
+		"*v1.Deployment/basic-discovery",
+		"*v1.Service/basic-discovery",
+	}))
+	g.Expect(names[5:9]).To(Equal([]string{"*v1.Service/basic-pd", "*v1.Service/basic-pd-peer", "*v1.ConfigMap/" + objs[7].GetName(), "*v1.StatefulSet/basic-pd"}))
+	// the ConfigMap is named with the digest of the data by the default RollingUpdate strategy
+	g.Expect(strings.HasPrefix(objs[7].GetName(), "basic-pd-")).To(BeTrue())
+
+	// the StatefulSet mounts the rendered ConfigMap and is annotated as created by the operator
+	set := objs[8].(*apps.StatefulSet)
+	g.Expect(set.Annotations).To(HaveKey("pingcap.com/last-applied-configuration"))
+	var mounted bool
+	for _, vol := range set.Spec.Template.Spec.Volumes {
+		if vol.ConfigMap != nil && vol.ConfigMap.Name == objs[7].GetName() {
+			mounted = true
+		}
+	}
+	g.Expect(mounted).To(BeTrue())
+
+	// the input isn't mutated by the defaulting
+	g.Expect(tc.Spec.TiDB.BaseImage).To(BeEmpty())
+
+	// the embedded discovery mode doesn't render the discovery service
+	tc.Spec.Discovery.Mode = v1alpha1.DiscoveryModeEmbedded
+	tc.Spec.StartScriptVersion = v1alpha1.StartScriptV2
+	objs, err = RenderTidbCluster(tc, nil)
+	g.Expect(err).To(Succeed())
+	g.Expect(objs).To(HaveLen(10))
+
+	// the invalid spec is rejected
+	tc.Spec.PD.ResourceRequirements = corev1.ResourceRequirements{}
+	_, err = RenderTidbCluster(tc, nil)
+	g.Expect(err).To(HaveOccurred())
+}
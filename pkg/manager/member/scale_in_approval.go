@@ -0,0 +1,164 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	httputil "github.com/pingcap/tidb-operator/pkg/util/http"
+	utiltidbcluster "github.com/pingcap/tidb-operator/pkg/util/tidbcluster"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+)
+
+const (
+	scaleInApprovalRequestedReason = "ScaleInApprovalRequested"
+	scaleInApprovedReason          = "ScaleInApproved"
+)
+
+// ScaleInReview is the request POSTed to the scale-in approval webhook
+type ScaleInReview struct {
+	Namespace string `json:"namespace"`
+	Cluster   string `json:"cluster"`
+	Component string `json:"component"`
+	PodName   string `json:"podName"`
+	Replicas  int32  `json:"replicas"`
+}
+
+// ScaleInReviewResponse is the response of the scale-in approval webhook
+type ScaleInReviewResponse struct {
+	Allowed bool   `json:"allowed"`
+	Message string `json:"message,omitempty"`
+}
+
+// approveScaleIn returns nil if the scale-in of the pod doesn't need the external approval or is approved,
+// or else the pending scale-in is recorded in the status and a requeue error is returned to wait for the approval.
+// The approval is kept in the status until the scale-in is done, so it's not asked again while the member or
+// the store is being removed.
+func approveScaleIn(deps *controller.Dependencies, tc *v1alpha1.TidbCluster, memberType v1alpha1.MemberType, podName string, replicas int32) error {
+	if !tc.ScaleInNeedsApproval(memberType) {
+		return nil
+	}
+	ns := tc.GetNamespace()
+	tcName := tc.GetName()
+
+	pending, ok := tc.Status.PendingScaleIns[podName]
+	if ok && pending.Component == memberType && pending.Approved {
+		return nil
+	}
+	if !ok || pending.Component != memberType {
+		pending = v1alpha1.PendingScaleIn{Component: memberType, RequestTime: metav1.Now()}
+		deps.Recorder.Eventf(tc, corev1.EventTypeNormal, scaleInApprovalRequestedReason, "scale-in of %s pod %s is waiting for approval", memberType, podName)
+	}
+
+	pending.Approved, pending.Message = checkScaleInApproval(tc, memberType, podName, replicas, pending.RequestTime)
+	if tc.Status.PendingScaleIns == nil {
+		tc.Status.PendingScaleIns = map[string]v1alpha1.PendingScaleIn{}
+	}
+	tc.Status.PendingScaleIns[podName] = pending
+	if !pending.Approved {
+		return controller.RequeueErrorf("tidbcluster: [%s/%s]'s scale-in of %s pod %s is not approved yet: %s", ns, tcName, memberType, podName, pending.Message)
+	}
+
+	klog.Infof("tidbcluster: [%s/%s]'s scale-in of %s pod %s is approved", ns, tcName, memberType, podName)
+	deps.Recorder.Eventf(tc, corev1.EventTypeNormal, scaleInApprovedReason, "scale-in of %s pod %s is approved", memberType, podName)
+	return nil
+}
+
+// checkScaleInApproval returns whether the scale-in is approved by the condition and the webhook, and the reason if it's not
+func checkScaleInApproval(tc *v1alpha1.TidbCluster, memberType v1alpha1.MemberType, podName string, replicas int32, requestTime metav1.Time) (bool, string) {
+	approval := tc.Spec.ScaleInApproval
+	if approval.ConditionType != "" {
+		cond := utiltidbcluster.GetTidbClusterCondition(tc.Status, approval.ConditionType)
+		if cond == nil || cond.Status != corev1.ConditionTrue {
+			return false, fmt.Sprintf("condition %s is not True", approval.ConditionType)
+		}
+		if cond.LastUpdateTime.Before(&requestTime) {
+			return false, fmt.Sprintf("condition %s is not updated after the scale-in was requested at %s", approval.ConditionType, requestTime.Format(time.RFC3339))
+		}
+	}
+
+	if approval.Webhook != nil {
+		resp, err := callScaleInApprovalWebhook(tc, &ScaleInReview{
+			Namespace: tc.GetNamespace(),
+			Cluster:   tc.GetName(),
+			Component: memberType.String(),
+			PodName:   podName,
+			Replicas:  replicas,
+		})
+		if err != nil {
+			return false, err.Error()
+		}
+		if !resp.Allowed {
+			if resp.Message == "" {
+				return false, "denied by the webhook"
+			}
+			return false, resp.Message
+		}
+	}
+	return true, ""
+}
+
+func callScaleInApprovalWebhook(tc *v1alpha1.TidbCluster, review *ScaleInReview) (*ScaleInReviewResponse, error) {
+	url := tc.Spec.ScaleInApproval.Webhook.URL
+	data, err := json.Marshal(review)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal the scale-in review: %v", err)
+	}
+	client := &http.Client{Timeout: tc.ScaleInApprovalWebhookTimeout()}
+	res, err := client.Post(url, "application/json", bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to call the webhook %s: %v", url, err)
+	}
+	defer httputil.DeferClose(res.Body)
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the response of the webhook %s: %v", url, err)
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("the webhook %s responds %d: %s", url, res.StatusCode, string(body))
+	}
+	resp := &ScaleInReviewResponse{}
+	if err := json.Unmarshal(body, resp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal the response of the webhook %s: %v", url, err)
+	}
+	return resp, nil
+}
+
+// clearPendingScaleIn removes the pending scale-in of the pod from the status after the scale-in is done
+func clearPendingScaleIn(tc *v1alpha1.TidbCluster, podName string) {
+	delete(tc.Status.PendingScaleIns, podName)
+	if len(tc.Status.PendingScaleIns) == 0 {
+		tc.Status.PendingScaleIns = nil
+	}
+}
+
+// clearPendingScaleIns removes the pending scale-ins of the component from the status when it's not scaling in,
+// e.g. the scale-in is reverted before it's approved
+func clearPendingScaleIns(tc *v1alpha1.TidbCluster, memberType v1alpha1.MemberType) {
+	for podName, pending := range tc.Status.PendingScaleIns {
+		if pending.Component == memberType {
+			clearPendingScaleIn(tc, podName)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestApproveScaleInByCondition(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	deps := controller.NewFakeDependencies()
+	tc := newTidbClusterForPD()
+	g.Expect(approveScaleIn(deps, tc, v1alpha1.PDMemberType, "test-pd-2", 2)).To(Succeed())
+	g.Expect(tc.Status.PendingScaleIns).To(BeEmpty())
+
+	tc.Spec.ScaleInApproval = &v1alpha1.ScaleInApproval{ConditionType: "ChangeApproved"}
+	// TiFlash is not supported
+	g.Expect(approveScaleIn(deps, tc, v1alpha1.TiFlashMemberType, "test-tiflash-2", 2)).To(Succeed())
+
+	err := approveScaleIn(deps, tc, v1alpha1.PDMemberType, "test-pd-2", 2)
+	g.Expect(controller.IsRequeueError(err)).To(BeTrue())
+	pending := tc.Status.PendingScaleIns["test-pd-2"]
+	g.Expect(pending.Component).To(Equal(v1alpha1.PDMemberType))
+	g.Expect(pending.Approved).To(BeFalse())
+	g.Expect(pending.Message).To(ContainSubstring("is not True"))
+
+	// the condition approved an earlier scale-in
+	tc.Status.Conditions = []v1alpha1.TidbClusterCondition{{
+		Type:           "ChangeApproved",
+		Status:         corev1.ConditionTrue,
+		LastUpdateTime: metav1.NewTime(pending.RequestTime.Add(-time.Minute)),
+	}}
+	err = approveScaleIn(deps, tc, v1alpha1.PDMemberType, "test-pd-2", 2)
+	g.Expect(controller.IsRequeueError(err)).To(BeTrue())
+	g.Expect(tc.Status.PendingScaleIns["test-pd-2"].Message).To(ContainSubstring("is not updated"))
+
+	tc.Status.Conditions[0].LastUpdateTime = metav1.NewTime(pending.RequestTime.Add(time.Second))
+	g.Expect(approveScaleIn(deps, tc, v1alpha1.PDMemberType, "test-pd-2", 2)).To(Succeed())
+	g.Expect(tc.Status.PendingScaleIns["test-pd-2"].Approved).To(BeTrue())
+
+	// the approval is kept until the scale-in is done
+	tc.Status.Conditions = nil
+	g.Expect(approveScaleIn(deps, tc, v1alpha1.PDMemberType, "test-pd-2", 2)).To(Succeed())
+	clearPendingScaleIn(tc, "test-pd-2")
+	g.Expect(tc.Status.PendingScaleIns).To(BeNil())
+}
+
+func TestApproveScaleInByWebhook(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	var reviews []ScaleInReview
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		review := ScaleInReview{}
+		if err := json.NewDecoder(r.Body).Decode(&review); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		reviews = append(reviews, review)
+		resp := ScaleInReviewResponse{Allowed: review.PodName == "test-tikv-2"}
+		if !resp.Allowed {
+			resp.Message = "change window is closed"
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	deps := controller.NewFakeDependencies()
+	tc := newTidbClusterForPD()
+	tc.Spec.ScaleInApproval = &v1alpha1.ScaleInApproval{
+		Components: []v1alpha1.MemberType{v1alpha1.TiKVMemberType},
+		Webhook:    &v1alpha1.ScaleInApprovalWebhook{URL: server.URL},
+	}
+	// PD is not in the components
+	g.Expect(approveScaleIn(deps, tc, v1alpha1.PDMemberType, "test-pd-2", 2)).To(Succeed())
+	g.Expect(reviews).To(BeEmpty())
+
+	err := approveScaleIn(deps, tc, v1alpha1.TiKVMemberType, "test-tikv-1", 1)
+	g.Expect(controller.IsRequeueError(err)).To(BeTrue())
+	g.Expect(tc.Status.PendingScaleIns["test-tikv-1"].Message).To(Equal("change window is closed"))
+
+	g.Expect(approveScaleIn(deps, tc, v1alpha1.TiKVMemberType, "test-tikv-2", 2)).To(Succeed())
+	g.Expect(tc.Status.PendingScaleIns["test-tikv-2"].Approved).To(BeTrue())
+	g.Expect(reviews).To(HaveLen(2))
+	g.Expect(reviews[1]).To(Equal(ScaleInReview{
+		Namespace: tc.Namespace,
+		Cluster:   tc.Name,
+		Component: "tikv",
+		PodName:   "test-tikv-2",
+		Replicas:  2,
+	}))
+
+	// the pending scale-ins are cleared when TiKV is not scaling in
+	clearPendingScaleIns(tc, v1alpha1.TiKVMemberType)
+	g.Expect(tc.Status.PendingScaleIns).To(BeNil())
+}
@@ -15,6 +15,7 @@ package member
 
 import (
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/pingcap/advanced-statefulset/client/apis/apps/v1/helper"
@@ -22,7 +23,9 @@ import (
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
 	"github.com/pingcap/tidb-operator/pkg/controller"
 	"github.com/pingcap/tidb-operator/pkg/features"
+	"github.com/pingcap/tidb-operator/pkg/util"
 	apps "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -100,6 +103,34 @@ func (s *generalScaler) deleteDeferDeletingPVC(controller runtime.Object, member
 	return skipReason, nil
 }
 
+// reuseDeferDeletingPVC removes the defer deleting annotation of the PVCs left by the scale-in of the member,
+// so that they're re-used by the pod scaled out at the same ordinal instead of being deleted
+func (s *generalScaler) reuseDeferDeletingPVC(tc *v1alpha1.TidbCluster, memberType v1alpha1.MemberType, ordinal int32) error {
+	ns := tc.GetNamespace()
+	selector, err := GetPVCSelectorForPod(tc, memberType, ordinal)
+	if err != nil {
+		return fmt.Errorf("cluster %s/%s assemble label selector failed, err: %v", ns, tc.Name, err)
+	}
+
+	pvcs, err := s.deps.PVCLister.PersistentVolumeClaims(ns).List(selector)
+	if err != nil {
+		return fmt.Errorf("cluster %s/%s list pvc failed, selector: %s, err: %v", ns, tc.Name, selector, err)
+	}
+	for _, pvc := range pvcs {
+		if _, ok := pvc.Annotations[label.AnnPVCDeferDeleting]; !ok || pvc.DeletionTimestamp != nil {
+			continue
+		}
+		pvc = pvc.DeepCopy()
+		delete(pvc.Annotations, label.AnnPVCDeferDeleting)
+		if _, err := s.deps.PVCControl.UpdatePVC(tc, pvc); err != nil {
+			klog.Errorf("Scale out: failed to remove pvc %s/%s annotation %s, error: %v", ns, pvc.Name, label.AnnPVCDeferDeleting, err)
+			return err
+		}
+		klog.Infof("Scale out: re-use the retained pvc %s/%s", ns, pvc.Name)
+	}
+	return nil
+}
+
 func (s *generalScaler) updateDeferDeletingPVC(tc *v1alpha1.TidbCluster,
 	memberType v1alpha1.MemberType, ordinal int32) error {
 	ns := tc.GetNamespace()
@@ -143,6 +174,71 @@ func (s *generalScaler) updateDeferDeletingPVC(tc *v1alpha1.TidbCluster,
 	return nil
 }
 
+// setZoneAwareDeleteSlots makes the pods in the zones above their quotas declared in spec.topologyZones
+// removed first when scaling in, by adding their ordinals to the delete slots of the advanced StatefulSet.
+// The delete slots set by the annotations of the TidbCluster take precedence. The pods above the quotas are
+// reported by the events, as the quotas are only enforced when the pods are scheduled by tidb-scheduler.
+func (s *generalScaler) setZoneAwareDeleteSlots(tc *v1alpha1.TidbCluster, memberType v1alpha1.MemberType, oldSet *apps.StatefulSet, newSet *apps.StatefulSet) error {
+	quotas := tc.ZoneQuotas(memberType)
+	if len(quotas) == 0 || s.deps.NodeLister == nil {
+		return nil
+	}
+	advancedStatefulSet := features.DefaultFeatureGate.Enabled(features.AdvancedStatefulSet)
+	if advancedStatefulSet && helper.GetDeleteSlots(newSet).Len() > 0 {
+		return nil
+	}
+	deleteSlots := helper.GetDeleteSlots(oldSet)
+
+	ns := tc.GetNamespace()
+	selector, err := label.New().Instance(tc.GetInstanceName()).Component(memberType.String()).Selector()
+	if err != nil {
+		return fmt.Errorf("cluster %s/%s assemble label selector failed, err: %v", ns, tc.GetName(), err)
+	}
+	pods, err := s.deps.PodLister.Pods(ns).List(selector)
+	if err != nil {
+		return fmt.Errorf("cluster %s/%s list pods of %s failed, err: %v", ns, tc.GetName(), memberType, err)
+	}
+	topologyKey := tc.ZoneTopologyKey()
+	zoneOrdinals := map[string][]int32{}
+	for _, pod := range pods {
+		if pod.Spec.NodeName == "" || pod.DeletionTimestamp != nil {
+			continue
+		}
+		ordinal, err := util.GetOrdinalFromPodName(pod.Name)
+		if err != nil || deleteSlots.Has(ordinal) {
+			continue
+		}
+		node, err := s.deps.NodeLister.Get(pod.Spec.NodeName)
+		if err != nil {
+			return fmt.Errorf("cluster %s/%s get node %s of pod %s failed, err: %v", ns, tc.GetName(), pod.Spec.NodeName, pod.Name, err)
+		}
+		zone := node.Labels[topologyKey]
+		zoneOrdinals[zone] = append(zoneOrdinals[zone], ordinal)
+	}
+
+	// the pods with the largest ordinals in a zone above its quota are removed first
+	var excess []int32
+	for zone, ordinals := range zoneOrdinals {
+		over := len(ordinals) - int(quotas[zone])
+		if over <= 0 {
+			continue
+		}
+		s.deps.Recorder.Eventf(tc, corev1.EventTypeWarning, "ZoneQuotaExceeded",
+			"%d pods of %s are in zone %q above its quota %d", len(ordinals), memberType, zone, quotas[zone])
+		sort.Slice(ordinals, func(i, j int) bool { return ordinals[i] > ordinals[j] })
+		excess = append(excess, ordinals[:over]...)
+	}
+	if !advancedStatefulSet {
+		return nil
+	}
+	sort.Slice(excess, func(i, j int) bool { return excess[i] > excess[j] })
+	for i := 0; i < len(excess) && i < int(*oldSet.Spec.Replicas-*newSet.Spec.Replicas); i++ {
+		deleteSlots.Insert(excess[i])
+	}
+	helper.SetDeleteSlots(newSet, deleteSlots)
+	return nil
+}
+
 func resetReplicas(newSet *apps.StatefulSet, oldSet *apps.StatefulSet) {
 	*newSet.Spec.Replicas = *oldSet.Spec.Replicas
 	if features.DefaultFeatureGate.Enabled(features.AdvancedStatefulSet) {
@@ -1793,3 +1793,60 @@ func TestSetReplicasAndDeleteSlotsByFinished(t *testing.T) {
 		})
 	}
 }
+
+func TestSetZoneAwareDeleteSlots(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	features.DefaultFeatureGate.Set("AdvancedStatefulSet=true")
+	defer features.DefaultFeatureGate.Set("AdvancedStatefulSet=false")
+
+	tc := newTidbClusterForPD()
+	tc.Spec.TopologyZones = &v1alpha1.TopologyZones{
+		TiKV: map[string]int32{"zone-a": 1, "zone-b": 2},
+	}
+	gs, _, _ := newFakeGeneralScaler()
+	podIndexer := gs.deps.KubeInformerFactory.Core().V1().Pods().Informer().GetIndexer()
+	nodeIndexer := gs.deps.KubeInformerFactory.Core().V1().Nodes().Informer().GetIndexer()
+	for _, zone := range []string{"zone-a", "zone-b"} {
+		nodeIndexer.Add(&corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: zone, Labels: map[string]string{corev1.LabelTopologyZone: zone}},
+		})
+	}
+	// zone-a has 2 pods above its quota 1 and zone-b has 2 pods
+	for ordinal, zone := range []string{"zone-a", "zone-b", "zone-a", "zone-b"} {
+		podIndexer.Add(&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      ordinalPodName(v1alpha1.TiKVMemberType, tc.GetName(), int32(ordinal)),
+				Namespace: tc.GetNamespace(),
+				Labels:    label.New().Instance(tc.GetInstanceName()).TiKV(),
+			},
+			Spec: corev1.PodSpec{NodeName: zone},
+		})
+	}
+
+	oldSet := newStatefulSetWithReplicas(4)
+	newSet := newStatefulSetWithReplicas(3)
+	g.Expect(gs.setZoneAwareDeleteSlots(tc, v1alpha1.TiKVMemberType, oldSet, newSet)).To(Succeed())
+	g.Expect(helper.GetDeleteSlots(newSet)).To(Equal(sets.NewInt32(2)))
+	_, ordinal, _, _ := scaleOne(oldSet, newSet)
+	g.Expect(ordinal).To(Equal(int32(2)))
+
+	// the delete slots of the old StatefulSet are kept when not scaling
+	setReplicasAndDeleteSlots(oldSet, 3, sets.NewInt32(2))
+	newSet = newStatefulSetWithReplicas(3)
+	g.Expect(gs.setZoneAwareDeleteSlots(tc, v1alpha1.TiKVMemberType, oldSet, newSet)).To(Succeed())
+	g.Expect(helper.GetDeleteSlots(newSet)).To(Equal(sets.NewInt32(2)))
+
+	// the delete slots set by the annotations take precedence
+	newSet = newStatefulSetWithReplicas(2)
+	helper.SetDeleteSlots(newSet, sets.NewInt32(0))
+	g.Expect(gs.setZoneAwareDeleteSlots(tc, v1alpha1.TiKVMemberType, oldSet, newSet)).To(Succeed())
+	g.Expect(helper.GetDeleteSlots(newSet)).To(Equal(sets.NewInt32(0)))
+}
+
+func newStatefulSetWithReplicas(replicas int32) *apps.StatefulSet {
+	return &apps.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-tikv", Namespace: corev1.NamespaceDefault},
+		Spec:       apps.StatefulSetSpec{Replicas: pointer.Int32Ptr(replicas)},
+	}
+}
@@ -0,0 +1,237 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"fmt"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/manager"
+	mngerutils "github.com/pingcap/tidb-operator/pkg/manager/utils"
+	apps "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	standbyImagePullerComponent = "standby-image-puller"
+	// standbyNoopBinaryDir is the directory a no-op binary is copied to from the helper image,
+	// the images to pull run it instead of relying on a shell in the images
+	standbyNoopBinaryDir = "/standby-image-puller"
+)
+
+type standbyManager struct {
+	deps *controller.Dependencies
+}
+
+// NewStandbyManager returns a manager that pre-pulls the images of a warm standby TidbCluster
+func NewStandbyManager(deps *controller.Dependencies) manager.Manager {
+	return &standbyManager{deps: deps}
+}
+
+// Sync creates or updates the image puller DaemonSets of the components of a warm standby TidbCluster,
+// and deletes them once the cluster is promoted, pre-pulling is disabled or the component is removed.
+func (m *standbyManager) Sync(tc *v1alpha1.TidbCluster) error {
+	prePull := tc.Spec.Standby != nil && tc.Spec.Standby.PrePullImages
+	for _, memberType := range []v1alpha1.MemberType{v1alpha1.PDMemberType, v1alpha1.TiKVMemberType, v1alpha1.TiFlashMemberType, v1alpha1.TiDBMemberType} {
+		ds := getStandbyImagePullerDaemonSet(tc, memberType)
+		if ds == nil || !prePull {
+			name := standbyImagePullerName(tc.Name, memberType)
+			exist, err := m.deps.TypedControl.Exist(client.ObjectKey{Namespace: tc.Namespace, Name: name}, &apps.DaemonSet{})
+			if err != nil {
+				return err
+			}
+			if !exist {
+				continue
+			}
+			klog.Infof("tc[%s/%s] is not pre-pulling the images of %s, delete daemonset %s", tc.Namespace, tc.Name, memberType, name)
+			if err := m.deps.TypedControl.Delete(tc, &apps.DaemonSet{ObjectMeta: metav1.ObjectMeta{Namespace: tc.Namespace, Name: name}}); err != nil {
+				return err
+			}
+			continue
+		}
+
+		_, err := m.deps.GenericControl.CreateOrUpdate(tc, ds, func(existing, desired client.Object) error {
+			existingDs := existing.(*apps.DaemonSet)
+			desiredDs := desired.(*apps.DaemonSet)
+			existingDs.Labels = desiredDs.Labels
+			existingDs.Spec.Template = desiredDs.Spec.Template
+			return nil
+		}, true)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func standbyImagePullerName(tcName string, memberType v1alpha1.MemberType) string {
+	return fmt.Sprintf("%s-%s-%s", tcName, memberType, standbyImagePullerComponent)
+}
+
+// getStandbyImagePullerDaemonSet returns a DaemonSet that pulls the image of the component of tc on every
+// node the component can be scheduled on, it returns nil if the component is not in tc.
+// The image is pulled by an init container that only runs a no-op binary copied from the helper image,
+// so it neither depends on a shell in the image nor runs the component.
+func getStandbyImagePullerDaemonSet(tc *v1alpha1.TidbCluster, memberType v1alpha1.MemberType) *apps.DaemonSet {
+	var image string
+	var baseSpec v1alpha1.ComponentAccessor
+	switch memberType {
+	case v1alpha1.PDMemberType:
+		if tc.Spec.PD == nil {
+			return nil
+		}
+		image, baseSpec = tc.PDImage(), tc.BasePDSpec()
+	case v1alpha1.TiKVMemberType:
+		if tc.Spec.TiKV == nil {
+			return nil
+		}
+		image, baseSpec = tc.TiKVImage(), tc.BaseTiKVSpec()
+	case v1alpha1.TiFlashMemberType:
+		if tc.Spec.TiFlash == nil {
+			return nil
+		}
+		image, baseSpec = tc.TiFlashImage(), tc.BaseTiFlashSpec()
+	case v1alpha1.TiDBMemberType:
+		if tc.Spec.TiDB == nil {
+			return nil
+		}
+		image, baseSpec = tc.TiDBImage(), tc.BaseTiDBSpec()
+	default:
+		return nil
+	}
+
+	dsLabels := label.New().Instance(tc.GetInstanceName()).Component(fmt.Sprintf("%s-%s", memberType, standbyImagePullerComponent))
+	noopVolume := corev1.VolumeMount{Name: "noop", MountPath: standbyNoopBinaryDir}
+
+	return &apps.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            standbyImagePullerName(tc.Name, memberType),
+			Namespace:       tc.Namespace,
+			Labels:          dsLabels.Labels(),
+			OwnerReferences: []metav1.OwnerReference{controller.GetOwnerRef(tc)},
+		},
+		Spec: apps.DaemonSetSpec{
+			Selector: dsLabels.LabelSelector(),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: dsLabels.Labels(),
+				},
+				Spec: corev1.PodSpec{
+					InitContainers: []corev1.Container{
+						{
+							Name:            "copy-noop",
+							Image:           tc.HelperImage(),
+							ImagePullPolicy: tc.HelperImagePullPolicy(),
+							Command:         []string{"cp", "/bin/true", standbyNoopBinaryDir + "/true"},
+							VolumeMounts:    []corev1.VolumeMount{noopVolume},
+						},
+						{
+							Name:            "pull",
+							Image:           image,
+							ImagePullPolicy: corev1.PullIfNotPresent,
+							Command:         []string{standbyNoopBinaryDir + "/true"},
+							VolumeMounts:    []corev1.VolumeMount{noopVolume},
+						},
+					},
+					Containers: []corev1.Container{
+						{
+							Name:            "pause",
+							Image:           tc.HelperImage(),
+							ImagePullPolicy: tc.HelperImagePullPolicy(),
+							Command:         []string{"/bin/sh", "-c", "trap 'exit 0' TERM; while true; do sleep 3600 & wait; done"},
+						},
+					},
+					Volumes: []corev1.Volume{
+						{Name: noopVolume.Name, VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}},
+					},
+					ImagePullSecrets: baseSpec.ImagePullSecrets(),
+					NodeSelector:     baseSpec.NodeSelector(),
+					Tolerations:      baseSpec.Tolerations(),
+				},
+			},
+		},
+	}
+}
+
+// isStandbyStatefulSet returns whether set is a StatefulSet reconciled by syncStandbyStatefulSet
+// that has not been scaled up yet, i.e. the cluster is being promoted if it is no longer a standby.
+func isStandbyStatefulSet(set *apps.StatefulSet) bool {
+	return set != nil && set.Annotations[label.AnnStandbyKey] == label.AnnStandbyVal &&
+		set.Spec.Replicas != nil && *set.Spec.Replicas == 0
+}
+
+// syncStandbyStatefulSet keeps the StatefulSet of a component of a warm standby TidbCluster at
+// zero replicas with the latest template, and pre-provisions the PVCs of the desired ordinals,
+// so that promoting the cluster only scales up the StatefulSet. oldSet is nil if it doesn't exist.
+func syncStandbyStatefulSet(deps *controller.Dependencies, tc *v1alpha1.TidbCluster, ordinals sets.Int32, newSet, oldSet *apps.StatefulSet) error {
+	if oldSet != nil && *oldSet.Spec.Replicas > 0 {
+		return fmt.Errorf("tc[%s/%s] is running %d replicas of sts %s, it can not be turned into a standby cluster", tc.Namespace, tc.Name, *oldSet.Spec.Replicas, oldSet.Name)
+	}
+	if err := preProvisionStandbyPVCs(deps, tc, ordinals, newSet); err != nil {
+		return err
+	}
+	if newSet.Annotations == nil {
+		newSet.Annotations = map[string]string{}
+	}
+	newSet.Annotations[label.AnnStandbyKey] = label.AnnStandbyVal
+
+	if oldSet == nil {
+		if err := mngerutils.SetStatefulSetLastAppliedConfigAnnotation(newSet); err != nil {
+			return err
+		}
+		return deps.StatefulSetControl.CreateStatefulSet(tc, newSet)
+	}
+	// no pod is running, the template is applied without rolling upgrade
+	mngerutils.SetUpgradePartition(newSet, 0)
+	return mngerutils.UpdateStatefulSet(deps.StatefulSetControl, tc, newSet, oldSet)
+}
+
+// preProvisionStandbyPVCs creates the PVCs that the StatefulSet controller would create
+// for the ordinals from the volume claim templates of set.
+func preProvisionStandbyPVCs(deps *controller.Dependencies, tc *v1alpha1.TidbCluster, ordinals sets.Int32, set *apps.StatefulSet) error {
+	for _, ordinal := range ordinals.List() {
+		for _, tmpl := range set.Spec.VolumeClaimTemplates {
+			name := fmt.Sprintf("%s-%s-%d", tmpl.Name, set.Name, ordinal)
+			_, err := deps.PVCLister.PersistentVolumeClaims(set.Namespace).Get(name)
+			if err == nil {
+				continue
+			}
+			if !errors.IsNotFound(err) {
+				return fmt.Errorf("preProvisionStandbyPVCs: failed to get pvc %s/%s for tc %s/%s, error: %v", set.Namespace, name, tc.Namespace, tc.Name, err)
+			}
+
+			pvc := tmpl.DeepCopy()
+			pvc.Name = name
+			pvc.Namespace = set.Namespace
+			if pvc.Labels == nil {
+				pvc.Labels = map[string]string{}
+			}
+			for k, v := range set.Spec.Selector.MatchLabels {
+				pvc.Labels[k] = v
+			}
+			klog.Infof("tc[%s/%s] is a standby cluster, pre-provision pvc %s", tc.Namespace, tc.Name, name)
+			if err := deps.PVCControl.CreatePVC(tc, pvc); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	apps "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/pointer"
+)
+
+func TestSyncStandbyStatefulSet(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	deps := controller.NewFakeDependencies()
+	tc := newTidbClusterForPD()
+	tc.Spec.Standby = &v1alpha1.StandbySpec{}
+	g.Expect(tc.PDStsDesiredReplicas()).To(Equal(int32(0)))
+	g.Expect(tc.PDStsDesiredOrdinals(true).List()).To(Equal([]int32{0, 1, 2}))
+
+	selector := label.New().Instance(tc.Name).PD()
+	newSet := &apps.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      controller.PDMemberName(tc.Name),
+			Namespace: tc.Namespace,
+		},
+		Spec: apps.StatefulSetSpec{
+			Replicas: pointer.Int32Ptr(tc.PDStsDesiredReplicas()),
+			Selector: selector.LabelSelector(),
+			VolumeClaimTemplates: []corev1.PersistentVolumeClaim{
+				{ObjectMeta: metav1.ObjectMeta{Name: "pd"}},
+			},
+			UpdateStrategy: apps.StatefulSetUpdateStrategy{
+				Type: apps.RollingUpdateStatefulSetStrategyType,
+			},
+		},
+	}
+
+	g.Expect(syncStandbyStatefulSet(deps, tc, tc.PDStsDesiredOrdinals(true), newSet, nil)).To(Succeed())
+	for _, ordinal := range []string{"0", "1", "2"} {
+		pvc, err := deps.PVCLister.PersistentVolumeClaims(tc.Namespace).Get("pd-test-pd-" + ordinal)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(pvc.Labels).To(Equal(selector.Labels()))
+	}
+	set, err := deps.StatefulSetLister.StatefulSets(tc.Namespace).Get(newSet.Name)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(*set.Spec.Replicas).To(Equal(int32(0)))
+
+	// a running cluster can not be turned into a standby cluster
+	oldSet := set.DeepCopy()
+	oldSet.Spec.Replicas = pointer.Int32Ptr(3)
+	g.Expect(syncStandbyStatefulSet(deps, tc, tc.PDStsDesiredOrdinals(true), newSet, oldSet)).NotTo(Succeed())
+}
+
+func TestGetStandbyImagePullerDaemonSet(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbClusterForPD()
+	tc.Spec.NodeSelector = map[string]string{"dr": "true"}
+	tc.Spec.Standby = &v1alpha1.StandbySpec{PrePullImages: true}
+
+	tc.Spec.TiKV.NodeSelector = map[string]string{"disk": "nvme"}
+	tc.Spec.TiKV.Tolerations = []corev1.Toleration{{Key: "tikv", Operator: corev1.TolerationOpExists}}
+	tc.Spec.TiDB = nil
+
+	ds := getStandbyImagePullerDaemonSet(tc, v1alpha1.PDMemberType)
+	g.Expect(ds.Name).To(Equal("test-pd-standby-image-puller"))
+	g.Expect(ds.Spec.Template.Spec.NodeSelector).To(Equal(tc.Spec.NodeSelector))
+	g.Expect(ds.Spec.Template.Spec.InitContainers).To(HaveLen(2))
+	g.Expect(ds.Spec.Template.Spec.InitContainers[1].Image).To(Equal("pd-test-image"))
+	g.Expect(ds.Spec.Template.Spec.InitContainers[1].Command).To(Equal([]string{"/standby-image-puller/true"}))
+
+	ds = getStandbyImagePullerDaemonSet(tc, v1alpha1.TiKVMemberType)
+	g.Expect(ds.Name).To(Equal("test-tikv-standby-image-puller"))
+	g.Expect(ds.Spec.Template.Spec.InitContainers[1].Image).To(Equal("tikv-test-image"))
+	g.Expect(ds.Spec.Template.Spec.NodeSelector).To(Equal(map[string]string{"dr": "true", "disk": "nvme"}))
+	g.Expect(ds.Spec.Template.Spec.Tolerations).To(Equal(tc.Spec.TiKV.Tolerations))
+
+	g.Expect(getStandbyImagePullerDaemonSet(tc, v1alpha1.TiDBMemberType)).To(BeNil())
+}
+
+func TestIsStandbyStatefulSet(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	deps := controller.NewFakeDependencies()
+	tc := newTidbClusterForPD()
+	tc.Spec.Standby = &v1alpha1.StandbySpec{}
+	newSet := &apps.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      controller.PDMemberName(tc.Name),
+			Namespace: tc.Namespace,
+		},
+		Spec: apps.StatefulSetSpec{
+			Replicas: pointer.Int32Ptr(0),
+			Selector: label.New().Instance(tc.Name).PD().LabelSelector(),
+		},
+	}
+	g.Expect(isStandbyStatefulSet(newSet)).To(BeFalse())
+
+	g.Expect(syncStandbyStatefulSet(deps, tc, sets.NewInt32(), newSet, nil)).To(Succeed())
+	set, err := deps.StatefulSetLister.StatefulSets(tc.Namespace).Get(newSet.Name)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(isStandbyStatefulSet(set)).To(BeTrue())
+
+	// the standby sts has been scaled up
+	set.Spec.Replicas = pointer.Int32Ptr(3)
+	g.Expect(isStandbyStatefulSet(set)).To(BeFalse())
+}
@@ -51,8 +51,10 @@ func RenderTiKVStartScript(tc *v1alpha1.TidbCluster) (string, error) {
 	if tc.Spec.PreferIPv6 {
 		listenHost = "[::]"
 	}
-	model.Addr = fmt.Sprintf("%s:20160", listenHost)
-	model.StatusAddr = fmt.Sprintf("%s:20180", listenHost)
+	model.Port = tc.Spec.TiKV.GetServerPort()
+	model.StatusPort = tc.Spec.TiKV.GetStatusPort()
+	model.Addr = fmt.Sprintf("%s:%d", listenHost, model.Port)
+	model.StatusAddr = fmt.Sprintf("%s:%d", listenHost, model.StatusPort)
 
 	return renderTemplateFunc(tikvStartScriptTpl, model)
 }
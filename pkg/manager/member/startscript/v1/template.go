@@ -273,10 +273,10 @@ done
 ARGS="--pd=${result} \
 {{ else }}
 ARGS="--pd={{ .PDAddress }} \{{ end }}
---advertise-addr=${POD_NAME}.${HEADLESS_SERVICE_NAME}.${NAMESPACE}.svc{{ .FormatClusterDomain }}:20160 \
+--advertise-addr=${POD_NAME}.${HEADLESS_SERVICE_NAME}.${NAMESPACE}.svc{{ .FormatClusterDomain }}:{{ .Port }} \
 --addr={{ .Addr }} \
 --status-addr={{ .StatusAddr }} \{{if .EnableAdvertiseStatusAddr }}
---advertise-status-addr={{ .AdvertiseStatusAddr }}:20180 \{{end}}
+--advertise-status-addr={{ .AdvertiseStatusAddr }}:{{ .StatusPort }} \{{end}}
 --data-dir={{ .DataDir }} \
 --capacity=${CAPACITY} \
 --config=/etc/tikv/tikv.toml
@@ -301,6 +301,8 @@ type TiKVStartScriptModel struct {
 	PDAddress                 string
 	Addr                      string
 	StatusAddr                string
+	Port                      int32
+	StatusPort                int32
 }
 
 // pumpStartScriptTpl is the template string of pump start script
@@ -422,6 +424,85 @@ func RenderTiDBInitStartScript(model *TiDBInitStartScriptModel) (string, error)
 	return renderTemplateFunc(tidbInitStartScriptTpl, model)
 }
 
+// tidbInitSQLBundleScriptTpl is the template string of the script applying the SQL bundle of tidb initializer,
+// the scripts are applied in the order of their names and the applied ones are recorded in the metadata table
+var tidbInitSQLBundleScriptTpl = template.Must(template.New("tidb-init-sql-bundle-script").Parse(`import hashlib, os, sys, time, MySQLdb
+host = '{{ .ClusterName }}-tidb'
+port = {{ .TiDBServicePort }}
+bundle_dir = '{{ .BundleDir }}'
+table = '{{ .MetadataTable }}'
+{{- if .PasswordSet }}
+root_password = ''
+if os.path.exists('/etc/tidb/password/root'):
+    with open('/etc/tidb/password/root', 'r') as f:
+        lines = f.read().splitlines()
+        root_password = lines[0] if len(lines) > 0 else ""
+{{- end }}
+retry_count = 0
+for i in range(0, 10):
+    try:
+{{- if and .TLS .SkipCA }}
+        conn = MySQLdb.connect(host=host, port=port, user='root', charset='utf8mb4',connect_timeout=5, ssl={'cert': '{{ .CertPath }}', 'key': '{{ .KeyPath }}'}{{ if .PasswordSet }}, passwd=root_password{{ end }})
+{{- else if .TLS }}
+        conn = MySQLdb.connect(host=host, port=port, user='root', charset='utf8mb4',connect_timeout=5, ssl={'ca': '{{ .CAPath }}', 'cert': '{{ .CertPath }}', 'key': '{{ .KeyPath }}'}{{ if .PasswordSet }}, passwd=root_password{{ end }})
+{{- else }}
+        conn = MySQLdb.connect(host=host, port=port, user='root', connect_timeout=5, charset='utf8mb4'{{ if .PasswordSet }}, passwd=root_password{{ end }})
+{{- end }}
+    except MySQLdb.OperationalError as e:
+        print(e)
+        retry_count += 1
+        time.sleep(1)
+        continue
+    break
+if retry_count == 10:
+    sys.exit(1)
+cursor = conn.cursor()
+cursor.execute("create table if not exists " + table + " (name varchar(255) primary key, checksum char(64) not null, applied_at timestamp not null default current_timestamp);")
+cursor.execute("select name, checksum from " + table + ";")
+applied = dict(cursor.fetchall())
+for name in sorted(os.listdir(bundle_dir)):
+    file = os.path.join(bundle_dir, name)
+    if name.startswith('.') or not os.path.isfile(file):
+        continue
+    with open(file, 'r') as f:
+        content = f.read()
+    checksum = hashlib.sha256(content.encode('utf-8')).hexdigest()
+    if name in applied:
+        if applied[name] != checksum:
+            print("script %s is changed after it was applied" % name)
+            sys.exit(1)
+        continue
+    statement = ''
+    for line in content.splitlines():
+        statement += line + '\n'
+        if line.strip().endswith(';'):
+            cursor.execute(statement)
+            statement = ''
+    if statement.strip():
+        cursor.execute(statement)
+    cursor.execute("insert into " + table + " (name, checksum) values (%s, %s);", (name, checksum,))
+    conn.commit()
+    print("script %s is applied" % name)
+conn.close()
+`))
+
+type TiDBInitSQLBundleScriptModel struct {
+	ClusterName     string
+	BundleDir       string
+	MetadataTable   string
+	PasswordSet     bool
+	TLS             bool
+	SkipCA          bool
+	CAPath          string
+	CertPath        string
+	KeyPath         string
+	TiDBServicePort int32
+}
+
+func RenderTiDBInitSQLBundleScript(model *TiDBInitSQLBundleScriptModel) (string, error) {
+	return renderTemplateFunc(tidbInitSQLBundleScriptTpl, model)
+}
+
 // tidbInitInitStartScriptTpl is the template string of tidb initializer init container start script
 var tidbInitInitStartScriptTpl = template.Must(template.New("tidb-init-init-start-script").Parse(`trap exit TERM
 host={{ .ClusterName }}-tidb
@@ -543,6 +624,9 @@ sleep $((RANDOM % 5))
 done
 ARGS="${ARGS}${result}"
 fi
+{{- if .ExtraArgs }}
+ARGS="${ARGS} {{ .ExtraArgs }}"
+{{- end }}
 
 echo "starting dm-master ..."
 sleep $((RANDOM % 10))
@@ -565,6 +649,7 @@ type DMMasterStartScriptModel struct {
 	Scheme            string
 	DataDir           string
 	CheckDomainScript string
+	ExtraArgs         string
 }
 
 func RenderDMMasterStartScript(model *DMMasterStartScriptModel) (string, error) {
@@ -616,6 +701,9 @@ if [ ! -z "${STORE_LABELS:-}" ]; then
   LABELS=" --labels ${STORE_LABELS} "
   ARGS="${ARGS}${LABELS}"
 fi
+{{- if .ExtraArgs }}
+ARGS="${ARGS} {{ .ExtraArgs }}"
+{{- end }}
 
 echo "starting dm-worker ..."
 echo "/dm-worker ${ARGS}"
@@ -625,6 +713,7 @@ exec /dm-worker ${ARGS}
 type DMWorkerStartScriptModel struct {
 	DataDir       string
 	MasterAddress string
+	ExtraArgs     string
 }
 
 func RenderDMWorkerStartScript(model *DMWorkerStartScriptModel) (string, error) {
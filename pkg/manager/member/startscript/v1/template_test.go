@@ -1864,6 +1864,88 @@ conn.close()
 	}
 }
 
+func TestRenderTiDBInitSQLBundleScript(t *testing.T) {
+	tests := []struct {
+		name   string
+		model  *TiDBInitSQLBundleScriptModel
+		result string
+	}{
+		{
+			name: "password set",
+			model: &TiDBInitSQLBundleScriptModel{
+				ClusterName:     "test",
+				BundleDir:       "/sql-bundle",
+				MetadataTable:   "mysql.tidb_initializer_scripts",
+				PasswordSet:     true,
+				TiDBServicePort: 4000,
+			},
+			result: `import hashlib, os, sys, time, MySQLdb
+host = 'test-tidb'
+port = 4000
+bundle_dir = '/sql-bundle'
+table = 'mysql.tidb_initializer_scripts'
+root_password = ''
+if os.path.exists('/etc/tidb/password/root'):
+    with open('/etc/tidb/password/root', 'r') as f:
+        lines = f.read().splitlines()
+        root_password = lines[0] if len(lines) > 0 else ""
+retry_count = 0
+for i in range(0, 10):
+    try:
+        conn = MySQLdb.connect(host=host, port=port, user='root', connect_timeout=5, charset='utf8mb4', passwd=root_password)
+    except MySQLdb.OperationalError as e:
+        print(e)
+        retry_count += 1
+        time.sleep(1)
+        continue
+    break
+if retry_count == 10:
+    sys.exit(1)
+cursor = conn.cursor()
+cursor.execute("create table if not exists " + table + " (name varchar(255) primary key, checksum char(64) not null, applied_at timestamp not null default current_timestamp);")
+cursor.execute("select name, checksum from " + table + ";")
+applied = dict(cursor.fetchall())
+for name in sorted(os.listdir(bundle_dir)):
+    file = os.path.join(bundle_dir, name)
+    if name.startswith('.') or not os.path.isfile(file):
+        continue
+    with open(file, 'r') as f:
+        content = f.read()
+    checksum = hashlib.sha256(content.encode('utf-8')).hexdigest()
+    if name in applied:
+        if applied[name] != checksum:
+            print("script %s is changed after it was applied" % name)
+            sys.exit(1)
+        continue
+    statement = ''
+    for line in content.splitlines():
+        statement += line + '\n'
+        if line.strip().endswith(';'):
+            cursor.execute(statement)
+            statement = ''
+    if statement.strip():
+        cursor.execute(statement)
+    cursor.execute("insert into " + table + " (name, checksum) values (%s, %s);", (name, checksum,))
+    conn.commit()
+    print("script %s is applied" % name)
+conn.close()
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			script, err := RenderTiDBInitSQLBundleScript(tt.model)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tt.result, script); diff != "" {
+				t.Errorf("unexpected (-want, +got): %s", diff)
+			}
+		})
+	}
+}
+
 func TestRenderDMMasterStartScript(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -15,7 +15,10 @@ package v2
 
 import (
 	"bytes"
+	"fmt"
 	"text/template"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
 )
 
 const (
@@ -51,6 +54,16 @@ type AcrossK8sScriptModel struct {
 	PDAddr string
 }
 
+// appendAdditionalArgs appends the additional args specified by users to the extra args generated by the
+// operator. It returns an error if an additional arg sets a flag generated by the operator, which is either
+// one of the flags set by the start script of the component or a flag in extraArgs.
+func appendAdditionalArgs(memberType v1alpha1.MemberType, extraArgs, additionalArgs []string) ([]string, error) {
+	if arg, name := v1alpha1.AdditionalArgsConflict(memberType, additionalArgs, extraArgs...); arg != "" {
+		return nil, fmt.Errorf("additional arg %q conflicts with the flag %q generated by the operator", arg, name)
+	}
+	return append(extraArgs, additionalArgs...), nil
+}
+
 func renderTemplateFunc(tpl *template.Template, model interface{}) (string, error) {
 	buff := new(bytes.Buffer)
 	err := tpl.Execute(buff, model)
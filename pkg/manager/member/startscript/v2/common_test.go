@@ -19,6 +19,7 @@ import (
 	"testing"
 
 	"github.com/onsi/gomega"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
 	"mvdan.cc/sh/v3/syntax"
 )
 
@@ -60,3 +61,19 @@ func validateScript(script string) error {
 	_, err := syntax.NewParser().Parse(strings.NewReader(script), "")
 	return err
 }
+
+func TestAppendAdditionalArgs(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	args, err := appendAdditionalArgs(v1alpha1.TiKVMemberType, []string{"--advertise-status-addr=a:20180"}, []string{"--experimental-flag", "-x=1"})
+	g.Expect(err).Should(gomega.Succeed())
+	g.Expect(args).Should(gomega.Equal([]string{"--advertise-status-addr=a:20180", "--experimental-flag", "-x=1"}))
+
+	// conflict with a reserved flag
+	_, err = appendAdditionalArgs(v1alpha1.TiKVMemberType, nil, []string{"-config=/tmp/tikv.toml"})
+	g.Expect(err).Should(gomega.HaveOccurred())
+
+	// conflict with a generated extra arg
+	_, err = appendAdditionalArgs(v1alpha1.TiFlashMemberType, []string{"--log-level=info"}, []string{"--log-level=debug"})
+	g.Expect(err).Should(gomega.HaveOccurred())
+}
@@ -16,6 +16,7 @@ package v2
 import (
 	"fmt"
 	"path/filepath"
+	"strings"
 	"text/template"
 
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
@@ -34,6 +35,13 @@ type PDStartScriptModel struct {
 	AdvertiseClientURL string
 	DiscoveryAddr      string
 	ExtraArgs          string
+
+	// EmbeddedDiscovery renders the discovery logic into the script instead of asking the discovery service
+	EmbeddedDiscovery bool
+	// PDServiceHost is the host of the PD service to join in the embedded discovery mode
+	PDServiceHost string
+	// PDServiceURL is the client URL of the PD service to join in the embedded discovery mode
+	PDServiceURL string
 }
 
 // RenderPDStartScript renders PD start script from TidbCluster
@@ -65,6 +73,21 @@ func RenderPDStartScript(tc *v1alpha1.TidbCluster) (string, error) {
 
 	m.DiscoveryAddr = fmt.Sprintf("%s-discovery.%s:10261", tcName, tcNS)
 
+	if tc.EmbeddedDiscovery() {
+		m.EmbeddedDiscovery = true
+		m.PDServiceHost = fmt.Sprintf("%s.%s.svc", controller.PDMemberName(tcName), tcNS)
+		if tc.Spec.ClusterDomain != "" {
+			m.PDServiceHost = m.PDServiceHost + "." + tc.Spec.ClusterDomain
+		}
+		m.PDServiceURL = fmt.Sprintf("%s://%s:2379", tc.Scheme(), m.PDServiceHost)
+	}
+
+	extraArgs, err := appendAdditionalArgs(v1alpha1.PDMemberType, nil, tc.Spec.PD.AdditionalArgs)
+	if err != nil {
+		return "", err
+	}
+	m.ExtraArgs = strings.Join(extraArgs, " ")
+
 	return renderTemplateFunc(pdStartScriptTpl, m)
 }
 
@@ -122,12 +145,27 @@ if [[ -f {{ .DataDir }}/join ]]; then
     join=${join%,}
     ARGS="${ARGS} --join=${join}"
 elif [[ ! -d {{ .DataDir }}/member/wal ]]; then
+{{- if .EmbeddedDiscovery }}
+    result=""
+    until [[ -n "${result}" ]]; do
+        if nc -z -w 3 {{ .PDServiceHost }} 2379; then
+            result="--join={{ .PDServiceURL }}"
+        elif [[ ${PD_POD_NAME##*-} -eq 0 ]]; then
+            echo "pd service {{ .PDServiceHost }} is not ready, bootstrap a new pd cluster"
+            result="--initial-cluster={{ .PDName }}={{ .AdvertisePeerURL }}"
+        else
+            echo "waiting for pd service {{ .PDServiceHost }} to be ready to join ..."
+            sleep $((RANDOM % 5))
+        fi
+    done
+{{- else }}
     encoded_domain_url=$(echo ${PD_DOMAIN}:2380 | base64 | tr "\n" " " | sed "s/ //g")
 
     until result=$(wget -qO- -T 3 http://{{ .DiscoveryAddr }}/new/${encoded_domain_url} 2>/dev/null); do
         echo "waiting for discovery service to return start args ..."
         sleep $((RANDOM % 5))
     done
+{{- end }}
     ARGS="${ARGS} ${result}"
 fi
 
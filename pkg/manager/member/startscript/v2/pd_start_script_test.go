@@ -530,6 +530,96 @@ elif [[ ! -d /var/lib/pd/member/wal ]]; then
     ARGS="${ARGS} ${result}"
 fi
 
+echo "starting pd-server ..."
+sleep $((RANDOM % 10))
+echo "/pd-server ${ARGS}"
+exec /pd-server ${ARGS}
+`,
+		},
+		{
+			name: "embedded discovery",
+			modifyTC: func(tc *v1alpha1.TidbCluster) {
+				tc.Spec.Discovery.Mode = v1alpha1.DiscoveryModeEmbedded
+			},
+			expectScript: `#!/bin/sh
+
+set -uo pipefail
+
+ANNOTATIONS="/etc/podinfo/annotations"
+if [[ ! -f "${ANNOTATIONS}" ]]
+then
+    echo "${ANNOTATIONS} does't exist, exiting."
+    exit 1
+fi
+source ${ANNOTATIONS} 2>/dev/null
+
+runmode=${runmode:-normal}
+if [[ X${runmode} == Xdebug ]]
+then
+    echo "entering debug mode."
+    tail -f /dev/null
+fi
+
+PD_POD_NAME=${POD_NAME:-$HOSTNAME}
+PD_DOMAIN=${PD_POD_NAME}.start-script-test-pd-peer.start-script-test-ns.svc
+
+elapseTime=0
+period=1
+threshold=30
+while true; do
+    sleep ${period}
+    elapseTime=$(( elapseTime+period ))
+
+    if [[ ${elapseTime} -ge ${threshold} ]]; then
+        echo "waiting for pd cluster ready timeout" >&2
+        exit 1
+    fi
+
+    digRes=$(dig ${PD_DOMAIN} A ${PD_DOMAIN} AAAA +search +short)
+    if [ $? -ne 0  ]; then
+        echo "domain resolve ${PD_DOMAIN} failed"
+        echo "$digRes"
+        continue
+    fi
+
+    if [ -z "${digRes}" ]
+    then
+        echo "domain resolve ${PD_DOMAIN} no record return"
+    else
+        echo "domain resolve ${PD_DOMAIN} success"
+        echo "$digRes"
+        break
+    fi
+done
+
+ARGS="--data-dir=/var/lib/pd \
+--name=${PD_POD_NAME} \
+--peer-urls=http://0.0.0.0:2380 \
+--advertise-peer-urls=http://${PD_DOMAIN}:2380 \
+--client-urls=http://0.0.0.0:2379 \
+--advertise-client-urls=http://${PD_DOMAIN}:2379 \
+--config=/etc/pd/pd.toml"
+
+if [[ -f /var/lib/pd/join ]]; then
+    join=$(cat /var/lib/pd/join | tr "," "\n" | awk -F'=' '{print $2}' | tr "\n" ",")
+    join=${join%,}
+    ARGS="${ARGS} --join=${join}"
+elif [[ ! -d /var/lib/pd/member/wal ]]; then
+    result=""
+    until [[ -n "${result}" ]]; do
+        if nc -z -w 3 start-script-test-pd.start-script-test-ns.svc 2379; then
+            result="--join=http://start-script-test-pd.start-script-test-ns.svc:2379"
+        elif [[ ${PD_POD_NAME##*-} -eq 0 ]]; then
+            echo "pd service start-script-test-pd.start-script-test-ns.svc is not ready, bootstrap a new pd cluster"
+            result="--initial-cluster=${PD_POD_NAME}=http://${PD_DOMAIN}:2380"
+        else
+            echo "waiting for pd service start-script-test-pd.start-script-test-ns.svc to be ready to join ..."
+            sleep $((RANDOM % 5))
+        fi
+    done
+    ARGS="${ARGS} ${result}"
+fi
+
 echo "starting pd-server ..."
 sleep $((RANDOM % 10))
 echo "/pd-server ${ARGS}"
@@ -15,6 +15,7 @@ package v2
 
 import (
 	"fmt"
+	"strings"
 	"text/template"
 
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
@@ -59,7 +60,11 @@ func RenderPumpStartScript(tc *v1alpha1.TidbCluster) (string, error) {
 	}
 	m.AdvertiseAddr = advertiseAddr + ":8250"
 
-	m.ExtraArgs = ""
+	extraArgs, err := appendAdditionalArgs(v1alpha1.PumpMemberType, nil, tc.Spec.Pump.AdditionalArgs)
+	if err != nil {
+		return "", err
+	}
+	m.ExtraArgs = strings.Join(extraArgs, " ")
 
 	return renderTemplateFunc(pumpStartScriptTpl, m)
 }
@@ -79,6 +79,10 @@ func RenderTiCDCStartScript(tc *v1alpha1.TidbCluster) (string, error) {
 	if tc.Spec.TiCDC.Config != nil && !tc.Spec.TiCDC.Config.OnlyOldItems() {
 		extraArgs = append(extraArgs, fmt.Sprintf("--config=%s", "/etc/ticdc/ticdc.toml"))
 	}
+	extraArgs, err := appendAdditionalArgs(v1alpha1.TiCDCMemberType, extraArgs, tc.Spec.TiCDC.AdditionalArgs)
+	if err != nil {
+		return "", err
+	}
 	if len(extraArgs) > 0 {
 		m.ExtraArgs = strings.Join(extraArgs, " ")
 	}
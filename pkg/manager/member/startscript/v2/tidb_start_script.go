@@ -62,6 +62,10 @@ func RenderTiDBStartScript(tc *v1alpha1.TidbCluster) (string, error) {
 		extraArgs = append(extraArgs, "--plugin-dir=/plugins")
 		extraArgs = append(extraArgs, fmt.Sprintf("--plugin-load=%s", strings.Join(plugins, ",")))
 	}
+	extraArgs, err := appendAdditionalArgs(v1alpha1.TiDBMemberType, extraArgs, tc.Spec.TiDB.AdditionalArgs)
+	if err != nil {
+		return "", err
+	}
 	if len(extraArgs) > 0 {
 		m.ExtraArgs = strings.Join(extraArgs, " ")
 	}
@@ -14,6 +14,7 @@
 package v2
 
 import (
+	"strings"
 	"text/template"
 
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
@@ -28,7 +29,11 @@ type TiFlashStartScriptModel struct {
 func RenderTiFlashStartScript(tc *v1alpha1.TidbCluster) (string, error) {
 	m := &TiFlashStartScriptModel{}
 
-	m.ExtraArgs = ""
+	extraArgs, err := appendAdditionalArgs(v1alpha1.TiFlashMemberType, nil, tc.Spec.TiFlash.AdditionalArgs)
+	if err != nil {
+		return "", err
+	}
+	m.ExtraArgs = strings.Join(extraArgs, " ")
 
 	return renderTemplateFunc(tiflashStartScriptTpl, m)
 }
@@ -59,14 +59,15 @@ func RenderTiKVStartScript(tc *v1alpha1.TidbCluster) (string, error) {
 	if tc.Spec.PreferIPv6 {
 		listenHost = "[::]"
 	}
-	m.Addr = fmt.Sprintf("%s:20160", listenHost)
-	m.StatusAddr = fmt.Sprintf("%s:20180", listenHost)
+	port, statusPort := tc.Spec.TiKV.GetServerPort(), tc.Spec.TiKV.GetStatusPort()
+	m.Addr = fmt.Sprintf("%s:%d", listenHost, port)
+	m.StatusAddr = fmt.Sprintf("%s:%d", listenHost, statusPort)
 
 	advertiseAddr := fmt.Sprintf("${TIKV_POD_NAME}.%s.%s.svc", peerServiceName, tcNS)
 	if tc.Spec.ClusterDomain != "" {
 		advertiseAddr = advertiseAddr + "." + tc.Spec.ClusterDomain
 	}
-	m.AdvertiseAddr = advertiseAddr + ":20160"
+	m.AdvertiseAddr = fmt.Sprintf("%s:%d", advertiseAddr, port)
 
 	m.DataDir = filepath.Join(constants.TiKVDataVolumeMountPath, tc.Spec.TiKV.DataSubDir)
 
@@ -78,7 +79,11 @@ func RenderTiKVStartScript(tc *v1alpha1.TidbCluster) (string, error) {
 		if tc.Spec.ClusterDomain != "" {
 			advertiseStatusAddr = advertiseStatusAddr + "." + tc.Spec.ClusterDomain
 		}
-		extraArgs = append(extraArgs, fmt.Sprintf("--advertise-status-addr=%s:20180", advertiseStatusAddr))
+		extraArgs = append(extraArgs, fmt.Sprintf("--advertise-status-addr=%s:%d", advertiseStatusAddr, statusPort))
+	}
+	extraArgs, err := appendAdditionalArgs(v1alpha1.TiKVMemberType, extraArgs, tc.Spec.TiKV.AdditionalArgs)
+	if err != nil {
+		return "", err
 	}
 	if len(extraArgs) > 0 {
 		m.ExtraArgs = strings.Join(extraArgs, " ")
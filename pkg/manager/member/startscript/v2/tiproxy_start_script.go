@@ -14,6 +14,7 @@
 package v2
 
 import (
+	"strings"
 	"text/template"
 
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
@@ -21,13 +22,24 @@ import (
 
 // TiProxyStartScriptModel contain fields for rendering TiProxy start script
 type TiProxyStartScriptModel struct {
+	ExtraArgs string
 }
 
 // RenderTiProxyStartScript renders tiproxy start script for TidbCluster
 func RenderTiProxyStartScript(tc *v1alpha1.TidbCluster) (string, error) {
 	m := &TiProxyStartScriptModel{}
+
+	extraArgs, err := appendAdditionalArgs(v1alpha1.TiProxyMemberType, nil, tc.Spec.TiProxy.AdditionalArgs)
+	if err != nil {
+		return "", err
+	}
+	m.ExtraArgs = strings.Join(extraArgs, " ")
+
 	return renderTemplateFunc(template.Must(template.New("tiproxy").Parse(componentCommonScript+`
 ARGS="--config=/etc/proxy/proxy.toml"
+{{- if .ExtraArgs }}
+ARGS="${ARGS} {{ .ExtraArgs }}"
+{{- end }}
 echo "starting: tiproxy ${ARGS}"
 exec /bin/tiproxy ${ARGS}
 `)), m)
@@ -0,0 +1,47 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"testing"
+
+	"github.com/onsi/gomega"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+)
+
+func TestRenderTiProxyStartScript(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	tc := &v1alpha1.TidbCluster{
+		Spec: v1alpha1.TidbClusterSpec{
+			TiProxy: &v1alpha1.TiProxySpec{},
+		},
+	}
+	script, err := RenderTiProxyStartScript(tc)
+	g.Expect(err).Should(gomega.Succeed())
+	g.Expect(script).ShouldNot(gomega.ContainSubstring(`ARGS="${ARGS}`))
+	g.Expect(validateScript(script)).Should(gomega.Succeed())
+
+	tc.Spec.TiProxy.AdditionalArgs = []string{"--experimental-flag", "--other=value"}
+	script, err = RenderTiProxyStartScript(tc)
+	g.Expect(err).Should(gomega.Succeed())
+	g.Expect(script).Should(gomega.ContainSubstring(`ARGS="--config=/etc/proxy/proxy.toml"
+ARGS="${ARGS} --experimental-flag --other=value"
+`))
+	g.Expect(validateScript(script)).Should(gomega.Succeed())
+
+	tc.Spec.TiProxy.AdditionalArgs = []string{"--config=/tmp/proxy.toml"}
+	_, err = RenderTiProxyStartScript(tc)
+	g.Expect(err).Should(gomega.HaveOccurred())
+}
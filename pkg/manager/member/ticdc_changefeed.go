@@ -0,0 +1,232 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	mngerutils "github.com/pingcap/tidb-operator/pkg/manager/utils"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// changefeedStateNormal is the state of a running changefeed
+	changefeedStateNormal = "normal"
+	// changefeedStateStopped is the state of a paused changefeed
+	changefeedStateStopped = "stopped"
+
+	// tsoPhysicalShiftBits is the number of the bits of the logical part of a TSO
+	tsoPhysicalShiftBits = 18
+)
+
+// syncChangefeeds creates and reconciles the changefeeds of spec.ticdc.changefeeds via the TiCDC API,
+// and records their checkpoints in the status of TiCDC. The changefeeds recorded in the status but
+// removed from the spec are removed from TiCDC.
+func (m *ticdcMemberManager) syncChangefeeds(tc *v1alpha1.TidbCluster) error {
+	ns := tc.GetNamespace()
+	tcName := tc.GetName()
+
+	if len(tc.Spec.TiCDC.Changefeeds) == 0 && len(tc.Status.TiCDC.Changefeeds) == 0 {
+		return nil
+	}
+	ordinal, ok := readyCaptureOrdinal(tc)
+	if !ok {
+		klog.Infof("TidbCluster: [%s/%s], no TiCDC capture is ready, skip syncing changefeeds", ns, tcName)
+		return nil
+	}
+
+	changefeeds, err := m.deps.CDCControl.ListChangefeeds(tc, ordinal)
+	if err != nil {
+		return fmt.Errorf("failed to list changefeeds of cluster %s/%s: %v", ns, tcName, err)
+	}
+	existing := make(map[string]*controller.ChangefeedInfo, len(changefeeds))
+	for i := range changefeeds {
+		existing[changefeeds[i].ID] = &changefeeds[i]
+	}
+
+	status := map[string]v1alpha1.TiCDCChangefeedStatus{}
+	desired := map[string]bool{}
+	var errs []error
+	for i := range tc.Spec.TiCDC.Changefeeds {
+		changefeed := &tc.Spec.TiCDC.Changefeeds[i]
+		desired[changefeed.ID] = true
+		last := tc.Status.TiCDC.Changefeeds[changefeed.ID]
+		st, err := m.syncChangefeed(tc, ordinal, changefeed, existing[changefeed.ID], last)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("changefeed %s: %v", changefeed.ID, err))
+		}
+		status[changefeed.ID] = st
+	}
+
+	for id, last := range tc.Status.TiCDC.Changefeeds {
+		if desired[id] || existing[id] == nil {
+			continue
+		}
+		klog.Infof("TidbCluster: [%s/%s], remove changefeed %s", ns, tcName, id)
+		if err := m.deps.CDCControl.RemoveChangefeed(tc, ordinal, id); err != nil {
+			errs = append(errs, fmt.Errorf("changefeed %s: %v", id, err))
+			// keep the status so that the removal is retried
+			status[id] = last
+		}
+	}
+
+	if len(status) == 0 {
+		status = nil
+	}
+	tc.Status.TiCDC.Changefeeds = status
+	return utilerrors.NewAggregate(errs)
+}
+
+// syncChangefeed creates the changefeed if it doesn't exist, applies the configuration if it's changed
+// since it's applied last time, and pauses or resumes it according to the spec.
+func (m *ticdcMemberManager) syncChangefeed(tc *v1alpha1.TidbCluster, ordinal int32, changefeed *v1alpha1.TiCDCChangefeed,
+	info *controller.ChangefeedInfo, last v1alpha1.TiCDCChangefeedStatus) (v1alpha1.TiCDCChangefeedStatus, error) {
+	ns := tc.GetNamespace()
+	tcName := tc.GetName()
+	ctl := m.deps.CDCControl
+
+	cfg, err := m.changefeedConfig(tc, changefeed)
+	if err != nil {
+		return last, err
+	}
+	// the start TSO only takes effect on creation, so it's not part of the hash
+	hash, err := mngerutils.Sha256Sum(controller.ChangefeedConfig{
+		ID:          cfg.ID,
+		SinkURI:     cfg.SinkURI,
+		TargetTs:    cfg.TargetTs,
+		FilterRules: cfg.FilterRules,
+	})
+	if err != nil {
+		return last, err
+	}
+
+	if info == nil {
+		klog.Infof("TidbCluster: [%s/%s], create changefeed %s", ns, tcName, changefeed.ID)
+		if err := ctl.CreateChangefeed(tc, ordinal, cfg); err != nil {
+			return last, err
+		}
+		st := v1alpha1.TiCDCChangefeedStatus{State: changefeedStateNormal, ConfigHash: hash}
+		if changefeed.Paused {
+			if err := ctl.PauseChangefeed(tc, ordinal, changefeed.ID); err != nil {
+				return st, err
+			}
+			st.State = changefeedStateStopped
+		}
+		return st, nil
+	}
+
+	st := newChangefeedStatus(info, last.ConfigHash)
+	if hash != last.ConfigHash {
+		// a changefeed can only be updated when it's paused
+		klog.Infof("TidbCluster: [%s/%s], update changefeed %s", ns, tcName, changefeed.ID)
+		if st.State != changefeedStateStopped {
+			if err := ctl.PauseChangefeed(tc, ordinal, changefeed.ID); err != nil {
+				return st, err
+			}
+			st.State = changefeedStateStopped
+		}
+		if err := ctl.UpdateChangefeed(tc, ordinal, cfg); err != nil {
+			return st, err
+		}
+		st.ConfigHash = hash
+	}
+
+	switch {
+	case changefeed.Paused && st.State == changefeedStateNormal:
+		klog.Infof("TidbCluster: [%s/%s], pause changefeed %s", ns, tcName, changefeed.ID)
+		if err := ctl.PauseChangefeed(tc, ordinal, changefeed.ID); err != nil {
+			return st, err
+		}
+		st.State = changefeedStateStopped
+	case !changefeed.Paused && st.State == changefeedStateStopped:
+		klog.Infof("TidbCluster: [%s/%s], resume changefeed %s", ns, tcName, changefeed.ID)
+		if err := ctl.ResumeChangefeed(tc, ordinal, changefeed.ID); err != nil {
+			return st, err
+		}
+		st.State = changefeedStateNormal
+	}
+	return st, nil
+}
+
+// changefeedConfig returns the request to create or update the changefeed, the sink URI is read from
+// the secret if SinkURISecretRef is set
+func (m *ticdcMemberManager) changefeedConfig(tc *v1alpha1.TidbCluster, changefeed *v1alpha1.TiCDCChangefeed) (*controller.ChangefeedConfig, error) {
+	sinkURI := changefeed.SinkURI
+	if ref := changefeed.SinkURISecretRef; ref != nil {
+		secret, err := m.deps.SecretLister.Secrets(tc.GetNamespace()).Get(ref.Name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the secret %s of the sink URI: %v", ref.Name, err)
+		}
+		data, ok := secret.Data[ref.Key]
+		if !ok {
+			return nil, fmt.Errorf("key %s is not found in the secret %s of the sink URI", ref.Key, ref.Name)
+		}
+		sinkURI = string(data)
+	}
+	if sinkURI == "" {
+		return nil, fmt.Errorf("the sink URI is empty")
+	}
+	return &controller.ChangefeedConfig{
+		ID:          changefeed.ID,
+		SinkURI:     sinkURI,
+		StartTs:     changefeed.StartTs,
+		TargetTs:    changefeed.TargetTs,
+		FilterRules: changefeed.FilterRules,
+	}, nil
+}
+
+func newChangefeedStatus(info *controller.ChangefeedInfo, hash string) v1alpha1.TiCDCChangefeedStatus {
+	st := v1alpha1.TiCDCChangefeedStatus{
+		State:         info.State,
+		CheckpointTSO: info.CheckpointTSO,
+		ConfigHash:    hash,
+	}
+	if info.CheckpointTSO > 0 {
+		checkpoint := time.UnixMilli(int64(info.CheckpointTSO >> tsoPhysicalShiftBits))
+		st.CheckpointTime = &metav1.Time{Time: checkpoint}
+		st.CheckpointLag = &metav1.Duration{Duration: time.Since(checkpoint).Truncate(time.Second)}
+	}
+	if info.Error != nil {
+		st.Error = info.Error.Message
+	}
+	return st
+}
+
+// readyCaptureOrdinal returns the ordinal of a ready TiCDC capture, the owner is preferred
+func readyCaptureOrdinal(tc *v1alpha1.TidbCluster) (int32, bool) {
+	var podNames []string
+	for podName, capture := range tc.Status.TiCDC.Captures {
+		if !capture.Ready {
+			continue
+		}
+		if capture.IsOwner {
+			podNames = []string{podName}
+			break
+		}
+		podNames = append(podNames, podName)
+	}
+	sort.Strings(podNames)
+	for _, podName := range podNames {
+		if ordinal, err := parserOrdinal(podName); err == nil {
+			return ordinal, true
+		}
+	}
+	return 0, false
+}
@@ -124,6 +124,7 @@ func (m *ticdcMemberManager) syncTiCDCConfigMap(tc *v1alpha1.TidbCluster, set *a
 	if err != nil {
 		return nil, err
 	}
+	controller.PropagateMetadata(newCm, tc.BaseTiCDCSpec().Metadata())
 	return m.deps.TypedControl.CreateOrUpdateConfigMap(tc, newCm)
 }
 
@@ -161,7 +162,15 @@ func (m *ticdcMemberManager) Sync(tc *v1alpha1.TidbCluster) error {
 		return err
 	}
 
-	return m.syncStatefulSet(tc)
+	if err := m.syncStatefulSet(tc); err != nil {
+		return err
+	}
+
+	if err := syncPodDisruptionBudget(m.deps, tc, ticdcPDBComponent(tc)); err != nil {
+		return err
+	}
+
+	return m.syncChangefeeds(tc)
 }
 
 func (m *ticdcMemberManager) syncStatefulSet(tc *v1alpha1.TidbCluster) error {
@@ -209,6 +218,12 @@ func (m *ticdcMemberManager) syncStatefulSet(tc *v1alpha1.TidbCluster) error {
 		return nil
 	}
 
+	// Pod template changes made in the middle of an upgrade or a scale are held until it
+	// completes, unless the pending changes are applied explicitly. The replicas are not held.
+	if err := holdPendingChanges(tc, v1alpha1.TiCDCMemberType, newSts, oldSts); err != nil {
+		return err
+	}
+
 	// Scaling takes precedence over upgrading because:
 	// - if a pod fails in the upgrading, users may want to delete it or add
 	//   new replicas
@@ -316,6 +331,9 @@ func (m *ticdcMemberManager) syncCDCHeadlessService(tc *v1alpha1.TidbCluster) er
 	if err != nil {
 		return err
 	}
+	if mngerutils.CheckServiceDrift(tc, oldSvc) {
+		equal = false
+	}
 	if !equal {
 		svc := *oldSvc
 		svc.Spec = newSvc.Spec
@@ -359,6 +377,7 @@ func getNewCDCHeadlessService(tc *v1alpha1.TidbCluster) *corev1.Service {
 		},
 	}
 
+	controller.PropagateMetadata(svc, tc.BaseTiCDCSpec().Metadata())
 	if tc.Spec.PreferIPv6 {
 		SetServiceWhenPreferIPv6(svc)
 	}
@@ -471,6 +490,7 @@ func getNewTiCDCStatefulSet(tc *v1alpha1.TidbCluster, cm *corev1.ConfigMap) (*ap
 		Env:          util.AppendEnv(envs, baseTiCDCSpec.Env()),
 		EnvFrom:      baseTiCDCSpec.EnvFrom(),
 	}
+	setLifecycle(&ticdcContainer, baseTiCDCSpec.Lifecycle())
 	if cm != nil {
 		ticdcContainer.VolumeMounts = append(ticdcContainer.VolumeMounts, corev1.VolumeMount{
 			Name: "config", ReadOnly: true, MountPath: "/etc/ticdc",
@@ -563,6 +583,10 @@ func getNewTiCDCStatefulSet(tc *v1alpha1.TidbCluster, cm *corev1.ConfigMap) (*ap
 		},
 	}
 	ticdcSts.Spec.VolumeClaimTemplates = append(ticdcSts.Spec.VolumeClaimTemplates, additionalPVCs...)
+	propagateStatefulSetMetadata(ticdcSts, baseTiCDCSpec.Metadata())
+	if err := ApplyPodTemplatePatches(&ticdcSts.Spec.Template, baseTiCDCSpec.PodTemplatePatches()); err != nil {
+		return nil, fmt.Errorf("failed to apply pod template patches for TiCDC of [%s/%s], error: %v", ns, tcName, err)
+	}
 	return ticdcSts, nil
 }
 
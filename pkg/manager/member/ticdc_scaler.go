@@ -62,6 +62,16 @@ func (s *ticdcScaler) ScaleOut(meta metav1.Object, oldSet *apps.StatefulSet, new
 		return nil
 	}
 	klog.Infof("scaling out ticdc statefulset %s/%s, ordinal: %d (replicas: %d, delete slots: %v)", oldSet.Namespace, oldSet.Name, ordinal, replicas, deleteSlots.List())
+	if tc, ok := meta.(*v1alpha1.TidbCluster); ok {
+		if policy := tc.ScaleInPVCRetentionPolicy(v1alpha1.TiCDCMemberType); policy != nil && *policy == v1alpha1.ScaleInPVCRetain {
+			// the retained PVCs are re-used by the new pod
+			if err := s.reuseDeferDeletingPVC(tc, v1alpha1.TiCDCMemberType, ordinal); err != nil {
+				return err
+			}
+			setReplicasAndDeleteSlots(newSet, replicas, deleteSlots)
+			return nil
+		}
+	}
 	skipReason, err := s.deleteDeferDeletingPVC(obj, v1alpha1.TiCDCMemberType, ordinal)
 	if err != nil {
 		return err
@@ -136,6 +136,8 @@ func (u *ticdcUpgrader) Upgrade(tc *v1alpha1.TidbCluster, oldSet *apps.StatefulS
 			klog.Infof("ticdcUpgrade.Upgrade: %s graceful shutdown complete in cluster %s/%s", podName, tc.GetNamespace(), tc.GetName())
 		}
 
+		reason, detail := mngerutils.UpgradeRestartReason(pod, &newSet.Spec.Template)
+		mngerutils.RecordPodRestart(tc, pod, reason, detail)
 		mngerutils.SetUpgradePartition(newSet, ordinal)
 		return nil
 	}
@@ -50,20 +50,70 @@ func NewTidbDiscoveryManager(deps *controller.Dependencies) TidbDiscoveryManager
 }
 
 func (m *realTidbDiscoveryManager) Reconcile(obj client.Object) error {
+	objs, err := getTidbDiscoveryObjects(obj, m.deps.CLIConfig)
+	if err != nil {
+		return controller.RequeueErrorf("error generating discovery deployment: %v", err)
+	}
+	if objs == nil {
+		return nil
+	}
+
+	// Ensure RBAC
+	_, err = m.deps.TypedControl.CreateOrUpdateRole(obj, objs.role)
+	if err != nil {
+		return controller.RequeueErrorf("error creating or updating discovery role: %v", err)
+	}
+	_, err = m.deps.TypedControl.CreateOrUpdateServiceAccount(obj, objs.serviceAccount)
+	if err != nil {
+		return controller.RequeueErrorf("error creating or updating discovery serviceaccount: %v", err)
+	}
+	_, err = m.deps.TypedControl.CreateOrUpdateRoleBinding(obj, objs.roleBinding)
+	if err != nil {
+		return controller.RequeueErrorf("error creating or updating discovery rolebinding: %v", err)
+	}
+	deploy, err := m.deps.TypedControl.CreateOrUpdateDeployment(obj, objs.deployment)
+	if err != nil {
+		return controller.RequeueErrorf("error creating or updating discovery service: %v", err)
+	}
+	// RBAC ensured, reconcile
+	_, err = m.deps.TypedControl.CreateOrUpdateService(obj, getTidbDiscoveryService(obj, deploy, objs.preferIPv6))
+	if err != nil {
+		return controller.RequeueErrorf("error creating or updating discovery service: %v", err)
+	}
+	return nil
+}
+
+// tidbDiscoveryObjects are the desired objects of the discovery service of a cluster
+type tidbDiscoveryObjects struct {
+	role           *rbacv1.Role
+	serviceAccount *corev1.ServiceAccount
+	roleBinding    *rbacv1.RoleBinding
+	deployment     *appsv1.Deployment
+	preferIPv6     bool
+}
+
+// getTidbDiscoveryObjects returns the desired objects of the discovery service of the cluster,
+// or nil if the cluster doesn't need the discovery service
+func getTidbDiscoveryObjects(obj client.Object, cliConfig *controller.CLIConfig) (*tidbDiscoveryObjects, error) {
 	metaObj, ok := obj.(metav1.Object)
 	if !ok {
-		return fmt.Errorf("%T is not a metav1.Object", obj)
+		return nil, fmt.Errorf("%T is not a metav1.Object", obj)
 	}
 
 	var (
 		clusterPolicyRule rbacv1.PolicyRule
+		extraPolicyRules  []rbacv1.PolicyRule
 		preferIPv6        bool
 	)
 	switch cluster := obj.(type) {
 	case *v1alpha1.TidbCluster:
 		// If PD is not specified return
 		if cluster.Spec.PD == nil && !cluster.AcrossK8s() {
-			return nil
+			return nil, nil
+		}
+		// The PD start script takes over the discovery in the embedded mode
+		if cluster.EmbeddedDiscovery() {
+			return nil, nil
 		}
 		clusterPolicyRule = rbacv1.PolicyRule{
 			APIGroups:     []string{v1alpha1.GroupName},
@@ -71,6 +121,20 @@ func (m *realTidbDiscoveryManager) Reconcile(obj client.Object) error {
 			ResourceNames: []string{metaObj.GetName()},
 			Verbs:         []string{"get"},
 		}
+		// the PD cluster ID of the referenced cluster in the same namespace is expected before
+		// the cluster ID of this cluster is synced, the one across Kubernetes can't be got
+		if cluster.Heterogeneous() && !cluster.AcrossK8s() && (cluster.Spec.Cluster.Namespace == "" || cluster.Spec.Cluster.Namespace == cluster.Namespace) {
+			clusterPolicyRule.ResourceNames = append(clusterPolicyRule.ResourceNames, cluster.Spec.Cluster.Name)
+		}
+		// the preStop hooks of PD and TiKV pods annotate the pods through discovery
+		if podNames := preStopPodNames(cluster); len(podNames) > 0 {
+			extraPolicyRules = append(extraPolicyRules, rbacv1.PolicyRule{
+				APIGroups:     []string{corev1.GroupName},
+				Resources:     []string{"pods"},
+				ResourceNames: podNames,
+				Verbs:         []string{"get", "patch"},
+			})
+		}
 		preferIPv6 = cluster.Spec.PreferIPv6
 	case *v1alpha1.DMCluster:
 		clusterPolicyRule = rbacv1.PolicyRule{
@@ -82,60 +146,84 @@ func (m *realTidbDiscoveryManager) Reconcile(obj client.Object) error {
 		preferIPv6 = cluster.Spec.PreferIPv6
 	default:
 		klog.Warningf("unsupported type %T for discovery", obj)
-		return nil
+		return nil, nil
 	}
 
 	meta, _ := getDiscoveryMeta(metaObj, controller.DiscoveryMemberName)
-	// Ensure RBAC
-	_, err := m.deps.TypedControl.CreateOrUpdateRole(obj, &rbacv1.Role{
-		ObjectMeta: meta,
-		Rules: []rbacv1.PolicyRule{
-			clusterPolicyRule,
-			{
-				APIGroups: []string{corev1.GroupName},
-				Resources: []string{"secrets"},
-				Verbs:     []string{"get", "list", "watch"},
+	d, err := getTidbDiscoveryDeployment(metaObj, cliConfig)
+	if err != nil {
+		return nil, err
+	}
+	return &tidbDiscoveryObjects{
+		role: &rbacv1.Role{
+			ObjectMeta: meta,
+			Rules: append([]rbacv1.PolicyRule{
+				clusterPolicyRule,
+				{
+					APIGroups: []string{corev1.GroupName},
+					Resources: []string{"secrets"},
+					Verbs:     []string{"get", "list", "watch"},
+				},
+			}, extraPolicyRules...),
+		},
+		serviceAccount: &corev1.ServiceAccount{
+			ObjectMeta: meta,
+		},
+		roleBinding: &rbacv1.RoleBinding{
+			ObjectMeta: meta,
+			Subjects: []rbacv1.Subject{{
+				Kind: rbacv1.ServiceAccountKind,
+				Name: meta.Name,
+			}},
+			RoleRef: rbacv1.RoleRef{
+				Kind:     "Role",
+				Name:     meta.Name,
+				APIGroup: rbacv1.GroupName,
 			},
 		},
-	})
-	if err != nil {
-		return controller.RequeueErrorf("error creating or updating discovery role: %v", err)
+		deployment: d,
+		preferIPv6: preferIPv6,
+	}, nil
+}
+
+// preStopPodNames returns the names of the PD and TiKV pods with the preStop hook, including the pods
+// of failover members and the pods still being scaled in.
+func preStopPodNames(tc *v1alpha1.TidbCluster) []string {
+	var names []string
+	if tc.Spec.PD != nil && tc.Spec.PD.PreStopHook != nil {
+		for _, ordinal := range preStopPodOrdinals(tc, v1alpha1.PDMemberType, tc.PDStsDesiredReplicas(), tc.Status.PD.StatefulSet) {
+			names = append(names, PdPodName(tc.Name, ordinal))
+		}
 	}
-	_, err = m.deps.TypedControl.CreateOrUpdateServiceAccount(obj, &corev1.ServiceAccount{
-		ObjectMeta: meta,
-	})
-	if err != nil {
-		return controller.RequeueErrorf("error creating or updating discovery serviceaccount: %v", err)
+	if tc.Spec.TiKV != nil && tc.Spec.TiKV.PreStopHook != nil {
+		for _, ordinal := range preStopPodOrdinals(tc, v1alpha1.TiKVMemberType, tc.TiKVStsDesiredReplicas(), tc.Status.TiKV.StatefulSet) {
+			names = append(names, TikvPodName(tc.Name, ordinal))
+		}
 	}
-	_, err = m.deps.TypedControl.CreateOrUpdateRoleBinding(obj, &rbacv1.RoleBinding{
-		ObjectMeta: meta,
-		Subjects: []rbacv1.Subject{{
-			Kind: rbacv1.ServiceAccountKind,
-			Name: meta.Name,
-		}},
-		RoleRef: rbacv1.RoleRef{
-			Kind:     "Role",
-			Name:     meta.Name,
-			APIGroup: rbacv1.GroupName,
-		},
-	})
+	return names
+}
+
+func preStopPodOrdinals(tc *v1alpha1.TidbCluster, memberType v1alpha1.MemberType, desiredReplicas int32, status *appsv1.StatefulSetStatus) []int32 {
+	ordinals, err := util.GetPodOrdinals(tc, memberType)
 	if err != nil {
-		return controller.RequeueErrorf("error creating or updating discovery rolebinding: %v", err)
+		klog.Warningf("failed to get the pod ordinals of %s of tc %s/%s, error: %v", memberType, tc.Namespace, tc.Name, err)
+		return nil
 	}
-	d, err := m.getTidbDiscoveryDeployment(metaObj)
-	if err != nil {
-		return controller.RequeueErrorf("error generating discovery deployment: %v", err)
+	count := desiredReplicas
+	if status != nil && status.Replicas > count {
+		count = status.Replicas
 	}
-	deploy, err := m.deps.TypedControl.CreateOrUpdateDeployment(obj, d)
-	if err != nil {
-		return controller.RequeueErrorf("error creating or updating discovery service: %v", err)
+	// the ordinals in the delete slots are skipped by the StatefulSet, so the pods
+	// are spread over more ordinals than the replicas
+	var holes int32
+	if list := ordinals.List(); len(list) > 0 {
+		holes = list[len(list)-1] + 1 - int32(len(list))
 	}
-	// RBAC ensured, reconcile
-	_, err = m.deps.TypedControl.CreateOrUpdateService(obj, getTidbDiscoveryService(metaObj, deploy, preferIPv6))
-	if err != nil {
-		return controller.RequeueErrorf("error creating or updating discovery service: %v", err)
+	var ret []int32
+	for i := int32(0); i < count+holes; i++ {
+		ret = append(ret, i)
 	}
-	return nil
+	return ret
 }
 
 func getTidbDiscoveryService(obj metav1.Object, deploy *appsv1.Deployment, preferIPv6 bool) *corev1.Service {
@@ -167,12 +255,13 @@ func getTidbDiscoveryService(obj metav1.Object, deploy *appsv1.Deployment, prefe
 	return svc
 }
 
-func (m *realTidbDiscoveryManager) getTidbDiscoveryDeployment(obj metav1.Object) (*appsv1.Deployment, error) {
+func getTidbDiscoveryDeployment(obj metav1.Object, cliConfig *controller.CLIConfig) (*appsv1.Deployment, error) {
 	var (
 		resources corev1.ResourceRequirements
 		timezone  string
 		baseSpec  v1alpha1.ComponentAccessor
 		podSpec   corev1.PodSpec
+		proxy     *v1alpha1.HTTPProxyConfig
 	)
 
 	switch cluster := obj.(type) {
@@ -181,6 +270,7 @@ func (m *realTidbDiscoveryManager) getTidbDiscoveryDeployment(obj metav1.Object)
 		timezone = cluster.Timezone()
 		baseSpec = cluster.BaseDiscoverySpec()
 		podSpec = baseSpec.BuildPodSpec()
+		proxy = cluster.Spec.Proxy
 	case *v1alpha1.DMCluster:
 		resources = cluster.Spec.Discovery.ResourceRequirements
 		timezone = cluster.Timezone()
@@ -211,6 +301,8 @@ func (m *realTidbDiscoveryManager) getTidbDiscoveryDeployment(obj metav1.Object)
 		},
 	}
 	envs = util.AppendEnv(envs, baseSpec.Env())
+	// the proxy envs set explicitly in the spec take precedence
+	envs = util.AppendEnv(envs, cliConfig.ProxyEnvVars(proxy))
 	volMounts := []corev1.VolumeMount{}
 	volMounts = append(volMounts, baseSpec.AdditionalVolumeMounts()...)
 	podSpec.Containers = append(podSpec.Containers, corev1.Container{
@@ -219,7 +311,7 @@ func (m *realTidbDiscoveryManager) getTidbDiscoveryDeployment(obj metav1.Object)
 		Command: []string{
 			"/usr/local/bin/tidb-discovery",
 		},
-		Image:           m.deps.CLIConfig.TiDBDiscoveryImage,
+		Image:           cliConfig.GetTiDBDiscoveryImage(),
 		ImagePullPolicy: baseSpec.ImagePullPolicy(),
 		Env:             envs,
 		EnvFrom:         baseSpec.EnvFrom(),
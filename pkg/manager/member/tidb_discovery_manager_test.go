@@ -61,6 +61,16 @@ func TestTidbDiscoveryManager_Reconcile(t *testing.T) {
 			},
 			errOnCreateOrUpdate: false,
 		},
+		{
+			name: "Embedded discovery",
+			prepare: func(tc *v1alpha1.TidbCluster, ctrl *controller.FakeGenericControl) {
+				tc.Spec.Discovery.Mode = v1alpha1.DiscoveryModeEmbedded
+			},
+			expect: func(deploys []appsv1.Deployment, tc *v1alpha1.TidbCluster, err error) {
+				g.Expect(err).To(Succeed())
+				g.Expect(deploys).To(BeEmpty())
+			},
+		},
 		{
 			name: "Setting discovery resource",
 			prepare: func(tc *v1alpha1.TidbCluster, ctrl *controller.FakeGenericControl) {
@@ -199,3 +209,25 @@ func newFakeTidbDiscoveryManager() (*realTidbDiscoveryManager, *controller.FakeG
 	ctrl := fakeDeps.GenericControl.(*controller.FakeGenericControl)
 	return &realTidbDiscoveryManager{deps: fakeDeps}, ctrl
 }
+
+func TestPreStopPodNames(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbClusterForTiDB()
+	tc.Spec.PD = &v1alpha1.PDSpec{Replicas: 3}
+	tc.Spec.TiKV = &v1alpha1.TiKVSpec{Replicas: 3}
+	g.Expect(preStopPodNames(tc)).To(BeEmpty())
+
+	tc.Spec.PD.PreStopHook = &v1alpha1.PreStopHookSpec{}
+	g.Expect(preStopPodNames(tc)).To(Equal([]string{"test-pd-0", "test-pd-1", "test-pd-2"}))
+
+	// the pod being scaled in is kept
+	tc.Spec.PD.Replicas = 2
+	tc.Status.PD.StatefulSet = &appsv1.StatefulSetStatus{Replicas: 3}
+	g.Expect(preStopPodNames(tc)).To(Equal([]string{"test-pd-0", "test-pd-1", "test-pd-2"}))
+
+	tc.Spec.PD.PreStopHook = nil
+	tc.Spec.TiKV.PreStopHook = &v1alpha1.PreStopHookSpec{}
+	tc.Status.TiKV.FailureStores = map[string]v1alpha1.TiKVFailureStore{"4": {PodName: "test-tikv-3"}}
+	g.Expect(preStopPodNames(tc)).To(Equal([]string{"test-tikv-0", "test-tikv-1", "test-tikv-2", "test-tikv-3"}))
+}
@@ -65,7 +65,7 @@ func (f *tidbFailover) Failover(tc *v1alpha1.TidbCluster) error {
 			continue
 		}
 
-		deadline := tidbMember.LastTransitionTime.Add(f.deps.CLIConfig.TiDBFailoverPeriod)
+		deadline := tidbMember.LastTransitionTime.Add(f.deps.CLIConfig.GetTiDBFailoverPeriod())
 		if time.Now().After(deadline) {
 			if len(tc.Status.TiDB.FailureMembers) >= int(maxFailoverCount) {
 				klog.Warningf("the failover count reaches the limit (%d), no more failover pods will be created", maxFailoverCount)
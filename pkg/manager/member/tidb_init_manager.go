@@ -15,8 +15,11 @@ package member
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"path"
+	"sort"
 
 	"github.com/pingcap/tidb-operator/pkg/apis/label"
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
@@ -49,6 +52,12 @@ const (
 	startScriptDir      = "/usr/local/bin"
 	startKey            = "start-script"
 	initStartKey        = "init-start-script"
+	sqlBundleKey        = "sql-bundle"
+	sqlBundleDir        = "/sql-bundle"
+	sqlBundleScriptKey  = "sql-bundle-script"
+	sqlBundleScriptPath = "sql_bundle.py"
+	// sqlBundleChecksumAnnKey is the annotation of the job recording the checksum of the SQL bundle it applies
+	sqlBundleChecksumAnnKey = "tidb.pingcap.com/sql-bundle-checksum"
 )
 
 // InitManager implements the logic for syncing TidbInitializer.
@@ -77,12 +86,24 @@ func (m *tidbInitManager) Sync(ti *v1alpha1.TidbInitializer) error {
 		klog.Infof("TidbInitManager.Sync: Spec.TiDB is nil in tidbcluster %s, skip syncing TidbInitializer %s/%s", tcName, ns, ti.Name)
 		return nil
 	}
+	bundleChecksum, err := m.getSQLBundleChecksum(ti)
+	if err != nil {
+		return err
+	}
+	if ti.Status.Phase == v1alpha1.InitializePhaseCompleted || ti.Status.Phase == v1alpha1.InitializePhaseFailed {
+		// the finished job may be garbage collected, do not run the initialization again unless the SQL bundle changes
+		jobName := controller.TiDBInitializerMemberName(tcName)
+		if _, err := m.deps.JobLister.Jobs(ns).Get(jobName); errors.IsNotFound(err) && bundleChecksum == ti.Status.SQLBundleChecksum {
+			klog.V(4).Infof("TidbInitManager.Sync: job %s of finished TidbInitializer %s/%s is deleted, skip syncing", jobName, ns, ti.Name)
+			return nil
+		}
+	}
 
 	err = m.syncTiDBInitConfigMap(ti, tc)
 	if err != nil {
 		return err
 	}
-	err = m.syncTiDBInitJob(ti)
+	err = m.syncTiDBInitJob(ti, bundleChecksum)
 	if err != nil {
 		return err
 	}
@@ -112,6 +133,10 @@ func (m *tidbInitManager) updateStatus(ti *v1alpha1.TidbInitializer) error {
 	}
 
 	var update bool
+	if phase == v1alpha1.InitializePhaseCompleted && ti.Status.SQLBundleChecksum != job.Annotations[sqlBundleChecksumAnnKey] {
+		ti.Status.SQLBundleChecksum = job.Annotations[sqlBundleChecksumAnnKey]
+		update = true
+	}
 	if !apiequality.Semantic.DeepEqual(ti.Status.JobStatus, job.Status) {
 		job.Status.DeepCopyInto(&ti.Status.JobStatus)
 		update = true
@@ -176,7 +201,8 @@ func (m *tidbInitManager) syncTiDBInitConfigMap(ti *v1alpha1.TidbInitializer, tc
 	if err != nil {
 		return err
 	}
-	if exist {
+	// the configmap is not changed after it's created except that the script of the SQL bundle is added
+	if exist && (ti.Spec.SQLBundle == nil || cm.Data[sqlBundleScriptKey] != "") {
 		return nil
 	}
 
@@ -190,7 +216,14 @@ func (m *tidbInitManager) syncTiDBInitConfigMap(ti *v1alpha1.TidbInitializer, tc
 	if err != nil {
 		return err
 	}
+	if md := tc.Spec.Metadata; md != nil {
+		controller.PropagateMetadata(newCm, *md)
+	}
 
+	if exist {
+		_, err = m.deps.TypedControl.CreateOrUpdateConfigMap(ti, newCm)
+		return err
+	}
 	err = m.deps.TypedControl.Create(ti, newCm)
 	if errors.IsAlreadyExists(err) {
 		klog.Infof("Configmap %s/%s already exists", newCm.Namespace, newCm.Name)
@@ -199,21 +232,30 @@ func (m *tidbInitManager) syncTiDBInitConfigMap(ti *v1alpha1.TidbInitializer, tc
 	return err
 }
 
-func (m *tidbInitManager) syncTiDBInitJob(ti *v1alpha1.TidbInitializer) error {
+func (m *tidbInitManager) syncTiDBInitJob(ti *v1alpha1.TidbInitializer, bundleChecksum string) error {
 	ns := ti.GetNamespace()
 	name := ti.GetName()
 	jobName := controller.TiDBInitializerMemberName(ti.Spec.Clusters.Name)
 
-	_, err := m.deps.JobLister.Jobs(ns).Get(jobName)
+	oldJob, err := m.deps.JobLister.Jobs(ns).Get(jobName)
 	if err == nil {
-		return nil
+		// the finished job is recreated to apply the new scripts if the SQL bundle changes
+		if !isJobFinished(oldJob) || oldJob.Annotations[sqlBundleChecksumAnnKey] == bundleChecksum {
+			return nil
+		}
+		if oldJob.DeletionTimestamp == nil {
+			if err := m.deps.JobControl.DeleteJob(ti, oldJob); err != nil && !errors.IsNotFound(err) {
+				return err
+			}
+		}
+		return controller.RequeueErrorf("TiDBInitializer %s/%s: the SQL bundle is changed, wait for job %s to be deleted", ns, name, jobName)
 	}
 
 	if !errors.IsNotFound(err) {
 		return fmt.Errorf("TiDBInitializer %s/%s get job %s failed, err: %v", ns, ti.Name, name, err)
 	}
 
-	job, err := m.makeTiDBInitJob(ti)
+	job, err := m.makeTiDBInitJob(ti, bundleChecksum)
 	if err != nil {
 		return err
 	}
@@ -226,7 +268,7 @@ func (m *tidbInitManager) syncTiDBInitJob(ti *v1alpha1.TidbInitializer) error {
 	return err
 }
 
-func (m *tidbInitManager) makeTiDBInitJob(ti *v1alpha1.TidbInitializer) (*batchv1.Job, error) {
+func (m *tidbInitManager) makeTiDBInitJob(ti *v1alpha1.TidbInitializer, bundleChecksum string) (*batchv1.Job, error) {
 	jobName := controller.TiDBInitializerMemberName(ti.Spec.Clusters.Name)
 	ns := ti.Namespace
 	tcName := ti.Spec.Clusters.Name
@@ -252,6 +294,15 @@ func (m *tidbInitManager) makeTiDBInitJob(ti *v1alpha1.TidbInitializer) (*batchv
 		"python",
 		"/usr/local/bin/start_script.py",
 	}
+	if ti.Spec.SQLBundle != nil {
+		bundleCmd := "python " + path.Join(startScriptDir, sqlBundleScriptPath)
+		if ti.Status.Phase == v1alpha1.InitializePhaseCompleted {
+			// the cluster is initialized, only the new scripts of the SQL bundle are applied
+			cmds = []string{"sh", "-c", bundleCmd}
+		} else {
+			cmds = []string{"sh", "-c", "python " + path.Join(startScriptDir, startScriptPath) + " && " + bundleCmd}
+		}
+	}
 
 	var vms []corev1.VolumeMount
 	var vs []corev1.Volume
@@ -344,7 +395,44 @@ func (m *tidbInitManager) makeTiDBInitJob(ti *v1alpha1.TidbInitializer) (*batchv
 		})
 	}
 
+	if bundle := ti.Spec.SQLBundle; bundle != nil {
+		vms = append(vms, corev1.VolumeMount{
+			Name:      sqlBundleScriptKey,
+			ReadOnly:  true,
+			MountPath: path.Join(startScriptDir, sqlBundleScriptPath),
+			SubPath:   sqlBundleScriptPath,
+		}, corev1.VolumeMount{
+			Name: sqlBundleKey, ReadOnly: true, MountPath: sqlBundleDir,
+		})
+		vs = append(vs, corev1.Volume{
+			Name: sqlBundleScriptKey,
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: jobName,
+					},
+					Items: []corev1.KeyToPath{{Key: sqlBundleScriptKey, Path: sqlBundleScriptPath}},
+				},
+			},
+		})
+		bundleVolume := corev1.Volume{Name: sqlBundleKey}
+		if bundle.Secret != nil {
+			bundleVolume.Secret = &corev1.SecretVolumeSource{SecretName: *bundle.Secret}
+		} else if bundle.ConfigMap != nil {
+			bundleVolume.ConfigMap = &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: *bundle.ConfigMap},
+			}
+		}
+		vs = append(vs, bundleVolume)
+	}
+
 	meta, initLabel := getInitMeta(ti)
+	if bundleChecksum != "" {
+		if meta.Annotations == nil {
+			meta.Annotations = map[string]string{}
+		}
+		meta.Annotations[sqlBundleChecksumAnnKey] = bundleChecksum
+	}
 
 	podSpec := &corev1.PodTemplateSpec{
 		ObjectMeta: metav1.ObjectMeta{
@@ -400,6 +488,10 @@ func (m *tidbInitManager) makeTiDBInitJob(ti *v1alpha1.TidbInitializer) (*batchv
 			Template:     *podSpec,
 		},
 	}
+	if md := tc.Spec.Metadata; md != nil {
+		controller.PropagateMetadata(job, *md)
+		controller.PropagateMetadata(&job.Spec.Template, *md)
+	}
 
 	return job, nil
 }
@@ -447,6 +539,24 @@ func getTiDBInitConfigMap(ti *v1alpha1.TidbInitializer, tlsClientEnabled bool, s
 		initStartKey: initStartScript,
 		startKey:     startScript,
 	}
+	if ti.Spec.SQLBundle != nil {
+		bundleScript, err := startscriptv1.RenderTiDBInitSQLBundleScript(&startscriptv1.TiDBInitSQLBundleScriptModel{
+			ClusterName:     initModel.ClusterName,
+			BundleDir:       sqlBundleDir,
+			MetadataTable:   ti.GetSQLBundleMetadataTable(),
+			PasswordSet:     passwdSet,
+			TLS:             initModel.TLS,
+			SkipCA:          initModel.SkipCA,
+			CAPath:          initModel.CAPath,
+			CertPath:        initModel.CertPath,
+			KeyPath:         initModel.KeyPath,
+			TiDBServicePort: tidbSvcPort,
+		})
+		if err != nil {
+			return nil, err
+		}
+		data[sqlBundleScriptKey] = bundleScript
+	}
 	if ti.Spec.InitSql != nil {
 		data[sqlKey] = *ti.Spec.InitSql
 	}
@@ -460,6 +570,61 @@ func getTiDBInitConfigMap(ti *v1alpha1.TidbInitializer, tlsClientEnabled bool, s
 	return cm, nil
 }
 
+// getSQLBundleChecksum returns the checksum of the scripts in the SQL bundle, or an empty string if there is no SQL bundle
+func (m *tidbInitManager) getSQLBundleChecksum(ti *v1alpha1.TidbInitializer) (string, error) {
+	bundle := ti.Spec.SQLBundle
+	if bundle == nil {
+		return "", nil
+	}
+	ns := ti.Namespace
+	scripts := map[string][]byte{}
+	switch {
+	case bundle.ConfigMap != nil && bundle.Secret != nil:
+		return "", fmt.Errorf("TidbInitializer %s/%s: only one of configMap and secret of the SQL bundle can be set", ns, ti.Name)
+	case bundle.Secret != nil:
+		secret, err := m.deps.SecretLister.Secrets(ns).Get(*bundle.Secret)
+		if err != nil {
+			return "", fmt.Errorf("TidbInitializer %s/%s: failed to get secret %s of the SQL bundle, error: %v", ns, ti.Name, *bundle.Secret, err)
+		}
+		scripts = secret.Data
+	case bundle.ConfigMap != nil:
+		// the configmap lister only caches the configmaps managed by the operator
+		cm, err := m.deps.KubeClientset.CoreV1().ConfigMaps(ns).Get(context.TODO(), *bundle.ConfigMap, metav1.GetOptions{})
+		if err != nil {
+			return "", fmt.Errorf("TidbInitializer %s/%s: failed to get configmap %s of the SQL bundle, error: %v", ns, ti.Name, *bundle.ConfigMap, err)
+		}
+		for name, script := range cm.Data {
+			scripts[name] = []byte(script)
+		}
+	default:
+		return "", fmt.Errorf("TidbInitializer %s/%s: one of configMap and secret of the SQL bundle must be set", ns, ti.Name)
+	}
+
+	names := make([]string, 0, len(scripts))
+	for name := range scripts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	h := sha256.New()
+	for _, name := range names {
+		h.Write([]byte(name))
+		h.Write([]byte{0})
+		h.Write(scripts[name])
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// isJobFinished returns whether the job is complete or failed
+func isJobFinished(job *batchv1.Job) bool {
+	for _, c := range job.Status.Conditions {
+		if (c.Type == batchv1.JobComplete || c.Type == batchv1.JobFailed) && c.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
+
 func getInitMeta(ti *v1alpha1.TidbInitializer) (metav1.ObjectMeta, label.Label) {
 	name := controller.TiDBInitializerMemberName(ti.Spec.Clusters.Name)
 	initLabel := label.NewInitializer().Instance(ti.Name).Initializer(ti.Name)
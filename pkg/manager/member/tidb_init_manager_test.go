@@ -14,6 +14,7 @@
 package member
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
@@ -21,10 +22,12 @@ import (
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
 	"github.com/pingcap/tidb-operator/pkg/controller"
 	apps "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
 )
 
 func TestTiDBInitManagerSync(t *testing.T) {
@@ -66,7 +69,7 @@ func TestTiDBInitManagerSync(t *testing.T) {
 			err = indexers.ti.Add(ti)
 			g.Expect(err).NotTo(HaveOccurred())
 
-			job, err := tim.makeTiDBInitJob(ti)
+			job, err := tim.makeTiDBInitJob(ti, "")
 			g.Expect(err).NotTo(HaveOccurred())
 			err = indexers.job.Add(job)
 			g.Expect(err).NotTo(HaveOccurred())
@@ -77,7 +80,7 @@ func TestTiDBInitManagerSync(t *testing.T) {
 			if err != nil {
 				return err
 			}
-			err = tim.syncTiDBInitJob(ti)
+			err = tim.syncTiDBInitJob(ti, "")
 			/* The test for genericClient is not fully working yet
 			if err != nil {
 				return err
@@ -128,6 +131,68 @@ func TestTiDBInitManagerSync(t *testing.T) {
 	}
 }
 
+func TestTiDBInitManagerSQLBundle(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := context.TODO()
+
+	tim, tmm, indexers := newFakeTiDBInitManager()
+	tc := newTidbClusterForTiDB()
+	g.Expect(indexers.tc.Add(tc)).To(Succeed())
+	ti := newTidbInitializerForTiDB()
+	ti.Spec.Clusters.Name = tc.Name
+	ti.Spec.SQLBundle = &v1alpha1.SQLBundleSource{ConfigMap: pointer.StringPtr("bundle")}
+	bundle := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: ti.Namespace, Name: "bundle"},
+		Data:       map[string]string{"001-schema.sql": "create database app;"},
+	}
+	_, err := tmm.deps.KubeClientset.CoreV1().ConfigMaps(ti.Namespace).Create(ctx, bundle, metav1.CreateOptions{})
+	g.Expect(err).NotTo(HaveOccurred())
+	checksum, err := tim.getSQLBundleChecksum(ti)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(checksum).NotTo(BeEmpty())
+
+	cm, err := getTiDBInitConfigMap(ti, false, false, 4000)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(cm.Data[sqlBundleScriptKey]).To(ContainSubstring("table = 'mysql.tidb_initializer_scripts'"))
+
+	// the SQL bundle is applied after the initialization
+	job, err := tim.makeTiDBInitJob(ti, checksum)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(job.Annotations).To(HaveKeyWithValue(sqlBundleChecksumAnnKey, checksum))
+	container := job.Spec.Template.Spec.Containers[0]
+	g.Expect(container.Command).To(Equal([]string{"sh", "-c", "python /usr/local/bin/start_script.py && python /usr/local/bin/sql_bundle.py"}))
+	g.Expect(container.VolumeMounts).To(ContainElement(corev1.VolumeMount{Name: sqlBundleKey, ReadOnly: true, MountPath: sqlBundleDir}))
+	g.Expect(job.Spec.Template.Spec.Volumes).To(ContainElement(corev1.Volume{
+		Name: sqlBundleKey,
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{LocalObjectReference: corev1.LocalObjectReference{Name: "bundle"}},
+		},
+	}))
+
+	// the finished job is kept until the SQL bundle changes
+	job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}}
+	g.Expect(indexers.job.Add(job)).To(Succeed())
+	g.Expect(tim.syncTiDBInitJob(ti, checksum)).To(Succeed())
+	bundle.Data["002-users.sql"] = "create user app;"
+	_, err = tmm.deps.KubeClientset.CoreV1().ConfigMaps(ti.Namespace).Update(ctx, bundle, metav1.UpdateOptions{})
+	g.Expect(err).NotTo(HaveOccurred())
+	newChecksum, err := tim.getSQLBundleChecksum(ti)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(newChecksum).NotTo(Equal(checksum))
+	err = tim.syncTiDBInitJob(ti, newChecksum)
+	g.Expect(controller.IsRequeueError(err)).To(BeTrue())
+
+	// only the SQL bundle is applied once the cluster is initialized
+	ti.Status.Phase = v1alpha1.InitializePhaseCompleted
+	job, err = tim.makeTiDBInitJob(ti, newChecksum)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(job.Spec.Template.Spec.Containers[0].Command).To(Equal([]string{"sh", "-c", "python /usr/local/bin/sql_bundle.py"}))
+
+	ti.Spec.SQLBundle = &v1alpha1.SQLBundleSource{}
+	_, err = tim.getSQLBundleChecksum(ti)
+	g.Expect(err).To(HaveOccurred())
+}
+
 func newFakeTiDBInitManager() (*tidbInitManager, *tidbMemberManager, *fakeIndexers) {
 	tmm, _, _, indexers := newFakeTiDBMemberManager()
 	indexers.job = tmm.deps.KubeInformerFactory.Batch().V1().Jobs().Informer().GetIndexer()
@@ -0,0 +1,184 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"sync/atomic"
+	"time"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/util/cmpver"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+)
+
+const (
+	tidbSupportLabelsMinVersin = "6.3.0"
+
+	// tidbLabelsSyncConcurrency is the max number of TiDB members whose labels are set in parallel
+	tidbLabelsSyncConcurrency = 5
+	// tidbLabelsSyncRetryBudget is the max number of retries of setting the labels in one sync,
+	// it's shared by all the members so that a sync doesn't take too long if TiDB is unavailable
+	tidbLabelsSyncRetryBudget = 10
+	// tidbLabelsSyncRetryInterval is the interval between the retries of setting the labels of a member
+	tidbLabelsSyncRetryInterval = 200 * time.Millisecond
+)
+
+// tidbLabelsSyncer sets the server labels of all the healthy TiDB members to the location labels of
+// their nodes, e.g. the zone used by the follower read, and records the members whose labels
+// failed to be set in the status.
+type tidbLabelsSyncer struct {
+	deps          *controller.Dependencies
+	concurrency   int
+	retryBudget   int32
+	retryInterval time.Duration
+}
+
+func newTiDBLabelsSyncer(deps *controller.Dependencies) *tidbLabelsSyncer {
+	return &tidbLabelsSyncer{
+		deps:          deps,
+		concurrency:   tidbLabelsSyncConcurrency,
+		retryBudget:   tidbLabelsSyncRetryBudget,
+		retryInterval: tidbLabelsSyncRetryInterval,
+	}
+}
+
+type tidbServerLabels struct {
+	name    string
+	ordinal int32
+	labels  map[string]string
+}
+
+// Sync sets the server labels of the TiDB members and returns the number of the members whose
+// labels are set.
+func (s *tidbLabelsSyncer) Sync(tc *v1alpha1.TidbCluster) (int, error) {
+	tidbVersion := tc.TiDBVersion()
+	isOlder, err := cmpver.Compare(tidbVersion, cmpver.Less, tidbSupportLabelsMinVersin)
+	// meet a custom build of tidb without version in tag, directly return as if it was old tidb that doesn't support set labels
+	if err != nil {
+		klog.Warningf("parse tidb verson '%s' failed, skip setting store labels for TiKV of TiDB cluster %s/%s. err: %v", tidbVersion, tc.Namespace, tc.Name, err)
+		return 0, nil
+	}
+	// meet an old verion tidb, directly return because tidb doesn't support set labels
+	if isOlder {
+		return 0, nil
+	}
+	if s.deps.NodeLister == nil {
+		klog.V(4).Infof("Node lister is unavailable, skip setting store labels for TiKV of TiDB cluster %s/%s. This may be caused by no relevant permissions", tc.Namespace, tc.Name)
+		return 0, nil
+	}
+
+	ns := tc.GetNamespace()
+	pdCli := controller.GetPDClient(s.deps.PDControl, tc)
+	config, err := pdCli.GetConfig()
+	if err != nil {
+		return 0, err
+	}
+
+	var zoneLabel string
+outer:
+	for _, label := range topologyZoneLabels {
+		for _, l := range config.Replication.LocationLabels {
+			if l == label {
+				zoneLabel = l
+				break outer
+			}
+		}
+	}
+
+	if zoneLabel == "" {
+		klog.V(4).Infof("zone labels not found in pd location-labels %v, skip set labels", config.Replication.LocationLabels)
+		tc.Status.TiDB.ServerLabels = nil
+		return 0, nil
+	}
+
+	names := make([]string, 0, len(tc.Status.TiDB.Members))
+	for name := range tc.Status.TiDB.Members {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var pending []tidbServerLabels
+	for _, name := range names {
+		db := tc.Status.TiDB.Members[name]
+		if !db.Health {
+			continue
+		}
+		ordinal, err := parserOrdinal(name)
+		if err != nil {
+			return 0, err
+		}
+
+		labels, err := getNodeLabels(s.deps.NodeLister, db.NodeName, config.Replication.LocationLabels)
+		if err != nil || len(labels) == 0 {
+			klog.Warningf("node: [%s] has no node labels %v, skipping set store labels for Pod: [%s/%s]", db.NodeName, config.Replication.LocationLabels, ns, name)
+			continue
+		}
+		// add the special `zone` label because tidb depends on this label for follower read.
+		labels[tidbDCLabel] = labels[zoneLabel]
+		pending = append(pending, tidbServerLabels{name: name, ordinal: ordinal, labels: labels})
+	}
+
+	budget := s.retryBudget
+	synced := make([]bool, len(pending))
+	workqueue.ParallelizeUntil(context.TODO(), s.concurrency, len(pending), func(i int) {
+		synced[i] = s.setServerLabels(tc, pending[i], &budget)
+	})
+
+	setCount := 0
+	var outOfSync []string
+	for i := range pending {
+		if synced[i] {
+			setCount++
+		} else {
+			outOfSync = append(outOfSync, pending[i].name)
+		}
+	}
+	// the time is only updated when the summary changes to avoid updating the status in every sync
+	last := tc.Status.TiDB.ServerLabels
+	if last == nil || last.SyncedMembers != int32(setCount) || !reflect.DeepEqual(last.OutOfSyncMembers, outOfSync) {
+		tc.Status.TiDB.ServerLabels = &v1alpha1.TiDBServerLabelsStatus{
+			SyncedMembers:      int32(setCount),
+			OutOfSyncMembers:   outOfSync,
+			LastTransitionTime: metav1.Now(),
+		}
+	}
+	if len(outOfSync) > 0 {
+		klog.Warningf("cluster %s/%s server labels of tidb members %v are out of sync", ns, tc.GetName(), outOfSync)
+	}
+
+	return setCount, nil
+}
+
+// setServerLabels sets the server labels of a member, the failed request is retried as long as
+// the retry budget of the sync isn't used up.
+func (s *tidbLabelsSyncer) setServerLabels(tc *v1alpha1.TidbCluster, member tidbServerLabels, budget *int32) bool {
+	for {
+		err := s.deps.TiDBControl.SetServerLabels(tc, member.ordinal, member.labels)
+		if err == nil {
+			return true
+		}
+		if atomic.AddInt32(budget, -1) < 0 {
+			klog.Warningf("cluster %s/%s set server labels for pod %s failed, the retry budget is used up, error: %v", tc.GetNamespace(), tc.GetName(), member.name, err)
+			return false
+		}
+		klog.V(4).Infof("cluster %s/%s set server labels for pod %s failed, retry later, error: %v", tc.GetNamespace(), tc.GetName(), member.name, err)
+		time.Sleep(s.retryInterval)
+	}
+}
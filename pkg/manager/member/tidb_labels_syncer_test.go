@@ -0,0 +1,98 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"fmt"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/pdapi"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestTiDBLabelsSyncerRetryBudget(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbClusterForPD()
+	version := tidbSupportLabelsMinVersin
+	tc.Spec.TiDB.Version = &version
+	tc.Spec.TiDB.BaseImage = "pingcap/tidb"
+
+	deps := controller.NewFakeDependencies()
+	nodeIndexer := deps.KubeInformerFactory.Core().V1().Nodes().Informer().GetIndexer()
+	tc.Status.TiDB.Members = map[string]v1alpha1.TiDBMember{}
+	for i := 0; i < 3; i++ {
+		name := fmt.Sprintf("test-tidb-%d", i)
+		node := fmt.Sprintf("node-%d", i)
+		tc.Status.TiDB.Members[name] = v1alpha1.TiDBMember{Name: name, Health: true, NodeName: node}
+		nodeIndexer.Add(&corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   node,
+				Labels: map[string]string{"topology.kubernetes.io/zone": "zone", corev1.LabelHostname: node},
+			},
+		})
+	}
+	pdClient := controller.NewFakePDClient(deps.PDControl.(*pdapi.FakePDControl), tc)
+	pdClient.AddReaction(pdapi.GetConfigActionType, func(action *pdapi.Action) (interface{}, error) {
+		return &pdapi.PDConfigFromAPI{
+			Replication: &pdapi.PDReplicationConfig{
+				LocationLabels: []string{"topology.kubernetes.io/zone", corev1.LabelHostname},
+			},
+		}, nil
+	})
+
+	// every member fails once and test-tidb-1 always fails, test-tidb-2 is not retried
+	// because the retry budget is used up by test-tidb-1
+	calls := map[int32]int{}
+	deps.TiDBControl.(*controller.FakeTiDBControl).SetLabelsErrFn(func(ordinal int32) error {
+		calls[ordinal]++
+		if ordinal == 1 || calls[ordinal] == 1 {
+			return fmt.Errorf("mock label set failed")
+		}
+		return nil
+	})
+
+	syncer := newTiDBLabelsSyncer(deps)
+	syncer.concurrency = 1
+	syncer.retryBudget = 3
+	syncer.retryInterval = 0
+
+	setCount, err := syncer.Sync(tc)
+	g.Expect(err).To(Succeed())
+	g.Expect(setCount).To(Equal(1))
+	g.Expect(calls).To(Equal(map[int32]int{0: 2, 1: 3, 2: 1}))
+	status := tc.Status.TiDB.ServerLabels
+	g.Expect(status).NotTo(BeNil())
+	g.Expect(status.SyncedMembers).To(Equal(int32(1)))
+	g.Expect(status.OutOfSyncMembers).To(Equal([]string{"test-tidb-1", "test-tidb-2"}))
+	lastTransitionTime := status.LastTransitionTime
+
+	// the summary is not changed
+	calls = map[int32]int{}
+	_, err = syncer.Sync(tc)
+	g.Expect(err).To(Succeed())
+	g.Expect(tc.Status.TiDB.ServerLabels.LastTransitionTime).To(Equal(lastTransitionTime))
+
+	// all the members are in sync
+	deps.TiDBControl.(*controller.FakeTiDBControl).SetLabelsErrFn(nil)
+	setCount, err = syncer.Sync(tc)
+	g.Expect(err).To(Succeed())
+	g.Expect(setCount).To(Equal(3))
+	g.Expect(tc.Status.TiDB.ServerLabels.SyncedMembers).To(Equal(int32(3)))
+	g.Expect(tc.Status.TiDB.ServerLabels.OutOfSyncMembers).To(BeEmpty())
+}
@@ -42,6 +42,8 @@ import (
 	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/uuid"
 	corelisters "k8s.io/client-go/listers/core/v1"
@@ -57,6 +59,10 @@ const (
 	defaultSlowLogVolume = "slowlog"
 	defaultSlowLogDir    = "/var/log/tidb"
 	defaultSlowLogFile   = defaultSlowLogDir + "/slowlog"
+	// tidbPluginVolume is the volume that holds the plugin binaries of TiDB
+	tidbPluginVolume = "plugins"
+	// tidbPluginDir is the plugin directory passed to tidb-server by the start script
+	tidbPluginDir = "/plugins"
 	// clusterCertPath is where the cert for inter-cluster communication stored (if any)
 	clusterCertPath = "/var/lib/tidb-tls"
 	// serverCertPath is where the tidb-server cert stored (if any)
@@ -76,6 +82,11 @@ const (
 
 	bootstrapSQLFilePath = "/etc/tidb-bootstrap"
 	bootstrapSQLFileName = "bootstrap.sql"
+	// tidbBindingSecretType is the type of the binding secret defined by the Service Binding spec
+	tidbBindingSecretType corev1.SecretType = "servicebinding.io/mysql"
+	// tidbServerTLSReloadDelay is the delay to reload the renewed server-side certificate, which is
+	// longer than the sync period of the kubelet to update the mounted Secret
+	tidbServerTLSReloadDelay = 2 * time.Minute
 )
 
 var (
@@ -90,6 +101,7 @@ type tidbMemberManager struct {
 	tidbFailover      Failover
 	suspender         suspender.Suspender
 	podVolumeModifier volumes.PodVolumeModifier
+	labelsSyncer      *tidbLabelsSyncer
 
 	tidbStatefulSetIsUpgradingFn func(corelisters.PodLister, *apps.StatefulSet, *v1alpha1.TidbCluster) (bool, error)
 }
@@ -103,6 +115,7 @@ func NewTiDBMemberManager(deps *controller.Dependencies, scaler Scaler, tidbUpgr
 		tidbFailover:                 tidbFailover,
 		suspender:                    spder,
 		podVolumeModifier:            pvm,
+		labelsSyncer:                 newTiDBLabelsSyncer(deps),
 		tidbStatefulSetIsUpgradingFn: tidbStatefulSetIsUpgrading,
 	}
 }
@@ -127,7 +140,7 @@ func (m *tidbMemberManager) Sync(tc *v1alpha1.TidbCluster) error {
 		return nil
 	}
 
-	if tc.Spec.TiKV != nil && !tc.TiKVIsAvailable() {
+	if tc.Spec.TiKV != nil && !tc.TiKVIsAvailable() && !tc.IsStandby() {
 		return controller.RequeueErrorf("TidbCluster: [%s/%s], waiting for TiKV cluster running", ns, tcName)
 	}
 
@@ -136,7 +149,7 @@ func (m *tidbMemberManager) Sync(tc *v1alpha1.TidbCluster) error {
 		return err
 	}
 
-	if tc.Spec.Pump != nil && !tc.PumpIsAvailable() {
+	if tc.Spec.Pump != nil && !tc.PumpIsAvailable() && !tc.IsStandby() {
 		return controller.RequeueErrorf("TidbCluster: [%s/%s], waiting for Pump cluster running", ns, tcName)
 	}
 
@@ -161,7 +174,25 @@ func (m *tidbMemberManager) Sync(tc *v1alpha1.TidbCluster) error {
 	}
 
 	// Sync TiDB StatefulSet
-	return m.syncTiDBStatefulSetForTidbCluster(tc)
+	if err := m.syncTiDBStatefulSetForTidbCluster(tc); err != nil {
+		return err
+	}
+
+	// Sync TiDB PodDisruptionBudget
+	if err := syncPodDisruptionBudget(m.deps, tc, tidbPDBComponent(tc)); err != nil {
+		return err
+	}
+
+	// Sync TiDB binding Secret after the StatefulSet, so that it never blocks the rollout of TiDB
+	if err := m.syncTiDBBindingSecret(tc); err != nil {
+		return err
+	}
+
+	if err := m.syncTiDBServerTLS(tc); err != nil {
+		return err
+	}
+
+	return m.syncTiDBOnlineSettings(tc)
 }
 
 func (m *tidbMemberManager) syncRecoveryForTidbCluster(tc *v1alpha1.TidbCluster) error {
@@ -225,6 +256,9 @@ func (m *tidbMemberManager) syncTiDBHeadlessServiceForTidbCluster(tc *v1alpha1.T
 	if err != nil {
 		return err
 	}
+	if mngerutils.CheckServiceDrift(tc, oldSvc) {
+		equal = false
+	}
 	if !equal {
 		svc := *oldSvc
 		svc.Spec = newSvc.Spec
@@ -268,6 +302,9 @@ func (m *tidbMemberManager) syncTiDBStatefulSetForTidbCluster(tc *v1alpha1.TidbC
 	if err != nil {
 		return err
 	}
+	if tc.IsStandby() {
+		return syncStandbyStatefulSet(m.deps, tc, tc.TiDBStsDesiredOrdinals(true), newTiDBSet, oldTiDBSet)
+	}
 
 	if setNotExist {
 		err = mngerutils.SetStatefulSetLastAppliedConfigAnnotation(newTiDBSet)
@@ -282,7 +319,13 @@ func (m *tidbMemberManager) syncTiDBStatefulSetForTidbCluster(tc *v1alpha1.TidbC
 		return nil
 	}
 
-	if _, err := m.setServerLabels(tc); err != nil {
+	if _, err := m.labelsSyncer.Sync(tc); err != nil {
+		return err
+	}
+
+	// Pod template changes made in the middle of an upgrade or a scale are held until it
+	// completes, unless the pending changes are applied explicitly. The replicas are not held.
+	if err := holdPendingChanges(tc, v1alpha1.TiDBMemberType, newTiDBSet, oldTiDBSet); err != nil {
 		return err
 	}
 
@@ -295,7 +338,7 @@ func (m *tidbMemberManager) syncTiDBStatefulSetForTidbCluster(tc *v1alpha1.TidbC
 		return err
 	}
 
-	if m.deps.CLIConfig.AutoFailover {
+	if m.deps.CLIConfig.IsAutoFailover() && !tc.IsQuotaExceeded() {
 		if m.shouldRecover(tc) {
 			m.tidbFailover.Recover(tc)
 		} else if tc.TiDBAllPodsStarted() && !tc.TiDBAllMembersReady() {
@@ -401,6 +444,78 @@ func (m *tidbMemberManager) syncInitializer(tc *v1alpha1.TidbCluster) {
 	}
 }
 
+// syncTiDBServerTLS reloads the TiDB server-side certificate online when its Secret is renewed.
+// The reload is delayed for tidbServerTLSReloadDelay after the change is observed, because the kubelet
+// updates the mounted Secret periodically.
+func (m *tidbMemberManager) syncTiDBServerTLS(tc *v1alpha1.TidbCluster) error {
+	if !tc.Spec.TiDB.IsTLSClientEnabled() || !tc.Spec.TiDB.TLSClient.AutoReload {
+		tc.Status.TiDB.ServerTLS = nil
+		return nil
+	}
+	ns := tc.Namespace
+	tcName := tc.Name
+
+	secretName := util.TiDBServerTLSSecretName(tcName)
+	secret, err := m.deps.SecretLister.Secrets(ns).Get(secretName)
+	if err != nil {
+		return fmt.Errorf("syncTiDBServerTLS: failed to get secret %s/%s, error: %s", ns, secretName, err)
+	}
+	hash, err := mngerutils.Sha256Sum(secret.Data)
+	if err != nil {
+		return err
+	}
+
+	status := tc.Status.TiDB.ServerTLS
+	if status == nil {
+		// the running TiDB members are assumed to serve the current certificate
+		tc.Status.TiDB.ServerTLS = &v1alpha1.TiDBServerTLSStatus{SecretHash: hash, Reloaded: true}
+		return nil
+	}
+	if status.SecretHash != hash {
+		klog.Infof("TiDB server-side certificate secret %s/%s is changed, reload it after %s", ns, secretName, tidbServerTLSReloadDelay)
+		tc.Status.TiDB.ServerTLS = &v1alpha1.TiDBServerTLSStatus{SecretHash: hash, LastChangeTime: metav1.Now()}
+		return nil
+	}
+	if status.Reloaded || time.Since(status.LastChangeTime.Time) < tidbServerTLSReloadDelay {
+		return nil
+	}
+
+	// the root password may be not managed by the operator, try the empty password then
+	password, err := m.getTiDBUserPassword(tc, constants.TidbRootKey)
+	if err != nil {
+		klog.V(4).Infof("use the empty root password to reload tls for cluster %s/%s: %v", ns, tcName, err)
+	}
+
+	var errs []error
+	for name, member := range tc.Status.TiDB.Members {
+		// the unhealthy members load the new certificate when they restart
+		if !member.Health {
+			continue
+		}
+		if err := reloadTiDBMemberTLS(tc, name, string(password)); err != nil {
+			errs = append(errs, fmt.Errorf("failed to reload tls of tidb %s/%s: %v", ns, name, err))
+			continue
+		}
+		klog.Infof("Reload TiDB server-side certificate of %s/%s successfully", ns, name)
+	}
+	if len(errs) > 0 {
+		return utilerrors.NewAggregate(errs)
+	}
+	status.Reloaded = true
+	return nil
+}
+
+func reloadTiDBMemberTLS(tc *v1alpha1.TidbCluster, podName, password string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	db, err := util.OpenDB(ctx, util.GetMemberDSN(tc, podName, password))
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	return util.ReloadTLS(ctx, db)
+}
+
 func (m *tidbMemberManager) BuildRandomPasswordSecret(tc *v1alpha1.TidbCluster) (*corev1.Secret, string) {
 
 	s := &corev1.Secret{
@@ -495,6 +610,9 @@ func (m *tidbMemberManager) syncTiDBService(tc *v1alpha1.TidbCluster) error {
 	if err != nil {
 		return err
 	}
+	if mngerutils.CheckServiceDrift(tc, oldSvc) {
+		equal = false
+	}
 
 	delete(oldSvc.Annotations, LastAppliedConfigAnnotation)
 	annoEqual := equality.Semantic.DeepEqual(newSvc.Annotations, oldSvc.Annotations)
@@ -525,6 +643,127 @@ func (m *tidbMemberManager) syncTiDBService(tc *v1alpha1.TidbCluster) error {
 	return err
 }
 
+// syncTiDBBindingSecret publishes the connection info of TiDB in the binding Secret
+func (m *tidbMemberManager) syncTiDBBindingSecret(tc *v1alpha1.TidbCluster) error {
+	if tc.Spec.TiDB.Binding == nil {
+		// TODO: delete the binding secret if user remove the binding spec deliberately
+		tc.Status.Binding = nil
+		return nil
+	}
+	if tc.Spec.Paused {
+		klog.V(4).Infof("tidb cluster %s/%s is paused, skip syncing for tidb binding secret", tc.GetNamespace(), tc.GetName())
+		return nil
+	}
+
+	secret, err := m.getNewTiDBBindingSecret(tc)
+	if err != nil {
+		return err
+	}
+	if _, err := m.deps.TypedControl.CreateOrUpdateSecret(tc, secret); err != nil {
+		return fmt.Errorf("syncTiDBBindingSecret: failed to sync secret %s for cluster %s/%s, error: %s", secret.Name, tc.GetNamespace(), tc.GetName(), err)
+	}
+	tc.Status.Binding = &corev1.LocalObjectReference{Name: secret.Name}
+	return nil
+}
+
+func (m *tidbMemberManager) getNewTiDBBindingSecret(tc *v1alpha1.TidbCluster) (*corev1.Secret, error) {
+	ns := tc.Namespace
+	tcName := tc.Name
+	binding := tc.Spec.TiDB.Binding
+
+	secretName := binding.SecretName
+	if secretName == "" {
+		secretName = controller.TiDBBindingSecret(tcName)
+	}
+
+	// prefer the TiDB service which is exposed to the end users
+	host := controller.TiDBPeerMemberName(tcName)
+	port := tc.Spec.TiDB.GetServerPort()
+	if tc.Spec.TiDB.Service != nil {
+		host = controller.TiDBMemberName(tcName)
+		port = tc.Spec.TiDB.GetServicePort()
+	}
+	data := map[string][]byte{
+		"type":     []byte("mysql"),
+		"provider": []byte("pingcap"),
+		"host":     []byte(fmt.Sprintf("%s.%s.svc%s", host, ns, controller.FormatClusterDomain(tc.Spec.ClusterDomain))),
+		"port":     []byte(strconv.Itoa(int(port))),
+	}
+
+	if tc.Spec.TiDB.IsTLSClientEnabled() {
+		tlsSecretName := util.TiDBServerTLSSecretName(tcName)
+		tlsSecret, err := m.deps.SecretLister.Secrets(ns).Get(tlsSecretName)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load the CA from secret %s/%s: %v", ns, tlsSecretName, err)
+		}
+		ca, ok := tlsSecret.Data[tlsSecretRootCAKey]
+		if !ok {
+			return nil, fmt.Errorf("CA does not exist in secret %s/%s", ns, tlsSecretName)
+		}
+		data["certificates"] = ca
+	}
+
+	if binding.User != "" {
+		password, err := m.getTiDBUserPassword(tc, binding.User)
+		if err != nil {
+			return nil, err
+		}
+		data["username"] = []byte(binding.User)
+		data["password"] = password
+	}
+
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            secretName,
+			Namespace:       ns,
+			Labels:          label.New().Instance(tc.GetInstanceName()).TiDB().UsedByEndUser().Labels(),
+			OwnerReferences: []metav1.OwnerReference{controller.GetOwnerRef(tc)},
+		},
+		Type: tidbBindingSecretType,
+		Data: data,
+	}, nil
+}
+
+// getTiDBUserPassword returns the password of the user from the password Secret of the TidbInitializer of tc,
+// or from the Secret of the random root password.
+func (m *tidbMemberManager) getTiDBUserPassword(tc *v1alpha1.TidbCluster, user string) ([]byte, error) {
+	ns := tc.Namespace
+	tcName := tc.Name
+
+	tis, err := m.deps.TiDBInitializerLister.TidbInitializers(ns).List(labels.Everything())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tidb initializers in namespace %s, error: %s", ns, err)
+	}
+	for _, ti := range tis {
+		if ti.Spec.Clusters.Name != tcName || ti.Spec.PasswordSecret == nil {
+			continue
+		}
+		if ti.Spec.Clusters.Namespace != "" && ti.Spec.Clusters.Namespace != ns {
+			continue
+		}
+		secret, err := m.deps.SecretLister.Secrets(ns).Get(*ti.Spec.PasswordSecret)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get password secret %s/%s of tidb initializer %s, error: %s", ns, *ti.Spec.PasswordSecret, ti.Name, err)
+		}
+		if password, ok := secret.Data[user]; ok {
+			return password, nil
+		}
+	}
+
+	if user == constants.TidbRootKey && tc.Spec.TiDB.Initializer != nil && tc.Spec.TiDB.Initializer.CreatePassword {
+		secretName := controller.TiDBInitSecret(tcName)
+		secret, err := m.deps.SecretLister.Secrets(ns).Get(secretName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get password secret %s/%s, error: %s", ns, secretName, err)
+		}
+		if password, ok := secret.Data[constants.TidbRootKey]; ok {
+			return password, nil
+		}
+	}
+
+	return nil, fmt.Errorf("password of user %s is not found for cluster %s/%s", user, ns, tcName)
+}
+
 // syncTiDBConfigMap syncs the configmap of tidb
 func (m *tidbMemberManager) syncTiDBConfigMap(tc *v1alpha1.TidbCluster, set *apps.StatefulSet) (*corev1.ConfigMap, error) {
 
@@ -550,6 +789,7 @@ func (m *tidbMemberManager) syncTiDBConfigMap(tc *v1alpha1.TidbCluster, set *app
 	if err != nil {
 		return nil, err
 	}
+	controller.PropagateMetadata(newCm, tc.BaseTiDBSpec().Metadata())
 	return m.deps.TypedControl.CreateOrUpdateConfigMap(tc, newCm)
 }
 
@@ -558,6 +798,7 @@ func getTiDBConfigMap(tc *v1alpha1.TidbCluster) (*corev1.ConfigMap, error) {
 		return nil, nil
 	}
 	config := tc.Spec.TiDB.Config.DeepCopy()
+	applyConfigProfile(config.GenericConfig, tc, v1alpha1.TiDBMemberType, tc.TiDBVersion())
 
 	if pointer.BoolPtrDerefOr(tc.Spec.TiDB.TokenBasedAuthEnabled, false) {
 		config.Set("security.auth-token-jwks", path.Join(tidbAuthTokenPath, tidbAuthTokenJWKS))
@@ -582,9 +823,20 @@ func getTiDBConfigMap(tc *v1alpha1.TidbCluster) (*corev1.ConfigMap, error) {
 		config.Set("security.ssl-cert", path.Join(serverCertPath, corev1.TLSCertKey))
 		config.Set("security.ssl-key", path.Join(serverCertPath, corev1.TLSPrivateKeyKey))
 	}
+	// the clients without TLS connect through TiProxy which offloads TLS for them
+	if tc.IsTiProxyTLSOffloadEnabled() {
+		config.Set("security.require-secure-transport", true)
+	}
 	if tc.Spec.TiDB.IsBootstrapSQLEnabled() {
 		config.Set("initialize-sql-file", path.Join(bootstrapSQLFilePath, bootstrapSQLFileName))
 	}
+	// set the ports only if they're customized to keep the config of the existing clusters unchanged
+	if port := tc.Spec.TiDB.GetServerPort(); port != v1alpha1.DefaultTiDBServicePort {
+		config.Set("port", int64(port))
+	}
+	if port := tc.Spec.TiDB.GetStatusPort(); port != v1alpha1.DefaultTiDBStatusPort {
+		config.Set("status.status-port", int64(port))
+	}
 	confText, err := config.MarshalTOML()
 	if err != nil {
 		return nil, err
@@ -633,7 +885,7 @@ func getNewTiDBServiceOrNil(tc *v1alpha1.TidbCluster) *corev1.Service {
 		{
 			Name:       svcSpec.GetPortName(),
 			Port:       tc.Spec.TiDB.GetServicePort(),
-			TargetPort: intstr.FromInt(4000),
+			TargetPort: intstr.FromInt(int(tc.Spec.TiDB.GetServerPort())),
 			Protocol:   corev1.ProtocolTCP,
 			NodePort:   svcSpec.GetMySQLNodePort(),
 		},
@@ -642,8 +894,8 @@ func getNewTiDBServiceOrNil(tc *v1alpha1.TidbCluster) *corev1.Service {
 	if svcSpec.ShouldExposeStatus() {
 		ports = append(ports, corev1.ServicePort{
 			Name:       "status",
-			Port:       10080,
-			TargetPort: intstr.FromInt(10080),
+			Port:       tc.Spec.TiDB.GetStatusPort(),
+			TargetPort: intstr.FromInt(int(tc.Spec.TiDB.GetStatusPort())),
 			Protocol:   corev1.ProtocolTCP,
 			NodePort:   svcSpec.GetStatusNodePort(),
 		})
@@ -677,9 +929,11 @@ func getNewTiDBServiceOrNil(tc *v1alpha1.TidbCluster) *corev1.Service {
 	if svcSpec.ClusterIP != nil {
 		tidbSvc.Spec.ClusterIP = *svcSpec.ClusterIP
 	}
+	controller.PropagateMetadata(tidbSvc, tc.BaseTiDBSpec().Metadata())
 	if tc.Spec.PreferIPv6 {
 		SetServiceWhenPreferIPv6(tidbSvc)
 	}
+	SetServiceIPFamilies(tidbSvc, &svcSpec.ServiceSpec)
 
 	return tidbSvc
 }
@@ -704,8 +958,8 @@ func getNewTiDBHeadlessServiceForTidbCluster(tc *v1alpha1.TidbCluster) *corev1.S
 			Ports: []corev1.ServicePort{
 				{
 					Name:       "status",
-					Port:       10080,
-					TargetPort: intstr.FromInt(10080),
+					Port:       tc.Spec.TiDB.GetStatusPort(),
+					TargetPort: intstr.FromInt(int(tc.Spec.TiDB.GetStatusPort())),
 					Protocol:   corev1.ProtocolTCP,
 				},
 			},
@@ -713,6 +967,7 @@ func getNewTiDBHeadlessServiceForTidbCluster(tc *v1alpha1.TidbCluster) *corev1.S
 			PublishNotReadyAddresses: true,
 		},
 	}
+	controller.PropagateMetadata(svc, tc.BaseTiDBSpec().Metadata())
 	if tc.Spec.PreferIPv6 {
 		SetServiceWhenPreferIPv6(svc)
 	}
@@ -720,6 +975,46 @@ func getNewTiDBHeadlessServiceForTidbCluster(tc *v1alpha1.TidbCluster) *corev1.S
 	return svc
 }
 
+// buildTiDBPluginVolume returns the volume and the volume mount of the plugin directory of TiDB.
+// If the plugins are provided by an image, an init container is also returned to copy the
+// plugin binaries into an emptyDir volume shared with the TiDB container.
+func buildTiDBPluginVolume(tc *v1alpha1.TidbCluster) (corev1.Volume, corev1.VolumeMount, *corev1.Container, error) {
+	source := tc.Spec.TiDB.PluginSource
+	if source.PersistentVolumeClaim != nil {
+		pvc := source.PersistentVolumeClaim.DeepCopy()
+		pvc.ReadOnly = true
+		vol := corev1.Volume{
+			Name:         tidbPluginVolume,
+			VolumeSource: corev1.VolumeSource{PersistentVolumeClaim: pvc},
+		}
+		return vol, corev1.VolumeMount{Name: tidbPluginVolume, ReadOnly: true, MountPath: tidbPluginDir}, nil, nil
+	}
+
+	// the path is used in the shell command of the init container
+	if !source.IsPathValid() {
+		return corev1.Volume{}, corev1.VolumeMount{}, nil, fmt.Errorf("invalid plugin path %q of tidbcluster %s/%s", source.Path, tc.Namespace, tc.Name)
+	}
+	vol := corev1.Volume{
+		Name:         tidbPluginVolume,
+		VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+	}
+	mount := corev1.VolumeMount{Name: tidbPluginVolume, MountPath: tidbPluginDir}
+	init := &corev1.Container{
+		Name:            "plugin-init",
+		Image:           source.Image,
+		ImagePullPolicy: source.ImagePullPolicy,
+		Command: []string{
+			"sh",
+			"-c",
+			fmt.Sprintf("cp -r %s/. %s/", strings.TrimSuffix(source.GetPath(), "/"), tidbPluginDir),
+		},
+		VolumeMounts: []corev1.VolumeMount{mount},
+		Resources:    controller.ContainerResource(tc.Spec.TiDB.ResourceRequirements),
+	}
+	mount.ReadOnly = true
+	return vol, mount, init, nil
+}
+
 func getNewTiDBSetForTidbCluster(tc *v1alpha1.TidbCluster, cm *corev1.ConfigMap) (*apps.StatefulSet, error) {
 	ns := tc.GetNamespace()
 	tcName := tc.GetName()
@@ -857,6 +1152,18 @@ func getNewTiDBSetForTidbCluster(tc *v1alpha1.TidbCluster, cm *corev1.ConfigMap)
 		podSecurityContext.Sysctls = []corev1.Sysctl{}
 	}
 
+	if tc.Spec.TiDB.IsPluginSourceEnabled() {
+		pluginVol, pluginMount, pluginInit, err := buildTiDBPluginVolume(tc)
+		if err != nil {
+			return nil, err
+		}
+		vols = append(vols, pluginVol)
+		volMounts = append(volMounts, pluginMount)
+		if pluginInit != nil {
+			initContainers = append(initContainers, *pluginInit)
+		}
+	}
+
 	// handle StorageVolumes and AdditionalVolumeMounts in ComponentSpec
 	storageVolMounts, additionalPVCs := util.BuildStorageVolumeAndVolumeMount(tc.Spec.TiDB.StorageVolumes, tc.Spec.TiDB.StorageClassName, v1alpha1.TiDBMemberType)
 	volMounts = append(volMounts, storageVolMounts...)
@@ -963,12 +1270,12 @@ func getNewTiDBSetForTidbCluster(tc *v1alpha1.TidbCluster, cm *corev1.ConfigMap)
 		Ports: []corev1.ContainerPort{
 			{
 				Name:          "server",
-				ContainerPort: int32(4000),
+				ContainerPort: tc.Spec.TiDB.GetServerPort(),
 				Protocol:      corev1.ProtocolTCP,
 			},
 			{
 				Name:          "status", // pprof, status, metrics
-				ContainerPort: int32(10080),
+				ContainerPort: tc.Spec.TiDB.GetStatusPort(),
 				Protocol:      corev1.ProtocolTCP,
 			},
 		},
@@ -981,9 +1288,7 @@ func getNewTiDBSetForTidbCluster(tc *v1alpha1.TidbCluster, cm *corev1.ConfigMap)
 			InitialDelaySeconds: int32(10),
 		},
 	}
-	if tc.Spec.TiDB.Lifecycle != nil {
-		c.Lifecycle = tc.Spec.TiDB.Lifecycle
-	}
+	setLifecycle(&c, baseTiDBSpec.Lifecycle())
 	if tc.Spec.TiDB.ReadinessProbe != nil {
 		if tc.Spec.TiDB.ReadinessProbe.InitialDelaySeconds != nil {
 			c.ReadinessProbe.InitialDelaySeconds = *tc.Spec.TiDB.ReadinessProbe.InitialDelaySeconds
@@ -993,9 +1298,13 @@ func getNewTiDBSetForTidbCluster(tc *v1alpha1.TidbCluster, cm *corev1.ConfigMap)
 		}
 	}
 
-	containers = append(containers, c)
-
 	podSpec := baseTiDBSpec.BuildPodSpec()
+	setZoneAffinity(&podSpec, tc, v1alpha1.TiDBMemberType)
+	if tc.Spec.TiDB.PreStopHook != nil {
+		setPreStopHook(&podSpec, &c, tc.TiDBPreStopHookTimeout(), buildDrainPreStopScript(tc))
+	}
+
+	containers = append(containers, c)
 
 	var err error
 	podSpec.Containers, err = MergePatchContainers(containers, baseTiDBSpec.AdditionalContainers())
@@ -1013,8 +1322,11 @@ func getNewTiDBSetForTidbCluster(tc *v1alpha1.TidbCluster, cm *corev1.ConfigMap)
 
 	stsLabels := label.New().Instance(instanceName).TiDB()
 	podLabels := util.CombineStringMap(stsLabels, baseTiDBSpec.Labels())
-	podAnnotations := util.CombineStringMap(baseTiDBSpec.Annotations(), controller.AnnProm(10080, "/metrics"))
+	podAnnotations := util.CombineStringMap(baseTiDBSpec.Annotations(), controller.AnnProm(tc.Spec.TiDB.GetStatusPort(), "/metrics"))
 	stsAnnotations := getStsAnnotations(tc.Annotations, label.TiDBLabelVal)
+	if err := addOfflineOrdinals(stsAnnotations, tc.OfflineOrdinals(v1alpha1.TiDBMemberType)); err != nil {
+		return nil, err
+	}
 
 	deleteSlotsNumber, err := util.GetDeleteSlotsNumber(stsAnnotations)
 	if err != nil {
@@ -1056,6 +1368,10 @@ func getNewTiDBSetForTidbCluster(tc *v1alpha1.TidbCluster, cm *corev1.ConfigMap)
 	}
 
 	tidbSet.Spec.VolumeClaimTemplates = append(tidbSet.Spec.VolumeClaimTemplates, additionalPVCs...)
+	propagateStatefulSetMetadata(tidbSet, baseTiDBSpec.Metadata())
+	if err := ApplyPodTemplatePatches(&tidbSet.Spec.Template, baseTiDBSpec.PodTemplatePatches()); err != nil {
+		return nil, fmt.Errorf("failed to apply pod template patches for TiDB of [%s/%s], error: %v", ns, tcName, err)
+	}
 	return tidbSet, nil
 }
 
@@ -1128,79 +1444,6 @@ func (m *tidbMemberManager) syncTidbClusterStatus(tc *v1alpha1.TidbCluster, set
 	return nil
 }
 
-const tidbSupportLabelsMinVersin = "6.3.0"
-
-func (m *tidbMemberManager) setServerLabels(tc *v1alpha1.TidbCluster) (int, error) {
-	tidbVersion := tc.TiDBVersion()
-	isOlder, err := cmpver.Compare(tidbVersion, cmpver.Less, tidbSupportLabelsMinVersin)
-	// meet a custom build of tidb without version in tag, directly return as if it was old tidb that doesn't support set labels
-	if err != nil {
-		klog.Warningf("parse tidb verson '%s' failed, skip setting store labels for TiKV of TiDB cluster %s/%s. err: %v", tidbVersion, tc.Namespace, tc.Name, err)
-		return 0, nil
-	}
-	// meet an old verion tidb, directly return because tidb doesn't support set labels
-	if isOlder {
-		return 0, nil
-	}
-	if m.deps.NodeLister == nil {
-		klog.V(4).Infof("Node lister is unavailable, skip setting store labels for TiKV of TiDB cluster %s/%s. This may be caused by no relevant permissions", tc.Namespace, tc.Name)
-		return 0, nil
-	}
-
-	ns := tc.GetNamespace()
-	// for unit test
-	setCount := 0
-
-	pdCli := controller.GetPDClient(m.deps.PDControl, tc)
-	config, err := pdCli.GetConfig()
-	if err != nil {
-		return setCount, err
-	}
-
-	var zoneLabel string
-outer:
-	for _, label := range topologyZoneLabels {
-		for _, l := range config.Replication.LocationLabels {
-			if l == label {
-				zoneLabel = l
-				break outer
-			}
-		}
-	}
-
-	if zoneLabel == "" {
-		klog.V(4).Infof("zone labels not found in pd location-labels %v, skip set labels", config.Replication.LocationLabels)
-		return 0, nil
-	}
-
-	for name, db := range tc.Status.TiDB.Members {
-		if !db.Health {
-			continue
-		}
-		ordinal, err := parserOrdinal(name)
-		if err != nil {
-			return setCount, err
-		}
-
-		labels, err := getNodeLabels(m.deps.NodeLister, db.NodeName, config.Replication.LocationLabels)
-		if err != nil || len(labels) == 0 {
-			klog.Warningf("node: [%s] has no node labels %v, skipping set store labels for Pod: [%s/%s]", db.NodeName, config.Replication.LocationLabels, ns, name)
-			continue
-		}
-		// add the special `zone` label because tidb depends on this label for follower read.
-		labels[tidbDCLabel] = labels[zoneLabel]
-
-		if err := m.deps.TiDBControl.SetServerLabels(tc, ordinal, labels); err != nil {
-			klog.Warningf("cluster %s/%s set server labels for pod %s failed, error: %v", ns, tc.GetName(), name, err)
-			continue
-		}
-
-		setCount++
-	}
-
-	return setCount, nil
-}
-
 var (
 	podOrdinalPattern = regexp.MustCompile(`^.*-(\d+)$`)
 )
@@ -1262,7 +1505,7 @@ func buildTiDBReadinessProbHandler(tc *v1alpha1.TidbCluster) corev1.Handler {
 	// fall to default case v1alpha1.TCPProbeType
 	return corev1.Handler{
 		TCPSocket: &corev1.TCPSocketAction{
-			Port: intstr.FromInt(4000),
+			Port: intstr.FromInt(int(tc.Spec.TiDB.GetServerPort())),
 		},
 	}
 }
@@ -1270,7 +1513,7 @@ func buildTiDBReadinessProbHandler(tc *v1alpha1.TidbCluster) corev1.Handler {
 func buildTiDBProbeCommand(tc *v1alpha1.TidbCluster) (command []string) {
 	host := "127.0.0.1"
 
-	readinessURL := fmt.Sprintf("%s://%s:10080/status", tc.Scheme(), host)
+	readinessURL := fmt.Sprintf("%s://%s:%d/status", tc.Scheme(), host, tc.Spec.TiDB.GetStatusPort())
 	command = append(command, "curl")
 	command = append(command, readinessURL)
 
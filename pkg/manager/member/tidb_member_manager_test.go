@@ -26,6 +26,7 @@ import (
 	. "github.com/onsi/gomega"
 	"github.com/pingcap/tidb-operator/pkg/apis/label"
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/apis/util/config"
 	"github.com/pingcap/tidb-operator/pkg/apis/util/toml"
 	"github.com/pingcap/tidb-operator/pkg/controller"
 	"github.com/pingcap/tidb-operator/pkg/manager/suspender"
@@ -854,6 +855,7 @@ func newFakeTiDBMemberManager() (*tidbMemberManager, *controller.FakeStatefulSet
 		tidbStatefulSetIsUpgradingFn: tidbStatefulSetIsUpgrading,
 		suspender:                    suspender.NewFakeSuspender(),
 		podVolumeModifier:            &volumes.FakePodVolumeModifier{},
+		labelsSyncer:                 newTiDBLabelsSyncer(fakeDeps),
 	}
 	indexers := &fakeIndexers{
 		pod:    fakeDeps.KubeInformerFactory.Core().V1().Pods().Informer().GetIndexer(),
@@ -1264,6 +1266,38 @@ func TestGetNewTiDBSetForTidbCluster(t *testing.T) {
 				g.Expect(sts.Spec.Template.Spec.Containers[1].ReadinessProbe.PeriodSeconds).To(Equal(int32(2)))
 			},
 		},
+		{
+			name: "tidb plugins from pvc",
+			tc: v1alpha1.TidbCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "tc",
+					Namespace: "ns",
+				},
+				Spec: v1alpha1.TidbClusterSpec{
+					TiDB: &v1alpha1.TiDBSpec{
+						Plugins: []string{"audit-1"},
+						PluginSource: &v1alpha1.TiDBPluginSource{
+							PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "tidb-plugins"},
+						},
+					},
+					PD:   &v1alpha1.PDSpec{},
+					TiKV: &v1alpha1.TiKVSpec{},
+				},
+			},
+			testSts: func(sts *apps.StatefulSet) {
+				g := NewGomegaWithT(t)
+				g.Expect(sts.Spec.Template.Spec.InitContainers).To(BeEmpty())
+				g.Expect(sts.Spec.Template.Spec.Volumes).To(ContainElement(corev1.Volume{
+					Name: "plugins",
+					VolumeSource: corev1.VolumeSource{
+						PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "tidb-plugins", ReadOnly: true},
+					},
+				}))
+				g.Expect(sts.Spec.Template.Spec.Containers[1].VolumeMounts).To(ContainElement(corev1.VolumeMount{
+					Name: "plugins", ReadOnly: true, MountPath: "/plugins",
+				}))
+			},
+		},
 		// TODO add more tests
 	}
 
@@ -1599,6 +1633,59 @@ func TestTiDBInitContainers(t *testing.T) {
 				Sysctls:      []corev1.Sysctl{},
 			},
 		},
+		{
+			name: "plugins from image",
+			tc: v1alpha1.TidbCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "tc",
+					Namespace: "ns",
+				},
+				Spec: v1alpha1.TidbClusterSpec{
+					TiDB: &v1alpha1.TiDBSpec{
+						ComponentSpec: v1alpha1.ComponentSpec{
+							PodSecurityContext: &corev1.PodSecurityContext{
+								RunAsNonRoot: &asRoot,
+								Sysctls: []corev1.Sysctl{
+									{
+										Name:  "net.core.somaxconn",
+										Value: "32768",
+									},
+								},
+							},
+						},
+						Plugins: []string{"audit-1"},
+						PluginSource: &v1alpha1.TiDBPluginSource{
+							Image: "plugins:v7.1.0",
+						},
+					},
+					PD:   &v1alpha1.PDSpec{},
+					TiKV: &v1alpha1.TiKVSpec{},
+				},
+			},
+			expectedInit: []corev1.Container{
+				{
+					Name:  "plugin-init",
+					Image: "plugins:v7.1.0",
+					Command: []string{
+						"sh",
+						"-c",
+						"cp -r /plugins/. /plugins/",
+					},
+					VolumeMounts: []corev1.VolumeMount{
+						{Name: "plugins", MountPath: "/plugins"},
+					},
+				},
+			},
+			expectedSecurity: &corev1.PodSecurityContext{
+				RunAsNonRoot: &asRoot,
+				Sysctls: []corev1.Sysctl{
+					{
+						Name:  "net.core.somaxconn",
+						Value: "32768",
+					},
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -1945,6 +2032,33 @@ func TestGetNewTiDBService(t *testing.T) {
 	}
 }
 
+func TestGetTiDBConfigMapWithTiProxyTLSOffload(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := &v1alpha1.TidbCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "ns"},
+		Spec: v1alpha1.TidbClusterSpec{
+			TiDB: &v1alpha1.TiDBSpec{
+				TLSClient: &v1alpha1.TiDBTLSClient{Enabled: true},
+				Config:    v1alpha1.NewTiDBConfig(),
+			},
+			TiProxy: &v1alpha1.TiProxySpec{Replicas: 1},
+			PD:      &v1alpha1.PDSpec{},
+			TiKV:    &v1alpha1.TiKVSpec{},
+		},
+	}
+	cm, err := getTiDBConfigMap(tc)
+	g.Expect(err).To(Succeed())
+	g.Expect(cm.Data["config-file"]).NotTo(ContainSubstring("require-secure-transport"))
+
+	// TiDB requires secure transport so that the clients without TLS can only connect through TiProxy
+	tc.Spec.TiProxy.TLSOffload = true
+	cm, err = getTiDBConfigMap(tc)
+	g.Expect(err).To(Succeed())
+	g.Expect(cm.Data["config-file"]).To(ContainSubstring("require-secure-transport = true"))
+	g.Expect(cm.Data["config-file"]).To(ContainSubstring(`ssl-cert = "/var/lib/tidb-server-tls/tls.crt"`))
+}
+
 func TestGetTiDBConfigMap(t *testing.T) {
 	g := NewGomegaWithT(t)
 	updateStrategy := v1alpha1.ConfigUpdateStrategyInPlace
@@ -2585,6 +2699,26 @@ func mustTiDBConfig(x interface{}) *v1alpha1.TiDBConfigWraper {
 	return c
 }
 
+func TestBuildTiDBPluginVolume(t *testing.T) {
+	g := NewGomegaWithT(t)
+	tc := &v1alpha1.TidbCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "tc", Namespace: "ns"},
+		Spec: v1alpha1.TidbClusterSpec{
+			TiDB: &v1alpha1.TiDBSpec{
+				Plugins:      []string{"audit-1"},
+				PluginSource: &v1alpha1.TiDBPluginSource{Image: "plugins:v7.1.0", Path: "/opt/plugins/"},
+			},
+		},
+	}
+	_, _, init, err := buildTiDBPluginVolume(tc)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(init.Command).To(Equal([]string{"sh", "-c", "cp -r /opt/plugins/. /plugins/"}))
+
+	tc.Spec.TiDB.PluginSource.Path = "/opt/plugins; rm -rf /plugins"
+	_, _, _, err = buildTiDBPluginVolume(tc)
+	g.Expect(err).To(HaveOccurred())
+}
+
 func TestBuildRandomPasswordSecret(t *testing.T) {
 	g := NewGomegaWithT(t)
 	patch := gomonkey.ApplyFunc(util.FixedLengthRandomPasswordBytes, func() []byte {
@@ -2603,6 +2737,89 @@ func TestBuildRandomPasswordSecret(t *testing.T) {
 
 }
 
+func TestGetNewTiDBBindingSecret(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	newTC := func() *v1alpha1.TidbCluster {
+		return &v1alpha1.TidbCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "foo",
+				Namespace: "ns",
+			},
+			Spec: v1alpha1.TidbClusterSpec{
+				TiDB: &v1alpha1.TiDBSpec{
+					Binding: &v1alpha1.TiDBBindingSpec{},
+				},
+			},
+		}
+	}
+
+	tmm, _, _, indexers := newFakeTiDBMemberManager()
+	tiIndexer := tmm.deps.InformerFactory.Pingcap().V1alpha1().TidbInitializers().Informer().GetIndexer()
+
+	// the peer service is used if the tidb service is not specified
+	secret, err := tmm.getNewTiDBBindingSecret(newTC())
+	g.Expect(err).Should(BeNil())
+	g.Expect(secret.Name).Should(Equal("foo-tidb-binding"))
+	g.Expect(secret.Type).Should(Equal(tidbBindingSecretType))
+	g.Expect(secret.Data).Should(Equal(map[string][]byte{
+		"type":     []byte("mysql"),
+		"provider": []byte("pingcap"),
+		"host":     []byte("foo-tidb-peer.ns.svc"),
+		"port":     []byte("4000"),
+	}))
+
+	// the tidb service, cluster domain and the CA of the tidb server
+	tc := newTC()
+	tc.Spec.ClusterDomain = "cluster.local"
+	tc.Spec.TiDB.Service = &v1alpha1.TiDBServiceSpec{ServiceSpec: v1alpha1.ServiceSpec{Port: pointer.Int32Ptr(3306)}}
+	tc.Spec.TiDB.TLSClient = &v1alpha1.TiDBTLSClient{Enabled: true}
+	tc.Spec.TiDB.Binding.SecretName = "app-binding"
+	_, err = tmm.getNewTiDBBindingSecret(tc)
+	g.Expect(err).ShouldNot(BeNil())
+	g.Expect(indexers.secret.Add(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: util.TiDBServerTLSSecretName("foo"), Namespace: "ns"},
+		Data:       map[string][]byte{corev1.ServiceAccountRootCAKey: []byte("ca")},
+	})).Should(Succeed())
+	secret, err = tmm.getNewTiDBBindingSecret(tc)
+	g.Expect(err).Should(BeNil())
+	g.Expect(secret.Name).Should(Equal("app-binding"))
+	g.Expect(string(secret.Data["host"])).Should(Equal("foo-tidb.ns.svc.cluster.local"))
+	g.Expect(string(secret.Data["port"])).Should(Equal("3306"))
+	g.Expect(string(secret.Data["certificates"])).Should(Equal("ca"))
+
+	// the credentials of the random root password
+	tc = newTC()
+	tc.Spec.TiDB.Binding.User = "root"
+	_, err = tmm.getNewTiDBBindingSecret(tc)
+	g.Expect(err).ShouldNot(BeNil())
+	tc.Spec.TiDB.Initializer = &v1alpha1.TiDBInitializer{CreatePassword: true}
+	g.Expect(indexers.secret.Add(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: controller.TiDBInitSecret("foo"), Namespace: "ns"},
+		Data:       map[string][]byte{"root": []byte("random")},
+	})).Should(Succeed())
+	secret, err = tmm.getNewTiDBBindingSecret(tc)
+	g.Expect(err).Should(BeNil())
+	g.Expect(string(secret.Data["username"])).Should(Equal("root"))
+	g.Expect(string(secret.Data["password"])).Should(Equal("random"))
+
+	// the credentials of the tidb initializer take precedence
+	g.Expect(tiIndexer.Add(&v1alpha1.TidbInitializer{
+		ObjectMeta: metav1.ObjectMeta{Name: "init", Namespace: "ns"},
+		Spec: v1alpha1.TidbInitializerSpec{
+			Clusters:       v1alpha1.TidbClusterRef{Name: "foo"},
+			PasswordSecret: pointer.StringPtr("init-password"),
+		},
+	})).Should(Succeed())
+	g.Expect(indexers.secret.Add(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "init-password", Namespace: "ns"},
+		Data:       map[string][]byte{"root": []byte("initialized")},
+	})).Should(Succeed())
+	secret, err = tmm.getNewTiDBBindingSecret(tc)
+	g.Expect(err).Should(BeNil())
+	g.Expect(string(secret.Data["password"])).Should(Equal("initialized"))
+}
+
 func TestTiDBMemberManagerSetServerLabels(t *testing.T) {
 	g := NewGomegaWithT(t)
 	type Member struct {
@@ -2698,7 +2915,7 @@ func TestTiDBMemberManagerSetServerLabels(t *testing.T) {
 			tidbCtl.SetLabelsErr(fmt.Errorf("mock label set failed"))
 		}
 
-		setCount, err := pmm.setServerLabels(tc)
+		setCount, err := pmm.labelsSyncer.Sync(tc)
 		if test.errExpectFn != nil {
 			test.errExpectFn(g, err)
 		} else {
@@ -2818,3 +3035,162 @@ func TestTiDBMemberManagerSetServerLabels(t *testing.T) {
 		testFn(&tests[i], t)
 	}
 }
+
+func TestSyncTiDBServerTLS(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := &v1alpha1.TidbCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "foo",
+			Namespace: "ns",
+		},
+		Spec: v1alpha1.TidbClusterSpec{
+			TiDB: &v1alpha1.TiDBSpec{
+				TLSClient: &v1alpha1.TiDBTLSClient{Enabled: true},
+			},
+		},
+	}
+	tc.Status.TiDB.ServerTLS = &v1alpha1.TiDBServerTLSStatus{SecretHash: "stale"}
+
+	tmm, _, _, indexers := newFakeTiDBMemberManager()
+
+	// the status is cleared if auto reload is disabled
+	g.Expect(tmm.syncTiDBServerTLS(tc)).Should(Succeed())
+	g.Expect(tc.Status.TiDB.ServerTLS).Should(BeNil())
+
+	tc.Spec.TiDB.TLSClient.AutoReload = true
+	g.Expect(tmm.syncTiDBServerTLS(tc)).ShouldNot(Succeed())
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: util.TiDBServerTLSSecretName("foo"), Namespace: "ns"},
+		Data:       map[string][]byte{"tls.crt": []byte("crt")},
+	}
+	g.Expect(indexers.secret.Add(secret)).Should(Succeed())
+
+	// the current certificate is observed first
+	g.Expect(tmm.syncTiDBServerTLS(tc)).Should(Succeed())
+	g.Expect(tc.Status.TiDB.ServerTLS.Reloaded).Should(BeTrue())
+	hash := tc.Status.TiDB.ServerTLS.SecretHash
+	g.Expect(hash).ShouldNot(BeEmpty())
+
+	// the renewed certificate is not reloaded until the kubelet updates the mounted secret
+	secret = secret.DeepCopy()
+	secret.Data["tls.crt"] = []byte("renewed")
+	g.Expect(indexers.secret.Update(secret)).Should(Succeed())
+	g.Expect(tmm.syncTiDBServerTLS(tc)).Should(Succeed())
+	g.Expect(tc.Status.TiDB.ServerTLS.SecretHash).ShouldNot(Equal(hash))
+	g.Expect(tc.Status.TiDB.ServerTLS.Reloaded).Should(BeFalse())
+	g.Expect(tc.Status.TiDB.ServerTLS.LastChangeTime.IsZero()).Should(BeFalse())
+	g.Expect(tmm.syncTiDBServerTLS(tc)).Should(Succeed())
+	g.Expect(tc.Status.TiDB.ServerTLS.Reloaded).Should(BeFalse())
+
+	// no healthy member to reload
+	tc.Status.TiDB.ServerTLS.LastChangeTime = metav1.NewTime(time.Now().Add(-tidbServerTLSReloadDelay))
+	g.Expect(tmm.syncTiDBServerTLS(tc)).Should(Succeed())
+	g.Expect(tc.Status.TiDB.ServerTLS.Reloaded).Should(BeTrue())
+}
+
+func TestSyncTiDBOnlineSettings(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := &v1alpha1.TidbCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "foo",
+			Namespace: "ns",
+		},
+		Spec: v1alpha1.TidbClusterSpec{
+			TiDB: &v1alpha1.TiDBSpec{Config: v1alpha1.NewTiDBConfig()},
+		},
+	}
+	tc.Spec.TiDB.Config.Set("log.level", "warn")
+	tc.Spec.TiDB.Config.Set("check-mb4-value-in-utf8", false)
+	tc.Spec.TiDB.Config.Set("pessimistic-txn.deadlock-history-capacity", int64(10))
+	tc.Spec.TiDB.Config.Set("token-limit", int64(1000))
+	tc.Status.TiDB.Members = map[string]v1alpha1.TiDBMember{
+		"foo-tidb-0": {Name: "foo-tidb-0", Health: true},
+		"foo-tidb-1": {Name: "foo-tidb-1", Health: false},
+		"foo-tidb-2": {Name: "foo-tidb-2", Health: true},
+	}
+
+	tmm, _, tidbControl, _ := newFakeTiDBMemberManager()
+	tidbControl.SetPodSettings("foo-tidb-0", config.New(map[string]interface{}{
+		"log":                     map[string]interface{}{"level": "info"},
+		"check-mb4-value-in-utf8": true,
+		"pessimistic-txn":         map[string]interface{}{"deadlock-history-capacity": float64(10)},
+		"token-limit":             float64(500),
+	}))
+	tidbControl.SetPodSettings("foo-tidb-2", config.New(map[string]interface{}{
+		"log":                     map[string]interface{}{"level": "warn"},
+		"check-mb4-value-in-utf8": false,
+		"pessimistic-txn":         map[string]interface{}{"deadlock-history-capacity": float64(10)},
+	}))
+
+	// only the changed settings of the healthy members are set online
+	g.Expect(tmm.syncTiDBOnlineSettings(tc)).Should(Succeed())
+	g.Expect(tidbControl.SettingsSet("foo-tidb-0")).Should(Equal(map[string]string{"log_level": "warn", "check_mb4_value_in_utf8": "0"}))
+	g.Expect(tidbControl.SettingsSet("foo-tidb-1")).Should(BeNil())
+	g.Expect(tidbControl.SettingsSet("foo-tidb-2")).Should(BeNil())
+
+	// the settings are not changed online if the config is rolling updated
+	strategy := v1alpha1.ConfigUpdateStrategyRollingUpdate
+	tc.Spec.TiDB.ConfigUpdateStrategy = &strategy
+	tc.Spec.TiDB.Config.Set("log.level", "error")
+	g.Expect(tmm.syncTiDBOnlineSettings(tc)).Should(Succeed())
+	g.Expect(tidbControl.SettingsSet("foo-tidb-0")).Should(HaveKeyWithValue("log_level", "warn"))
+
+	tc.Spec.TiDB.ConfigUpdateStrategy = nil
+	tidbControl.SetSettingsError(fmt.Errorf("connection refused"))
+	err := tmm.syncTiDBOnlineSettings(tc)
+	g.Expect(controller.IsRequeueError(err)).Should(BeTrue())
+}
+
+func TestTiDBCustomPorts(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := &v1alpha1.TidbCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "foo",
+			Namespace: "ns",
+		},
+		Spec: v1alpha1.TidbClusterSpec{
+			TiDB: &v1alpha1.TiDBSpec{
+				Config: v1alpha1.NewTiDBConfig(),
+				Ports: &v1alpha1.TiDBPorts{
+					Server: pointer.Int32Ptr(14000),
+					Status: pointer.Int32Ptr(20080),
+				},
+				Service: &v1alpha1.TiDBServiceSpec{
+					ServiceSpec: v1alpha1.ServiceSpec{
+						IPFamilies: []corev1.IPFamily{corev1.IPv6Protocol, corev1.IPv4Protocol},
+					},
+				},
+			},
+			PD:         &v1alpha1.PDSpec{},
+			TiKV:       &v1alpha1.TiKVSpec{},
+			PreferIPv6: true,
+		},
+	}
+
+	cm, err := getTiDBConfigMap(tc)
+	g.Expect(err).Should(Succeed())
+	g.Expect(cm.Data["config-file"]).Should(ContainSubstring("port = 14000"))
+	g.Expect(cm.Data["config-file"]).Should(ContainSubstring("status-port = 20080"))
+
+	sts, err := getNewTiDBSetForTidbCluster(tc, cm)
+	g.Expect(err).Should(Succeed())
+	container := sts.Spec.Template.Spec.Containers[len(sts.Spec.Template.Spec.Containers)-1]
+	g.Expect(container.Ports[0].ContainerPort).Should(Equal(int32(14000)))
+	g.Expect(container.Ports[1].ContainerPort).Should(Equal(int32(20080)))
+	g.Expect(container.ReadinessProbe.TCPSocket.Port).Should(Equal(intstr.FromInt(14000)))
+	g.Expect(sts.Spec.Template.Annotations).Should(HaveKeyWithValue("prometheus.io/port", "20080"))
+
+	// the service keeps the port for the clients and targets the custom ports
+	svc := getNewTiDBServiceOrNil(tc)
+	g.Expect(svc.Spec.Ports[0].Port).Should(Equal(v1alpha1.DefaultTiDBServicePort))
+	g.Expect(svc.Spec.Ports[0].TargetPort).Should(Equal(intstr.FromInt(14000)))
+	g.Expect(svc.Spec.Ports[1].Port).Should(Equal(int32(20080)))
+	g.Expect(svc.Spec.IPFamilies).Should(Equal([]corev1.IPFamily{corev1.IPv6Protocol, corev1.IPv4Protocol}))
+	g.Expect(*svc.Spec.IPFamilyPolicy).Should(Equal(corev1.IPFamilyPolicyPreferDualStack))
+
+	headless := getNewTiDBHeadlessServiceForTidbCluster(tc)
+	g.Expect(headless.Spec.Ports[0].TargetPort).Should(Equal(intstr.FromInt(20080)))
+}
@@ -0,0 +1,104 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"fmt"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/apis/util/config"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/util"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/klog/v2"
+)
+
+// tidbOnlineSettings are the TiDB configs that can be changed online by the /settings API, which maps the
+// config keys to the form fields of the API
+var tidbOnlineSettings = []struct {
+	configKey string
+	field     string
+}{
+	{configKey: "log.level", field: "log_level"},
+	{configKey: "check-mb4-value-in-utf8", field: "check_mb4_value_in_utf8"},
+	{configKey: "pessimistic-txn.deadlock-history-capacity", field: "deadlock_history_capacity"},
+	{configKey: "pessimistic-txn.deadlock-history-collect-retryable", field: "deadlock_history_collect_retryable"},
+}
+
+// syncTiDBOnlineSettings applies the changes of the configs in tidbOnlineSettings to the healthy TiDB members
+// online if the config is updated in place, which otherwise only take effect after the members restart.
+func (m *tidbMemberManager) syncTiDBOnlineSettings(tc *v1alpha1.TidbCluster) error {
+	if tc.BaseTiDBSpec().ConfigUpdateStrategy() != v1alpha1.ConfigUpdateStrategyInPlace || tc.Spec.TiDB.Config == nil {
+		return nil
+	}
+	ns := tc.GetNamespace()
+
+	var errs []error
+	for name, member := range tc.Status.TiDB.Members {
+		if !member.Health {
+			continue
+		}
+		ordinal, err := util.GetOrdinalFromPodName(name)
+		if err != nil {
+			continue
+		}
+		current, err := m.deps.TiDBControl.GetSettings(tc, ordinal)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to get settings of tidb %s/%s: %v", ns, name, err))
+			continue
+		}
+		changes := tidbSettingsChanges(tc.Spec.TiDB.Config.GenericConfig, current)
+		if len(changes) == 0 {
+			continue
+		}
+		if err := m.deps.TiDBControl.SetSettings(tc, ordinal, changes); err != nil {
+			errs = append(errs, fmt.Errorf("failed to set settings %v of tidb %s/%s: %v", changes, ns, name, err))
+			continue
+		}
+		klog.Infof("set settings %v of tidb %s/%s online", changes, ns, name)
+	}
+	if len(errs) > 0 {
+		return controller.RequeueErrorf("%v", utilerrors.NewAggregate(errs))
+	}
+	return nil
+}
+
+// tidbSettingsChanges returns the form fields of the configs in tidbOnlineSettings whose desired values are
+// different from the current ones
+func tidbSettingsChanges(desired, current *config.GenericConfig) map[string]string {
+	changes := map[string]string{}
+	for _, s := range tidbOnlineSettings {
+		d := desired.Get(s.configKey)
+		if d == nil {
+			continue
+		}
+		want := tidbSettingValue(d.Interface())
+		if c := current.Get(s.configKey); c != nil && tidbSettingValue(c.Interface()) == want {
+			continue
+		}
+		changes[s.field] = want
+	}
+	return changes
+}
+
+// tidbSettingValue formats the config value as the form value of the /settings API, which takes 1 and 0 as booleans
+func tidbSettingValue(v interface{}) string {
+	if b, ok := v.(bool); ok {
+		if b {
+			return "1"
+		}
+		return "0"
+	}
+	return fmt.Sprint(v)
+}
@@ -38,6 +38,11 @@ func NewTiDBScaler(deps *controller.Dependencies) *tidbScaler {
 
 // Scale scales in or out of the statefulset.
 func (s *tidbScaler) Scale(meta metav1.Object, oldSet *apps.StatefulSet, newSet *apps.StatefulSet) error {
+	if tc, ok := meta.(*v1alpha1.TidbCluster); ok {
+		if err := s.setZoneAwareDeleteSlots(tc, v1alpha1.TiDBMemberType, oldSet, newSet); err != nil {
+			return err
+		}
+	}
 	scaling, _, _, _ := scaleOne(oldSet, newSet)
 	if scaling > 0 {
 		return s.ScaleOut(meta, oldSet, newSet)
@@ -57,6 +62,16 @@ func (s *tidbScaler) ScaleOut(meta metav1.Object, oldSet *apps.StatefulSet, newS
 		return nil
 	}
 	klog.Infof("scaling out tidb statefulset %s/%s, ordinal: %d (replicas: %d, delete slots: %v)", oldSet.Namespace, oldSet.Name, ordinal, replicas, deleteSlots.List())
+	if tc, ok := meta.(*v1alpha1.TidbCluster); ok {
+		if policy := tc.ScaleInPVCRetentionPolicy(v1alpha1.TiDBMemberType); policy != nil && *policy == v1alpha1.ScaleInPVCRetain {
+			// the retained PVCs are re-used by the new pod
+			if err := s.reuseDeferDeletingPVC(tc, v1alpha1.TiDBMemberType, ordinal); err != nil {
+				return err
+			}
+			setReplicasAndDeleteSlots(newSet, replicas, deleteSlots)
+			return nil
+		}
+	}
 	skipReason, err := s.deleteDeferDeletingPVC(obj, v1alpha1.TiDBMemberType, ordinal)
 	if err != nil {
 		return err
@@ -102,6 +117,10 @@ func (s *tidbScaler) ScaleIn(meta metav1.Object, oldSet *apps.StatefulSet, newSe
 		}
 	}
 
+	if err := resignTiDBDDLOwner(s.deps, tc, ordinal); err != nil {
+		return err
+	}
+
 	setReplicasAndDeleteSlots(newSet, replicas, deleteSlots)
 	return nil
 }
@@ -16,14 +16,17 @@ package member
 import (
 	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
 	"github.com/pingcap/tidb-operator/pkg/controller"
 	mngerutils "github.com/pingcap/tidb-operator/pkg/manager/utils"
 
 	"github.com/pingcap/advanced-statefulset/client/apis/apps/v1/helper"
+	"github.com/robfig/cron"
 	apps "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/klog/v2"
 	podutil "k8s.io/kubernetes/pkg/api/v1/pod"
@@ -33,6 +36,14 @@ const (
 	// TODO: change to use minReadySeconds in sts spec
 	// See https://kubernetes.io/blog/2021/08/27/minreadyseconds-statefulsets/
 	annoKeyTiDBMinReadySeconds = "tidb.pingcap.com/tidb-min-ready-seconds"
+
+	// maxResignDDLOwnerCount is the max number of the syncs to resign the ddl owner before the tidb pod is upgraded
+	maxResignDDLOwnerCount = 3
+
+	// tidbConnectionsQueryPattern and tidbQPSQueryPattern query the load of a tidb pod from the Prometheus of
+	// TidbMonitor, which labels the metrics with the namespace and the pod name
+	tidbConnectionsQueryPattern = `sum(tidb_server_connections{kubernetes_namespace="%s",instance="%s"})`
+	tidbQPSQueryPattern         = `sum(rate(tidb_server_query_total{kubernetes_namespace="%s",instance="%s"}[1m]))`
 )
 
 type tidbUpgrader struct {
@@ -75,12 +86,18 @@ func (u *tidbUpgrader) Upgrade(tc *v1alpha1.TidbCluster, oldSet *apps.StatefulSe
 		return nil
 	}
 
+	if held, err := holdCanaryUpgrade(tc.TiDBCanaryUpgrade(), &tc.Status.TiDB.CanaryUpgrade, oldSet, newSet); err != nil || held {
+		return err
+	}
+
 	tc.Status.TiDB.Phase = v1alpha1.UpgradePhase
 	if !templateEqual(newSet, oldSet) {
 		return nil
 	}
 
 	if tc.Status.TiDB.StatefulSet.UpdateRevision == tc.Status.TiDB.StatefulSet.CurrentRevision {
+		tc.Status.TiDB.CanaryUpgrade = nil
+		tc.Status.TiDB.LoadAwareRestart = nil
 		return nil
 	}
 
@@ -107,6 +124,16 @@ func (u *tidbUpgrader) Upgrade(tc *v1alpha1.TidbCluster, oldSet *apps.StatefulSe
 
 	mngerutils.SetUpgradePartition(newSet, *oldSet.Spec.UpdateStrategy.RollingUpdate.Partition)
 	podOrdinals := helper.GetPodOrdinals(*oldSet.Spec.Replicas, oldSet).List()
+	if maxUnavailable := tc.TiDBUpgradeMaxUnavailable(); maxUnavailable > 1 {
+		return u.upgradeInParallel(tc, oldSet, newSet, podOrdinals, minReadySeconds, maxUnavailable)
+	}
+	var canary *canaryUpgrader
+	if spec := tc.TiDBCanaryUpgrade(); spec != nil {
+		canary = u.newCanaryUpgrader(tc, spec)
+		if done, err := canary.evaluate(oldSet, newSet, podOrdinals); err != nil || done {
+			return err
+		}
+	}
 	for _i := len(podOrdinals) - 1; _i >= 0; _i-- {
 		i := podOrdinals[_i]
 		podName := tidbPodName(tcName, i)
@@ -133,17 +160,257 @@ func (u *tidbUpgrader) Upgrade(tc *v1alpha1.TidbCluster, oldSet *apps.StatefulSe
 			}
 			continue
 		}
+		if canary != nil {
+			// all the pods above are upgraded and healthy
+			if err := canary.soak(oldSet, len(podOrdinals)-1-_i); err != nil {
+				return err
+			}
+		}
+		if spec := tc.TiDBLoadAwareRestart(); spec != nil {
+			if err := u.waitForLowLoad(tc, spec, i); err != nil {
+				return err
+			}
+		}
 		return u.upgradeTiDBPod(tc, i, newSet)
 	}
 
 	return nil
 }
 
+func (u *tidbUpgrader) newCanaryUpgrader(tc *v1alpha1.TidbCluster, spec *v1alpha1.CanaryUpgrade) *canaryUpgrader {
+	c := &canaryUpgrader{
+		deps:       u.deps,
+		tc:         tc,
+		memberType: v1alpha1.TiDBMemberType,
+		spec:       spec,
+		status:     &tc.Status.TiDB.CanaryUpgrade,
+		setStatus:  tc.Status.TiDB.StatefulSet,
+		podName: func(ordinal int32) string {
+			return tidbPodName(tc.GetName(), ordinal)
+		},
+		healthy: func(ordinal int32) bool {
+			member, exist := tc.Status.TiDB.Members[tidbPodName(tc.GetName(), ordinal)]
+			return exist && member.Health
+		},
+	}
+	if spec.MaxErrorRate != nil {
+		c.regression = func(ordinals []int32) (string, error) {
+			return u.errorRateRegression(tc, ordinals, *spec.MaxErrorRate)
+		}
+	}
+	return c
+}
+
+// errorRateRegression returns the regression if the ratio of the failed SQL statements executed by the
+// upgraded TiDB pods since they started exceeds maxErrorRate
+func (u *tidbUpgrader) errorRateRegression(tc *v1alpha1.TidbCluster, ordinals []int32, maxErrorRate float64) (string, error) {
+	total, failed := 0.0, 0.0
+	for _, i := range ordinals {
+		counters, err := u.deps.TiDBControl.GetQueryCounters(tc, i)
+		if err != nil {
+			return "", controller.RequeueErrorf("tidbcluster: [%s/%s] failed to get the query counters of tidb pod %s: %v",
+				tc.GetNamespace(), tc.GetName(), tidbPodName(tc.GetName(), i), err)
+		}
+		total += counters.Total
+		failed += counters.Failed
+	}
+	if total == 0 {
+		return "", nil
+	}
+	if rate := failed / total; rate > maxErrorRate {
+		return fmt.Sprintf("the SQL error rate %.4f of the upgraded pods exceeds %v", rate, maxErrorRate), nil
+	}
+	return "", nil
+}
+
+// waitForLowLoad returns nil if the TiDB pod of the ordinal can be restarted now, i.e. inside the maintenance
+// window, and either its load is below the thresholds or its restart has been delayed for the max delay.
+// Otherwise it records the delay in the status and returns a requeue error.
+func (u *tidbUpgrader) waitForLowLoad(tc *v1alpha1.TidbCluster, spec *v1alpha1.LoadAwareRestart, ordinal int32) error {
+	ns := tc.GetNamespace()
+	tcName := tc.GetName()
+	podName := tidbPodName(tcName, ordinal)
+	now := time.Now()
+
+	if spec.Window != nil {
+		active, err := isMaintenanceWindowActive(spec.Window, now)
+		if err != nil {
+			return fmt.Errorf("tidbcluster: [%s/%s] invalid maintenance window of the load-aware restart: %v", ns, tcName, err)
+		}
+		if !active {
+			return controller.RequeueErrorf("tidbcluster: [%s/%s]'s tidb pod: [%s] is not restarted outside the maintenance window", ns, tcName, podName)
+		}
+	}
+
+	// the status is kept until the next pod, so that the delay isn't restarted if the restart is requeued, e.g. by
+	// resigning the ddl owner
+	status := tc.Status.TiDB.LoadAwareRestart
+	if status == nil || status.Pod != podName {
+		status = &v1alpha1.LoadAwareRestartStatus{Pod: podName, DelayStartTime: metav1.NewTime(now)}
+		tc.Status.TiDB.LoadAwareRestart = status
+	}
+	if now.Sub(status.DelayStartTime.Time) >= spec.GetMaxDelay() {
+		if status.Message != "" {
+			klog.Infof("tidbcluster: [%s/%s] restart tidb pod %s regardless of its load after the max delay %s", ns, tcName, podName, spec.GetMaxDelay())
+			status.Message = ""
+		}
+		return nil
+	}
+	reason, err := u.highLoad(tc, spec, podName)
+	if err != nil {
+		reason = fmt.Sprintf("failed to query the load: %v", err)
+	}
+	status.Message = reason
+	if reason != "" {
+		return controller.RequeueErrorf("tidbcluster: [%s/%s]'s tidb pod: [%s] restart is delayed: %s", ns, tcName, podName, reason)
+	}
+	return nil
+}
+
+// highLoad returns why the load of the TiDB pod is too high to restart it, it's empty if the load is low
+func (u *tidbUpgrader) highLoad(tc *v1alpha1.TidbCluster, spec *v1alpha1.LoadAwareRestart, podName string) (string, error) {
+	ns := tc.GetNamespace()
+	if spec.MaxConnections != nil {
+		conns, err := u.deps.PrometheusControl.Query(spec.PrometheusURL, fmt.Sprintf(tidbConnectionsQueryPattern, ns, podName))
+		if err != nil {
+			return "", err
+		}
+		if conns > float64(*spec.MaxConnections) {
+			return fmt.Sprintf("%.0f active connections exceed %d", conns, *spec.MaxConnections), nil
+		}
+	}
+	if spec.MaxQPS != nil {
+		qps, err := u.deps.PrometheusControl.Query(spec.PrometheusURL, fmt.Sprintf(tidbQPSQueryPattern, ns, podName))
+		if err != nil {
+			return "", err
+		}
+		if qps > *spec.MaxQPS {
+			return fmt.Sprintf("QPS %.2f exceeds %v", qps, *spec.MaxQPS), nil
+		}
+	}
+	return "", nil
+}
+
+// isMaintenanceWindowActive returns whether the window is active at the time, the window is active if its
+// next end comes before its next start.
+func isMaintenanceWindowActive(window *v1alpha1.MaintenanceWindow, now time.Time) (bool, error) {
+	start, err := cron.ParseStandard(window.Start)
+	if err != nil {
+		return false, fmt.Errorf("parse start %q failed, err: %v", window.Start, err)
+	}
+	end, err := cron.ParseStandard(window.End)
+	if err != nil {
+		return false, fmt.Errorf("parse end %q failed, err: %v", window.End, err)
+	}
+	now = now.UTC()
+	return end.Next(now).Before(start.Next(now)), nil
+}
+
+// upgradeInParallel upgrades at most maxUnavailable TiDB pods at once in the descending order of the ordinals.
+// The StatefulSet controller recreates only one pod at a time, so the outdated pods above the partition
+// are deleted by the upgrader to be recreated with the update revision together.
+func (u *tidbUpgrader) upgradeInParallel(tc *v1alpha1.TidbCluster, oldSet *apps.StatefulSet, newSet *apps.StatefulSet,
+	podOrdinals []int32, minReadySeconds int, maxUnavailable int) error {
+	ns := tc.GetNamespace()
+	tcName := tc.GetName()
+	partition := *oldSet.Spec.UpdateStrategy.RollingUpdate.Partition
+
+	unavailable := 0
+	upgraded := false
+	for _i := len(podOrdinals) - 1; _i >= 0; _i-- {
+		i := podOrdinals[_i]
+		podName := tidbPodName(tcName, i)
+		pod, err := u.deps.PodLister.Pods(ns).Get(podName)
+		if errors.IsNotFound(err) {
+			// the pod deleted by the parallel upgrade is not recreated yet
+			unavailable++
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("tidbUpgrader.upgradeInParallel: failed to get pods %s for cluster %s/%s, error: %s", podName, ns, tcName, err)
+		}
+		revision, exist := pod.Labels[apps.ControllerRevisionHashLabelKey]
+		if !exist {
+			return controller.RequeueErrorf("tidbcluster: [%s/%s]'s tidb pod: [%s] has no label: %s", ns, tcName, podName, apps.ControllerRevisionHashLabelKey)
+		}
+
+		if revision == tc.Status.TiDB.StatefulSet.UpdateRevision {
+			member, exist := tc.Status.TiDB.Members[podName]
+			if !podutil.IsPodAvailable(pod, int32(minReadySeconds), metav1.Now()) || !exist || !member.Health {
+				unavailable++
+			}
+			continue
+		}
+
+		if i >= partition {
+			// the pod is being upgraded
+			unavailable++
+			if pod.DeletionTimestamp == nil {
+				klog.Infof("tidbcluster: [%s/%s] delete tidb pod %s to upgrade it in parallel", ns, tcName, podName)
+				reason, detail := mngerutils.UpgradeRestartReason(pod, &newSet.Spec.Template)
+				mngerutils.RecordPodRestart(tc, pod, reason, detail)
+				if err := u.deps.PodControl.DeletePod(tc, pod); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		if unavailable >= maxUnavailable {
+			break
+		}
+		if err := u.upgradeTiDBPod(tc, i, newSet); err != nil {
+			if upgraded {
+				// keep the partition lowered for the pods whose ddl owner is not involved
+				klog.Infof("tidbcluster: [%s/%s] stop lowering the partition of tidb at %d: %v", ns, tcName, i, err)
+				return nil
+			}
+			return err
+		}
+		upgraded = true
+		unavailable++
+	}
+
+	if !upgraded && unavailable > 0 {
+		return controller.RequeueErrorf("tidbcluster: [%s/%s]'s %d tidb pods are being upgraded in parallel", ns, tcName, unavailable)
+	}
+	return nil
+}
+
 func (u *tidbUpgrader) upgradeTiDBPod(tc *v1alpha1.TidbCluster, ordinal int32, newSet *apps.StatefulSet) error {
+	if err := resignTiDBDDLOwner(u.deps, tc, ordinal); err != nil {
+		return err
+	}
+	recordUpgradeRestart(u.deps, tc, tidbPodName(tc.GetName(), ordinal), newSet)
 	mngerutils.SetUpgradePartition(newSet, ordinal)
 	return nil
 }
 
+// resignTiDBDDLOwner resigns the ddl owner before the tidb pod is restarted or deleted, so that the running
+// DDL jobs are not stalled until the owner lease of the tidb expires. It gives up after maxResignDDLOwnerCount
+// syncs, so that a tidb that fails to resign does not block the upgrade or the scaling forever.
+func resignTiDBDDLOwner(deps *controller.Dependencies, tc *v1alpha1.TidbCluster, ordinal int32) error {
+	ns := tc.GetNamespace()
+	tcName := tc.GetName()
+	podName := tidbPodName(tcName, ordinal)
+
+	if member, exist := tc.Status.TiDB.Members[podName]; exist && member.Health && tc.Status.TiDB.ResignDDLOwnerRetryCount < maxResignDDLOwnerCount {
+		notOwner, err := deps.TiDBControl.ResignDDLOwner(tc, ordinal)
+		if err != nil {
+			tc.Status.TiDB.ResignDDLOwnerRetryCount++
+			return fmt.Errorf("tidbcluster: [%s/%s]'s tidb pod: [%s] failed to resign ddl owner, retry count: %d, error: %v",
+				ns, tcName, podName, tc.Status.TiDB.ResignDDLOwnerRetryCount, err)
+		}
+		if !notOwner {
+			tc.Status.TiDB.ResignDDLOwnerRetryCount++
+			return controller.RequeueErrorf("tidbcluster: [%s/%s]'s tidb pod: [%s] has resigned ddl owner, wait for the new owner",
+				ns, tcName, podName)
+		}
+	}
+	tc.Status.TiDB.ResignDDLOwnerRetryCount = 0
+	return nil
+}
+
 type fakeTiDBUpgrader struct{}
 
 // NewFakeTiDBUpgrader returns a fake tidb upgrader
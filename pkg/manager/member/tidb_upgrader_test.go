@@ -14,7 +14,10 @@
 package member
 
 import (
+	"context"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/pingcap/tidb-operator/pkg/apis/label"
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
@@ -24,8 +27,11 @@ import (
 	. "github.com/onsi/gomega"
 	apps "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	podinformers "k8s.io/client-go/informers/core/v1"
 	"k8s.io/utils/pointer"
 )
@@ -256,6 +262,263 @@ func TestTiDBUpgrader_Upgrade(t *testing.T) {
 
 }
 
+func TestTiDBUpgraderResignDDLOwner(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	upgrade := func(upgrader Upgrader, tc *v1alpha1.TidbCluster) (*apps.StatefulSet, error) {
+		oldSet := newStatefulSetForTiDBUpgrader()
+		mngerutils.SetStatefulSetLastAppliedConfigAnnotation(oldSet)
+		newSet := oldSet.DeepCopy()
+		err := upgrader.Upgrade(tc, oldSet, newSet)
+		return newSet, err
+	}
+
+	// the ddl owner is resigned before the pod is upgraded
+	upgrader, tidbControl, podInformer := newTiDBUpgrader()
+	for _, pod := range getTiDBPods() {
+		podInformer.Informer().GetIndexer().Add(pod)
+	}
+	tc := newTidbClusterForTiDBUpgrader()
+	tidbControl.SetDDLOwner("upgrader-tidb-0", nil)
+	newSet, err := upgrade(upgrader, tc)
+	g.Expect(controller.IsRequeueError(err)).To(BeTrue())
+	g.Expect(newSet.Spec.UpdateStrategy.RollingUpdate.Partition).To(Equal(pointer.Int32Ptr(1)))
+	g.Expect(tc.Status.TiDB.ResignDDLOwnerRetryCount).To(Equal(int32(1)))
+	newSet, err = upgrade(upgrader, tc)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(newSet.Spec.UpdateStrategy.RollingUpdate.Partition).To(Equal(pointer.Int32Ptr(0)))
+	g.Expect(tc.Status.TiDB.ResignDDLOwnerRetryCount).To(Equal(int32(0)))
+
+	// the pod is upgraded after the retries are exhausted
+	tc = newTidbClusterForTiDBUpgrader()
+	tidbControl.SetDDLOwner("", fmt.Errorf("resign ddl owner failed"))
+	for i := 1; i <= maxResignDDLOwnerCount; i++ {
+		_, err = upgrade(upgrader, tc)
+		g.Expect(err).To(HaveOccurred())
+		g.Expect(tc.Status.TiDB.ResignDDLOwnerRetryCount).To(Equal(int32(i)))
+	}
+	newSet, err = upgrade(upgrader, tc)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(newSet.Spec.UpdateStrategy.RollingUpdate.Partition).To(Equal(pointer.Int32Ptr(0)))
+	g.Expect(tc.Status.TiDB.ResignDDLOwnerRetryCount).To(Equal(int32(0)))
+}
+
+func TestTiDBUpgraderUpgradeInParallel(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	upgrader, _, podInformer := newTiDBUpgrader()
+	tc := newTidbClusterForTiDBUpgrader()
+	tc.Spec.TiDB.Replicas = 4
+	tc.Spec.TiDB.UpgradePolicy = v1alpha1.TiDBUpgradePolicyParallel
+	tc.Spec.TiDB.MaxUnavailable = &intstr.IntOrString{Type: intstr.String, StrVal: "50%"}
+	tc.Status.TiDB.StatefulSet.Replicas = 4
+	for i := int32(0); i < 4; i++ {
+		name := tidbPodName(upgradeTcName, i)
+		tc.Status.TiDB.Members[name] = v1alpha1.TiDBMember{Name: name, Health: true}
+		pod := getTiDBPods()[0].DeepCopy()
+		pod.Name = name
+		podInformer.Informer().GetIndexer().Add(pod)
+	}
+
+	oldSet := newStatefulSetForTiDBUpgrader()
+	oldSet.Spec.Replicas = pointer.Int32Ptr(4)
+	oldSet.Spec.UpdateStrategy.RollingUpdate.Partition = pointer.Int32Ptr(4)
+	mngerutils.SetStatefulSetLastAppliedConfigAnnotation(oldSet)
+
+	// the partition is lowered for 2 pods at once
+	newSet := oldSet.DeepCopy()
+	g.Expect(upgrader.Upgrade(tc, oldSet, newSet)).To(Succeed())
+	g.Expect(newSet.Spec.UpdateStrategy.RollingUpdate.Partition).To(Equal(pointer.Int32Ptr(2)))
+
+	// the outdated pods above the partition are deleted to be recreated together
+	oldSet.Spec.UpdateStrategy.RollingUpdate.Partition = pointer.Int32Ptr(2)
+	newSet = oldSet.DeepCopy()
+	err := upgrader.Upgrade(tc, oldSet, newSet)
+	g.Expect(controller.IsRequeueError(err)).To(BeTrue())
+	g.Expect(newSet.Spec.UpdateStrategy.RollingUpdate.Partition).To(Equal(pointer.Int32Ptr(2)))
+	for i := int32(0); i < 4; i++ {
+		_, err := podInformer.Lister().Pods(corev1.NamespaceDefault).Get(tidbPodName(upgradeTcName, i))
+		g.Expect(errors.IsNotFound(err)).To(Equal(i >= 2))
+	}
+}
+
+func TestTiDBUpgraderCanaryUpgrade(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	fakeDeps := controller.NewFakeDependencies()
+	upgrader := &tidbUpgrader{fakeDeps}
+	tidbControl := fakeDeps.TiDBControl.(*controller.FakeTiDBControl)
+	podIndexer := fakeDeps.KubeInformerFactory.Core().V1().Pods().Informer().GetIndexer()
+	for _, pod := range getTiDBPods() {
+		g.Expect(podIndexer.Add(pod)).To(Succeed())
+	}
+	_, err := fakeDeps.KubeClientset.AppsV1().ControllerRevisions(corev1.NamespaceDefault).Create(context.TODO(), &apps.ControllerRevision{
+		ObjectMeta: metav1.ObjectMeta{Name: "1", Namespace: corev1.NamespaceDefault},
+		Data:       runtime.RawExtension{Raw: []byte(`{"spec":{"template":{"spec":{"containers":[{"name":"tidb","image":"tidb-old-image"}]},"$patch":"replace"}}}`)},
+	}, metav1.CreateOptions{})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	tc := newTidbClusterForTiDBUpgrader()
+	tc.Spec.TiDB.CanaryUpgrade = &v1alpha1.CanaryUpgrade{
+		SoakDuration: &metav1.Duration{Duration: time.Hour},
+		MaxErrorRate: pointer.Float64Ptr(0.1),
+	}
+	oldSet := newStatefulSetForTiDBUpgrader()
+	mngerutils.SetStatefulSetLastAppliedConfigAnnotation(oldSet)
+	upgrade := func() (*apps.StatefulSet, error) {
+		newSet := oldSet.DeepCopy()
+		err := upgrader.Upgrade(tc, oldSet, newSet)
+		return newSet, err
+	}
+
+	// the soak period starts after the first pod is upgraded
+	newSet, err := upgrade()
+	g.Expect(controller.IsRequeueError(err)).To(BeTrue())
+	g.Expect(newSet.Spec.UpdateStrategy.RollingUpdate.Partition).To(Equal(pointer.Int32Ptr(1)))
+	g.Expect(tc.Status.TiDB.CanaryUpgrade.Phase).To(Equal(v1alpha1.CanaryUpgradeSoaking))
+	g.Expect(tc.Status.TiDB.CanaryUpgrade.Revision).To(Equal("2"))
+	g.Expect(tc.Status.TiDB.CanaryUpgrade.Partition).To(Equal(int32(1)))
+	_, err = upgrade()
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(err.Error()).To(ContainSubstring("remaining"))
+
+	// the upgrade advances after the soak period
+	tc.Status.TiDB.CanaryUpgrade.SoakStartTime = &metav1.Time{Time: time.Now().Add(-2 * time.Hour)}
+	tidbControl.SetQueryCounters(map[string]*controller.QueryCounters{"upgrader-tidb-1": {Total: 100, Failed: 5}})
+	newSet, err = upgrade()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(newSet.Spec.UpdateStrategy.RollingUpdate.Partition).To(Equal(pointer.Int32Ptr(0)))
+	g.Expect(tc.Status.TiDB.CanaryUpgrade.Phase).To(Equal(v1alpha1.CanaryUpgradeUpgrading))
+
+	// the upgraded pod is rolled back on the regression of the error rate
+	tc.Status.TiDB.CanaryUpgrade.Phase = v1alpha1.CanaryUpgradeSoaking
+	tidbControl.SetQueryCounters(map[string]*controller.QueryCounters{"upgrader-tidb-1": {Total: 100, Failed: 20}})
+	newSet, err = upgrade()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(newSet.Spec.Template.Spec.Containers[0].Image).To(Equal("tidb-old-image"))
+	g.Expect(newSet.Spec.UpdateStrategy.RollingUpdate.Partition).To(Equal(pointer.Int32Ptr(1)))
+	g.Expect(tc.Status.TiDB.CanaryUpgrade.Phase).To(Equal(v1alpha1.CanaryUpgradeRolledBack))
+	g.Expect(tc.Status.TiDB.CanaryUpgrade.Message).To(ContainSubstring("error rate 0.2000"))
+
+	// the StatefulSet is held until the pod template changes
+	rolledBack := newSet.DeepCopy()
+	mngerutils.SetStatefulSetLastAppliedConfigAnnotation(rolledBack)
+	newSet = oldSet.DeepCopy()
+	g.Expect(upgrader.Upgrade(tc, rolledBack, newSet)).To(Succeed())
+	g.Expect(newSet.Spec.Template.Spec.Containers[0].Image).To(Equal("tidb-old-image"))
+	newSet = oldSet.DeepCopy()
+	newSet.Spec.Template.Spec.Containers[0].Image = "tidb-new-image"
+	g.Expect(upgrader.Upgrade(tc, rolledBack, newSet)).To(Succeed())
+	g.Expect(newSet.Spec.Template.Spec.Containers[0].Image).To(Equal("tidb-new-image"))
+	g.Expect(tc.Status.TiDB.CanaryUpgrade).To(BeNil())
+
+	// the upgrade is paused if the upgraded pod restarts and the rollback is disabled
+	tc.Spec.TiDB.CanaryUpgrade.DisableRollback = true
+	_, err = upgrade()
+	g.Expect(controller.IsRequeueError(err)).To(BeTrue())
+	pod := getTiDBPods()[1]
+	pod.Status.ContainerStatuses = []corev1.ContainerStatus{{Name: "tidb", RestartCount: 1}}
+	g.Expect(podIndexer.Update(pod)).To(Succeed())
+	newSet, err = upgrade()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(newSet.Spec.Template.Spec.Containers[0].Image).To(Equal("tidb-test-image"))
+	g.Expect(newSet.Spec.UpdateStrategy.RollingUpdate.Partition).To(Equal(pointer.Int32Ptr(1)))
+	g.Expect(tc.Status.TiDB.CanaryUpgrade.Phase).To(Equal(v1alpha1.CanaryUpgradePaused))
+	g.Expect(tc.Status.TiDB.CanaryUpgrade.Message).To(Equal("upgraded pod upgrader-tidb-1 restarted 1 times"))
+}
+
+func TestTiDBUpgraderLoadAwareRestart(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	fakeDeps := controller.NewFakeDependencies()
+	upgrader := &tidbUpgrader{fakeDeps}
+	promControl := fakeDeps.PrometheusControl.(*controller.FakePrometheusControl)
+	podIndexer := fakeDeps.KubeInformerFactory.Core().V1().Pods().Informer().GetIndexer()
+	for _, pod := range getTiDBPods() {
+		g.Expect(podIndexer.Add(pod)).To(Succeed())
+	}
+
+	tc := newTidbClusterForTiDBUpgrader()
+	tc.Spec.TiDB.LoadAwareRestart = &v1alpha1.LoadAwareRestart{
+		PrometheusURL:  "http://upgrader-prometheus:9090",
+		MaxConnections: pointer.Int32Ptr(10),
+		MaxQPS:         pointer.Float64Ptr(100),
+	}
+	connsQuery := fmt.Sprintf(tidbConnectionsQueryPattern, corev1.NamespaceDefault, "upgrader-tidb-0")
+	qpsQuery := fmt.Sprintf(tidbQPSQueryPattern, corev1.NamespaceDefault, "upgrader-tidb-0")
+	oldSet := newStatefulSetForTiDBUpgrader()
+	mngerutils.SetStatefulSetLastAppliedConfigAnnotation(oldSet)
+	upgrade := func() (*apps.StatefulSet, error) {
+		newSet := oldSet.DeepCopy()
+		err := upgrader.Upgrade(tc, oldSet, newSet)
+		return newSet, err
+	}
+
+	// the restart is delayed while the load is high
+	promControl.SetValue(connsQuery, 20)
+	newSet, err := upgrade()
+	g.Expect(controller.IsRequeueError(err)).To(BeTrue())
+	g.Expect(newSet.Spec.UpdateStrategy.RollingUpdate.Partition).To(Equal(pointer.Int32Ptr(1)))
+	g.Expect(tc.Status.TiDB.LoadAwareRestart.Pod).To(Equal("upgrader-tidb-0"))
+	g.Expect(tc.Status.TiDB.LoadAwareRestart.Message).To(Equal("20 active connections exceed 10"))
+	promControl.SetValue(connsQuery, 5)
+	promControl.SetValue(qpsQuery, 200)
+	_, err = upgrade()
+	g.Expect(controller.IsRequeueError(err)).To(BeTrue())
+	g.Expect(tc.Status.TiDB.LoadAwareRestart.Message).To(Equal("QPS 200.00 exceeds 100"))
+
+	// the pod is restarted once the load is low
+	promControl.SetValue(qpsQuery, 10)
+	newSet, err = upgrade()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(newSet.Spec.UpdateStrategy.RollingUpdate.Partition).To(Equal(pointer.Int32Ptr(0)))
+	g.Expect(tc.Status.TiDB.LoadAwareRestart.Message).To(BeEmpty())
+
+	// the pod is restarted regardless of the load after the max delay
+	promControl.SetValue(qpsQuery, 200)
+	tc.Status.TiDB.LoadAwareRestart.DelayStartTime = metav1.NewTime(time.Now().Add(-2 * time.Hour))
+	newSet, err = upgrade()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(newSet.Spec.UpdateStrategy.RollingUpdate.Partition).To(Equal(pointer.Int32Ptr(0)))
+
+	// the pod is not restarted outside the maintenance window
+	now := time.Now().UTC()
+	tc.Spec.TiDB.LoadAwareRestart.Window = &v1alpha1.MaintenanceWindow{
+		Start: fmt.Sprintf("0 %d * * *", now.Add(2*time.Hour).Hour()),
+		End:   fmt.Sprintf("0 %d * * *", now.Add(3*time.Hour).Hour()),
+	}
+	_, err = upgrade()
+	g.Expect(controller.IsRequeueError(err)).To(BeTrue())
+	g.Expect(err.Error()).To(ContainSubstring("outside the maintenance window"))
+
+	// the status is cleared after the upgrade completes
+	tc.Status.TiDB.StatefulSet.CurrentRevision = tc.Status.TiDB.StatefulSet.UpdateRevision
+	_, err = upgrade()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(tc.Status.TiDB.LoadAwareRestart).To(BeNil())
+}
+
+func TestIsMaintenanceWindowActive(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	window := &v1alpha1.MaintenanceWindow{Start: "0 22 * * *", End: "0 2 * * *"}
+	for _, tt := range []struct {
+		hour   int
+		active bool
+	}{
+		{hour: 21, active: false},
+		{hour: 23, active: true},
+		{hour: 1, active: true},
+		{hour: 3, active: false},
+	} {
+		active, err := isMaintenanceWindowActive(window, time.Date(2024, 1, 1, tt.hour, 30, 0, 0, time.UTC))
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(active).To(Equal(tt.active), "hour %d", tt.hour)
+	}
+	_, err := isMaintenanceWindowActive(&v1alpha1.MaintenanceWindow{Start: "invalid", End: "0 2 * * *"}, time.Now())
+	g.Expect(err).To(HaveOccurred())
+}
+
 func newTiDBUpgrader() (Upgrader, *controller.FakeTiDBControl, podinformers.PodInformer) {
 	fakeDeps := controller.NewFakeDependencies()
 	upgrader := &tidbUpgrader{fakeDeps}
@@ -107,11 +107,13 @@ func (m *TidbClusterStatusManager) syncTiDBInfoKey(tc *v1alpha1.TidbCluster) err
 		// connect to pd of other cluster and use own cert
 		pdEtcdClient, err = m.deps.PDControl.GetPDEtcdClient(pdapi.Namespace(tc.Spec.Cluster.Namespace), tc.Spec.Cluster.Name, tc.IsTLSClusterEnabled(),
 			pdapi.TLSCertFromTC(pdapi.Namespace(tc.Namespace), tc.Name),
+			pdapi.TLSFromCluster(pdapi.Namespace(tc.Namespace), tc.Spec.TLSCluster),
 			pdapi.ClusterRef(tc.Spec.Cluster.ClusterDomain),
 			pdapi.UseHeadlessService(tc.Spec.AcrossK8s),
 		)
 	} else {
-		pdEtcdClient, err = m.deps.PDControl.GetPDEtcdClient(pdapi.Namespace(tc.Namespace), tc.Name, tc.IsTLSClusterEnabled())
+		pdEtcdClient, err = m.deps.PDControl.GetPDEtcdClient(pdapi.Namespace(tc.Namespace), tc.Name, tc.IsTLSClusterEnabled(),
+			pdapi.TLSFromCluster(pdapi.Namespace(tc.Namespace), tc.Spec.TLSCluster))
 	}
 	if err != nil {
 		return err
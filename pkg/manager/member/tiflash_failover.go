@@ -35,8 +35,16 @@ type tiflashStoreAccess struct{}
 
 var _ StoreAccess = (*tiflashStoreAccess)(nil)
 
-func (tsa *tiflashStoreAccess) GetFailoverPeriod(cliConfig *controller.CLIConfig) time.Duration {
-	return cliConfig.TiFlashFailoverPeriod
+func (tsa *tiflashStoreAccess) GetFailoverPeriod(tc *v1alpha1.TidbCluster, cliConfig *controller.CLIConfig) time.Duration {
+	return tc.Spec.TiFlash.GetFailoverPeriod(cliConfig.GetTiFlashFailoverPeriod())
+}
+
+func (tsa *tiflashStoreAccess) AutoRecoverFailover(tc *v1alpha1.TidbCluster) bool {
+	return tc.Spec.TiFlash.AutoRecoverFailover()
+}
+
+func (tsa *tiflashStoreAccess) GetNodeFailureGracePeriod(tc *v1alpha1.TidbCluster) time.Duration {
+	return tc.Spec.TiFlash.GetNodeFailureGracePeriod()
 }
 
 func (tsa *tiflashStoreAccess) GetMemberType() v1alpha1.MemberType {
@@ -35,7 +35,10 @@ func TestTiFlashStoreAccess(t *testing.T) {
 
 	storeAccess := &tiflashStoreAccess{}
 
-	g.Expect(storeAccess.GetFailoverPeriod(cliConfig)).To(Equal(6 * time.Minute))
+	g.Expect(storeAccess.GetFailoverPeriod(tc, cliConfig)).To(Equal(6 * time.Minute))
+	tc.Spec.TiFlash.Failover = &v1alpha1.StoreFailover{Failover: v1alpha1.Failover{Period: &metav1.Duration{Duration: 10 * time.Minute}}}
+	g.Expect(storeAccess.GetFailoverPeriod(tc, cliConfig)).To(Equal(10 * time.Minute))
+	g.Expect(storeAccess.AutoRecoverFailover(tc)).To(BeFalse())
 
 	g.Expect(storeAccess.GetMemberType()).To(Equal(v1alpha1.TiFlashMemberType))
 
@@ -58,17 +58,19 @@ type tiflashMemberManager struct {
 	suspender                suspender.Suspender
 	podVolumeModifier        volumes.PodVolumeModifier
 	statefulSetIsUpgradingFn func(corelisters.PodLister, pdapi.PDControlInterface, *apps.StatefulSet, *v1alpha1.TidbCluster) (bool, error)
+	tombstoneSyncTimes       *tombstoneStoresSyncTimes
 }
 
 // NewTiFlashMemberManager returns a *tiflashMemberManager
 func NewTiFlashMemberManager(deps *controller.Dependencies, tiflashFailover Failover, tiflashScaler Scaler, tiflashUpgrader Upgrader, spder suspender.Suspender, pvm volumes.PodVolumeModifier) manager.Manager {
 	m := tiflashMemberManager{
-		deps:              deps,
-		failover:          tiflashFailover,
-		scaler:            tiflashScaler,
-		upgrader:          tiflashUpgrader,
-		suspender:         spder,
-		podVolumeModifier: pvm,
+		deps:               deps,
+		failover:           tiflashFailover,
+		scaler:             tiflashScaler,
+		upgrader:           tiflashUpgrader,
+		suspender:          spder,
+		podVolumeModifier:  pvm,
+		tombstoneSyncTimes: newTombstoneStoresSyncTimes(),
 	}
 	m.statefulSetIsUpgradingFn = tiflashStatefulSetIsUpgrading
 	return &m
@@ -106,7 +108,11 @@ func (m *tiflashMemberManager) Sync(tc *v1alpha1.TidbCluster) error {
 		return err
 	}
 
-	return m.syncStatefulSet(tc)
+	if err = m.syncStatefulSet(tc); err != nil {
+		return err
+	}
+
+	return syncPodDisruptionBudget(m.deps, tc, tiflashPDBComponent(tc))
 }
 
 func (m *tiflashMemberManager) syncRecoveryForTiFlash(tc *v1alpha1.TidbCluster) error {
@@ -209,7 +215,7 @@ func (m *tiflashMemberManager) syncStatefulSet(tc *v1alpha1.TidbCluster) error {
 
 	// if TiFlash is scale from 0 and with previous StatefulSet, we delete the previous StatefulSet first
 	// to avoid some fileds (e.g storage request) reused and cause unexpected behavior (e.g scale down).
-	if oldSetTmp != nil && *oldSetTmp.Spec.Replicas == 0 && oldSetTmp.Status.UpdatedReplicas == 0 && tc.Spec.TiFlash.Replicas > 0 {
+	if oldSetTmp != nil && *oldSetTmp.Spec.Replicas == 0 && oldSetTmp.Status.UpdatedReplicas == 0 && tc.Spec.TiFlash.Replicas > 0 && !tc.IsStandby() {
 		if err := m.deps.StatefulSetControl.DeleteStatefulSet(tc, oldSetTmp); err != nil && !errors.IsNotFound(err) {
 			return fmt.Errorf("syncStatefulSet: fail to delete sts %s for cluster %s/%s, error: %s", controller.TiFlashMemberName(tcName), ns, tcName, err)
 		}
@@ -237,7 +243,7 @@ func (m *tiflashMemberManager) syncStatefulSet(tc *v1alpha1.TidbCluster) error {
 		m.failover.RemoveUndesiredFailures(tc)
 	}
 	if len(tc.Status.TiFlash.FailureStores) > 0 &&
-		(tc.Spec.TiFlash.RecoverFailover || tc.Status.TiFlash.FailoverUID == tc.Spec.TiFlash.GetRecoverByUID()) &&
+		(tc.Spec.TiFlash.RecoverFailover || tc.Spec.TiFlash.AutoRecoverFailover() || tc.Status.TiFlash.FailoverUID == tc.Spec.TiFlash.GetRecoverByUID()) &&
 		shouldRecover(tc, label.TiFlashLabelVal, m.deps.PodLister) {
 		m.failover.Recover(tc)
 	}
@@ -246,6 +252,9 @@ func (m *tiflashMemberManager) syncStatefulSet(tc *v1alpha1.TidbCluster) error {
 	if err != nil {
 		return err
 	}
+	if tc.IsStandby() {
+		return syncStandbyStatefulSet(m.deps, tc, tc.TiFlashStsDesiredOrdinals(true), newSet, oldSet)
+	}
 	if setNotExist {
 		if !tc.PDIsAvailable() {
 			klog.Infof("TidbCluster: %s/%s, waiting for PD cluster running", ns, tcName)
@@ -267,6 +276,12 @@ func (m *tiflashMemberManager) syncStatefulSet(tc *v1alpha1.TidbCluster) error {
 		return err
 	}
 
+	// Pod template changes made in the middle of an upgrade or a scale are held until it
+	// completes, unless the pending changes are applied explicitly. The replicas are not held.
+	if err := holdPendingChanges(tc, v1alpha1.TiFlashMemberType, newSet, oldSet); err != nil {
+		return err
+	}
+
 	// Scaling takes precedence over upgrading because:
 	// - if a tiflash fails in the upgrading, users may want to delete it or add
 	//   new replicas
@@ -276,7 +291,7 @@ func (m *tiflashMemberManager) syncStatefulSet(tc *v1alpha1.TidbCluster) error {
 		return err
 	}
 
-	if m.deps.CLIConfig.AutoFailover && tc.Spec.TiFlash.MaxFailoverCount != nil {
+	if m.deps.CLIConfig.IsAutoFailover() && tc.Spec.TiFlash.MaxFailoverCount != nil && !tc.IsQuotaExceeded() {
 		if tc.TiFlashAllPodsStarted() && !tc.TiFlashAllStoresReady() {
 			if err := m.failover.Failover(tc); err != nil {
 				return err
@@ -310,6 +325,7 @@ func (m *tiflashMemberManager) syncConfigMap(tc *v1alpha1.TidbCluster, set *apps
 	if err != nil {
 		return nil, err
 	}
+	controller.PropagateMetadata(newCm, tc.BaseTiFlashSpec().Metadata())
 	return m.deps.TypedControl.CreateOrUpdateConfigMap(tc, newCm)
 }
 
@@ -361,6 +377,7 @@ func getNewHeadlessService(tc *v1alpha1.TidbCluster) *corev1.Service {
 		},
 	}
 
+	controller.PropagateMetadata(svc, tc.BaseTiFlashSpec().Metadata())
 	if tc.Spec.PreferIPv6 {
 		SetServiceWhenPreferIPv6(svc)
 	}
@@ -591,6 +608,7 @@ func getNewStatefulSet(tc *v1alpha1.TidbCluster, cm *corev1.ConfigMap) (*apps.St
 		Resources:    controller.ContainerResource(tc.Spec.TiFlash.ResourceRequirements),
 	}
 	podSpec := baseTiFlashSpec.BuildPodSpec()
+	setZoneAffinity(&podSpec, tc, v1alpha1.TiFlashMemberType)
 	if baseTiFlashSpec.HostNetwork() {
 		env = append(env, corev1.EnvVar{
 			Name: "POD_NAME",
@@ -603,6 +621,7 @@ func getNewStatefulSet(tc *v1alpha1.TidbCluster, cm *corev1.ConfigMap) (*apps.St
 	}
 	tiflashContainer.Env = util.AppendEnv(env, baseTiFlashSpec.Env())
 	tiflashContainer.EnvFrom = baseTiFlashSpec.EnvFrom()
+	setLifecycle(&tiflashContainer, baseTiFlashSpec.Lifecycle())
 	podSpec.Volumes = append(vols, baseTiFlashSpec.AdditionalVolumes()...)
 	podSpec.SecurityContext = podSecurityContext
 	podSpec.InitContainers = append(initContainers, baseTiFlashSpec.InitContainers()...)
@@ -656,6 +675,10 @@ func getNewStatefulSet(tc *v1alpha1.TidbCluster, cm *corev1.ConfigMap) (*apps.St
 			UpdateStrategy:       updateStrategy,
 		},
 	}
+	propagateStatefulSetMetadata(tiflashset, baseTiFlashSpec.Metadata())
+	if err := ApplyPodTemplatePatches(&tiflashset.Spec.Template, baseTiFlashSpec.PodTemplatePatches()); err != nil {
+		return nil, fmt.Errorf("failed to apply pod template patches for TiFlash of [%s/%s], error: %v", ns, tcName, err)
+	}
 	return tiflashset, nil
 }
 
@@ -740,6 +763,11 @@ func (m *tiflashMemberManager) syncTidbClusterStatus(tc *v1alpha1.TidbCluster, s
 	peerStores := map[string]v1alpha1.TiKVStore{}
 	tombstoneStores := map[string]v1alpha1.TiKVStore{}
 
+	if tc.IsStandby() {
+		// the PD cluster of a warm standby cluster is not running
+		return nil
+	}
+
 	pdCli := controller.GetPDClient(m.deps.PDControl, tc)
 	// This only returns Up/Down/Offline stores
 	storesInfo, err := pdCli.GetStores()
@@ -768,6 +796,7 @@ func (m *tiflashMemberManager) syncTidbClusterStatus(tc *v1alpha1.TidbCluster, s
 		if exist && status.State == oldStore.State {
 			status.LastTransitionTime = oldStore.LastTransitionTime
 		}
+		syncStoreDrainStatus(status, oldStore.Drain, store, metav1.Now())
 
 		if store.Store != nil {
 			if pattern.Match([]byte(store.Store.Address)) {
@@ -778,28 +807,43 @@ func (m *tiflashMemberManager) syncTidbClusterStatus(tc *v1alpha1.TidbCluster, s
 		}
 	}
 
-	// this returns all tombstone stores
-	tombstoneStoresInfo, err := pdCli.GetTombStoneStores()
-	if err != nil {
-		tc.Status.TiFlash.Synced = false
-		klog.Warningf("Fail to GetTombStoneStores for TidbCluster %s/%s", tc.Namespace, tc.Name)
-		return err
-	}
-	for _, store := range tombstoneStoresInfo.Stores {
-		if store.Store != nil && !pattern.Match([]byte(store.Store.Address)) {
-			continue
+	// the tombstone stores are unbounded in a large cluster, so they are fetched only if some stores
+	// may have become tombstone since the last sync, or periodically to drop the removed ones
+	if m.tombstoneSyncTimes.needSync(tc, v1alpha1.TiFlashMemberType, tc.Status.TiFlash.Synced, previousStores, stores) {
+		// this returns all tombstone stores
+		tombstoneStoresInfo, err := pdCli.GetTombStoneStores()
+		if err != nil {
+			tc.Status.TiFlash.Synced = false
+			klog.Warningf("Fail to GetTombStoneStores for TidbCluster %s/%s", tc.Namespace, tc.Name)
+			return err
 		}
-		status := m.getTiFlashStore(store)
-		if status == nil {
-			continue
+		for _, store := range tombstoneStoresInfo.Stores {
+			if store.Store != nil && !pattern.Match([]byte(store.Store.Address)) {
+				continue
+			}
+			status := m.getTiFlashStore(store)
+			if status == nil {
+				continue
+			}
+
+			oldStore, exist := previousTombstoneStores[status.ID]
+			status.LastTransitionTime = metav1.Now()
+			if exist && status.State == oldStore.State {
+				status.LastTransitionTime = oldStore.LastTransitionTime
+			}
+			tombstoneStores[status.ID] = *status
 		}
+	} else {
+		tombstoneStores = previousTombstoneStores
+	}
 
-		oldStore, exist := previousTombstoneStores[status.ID]
-		status.LastTransitionTime = metav1.Now()
-		if exist && status.State == oldStore.State {
-			status.LastTransitionTime = oldStore.LastTransitionTime
+	if len(tombstoneStores) > maxTombstoneStoresInStatus {
+		inUse, err := storeIDsInUse(m.deps, tc, v1alpha1.TiFlashMemberType)
+		if err != nil {
+			tc.Status.TiFlash.Synced = false
+			return err
 		}
-		tombstoneStores[status.ID] = *status
+		tombstoneStores = boundTombstoneStores(tombstoneStores, maxTombstoneStoresInStatus, inUse)
 	}
 
 	tc.Status.TiFlash.Synced = true
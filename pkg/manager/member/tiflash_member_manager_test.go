@@ -1361,6 +1361,7 @@ func newFakeTiFlashMemberManager(tc *v1alpha1.TidbCluster) (
 		statefulSetIsUpgradingFn: tiflashStatefulSetIsUpgrading,
 		suspender:                suspender.NewFakeSuspender(),
 		podVolumeModifier:        &volumes.FakePodVolumeModifier{},
+		tombstoneSyncTimes:       newTombstoneStoresSyncTimes(),
 	}
 	pdClient := controller.NewFakePDClient(fakeDeps.PDControl.(*pdapi.FakePDControl), tc)
 	setControl := fakeDeps.StatefulSetControl.(*controller.FakeStatefulSetControl)
@@ -39,6 +39,11 @@ func NewTiFlashScaler(deps *controller.Dependencies) Scaler {
 }
 
 func (s *tiflashScaler) Scale(meta metav1.Object, oldSet *apps.StatefulSet, newSet *apps.StatefulSet) error {
+	if tc, ok := meta.(*v1alpha1.TidbCluster); ok {
+		if err := s.setZoneAwareDeleteSlots(tc, v1alpha1.TiFlashMemberType, oldSet, newSet); err != nil {
+			return err
+		}
+	}
 	scaling, _, _, _ := scaleOne(oldSet, newSet)
 	if scaling > 0 {
 		return s.ScaleOut(meta, oldSet, newSet)
@@ -145,6 +150,9 @@ func (s *tiflashScaler) scaleInOne(tc *v1alpha1.TidbCluster, ordinal int32) erro
 				return err
 			}
 			if state != v1alpha1.TiKVStateOffline {
+				if err := fenceStore(s.deps, tc, id, podName); err != nil {
+					return err
+				}
 				if err := controller.GetPDClient(s.deps.PDControl, tc).DeleteStore(id); err != nil {
 					klog.Errorf("tiflash scale in: failed to delete store %d, %v", id, err)
 					return err
@@ -598,6 +598,7 @@ func TestTiFlashScalerScaleIn(t *testing.T) {
 		podIndexer.Add(pod)
 
 		pdClient := controller.NewFakePDClient(pdControl, tc)
+		addFencingReactions(scaler.deps, pdClient, tc)
 
 		pdClient.AddReaction(pdapi.GetConfigActionType, func(action *pdapi.Action) (interface{}, error) {
 			var replicas uint64 = 3
@@ -950,6 +951,7 @@ func TestTiFlashScalerScaleInSimultaneously(t *testing.T) {
 		}
 
 		pdClient := controller.NewFakePDClient(pdControl, tc)
+		addFencingReactions(scaler.deps, pdClient, tc)
 
 		pdClient.AddReaction(pdapi.GetConfigActionType, func(action *pdapi.Action) (interface{}, error) {
 			var replicas uint64 = 3
@@ -1456,6 +1458,7 @@ func TestTiFlashScalerScaleInSimultaneouslyExtra(t *testing.T) {
 		createPodFn(4, "1")
 
 		pdClient := controller.NewFakePDClient(pdControl, tc)
+		addFencingReactions(scaler.deps, pdClient, tc)
 		pdClient.AddReaction(pdapi.GetConfigActionType, func(action *pdapi.Action) (interface{}, error) {
 			var replicas uint64 = 3
 			return &pdapi.PDConfigFromAPI{
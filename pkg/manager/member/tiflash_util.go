@@ -165,7 +165,7 @@ func getTiFlashConfigV2(tc *v1alpha1.TidbCluster) *v1alpha1.TiFlashConfigWraper
 		common.SetIfNil("http_port", int64(8123))
 
 		// flash
-		tidbStatusAddr := fmt.Sprintf("%s.%s.svc:10080", controller.TiDBMemberName(name), ns)
+		tidbStatusAddr := fmt.Sprintf("%s.%s.svc:%d", controller.TiDBMemberName(name), ns, tc.Spec.TiDB.GetStatusPort())
 		if tc.WithoutLocalTiDB() {
 			// TODO: support first cluster which don't contain TiDB when deploy cluster across mutli Kubernete clusters
 			if tc.Heterogeneous() {
@@ -268,6 +268,10 @@ func getTiFlashConfig(tc *v1alpha1.TidbCluster) *v1alpha1.TiFlashConfigWraper {
 		listenHost = listenHostForIPv6
 	}
 
+	if !noLocalTiDB {
+		// the default of flash.tidb_status_addr assumes the default status port of TiDB
+		config.Common.SetIfNil("flash.tidb_status_addr", fmt.Sprintf("%s.%s.svc:%d", controller.TiDBMemberName(tc.Name), tc.Namespace, tc.Spec.TiDB.GetStatusPort()))
+	}
 	setTiFlashConfigDefault(config, ref, tc.Name, tc.Namespace, tc.Spec.ClusterDomain, listenHost, noLocalPD, noLocalTiDB, acrossK8s)
 
 	// Note the config of tiflash use "_" by convention, others(proxy) use "-".
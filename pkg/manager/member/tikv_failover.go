@@ -35,8 +35,16 @@ type tikvStoreAccess struct{}
 
 var _ StoreAccess = (*tikvStoreAccess)(nil)
 
-func (tsa *tikvStoreAccess) GetFailoverPeriod(cliConfig *controller.CLIConfig) time.Duration {
-	return cliConfig.TiKVFailoverPeriod
+func (tsa *tikvStoreAccess) GetFailoverPeriod(tc *v1alpha1.TidbCluster, cliConfig *controller.CLIConfig) time.Duration {
+	return tc.Spec.TiKV.GetFailoverPeriod(cliConfig.GetTiKVFailoverPeriod())
+}
+
+func (tsa *tikvStoreAccess) AutoRecoverFailover(tc *v1alpha1.TidbCluster) bool {
+	return tc.Spec.TiKV.AutoRecoverFailover()
+}
+
+func (tsa *tikvStoreAccess) GetNodeFailureGracePeriod(tc *v1alpha1.TidbCluster) time.Duration {
+	return tc.Spec.TiKV.GetNodeFailureGracePeriod()
 }
 
 func (tsa *tikvStoreAccess) GetMemberType() v1alpha1.MemberType {
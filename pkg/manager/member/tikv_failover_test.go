@@ -360,7 +360,10 @@ func TestTiKVStoreAccess(t *testing.T) {
 
 	storeAccess := &tikvStoreAccess{}
 
-	g.Expect(storeAccess.GetFailoverPeriod(cliConfig)).To(Equal(6 * time.Minute))
+	g.Expect(storeAccess.GetFailoverPeriod(tc, cliConfig)).To(Equal(6 * time.Minute))
+	tc.Spec.TiKV.Failover = &v1alpha1.StoreFailover{Failover: v1alpha1.Failover{Period: &metav1.Duration{Duration: 10 * time.Minute}}}
+	g.Expect(storeAccess.GetFailoverPeriod(tc, cliConfig)).To(Equal(10 * time.Minute))
+	g.Expect(storeAccess.AutoRecoverFailover(tc)).To(BeFalse())
 
 	g.Expect(storeAccess.GetMemberType()).To(Equal(v1alpha1.TiKVMemberType))
 
@@ -471,6 +474,7 @@ func TestInvokeDeleteTiKVFailureStore(t *testing.T) {
 
 			fakePDControl := fakeDeps.PDControl.(*pdapi.FakePDControl)
 			pdClient := controller.NewFakePDClient(fakePDControl, tc)
+			addFencingReactions(fakeDeps, pdClient, tc)
 			if !test.storeDeleted {
 				pdClient.AddReaction(pdapi.DeleteStoreActionType, func(action *pdapi.Action) (interface{}, error) {
 					return nil, nil
@@ -537,6 +541,160 @@ func TestCheckAndRemoveTiKVFailurePVC(t *testing.T) {
 	}
 }
 
+func TestTiKVForceDeletePodOnFailedNode(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	type testcase struct {
+		name           string
+		gracePeriod    time.Duration
+		storeState     string
+		terminating    bool
+		nodeReady      corev1.ConditionStatus
+		nodeNotReadyAt time.Time
+		expectDeleted  bool
+	}
+
+	tests := []testcase{
+		{
+			name:           "grace period not set",
+			storeState:     v1alpha1.TiKVStateDown,
+			terminating:    true,
+			nodeReady:      corev1.ConditionUnknown,
+			nodeNotReadyAt: time.Now().Add(-time.Hour),
+			expectDeleted:  false,
+		},
+		{
+			name:           "store is up",
+			gracePeriod:    5 * time.Minute,
+			storeState:     v1alpha1.TiKVStateUp,
+			terminating:    true,
+			nodeReady:      corev1.ConditionUnknown,
+			nodeNotReadyAt: time.Now().Add(-time.Hour),
+			expectDeleted:  false,
+		},
+		{
+			name:           "pod is not terminating",
+			gracePeriod:    5 * time.Minute,
+			storeState:     v1alpha1.TiKVStateDown,
+			terminating:    false,
+			nodeReady:      corev1.ConditionUnknown,
+			nodeNotReadyAt: time.Now().Add(-time.Hour),
+			expectDeleted:  false,
+		},
+		{
+			name:           "node is ready",
+			gracePeriod:    5 * time.Minute,
+			storeState:     v1alpha1.TiKVStateDown,
+			terminating:    true,
+			nodeReady:      corev1.ConditionTrue,
+			nodeNotReadyAt: time.Now().Add(-time.Hour),
+			expectDeleted:  false,
+		},
+		{
+			name:           "node is not ready within grace period",
+			gracePeriod:    5 * time.Minute,
+			storeState:     v1alpha1.TiKVStateDown,
+			terminating:    true,
+			nodeReady:      corev1.ConditionUnknown,
+			nodeNotReadyAt: time.Now().Add(-time.Minute),
+			expectDeleted:  false,
+		},
+		{
+			name:           "node is not ready beyond grace period",
+			gracePeriod:    5 * time.Minute,
+			storeState:     v1alpha1.TiKVStateDown,
+			terminating:    true,
+			nodeReady:      corev1.ConditionUnknown,
+			nodeNotReadyAt: time.Now().Add(-time.Hour),
+			expectDeleted:  true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tc := newTidbClusterForPD()
+			if test.gracePeriod > 0 {
+				tc.Spec.TiKV.Failover = &v1alpha1.StoreFailover{NodeFailureGracePeriod: &metav1.Duration{Duration: test.gracePeriod}}
+			}
+			tc.Status.TiKV.Stores = map[string]v1alpha1.TiKVStore{
+				"1": {
+					ID:                 "1",
+					PodName:            ordinalPodName(v1alpha1.TiKVMemberType, tc.GetName(), 1),
+					State:              test.storeState,
+					LastTransitionTime: metav1.NewTime(time.Now().Add(-time.Hour)),
+				},
+			}
+
+			fakeDeps, _, podIndexer, nodeIndexer := newFakeDependenciesForFailover(true)
+			pod := newPodForFailover(tc, v1alpha1.TiKVMemberType, 1)
+			pod.Spec.NodeName = testNode1Name
+			if test.terminating {
+				pod.DeletionTimestamp = &metav1.Time{Time: time.Now().Add(-time.Hour)}
+			}
+			podIndexer.Add(pod)
+			nodeIndexer.Add(&corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{Name: testNode1Name},
+				Status: corev1.NodeStatus{Conditions: []corev1.NodeCondition{
+					{Type: corev1.NodeReady, Status: test.nodeReady, LastTransitionTime: metav1.NewTime(test.nodeNotReadyAt)},
+				}},
+			})
+
+			storeAccess := tikvStoreAccess{}
+			tikvFailover := &commonStoreFailover{
+				storeAccess: &storeAccess,
+				deps:        fakeDeps,
+				failureRecovery: commonStatefulFailureRecovery{
+					deps:                fakeDeps,
+					failureObjectAccess: &failureStoreAccess{storeAccess: &storeAccess},
+				},
+			}
+			err := tikvFailover.forceDeletePodOnFailedNode(tc)
+			_, exists, _ := podIndexer.Get(pod)
+			if test.expectDeleted {
+				g.Expect(controller.IsIgnoreError(err)).To(BeTrue())
+				g.Expect(exists).To(BeFalse())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(exists).To(BeTrue())
+			}
+		})
+	}
+}
+
+func TestTiKVAutoRecoverFailureStores(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	fakeDeps, _, _, _ := newFakeDependenciesForFailover(false)
+	storeAccess := tikvStoreAccess{}
+	tikvFailover := &commonStoreFailover{storeAccess: &storeAccess, deps: fakeDeps}
+
+	// the failure store is kept by default until the recovery is requested
+	tc := newTidbClusterWithTiKVFailureMember(true, false, false)
+	tc.Status.TiKV.FailoverUID = "failover-uid"
+	tc.Status.TiKV.Stores["1"] = v1alpha1.TiKVStore{ID: "1", State: v1alpha1.TiKVStateUp, LastTransitionTime: metav1.Now()}
+	tikvFailover.RemoveUndesiredFailures(tc)
+	g.Expect(tc.Status.TiKV.FailureStores).To(HaveKey("1"))
+
+	// the failure store is kept while the original store is still down
+	tc = newTidbClusterWithTiKVFailureMember(true, false, false)
+	tc.Spec.TiKV.Failover = &v1alpha1.StoreFailover{Failover: v1alpha1.Failover{RecoverPolicy: v1alpha1.FailoverRecoverPolicyAuto}}
+	tikvFailover.RemoveUndesiredFailures(tc)
+	g.Expect(tc.Status.TiKV.FailureStores).To(HaveKey("1"))
+
+	// the failure store is removed once the original store is up again
+	tc.Status.TiKV.FailoverUID = "failover-uid"
+	tc.Status.TiKV.Stores["1"] = v1alpha1.TiKVStore{ID: "1", State: v1alpha1.TiKVStateUp, LastTransitionTime: metav1.Now()}
+	tikvFailover.RemoveUndesiredFailures(tc)
+	g.Expect(tc.Status.TiKV.FailureStores).To(BeEmpty())
+	g.Expect(tc.Status.TiKV.FailoverUID).To(BeEmpty())
+
+	// the failure store on a down host is recovered after the store is recreated
+	tc = newTidbClusterWithTiKVFailureMember(true, true, false)
+	tc.Spec.TiKV.Failover = &v1alpha1.StoreFailover{Failover: v1alpha1.Failover{RecoverPolicy: v1alpha1.FailoverRecoverPolicyAuto}}
+	tc.Status.TiKV.Stores["1"] = v1alpha1.TiKVStore{ID: "1", State: v1alpha1.TiKVStateUp, LastTransitionTime: metav1.Now()}
+	tikvFailover.RemoveUndesiredFailures(tc)
+	g.Expect(tc.Status.TiKV.FailureStores).To(HaveKey("1"))
+}
+
 func newTidbClusterWithTiKVFailureMember(hasFailureStore, hostDown, storeDeleted bool) *v1alpha1.TidbCluster {
 	tc := newTidbClusterForPD()
 	tc.Spec.TiKV.MaxFailoverCount = pointer.Int32Ptr(int32(2))
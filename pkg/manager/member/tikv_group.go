@@ -0,0 +1,223 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	mngerutils "github.com/pingcap/tidb-operator/pkg/manager/utils"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/klog/v2"
+)
+
+// tikvGroupServerLabelKey is the server label of the TiKV stores of a group, the value is the group name
+const tikvGroupServerLabelKey = "tikv-group"
+
+// tikvGroupClusterName returns the name of the heterogeneous TidbCluster of a TiKV group
+func tikvGroupClusterName(tcName, group string) string {
+	return fmt.Sprintf("%s-tikv-%s", tcName, group)
+}
+
+// syncTiKVGroups creates, updates and deletes the heterogeneous TidbClusters of spec.tikv.groups, and
+// records their status in the status of TiKV. A group removed from the spec is scaled in to 0 first
+// so that the regions are migrated, and its TidbCluster is deleted after all the TiKV pods are gone.
+func (m *tikvMemberManager) syncTiKVGroups(tc *v1alpha1.TidbCluster) error {
+	ns := tc.GetNamespace()
+	tcName := tc.GetName()
+
+	selector := labels.SelectorFromSet(labels.Set{label.BaseTCLabelKey: tcName})
+	requirement, err := labels.NewRequirement(label.TiKVGroupLabelKey, selection.Exists, nil)
+	if err != nil {
+		return err
+	}
+	selector = selector.Add(*requirement)
+	children, err := m.deps.TiDBClusterLister.TidbClusters(ns).List(selector)
+	if err != nil {
+		return fmt.Errorf("failed to list tikv groups of cluster %s/%s: %v", ns, tcName, err)
+	}
+	if len(tc.Spec.TiKV.Groups) == 0 && len(children) == 0 {
+		tc.Status.TiKV.Groups = nil
+		return nil
+	}
+
+	existing := make(map[string]*v1alpha1.TidbCluster, len(children))
+	for _, child := range children {
+		existing[child.Labels[label.TiKVGroupLabelKey]] = child
+	}
+
+	var errs []error
+	status := make(map[string]v1alpha1.TiKVGroupStatus, len(tc.Spec.TiKV.Groups))
+	for i := range tc.Spec.TiKV.Groups {
+		group := &tc.Spec.TiKV.Groups[i]
+		child := existing[group.Name]
+		delete(existing, group.Name)
+
+		desired, err := newTiKVGroupCluster(tc, group)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if child == nil {
+			if _, err := m.deps.Clientset.PingcapV1alpha1().TidbClusters(ns).Create(context.TODO(), desired, metav1.CreateOptions{}); err != nil {
+				errs = append(errs, fmt.Errorf("failed to create tikv group %s of cluster %s/%s: %v", group.Name, ns, tcName, err))
+				continue
+			}
+			klog.Infof("tikv group %s of cluster %s/%s created as %s", group.Name, ns, tcName, desired.Name)
+			child = desired
+		} else if child.Annotations[label.AnnTiKVGroupSpecHashKey] != desired.Annotations[label.AnnTiKVGroupSpecHashKey] {
+			updated := child.DeepCopy()
+			updated.Spec = desired.Spec
+			if updated.Annotations == nil {
+				updated.Annotations = map[string]string{}
+			}
+			updated.Annotations[label.AnnTiKVGroupSpecHashKey] = desired.Annotations[label.AnnTiKVGroupSpecHashKey]
+			if _, err := m.deps.Clientset.PingcapV1alpha1().TidbClusters(ns).Update(context.TODO(), updated, metav1.UpdateOptions{}); err != nil {
+				errs = append(errs, fmt.Errorf("failed to update tikv group %s of cluster %s/%s: %v", group.Name, ns, tcName, err))
+			} else {
+				klog.Infof("tikv group %s of cluster %s/%s updated", group.Name, ns, tcName)
+			}
+		}
+		status[group.Name] = newTiKVGroupStatus(child, group.Replicas, false)
+	}
+
+	// the groups removed from the spec
+	for name, child := range existing {
+		status[name] = newTiKVGroupStatus(child, 0, true)
+		if err := m.gracefullyDeleteTiKVGroup(child); err != nil {
+			errs = append(errs, fmt.Errorf("failed to delete tikv group %s of cluster %s/%s: %v", name, ns, tcName, err))
+		}
+	}
+
+	tc.Status.TiKV.Groups = status
+	return utilerrors.NewAggregate(errs)
+}
+
+// gracefullyDeleteTiKVGroup scales in the TiKV of the TidbCluster of a removed group and deletes the
+// TidbCluster after all the TiKV pods are deleted.
+func (m *tikvMemberManager) gracefullyDeleteTiKVGroup(child *v1alpha1.TidbCluster) error {
+	ns := child.GetNamespace()
+	if child.Spec.TiKV != nil {
+		if child.Spec.TiKV.Replicas != 0 {
+			cloned := child.DeepCopy()
+			cloned.Spec.TiKV.Replicas = 0
+			_, err := m.deps.Clientset.PingcapV1alpha1().TidbClusters(ns).Update(context.TODO(), cloned, metav1.UpdateOptions{})
+			return err
+		}
+		if child.Status.TiKV.StatefulSet != nil && child.Status.TiKV.StatefulSet.Replicas != 0 {
+			// still scaling in
+			return nil
+		}
+	}
+
+	klog.Infof("tikv group %s/%s is scaled in, delete it", ns, child.GetName())
+	return m.deps.Clientset.PingcapV1alpha1().TidbClusters(ns).Delete(context.TODO(), child.GetName(), metav1.DeleteOptions{})
+}
+
+// newTiKVGroupCluster returns the heterogeneous TidbCluster of a TiKV group, its TiKV spec is the TiKV spec of
+// the cluster overridden by the group.
+func newTiKVGroupCluster(tc *v1alpha1.TidbCluster, group *v1alpha1.TiKVGroupSpec) (*v1alpha1.TidbCluster, error) {
+	child := &v1alpha1.TidbCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      tikvGroupClusterName(tc.Name, group.Name),
+			Namespace: tc.Namespace,
+			Labels: map[string]string{
+				label.BaseTCLabelKey:    tc.Name,
+				label.TiKVGroupLabelKey: group.Name,
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				controller.GetOwnerRef(tc),
+			},
+		},
+		Spec: *tc.Spec.DeepCopy(),
+	}
+
+	child.Spec.Cluster = &v1alpha1.TidbClusterRef{
+		Namespace: tc.Namespace,
+		Name:      tc.Name,
+	}
+	child.Spec.PD = nil
+	child.Spec.TiDB = nil
+	child.Spec.TiFlash = nil
+	child.Spec.TiCDC = nil
+	child.Spec.Pump = nil
+	child.Spec.TiProxy = nil
+
+	tikv := child.Spec.TiKV
+	tikv.Groups = nil
+	tikv.Replicas = group.Replicas
+	if group.Resources != nil {
+		tikv.ResourceRequirements = *group.Resources.DeepCopy()
+	}
+	if group.StorageClassName != nil {
+		tikv.StorageClassName = group.StorageClassName
+	}
+	if len(group.NodeSelector) > 0 {
+		nodeSelector := map[string]string{}
+		for k, v := range tikv.NodeSelector {
+			nodeSelector[k] = v
+		}
+		for k, v := range group.NodeSelector {
+			nodeSelector[k] = v
+		}
+		tikv.NodeSelector = nodeSelector
+	}
+	if len(group.Tolerations) > 0 {
+		// the tolerations of TiKV override the cluster-level ones if non-empty
+		tolerations := tikv.Tolerations
+		if len(tolerations) == 0 {
+			tolerations = child.Spec.Tolerations
+		}
+		tikv.Tolerations = append(append([]corev1.Toleration{}, tolerations...), group.Tolerations...)
+	}
+
+	// Initialize Config
+	if tikv.Config == nil {
+		tikv.Config = v1alpha1.NewTiKVConfig()
+	}
+	for k, v := range group.ServerLabels {
+		tikv.Config.Set("server.labels."+k, v)
+	}
+	tikv.Config.Set("server.labels."+tikvGroupServerLabelKey, group.Name)
+
+	hash, err := mngerutils.Sha256Sum(child.Spec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash the spec of tikv group %s of cluster %s/%s: %v", group.Name, tc.Namespace, tc.Name, err)
+	}
+	child.Annotations = map[string]string{label.AnnTiKVGroupSpecHashKey: hash}
+	return child, nil
+}
+
+func newTiKVGroupStatus(child *v1alpha1.TidbCluster, replicas int32, deleting bool) v1alpha1.TiKVGroupStatus {
+	var upStores int32
+	for _, store := range child.Status.TiKV.Stores {
+		if store.State == v1alpha1.TiKVStateUp {
+			upStores++
+		}
+	}
+	return v1alpha1.TiKVGroupStatus{
+		Cluster:  child.Name,
+		Replicas: replicas,
+		UpStores: upStores,
+		Phase:    child.Status.TiKV.Phase,
+		Deleting: deleting,
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	apps "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSyncTiKVGroups(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbClusterForTiKV()
+	tc.Spec.TiKV.NodeSelector = map[string]string{"dedicated": "tikv"}
+	tc.Spec.TiKV.Groups = []v1alpha1.TiKVGroupSpec{
+		{
+			Name:         "mem",
+			Replicas:     2,
+			NodeSelector: map[string]string{"pool": "mem"},
+			Tolerations:  []corev1.Toleration{{Key: "pool", Operator: corev1.TolerationOpEqual, Value: "mem"}},
+			ServerLabels: map[string]string{"disk": "ssd"},
+		},
+	}
+	tmm, _, _, _, _, _ := newFakeTiKVMemberManager(tc)
+	cli := tmm.deps.Clientset.PingcapV1alpha1().TidbClusters(tc.Namespace)
+	tcIndexer := tmm.deps.InformerFactory.Pingcap().V1alpha1().TidbClusters().Informer().GetIndexer()
+	getChild := func() *v1alpha1.TidbCluster {
+		child, err := cli.Get(context.TODO(), "test-tikv-mem", metav1.GetOptions{})
+		g.Expect(err).To(Succeed())
+		g.Expect(tcIndexer.Update(child)).To(Succeed())
+		return child
+	}
+
+	// the heterogeneous cluster of the group is created
+	g.Expect(tmm.syncTiKVGroups(tc)).To(Succeed())
+	child := getChild()
+	g.Expect(child.Labels).To(Equal(map[string]string{label.BaseTCLabelKey: "test", label.TiKVGroupLabelKey: "mem"}))
+	g.Expect(child.Spec.Cluster).To(Equal(&v1alpha1.TidbClusterRef{Namespace: tc.Namespace, Name: tc.Name}))
+	g.Expect(child.Spec.PD).To(BeNil())
+	g.Expect(child.Spec.TiKV.Groups).To(BeNil())
+	g.Expect(child.Spec.TiKV.Replicas).To(Equal(int32(2)))
+	g.Expect(child.Spec.TiKV.StorageClassName).To(Equal(tc.Spec.TiKV.StorageClassName))
+	g.Expect(child.Spec.TiKV.NodeSelector).To(Equal(map[string]string{"dedicated": "tikv", "pool": "mem"}))
+	g.Expect(child.Spec.TiKV.Tolerations).To(HaveLen(1))
+	g.Expect(child.Spec.TiKV.Config.Get("server.labels.tikv-group").MustString()).To(Equal("mem"))
+	g.Expect(child.Spec.TiKV.Config.Get("server.labels.disk").MustString()).To(Equal("ssd"))
+	g.Expect(tc.Spec.TiKV.Config.Get("server.labels")).To(BeNil())
+	g.Expect(tc.Status.TiKV.Groups).To(Equal(map[string]v1alpha1.TiKVGroupStatus{
+		"mem": {Cluster: "test-tikv-mem", Replicas: 2},
+	}))
+
+	// the group is updated
+	hash := child.Annotations[label.AnnTiKVGroupSpecHashKey]
+	tc.Spec.TiKV.Groups[0].Replicas = 3
+	g.Expect(tmm.syncTiKVGroups(tc)).To(Succeed())
+	child = getChild()
+	g.Expect(child.Spec.TiKV.Replicas).To(Equal(int32(3)))
+	g.Expect(child.Annotations[label.AnnTiKVGroupSpecHashKey]).NotTo(Equal(hash))
+
+	// the group is removed, it's scaled in before it's deleted
+	tc.Spec.TiKV.Groups = nil
+	g.Expect(tmm.syncTiKVGroups(tc)).To(Succeed())
+	child = getChild()
+	g.Expect(child.Spec.TiKV.Replicas).To(Equal(int32(0)))
+	g.Expect(tc.Status.TiKV.Groups["mem"].Deleting).To(BeTrue())
+
+	child.Status.TiKV.StatefulSet = &apps.StatefulSetStatus{Replicas: 1}
+	g.Expect(tcIndexer.Update(child)).To(Succeed())
+	g.Expect(tmm.syncTiKVGroups(tc)).To(Succeed())
+	_, err := cli.Get(context.TODO(), "test-tikv-mem", metav1.GetOptions{})
+	g.Expect(err).To(Succeed())
+
+	child.Status.TiKV.StatefulSet = nil
+	g.Expect(tcIndexer.Update(child)).To(Succeed())
+	g.Expect(tmm.syncTiKVGroups(tc)).To(Succeed())
+	_, err = cli.Get(context.TODO(), "test-tikv-mem", metav1.GetOptions{})
+	g.Expect(errors.IsNotFound(err)).To(BeTrue())
+}
@@ -0,0 +1,142 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"fmt"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/pdapi"
+	"k8s.io/apimachinery/pkg/labels"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/klog/v2"
+)
+
+const (
+	tikvIORateLimitMaxBytesPerSecKey = "storage.io-rate-limit.max-bytes-per-sec"
+	// tikvIORateLimitUnlimited is the default max-bytes-per-sec of TiKV, which means no limit
+	tikvIORateLimitUnlimited = "0MB"
+)
+
+// syncIORateLimit applies spec.tikv.ioRateLimit.maintenanceMaxBytesPerSec to the TiKV stores online
+// while a BR snapshot backup or restore of the cluster is running, and restores the configured
+// max-bytes-per-sec after all of them are finished.
+func (m *tikvMemberManager) syncIORateLimit(tc *v1alpha1.TidbCluster) error {
+	ns := tc.GetNamespace()
+	tcName := tc.GetName()
+
+	desired := ""
+	if rateLimit := tc.Spec.TiKV.IORateLimit; rateLimit != nil && rateLimit.MaintenanceMaxBytesPerSec != "" {
+		running, err := m.isBRJobRunning(tc)
+		if err != nil {
+			return err
+		}
+		if running {
+			desired = rateLimit.MaintenanceMaxBytesPerSec
+		}
+	}
+	if tc.Status.TiKV.IORateLimit == desired {
+		return nil
+	}
+
+	value := desired
+	if value == "" {
+		value = configuredIORateLimit(tc)
+	}
+	var errs []error
+	for _, store := range tc.Status.TiKV.Stores {
+		if store.State != v1alpha1.TiKVStateUp {
+			continue
+		}
+		tikvClient := m.deps.TiKVControl.GetTiKVPodClient(ns, tcName, store.PodName, tc.Spec.TiKV.GetStatusPort(), tc.IsTLSClusterEnabled(),
+			pdapi.TLSFromCluster(pdapi.Namespace(ns), tc.Spec.TLSCluster))
+		if err := tikvClient.UpdateConfig(map[string]string{tikvIORateLimitMaxBytesPerSecKey: value}); err != nil {
+			errs = append(errs, fmt.Errorf("failed to set io rate limit of tikv %s/%s to %s: %v", ns, store.PodName, value, err))
+		}
+	}
+	if len(errs) > 0 {
+		return utilerrors.NewAggregate(errs)
+	}
+	klog.Infof("set io rate limit of tikv of cluster %s/%s to %s", ns, tcName, value)
+	tc.Status.TiKV.IORateLimit = desired
+	return nil
+}
+
+// isBRJobRunning returns whether a BR snapshot backup or restore of the cluster is running
+func (m *tikvMemberManager) isBRJobRunning(tc *v1alpha1.TidbCluster) (bool, error) {
+	backups, err := m.deps.BackupLister.List(labels.Everything())
+	if err != nil {
+		return false, fmt.Errorf("failed to list backups: %v", err)
+	}
+	for _, backup := range backups {
+		if backup.Spec.Mode != v1alpha1.BackupModeSnapshot && backup.Spec.Mode != "" {
+			continue
+		}
+		if !isBRTargetOf(backup.Spec.BR, backup.Namespace, tc) {
+			continue
+		}
+		if v1alpha1.IsBackupComplete(backup) || v1alpha1.IsBackupFailed(backup) || v1alpha1.IsBackupInvalid(backup) {
+			continue
+		}
+		if v1alpha1.IsBackupScheduled(backup) || v1alpha1.IsBackupRunning(backup) {
+			return true, nil
+		}
+	}
+
+	restores, err := m.deps.RestoreLister.List(labels.Everything())
+	if err != nil {
+		return false, fmt.Errorf("failed to list restores: %v", err)
+	}
+	for _, restore := range restores {
+		if restore.Spec.Mode == v1alpha1.RestoreModeVolumeSnapshot {
+			continue
+		}
+		if !isBRTargetOf(restore.Spec.BR, restore.Namespace, tc) {
+			continue
+		}
+		if v1alpha1.IsRestoreComplete(restore) || v1alpha1.IsRestoreFailed(restore) || v1alpha1.IsRestoreInvalid(restore) {
+			continue
+		}
+		if v1alpha1.IsRestoreScheduled(restore) || v1alpha1.IsRestoreRunning(restore) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// isBRTargetOf returns whether the BR config targets the cluster, the cluster namespace defaults to the namespace of the CR
+func isBRTargetOf(br *v1alpha1.BRConfig, namespace string, tc *v1alpha1.TidbCluster) bool {
+	if br == nil || br.Cluster != tc.Name {
+		return false
+	}
+	if br.ClusterNamespace != "" {
+		namespace = br.ClusterNamespace
+	}
+	return namespace == tc.Namespace
+}
+
+// configuredIORateLimit returns the max-bytes-per-sec configured in the spec
+func configuredIORateLimit(tc *v1alpha1.TidbCluster) string {
+	if rateLimit := tc.Spec.TiKV.IORateLimit; rateLimit != nil && rateLimit.MaxBytesPerSec != "" {
+		return rateLimit.MaxBytesPerSec
+	}
+	if tc.Spec.TiKV.Config != nil {
+		if v := tc.Spec.TiKV.Config.Get(tikvIORateLimitMaxBytesPerSecKey); v != nil {
+			if s, err := v.AsString(); err == nil && s != "" {
+				return s
+			}
+		}
+	}
+	return tikvIORateLimitUnlimited
+}
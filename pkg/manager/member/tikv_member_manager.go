@@ -39,6 +39,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/uuid"
 	corelisters "k8s.io/client-go/listers/core/v1"
 	"k8s.io/klog/v2"
@@ -53,6 +54,18 @@ const (
 	tikvStoreLimitPattern = `%s-tikv-\d+\.%s-tikv-peer\.%s\.svc%s\:\d+`
 )
 
+// tikvSvcConfigs are the configs of the services of TiKV
+var tikvSvcConfigs = []SvcConfig{
+	{
+		Name:       "peer",
+		Port:       v1alpha1.DefaultTiKVServerPort,
+		PortFn:     func(tc *v1alpha1.TidbCluster) int32 { return tc.Spec.TiKV.GetServerPort() },
+		Headless:   true,
+		SvcLabel:   func(l label.Label) label.Label { return l.TiKV() },
+		MemberName: controller.TiKVPeerMemberName,
+	},
+}
+
 // tikvMemberManager implements manager.Manager.
 type tikvMemberManager struct {
 	deps                     *controller.Dependencies
@@ -62,17 +75,19 @@ type tikvMemberManager struct {
 	suspender                suspender.Suspender
 	podVolumeModifier        volumes.PodVolumeModifier
 	statefulSetIsUpgradingFn func(corelisters.PodLister, pdapi.PDControlInterface, *apps.StatefulSet, *v1alpha1.TidbCluster) (bool, error)
+	tombstoneSyncTimes       *tombstoneStoresSyncTimes
 }
 
 // NewTiKVMemberManager returns a *tikvMemberManager
 func NewTiKVMemberManager(deps *controller.Dependencies, failover Failover, scaler Scaler, upgrader TiKVUpgrader, spder suspender.Suspender, pvm volumes.PodVolumeModifier) manager.Manager {
 	m := &tikvMemberManager{
-		deps:              deps,
-		failover:          failover,
-		scaler:            scaler,
-		upgrader:          upgrader,
-		suspender:         spder,
-		podVolumeModifier: pvm,
+		deps:               deps,
+		failover:           failover,
+		scaler:             scaler,
+		upgrader:           upgrader,
+		suspender:          spder,
+		podVolumeModifier:  pvm,
+		tombstoneSyncTimes: newTombstoneStoresSyncTimes(),
 	}
 	m.statefulSetIsUpgradingFn = tikvStatefulSetIsUpgrading
 	return m
@@ -80,8 +95,10 @@ func NewTiKVMemberManager(deps *controller.Dependencies, failover Failover, scal
 
 // SvcConfig corresponds to a K8s service
 type SvcConfig struct {
-	Name       string
-	Port       int32
+	Name string
+	Port int32
+	// PortFn returns the port customized in the TidbCluster, Port is used if it's nil
+	PortFn     func(tc *v1alpha1.TidbCluster) int32
 	SvcLabel   func(label.Label) label.Label
 	MemberName func(clusterName string) string
 	Headless   bool
@@ -108,7 +125,7 @@ func (m *tikvMemberManager) Sync(tc *v1alpha1.TidbCluster) error {
 		return nil
 	}
 
-	if tc.Spec.PD != nil && !tc.PDIsAvailable() {
+	if tc.Spec.PD != nil && !tc.PDIsAvailable() && !tc.IsStandby() {
 		return controller.RequeueErrorf("TidbCluster: [%s/%s], waiting for PD cluster running", ns, tcName)
 	}
 
@@ -117,21 +134,24 @@ func (m *tikvMemberManager) Sync(tc *v1alpha1.TidbCluster) error {
 		return err
 	}
 
-	svcList := []SvcConfig{
-		{
-			Name:       "peer",
-			Port:       20160,
-			Headless:   true,
-			SvcLabel:   func(l label.Label) label.Label { return l.TiKV() },
-			MemberName: controller.TiKVPeerMemberName,
-		},
-	}
-	for _, svc := range svcList {
+	for _, svc := range tikvSvcConfigs {
 		if err := m.syncServiceForTidbCluster(tc, svc); err != nil {
 			return err
 		}
 	}
-	return m.syncStatefulSetForTidbCluster(tc)
+	if err := syncDataDirPreCheckStatus(m.deps, tc, v1alpha1.TiKVMemberType, tc.Spec.TiKV.DataDirPreCheck); err != nil {
+		return err
+	}
+	if err := m.syncStatefulSetForTidbCluster(tc); err != nil {
+		return err
+	}
+	if err := syncPodDisruptionBudget(m.deps, tc, tikvPDBComponent(tc)); err != nil {
+		return err
+	}
+	if err := m.syncIORateLimit(tc); err != nil {
+		return err
+	}
+	return m.syncTiKVGroups(tc)
 }
 
 func (m *tikvMemberManager) checkRecoveryForTidbCluster(tc *v1alpha1.TidbCluster) error {
@@ -220,7 +240,7 @@ func (m *tikvMemberManager) syncStatefulSetForTidbCluster(tc *v1alpha1.TidbClust
 		m.failover.RemoveUndesiredFailures(tc)
 	}
 	if len(tc.Status.TiKV.FailureStores) > 0 &&
-		(tc.Spec.TiKV.RecoverFailover || tc.Status.TiKV.FailoverUID == tc.Spec.TiKV.GetRecoverByUID()) &&
+		(tc.Spec.TiKV.RecoverFailover || tc.Spec.TiKV.AutoRecoverFailover() || tc.Status.TiKV.FailoverUID == tc.Spec.TiKV.GetRecoverByUID()) &&
 		shouldRecover(tc, label.TiKVLabelVal, m.deps.PodLister) {
 		m.failover.Recover(tc)
 	}
@@ -229,6 +249,9 @@ func (m *tikvMemberManager) syncStatefulSetForTidbCluster(tc *v1alpha1.TidbClust
 	if err != nil {
 		return err
 	}
+	if tc.IsStandby() {
+		return syncStandbyStatefulSet(m.deps, tc, tc.TiKVStsDesiredOrdinals(true), newSet, oldSet)
+	}
 	if setNotExist {
 		err = mngerutils.SetStatefulSetLastAppliedConfigAnnotation(newSet)
 		if err != nil {
@@ -246,6 +269,12 @@ func (m *tikvMemberManager) syncStatefulSetForTidbCluster(tc *v1alpha1.TidbClust
 		return err
 	}
 
+	// Pod template changes made in the middle of an upgrade or a scale are held until it
+	// completes, unless the pending changes are applied explicitly. The replicas are not held.
+	if err := holdPendingChanges(tc, v1alpha1.TiKVMemberType, newSet, oldSet); err != nil {
+		return err
+	}
+
 	// Scaling takes precedence over upgrading because:
 	// - if a store fails in the upgrading, users may want to delete it or add
 	//   new replicas
@@ -258,7 +287,7 @@ func (m *tikvMemberManager) syncStatefulSetForTidbCluster(tc *v1alpha1.TidbClust
 	// Perform failover logic if necessary. Note that this will only update
 	// TidbCluster status. The actual scaling performs in next sync loop (if a
 	// new replica needs to be added).
-	if m.deps.CLIConfig.AutoFailover && tc.Spec.TiKV.MaxFailoverCount != nil {
+	if m.deps.CLIConfig.IsAutoFailover() && tc.Spec.TiKV.MaxFailoverCount != nil && !tc.IsQuotaExceeded() {
 		if tc.TiKVAllPodsStarted() && !tc.TiKVAllStoresReady() {
 			if err := m.failover.Failover(tc); err != nil {
 				return err
@@ -296,6 +325,7 @@ func (m *tikvMemberManager) syncTiKVConfigMap(tc *v1alpha1.TidbCluster, set *app
 	if err != nil {
 		return nil, err
 	}
+	controller.PropagateMetadata(newCm, tc.BaseTiKVSpec().Metadata())
 	return m.deps.TypedControl.CreateOrUpdateConfigMap(tc, newCm)
 }
 
@@ -304,6 +334,10 @@ func getNewServiceForTidbCluster(tc *v1alpha1.TidbCluster, svcConfig SvcConfig)
 	tcName := tc.Name
 	instanceName := tc.GetInstanceName()
 	svcName := svcConfig.MemberName(tcName)
+	port := svcConfig.Port
+	if svcConfig.PortFn != nil {
+		port = svcConfig.PortFn(tc)
+	}
 	svcSelector := svcConfig.SvcLabel(label.New().Instance(instanceName))
 	svcLabel := svcSelector.Copy()
 	if svcConfig.Headless {
@@ -323,8 +357,8 @@ func getNewServiceForTidbCluster(tc *v1alpha1.TidbCluster, svcConfig SvcConfig)
 			Ports: []corev1.ServicePort{
 				{
 					Name:       svcConfig.Name,
-					Port:       svcConfig.Port,
-					TargetPort: intstr.FromInt(int(svcConfig.Port)),
+					Port:       port,
+					TargetPort: intstr.FromInt(int(port)),
 					Protocol:   corev1.ProtocolTCP,
 				},
 			},
@@ -338,6 +372,7 @@ func getNewServiceForTidbCluster(tc *v1alpha1.TidbCluster, svcConfig SvcConfig)
 		svc.Spec.Type = controller.GetServiceType(tc.Spec.Services, v1alpha1.TiKVMemberType.String())
 	}
 
+	controller.PropagateMetadata(&svc, tc.BaseTiKVSpec().Metadata())
 	if tc.Spec.PreferIPv6 {
 		SetServiceWhenPreferIPv6(&svc)
 	}
@@ -457,6 +492,9 @@ func getNewTiKVSetForTidbCluster(tc *v1alpha1.TidbCluster, cm *corev1.ConfigMap)
 	if len(initContainers) > 0 {
 		podSecurityContext.Sysctls = []corev1.Sysctl{}
 	}
+	if c := buildDataDirPreCheckContainer(tc, tc.Spec.TiKV.DataDirPreCheck, tikvDataVol, controller.ContainerResource(tc.Spec.TiKV.ResourceRequirements)); c != nil {
+		initContainers = append(initContainers, *c)
+	}
 
 	storageRequest, err := controller.ParseStorageRequest(tc.Spec.TiKV.Requests)
 	if err != nil {
@@ -466,8 +504,11 @@ func getNewTiKVSetForTidbCluster(tc *v1alpha1.TidbCluster, cm *corev1.ConfigMap)
 	stsLabels := labelTiKV(tc)
 	podLabels := util.CombineStringMap(stsLabels.Labels(), baseTiKVSpec.Labels())
 	setName := controller.TiKVMemberName(tcName)
-	podAnnotations := util.CombineStringMap(baseTiKVSpec.Annotations(), controller.AnnProm(20180, "/metrics"))
+	podAnnotations := util.CombineStringMap(baseTiKVSpec.Annotations(), controller.AnnProm(tc.Spec.TiKV.GetStatusPort(), "/metrics"))
 	stsAnnotations := getStsAnnotations(tc.Annotations, label.TiKVLabelVal)
+	if err := addOfflineOrdinals(stsAnnotations, tc.OfflineOrdinals(v1alpha1.TiKVMemberType)); err != nil {
+		return nil, err
+	}
 	capacity := controller.TiKVCapacity(tc.Spec.TiKV.Limits)
 	headlessSvcName := controller.TiKVPeerMemberName(tcName)
 
@@ -613,7 +654,7 @@ func getNewTiKVSetForTidbCluster(tc *v1alpha1.TidbCluster, cm *corev1.ConfigMap)
 		Ports: []corev1.ContainerPort{
 			{
 				Name:          "server",
-				ContainerPort: int32(20160),
+				ContainerPort: tc.Spec.TiKV.GetServerPort(),
 				Protocol:      corev1.ProtocolTCP,
 			},
 		},
@@ -637,10 +678,12 @@ func getNewTiKVSetForTidbCluster(tc *v1alpha1.TidbCluster, cm *corev1.ConfigMap)
 		}
 	}
 
-	if tc.Spec.TiKV.EnableNamedStatusPort {
+	// the customized status port is declared with hostNetwork so that it's reserved on the node by the scheduler
+	// as well, it's not declared for the existing clusters with the default ports to avoid rolling updates
+	if tc.Spec.TiKV.EnableNamedStatusPort || (baseTiKVSpec.HostNetwork() && tc.Spec.TiKV.Ports != nil) {
 		kvStatusPort := corev1.ContainerPort{
 			Name:          "status",
-			ContainerPort: int32(20180),
+			ContainerPort: tc.Spec.TiKV.GetStatusPort(),
 			Protocol:      corev1.ProtocolTCP,
 		}
 
@@ -648,6 +691,7 @@ func getNewTiKVSetForTidbCluster(tc *v1alpha1.TidbCluster, cm *corev1.ConfigMap)
 	}
 
 	podSpec := baseTiKVSpec.BuildPodSpec()
+	setZoneAffinity(&podSpec, tc, v1alpha1.TiKVMemberType)
 	if baseTiKVSpec.HostNetwork() {
 		env = append(env, corev1.EnvVar{
 			Name: "POD_NAME",
@@ -660,6 +704,11 @@ func getNewTiKVSetForTidbCluster(tc *v1alpha1.TidbCluster, cm *corev1.ConfigMap)
 	}
 	tikvContainer.Env = util.AppendEnv(env, baseTiKVSpec.Env())
 	tikvContainer.EnvFrom = baseTiKVSpec.EnvFrom()
+	setLifecycle(&tikvContainer, baseTiKVSpec.Lifecycle())
+	// the preStop hook relies on the discovery service, which is not deployed without PD
+	if tc.Spec.TiKV.PreStopHook != nil && (tc.Spec.PD != nil || tc.AcrossK8s()) {
+		setPreStopHook(&podSpec, &tikvContainer, tc.TiKVPreStopHookTimeout(), buildDiscoveryPreStopScript(tc))
+	}
 	containers = append(containers, tikvContainer)
 
 	podSpec.Volumes = append(vols, baseTiKVSpec.AdditionalVolumes()...)
@@ -714,6 +763,10 @@ func getNewTiKVSetForTidbCluster(tc *v1alpha1.TidbCluster, cm *corev1.ConfigMap)
 	}
 
 	tikvset.Spec.VolumeClaimTemplates = append(tikvset.Spec.VolumeClaimTemplates, additionalPVCs...)
+	propagateStatefulSetMetadata(tikvset, baseTiKVSpec.Metadata())
+	if err := ApplyPodTemplatePatches(&tikvset.Spec.Template, baseTiKVSpec.PodTemplatePatches()); err != nil {
+		return nil, fmt.Errorf("failed to apply pod template patches for TiKV of [%s/%s], error: %v", ns, tcName, err)
+	}
 	return tikvset, nil
 }
 
@@ -825,6 +878,11 @@ func (m *tikvMemberManager) syncTiKVClusterStatus(tc *v1alpha1.TidbCluster, set
 	peerStores := map[string]v1alpha1.TiKVStore{}
 	tombstoneStores := map[string]v1alpha1.TiKVStore{}
 
+	if tc.IsStandby() {
+		// the PD cluster of a warm standby cluster is not running
+		return nil
+	}
+
 	pdCli := controller.GetPDClient(m.deps.PDControl, tc)
 	// This only returns Up/Down/Offline stores
 	storesInfo, err := pdCli.GetStores()
@@ -862,6 +920,7 @@ func (m *tikvMemberManager) syncTiKVClusterStatus(tc *v1alpha1.TidbCluster, set
 		if oldStore.LeaderCountBeforeUpgrade != nil {
 			status.LeaderCountBeforeUpgrade = oldStore.LeaderCountBeforeUpgrade
 		}
+		syncStoreDrainStatus(status, oldStore.Drain, store, metav1.Now())
 
 		// In theory, the external tikv can join the cluster, and the operator would only manage the internal tikv.
 		// So we check the store owner to make sure it.
@@ -874,27 +933,42 @@ func (m *tikvMemberManager) syncTiKVClusterStatus(tc *v1alpha1.TidbCluster, set
 		}
 	}
 
-	// this returns all tombstone stores
-	tombstoneStoresInfo, err := pdCli.GetTombStoneStores()
-	if err != nil {
-		tc.Status.TiKV.Synced = false
-		return err
-	}
-	for _, store := range tombstoneStoresInfo.Stores {
-		if store.Store != nil && !pattern.Match([]byte(store.Store.Address)) {
-			continue
+	// the tombstone stores are unbounded in a large cluster, so they are fetched only if some stores
+	// may have become tombstone since the last sync, or periodically to drop the removed ones
+	if m.tombstoneSyncTimes.needSync(tc, v1alpha1.TiKVMemberType, tc.Status.TiKV.Synced, previousStores, stores) {
+		// this returns all tombstone stores
+		tombstoneStoresInfo, err := pdCli.GetTombStoneStores()
+		if err != nil {
+			tc.Status.TiKV.Synced = false
+			return err
 		}
-		status := getTiKVStore(store)
-		if status == nil {
-			continue
+		for _, store := range tombstoneStoresInfo.Stores {
+			if store.Store != nil && !pattern.Match([]byte(store.Store.Address)) {
+				continue
+			}
+			status := getTiKVStore(store)
+			if status == nil {
+				continue
+			}
+
+			oldStore, exist := previousTombstoneStores[status.ID]
+			status.LastTransitionTime = metav1.Now()
+			if exist && status.State == oldStore.State {
+				status.LastTransitionTime = oldStore.LastTransitionTime
+			}
+			tombstoneStores[status.ID] = *status
 		}
+	} else {
+		tombstoneStores = previousTombstoneStores
+	}
 
-		oldStore, exist := previousTombstoneStores[status.ID]
-		status.LastTransitionTime = metav1.Now()
-		if exist && status.State == oldStore.State {
-			status.LastTransitionTime = oldStore.LastTransitionTime
+	if len(tombstoneStores) > maxTombstoneStoresInStatus {
+		inUse, err := storeIDsInUse(m.deps, tc, v1alpha1.TiKVMemberType)
+		if err != nil {
+			tc.Status.TiKV.Synced = false
+			return err
 		}
-		tombstoneStores[status.ID] = *status
+		tombstoneStores = boundTombstoneStores(tombstoneStores, maxTombstoneStoresInStatus, inUse)
 	}
 
 	tc.Status.TiKV.Synced = true
@@ -934,11 +1008,6 @@ func getTiKVStore(store *pdapi.StoreInfo) *v1alpha1.TiKVStore {
 }
 
 func (m *tikvMemberManager) setStoreLabelsForTiKV(tc *v1alpha1.TidbCluster) (int, error) {
-	if m.deps.NodeLister == nil {
-		klog.V(4).Infof("Node lister is unavailable, skip setting store labels for TiKV of TiDB cluster %s/%s. This may be caused by no relevant permissions", tc.Namespace, tc.Name)
-		return 0, nil
-	}
-
 	ns := tc.GetNamespace()
 	// for unit test
 	setCount := 0
@@ -959,6 +1028,24 @@ func (m *tikvMemberManager) setStoreLabelsForTiKV(tc *v1alpha1.TidbCluster) (int
 		return setCount, err
 	}
 
+	// the replicas of the regions are spread across the zones declared in spec.topologyZones if it's required explicitly
+	if zoneLabel := zoneLocationLabel(tc); zoneLabel != "" && !sets.NewString(config.Replication.LocationLabels...).Has(zoneLabel) {
+		locationLabels := append([]string{zoneLabel}, config.Replication.LocationLabels...)
+		if err := pdCli.UpdateReplicationConfig(pdapi.PDReplicationConfig{LocationLabels: locationLabels}); err != nil {
+			return setCount, fmt.Errorf("failed to set location labels %v, error: %v", locationLabels, err)
+		}
+		klog.Infof("set location labels %v of cluster %s/%s", locationLabels, ns, tc.GetName())
+		config.Replication.LocationLabels = locationLabels
+	}
+
+	// the config is reused to verify the replica placement, so that it's not fetched again in every sync
+	verifyReplicaPlacement(tc, storesInfo, config.Replication)
+
+	if m.deps.NodeLister == nil {
+		klog.V(4).Infof("Node lister is unavailable, skip setting store labels for TiKV of TiDB cluster %s/%s. This may be caused by no relevant permissions", tc.Namespace, tc.Name)
+		return setCount, nil
+	}
+
 	storeLabels := append(config.Replication.LocationLabels, tc.Spec.TiKV.StoreLabels...)
 	if storeLabels == nil {
 		return setCount, nil
@@ -1054,7 +1141,7 @@ func tikvStatefulSetIsUpgrading(podLister corelisters.PodLister, pdControl pdapi
 func buildTiKVReadinessProbHandler(tc *v1alpha1.TidbCluster) corev1.Handler {
 	return corev1.Handler{
 		TCPSocket: &corev1.TCPSocketAction{
-			Port: intstr.FromInt(20160),
+			Port: intstr.FromInt(int(tc.Spec.TiKV.GetServerPort())),
 		},
 	}
 }
@@ -29,6 +29,7 @@ import (
 	"github.com/pingcap/tidb-operator/pkg/manager/suspender"
 	"github.com/pingcap/tidb-operator/pkg/manager/volumes"
 	"github.com/pingcap/tidb-operator/pkg/pdapi"
+	"github.com/pingcap/tidb-operator/pkg/tikvapi"
 	apps "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/api/core/v1"
@@ -1537,6 +1538,46 @@ func TestTiKVMemberManagerSyncTidbClusterStatus(t *testing.T) {
 				g.Expect(tc.Status.TiKV.Synced).To(BeTrue())
 			},
 		},
+		{
+			name: "skip fetching tombstone stores if no store disappears",
+			updateTC: func(tc *v1alpha1.TidbCluster) {
+				tc.Status.TiKV.Synced = true
+				tc.Status.TiKV.Stores = map[string]v1alpha1.TiKVStore{
+					"333": {ID: "333", LastTransitionTime: now, State: v1alpha1.TiKVStateUp},
+				}
+				tc.Status.TiKV.TombstoneStores = map[string]v1alpha1.TiKVStore{
+					"332": {ID: "332", LastTransitionTime: now, State: v1alpha1.TiKVStateTombstone},
+				}
+			},
+			upgradingFn: func(lister corelisters.PodLister, controlInterface pdapi.PDControlInterface, set *apps.StatefulSet, cluster *v1alpha1.TidbCluster) (bool, error) {
+				return false, nil
+			},
+			errWhenGetStores: false,
+			storeInfo: &pdapi.StoresInfo{
+				Stores: []*pdapi.StoreInfo{
+					{
+						Store: &pdapi.MetaStore{
+							Store: &metapb.Store{
+								Id:      333,
+								Address: fmt.Sprintf("%s-tikv-1.%s-tikv-peer.%s.svc:20160", "test", "test", "default"),
+							},
+							StateName: "Up",
+						},
+						Status: &pdapi.StoreStatus{
+							LastHeartbeatTS: time.Now(),
+						},
+					},
+				},
+			},
+			// the tombstone stores are not fetched
+			errWhenGetTombstoneStores: true,
+			errExpectFn:               errExpectNil,
+			tcExpectFn: func(g *GomegaWithT, tc *v1alpha1.TidbCluster) {
+				g.Expect(len(tc.Status.TiKV.Stores)).To(Equal(1))
+				g.Expect(tc.Status.TiKV.TombstoneStores).To(HaveKey("332"))
+				g.Expect(tc.Status.TiKV.Synced).To(BeTrue())
+			},
+		},
 		{
 			name: "get TiKV Store and PeerStores without TiFlash Stores",
 			updateTC: func(tc *v1alpha1.TidbCluster) {
@@ -1622,6 +1663,7 @@ func newFakeTiKVMemberManager(tc *v1alpha1.TidbCluster) (
 		statefulSetIsUpgradingFn: tikvStatefulSetIsUpgrading,
 		suspender:                suspender.NewFakeSuspender(),
 		podVolumeModifier:        &volumes.FakePodVolumeModifier{},
+		tombstoneSyncTimes:       newTombstoneStoresSyncTimes(),
 	}
 	setControl := fakeDeps.StatefulSetControl.(*controller.FakeStatefulSetControl)
 	svcControl := fakeDeps.ServiceControl.(*controller.FakeServiceControl)
@@ -2702,3 +2744,111 @@ func mustTiKVConfig(x interface{}) *v1alpha1.TiKVConfigWraper {
 
 	return c
 }
+
+func TestSyncIORateLimit(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := &v1alpha1.TidbCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: metav1.NamespaceDefault},
+		Spec: v1alpha1.TidbClusterSpec{
+			TiKV: &v1alpha1.TiKVSpec{
+				IORateLimit: &v1alpha1.TiKVIORateLimit{MaxBytesPerSec: "200MiB", MaintenanceMaxBytesPerSec: "50MiB"},
+			},
+		},
+		Status: v1alpha1.TidbClusterStatus{
+			TiKV: v1alpha1.TiKVStatus{
+				Stores: map[string]v1alpha1.TiKVStore{
+					"1": {ID: "1", PodName: "test-tikv-0", State: v1alpha1.TiKVStateUp},
+					"2": {ID: "2", PodName: "test-tikv-1", State: v1alpha1.TiKVStateDown},
+				},
+			},
+		},
+	}
+	tmm, _, _, _, _, _ := newFakeTiKVMemberManager(tc)
+	tikvClient := tikvapi.NewFakeTiKVClient()
+	var applied []map[string]string
+	tikvClient.AddReaction(tikvapi.UpdateConfigActionType, func(action *tikvapi.Action) (interface{}, error) {
+		applied = append(applied, action.Config)
+		return nil, nil
+	})
+	tikvControl := tmm.deps.TiKVControl.(*tikvapi.FakeTiKVControl)
+	tikvControl.SetTiKVPodClient(tc.Namespace, tc.Name, "test-tikv-0", tikvClient)
+
+	// no backup is running
+	g.Expect(tmm.syncIORateLimit(tc)).Should(Succeed())
+	g.Expect(applied).Should(BeEmpty())
+	g.Expect(tc.Status.TiKV.IORateLimit).Should(BeEmpty())
+
+	backup := &v1alpha1.Backup{
+		ObjectMeta: metav1.ObjectMeta{Name: "backup", Namespace: "other"},
+		Spec: v1alpha1.BackupSpec{
+			BR: &v1alpha1.BRConfig{Cluster: tc.Name, ClusterNamespace: tc.Namespace},
+		},
+		Status: v1alpha1.BackupStatus{
+			Conditions: []v1alpha1.BackupCondition{{Type: v1alpha1.BackupRunning, Status: corev1.ConditionTrue}},
+		},
+	}
+	backupIndexer := tmm.deps.InformerFactory.Pingcap().V1alpha1().Backups().Informer().GetIndexer()
+	g.Expect(backupIndexer.Add(backup)).Should(Succeed())
+
+	// the maintenance limit is applied to the up stores during the backup
+	g.Expect(tmm.syncIORateLimit(tc)).Should(Succeed())
+	g.Expect(applied).Should(Equal([]map[string]string{{tikvIORateLimitMaxBytesPerSecKey: "50MiB"}}))
+	g.Expect(tc.Status.TiKV.IORateLimit).Should(Equal("50MiB"))
+	g.Expect(tmm.syncIORateLimit(tc)).Should(Succeed())
+	g.Expect(applied).Should(HaveLen(1))
+
+	// the configured limit is restored after the backup completes
+	backup = backup.DeepCopy()
+	backup.Status.Conditions = []v1alpha1.BackupCondition{{Type: v1alpha1.BackupComplete, Status: corev1.ConditionTrue}}
+	g.Expect(backupIndexer.Update(backup)).Should(Succeed())
+	g.Expect(tmm.syncIORateLimit(tc)).Should(Succeed())
+	g.Expect(applied).Should(HaveLen(2))
+	g.Expect(applied[1]).Should(Equal(map[string]string{tikvIORateLimitMaxBytesPerSecKey: "200MiB"}))
+	g.Expect(tc.Status.TiKV.IORateLimit).Should(BeEmpty())
+}
+
+func TestTiKVCustomPorts(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbClusterForTiKV()
+	tc.Spec.TiKV.HostNetwork = pointer.BoolPtr(true)
+	tc.Spec.TiKV.Ports = &v1alpha1.TiKVPorts{
+		Server: pointer.Int32Ptr(30160),
+		Status: pointer.Int32Ptr(30180),
+	}
+	tc.Spec.DNSPolicy = corev1.DNSClusterFirst
+
+	sts, err := getNewTiKVSetForTidbCluster(tc, nil)
+	g.Expect(err).Should(Succeed())
+	podSpec := sts.Spec.Template.Spec
+	g.Expect(podSpec.HostNetwork).Should(BeTrue())
+	g.Expect(podSpec.DNSPolicy).Should(Equal(corev1.DNSClusterFirstWithHostNet))
+	var container corev1.Container
+	for _, c := range podSpec.Containers {
+		if c.Name == v1alpha1.TiKVMemberType.String() {
+			container = c
+		}
+	}
+	// the status port is declared to be reserved on the node with hostNetwork
+	g.Expect(container.Ports).Should(ConsistOf(
+		corev1.ContainerPort{Name: "server", ContainerPort: 30160, Protocol: corev1.ProtocolTCP},
+		corev1.ContainerPort{Name: "status", ContainerPort: 30180, Protocol: corev1.ProtocolTCP},
+	))
+	g.Expect(sts.Spec.Template.Annotations).Should(HaveKeyWithValue("prometheus.io/port", "30180"))
+
+	svc := getNewServiceForTidbCluster(tc, tikvSvcConfigs[0])
+	g.Expect(svc.Spec.Ports[0].Port).Should(Equal(int32(30160)))
+	g.Expect(svc.Spec.Ports[0].TargetPort).Should(Equal(intstr.FromInt(30160)))
+
+	// the status port isn't declared for the default ports to avoid rolling updates
+	tc.Spec.TiKV.Ports = nil
+	sts, err = getNewTiKVSetForTidbCluster(tc, nil)
+	g.Expect(err).Should(Succeed())
+	for _, c := range sts.Spec.Template.Spec.Containers {
+		if c.Name == v1alpha1.TiKVMemberType.String() {
+			g.Expect(c.Ports).Should(HaveLen(1))
+			g.Expect(c.Ports[0].ContainerPort).Should(Equal(v1alpha1.DefaultTiKVServerPort))
+		}
+	}
+}
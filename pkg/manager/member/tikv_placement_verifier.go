@@ -0,0 +1,115 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/pdapi"
+	utiltidbcluster "github.com/pingcap/tidb-operator/pkg/util/tidbcluster"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const defaultMaxReplicas uint64 = 3
+
+// replicaPlacement is the distribution of region replicas over the values
+// of the top level location label (usually the zone).
+type replicaPlacement struct {
+	labelKey    string
+	maxReplicas uint64
+	total       int
+	zones       map[string]int
+}
+
+// newReplicaPlacement aggregates the region count of every store by the value
+// of the first location label. Stores without the label are ignored because
+// PD can not place replicas on them in a topology aware way either.
+func newReplicaPlacement(stores []*pdapi.StoreInfo, replication *pdapi.PDReplicationConfig) *replicaPlacement {
+	if replication == nil || len(replication.LocationLabels) == 0 {
+		return nil
+	}
+	p := &replicaPlacement{
+		labelKey:    replication.LocationLabels[0],
+		maxReplicas: defaultMaxReplicas,
+		zones:       map[string]int{},
+	}
+	if replication.MaxReplicas != nil {
+		p.maxReplicas = *replication.MaxReplicas
+	}
+	for _, store := range stores {
+		if store.Store == nil || store.Status == nil {
+			continue
+		}
+		if store.Store.StateName == v1alpha1.TiKVStateTombstone {
+			continue
+		}
+		for _, l := range store.Store.Labels {
+			if l.GetKey() == p.labelKey && l.GetValue() != "" {
+				p.zones[l.GetValue()] += store.Status.RegionCount
+				p.total += store.Status.RegionCount
+				break
+			}
+		}
+	}
+	return p
+}
+
+// concentratedZones returns the zones that hold so many replicas that at
+// least one region must have a majority of its peers inside that zone.
+//
+// Every region has maxReplicas peers, so the sum of all region counts is
+// regions*maxReplicas. If a single zone holds more than regions*(quorum-1)
+// replicas, by the pigeonhole principle some region has at least quorum
+// peers there, and losing that zone makes the region unavailable.
+func (p *replicaPlacement) concentratedZones() []string {
+	if p == nil || p.total == 0 || p.maxReplicas <= 1 {
+		return nil
+	}
+	quorum := p.maxReplicas/2 + 1
+	var zones []string
+	for zone, count := range p.zones {
+		if uint64(count)*p.maxReplicas > uint64(p.total)*(quorum-1) {
+			zones = append(zones, zone)
+		}
+	}
+	sort.Strings(zones)
+	return zones
+}
+
+// verifyReplicaPlacement cross-checks the actual region replica distribution
+// reported by PD against the location labels of the replication config and
+// sets the Degraded condition of the TidbCluster accordingly. It never fails
+// the sync loop because the placement is only informational.
+func verifyReplicaPlacement(tc *v1alpha1.TidbCluster, storesInfo *pdapi.StoresInfo, replication *pdapi.PDReplicationConfig) {
+	placement := newReplicaPlacement(storesInfo.Stores, replication)
+	if placement == nil || placement.total == 0 {
+		return
+	}
+
+	status := corev1.ConditionFalse
+	reason := utiltidbcluster.ReplicaPlacementHealthy
+	message := fmt.Sprintf("Region replicas are spread across %d %s(s)", len(placement.zones), placement.labelKey)
+	if zones := placement.concentratedZones(); len(zones) > 0 {
+		status = corev1.ConditionTrue
+		reason = utiltidbcluster.ReplicasConcentrated
+		message = fmt.Sprintf("Region replicas concentrate in %s %s, losing it breaks the quorum of some regions",
+			placement.labelKey, strings.Join(zones, ","))
+	}
+	cond := utiltidbcluster.NewTidbClusterCondition(v1alpha1.TidbClusterDegraded, status, reason, message)
+	utiltidbcluster.SetTidbClusterCondition(&tc.Status, *cond)
+}
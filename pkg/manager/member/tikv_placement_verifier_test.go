@@ -0,0 +1,151 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"fmt"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/pingcap/kvproto/pkg/metapb"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/pdapi"
+	utiltidbcluster "github.com/pingcap/tidb-operator/pkg/util/tidbcluster"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func newPlacementStore(id uint64, zone string, regions int) *pdapi.StoreInfo {
+	var labels []*metapb.StoreLabel
+	if zone != "" {
+		labels = append(labels, &metapb.StoreLabel{Key: "zone", Value: zone})
+	}
+	return &pdapi.StoreInfo{
+		Store: &pdapi.MetaStore{
+			Store: &metapb.Store{
+				Id:      id,
+				Address: fmt.Sprintf("store-%d", id),
+				Labels:  labels,
+			},
+			StateName: v1alpha1.TiKVStateUp,
+		},
+		Status: &pdapi.StoreStatus{RegionCount: regions},
+	}
+}
+
+func TestReplicaPlacementConcentratedZones(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	fiveReplicas := uint64(5)
+	tests := []struct {
+		name        string
+		stores      []*pdapi.StoreInfo
+		replication *pdapi.PDReplicationConfig
+		expect      []string
+	}{
+		{
+			name: "no location labels",
+			stores: []*pdapi.StoreInfo{
+				newPlacementStore(1, "a", 100),
+			},
+			replication: &pdapi.PDReplicationConfig{},
+			expect:      nil,
+		},
+		{
+			name: "evenly spread",
+			stores: []*pdapi.StoreInfo{
+				newPlacementStore(1, "a", 100),
+				newPlacementStore(2, "b", 100),
+				newPlacementStore(3, "c", 100),
+			},
+			replication: &pdapi.PDReplicationConfig{LocationLabels: []string{"zone"}},
+			expect:      nil,
+		},
+		{
+			name: "all replicas in one zone",
+			stores: []*pdapi.StoreInfo{
+				newPlacementStore(1, "a", 100),
+				newPlacementStore(2, "a", 100),
+				newPlacementStore(3, "a", 100),
+			},
+			replication: &pdapi.PDReplicationConfig{LocationLabels: []string{"zone"}},
+			expect:      []string{"a"},
+		},
+		{
+			name: "two zones for three replicas",
+			stores: []*pdapi.StoreInfo{
+				newPlacementStore(1, "a", 100),
+				newPlacementStore(2, "a", 100),
+				newPlacementStore(3, "b", 100),
+			},
+			replication: &pdapi.PDReplicationConfig{LocationLabels: []string{"zone"}},
+			expect:      []string{"a"},
+		},
+		{
+			name: "five replicas in three zones",
+			stores: []*pdapi.StoreInfo{
+				newPlacementStore(1, "a", 100),
+				newPlacementStore(2, "a", 100),
+				newPlacementStore(3, "b", 100),
+				newPlacementStore(4, "b", 100),
+				newPlacementStore(5, "c", 100),
+			},
+			replication: &pdapi.PDReplicationConfig{LocationLabels: []string{"zone"}, MaxReplicas: &fiveReplicas},
+			expect:      nil,
+		},
+		{
+			name: "unlabeled stores are ignored",
+			stores: []*pdapi.StoreInfo{
+				newPlacementStore(1, "a", 100),
+				newPlacementStore(2, "b", 100),
+				newPlacementStore(3, "c", 100),
+				newPlacementStore(4, "", 300),
+			},
+			replication: &pdapi.PDReplicationConfig{LocationLabels: []string{"zone"}},
+			expect:      nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newReplicaPlacement(tt.stores, tt.replication)
+			g.Expect(p.concentratedZones()).To(Equal(tt.expect))
+		})
+	}
+}
+
+func TestVerifyReplicaPlacement(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbClusterForPD()
+	replication := &pdapi.PDReplicationConfig{LocationLabels: []string{"zone"}}
+
+	storesInfo := &pdapi.StoresInfo{Stores: []*pdapi.StoreInfo{
+		newPlacementStore(1, "a", 100),
+		newPlacementStore(2, "a", 100),
+		newPlacementStore(3, "b", 100),
+	}}
+	verifyReplicaPlacement(tc, storesInfo, replication)
+	cond := utiltidbcluster.GetTidbClusterCondition(tc.Status, v1alpha1.TidbClusterDegraded)
+	g.Expect(cond).NotTo(BeNil())
+	g.Expect(cond.Status).To(Equal(corev1.ConditionTrue))
+	g.Expect(cond.Reason).To(Equal(utiltidbcluster.ReplicasConcentrated))
+
+	storesInfo.Stores = append(storesInfo.Stores, newPlacementStore(4, "c", 0))
+	storesInfo.Stores[1].Status.RegionCount = 0
+	storesInfo.Stores[3].Status.RegionCount = 100
+	verifyReplicaPlacement(tc, storesInfo, replication)
+	cond = utiltidbcluster.GetTidbClusterCondition(tc.Status, v1alpha1.TidbClusterDegraded)
+	g.Expect(cond.Status).To(Equal(corev1.ConditionFalse))
+	g.Expect(cond.Reason).To(Equal(utiltidbcluster.ReplicaPlacementHealthy))
+}
@@ -42,12 +42,20 @@ func NewTiKVScaler(deps *controller.Dependencies) *tikvScaler {
 }
 
 func (s *tikvScaler) Scale(meta metav1.Object, oldSet *apps.StatefulSet, newSet *apps.StatefulSet) error {
+	if tc, ok := meta.(*v1alpha1.TidbCluster); ok {
+		if err := s.setZoneAwareDeleteSlots(tc, v1alpha1.TiKVMemberType, oldSet, newSet); err != nil {
+			return err
+		}
+	}
 	scaling, _, _, _ := scaleOne(oldSet, newSet)
 	if scaling > 0 {
 		return s.ScaleOut(meta, oldSet, newSet)
 	} else if scaling < 0 {
 		return s.ScaleIn(meta, oldSet, newSet)
 	}
+	if tc, ok := meta.(*v1alpha1.TidbCluster); ok {
+		clearPendingScaleIns(tc, v1alpha1.TiKVMemberType)
+	}
 	// we only sync auto scaler annotations when we are finishing syncing scaling
 	return nil
 }
@@ -196,6 +204,13 @@ func (s *tikvScaler) scaleInOne(tc *v1alpha1.TidbCluster, skipPreCheck bool, upT
 				return deletedUpStore, err
 			}
 			if state != v1alpha1.TiKVStateOffline {
+				// the store is deleted only after the scale-in is approved if the external approval is required
+				if err := approveScaleIn(s.deps, tc, v1alpha1.TiKVMemberType, podName, tc.Spec.TiKV.Replicas); err != nil {
+					return deletedUpStore, err
+				}
+				if err := fenceStore(s.deps, tc, id, podName); err != nil {
+					return deletedUpStore, err
+				}
 				if err := controller.GetPDClient(s.deps.PDControl, tc).DeleteStore(id); err != nil {
 					klog.Errorf("tikvScaler.ScaleIn: failed to delete store %d, %v", id, err)
 					return deletedUpStore, err
@@ -243,6 +258,7 @@ func (s *tikvScaler) scaleInOne(tc *v1alpha1.TidbCluster, skipPreCheck bool, upT
 		if err = endEvictLeaderbyStoreID(s.deps, tc, id); err != nil {
 			return deletedUpStore, err
 		}
+		clearPendingScaleIn(tc, podName)
 		return deletedUpStore, nil
 	}
 
@@ -617,6 +617,7 @@ func TestTiKVScalerScaleIn(t *testing.T) {
 		podIndexer.Add(pod)
 
 		pdClient := controller.NewFakePDClient(pdControl, tc)
+		addFencingReactions(scaler.deps, pdClient, tc)
 
 		pdClient.AddReaction(pdapi.GetConfigActionType, func(action *pdapi.Action) (interface{}, error) {
 			var replicas uint64 = 3
@@ -1049,6 +1050,7 @@ func TestTiKVScalerScaleInSimultaneously(t *testing.T) {
 		}
 
 		pdClient := controller.NewFakePDClient(pdControl, tc)
+		addFencingReactions(scaler.deps, pdClient, tc)
 
 		pdClient.AddReaction(pdapi.GetConfigActionType, func(action *pdapi.Action) (interface{}, error) {
 			var replicas uint64 = 3
@@ -1713,6 +1715,7 @@ func TestTiKVScalerScaleInSimultaneouslyExtra(t *testing.T) {
 		createPodFn(4, "1")
 
 		pdClient := controller.NewFakePDClient(pdControl, tc)
+		addFencingReactions(scaler.deps, pdClient, tc)
 		pdClient.AddReaction(pdapi.GetConfigActionType, func(action *pdapi.Action) (interface{}, error) {
 			var replicas uint64 = 3
 			return &pdapi.PDConfigFromAPI{
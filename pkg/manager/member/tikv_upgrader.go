@@ -29,6 +29,7 @@ import (
 
 	apps "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	errorutils "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/klog/v2"
@@ -88,6 +89,10 @@ func (u *tikvUpgrader) Upgrade(meta metav1.Object, oldSet *apps.StatefulSet, new
 
 	tc, _ := meta.(*v1alpha1.TidbCluster)
 
+	if held, err := holdCanaryUpgrade(tc.TiKVCanaryUpgrade(), &status.CanaryUpgrade, oldSet, newSet); err != nil || held {
+		return err
+	}
+
 	// upgrade tikv without evicting leader when only one tikv is exist
 	// NOTE: If `TiKVStatus.Synced`` is false, it's acceptable to use old record about peer stores
 	if *oldSet.Spec.Replicas < 2 && len(tc.Status.TiKV.PeerStores) == 0 {
@@ -107,6 +112,7 @@ func (u *tikvUpgrader) Upgrade(meta metav1.Object, oldSet *apps.StatefulSet, new
 	}
 
 	if status.StatefulSet.UpdateRevision == status.StatefulSet.CurrentRevision {
+		status.CanaryUpgrade = nil
 		return nil
 	}
 
@@ -133,6 +139,16 @@ func (u *tikvUpgrader) Upgrade(meta metav1.Object, oldSet *apps.StatefulSet, new
 
 	mngerutils.SetUpgradePartition(newSet, *oldSet.Spec.UpdateStrategy.RollingUpdate.Partition)
 	podOrdinals := helper.GetPodOrdinals(*oldSet.Spec.Replicas, oldSet).List()
+	if maxConcurrency := tc.TiKVMaxUpgradeConcurrency(); maxConcurrency > 1 {
+		return u.upgradeInBatches(tc, oldSet, newSet, podOrdinals, minReadySeconds, maxConcurrency)
+	}
+	var canary *canaryUpgrader
+	if spec := tc.TiKVCanaryUpgrade(); spec != nil {
+		canary = u.newCanaryUpgrader(tc, spec)
+		if done, err := canary.evaluate(oldSet, newSet, podOrdinals); err != nil || done {
+			return err
+		}
+	}
 	for _i := len(podOrdinals) - 1; _i >= 0; _i-- {
 		i := podOrdinals[_i]
 		store := getStoreByOrdinal(meta.GetName(), *status, i)
@@ -181,12 +197,190 @@ func (u *tikvUpgrader) Upgrade(meta metav1.Object, oldSet *apps.StatefulSet, new
 			return controller.RequeueErrorf("cluster is unstable: %s", unstableReason)
 		}
 
+		if canary != nil {
+			// all the pods above are upgraded and healthy
+			if err := canary.soak(oldSet, len(podOrdinals)-1-_i); err != nil {
+				return err
+			}
+		}
 		return u.upgradeTiKVPod(tc, i, newSet)
 	}
 
 	return nil
 }
 
+func (u *tikvUpgrader) newCanaryUpgrader(tc *v1alpha1.TidbCluster, spec *v1alpha1.CanaryUpgrade) *canaryUpgrader {
+	return &canaryUpgrader{
+		deps:       u.deps,
+		tc:         tc,
+		memberType: v1alpha1.TiKVMemberType,
+		spec:       spec,
+		status:     &tc.Status.TiKV.CanaryUpgrade,
+		setStatus:  tc.Status.TiKV.StatefulSet,
+		podName: func(ordinal int32) string {
+			return TikvPodName(tc.GetName(), ordinal)
+		},
+		healthy: func(ordinal int32) bool {
+			store := getStoreByOrdinal(tc.GetName(), tc.Status.TiKV, ordinal)
+			return store != nil && store.State == v1alpha1.TiKVStateUp
+		},
+	}
+}
+
+// upgradeInBatches upgrades at most maxConcurrency TiKV pods at once in the descending order of the ordinals.
+// The next batch is started only after the pods of the previous one are up and their leaders are transferred back.
+// The StatefulSet controller recreates only one pod at a time, so the outdated pods above the partition are deleted
+// by the upgrader to be recreated with the update revision together.
+func (u *tikvUpgrader) upgradeInBatches(tc *v1alpha1.TidbCluster, oldSet *apps.StatefulSet, newSet *apps.StatefulSet,
+	podOrdinals []int32, minReadySeconds int, maxConcurrency int) error {
+	ns := tc.GetNamespace()
+	tcName := tc.GetName()
+	partition := *oldSet.Spec.UpdateStrategy.RollingUpdate.Partition
+
+	unavailable := 0
+	for _i := len(podOrdinals) - 1; _i >= 0; _i-- {
+		i := podOrdinals[_i]
+		store := getStoreByOrdinal(tcName, tc.Status.TiKV, i)
+		if store == nil {
+			mngerutils.SetUpgradePartition(newSet, i)
+			continue
+		}
+		podName := TikvPodName(tcName, i)
+		pod, err := u.deps.PodLister.Pods(ns).Get(podName)
+		if errors.IsNotFound(err) {
+			// the pod deleted by the batch upgrade is not recreated yet
+			unavailable++
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("tikvUpgrader.upgradeInBatches: failed to get pods %s for cluster %s/%s, error: %s", podName, ns, tcName, err)
+		}
+		revision, exist := pod.Labels[apps.ControllerRevisionHashLabelKey]
+		if !exist {
+			return controller.RequeueErrorf("tidbcluster: [%s/%s]'s tikv pod: [%s] has no label: %s", ns, tcName, podName, apps.ControllerRevisionHashLabelKey)
+		}
+
+		if revision == tc.Status.TiKV.StatefulSet.UpdateRevision {
+			if !podutil.IsPodAvailable(pod, int32(minReadySeconds), metav1.Now()) || store.State != v1alpha1.TiKVStateUp {
+				unavailable++
+				continue
+			}
+			done, err := u.endEvictLeaderAfterUpgrade(tc, pod)
+			if err != nil {
+				return err
+			}
+			if !done {
+				unavailable++
+			}
+			continue
+		}
+
+		if i >= partition {
+			// the pod is in the batch being upgraded
+			unavailable++
+			if pod.DeletionTimestamp == nil {
+				klog.Infof("tidbcluster: [%s/%s] delete tikv pod %s to upgrade it in batch", ns, tcName, podName)
+				reason, detail := mngerutils.UpgradeRestartReason(pod, &newSet.Spec.Template)
+				mngerutils.RecordPodRestart(tc, pod, reason, detail)
+				if err := u.deps.PodControl.DeletePod(tc, pod); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		if unavailable > 0 {
+			break
+		}
+		// verify that cluster is stable before each batch upgrade
+		if unstableReason := u.isClusterStable(tc); unstableReason != "" {
+			return controller.RequeueErrorf("cluster is unstable: %s", unstableReason)
+		}
+		batch, err := u.nextUpgradeBatch(tc, podOrdinals[:_i+1], maxConcurrency)
+		if err != nil {
+			return err
+		}
+		return u.upgradeTiKVPods(tc, batch, newSet)
+	}
+
+	if unavailable > 0 {
+		return controller.RequeueErrorf("tidbcluster: [%s/%s]'s %d tikv pods are being upgraded in batch", ns, tcName, unavailable)
+	}
+	return nil
+}
+
+// nextUpgradeBatch returns the ordinals of the next batch of pods to upgrade, starting from the last of the ordinals
+// in the descending order. A pod joins the batch only if PD confirms that its store shares no region with the stores
+// of the batch, so a region loses at most one replica when the batch is restarted. The batch ends at the first pod
+// which can't join it, since the partition upgrades all the pods above it.
+func (u *tikvUpgrader) nextUpgradeBatch(tc *v1alpha1.TidbCluster, ordinals []int32, maxConcurrency int) ([]int32, error) {
+	pdClient := controller.GetPDClient(u.deps.PDControl, tc)
+	regions := map[uint64]struct{}{}
+	batch := make([]int32, 0, maxConcurrency)
+	for _i := len(ordinals) - 1; _i >= 0 && len(batch) < maxConcurrency; _i-- {
+		i := ordinals[_i]
+		store := getStoreByOrdinal(tc.GetName(), tc.Status.TiKV, i)
+		if store == nil {
+			break
+		}
+		storeID, err := strconv.ParseUint(store.ID, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		info, err := pdClient.GetRegionsByStore(storeID)
+		if err != nil {
+			if len(batch) > 0 {
+				klog.Warningf("tidbcluster: [%s/%s] failed to get regions of tikv store %d, stop the batch at %d: %v", tc.Namespace, tc.Name, storeID, i, err)
+				break
+			}
+			return nil, fmt.Errorf("get regions of tikv store %d failed: %v", storeID, err)
+		}
+		shared := false
+		for _, region := range info.Regions {
+			if _, ok := regions[region.ID]; ok {
+				shared = true
+				break
+			}
+		}
+		if shared {
+			klog.Infof("tidbcluster: [%s/%s] tikv store %d shares regions with the stores of the batch, stop the batch at %d", tc.Namespace, tc.Name, storeID, i)
+			break
+		}
+		for _, region := range info.Regions {
+			regions[region.ID] = struct{}{}
+		}
+		batch = append(batch, i)
+	}
+	if len(batch) == 0 {
+		// the store of the pod isn't found, upgrade it alone
+		batch = append(batch, ordinals[len(ordinals)-1])
+	}
+	return batch, nil
+}
+
+// upgradeTiKVPods lowers the partition to upgrade the batch of pods together after all of them are ready to upgrade,
+// the leaders of the stores are evicted at the same time.
+func (u *tikvUpgrader) upgradeTiKVPods(tc *v1alpha1.TidbCluster, ordinals []int32, newSet *apps.StatefulSet) error {
+	var errs []error
+	for _, ordinal := range ordinals {
+		if err := u.prepareTiKVPodToUpgrade(tc, ordinal); err != nil {
+			if !controller.IsRequeueError(err) {
+				return err
+			}
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return controller.RequeueErrorf("tidbcluster: [%s/%s] %d of the batch of %d tikv pods are not ready to upgrade: %v",
+			tc.Namespace, tc.Name, len(errs), len(ordinals), errorutils.NewAggregate(errs))
+	}
+	for _, ordinal := range ordinals {
+		recordUpgradeRestart(u.deps, tc, TikvPodName(tc.GetName(), ordinal), newSet)
+	}
+	mngerutils.SetUpgradePartition(newSet, ordinals[len(ordinals)-1])
+	return nil
+}
+
 func (u *tikvUpgrader) isClusterStable(tc *v1alpha1.TidbCluster) string {
 	if check, ok := tc.Annotations[annoKeyTiKVStoreStateCheck]; ok && check == "true" {
 		return pdapi.IsTiKVStable(controller.GetPDClient(u.deps.PDControl, tc))
@@ -195,6 +389,16 @@ func (u *tikvUpgrader) isClusterStable(tc *v1alpha1.TidbCluster) string {
 }
 
 func (u *tikvUpgrader) upgradeTiKVPod(tc *v1alpha1.TidbCluster, ordinal int32, newSet *apps.StatefulSet) error {
+	if err := u.prepareTiKVPodToUpgrade(tc, ordinal); err != nil {
+		return err
+	}
+	recordUpgradeRestart(u.deps, tc, TikvPodName(tc.GetName(), ordinal), newSet)
+	mngerutils.SetUpgradePartition(newSet, ordinal)
+	return nil
+}
+
+// prepareTiKVPodToUpgrade evicts the leaders of the store and modifies the volumes of the pod before it's upgraded
+func (u *tikvUpgrader) prepareTiKVPodToUpgrade(tc *v1alpha1.TidbCluster, ordinal int32) error {
 	ns := tc.GetNamespace()
 	tcName := tc.GetName()
 	upgradePodName := TikvPodName(tcName, ordinal)
@@ -221,7 +425,6 @@ func (u *tikvUpgrader) upgradeTiKVPod(tc *v1alpha1.TidbCluster, ordinal int32, n
 		}
 	}
 
-	mngerutils.SetUpgradePartition(newSet, ordinal)
 	return nil
 }
 
@@ -241,19 +444,24 @@ func (u *tikvUpgrader) evictLeaderBeforeUpgrade(tc *v1alpha1.TidbCluster, upgrad
 
 	// wait for leader eviction to complete or timeout
 	evictLeaderTimeout := tc.TiKVEvictLeaderTimeout()
+	timeout := false
 	if evictLeaderBeginTimeStr, evicting := upgradePod.Annotations[annoKeyEvictLeaderBeginTime]; evicting {
 		evictLeaderBeginTime, err := time.Parse(time.RFC3339, evictLeaderBeginTimeStr)
 		if err != nil {
 			klog.Errorf("%s: parse annotation %q to time failed", logPrefix, annoKeyEvictLeaderBeginTime)
 			return false, nil
 		}
-		if time.Now().After(evictLeaderBeginTime.Add(evictLeaderTimeout)) {
-			klog.Infof("%s: evict leader timeout with threshold %v, so ready to upgrade", logPrefix, evictLeaderTimeout)
-			return true, nil
-		}
+		timeout = time.Now().After(evictLeaderBeginTime.Add(evictLeaderTimeout))
+	}
+	if timeout && tc.TiKVEvictLeaderTimeoutPolicy() == v1alpha1.EvictLeaderTimeoutPolicyContinue {
+		klog.Infof("%s: evict leader timeout with threshold %v, so ready to upgrade", logPrefix, evictLeaderTimeout)
+		setLeaderEvictionStalledCondition(tc, true, v1alpha1.LeaderEvictionReasonTimeoutContinued,
+			fmt.Sprintf("Evicting the leaders of pod %s times out after %v, the pod is upgraded anyway", upgradePod.Name, evictLeaderTimeout))
+		return true, nil
 	}
 
-	leaderCount, err := u.deps.TiKVControl.GetTiKVPodClient(tc.Namespace, tc.Name, upgradePod.Name, tc.IsTLSClusterEnabled()).GetLeaderCount()
+	leaderCount, err := u.deps.TiKVControl.GetTiKVPodClient(tc.Namespace, tc.Name, upgradePod.Name, tc.Spec.TiKV.GetStatusPort(), tc.IsTLSClusterEnabled(),
+		pdapi.TLSFromCluster(pdapi.Namespace(tc.Namespace), tc.Spec.TLSCluster)).GetLeaderCount()
 	if err != nil {
 		klog.Warningf("%s: failed to get leader count, error: %v", logPrefix, err)
 		return false, nil
@@ -261,13 +469,38 @@ func (u *tikvUpgrader) evictLeaderBeforeUpgrade(tc *v1alpha1.TidbCluster, upgrad
 
 	if leaderCount == 0 {
 		klog.Infof("%s: leader count is 0, so ready to upgrade", logPrefix)
+		setLeaderEvictionStalledCondition(tc, false, v1alpha1.LeaderEvictionReasonEvicted,
+			fmt.Sprintf("The leaders of pod %s are evicted", upgradePod.Name))
 		return true, nil
 	}
 
+	if timeout {
+		klog.Warningf("%s: evict leader timeout with threshold %v and leader count is %d, the upgrade is blocked", logPrefix, evictLeaderTimeout, leaderCount)
+		setLeaderEvictionStalledCondition(tc, true, v1alpha1.LeaderEvictionReasonTimeoutBlocked,
+			fmt.Sprintf("Evicting the leaders of pod %s times out after %v with %d leaders left, the upgrade is blocked until the leaders are evicted, "+
+				"the timeout is raised or the policy is changed to Continue", upgradePod.Name, evictLeaderTimeout, leaderCount))
+		return false, nil
+	}
+
 	klog.Infof("%s: leader count is %d, and wait for evictition to complete", logPrefix, leaderCount)
 	return false, nil
 }
 
+// setLeaderEvictionStalledCondition reports whether the leader eviction before the upgrade of a TiKV pod times out
+func setLeaderEvictionStalledCondition(tc *v1alpha1.TidbCluster, stalled bool, reason, message string) {
+	status := metav1.ConditionFalse
+	if stalled {
+		status = metav1.ConditionTrue
+	}
+	tc.Status.TiKV.SetCondition(metav1.Condition{
+		Type:               v1alpha1.ConditionTypeLeaderEvictionStalled,
+		Status:             status,
+		ObservedGeneration: tc.Generation,
+		Reason:             reason,
+		Message:            message,
+	})
+}
+
 func (u *tikvUpgrader) modifyVolumesBeforeUpgrade(tc *v1alpha1.TidbCluster, upgradePod *corev1.Pod) (bool, error) {
 	desiredVolumes, err := u.volumeModifier.GetDesiredVolumes(tc, v1alpha1.TiKVMemberType)
 	if err != nil {
@@ -31,6 +31,7 @@ import (
 	. "github.com/onsi/gomega"
 	apps "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	podinformers "k8s.io/client-go/informers/core/v1"
@@ -634,6 +635,46 @@ func TestTiKVUpgraderUpgrade(t *testing.T) {
 			},
 			expectFn: func(g *GomegaWithT, tc *v1alpha1.TidbCluster, newSet *apps.StatefulSet, pods map[string]*corev1.Pod) {
 				g.Expect(*newSet.Spec.UpdateStrategy.RollingUpdate.Partition).To(Equal(int32(1)))
+				cond := meta.FindStatusCondition(tc.Status.TiKV.Conditions, v1alpha1.ConditionTypeLeaderEvictionStalled)
+				g.Expect(cond).NotTo(BeNil())
+				g.Expect(cond.Status).To(Equal(metav1.ConditionTrue))
+				g.Expect(cond.Reason).To(Equal(v1alpha1.LeaderEvictionReasonTimeoutContinued))
+			},
+		},
+		{
+			name: "evict leaders time out and the upgrade is blocked",
+			changeFn: func(tc *v1alpha1.TidbCluster) {
+				tc.Spec.TiKV.EvictLeaderTimeoutPolicy = v1alpha1.EvictLeaderTimeoutPolicyBlock
+				tc.Status.PD.Phase = v1alpha1.NormalPhase
+				tc.Status.TiKV.Phase = v1alpha1.UpgradePhase
+				tc.Status.TiKV.Synced = true
+				tc.Status.TiKV.StatefulSet.CurrentReplicas = 2
+				tc.Status.TiKV.StatefulSet.UpdatedReplicas = 1
+			},
+			changeOldSet: func(oldSet *apps.StatefulSet) {
+				mngerutils.SetStatefulSetLastAppliedConfigAnnotation(oldSet)
+				oldSet.Status.CurrentReplicas = 2
+				oldSet.Status.UpdatedReplicas = 1
+				oldSet.Spec.UpdateStrategy.RollingUpdate.Partition = pointer.Int32Ptr(2)
+			},
+			changePods: func(pods []*corev1.Pod) {
+				for _, pod := range pods {
+					if pod.GetName() == TikvPodName(upgradeTcName, 1) {
+						pod.Annotations = map[string]string{annoKeyEvictLeaderBeginTime: time.Now().Add(-2000 * time.Minute).Format(time.RFC3339)}
+					}
+				}
+			},
+			podName:     "upgrader-tikv-1",
+			leaderCount: 10,
+			errExpectFn: func(g *GomegaWithT, err error) {
+				g.Expect(controller.IsRequeueError(err)).To(BeTrue())
+			},
+			expectFn: func(g *GomegaWithT, tc *v1alpha1.TidbCluster, newSet *apps.StatefulSet, pods map[string]*corev1.Pod) {
+				g.Expect(*newSet.Spec.UpdateStrategy.RollingUpdate.Partition).To(Equal(int32(2)))
+				cond := meta.FindStatusCondition(tc.Status.TiKV.Conditions, v1alpha1.ConditionTypeLeaderEvictionStalled)
+				g.Expect(cond).NotTo(BeNil())
+				g.Expect(cond.Status).To(Equal(metav1.ConditionTrue))
+				g.Expect(cond.Reason).To(Equal(v1alpha1.LeaderEvictionReasonTimeoutBlocked))
 			},
 		},
 		{
@@ -1085,6 +1126,83 @@ func TestTiKVUpgraderUpgrade(t *testing.T) {
 	}
 }
 
+func TestTiKVUpgraderUpgradeInBatches(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	upgrader, pdControl, _, podInformer, tikvControl, _ := newTiKVUpgrader()
+	tc := newTidbClusterForTiKVUpgrader()
+	tc.Spec.TiKV.MaxUpgradeConcurrency = pointer.Int32Ptr(3)
+	oldSet := oldStatefulSetForTiKVUpgrader()
+	for _, pod := range getTiKVPods(oldSet) {
+		g.Expect(podInformer.Informer().GetIndexer().Add(pod)).To(Succeed())
+	}
+
+	pdClient := controller.NewFakePDClient(pdControl, tc)
+	// the store of tikv-0 shares region 1 with the store of tikv-2
+	storeRegions := map[uint64][]uint64{1: {1}, 2: {3}, 3: {1, 2}}
+	pdClient.AddReaction(pdapi.GetRegionsByStoreActionType, func(action *pdapi.Action) (interface{}, error) {
+		info := &pdapi.RegionsInfo{}
+		for _, id := range storeRegions[action.ID] {
+			info.Regions = append(info.Regions, &pdapi.RegionInfo{ID: id})
+		}
+		info.Count = len(info.Regions)
+		return info, nil
+	})
+	evicting := map[uint64]bool{}
+	pdClient.AddReaction(pdapi.BeginEvictLeaderActionType, func(action *pdapi.Action) (interface{}, error) {
+		evicting[action.ID] = true
+		return nil, nil
+	})
+	pdClient.AddReaction(pdapi.EndEvictLeaderActionType, func(action *pdapi.Action) (interface{}, error) {
+		delete(evicting, action.ID)
+		return nil, nil
+	})
+	for i := 0; i < 3; i++ {
+		tikvClient := controller.NewFakeTiKVClient(tikvControl, tc, TikvPodName(upgradeTcName, int32(i)))
+		tikvClient.AddReaction(tikvapi.GetLeaderCountActionType, func(action *tikvapi.Action) (interface{}, error) {
+			return 0, nil
+		})
+	}
+
+	getPod := func(ordinal int32) *corev1.Pod {
+		pod, err := podInformer.Lister().Pods(corev1.NamespaceDefault).Get(TikvPodName(upgradeTcName, ordinal))
+		g.Expect(err).NotTo(HaveOccurred())
+		return pod
+	}
+
+	// the leaders of tikv-2 and tikv-1 are evicted together
+	newSet := newStatefulSetForTiKVUpgrader()
+	err := upgrader.Upgrade(tc, oldSet, newSet)
+	g.Expect(controller.IsRequeueError(err)).To(BeTrue())
+	g.Expect(evicting).To(Equal(map[uint64]bool{3: true, 2: true}))
+	g.Expect(*newSet.Spec.UpdateStrategy.RollingUpdate.Partition).To(Equal(int32(3)))
+
+	// the partition is lowered for the batch once the leaders are evicted
+	newSet = newStatefulSetForTiKVUpgrader()
+	g.Expect(upgrader.Upgrade(tc, oldSet, newSet)).To(Succeed())
+	g.Expect(*newSet.Spec.UpdateStrategy.RollingUpdate.Partition).To(Equal(int32(1)))
+
+	// tikv-1 is deleted to be upgraded together with tikv-2
+	mngerutils.SetUpgradePartition(oldSet, 1)
+	getPod(2).Labels[apps.ControllerRevisionHashLabelKey] = "2"
+	newSet = newStatefulSetForTiKVUpgrader()
+	err = upgrader.Upgrade(tc, oldSet, newSet)
+	g.Expect(controller.IsRequeueError(err)).To(BeTrue())
+	_, err = podInformer.Lister().Pods(corev1.NamespaceDefault).Get(TikvPodName(upgradeTcName, 1))
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(*newSet.Spec.UpdateStrategy.RollingUpdate.Partition).To(Equal(int32(1)))
+
+	// the next batch starts after the batch is upgraded
+	pods := getTiKVPods(oldSet)
+	pods[1].Labels[apps.ControllerRevisionHashLabelKey] = "2"
+	g.Expect(podInformer.Informer().GetIndexer().Add(pods[1])).To(Succeed())
+	newSet = newStatefulSetForTiKVUpgrader()
+	err = upgrader.Upgrade(tc, oldSet, newSet)
+	g.Expect(controller.IsRequeueError(err)).To(BeTrue())
+	g.Expect(evicting).To(Equal(map[uint64]bool{1: true}))
+	g.Expect(getPod(0).Annotations).To(HaveKey(annoKeyEvictLeaderBeginTime))
+}
+
 func newTiKVUpgrader() (TiKVUpgrader, *pdapi.FakePDControl, *controller.FakePodControl, podinformers.PodInformer, *tikvapi.FakeTiKVControl, *volumes.FakePodVolumeModifier) {
 	fakeDeps := controller.NewFakeDependencies()
 	pdControl := fakeDeps.PDControl.(*pdapi.FakePDControl)
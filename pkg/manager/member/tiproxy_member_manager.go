@@ -103,6 +103,30 @@ func (m *tiproxyMemberManager) Sync(tc *v1alpha1.TidbCluster) error {
 }
 
 func (m *tiproxyMemberManager) syncConfigMap(tc *v1alpha1.TidbCluster, set *apps.StatefulSet) (*corev1.ConfigMap, error) {
+	newCm, err := getTiProxyConfigMap(tc)
+	if err != nil {
+		return nil, err
+	}
+
+	var inUseName string
+	if set != nil {
+		inUseName = mngerutils.FindConfigMapVolume(&set.Spec.Template.Spec, func(name string) bool {
+			return strings.HasPrefix(name, controller.TiProxyMemberName(tc.Name))
+		})
+	}
+
+	klog.V(4).Info("get tiproxy in use config map name: ", inUseName)
+
+	err = mngerutils.UpdateConfigMapIfNeed(m.deps.ConfigMapLister, v1alpha1.ConfigUpdateStrategyInPlace, inUseName, newCm)
+	if err != nil {
+		return nil, err
+	}
+
+	controller.PropagateMetadata(newCm, tc.BaseTiProxySpec().Metadata())
+	return m.deps.TypedControl.CreateOrUpdateConfigMap(tc, newCm)
+}
+
+func getTiProxyConfigMap(tc *v1alpha1.TidbCluster) (*corev1.ConfigMap, error) {
 	PDAddr := fmt.Sprintf("%s:2379", controller.PDMemberName(tc.Name))
 	// TODO: support it
 	if tc.AcrossK8s() {
@@ -121,7 +145,9 @@ func (m *tiproxyMemberManager) syncConfigMap(tc *v1alpha1.TidbCluster, set *apps
 
 	cfgWrapper.Set("workdir", filepath.Join(tiproxyVolumeMountPath, "work"))
 	cfgWrapper.Set("proxy.pd-addrs", PDAddr)
-	cfgWrapper.Set("proxy.require-backend-tls", false)
+	// TiProxy must connect to TiDB with TLS if it offloads TLS for the clients, the server TLS of TiProxy
+	// set below is optional for the clients so that the clients without TLS are accepted
+	cfgWrapper.Set("proxy.require-backend-tls", tc.IsTiProxyTLSOffloadEnabled())
 
 	if tc.IsTLSClusterEnabled() {
 		cfgWrapper.Set("security.cluster-tls.ca", path.Join(util.ClusterClientTLSPath, "ca.crt"))
@@ -169,22 +195,7 @@ func (m *tiproxyMemberManager) syncConfigMap(tc *v1alpha1.TidbCluster, set *apps
 			"startup-script": startScript,
 		},
 	}
-
-	var inUseName string
-	if set != nil {
-		inUseName = mngerutils.FindConfigMapVolume(&set.Spec.Template.Spec, func(name string) bool {
-			return strings.HasPrefix(name, controller.TiProxyMemberName(tc.Name))
-		})
-	}
-
-	klog.V(4).Info("get tiproxy in use config map name: ", inUseName)
-
-	err = mngerutils.UpdateConfigMapIfNeed(m.deps.ConfigMapLister, v1alpha1.ConfigUpdateStrategyInPlace, inUseName, newCm)
-	if err != nil {
-		return nil, err
-	}
-
-	return m.deps.TypedControl.CreateOrUpdateConfigMap(tc, newCm)
+	return newCm, nil
 }
 
 func (m *tiproxyMemberManager) syncStatefulSet(tc *v1alpha1.TidbCluster) error {
@@ -210,7 +221,7 @@ func (m *tiproxyMemberManager) syncStatefulSet(tc *v1alpha1.TidbCluster) error {
 		return err
 	}
 
-	newSts, err := m.getNewStatefulSet(tc, cm)
+	newSts, err := getNewTiProxyStatefulSet(tc, cm)
 	if err != nil {
 		return err
 	}
@@ -227,6 +238,12 @@ func (m *tiproxyMemberManager) syncStatefulSet(tc *v1alpha1.TidbCluster) error {
 		return nil
 	}
 
+	// Pod template changes made in the middle of an upgrade or a scale are held until it
+	// completes, unless the pending changes are applied explicitly. The replicas are not held.
+	if err := holdPendingChanges(tc, v1alpha1.TiProxyMemberType, newSts, oldStatefulSet); err != nil {
+		return err
+	}
+
 	// Scaling takes precedence over upgrading because:
 	// - if a pod fails in the upgrading, users may want to delete it or add
 	//   new replicas
@@ -295,6 +312,36 @@ func (m *tiproxyMemberManager) syncStatus(tc *v1alpha1.TidbCluster, sts *apps.St
 }
 
 func (m *tiproxyMemberManager) syncProxyService(tc *v1alpha1.TidbCluster, peer bool) error {
+	newSvc := getNewTiProxyService(tc, peer)
+
+	oldSvcTmp, err := m.deps.ServiceLister.Services(tc.GetNamespace()).Get(newSvc.ObjectMeta.Name)
+	if errors.IsNotFound(err) {
+		err = controller.SetServiceLastAppliedConfigAnnotation(newSvc)
+		if err != nil {
+			return err
+		}
+		return m.deps.ServiceControl.CreateService(tc, newSvc)
+	}
+	if err != nil {
+		return fmt.Errorf("syncProxyService: failed to get svc %s for cluster %s/%s, error: %s", controller.TiProxyPeerMemberName(tc.GetName()), tc.GetNamespace(), tc.GetName(), err)
+	}
+
+	oldSvc := oldSvcTmp.DeepCopy()
+
+	_, err = m.deps.ServiceControl.SyncComponentService(
+		tc,
+		newSvc,
+		oldSvc,
+		false)
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func getNewTiProxyService(tc *v1alpha1.TidbCluster, peer bool) *corev1.Service {
 	svcLabel := labelTiProxy(tc)
 	newSvc := &corev1.Service{
 		ObjectMeta: metav1.ObjectMeta{
@@ -344,39 +391,15 @@ func (m *tiproxyMemberManager) syncProxyService(tc *v1alpha1.TidbCluster, peer b
 			},
 		)
 	}
+	controller.PropagateMetadata(newSvc, tc.BaseTiProxySpec().Metadata())
 	if tc.Spec.PreferIPv6 {
 		SetServiceWhenPreferIPv6(newSvc)
 	}
-
-	oldSvcTmp, err := m.deps.ServiceLister.Services(tc.GetNamespace()).Get(newSvc.ObjectMeta.Name)
-	if errors.IsNotFound(err) {
-		err = controller.SetServiceLastAppliedConfigAnnotation(newSvc)
-		if err != nil {
-			return err
-		}
-		return m.deps.ServiceControl.CreateService(tc, newSvc)
-	}
-	if err != nil {
-		return fmt.Errorf("syncProxyService: failed to get svc %s for cluster %s/%s, error: %s", controller.TiProxyPeerMemberName(tc.GetName()), tc.GetNamespace(), tc.GetName(), err)
-	}
-
-	oldSvc := oldSvcTmp.DeepCopy()
-
-	_, err = m.deps.ServiceControl.SyncComponentService(
-		tc,
-		newSvc,
-		oldSvc,
-		false)
-
-	if err != nil {
-		return err
-	}
-
-	return nil
+	return newSvc
 }
 
 // Only Use config file if cm is not nil
-func (m *tiproxyMemberManager) getNewStatefulSet(tc *v1alpha1.TidbCluster, cm *corev1.ConfigMap) (*apps.StatefulSet, error) {
+func getNewTiProxyStatefulSet(tc *v1alpha1.TidbCluster, cm *corev1.ConfigMap) (*apps.StatefulSet, error) {
 	var err error
 
 	ns := tc.GetNamespace()
@@ -509,6 +532,7 @@ func (m *tiproxyMemberManager) getNewStatefulSet(tc *v1alpha1.TidbCluster, cm *c
 		Env:          util.AppendEnv(envs, baseTiProxySpec.Env()),
 		EnvFrom:      baseTiProxySpec.EnvFrom(),
 	}
+	setLifecycle(&tiproxyContainer, baseTiProxySpec.Lifecycle())
 
 	podSpec := baseTiProxySpec.BuildPodSpec()
 
@@ -558,6 +582,10 @@ func (m *tiproxyMemberManager) getNewStatefulSet(tc *v1alpha1.TidbCluster, cm *c
 		},
 	}
 	tiproxySts.Spec.VolumeClaimTemplates = append(tiproxySts.Spec.VolumeClaimTemplates, additionalPVCs...)
+	propagateStatefulSetMetadata(tiproxySts, baseTiProxySpec.Metadata())
+	if err := ApplyPodTemplatePatches(&tiproxySts.Spec.Template, baseTiProxySpec.PodTemplatePatches()); err != nil {
+		return nil, fmt.Errorf("failed to apply pod template patches for TiProxy of [%s/%s], error: %v", ns, tcName, err)
+	}
 	return tiproxySts, nil
 }
 
@@ -85,6 +85,8 @@ func (u *tiproxyUpgrader) Upgrade(tc *v1alpha1.TidbCluster, oldSet *apps.Statefu
 			continue
 		}
 
+		reason, detail := mngerutils.UpgradeRestartReason(pod, &newSet.Spec.Template)
+		mngerutils.RecordPodRestart(tc, pod, reason, detail)
 		mngerutils.SetUpgradePartition(newSet, i)
 		return nil
 	}
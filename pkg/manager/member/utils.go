@@ -17,8 +17,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"path"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/pingcap/tidb-operator/pkg/apis/label"
@@ -26,9 +28,13 @@ import (
 	"github.com/pingcap/tidb-operator/pkg/apis/util/toml"
 	"github.com/pingcap/tidb-operator/pkg/controller"
 	"github.com/pingcap/tidb-operator/pkg/manager/member/startscript"
+	mngerutils "github.com/pingcap/tidb-operator/pkg/manager/utils"
+	"github.com/pingcap/tidb-operator/pkg/pdapi"
 	"github.com/pingcap/tidb-operator/pkg/util"
 
 	"github.com/Masterminds/semver"
+	jsonpatch "github.com/evanphx/json-patch"
+	"github.com/ghodss/yaml"
 	"github.com/pingcap/advanced-statefulset/client/apis/apps/v1/helper"
 	apps "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -169,6 +175,17 @@ func NeedForceUpgrade(ann map[string]string) bool {
 }
 
 // MarshalTOML is a template function that try to marshal a go value to toml
+// recordUpgradeRestart records the cause of the restart of the pod to upgrade it to the template of the StatefulSet
+func recordUpgradeRestart(deps *controller.Dependencies, tc *v1alpha1.TidbCluster, podName string, newSet *apps.StatefulSet) {
+	pod, err := deps.PodLister.Pods(tc.GetNamespace()).Get(podName)
+	if err != nil {
+		klog.V(4).Infof("failed to get pod %s/%s to record the restart cause: %v", tc.GetNamespace(), podName, err)
+		return
+	}
+	reason, detail := mngerutils.UpgradeRestartReason(pod, &newSet.Spec.Template)
+	mngerutils.RecordPodRestart(tc, pod, reason, detail)
+}
+
 func MarshalTOML(v interface{}) ([]byte, error) {
 	return toml.Marshal(v)
 }
@@ -213,6 +230,27 @@ func getStsAnnotations(tcAnns map[string]string, component string) map[string]st
 	return anns
 }
 
+// addOfflineOrdinals merges the offline ordinals of the component into the delete slots annotation of the StatefulSet
+func addOfflineOrdinals(stsAnns map[string]string, offlineOrdinals []int32) error {
+	if len(offlineOrdinals) == 0 {
+		return nil
+	}
+	deleteSlots := sets.NewInt32(offlineOrdinals...)
+	if val, ok := stsAnns[helper.DeleteSlotsAnn]; ok {
+		var slots []int32
+		if err := json.Unmarshal([]byte(val), &slots); err != nil {
+			return fmt.Errorf("failed to parse delete slots %q, error: %v", val, err)
+		}
+		deleteSlots.Insert(slots...)
+	}
+	b, err := json.Marshal(deleteSlots.List())
+	if err != nil {
+		return err
+	}
+	stsAnns[helper.DeleteSlotsAnn] = string(b)
+	return nil
+}
+
 // MapContainers index containers of Pod by container name in favor of looking up
 func MapContainers(podSpec *corev1.PodSpec) map[string]corev1.Container {
 	m := map[string]corev1.Container{}
@@ -243,11 +281,22 @@ func findContainerByName(sts *apps.StatefulSet, containerName string) *corev1.Co
 
 func getTikVConfigMapForTiKVSpec(tikvSpec *v1alpha1.TiKVSpec, tc *v1alpha1.TidbCluster) (*corev1.ConfigMap, error) {
 	config := tikvSpec.Config.DeepCopy()
+	if config != nil {
+		applyConfigProfile(config.GenericConfig, tc, v1alpha1.TiKVMemberType, tc.TiKVVersion())
+	}
 	if tc.IsTLSClusterEnabled() {
 		config.Set("security.ca-path", path.Join(tikvClusterCertPath, tlsSecretRootCAKey))
 		config.Set("security.cert-path", path.Join(tikvClusterCertPath, corev1.TLSCertKey))
 		config.Set("security.key-path", path.Join(tikvClusterCertPath, corev1.TLSPrivateKeyKey))
 	}
+	if rateLimit := tikvSpec.IORateLimit; rateLimit != nil {
+		if rateLimit.MaxBytesPerSec != "" {
+			config.Set(tikvIORateLimitMaxBytesPerSecKey, rateLimit.MaxBytesPerSec)
+		}
+		if rateLimit.Mode != "" {
+			config.Set("storage.io-rate-limit.mode", rateLimit.Mode)
+		}
+	}
 	confText, err := config.MarshalTOML()
 	if err != nil {
 		return nil, err
@@ -392,6 +441,9 @@ func CreateOrUpdateService(serviceLister corelisters.ServiceLister, serviceContr
 	if err != nil {
 		return err
 	}
+	if tc, ok := obj.(*v1alpha1.TidbCluster); ok && mngerutils.CheckServiceDrift(tc, oldSvc) {
+		equal = false
+	}
 	annoEqual := util.IsSubMapOf(newSvc.Annotations, oldSvc.Annotations)
 	isOrphan := metav1.GetControllerOf(oldSvc) == nil
 
@@ -565,6 +617,63 @@ func MergePatchContainers(base, patches []corev1.Container) ([]corev1.Container,
 	return out, nil
 }
 
+// ApplyPodTemplatePatches applies the user defined patches to the pod template in order,
+// failing on the first error. The template is left untouched if any patch fails.
+func ApplyPodTemplatePatches(template *corev1.PodTemplateSpec, patches []v1alpha1.PodTemplatePatch) error {
+	if len(patches) == 0 {
+		return nil
+	}
+
+	templateBytes, err := json.Marshal(template)
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON for pod template, error: %v", err)
+	}
+
+	for i, p := range patches {
+		patchBytes, err := yaml.YAMLToJSON([]byte(p.Patch))
+		if err != nil {
+			return fmt.Errorf("failed to convert pod template patch %d to JSON, error: %v", i, err)
+		}
+
+		switch p.Type {
+		case v1alpha1.PodTemplatePatchTypeJSON:
+			patch, err := jsonpatch.DecodePatch(patchBytes)
+			if err != nil {
+				return fmt.Errorf("failed to decode JSON patch %d, error: %v", i, err)
+			}
+			templateBytes, err = patch.Apply(templateBytes)
+			if err != nil {
+				return fmt.Errorf("failed to apply JSON patch %d, error: %v", i, err)
+			}
+		case v1alpha1.PodTemplatePatchTypeStrategicMerge, "":
+			templateBytes, err = strategicpatch.StrategicMergePatch(templateBytes, patchBytes, corev1.PodTemplateSpec{})
+			if err != nil {
+				return fmt.Errorf("failed to apply strategic merge patch %d, error: %v", i, err)
+			}
+		default:
+			return fmt.Errorf("unsupported type %q of pod template patch %d", p.Type, i)
+		}
+	}
+
+	var patched corev1.PodTemplateSpec
+	if err := json.Unmarshal(templateBytes, &patched); err != nil {
+		return fmt.Errorf("failed to unmarshal patched pod template, error: %v", err)
+	}
+	*template = patched
+	return nil
+}
+
+// propagateStatefulSetMetadata adds the custom metadata of the component to the StatefulSet, the pod
+// template and the volume claim templates. The PVCs that already exist are synced by the meta manager
+// because the volume claim templates of a StatefulSet can not be updated.
+func propagateStatefulSetMetadata(set *apps.StatefulSet, md v1alpha1.ObjectMetadata) {
+	controller.PropagateMetadata(set, md)
+	controller.PropagateMetadata(&set.Spec.Template, md)
+	for i := range set.Spec.VolumeClaimTemplates {
+		controller.PropagateMetadata(&set.Spec.VolumeClaimTemplates[i], md)
+	}
+}
+
 func BuildProbeCommand(tc *v1alpha1.TidbCluster, componentType string) (command []string) {
 	host := "127.0.0.1"
 	var readinessURL string
@@ -572,7 +681,7 @@ func BuildProbeCommand(tc *v1alpha1.TidbCluster, componentType string) (command
 		readinessURL = fmt.Sprintf("%s://%s:2379/status", tc.Scheme(), host)
 	}
 	if componentType == label.TiDBLabelVal {
-		readinessURL = fmt.Sprintf("%s://%s:10080/status", tc.Scheme(), host)
+		readinessURL = fmt.Sprintf("%s://%s:%d/status", tc.Scheme(), host, tc.Spec.TiDB.GetStatusPort())
 	}
 	command = append(command, "curl")
 	command = append(command, readinessURL)
@@ -599,3 +708,398 @@ func SetServiceWhenPreferIPv6(svc *corev1.Service) {
 	policy := corev1.IPFamilyPolicyPreferDualStack
 	svc.Spec.IPFamilyPolicy = &policy
 }
+
+// SetServiceIPFamilies sets the ipFamilyPolicy and ipFamilies of the service specified by the user,
+// which overrides the policy set by SetServiceWhenPreferIPv6
+func SetServiceIPFamilies(svc *corev1.Service, svcSpec *v1alpha1.ServiceSpec) {
+	if svcSpec.IPFamilyPolicy != nil {
+		policy := *svcSpec.IPFamilyPolicy
+		svc.Spec.IPFamilyPolicy = &policy
+	}
+	if svcSpec.IPFamilies != nil {
+		svc.Spec.IPFamilies = svcSpec.IPFamilies
+	}
+}
+
+// NeedApplyPendingChanges checks if the spec changes held during an in-progress upgrade or scale should be applied immediately
+func NeedApplyPendingChanges(ann map[string]string) bool {
+	if ann != nil {
+		val, ok := ann[label.AnnApplyPendingChangesKey]
+		if ok && val == label.AnnApplyPendingChangesVal {
+			return true
+		}
+	}
+	return false
+}
+
+// holdPendingChanges keeps the pod template of the statefulset unchanged while a rolling upgrade or a scale
+// of the component is in progress, so that a spec change made in the middle of the operation does not
+// interleave with it. The held changes are recorded in the component status and are applied once the
+// operation completes, or immediately if the TidbCluster is annotated to apply pending changes.
+// The replicas are never held because scaling takes precedence over upgrading, e.g. users may scale out
+// or delete a failed member in the middle of a stuck upgrade.
+func holdPendingChanges(tc *v1alpha1.TidbCluster, memberType v1alpha1.MemberType, newSet, oldSet *apps.StatefulSet) error {
+	pending := pendingChangesOf(tc, memberType)
+	status := tc.ComponentStatus(memberType)
+	if pending == nil || status == nil {
+		return nil
+	}
+
+	operation := inProgressOperation(status)
+	if operation == "" || NeedApplyPendingChanges(tc.Annotations) {
+		*pending = nil
+		return nil
+	}
+
+	applied, _, err := GetLastAppliedConfig(oldSet)
+	if err != nil {
+		return err
+	}
+	var held []string
+	if !apiequality.Semantic.DeepEqual(applied.Template.Spec, newSet.Spec.Template.Spec) {
+		newSet.Spec.Template.Spec = applied.Template.Spec
+		held = append(held, "pod spec")
+	}
+	// the last applied pod config is kept by UpdateStatefulSet, it is not a part of the desired template
+	appliedMeta := applied.Template.ObjectMeta.DeepCopy()
+	delete(appliedMeta.Annotations, LastAppliedConfigAnnotation)
+	if !apiequality.Semantic.DeepEqual(appliedMeta.Labels, newSet.Spec.Template.Labels) ||
+		!apiequality.Semantic.DeepEqual(appliedMeta.Annotations, newSet.Spec.Template.Annotations) {
+		newSet.Spec.Template.ObjectMeta = *appliedMeta
+		held = append(held, "pod metadata")
+	}
+	if len(held) == 0 {
+		*pending = nil
+		return nil
+	}
+
+	if *pending == nil || (*pending).Operation != operation {
+		klog.Infof("TidbCluster: [%s/%s]'s %s is in %s phase, hold the spec changes until it completes",
+			tc.GetNamespace(), tc.GetName(), memberType, operation)
+		*pending = &v1alpha1.PendingChanges{
+			Operation: operation,
+			Since:     metav1.Now(),
+		}
+	}
+	(*pending).Message = fmt.Sprintf("%s changes of %s are held until the in-progress %s completes",
+		strings.Join(held, ", "), memberType, strings.ToLower(string(operation)))
+	return nil
+}
+
+// inProgressOperation returns the phase of the in-progress operation of the component which holds the
+// spec changes, it returns empty if there is no operation in progress
+func inProgressOperation(status v1alpha1.ComponentStatus) v1alpha1.MemberPhase {
+	sts := status.GetStatefulSet()
+	switch status.GetPhase() {
+	case v1alpha1.UpgradePhase:
+		if sts != nil && sts.UpdateRevision != sts.CurrentRevision {
+			return v1alpha1.UpgradePhase
+		}
+	case v1alpha1.ScalePhase:
+		return v1alpha1.ScalePhase
+	}
+	return ""
+}
+
+func pendingChangesOf(tc *v1alpha1.TidbCluster, memberType v1alpha1.MemberType) **v1alpha1.PendingChanges {
+	switch memberType {
+	case v1alpha1.PDMemberType:
+		return &tc.Status.PD.PendingChanges
+	case v1alpha1.TiKVMemberType:
+		return &tc.Status.TiKV.PendingChanges
+	case v1alpha1.TiDBMemberType:
+		return &tc.Status.TiDB.PendingChanges
+	case v1alpha1.TiFlashMemberType:
+		return &tc.Status.TiFlash.PendingChanges
+	case v1alpha1.TiCDCMemberType:
+		return &tc.Status.TiCDC.PendingChanges
+	case v1alpha1.TiProxyMemberType:
+		return &tc.Status.TiProxy.PendingChanges
+	}
+	return nil
+}
+
+// preStopHookShutdownPeriod is the time reserved for a component to shut down after its preStop hook exits
+const preStopHookShutdownPeriod = 30 * time.Second
+
+// setLifecycle sets the lifecycle of the main container of a component to the one in the spec of the component,
+// it must be called before setPreStopHook so that the preStop hook of the user is composed with the one of TiDB Operator.
+func setLifecycle(container *corev1.Container, lifecycle *corev1.Lifecycle) {
+	if lifecycle != nil {
+		container.Lifecycle = lifecycle.DeepCopy()
+	}
+}
+
+// setPreStopHook sets the preStop hook of the container, and keeps the terminationGracePeriodSeconds of the pod
+// consistent with the timeout of the hook: the grace period defaults to the timeout plus preStopHookShutdownPeriod,
+// and the timeout is cut down to the grace period if it is set by user.
+// The exec preStop handler already set in the container by user runs before the hook, the other lifecycle handlers
+// are kept as they are.
+func setPreStopHook(podSpec *corev1.PodSpec, container *corev1.Container, timeout time.Duration, buildScript func(timeoutSeconds int64) string) {
+	timeoutSeconds := int64(timeout.Seconds())
+	if podSpec.TerminationGracePeriodSeconds == nil {
+		gracePeriodSeconds := timeoutSeconds + int64(preStopHookShutdownPeriod.Seconds())
+		podSpec.TerminationGracePeriodSeconds = &gracePeriodSeconds
+	} else if timeoutSeconds > *podSpec.TerminationGracePeriodSeconds {
+		timeoutSeconds = *podSpec.TerminationGracePeriodSeconds
+	}
+	script := buildScript(timeoutSeconds)
+	if container.Lifecycle == nil {
+		container.Lifecycle = &corev1.Lifecycle{}
+	}
+	if preStop := container.Lifecycle.PreStop; preStop != nil && preStop.Exec != nil && len(preStop.Exec.Command) > 0 {
+		script = composePreStopScript(preStop.Exec.Command, script)
+	}
+	container.Lifecycle.PreStop = &corev1.Handler{
+		Exec: &corev1.ExecAction{
+			Command: []string{"/bin/sh", "-c", script},
+		},
+	}
+}
+
+// composePreStopScript returns the script which runs the preStop command of the user and then the script of
+// the preStop hook of TiDB Operator, the hook runs even if the command of the user fails.
+func composePreStopScript(command []string, script string) string {
+	quoted := make([]string, 0, len(command))
+	for _, arg := range command {
+		quoted = append(quoted, "'"+strings.ReplaceAll(arg, "'", `'\''`)+"'")
+	}
+	return fmt.Sprintf("%s || echo \"the preStop command in spec exits with $?\"\n%s", strings.Join(quoted, " "), script)
+}
+
+// buildDiscoveryPreStopScript returns the preStop script which asks the discovery service to hand over the
+// workload of the pod, and waits until the discovery service reports that nothing is left or the timeout.
+func buildDiscoveryPreStopScript(tc *v1alpha1.TidbCluster) func(timeoutSeconds int64) string {
+	discoveryAddr := fmt.Sprintf("%s.%s:10261", controller.DiscoveryMemberName(tc.Name), tc.Namespace)
+	return func(timeoutSeconds int64) string {
+		return fmt.Sprintf(`POD_NAME=${POD_NAME:-$HOSTNAME}
+deadline=$(($(date +%%s) + %d))
+until [ "$(wget -qO- -T 3 http://%s/prestop/${POD_NAME} 2>/dev/null)" = "0" ]; do
+    if [ $(date +%%s) -ge ${deadline} ]; then
+        echo "timeout waiting for the workload to be handed over"
+        break
+    fi
+    sleep 1
+done`, timeoutSeconds, discoveryAddr)
+	}
+}
+
+// buildDrainPreStopScript returns the preStop script of TiDB, which polls the status port of TiDB until the client
+// connections are closed or the timeout. The pod is removed from the endpoints of the service when it's terminating,
+// so no new connections come in and the existing ones are drained. The script exits immediately if the status
+// can't be fetched, e.g. TiDB is not running or the image has no curl.
+func buildDrainPreStopScript(tc *v1alpha1.TidbCluster) func(timeoutSeconds int64) string {
+	statusCmd := fmt.Sprintf("curl -s --max-time 3 %s://127.0.0.1:%d/status", tc.Scheme(), tc.Spec.TiDB.GetStatusPort())
+	if tc.IsTLSClusterEnabled() {
+		statusCmd += fmt.Sprintf(" --cacert %s --cert %s --key %s",
+			path.Join(clusterCertPath, tlsSecretRootCAKey),
+			path.Join(clusterCertPath, corev1.TLSCertKey),
+			path.Join(clusterCertPath, corev1.TLSPrivateKeyKey))
+	}
+	return func(timeoutSeconds int64) string {
+		return fmt.Sprintf(`deadline=$(($(date +%%s) + %d))
+while true; do
+    connections=$(%s 2>/dev/null | sed -n 's/.*"connections":[ ]*\([0-9]*\).*/\1/p')
+    if [ -z "${connections}" ] || [ "${connections}" = "0" ]; then
+        break
+    fi
+    if [ $(date +%%s) -ge ${deadline} ]; then
+        echo "timeout waiting for ${connections} connections to be closed"
+        break
+    fi
+    sleep 1
+done`, timeoutSeconds, statusCmd)
+	}
+}
+
+// maxTombstoneStoresInStatus bounds the tombstone stores recorded in the status of a component,
+// the tombstone stores of a large cluster scaled in and out frequently can bloat the object.
+const maxTombstoneStoresInStatus = 128
+
+// tombstoneStoresResyncInterval is the interval to fetch the tombstone stores from PD even if no store
+// disappears, so that the stores removed from PD by `remove-tombstone` are also removed from the status.
+const tombstoneStoresResyncInterval = 10 * time.Minute
+
+// tombstoneStoresSyncTimes records when the tombstone stores of the components are fetched from PD last time
+type tombstoneStoresSyncTimes struct {
+	lock  sync.Mutex
+	times map[string]time.Time
+}
+
+func newTombstoneStoresSyncTimes() *tombstoneStoresSyncTimes {
+	return &tombstoneStoresSyncTimes{times: map[string]time.Time{}}
+}
+
+// needSync returns whether the tombstone stores of the component should be fetched from PD again.
+// A store only becomes tombstone after it's removed from the Up/Down/Offline stores, so the tombstone
+// stores are fetched only if the status is not synced, a previous store disappears or they are not
+// fetched for tombstoneStoresResyncInterval.
+func (t *tombstoneStoresSyncTimes) needSync(tc *v1alpha1.TidbCluster, memberType v1alpha1.MemberType,
+	synced bool, previousStores, stores map[string]v1alpha1.TiKVStore) bool {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	now := time.Now()
+	key := fmt.Sprintf("%s/%s", tc.UID, memberType)
+	last, ok := t.times[key]
+	if !ok {
+		// the first sync after the operator starts, the resync interval is counted from now
+		t.times[key] = now
+		last = now
+	}
+	for k, tt := range t.times {
+		// the clusters not synced for long are likely deleted
+		if now.Sub(tt) > 2*tombstoneStoresResyncInterval && k != key {
+			delete(t.times, k)
+		}
+	}
+	if needSyncTombstoneStores(synced, previousStores, stores) || now.Sub(last) >= tombstoneStoresResyncInterval {
+		t.times[key] = now
+		return true
+	}
+	return false
+}
+
+// needSyncTombstoneStores returns whether some stores may have become tombstone since the last sync
+func needSyncTombstoneStores(synced bool, previousStores, stores map[string]v1alpha1.TiKVStore) bool {
+	if !synced {
+		return true
+	}
+	for id := range previousStores {
+		if _, ok := stores[id]; !ok {
+			return true
+		}
+	}
+	return false
+}
+
+// storeIDsInUse returns the IDs of the stores whose pods or PVCs of the component still exist, the tombstone
+// stores of them are still required to clean the pods and the PVCs when scaling in.
+func storeIDsInUse(deps *controller.Dependencies, tc *v1alpha1.TidbCluster, memberType v1alpha1.MemberType) (sets.String, error) {
+	selector, err := label.New().Instance(tc.GetInstanceName()).Component(memberType.String()).Selector()
+	if err != nil {
+		return nil, err
+	}
+	ids := sets.NewString()
+	pods, err := deps.PodLister.Pods(tc.Namespace).List(selector)
+	if err != nil {
+		return nil, fmt.Errorf("list pods of %s for cluster %s/%s failed, error: %v", memberType, tc.Namespace, tc.Name, err)
+	}
+	for _, pod := range pods {
+		if id := pod.Labels[label.StoreIDLabelKey]; id != "" {
+			ids.Insert(id)
+		}
+	}
+	pvcs, err := deps.PVCLister.PersistentVolumeClaims(tc.Namespace).List(selector)
+	if err != nil {
+		return nil, fmt.Errorf("list pvcs of %s for cluster %s/%s failed, error: %v", memberType, tc.Namespace, tc.Name, err)
+	}
+	for _, pvc := range pvcs {
+		if id := pvc.Labels[label.StoreIDLabelKey]; id != "" {
+			ids.Insert(id)
+		}
+	}
+	return ids, nil
+}
+
+// boundTombstoneStores keeps the tombstone stores in inUse, whose pods or PVCs still need to be cleaned, and fills
+// the rest of max with the stores with the largest IDs, which are the most recently created ones.
+func boundTombstoneStores(stores map[string]v1alpha1.TiKVStore, max int, inUse sets.String) map[string]v1alpha1.TiKVStore {
+	if len(stores) <= max {
+		return stores
+	}
+	bounded := make(map[string]v1alpha1.TiKVStore, max)
+	ids := make([]uint64, 0, len(stores))
+	for id, store := range stores {
+		if inUse.Has(id) {
+			bounded[id] = store
+			continue
+		}
+		storeID, err := strconv.ParseUint(id, 10, 64)
+		if err != nil {
+			continue
+		}
+		ids = append(ids, storeID)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] > ids[j] })
+	for _, id := range ids {
+		if len(bounded) >= max {
+			break
+		}
+		key := strconv.FormatUint(id, 10)
+		bounded[key] = stores[key]
+	}
+	return bounded
+}
+
+// syncStoreDrainStatus sets the progress of moving the regions out of the store if it's offline, the rate is the
+// average since the store was found offline, by which the completion time is estimated
+func syncStoreDrainStatus(status *v1alpha1.TiKVStore, old *v1alpha1.StoreDrainStatus, store *pdapi.StoreInfo, now metav1.Time) {
+	if status.State != v1alpha1.TiKVStateOffline || store.Status == nil {
+		status.Drain = nil
+		return
+	}
+	// the region size reported by PD is in MiB
+	remaining := store.Status.RegionSize << 20
+	drain := &v1alpha1.StoreDrainStatus{
+		StartTime:    now,
+		InitialBytes: remaining,
+	}
+	if old != nil {
+		drain.StartTime = old.StartTime
+		drain.InitialBytes = old.InitialBytes
+	}
+	drain.RegionsRemaining = int32(store.Status.RegionCount)
+	drain.BytesRemaining = remaining
+
+	minutes := now.Sub(drain.StartTime.Time).Minutes()
+	moved := drain.InitialBytes - remaining
+	if minutes >= 1 && moved > 0 {
+		drain.BytesMovedPerMinute = int64(float64(moved) / minutes)
+	}
+	if drain.BytesMovedPerMinute > 0 {
+		eta := metav1.NewTime(now.Add(time.Duration(float64(remaining) / float64(drain.BytesMovedPerMinute) * float64(time.Minute))))
+		drain.EstimatedCompletionTime = &eta
+	}
+	status.Drain = drain
+}
+
+// setZoneAffinity restricts the pods of the component to the zones declared in spec.topologyZones,
+// the requirement is added to every node selector term because the terms are ORed.
+func setZoneAffinity(podSpec *corev1.PodSpec, tc *v1alpha1.TidbCluster, memberType v1alpha1.MemberType) {
+	zones := []string{}
+	for zone, replicas := range tc.ZoneQuotas(memberType) {
+		if replicas > 0 {
+			zones = append(zones, zone)
+		}
+	}
+	if len(zones) == 0 {
+		return
+	}
+	sort.Strings(zones)
+	requirement := corev1.NodeSelectorRequirement{
+		Key:      tc.ZoneTopologyKey(),
+		Operator: corev1.NodeSelectorOpIn,
+		Values:   zones,
+	}
+
+	// the affinity is shared with the spec of the component
+	affinity := podSpec.Affinity.DeepCopy()
+	if affinity == nil {
+		affinity = &corev1.Affinity{}
+	}
+	if affinity.NodeAffinity == nil {
+		affinity.NodeAffinity = &corev1.NodeAffinity{}
+	}
+	if affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution = &corev1.NodeSelector{}
+	}
+	selector := affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution
+	if len(selector.NodeSelectorTerms) == 0 {
+		selector.NodeSelectorTerms = []corev1.NodeSelectorTerm{{}}
+	}
+	for i := range selector.NodeSelectorTerms {
+		selector.NodeSelectorTerms[i].MatchExpressions = append(selector.NodeSelectorTerms[i].MatchExpressions, requirement)
+	}
+	podSpec.Affinity = affinity
+}
@@ -24,12 +24,19 @@ import (
 	"github.com/pingcap/advanced-statefulset/client/apis/apps/v1/helper"
 	"github.com/pingcap/tidb-operator/pkg/apis/label"
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	mngerutils "github.com/pingcap/tidb-operator/pkg/manager/utils"
+	"github.com/pingcap/tidb-operator/pkg/pdapi"
 	"github.com/pingcap/tidb-operator/pkg/util"
+	apps "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	kubeinformers "k8s.io/client-go/informers"
 	kubefake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/pointer"
 )
 
 func TestGetStsAnnotations(t *testing.T) {
@@ -96,6 +103,21 @@ func TestGetStsAnnotations(t *testing.T) {
 	}
 }
 
+func TestAddOfflineOrdinals(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	anns := map[string]string{}
+	g.Expect(addOfflineOrdinals(anns, nil)).To(Succeed())
+	g.Expect(anns).To(BeEmpty())
+
+	anns[helper.DeleteSlotsAnn] = "[1,5]"
+	g.Expect(addOfflineOrdinals(anns, []int32{3, 1})).To(Succeed())
+	g.Expect(anns[helper.DeleteSlotsAnn]).To(Equal("[1,3,5]"))
+
+	anns[helper.DeleteSlotsAnn] = "invalid"
+	g.Expect(addOfflineOrdinals(anns, []int32{3})).NotTo(Succeed())
+}
+
 func TestShouldRecover(t *testing.T) {
 	notReadyPods := []*v1.Pod{
 		{
@@ -978,3 +1000,436 @@ func TestMergePatchContainersOrderPreserved(t *testing.T) {
 		}
 	}
 }
+
+func TestApplyPodTemplatePatches(t *testing.T) {
+	g := NewGomegaWithT(t)
+	newTemplate := func() *corev1.PodTemplateSpec {
+		return &corev1.PodTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels: map[string]string{"app": "tikv"},
+			},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{Name: "tikv", Image: "tikv:base"},
+					{Name: "slowlog", Image: "busybox"},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		patches []v1alpha1.PodTemplatePatch
+		expect  func(*corev1.PodTemplateSpec)
+		wantErr bool
+	}{
+		{
+			name:   "no patches",
+			expect: func(*corev1.PodTemplateSpec) {},
+		},
+		{
+			name: "strategic merge patch in yaml",
+			patches: []v1alpha1.PodTemplatePatch{
+				{
+					Patch: "spec:\n  shareProcessNamespace: true\n  containers:\n  - name: tikv\n    image: tikv:patched\n",
+				},
+			},
+			expect: func(tmpl *corev1.PodTemplateSpec) {
+				tmpl.Spec.ShareProcessNamespace = pointer.BoolPtr(true)
+				tmpl.Spec.Containers[0].Image = "tikv:patched"
+			},
+		},
+		{
+			name: "json patch applied after strategic merge patch",
+			patches: []v1alpha1.PodTemplatePatch{
+				{
+					Type:  v1alpha1.PodTemplatePatchTypeStrategicMerge,
+					Patch: `{"metadata":{"labels":{"team":"db"}}}`,
+				},
+				{
+					Type:  v1alpha1.PodTemplatePatchTypeJSON,
+					Patch: `[{"op":"remove","path":"/spec/containers/1"},{"op":"replace","path":"/metadata/labels/team","value":"infra"}]`,
+				},
+			},
+			expect: func(tmpl *corev1.PodTemplateSpec) {
+				tmpl.Labels["team"] = "infra"
+				tmpl.Spec.Containers = tmpl.Spec.Containers[:1]
+			},
+		},
+		{
+			name: "failed patch leaves template untouched",
+			patches: []v1alpha1.PodTemplatePatch{
+				{
+					Type:  v1alpha1.PodTemplatePatchTypeJSON,
+					Patch: `[{"op":"remove","path":"/spec/hostname"}]`,
+				},
+			},
+			expect:  func(*corev1.PodTemplateSpec) {},
+			wantErr: true,
+		},
+		{
+			name: "unsupported patch type",
+			patches: []v1alpha1.PodTemplatePatch{
+				{
+					Type:  "merge",
+					Patch: `{}`,
+				},
+			},
+			expect:  func(*corev1.PodTemplateSpec) {},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpl := newTemplate()
+			err := ApplyPodTemplatePatches(tmpl, tt.patches)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			expected := newTemplate()
+			tt.expect(expected)
+			if diff := cmp.Diff(expected, tmpl); diff != "" {
+				t.Errorf("unexpected pod template (-want, +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestHoldPendingChanges(t *testing.T) {
+	g := NewGomegaWithT(t)
+	newSets := func(oldImage, newImage string, newReplicas int32, newLabels map[string]string) (*apps.StatefulSet, *apps.StatefulSet) {
+		oldSet := &apps.StatefulSet{
+			Spec: apps.StatefulSetSpec{
+				Replicas: pointer.Int32Ptr(3),
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "tikv"}},
+					Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "tikv", Image: oldImage}}},
+				},
+			},
+		}
+		g.Expect(mngerutils.SetStatefulSetLastAppliedConfigAnnotation(oldSet)).To(Succeed())
+		newSet := oldSet.DeepCopy()
+		newSet.Spec.Template.Spec.Containers[0].Image = newImage
+		if newReplicas != 0 {
+			newSet.Spec.Replicas = pointer.Int32Ptr(newReplicas)
+		}
+		if newLabels != nil {
+			newSet.Spec.Template.Labels = newLabels
+		}
+		return oldSet, newSet
+	}
+
+	tests := []struct {
+		name        string
+		phase       v1alpha1.MemberPhase
+		rolling     bool
+		newImage    string
+		newReplicas int32
+		newLabels   map[string]string
+		annotations map[string]string
+		expectHeld  bool
+		expectOp    v1alpha1.MemberPhase
+	}{
+		{
+			name:     "not upgrading",
+			phase:    v1alpha1.NormalPhase,
+			newImage: "tikv:v2",
+		},
+		{
+			name:     "upgrade completed",
+			phase:    v1alpha1.UpgradePhase,
+			newImage: "tikv:v2",
+		},
+		{
+			name:     "no changes during upgrade",
+			phase:    v1alpha1.UpgradePhase,
+			rolling:  true,
+			newImage: "tikv:v1",
+		},
+		{
+			name:       "changes held during upgrade",
+			phase:      v1alpha1.UpgradePhase,
+			rolling:    true,
+			newImage:   "tikv:v2",
+			expectHeld: true,
+			expectOp:   v1alpha1.UpgradePhase,
+		},
+		{
+			name:        "replicas changes not held during upgrade",
+			phase:       v1alpha1.UpgradePhase,
+			rolling:     true,
+			newImage:    "tikv:v1",
+			newReplicas: 5,
+		},
+		{
+			name:        "pod changes held and replicas changes not held during upgrade",
+			phase:       v1alpha1.UpgradePhase,
+			rolling:     true,
+			newImage:    "tikv:v2",
+			newReplicas: 5,
+			expectHeld:  true,
+			expectOp:    v1alpha1.UpgradePhase,
+		},
+		{
+			name:       "pod metadata changes held during upgrade",
+			phase:      v1alpha1.UpgradePhase,
+			rolling:    true,
+			newImage:   "tikv:v1",
+			newLabels:  map[string]string{"app": "tikv", "team": "db"},
+			expectHeld: true,
+			expectOp:   v1alpha1.UpgradePhase,
+		},
+		{
+			name:       "changes held during scale",
+			phase:      v1alpha1.ScalePhase,
+			newImage:   "tikv:v2",
+			expectHeld: true,
+			expectOp:   v1alpha1.ScalePhase,
+		},
+		{
+			name:        "replicas changes not held during scale",
+			phase:       v1alpha1.ScalePhase,
+			newImage:    "tikv:v1",
+			newReplicas: 5,
+		},
+		{
+			name:        "changes applied during upgrade by annotation",
+			phase:       v1alpha1.UpgradePhase,
+			rolling:     true,
+			newImage:    "tikv:v2",
+			annotations: map[string]string{label.AnnApplyPendingChangesKey: label.AnnApplyPendingChangesVal},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := newTidbClusterForPD()
+			tc.Spec.TiKV = &v1alpha1.TiKVSpec{}
+			tc.Annotations = tt.annotations
+			tc.Status.TiKV.Phase = tt.phase
+			tc.Status.TiKV.StatefulSet = &apps.StatefulSetStatus{CurrentRevision: "v1", UpdateRevision: "v1"}
+			if tt.rolling {
+				tc.Status.TiKV.StatefulSet.UpdateRevision = "v2"
+			}
+			tc.Status.TiKV.PendingChanges = &v1alpha1.PendingChanges{Operation: v1alpha1.UpgradePhase}
+
+			oldSet, newSet := newSets("tikv:v1", tt.newImage, tt.newReplicas, tt.newLabels)
+			g.Expect(holdPendingChanges(tc, v1alpha1.TiKVMemberType, newSet, oldSet)).To(Succeed())
+			expectReplicas := int32(3)
+			if tt.newReplicas != 0 {
+				expectReplicas = tt.newReplicas
+			}
+			g.Expect(*newSet.Spec.Replicas).To(Equal(expectReplicas))
+			if tt.expectHeld {
+				g.Expect(newSet.Spec.Template.Spec.Containers[0].Image).To(Equal("tikv:v1"))
+				g.Expect(newSet.Spec.Template.Labels).To(Equal(map[string]string{"app": "tikv"}))
+				g.Expect(tc.Status.TiKV.PendingChanges).NotTo(BeNil())
+				g.Expect(tc.Status.TiKV.PendingChanges.Operation).To(Equal(tt.expectOp))
+			} else {
+				g.Expect(newSet.Spec.Template.Spec.Containers[0].Image).To(Equal(tt.newImage))
+				g.Expect(tc.Status.TiKV.PendingChanges).To(BeNil())
+			}
+		})
+	}
+}
+
+func TestSetPreStopHook(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// the grace period defaults to the timeout plus the shutdown period
+	tc := &v1alpha1.TidbCluster{ObjectMeta: metav1.ObjectMeta{Name: "demo", Namespace: "ns"}}
+	podSpec := &corev1.PodSpec{}
+	container := &corev1.Container{}
+	setPreStopHook(podSpec, container, time.Minute, buildDrainPreStopScript(tc))
+	g.Expect(*podSpec.TerminationGracePeriodSeconds).To(Equal(int64(90)))
+	g.Expect(container.Lifecycle.PreStop.Exec.Command[:2]).To(Equal([]string{"/bin/sh", "-c"}))
+	g.Expect(container.Lifecycle.PreStop.Exec.Command[2]).To(ContainSubstring("deadline=$(($(date +%s) + 60))"))
+
+	// the timeout is cut down to the grace period set by user
+	podSpec = &corev1.PodSpec{TerminationGracePeriodSeconds: pointer.Int64Ptr(20)}
+	container = &corev1.Container{}
+	setPreStopHook(podSpec, container, time.Minute, buildDrainPreStopScript(tc))
+	g.Expect(*podSpec.TerminationGracePeriodSeconds).To(Equal(int64(20)))
+	g.Expect(container.Lifecycle.PreStop.Exec.Command[2]).To(ContainSubstring("deadline=$(($(date +%s) + 20))"))
+
+	// the drain preStop script polls the connections of TiDB
+	script := buildDrainPreStopScript(tc)(30)
+	g.Expect(script).To(ContainSubstring("curl -s --max-time 3 http://127.0.0.1:10080/status"))
+	tc.Spec.TLSCluster = &v1alpha1.TLSCluster{Enabled: true}
+	script = buildDrainPreStopScript(tc)(30)
+	g.Expect(script).To(ContainSubstring("curl -s --max-time 3 https://127.0.0.1:10080/status --cacert"))
+
+	// the discovery preStop script waits for the workload to be handed over
+	script = buildDiscoveryPreStopScript(tc)(30)
+	g.Expect(script).To(ContainSubstring("deadline=$(($(date +%s) + 30))"))
+	g.Expect(script).To(ContainSubstring("http://demo-discovery.ns:10261/prestop/${POD_NAME}"))
+
+	// the lifecycle of the user is kept and its preStop command runs before the hook
+	podSpec = &corev1.PodSpec{}
+	container = &corev1.Container{}
+	postStart := &corev1.Handler{Exec: &corev1.ExecAction{Command: []string{"register"}}}
+	lifecycle := &corev1.Lifecycle{
+		PostStart: postStart,
+		PreStop:   &corev1.Handler{Exec: &corev1.ExecAction{Command: []string{"/bin/sh", "-c", "echo 'bye' > /dev/null"}}},
+	}
+	setLifecycle(container, lifecycle)
+	setPreStopHook(podSpec, container, time.Minute, buildDrainPreStopScript(tc))
+	g.Expect(container.Lifecycle.PostStart).To(Equal(postStart))
+	g.Expect(container.Lifecycle.PreStop.Exec.Command[2]).To(HavePrefix(`'/bin/sh' '-c' 'echo '\''bye'\'' > /dev/null' || echo`))
+	g.Expect(container.Lifecycle.PreStop.Exec.Command[2]).To(ContainSubstring("deadline=$(($(date +%s) + 60))"))
+	g.Expect(lifecycle.PreStop.Exec.Command).To(HaveLen(3))
+}
+
+func TestNeedSyncTombstoneStores(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	stores := map[string]v1alpha1.TiKVStore{"1": {ID: "1"}, "2": {ID: "2"}}
+	g.Expect(needSyncTombstoneStores(false, stores, stores)).To(BeTrue())
+	g.Expect(needSyncTombstoneStores(true, stores, stores)).To(BeFalse())
+	// a new store doesn't make any store tombstone
+	g.Expect(needSyncTombstoneStores(true, stores, map[string]v1alpha1.TiKVStore{"1": {ID: "1"}, "2": {ID: "2"}, "3": {ID: "3"}})).To(BeFalse())
+	// a store disappears
+	g.Expect(needSyncTombstoneStores(true, stores, map[string]v1alpha1.TiKVStore{"1": {ID: "1"}})).To(BeTrue())
+}
+
+func TestTombstoneStoresSyncTimes(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := &v1alpha1.TidbCluster{ObjectMeta: metav1.ObjectMeta{UID: types.UID("tc")}}
+	stores := map[string]v1alpha1.TiKVStore{"1": {ID: "1"}}
+	syncTimes := newTombstoneStoresSyncTimes()
+	g.Expect(syncTimes.needSync(tc, v1alpha1.TiKVMemberType, true, stores, stores)).To(BeFalse())
+	g.Expect(syncTimes.needSync(tc, v1alpha1.TiKVMemberType, false, stores, stores)).To(BeTrue())
+
+	// the tombstone stores are fetched again after the resync interval
+	syncTimes.times[fmt.Sprintf("%s/%s", tc.UID, v1alpha1.TiKVMemberType)] = time.Now().Add(-tombstoneStoresResyncInterval)
+	g.Expect(syncTimes.needSync(tc, v1alpha1.TiKVMemberType, true, stores, stores)).To(BeTrue())
+	g.Expect(syncTimes.needSync(tc, v1alpha1.TiKVMemberType, true, stores, stores)).To(BeFalse())
+	// the sync times of the components are recorded separately
+	g.Expect(syncTimes.needSync(tc, v1alpha1.TiFlashMemberType, true, stores, stores)).To(BeFalse())
+	g.Expect(syncTimes.times).To(HaveLen(2))
+
+	// the stale records are dropped
+	syncTimes.times["deleted/tikv"] = time.Now().Add(-3 * tombstoneStoresResyncInterval)
+	g.Expect(syncTimes.needSync(tc, v1alpha1.TiKVMemberType, true, stores, stores)).To(BeFalse())
+	g.Expect(syncTimes.times).NotTo(HaveKey("deleted/tikv"))
+}
+
+func TestBoundTombstoneStores(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	stores := map[string]v1alpha1.TiKVStore{}
+	for i := 1; i <= 10; i++ {
+		id := fmt.Sprintf("%d", i)
+		stores[id] = v1alpha1.TiKVStore{ID: id}
+	}
+	g.Expect(boundTombstoneStores(stores, 10, sets.NewString())).To(HaveLen(10))
+	bounded := boundTombstoneStores(stores, 3, sets.NewString())
+	g.Expect(bounded).To(HaveLen(3))
+	g.Expect(bounded).To(HaveKey("10"))
+	g.Expect(bounded).To(HaveKey("9"))
+	g.Expect(bounded).To(HaveKey("8"))
+
+	// the stores whose pods or PVCs still exist are never dropped
+	bounded = boundTombstoneStores(stores, 3, sets.NewString("1", "2"))
+	g.Expect(bounded).To(HaveLen(3))
+	g.Expect(bounded).To(HaveKey("1"))
+	g.Expect(bounded).To(HaveKey("2"))
+	g.Expect(bounded).To(HaveKey("10"))
+	bounded = boundTombstoneStores(stores, 3, sets.NewString("1", "2", "3", "4"))
+	g.Expect(bounded).To(HaveLen(4))
+}
+
+func TestSyncStoreDrainStatus(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	start := metav1.NewTime(time.Now().Add(-10 * time.Minute))
+	now := metav1.NewTime(start.Add(10 * time.Minute))
+	storeInfo := func(regionCount int, regionSize int64) *pdapi.StoreInfo {
+		return &pdapi.StoreInfo{Status: &pdapi.StoreStatus{RegionCount: regionCount, RegionSize: regionSize}}
+	}
+
+	// the drain starts when the store is found offline
+	status := &v1alpha1.TiKVStore{State: v1alpha1.TiKVStateOffline}
+	syncStoreDrainStatus(status, nil, storeInfo(100, 300), start)
+	g.Expect(status.Drain).NotTo(BeNil())
+	g.Expect(status.Drain.StartTime).To(Equal(start))
+	g.Expect(status.Drain.InitialBytes).To(Equal(int64(300 << 20)))
+	g.Expect(status.Drain.RegionsRemaining).To(Equal(int32(100)))
+	g.Expect(status.Drain.BytesMovedPerMinute).To(BeZero())
+	g.Expect(status.Drain.EstimatedCompletionTime).To(BeNil())
+
+	// the completion time is estimated by the average rate
+	old := status.Drain
+	status = &v1alpha1.TiKVStore{State: v1alpha1.TiKVStateOffline}
+	syncStoreDrainStatus(status, old, storeInfo(50, 200), now)
+	g.Expect(status.Drain.StartTime).To(Equal(start))
+	g.Expect(status.Drain.RegionsRemaining).To(Equal(int32(50)))
+	g.Expect(status.Drain.BytesRemaining).To(Equal(int64(200 << 20)))
+	g.Expect(status.Drain.BytesMovedPerMinute).To(Equal(int64(10 << 20)))
+	g.Expect(status.Drain.EstimatedCompletionTime).NotTo(BeNil())
+	g.Expect(status.Drain.EstimatedCompletionTime.Time).To(BeTemporally("~", now.Add(20*time.Minute), time.Second))
+
+	// the status is cleared once the store is not offline
+	status = &v1alpha1.TiKVStore{State: v1alpha1.TiKVStateUp}
+	syncStoreDrainStatus(status, old, storeInfo(50, 200), now)
+	g.Expect(status.Drain).To(BeNil())
+}
+
+func TestStoreIDsInUse(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	deps := controller.NewFakeDependencies()
+	tc := newTidbClusterForPD()
+	podLabels := label.New().Instance(tc.GetInstanceName()).TiKV().Labels()
+	podLabels[label.StoreIDLabelKey] = "1"
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "tikv-0", Namespace: tc.Namespace, Labels: podLabels}}
+	g.Expect(deps.KubeInformerFactory.Core().V1().Pods().Informer().GetIndexer().Add(pod)).To(Succeed())
+	pvcLabels := label.New().Instance(tc.GetInstanceName()).TiKV().Labels()
+	pvcLabels[label.StoreIDLabelKey] = "2"
+	pvc := &corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: "tikv-tikv-1", Namespace: tc.Namespace, Labels: pvcLabels}}
+	g.Expect(deps.KubeInformerFactory.Core().V1().PersistentVolumeClaims().Informer().GetIndexer().Add(pvc)).To(Succeed())
+
+	ids, err := storeIDsInUse(deps, tc, v1alpha1.TiKVMemberType)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ids.List()).To(Equal([]string{"1", "2"}))
+	ids, err = storeIDsInUse(deps, tc, v1alpha1.TiFlashMemberType)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ids.Len()).To(BeZero())
+}
+
+func TestSetZoneAffinity(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := &v1alpha1.TidbCluster{}
+	tc.Spec.TiKV = &v1alpha1.TiKVSpec{}
+	podSpec := &corev1.PodSpec{}
+	setZoneAffinity(podSpec, tc, v1alpha1.TiKVMemberType)
+	g.Expect(podSpec.Affinity).To(BeNil())
+
+	tc.Spec.TopologyZones = &v1alpha1.TopologyZones{
+		TiKV: map[string]int32{"us-east-1b": 2, "us-east-1a": 2, "us-east-1c": 0},
+	}
+	userAffinity := &corev1.Affinity{
+		NodeAffinity: &corev1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+				NodeSelectorTerms: []corev1.NodeSelectorTerm{
+					{MatchExpressions: []corev1.NodeSelectorRequirement{{Key: "dedicated", Operator: corev1.NodeSelectorOpExists}}},
+				},
+			},
+		},
+	}
+	podSpec = &corev1.PodSpec{Affinity: userAffinity}
+	setZoneAffinity(podSpec, tc, v1alpha1.TiKVMemberType)
+	terms := podSpec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+	g.Expect(terms).To(HaveLen(1))
+	g.Expect(terms[0].MatchExpressions).To(Equal([]corev1.NodeSelectorRequirement{
+		{Key: "dedicated", Operator: corev1.NodeSelectorOpExists},
+		{Key: corev1.LabelTopologyZone, Operator: corev1.NodeSelectorOpIn, Values: []string{"us-east-1a", "us-east-1b"}},
+	}))
+	// the affinity of the spec is not changed
+	g.Expect(userAffinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[0].MatchExpressions).To(HaveLen(1))
+}
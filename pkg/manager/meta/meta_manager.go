@@ -15,12 +15,16 @@ package meta
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/pingcap/tidb-operator/pkg/apis/label"
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
 	"github.com/pingcap/tidb-operator/pkg/controller"
 	"github.com/pingcap/tidb-operator/pkg/manager"
+	mngerutils "github.com/pingcap/tidb-operator/pkg/manager/utils"
+	"github.com/pingcap/tidb-operator/pkg/metrics"
 	"github.com/pingcap/tidb-operator/pkg/util"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/klog/v2"
 )
@@ -81,11 +85,22 @@ func (m *metaManager) Sync(tc *v1alpha1.TidbCluster) error {
 			}
 			return err
 		}
+		var md v1alpha1.ObjectMetadata
+		if spec := tc.ComponentSpec(v1alpha1.MemberType(pod.Labels[label.ComponentLabelKey])); spec != nil {
+			md = spec.Metadata()
+		}
 		for _, pvc := range pvcs {
-			_, err = m.deps.PVCControl.UpdateMetaInfo(tc, pvc, pod)
+			updated, err := m.deps.PVCControl.UpdateMetaInfo(tc, pvc, pod)
 			if err != nil {
 				return err
 			}
+			// the volume claim templates of the StatefulSet can not be updated, so the custom
+			// metadata is propagated to the PVCs here
+			if updated = updated.DeepCopy(); controller.PropagateMetadata(updated, md) {
+				if _, err = m.deps.PVCControl.UpdatePVC(tc, updated); err != nil {
+					return err
+				}
+			}
 			if pvc.Spec.VolumeName == "" {
 				continue
 			}
@@ -107,6 +122,50 @@ func (m *metaManager) Sync(tc *v1alpha1.TidbCluster) error {
 		}
 	}
 
+	return m.syncPodRestarts(tc, pods)
+}
+
+// syncPodRestarts annotates the pods recreated by the restarts initiated by the operator with the causes
+// recorded in the status of the TidbCluster, and counts the restarts by the causes.
+func (m *metaManager) syncPodRestarts(tc *v1alpha1.TidbCluster, pods []*corev1.Pod) error {
+	if len(tc.Status.PodRestarts) == 0 {
+		return nil
+	}
+	podsByName := make(map[string]*corev1.Pod, len(pods))
+	for _, pod := range pods {
+		podsByName[pod.Name] = pod
+	}
+
+	for podName, restart := range tc.Status.PodRestarts {
+		pod, ok := podsByName[podName]
+		if !ok || pod.UID == restart.PodUID {
+			// the pod is not recreated yet, the causes not followed by a restart in time are dropped,
+			// e.g. the upgrade is reverted before the pod is upgraded
+			if time.Since(restart.Time.Time) > mngerutils.PodRestartRecordTTL {
+				delete(tc.Status.PodRestarts, podName)
+			}
+			continue
+		}
+
+		// the new pod is already annotated if the status failed to be updated last time
+		if pod.Annotations[label.AnnRestartReasonKey] != restart.Reason || pod.Annotations[label.AnnRestartDetailKey] != restart.Detail {
+			newPod := pod.DeepCopy()
+			if newPod.Annotations == nil {
+				newPod.Annotations = map[string]string{}
+			}
+			newPod.Annotations[label.AnnRestartReasonKey] = restart.Reason
+			newPod.Annotations[label.AnnRestartDetailKey] = restart.Detail
+			if _, err := m.deps.PodControl.UpdatePod(tc, newPod); err != nil {
+				return fmt.Errorf("metaManager.syncPodRestarts: failed to annotate pod %s/%s with the restart reason %s, error: %v", pod.Namespace, podName, restart.Reason, err)
+			}
+			klog.Infof("pod %s/%s of cluster %s was restarted by the operator, reason: %s, detail: %q", pod.Namespace, podName, tc.GetName(), restart.Reason, restart.Detail)
+			metrics.ClusterPodRestarts.WithLabelValues(tc.GetNamespace(), tc.GetName(), pod.Labels[label.ComponentLabelKey], restart.Reason).Inc()
+		}
+		delete(tc.Status.PodRestarts, podName)
+	}
+	if len(tc.Status.PodRestarts) == 0 {
+		tc.Status.PodRestarts = nil
+	}
 	return nil
 }
 
@@ -15,6 +15,7 @@ package meta
 
 import (
 	"testing"
+	"time"
 
 	"fmt"
 
@@ -721,6 +722,69 @@ func TestMetaManagerSyncMultiPVC(t *testing.T) {
 	}
 }
 
+func TestMetaManagerSyncPVCMetadata(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbClusterForMeta()
+	tc.Spec.Metadata = &v1alpha1.ObjectMetadata{
+		Labels:      map[string]string{"team": "db", "cost-center": "cluster"},
+		Annotations: map[string]string{"owner": "dba"},
+	}
+	tc.Spec.TiKV = &v1alpha1.TiKVSpec{}
+	tc.Spec.TiKV.Metadata = &v1alpha1.ObjectMetadata{
+		Labels: map[string]string{"cost-center": "tikv", label.ComponentLabelKey: "user"},
+	}
+	pvc1 := newPVC(tc, "1")
+	pvc1.Labels["team"] = "storage"
+	pod1 := newPod(tc)
+
+	nmm, _, _, _, podIndexer, pvcIndexer, pvIndexer := newFakeMetaManager()
+	g.Expect(podIndexer.Add(pod1)).To(Succeed())
+	g.Expect(pvcIndexer.Add(pvc1)).To(Succeed())
+	g.Expect(pvIndexer.Add(newPV("1"))).To(Succeed())
+
+	g.Expect(nmm.Sync(tc)).To(Succeed())
+
+	pvc, err := nmm.deps.PVCLister.PersistentVolumeClaims(tc.GetNamespace()).Get(pvc1.Name)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(pvcMetaInfoMatchDesire(pvc)).To(BeTrue())
+	// the existing labels and the labels managed by TiDB Operator are not overridden
+	g.Expect(pvc.Labels["team"]).To(Equal("storage"))
+	g.Expect(pvc.Labels[label.ComponentLabelKey]).To(Equal(controller.TestComponentName))
+	// the component-level metadata overrides the cluster-level one
+	g.Expect(pvc.Labels["cost-center"]).To(Equal("tikv"))
+	g.Expect(pvc.Annotations["owner"]).To(Equal("dba"))
+}
+
+func TestMetaManagerSyncPodRestarts(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbClusterForMeta()
+	pod1 := newPod(tc)
+	tc.Status.PodRestarts = map[string]v1alpha1.PodRestart{
+		// the pod is recreated with a new UID
+		pod1.Name: {Reason: label.RestartReasonUpgrade, Detail: "pingcap/pd:v8.1.0", PodUID: types.UID("old"), Time: metav1.Now()},
+		// the pod is not recreated yet
+		"pending": {Reason: label.RestartReasonFailover, PodUID: types.UID("pending"), Time: metav1.Now()},
+		// the pod is not recreated in time
+		"expired": {Reason: label.RestartReasonConfigChange, PodUID: types.UID("expired"), Time: metav1.NewTime(time.Now().Add(-2 * time.Hour))},
+	}
+
+	nmm, _, _, _, podIndexer, pvcIndexer, pvIndexer := newFakeMetaManager()
+	g.Expect(podIndexer.Add(pod1)).To(Succeed())
+	g.Expect(pvcIndexer.Add(newPVC(tc, "1"))).To(Succeed())
+	g.Expect(pvIndexer.Add(newPV("1"))).To(Succeed())
+
+	g.Expect(nmm.Sync(tc)).To(Succeed())
+
+	pod, err := nmm.deps.PodLister.Pods(pod1.Namespace).Get(pod1.Name)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(pod.Annotations[label.AnnRestartReasonKey]).To(Equal(label.RestartReasonUpgrade))
+	g.Expect(pod.Annotations[label.AnnRestartDetailKey]).To(Equal("pingcap/pd:v8.1.0"))
+	g.Expect(tc.Status.PodRestarts).To(HaveLen(1))
+	g.Expect(tc.Status.PodRestarts).To(HaveKey("pending"))
+}
+
 func newFakeMetaManager() (
 	*metaManager,
 	*controller.FakePodControl,
@@ -0,0 +1,343 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package meta
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/manager"
+	corev1 "k8s.io/api/core/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type orphanResourceJanitor struct {
+	deps *controller.Dependencies
+
+	lock sync.Mutex
+	// reported holds the reason last reported for each resource, so that a resource is reported once
+	reported map[types.UID]string
+}
+
+// NewOrphanResourceJanitor returns a manager that detects the services, configmaps and PDBs labeled for a
+// TidbCluster but no longer referenced by its spec, e.g. the ones of a removed component, the configmaps
+// not mounted by any pod or the PDBs not selecting the pods of the component, and reports, adopts or prunes
+// them by the orphan resource policy.
+func NewOrphanResourceJanitor(deps *controller.Dependencies) manager.Manager {
+	return &orphanResourceJanitor{deps: deps, reported: map[types.UID]string{}}
+}
+
+// componentMemberName returns the name of the StatefulSet of the components managed by the janitor
+var componentMemberName = map[string]func(string) string{
+	label.PDLabelVal:      controller.PDMemberName,
+	label.TiKVLabelVal:    controller.TiKVMemberName,
+	label.TiDBLabelVal:    controller.TiDBMemberName,
+	label.TiFlashLabelVal: controller.TiFlashMemberName,
+	label.TiCDCLabelVal:   controller.TiCDCMemberName,
+	label.TiProxyLabelVal: controller.TiProxyMemberName,
+	label.PumpLabelVal:    controller.PumpMemberName,
+}
+
+func (j *orphanResourceJanitor) Sync(tc *v1alpha1.TidbCluster) error {
+	policy := j.deps.CLIConfig.OrphanResourcePolicy
+	if policy == "" || policy == controller.OrphanResourcePolicyIgnore || tc.DeletionTimestamp != nil {
+		return nil
+	}
+
+	ns := tc.GetNamespace()
+	selector, err := label.New().Instance(tc.GetInstanceName()).Selector()
+	if err != nil {
+		return err
+	}
+	svcs, err := j.deps.ServiceLister.Services(ns).List(selector)
+	if err != nil {
+		return fmt.Errorf("orphanResourceJanitor.Sync: failed to list services for tc %s/%s, error: %v", ns, tc.Name, err)
+	}
+	cms, err := j.deps.ConfigMapLister.ConfigMaps(ns).List(selector)
+	if err != nil {
+		return fmt.Errorf("orphanResourceJanitor.Sync: failed to list configmaps for tc %s/%s, error: %v", ns, tc.Name, err)
+	}
+
+	enabled := enabledComponents(tc)
+	mountedCMs := map[string]sets.String{}
+	for _, svc := range svcs {
+		component := svc.Labels[label.ComponentLabelKey]
+		if !j.managed(tc, svc, component) {
+			continue
+		}
+		if err := j.handle(tc, policy, svc.DeepCopy(), !enabled.Has(component)); err != nil {
+			return err
+		}
+	}
+	for _, cm := range cms {
+		component := cm.Labels[label.ComponentLabelKey]
+		if !j.managed(tc, cm, component) {
+			continue
+		}
+		orphan := !enabled.Has(component)
+		if !orphan {
+			if _, ok := mountedCMs[component]; !ok {
+				mounted, err := j.mountedConfigMaps(tc, component)
+				if err != nil {
+					return err
+				}
+				mountedCMs[component] = mounted
+			}
+			// the configmaps of a component not created yet are not pruned
+			orphan = mountedCMs[component] != nil && !mountedCMs[component].Has(cm.Name)
+		}
+		if err := j.handle(tc, policy, cm.DeepCopy(), orphan); err != nil {
+			return err
+		}
+	}
+
+	// the PDBs are not cached by the informers, as they are only listed when the policy is not Ignore
+	pdbs, err := j.deps.KubeClientset.PolicyV1beta1().PodDisruptionBudgets(ns).List(context.TODO(), metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return fmt.Errorf("orphanResourceJanitor.Sync: failed to list pdbs for tc %s/%s, error: %v", ns, tc.Name, err)
+	}
+	for i := range pdbs.Items {
+		pdb := &pdbs.Items[i]
+		component := pdb.Labels[label.ComponentLabelKey]
+		if !j.managed(tc, pdb, component) {
+			continue
+		}
+		orphan := !enabled.Has(component)
+		if !orphan {
+			stale, err := j.stalePDB(tc, pdb, component)
+			if err != nil {
+				return err
+			}
+			orphan = stale
+		}
+		if err := j.handle(tc, policy, pdb, orphan); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// stalePDB returns whether the PDB doesn't select the pods of the component any more, e.g. the labels of
+// the pods are changed. The PDBs of a component not created yet are not stale.
+func (j *orphanResourceJanitor) stalePDB(tc *v1alpha1.TidbCluster, pdb *policyv1beta1.PodDisruptionBudget, component string) (bool, error) {
+	ns := tc.GetNamespace()
+	set, err := j.deps.StatefulSetLister.StatefulSets(ns).Get(componentMemberName[component](tc.Name))
+	if errors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("orphanResourceJanitor: failed to get sts of %s for tc %s/%s, error: %v", component, ns, tc.Name, err)
+	}
+	selector, err := metav1.LabelSelectorAsSelector(pdb.Spec.Selector)
+	if err != nil {
+		// a PDB with an invalid selector protects nothing
+		return true, nil
+	}
+	return selector.Empty() || !selector.Matches(labels.Set(set.Spec.Template.Labels)), nil
+}
+
+// report records an event for obj once until the reason changes
+func (j *orphanResourceJanitor) report(tc *v1alpha1.TidbCluster, obj client.Object, reason, messageFmt string, args ...interface{}) {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+	key := reason + ":" + messageFmt
+	if j.reported[obj.GetUID()] == key {
+		return
+	}
+	j.reported[obj.GetUID()] = key
+	j.deps.Recorder.Eventf(tc, corev1.EventTypeWarning, reason, messageFmt, args...)
+}
+
+// forget removes the reported reason of obj
+func (j *orphanResourceJanitor) forget(obj client.Object) {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+	delete(j.reported, obj.GetUID())
+}
+
+// managed returns whether obj is a resource of a component of tc, which is controlled by tc or nothing
+func (j *orphanResourceJanitor) managed(tc *v1alpha1.TidbCluster, obj metav1.Object, component string) bool {
+	if _, ok := componentMemberName[component]; !ok {
+		return false
+	}
+	ref := metav1.GetControllerOf(obj)
+	return ref == nil || ref.UID == tc.UID
+}
+
+// mountedConfigMaps returns the configmaps mounted by the StatefulSet and the pods of the component,
+// the old pods are counted as they still mount the old configmaps during a rolling update.
+// It returns nil if the StatefulSet doesn't exist.
+func (j *orphanResourceJanitor) mountedConfigMaps(tc *v1alpha1.TidbCluster, component string) (sets.String, error) {
+	ns := tc.GetNamespace()
+	set, err := j.deps.StatefulSetLister.StatefulSets(ns).Get(componentMemberName[component](tc.Name))
+	if errors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("orphanResourceJanitor: failed to get sts of %s for tc %s/%s, error: %v", component, ns, tc.Name, err)
+	}
+	mounted := sets.NewString()
+	addVolumes := func(volumes []corev1.Volume) {
+		for _, vol := range volumes {
+			if vol.ConfigMap != nil {
+				mounted.Insert(vol.ConfigMap.Name)
+			}
+			if vol.Projected != nil {
+				for _, source := range vol.Projected.Sources {
+					if source.ConfigMap != nil {
+						mounted.Insert(source.ConfigMap.Name)
+					}
+				}
+			}
+		}
+	}
+	addVolumes(set.Spec.Template.Spec.Volumes)
+
+	selector, err := metav1.LabelSelectorAsSelector(set.Spec.Selector)
+	if err != nil {
+		return nil, err
+	}
+	pods, err := j.deps.PodLister.Pods(ns).List(selector)
+	if err != nil {
+		return nil, fmt.Errorf("orphanResourceJanitor: failed to list pods of %s for tc %s/%s, error: %v", component, ns, tc.Name, err)
+	}
+	for _, pod := range pods {
+		addVolumes(pod.Spec.Volumes)
+	}
+	return mounted, nil
+}
+
+// handle reports, adopts or prunes obj by the policy
+func (j *orphanResourceJanitor) handle(tc *v1alpha1.TidbCluster, policy string, obj client.Object, orphan bool) error {
+	var kind string
+	switch obj.(type) {
+	case *corev1.Service:
+		kind = "Service"
+	case *corev1.ConfigMap:
+		kind = "ConfigMap"
+	case *policyv1beta1.PodDisruptionBudget:
+		kind = "PodDisruptionBudget"
+	}
+	unowned := metav1.GetControllerOf(obj) == nil
+	if !orphan && !unowned {
+		j.forget(obj)
+		return nil
+	}
+
+	switch policy {
+	case controller.OrphanResourcePolicyDryRun:
+		if orphan {
+			j.report(tc, obj, "OrphanResource",
+				"%s %s is no longer referenced by the spec, it would be pruned by policy %s", kind, obj.GetName(), controller.OrphanResourcePolicyPrune)
+		} else {
+			j.report(tc, obj, "OrphanResource",
+				"%s %s is not owned by the cluster, it would be adopted by policy %s", kind, obj.GetName(), controller.OrphanResourcePolicyAdopt)
+		}
+		return nil
+	case controller.OrphanResourcePolicyPrune:
+		if orphan {
+			klog.Infof("orphanResourceJanitor: prune %s %s/%s of tc %s", kind, obj.GetNamespace(), obj.GetName(), tc.Name)
+			if err := j.deps.TypedControl.Delete(tc, obj); err != nil && !errors.IsNotFound(err) {
+				return err
+			}
+			j.forget(obj)
+			j.deps.Recorder.Eventf(tc, corev1.EventTypeNormal, "OrphanResourcePruned", "%s %s is pruned", kind, obj.GetName())
+			return nil
+		}
+	case controller.OrphanResourcePolicyAdopt:
+		if orphan && !unowned {
+			j.report(tc, obj, "OrphanResource",
+				"%s %s is no longer referenced by the spec, it is kept until the cluster is deleted", kind, obj.GetName())
+			return nil
+		}
+	default:
+		return fmt.Errorf("unknown orphan resource policy %q", policy)
+	}
+
+	if !unowned {
+		return nil
+	}
+	klog.Infof("orphanResourceJanitor: adopt %s %s/%s by tc %s", kind, obj.GetNamespace(), obj.GetName(), tc.Name)
+	obj.SetOwnerReferences(append(obj.GetOwnerReferences(), controller.GetOwnerRef(tc)))
+	var err error
+	switch o := obj.(type) {
+	case *corev1.Service:
+		_, err = j.deps.KubeClientset.CoreV1().Services(o.Namespace).Update(context.TODO(), o, metav1.UpdateOptions{})
+	case *corev1.ConfigMap:
+		_, err = j.deps.KubeClientset.CoreV1().ConfigMaps(o.Namespace).Update(context.TODO(), o, metav1.UpdateOptions{})
+	case *policyv1beta1.PodDisruptionBudget:
+		_, err = j.deps.KubeClientset.PolicyV1beta1().PodDisruptionBudgets(o.Namespace).Update(context.TODO(), o, metav1.UpdateOptions{})
+	}
+	if err != nil {
+		return fmt.Errorf("orphanResourceJanitor: failed to adopt %s %s/%s by tc %s, error: %v", kind, obj.GetNamespace(), obj.GetName(), tc.Name, err)
+	}
+	j.deps.Recorder.Eventf(tc, corev1.EventTypeNormal, "OrphanResourceAdopted", "%s %s is adopted", kind, obj.GetName())
+	return nil
+}
+
+// enabledComponents returns the label values of the components in the spec of tc
+func enabledComponents(tc *v1alpha1.TidbCluster) sets.String {
+	components := sets.NewString()
+	if tc.Spec.PD != nil {
+		components.Insert(label.PDLabelVal)
+	}
+	if tc.Spec.TiKV != nil {
+		components.Insert(label.TiKVLabelVal)
+	}
+	if tc.Spec.TiDB != nil {
+		components.Insert(label.TiDBLabelVal)
+	}
+	if tc.Spec.TiFlash != nil {
+		components.Insert(label.TiFlashLabelVal)
+	}
+	if tc.Spec.TiCDC != nil {
+		components.Insert(label.TiCDCLabelVal)
+	}
+	if tc.Spec.TiProxy != nil {
+		components.Insert(label.TiProxyLabelVal)
+	}
+	if tc.Spec.Pump != nil {
+		components.Insert(label.PumpLabelVal)
+	}
+	return components
+}
+
+var _ manager.Manager = &orphanResourceJanitor{}
+
+type FakeOrphanResourceJanitor struct {
+	err error
+}
+
+func NewFakeOrphanResourceJanitor() *FakeOrphanResourceJanitor {
+	return &FakeOrphanResourceJanitor{}
+}
+
+func (m *FakeOrphanResourceJanitor) SetSyncError(err error) {
+	m.err = err
+}
+
+func (m *FakeOrphanResourceJanitor) Sync(_ *v1alpha1.TidbCluster) error {
+	return m.err
+}
@@ -0,0 +1,189 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package meta
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	apps "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestOrphanResourceJanitorSync(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := context.TODO()
+
+	type testcase struct {
+		name    string
+		policy  string
+		pruned  []string
+		adopted []string
+	}
+	tests := []testcase{
+		{
+			name:   "ignore",
+			policy: controller.OrphanResourcePolicyIgnore,
+		},
+		{
+			name:   "dry run",
+			policy: controller.OrphanResourcePolicyDryRun,
+		},
+		{
+			name:    "adopt",
+			policy:  controller.OrphanResourcePolicyAdopt,
+			adopted: []string{"test-pd-unowned"},
+		},
+		{
+			name:    "prune",
+			policy:  controller.OrphanResourcePolicyPrune,
+			pruned:  []string{"test-tidb", "test-pd-old", "test-tidb-pdb", "test-pd-stale"},
+			adopted: []string{"test-pd-unowned"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Log(tt.name)
+
+		deps := controller.NewFakeDependencies()
+		deps.CLIConfig.OrphanResourcePolicy = tt.policy
+		janitor := NewOrphanResourceJanitor(deps)
+
+		tc := newTidbClusterForMeta()
+		tc.Spec.PD = &v1alpha1.PDSpec{}
+		tc.Spec.TiKV = &v1alpha1.TiKVSpec{}
+
+		owned := []metav1.OwnerReference{controller.GetOwnerRef(tc)}
+		pdLabels := label.New().Instance(tc.Name).PD().Labels()
+		tidbLabels := label.New().Instance(tc.Name).TiDB().Labels()
+		objs := []client.Object{
+			// the services of pd are referenced, the ones of the removed tidb are orphan
+			&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "test-pd", Labels: pdLabels, OwnerReferences: owned}},
+			&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "test-tidb", Labels: tidbLabels, OwnerReferences: owned}},
+			// the configmap mounted by the sts and the one mounted by an old pod are referenced
+			&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test-pd-new", Labels: pdLabels, OwnerReferences: owned}},
+			&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test-pd-rolling", Labels: pdLabels, OwnerReferences: owned}},
+			&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test-pd-old", Labels: pdLabels, OwnerReferences: owned}},
+			// the configmap mounted by the sts but not owned by the cluster
+			&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test-pd-unowned", Labels: pdLabels}},
+			// the configmap of the tikv not created yet is kept
+			&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test-tikv", Labels: label.New().Instance(tc.Name).TiKV().Labels(), OwnerReferences: owned}},
+			// the pdb of pd selecting its pods is referenced, the one of the removed tidb and the stale one are orphan
+			&policyv1beta1.PodDisruptionBudget{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-pd-pdb", Labels: pdLabels, OwnerReferences: owned},
+				Spec:       policyv1beta1.PodDisruptionBudgetSpec{Selector: label.New().Instance(tc.Name).PD().LabelSelector()},
+			},
+			&policyv1beta1.PodDisruptionBudget{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-tidb-pdb", Labels: tidbLabels, OwnerReferences: owned},
+				Spec:       policyv1beta1.PodDisruptionBudgetSpec{Selector: label.New().Instance(tc.Name).TiDB().LabelSelector()},
+			},
+			&policyv1beta1.PodDisruptionBudget{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-pd-stale", Labels: pdLabels, OwnerReferences: owned},
+				Spec:       policyv1beta1.PodDisruptionBudgetSpec{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "removed"}}},
+			},
+		}
+		svcIndexer := deps.KubeInformerFactory.Core().V1().Services().Informer().GetIndexer()
+		cmIndexer := deps.KubeInformerFactory.Core().V1().ConfigMaps().Informer().GetIndexer()
+		for _, obj := range objs {
+			obj.SetNamespace(tc.Namespace)
+			g.Expect(deps.GenericClient.Create(ctx, obj.DeepCopyObject().(client.Object))).To(Succeed())
+			switch o := obj.(type) {
+			case *corev1.Service:
+				_, err := deps.KubeClientset.CoreV1().Services(tc.Namespace).Create(ctx, o.DeepCopy(), metav1.CreateOptions{})
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(svcIndexer.Add(o)).To(Succeed())
+			case *corev1.ConfigMap:
+				_, err := deps.KubeClientset.CoreV1().ConfigMaps(tc.Namespace).Create(ctx, o.DeepCopy(), metav1.CreateOptions{})
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(cmIndexer.Add(o)).To(Succeed())
+			case *policyv1beta1.PodDisruptionBudget:
+				_, err := deps.KubeClientset.PolicyV1beta1().PodDisruptionBudgets(tc.Namespace).Create(ctx, o.DeepCopy(), metav1.CreateOptions{})
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		}
+
+		cmVolume := func(name string) corev1.Volume {
+			return corev1.Volume{Name: name, VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{LocalObjectReference: corev1.LocalObjectReference{Name: name}},
+			}}
+		}
+		set := &apps.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Name: controller.PDMemberName(tc.Name), Namespace: tc.Namespace},
+			Spec: apps.StatefulSetSpec{
+				Selector: label.New().Instance(tc.Name).PD().LabelSelector(),
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{Labels: pdLabels},
+					Spec: corev1.PodSpec{
+						Volumes: []corev1.Volume{cmVolume("test-pd-new"), cmVolume("test-pd-unowned")},
+					},
+				},
+			},
+		}
+		g.Expect(deps.KubeInformerFactory.Apps().V1().StatefulSets().Informer().GetIndexer().Add(set)).To(Succeed())
+		g.Expect(deps.KubeInformerFactory.Core().V1().Pods().Informer().GetIndexer().Add(&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-pd-0", Namespace: tc.Namespace, Labels: pdLabels},
+			Spec:       corev1.PodSpec{Volumes: []corev1.Volume{cmVolume("test-pd-rolling")}},
+		})).To(Succeed())
+
+		g.Expect(janitor.Sync(tc)).To(Succeed())
+		// the resources are reported once
+		events := len(deps.Recorder.(*record.FakeRecorder).Events)
+		if tt.policy == controller.OrphanResourcePolicyDryRun {
+			g.Expect(events).To(Equal(5))
+		}
+		g.Expect(janitor.Sync(tc)).To(Succeed())
+		// the informers are not updated by the adoption and the pruning in the test
+		if tt.policy == controller.OrphanResourcePolicyIgnore || tt.policy == controller.OrphanResourcePolicyDryRun {
+			g.Expect(deps.Recorder.(*record.FakeRecorder).Events).To(HaveLen(events))
+		}
+
+		for _, obj := range objs {
+			key := client.ObjectKeyFromObject(obj)
+			err := deps.GenericClient.Get(ctx, key, obj.DeepCopyObject().(client.Object))
+			if contains(tt.pruned, obj.GetName()) {
+				g.Expect(errors.IsNotFound(err)).To(BeTrue(), obj.GetName())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred(), obj.GetName())
+			}
+		}
+		for _, obj := range objs {
+			cm, ok := obj.(*corev1.ConfigMap)
+			if !ok {
+				continue
+			}
+			updated, err := deps.KubeClientset.CoreV1().ConfigMaps(tc.Namespace).Get(ctx, cm.Name, metav1.GetOptions{})
+			g.Expect(err).NotTo(HaveOccurred())
+			adopted := len(cm.OwnerReferences) == 0 && metav1.IsControlledBy(updated, tc)
+			g.Expect(adopted).To(Equal(contains(tt.adopted, cm.Name)), cm.Name)
+		}
+	}
+}
+
+func contains(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
@@ -38,27 +38,40 @@ func NewReclaimPolicyManager(deps *controller.Dependencies) *reclaimPolicyManage
 	}
 }
 
+// pvcReclaimPolicy returns the reclaim policy of the PV bound to the PVC, and whether the PVC is deleted after
+// it's left by the scale-in, in which case the PV is reclaimed by the PVC cleaner
+type pvcReclaimPolicy func(pvc *corev1.PersistentVolumeClaim) (corev1.PersistentVolumeReclaimPolicy, bool)
+
+func uniformReclaimPolicy(isPVReclaimEnabled bool, policy corev1.PersistentVolumeReclaimPolicy) pvcReclaimPolicy {
+	return func(_ *corev1.PersistentVolumeClaim) (corev1.PersistentVolumeReclaimPolicy, bool) {
+		return policy, isPVReclaimEnabled
+	}
+}
+
 func (m *reclaimPolicyManager) Sync(tc *v1alpha1.TidbCluster) error {
-	return m.sync(v1alpha1.TiDBClusterKind, tc, tc.IsPVReclaimEnabled(), *tc.Spec.PVReclaimPolicy)
+	return m.sync(v1alpha1.TiDBClusterKind, tc, func(pvc *corev1.PersistentVolumeClaim) (corev1.PersistentVolumeReclaimPolicy, bool) {
+		typ := v1alpha1.MemberType(label.Label(pvc.Labels).ComponentType())
+		return tc.PVReclaimPolicy(typ), tc.IsScaleInPVCDeleted(typ)
+	})
 }
 
 func (m *reclaimPolicyManager) SyncMonitor(tm *v1alpha1.TidbMonitor) error {
-	return m.sync(v1alpha1.TiDBMonitorKind, tm, false, *tm.Spec.PVReclaimPolicy)
+	return m.sync(v1alpha1.TiDBMonitorKind, tm, uniformReclaimPolicy(false, *tm.Spec.PVReclaimPolicy))
 }
 
 func (m *reclaimPolicyManager) SyncTiDBNGMonitoring(tngm *v1alpha1.TidbNGMonitoring) error {
-	return m.sync(v1alpha1.TiDBNGMonitoringKind, tngm, false, *tngm.Spec.PVReclaimPolicy)
+	return m.sync(v1alpha1.TiDBNGMonitoringKind, tngm, uniformReclaimPolicy(false, *tngm.Spec.PVReclaimPolicy))
 }
 
 func (m *reclaimPolicyManager) SyncDM(dc *v1alpha1.DMCluster) error {
-	return m.sync(v1alpha1.DMClusterKind, dc, dc.IsPVReclaimEnabled(), *dc.Spec.PVReclaimPolicy)
+	return m.sync(v1alpha1.DMClusterKind, dc, uniformReclaimPolicy(dc.IsPVReclaimEnabled(), *dc.Spec.PVReclaimPolicy))
 }
 
 func (m *reclaimPolicyManager) SyncTiDBDashboard(td *v1alpha1.TidbDashboard) error {
-	return m.sync(v1alpha1.TiDBDashboardKind, td, false, *td.Spec.PVReclaimPolicy)
+	return m.sync(v1alpha1.TiDBDashboardKind, td, uniformReclaimPolicy(false, *td.Spec.PVReclaimPolicy))
 }
 
-func (m *reclaimPolicyManager) sync(kind string, obj runtime.Object, isPVReclaimEnabled bool, policy corev1.PersistentVolumeReclaimPolicy) error {
+func (m *reclaimPolicyManager) sync(kind string, obj runtime.Object, policyOf pvcReclaimPolicy) error {
 	if m.deps.PVLister == nil {
 		klog.V(4).Infof("Persistent volumes lister is unavailable, skip syncing reclaim policy for %s. This may be caused by no relevant permissions", kind)
 		return nil
@@ -98,6 +111,7 @@ func (m *reclaimPolicyManager) sync(kind string, obj runtime.Object, isPVReclaim
 		if pvc.Spec.VolumeName == "" {
 			continue
 		}
+		policy, isPVReclaimEnabled := policyOf(pvc)
 		if isPVReclaimEnabled && len(pvc.Annotations[label.AnnPVCDeferDeleting]) != 0 {
 			// If the PV reclaim setting is enabled, and when PV is a candidate to be reclaimed, skip patching this PV.
 			continue
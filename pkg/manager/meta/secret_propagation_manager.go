@@ -0,0 +1,224 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package meta
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	listers "github.com/pingcap/tidb-operator/pkg/client/listers/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/features"
+	"github.com/pingcap/tidb-operator/pkg/manager"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	errorutils "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/klog/v2"
+)
+
+const (
+	secretPropagatedReason        = "SecretPropagated"
+	secretNotGrantedReason        = "SecretNotGranted"
+	secretPropagationFailedReason = "SecretPropagationFailed"
+)
+
+type secretPropagationManager struct {
+	deps *controller.Dependencies
+	// grantLister is nil if the SecretPropagation feature is disabled
+	grantLister listers.TidbClusterSecretGrantLister
+}
+
+// NewSecretPropagationManager returns a manager that copies the secrets of the cluster referenced by spec.cluster into
+// the namespace of the TidbCluster by spec.secretPropagation. A secret is only copied if it's granted to the TidbCluster
+// by a TidbClusterSecretGrant in the namespace of the referenced cluster. The copy is annotated with the hash of the
+// data of the secret, and it's updated when the hash of the secret or of the copy itself changes. The copies are
+// not updated any more after the grant is revoked, and the secrets not copied by TiDB Operator are left untouched.
+func NewSecretPropagationManager(deps *controller.Dependencies) manager.Manager {
+	m := &secretPropagationManager{deps: deps}
+	if features.DefaultFeatureGate.Enabled(features.SecretPropagation) {
+		m.grantLister = deps.InformerFactory.Pingcap().V1alpha1().TidbClusterSecretGrants().Lister()
+	}
+	return m
+}
+
+func (m *secretPropagationManager) Sync(tc *v1alpha1.TidbCluster) error {
+	if tc.Spec.SecretPropagation == nil || tc.Spec.Cluster == nil || tc.Spec.Cluster.Name == "" {
+		return nil
+	}
+	ns := tc.GetNamespace()
+	tcName := tc.GetName()
+	if m.grantLister == nil {
+		klog.Warningf("tidbcluster: [%s/%s]'s secrets are not propagated as the %s feature is disabled", ns, tcName, features.SecretPropagation)
+		return nil
+	}
+
+	srcNs := tc.Spec.Cluster.Namespace
+	if srcNs == "" {
+		srcNs = ns
+	}
+	srcTc, err := m.deps.TiDBClusterLister.TidbClusters(srcNs).Get(tc.Spec.Cluster.Name)
+	if err != nil {
+		return fmt.Errorf("tidbcluster: [%s/%s] failed to get the referenced tidbcluster %s/%s: %v", ns, tcName, srcNs, tc.Spec.Cluster.Name, err)
+	}
+	grants, err := m.grantLister.TidbClusterSecretGrants(srcNs).List(labels.Everything())
+	if err != nil {
+		return fmt.Errorf("tidbcluster: [%s/%s] failed to list the secret grants in namespace %s: %v", ns, tcName, srcNs, err)
+	}
+
+	var errs []error
+	for _, secret := range propagatedSecrets(tc, srcTc) {
+		if secret.TargetName == "" {
+			secret.TargetName = secret.Name
+		}
+		if srcNs == ns && secret.TargetName == secret.Name {
+			continue
+		}
+		if !secretGranted(grants, srcTc, tc, secret.Name) {
+			klog.Warningf("tidbcluster: [%s/%s]'s secret %s/%s is not granted by any TidbClusterSecretGrant", ns, tcName, srcNs, secret.Name)
+			m.deps.Recorder.Eventf(tc, corev1.EventTypeWarning, secretNotGrantedReason, "secret %s/%s is not granted by any TidbClusterSecretGrant", srcNs, secret.Name)
+			continue
+		}
+		if err := m.propagate(tc, srcNs, secret); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errorutils.NewAggregate(errs)
+}
+
+// propagate copies the secret into the namespace of the TidbCluster if the copy is missing or out of date
+func (m *secretPropagationManager) propagate(tc *v1alpha1.TidbCluster, srcNs string, secret v1alpha1.PropagatedSecret) error {
+	ns := tc.GetNamespace()
+	tcName := tc.GetName()
+	from := fmt.Sprintf("%s/%s", srcNs, secret.Name)
+
+	src, err := m.deps.SecretLister.Secrets(srcNs).Get(secret.Name)
+	if err != nil {
+		return fmt.Errorf("tidbcluster: [%s/%s] failed to get secret %s to propagate: %v", ns, tcName, from, err)
+	}
+	hash, err := secretDataHash(src.Data)
+	if err != nil {
+		return err
+	}
+
+	dst, err := m.deps.SecretLister.Secrets(ns).Get(secret.TargetName)
+	if err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("tidbcluster: [%s/%s] failed to get secret %s: %v", ns, tcName, secret.TargetName, err)
+	}
+	if err == nil {
+		if dst.Annotations[label.AnnPropagatedFromKey] != from {
+			klog.Warningf("tidbcluster: [%s/%s]'s secret %s is not copied from %s by TiDB Operator, skip propagating", ns, tcName, secret.TargetName, from)
+			m.deps.Recorder.Eventf(tc, corev1.EventTypeWarning, secretPropagationFailedReason, "secret %s is not copied from %s by TiDB Operator, delete it to propagate the secret", secret.TargetName, from)
+			return nil
+		}
+		// the copy is updated if either the secret or the copy itself changes
+		if dstHash, err := secretDataHash(dst.Data); err == nil && dstHash == hash && dst.Annotations[label.AnnPropagatedHashKey] == hash {
+			return nil
+		}
+	}
+
+	data := make(map[string][]byte, len(src.Data))
+	for k, v := range src.Data {
+		data[k] = v
+	}
+	copied := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      secret.TargetName,
+			Namespace: ns,
+			Labels:    label.New().Instance(tcName).Labels(),
+			Annotations: map[string]string{
+				label.AnnPropagatedFromKey: from,
+				label.AnnPropagatedHashKey: hash,
+			},
+		},
+		Type: src.Type,
+		Data: data,
+	}
+	if _, err := m.deps.TypedControl.CreateOrUpdateSecret(tc, copied); err != nil {
+		return fmt.Errorf("tidbcluster: [%s/%s] failed to copy secret %s to %s: %v", ns, tcName, from, secret.TargetName, err)
+	}
+	klog.Infof("tidbcluster: [%s/%s]'s secret %s is copied from %s", ns, tcName, secret.TargetName, from)
+	m.deps.Recorder.Eventf(tc, corev1.EventTypeNormal, secretPropagatedReason, "secret %s is copied from %s", secret.TargetName, from)
+	return nil
+}
+
+// propagatedSecrets returns the secrets to copy, it's the client secret of the referenced cluster by default
+func propagatedSecrets(tc, srcTc *v1alpha1.TidbCluster) []v1alpha1.PropagatedSecret {
+	if len(tc.Spec.SecretPropagation.Secrets) > 0 {
+		return tc.Spec.SecretPropagation.Secrets
+	}
+	return []v1alpha1.PropagatedSecret{{
+		Name:       clientSecretName(srcTc),
+		TargetName: clientSecretName(tc),
+	}}
+}
+
+// secretGranted returns whether the secret of the referenced cluster is granted to the TidbCluster
+func secretGranted(grants []*v1alpha1.TidbClusterSecretGrant, srcTc, tc *v1alpha1.TidbCluster, secretName string) bool {
+	for _, grant := range grants {
+		if grant.Spec.Cluster != srcTc.GetName() {
+			continue
+		}
+		subjectGranted := false
+		for _, subject := range grant.Spec.From {
+			if subject.Namespace == tc.GetNamespace() && (subject.Name == "" || subject.Name == tc.GetName()) {
+				subjectGranted = true
+				break
+			}
+		}
+		if !subjectGranted {
+			continue
+		}
+		secretNames := grant.Spec.SecretNames
+		if len(secretNames) == 0 {
+			secretNames = []string{clientSecretName(srcTc)}
+		}
+		for _, name := range secretNames {
+			if name == secretName {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// secretDataHash returns the hash of the data of a secret, the keys are sorted when marshaling the map
+func secretDataHash(data map[string][]byte) (string, error) {
+	b, err := json.Marshal(data)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal the data of the secret: %v", err)
+	}
+	return v1alpha1.HashContents(b), nil
+}
+
+var _ manager.Manager = &FakeSecretPropagationManager{}
+
+type FakeSecretPropagationManager struct {
+	err error
+}
+
+func NewFakeSecretPropagationManager() *FakeSecretPropagationManager {
+	return &FakeSecretPropagationManager{}
+}
+
+func (m *FakeSecretPropagationManager) SetSyncError(err error) {
+	m.err = err
+}
+
+func (m *FakeSecretPropagationManager) Sync(_ *v1alpha1.TidbCluster) error {
+	return m.err
+}
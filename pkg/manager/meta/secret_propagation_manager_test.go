@@ -0,0 +1,123 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package meta
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestSecretPropagationManagerSync(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	deps := controller.NewFakeDependencies()
+	cli := deps.GenericControl.(*controller.FakeGenericControl).FakeCli
+	secretIndexer := deps.KubeInformerFactory.Core().V1().Secrets().Informer().GetIndexer()
+	tcIndexer := deps.InformerFactory.Pingcap().V1alpha1().TidbClusters().Informer().GetIndexer()
+	grantInformer := deps.InformerFactory.Pingcap().V1alpha1().TidbClusterSecretGrants()
+	m := &secretPropagationManager{deps: deps, grantLister: grantInformer.Lister()}
+
+	srcTc := &v1alpha1.TidbCluster{ObjectMeta: metav1.ObjectMeta{Namespace: "primary", Name: "basic"}}
+	g.Expect(tcIndexer.Add(srcTc)).To(Succeed())
+	src := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "primary", Name: "basic-cluster-client-secret"},
+		Type:       corev1.SecretTypeTLS,
+		Data:       map[string][]byte{"ca.crt": []byte("ca"), "tls.crt": []byte("crt"), "tls.key": []byte("key")},
+	}
+	g.Expect(secretIndexer.Add(src)).To(Succeed())
+
+	tc := newTidbClusterForMeta()
+	tc.Namespace = "secondary"
+	g.Expect(m.Sync(tc)).To(Succeed())
+
+	tc.Spec.Cluster = &v1alpha1.TidbClusterRef{Namespace: "primary", Name: "basic"}
+	tc.Spec.SecretPropagation = &v1alpha1.SecretPropagation{}
+	getCopy := func() *corev1.Secret {
+		secret := &corev1.Secret{}
+		if err := cli.Get(context.TODO(), types.NamespacedName{Namespace: "secondary", Name: "test-cluster-client-secret"}, secret); err != nil {
+			return nil
+		}
+		return secret
+	}
+
+	// not granted
+	g.Expect(m.Sync(tc)).To(Succeed())
+	g.Expect(getCopy()).To(BeNil())
+
+	// the grant is for another namespace
+	grant := &v1alpha1.TidbClusterSecretGrant{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "primary", Name: "grant"},
+		Spec: v1alpha1.TidbClusterSecretGrantSpec{
+			Cluster: "basic",
+			From:    []v1alpha1.SecretGrantSubject{{Namespace: "others"}},
+		},
+	}
+	g.Expect(grantInformer.Informer().GetIndexer().Add(grant)).To(Succeed())
+	g.Expect(m.Sync(tc)).To(Succeed())
+	g.Expect(getCopy()).To(BeNil())
+
+	// the client secret is granted by default
+	grant.Spec.From = append(grant.Spec.From, v1alpha1.SecretGrantSubject{Namespace: "secondary", Name: tc.Name})
+	g.Expect(grantInformer.Informer().GetIndexer().Update(grant)).To(Succeed())
+	g.Expect(m.Sync(tc)).To(Succeed())
+	copied := getCopy()
+	g.Expect(copied).NotTo(BeNil())
+	g.Expect(copied.Type).To(Equal(corev1.SecretTypeTLS))
+	g.Expect(copied.Data).To(Equal(src.Data))
+	g.Expect(copied.Annotations[label.AnnPropagatedFromKey]).To(Equal("primary/basic-cluster-client-secret"))
+	hash := copied.Annotations[label.AnnPropagatedHashKey]
+	g.Expect(hash).NotTo(BeEmpty())
+	g.Expect(copied.OwnerReferences).To(HaveLen(1))
+
+	// the copy is synced when the secret changes
+	g.Expect(secretIndexer.Add(copied)).To(Succeed())
+	src.Data["tls.crt"] = []byte("renewed")
+	g.Expect(secretIndexer.Update(src)).To(Succeed())
+	g.Expect(m.Sync(tc)).To(Succeed())
+	copied = getCopy()
+	g.Expect(copied.Data["tls.crt"]).To(Equal([]byte("renewed")))
+	g.Expect(copied.Annotations[label.AnnPropagatedHashKey]).NotTo(Equal(hash))
+
+	// the other secrets must be granted explicitly
+	tc.Spec.SecretPropagation.Secrets = []v1alpha1.PropagatedSecret{{Name: "basic-ca-secret"}}
+	g.Expect(secretIndexer.Add(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "primary", Name: "basic-ca-secret"},
+		Data:       map[string][]byte{"ca.crt": []byte("ca")},
+	})).To(Succeed())
+	g.Expect(m.Sync(tc)).To(Succeed())
+	err := cli.Get(context.TODO(), types.NamespacedName{Namespace: "secondary", Name: "basic-ca-secret"}, &corev1.Secret{})
+	g.Expect(err).To(HaveOccurred())
+
+	grant.Spec.SecretNames = []string{"basic-ca-secret"}
+	g.Expect(grantInformer.Informer().GetIndexer().Update(grant)).To(Succeed())
+	g.Expect(m.Sync(tc)).To(Succeed())
+	g.Expect(cli.Get(context.TODO(), types.NamespacedName{Namespace: "secondary", Name: "basic-ca-secret"}, &corev1.Secret{})).To(Succeed())
+
+	// the secret not copied by TiDB Operator is not overwritten
+	tc.Spec.SecretPropagation.Secrets = []v1alpha1.PropagatedSecret{{Name: "basic-ca-secret", TargetName: "user-secret"}}
+	g.Expect(secretIndexer.Add(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "secondary", Name: "user-secret"},
+	})).To(Succeed())
+	g.Expect(m.Sync(tc)).To(Succeed())
+	err = cli.Get(context.TODO(), types.NamespacedName{Namespace: "secondary", Name: "user-secret"}, &corev1.Secret{})
+	g.Expect(err).To(HaveOccurred())
+}
@@ -0,0 +1,215 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package meta
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/manager"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	"k8s.io/kubernetes/pkg/util/slice"
+)
+
+// teardownOrder is the order to tear down the components, the components depending on the others go first
+var teardownOrder = []struct {
+	memberType v1alpha1.MemberType
+	memberName func(string) string
+}{
+	{v1alpha1.TiDBMemberType, controller.TiDBMemberName},
+	{v1alpha1.TiProxyMemberType, controller.TiProxyMemberName},
+	{v1alpha1.TiCDCMemberType, controller.TiCDCMemberName},
+	{v1alpha1.PumpMemberType, controller.PumpMemberName},
+	{v1alpha1.TiFlashMemberType, controller.TiFlashMemberName},
+	{v1alpha1.TiKVMemberType, controller.TiKVMemberName},
+	{v1alpha1.PDMemberType, controller.PDMemberName},
+}
+
+type teardownManager struct {
+	deps *controller.Dependencies
+}
+
+// NewTeardownManager returns a manager that keeps the teardown finalizer on the TidbClusters with the teardown
+// enabled, and tears down a deleted TidbCluster component by component: the StatefulSet of a component is deleted,
+// then its PVCs are deleted with bounded concurrency after its pods are gone. The progress is derived from the
+// remaining resources in each sync, so the teardown is resumed after the operator restarts. The finalizer is
+// removed once all the components are torn down.
+func NewTeardownManager(deps *controller.Dependencies) manager.Manager {
+	return &teardownManager{deps: deps}
+}
+
+func (m *teardownManager) Sync(tc *v1alpha1.TidbCluster) error {
+	hasFinalizer := slice.ContainsString(tc.Finalizers, label.TeardownFinalizer, nil)
+	if tc.DeletionTimestamp == nil {
+		if tc.Spec.Teardown != nil && !hasFinalizer {
+			return m.patchFinalizers(tc, append(tc.Finalizers, label.TeardownFinalizer))
+		}
+		if tc.Spec.Teardown == nil && hasFinalizer {
+			return m.patchFinalizers(tc, slice.RemoveString(tc.Finalizers, label.TeardownFinalizer, nil))
+		}
+		return nil
+	}
+	if !hasFinalizer {
+		return nil
+	}
+	// the teardown is disabled after the deletion, the resources are left to the garbage collector
+	if tc.Spec.Teardown == nil {
+		return m.patchFinalizers(tc, slice.RemoveString(tc.Finalizers, label.TeardownFinalizer, nil))
+	}
+
+	ns := tc.GetNamespace()
+	if tc.Status.Teardown == nil {
+		tc.Status.Teardown = &v1alpha1.TeardownStatus{StartTime: metav1.Now()}
+	}
+	remaining, err := m.listPVCs(tc, "")
+	if err != nil {
+		return err
+	}
+	tc.Status.Teardown.RemainingPVCs = int32(len(remaining))
+
+	for _, component := range teardownOrder {
+		done, err := m.teardown(tc, component.memberType, component.memberName(tc.GetName()))
+		if err != nil {
+			return err
+		}
+		if !done {
+			return controller.RequeueErrorf("tidbcluster %s/%s: tearing down %s, %d PVCs remaining",
+				ns, tc.GetName(), component.memberType, tc.Status.Teardown.RemainingPVCs)
+		}
+	}
+
+	klog.Infof("tidbcluster %s/%s: teardown is done in %v, remove finalizer %s", ns, tc.GetName(),
+		metav1.Now().Sub(tc.Status.Teardown.StartTime.Time), label.TeardownFinalizer)
+	return m.patchFinalizers(tc, slice.RemoveString(tc.Finalizers, label.TeardownFinalizer, nil))
+}
+
+// teardown deletes the StatefulSet of the component and then its PVCs, and returns whether the component is torn down
+func (m *teardownManager) teardown(tc *v1alpha1.TidbCluster, memberType v1alpha1.MemberType, setName string) (bool, error) {
+	ns := tc.GetNamespace()
+	status := tc.Status.Teardown
+	status.Component = memberType
+	status.DeletingPVCs = 0
+
+	set, err := m.deps.StatefulSetLister.StatefulSets(ns).Get(setName)
+	if err != nil && !errors.IsNotFound(err) {
+		return false, fmt.Errorf("teardownManager.teardown: failed to get sts %s for tc %s/%s, error: %v", setName, ns, tc.GetName(), err)
+	}
+	if err == nil {
+		if set.DeletionTimestamp == nil {
+			if err := m.deps.StatefulSetControl.DeleteStatefulSet(tc, set); err != nil && !errors.IsNotFound(err) {
+				return false, err
+			}
+			klog.Infof("tidbcluster %s/%s: teardown deletes sts %s", ns, tc.GetName(), setName)
+		}
+		return false, nil
+	}
+
+	selector, err := label.New().Instance(tc.GetInstanceName()).Component(string(memberType)).Selector()
+	if err != nil {
+		return false, err
+	}
+	pods, err := m.deps.PodLister.Pods(ns).List(selector)
+	if err != nil {
+		return false, fmt.Errorf("teardownManager.teardown: failed to list pods for tc %s/%s, error: %v", ns, tc.GetName(), err)
+	}
+	// the PVCs are protected until the pods using them are gone, so wait for the pods before deleting the PVCs
+	if len(pods) > 0 {
+		return false, nil
+	}
+	if !tc.Spec.Teardown.DeletePVCs {
+		return true, nil
+	}
+
+	pvcs, err := m.listPVCs(tc, memberType)
+	if err != nil {
+		return false, err
+	}
+	var pending []*corev1.PersistentVolumeClaim
+	for _, pvc := range pvcs {
+		if pvc.DeletionTimestamp != nil {
+			status.DeletingPVCs++
+		} else {
+			pending = append(pending, pvc)
+		}
+	}
+	for _, pvc := range pending {
+		if int(status.DeletingPVCs) >= tc.TeardownMaxConcurrentPVCDeletions() {
+			break
+		}
+		if err := m.deps.PVCControl.DeletePVC(tc, pvc); err != nil && !errors.IsNotFound(err) {
+			return false, err
+		}
+		status.DeletingPVCs++
+	}
+	return len(pvcs) == 0, nil
+}
+
+// listPVCs lists the PVCs of the component, or all the PVCs of the cluster if the component is empty
+func (m *teardownManager) listPVCs(tc *v1alpha1.TidbCluster, memberType v1alpha1.MemberType) ([]*corev1.PersistentVolumeClaim, error) {
+	l := label.New().Instance(tc.GetInstanceName())
+	if memberType != "" {
+		l = l.Component(string(memberType))
+	}
+	selector, err := l.Selector()
+	if err != nil {
+		return nil, err
+	}
+	pvcs, err := m.deps.PVCLister.PersistentVolumeClaims(tc.GetNamespace()).List(selector)
+	if err != nil {
+		return nil, fmt.Errorf("teardownManager.listPVCs: failed to list pvcs for tc %s/%s, error: %v", tc.GetNamespace(), tc.GetName(), err)
+	}
+	return pvcs, nil
+}
+
+// patchFinalizers patches the finalizers of the TidbCluster, the status update after the sync sends the whole
+// object, so the finalizers of tc are updated too
+func (m *teardownManager) patchFinalizers(tc *v1alpha1.TidbCluster, finalizers []string) error {
+	data, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"finalizers": finalizers,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if _, err := m.deps.TiDBClusterControl.Patch(tc, data); err != nil {
+		return fmt.Errorf("failed to patch finalizers of tc %s/%s, error: %v", tc.GetNamespace(), tc.GetName(), err)
+	}
+	tc.Finalizers = finalizers
+	return nil
+}
+
+var _ manager.Manager = &teardownManager{}
+
+type FakeTeardownManager struct {
+	err error
+}
+
+func NewFakeTeardownManager() *FakeTeardownManager {
+	return &FakeTeardownManager{}
+}
+
+func (m *FakeTeardownManager) SetSyncError(err error) {
+	m.err = err
+}
+
+func (m *FakeTeardownManager) Sync(_ *v1alpha1.TidbCluster) error {
+	return m.err
+}
@@ -0,0 +1,115 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package meta
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	apps "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+func TestTeardownManagerFinalizer(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	m := NewTeardownManager(controller.NewFakeDependencies())
+	tc := newTidbClusterForMeta()
+	g.Expect(m.Sync(tc)).To(Succeed())
+	g.Expect(tc.Finalizers).To(BeEmpty())
+
+	tc.Spec.Teardown = &v1alpha1.TeardownSpec{}
+	g.Expect(m.Sync(tc)).To(Succeed())
+	g.Expect(tc.Finalizers).To(Equal([]string{label.TeardownFinalizer}))
+
+	tc.Spec.Teardown = nil
+	g.Expect(m.Sync(tc)).To(Succeed())
+	g.Expect(tc.Finalizers).To(BeEmpty())
+}
+
+func TestTeardownManagerSync(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	deps := controller.NewFakeDependencies()
+	setIndexer := deps.KubeInformerFactory.Apps().V1().StatefulSets().Informer().GetIndexer()
+	podIndexer := deps.KubeInformerFactory.Core().V1().Pods().Informer().GetIndexer()
+	pvcIndexer := deps.KubeInformerFactory.Core().V1().PersistentVolumeClaims().Informer().GetIndexer()
+	m := NewTeardownManager(deps)
+
+	tc := newTidbClusterForMeta()
+	now := metav1.Now()
+	tc.DeletionTimestamp = &now
+	tc.Finalizers = []string{label.TeardownFinalizer}
+	tc.Spec.Teardown = &v1alpha1.TeardownSpec{DeletePVCs: true, MaxConcurrentPVCDeletions: pointer.Int32Ptr(1)}
+
+	newSet := func(name string) *apps.StatefulSet {
+		return &apps.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: tc.Namespace}}
+	}
+	pdLabels := label.New().Instance(tc.Name).PD().Labels()
+	newPVC := func(name string, deleting bool) *corev1.PersistentVolumeClaim {
+		pvc := &corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: tc.Namespace, Labels: pdLabels}}
+		if deleting {
+			pvc.DeletionTimestamp = &now
+		}
+		return pvc
+	}
+	tidbSet := newSet(controller.TiDBMemberName(tc.Name))
+	pdSet := newSet(controller.PDMemberName(tc.Name))
+	pdPod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pd-0", Namespace: tc.Namespace, Labels: pdLabels}}
+	deletingPVC := newPVC("pd-pd-0", true)
+	g.Expect(setIndexer.Add(tidbSet)).To(Succeed())
+	g.Expect(setIndexer.Add(pdSet)).To(Succeed())
+	g.Expect(podIndexer.Add(pdPod)).To(Succeed())
+	g.Expect(pvcIndexer.Add(deletingPVC)).To(Succeed())
+	g.Expect(pvcIndexer.Add(newPVC("pd-pd-1", false))).To(Succeed())
+	g.Expect(pvcIndexer.Add(newPVC("pd-pd-2", false))).To(Succeed())
+
+	// tidb goes first
+	err := m.Sync(tc)
+	g.Expect(controller.IsRequeueError(err)).To(BeTrue())
+	g.Expect(tc.Status.Teardown.Component).To(Equal(v1alpha1.TiDBMemberType))
+	g.Expect(tc.Status.Teardown.RemainingPVCs).To(Equal(int32(3)))
+
+	// pd waits for its pods after the sts is deleted
+	g.Expect(setIndexer.Delete(tidbSet)).To(Succeed())
+	g.Expect(setIndexer.Delete(pdSet)).To(Succeed())
+	err = m.Sync(tc)
+	g.Expect(controller.IsRequeueError(err)).To(BeTrue())
+	g.Expect(tc.Status.Teardown.Component).To(Equal(v1alpha1.PDMemberType))
+	g.Expect(pvcIndexer.ListKeys()).To(HaveLen(3))
+
+	// no more PVCs are deleted while the max number of PVCs are being deleted
+	g.Expect(podIndexer.Delete(pdPod)).To(Succeed())
+	err = m.Sync(tc)
+	g.Expect(controller.IsRequeueError(err)).To(BeTrue())
+	g.Expect(tc.Status.Teardown.DeletingPVCs).To(Equal(int32(1)))
+	g.Expect(pvcIndexer.ListKeys()).To(HaveLen(3))
+
+	g.Expect(pvcIndexer.Delete(deletingPVC)).To(Succeed())
+	err = m.Sync(tc)
+	g.Expect(controller.IsRequeueError(err)).To(BeTrue())
+	g.Expect(pvcIndexer.ListKeys()).To(HaveLen(1))
+	err = m.Sync(tc)
+	g.Expect(controller.IsRequeueError(err)).To(BeTrue())
+	g.Expect(pvcIndexer.ListKeys()).To(BeEmpty())
+
+	// the finalizer is removed once all the components are torn down
+	g.Expect(m.Sync(tc)).To(Succeed())
+	g.Expect(tc.Finalizers).To(BeEmpty())
+}
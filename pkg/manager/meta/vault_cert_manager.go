@@ -0,0 +1,304 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package meta
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/manager"
+	"github.com/pingcap/tidb-operator/pkg/util"
+	"github.com/pingcap/tidb-operator/pkg/vaultapi"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	errorutils "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/pointer"
+)
+
+const (
+	// vaultCertCommonName is the common name of the certificates issued from Vault
+	vaultCertCommonName = "TiDB"
+	// vaultTokenExpirationSeconds is the lifetime of the service account token requested to log in to Vault
+	vaultTokenExpirationSeconds = 600
+
+	vaultCertIssuedReason       = "CertificateIssued"
+	vaultSecretNotManagedReason = "SecretNotManaged"
+)
+
+// serviceAccountTokenFile is the token of the service account of TiDB Operator
+var serviceAccountTokenFile = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+
+// vaultCert is a certificate to be issued from Vault and the secret to store it
+type vaultCert struct {
+	secretName string
+	// component is empty for the client certificate
+	component string
+	dnsNames  []string
+	ipSANs    []string
+}
+
+type vaultCertManager struct {
+	deps *controller.Dependencies
+}
+
+// NewVaultCertManager returns a manager that issues the certificates of the components and the client from the PKI
+// secrets engine of Vault if spec.tlsCluster.vault is set, and writes them into the <clusterName>-<componentName>-cluster-secret
+// and the client secret. A certificate is issued again when it expires in spec.tlsCluster.vault.renewBefore, or one third
+// of its lifetime by default. The secrets not created by TiDB Operator are left untouched.
+func NewVaultCertManager(deps *controller.Dependencies) manager.Manager {
+	return &vaultCertManager{deps: deps}
+}
+
+func (m *vaultCertManager) Sync(tc *v1alpha1.TidbCluster) error {
+	vault := tc.VaultPKI()
+	if vault == nil {
+		return nil
+	}
+	ns := tc.GetNamespace()
+	tcName := tc.GetName()
+
+	var client vaultapi.Client
+	var token string
+	var errs []error
+	now := time.Now()
+	for _, cert := range desiredVaultCerts(tc) {
+		secret, err := m.deps.SecretLister.Secrets(ns).Get(cert.secretName)
+		if err != nil && !errors.IsNotFound(err) {
+			errs = append(errs, fmt.Errorf("failed to get secret %s/%s: %v", ns, cert.secretName, err))
+			continue
+		}
+		if err == nil && secret.Labels[label.ManagedByLabelKey] != label.TiDBOperator {
+			klog.Warningf("tidbcluster: [%s/%s]'s secret %s is not managed by TiDB Operator, skip issuing its certificate from Vault", ns, tcName, cert.secretName)
+			m.deps.Recorder.Eventf(tc, corev1.EventTypeWarning, vaultSecretNotManagedReason, "secret %s is not created by TiDB Operator, delete it to issue the certificate from Vault", cert.secretName)
+			continue
+		}
+		if err == nil {
+			renew, reason := certNeedsRenewal(secret, vault.RenewBefore, now)
+			if !renew {
+				continue
+			}
+			klog.Infof("tidbcluster: [%s/%s]'s certificate in secret %s needs to be renewed: %s", ns, tcName, cert.secretName, reason)
+		}
+
+		// log in to Vault once in a sync when any certificate is to be issued
+		if client == nil {
+			client, token, err = m.login(tc)
+			if err != nil {
+				return controller.RequeueErrorf("tidbcluster: [%s/%s] failed to log in to Vault %s: %v", ns, tcName, vault.Address, err)
+			}
+		}
+		if err := m.issue(tc, client, token, cert); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errorutils.NewAggregate(errs)
+}
+
+// login logs in to Vault by the Kubernetes auth method and returns the client and the Vault token
+func (m *vaultCertManager) login(tc *v1alpha1.TidbCluster) (vaultapi.Client, string, error) {
+	vault := tc.Spec.TLSCluster.Vault
+	tlsConfig, err := m.vaultTLSConfig(tc)
+	if err != nil {
+		return nil, "", err
+	}
+	jwt, err := m.serviceAccountToken(tc)
+	if err != nil {
+		return nil, "", err
+	}
+	client := vaultapi.NewClient(vault.Address, vaultapi.DefaultTimeout, tlsConfig)
+	token, err := client.LoginKubernetes(vault.Auth.GetPath(), vault.Auth.Role, jwt)
+	if err != nil {
+		return nil, "", err
+	}
+	return client, token, nil
+}
+
+// vaultTLSConfig returns the TLS config to verify the Vault server, it's nil to use the system CAs
+func (m *vaultCertManager) vaultTLSConfig(tc *v1alpha1.TidbCluster) (*tls.Config, error) {
+	vault := tc.Spec.TLSCluster.Vault
+	if vault.CASecretName == "" {
+		return nil, nil
+	}
+	secret, err := m.deps.SecretLister.Secrets(tc.GetNamespace()).Get(vault.CASecretName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the CA secret %s of Vault: %v", vault.CASecretName, err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(secret.Data[corev1.ServiceAccountRootCAKey]) {
+		return nil, fmt.Errorf("no CA certificate in %s of secret %s", corev1.ServiceAccountRootCAKey, vault.CASecretName)
+	}
+	return &tls.Config{RootCAs: pool}, nil
+}
+
+// serviceAccountToken returns the token to log in to Vault, it's requested for the service account in the namespace of
+// the cluster if it's set, or else the token of TiDB Operator itself
+func (m *vaultCertManager) serviceAccountToken(tc *v1alpha1.TidbCluster) (string, error) {
+	saName := tc.Spec.TLSCluster.Vault.Auth.ServiceAccountName
+	if saName == "" {
+		data, err := os.ReadFile(serviceAccountTokenFile)
+		if err != nil {
+			return "", fmt.Errorf("failed to read the service account token: %v", err)
+		}
+		return strings.TrimSpace(string(data)), nil
+	}
+	req := &authenticationv1.TokenRequest{
+		Spec: authenticationv1.TokenRequestSpec{ExpirationSeconds: pointer.Int64Ptr(vaultTokenExpirationSeconds)},
+	}
+	resp, err := m.deps.KubeClientset.CoreV1().ServiceAccounts(tc.GetNamespace()).CreateToken(context.TODO(), saName, req, metav1.CreateOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to request a token of service account %s: %v", saName, err)
+	}
+	return resp.Status.Token, nil
+}
+
+// issue issues the certificate from Vault and writes it into the secret
+func (m *vaultCertManager) issue(tc *v1alpha1.TidbCluster, client vaultapi.Client, token string, cert *vaultCert) error {
+	ns := tc.GetNamespace()
+	tcName := tc.GetName()
+	vault := tc.Spec.TLSCluster.Vault
+
+	req := &vaultapi.IssueRequest{
+		CommonName: vaultCertCommonName,
+		AltNames:   strings.Join(cert.dnsNames, ","),
+		IPSANs:     strings.Join(cert.ipSANs, ","),
+	}
+	if vault.TTL != nil {
+		req.TTL = vault.TTL.Duration.String()
+	}
+	issued, err := client.IssueCertificate(token, vault.GetPath(), vault.Role, req)
+	if err != nil {
+		return fmt.Errorf("tidbcluster: [%s/%s] failed to issue the certificate of secret %s from Vault: %v", ns, tcName, cert.secretName, err)
+	}
+
+	l := label.New().Instance(tcName)
+	if cert.component != "" {
+		l = l.Component(cert.component)
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cert.secretName,
+			Namespace: ns,
+			Labels:    l.Labels(),
+		},
+		Type: corev1.SecretTypeTLS,
+		Data: map[string][]byte{
+			corev1.TLSCertKey:              []byte(issued.Certificate),
+			corev1.TLSPrivateKeyKey:        []byte(issued.PrivateKey),
+			corev1.ServiceAccountRootCAKey: []byte(issued.CA()),
+		},
+	}
+	if _, err := m.deps.TypedControl.CreateOrUpdateSecret(tc, secret); err != nil {
+		return fmt.Errorf("tidbcluster: [%s/%s] failed to write the certificate into secret %s: %v", ns, tcName, cert.secretName, err)
+	}
+	klog.Infof("tidbcluster: [%s/%s]'s certificate in secret %s is issued from Vault", ns, tcName, cert.secretName)
+	m.deps.Recorder.Eventf(tc, corev1.EventTypeNormal, vaultCertIssuedReason, "certificate in secret %s is issued from Vault", cert.secretName)
+	return nil
+}
+
+// desiredVaultCerts returns the certificates of the components in the spec and the client
+func desiredVaultCerts(tc *v1alpha1.TidbCluster) []*vaultCert {
+	var certs []*vaultCert
+	for _, spec := range tc.AllComponentSpec() {
+		// the discovery uses the client certificate
+		if spec.MemberType() == v1alpha1.DiscoveryMemberType {
+			continue
+		}
+		component := spec.MemberType().String()
+		certs = append(certs, &vaultCert{
+			secretName: util.ClusterTLSSecretName(tc.GetName(), component),
+			component:  component,
+			dnsNames:   vaultCertDNSNames(tc, fmt.Sprintf("%s-%s", tc.GetName(), component)),
+			ipSANs:     []string{"127.0.0.1", "::1"},
+		})
+	}
+	certs = append(certs, &vaultCert{secretName: clientSecretName(tc)})
+	return certs
+}
+
+// clientSecretName returns the name of the secret of the cluster client certificate of the tc
+func clientSecretName(tc *v1alpha1.TidbCluster) string {
+	if tc.Spec.TLSCluster != nil && tc.Spec.TLSCluster.ClientSecretName != "" {
+		return tc.Spec.TLSCluster.ClientSecretName
+	}
+	return util.ClusterClientTLSSecretName(tc.GetName())
+}
+
+// vaultCertDNSNames returns the DNS names of the service and the peer service of a component, including the
+// wildcard names of the pods
+func vaultCertDNSNames(tc *v1alpha1.TidbCluster, svcName string) []string {
+	ns := tc.GetNamespace()
+	var names []string
+	for _, name := range []string{svcName, svcName + "-peer", "*." + svcName + "-peer"} {
+		names = append(names, name, fmt.Sprintf("%s.%s", name, ns), fmt.Sprintf("%s.%s.svc", name, ns))
+		if tc.Spec.ClusterDomain != "" {
+			names = append(names, fmt.Sprintf("%s.%s.svc.%s", name, ns, tc.Spec.ClusterDomain))
+		}
+	}
+	return names
+}
+
+// certNeedsRenewal returns whether the certificate in the secret needs to be issued again and the reason
+func certNeedsRenewal(secret *corev1.Secret, renewBefore *metav1.Duration, now time.Time) (bool, string) {
+	for _, key := range []string{corev1.TLSCertKey, corev1.TLSPrivateKeyKey, corev1.ServiceAccountRootCAKey} {
+		if len(secret.Data[key]) == 0 {
+			return true, fmt.Sprintf("%s is missing", key)
+		}
+	}
+	block, _ := pem.Decode(secret.Data[corev1.TLSCertKey])
+	if block == nil {
+		return true, "no PEM certificate is found"
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return true, fmt.Sprintf("failed to parse the certificate: %v", err)
+	}
+	before := cert.NotAfter.Sub(cert.NotBefore) / 3
+	if renewBefore != nil {
+		before = renewBefore.Duration
+	}
+	if renewTime := cert.NotAfter.Add(-before); now.After(renewTime) {
+		return true, fmt.Sprintf("it expires at %s", cert.NotAfter.Format(time.RFC3339))
+	}
+	return false, ""
+}
+
+var _ manager.Manager = &FakeVaultCertManager{}
+
+type FakeVaultCertManager struct {
+	err error
+}
+
+func NewFakeVaultCertManager() *FakeVaultCertManager {
+	return &FakeVaultCertManager{}
+}
+
+func (m *FakeVaultCertManager) SetSyncError(err error) {
+	m.err = err
+}
+
+func (m *FakeVaultCertManager) Sync(_ *v1alpha1.TidbCluster) error {
+	return m.err
+}
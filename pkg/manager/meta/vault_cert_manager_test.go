@@ -0,0 +1,200 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package meta
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/vaultapi"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// fakeVault is a Vault server whose PKI secrets engine issues self-signed certificates of the lifetime
+type fakeVault struct {
+	lifetime time.Duration
+	logins   int
+	issued   []vaultapi.IssueRequest
+}
+
+func (v *fakeVault) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/v1/auth/kubernetes/login":
+		body := map[string]string{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		if body["role"] != "tidb" || body["jwt"] != "operator-token" {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"errors":["permission denied"]}`))
+			return
+		}
+		v.logins++
+		_, _ = w.Write([]byte(`{"auth":{"client_token":"vault-token"}}`))
+	case "/v1/pki/issue/tidb-cluster":
+		if r.Header.Get("X-Vault-Token") != "vault-token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		req := vaultapi.IssueRequest{}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		v.issued = append(v.issued, req)
+		cert, key := newSelfSignedCert(v.lifetime)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": vaultapi.Certificate{Certificate: cert, PrivateKey: key, IssuingCA: cert},
+		})
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func newSelfSignedCert(lifetime time.Duration) (string, string) {
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	now := time.Now()
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(now.UnixNano()),
+		Subject:      pkix.Name{CommonName: "TiDB"},
+		NotBefore:    now.Add(-time.Minute),
+		NotAfter:     now.Add(lifetime),
+	}
+	der, _ := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	keyDER, _ := x509.MarshalECPrivateKey(key)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}))
+}
+
+func TestVaultCertManagerSync(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	g.Expect(os.WriteFile(tokenFile, []byte("operator-token\n"), 0600)).To(Succeed())
+	defer func(f string) { serviceAccountTokenFile = f }(serviceAccountTokenFile)
+	serviceAccountTokenFile = tokenFile
+
+	vault := &fakeVault{lifetime: 30 * 24 * time.Hour}
+	server := httptest.NewServer(vault)
+	defer server.Close()
+
+	deps := controller.NewFakeDependencies()
+	cli := deps.GenericControl.(*controller.FakeGenericControl).FakeCli
+	secretIndexer := deps.KubeInformerFactory.Core().V1().Secrets().Informer().GetIndexer()
+	m := NewVaultCertManager(deps)
+
+	tc := newTidbClusterForMeta()
+	g.Expect(m.Sync(tc)).To(Succeed())
+
+	tc.Spec.PD = &v1alpha1.PDSpec{}
+	tc.Spec.TiKV = &v1alpha1.TiKVSpec{}
+	tc.Spec.TLSCluster = &v1alpha1.TLSCluster{
+		Enabled: true,
+		Vault: &v1alpha1.VaultPKI{
+			Address: server.URL,
+			Role:    "tidb-cluster",
+			Auth:    v1alpha1.VaultKubernetesAuth{Role: "tidb"},
+		},
+	}
+	g.Expect(m.Sync(tc)).To(Succeed())
+	g.Expect(vault.logins).To(Equal(1))
+	g.Expect(vault.issued).To(HaveLen(3))
+	g.Expect(vault.issued[0].CommonName).To(Equal("TiDB"))
+	g.Expect(strings.Split(vault.issued[0].AltNames, ",")).To(ContainElements(
+		"test-pd", "test-pd.default.svc", "test-pd-peer.default", "*.test-pd-peer.default.svc"))
+	g.Expect(vault.issued[0].IPSANs).To(Equal("127.0.0.1,::1"))
+	// the client certificate has no SANs
+	g.Expect(vault.issued[2].AltNames).To(BeEmpty())
+
+	for _, name := range []string{"test-pd-cluster-secret", "test-tikv-cluster-secret", "test-cluster-client-secret"} {
+		secret := &corev1.Secret{}
+		g.Expect(cli.Get(context.TODO(), types.NamespacedName{Namespace: tc.Namespace, Name: name}, secret)).To(Succeed())
+		g.Expect(secret.Type).To(Equal(corev1.SecretTypeTLS))
+		g.Expect(secret.Labels[label.ManagedByLabelKey]).To(Equal(label.TiDBOperator))
+		g.Expect(secret.Data).To(HaveKey(corev1.TLSCertKey))
+		g.Expect(secret.Data).To(HaveKey(corev1.TLSPrivateKeyKey))
+		g.Expect(secret.Data).To(HaveKey(corev1.ServiceAccountRootCAKey))
+		g.Expect(secretIndexer.Add(secret)).To(Succeed())
+	}
+
+	// the certificates are valid
+	g.Expect(m.Sync(tc)).To(Succeed())
+	g.Expect(vault.logins).To(Equal(1))
+	g.Expect(vault.issued).To(HaveLen(3))
+
+	// the certificates are renewed in the last 40 days of the 30 days lifetime
+	tc.Spec.TLSCluster.Vault.RenewBefore = &metav1.Duration{Duration: 40 * 24 * time.Hour}
+	g.Expect(m.Sync(tc)).To(Succeed())
+	g.Expect(vault.logins).To(Equal(2))
+	g.Expect(vault.issued).To(HaveLen(6))
+	tc.Spec.TLSCluster.Vault.RenewBefore = nil
+
+	// the secret not created by TiDB Operator is not overwritten
+	g.Expect(secretIndexer.Add(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: tc.Namespace, Name: "test-tidb-cluster-secret"},
+	})).To(Succeed())
+	tc.Spec.TiDB = &v1alpha1.TiDBSpec{}
+	g.Expect(m.Sync(tc)).To(Succeed())
+	g.Expect(vault.issued).To(HaveLen(6))
+
+	// failed to log in
+	tc.Spec.TiDB = nil
+	tc.Spec.TiCDC = &v1alpha1.TiCDCSpec{}
+	tc.Spec.TLSCluster.Vault.Auth.Role = "unknown"
+	err := m.Sync(tc)
+	g.Expect(controller.IsRequeueError(err)).To(BeTrue())
+	g.Expect(err.Error()).To(ContainSubstring("permission denied"))
+}
+
+func TestCertNeedsRenewal(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	cert, key := newSelfSignedCert(30 * time.Hour)
+	secret := &corev1.Secret{Data: map[string][]byte{
+		corev1.TLSCertKey:       []byte(cert),
+		corev1.TLSPrivateKeyKey: []byte(key),
+	}}
+	renew, reason := certNeedsRenewal(secret, nil, time.Now())
+	g.Expect(renew).To(BeTrue())
+	g.Expect(reason).To(Equal("ca.crt is missing"))
+
+	secret.Data[corev1.ServiceAccountRootCAKey] = []byte(cert)
+	renew, _ = certNeedsRenewal(secret, nil, time.Now())
+	g.Expect(renew).To(BeFalse())
+	// one third of the lifetime by default
+	renew, _ = certNeedsRenewal(secret, nil, time.Now().Add(21*time.Hour))
+	g.Expect(renew).To(BeTrue())
+	renew, _ = certNeedsRenewal(secret, &metav1.Duration{Duration: time.Hour}, time.Now().Add(21*time.Hour))
+	g.Expect(renew).To(BeFalse())
+
+	secret.Data[corev1.TLSCertKey] = []byte("invalid")
+	renew, reason = certNeedsRenewal(secret, nil, time.Now())
+	g.Expect(renew).To(BeTrue())
+	g.Expect(reason).To(Equal("no PEM certificate is found"))
+}
@@ -39,6 +39,19 @@ var (
 		v1alpha1.DMWorkerMemberType,
 		v1alpha1.DMMasterMemberType,
 	}
+	// the components are resumed in the reverse order of the suspension
+	resumeOrderForTC = []v1alpha1.MemberType{
+		v1alpha1.PDMemberType,
+		v1alpha1.PumpMemberType,
+		v1alpha1.TiKVMemberType,
+		v1alpha1.TiCDCMemberType,
+		v1alpha1.TiFlashMemberType,
+		v1alpha1.TiDBMemberType,
+	}
+	resumeOrderForDM = []v1alpha1.MemberType{
+		v1alpha1.DMMasterMemberType,
+		v1alpha1.DMWorkerMemberType,
+	}
 
 	_ Suspender = &suspender{}
 	_ Suspender = &FakeSuspender{}
@@ -91,6 +104,11 @@ func (s *suspender) SuspendComponent(cluster v1alpha1.Cluster, comp v1alpha1.Mem
 
 	if !needsSuspendComponent(ctx.cluster, ctx.component) {
 		if suspending {
+			if can, reason := canResumeComponent(ctx.cluster, ctx.component); !can {
+				klog.Infof("component %s can not be resumed now because: %s", ctx.ComponentID(), reason)
+				return true, nil
+			}
+
 			err := s.end(ctx)
 			return true, err
 		}
@@ -250,3 +268,55 @@ func canSuspendComponent(cluster v1alpha1.Cluster, comp v1alpha1.MemberType) (bo
 
 	return true, ""
 }
+
+// canResumeComponent checks whether suspender can end the suspension of the component.
+// The prior components must be resumed and available, so that the cluster is brought back in order,
+// e.g. PD -> TiKV -> TiDB.
+func canResumeComponent(cluster v1alpha1.Cluster, comp v1alpha1.MemberType) (bool, string) {
+	var resumeOrder []v1alpha1.MemberType
+	switch cluster.(type) {
+	case *v1alpha1.TidbCluster:
+		resumeOrder = resumeOrderForTC
+	case *v1alpha1.DMCluster:
+		resumeOrder = resumeOrderForDM
+	}
+	for _, typ := range resumeOrder {
+		if typ == comp {
+			break
+		}
+		if cluster.ComponentSpec(typ) == nil {
+			continue
+		}
+
+		if cluster.ComponentIsSuspending(typ) {
+			return false, fmt.Sprintf("wait another component %s to be resumed", typ)
+		}
+		if !componentIsAvailable(cluster, typ) {
+			return false, fmt.Sprintf("wait another component %s to be available", typ)
+		}
+	}
+
+	return true, ""
+}
+
+// componentIsAvailable returns whether the component that others depend on is available
+func componentIsAvailable(cluster v1alpha1.Cluster, comp v1alpha1.MemberType) bool {
+	switch c := cluster.(type) {
+	case *v1alpha1.TidbCluster:
+		// the PD cluster of a warm standby cluster is not running
+		if c.IsStandby() {
+			return true
+		}
+		switch comp {
+		case v1alpha1.PDMemberType:
+			return c.PDIsAvailable()
+		case v1alpha1.TiKVMemberType:
+			return c.TiKVIsAvailable()
+		}
+	case *v1alpha1.DMCluster:
+		if comp == v1alpha1.DMMasterMemberType {
+			return c.MasterIsAvailable()
+		}
+	}
+	return true
+}
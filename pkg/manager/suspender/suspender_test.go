@@ -457,3 +457,76 @@ func TestCanSuspendComponent(t *testing.T) {
 		c.expect(can, reason)
 	}
 }
+
+func TestCanResumeComponent(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	cases := map[string]struct {
+		setup     func(tc *v1alpha1.TidbCluster)
+		component v1alpha1.MemberType
+		expect    func(can bool, reason string)
+	}{
+		"can resume the first component": {
+			setup: func(tc *v1alpha1.TidbCluster) {
+				tc.Status.PD.Phase = v1alpha1.SuspendPhase
+			},
+			component: v1alpha1.PDMemberType,
+			expect: func(can bool, reason string) {
+				g.Expect(can).To(BeTrue())
+				g.Expect(reason).To(BeEmpty())
+			},
+		},
+		"wait for other components to be resumed": {
+			setup: func(tc *v1alpha1.TidbCluster) {
+				tc.Status.PD.Phase = v1alpha1.SuspendPhase
+				tc.Status.TiKV.Phase = v1alpha1.SuspendPhase
+			},
+			component: v1alpha1.TiKVMemberType,
+			expect: func(can bool, reason string) {
+				g.Expect(can).To(BeFalse())
+				g.Expect(reason).To(Equal("wait another component pd to be resumed"))
+			},
+		},
+		"wait for other components to be available": {
+			setup: func(tc *v1alpha1.TidbCluster) {
+				tc.Status.PD.Phase = v1alpha1.NormalPhase
+				tc.Status.TiKV.Phase = v1alpha1.SuspendPhase
+			},
+			component: v1alpha1.TiKVMemberType,
+			expect: func(can bool, reason string) {
+				g.Expect(can).To(BeFalse())
+				g.Expect(reason).To(Equal("wait another component pd to be available"))
+			},
+		},
+		"can resume component when prior components are available": {
+			setup: func(tc *v1alpha1.TidbCluster) {
+				tc.Spec.PD.Replicas = 1
+				tc.Status.PD.Phase = v1alpha1.NormalPhase
+				tc.Status.PD.Members = map[string]v1alpha1.PDMember{"pd-0": {Health: true}}
+				tc.Status.PD.StatefulSet = &appsv1.StatefulSetStatus{ReadyReplicas: 1}
+				tc.Status.TiKV.Phase = v1alpha1.SuspendPhase
+			},
+			component: v1alpha1.TiKVMemberType,
+			expect: func(can bool, reason string) {
+				g.Expect(can).To(BeTrue())
+				g.Expect(reason).To(BeEmpty())
+			},
+		},
+	}
+
+	for name, c := range cases {
+		t.Logf("test case: %s\n", name)
+
+		tc := &v1alpha1.TidbCluster{}
+		tc.Name = "test-cluster"
+		tc.Namespace = "test-namespace"
+		tc.Spec.PD = &v1alpha1.PDSpec{}
+		tc.Spec.TiKV = &v1alpha1.TiKVSpec{}
+		tc.Spec.TiDB = &v1alpha1.TiDBSpec{}
+
+		c.setup(tc)
+
+		can, reason := canResumeComponent(tc, c.component)
+		c.expect(can, reason)
+	}
+}
@@ -405,6 +405,7 @@ func generateTiDBDashboardService(td *v1alpha1.TidbDashboard) *corev1.Service {
 	if td.Spec.PreferIPv6 {
 		member.SetServiceWhenPreferIPv6(svc)
 	}
+	member.SetServiceIPFamilies(svc, &td.Spec.Service)
 
 	return svc
 }
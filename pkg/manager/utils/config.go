@@ -73,6 +73,18 @@ func updateConfigMap(old, new *corev1.ConfigMap) (bool, error) {
 	return dataEqual, nil
 }
 
+// NameNewConfigMap names the desired ConfigMap which is not in use by any StatefulSet yet.
+func NameNewConfigMap(configUpdateStrategy v1alpha1.ConfigUpdateStrategy, desired *corev1.ConfigMap) error {
+	switch configUpdateStrategy {
+	case v1alpha1.ConfigUpdateStrategyInPlace:
+		return nil
+	case v1alpha1.ConfigUpdateStrategyRollingUpdate:
+		return AddConfigMapDigestSuffix(desired)
+	default:
+		return perrors.Errorf("unknown config update strategy: %v", configUpdateStrategy)
+	}
+}
+
 // UpdateConfigMapIfNeed set the toml field as the old one if they are logically equal.
 func UpdateConfigMapIfNeed(
 	cmLister corelisters.ConfigMapLister,
@@ -80,6 +92,9 @@ func UpdateConfigMapIfNeed(
 	inUseName string,
 	desired *corev1.ConfigMap,
 ) error {
+	if inUseName == "" {
+		return NameNewConfigMap(configUpdateStrategy, desired)
+	}
 
 	switch configUpdateStrategy {
 	case v1alpha1.ConfigUpdateStrategyInPlace:
@@ -0,0 +1,158 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+
+	apps "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/klog/v2"
+)
+
+const (
+	driftKindStatefulSet = "StatefulSet"
+	driftKindService     = "Service"
+)
+
+// CheckStatefulSetDrift checks whether the live StatefulSet is drifted from its last applied config, and handles
+// the drift by the drift mode of the TidbCluster. It returns true if the drift should be reverted.
+func CheckStatefulSetDrift(object runtime.Object, set *apps.StatefulSet) bool {
+	tc, ok := object.(*v1alpha1.TidbCluster)
+	if !ok {
+		return false
+	}
+	fields, err := statefulSetDriftedFields(set)
+	if err != nil {
+		klog.Warningf("failed to check the drift of statefulset %s/%s: %v", set.Namespace, set.Name, err)
+		return false
+	}
+	return handleDrift(tc, tc.StatefulSetDriftMode(), driftKindStatefulSet, set.Name, fields)
+}
+
+// CheckServiceDrift checks whether the live Service is drifted from its last applied config, and handles
+// the drift by the drift mode of the TidbCluster. It returns true if the drift should be reverted.
+func CheckServiceDrift(tc *v1alpha1.TidbCluster, svc *corev1.Service) bool {
+	fields, err := serviceDriftedFields(svc)
+	if err != nil {
+		klog.Warningf("failed to check the drift of service %s/%s: %v", svc.Namespace, svc.Name, err)
+		return false
+	}
+	return handleDrift(tc, tc.ServiceDriftMode(), driftKindService, svc.Name, fields)
+}
+
+func handleDrift(tc *v1alpha1.TidbCluster, mode v1alpha1.DriftMode, kind, name string, fields []string) bool {
+	switch mode {
+	case v1alpha1.DriftModeEnforce:
+		setDriftedResource(&tc.Status, kind, name, nil)
+		if len(fields) == 0 {
+			return false
+		}
+		klog.Infof("%s %s/%s is drifted, fields: %v, revert it", kind, tc.Namespace, name, fields)
+		return true
+	case v1alpha1.DriftModeWarn:
+		if len(fields) > 0 {
+			klog.Warningf("%s %s/%s is drifted, fields: %v", kind, tc.Namespace, name, fields)
+		}
+		setDriftedResource(&tc.Status, kind, name, fields)
+	default:
+		setDriftedResource(&tc.Status, kind, name, nil)
+	}
+	return false
+}
+
+// setDriftedResource records the drifted fields of the resource in the status, the resource is removed
+// from the status if fields is empty.
+func setDriftedResource(status *v1alpha1.TidbClusterStatus, kind, name string, fields []string) {
+	resources := make([]v1alpha1.DriftedResource, 0, len(status.DriftedResources)+1)
+	for _, r := range status.DriftedResources {
+		if r.Kind != kind || r.Name != name {
+			resources = append(resources, r)
+		}
+	}
+	if len(fields) > 0 {
+		resources = append(resources, v1alpha1.DriftedResource{Kind: kind, Name: name, Fields: fields})
+	}
+	sort.Slice(resources, func(i, j int) bool {
+		if resources[i].Kind != resources[j].Kind {
+			return resources[i].Kind < resources[j].Kind
+		}
+		return resources[i].Name < resources[j].Name
+	})
+	if len(resources) == 0 {
+		resources = nil
+	}
+	status.DriftedResources = resources
+}
+
+// statefulSetDriftedFields returns the fields of the live StatefulSet which are changed from its last applied
+// config, the fields not set in the last applied config, e.g. the defaults, are not compared.
+func statefulSetDriftedFields(set *apps.StatefulSet) ([]string, error) {
+	lastApplied, ok := set.Annotations[LastAppliedConfigAnnotation]
+	if !ok {
+		return nil, nil
+	}
+	spec := apps.StatefulSetSpec{}
+	if err := json.Unmarshal([]byte(lastApplied), &spec); err != nil {
+		return nil, fmt.Errorf("unmarshal the last applied config: %v", err)
+	}
+
+	var fields []string
+	if spec.Replicas != nil && set.Spec.Replicas != nil && *spec.Replicas != *set.Spec.Replicas {
+		fields = append(fields, "spec.replicas")
+	}
+	if !apiequality.Semantic.DeepDerivative(spec.UpdateStrategy, set.Spec.UpdateStrategy) {
+		fields = append(fields, "spec.updateStrategy")
+	}
+	if !apiequality.Semantic.DeepDerivative(spec.Template, set.Spec.Template) {
+		fields = append(fields, "spec.template")
+	}
+	return fields, nil
+}
+
+// serviceDriftedFields returns the fields of the live Service which are changed from its last applied
+// config, the fields not set in the last applied config, e.g. the allocated cluster IP, are not compared.
+func serviceDriftedFields(svc *corev1.Service) ([]string, error) {
+	lastApplied, ok := svc.Annotations[LastAppliedConfigAnnotation]
+	if !ok {
+		return nil, nil
+	}
+	spec := corev1.ServiceSpec{}
+	if err := json.Unmarshal([]byte(lastApplied), &spec); err != nil {
+		return nil, fmt.Errorf("unmarshal the last applied config: %v", err)
+	}
+
+	var fields []string
+	if spec.Type != "" && spec.Type != svc.Spec.Type {
+		fields = append(fields, "spec.type")
+	}
+	if !apiequality.Semantic.DeepDerivative(spec.Ports, svc.Spec.Ports) {
+		fields = append(fields, "spec.ports")
+	}
+	if !apiequality.Semantic.DeepDerivative(spec.Selector, svc.Spec.Selector) {
+		fields = append(fields, "spec.selector")
+	}
+	// the other fields
+	spec.Type, spec.Ports, spec.Selector = "", nil, nil
+	if !apiequality.Semantic.DeepDerivative(spec, svc.Spec) {
+		fields = append(fields, "spec")
+	}
+	return fields, nil
+}
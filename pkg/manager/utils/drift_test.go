@@ -0,0 +1,161 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"encoding/json"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	apps "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+func newDriftTestStatefulSet() *apps.StatefulSet {
+	set := &apps.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-tikv",
+			Namespace: metav1.NamespaceDefault,
+		},
+		Spec: apps.StatefulSetSpec{
+			Replicas: pointer.Int32Ptr(3),
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{Name: "tikv", Image: "tikv:v7.1.0"},
+					},
+				},
+			},
+		},
+	}
+	SetStatefulSetLastAppliedConfigAnnotation(set)
+	// the defaults set by the api server
+	set.Spec.Template.Spec.Containers[0].TerminationMessagePath = corev1.TerminationMessagePathDefault
+	set.Spec.RevisionHistoryLimit = pointer.Int32Ptr(10)
+	return set
+}
+
+func TestStatefulSetDriftedFields(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tests := []struct {
+		name   string
+		update func(*apps.StatefulSet)
+		fields []string
+	}{
+		{
+			name:   "not drifted",
+			update: func(set *apps.StatefulSet) {},
+		},
+		{
+			name: "no last applied config",
+			update: func(set *apps.StatefulSet) {
+				delete(set.Annotations, LastAppliedConfigAnnotation)
+				set.Spec.Replicas = pointer.Int32Ptr(4)
+			},
+		},
+		{
+			name: "replicas and image changed",
+			update: func(set *apps.StatefulSet) {
+				set.Spec.Replicas = pointer.Int32Ptr(4)
+				set.Spec.Template.Spec.Containers[0].Image = "tikv:v7.1.1"
+			},
+			fields: []string{"spec.replicas", "spec.template"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Log(tt.name)
+		set := newDriftTestStatefulSet()
+		tt.update(set)
+		fields, err := statefulSetDriftedFields(set)
+		g.Expect(err).Should(Succeed())
+		g.Expect(fields).Should(Equal(tt.fields))
+	}
+}
+
+func TestServiceDriftedFields(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	newSvc := func() *corev1.Service {
+		svc := &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-tidb", Namespace: metav1.NamespaceDefault},
+			Spec: corev1.ServiceSpec{
+				Type:     corev1.ServiceTypeNodePort,
+				Ports:    []corev1.ServicePort{{Name: "mysql-client", Port: 4000}},
+				Selector: map[string]string{"app.kubernetes.io/component": "tidb"},
+			},
+		}
+		b, err := json.Marshal(svc.Spec)
+		g.Expect(err).Should(Succeed())
+		svc.Annotations = map[string]string{LastAppliedConfigAnnotation: string(b)}
+		// the fields allocated by the api server
+		svc.Spec.ClusterIP = "10.0.0.1"
+		svc.Spec.Ports[0].NodePort = 30000
+		return svc
+	}
+
+	svc := newSvc()
+	fields, err := serviceDriftedFields(svc)
+	g.Expect(err).Should(Succeed())
+	g.Expect(fields).Should(BeEmpty())
+
+	svc = newSvc()
+	svc.Spec.Type = corev1.ServiceTypeLoadBalancer
+	svc.Spec.Ports[0].Port = 3306
+	svc.Spec.ExternalTrafficPolicy = corev1.ServiceExternalTrafficPolicyTypeLocal
+	fields, err = serviceDriftedFields(svc)
+	g.Expect(err).Should(Succeed())
+	g.Expect(fields).Should(Equal([]string{"spec.type", "spec.ports"}))
+}
+
+func TestCheckStatefulSetDrift(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tests := []struct {
+		name           string
+		mode           v1alpha1.DriftMode
+		expectRevert   bool
+		expectRecorded bool
+	}{
+		{name: "ignore", mode: v1alpha1.DriftModeIgnore},
+		{name: "warn", mode: v1alpha1.DriftModeWarn, expectRecorded: true},
+		{name: "enforce", mode: v1alpha1.DriftModeEnforce, expectRevert: true},
+	}
+
+	for _, tt := range tests {
+		t.Log(tt.name)
+		tc := &v1alpha1.TidbCluster{}
+		tc.Spec.DriftPolicy = &v1alpha1.DriftPolicy{StatefulSet: tt.mode}
+		set := newDriftTestStatefulSet()
+		set.Spec.Replicas = pointer.Int32Ptr(4)
+
+		g.Expect(CheckStatefulSetDrift(tc, set)).Should(Equal(tt.expectRevert))
+		if tt.expectRecorded {
+			g.Expect(tc.Status.DriftedResources).Should(Equal([]v1alpha1.DriftedResource{
+				{Kind: "StatefulSet", Name: "test-tikv", Fields: []string{"spec.replicas"}},
+			}))
+		} else {
+			g.Expect(tc.Status.DriftedResources).Should(BeEmpty())
+		}
+
+		// the record is removed after the drift is gone
+		set = newDriftTestStatefulSet()
+		g.Expect(CheckStatefulSetDrift(tc, set)).Should(BeFalse())
+		g.Expect(tc.Status.DriftedResources).Should(BeEmpty())
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"strings"
+	"time"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// configVolumeName is the name of the volume of the ConfigMap of a component
+	configVolumeName = "config"
+	// PodRestartRecordTTL is how long a recorded restart cause is kept for the pod to be recreated,
+	// the upgraders record the cause again on every sync until the pod is recreated.
+	PodRestartRecordTTL = time.Hour
+)
+
+// RecordPodRestart records the cause of a restart of the pod initiated by the operator in the status of the TidbCluster,
+// the new pod of the same name is annotated with the cause by the meta manager. The first cause recorded for a pod wins.
+func RecordPodRestart(tc *v1alpha1.TidbCluster, pod *corev1.Pod, reason, detail string) {
+	if pod == nil {
+		return
+	}
+	if r, ok := tc.Status.PodRestarts[pod.Name]; ok && r.PodUID == pod.UID {
+		return
+	}
+	if tc.Status.PodRestarts == nil {
+		tc.Status.PodRestarts = map[string]v1alpha1.PodRestart{}
+	}
+	tc.Status.PodRestarts[pod.Name] = v1alpha1.PodRestart{
+		Reason: reason,
+		Detail: detail,
+		PodUID: pod.UID,
+		Time:   metav1.Now(),
+	}
+}
+
+// UpgradeRestartReason returns the cause of the restart of the pod to update it to the template, it's the new image
+// if any image changes, the new config if the ConfigMap changes, a user request if the restartedAt annotation changes,
+// or else a change of the other fields.
+func UpgradeRestartReason(pod *corev1.Pod, template *corev1.PodTemplateSpec) (reason, detail string) {
+	images := map[string]string{}
+	for _, c := range pod.Spec.Containers {
+		images[c.Name] = c.Image
+	}
+	for _, c := range template.Spec.Containers {
+		if image, ok := images[c.Name]; ok && image != c.Image {
+			return label.RestartReasonUpgrade, c.Image
+		}
+	}
+
+	if oldCm, newCm := configMapOfVolume(pod.Spec.Volumes), configMapOfVolume(template.Spec.Volumes); oldCm != newCm {
+		// the name of the ConfigMap ends with the hash of the config if the config update strategy is RollingUpdate
+		return label.RestartReasonConfigChange, newCm[strings.LastIndex(newCm, "-")+1:]
+	}
+
+	if restartedAt := template.Annotations[label.AnnRestartedAtKey]; restartedAt != pod.Annotations[label.AnnRestartedAtKey] {
+		return label.RestartReasonUserRequested, restartedAt
+	}
+	return label.RestartReasonSpecChange, ""
+}
+
+func configMapOfVolume(volumes []corev1.Volume) string {
+	for _, v := range volumes {
+		if v.Name == configVolumeName && v.ConfigMap != nil {
+			return v.ConfigMap.Name
+		}
+	}
+	return ""
+}
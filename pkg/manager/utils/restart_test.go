@@ -0,0 +1,114 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestUpgradeRestartReason(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	newTemplate := func() *corev1.PodTemplateSpec {
+		return &corev1.PodTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{label.AnnRestartedAtKey: "2024-01-01T00:00:00Z"},
+			},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{Name: "tikv", Image: "pingcap/tikv:v7.5.0"}},
+				Volumes: []corev1.Volume{{Name: "config", VolumeSource: corev1.VolumeSource{
+					ConfigMap: &corev1.ConfigMapVolumeSource{LocalObjectReference: corev1.LocalObjectReference{Name: "basic-tikv-3961643"}},
+				}}},
+			},
+		}
+	}
+
+	tests := []struct {
+		name         string
+		update       func(*corev1.PodTemplateSpec)
+		expectReason string
+		expectDetail string
+	}{
+		{
+			name: "image changed",
+			update: func(t *corev1.PodTemplateSpec) {
+				t.Spec.Containers[0].Image = "pingcap/tikv:v8.1.0"
+				t.Spec.Volumes[0].ConfigMap.Name = "basic-tikv-6239613"
+			},
+			expectReason: label.RestartReasonUpgrade,
+			expectDetail: "pingcap/tikv:v8.1.0",
+		},
+		{
+			name: "config changed",
+			update: func(t *corev1.PodTemplateSpec) {
+				t.Spec.Volumes[0].ConfigMap.Name = "basic-tikv-6239613"
+			},
+			expectReason: label.RestartReasonConfigChange,
+			expectDetail: "6239613",
+		},
+		{
+			name: "restart requested",
+			update: func(t *corev1.PodTemplateSpec) {
+				t.Annotations[label.AnnRestartedAtKey] = "2024-02-01T00:00:00Z"
+			},
+			expectReason: label.RestartReasonUserRequested,
+			expectDetail: "2024-02-01T00:00:00Z",
+		},
+		{
+			name: "other fields changed",
+			update: func(t *corev1.PodTemplateSpec) {
+				t.Spec.NodeSelector = map[string]string{"disk": "ssd"}
+			},
+			expectReason: label.RestartReasonSpecChange,
+		},
+	}
+
+	for _, test := range tests {
+		t.Log(test.name)
+		old := newTemplate()
+		pod := &corev1.Pod{ObjectMeta: old.ObjectMeta, Spec: old.Spec}
+		template := newTemplate()
+		test.update(template)
+
+		reason, detail := UpgradeRestartReason(pod, template)
+		g.Expect(reason).To(Equal(test.expectReason))
+		g.Expect(detail).To(Equal(test.expectDetail))
+	}
+}
+
+func TestRecordPodRestart(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := &v1alpha1.TidbCluster{}
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "basic-tikv-0", UID: types.UID("1")}}
+
+	RecordPodRestart(tc, pod, label.RestartReasonUpgrade, "pingcap/tikv:v8.1.0")
+	// the first cause of the restart of the same pod wins
+	RecordPodRestart(tc, pod, label.RestartReasonFailover, "")
+	g.Expect(tc.Status.PodRestarts).To(HaveLen(1))
+	g.Expect(tc.Status.PodRestarts[pod.Name].Reason).To(Equal(label.RestartReasonUpgrade))
+
+	// the recreated pod is restarted again
+	pod.UID = types.UID("2")
+	RecordPodRestart(tc, pod, label.RestartReasonFailover, "host-down")
+	g.Expect(tc.Status.PodRestarts[pod.Name].Reason).To(Equal(label.RestartReasonFailover))
+	g.Expect(tc.Status.PodRestarts[pod.Name].PodUID).To(Equal(types.UID("2")))
+}
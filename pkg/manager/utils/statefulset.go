@@ -114,9 +114,12 @@ func UpdateStatefulSet(setCtl controller.StatefulSetControlInterface, object run
 		oldSet.Annotations = map[string]string{}
 	}
 
+	// Check if the live statefulset is drifted from the last applied config by external edits
+	drifted := CheckStatefulSetDrift(object, oldSet)
+
 	// Check if an upgrade is needed.
 	// If not, early return.
-	if util.StatefulSetEqual(*newSet, *oldSet) && !isOrphan {
+	if util.StatefulSetEqual(*newSet, *oldSet) && !isOrphan && !drifted {
 		return nil
 	}
 
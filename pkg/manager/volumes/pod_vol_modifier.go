@@ -297,6 +297,18 @@ func getDesiredVolumeByName(vs []DesiredVolume, name v1alpha1.StorageVolumeName)
 	return nil
 }
 
+// desiredVolumeWithStorageClassOfPVC returns the desired volume with the storage class of the pvc if the storage class
+// is not specified, which means the pvc is provisioned by the default storage class and keeps using it.
+// So that the pvc can still be expanded when only the size is changed.
+func desiredVolumeWithStorageClassOfPVC(desired *DesiredVolume, sc *storagev1.StorageClass) *DesiredVolume {
+	if desired == nil || desired.StorageClass != nil || sc == nil {
+		return desired
+	}
+	d := *desired
+	d.StorageClass = sc
+	return &d
+}
+
 func (p *podVolModifier) getBoundPVFromPVC(pvc *corev1.PersistentVolumeClaim) (*corev1.PersistentVolume, error) {
 	if p.deps.PVLister == nil {
 		klog.V(4).Infof("Persistent volumes lister is unavailable, skip getting PV for %s. This may be caused by no relevant permissions", pvc.Spec.VolumeName)
@@ -375,7 +387,7 @@ func (p *podVolModifier) NewActualVolumeOfPod(vs []DesiredVolume, ns string, vol
 		return nil, err
 	}
 
-	desired := getDesiredVolumeByName(vs, v1alpha1.StorageVolumeName(vol.Name))
+	desired := desiredVolumeWithStorageClassOfPVC(getDesiredVolumeByName(vs, v1alpha1.StorageVolumeName(vol.Name)), sc)
 
 	actual := ActualVolume{
 		Desired:      desired,
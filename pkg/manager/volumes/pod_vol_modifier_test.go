@@ -231,3 +231,33 @@ func TestModify(t *testing.T) {
 		g.Expect(resultPVC).Should(Equal(c.expectedPVC), c.desc)
 	}
 }
+
+func TestDesiredVolumeWithStorageClassOfPVC(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	sc := newTestSCForModify("default", "ebs.csi.aws.com")
+	g.Expect(desiredVolumeWithStorageClassOfPVC(nil, sc)).Should(BeNil())
+
+	// the specified storage class is kept
+	newSc := newTestSCForModify("new", "ebs.csi.aws.com")
+	desired := &DesiredVolume{Name: "tikv", StorageClass: newSc, Size: resource.MustParse("20Gi")}
+	g.Expect(desiredVolumeWithStorageClassOfPVC(desired, sc)).Should(Equal(desired))
+
+	// the storage class of the pvc is used if the storage class is not specified
+	desired = &DesiredVolume{Name: "tikv", Size: resource.MustParse("20Gi")}
+	resolved := desiredVolumeWithStorageClassOfPVC(desired, sc)
+	g.Expect(resolved.StorageClass).Should(Equal(sc))
+	g.Expect(resolved.Size).Should(Equal(desired.Size))
+	g.Expect(desired.StorageClass).Should(BeNil())
+
+	// the pvc with the default storage class can be expanded
+	pvm := &podVolModifier{
+		modifiers: map[string]delegation.VolumeModifier{},
+	}
+	actual := &ActualVolume{
+		Desired:      resolved,
+		PVC:          newTestPVCForModify(pointer.StringPtr("default"), "10Gi", "10Gi", nil),
+		StorageClass: sc,
+	}
+	g.Expect(pvm.getVolumePhase(actual)).Should(Equal(VolumePhasePreparing))
+}
@@ -0,0 +1,265 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package volumes
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	klog "k8s.io/klog/v2"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/manager/utils"
+)
+
+// PVCReplacerInterface replaces the volumes of PD and TiKV which can't be modified in place, e.g. the storage
+// class is changed to one which isn't supported by the volume modifiers, or the size is shrunk.
+type PVCReplacerInterface interface {
+	Sync(tc *v1alpha1.TidbCluster) error
+}
+
+// pvcReplacer replaces the volumes one pod at a time:
+//  1. for TiKV, a spare store is scaled out to keep the replicas of the regions while a store is replaced
+//  2. the store or the member of the pod is deleted from PD, the data of the store is rebalanced to the others
+//  3. the pod and its PVCs are deleted, the pod is recreated by the StatefulSet with the new volumes
+//  4. the next pod is replaced after the recreated pod joins the cluster again
+//
+// The spare store is scaled in after all the pods are replaced.
+type pvcReplacer struct {
+	*pvcModifier
+}
+
+func NewPVCReplacer(deps *controller.Dependencies) PVCReplacerInterface {
+	return &pvcReplacer{
+		pvcModifier: &pvcModifier{
+			deps: deps,
+			sf:   MustNewSelectorFactory(),
+			pm:   NewPodVolumeModifier(deps),
+		},
+	}
+}
+
+func (p *pvcReplacer) Sync(tc *v1alpha1.TidbCluster) error {
+	if tc.Spec.PD != nil {
+		if err := p.syncComponent(tc, &tc.Status.PD); err != nil {
+			return fmt.Errorf("replace volumes for %s/%s:%s failed: %w", tc.Namespace, tc.Name, v1alpha1.PDMemberType, err)
+		}
+	}
+	if tc.Spec.TiKV != nil {
+		if err := p.syncComponent(tc, &tc.Status.TiKV); err != nil {
+			return fmt.Errorf("replace volumes for %s/%s:%s failed: %w", tc.Namespace, tc.Name, v1alpha1.TiKVMemberType, err)
+		}
+	}
+	return nil
+}
+
+func (p *pvcReplacer) syncComponent(tc *v1alpha1.TidbCluster, status v1alpha1.ComponentStatus) error {
+	ctx, err := p.buildContextForTC(tc, status)
+	if err != nil {
+		return err
+	}
+
+	pod, vols, err := p.nextPodToReplace(ctx)
+	if err != nil {
+		return err
+	}
+	inProgress := isVolReplaceInProgress(tc, status.MemberType())
+	if pod == nil {
+		if inProgress {
+			klog.Infof("volumes of %s are replaced", ctx.ComponentID())
+			setVolReplaceInProgress(tc, status.MemberType(), false)
+		}
+		return nil
+	}
+
+	if !inProgress {
+		if status.GetPhase() != v1alpha1.NormalPhase {
+			return controller.RequeueErrorf("wait for the phase of %s to be Normal to replace volumes", ctx.ComponentID())
+		}
+		if status.MemberType() == v1alpha1.PDMemberType && tc.Spec.PD.Replicas < 3 {
+			return fmt.Errorf("can't replace volumes of %s with less than 3 replicas", ctx.ComponentID())
+		}
+		klog.Infof("start to replace volumes of %s", ctx.ComponentID())
+		setVolReplaceInProgress(tc, status.MemberType(), true)
+		return controller.RequeueErrorf("start to replace volumes of %s", ctx.ComponentID())
+	}
+
+	// the StatefulSet is recreated with the new volume claim templates so that the pods are recreated with the
+	// new volumes
+	if err := p.tryToRecreateSTS(ctx); err != nil {
+		return err
+	}
+	synced, err := p.isStatefulSetSynced(ctx, ctx.sts)
+	if err != nil {
+		return err
+	}
+	if !synced {
+		return controller.RequeueErrorf("wait for the StatefulSet of %s to be recreated", ctx.ComponentID())
+	}
+
+	switch status.MemberType() {
+	case v1alpha1.PDMemberType:
+		return p.replacePDVolumes(ctx, pod, vols)
+	case v1alpha1.TiKVMemberType:
+		return p.replaceTiKVVolumes(ctx, pod, vols)
+	}
+	return nil
+}
+
+// nextPodToReplace returns the first pod whose volumes need to be replaced
+func (p *pvcReplacer) nextPodToReplace(ctx *componentVolumeContext) (*corev1.Pod, []ActualVolume, error) {
+	for _, pod := range ctx.pods {
+		vols, err := p.pm.GetActualVolumes(pod, ctx.desiredVolumes)
+		if err != nil {
+			return nil, nil, err
+		}
+		for i := range vols {
+			if needReplace(&vols[i]) {
+				return pod, vols, nil
+			}
+		}
+	}
+	return nil, nil, nil
+}
+
+// needReplace returns true if the volume needs to be changed but it can't be modified in place
+func needReplace(vol *ActualVolume) bool {
+	if vol.Phase != VolumePhaseCannotModify || vol.Desired == nil || vol.Desired.StorageClass == nil {
+		return false
+	}
+	return needModify(vol.PVC, vol.Desired)
+}
+
+func (p *pvcReplacer) replacePDVolumes(ctx *componentVolumeContext, pod *corev1.Pod, vols []ActualVolume) error {
+	for name, member := range ctx.tc.Status.PD.Members {
+		if strings.Split(name, ".")[0] != pod.Name {
+			continue
+		}
+		if !isComponentReadyToReplace(ctx.tc, v1alpha1.PDMemberType) {
+			return controller.RequeueErrorf("wait for all the members of %s to be healthy to replace volumes of pod %s", ctx.ComponentID(), pod.Name)
+		}
+		id, err := strconv.ParseUint(member.ID, 10, 64)
+		if err != nil {
+			return err
+		}
+		if err := controller.GetPDClient(p.deps.PDControl, ctx.tc).DeleteMemberByID(id); err != nil {
+			return fmt.Errorf("delete pd member %s(%d) failed: %w", name, id, err)
+		}
+		klog.Infof("pd member %s(%d) of %s is deleted to replace volumes", name, id, ctx.ComponentID())
+		return controller.RequeueErrorf("wait for pd member %s to be deleted", name)
+	}
+	return p.deletePodAndPVCs(ctx, pod, vols)
+}
+
+func (p *pvcReplacer) replaceTiKVVolumes(ctx *componentVolumeContext, pod *corev1.Pod, vols []ActualVolume) error {
+	for id, store := range ctx.tc.Status.TiKV.Stores {
+		if store.PodName != pod.Name {
+			continue
+		}
+		if store.State == v1alpha1.TiKVStateUp {
+			if !isComponentReadyToReplace(ctx.tc, v1alpha1.TiKVMemberType) {
+				return controller.RequeueErrorf("wait for all the stores of %s to be up to replace volumes of pod %s", ctx.ComponentID(), pod.Name)
+			}
+			storeID, err := strconv.ParseUint(id, 10, 64)
+			if err != nil {
+				return err
+			}
+			if err := controller.GetPDClient(p.deps.PDControl, ctx.tc).DeleteStore(storeID); err != nil {
+				return fmt.Errorf("delete tikv store %s of pod %s/%s failed: %w", id, pod.Namespace, pod.Name, err)
+			}
+			klog.Infof("tikv store %s of pod %s/%s is deleted to replace volumes", id, pod.Namespace, pod.Name)
+		}
+		return controller.RequeueErrorf("wait for tikv store %s of pod %s/%s to be tombstone", id, pod.Namespace, pod.Name)
+	}
+	return p.deletePodAndPVCs(ctx, pod, vols)
+}
+
+// deletePodAndPVCs deletes the pod and its PVCs, the pod is recreated by the StatefulSet with the new volumes.
+// The pod is deleted before the PVCs, the pod pending on the PVCs being deleted is cleaned by the orphan pods cleaner.
+func (p *pvcReplacer) deletePodAndPVCs(ctx *componentVolumeContext, pod *corev1.Pod, vols []ActualVolume) error {
+	if pod.DeletionTimestamp == nil {
+		utils.RecordPodRestart(ctx.tc, pod, label.RestartReasonVolumeReplace, "recreate-pvcs")
+		if err := p.deps.PodControl.DeletePod(ctx.tc, pod); err != nil {
+			return err
+		}
+	}
+	for i := range vols {
+		pvc := vols[i].PVC
+		if pvc.DeletionTimestamp != nil {
+			continue
+		}
+		if err := p.deps.PVCControl.DeletePVC(ctx.tc, pvc); err != nil {
+			return err
+		}
+		klog.Infof("pvc %s/%s of %s is deleted to replace volumes", pvc.Namespace, pvc.Name, ctx.ComponentID())
+	}
+	return controller.RequeueErrorf("wait for pod %s/%s to be recreated with the new volumes", pod.Namespace, pod.Name)
+}
+
+// isComponentReadyToReplace returns true if all the pods are ready and the members of the component are healthy
+// before a member is deleted, so that the last replaced pod has joined and the spare TiKV store is up
+func isComponentReadyToReplace(tc *v1alpha1.TidbCluster, mt v1alpha1.MemberType) bool {
+	switch mt {
+	case v1alpha1.PDMemberType:
+		sts := tc.Status.PD.StatefulSet
+		if sts == nil || sts.ReadyReplicas != tc.PDStsDesiredReplicas() {
+			return false
+		}
+		if int32(len(tc.Status.PD.Members)) < tc.PDStsDesiredReplicas() {
+			return false
+		}
+		for _, member := range tc.Status.PD.Members {
+			if !member.Health {
+				return false
+			}
+		}
+	case v1alpha1.TiKVMemberType:
+		sts := tc.Status.TiKV.StatefulSet
+		if sts == nil || sts.ReadyReplicas != tc.TiKVStsDesiredReplicas() {
+			return false
+		}
+		if int32(len(tc.Status.TiKV.Stores)) < tc.TiKVStsDesiredReplicas() {
+			return false
+		}
+		for _, store := range tc.Status.TiKV.Stores {
+			if store.State != v1alpha1.TiKVStateUp {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func isVolReplaceInProgress(tc *v1alpha1.TidbCluster, mt v1alpha1.MemberType) bool {
+	switch mt {
+	case v1alpha1.PDMemberType:
+		return tc.Status.PD.VolReplaceInProgress
+	case v1alpha1.TiKVMemberType:
+		return tc.Status.TiKV.VolReplaceInProgress
+	}
+	return false
+}
+
+func setVolReplaceInProgress(tc *v1alpha1.TidbCluster, mt v1alpha1.MemberType, inProgress bool) {
+	switch mt {
+	case v1alpha1.PDMemberType:
+		tc.Status.PD.VolReplaceInProgress = inProgress
+	case v1alpha1.TiKVMemberType:
+		tc.Status.TiKV.VolReplaceInProgress = inProgress
+	}
+}
@@ -0,0 +1,157 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package volumes
+
+import (
+	"fmt"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/pdapi"
+)
+
+func TestReplaceTiKVVolumes(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	deps := controller.NewFakeDependencies()
+	podIndexer := deps.KubeInformerFactory.Core().V1().Pods().Informer().GetIndexer()
+	stsIndexer := deps.KubeInformerFactory.Apps().V1().StatefulSets().Informer().GetIndexer()
+
+	tc := &v1alpha1.TidbCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "ns"},
+		Spec: v1alpha1.TidbClusterSpec{
+			TiKV: &v1alpha1.TiKVSpec{Replicas: 3},
+		},
+		Status: v1alpha1.TidbClusterStatus{
+			TiKV: v1alpha1.TiKVStatus{
+				Phase:       v1alpha1.NormalPhase,
+				StatefulSet: &appsv1.StatefulSetStatus{Replicas: 3, ReadyReplicas: 3},
+				Stores:      map[string]v1alpha1.TiKVStore{},
+			},
+		},
+	}
+	for i := 0; i < 3; i++ {
+		podName := fmt.Sprintf("test-tikv-%d", i)
+		g.Expect(podIndexer.Add(&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      podName,
+				Namespace: "ns",
+				Labels:    label.New().Instance("test").TiKV().Labels(),
+			},
+		})).To(Succeed())
+		tc.Status.TiKV.Stores[fmt.Sprint(i+1)] = v1alpha1.TiKVStore{ID: fmt.Sprint(i + 1), PodName: podName, State: v1alpha1.TiKVStateUp}
+	}
+	g.Expect(stsIndexer.Add(&appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-tikv", Namespace: "ns"},
+		Spec: appsv1.StatefulSetSpec{
+			VolumeClaimTemplates: []corev1.PersistentVolumeClaim{{
+				ObjectMeta: metav1.ObjectMeta{Name: "tikv"},
+				Spec: corev1.PersistentVolumeClaimSpec{
+					StorageClassName: pointer.StringPtr("new"),
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("10Gi")},
+					},
+				},
+			}},
+		},
+	})).To(Succeed())
+
+	// the storage classes of the volumes of the pods, the volumes of the storage class old can't be modified
+	storageClasses := map[string]string{"test-tikv-0": "old", "test-tikv-1": "old", "test-tikv-2": "new"}
+	desired := DesiredVolume{Name: "tikv", Size: resource.MustParse("10Gi"), StorageClass: newStorageClass("new", true)}
+	pm := &FakePodVolumeModifier{
+		GetDesiredVolumesFunc: func(_ *v1alpha1.TidbCluster, _ v1alpha1.MemberType) ([]DesiredVolume, error) {
+			return []DesiredVolume{desired}, nil
+		},
+		GetActualVolumesFunc: func(pod *corev1.Pod, _ []DesiredVolume) ([]ActualVolume, error) {
+			sc := storageClasses[pod.Name]
+			pvc := newTestPVCForModify(pointer.StringPtr(sc), "10Gi", "10Gi", nil)
+			pvc.Name = "tikv-" + pod.Name
+			phase := VolumePhaseModified
+			if sc == "old" {
+				phase = VolumePhaseCannotModify
+			}
+			return []ActualVolume{{Desired: &desired, PVC: pvc, Phase: phase}}, nil
+		},
+	}
+	replacer := &pvcReplacer{pvcModifier: &pvcModifier{deps: deps, sf: MustNewSelectorFactory(), pm: pm}}
+
+	pdClient := controller.NewFakePDClient(deps.PDControl.(*pdapi.FakePDControl), tc)
+	deletedStores := []uint64{}
+	pdClient.AddReaction(pdapi.DeleteStoreActionType, func(action *pdapi.Action) (interface{}, error) {
+		deletedStores = append(deletedStores, action.ID)
+		return nil, nil
+	})
+
+	// the replacement starts with a spare store
+	err := replacer.Sync(tc)
+	g.Expect(controller.IsRequeueError(err)).To(BeTrue())
+	g.Expect(tc.Status.TiKV.VolReplaceInProgress).To(BeTrue())
+	g.Expect(tc.TiKVStsDesiredReplicas()).To(Equal(int32(4)))
+
+	// the store isn't deleted until the spare store is up
+	err = replacer.Sync(tc)
+	g.Expect(controller.IsRequeueError(err)).To(BeTrue())
+	g.Expect(deletedStores).To(BeEmpty())
+
+	tc.Status.TiKV.StatefulSet.ReadyReplicas = 4
+	tc.Status.TiKV.Stores["4"] = v1alpha1.TiKVStore{ID: "4", PodName: "test-tikv-3", State: v1alpha1.TiKVStateUp}
+	err = replacer.Sync(tc)
+	g.Expect(controller.IsRequeueError(err)).To(BeTrue())
+	g.Expect(deletedStores).To(Equal([]uint64{1}))
+
+	// wait for the store to be tombstone
+	store := tc.Status.TiKV.Stores["1"]
+	store.State = v1alpha1.TiKVStateOffline
+	tc.Status.TiKV.Stores["1"] = store
+	err = replacer.Sync(tc)
+	g.Expect(controller.IsRequeueError(err)).To(BeTrue())
+	g.Expect(deletedStores).To(Equal([]uint64{1}))
+	_, err = deps.PodLister.Pods("ns").Get("test-tikv-0")
+	g.Expect(err).NotTo(HaveOccurred())
+
+	// the pod is deleted after the store is tombstone
+	delete(tc.Status.TiKV.Stores, "1")
+	err = replacer.Sync(tc)
+	g.Expect(controller.IsRequeueError(err)).To(BeTrue())
+	_, err = deps.PodLister.Pods("ns").Get("test-tikv-0")
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(tc.Status.PodRestarts).To(HaveKey("test-tikv-0"))
+	g.Expect(tc.Status.PodRestarts["test-tikv-0"].Reason).To(Equal(label.RestartReasonVolumeReplace))
+
+	// the pod is recreated with the new volumes and the next pod is replaced
+	g.Expect(podIndexer.Add(&corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-tikv-0", Namespace: "ns", Labels: label.New().Instance("test").TiKV().Labels()},
+	})).To(Succeed())
+	storageClasses["test-tikv-0"] = "new"
+	tc.Status.TiKV.Stores["5"] = v1alpha1.TiKVStore{ID: "5", PodName: "test-tikv-0", State: v1alpha1.TiKVStateUp}
+	err = replacer.Sync(tc)
+	g.Expect(controller.IsRequeueError(err)).To(BeTrue())
+	g.Expect(deletedStores).To(Equal([]uint64{1, 2}))
+
+	// the spare store is scaled in after all the volumes are replaced
+	storageClasses["test-tikv-1"] = "new"
+	g.Expect(replacer.Sync(tc)).To(Succeed())
+	g.Expect(tc.Status.TiKV.VolReplaceInProgress).To(BeFalse())
+	g.Expect(tc.TiKVStsDesiredReplicas()).To(Equal(int32(3)))
+}
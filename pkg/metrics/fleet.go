@@ -0,0 +1,60 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	FleetClusters = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "tidb_operator",
+			Subsystem: "fleet",
+			Name:      "clusters",
+			Help:      "Number of TidbClusters in each phase",
+		}, []string{LabelPhase})
+
+	FleetReadyClusters = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "tidb_operator",
+			Subsystem: "fleet",
+			Name:      "ready_clusters",
+			Help:      "Number of TidbClusters whose Ready condition is true",
+		})
+
+	FleetVersions = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "tidb_operator",
+			Subsystem: "fleet",
+			Name:      "versions",
+			Help:      "Number of TidbClusters running each version",
+		}, []string{LabelVersion})
+
+	FleetPendingUpgrades = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "tidb_operator",
+			Subsystem: "fleet",
+			Name:      "pending_upgrades",
+			Help:      "Number of TidbClusters being upgraded",
+		})
+
+	FleetFailedBackups = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "tidb_operator",
+			Subsystem: "fleet",
+			Name:      "failed_backups",
+			Help:      "Number of failed Backups",
+		})
+)
@@ -0,0 +1,31 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// InstrumentRoundTripper returns a RoundTripper which records the latency of the requests sent
+// to the component in ExternalAPIRequestDuration, the requests are sent by next.
+func InstrumentRoundTripper(component string, next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	obs := ExternalAPIRequestDuration.MustCurryWith(prometheus.Labels{LabelComponent: component})
+	return promhttp.InstrumentRoundTripperDuration(obs, next)
+}
@@ -22,6 +22,16 @@ const (
 	LabelNamespace = "namespace"
 	LabelName      = "name"
 	LabelComponent = "component"
+	LabelPhase     = "phase"
+	LabelVersion   = "version"
+	LabelReason    = "reason"
+)
+
+// Reconcile results of ReconcileTotal.
+const (
+	ReconcileResultSuccess = "success"
+	ReconcileResultError   = "error"
+	ReconcileResultRequeue = "requeue"
 )
 
 var (
@@ -63,6 +73,16 @@ var (
 		Name: "controller_runtime_active_workers",
 		Help: "Number of currently used workers per controller",
 	}, []string{"controller"})
+
+	// ExternalAPIRequestDuration is a prometheus metric which keeps track of the latency
+	// of the HTTP requests sent to the components, e.g. PD, TiDB and TiCDC.
+	ExternalAPIRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "tidb_operator",
+		Subsystem: "external_api",
+		Name:      "request_duration_seconds",
+		Help:      "Latency of the HTTP requests sent to the components of the clusters",
+		Buckets:   prometheus.ExponentialBuckets(0.001, 2, 15),
+	}, []string{LabelComponent, "method", "code"})
 )
 
 func init() {
@@ -72,8 +92,19 @@ func init() {
 		ReconcileTime,
 		WorkerCount,
 		ActiveWorkers,
+		ExternalAPIRequestDuration,
 
 		ClusterSpecReplicas,
 		ClusterUpdateErrors,
+		ClusterPDAPIErrors,
+		ClusterHealthProbeSuccess,
+		ClusterHealthProbeLatency,
+		ClusterPodRestarts,
+
+		FleetClusters,
+		FleetReadyClusters,
+		FleetVersions,
+		FleetPendingUpgrades,
+		FleetFailedBackups,
 	)
 }
@@ -33,4 +33,36 @@ var (
 			Name:      "update_errors",
 			Help:      "Number of errors generated in each stage when updating TiDB Clusters",
 		}, []string{LabelNamespace, LabelName, LabelComponent})
+
+	ClusterPDAPIErrors = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "tidb_operator",
+			Subsystem: "cluster",
+			Name:      "pd_api_errors",
+			Help:      "Number of failed PD API requests of TiDB Clusters by reason",
+		}, []string{LabelNamespace, LabelName, LabelReason})
+
+	ClusterHealthProbeSuccess = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "tidb_operator",
+			Subsystem: "cluster",
+			Name:      "health_probe_success",
+			Help:      "Whether the last synthetic SQL probe of TiDB Clusters succeeded",
+		}, []string{LabelNamespace, LabelName})
+
+	ClusterHealthProbeLatency = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "tidb_operator",
+			Subsystem: "cluster",
+			Name:      "health_probe_latency_seconds",
+			Help:      "Latency of the last synthetic SQL probe of TiDB Clusters",
+		}, []string{LabelNamespace, LabelName})
+
+	ClusterPodRestarts = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "tidb_operator",
+			Subsystem: "cluster",
+			Name:      "pod_restarts_total",
+			Help:      "Number of pod restarts of TiDB Clusters initiated by the operator by reason",
+		}, []string{LabelNamespace, LabelName, LabelComponent, LabelReason})
 )
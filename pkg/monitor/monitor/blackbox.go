@@ -0,0 +1,261 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package monitor
+
+import (
+	"fmt"
+	"path"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/util"
+	"gopkg.in/yaml.v2"
+	core "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	// blackboxConfigKey is the key of the config of blackbox-exporter in the ConfigMap
+	blackboxConfigKey = "blackbox.yml"
+	// blackboxRulesConfigKey is the key of the alert rules of the blackbox probes in the ConfigMap
+	blackboxRulesConfigKey = "blackbox.rules.yml"
+	// blackboxExporterPort is the port of blackbox-exporter
+	blackboxExporterPort = 9115
+
+	blackboxTCPModule = "tcp_connect"
+	blackboxTLSModule = "tls_connect"
+)
+
+// blackboxTLSClusterModule returns the name of the module which probes the services of a TLS enabled cluster
+// by the TLS handshake with the client certificate of the cluster
+func blackboxTLSClusterModule(cluster ClusterRegexInfo) string {
+	return fmt.Sprintf("tls_connect_%s_%s", cluster.Namespace, cluster.Name)
+}
+
+// RenderBlackboxExporterConfig renders the config of blackbox-exporter. Besides the TCP and TLS modules for the
+// additional targets, a module with the client certificate is rendered for each TLS enabled cluster because
+// the TLS handshake of the cluster components requires a client certificate.
+func RenderBlackboxExporterConfig(clusters []ClusterRegexInfo) yaml.MapSlice {
+	modules := yaml.MapSlice{
+		{Key: blackboxTCPModule, Value: yaml.MapSlice{
+			{Key: "prober", Value: "tcp"},
+			{Key: "timeout", Value: "5s"},
+		}},
+		{Key: blackboxTLSModule, Value: yaml.MapSlice{
+			{Key: "prober", Value: "tcp"},
+			{Key: "timeout", Value: "5s"},
+			{Key: "tcp", Value: yaml.MapSlice{
+				{Key: "tls", Value: true},
+				{Key: "tls_config", Value: yaml.MapSlice{
+					{Key: "insecure_skip_verify", Value: true},
+				}},
+			}},
+		}},
+	}
+	for _, cluster := range clusters {
+		if !cluster.enableTLS || cluster.pdPort == 0 {
+			continue
+		}
+		secretName := util.ClusterClientTLSSecretName(cluster.Name)
+		modules = append(modules, yaml.MapItem{Key: blackboxTLSClusterModule(cluster), Value: yaml.MapSlice{
+			{Key: "prober", Value: "tcp"},
+			{Key: "timeout", Value: "5s"},
+			{Key: "tcp", Value: yaml.MapSlice{
+				{Key: "tls", Value: true},
+				{Key: "tls_config", Value: yaml.MapSlice{
+					{Key: "ca_file", Value: path.Join(util.ClusterAssetsTLSPath, TLSAssetKey{"secret", cluster.Namespace, secretName, core.ServiceAccountRootCAKey}.String())},
+					{Key: "cert_file", Value: path.Join(util.ClusterAssetsTLSPath, TLSAssetKey{"secret", cluster.Namespace, secretName, core.TLSCertKey}.String())},
+					{Key: "key_file", Value: path.Join(util.ClusterAssetsTLSPath, TLSAssetKey{"secret", cluster.Namespace, secretName, core.TLSPrivateKeyKey}.String())},
+				}},
+			}},
+		}})
+	}
+	return yaml.MapSlice{{Key: "modules", Value: modules}}
+}
+
+// blackboxStaticConfig returns a target group of the blackbox job, the module of the probe is passed by the
+// __param_module label
+func blackboxStaticConfig(address, module string, labels yaml.MapSlice) yaml.MapSlice {
+	return yaml.MapSlice{
+		{Key: "targets", Value: []string{address}},
+		{Key: "labels", Value: append(yaml.MapSlice{{Key: "__param_module", Value: module}}, labels...)},
+	}
+}
+
+// blackboxScrapeJob returns the scrape job of the probes of blackbox-exporter in the same pod. The PD service of
+// each cluster is probed by the TLS handshake if the cluster enables TLS, and the TiDB service is probed by the
+// TCP connection because TiDB negotiates TLS in the MySQL protocol.
+func blackboxScrapeJob(cmodel *MonitorConfigModel) yaml.MapSlice {
+	var staticConfigs []yaml.MapSlice
+	for _, cluster := range cmodel.ClusterInfos {
+		clusterLabels := func(component string) yaml.MapSlice {
+			return yaml.MapSlice{
+				{Key: "kubernetes_namespace", Value: cluster.Namespace},
+				{Key: "cluster", Value: cluster.Name},
+				{Key: "tidb_cluster", Value: fmt.Sprintf("%s-%s", cluster.Namespace, cluster.Name)},
+				{Key: "component", Value: component},
+			}
+		}
+		if cluster.pdPort != 0 {
+			module := blackboxTCPModule
+			if cluster.enableTLS {
+				module = blackboxTLSClusterModule(cluster)
+			}
+			address := fmt.Sprintf("%s-pd.%s:%d", cluster.Name, cluster.Namespace, cluster.pdPort)
+			staticConfigs = append(staticConfigs, blackboxStaticConfig(address, module, clusterLabels("pd")))
+		}
+		if cluster.tidbPort != 0 {
+			address := fmt.Sprintf("%s-tidb.%s:%d", cluster.Name, cluster.Namespace, cluster.tidbPort)
+			staticConfigs = append(staticConfigs, blackboxStaticConfig(address, blackboxTCPModule, clusterLabels("tidb")))
+		}
+	}
+	for _, target := range cmodel.Blackbox.Targets {
+		module := blackboxTCPModule
+		if target.TLS {
+			module = blackboxTLSModule
+		}
+		staticConfigs = append(staticConfigs, blackboxStaticConfig(target.Address, module, yaml.MapSlice{
+			{Key: "endpoint", Value: target.Name},
+		}))
+	}
+
+	// shard the probes by the probed address before it's replaced by the address of blackbox-exporter
+	relabelConfigs := appendShardingRelabelConfigRules(nil, uint64(cmodel.shards))
+	relabelConfigs = append(relabelConfigs,
+		yaml.MapSlice{
+			{Key: "source_labels", Value: []string{"__address__"}},
+			{Key: "target_label", Value: "__param_target"},
+		},
+		yaml.MapSlice{
+			{Key: "source_labels", Value: []string{"__param_target"}},
+			{Key: "target_label", Value: "instance"},
+		},
+		yaml.MapSlice{
+			{Key: "target_label", Value: "__address__"},
+			{Key: "replacement", Value: fmt.Sprintf("127.0.0.1:%d", blackboxExporterPort)},
+		},
+	)
+	return yaml.MapSlice{
+		{Key: "job_name", Value: "blackbox"},
+		{Key: "scrape_interval", Value: cmodel.Blackbox.GetInterval()},
+		{Key: "metrics_path", Value: "/probe"},
+		{Key: "static_configs", Value: staticConfigs},
+		{Key: "relabel_configs", Value: relabelConfigs},
+	}
+}
+
+// RenderBlackboxAlertRules renders the default alert rules on the results of the blackbox probes
+func RenderBlackboxAlertRules() yaml.MapSlice {
+	rules := []yaml.MapSlice{
+		{
+			{Key: "alert", Value: "BlackboxProbeFailed"},
+			{Key: "expr", Value: `probe_success{job="blackbox"} == 0`},
+			{Key: "for", Value: "1m"},
+			{Key: "labels", Value: yaml.MapSlice{
+				{Key: "level", Value: "critical"},
+			}},
+			{Key: "annotations", Value: yaml.MapSlice{
+				{Key: "description", Value: "the probe of {{ $labels.instance }} fails"},
+				{Key: "summary", Value: "endpoint {{ $labels.instance }} is unreachable"},
+			}},
+		},
+		{
+			{Key: "alert", Value: "BlackboxProbeSlow"},
+			{Key: "expr", Value: `avg_over_time(probe_duration_seconds{job="blackbox"}[5m]) > 1`},
+			{Key: "for", Value: "5m"},
+			{Key: "labels", Value: yaml.MapSlice{
+				{Key: "level", Value: "warning"},
+			}},
+			{Key: "annotations", Value: yaml.MapSlice{
+				{Key: "description", Value: "the probe of {{ $labels.instance }} takes {{ $value }}s on average"},
+				{Key: "summary", Value: "endpoint {{ $labels.instance }} is slow to connect"},
+			}},
+		},
+		{
+			{Key: "alert", Value: "BlackboxTLSCertificateExpiring"},
+			{Key: "expr", Value: `probe_ssl_earliest_cert_expiry{job="blackbox"} - time() < 86400 * 7`},
+			{Key: "for", Value: "10m"},
+			{Key: "labels", Value: yaml.MapSlice{
+				{Key: "level", Value: "warning"},
+			}},
+			{Key: "annotations", Value: yaml.MapSlice{
+				{Key: "description", Value: "the TLS certificate of {{ $labels.instance }} expires in less than 7 days"},
+				{Key: "summary", Value: "TLS certificate of endpoint {{ $labels.instance }} is expiring"},
+			}},
+		},
+	}
+	return yaml.MapSlice{
+		{Key: "groups", Value: []yaml.MapSlice{
+			{
+				{Key: "name", Value: "blackbox"},
+				{Key: "rules", Value: rules},
+			},
+		}},
+	}
+}
+
+// getMonitorBlackboxExporterContainer returns the container of blackbox-exporter, it mounts the TLS assets
+// to probe the TLS enabled clusters with their client certificates.
+func getMonitorBlackboxExporterContainer(monitor *v1alpha1.TidbMonitor) core.Container {
+	blackbox := monitor.Spec.Blackbox
+	c := core.Container{
+		Name:      "blackbox-exporter",
+		Image:     blackbox.GetImage(),
+		Resources: controller.ContainerResource(blackbox.ResourceRequirements),
+		Args: []string{
+			"--config.file=/etc/prometheus/config/" + blackboxConfigKey,
+			fmt.Sprintf("--web.listen-address=:%d", blackboxExporterPort),
+		},
+		Ports: []core.ContainerPort{
+			{
+				Name:          "blackbox",
+				ContainerPort: blackboxExporterPort,
+				Protocol:      core.ProtocolTCP,
+			},
+		},
+		Env: []core.EnvVar{
+			{
+				Name:  "TZ",
+				Value: monitor.Timezone(),
+			},
+		},
+		VolumeMounts: []core.VolumeMount{
+			{
+				Name:      "prometheus-config",
+				MountPath: "/etc/prometheus/config",
+				ReadOnly:  true,
+			},
+			{
+				Name:      "tls-assets",
+				MountPath: util.ClusterAssetsTLSPath,
+				ReadOnly:  true,
+			},
+		},
+		ReadinessProbe: &core.Probe{
+			Handler: core.Handler{
+				HTTPGet: &core.HTTPGetAction{
+					Path: "/-/healthy",
+					Port: intstr.FromInt(blackboxExporterPort),
+				},
+			},
+			TimeoutSeconds:   3,
+			PeriodSeconds:    5,
+			FailureThreshold: 120,
+		},
+	}
+	if blackbox.ImagePullPolicy != nil {
+		c.ImagePullPolicy = *blackbox.ImagePullPolicy
+	}
+	return c
+}
@@ -0,0 +1,147 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package monitor
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"gopkg.in/yaml.v2"
+)
+
+func TestRenderBlackboxExporterConfig(t *testing.T) {
+	g := NewGomegaWithT(t)
+	expected := `modules:
+  tcp_connect:
+    prober: tcp
+    timeout: 5s
+  tls_connect:
+    prober: tcp
+    timeout: 5s
+    tcp:
+      tls: true
+      tls_config:
+        insecure_skip_verify: true
+  tls_connect_ns2_basic:
+    prober: tcp
+    timeout: 5s
+    tcp:
+      tls: true
+      tls_config:
+        ca_file: /var/lib/cluster-assets-tls/secret_ns2_basic-cluster-client-secret_ca.crt
+        cert_file: /var/lib/cluster-assets-tls/secret_ns2_basic-cluster-client-secret_tls.crt
+        key_file: /var/lib/cluster-assets-tls/secret_ns2_basic-cluster-client-secret_tls.key
+`
+	bs, err := yaml.Marshal(RenderBlackboxExporterConfig([]ClusterRegexInfo{
+		{Name: "basic", Namespace: "ns1", pdPort: 2379, tidbPort: 4000},
+		{Name: "basic", Namespace: "ns2", enableTLS: true, pdPort: 2379, tidbPort: 4000},
+		{Name: "tikv-only", Namespace: "ns2", enableTLS: true},
+	}))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(bs)).To(Equal(expected))
+}
+
+func TestBlackboxScrapeJob(t *testing.T) {
+	g := NewGomegaWithT(t)
+	expected := `job_name: blackbox
+scrape_interval: 1m
+metrics_path: /probe
+static_configs:
+- targets:
+  - basic-pd.ns1:2379
+  labels:
+    __param_module: tls_connect_ns1_basic
+    kubernetes_namespace: ns1
+    cluster: basic
+    tidb_cluster: ns1-basic
+    component: pd
+- targets:
+  - basic-tidb.ns1:4000
+  labels:
+    __param_module: tcp_connect
+    kubernetes_namespace: ns1
+    cluster: basic
+    tidb_cluster: ns1-basic
+    component: tidb
+- targets:
+  - tidb.example.com:4000
+  labels:
+    __param_module: tcp_connect
+    endpoint: tidb-lb
+- targets:
+  - pd.example.com:2379
+  labels:
+    __param_module: tls_connect
+    endpoint: pd-lb
+relabel_configs:
+- source_labels:
+  - __address__
+  action: hashmod
+  target_label: __tmp_hash
+  modulus: 1
+- source_labels:
+  - __tmp_hash
+  regex: $(SHARD)
+  action: keep
+- source_labels:
+  - __address__
+  target_label: __param_target
+- source_labels:
+  - __param_target
+  target_label: instance
+- target_label: __address__
+  replacement: 127.0.0.1:9115
+`
+	interval := "1m"
+	model := &MonitorConfigModel{
+		ClusterInfos: []ClusterRegexInfo{
+			{Name: "basic", Namespace: "ns1", enableTLS: true, pdPort: 2379, tidbPort: 4000},
+		},
+		Blackbox: &v1alpha1.BlackboxExporterSpec{
+			Interval: &interval,
+			Targets: []v1alpha1.BlackboxTarget{
+				{Name: "tidb-lb", Address: "tidb.example.com:4000"},
+				{Name: "pd-lb", Address: "pd.example.com:2379", TLS: true},
+			},
+		},
+		shards: 1,
+	}
+	bs, err := yaml.Marshal(blackboxScrapeJob(model))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(bs)).To(Equal(expected))
+
+	cfg, err := RenderPrometheusConfig(model)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(cfg[len(cfg)-1]).To(Equal(yaml.MapItem{
+		Key:   "rule_files",
+		Value: []string{"/etc/prometheus/config/blackbox.rules.yml"},
+	}))
+}
+
+func TestGetMonitorBlackboxExporterContainer(t *testing.T) {
+	g := NewGomegaWithT(t)
+	monitor := &v1alpha1.TidbMonitor{
+		Spec: v1alpha1.TidbMonitorSpec{
+			Blackbox: &v1alpha1.BlackboxExporterSpec{},
+		},
+	}
+	c := getMonitorBlackboxExporterContainer(monitor)
+	g.Expect(c.Image).To(Equal("prom/blackbox-exporter:v0.24.0"))
+	g.Expect(c.Args).To(ContainElement("--config.file=/etc/prometheus/config/blackbox.yml"))
+
+	monitor.Spec.Blackbox.BaseImage = "registry.example.com/blackbox-exporter"
+	c = getMonitorBlackboxExporterContainer(monitor)
+	g.Expect(c.Image).To(Equal("registry.example.com/blackbox-exporter:v0.24.0"))
+}
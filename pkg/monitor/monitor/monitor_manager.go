@@ -16,6 +16,7 @@ package monitor
 import (
 	"encoding/json"
 	"fmt"
+	"path"
 	"sort"
 	"strings"
 
@@ -84,6 +85,12 @@ func (m *MonitorManager) SyncMonitor(monitor *v1alpha1.TidbMonitor) error {
 	assetStore := NewStore(m.deps.SecretLister)
 
 	for _, tcRef := range monitor.Spec.Clusters {
+		if tcRef.Remote != nil {
+			if err := addRemoteClusterAssets(assetStore, monitor.Namespace, tcRef.Remote); err != nil {
+				return err
+			}
+			continue
+		}
 		tc, err := m.deps.TiDBClusterLister.TidbClusters(tcRef.Namespace).Get(tcRef.Name)
 		if err != nil {
 			rerr := fmt.Errorf("get tm[%s/%s]'s target tc[%s/%s] failed, err: %v", monitor.Namespace, monitor.Name, tcRef.Namespace, tcRef.Name, err)
@@ -112,6 +119,12 @@ func (m *MonitorManager) SyncMonitor(monitor *v1alpha1.TidbMonitor) error {
 	var firstDc *v1alpha1.DMCluster
 	if monitor.Spec.DM != nil {
 		for _, dcRef := range monitor.Spec.DM.Clusters {
+			if dcRef.Remote != nil {
+				if err := addRemoteClusterAssets(assetStore, monitor.Namespace, dcRef.Remote); err != nil {
+					return err
+				}
+				continue
+			}
 			dc, err := m.deps.DMClusterLister.DMClusters(dcRef.Namespace).Get(dcRef.Name)
 			if err != nil {
 				rerr := fmt.Errorf("get tm[%s/%s]'s target dc[%s/%s] failed, err: %v", monitor.Namespace, monitor.Name, dcRef.Namespace, dcRef.Name, err)
@@ -271,6 +284,11 @@ func (m *MonitorManager) syncTidbMonitorStatefulset(tc *v1alpha1.TidbCluster, dc
 		}
 	}
 
+	if err := m.syncRemovedShards(monitor); err != nil {
+		klog.Errorf("Fail to remove the scaled in shards for tm [%s/%s], err: %v", ns, name, err)
+		return err
+	}
+
 	if !isAllCreated {
 		return controller.RequeueErrorf("TidbMonitor: [%s/%s], waiting for tidbmonitor running", ns, name)
 	} else {
@@ -278,6 +296,53 @@ func (m *MonitorManager) syncTidbMonitorStatefulset(tc *v1alpha1.TidbCluster, dc
 	}
 }
 
+// syncRemovedShards deletes the StatefulSets and the Services of the shards removed from spec.shards.
+// The PVCs of the removed shards are retained, since their data is not resharded onto the remaining shards.
+func (m *MonitorManager) syncRemovedShards(monitor *v1alpha1.TidbMonitor) error {
+	ns := monitor.Namespace
+	var removed []*appsv1.StatefulSet
+	for shard := monitor.GetShards(); ; shard++ {
+		stsName := GetMonitorShardName(monitor.Name, shard)
+		sts, err := m.deps.StatefulSetLister.StatefulSets(ns).Get(stsName)
+		if errors.IsNotFound(err) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("syncRemovedShards: fail to get sts %s for tm %s/%s, error: %s", stsName, ns, monitor.Name, err)
+		}
+		if !metav1.IsControlledBy(sts, monitor) {
+			break
+		}
+		removed = append(removed, sts)
+	}
+
+	// delete the shards from the last one, so that the shards are kept contiguous if the deletion fails
+	for i := len(removed) - 1; i >= 0; i-- {
+		shard := monitor.GetShards() + int32(i)
+		sts := removed[i]
+		for _, svcName := range []string{PrometheusName(monitor.Name, shard), reloaderName(monitor, shard), GrafanaName(monitor.Name, shard)} {
+			svc, err := m.deps.ServiceLister.Services(ns).Get(svcName)
+			if errors.IsNotFound(err) {
+				continue
+			}
+			if err != nil {
+				return fmt.Errorf("syncRemovedShards: fail to get svc %s for tm %s/%s, error: %s", svcName, ns, monitor.Name, err)
+			}
+			if err := m.deps.ServiceControl.DeleteService(monitor, svc); err != nil && !errors.IsNotFound(err) {
+				return err
+			}
+		}
+		if sts.DeletionTimestamp != nil {
+			continue
+		}
+		klog.Infof("tm[%s/%s]'s shard %d is removed, delete its statefulset %s", ns, monitor.Name, shard, sts.Name)
+		if err := m.deps.StatefulSetControl.DeleteStatefulSet(monitor, sts); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
 func (m *MonitorManager) syncTidbMonitorSecret(monitor *v1alpha1.TidbMonitor) (*corev1.Secret, error) {
 	if monitor.Spec.Grafana == nil {
 		return nil, nil
@@ -294,6 +359,9 @@ func (m *MonitorManager) syncTidbMonitorConfig(monitor *v1alpha1.TidbMonitor, st
 		cloned := monitor.DeepCopy()
 		autoTcRefs := []v1alpha1.TidbClusterRef{}
 		for _, tcRef := range monitor.Spec.Clusters {
+			if tcRef.Remote != nil {
+				continue
+			}
 			r1, err := labels.NewRequirement(label.AutoInstanceLabelKey, selection.Exists, nil)
 			if err != nil {
 				klog.Errorf("tm[%s/%s] gets tc[%s/%s]'s autoscaling clusters failed, err: %v", monitor.Namespace, monitor.Name, tcRef.Namespace, tcRef.Name, err)
@@ -332,6 +400,10 @@ func (m *MonitorManager) syncTidbMonitorConfig(monitor *v1alpha1.TidbMonitor, st
 
 	var monitorClusterInfos []ClusterRegexInfo
 	for _, tcRef := range monitor.Spec.Clusters {
+		if tcRef.Remote != nil {
+			monitorClusterInfos = append(monitorClusterInfos, buildRemoteClusterRegexInfo(monitor, tcRef))
+			continue
+		}
 		tc, err := m.deps.TiDBClusterLister.TidbClusters(tcRef.Namespace).Get(tcRef.Name)
 		if err != nil {
 			rerr := fmt.Errorf("get tm[%s/%s]'s target tc[%s/%s] failed, err: %v", monitor.Namespace, monitor.Name, tcRef.Namespace, tcRef.Name, err)
@@ -345,12 +417,22 @@ func (m *MonitorManager) syncTidbMonitorConfig(monitor *v1alpha1.TidbMonitor, st
 		if tc.IsTLSClusterEnabled() {
 			clusterRegex.enableTLS = true
 		}
+		if tc.Spec.PD != nil {
+			clusterRegex.pdPort = v1alpha1.DefaultPDClientPort
+		}
+		if tc.Spec.TiDB != nil {
+			clusterRegex.tidbPort = tc.Spec.TiDB.GetServicePort()
+		}
 		monitorClusterInfos = append(monitorClusterInfos, clusterRegex)
 	}
 
 	var dmClusterInfos []ClusterRegexInfo
 	if monitor.Spec.DM != nil {
 		for _, dmRef := range monitor.Spec.DM.Clusters {
+			if dmRef.Remote != nil {
+				dmClusterInfos = append(dmClusterInfos, buildRemoteClusterRegexInfo(monitor, v1alpha1.TidbClusterRef(dmRef)))
+				continue
+			}
 			dm, err := m.deps.DMClusterLister.DMClusters(dmRef.Namespace).Get(dmRef.Name)
 			if err != nil {
 				rerr := fmt.Errorf("get tm[%s/%s]'s target dm[%s/%s] failed, err: %v", monitor.Namespace, monitor.Name, dmRef.Namespace, dmRef.Name, err)
@@ -409,6 +491,44 @@ func (m *MonitorManager) syncTidbMonitorConfig(monitor *v1alpha1.TidbMonitor, st
 	return err
 }
 
+// addRemoteClusterAssets adds the kubeconfig and the client certificate of the cluster in another Kubernetes
+// cluster to the store, the secrets are in the namespace of TidbMonitor
+func addRemoteClusterAssets(store *Store, ns string, remote *v1alpha1.RemoteClusterRef) error {
+	if remote.KubeconfigSecret != "" {
+		if err := store.addTLSAssets(ns, remote.KubeconfigSecret); err != nil {
+			return err
+		}
+	}
+	if remote.TLSClientSecret != "" {
+		if err := store.addTLSAssets(ns, remote.TLSClientSecret); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// buildRemoteClusterRegexInfo builds the monitor cluster info of the cluster in another Kubernetes cluster,
+// the components of the cluster are unknown, so that they are not probed by blackbox-exporter.
+func buildRemoteClusterRegexInfo(monitor *v1alpha1.TidbMonitor, ref v1alpha1.TidbClusterRef) ClusterRegexInfo {
+	remote := ref.Remote
+	info := &remoteClusterInfo{
+		kubernetesCluster:  remote.KubernetesCluster,
+		clusterDomain:      ref.ClusterDomain,
+		endpoints:          remote.Endpoints,
+		tlsSecretNamespace: monitor.Namespace,
+		tlsSecretName:      remote.TLSClientSecret,
+	}
+	if remote.KubeconfigSecret != "" {
+		info.kubeconfigFile = path.Join(util.ClusterAssetsTLSPath, TLSAssetKey{"secret", monitor.Namespace, remote.KubeconfigSecret, v1alpha1.RemoteKubeconfigKey}.String())
+	}
+	return ClusterRegexInfo{
+		Name:      ref.Name,
+		Namespace: ref.Namespace,
+		enableTLS: remote.TLSClientSecret != "",
+		remote:    info,
+	}
+}
+
 func (m *MonitorManager) syncTidbMonitorRbac(monitor *v1alpha1.TidbMonitor) (*corev1.ServiceAccount, error) {
 	sa := getMonitorServiceAccount(monitor)
 	sa, err := m.deps.TypedControl.CreateOrUpdateServiceAccount(monitor, sa)
@@ -749,7 +869,8 @@ func (m *MonitorManager) syncDashboardMetricStorage(tc *v1alpha1.TidbCluster, tm
 	if tc.Spec.PD == nil || tc.ComponentIsSuspending(v1alpha1.PDMemberType) {
 		return nil
 	}
-	pdEtcdClient, err := m.deps.PDControl.GetPDEtcdClient(pdapi.Namespace(tc.Namespace), tc.Name, tc.IsTLSClusterEnabled())
+	pdEtcdClient, err := m.deps.PDControl.GetPDEtcdClient(pdapi.Namespace(tc.Namespace), tc.Name, tc.IsTLSClusterEnabled(),
+		pdapi.TLSFromCluster(pdapi.Namespace(tc.Namespace), tc.Spec.TLSCluster))
 
 	if err != nil {
 		return err
@@ -818,6 +939,9 @@ func (m *MonitorManager) syncBasicAuth(monitor *v1alpha1.TidbMonitor, store *Sto
 		if err := store.AddBasicAuth(monitor.Namespace, remoteWrite.BasicAuth, fmt.Sprintf("remoteWrite/%d", i)); err != nil {
 			return err
 		}
+		if err := store.AddBearerToken(monitor.Namespace, remoteWrite.BearerTokenSecret, fmt.Sprintf("remoteWrite/%d", i)); err != nil {
+			return err
+		}
 	}
 	return nil
 }
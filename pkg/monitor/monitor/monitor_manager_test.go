@@ -22,9 +22,11 @@ import (
 	"github.com/pingcap/tidb-operator/pkg/controller"
 	"github.com/pingcap/tidb-operator/pkg/manager/meta"
 	"github.com/prometheus/common/model"
+	apps "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	discoverycachedmemory "k8s.io/client-go/discovery/cached/memory"
 	discoveryfake "k8s.io/client-go/discovery/fake"
@@ -625,6 +627,51 @@ func TestTidbMonitorSyncUpdate(t *testing.T) {
 	}
 }
 
+// deletionRecordingStatefulSetControl records the StatefulSets deleted by the monitor manager
+type deletionRecordingStatefulSetControl struct {
+	*controller.FakeStatefulSetControl
+	deleted []string
+}
+
+func (c *deletionRecordingStatefulSetControl) DeleteStatefulSet(_ runtime.Object, set *apps.StatefulSet) error {
+	c.deleted = append(c.deleted, set.Name)
+	return nil
+}
+
+func TestTidbMonitorSyncRemovedShards(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tmm := newFakeTidbMonitorManager()
+	setControl := &deletionRecordingStatefulSetControl{FakeStatefulSetControl: tmm.deps.StatefulSetControl.(*controller.FakeStatefulSetControl)}
+	tmm.deps.StatefulSetControl = setControl
+	tm := newTidbMonitor(v1alpha1.TidbClusterRef{Name: "foo", Namespace: "ns"})
+	tm.UID = "foo"
+	tm.Spec.Shards = pointer.Int32Ptr(3)
+
+	setIndexer := tmm.deps.KubeInformerFactory.Apps().V1().StatefulSets().Informer().GetIndexer()
+	for shard := int32(0); shard < 3; shard++ {
+		g.Expect(setIndexer.Add(&apps.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            GetMonitorShardName(tm.Name, shard),
+				Namespace:       tm.Namespace,
+				OwnerReferences: []metav1.OwnerReference{controller.GetTiDBMonitorOwnerRef(tm)},
+			},
+		})).To(Succeed())
+	}
+	// the StatefulSet of another TidbMonitor is never deleted
+	g.Expect(setIndexer.Add(&apps.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: GetMonitorShardName(tm.Name, 3), Namespace: tm.Namespace},
+	})).To(Succeed())
+
+	g.Expect(tmm.syncRemovedShards(tm)).To(Succeed())
+	g.Expect(setControl.deleted).To(BeEmpty())
+
+	// the shards are deleted from the last one
+	tm.Spec.Shards = pointer.Int32Ptr(1)
+	g.Expect(tmm.syncRemovedShards(tm)).To(Succeed())
+	g.Expect(setControl.deleted).To(Equal([]string{GetMonitorShardName(tm.Name, 2), GetMonitorShardName(tm.Name, 1)}))
+}
+
 func newTidbMonitor(cluster v1alpha1.TidbClusterRef) *v1alpha1.TidbMonitor {
 	return &v1alpha1.TidbMonitor{
 		ObjectMeta: metav1.ObjectMeta{
@@ -17,6 +17,7 @@ import (
 	"fmt"
 
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
 	corelisterv1 "k8s.io/client-go/listers/core/v1"
 )
 
@@ -28,17 +29,19 @@ import (
 //
 // Store doesn't support concurrent access.
 type Store struct {
-	secretLister    corelisterv1.SecretLister
-	TLSAssets       map[TLSAssetKey]TLSAsset
-	BasicAuthAssets map[string]BasicAuthCredentials
+	secretLister      corelisterv1.SecretLister
+	TLSAssets         map[TLSAssetKey]TLSAsset
+	BasicAuthAssets   map[string]BasicAuthCredentials
+	BearerTokenAssets map[string]string
 }
 
 // NewStore returns an empty assetStore.
 func NewStore(secretLister corelisterv1.SecretLister) *Store {
 	return &Store{
-		secretLister:    secretLister,
-		TLSAssets:       make(map[TLSAssetKey]TLSAsset),
-		BasicAuthAssets: make(map[string]BasicAuthCredentials),
+		secretLister:      secretLister,
+		TLSAssets:         make(map[TLSAssetKey]TLSAsset),
+		BasicAuthAssets:   make(map[string]BasicAuthCredentials),
+		BearerTokenAssets: make(map[string]string),
 	}
 }
 
@@ -86,6 +89,26 @@ func (s *Store) AddBasicAuth(ns string, ba *v1alpha1.BasicAuth, key string) erro
 	return nil
 }
 
+// AddBearerToken processes the given *SecretKeySelector and adds the referenced bearer token to the store.
+func (s *Store) AddBearerToken(ns string, sel *corev1.SecretKeySelector, key string) error {
+	if sel == nil {
+		return nil
+	}
+
+	secret, err := s.secretLister.Secrets(ns).Get(sel.Name)
+	if err != nil {
+		return fmt.Errorf("get secret [%s/%s] failed, err: %v", ns, sel.Name, err)
+	}
+
+	token, ok := secret.Data[sel.Key]
+	if !ok {
+		return fmt.Errorf("secret:[%s/%s] not contain key:[%s]", secret.Namespace, secret.Name, sel.Key)
+	}
+	s.BearerTokenAssets[key] = string(token)
+
+	return nil
+}
+
 // TLSAssetKey is a key for a TLS asset.
 type TLSAssetKey struct {
 	from string
@@ -18,6 +18,7 @@ import (
 	"path"
 	"strings"
 
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
 	"github.com/pingcap/tidb-operator/pkg/util"
 	"github.com/prometheus/common/model"
 	"gopkg.in/yaml.v2"
@@ -70,6 +71,9 @@ var (
         }
     ]
 }`
+
+	// remoteAddressPattern matches the peer address of the pod of the cluster in another Kubernetes cluster
+	remoteAddressPattern = "(.+):(\\d+)"
 )
 
 type MonitorConfigModel struct {
@@ -80,6 +84,8 @@ type MonitorConfigModel struct {
 	RemoteWriteCfg            *yaml.MapItem
 	EnableAlertRules          bool
 	EnableExternalRuleConfigs bool
+	EnableDownsampledRules    bool
+	Blackbox                  *v1alpha1.BlackboxExporterSpec
 	shards                    int32
 }
 
@@ -88,6 +94,35 @@ type ClusterRegexInfo struct {
 	Name      string
 	Namespace string
 	enableTLS bool
+	// pdPort and tidbPort are the ports of the PD and TiDB services probed by blackbox-exporter,
+	// 0 if the component is not deployed in the cluster
+	pdPort   int32
+	tidbPort int32
+	// remote is set if the cluster is in another Kubernetes cluster
+	remote *remoteClusterInfo
+}
+
+// remoteClusterInfo is how the cluster in another Kubernetes cluster is scraped
+type remoteClusterInfo struct {
+	kubernetesCluster string
+	// kubeconfigFile is the path of the kubeconfig of the Kubernetes cluster in the monitor pod,
+	// the static endpoints are scraped if it's empty
+	kubeconfigFile string
+	clusterDomain  string
+	endpoints      []v1alpha1.RemoteClusterEndpoints
+	// tlsSecretNamespace and tlsSecretName are the secret of the client certificate of the cluster
+	tlsSecretNamespace string
+	tlsSecretName      string
+}
+
+// tlsAssetPath returns the path of the key of the client certificate of the cluster in the monitor pod,
+// the secret of the client certificate of a local cluster is the given secret in the namespace of the cluster
+func (c ClusterRegexInfo) tlsAssetPath(localSecret string, key string) string {
+	ns, name := c.Namespace, localSecret
+	if c.remote != nil {
+		ns, name = c.remote.tlsSecretNamespace, c.remote.tlsSecretName
+	}
+	return path.Join(util.ClusterAssetsTLSPath, TLSAssetKey{"secret", ns, name, key}.String())
 }
 
 func newPrometheusConfig(cmodel *MonitorConfigModel) yaml.MapSlice {
@@ -105,6 +140,9 @@ func newPrometheusConfig(cmodel *MonitorConfigModel) yaml.MapSlice {
 	scrapeJobs = append(scrapeJobs, scrapeJob("lightning", lightningPattern, cmodel, buildAddressRelabelConfigByComponent("lightning"))...)
 	scrapeJobs = append(scrapeJobs, scrapeJob(dmWorker, dmWorkerPattern, cmodel, buildAddressRelabelConfigByComponent(dmWorker))...)
 	scrapeJobs = append(scrapeJobs, scrapeJob(dmMaster, dmMasterPattern, cmodel, buildAddressRelabelConfigByComponent(dmMaster))...)
+	if cmodel.Blackbox != nil {
+		scrapeJobs = append(scrapeJobs, blackboxScrapeJob(cmodel))
+	}
 	cfg := yaml.MapSlice{}
 	globalItems := yaml.MapSlice{
 		{Key: "evaluation_interval", Value: "15s"},
@@ -273,15 +311,15 @@ func scrapeJob(jobName string, componentPattern string, cmodel *MonitorConfigMod
 				tlsConfigRelabelConfig = yaml.MapSlice{
 					yaml.MapItem{
 						Key:   "ca_file",
-						Value: path.Join(util.ClusterAssetsTLSPath, TLSAssetKey{"secret", cluster.Namespace, dmTlsSecretName, corev1.ServiceAccountRootCAKey}.String()),
+						Value: cluster.tlsAssetPath(dmTlsSecretName, corev1.ServiceAccountRootCAKey),
 					},
 					yaml.MapItem{
 						Key:   "cert_file",
-						Value: path.Join(util.ClusterAssetsTLSPath, TLSAssetKey{"secret", cluster.Namespace, dmTlsSecretName, corev1.TLSCertKey}.String()),
+						Value: cluster.tlsAssetPath(dmTlsSecretName, corev1.TLSCertKey),
 					},
 					yaml.MapItem{
 						Key:   "key_file",
-						Value: path.Join(util.ClusterAssetsTLSPath, TLSAssetKey{"secret", cluster.Namespace, dmTlsSecretName, corev1.TLSPrivateKeyKey}.String()),
+						Value: cluster.tlsAssetPath(dmTlsSecretName, corev1.TLSPrivateKeyKey),
 					},
 				}
 			default:
@@ -290,31 +328,47 @@ func scrapeJob(jobName string, componentPattern string, cmodel *MonitorConfigMod
 				tlsConfigRelabelConfig = yaml.MapSlice{
 					yaml.MapItem{
 						Key:   "ca_file",
-						Value: path.Join(util.ClusterAssetsTLSPath, TLSAssetKey{"secret", cluster.Namespace, tcTlsSecretName, corev1.ServiceAccountRootCAKey}.String()),
+						Value: cluster.tlsAssetPath(tcTlsSecretName, corev1.ServiceAccountRootCAKey),
 					},
 					yaml.MapItem{
 						Key:   "cert_file",
-						Value: path.Join(util.ClusterAssetsTLSPath, TLSAssetKey{"secret", cluster.Namespace, tcTlsSecretName, corev1.TLSCertKey}.String()),
+						Value: cluster.tlsAssetPath(tcTlsSecretName, corev1.TLSCertKey),
 					},
 					yaml.MapItem{
 						Key:   "key_file",
-						Value: path.Join(util.ClusterAssetsTLSPath, TLSAssetKey{"secret", cluster.Namespace, tcTlsSecretName, corev1.TLSPrivateKeyKey}.String()),
+						Value: cluster.tlsAssetPath(tcTlsSecretName, corev1.TLSPrivateKeyKey),
 					},
 				}
 			}
 		}
 
+		if cluster.remote != nil && cluster.remote.kubeconfigFile == "" {
+			if job := remoteStaticScrapeJob(jobName, cluster, cmodel, schemeRelabelConfig, tlsConfigRelabelConfig); job != nil {
+				scrapeJobs = append(scrapeJobs, job)
+			}
+			continue
+		}
+
+		jobNameValue := fmt.Sprintf("%s-%s-%s", cluster.Namespace, cluster.Name, jobName)
+		apiServerConfig := yaml.MapItem{
+			Key:   "api_server",
+			Value: nil,
+		}
+		if cluster.remote != nil {
+			jobNameValue = fmt.Sprintf("%s-%s", cluster.remote.kubernetesCluster, jobNameValue)
+			apiServerConfig = yaml.MapItem{
+				Key:   "kubeconfig_file",
+				Value: cluster.remote.kubeconfigFile,
+			}
+		}
 		scrapeConfig := yaml.MapSlice{
-			{Key: "job_name", Value: fmt.Sprintf("%s-%s-%s", cluster.Namespace, cluster.Name, jobName)},
+			{Key: "job_name", Value: jobNameValue},
 			{Key: "honor_labels", Value: true},
 			{Key: "scrape_interval", Value: "15s"},
 			schemeRelabelConfig,
 			{Key: "kubernetes_sd_configs", Value: []yaml.MapSlice{
 				{
-					{
-						Key:   "api_server",
-						Value: nil,
-					},
+					apiServerConfig,
 					{
 						Key:   "role",
 						Value: "pod",
@@ -441,6 +495,19 @@ func scrapeJob(jobName string, componentPattern string, cmodel *MonitorConfigMod
 			},
 		)
 
+		if cluster.remote != nil {
+			if cluster.remote.clusterDomain != "" {
+				// the peer addresses are resolved by the cluster domain of the remote Kubernetes cluster
+				relabelConfigs = append(relabelConfigs, yaml.MapSlice{
+					{Key: "source_labels", Value: []string{"__address__"}},
+					{Key: "action", Value: "replace"},
+					{Key: "regex", Value: remoteAddressPattern},
+					{Key: "replacement", Value: fmt.Sprintf("$1.svc.%s:$2", cluster.remote.clusterDomain)},
+					{Key: "target_label", Value: "__address__"},
+				})
+			}
+			relabelConfigs = append(relabelConfigs, kubernetesClusterRelabelConfig(cluster.remote))
+		}
 		relabelConfigs = appendShardingRelabelConfigRules(relabelConfigs, uint64(cmodel.shards))
 		scrapeConfig = append(scrapeConfig, yaml.MapItem{Key: "relabel_configs", Value: relabelConfigs})
 		scrapeJobs = append(scrapeJobs, scrapeConfig)
@@ -450,6 +517,51 @@ func scrapeJob(jobName string, componentPattern string, cmodel *MonitorConfigMod
 
 }
 
+// kubernetesClusterRelabelConfig returns the relabel config which sets the kubernetes_cluster label of the
+// series scraped from the cluster in another Kubernetes cluster
+func kubernetesClusterRelabelConfig(remote *remoteClusterInfo) yaml.MapSlice {
+	return yaml.MapSlice{
+		{Key: "action", Value: "replace"},
+		{Key: "replacement", Value: remote.kubernetesCluster},
+		{Key: "target_label", Value: "kubernetes_cluster"},
+	}
+}
+
+// remoteStaticScrapeJob returns the scrape job of the static endpoints of the component of the cluster in
+// another Kubernetes cluster, nil is returned if there are no endpoints of the component.
+func remoteStaticScrapeJob(jobName string, cluster ClusterRegexInfo, cmodel *MonitorConfigModel, scheme yaml.MapItem, tlsConfig yaml.MapSlice) yaml.MapSlice {
+	var targets []string
+	for _, endpoints := range cluster.remote.endpoints {
+		if endpoints.Component == jobName {
+			targets = append(targets, endpoints.Targets...)
+		}
+	}
+	if len(targets) == 0 {
+		return nil
+	}
+	relabelConfigs := []yaml.MapSlice{kubernetesClusterRelabelConfig(cluster.remote)}
+	relabelConfigs = appendShardingRelabelConfigRules(relabelConfigs, uint64(cmodel.shards))
+	return yaml.MapSlice{
+		{Key: "job_name", Value: fmt.Sprintf("%s-%s-%s-%s", cluster.remote.kubernetesCluster, cluster.Namespace, cluster.Name, jobName)},
+		{Key: "honor_labels", Value: true},
+		{Key: "scrape_interval", Value: "15s"},
+		scheme,
+		{Key: "static_configs", Value: []yaml.MapSlice{
+			{
+				{Key: "targets", Value: targets},
+				{Key: "labels", Value: yaml.MapSlice{
+					{Key: "kubernetes_namespace", Value: cluster.Namespace},
+					{Key: "cluster", Value: cluster.Name},
+					{Key: "component", Value: jobName},
+					{Key: "tidb_cluster", Value: fmt.Sprintf("%s-%s", cluster.Namespace, cluster.Name)},
+				}},
+			},
+		}},
+		{Key: "tls_config", Value: tlsConfig},
+		{Key: "relabel_configs", Value: relabelConfigs},
+	}
+}
+
 func isDMJob(jobName string) bool {
 	if jobName == dmMaster || jobName == dmWorker {
 		return true
@@ -503,6 +615,12 @@ func RenderPrometheusConfig(model *MonitorConfigModel) (yaml.MapSlice, error) {
 			"/prometheus-external-rules/*.rules.yml",
 		}
 	}
+	if model.EnableDownsampledRules {
+		rulesPath = append(rulesPath, "/etc/prometheus/config/"+downsampledRulesConfigKey)
+	}
+	if model.Blackbox != nil {
+		rulesPath = append(rulesPath, "/etc/prometheus/config/"+blackboxRulesConfigKey)
+	}
 	if rulesPath != nil {
 		cfg = append(cfg, yaml.MapItem{
 			Key:   "rule_files",
@@ -512,6 +630,91 @@ func RenderPrometheusConfig(model *MonitorConfigModel) (yaml.MapSlice, error) {
 	return cfg, nil
 }
 
+// downsampledRecordingRules are the built-in recording rules of the downsampled tier of Prometheus,
+// $(INTERVAL) in the expressions is replaced by the downsampling interval.
+var downsampledRecordingRules = []v1alpha1.PrometheusRecordingRule{
+	{
+		Record: "downsampled:tidb_server_query:rate",
+		Expr:   "sum(rate(tidb_server_query_total[$(INTERVAL)])) by (kubernetes_namespace, tidb_cluster, instance, type, result)",
+	},
+	{
+		Record: "downsampled:tidb_server_handle_query_duration_seconds:p99",
+		Expr:   "histogram_quantile(0.99, sum(rate(tidb_server_handle_query_duration_seconds_bucket[$(INTERVAL)])) by (kubernetes_namespace, tidb_cluster, le))",
+	},
+	{
+		Record: "downsampled:tidb_server_connections",
+		Expr:   "sum(tidb_server_connections) by (kubernetes_namespace, tidb_cluster, instance)",
+	},
+	{
+		Record: "downsampled:process_cpu_seconds:rate",
+		Expr:   "sum(rate(process_cpu_seconds_total[$(INTERVAL)])) by (kubernetes_namespace, tidb_cluster, component, instance)",
+	},
+	{
+		Record: "downsampled:process_resident_memory_bytes",
+		Expr:   "sum(process_resident_memory_bytes) by (kubernetes_namespace, tidb_cluster, component, instance)",
+	},
+	{
+		Record: "downsampled:tikv_store_size_bytes",
+		Expr:   "sum(tikv_store_size_bytes) by (kubernetes_namespace, tidb_cluster, instance, type)",
+	},
+	{
+		Record: "downsampled:pd_cluster_status",
+		Expr:   "sum(pd_cluster_status) by (kubernetes_namespace, tidb_cluster, type)",
+	},
+}
+
+// RenderDownsampledRecordingRules renders the recording rules evaluated by the raw Prometheus at the downsampling
+// interval, which aggregate the raw series into the ones kept by the downsampled tier of Prometheus.
+func RenderDownsampledRecordingRules(interval string, additionalRules []v1alpha1.PrometheusRecordingRule) yaml.MapSlice {
+	var rules []yaml.MapSlice
+	for _, rule := range downsampledRecordingRules {
+		rules = append(rules, yaml.MapSlice{
+			{Key: "record", Value: rule.Record},
+			{Key: "expr", Value: strings.ReplaceAll(rule.Expr, "$(INTERVAL)", interval)},
+		})
+	}
+	for _, rule := range additionalRules {
+		rules = append(rules, yaml.MapSlice{
+			{Key: "record", Value: rule.Record},
+			{Key: "expr", Value: rule.Expr},
+		})
+	}
+	return yaml.MapSlice{
+		{Key: "groups", Value: []yaml.MapSlice{
+			{
+				{Key: "name", Value: "downsampled"},
+				{Key: "interval", Value: interval},
+				{Key: "rules", Value: rules},
+			},
+		}},
+	}
+}
+
+// RenderDownsampledPrometheusConfig renders the config of the downsampled tier of Prometheus, which federates
+// only the series recorded by the downsampled recording rules from the raw Prometheus in the same pod once per interval.
+func RenderDownsampledPrometheusConfig(interval string) yaml.MapSlice {
+	return yaml.MapSlice{
+		{Key: "global", Value: yaml.MapSlice{
+			{Key: "scrape_interval", Value: interval},
+			{Key: "evaluation_interval", Value: interval},
+		}},
+		{Key: "scrape_configs", Value: []yaml.MapSlice{
+			{
+				{Key: "job_name", Value: "federate"},
+				{Key: "honor_labels", Value: true},
+				{Key: "scrape_timeout", Value: "1m"},
+				{Key: "metrics_path", Value: "/federate"},
+				{Key: "params", Value: yaml.MapSlice{
+					{Key: "match[]", Value: []string{fmt.Sprintf(`{__name__=~"%s.+"}`, v1alpha1.PrometheusDownsampledRecordPrefix)}},
+				}},
+				{Key: "static_configs", Value: []yaml.MapSlice{
+					{{Key: "targets", Value: []string{"127.0.0.1:9090"}}},
+				}},
+			},
+		}},
+	}
+}
+
 func appendShardingRelabelConfigRules(relabelConfigs []yaml.MapSlice, shard uint64) []yaml.MapSlice {
 	shardsPattern := "$(SHARD)"
 	return append(relabelConfigs, yaml.MapSlice{
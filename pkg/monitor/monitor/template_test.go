@@ -3275,3 +3275,139 @@ func TestScrapeJob(t *testing.T) {
 		},
 	}))
 }
+
+func TestRemoteClusterScrapeJob(t *testing.T) {
+	g := NewGomegaWithT(t)
+	model := &MonitorConfigModel{
+		ClusterInfos: []ClusterRegexInfo{
+			{Name: "basic", Namespace: "ns1", enableTLS: true, remote: &remoteClusterInfo{
+				kubernetesCluster:  "us-west-1",
+				kubeconfigFile:     "/var/lib/cluster-assets-tls/secret_monitor_us-west-1_kubeconfig",
+				clusterDomain:      "us-west-1.local",
+				tlsSecretNamespace: "monitor",
+				tlsSecretName:      "us-west-1-basic-client",
+			}},
+			{Name: "basic", Namespace: "ns1", remote: &remoteClusterInfo{
+				kubernetesCluster: "us-east-1",
+				endpoints: []v1alpha1.RemoteClusterEndpoints{
+					{Component: "pd", Targets: []string{"10.0.0.1:2379", "10.0.0.2:2379"}},
+					{Component: "tikv", Targets: []string{"10.0.1.1:20180"}},
+				},
+			}},
+		},
+		shards: 1,
+	}
+
+	scrapeJobs := scrapeJob("pd", pdPattern, model, buildAddressRelabelConfigByComponent("pd"))
+	g.Expect(scrapeJobs).To(HaveLen(2))
+
+	// the pods are discovered by the kubeconfig of the remote Kubernetes cluster
+	discovered := scrapeJobs[0]
+	g.Expect(discovered[0].Value).To(Equal("us-west-1-ns1-basic-pd"))
+	g.Expect(discovered[4].Value.([]yaml.MapSlice)[0][0]).To(Equal(yaml.MapItem{
+		Key:   "kubeconfig_file",
+		Value: "/var/lib/cluster-assets-tls/secret_monitor_us-west-1_kubeconfig",
+	}))
+	g.Expect(discovered[5].Value.(yaml.MapSlice)[0].Value).To(Equal(
+		path.Join(util.ClusterAssetsTLSPath, TLSAssetKey{"secret", "monitor", "us-west-1-basic-client", corev1.ServiceAccountRootCAKey}.String())))
+	relabelConfigs := discovered[6].Value.([]yaml.MapSlice)
+	g.Expect(relabelConfigs).To(ContainElement(yaml.MapSlice{
+		{Key: "source_labels", Value: []string{"__address__"}},
+		{Key: "action", Value: "replace"},
+		{Key: "regex", Value: remoteAddressPattern},
+		{Key: "replacement", Value: "$1.svc.us-west-1.local:$2"},
+		{Key: "target_label", Value: "__address__"},
+	}))
+	g.Expect(relabelConfigs).To(ContainElement(kubernetesClusterRelabelConfig(model.ClusterInfos[0].remote)))
+
+	// the static endpoints are scraped
+	expected := `job_name: us-east-1-ns1-basic-pd
+honor_labels: true
+scrape_interval: 15s
+scheme: http
+static_configs:
+- targets:
+  - 10.0.0.1:2379
+  - 10.0.0.2:2379
+  labels:
+    kubernetes_namespace: ns1
+    cluster: basic
+    component: pd
+    tidb_cluster: ns1-basic
+tls_config:
+  insecure_skip_verify: true
+relabel_configs:
+- action: replace
+  replacement: us-east-1
+  target_label: kubernetes_cluster
+- source_labels:
+  - __address__
+  action: hashmod
+  target_label: __tmp_hash
+  modulus: 1
+- source_labels:
+  - __tmp_hash
+  regex: $(SHARD)
+  action: keep
+`
+	bs, err := yaml.Marshal(scrapeJobs[1])
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(bs)).To(Equal(expected))
+
+	// no job is generated for the component without the static endpoints
+	scrapeJobs = scrapeJob("tidb", tidbPattern, model, buildAddressRelabelConfigByComponent("tidb"))
+	g.Expect(scrapeJobs).To(HaveLen(1))
+}
+
+func TestRenderDownsampledPrometheusConfig(t *testing.T) {
+	g := NewGomegaWithT(t)
+	expected := `global:
+  scrape_interval: 5m
+  evaluation_interval: 5m
+scrape_configs:
+- job_name: federate
+  honor_labels: true
+  scrape_timeout: 1m
+  metrics_path: /federate
+  params:
+    match[]:
+    - '{__name__=~"downsampled:.+"}'
+  static_configs:
+  - targets:
+    - 127.0.0.1:9090
+`
+	bs, err := yaml.Marshal(RenderDownsampledPrometheusConfig("5m"))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(bs)).To(Equal(expected))
+}
+
+func TestRenderDownsampledRecordingRules(t *testing.T) {
+	g := NewGomegaWithT(t)
+	rules := RenderDownsampledRecordingRules("10m", []v1alpha1.PrometheusRecordingRule{
+		{Record: "downsampled:tidb_server_connections:max", Expr: "max(tidb_server_connections)"},
+	})
+	bs, err := yaml.Marshal(rules)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(bs)).To(HavePrefix(`groups:
+- name: downsampled
+  interval: 10m
+  rules:
+  - record: downsampled:tidb_server_query:rate
+    expr: sum(rate(tidb_server_query_total[10m])) by (kubernetes_namespace, tidb_cluster, instance, type, result)
+`))
+	g.Expect(string(bs)).To(HaveSuffix(`  - record: downsampled:tidb_server_connections:max
+    expr: max(tidb_server_connections)
+`))
+	g.Expect(string(bs)).NotTo(ContainSubstring("$(INTERVAL)"))
+
+	for _, rule := range downsampledRecordingRules {
+		g.Expect(rule.Record).To(HavePrefix(v1alpha1.PrometheusDownsampledRecordPrefix))
+	}
+
+	cfg, err := RenderPrometheusConfig(&MonitorConfigModel{EnableAlertRules: true, EnableDownsampledRules: true})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(cfg[len(cfg)-1]).To(Equal(yaml.MapItem{
+		Key:   "rule_files",
+		Value: []string{"/prometheus-rules/rules/*.rules.yml", "/etc/prometheus/config/downsampled.rules.yml"},
+	}))
+}
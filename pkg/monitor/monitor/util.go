@@ -43,6 +43,12 @@ import (
 
 const (
 	defaultReplicaExternalLabelName = "prometheus_replica"
+	// downsampledPrometheusConfigKey is the key of the config of the downsampled tier of Prometheus in the ConfigMap
+	downsampledPrometheusConfigKey = "prometheus-downsampled.yml"
+	// downsampledRulesConfigKey is the key of the recording rules of the downsampled tier of Prometheus in the ConfigMap
+	downsampledRulesConfigKey = "downsampled.rules.yml"
+	// downsampledPrometheusPort is the port of the downsampled tier of Prometheus
+	downsampledPrometheusPort = 9091
 )
 
 func GetTLSAssetsSecretName(name string) string {
@@ -167,6 +173,9 @@ func getPromConfigMap(monitor *v1alpha1.TidbMonitor, monitorClusterInfos []Clust
 	if monitor.Spec.Prometheus.Config != nil && monitor.Spec.Prometheus.Config.RuleConfigRef != nil {
 		model.EnableExternalRuleConfigs = true
 	}
+	downsampled := monitor.PrometheusDownsampled()
+	model.EnableDownsampledRules = downsampled != nil
+	model.Blackbox = monitor.Spec.Blackbox
 
 	remoteWriteCfg, err := generateRemoteWrite(monitor, store)
 	if err != nil {
@@ -192,6 +201,30 @@ func getPromConfigMap(monitor *v1alpha1.TidbMonitor, monitorClusterInfos []Clust
 			"prometheus.yml": string(prometheusYaml),
 		},
 	}
+	if downsampled != nil {
+		downsampledYaml, err := yaml.Marshal(RenderDownsampledPrometheusConfig(downsampled.GetInterval()))
+		if err != nil {
+			return nil, err
+		}
+		cm.Data[downsampledPrometheusConfigKey] = string(downsampledYaml)
+		rulesYaml, err := yaml.Marshal(RenderDownsampledRecordingRules(downsampled.GetInterval(), downsampled.Rules))
+		if err != nil {
+			return nil, err
+		}
+		cm.Data[downsampledRulesConfigKey] = string(rulesYaml)
+	}
+	if monitor.Spec.Blackbox != nil {
+		blackboxYaml, err := yaml.Marshal(RenderBlackboxExporterConfig(monitorClusterInfos))
+		if err != nil {
+			return nil, err
+		}
+		cm.Data[blackboxConfigKey] = string(blackboxYaml)
+		rulesYaml, err := yaml.Marshal(RenderBlackboxAlertRules())
+		if err != nil {
+			return nil, err
+		}
+		cm.Data[blackboxRulesConfigKey] = string(rulesYaml)
+	}
 	return cm, nil
 }
 
@@ -471,12 +504,7 @@ func getMonitorDMInitContainer(monitor *v1alpha1.TidbMonitor, dc *v1alpha1.DMClu
 }
 
 func getMonitorPrometheusContainer(monitor *v1alpha1.TidbMonitor, shard int32) core.Container {
-	var retention string
-	if monitor.Spec.Prometheus.RetentionTime != nil {
-		retention = *monitor.Spec.Prometheus.RetentionTime
-	} else {
-		retention = fmt.Sprintf("%dd", monitor.Spec.Prometheus.ReserveDays)
-	}
+	retention := monitor.PrometheusRawRetention()
 	commands := []string{"sed -e '5s/[()]//g' -e 's/SHARD//g'  -e 's/$NAMESPACE/'\"$NAMESPACE\"'/g;s/$POD_NAME/'\"$POD_NAME\"'/g;s/$()/'$(SHARD)'/g' /etc/prometheus/config/prometheus.yml > /etc/prometheus/config_out/prometheus.yml && /bin/prometheus --web.enable-admin-api --web.enable-lifecycle --config.file=/etc/prometheus/config_out/prometheus.yml --storage.tsdb.path=/data/prometheus --storage.tsdb.retention.time=" + retention}
 	c := core.Container{
 		Name:      "prometheus",
@@ -590,6 +618,67 @@ func getMonitorPrometheusContainer(monitor *v1alpha1.TidbMonitor, shard int32) c
 	return c
 }
 
+// getMonitorDownsampledPrometheusContainer returns the container of the downsampled tier of Prometheus.
+// It uses the same image and data volume as the raw Prometheus, but keeps its own TSDB with a longer retention.
+func getMonitorDownsampledPrometheusContainer(monitor *v1alpha1.TidbMonitor) core.Container {
+	downsampled := monitor.PrometheusDownsampled()
+	c := core.Container{
+		Name:      "prometheus-downsampled",
+		Image:     fmt.Sprintf("%s:%s", monitor.Spec.Prometheus.BaseImage, monitor.Spec.Prometheus.Version),
+		Resources: controller.ContainerResource(downsampled.ResourceRequirements),
+		Command: []string{
+			"/bin/prometheus",
+			fmt.Sprintf("--web.listen-address=:%d", downsampledPrometheusPort),
+			"--web.enable-lifecycle",
+			"--config.file=/etc/prometheus/config/" + downsampledPrometheusConfigKey,
+			"--storage.tsdb.path=/data/prometheus-downsampled",
+			"--storage.tsdb.retention.time=" + downsampled.Retention,
+		},
+		Ports: []core.ContainerPort{
+			{
+				Name:          "prom-downsampled",
+				ContainerPort: downsampledPrometheusPort,
+				Protocol:      core.ProtocolTCP,
+			},
+		},
+		Env: []core.EnvVar{
+			{
+				Name:  "TZ",
+				Value: monitor.Timezone(),
+			},
+		},
+		VolumeMounts: []core.VolumeMount{
+			{
+				Name:      "prometheus-config",
+				MountPath: "/etc/prometheus/config",
+				ReadOnly:  true,
+			},
+			{
+				Name:      v1alpha1.TidbMonitorMemberType.String(),
+				MountPath: "/data",
+			},
+		},
+		ReadinessProbe: &core.Probe{
+			Handler: core.Handler{
+				HTTPGet: &core.HTTPGetAction{
+					Path: "/-/ready",
+					Port: intstr.FromInt(downsampledPrometheusPort),
+				},
+			},
+			TimeoutSeconds:   3,
+			PeriodSeconds:    5,
+			FailureThreshold: 120,
+		},
+	}
+	if len(monitor.Spec.Prometheus.LogLevel) > 0 {
+		c.Command = append(c.Command, fmt.Sprintf("--log.level=%s", monitor.Spec.Prometheus.LogLevel))
+	}
+	if monitor.Spec.Prometheus.ImagePullPolicy != nil {
+		c.ImagePullPolicy = *monitor.Spec.Prometheus.ImagePullPolicy
+	}
+	return c
+}
+
 func getMonitorGrafanaContainer(secret *core.Secret, monitor *v1alpha1.TidbMonitor) core.Container {
 	var adminUserFrom, adminPasswordFrom *core.EnvVarSource
 
@@ -988,7 +1077,16 @@ func getMonitorService(monitor *v1alpha1.TidbMonitor) []*core.Service {
 				prometheusService.Spec.LoadBalancerSourceRanges = monitor.Spec.Prometheus.Service.LoadBalancerSourceRanges
 			}
 		}
+		member.SetServiceIPFamilies(prometheusService, &monitor.Spec.Prometheus.Service)
 
+		if monitor.PrometheusDownsampled() != nil {
+			prometheusService.Spec.Ports = append(prometheusService.Spec.Ports, core.ServicePort{
+				Name:       "http-prometheus-downsampled",
+				Port:       downsampledPrometheusPort,
+				Protocol:   core.ProtocolTCP,
+				TargetPort: intstr.FromInt(downsampledPrometheusPort),
+			})
+		}
 		if monitor.Spec.Thanos != nil {
 			prometheusService.Spec.Ports = append(prometheusService.Spec.Ports, core.ServicePort{
 				Name:       "thanos-grpc",
@@ -1033,6 +1131,7 @@ func getMonitorService(monitor *v1alpha1.TidbMonitor) []*core.Service {
 				reloaderService.Spec.LoadBalancerSourceRanges = monitor.Spec.Reloader.Service.LoadBalancerSourceRanges
 			}
 		}
+		member.SetServiceIPFamilies(reloaderService, &monitor.Spec.Reloader.Service)
 
 		services = append(services, prometheusService, reloaderService)
 		if monitor.Spec.Grafana != nil {
@@ -1066,13 +1165,14 @@ func getMonitorService(monitor *v1alpha1.TidbMonitor) []*core.Service {
 					grafanaService.Spec.LoadBalancerSourceRanges = monitor.Spec.Grafana.Service.LoadBalancerSourceRanges
 				}
 			}
+			member.SetServiceIPFamilies(grafanaService, &monitor.Spec.Grafana.Service)
 
 			services = append(services, grafanaService)
 		}
 	}
 
 	for _, svc := range services {
-		if monitor.Spec.PreferIPv6 {
+		if monitor.Spec.PreferIPv6 && svc.Spec.IPFamilyPolicy == nil {
 			member.SetServiceWhenPreferIPv6(svc)
 		}
 	}
@@ -1231,6 +1331,12 @@ func getMonitorStatefulSet(sa *core.ServiceAccount, secret *core.Secret, monitor
 	prometheusContainer := getMonitorPrometheusContainer(monitor, shard)
 	reloaderContainer := getMonitorReloaderContainer(monitor)
 	statefulSet.Spec.Template.Spec.Containers = append(statefulSet.Spec.Template.Spec.Containers, prometheusContainer, reloaderContainer)
+	if monitor.PrometheusDownsampled() != nil {
+		statefulSet.Spec.Template.Spec.Containers = append(statefulSet.Spec.Template.Spec.Containers, getMonitorDownsampledPrometheusContainer(monitor))
+	}
+	if monitor.Spec.Blackbox != nil {
+		statefulSet.Spec.Template.Spec.Containers = append(statefulSet.Spec.Template.Spec.Containers, getMonitorBlackboxExporterContainer(monitor))
+	}
 	if monitor.Spec.Thanos != nil {
 		thanosSideCarContainer := getThanosSidecarContainer(monitor)
 		statefulSet.Spec.Template.Spec.Containers = append(statefulSet.Spec.Template.Spec.Containers, thanosSideCarContainer)
@@ -1554,7 +1660,9 @@ func generateRemoteWrite(monitor *v1alpha1.TidbMonitor, store *Store) (yaml.MapI
 			}
 		}
 
-		if spec.BearerToken != "" {
+		if token, ok := store.BearerTokenAssets[fmt.Sprintf("remoteWrite/%d", i)]; ok && spec.BearerTokenSecret != nil {
+			cfg = append(cfg, yaml.MapItem{Key: "bearer_token", Value: token})
+		} else if spec.BearerToken != "" {
 			cfg = append(cfg, yaml.MapItem{Key: "bearer_token", Value: spec.BearerToken})
 		}
 
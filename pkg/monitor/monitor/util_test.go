@@ -1302,6 +1302,60 @@ func TestGetMonitorPrometheusContainer(t *testing.T) {
 	}
 }
 
+func TestGetMonitorDownsampledPrometheus(t *testing.T) {
+	g := NewGomegaWithT(t)
+	monitor := &v1alpha1.TidbMonitor{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "foo",
+			Namespace: "ns",
+		},
+		Spec: v1alpha1.TidbMonitorSpec{
+			Prometheus: v1alpha1.PrometheusSpec{
+				MonitorContainer: v1alpha1.MonitorContainer{
+					BaseImage: "prom/prometheus",
+					Version:   "v2.27.1",
+				},
+				RetentionTime: pointer.StringPtr("2h"),
+				Retention: &v1alpha1.PrometheusRetentionSpec{
+					Raw: pointer.StringPtr("2d"),
+					Downsampled: &v1alpha1.PrometheusDownsampledSpec{
+						Retention: "30d",
+						Interval:  pointer.StringPtr("10m"),
+					},
+				},
+			},
+		},
+	}
+
+	prometheus := getMonitorPrometheusContainer(monitor, 0)
+	g.Expect(prometheus.Command[2]).To(ContainSubstring("--storage.tsdb.retention.time=2d"))
+
+	downsampled := getMonitorDownsampledPrometheusContainer(monitor)
+	g.Expect(downsampled.Image).To(Equal("prom/prometheus:v2.27.1"))
+	g.Expect(downsampled.Command).To(ContainElements(
+		"--web.listen-address=:9091",
+		"--config.file=/etc/prometheus/config/prometheus-downsampled.yml",
+		"--storage.tsdb.path=/data/prometheus-downsampled",
+		"--storage.tsdb.retention.time=30d",
+	))
+
+	cm, err := getPromConfigMap(monitor, nil, nil, 0, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(cm.Data).To(HaveKey("prometheus-downsampled.yml"))
+	g.Expect(cm.Data["prometheus-downsampled.yml"]).To(ContainSubstring("scrape_interval: 10m"))
+	g.Expect(cm.Data["prometheus.yml"]).To(ContainSubstring("/etc/prometheus/config/downsampled.rules.yml"))
+	g.Expect(cm.Data).To(HaveKey("downsampled.rules.yml"))
+	g.Expect(cm.Data["downsampled.rules.yml"]).To(ContainSubstring("interval: 10m"))
+
+	services := getMonitorService(monitor)
+	g.Expect(services[0].Spec.Ports).To(ContainElement(corev1.ServicePort{
+		Name:       "http-prometheus-downsampled",
+		Port:       9091,
+		Protocol:   corev1.ProtocolTCP,
+		TargetPort: intstr.FromInt(9091),
+	}))
+}
+
 func TestGetMonitorGrafanaContainer(t *testing.T) {
 	g := NewGomegaWithT(t)
 
@@ -1627,3 +1681,39 @@ func TestBuildExternalLabels(t *testing.T) {
 		})
 	}
 }
+
+func TestGenerateRemoteWriteWithBearerTokenSecret(t *testing.T) {
+	g := NewGomegaWithT(t)
+	expectedContent := `- url: http://127.0.0.1/a/b/c
+  remote_timeout: 30s
+  bearer_token: token-in-secret
+`
+	monitor := v1alpha1.TidbMonitor{
+		Spec: v1alpha1.TidbMonitorSpec{
+			Prometheus: v1alpha1.PrometheusSpec{
+				RemoteWrite: []*v1alpha1.RemoteWriteSpec{
+					{
+						URL:         "http://127.0.0.1/a/b/c",
+						BearerToken: "inline-token",
+						BearerTokenSecret: &corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{Name: "token"},
+							Key:                  "token",
+						},
+					},
+				},
+				MonitorContainer: v1alpha1.MonitorContainer{
+					Version: "v2.27.1",
+				},
+			},
+		},
+	}
+	store := &Store{
+		BearerTokenAssets: map[string]string{"remoteWrite/0": "token-in-secret"},
+	}
+	remoteWriteConfig, err := generateRemoteWrite(&monitor, store)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	prometheusYaml, err := yaml.Marshal(remoteWriteConfig.Value)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(prometheusYaml)).Should(Equal(expectedContent))
+}
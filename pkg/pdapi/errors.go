@@ -0,0 +1,84 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pdapi
+
+import (
+	"errors"
+	"net"
+	"net/url"
+	"strings"
+)
+
+// APIErrorReason is the reason of a failed PD API request
+type APIErrorReason string
+
+const (
+	// APIErrorNotBootstrapped means the cluster is not bootstrapped yet
+	APIErrorNotBootstrapped APIErrorReason = "PDNotBootstrapped"
+	// APIErrorLeaderChanging means PD has no leader or the leader is changing, it's usually transient
+	APIErrorLeaderChanging APIErrorReason = "PDLeaderChanging"
+	// APIErrorEtcdTimeout means the request to the embedded etcd of PD timed out
+	APIErrorEtcdTimeout APIErrorReason = "PDEtcdTimeout"
+	// APIErrorUnreachable means PD can not be connected, e.g. connection refused or timeout
+	APIErrorUnreachable APIErrorReason = "PDUnreachable"
+	// APIErrorUnknown is any other failure
+	APIErrorUnknown APIErrorReason = "PDAPIError"
+)
+
+var (
+	notBootstrappedMessages = []string{"not bootstrapped"}
+	leaderChangingMessages  = []string{"no leader", "not leader", "leader is nil", "leader changed", "redirect"}
+	etcdTimeoutMessages     = []string{"etcdserver: request timed out", "etcdserver: timed out", "etcdserver: request timeout"}
+	unreachableMessages     = []string{"connection refused", "no such host", "no route to host", "i/o timeout",
+		"Client.Timeout exceeded", "connection reset by peer"}
+)
+
+// ClassifyAPIError returns the reason of the error returned by a PD API request, so that the callers
+// can tell a transient failure, e.g. PD is electing a leader, from PD being unreachable.
+func ClassifyAPIError(err error) APIErrorReason {
+	if err == nil {
+		return ""
+	}
+	if IsTiKVNotBootstrappedError(err) {
+		return APIErrorNotBootstrapped
+	}
+
+	msg := err.Error()
+	containsAny := func(substrs []string) bool {
+		for _, s := range substrs {
+			if strings.Contains(msg, s) {
+				return true
+			}
+		}
+		return false
+	}
+	switch {
+	case containsAny(notBootstrappedMessages):
+		return APIErrorNotBootstrapped
+	case containsAny(leaderChangingMessages):
+		return APIErrorLeaderChanging
+	case containsAny(etcdTimeoutMessages):
+		return APIErrorEtcdTimeout
+	case containsAny(unreachableMessages):
+		return APIErrorUnreachable
+	}
+
+	var urlErr *url.Error
+	var netErr net.Error
+	if errors.As(err, &urlErr) || errors.As(err, &netErr) {
+		// the request failed before PD responded
+		return APIErrorUnreachable
+	}
+	return APIErrorUnknown
+}
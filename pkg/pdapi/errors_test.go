@@ -0,0 +1,51 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pdapi
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestClassifyAPIError(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tests := []struct {
+		err      error
+		expected APIErrorReason
+	}{
+		{nil, ""},
+		{TiKVNotBootstrappedErrorf("TiKV cluster not bootstrapped, please start TiKV first"), APIErrorNotBootstrapped},
+		{fmt.Errorf("[PD:cluster:ErrNotBootstrapped]TiKV cluster not bootstrapped, please start TiKV first"), APIErrorNotBootstrapped},
+		{fmt.Errorf("[PD:apiutil:ErrRedirect]redirect failed"), APIErrorLeaderChanging},
+		{fmt.Errorf("no leader"), APIErrorLeaderChanging},
+		{fmt.Errorf("etcdserver: leader changed"), APIErrorLeaderChanging},
+		{fmt.Errorf("[PD:etcd:ErrEtcdKVGet]etcdserver: request timed out"), APIErrorEtcdTimeout},
+		{fmt.Errorf(`Get "http://basic-pd.ns:2379/pd/api/v1/health": dial tcp 10.0.0.1:2379: connect: connection refused`), APIErrorUnreachable},
+		{fmt.Errorf("internal server error"), APIErrorUnknown},
+	}
+	for _, tt := range tests {
+		g.Expect(ClassifyAPIError(tt.err)).To(Equal(tt.expected), fmt.Sprintf("%v", tt.err))
+	}
+
+	// the error of a request to an address nobody listens on
+	client := &http.Client{Timeout: time.Second}
+	_, err := client.Get("http://127.0.0.1:1/pd/api/v1/health")
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(ClassifyAPIError(err)).To(Equal(APIErrorUnreachable))
+}
@@ -18,6 +18,7 @@ import (
 
 	"github.com/pingcap/kvproto/pkg/metapb"
 	"github.com/pingcap/kvproto/pkg/pdpb"
+	"github.com/pingcap/tidb-operator/pkg/apis/util/config"
 )
 
 type ActionType string
@@ -44,6 +45,15 @@ const (
 	TransferPDLeaderActionType                  ActionType = "TransferPDLeader"
 	GetAutoscalingPlansActionType               ActionType = "GetAutoscalingPlans"
 	GetRecoveringMarkActionType                 ActionType = "GetRecoveringMark"
+	GetStoreLimitsActionType                    ActionType = "GetStoreLimits"
+	SetStoreLimitActionType                     ActionType = "SetStoreLimit"
+	SetMemberLeaderPriorityActionType           ActionType = "SetMemberLeaderPriority"
+	GetRegionsByStoreActionType                 ActionType = "GetRegionsByStore"
+	GetOnlineConfigActionType                   ActionType = "GetOnlineConfig"
+	SetConfigActionType                         ActionType = "SetConfig"
+	GetSchedulersActionType                     ActionType = "GetSchedulers"
+	AddSchedulerActionType                      ActionType = "AddScheduler"
+	RemoveSchedulerActionType                   ActionType = "RemoveScheduler"
 )
 
 type NotFoundReaction struct {
@@ -59,6 +69,10 @@ type Action struct {
 	Name        string
 	Labels      map[string]string
 	Replication PDReplicationConfig
+	LimitType   string
+	Rate        float64
+	Priority    int
+	Config      map[string]interface{}
 }
 
 type Reaction func(action *Action) (interface{}, error)
@@ -281,3 +295,84 @@ func (c *FakePDClient) GetRecoveringMark() (bool, error) {
 
 	return true, nil
 }
+
+func (c *FakePDClient) GetStoreLimits() (map[uint64]*StoreLimit, error) {
+	if reaction, ok := c.reactions[GetStoreLimitsActionType]; ok {
+		action := &Action{}
+		result, err := reaction(action)
+		return result.(map[uint64]*StoreLimit), err
+	}
+	return nil, nil
+}
+
+func (c *FakePDClient) SetStoreLimit(storeID uint64, limitType string, rate float64) error {
+	if reaction, ok := c.reactions[SetStoreLimitActionType]; ok {
+		action := &Action{ID: storeID, LimitType: limitType, Rate: rate}
+		_, err := reaction(action)
+		return err
+	}
+	return nil
+}
+
+func (c *FakePDClient) SetMemberLeaderPriority(name string, priority int) error {
+	if reaction, ok := c.reactions[SetMemberLeaderPriorityActionType]; ok {
+		action := &Action{Name: name, Priority: priority}
+		_, err := reaction(action)
+		return err
+	}
+	return nil
+}
+
+func (c *FakePDClient) GetRegionsByStore(storeID uint64) (*RegionsInfo, error) {
+	if reaction, ok := c.reactions[GetRegionsByStoreActionType]; ok {
+		action := &Action{ID: storeID}
+		result, err := reaction(action)
+		return result.(*RegionsInfo), err
+	}
+	return &RegionsInfo{}, nil
+}
+
+func (c *FakePDClient) GetOnlineConfig() (*config.GenericConfig, error) {
+	if reaction, ok := c.reactions[GetOnlineConfigActionType]; ok {
+		action := &Action{}
+		result, err := reaction(action)
+		return result.(*config.GenericConfig), err
+	}
+	return config.New(map[string]interface{}{}), nil
+}
+
+func (c *FakePDClient) SetConfig(values map[string]interface{}) error {
+	if reaction, ok := c.reactions[SetConfigActionType]; ok {
+		action := &Action{Config: values}
+		_, err := reaction(action)
+		return err
+	}
+	return nil
+}
+
+func (c *FakePDClient) GetSchedulers() ([]string, error) {
+	if reaction, ok := c.reactions[GetSchedulersActionType]; ok {
+		action := &Action{}
+		result, err := reaction(action)
+		return result.([]string), err
+	}
+	return nil, nil
+}
+
+func (c *FakePDClient) AddScheduler(name string) error {
+	if reaction, ok := c.reactions[AddSchedulerActionType]; ok {
+		action := &Action{Name: name}
+		_, err := reaction(action)
+		return err
+	}
+	return nil
+}
+
+func (c *FakePDClient) RemoveScheduler(name string) error {
+	if reaction, ok := c.reactions[RemoveSchedulerActionType]; ok {
+		action := &Action{Name: name}
+		_, err := reaction(action)
+		return err
+	}
+	return nil
+}
@@ -19,6 +19,7 @@ import (
 	"net/http"
 	"sync"
 
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
 	"github.com/pingcap/tidb-operator/pkg/util"
 	"k8s.io/client-go/kubernetes"
 	corelisterv1 "k8s.io/client-go/listers/core/v1"
@@ -54,6 +55,23 @@ func TLSCertFromSecret(ns Namespace, secret string) Option {
 	}
 }
 
+// TLSFromCluster indicates that the clients use the client certificate and the CA specified by the
+// TLSCluster of a TC or DC in the namespace, the default ones are used if they are not specified.
+func TLSFromCluster(ns Namespace, tlsCluster *v1alpha1.TLSCluster) Option {
+	return func(c *clientConfig) {
+		if tlsCluster == nil {
+			return
+		}
+		if tlsCluster.ClientSecretName != "" {
+			c.tlsSecretNamespace = ns
+			c.tlsSecretName = tlsCluster.ClientSecretName
+		}
+		if tlsCluster.CASecretName != "" {
+			c.tlsCASecretName = tlsCluster.CASecretName
+		}
+	}
+}
+
 // SpecifyClient specify client addr without generating
 func SpecifyClient(clientURL, clientKey string) Option {
 	return func(c *clientConfig) {
@@ -91,6 +109,8 @@ type clientConfig struct {
 	tlsEnable          bool
 	tlsSecretNamespace Namespace
 	tlsSecretName      string
+	// tlsCASecretName is the secret of the CA in the tlsSecretNamespace. If it is empty, the CA in tlsSecretName is used
+	tlsCASecretName string
 }
 
 func (c *clientConfig) applyOptions(opts ...Option) {
@@ -99,6 +119,18 @@ func (c *clientConfig) applyOptions(opts ...Option) {
 	}
 }
 
+// GetTLSConfigFromOptions returns *tls.Config for the clients of other components, the client certificate
+// is loaded from the defaultSecretName in the namespace unless the TLS options override it.
+func GetTLSConfigFromOptions(secretLister corelisterv1.SecretLister, namespace Namespace, defaultSecretName string, opts ...Option) (*tls.Config, error) {
+	config := &clientConfig{}
+	config.applyOptions(opts...)
+	if config.tlsSecretName == "" {
+		config.tlsSecretNamespace = namespace
+		config.tlsSecretName = defaultSecretName
+	}
+	return GetTLSConfigWithCA(secretLister, config.tlsSecretNamespace, config.tlsSecretName, config.tlsCASecretName)
+}
+
 // completeForPDClient populate and correct config for pd client
 func (c *clientConfig) completeForPDClient(namespace Namespace, tcName string) {
 	scheme := "http"
@@ -173,7 +205,7 @@ func (pdc *defaultPDControl) GetEndpoints(namespace Namespace, tcName string, tl
 	config.completeForEtcdClient(namespace, tcName)
 
 	if config.tlsEnable {
-		tlsConfig, err = GetTLSConfig(pdc.secretLister, config.tlsSecretNamespace, config.tlsSecretName)
+		tlsConfig, err = GetTLSConfigWithCA(pdc.secretLister, config.tlsSecretNamespace, config.tlsSecretName, config.tlsCASecretName)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -196,7 +228,7 @@ func (pdc *defaultPDControl) GetPDEtcdClient(namespace Namespace, tcName string,
 	defer pdc.etcdmutex.Unlock()
 
 	if config.tlsEnable {
-		tlsConfig, err := GetTLSConfig(pdc.secretLister, config.tlsSecretNamespace, config.tlsSecretName)
+		tlsConfig, err := GetTLSConfigWithCA(pdc.secretLister, config.tlsSecretNamespace, config.tlsSecretName, config.tlsCASecretName)
 		if err != nil {
 			klog.Errorf("Unable to get tls config for tidb cluster %q in %s, pd client may not work: %v", tcName, namespace, err)
 			return nil, err
@@ -227,7 +259,7 @@ func (pdc *defaultPDControl) GetPDClient(namespace Namespace, tcName string, tls
 	defer pdc.mutex.Unlock()
 
 	if config.tlsEnable {
-		tlsConfig, err := GetTLSConfig(pdc.secretLister, config.tlsSecretNamespace, config.tlsSecretName)
+		tlsConfig, err := GetTLSConfigWithCA(pdc.secretLister, config.tlsSecretNamespace, config.tlsSecretName, config.tlsCASecretName)
 		if err != nil {
 			klog.Errorf("Unable to get tls config for tidb cluster %q in %s, pd client may not work: %v", tcName, namespace, err)
 			return &pdClient{url: config.clientURL, httpClient: &http.Client{Timeout: DefaultTimeout}}
@@ -17,6 +17,7 @@ import (
 	"testing"
 
 	. "github.com/onsi/gomega"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
 )
 
 func TestPDControl(t *testing.T) {
@@ -185,6 +186,46 @@ func TestPDControl(t *testing.T) {
 					g.Expect(string(etcdClient.tlsSecretNamespace)).To(Equal("test-namespace"))
 				},
 			},
+			{
+				name: "cert and ca from tls cluster",
+				options: []Option{
+					TLSCertFromTC("test-namespace", "test-cluster"),
+					TLSFromCluster("test-namespace", &v1alpha1.TLSCluster{
+						Enabled:          true,
+						ClientSecretName: "test-client-secret",
+						CASecretName:     "test-ca-secret",
+					}),
+				},
+				tcName:    "target-cluster",
+				tcNS:      "target-namespace",
+				tlsEnable: true,
+				expectConfig: func(pdClient *clientConfig, etcdClient *clientConfig) {
+					g.Expect(pdClient.tlsSecretName).To(Equal("test-client-secret"))
+					g.Expect(pdClient.tlsCASecretName).To(Equal("test-ca-secret"))
+					g.Expect(string(pdClient.tlsSecretNamespace)).To(Equal("test-namespace"))
+
+					g.Expect(etcdClient.tlsSecretName).To(Equal("test-client-secret"))
+					g.Expect(etcdClient.tlsCASecretName).To(Equal("test-ca-secret"))
+					g.Expect(string(etcdClient.tlsSecretNamespace)).To(Equal("test-namespace"))
+				},
+			},
+			{
+				name: "tls cluster without secret names",
+				options: []Option{
+					TLSFromCluster("test-namespace", &v1alpha1.TLSCluster{Enabled: true}),
+				},
+				tcName:    "target-cluster",
+				tcNS:      "target-namespace",
+				tlsEnable: true,
+				expectConfig: func(pdClient *clientConfig, etcdClient *clientConfig) {
+					g.Expect(pdClient.tlsSecretName).To(Equal("target-cluster-cluster-client-secret"))
+					g.Expect(pdClient.tlsCASecretName).To(BeEmpty())
+					g.Expect(string(pdClient.tlsSecretNamespace)).To(Equal("target-namespace"))
+
+					g.Expect(etcdClient.tlsSecretName).To(Equal("target-cluster-cluster-client-secret"))
+					g.Expect(etcdClient.tlsCASecretName).To(BeEmpty())
+				},
+			},
 		}
 
 		for _, c := range cases {
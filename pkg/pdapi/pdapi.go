@@ -25,6 +25,9 @@ import (
 
 	"github.com/pingcap/kvproto/pkg/metapb"
 	"github.com/pingcap/kvproto/pkg/pdpb"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/apis/util/config"
+	"github.com/pingcap/tidb-operator/pkg/metrics"
 	"github.com/pingcap/tidb-operator/pkg/util/crypto"
 	httputil "github.com/pingcap/tidb-operator/pkg/util/http"
 	"github.com/tikv/pd/pkg/typeutil"
@@ -48,6 +51,24 @@ func GetTLSConfig(secretLister corelisterv1.SecretLister, namespace Namespace, s
 	return crypto.LoadTlsConfigFromSecret(secret)
 }
 
+// GetTLSConfigWithCA returns *tls.Config for given TiDB cluster, the CA certificate is loaded from
+// the caSecretName in the same namespace if it's not empty.
+func GetTLSConfigWithCA(secretLister corelisterv1.SecretLister, namespace Namespace, secretName, caSecretName string) (*tls.Config, error) {
+	if caSecretName == "" {
+		return GetTLSConfig(secretLister, namespace, secretName)
+	}
+	secret, err := secretLister.Secrets(string(namespace)).Get(secretName)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load certificates from secret %s/%s: %v", namespace, secretName, err)
+	}
+	caSecret, err := secretLister.Secrets(string(namespace)).Get(caSecretName)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load ca certificate from secret %s/%s: %v", namespace, caSecretName, err)
+	}
+
+	return crypto.LoadTlsConfigFromSecrets(secret, caSecret)
+}
+
 // PDClient provides pd server's api
 type PDClient interface {
 	// GetHealth returns the PD's health info
@@ -94,6 +115,25 @@ type PDClient interface {
 	GetAutoscalingPlans(strategy Strategy) ([]Plan, error)
 	// GetRecoveringMark return the pd recovering mark
 	GetRecoveringMark() (bool, error)
+	// GetStoreLimits returns the store limits of all the stores
+	GetStoreLimits() (map[uint64]*StoreLimit, error)
+	// SetStoreLimit sets the rate of the store limit of the type for a store
+	SetStoreLimit(storeID uint64, limitType string, rate float64) error
+	// SetMemberLeaderPriority sets the leader priority of a PD member, PD transfers the leader
+	// to the healthy member with the highest priority
+	SetMemberLeaderPriority(name string, priority int) error
+	// GetRegionsByStore returns the regions which have a peer on the store
+	GetRegionsByStore(storeID uint64) (*RegionsInfo, error)
+	// GetOnlineConfig returns the config in effect of PD, including the items changed online, e.g. by pd-ctl
+	GetOnlineConfig() (*config.GenericConfig, error)
+	// SetConfig changes the config of PD online, the keys are the config items like `schedule.leader-schedule-limit`
+	SetConfig(values map[string]interface{}) error
+	// GetSchedulers returns the names of the running schedulers
+	GetSchedulers() ([]string, error)
+	// AddScheduler adds the scheduler without args, e.g. `balance-leader-scheduler`
+	AddScheduler(name string) error
+	// RemoveScheduler removes the scheduler
+	RemoveScheduler(name string) error
 }
 
 var (
@@ -112,6 +152,8 @@ var (
 	evictLeaderSchedulerConfigPrefix = "pd/api/v1/scheduler-config/evict-leader-scheduler/list"
 	autoscalingPrefix                = "autoscaling"
 	recoveringMarkPrefix             = "pd/api/v1/admin/cluster/markers/snapshot-recovering"
+	storesLimitPrefix                = "pd/api/v1/stores/limit"
+	regionsByStorePrefix             = "pd/api/v1/regions/store"
 )
 
 // pdClient is default implementation of PDClient
@@ -128,10 +170,8 @@ func NewPDClient(url string, timeout time.Duration, tlsConfig *tls.Config) PDCli
 	}
 	return &pdClient{
 		url: url,
-		httpClient: &http.Client{
-			Timeout:   timeout,
-			Transport: &http.Transport{TLSClientConfig: tlsConfig, DisableKeepAlives: disableKeepalive},
-		},
+		httpClient: httputil.NewClient(timeout, metrics.InstrumentRoundTripper(v1alpha1.PDMemberType.String(),
+			&http.Transport{TLSClientConfig: tlsConfig, DisableKeepAlives: disableKeepalive, Proxy: httputil.Proxy})),
 	}
 }
 
@@ -163,6 +203,7 @@ type StoreStatus struct {
 	Available          typeutil.ByteSize `json:"available"`
 	LeaderCount        int               `json:"leader_count"`
 	RegionCount        int               `json:"region_count"`
+	RegionSize         int64             `json:"region_size"`
 	SendingSnapCount   uint32            `json:"sending_snap_count"`
 	ReceivingSnapCount uint32            `json:"receiving_snap_count"`
 	ApplyingSnapCount  uint32            `json:"applying_snap_count"`
@@ -252,6 +293,31 @@ type RecoveringMark struct {
 	Mark bool `json:"marked"`
 }
 
+const (
+	// StoreLimitTypeAddPeer is the type of the store limit of adding peers
+	StoreLimitTypeAddPeer = "add-peer"
+	// StoreLimitTypeRemovePeer is the type of the store limit of removing peers
+	StoreLimitTypeRemovePeer = "remove-peer"
+)
+
+// StoreLimit is the store limit of a store returned from PD RESTful interface, the rate is
+// the number of the peers that can be added to or removed from the store per minute
+type StoreLimit struct {
+	AddPeer    float64 `json:"add-peer"`
+	RemovePeer float64 `json:"remove-peer"`
+}
+
+// RegionInfo is a single region info returned from PD RESTful interface, only the ID is decoded
+type RegionInfo struct {
+	ID uint64 `json:"id"`
+}
+
+// RegionsInfo is regions info returned from PD RESTful interface
+type RegionsInfo struct {
+	Count   int           `json:"count"`
+	Regions []*RegionInfo `json:"regions"`
+}
+
 func (c *pdClient) GetHealth() (*HealthInfo, error) {
 	apiURL := fmt.Sprintf("%s/%s", c.url, healthPrefix)
 	body, err := httputil.GetBodyOK(c.httpClient, apiURL)
@@ -282,6 +348,64 @@ func (c *pdClient) GetConfig() (*PDConfigFromAPI, error) {
 	return config, nil
 }
 
+func (c *pdClient) GetOnlineConfig() (*config.GenericConfig, error) {
+	apiURL := fmt.Sprintf("%s/%s", c.url, configPrefix)
+	body, err := httputil.GetBodyOK(c.httpClient, apiURL)
+	if err != nil {
+		return nil, err
+	}
+	values := map[string]interface{}{}
+	if err := json.Unmarshal(body, &values); err != nil {
+		return nil, err
+	}
+	return config.New(values), nil
+}
+
+func (c *pdClient) SetConfig(values map[string]interface{}) error {
+	apiURL := fmt.Sprintf("%s/%s", c.url, configPrefix)
+	data, err := json.Marshal(values)
+	if err != nil {
+		return err
+	}
+	if _, err := httputil.PostBodyOK(c.httpClient, apiURL, bytes.NewBuffer(data)); err != nil {
+		return fmt.Errorf("failed to set config %s: %v", data, err)
+	}
+	return nil
+}
+
+func (c *pdClient) GetSchedulers() ([]string, error) {
+	apiURL := fmt.Sprintf("%s/%s", c.url, schedulersPrefix)
+	body, err := httputil.GetBodyOK(c.httpClient, apiURL)
+	if err != nil {
+		return nil, err
+	}
+	var schedulers []string
+	if err := json.Unmarshal(body, &schedulers); err != nil {
+		return nil, err
+	}
+	return schedulers, nil
+}
+
+func (c *pdClient) AddScheduler(name string) error {
+	apiURL := fmt.Sprintf("%s/%s", c.url, schedulersPrefix)
+	data, err := json.Marshal(map[string]string{"name": name})
+	if err != nil {
+		return err
+	}
+	if _, err := httputil.PostBodyOK(c.httpClient, apiURL, bytes.NewBuffer(data)); err != nil {
+		return fmt.Errorf("failed to add scheduler %s: %v", name, err)
+	}
+	return nil
+}
+
+func (c *pdClient) RemoveScheduler(name string) error {
+	apiURL := fmt.Sprintf("%s/%s/%s", c.url, schedulersPrefix, name)
+	if _, err := httputil.DeleteBodyOK(c.httpClient, apiURL); err != nil {
+		return fmt.Errorf("failed to remove scheduler %s: %v", name, err)
+	}
+	return nil
+}
+
 func (c *pdClient) GetCluster() (*metapb.Cluster, error) {
 	apiURL := fmt.Sprintf("%s/%s", c.url, clusterIDPrefix)
 	body, err := httputil.GetBodyOK(c.httpClient, apiURL)
@@ -697,6 +821,75 @@ func (c *pdClient) GetRecoveringMark() (bool, error) {
 	return recoveringMark.Mark, nil
 }
 
+func (c *pdClient) GetStoreLimits() (map[uint64]*StoreLimit, error) {
+	apiURL := fmt.Sprintf("%s/%s", c.url, storesLimitPrefix)
+	body, err := httputil.GetBodyOK(c.httpClient, apiURL)
+	if err != nil {
+		return nil, err
+	}
+	limits := map[uint64]*StoreLimit{}
+	err = json.Unmarshal(body, &limits)
+	if err != nil {
+		return nil, err
+	}
+	return limits, nil
+}
+
+func (c *pdClient) SetStoreLimit(storeID uint64, limitType string, rate float64) error {
+	apiURL := fmt.Sprintf("%s/%s/%d/limit", c.url, storePrefix, storeID)
+	data, err := json.Marshal(map[string]interface{}{
+		"type": limitType,
+		"rate": rate,
+	})
+	if err != nil {
+		return err
+	}
+	res, err := c.httpClient.Post(apiURL, "application/json", bytes.NewBuffer(data))
+	if err != nil {
+		return err
+	}
+	defer httputil.DeferClose(res.Body)
+	if res.StatusCode == http.StatusOK {
+		return nil
+	}
+	err = httputil.ReadErrorBody(res.Body)
+	return fmt.Errorf("failed %v to set %s limit of store %d: %v", res.StatusCode, limitType, storeID, err)
+}
+
+func (c *pdClient) SetMemberLeaderPriority(name string, priority int) error {
+	apiURL := fmt.Sprintf("%s/%s/name/%s", c.url, membersPrefix, name)
+	data, err := json.Marshal(map[string]interface{}{
+		"leader-priority": priority,
+	})
+	if err != nil {
+		return err
+	}
+	res, err := c.httpClient.Post(apiURL, "application/json", bytes.NewBuffer(data))
+	if err != nil {
+		return err
+	}
+	defer httputil.DeferClose(res.Body)
+	if res.StatusCode == http.StatusOK {
+		return nil
+	}
+	err = httputil.ReadErrorBody(res.Body)
+	return fmt.Errorf("failed %v to set leader priority of pd member %s: %v", res.StatusCode, name, err)
+}
+
+func (c *pdClient) GetRegionsByStore(storeID uint64) (*RegionsInfo, error) {
+	apiURL := fmt.Sprintf("%s/%s/%d", c.url, regionsByStorePrefix, storeID)
+	body, err := httputil.GetBodyOK(c.httpClient, apiURL)
+	if err != nil {
+		return nil, err
+	}
+	regions := &RegionsInfo{}
+	err = json.Unmarshal(body, regions)
+	if err != nil {
+		return nil, err
+	}
+	return regions, nil
+}
+
 func (c *pdClient) GetPDLeader() (*pdpb.Member, error) {
 	apiURL := fmt.Sprintf("%s/%s", c.url, pdLeaderPrefix)
 	body, err := httputil.GetBodyOK(c.httpClient, apiURL)
@@ -353,6 +353,38 @@ func TestSetStoreLabels(t *testing.T) {
 	}
 }
 
+func TestStoreLimits(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	svc := getClientServer(func(w http.ResponseWriter, request *http.Request) {
+		w.Header().Set("Content-Type", ContentTypeJSON)
+		switch request.URL.Path {
+		case "/" + storesLimitPrefix:
+			g.Expect(request.Method).To(Equal("GET"), "check method")
+			w.Write([]byte(`{"1":{"add-peer":15,"remove-peer":15},"4":{"add-peer":30.5,"remove-peer":15}}`))
+		case fmt.Sprintf("/%s/%d/limit", storePrefix, 1):
+			g.Expect(request.Method).To(Equal("POST"), "check method")
+			body := map[string]interface{}{}
+			g.Expect(readJSON(request.Body, &body)).To(Succeed())
+			g.Expect(body).To(Equal(map[string]interface{}{"type": StoreLimitTypeAddPeer, "rate": float64(20)}))
+			w.WriteHeader(http.StatusOK)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	defer svc.Close()
+
+	pdClient := NewPDClient(svc.URL, DefaultTimeout, &tls.Config{})
+	limits, err := pdClient.GetStoreLimits()
+	g.Expect(err).To(Succeed())
+	g.Expect(limits).To(Equal(map[uint64]*StoreLimit{
+		1: {AddPeer: 15, RemovePeer: 15},
+		4: {AddPeer: 30.5, RemovePeer: 15},
+	}))
+	g.Expect(pdClient.SetStoreLimit(1, StoreLimitTypeAddPeer, 20)).To(Succeed())
+	g.Expect(pdClient.SetStoreLimit(2, StoreLimitTypeAddPeer, 20)).NotTo(Succeed())
+}
+
 func TestDeleteMember(t *testing.T) {
 	g := NewGomegaWithT(t)
 	name := "testMember"
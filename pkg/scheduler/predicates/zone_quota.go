@@ -0,0 +1,131 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package predicates
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/client/clientset/versioned"
+	apiv1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+)
+
+var (
+	// zoneQuotaComponents holds the components that zone quotas can be declared for
+	zoneQuotaComponents = sets.NewString(label.PDLabelVal, label.TiKVLabelVal, label.TiDBLabelVal, label.TiFlashLabelVal)
+)
+
+type zoneQuota struct {
+	podListFn func(ns, instanceName, component string) (*apiv1.PodList, error)
+	tcGetFn   func(ns, tcName string) (*v1alpha1.TidbCluster, error)
+	nodeGetFn func(nodeName string) (*apiv1.Node, error)
+}
+
+// NewZoneQuota returns a Predicate
+func NewZoneQuota(kubeCli kubernetes.Interface, cli versioned.Interface) Predicate {
+	return &zoneQuota{
+		podListFn: func(ns, instanceName, component string) (*apiv1.PodList, error) {
+			selector := label.New().Instance(instanceName).Component(component).Labels()
+			return kubeCli.CoreV1().Pods(ns).List(context.TODO(), metav1.ListOptions{
+				LabelSelector: labels.SelectorFromSet(selector).String(),
+			})
+		},
+		tcGetFn: func(ns, tcName string) (*v1alpha1.TidbCluster, error) {
+			return cli.PingcapV1alpha1().TidbClusters(ns).Get(context.TODO(), tcName, metav1.GetOptions{})
+		},
+		nodeGetFn: func(nodeName string) (*apiv1.Node, error) {
+			return kubeCli.CoreV1().Nodes().Get(context.TODO(), nodeName, metav1.GetOptions{})
+		},
+	}
+}
+
+func (z *zoneQuota) Name() string {
+	return "ZoneQuota"
+}
+
+// Filter keeps the nodes in the zones which have fewer pods of the component than their quotas
+// declared in spec.topologyZones of the TidbCluster.
+func (z *zoneQuota) Filter(instanceName string, pod *apiv1.Pod, nodes []apiv1.Node) ([]apiv1.Node, error) {
+	ns := pod.GetNamespace()
+	podName := pod.GetName()
+	component := pod.Labels[label.ComponentLabelKey]
+	tcName := getTCNameFromPod(pod, component)
+
+	if !zoneQuotaComponents.Has(component) {
+		return nodes, nil
+	}
+
+	tc, err := z.tcGetFn(ns, tcName)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nodes, nil
+		}
+		return nil, err
+	}
+	quotas := tc.ZoneQuotas(v1alpha1.MemberType(component))
+	if len(quotas) == 0 {
+		return nodes, nil
+	}
+	topologyKey := tc.ZoneTopologyKey()
+
+	podList, err := z.podListFn(ns, instanceName, component)
+	if err != nil {
+		return nil, err
+	}
+	zoneNames := map[string]string{}
+	counts := map[string]int32{}
+	for _, p := range podList.Items {
+		// the pod may be recreated with the same name
+		if p.Name == podName || p.Spec.NodeName == "" {
+			continue
+		}
+		zone, ok := zoneNames[p.Spec.NodeName]
+		if !ok {
+			node, err := z.nodeGetFn(p.Spec.NodeName)
+			if err != nil {
+				return nil, err
+			}
+			zone = node.Labels[topologyKey]
+			zoneNames[p.Spec.NodeName] = zone
+		}
+		counts[zone]++
+	}
+
+	available := sets.NewString()
+	for zone, quota := range quotas {
+		if counts[zone] < quota {
+			available.Insert(zone)
+		}
+	}
+	klog.Infof("zone quota: tidbcluster %s/%s component %s pods per zone %v, quotas %v", ns, tcName, component, counts, quotas)
+
+	var ret []apiv1.Node
+	for _, node := range nodes {
+		if zone, ok := node.Labels[topologyKey]; ok && available.Has(zone) {
+			ret = append(ret, node)
+		}
+	}
+	if len(ret) == 0 {
+		return nil, fmt.Errorf("no nodes in the zones %v under quota to schedule pod %s/%s", available.List(), ns, podName)
+	}
+	return ret, nil
+}
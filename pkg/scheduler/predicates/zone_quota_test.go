@@ -0,0 +1,117 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package predicates
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	pingcapfake "github.com/pingcap/tidb-operator/pkg/client/clientset/versioned/fake"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestZoneQuotaFilter(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	zoneNode := func(name, zone string) *v1.Node {
+		return &v1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{v1.LabelTopologyZone: zone}},
+		}
+	}
+	tikvPod := func(name, nodeName string) *v1.Pod {
+		return &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:         name,
+				Namespace:    v1.NamespaceDefault,
+				GenerateName: instanceName + "-tikv-",
+				Labels:       label.New().Instance(instanceName).TiKV().Labels(),
+			},
+			Spec: v1.PodSpec{NodeName: nodeName},
+		}
+	}
+	nodes := []*v1.Node{zoneNode("node-a", "zone-a"), zoneNode("node-b", "zone-b"), zoneNode("node-c", "zone-c")}
+
+	tests := []struct {
+		name       string
+		quotas     map[string]int32
+		pods       []*v1.Pod
+		expectErr  bool
+		expectNode []string
+	}{
+		{
+			name:       "no quotas",
+			pods:       []*v1.Pod{tikvPod("demo-tikv-0", "node-a")},
+			expectNode: []string{"node-a", "node-b", "node-c"},
+		},
+		{
+			name:       "filter the zones reaching the quotas",
+			quotas:     map[string]int32{"zone-a": 2, "zone-b": 1, "zone-c": 1},
+			pods:       []*v1.Pod{tikvPod("demo-tikv-0", "node-a"), tikvPod("demo-tikv-1", "node-b")},
+			expectNode: []string{"node-a", "node-c"},
+		},
+		{
+			name:       "the pod being scheduled is not counted",
+			quotas:     map[string]int32{"zone-a": 1, "zone-b": 1},
+			pods:       []*v1.Pod{tikvPod("demo-tikv-0", "node-a"), tikvPod("demo-tikv-2", "node-b")},
+			expectNode: []string{"node-b"},
+		},
+		{
+			name:      "all zones reach the quotas",
+			quotas:    map[string]int32{"zone-a": 1},
+			pods:      []*v1.Pod{tikvPod("demo-tikv-0", "node-a")},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Log(tt.name)
+		kubeCli := fake.NewSimpleClientset()
+		cli := pingcapfake.NewSimpleClientset()
+		tc := &v1alpha1.TidbCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: instanceName, Namespace: v1.NamespaceDefault},
+			Spec: v1alpha1.TidbClusterSpec{
+				TiKV:          &v1alpha1.TiKVSpec{},
+				TopologyZones: &v1alpha1.TopologyZones{TiKV: tt.quotas},
+			},
+		}
+		_, err := cli.PingcapV1alpha1().TidbClusters(tc.Namespace).Create(context.TODO(), tc, metav1.CreateOptions{})
+		g.Expect(err).NotTo(HaveOccurred())
+		candidates := []v1.Node{}
+		for _, node := range nodes {
+			_, err := kubeCli.CoreV1().Nodes().Create(context.TODO(), node, metav1.CreateOptions{})
+			g.Expect(err).NotTo(HaveOccurred())
+			candidates = append(candidates, *node)
+		}
+		for _, pod := range tt.pods {
+			_, err := kubeCli.CoreV1().Pods(pod.Namespace).Create(context.TODO(), pod, metav1.CreateOptions{})
+			g.Expect(err).NotTo(HaveOccurred())
+		}
+
+		p := NewZoneQuota(kubeCli, cli)
+		scheduling := tikvPod("demo-tikv-2", "")
+		filtered, err := p.Filter(instanceName, scheduling, candidates)
+		if tt.expectErr {
+			g.Expect(err).To(HaveOccurred())
+			g.Expect(filtered).To(BeEmpty())
+			continue
+		}
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(GetNodeNames(filtered)).To(Equal(tt.expectNode))
+	}
+}
@@ -64,18 +64,26 @@ func NewScheduler(kubeCli kubernetes.Interface, cli versioned.Interface) Schedul
 	eventBroadcaster.StartRecordingToSink(&eventv1.EventSinkImpl{
 		Interface: eventv1.New(kubeCli.CoreV1().RESTClient()).Events("")})
 	recorder := eventBroadcaster.NewRecorder(kubescheme.Scheme, apiv1.EventSource{Component: "tidb-scheduler"})
+	zoneQuota := predicates.NewZoneQuota(kubeCli, cli)
 	predicatesByComponent := map[string][]predicates.Predicate{
 		label.PDLabelVal: {
+			zoneQuota,
 			predicates.NewHA(kubeCli, cli),
 		},
 		label.TiKVLabelVal: {
+			zoneQuota,
 			predicates.NewHA(kubeCli, cli),
 		},
+		label.TiDBLabelVal: {
+			zoneQuota,
+		},
+		label.TiFlashLabelVal: {
+			zoneQuota,
+		},
 	}
 	if features.DefaultFeatureGate.Enabled(features.StableScheduling) {
-		predicatesByComponent[label.TiDBLabelVal] = []predicates.Predicate{
-			predicates.NewStableScheduling(kubeCli, cli),
-		}
+		predicatesByComponent[label.TiDBLabelVal] = append(predicatesByComponent[label.TiDBLabelVal],
+			predicates.NewStableScheduling(kubeCli, cli))
 	}
 	return &scheduler{
 		predicates: predicatesByComponent,
@@ -32,7 +32,7 @@ const (
 // TiFlashControlInterface is an interface that knows how to manage and get client for TiFlash
 type TiFlashControlInterface interface {
 	// GetTiFlashPodClient provides TiFlashClient of the TiFlash cluster.
-	GetTiFlashPodClient(namespace string, tcName string, podName string, tlsEnabled bool) TiFlashClient
+	GetTiFlashPodClient(namespace string, tcName string, podName string, tlsEnabled bool, opts ...pdapi.Option) TiFlashClient
 }
 
 // defaultTiFlashControl is the default implementation of TiFlashControlInterface.
@@ -46,7 +46,7 @@ func NewDefaultTiFlashControl(secretLister corelisterv1.SecretLister) TiFlashCon
 	return &defaultTiFlashControl{secretLister: secretLister}
 }
 
-func (tc *defaultTiFlashControl) GetTiFlashPodClient(namespace string, tcName string, podName string, tlsEnabled bool) TiFlashClient {
+func (tc *defaultTiFlashControl) GetTiFlashPodClient(namespace string, tcName string, podName string, tlsEnabled bool, opts ...pdapi.Option) TiFlashClient {
 	tc.mutex.Lock()
 	defer tc.mutex.Unlock()
 
@@ -56,7 +56,7 @@ func (tc *defaultTiFlashControl) GetTiFlashPodClient(namespace string, tcName st
 
 	if tlsEnabled {
 		scheme = "https"
-		tlsConfig, err = pdapi.GetTLSConfig(tc.secretLister, pdapi.Namespace(namespace), util.ClusterClientTLSSecretName(tcName))
+		tlsConfig, err = pdapi.GetTLSConfigFromOptions(tc.secretLister, pdapi.Namespace(namespace), util.ClusterClientTLSSecretName(tcName), opts...)
 		if err != nil {
 			klog.Errorf("Unable to get tls config for TiFlash cluster %q, tiflash client may not work: %v", tcName, err)
 			return NewTiFlashClient(TiFlashPodClientURL(namespace, tcName, podName, scheme), DefaultTimeout, tlsConfig, true)
@@ -94,6 +94,6 @@ func (ftc *FakeTiFlashControl) SetTiFlashPodClient(namespace, tcName, podName st
 	ftc.tiflashPodClients[tiflashPodClientKey("http", namespace, tcName, podName)] = tiflashPodClient
 }
 
-func (ftc *FakeTiFlashControl) GetTiFlashPodClient(namespace, tcName, podName string, tlsEnabled bool) TiFlashClient {
+func (ftc *FakeTiFlashControl) GetTiFlashPodClient(namespace, tcName, podName string, tlsEnabled bool, opts ...pdapi.Option) TiFlashClient {
 	return ftc.tiflashPodClients[tiflashPodClientKey("http", namespace, tcName, podName)]
 }
@@ -50,18 +50,16 @@ type tiflashClient struct {
 func NewTiFlashClient(url string, timeout time.Duration, tlsConfig *tls.Config, disableKeepalive bool) TiFlashClient {
 	return &tiflashClient{
 		url: url,
-		httpClient: &http.Client{
-			Timeout: timeout,
-			Transport: &http.Transport{
-				TLSClientConfig:       tlsConfig,
-				DisableKeepAlives:     disableKeepalive,
-				ResponseHeaderTimeout: 10 * time.Second,
-				TLSHandshakeTimeout:   10 * time.Second,
-				DialContext: (&net.Dialer{
-					Timeout: 10 * time.Second,
-				}).DialContext,
-			},
-		},
+		httpClient: httputil.NewClient(timeout, &http.Transport{
+			Proxy:                 httputil.Proxy,
+			TLSClientConfig:       tlsConfig,
+			DisableKeepAlives:     disableKeepalive,
+			ResponseHeaderTimeout: 10 * time.Second,
+			TLSHandshakeTimeout:   10 * time.Second,
+			DialContext: (&net.Dialer{
+				Timeout: 10 * time.Second,
+			}).DialContext,
+		}),
 	}
 }
 
@@ -26,8 +26,8 @@ import (
 
 // TiKVControlInterface is an interface that knows how to manage and get client for TiKV
 type TiKVControlInterface interface {
-	// GetTiKVPodClient provides TiKVClient of the TiKV cluster.
-	GetTiKVPodClient(namespace string, tcName string, podName string, tlsEnabled bool) TiKVClient
+	// GetTiKVPodClient provides TiKVClient of the TiKV cluster, the client connects to the status port of the pod.
+	GetTiKVPodClient(namespace string, tcName string, podName string, statusPort int32, tlsEnabled bool, opts ...pdapi.Option) TiKVClient
 }
 
 // defaultTiKVControl is the default implementation of TiKVControlInterface.
@@ -42,7 +42,7 @@ func NewDefaultTiKVControl(secretLister corelisterv1.SecretLister) TiKVControlIn
 	return &defaultTiKVControl{secretLister: secretLister, tikvClients: map[string]TiKVClient{}}
 }
 
-func (tc *defaultTiKVControl) GetTiKVPodClient(namespace string, tcName string, podName string, tlsEnabled bool) TiKVClient {
+func (tc *defaultTiKVControl) GetTiKVPodClient(namespace string, tcName string, podName string, statusPort int32, tlsEnabled bool, opts ...pdapi.Option) TiKVClient {
 	tc.mutex.Lock()
 	defer tc.mutex.Unlock()
 
@@ -52,16 +52,16 @@ func (tc *defaultTiKVControl) GetTiKVPodClient(namespace string, tcName string,
 
 	if tlsEnabled {
 		scheme = "https"
-		tlsConfig, err = pdapi.GetTLSConfig(tc.secretLister, pdapi.Namespace(namespace), util.ClusterClientTLSSecretName(tcName))
+		tlsConfig, err = pdapi.GetTLSConfigFromOptions(tc.secretLister, pdapi.Namespace(namespace), util.ClusterClientTLSSecretName(tcName), opts...)
 		if err != nil {
 			klog.Errorf("Unable to get tls config for TiKV cluster %q, tikv client may not work: %v", tcName, err)
-			return NewTiKVClient(TiKVPodClientURL(namespace, tcName, podName, scheme), DefaultTimeout, tlsConfig, true)
+			return NewTiKVClient(TiKVPodClientURL(namespace, tcName, podName, scheme, statusPort), DefaultTimeout, tlsConfig, true)
 		}
 
-		return NewTiKVClient(TiKVPodClientURL(namespace, tcName, podName, scheme), DefaultTimeout, tlsConfig, true)
+		return NewTiKVClient(TiKVPodClientURL(namespace, tcName, podName, scheme, statusPort), DefaultTimeout, tlsConfig, true)
 	}
 
-	return NewTiKVClient(TiKVPodClientURL(namespace, tcName, podName, scheme), DefaultTimeout, tlsConfig, true)
+	return NewTiKVClient(TiKVPodClientURL(namespace, tcName, podName, scheme, statusPort), DefaultTimeout, tlsConfig, true)
 }
 
 func tikvPodClientKey(schema, namespace, clusterName, podName string) string {
@@ -69,8 +69,8 @@ func tikvPodClientKey(schema, namespace, clusterName, podName string) string {
 }
 
 // TiKVPodClientURL builds the url of tikv pod client
-func TiKVPodClientURL(namespace, clusterName, podName, scheme string) string {
-	return fmt.Sprintf("%s://%s.%s-tikv-peer.%s:20180", scheme, podName, clusterName, namespace)
+func TiKVPodClientURL(namespace, clusterName, podName, scheme string, statusPort int32) string {
+	return fmt.Sprintf("%s://%s.%s-tikv-peer.%s:%d", scheme, podName, clusterName, namespace, statusPort)
 }
 
 // FakeTiKVControl implements a fake version of TiKVControlInterface.
@@ -90,6 +90,6 @@ func (ftc *FakeTiKVControl) SetTiKVPodClient(namespace, tcName, podName string,
 	ftc.tikvPodClients[tikvPodClientKey("http", namespace, tcName, podName)] = tikvPodClient
 }
 
-func (ftc *FakeTiKVControl) GetTiKVPodClient(namespace, tcName, podName string, tlsEnabled bool) TiKVClient {
+func (ftc *FakeTiKVControl) GetTiKVPodClient(namespace, tcName, podName string, statusPort int32, tlsEnabled bool, opts ...pdapi.Option) TiKVClient {
 	return ftc.tikvPodClients[tikvPodClientKey("http", namespace, tcName, podName)]
 }
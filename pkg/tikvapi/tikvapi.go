@@ -14,13 +14,16 @@
 package tikvapi
 
 import (
+	"bytes"
 	"crypto/tls"
+	"encoding/json"
 	"fmt"
 	"net"
 	"net/http"
 	"strconv"
 	"time"
 
+	httputil "github.com/pingcap/tidb-operator/pkg/util/http"
 	dto "github.com/prometheus/client_model/go"
 	"github.com/prometheus/prom2json"
 	"k8s.io/klog/v2"
@@ -31,11 +34,14 @@ const (
 	metricNameRegionCount = "tikv_raftstore_region_count"
 	labelNameLeaderCount  = "leader"
 	metricsPrefix         = "metrics"
+	configPrefix          = "config"
 )
 
 // TiKVClient provides tikv server's api
 type TiKVClient interface {
 	GetLeaderCount() (int, error)
+	// UpdateConfig updates the online config of the TiKV server
+	UpdateConfig(config map[string]string) error
 }
 
 // tikvClient is default implementation of TiKVClient
@@ -74,21 +80,30 @@ func (c *tikvClient) GetLeaderCount() (int, error) {
 	return 0, fmt.Errorf("metric %s{type=\"%s\"} not found for %s", metricNameRegionCount, labelNameLeaderCount, apiURL)
 }
 
+// UpdateConfig updates the online config of the TiKV server, the keys are the dotted config names
+func (c *tikvClient) UpdateConfig(config map[string]string) error {
+	apiURL := fmt.Sprintf("%s/%s", c.url, configPrefix)
+	data, err := json.Marshal(config)
+	if err != nil {
+		return err
+	}
+	_, err = httputil.PostBodyOK(c.httpClient, apiURL, bytes.NewBuffer(data))
+	return err
+}
+
 // NewTiKVClient returns a new TiKVClient
 func NewTiKVClient(url string, timeout time.Duration, tlsConfig *tls.Config, disableKeepalive bool) TiKVClient {
 	return &tikvClient{
 		url: url,
-		httpClient: &http.Client{
-			Timeout: timeout,
-			Transport: &http.Transport{
-				TLSClientConfig:       tlsConfig,
-				DisableKeepAlives:     disableKeepalive,
-				ResponseHeaderTimeout: 10 * time.Second,
-				TLSHandshakeTimeout:   10 * time.Second,
-				DialContext: (&net.Dialer{
-					Timeout: 10 * time.Second,
-				}).DialContext,
-			},
-		},
+		httpClient: httputil.NewClient(timeout, &http.Transport{
+			Proxy:                 httputil.Proxy,
+			TLSClientConfig:       tlsConfig,
+			DisableKeepAlives:     disableKeepalive,
+			ResponseHeaderTimeout: 10 * time.Second,
+			TLSHandshakeTimeout:   10 * time.Second,
+			DialContext: (&net.Dialer{
+				Timeout: 10 * time.Second,
+			}).DialContext,
+		}),
 	}
 }
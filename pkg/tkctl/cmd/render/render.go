@@ -0,0 +1,111 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package render
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/manager/member"
+	"github.com/pingcap/tidb-operator/pkg/scheme"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	renderLongDesc = `
+		Render the manifests tidb-operator creates for a tidb cluster spec
+		without accessing the kubernetes cluster.
+
+		The objects depending on the state of the running cluster, like
+		the TLS secrets and the initializer job, are not rendered.
+`
+	renderExample = `
+		# render the manifests of a tidb cluster spec
+		tkctl render -f tidb-cluster.yaml
+
+		# compare the manifests with the golden file
+		tkctl render -f tidb-cluster.yaml | diff golden.yaml -
+`
+	renderUsage = "expected 'render -f FILENAME' for the render command"
+)
+
+type RenderOptions struct {
+	Filename string
+
+	genericclioptions.IOStreams
+}
+
+// NewCmdRender creates the render command.
+func NewCmdRender(streams genericclioptions.IOStreams) *cobra.Command {
+	options := &RenderOptions{IOStreams: streams}
+
+	cmd := &cobra.Command{
+		Use:     "render",
+		Short:   "Render the manifests of a tidb cluster spec offline",
+		Long:    renderLongDesc,
+		Example: renderExample,
+		Run: func(command *cobra.Command, args []string) {
+			cmdutil.CheckErr(options.Complete(command))
+			cmdutil.CheckErr(options.Run())
+		},
+	}
+	cmd.Flags().StringVarP(&options.Filename, "filename", "f", "", "The file that contains the TidbCluster to render")
+
+	return cmd
+}
+
+func (o *RenderOptions) Complete(cmd *cobra.Command) error {
+	if len(o.Filename) == 0 {
+		return cmdutil.UsageErrorf(cmd, renderUsage)
+	}
+	return nil
+}
+
+func (o *RenderOptions) Run() error {
+	data, err := os.ReadFile(o.Filename)
+	if err != nil {
+		return err
+	}
+	tc := &v1alpha1.TidbCluster{}
+	if err := yaml.UnmarshalStrict(data, tc); err != nil {
+		return fmt.Errorf("failed to decode TidbCluster from %s: %v", o.Filename, err)
+	}
+	if len(tc.Namespace) == 0 {
+		tc.Namespace = metav1.NamespaceDefault
+	}
+
+	objs, err := member.RenderTidbCluster(tc, nil)
+	if err != nil {
+		return err
+	}
+	for _, obj := range objs {
+		gvk, err := apiutil.GVKForObject(obj, scheme.Scheme)
+		if err != nil {
+			return err
+		}
+		obj.GetObjectKind().SetGroupVersionKind(gvk)
+		out, err := yaml.Marshal(obj)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(o.Out, "---\n%s", out)
+	}
+	return nil
+}
@@ -0,0 +1,288 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package upgrader
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	asappsv1 "github.com/pingcap/advanced-statefulset/client/apis/apps/v1"
+	"github.com/pingcap/advanced-statefulset/client/apis/apps/v1/helper"
+	asclientset "github.com/pingcap/advanced-statefulset/client/client/clientset/versioned"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/util"
+	"github.com/pingcap/tidb-operator/pkg/util/cmpver"
+	appsv1 "k8s.io/api/apps/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apiextensionsclientset "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// astsCRDName is the name of the Advanced StatefulSet CRD
+	astsCRDName = "statefulsets." + asappsv1.GroupName
+	// astsControllerSelector selects the Deployment of the Advanced StatefulSet controller deployed by the chart
+	astsControllerSelector = "app.kubernetes.io/component=advanced-statefulset-controller"
+	// MinASTSControllerVersion is the minimum version of the Advanced StatefulSet controller compatible
+	// with the Advanced StatefulSet client of this build
+	MinASTSControllerVersion = "v0.4.0"
+)
+
+// astsSpecFields are the fields of the Advanced StatefulSet spec the operator relies on
+var astsSpecFields = []string{"replicas", "selector", "template", "serviceName", "updateStrategy", "podManagementPolicy", "volumeClaimTemplates"}
+
+// ASTSChecker verifies that the Advanced StatefulSet CRD and controller are compatible with this build of
+// the operator, and that the hijacked Kubernetes client sees the Advanced StatefulSets as they are. The
+// incompatibilities cause subtle pod management bugs instead of errors, e.g. the fields dropped when the
+// Advanced StatefulSets are converted, or the StatefulSets not migrated being invisible to the operator.
+type ASTSChecker struct {
+	extCli apiextensionsclientset.Interface
+	// kubeCli must not be the hijacked one
+	kubeCli     kubernetes.Interface
+	hijackedCli kubernetes.Interface
+	asCli       asclientset.Interface
+	ns          string
+}
+
+// NewASTSChecker returns an ASTSChecker, ns is the namespace the operator manages or empty for all the namespaces
+func NewASTSChecker(extCli apiextensionsclientset.Interface, kubeCli, hijackedCli kubernetes.Interface, asCli asclientset.Interface, ns string) *ASTSChecker {
+	return &ASTSChecker{
+		extCli:      extCli,
+		kubeCli:     kubeCli,
+		hijackedCli: hijackedCli,
+		asCli:       asCli,
+		ns:          ns,
+	}
+}
+
+// Check returns the incompatibilities found. An error is returned if the objects can't be read, in which
+// case the incompatibilities can't be detected.
+func (c *ASTSChecker) Check() ([]controller.ASTSProblem, error) {
+	var problems []controller.ASTSProblem
+	crdProblems, err := c.checkCRD()
+	if err != nil {
+		return nil, err
+	}
+	problems = append(problems, crdProblems...)
+	if len(crdProblems) > 0 {
+		// the Advanced StatefulSets can't be read without the CRD
+		return problems, nil
+	}
+
+	controllerProblems, err := c.checkController()
+	if err != nil {
+		return nil, err
+	}
+	problems = append(problems, controllerProblems...)
+
+	setProblems, err := c.checkStatefulSets()
+	if err != nil {
+		return nil, err
+	}
+	return append(problems, setProblems...), nil
+}
+
+// checkCRD checks that the CRD serves the version of the client and declares the fields the operator relies on
+func (c *ASTSChecker) checkCRD() ([]controller.ASTSProblem, error) {
+	crd, err := c.extCli.ApiextensionsV1().CustomResourceDefinitions().Get(context.TODO(), astsCRDName, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return []controller.ASTSProblem{{Message: fmt.Sprintf("the CRD %s is not installed", astsCRDName)}}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get CRD %s, err: %v", astsCRDName, err)
+	}
+
+	version := asappsv1.SchemeGroupVersion.Version
+	var served *apiextensionsv1.CustomResourceDefinitionVersion
+	for i := range crd.Spec.Versions {
+		if crd.Spec.Versions[i].Name == version && crd.Spec.Versions[i].Served {
+			served = &crd.Spec.Versions[i]
+		}
+	}
+	if served == nil {
+		return []controller.ASTSProblem{{Message: fmt.Sprintf("the CRD %s doesn't serve version %s", astsCRDName, version)}}, nil
+	}
+
+	var problems []controller.ASTSProblem
+	if served.Subresources == nil || served.Subresources.Status == nil {
+		problems = append(problems, controller.ASTSProblem{
+			Message: fmt.Sprintf("the CRD %s doesn't enable the status subresource of version %s", astsCRDName, version),
+		})
+	}
+	if served.Schema != nil && served.Schema.OpenAPIV3Schema != nil {
+		spec := served.Schema.OpenAPIV3Schema.Properties["spec"]
+		if len(spec.Properties) > 0 && (spec.XPreserveUnknownFields == nil || !*spec.XPreserveUnknownFields) {
+			var missing []string
+			for _, field := range astsSpecFields {
+				if _, ok := spec.Properties[field]; !ok {
+					missing = append(missing, "spec."+field)
+				}
+			}
+			if len(missing) > 0 {
+				problems = append(problems, controller.ASTSProblem{
+					Message: fmt.Sprintf("the CRD %s misses fields %s", astsCRDName, strings.Join(missing, ", ")),
+				})
+			}
+		}
+	}
+	return problems, nil
+}
+
+// checkController checks the version of the Advanced StatefulSet controller deployed by the chart, it's
+// skipped if the controller is deployed in another way or the Deployments can't be read
+func (c *ASTSChecker) checkController() ([]controller.ASTSProblem, error) {
+	deploys, err := c.kubeCli.AppsV1().Deployments(c.ns).List(context.TODO(), metav1.ListOptions{LabelSelector: astsControllerSelector})
+	if apierrors.IsForbidden(err) {
+		klog.V(4).Infof("ASTSChecker: skip the check of the Advanced StatefulSet controller, %v", err)
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the Deployments of the Advanced StatefulSet controller, err: %v", err)
+	}
+
+	var problems []controller.ASTSProblem
+	for _, deploy := range deploys.Items {
+		for _, container := range deploy.Spec.Template.Spec.Containers {
+			tag := imageTag(container.Image)
+			if tag == "" {
+				continue
+			}
+			ok, err := cmpver.Compare(tag, cmpver.GreaterOrEqual, MinASTSControllerVersion)
+			if err != nil {
+				// the versions of the custom builds can't be compared
+				klog.V(4).Infof("ASTSChecker: skip the version check of image %s, %v", container.Image, err)
+				continue
+			}
+			if !ok {
+				problems = append(problems, controller.ASTSProblem{
+					Message: fmt.Sprintf("the Advanced StatefulSet controller %s/%s runs image %s, %s or later is required",
+						deploy.Namespace, deploy.Name, container.Image, MinASTSControllerVersion),
+				})
+			}
+		}
+		if deploy.Spec.Replicas != nil && *deploy.Spec.Replicas > 0 && deploy.Status.AvailableReplicas == 0 {
+			problems = append(problems, controller.ASTSProblem{
+				Message: fmt.Sprintf("the Advanced StatefulSet controller %s/%s is not available", deploy.Namespace, deploy.Name),
+			})
+		}
+	}
+	return problems, nil
+}
+
+// checkStatefulSets checks the StatefulSets owned by the TidbClusters. The Kubernetes StatefulSets are invisible
+// to the hijacked client, and the Advanced StatefulSets must be read by the hijacked client without any loss.
+func (c *ASTSChecker) checkStatefulSets() ([]controller.ASTSProblem, error) {
+	var problems []controller.ASTSProblem
+	builtinSets, err := c.kubeCli.AppsV1().StatefulSets(c.ns).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the Kubernetes StatefulSets, err: %v", err)
+	}
+	for i := range builtinSets.Items {
+		set := &builtinSets.Items[i]
+		if ok, ref := util.IsOwnedByTidbCluster(set); ok {
+			problems = append(problems, controller.ASTSProblem{
+				Namespace: set.Namespace,
+				Cluster:   ref.Name,
+				Message:   fmt.Sprintf("Kubernetes StatefulSet %s is not migrated to Advanced StatefulSet, restart the operator to migrate it", set.Name),
+			})
+		}
+	}
+
+	asSets, err := c.asCli.AppsV1().StatefulSets(c.ns).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the Advanced StatefulSets, err: %v", err)
+	}
+	for i := range asSets.Items {
+		asSet := &asSets.Items[i]
+		ok, ref := util.IsOwnedByTidbCluster(asSet)
+		if !ok {
+			continue
+		}
+		set, err := c.hijackedCli.AppsV1().StatefulSets(asSet.Namespace).Get(context.TODO(), asSet.Name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			// deleted after listed
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get StatefulSet %s/%s by the hijacked client, err: %v", asSet.Namespace, asSet.Name, err)
+		}
+		if set.ResourceVersion != asSet.ResourceVersion {
+			// updated after listed, check it next time
+			continue
+		}
+		if diff := hijackDiff(asSet, set); diff != "" {
+			problems = append(problems, controller.ASTSProblem{
+				Namespace: asSet.Namespace,
+				Cluster:   ref.Name,
+				Message:   fmt.Sprintf("Advanced StatefulSet %s is read by the operator with different %s", asSet.Name, diff),
+			})
+		}
+	}
+	return problems, nil
+}
+
+// hijackDiff returns the fields of the Advanced StatefulSet that the operator sees differently through the
+// hijacked client, the fields unknown to the Kubernetes StatefulSet are lost when the operator updates it
+func hijackDiff(asSet *asappsv1.StatefulSet, set *appsv1.StatefulSet) string {
+	converted, err := helper.FromBuiltinStatefulSet(set)
+	if err != nil {
+		return fmt.Sprintf("fields as it fails to convert: %v", err)
+	}
+	var fields []string
+	if !apiequality.Semantic.DeepEqual(asSet.Spec, converted.Spec) {
+		fields = append(fields, "spec")
+	}
+	if !apiequality.Semantic.DeepEqual(asSet.Status, converted.Status) {
+		fields = append(fields, "status")
+	}
+	if !helper.GetDeleteSlots(asSet).Equal(helper.GetDeleteSlots(set)) {
+		fields = append(fields, "delete slots")
+	}
+	return strings.Join(fields, ", ")
+}
+
+// imageTag returns the tag of the image, it's empty if the image is referenced by digest or has no tag
+func imageTag(image string) string {
+	if strings.Contains(image, "@") {
+		return ""
+	}
+	i := strings.LastIndex(image, ":")
+	if i < 0 || strings.Contains(image[i:], "/") {
+		return ""
+	}
+	return image[i+1:]
+}
+
+// RunASTSChecker checks the compatibility of Advanced StatefulSet every interval until the stop channel is
+// closed, and records the result so that the incompatibilities are surfaced in the TidbCluster conditions.
+func RunASTSChecker(checker *ASTSChecker, compatibility *controller.ASTSCompatibility, interval time.Duration, stopCh <-chan struct{}) {
+	wait.Until(func() {
+		problems, err := checker.Check()
+		if err != nil {
+			klog.Warningf("ASTSChecker: skip the compatibility check of Advanced StatefulSet, %v", err)
+			return
+		}
+		if len(problems) > 0 {
+			klog.Errorf("ASTSChecker: Advanced StatefulSet is incompatible with this operator:\n%s", controller.FormatASTSProblems(problems))
+		}
+		compatibility.Set(problems)
+	}, interval, stopCh)
+}
@@ -0,0 +1,143 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package upgrader
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	asappsv1 "github.com/pingcap/advanced-statefulset/client/apis/apps/v1"
+	"github.com/pingcap/advanced-statefulset/client/apis/apps/v1/helper"
+	asclientsetfake "github.com/pingcap/advanced-statefulset/client/client/clientset/versioned/fake"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apiextensionsfake "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/fake"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/pointer"
+)
+
+func TestASTSChecker(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	crd := &apiextensionsv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: astsCRDName},
+		Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+			Versions: []apiextensionsv1.CustomResourceDefinitionVersion{{
+				Name:         "v1",
+				Served:       true,
+				Subresources: &apiextensionsv1.CustomResourceSubresources{Status: &apiextensionsv1.CustomResourceSubresourceStatus{}},
+			}},
+		},
+	}
+	controllerDeploy := func(image string) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "tidb-admin",
+				Name:      "advanced-statefulset-controller",
+				Labels:    map[string]string{"app.kubernetes.io/component": "advanced-statefulset-controller"},
+			},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: pointer.Int32Ptr(1),
+				Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "advanced-statefulset-controller", Image: image}},
+				}},
+			},
+			Status: appsv1.DeploymentStatus{AvailableReplicas: 1},
+		}
+	}
+	owner := []metav1.OwnerReference{{APIVersion: "pingcap.com/v1alpha1", Kind: "TidbCluster", Name: "basic", Controller: pointer.BoolPtr(true)}}
+
+	tests := []struct {
+		name        string
+		crds        []runtime.Object
+		kubeObjects []runtime.Object
+		asObjects   []runtime.Object
+		expect      []controller.ASTSProblem
+	}{
+		{
+			name:   "crd not installed",
+			expect: []controller.ASTSProblem{{Message: "the CRD statefulsets.apps.pingcap.com is not installed"}},
+		},
+		{
+			name:        "compatible",
+			crds:        []runtime.Object{crd},
+			kubeObjects: []runtime.Object{controllerDeploy("pingcap/advanced-statefulset:v0.4.0")},
+			asObjects: []runtime.Object{&asappsv1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "basic-tikv", OwnerReferences: owner},
+				Spec:       asappsv1.StatefulSetSpec{Replicas: pointer.Int32Ptr(3)},
+			}},
+		},
+		{
+			name:        "controller too old",
+			crds:        []runtime.Object{crd},
+			kubeObjects: []runtime.Object{controllerDeploy("pingcap/advanced-statefulset:v0.3.3")},
+			expect: []controller.ASTSProblem{{
+				Message: "the Advanced StatefulSet controller tidb-admin/advanced-statefulset-controller runs image pingcap/advanced-statefulset:v0.3.3, v0.4.0 or later is required",
+			}},
+		},
+		{
+			name: "statefulset not migrated",
+			crds: []runtime.Object{crd},
+			kubeObjects: []runtime.Object{&appsv1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "basic-pd", OwnerReferences: owner},
+			}},
+			expect: []controller.ASTSProblem{{
+				Namespace: "default",
+				Cluster:   "basic",
+				Message:   "Kubernetes StatefulSet basic-pd is not migrated to Advanced StatefulSet, restart the operator to migrate it",
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kubeCli := fake.NewSimpleClientset(tt.kubeObjects...)
+			asCli := asclientsetfake.NewSimpleClientset(tt.asObjects...)
+			checker := NewASTSChecker(apiextensionsfake.NewSimpleClientset(tt.crds...), kubeCli, helper.NewHijackClient(kubeCli, asCli), asCli, metav1.NamespaceAll)
+
+			problems, err := checker.Check()
+			g.Expect(err).To(Succeed())
+			g.Expect(problems).To(Equal(tt.expect))
+		})
+	}
+}
+
+func TestHijackDiff(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	asSet := &asappsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "basic-tikv"},
+		Spec:       asappsv1.StatefulSetSpec{Replicas: pointer.Int32Ptr(3)},
+		Status:     asappsv1.StatefulSetStatus{Replicas: 3},
+	}
+	set, err := helper.ToBuiltinStatefulSet(asSet)
+	g.Expect(err).To(Succeed())
+	g.Expect(hijackDiff(asSet, set)).To(BeEmpty())
+
+	set.Spec.Replicas = pointer.Int32Ptr(2)
+	set.Annotations = map[string]string{helper.DeleteSlotsAnn: "[1]"}
+	g.Expect(hijackDiff(asSet, set)).To(Equal("spec, delete slots"))
+}
+
+func TestImageTag(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	g.Expect(imageTag("pingcap/advanced-statefulset:v0.4.0")).To(Equal("v0.4.0"))
+	g.Expect(imageTag("localhost:5000/pingcap/advanced-statefulset")).To(BeEmpty())
+	g.Expect(imageTag("pingcap/advanced-statefulset@sha256:abcd")).To(BeEmpty())
+}
@@ -0,0 +1,225 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package upgrader
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-openapi/spec"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apiextensionsclientset "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+	"k8s.io/kube-openapi/pkg/common"
+)
+
+// maxSchemaDepth limits the recursion of the schema comparison in case of recursive types
+const maxSchemaDepth = 32
+
+// CRDReport describes the incompatibilities of an installed CRD with this build of the operator.
+type CRDReport struct {
+	// Name is the name of the CRD, e.g. tidbclusters.pingcap.com
+	Name string
+	// Problem is set if the CRD or the version isn't installed
+	Problem string
+	// MissingFields are the fields this build uses but the installed CRD doesn't have,
+	// they are pruned by the API server and the operator would see the default values
+	MissingFields []string
+	// UnknownRequiredFields are the fields the installed CRD requires but this build doesn't know,
+	// the objects written by this build would be rejected
+	UnknownRequiredFields []string
+}
+
+func (r *CRDReport) compatible() bool {
+	return r.Problem == "" && len(r.MissingFields) == 0 && len(r.UnknownRequiredFields) == 0
+}
+
+func (r *CRDReport) String() string {
+	if r.Problem != "" {
+		return fmt.Sprintf("%s: %s", r.Name, r.Problem)
+	}
+	var parts []string
+	if len(r.MissingFields) > 0 {
+		parts = append(parts, fmt.Sprintf("missing fields %s", strings.Join(r.MissingFields, ", ")))
+	}
+	if len(r.UnknownRequiredFields) > 0 {
+		parts = append(parts, fmt.Sprintf("unknown required fields %s", strings.Join(r.UnknownRequiredFields, ", ")))
+	}
+	return fmt.Sprintf("%s: %s", r.Name, strings.Join(parts, "; "))
+}
+
+// CRDChecker verifies that the installed CRDs have the schemas this build of the operator expects.
+// If a field is missing in the installed CRD, the API server prunes it silently and the operator
+// sees the default value, which may roll every cluster after an operator upgrade without the CRDs
+// being upgraded.
+type CRDChecker struct {
+	cli  apiextensionsclientset.Interface
+	defs map[string]common.OpenAPIDefinition
+}
+
+// NewCRDChecker returns a CRDChecker
+func NewCRDChecker(cli apiextensionsclientset.Interface) *CRDChecker {
+	return &CRDChecker{
+		cli: cli,
+		defs: v1alpha1.GetOpenAPIDefinitions(func(path string) spec.Ref {
+			return spec.MustCreateRef(path)
+		}),
+	}
+}
+
+// Check compares the installed CRDs of the kinds with the types of this build, and returns the reports
+// of the incompatible CRDs. An error is returned if the CRDs can't be read, e.g. the operator has no
+// permission to read the CRDs.
+func (c *CRDChecker) Check(kinds []v1alpha1.CrdKind) ([]CRDReport, error) {
+	var reports []CRDReport
+	for _, kind := range kinds {
+		name := fmt.Sprintf("%s.%s", kind.Plural, v1alpha1.GroupName)
+		crd, err := c.cli.ApiextensionsV1().CustomResourceDefinitions().Get(context.TODO(), name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			reports = append(reports, CRDReport{Name: name, Problem: "the CRD is not installed"})
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get CRD %s, err: %v", name, err)
+		}
+		report := c.checkCRD(crd, kind)
+		if !report.compatible() {
+			reports = append(reports, report)
+		}
+	}
+	return reports, nil
+}
+
+func (c *CRDChecker) checkCRD(crd *apiextensionsv1.CustomResourceDefinition, kind v1alpha1.CrdKind) CRDReport {
+	report := CRDReport{Name: crd.Name}
+	var installed *apiextensionsv1.JSONSchemaProps
+	for _, v := range crd.Spec.Versions {
+		if v.Name == v1alpha1.Version && v.Served {
+			if v.Schema == nil || v.Schema.OpenAPIV3Schema == nil {
+				report.Problem = fmt.Sprintf("version %s has no schema", v1alpha1.Version)
+				return report
+			}
+			installed = v.Schema.OpenAPIV3Schema
+		}
+	}
+	if installed == nil {
+		report.Problem = fmt.Sprintf("version %s is not served", v1alpha1.Version)
+		return report
+	}
+
+	def, ok := c.defs[kind.SpecName]
+	if !ok {
+		return report
+	}
+	for _, field := range []string{"spec", "status"} {
+		expected, ok := def.Schema.Properties[field]
+		if !ok {
+			continue
+		}
+		c.compare(field, expected, installed.Properties[field], &report, map[string]bool{}, 0)
+	}
+	sort.Strings(report.MissingFields)
+	sort.Strings(report.UnknownRequiredFields)
+	return report
+}
+
+// compare checks the expected schema against the installed schema recursively. The comparison stops at
+// the schemas which preserve unknown fields or don't declare the properties, and at the types without
+// openapi definitions, e.g. the types of Kubernetes, so that only the fields of the operator are compared.
+func (c *CRDChecker) compare(path string, expected spec.Schema, installed apiextensionsv1.JSONSchemaProps, report *CRDReport, visiting map[string]bool, depth int) {
+	if depth > maxSchemaDepth {
+		return
+	}
+	if ref := expected.Ref.String(); ref != "" {
+		if visiting[ref] {
+			return
+		}
+		def, ok := c.defs[ref]
+		if !ok {
+			return
+		}
+		visiting[ref] = true
+		defer delete(visiting, ref)
+		expected = def.Schema
+	}
+	if installed.XPreserveUnknownFields != nil && *installed.XPreserveUnknownFields {
+		return
+	}
+
+	if len(installed.Properties) > 0 {
+		for name, prop := range expected.Properties {
+			installedProp, ok := installed.Properties[name]
+			if !ok {
+				report.MissingFields = append(report.MissingFields, path+"."+name)
+				continue
+			}
+			c.compare(path+"."+name, prop, installedProp, report, visiting, depth+1)
+		}
+		if len(expected.Properties) > 0 {
+			for _, name := range installed.Required {
+				if _, ok := expected.Properties[name]; !ok {
+					report.UnknownRequiredFields = append(report.UnknownRequiredFields, path+"."+name)
+				}
+			}
+		}
+	}
+
+	if expected.Items != nil && expected.Items.Schema != nil && installed.Items != nil && installed.Items.Schema != nil {
+		c.compare(path+"[]", *expected.Items.Schema, *installed.Items.Schema, report, visiting, depth+1)
+	}
+	if expected.AdditionalProperties != nil && expected.AdditionalProperties.Schema != nil &&
+		installed.AdditionalProperties != nil && installed.AdditionalProperties.Schema != nil {
+		c.compare(path+"{}", *expected.AdditionalProperties.Schema, *installed.AdditionalProperties.Schema, report, visiting, depth+1)
+	}
+}
+
+// FormatCRDReports formats the reports in one message
+func FormatCRDReports(reports []CRDReport) string {
+	msgs := make([]string, 0, len(reports))
+	for i := range reports {
+		msgs = append(msgs, reports[i].String())
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// WaitForCompatibleCRDs checks the installed CRDs and logs the reports of the incompatible ones. If block
+// is true, it doesn't return until all of them are compatible or the stop channel is closed, the check
+// is retried every interval so that the controllers start once the CRDs are upgraded. It returns at once
+// if the CRDs can't be read because the incompatibilities can't be detected.
+func WaitForCompatibleCRDs(checker *CRDChecker, kinds []v1alpha1.CrdKind, block bool, interval time.Duration, stopCh <-chan struct{}) {
+	_ = wait.PollImmediateUntil(interval, func() (bool, error) {
+		reports, err := checker.Check(kinds)
+		if err != nil {
+			klog.Warningf("CRDChecker: skip the check of the installed CRDs, %v", err)
+			return true, nil
+		}
+		if len(reports) == 0 {
+			klog.Infof("CRDChecker: the installed CRDs are compatible")
+			return true, nil
+		}
+		if !block {
+			klog.Warningf("CRDChecker: the installed CRDs are incompatible with this operator, upgrade the CRDs first:\n%s", FormatCRDReports(reports))
+			return true, nil
+		}
+		klog.Errorf("CRDChecker: the installed CRDs are incompatible with this operator, the controllers are not started until the CRDs are upgraded:\n%s", FormatCRDReports(reports))
+		return false, nil
+	}, stopCh)
+}
@@ -0,0 +1,159 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package upgrader
+
+import (
+	"testing"
+
+	"github.com/go-openapi/spec"
+	. "github.com/onsi/gomega"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apiextensionsfake "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/fake"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/kube-openapi/pkg/common"
+	"k8s.io/utils/pointer"
+)
+
+func TestCRDChecker(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	kind := v1alpha1.CrdKind{Plural: "foos", Kind: "Foo", SpecName: "test.Foo"}
+	defs := map[string]common.OpenAPIDefinition{
+		"test.Foo": {Schema: spec.Schema{SchemaProps: spec.SchemaProps{
+			Properties: map[string]spec.Schema{
+				"spec":   {SchemaProps: spec.SchemaProps{Ref: spec.MustCreateRef("test.FooSpec")}},
+				"status": {SchemaProps: spec.SchemaProps{Ref: spec.MustCreateRef("test.FooStatus")}},
+			},
+		}}},
+		"test.FooSpec": {Schema: spec.Schema{SchemaProps: spec.SchemaProps{
+			Properties: map[string]spec.Schema{
+				"replicas": {SchemaProps: spec.SchemaProps{Type: []string{"integer"}}},
+				"config":   {SchemaProps: spec.SchemaProps{Type: []string{"object"}}},
+				"members": {SchemaProps: spec.SchemaProps{
+					Type:  []string{"array"},
+					Items: &spec.SchemaOrArray{Schema: &spec.Schema{SchemaProps: spec.SchemaProps{Ref: spec.MustCreateRef("test.FooMember")}}},
+				}},
+			},
+		}}},
+		"test.FooMember": {Schema: spec.Schema{SchemaProps: spec.SchemaProps{
+			Properties: map[string]spec.Schema{
+				"name":   {SchemaProps: spec.SchemaProps{Type: []string{"string"}}},
+				"weight": {SchemaProps: spec.SchemaProps{Type: []string{"integer"}}},
+			},
+		}}},
+		"test.FooStatus": {Schema: spec.Schema{SchemaProps: spec.SchemaProps{
+			Properties: map[string]spec.Schema{
+				"phase": {SchemaProps: spec.SchemaProps{Type: []string{"string"}}},
+			},
+		}}},
+	}
+	newCRD := func(specProps apiextensionsv1.JSONSchemaProps) *apiextensionsv1.CustomResourceDefinition {
+		return &apiextensionsv1.CustomResourceDefinition{
+			ObjectMeta: metav1.ObjectMeta{Name: "foos.pingcap.com"},
+			Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+				Versions: []apiextensionsv1.CustomResourceDefinitionVersion{{
+					Name:   v1alpha1.Version,
+					Served: true,
+					Schema: &apiextensionsv1.CustomResourceValidation{
+						OpenAPIV3Schema: &apiextensionsv1.JSONSchemaProps{
+							Properties: map[string]apiextensionsv1.JSONSchemaProps{
+								"spec": specProps,
+								"status": {Properties: map[string]apiextensionsv1.JSONSchemaProps{
+									"phase": {Type: "string"},
+								}},
+							},
+						},
+					},
+				}},
+			},
+		}
+	}
+	member := apiextensionsv1.JSONSchemaProps{Properties: map[string]apiextensionsv1.JSONSchemaProps{
+		"name":   {Type: "string"},
+		"weight": {Type: "integer"},
+	}}
+
+	tests := []struct {
+		name           string
+		crd            *apiextensionsv1.CustomResourceDefinition
+		expectProblem  bool
+		expectMissing  []string
+		expectRequired []string
+	}{
+		{
+			name: "compatible",
+			crd: newCRD(apiextensionsv1.JSONSchemaProps{Properties: map[string]apiextensionsv1.JSONSchemaProps{
+				"replicas": {Type: "integer"},
+				"config":   {Type: "object", XPreserveUnknownFields: pointer.BoolPtr(true)},
+				"members":  {Type: "array", Items: &apiextensionsv1.JSONSchemaPropsOrArray{Schema: &member}},
+			}}),
+		},
+		{
+			name:          "not installed",
+			expectProblem: true,
+		},
+		{
+			name: "missing fields",
+			crd: newCRD(apiextensionsv1.JSONSchemaProps{Properties: map[string]apiextensionsv1.JSONSchemaProps{
+				"replicas": {Type: "integer"},
+				"members": {Type: "array", Items: &apiextensionsv1.JSONSchemaPropsOrArray{Schema: &apiextensionsv1.JSONSchemaProps{
+					Properties: map[string]apiextensionsv1.JSONSchemaProps{"name": {Type: "string"}},
+				}}},
+			}}),
+			expectMissing: []string{"spec.config", "spec.members[].weight"},
+		},
+		{
+			name: "unknown required fields",
+			crd: newCRD(apiextensionsv1.JSONSchemaProps{
+				Properties: map[string]apiextensionsv1.JSONSchemaProps{
+					"replicas": {Type: "integer"},
+					"config":   {Type: "object"},
+					"members":  {Type: "array", Items: &apiextensionsv1.JSONSchemaPropsOrArray{Schema: &member}},
+					"version":  {Type: "string"},
+				},
+				Required: []string{"replicas", "version"},
+			}),
+			expectRequired: []string{"spec.version"},
+		},
+		{
+			name: "schemaless spec",
+			crd:  newCRD(apiextensionsv1.JSONSchemaProps{Type: "object", XPreserveUnknownFields: pointer.BoolPtr(true)}),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cli := apiextensionsfake.NewSimpleClientset()
+			if tt.crd != nil {
+				cli = apiextensionsfake.NewSimpleClientset(tt.crd)
+			}
+			checker := &CRDChecker{cli: cli, defs: defs}
+
+			reports, err := checker.Check([]v1alpha1.CrdKind{kind})
+			g.Expect(err).To(Succeed())
+			if !tt.expectProblem && tt.expectMissing == nil && tt.expectRequired == nil {
+				g.Expect(reports).To(BeEmpty())
+				return
+			}
+			g.Expect(reports).To(HaveLen(1))
+			g.Expect(reports[0].Name).To(Equal("foos.pingcap.com"))
+			if tt.expectProblem {
+				g.Expect(reports[0].Problem).NotTo(BeEmpty())
+			}
+			g.Expect(reports[0].MissingFields).To(Equal(tt.expectMissing))
+			g.Expect(reports[0].UnknownRequiredFields).To(Equal(tt.expectRequired))
+		})
+	}
+}
@@ -112,10 +112,16 @@ func ReadCACerts() (*x509.CertPool, error) {
 }
 
 func LoadTlsConfigFromSecret(secret *corev1.Secret) (*tls.Config, error) {
+	return LoadTlsConfigFromSecrets(secret, secret)
+}
+
+// LoadTlsConfigFromSecrets loads the client certificate from the secret and the CA certificate
+// from the caSecret, so that the servers can be issued by a CA other than the one in the secret.
+func LoadTlsConfigFromSecrets(secret, caSecret *corev1.Secret) (*tls.Config, error) {
 	rootCAs := x509.NewCertPool()
 	var tlsCert tls.Certificate
 
-	if !rootCAs.AppendCertsFromPEM(secret.Data[corev1.ServiceAccountRootCAKey]) {
+	if !rootCAs.AppendCertsFromPEM(caSecret.Data[corev1.ServiceAccountRootCAKey]) {
 		return nil, fmt.Errorf("failed to append ca certs")
 	}
 
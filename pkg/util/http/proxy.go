@@ -0,0 +1,105 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httputil
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"golang.org/x/net/http/httpproxy"
+)
+
+var (
+	proxyLock sync.RWMutex
+	// proxyFunc is nil unless a proxy is set by SetProxy, then the requests are sent without a proxy
+	proxyFunc func(*http.Request) (*url.URL, error)
+)
+
+// SetProxy makes the HTTP clients using Proxy send the requests through the proxies instead of the ones
+// from the environment variables, the arguments follow the semantics of HTTP_PROXY, HTTPS_PROXY and NO_PROXY.
+// It does nothing if all the arguments are empty.
+func SetProxy(httpProxy, httpsProxy, noProxy string) {
+	if httpProxy == "" && httpsProxy == "" && noProxy == "" {
+		return
+	}
+	fn := (&httpproxy.Config{
+		HTTPProxy:  httpProxy,
+		HTTPSProxy: httpsProxy,
+		NoProxy:    noProxy,
+	}).ProxyFunc()
+
+	proxyLock.Lock()
+	defer proxyLock.Unlock()
+	proxyFunc = func(req *http.Request) (*url.URL, error) {
+		return fn(req.URL)
+	}
+}
+
+// Proxy returns the proxy of the request set by SetProxy, it's used as the Proxy of http.Transport.
+// It returns nil if no proxy is set, the proxy environment variables are ignored.
+func Proxy(req *http.Request) (*url.URL, error) {
+	proxyLock.RLock()
+	defer proxyLock.RUnlock()
+	if proxyFunc == nil {
+		return nil, nil
+	}
+	return proxyFunc(req)
+}
+
+// ProxyOrFromEnvironment is the same as Proxy if a proxy is set by SetProxy, or it returns the proxy
+// from the environment variables like http.DefaultTransport, it's used by the clients that used
+// http.DefaultTransport before.
+func ProxyOrFromEnvironment(req *http.Request) (*url.URL, error) {
+	proxyLock.RLock()
+	fn := proxyFunc
+	proxyLock.RUnlock()
+	if fn == nil {
+		return http.ProxyFromEnvironment(req)
+	}
+	return fn(req)
+}
+
+// ValidateProxyURL validates the value of HTTP_PROXY or HTTPS_PROXY
+func ValidateProxyURL(proxy string) error {
+	u, err := url.Parse(proxy)
+	if err != nil {
+		return err
+	}
+	switch u.Scheme {
+	case "http", "https", "socks5":
+	default:
+		return fmt.Errorf("unsupported scheme %q, only http, https and socks5 are supported", u.Scheme)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("host is empty")
+	}
+	return nil
+}
+
+// ValidateNoProxy validates the value of NO_PROXY, a comma-separated list of hosts, domains, IPs or CIDRs
+func ValidateNoProxy(noProxy string) error {
+	for _, entry := range strings.Split(noProxy, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			return fmt.Errorf("empty entry in %q", noProxy)
+		}
+		if strings.ContainsAny(entry, " \t") || strings.Contains(entry, "://") {
+			return fmt.Errorf("invalid entry %q, it should be a host, domain, IP or CIDR", entry)
+		}
+	}
+	return nil
+}
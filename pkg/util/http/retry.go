@@ -0,0 +1,274 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httputil
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+// ErrCircuitOpen is returned without sending the request when the circuit breaker of the host is open
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// RetryPolicy is the retry, timeout and circuit breaker policy of the HTTP clients created by NewClient
+type RetryPolicy struct {
+	// MaxRetries is the max number of the retries of an idempotent request after a network error or
+	// a 502, 503 or 504 response, 0 disables the retries
+	MaxRetries int
+	// InitialBackoff is the backoff before the first retry, it's doubled for each retry up to MaxBackoff
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	// EndpointTimeouts are the timeouts of each attempt of the requests whose paths start with the keys,
+	// the longest matching key wins, the requests of the other paths use the timeout of the client
+	EndpointTimeouts map[string]time.Duration
+	// BreakerThreshold is the number of the consecutive failures of a host after which the requests to the
+	// host fail fast for BreakerCooldown, then one request is let through to probe the host, 0 disables it
+	BreakerThreshold int
+	BreakerCooldown  time.Duration
+}
+
+var (
+	retryPolicyLock sync.RWMutex
+	retryPolicy     RetryPolicy
+
+	breakersLock sync.Mutex
+	breakers     = map[string]*breaker{}
+)
+
+// SetRetryPolicy sets the policy of the HTTP clients created by NewClient, the clients created before
+// keep their overall timeouts
+func SetRetryPolicy(p RetryPolicy) {
+	retryPolicyLock.Lock()
+	defer retryPolicyLock.Unlock()
+	retryPolicy = p
+}
+
+func getRetryPolicy() RetryPolicy {
+	retryPolicyLock.RLock()
+	defer retryPolicyLock.RUnlock()
+	return retryPolicy
+}
+
+// ParseEndpointTimeouts parses the comma-separated <path prefix>=<duration> pairs
+func ParseEndpointTimeouts(s string) (map[string]time.Duration, error) {
+	if s == "" {
+		return nil, nil
+	}
+	timeouts := map[string]time.Duration{}
+	for _, pair := range strings.Split(s, ",") {
+		kv := strings.SplitN(strings.TrimSpace(pair), "=", 2)
+		if len(kv) != 2 || kv[0] == "" {
+			return nil, fmt.Errorf("invalid endpoint timeout %q, it should be <path prefix>=<duration>", pair)
+		}
+		d, err := time.ParseDuration(kv[1])
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("invalid timeout %q of endpoint %s", kv[1], kv[0])
+		}
+		timeouts[strings.TrimPrefix(kv[0], "/")] = d
+	}
+	return timeouts, nil
+}
+
+// NewClient returns an HTTP client sending the requests through the transport with the retry policy set by
+// SetRetryPolicy, timeout is the timeout of each attempt unless it's overridden by the endpoint timeouts
+func NewClient(timeout time.Duration, transport http.RoundTripper) *http.Client {
+	p := getRetryPolicy()
+	return &http.Client{
+		Timeout:   p.overallTimeout(timeout),
+		Transport: &retryRoundTripper{timeout: timeout, next: transport},
+	}
+}
+
+// overallTimeout returns the timeout of a request including all the attempts and the backoffs
+func (p RetryPolicy) overallTimeout(timeout time.Duration) time.Duration {
+	if timeout <= 0 {
+		return 0
+	}
+	attempt := timeout
+	for _, d := range p.EndpointTimeouts {
+		if d > attempt {
+			attempt = d
+		}
+	}
+	total := attempt
+	for i := 0; i < p.MaxRetries; i++ {
+		total += p.backoff(i) + attempt
+	}
+	return total
+}
+
+func (p RetryPolicy) backoff(retry int) time.Duration {
+	d := p.InitialBackoff
+	for i := 0; i < retry && d < p.MaxBackoff; i++ {
+		d *= 2
+	}
+	if p.MaxBackoff > 0 && d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+	return d
+}
+
+func (p RetryPolicy) attemptTimeout(path string, timeout time.Duration) time.Duration {
+	path = strings.TrimPrefix(path, "/")
+	matched := ""
+	for prefix, d := range p.EndpointTimeouts {
+		if strings.HasPrefix(path, prefix) && len(prefix) > len(matched) {
+			matched, timeout = prefix, d
+		}
+	}
+	return timeout
+}
+
+type retryRoundTripper struct {
+	timeout time.Duration
+	next    http.RoundTripper
+}
+
+func (rt *retryRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	p := getRetryPolicy()
+	b := getBreaker(req.URL.Host)
+	retries := 0
+	if isIdempotent(req) {
+		retries = p.MaxRetries
+	}
+
+	for attempt := 0; ; attempt++ {
+		if p.BreakerThreshold > 0 && !b.allow(p.BreakerCooldown) {
+			return nil, fmt.Errorf("%s %s: %w", req.Method, req.URL.Host, ErrCircuitOpen)
+		}
+		resp, err := rt.roundTrip(req, p.attemptTimeout(req.URL.Path, rt.timeout))
+		failed := err != nil || isRetriableStatus(resp.StatusCode)
+		if p.BreakerThreshold > 0 {
+			b.record(!failed, p.BreakerThreshold)
+		}
+		if !failed || attempt >= retries || req.Context().Err() != nil {
+			return resp, err
+		}
+
+		if resp != nil {
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		if req.GetBody != nil {
+			body, gerr := req.GetBody()
+			if gerr != nil {
+				return nil, gerr
+			}
+			req.Body = body
+		}
+		klog.V(4).Infof("retry %s %s after attempt %d failed, err: %v", req.Method, req.URL, attempt+1, err)
+		select {
+		case <-time.After(p.backoff(attempt)):
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+	}
+}
+
+// roundTrip sends the request with the timeout, which lasts until the body of the response is closed
+func (rt *retryRoundTripper) roundTrip(req *http.Request, timeout time.Duration) (*http.Response, error) {
+	if timeout <= 0 {
+		return rt.next.RoundTrip(req)
+	}
+	ctx, cancel := context.WithTimeout(req.Context(), timeout)
+	resp, err := rt.next.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	resp.Body = &cancelBody{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+type cancelBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
+// isIdempotent returns whether the request can be sent again, the body must be replayable
+func isIdempotent(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+	default:
+		return false
+	}
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
+
+func isRetriableStatus(code int) bool {
+	return code == http.StatusBadGateway || code == http.StatusServiceUnavailable || code == http.StatusGatewayTimeout
+}
+
+// breaker is the circuit breaker of a host
+type breaker struct {
+	lock     sync.Mutex
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+func getBreaker(host string) *breaker {
+	breakersLock.Lock()
+	defer breakersLock.Unlock()
+	b, ok := breakers[host]
+	if !ok {
+		b = &breaker{}
+		breakers[host] = b
+	}
+	return b
+}
+
+// allow returns whether a request can be sent, only one request is let through after the cooldown
+func (b *breaker) allow(cooldown time.Duration) bool {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if b.openedAt.IsZero() {
+		return true
+	}
+	if b.probing || time.Since(b.openedAt) < cooldown {
+		return false
+	}
+	b.probing = true
+	return true
+}
+
+func (b *breaker) record(success bool, threshold int) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if success {
+		b.failures = 0
+		b.openedAt = time.Time{}
+		b.probing = false
+		return
+	}
+	b.failures++
+	if b.probing || b.failures >= threshold {
+		b.openedAt = time.Now()
+		b.probing = false
+	}
+}
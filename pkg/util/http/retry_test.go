@@ -0,0 +1,112 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httputil
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestRetryPolicy(t *testing.T) {
+	g := NewGomegaWithT(t)
+	defer SetRetryPolicy(RetryPolicy{})
+
+	var requests int32
+	var failures int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		switch r.URL.Path {
+		case "/slow":
+			time.Sleep(200 * time.Millisecond)
+		case "/flaky":
+			if atomic.AddInt32(&failures, -1) >= 0 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	timeouts, err := ParseEndpointTimeouts("/slow=50ms")
+	g.Expect(err).To(Succeed())
+	SetRetryPolicy(RetryPolicy{MaxRetries: 2, InitialBackoff: time.Millisecond, MaxBackoff: 2 * time.Millisecond, EndpointTimeouts: timeouts})
+	client := NewClient(time.Second, http.DefaultTransport)
+
+	// the transient failures are retried
+	atomic.StoreInt32(&failures, 2)
+	body, err := GetBodyOK(client, server.URL+"/flaky")
+	g.Expect(err).To(Succeed())
+	g.Expect(string(body)).To(Equal("ok"))
+	g.Expect(atomic.LoadInt32(&requests)).To(Equal(int32(3)))
+
+	// the non-idempotent requests are not retried
+	atomic.StoreInt32(&requests, 0)
+	atomic.StoreInt32(&failures, 2)
+	_, err = PostBodyOK(client, server.URL+"/flaky", bytes.NewBufferString("{}"))
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(atomic.LoadInt32(&requests)).To(Equal(int32(1)))
+
+	// the endpoint timeout applies to each attempt
+	atomic.StoreInt32(&requests, 0)
+	_, err = GetBodyOK(client, server.URL+"/slow")
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(atomic.LoadInt32(&requests)).To(Equal(int32(3)))
+
+	_, err = ParseEndpointTimeouts("pd/api/v1/stores")
+	g.Expect(err).To(HaveOccurred())
+}
+
+func TestRetryPolicyCircuitBreaker(t *testing.T) {
+	g := NewGomegaWithT(t)
+	defer SetRetryPolicy(RetryPolicy{})
+
+	var requests int32
+	var healthy int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		if atomic.LoadInt32(&healthy) == 0 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	SetRetryPolicy(RetryPolicy{BreakerThreshold: 2, BreakerCooldown: 100 * time.Millisecond})
+	client := NewClient(time.Second, http.DefaultTransport)
+
+	for i := 0; i < 2; i++ {
+		_, err := GetBodyOK(client, server.URL)
+		g.Expect(err).To(HaveOccurred())
+	}
+	// the breaker is open, the request fails fast
+	_, err := GetBodyOK(client, server.URL)
+	g.Expect(errors.Is(err, ErrCircuitOpen)).To(BeTrue())
+	g.Expect(atomic.LoadInt32(&requests)).To(Equal(int32(2)))
+
+	// the probe after the cooldown closes the breaker
+	atomic.StoreInt32(&healthy, 1)
+	time.Sleep(100 * time.Millisecond)
+	_, err = GetBodyOK(client, server.URL)
+	g.Expect(err).To(Succeed())
+	_, err = GetBodyOK(client, server.URL)
+	g.Expect(err).To(Succeed())
+	g.Expect(atomic.LoadInt32(&requests)).To(Equal(int32(4)))
+}
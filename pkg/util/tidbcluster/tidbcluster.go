@@ -36,6 +36,53 @@ const (
 	TiFlashStoreNotUp = "TiFlashStoreNotUp"
 	// TiCDCCaptureNotReady is added when one of ticdc capture is not ready.
 	TiCDCCaptureNotReady = "TiCDCCaptureNotReady"
+	// TiProxyUnhealthy is added when one of tiproxy members is unhealthy.
+	TiProxyUnhealthy = "TiProxyUnhealthy"
+
+	// Degraded
+	// ReplicasConcentrated is added when a zone holds a majority of the replicas of some regions.
+	ReplicasConcentrated = "ReplicasConcentrated"
+	// ReplicaPlacementHealthy is added when region replicas are spread across zones.
+	ReplicaPlacementHealthy = "ReplicaPlacementHealthy"
+
+	// PDAvailable
+	// PDAPIAvailable is added when the PD API requests succeed, the reasons of the failures are
+	// the values of pdapi.APIErrorReason.
+	PDAPIAvailable = "PDAPIAvailable"
+
+	// Drifted
+	// ResourcesDrifted is added when some resources are changed by external edits and the drift is not reverted.
+	ResourcesDrifted = "ResourcesDrifted"
+	// NoDrift is added when no resource is drifted.
+	NoDrift = "NoDrift"
+
+	// QuotaExceeded
+	// PodsRejectedByQuota is added when the pods of any component are rejected by ResourceQuota or LimitRange.
+	PodsRejectedByQuota = "PodsRejectedByQuota"
+	// QuotaSufficient is added when no pod is rejected by ResourceQuota or LimitRange.
+	QuotaSufficient = "QuotaSufficient"
+
+	// AdvancedStatefulSetCompatible
+	// AdvancedStatefulSetIncompatible is added when the Advanced StatefulSet CRD or controller is incompatible
+	// with the operator, or the StatefulSets of the cluster are not managed consistently.
+	AdvancedStatefulSetIncompatible = "AdvancedStatefulSetIncompatible"
+	// AdvancedStatefulSetVerified is added when no incompatibility of Advanced StatefulSet is found.
+	AdvancedStatefulSetVerified = "AdvancedStatefulSetVerified"
+
+	// Reasons for the conditions of the components, ComponentReady also uses the reasons of Ready.
+
+	// ComponentUpgradeInProgress
+	// Upgrading is added when the pods of the component are being upgraded.
+	Upgrading = "Upgrading"
+	// NotUpgrading is added when no upgrade of the component is in progress.
+	NotUpgrading = "NotUpgrading"
+
+	// ComponentScalingBlocked
+	// ScalingStatusNotSynced is added when the component is scaling but the status of the members fails to sync,
+	// which the scaling depends on. The pods rejected by quota also block the scaling with PodsRejectedByQuota.
+	ScalingStatusNotSynced = "StatusNotSynced"
+	// ScalingNotBlocked is added when the scaling of the component is not blocked.
+	ScalingNotBlocked = "ScalingNotBlocked"
 )
 
 // NewTidbClusterCondition creates a new tidbcluster condition.
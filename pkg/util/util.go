@@ -124,6 +124,7 @@ func GetPodOrdinals(tc *v1alpha1.TidbCluster, memberType v1alpha1.MemberType) (s
 		return nil, fmt.Errorf("unknown member type %v", memberType)
 	}
 	deleteSlots := getDeleteSlots(tc, ann)
+	deleteSlots.Insert(tc.OfflineOrdinals(memberType)...)
 	maxReplicaCount, deleteSlots := helper.GetMaxReplicaCountAndDeleteSlots(replicas, deleteSlots)
 	podOrdinals := sets.NewInt32()
 	for i := int32(0); i < maxReplicaCount; i++ {
@@ -480,9 +481,21 @@ func SetPassword(ctx context.Context, db *sql.DB, password string) error {
 	return err
 }
 
+// ReloadTLS reloads the TLS certificate of the tidb server
+func ReloadTLS(ctx context.Context, db *sql.DB) error {
+	_, err := db.ExecContext(ctx, "ALTER INSTANCE RELOAD TLS")
+	return err
+}
+
 // GetDSN get tidb dsn
 func GetDSN(tc *v1alpha1.TidbCluster, password string) string {
 	port := tc.Spec.TiDB.GetServicePort()
 	return fmt.Sprintf("root:%s@tcp(%s-tidb.%s.svc:%d)/?charset=utf8mb4,utf8&multiStatements=true",
 		password, tc.Name, tc.Namespace, port)
 }
+
+// GetMemberDSN get the dsn of a tidb member
+func GetMemberDSN(tc *v1alpha1.TidbCluster, podName, password string) string {
+	return fmt.Sprintf("root:%s@tcp(%s.%s-tidb-peer.%s.svc:%d)/?charset=utf8mb4,utf8",
+		password, podName, tc.Name, tc.Namespace, tc.Spec.TiDB.GetServerPort())
+}
@@ -0,0 +1,160 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vaultapi
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pingcap/tidb-operator/pkg/metrics"
+	httputil "github.com/pingcap/tidb-operator/pkg/util/http"
+)
+
+const (
+	DefaultTimeout = 10 * time.Second
+
+	// component is the label of the metrics of the requests to Vault
+	component = "vault"
+	// tokenHeader is the header of the Vault token
+	tokenHeader = "X-Vault-Token"
+)
+
+// Client provides the APIs of HashiCorp Vault used by TiDB Operator
+type Client interface {
+	// LoginKubernetes logs in by the Kubernetes auth method with the service account token, and returns the Vault token
+	LoginKubernetes(authPath, role, jwt string) (string, error)
+	// IssueCertificate issues a certificate by the role of the PKI secrets engine
+	IssueCertificate(token, pkiPath, role string, req *IssueRequest) (*Certificate, error)
+}
+
+// IssueRequest is the request to issue a certificate from the PKI secrets engine
+type IssueRequest struct {
+	CommonName string `json:"common_name"`
+	// AltNames is the comma-separated DNS subject alternative names
+	AltNames string `json:"alt_names,omitempty"`
+	// IPSANs is the comma-separated IP subject alternative names
+	IPSANs string `json:"ip_sans,omitempty"`
+	// TTL is the requested lifetime of the certificate, e.g. 720h
+	TTL string `json:"ttl,omitempty"`
+}
+
+// Certificate is a certificate issued by the PKI secrets engine, all in PEM
+type Certificate struct {
+	Certificate string   `json:"certificate"`
+	PrivateKey  string   `json:"private_key"`
+	IssuingCA   string   `json:"issuing_ca"`
+	CAChain     []string `json:"ca_chain,omitempty"`
+}
+
+// CA returns the CA certificates to verify the certificate, it's the CA chain if Vault returns one
+func (c *Certificate) CA() string {
+	if len(c.CAChain) == 0 {
+		return c.IssuingCA
+	}
+	return strings.Join(c.CAChain, "\n")
+}
+
+type loginResponse struct {
+	Auth *struct {
+		ClientToken string `json:"client_token"`
+	} `json:"auth"`
+}
+
+type issueResponse struct {
+	Data *Certificate `json:"data"`
+}
+
+type errorResponse struct {
+	Errors []string `json:"errors"`
+}
+
+// vaultClient is the default implementation of Client
+type vaultClient struct {
+	url        string
+	httpClient *http.Client
+}
+
+// NewClient returns a new Client of the Vault server at the address
+func NewClient(address string, timeout time.Duration, tlsConfig *tls.Config) Client {
+	return &vaultClient{
+		url: strings.TrimSuffix(address, "/"),
+		httpClient: httputil.NewClient(timeout, metrics.InstrumentRoundTripper(component,
+			&http.Transport{TLSClientConfig: tlsConfig, Proxy: httputil.Proxy})),
+	}
+}
+
+func (c *vaultClient) LoginKubernetes(authPath, role, jwt string) (string, error) {
+	apiURL := fmt.Sprintf("%s/v1/auth/%s/login", c.url, strings.Trim(authPath, "/"))
+	resp := &loginResponse{}
+	if err := c.post(apiURL, "", map[string]string{"role": role, "jwt": jwt}, resp); err != nil {
+		return "", err
+	}
+	if resp.Auth == nil || resp.Auth.ClientToken == "" {
+		return "", fmt.Errorf("no client token in the response of %s", apiURL)
+	}
+	return resp.Auth.ClientToken, nil
+}
+
+func (c *vaultClient) IssueCertificate(token, pkiPath, role string, req *IssueRequest) (*Certificate, error) {
+	apiURL := fmt.Sprintf("%s/v1/%s/issue/%s", c.url, strings.Trim(pkiPath, "/"), role)
+	resp := &issueResponse{}
+	if err := c.post(apiURL, token, req, resp); err != nil {
+		return nil, err
+	}
+	if resp.Data == nil || resp.Data.Certificate == "" || resp.Data.PrivateKey == "" {
+		return nil, fmt.Errorf("no certificate in the response of %s", apiURL)
+	}
+	return resp.Data, nil
+}
+
+func (c *vaultClient) post(apiURL, token string, data, result interface{}) error {
+	reqBody, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, apiURL, bytes.NewReader(reqBody))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set(tokenHeader, token)
+	}
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer httputil.DeferClose(res.Body)
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+	if res.StatusCode >= 400 {
+		errResp := &errorResponse{}
+		if err := json.Unmarshal(body, errResp); err == nil && len(errResp.Errors) > 0 {
+			return fmt.Errorf("error response %d URL %s: %s", res.StatusCode, apiURL, strings.Join(errResp.Errors, "; "))
+		}
+		return fmt.Errorf("error response %d URL %s: %s", res.StatusCode, apiURL, string(body))
+	}
+	if err := json.Unmarshal(body, result); err != nil {
+		return fmt.Errorf("failed to unmarshal the response of %s: %v", apiURL, err)
+	}
+	return nil
+}
@@ -0,0 +1,195 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pod
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/openshift/generic-admission-server/pkg/apiserver"
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/client/clientset/versioned"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/pdapi"
+	"github.com/pingcap/tidb-operator/pkg/webhook/util"
+	admission "k8s.io/api/admission/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/klog/v2"
+)
+
+// PDEvictionAdmissionControl validates the evictions of the PD pods, e.g. the evictions issued by
+// `kubectl drain` during the upgrade of the nodes. An eviction is rejected if it would break the
+// quorum of PD, and if the PD pod is the leader, the leader is transferred to another healthy
+// member first and the eviction is rejected until the transfer is done.
+type PDEvictionAdmissionControl struct {
+	lock        sync.RWMutex
+	initialized bool
+	// kubernetes client interface
+	kubeCli kubernetes.Interface
+	// operator client interface
+	operatorCli versioned.Interface
+	pdControl   pdapi.PDControlInterface
+}
+
+var _ apiserver.ValidatingAdmissionHook = &PDEvictionAdmissionControl{}
+
+func NewPDEvictionAdmissionControl() *PDEvictionAdmissionControl {
+	return &PDEvictionAdmissionControl{}
+}
+
+func (pc *PDEvictionAdmissionControl) ValidatingResource() (plural schema.GroupVersionResource, singular string) {
+	return schema.GroupVersionResource{
+			Group:    "admission.tidb.pingcap.com",
+			Version:  "v1alpha1",
+			Resource: "pdevictionvalidations",
+		},
+		"pdevictionvalidation"
+}
+
+func (pc *PDEvictionAdmissionControl) Validate(ar *admission.AdmissionRequest) *admission.AdmissionResponse {
+	pc.lock.RLock()
+	defer pc.lock.RUnlock()
+	if !pc.initialized {
+		return &admission.AdmissionResponse{
+			Allowed: false,
+		}
+	}
+
+	if ar.Operation != admission.Create || ar.SubResource != "eviction" {
+		return util.ARSuccess()
+	}
+
+	name := ar.Name
+	namespace := ar.Namespace
+	pod, err := pc.kubeCli.CoreV1().Pods(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		return util.ARSuccess()
+	}
+	if err != nil {
+		err = fmt.Errorf("get pod %s/%s failed, err: %v", namespace, name, err)
+		klog.Error(err)
+		return util.ARFail(err)
+	}
+
+	l := label.Label(pod.Labels)
+	if !l.IsPD() || !l.IsManagedByTiDBOperator() || pod.DeletionTimestamp != nil {
+		return util.ARSuccess()
+	}
+
+	tcName := l[label.InstanceLabelKey]
+	tc, err := pc.operatorCli.PingcapV1alpha1().TidbClusters(namespace).Get(context.TODO(), tcName, metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		return util.ARSuccess()
+	}
+	if err != nil {
+		err = fmt.Errorf("get tidbcluster %s/%s failed, pod %s, err: %v", namespace, tcName, name, err)
+		klog.Error(err)
+		return util.ARFail(err)
+	}
+	if tc.DeletionTimestamp != nil {
+		return util.ARSuccess()
+	}
+
+	// the leader is not transferred in a dry run request
+	dryRun := ar.DryRun != nil && *ar.DryRun
+	if err := checkPDEviction(controller.GetPDClient(pc.pdControl, tc), name, !dryRun); err != nil {
+		klog.Infof("eviction of pd pod %s/%s is rejected: %v", namespace, name, err)
+		return util.ARFail(err)
+	}
+	klog.Infof("admit eviction of pd pod %s/%s", namespace, name)
+	return util.ARSuccess()
+}
+
+// Initialize implements AdmissionHook.Initialize interface. It's is called as
+// a post-start hook.
+func (pc *PDEvictionAdmissionControl) Initialize(cfg *rest.Config, stopCh <-chan struct{}) error {
+	pc.lock.Lock()
+	defer pc.lock.Unlock()
+
+	kubeCli, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return err
+	}
+	cli, err := versioned.NewForConfig(cfg)
+	if err != nil {
+		return err
+	}
+
+	pc.kubeCli = kubeCli
+	pc.operatorCli = cli
+	pc.pdControl = pdapi.NewDefaultPDControlByCli(kubeCli)
+
+	pc.initialized = true
+	return nil
+}
+
+// checkPDEviction returns an error if the eviction of the PD pod would break the quorum, or the PD pod is
+// the leader. The leader is transferred to another healthy member in the latter case if transfer is true.
+func checkPDEviction(pdClient pdapi.PDClient, podName string, transfer bool) error {
+	healthInfo, err := pdClient.GetHealth()
+	if err != nil {
+		return fmt.Errorf("failed to get the health of pd members, err: %v", err)
+	}
+
+	var healthy int
+	var podHealthy bool
+	var transferee string
+	for _, member := range healthInfo.Healths {
+		if !member.Health {
+			continue
+		}
+		healthy++
+		if isPodMember(member.Name, podName) {
+			podHealthy = true
+		} else if transferee == "" {
+			transferee = member.Name
+		}
+	}
+	if podHealthy {
+		quorum := len(healthInfo.Healths)/2 + 1
+		if healthy-1 < quorum {
+			return fmt.Errorf("evicting pd pod %s would break the quorum, healthy members %d, quorum %d", podName, healthy, quorum)
+		}
+	}
+
+	leader, err := pdClient.GetPDLeader()
+	if err != nil {
+		return fmt.Errorf("failed to get the pd leader, err: %v", err)
+	}
+	if leader == nil || !isPodMember(leader.GetName(), podName) {
+		return nil
+	}
+	if transferee == "" {
+		return fmt.Errorf("pd pod %s is the leader and there is no healthy member to transfer the leader to", podName)
+	}
+	if !transfer {
+		return fmt.Errorf("pd pod %s is the leader", podName)
+	}
+	if err := pdClient.TransferPDLeader(transferee); err != nil {
+		return fmt.Errorf("pd pod %s is the leader, failed to transfer the leader to %s, err: %v", podName, transferee, err)
+	}
+	return fmt.Errorf("pd pod %s is the leader, the leader is being transferred to %s, retry the eviction later", podName, transferee)
+}
+
+// isPodMember returns whether the PD member is the pod, the member name is the FQDN of the pod
+// if the cluster domain is set.
+func isPodMember(memberName, podName string) bool {
+	return memberName == podName || strings.HasPrefix(memberName, podName+".")
+}
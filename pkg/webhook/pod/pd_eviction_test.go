@@ -0,0 +1,110 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pod
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/pingcap/kvproto/pkg/pdpb"
+	"github.com/pingcap/tidb-operator/pkg/pdapi"
+)
+
+func TestCheckPDEviction(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tests := []struct {
+		name           string
+		healths        []pdapi.MemberHealth
+		leader         string
+		expectErr      bool
+		expectTransfer string
+	}{
+		{
+			name: "follower is evicted",
+			healths: []pdapi.MemberHealth{
+				{Name: "test-pd-0", Health: true},
+				{Name: "test-pd-1", Health: true},
+				{Name: "test-pd-2", Health: true},
+			},
+			leader: "test-pd-0",
+		},
+		{
+			name: "quorum is broken",
+			healths: []pdapi.MemberHealth{
+				{Name: "test-pd-0", Health: true},
+				{Name: "test-pd-1", Health: true},
+				{Name: "test-pd-2", Health: false},
+			},
+			leader:    "test-pd-0",
+			expectErr: true,
+		},
+		{
+			name: "unhealthy member is evicted",
+			healths: []pdapi.MemberHealth{
+				{Name: "test-pd-0", Health: true},
+				{Name: "test-pd-1", Health: false},
+				{Name: "test-pd-2", Health: true},
+			},
+			leader: "test-pd-0",
+		},
+		{
+			name: "leader is evicted",
+			healths: []pdapi.MemberHealth{
+				{Name: "test-pd-0", Health: true},
+				{Name: "test-pd-1", Health: true},
+				{Name: "test-pd-2", Health: true},
+			},
+			leader:         "test-pd-1",
+			expectErr:      true,
+			expectTransfer: "test-pd-0",
+		},
+		{
+			name: "leader with cluster domain is evicted",
+			healths: []pdapi.MemberHealth{
+				{Name: "test-pd-1.test-pd-peer.default.svc.cluster.local", Health: true},
+				{Name: "test-pd-2.test-pd-peer.default.svc.cluster.local", Health: true},
+				{Name: "test-pd-3.test-pd-peer.default.svc.cluster.local", Health: true},
+			},
+			leader:         "test-pd-1.test-pd-peer.default.svc.cluster.local",
+			expectErr:      true,
+			expectTransfer: "test-pd-2.test-pd-peer.default.svc.cluster.local",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pdClient := pdapi.NewFakePDClient()
+			pdClient.AddReaction(pdapi.GetHealthActionType, func(action *pdapi.Action) (interface{}, error) {
+				return &pdapi.HealthInfo{Healths: tt.healths}, nil
+			})
+			pdClient.AddReaction(pdapi.GetPDLeaderActionType, func(action *pdapi.Action) (interface{}, error) {
+				return &pdpb.Member{Name: tt.leader}, nil
+			})
+			var transferee string
+			pdClient.AddReaction(pdapi.TransferPDLeaderActionType, func(action *pdapi.Action) (interface{}, error) {
+				transferee = action.Name
+				return nil, nil
+			})
+
+			err := checkPDEviction(pdClient, "test-pd-1", true)
+			if tt.expectErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).To(Succeed())
+			}
+			g.Expect(transferee).To(Equal(tt.expectTransfer))
+		})
+	}
+}
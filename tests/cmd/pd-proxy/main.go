@@ -0,0 +1,66 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"flag"
+	"net/http"
+
+	"github.com/pingcap/tidb-operator/tests/pkg/pdproxy"
+	"k8s.io/apiserver/pkg/server/healthz"
+	"k8s.io/kubernetes/test/e2e/framework/log"
+)
+
+var (
+	listen      string
+	adminListen string
+	upstream    string
+	scenario    string
+)
+
+func init() {
+	flag.StringVar(&listen, "listen", ":2379", "The address that the proxy of PD listens on")
+	flag.StringVar(&adminListen, "admin-listen", ":8080", "The address that the admin service of the proxy listens on")
+	flag.StringVar(&upstream, "upstream", "http://127.0.0.1:2379", "The URL of PD that the requests are forwarded to")
+	flag.StringVar(&scenario, "scenario", "", "The JSON file of the scenario which is run after the proxy starts")
+}
+
+func main() {
+	flag.Parse()
+
+	p, err := pdproxy.NewProxy(upstream)
+	if err != nil {
+		log.Failf(err.Error())
+	}
+	if scenario != "" {
+		s, err := pdproxy.LoadScenario(scenario)
+		if err != nil {
+			log.Failf(err.Error())
+		}
+		p.RunScenario(*s)
+	}
+
+	go func() {
+		mux := http.NewServeMux()
+		mux.Handle("/", p.AdminHandler())
+		healthz.InstallHandler(mux)
+		if err := http.ListenAndServe(adminListen, mux); err != nil {
+			log.Failf(err.Error())
+		}
+	}()
+	log.Logf("pd proxy listens on %s, forwards to %s", listen, upstream)
+	if err := http.ListenAndServe(listen, p); err != nil {
+		log.Failf(err.Error())
+	}
+}
@@ -46,6 +46,8 @@ type Config struct {
 	OperatorTag          string          `yaml:"operator_tag" json:"operator_tag"`
 	OperatorImage        string          `yaml:"operator_image" json:"operator_image"`
 	BackupImage          string          `yaml:"backup_image" json:"backup_image"`
+	FailpointTiDBImage   string          `yaml:"failpoint_tidb_image" json:"failpoint_tidb_image"`
+	FailpointTiKVImage   string          `yaml:"failpoint_tikv_image" json:"failpoint_tikv_image"`
 	OperatorFeatures     map[string]bool `yaml:"operator_features" json:"operator_features"`
 	UpgradeOperatorTag   string          `yaml:"upgrade_operator_tag" json:"upgrade_operator_tag"`
 	UpgradeOperatorImage string          `yaml:"upgrade_operator_image" json:"upgrade_operator_image"`
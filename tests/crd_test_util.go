@@ -28,6 +28,7 @@ import (
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
 	"github.com/pingcap/tidb-operator/pkg/client/clientset/versioned"
 	"github.com/pingcap/tidb-operator/pkg/controller"
+	utiltidbcluster "github.com/pingcap/tidb-operator/pkg/util/tidbcluster"
 	utilstatefulset "github.com/pingcap/tidb-operator/tests/e2e/util/statefulset"
 	"github.com/pingcap/tidb-operator/tests/slack"
 	corev1 "k8s.io/api/core/v1"
@@ -117,6 +118,10 @@ func (ctu *CrdTestUtil) CheckDisasterTolerance(cluster *v1alpha1.TidbCluster) er
 	if err != nil {
 		return err
 	}
+	err = ctu.checkPodsSpread(pds.Items)
+	if err != nil {
+		return err
+	}
 
 	tikvs, err := ctu.kubeCli.CoreV1().Pods(cluster.Namespace).List(context.TODO(),
 		metav1.ListOptions{LabelSelector: labels.SelectorFromSet(
@@ -129,6 +134,10 @@ func (ctu *CrdTestUtil) CheckDisasterTolerance(cluster *v1alpha1.TidbCluster) er
 	if err != nil {
 		return err
 	}
+	err = ctu.checkPodsSpread(tikvs.Items)
+	if err != nil {
+		return err
+	}
 
 	tidbs, err := ctu.kubeCli.CoreV1().Pods(cluster.Namespace).List(context.TODO(),
 		metav1.ListOptions{LabelSelector: labels.SelectorFromSet(
@@ -137,11 +146,39 @@ func (ctu *CrdTestUtil) CheckDisasterTolerance(cluster *v1alpha1.TidbCluster) er
 	if err != nil {
 		return err
 	}
-	return checkPodsAffinity(tidbs.Items)
+	err = checkPodsAffinity(tidbs.Items)
+	if err != nil {
+		return err
+	}
+	err = ctu.checkPodsSpread(tidbs.Items)
+	if err != nil {
+		return err
+	}
+
+	return ctu.checkReplicaPlacement(cluster)
+}
+
+// checkReplicaPlacement verifies that PD reports the region replicas are not
+// concentrated in a single zone, which is verified by the operator and
+// surfaced as the Degraded condition of the TidbCluster.
+func (ctu *CrdTestUtil) checkReplicaPlacement(cluster *v1alpha1.TidbCluster) error {
+	tc, err := ctu.cli.PingcapV1alpha1().TidbClusters(cluster.Namespace).Get(context.TODO(), cluster.Name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	cond := utiltidbcluster.GetTidbClusterCondition(tc.Status, v1alpha1.TidbClusterDegraded)
+	if cond != nil && cond.Status == corev1.ConditionTrue {
+		return fmt.Errorf("the tidbcluster:[%s/%s] is degraded: %s", tc.Namespace, tc.Name, cond.Message)
+	}
+	return nil
 }
 
 func checkPodsAffinity(allPods []corev1.Pod) error {
 	for _, pod := range allPods {
+		// the pods are spread by the topology spread constraints instead, see checkPodsSpread
+		if len(pod.Spec.TopologySpreadConstraints) > 0 {
+			continue
+		}
 		if pod.Spec.Affinity == nil {
 			return fmt.Errorf("the pod:[%s/%s] has not Affinity", pod.Namespace, pod.Name)
 		}
@@ -160,6 +197,60 @@ func checkPodsAffinity(allPods []corev1.Pod) error {
 	return nil
 }
 
+// checkPodsSpread verifies that the scheduled pods of a component satisfy their DoNotSchedule topology
+// spread constraints, i.e. the difference between the numbers of the pods in any two topology domains
+// of the nodes is not greater than maxSkew.
+func (ctu *CrdTestUtil) checkPodsSpread(allPods []corev1.Pod) error {
+	if len(allPods) == 0 || len(allPods[0].Spec.TopologySpreadConstraints) == 0 {
+		return nil
+	}
+	nodes, err := ctu.kubeCli.CoreV1().Nodes().List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	nodeLabels := map[string]map[string]string{}
+	for _, node := range nodes.Items {
+		nodeLabels[node.Name] = node.Labels
+	}
+
+	for _, constraint := range allPods[0].Spec.TopologySpreadConstraints {
+		if constraint.WhenUnsatisfiable != corev1.DoNotSchedule {
+			continue
+		}
+		// all the domains of the nodes count, including the ones without pods
+		domains := map[string]int{}
+		for _, labels := range nodeLabels {
+			if domain, ok := labels[constraint.TopologyKey]; ok {
+				domains[domain] = 0
+			}
+		}
+		for _, pod := range allPods {
+			if pod.Spec.NodeName == "" {
+				continue
+			}
+			domain, ok := nodeLabels[pod.Spec.NodeName][constraint.TopologyKey]
+			if !ok {
+				return fmt.Errorf("the node %s of the pod:[%s/%s] has no label %s", pod.Spec.NodeName, pod.Namespace, pod.Name, constraint.TopologyKey)
+			}
+			domains[domain]++
+		}
+		min, max := -1, 0
+		for _, count := range domains {
+			if min < 0 || count < min {
+				min = count
+			}
+			if count > max {
+				max = count
+			}
+		}
+		if int32(max-min) > constraint.MaxSkew {
+			return fmt.Errorf("the pods:[%s/%s] are not spread by %s, the skew %d is greater than %d, pods per domain: %v",
+				allPods[0].Namespace, allPods[0].Labels[label.ComponentLabelKey], constraint.TopologyKey, max-min, constraint.MaxSkew, domains)
+		}
+	}
+	return nil
+}
+
 func (ctu *CrdTestUtil) DeleteTidbClusterOrDie(tc *v1alpha1.TidbCluster) {
 	err := ctu.cli.PingcapV1alpha1().TidbClusters(tc.Namespace).Delete(context.TODO(), tc.Name, metav1.DeleteOptions{})
 	if err != nil {
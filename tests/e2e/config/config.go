@@ -48,6 +48,8 @@ func RegisterTiDBOperatorFlags(flags *flag.FlagSet) {
 	flags.StringVar(&TestConfig.ChartDir, "chart-dir", "", "chart dir")
 	flags.BoolVar(&TestConfig.PreloadImages, "preload-images", false, "if set, preload images in the bootstrap of e2e process")
 	flags.StringVar(&TestConfig.BackupImage, "backup-image", "", "backup image")
+	flags.StringVar(&TestConfig.FailpointTiDBImage, "failpoint-tidb-image", "", "tidb image built with failpoints enabled, the failpoint tests are skipped if it's empty")
+	flags.StringVar(&TestConfig.FailpointTiKVImage, "failpoint-tikv-image", "", "tikv image built with failpoints enabled, the failpoint tests are skipped if it's empty")
 	flags.BoolVar(&TestConfig.OperatorKiller.Enabled, "operator-killer", false, "whether to enable operator kill")
 	flags.DurationVar(&TestConfig.OperatorKiller.Interval, "operator-killer-interval", 5*time.Minute, "interval between operator kills")
 	flags.Float64Var(&TestConfig.OperatorKiller.JitterFactor, "operator-killer-jitter-factor", 1, "factor used to jitter operator kills")
@@ -60,6 +60,7 @@ import (
 	e2eframework "github.com/pingcap/tidb-operator/tests/e2e/framework"
 	remotewrite "github.com/pingcap/tidb-operator/tests/e2e/remotewrite"
 	utile2e "github.com/pingcap/tidb-operator/tests/e2e/util"
+	"github.com/pingcap/tidb-operator/tests/e2e/util/failpoint"
 	utilginkgo "github.com/pingcap/tidb-operator/tests/e2e/util/ginkgo"
 	utilimage "github.com/pingcap/tidb-operator/tests/e2e/util/image"
 	utilpod "github.com/pingcap/tidb-operator/tests/e2e/util/pod"
@@ -660,6 +661,56 @@ var _ = ginkgo.Describe("TiDBCluster", func() {
 		framework.ExpectNoError(err, "failed to wait for tikv upgraded: %q", tc.Name)
 	})
 
+	ginkgo.Context("[Feature: Failpoint]", func() {
+		ginkgo.It("should toggle the failpoints of TiDB and TiKV", func() {
+			if cfg.FailpointTiDBImage == "" || cfg.FailpointTiKVImage == "" {
+				e2eskipper.Skipf("the images built with failpoints enabled are not specified, skipping")
+			}
+
+			ginkgo.By("Deploy tc with the failpoint-enabled images")
+			tcName := "failpoint"
+			tc := fixture.GetTidbCluster(ns, tcName, utilimage.TiDBLatest)
+			tc.Spec.PD.Replicas = 1
+			tc.Spec.TiKV.Replicas = 1
+			tc.Spec.TiDB.Replicas = 1
+			failpoint.UseImages(tc, cfg.FailpointTiDBImage, cfg.FailpointTiKVImage)
+			utiltc.MustCreateTCWithComponentsReady(genericCli, oa, tc, 30*time.Minute, 5*time.Second)
+
+			fpCli := failpoint.NewClient(fw)
+			tikvPod := fmt.Sprintf("%s-tikv-0", tcName)
+			tidbPod := fmt.Sprintf("%s-tidb-0", tcName)
+
+			ginkgo.By("Slow down the apply of TiKV")
+			err := fpCli.Enable(ns, tikvPod, v1alpha1.TiKVMemberType, failpoint.TiKVSlowApply, failpoint.Sleep(100*time.Millisecond))
+			framework.ExpectNoError(err, "failed to enable failpoint of pod %s/%s", ns, tikvPod)
+			failpoints, err := fpCli.List(ns, tikvPod, v1alpha1.TiKVMemberType)
+			framework.ExpectNoError(err, "failed to list failpoints of pod %s/%s", ns, tikvPod)
+			framework.ExpectEqual(failpoints[failpoint.TiKVSlowApply], "sleep(100)")
+
+			ginkgo.By("Check the tc is still ready with the slow apply")
+			err = utiltc.WaitForTCConditionReady(cli, ns, tcName, 5*time.Minute, time.Minute)
+			framework.ExpectNoError(err, "tc %s/%s is not ready with the slow apply", ns, tcName)
+
+			ginkgo.By("Disable the failpoint of TiKV")
+			err = fpCli.Disable(ns, tikvPod, v1alpha1.TiKVMemberType, failpoint.TiKVSlowApply)
+			framework.ExpectNoError(err, "failed to disable failpoint of pod %s/%s", ns, tikvPod)
+			failpoints, err = fpCli.List(ns, tikvPod, v1alpha1.TiKVMemberType)
+			framework.ExpectNoError(err, "failed to list failpoints of pod %s/%s", ns, tikvPod)
+			_, ok := failpoints[failpoint.TiKVSlowApply]
+			framework.ExpectEqual(ok, false, "failpoint %s is not disabled", failpoint.TiKVSlowApply)
+
+			ginkgo.By("Fail the snapshots of TiKV once")
+			err = fpCli.Enable(ns, tikvPod, v1alpha1.TiKVMemberType, failpoint.TiKVSnapshotError, failpoint.Times(1, failpoint.Return("")))
+			framework.ExpectNoError(err, "failed to enable failpoint of pod %s/%s", ns, tikvPod)
+			err = fpCli.Disable(ns, tikvPod, v1alpha1.TiKVMemberType, failpoint.TiKVSnapshotError)
+			framework.ExpectNoError(err, "failed to disable failpoint of pod %s/%s", ns, tikvPod)
+
+			ginkgo.By("Check the failpoints of TiDB are served")
+			_, err = fpCli.List(ns, tidbPod, v1alpha1.TiDBMemberType)
+			framework.ExpectNoError(err, "failed to list failpoints of pod %s/%s", ns, tidbPod)
+		})
+	})
+
 	ginkgo.Context("[Feature: AutoFailover]", func() {
 		// TODO: explain purpose of this case
 		ginkgo.It("should clear TiDB failureMembers when scale TiDB to zero", func() {
@@ -822,8 +873,8 @@ var _ = ginkgo.Describe("TiDBCluster", func() {
 			err = controller.GuaranteedUpdate(genericCli, tc, func() error {
 				tc.Spec.TiKV.RecoverFailover = false
 				tc.Spec.TiFlash.RecoverFailover = false
-				tc.Spec.TiKV.Failover = &v1alpha1.Failover{RecoverByUID: tc.Status.TiKV.FailoverUID}
-				tc.Spec.TiFlash.Failover = &v1alpha1.Failover{RecoverByUID: tc.Status.TiFlash.FailoverUID}
+				tc.Spec.TiKV.Failover = &v1alpha1.StoreFailover{Failover: v1alpha1.Failover{RecoverByUID: tc.Status.TiKV.FailoverUID}}
+				tc.Spec.TiFlash.Failover = &v1alpha1.StoreFailover{Failover: v1alpha1.Failover{RecoverByUID: tc.Status.TiFlash.FailoverUID}}
 				return nil
 			})
 			framework.ExpectNoError(err, "failed to update failover.recoverByUID of tikv and tiflash")
@@ -958,8 +1009,8 @@ var _ = ginkgo.Describe("TiDBCluster", func() {
 			err = controller.GuaranteedUpdate(genericCli, tc, func() error {
 				tc.Spec.TiKV.RecoverFailover = false
 				tc.Spec.TiFlash.RecoverFailover = false
-				tc.Spec.TiKV.Failover = &v1alpha1.Failover{RecoverByUID: "11111111-1111-1111-1111-111111111111"}
-				tc.Spec.TiFlash.Failover = &v1alpha1.Failover{RecoverByUID: "11111111-1111-1111-1111-111111111111"}
+				tc.Spec.TiKV.Failover = &v1alpha1.StoreFailover{Failover: v1alpha1.Failover{RecoverByUID: "11111111-1111-1111-1111-111111111111"}}
+				tc.Spec.TiFlash.Failover = &v1alpha1.StoreFailover{Failover: v1alpha1.Failover{RecoverByUID: "11111111-1111-1111-1111-111111111111"}}
 				return nil
 			})
 			framework.ExpectNoError(err, "failed to update TiKV wrong failover.recoverByUID")
@@ -0,0 +1,193 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package failpoint deploys the TiDB and TiKV images built with failpoints enabled and toggles the failpoints
+// over the status API of the components, so that the edge cases can be covered deterministically by e2e.
+//
+// TiDB serves the failpoints of github.com/pingcap/failpoint at /fail/ of the status port, TiKV built with the
+// failpoints feature serves the failpoints of fail-rs at /fail/ of the status port.
+package failpoint
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/tests/e2e/util/portforward"
+)
+
+// The failpoints of TiKV for the common edge cases
+const (
+	// TiKVSlowApply is run into before the write commands are applied, e.g. Sleep(time.Second) slows down the apply
+	TiKVSlowApply = "on_apply_write_cmd"
+	// TiKVSnapshotError fails the snapshots of the reads, which makes the regions unavailable to read
+	TiKVSnapshotError = "raftkv_async_snapshot_err"
+)
+
+const (
+	// Pause pauses the goroutine or thread running into the failpoint until the failpoint is disabled
+	Pause = "pause"
+	// Panic panics when the failpoint is run into
+	Panic = "panic"
+	// Off turns off the failpoint
+	Off = "off"
+)
+
+// Sleep returns the term which sleeps for d when the failpoint is run into
+func Sleep(d time.Duration) string {
+	return fmt.Sprintf("sleep(%d)", d.Milliseconds())
+}
+
+// Return returns the term which makes the failpoint return the value, e.g. an injected error, the failpoint
+// returns without a value if the value is empty
+func Return(value string) string {
+	if value == "" {
+		return "return"
+	}
+	return fmt.Sprintf("return(%s)", value)
+}
+
+// Times returns the term which is only run into for n times
+func Times(n int, term string) string {
+	return fmt.Sprintf("%d*%s", n, term)
+}
+
+// UseImages replaces the images of TiDB and TiKV of the cluster with the images built with failpoints enabled,
+// the components whose images are empty are kept.
+func UseImages(tc *v1alpha1.TidbCluster, tidbImage, tikvImage string) {
+	if tc.Spec.TiDB != nil && tidbImage != "" {
+		tc.Spec.TiDB.BaseImage, tc.Spec.TiDB.Version = splitImage(tidbImage)
+	}
+	if tc.Spec.TiKV != nil && tikvImage != "" {
+		tc.Spec.TiKV.BaseImage, tc.Spec.TiKV.Version = splitImage(tikvImage)
+	}
+}
+
+// splitImage splits the image to the base image and the version of the component, the version is nil if the
+// image isn't tagged
+func splitImage(image string) (string, *string) {
+	i := strings.LastIndex(image, ":")
+	if i < 0 || strings.Contains(image[i:], "/") {
+		return image, nil
+	}
+	version := image[i+1:]
+	return image[:i], &version
+}
+
+// Client toggles the failpoints of the pods of TiDB and TiKV by forwarding the status ports of the pods
+type Client struct {
+	fw portforward.PortForward
+}
+
+// NewClient returns a client forwarding the ports by fw
+func NewClient(fw portforward.PortForward) *Client {
+	return &Client{fw: fw}
+}
+
+// Enable enables the failpoint of the pod with the term, e.g. Sleep(time.Second)
+func (c *Client) Enable(ns, podName string, memberType v1alpha1.MemberType, name, term string) error {
+	return c.do(ns, podName, memberType, func(addr string) error {
+		return Enable(addr, name, term)
+	})
+}
+
+// Disable disables the failpoint of the pod
+func (c *Client) Disable(ns, podName string, memberType v1alpha1.MemberType, name string) error {
+	return c.do(ns, podName, memberType, func(addr string) error {
+		return Disable(addr, name)
+	})
+}
+
+// List returns the enabled failpoints of the pod and their terms
+func (c *Client) List(ns, podName string, memberType v1alpha1.MemberType) (map[string]string, error) {
+	var failpoints map[string]string
+	err := c.do(ns, podName, memberType, func(addr string) error {
+		var err error
+		failpoints, err = List(addr)
+		return err
+	})
+	return failpoints, err
+}
+
+func (c *Client) do(ns, podName string, memberType v1alpha1.MemberType, fn func(addr string) error) error {
+	var port int32
+	switch memberType {
+	case v1alpha1.TiDBMemberType:
+		port = v1alpha1.DefaultTiDBStatusPort
+	case v1alpha1.TiKVMemberType:
+		port = v1alpha1.DefaultTiKVStatusPort
+	default:
+		return fmt.Errorf("failpoints of %s are not supported", memberType)
+	}
+	host, localPort, cancel, err := portforward.ForwardOnePort(c.fw, ns, fmt.Sprintf("pod/%s", podName), uint16(port))
+	if err != nil {
+		return fmt.Errorf("forward port %d of pod %s/%s failed: %v", port, ns, podName, err)
+	}
+	defer cancel()
+	return fn(fmt.Sprintf("%s:%d", host, localPort))
+}
+
+// Enable enables the failpoint of the component served at addr with the term
+func Enable(addr, name, term string) error {
+	return request(http.MethodPut, fmt.Sprintf("http://%s/fail/%s", addr, name), term)
+}
+
+// Disable disables the failpoint of the component served at addr
+func Disable(addr, name string) error {
+	return request(http.MethodDelete, fmt.Sprintf("http://%s/fail/%s", addr, name), "")
+}
+
+// List returns the enabled failpoints of the component served at addr and their terms
+func List(addr string) (map[string]string, error) {
+	httpResp, err := http.Get(fmt.Sprintf("http://%s/fail/", addr))
+	if err != nil {
+		return nil, err
+	}
+	defer httpResp.Body.Close()
+	if httpResp.StatusCode != http.StatusOK {
+		data, _ := io.ReadAll(httpResp.Body)
+		return nil, fmt.Errorf("code %s msg %s", httpResp.Status, string(data))
+	}
+
+	failpoints := map[string]string{}
+	scanner := bufio.NewScanner(httpResp.Body)
+	for scanner.Scan() {
+		name, term, ok := strings.Cut(strings.TrimSpace(scanner.Text()), "=")
+		if !ok {
+			continue
+		}
+		failpoints[name] = term
+	}
+	return failpoints, scanner.Err()
+}
+
+func request(method, url, body string) error {
+	httpReq, err := http.NewRequest(method, url, strings.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("create req failed %s", err)
+	}
+	httpResp, err := http.DefaultClient.Do(httpReq)
+	if err != nil {
+		return err
+	}
+	defer httpResp.Body.Close()
+	if httpResp.StatusCode < http.StatusOK || httpResp.StatusCode >= http.StatusBadRequest {
+		data, _ := io.ReadAll(httpResp.Body)
+		return fmt.Errorf("code %s msg %s", httpResp.Status, string(data))
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package failpoint
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+)
+
+// fakeFailpointServer serves the failpoints in the same way as the status API of TiDB and TiKV
+type fakeFailpointServer struct {
+	sync.Mutex
+	failpoints map[string]string
+}
+
+func (s *fakeFailpointServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.Lock()
+	defer s.Unlock()
+	name := strings.TrimPrefix(r.URL.Path, "/fail/")
+	switch r.Method {
+	case http.MethodGet:
+		var lines []string
+		for name, term := range s.failpoints {
+			lines = append(lines, fmt.Sprintf("%s=%s", name, term))
+		}
+		sort.Strings(lines)
+		fmt.Fprint(w, strings.Join(lines, "\n"))
+	case http.MethodPut:
+		data, _ := io.ReadAll(r.Body)
+		if len(data) == 0 {
+			http.Error(w, "failpoint term is empty", http.StatusBadRequest)
+			return
+		}
+		s.failpoints[name] = string(data)
+		w.WriteHeader(http.StatusNoContent)
+	case http.MethodDelete:
+		if _, ok := s.failpoints[name]; !ok {
+			http.Error(w, "failpoint not found", http.StatusNotFound)
+			return
+		}
+		delete(s.failpoints, name)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func TestToggleFailpoints(t *testing.T) {
+	server := httptest.NewServer(&fakeFailpointServer{failpoints: map[string]string{}})
+	defer server.Close()
+	addr := strings.TrimPrefix(server.URL, "http://")
+
+	if err := Enable(addr, TiKVSlowApply, Sleep(100*time.Millisecond)); err != nil {
+		t.Fatalf("enable failpoint: %v", err)
+	}
+	if err := Enable(addr, TiKVSnapshotError, Times(1, Return(""))); err != nil {
+		t.Fatalf("enable failpoint: %v", err)
+	}
+	if err := Enable(addr, "github.com/pingcap/tidb/foo", ""); err == nil {
+		t.Errorf("expected error for the empty term")
+	}
+	failpoints, err := List(addr)
+	if err != nil {
+		t.Fatalf("list failpoints: %v", err)
+	}
+	want := map[string]string{TiKVSlowApply: "sleep(100)", TiKVSnapshotError: "1*return"}
+	if diff := cmp.Diff(want, failpoints); diff != "" {
+		t.Errorf("unexpected failpoints (-want, +got): %s", diff)
+	}
+
+	if err := Disable(addr, TiKVSlowApply); err != nil {
+		t.Fatalf("disable failpoint: %v", err)
+	}
+	if err := Disable(addr, TiKVSlowApply); err == nil {
+		t.Errorf("expected error for the disabled failpoint")
+	}
+	failpoints, err = List(addr)
+	if err != nil {
+		t.Fatalf("list failpoints: %v", err)
+	}
+	if diff := cmp.Diff(map[string]string{TiKVSnapshotError: "1*return"}, failpoints); diff != "" {
+		t.Errorf("unexpected failpoints (-want, +got): %s", diff)
+	}
+}
+
+func TestUseImages(t *testing.T) {
+	tc := &v1alpha1.TidbCluster{
+		Spec: v1alpha1.TidbClusterSpec{
+			TiDB: &v1alpha1.TiDBSpec{BaseImage: "pingcap/tidb"},
+			TiKV: &v1alpha1.TiKVSpec{BaseImage: "pingcap/tikv"},
+		},
+	}
+	UseImages(tc, "localhost:5000/pingcap/tidb-failpoint:v6.1.0", "localhost:5000/pingcap/tikv-failpoint")
+
+	if tc.Spec.TiDB.BaseImage != "localhost:5000/pingcap/tidb-failpoint" || tc.Spec.TiDB.Version == nil || *tc.Spec.TiDB.Version != "v6.1.0" {
+		t.Errorf("unexpected image of TiDB: %s", tc.TiDBImage())
+	}
+	if tc.Spec.TiKV.BaseImage != "localhost:5000/pingcap/tikv-failpoint" || tc.Spec.TiKV.Version != nil {
+		t.Errorf("unexpected image of TiKV: %s", tc.TiKVImage())
+	}
+}
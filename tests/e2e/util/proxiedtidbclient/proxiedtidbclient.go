@@ -14,11 +14,18 @@
 package proxiedtidbclient
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
 	"net/http"
+	neturl "net/url"
 	"time"
 
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/apis/util/config"
 	"github.com/pingcap/tidb-operator/pkg/controller"
+	httputil "github.com/pingcap/tidb-operator/pkg/util/http"
 	"github.com/pingcap/tidb-operator/tests/e2e/util/portforward"
 )
 
@@ -42,6 +49,93 @@ func (p *proxiedTiDBClient) SetServerLabels(tc *v1alpha1.TidbCluster, ordinal in
 	panic("implement when necessary")
 }
 
+func (p *proxiedTiDBClient) ResignDDLOwner(tc *v1alpha1.TidbCluster, ordinal int32) (bool, error) {
+	panic("implement when necessary")
+}
+
+func (p *proxiedTiDBClient) GetDDLOwner(tc *v1alpha1.TidbCluster, ordinal int32) (string, error) {
+	panic("implement when necessary")
+}
+
+// GetQueryCounters returns the query counters of the tidb from its metrics by forwarding the status port of the pod
+func (p *proxiedTiDBClient) GetQueryCounters(tc *v1alpha1.TidbCluster, ordinal int32) (*controller.QueryCounters, error) {
+	baseURL, cancel, err := p.forward(tc, ordinal)
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+
+	res, err := p.httpClient.Get(fmt.Sprintf("%s/metrics", baseURL))
+	if err != nil {
+		return nil, err
+	}
+	defer httputil.DeferClose(res.Body)
+	if res.StatusCode != http.StatusOK {
+		return nil, httputil.ReadErrorBody(res.Body)
+	}
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	return controller.ParseQueryCounters(body)
+}
+
+// GetSettings returns the config in effect of the tidb by forwarding the status port of the pod
+func (p *proxiedTiDBClient) GetSettings(tc *v1alpha1.TidbCluster, ordinal int32) (*config.GenericConfig, error) {
+	baseURL, cancel, err := p.forward(tc, ordinal)
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+
+	res, err := p.httpClient.Get(fmt.Sprintf("%s/settings", baseURL))
+	if err != nil {
+		return nil, err
+	}
+	defer httputil.DeferClose(res.Body)
+	if res.StatusCode != http.StatusOK {
+		return nil, httputil.ReadErrorBody(res.Body)
+	}
+	settings := map[string]interface{}{}
+	if err := json.NewDecoder(res.Body).Decode(&settings); err != nil {
+		return nil, err
+	}
+	return config.New(settings), nil
+}
+
+// SetSettings changes the settings of the tidb online by forwarding the status port of the pod
+func (p *proxiedTiDBClient) SetSettings(tc *v1alpha1.TidbCluster, ordinal int32, settings map[string]string) error {
+	baseURL, cancel, err := p.forward(tc, ordinal)
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	form := neturl.Values{}
+	for k, v := range settings {
+		form.Set(k, v)
+	}
+	res, err := p.httpClient.PostForm(fmt.Sprintf("%s/settings", baseURL), form)
+	if err != nil {
+		return err
+	}
+	defer httputil.DeferClose(res.Body)
+	if res.StatusCode != http.StatusOK {
+		return httputil.ReadErrorBody(res.Body)
+	}
+	return nil
+}
+
+// forward forwards the status port of the tidb pod and returns the base url of the forwarded port
+func (p *proxiedTiDBClient) forward(tc *v1alpha1.TidbCluster, ordinal int32) (string, context.CancelFunc, error) {
+	podName := fmt.Sprintf("%s-%d", controller.TiDBMemberName(tc.GetName()), ordinal)
+	host, port, cancel, err := portforward.ForwardOnePort(p.fw, tc.GetNamespace(), fmt.Sprintf("pod/%s", podName), uint16(tc.Spec.TiDB.GetStatusPort()))
+	if err != nil {
+		return "", nil, fmt.Errorf("forward the status port of pod %s/%s failed: %v", tc.GetNamespace(), podName, err)
+	}
+	return fmt.Sprintf("%s://%s:%d", tc.Scheme(), host, port), cancel, nil
+}
+
 func NewProxiedTiDBClient(fw portforward.PortForward, caCert []byte) controller.TiDBControlInterface {
 	return &proxiedTiDBClient{fw: fw, httpClient: &http.Client{Timeout: 5 * time.Second}, caCert: caCert}
 }
@@ -0,0 +1,148 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package pdproxy implements a proxy which sits between the operator and PD in the stability tests,
+// it forwards the requests to PD and injects the latency and the errors per API route, so that the
+// behavior of the operator under a degraded PD can be validated without real infrastructure failures.
+package pdproxy
+
+import (
+	"fmt"
+	"math/rand"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/kubernetes/test/e2e/framework/log"
+)
+
+// Fault is the fault injected into the requests of a route
+type Fault struct {
+	// Path is the prefix of the URL path of the route, e.g. /pd/api/v1/stores, empty matches all the paths
+	Path string `json:"path,omitempty"`
+	// Method is the HTTP method of the route, empty matches all the methods
+	Method string `json:"method,omitempty"`
+	// Latency is added before the request is forwarded or failed
+	Latency metav1.Duration `json:"latency,omitempty"`
+	// ErrorRate is the ratio of the requests which are failed, in [0, 1]
+	ErrorRate float64 `json:"errorRate,omitempty"`
+	// StatusCode is the status code of the failed requests, defaults to 500
+	StatusCode int `json:"statusCode,omitempty"`
+}
+
+func (f *Fault) match(r *http.Request) bool {
+	if f.Method != "" && !strings.EqualFold(f.Method, r.Method) {
+		return false
+	}
+	return strings.HasPrefix(r.URL.Path, f.Path)
+}
+
+// Stats is the number of the requests handled by the proxy
+type Stats struct {
+	Requests int64 `json:"requests"`
+	Delayed  int64 `json:"delayed"`
+	Failed   int64 `json:"failed"`
+}
+
+// Proxy is a reverse proxy of PD injecting the faults
+type Proxy struct {
+	upstream *url.URL
+	rp       *httputil.ReverseProxy
+
+	mu       sync.Mutex
+	faults   []Fault
+	rand     *rand.Rand
+	stats    Stats
+	scenario *scenarioRunner
+}
+
+// NewProxy returns a proxy forwarding the requests to the PD URL
+func NewProxy(upstream string) (*Proxy, error) {
+	u, err := url.Parse(upstream)
+	if err != nil {
+		return nil, fmt.Errorf("invalid upstream %q: %v", upstream, err)
+	}
+	return &Proxy{
+		upstream: u,
+		rp:       httputil.NewSingleHostReverseProxy(u),
+		rand:     rand.New(rand.NewSource(time.Now().UnixNano())),
+	}, nil
+}
+
+// SetFaults replaces the faults injected by the proxy, the first fault matching a request is applied
+func (p *Proxy) SetFaults(faults []Fault) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.faults = append([]Fault(nil), faults...)
+	log.Logf("pd proxy faults: %+v", p.faults)
+}
+
+// Faults returns the faults injected by the proxy
+func (p *Proxy) Faults() []Fault {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]Fault(nil), p.faults...)
+}
+
+// Stats returns the number of the requests handled by the proxy
+func (p *Proxy) Stats() Stats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.stats
+}
+
+// ServeHTTP injects the fault matching the request and forwards it to PD
+func (p *Proxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var fault *Fault
+	failed := false
+	p.mu.Lock()
+	p.stats.Requests++
+	for i := range p.faults {
+		if p.faults[i].match(r) {
+			f := p.faults[i]
+			fault = &f
+			break
+		}
+	}
+	if fault != nil {
+		if fault.Latency.Duration > 0 {
+			p.stats.Delayed++
+		}
+		if fault.ErrorRate > 0 && p.rand.Float64() < fault.ErrorRate {
+			failed = true
+			p.stats.Failed++
+		}
+	}
+	p.mu.Unlock()
+
+	if fault != nil && fault.Latency.Duration > 0 {
+		select {
+		case <-time.After(fault.Latency.Duration):
+		case <-r.Context().Done():
+			return
+		}
+	}
+	if failed {
+		code := fault.StatusCode
+		if code == 0 {
+			code = http.StatusInternalServerError
+		}
+		http.Error(w, fmt.Sprintf("fault injected by pd proxy for %s %s", r.Method, r.URL.Path), code)
+		return
+	}
+	p.rp.ServeHTTP(w, r)
+}
@@ -0,0 +1,85 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pdproxy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestProxyFaults(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	pd := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer pd.Close()
+	p, err := NewProxy(pd.URL)
+	g.Expect(err).To(Succeed())
+	server := httptest.NewServer(p)
+	defer server.Close()
+
+	get := func(path string) int {
+		resp, err := http.Get(server.URL + path)
+		g.Expect(err).To(Succeed())
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	g.Expect(get("/pd/api/v1/stores")).To(Equal(http.StatusOK))
+
+	p.SetFaults([]Fault{
+		{Path: "/pd/api/v1/stores", ErrorRate: 1, StatusCode: http.StatusServiceUnavailable},
+		{Path: "/pd/api/v1/members", Method: http.MethodGet, Latency: metav1.Duration{Duration: 100 * time.Millisecond}},
+	})
+	g.Expect(get("/pd/api/v1/stores")).To(Equal(http.StatusServiceUnavailable))
+	g.Expect(get("/pd/api/v1/health")).To(Equal(http.StatusOK))
+	start := time.Now()
+	g.Expect(get("/pd/api/v1/members")).To(Equal(http.StatusOK))
+	g.Expect(time.Since(start)).To(BeNumerically(">=", 100*time.Millisecond))
+	g.Expect(p.Stats()).To(Equal(Stats{Requests: 4, Delayed: 1, Failed: 1}))
+}
+
+func TestProxyScenario(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	p, err := NewProxy("http://127.0.0.1:2379")
+	g.Expect(err).To(Succeed())
+	fault := Fault{Path: "/pd/api/v1/stores", ErrorRate: 1}
+	p.RunScenario(Scenario{
+		Name: "unavailable",
+		Steps: []Step{
+			{Duration: metav1.Duration{Duration: 50 * time.Millisecond}},
+			{Duration: metav1.Duration{Duration: time.Hour}, Faults: []Fault{fault}},
+		},
+	})
+	g.Eventually(p.Faults).Should(Equal([]Fault{fault}))
+	g.Expect(p.RunningScenario()).To(Equal("unavailable"))
+
+	p.StopScenario()
+	g.Expect(p.Faults()).To(BeEmpty())
+	g.Expect(p.RunningScenario()).To(BeEmpty())
+
+	p.RunScenario(Scenario{
+		Name:  "short",
+		Steps: []Step{{Duration: metav1.Duration{Duration: 10 * time.Millisecond}, Faults: []Fault{fault}}},
+	})
+	g.Eventually(p.RunningScenario).Should(BeEmpty())
+	g.Expect(p.Faults()).To(BeEmpty())
+}
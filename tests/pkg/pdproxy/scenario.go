@@ -0,0 +1,188 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pdproxy
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/kubernetes/test/e2e/framework/log"
+)
+
+// Step is a step of a scenario, its faults are injected for the duration of the step
+type Step struct {
+	Duration metav1.Duration `json:"duration"`
+	Faults   []Fault         `json:"faults,omitempty"`
+}
+
+// Scenario is a script of the faults injected by the proxy, e.g. PD becomes slow for 1 minute and
+// then returns errors for 30 seconds. The faults are cleared after the last step unless it loops.
+type Scenario struct {
+	Name  string `json:"name"`
+	Steps []Step `json:"steps"`
+	Loop  bool   `json:"loop,omitempty"`
+}
+
+// LoadScenario reads a scenario from a JSON file
+func LoadScenario(path string) (*Scenario, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	s := &Scenario{}
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, fmt.Errorf("invalid scenario %s: %v", path, err)
+	}
+	return s, nil
+}
+
+type scenarioRunner struct {
+	name   string
+	stopCh chan struct{}
+	doneCh chan struct{}
+}
+
+// RunScenario runs the scenario in the background, the running scenario is stopped first
+func (p *Proxy) RunScenario(s Scenario) {
+	p.StopScenario()
+
+	runner := &scenarioRunner{
+		name:   s.Name,
+		stopCh: make(chan struct{}),
+		doneCh: make(chan struct{}),
+	}
+	p.mu.Lock()
+	p.scenario = runner
+	p.mu.Unlock()
+
+	go func() {
+		defer close(runner.doneCh)
+		defer p.SetFaults(nil)
+		for {
+			for i, step := range s.Steps {
+				log.Logf("pd proxy scenario %s: step %d for %s", s.Name, i, step.Duration.Duration)
+				p.SetFaults(step.Faults)
+				select {
+				case <-time.After(step.Duration.Duration):
+				case <-runner.stopCh:
+					log.Logf("pd proxy scenario %s is stopped", s.Name)
+					return
+				}
+			}
+			if !s.Loop || len(s.Steps) == 0 {
+				log.Logf("pd proxy scenario %s is finished", s.Name)
+				return
+			}
+		}
+	}()
+}
+
+// StopScenario stops the running scenario and clears the faults
+func (p *Proxy) StopScenario() {
+	p.mu.Lock()
+	runner := p.scenario
+	p.scenario = nil
+	p.mu.Unlock()
+	if runner != nil {
+		close(runner.stopCh)
+		<-runner.doneCh
+	}
+}
+
+// RunningScenario returns the name of the running scenario, it's empty if no scenario is running
+func (p *Proxy) RunningScenario() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.scenario == nil {
+		return ""
+	}
+	select {
+	case <-p.scenario.doneCh:
+		return ""
+	default:
+		return p.scenario.name
+	}
+}
+
+// AdminHandler returns the handler to control the proxy:
+//
+//	GET/PUT/DELETE /faults    get, replace or clear the faults
+//	POST/DELETE    /scenario  run or stop a scenario
+//	GET            /stats     get the number of the requests handled by the proxy
+func (p *Proxy) AdminHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/faults", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			writeJSON(w, p.Faults())
+		case http.MethodPut:
+			var faults []Fault
+			if err := readJSON(r.Body, &faults); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			p.SetFaults(faults)
+		case http.MethodDelete:
+			p.SetFaults(nil)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	})
+	mux.HandleFunc("/scenario", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			writeJSON(w, map[string]string{"running": p.RunningScenario()})
+		case http.MethodPost:
+			var s Scenario
+			if err := readJSON(r.Body, &s); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			p.RunScenario(s)
+		case http.MethodDelete:
+			p.StopScenario()
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	})
+	mux.HandleFunc("/stats", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, p.Stats())
+	})
+	return mux
+}
+
+func readJSON(r io.Reader, v interface{}) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if _, err := w.Write(data); err != nil {
+		log.Logf("ERROR: %v", err)
+	}
+}